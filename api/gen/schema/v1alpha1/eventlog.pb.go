@@ -0,0 +1,769 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: schema/v1alpha1/eventlog.proto
+
+package schemav1alpha1
+
+import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type EventSeverity int32
+
+const (
+	EventSeverity_EVENT_SEVERITY_UNSPECIFIED EventSeverity = 0
+	EventSeverity_EVENT_SEVERITY_OK          EventSeverity = 1
+	EventSeverity_EVENT_SEVERITY_WARNING     EventSeverity = 2
+	EventSeverity_EVENT_SEVERITY_CRITICAL    EventSeverity = 3
+)
+
+// Enum value maps for EventSeverity.
+var (
+	EventSeverity_name = map[int32]string{
+		0: "EVENT_SEVERITY_UNSPECIFIED",
+		1: "EVENT_SEVERITY_OK",
+		2: "EVENT_SEVERITY_WARNING",
+		3: "EVENT_SEVERITY_CRITICAL",
+	}
+	EventSeverity_value = map[string]int32{
+		"EVENT_SEVERITY_UNSPECIFIED": 0,
+		"EVENT_SEVERITY_OK":          1,
+		"EVENT_SEVERITY_WARNING":     2,
+		"EVENT_SEVERITY_CRITICAL":    3,
+	}
+)
+
+func (x EventSeverity) Enum() *EventSeverity {
+	p := new(EventSeverity)
+	*p = x
+	return p
+}
+
+func (x EventSeverity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EventSeverity) Descriptor() protoreflect.EnumDescriptor {
+	return file_schema_v1alpha1_eventlog_proto_enumTypes[0].Descriptor()
+}
+
+func (EventSeverity) Type() protoreflect.EnumType {
+	return &file_schema_v1alpha1_eventlog_proto_enumTypes[0]
+}
+
+func (x EventSeverity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EventSeverity.Descriptor instead.
+func (EventSeverity) EnumDescriptor() ([]byte, []int) {
+	return file_schema_v1alpha1_eventlog_proto_rawDescGZIP(), []int{0}
+}
+
+type EventSource int32
+
+const (
+	EventSource_EVENT_SOURCE_UNSPECIFIED           EventSource = 0
+	EventSource_EVENT_SOURCE_SENSOR                EventSource = 1
+	EventSource_EVENT_SOURCE_HOST                  EventSource = 2
+	EventSource_EVENT_SOURCE_CHASSIS               EventSource = 3
+	EventSource_EVENT_SOURCE_MANAGEMENT_CONTROLLER EventSource = 4
+)
+
+// Enum value maps for EventSource.
+var (
+	EventSource_name = map[int32]string{
+		0: "EVENT_SOURCE_UNSPECIFIED",
+		1: "EVENT_SOURCE_SENSOR",
+		2: "EVENT_SOURCE_HOST",
+		3: "EVENT_SOURCE_CHASSIS",
+		4: "EVENT_SOURCE_MANAGEMENT_CONTROLLER",
+	}
+	EventSource_value = map[string]int32{
+		"EVENT_SOURCE_UNSPECIFIED":           0,
+		"EVENT_SOURCE_SENSOR":                1,
+		"EVENT_SOURCE_HOST":                  2,
+		"EVENT_SOURCE_CHASSIS":               3,
+		"EVENT_SOURCE_MANAGEMENT_CONTROLLER": 4,
+	}
+)
+
+func (x EventSource) Enum() *EventSource {
+	p := new(EventSource)
+	*p = x
+	return p
+}
+
+func (x EventSource) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EventSource) Descriptor() protoreflect.EnumDescriptor {
+	return file_schema_v1alpha1_eventlog_proto_enumTypes[1].Descriptor()
+}
+
+func (EventSource) Type() protoreflect.EnumType {
+	return &file_schema_v1alpha1_eventlog_proto_enumTypes[1]
+}
+
+func (x EventSource) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EventSource.Descriptor instead.
+func (EventSource) EnumDescriptor() ([]byte, []int) {
+	return file_schema_v1alpha1_eventlog_proto_rawDescGZIP(), []int{1}
+}
+
+type SystemEventLogEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecordId      uint32                 `protobuf:"varint,1,opt,name=record_id,json=recordId,proto3" json:"record_id,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Severity      EventSeverity          `protobuf:"varint,3,opt,name=severity,proto3,enum=schema.v1alpha1.EventSeverity" json:"severity,omitempty"`
+	Source        EventSource            `protobuf:"varint,4,opt,name=source,proto3,enum=schema.v1alpha1.EventSource" json:"source,omitempty"`
+	Origin        string                 `protobuf:"bytes,5,opt,name=origin,proto3" json:"origin,omitempty"`
+	Message       string                 `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+	IpmiRecord    []byte                 `protobuf:"bytes,7,opt,name=ipmi_record,json=ipmiRecord,proto3" json:"ipmi_record,omitempty"`
+	SensorId      *string                `protobuf:"bytes,8,opt,name=sensor_id,json=sensorId,proto3,oneof" json:"sensor_id,omitempty"`
+	Reading       *float64               `protobuf:"fixed64,9,opt,name=reading,proto3,oneof" json:"reading,omitempty"`
+	Threshold     *float64               `protobuf:"fixed64,10,opt,name=threshold,proto3,oneof" json:"threshold,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SystemEventLogEntry) Reset() {
+	*x = SystemEventLogEntry{}
+	mi := &file_schema_v1alpha1_eventlog_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SystemEventLogEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemEventLogEntry) ProtoMessage() {}
+
+func (x *SystemEventLogEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_v1alpha1_eventlog_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemEventLogEntry.ProtoReflect.Descriptor instead.
+func (*SystemEventLogEntry) Descriptor() ([]byte, []int) {
+	return file_schema_v1alpha1_eventlog_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SystemEventLogEntry) GetRecordId() uint32 {
+	if x != nil {
+		return x.RecordId
+	}
+	return 0
+}
+
+func (x *SystemEventLogEntry) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *SystemEventLogEntry) GetSeverity() EventSeverity {
+	if x != nil {
+		return x.Severity
+	}
+	return EventSeverity_EVENT_SEVERITY_UNSPECIFIED
+}
+
+func (x *SystemEventLogEntry) GetSource() EventSource {
+	if x != nil {
+		return x.Source
+	}
+	return EventSource_EVENT_SOURCE_UNSPECIFIED
+}
+
+func (x *SystemEventLogEntry) GetOrigin() string {
+	if x != nil {
+		return x.Origin
+	}
+	return ""
+}
+
+func (x *SystemEventLogEntry) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SystemEventLogEntry) GetIpmiRecord() []byte {
+	if x != nil {
+		return x.IpmiRecord
+	}
+	return nil
+}
+
+func (x *SystemEventLogEntry) GetSensorId() string {
+	if x != nil && x.SensorId != nil {
+		return *x.SensorId
+	}
+	return ""
+}
+
+func (x *SystemEventLogEntry) GetReading() float64 {
+	if x != nil && x.Reading != nil {
+		return *x.Reading
+	}
+	return 0
+}
+
+func (x *SystemEventLogEntry) GetThreshold() float64 {
+	if x != nil && x.Threshold != nil {
+		return *x.Threshold
+	}
+	return 0
+}
+
+type GetSystemEventLogInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSystemEventLogInfoRequest) Reset() {
+	*x = GetSystemEventLogInfoRequest{}
+	mi := &file_schema_v1alpha1_eventlog_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSystemEventLogInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSystemEventLogInfoRequest) ProtoMessage() {}
+
+func (x *GetSystemEventLogInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_v1alpha1_eventlog_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSystemEventLogInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetSystemEventLogInfoRequest) Descriptor() ([]byte, []int) {
+	return file_schema_v1alpha1_eventlog_proto_rawDescGZIP(), []int{1}
+}
+
+type GetSystemEventLogInfoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       uint32                 `protobuf:"varint,1,opt,name=entries,proto3" json:"entries,omitempty"`
+	MaxEntries    uint32                 `protobuf:"varint,2,opt,name=max_entries,json=maxEntries,proto3" json:"max_entries,omitempty"`
+	LastAddition  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_addition,json=lastAddition,proto3,oneof" json:"last_addition,omitempty"`
+	LastErase     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_erase,json=lastErase,proto3,oneof" json:"last_erase,omitempty"`
+	Overflow      bool                   `protobuf:"varint,5,opt,name=overflow,proto3" json:"overflow,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSystemEventLogInfoResponse) Reset() {
+	*x = GetSystemEventLogInfoResponse{}
+	mi := &file_schema_v1alpha1_eventlog_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSystemEventLogInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSystemEventLogInfoResponse) ProtoMessage() {}
+
+func (x *GetSystemEventLogInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_v1alpha1_eventlog_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSystemEventLogInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetSystemEventLogInfoResponse) Descriptor() ([]byte, []int) {
+	return file_schema_v1alpha1_eventlog_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetSystemEventLogInfoResponse) GetEntries() uint32 {
+	if x != nil {
+		return x.Entries
+	}
+	return 0
+}
+
+func (x *GetSystemEventLogInfoResponse) GetMaxEntries() uint32 {
+	if x != nil {
+		return x.MaxEntries
+	}
+	return 0
+}
+
+func (x *GetSystemEventLogInfoResponse) GetLastAddition() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastAddition
+	}
+	return nil
+}
+
+func (x *GetSystemEventLogInfoResponse) GetLastErase() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastErase
+	}
+	return nil
+}
+
+func (x *GetSystemEventLogInfoResponse) GetOverflow() bool {
+	if x != nil {
+		return x.Overflow
+	}
+	return false
+}
+
+type GetSystemEventLogEntryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecordId      uint32                 `protobuf:"varint,1,opt,name=record_id,json=recordId,proto3" json:"record_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSystemEventLogEntryRequest) Reset() {
+	*x = GetSystemEventLogEntryRequest{}
+	mi := &file_schema_v1alpha1_eventlog_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSystemEventLogEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSystemEventLogEntryRequest) ProtoMessage() {}
+
+func (x *GetSystemEventLogEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_v1alpha1_eventlog_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSystemEventLogEntryRequest.ProtoReflect.Descriptor instead.
+func (*GetSystemEventLogEntryRequest) Descriptor() ([]byte, []int) {
+	return file_schema_v1alpha1_eventlog_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetSystemEventLogEntryRequest) GetRecordId() uint32 {
+	if x != nil {
+		return x.RecordId
+	}
+	return 0
+}
+
+type GetSystemEventLogEntryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entry         *SystemEventLogEntry   `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	NextRecordId  uint32                 `protobuf:"varint,2,opt,name=next_record_id,json=nextRecordId,proto3" json:"next_record_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSystemEventLogEntryResponse) Reset() {
+	*x = GetSystemEventLogEntryResponse{}
+	mi := &file_schema_v1alpha1_eventlog_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSystemEventLogEntryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSystemEventLogEntryResponse) ProtoMessage() {}
+
+func (x *GetSystemEventLogEntryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_v1alpha1_eventlog_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSystemEventLogEntryResponse.ProtoReflect.Descriptor instead.
+func (*GetSystemEventLogEntryResponse) Descriptor() ([]byte, []int) {
+	return file_schema_v1alpha1_eventlog_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetSystemEventLogEntryResponse) GetEntry() *SystemEventLogEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+func (x *GetSystemEventLogEntryResponse) GetNextRecordId() uint32 {
+	if x != nil {
+		return x.NextRecordId
+	}
+	return 0
+}
+
+type ListSystemEventLogEntriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MaxEntries    *uint32                `protobuf:"varint,1,opt,name=max_entries,json=maxEntries,proto3,oneof" json:"max_entries,omitempty"`
+	MinSeverity   *EventSeverity         `protobuf:"varint,2,opt,name=min_severity,json=minSeverity,proto3,enum=schema.v1alpha1.EventSeverity,oneof" json:"min_severity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSystemEventLogEntriesRequest) Reset() {
+	*x = ListSystemEventLogEntriesRequest{}
+	mi := &file_schema_v1alpha1_eventlog_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSystemEventLogEntriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSystemEventLogEntriesRequest) ProtoMessage() {}
+
+func (x *ListSystemEventLogEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_v1alpha1_eventlog_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSystemEventLogEntriesRequest.ProtoReflect.Descriptor instead.
+func (*ListSystemEventLogEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_schema_v1alpha1_eventlog_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListSystemEventLogEntriesRequest) GetMaxEntries() uint32 {
+	if x != nil && x.MaxEntries != nil {
+		return *x.MaxEntries
+	}
+	return 0
+}
+
+func (x *ListSystemEventLogEntriesRequest) GetMinSeverity() EventSeverity {
+	if x != nil && x.MinSeverity != nil {
+		return *x.MinSeverity
+	}
+	return EventSeverity_EVENT_SEVERITY_UNSPECIFIED
+}
+
+type ListSystemEventLogEntriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*SystemEventLogEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSystemEventLogEntriesResponse) Reset() {
+	*x = ListSystemEventLogEntriesResponse{}
+	mi := &file_schema_v1alpha1_eventlog_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSystemEventLogEntriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSystemEventLogEntriesResponse) ProtoMessage() {}
+
+func (x *ListSystemEventLogEntriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_v1alpha1_eventlog_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSystemEventLogEntriesResponse.ProtoReflect.Descriptor instead.
+func (*ListSystemEventLogEntriesResponse) Descriptor() ([]byte, []int) {
+	return file_schema_v1alpha1_eventlog_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListSystemEventLogEntriesResponse) GetEntries() []*SystemEventLogEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type ClearSystemEventLogRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClearSystemEventLogRequest) Reset() {
+	*x = ClearSystemEventLogRequest{}
+	mi := &file_schema_v1alpha1_eventlog_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearSystemEventLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearSystemEventLogRequest) ProtoMessage() {}
+
+func (x *ClearSystemEventLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_v1alpha1_eventlog_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearSystemEventLogRequest.ProtoReflect.Descriptor instead.
+func (*ClearSystemEventLogRequest) Descriptor() ([]byte, []int) {
+	return file_schema_v1alpha1_eventlog_proto_rawDescGZIP(), []int{7}
+}
+
+type ClearSystemEventLogResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ClearedEntries uint32                 `protobuf:"varint,1,opt,name=cleared_entries,json=clearedEntries,proto3" json:"cleared_entries,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ClearSystemEventLogResponse) Reset() {
+	*x = ClearSystemEventLogResponse{}
+	mi := &file_schema_v1alpha1_eventlog_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearSystemEventLogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearSystemEventLogResponse) ProtoMessage() {}
+
+func (x *ClearSystemEventLogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_v1alpha1_eventlog_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearSystemEventLogResponse.ProtoReflect.Descriptor instead.
+func (*ClearSystemEventLogResponse) Descriptor() ([]byte, []int) {
+	return file_schema_v1alpha1_eventlog_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ClearSystemEventLogResponse) GetClearedEntries() uint32 {
+	if x != nil {
+		return x.ClearedEntries
+	}
+	return 0
+}
+
+var File_schema_v1alpha1_eventlog_proto protoreflect.FileDescriptor
+
+const file_schema_v1alpha1_eventlog_proto_rawDesc = "" +
+	"\n" +
+	"\x1eschema/v1alpha1/eventlog.proto\x12\x0fschema.v1alpha1\x1a\x1bbuf/validate/validate.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xee\x03\n" +
+	"\x13SystemEventLogEntry\x12&\n" +
+	"\trecord_id\x18\x01 \x01(\rB\t\xbaH\x06*\x04\x18\xff\xff\x03R\brecordId\x128\n" +
+	"\ttimestamp\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12D\n" +
+	"\bseverity\x18\x03 \x01(\x0e2\x1e.schema.v1alpha1.EventSeverityB\b\xbaH\x05\x82\x01\x02\x10\x01R\bseverity\x12>\n" +
+	"\x06source\x18\x04 \x01(\x0e2\x1c.schema.v1alpha1.EventSourceB\b\xbaH\x05\x82\x01\x02\x10\x01R\x06source\x12\x1f\n" +
+	"\x06origin\x18\x05 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x06origin\x12\x18\n" +
+	"\amessage\x18\x06 \x01(\tR\amessage\x12(\n" +
+	"\vipmi_record\x18\a \x01(\fB\a\xbaH\x04z\x02h\x10R\n" +
+	"ipmiRecord\x12 \n" +
+	"\tsensor_id\x18\b \x01(\tH\x00R\bsensorId\x88\x01\x01\x12\x1d\n" +
+	"\areading\x18\t \x01(\x01H\x01R\areading\x88\x01\x01\x12!\n" +
+	"\tthreshold\x18\n" +
+	" \x01(\x01H\x02R\tthreshold\x88\x01\x01B\f\n" +
+	"\n" +
+	"_sensor_idB\n" +
+	"\n" +
+	"\b_readingB\f\n" +
+	"\n" +
+	"_threshold\"\x1e\n" +
+	"\x1cGetSystemEventLogInfoRequest\"\x9d\x02\n" +
+	"\x1dGetSystemEventLogInfoResponse\x12\x18\n" +
+	"\aentries\x18\x01 \x01(\rR\aentries\x12\x1f\n" +
+	"\vmax_entries\x18\x02 \x01(\rR\n" +
+	"maxEntries\x12D\n" +
+	"\rlast_addition\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\flastAddition\x88\x01\x01\x12>\n" +
+	"\n" +
+	"last_erase\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampH\x01R\tlastErase\x88\x01\x01\x12\x1a\n" +
+	"\boverflow\x18\x05 \x01(\bR\boverflowB\x10\n" +
+	"\x0e_last_additionB\r\n" +
+	"\v_last_erase\"G\n" +
+	"\x1dGetSystemEventLogEntryRequest\x12&\n" +
+	"\trecord_id\x18\x01 \x01(\rB\t\xbaH\x06*\x04\x18\xff\xff\x03R\brecordId\"\x8d\x01\n" +
+	"\x1eGetSystemEventLogEntryResponse\x12:\n" +
+	"\x05entry\x18\x01 \x01(\v2$.schema.v1alpha1.SystemEventLogEntryR\x05entry\x12/\n" +
+	"\x0enext_record_id\x18\x02 \x01(\rB\t\xbaH\x06*\x04\x18\xff\xff\x03R\fnextRecordId\"\xc4\x01\n" +
+	" ListSystemEventLogEntriesRequest\x12-\n" +
+	"\vmax_entries\x18\x01 \x01(\rB\a\xbaH\x04*\x02 \x00H\x00R\n" +
+	"maxEntries\x88\x01\x01\x12P\n" +
+	"\fmin_severity\x18\x02 \x01(\x0e2\x1e.schema.v1alpha1.EventSeverityB\b\xbaH\x05\x82\x01\x02\x10\x01H\x01R\vminSeverity\x88\x01\x01B\x0e\n" +
+	"\f_max_entriesB\x0f\n" +
+	"\r_min_severity\"c\n" +
+	"!ListSystemEventLogEntriesResponse\x12>\n" +
+	"\aentries\x18\x01 \x03(\v2$.schema.v1alpha1.SystemEventLogEntryR\aentries\"\x1c\n" +
+	"\x1aClearSystemEventLogRequest\"F\n" +
+	"\x1bClearSystemEventLogResponse\x12'\n" +
+	"\x0fcleared_entries\x18\x01 \x01(\rR\x0eclearedEntries*\x7f\n" +
+	"\rEventSeverity\x12\x1e\n" +
+	"\x1aEVENT_SEVERITY_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11EVENT_SEVERITY_OK\x10\x01\x12\x1a\n" +
+	"\x16EVENT_SEVERITY_WARNING\x10\x02\x12\x1b\n" +
+	"\x17EVENT_SEVERITY_CRITICAL\x10\x03*\x9d\x01\n" +
+	"\vEventSource\x12\x1c\n" +
+	"\x18EVENT_SOURCE_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13EVENT_SOURCE_SENSOR\x10\x01\x12\x15\n" +
+	"\x11EVENT_SOURCE_HOST\x10\x02\x12\x18\n" +
+	"\x14EVENT_SOURCE_CHASSIS\x10\x03\x12&\n" +
+	"\"EVENT_SOURCE_MANAGEMENT_CONTROLLER\x10\x04B\xc0\x01\n" +
+	"\x13com.schema.v1alpha1B\rEventlogProtoP\x01Z=github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1;schemav1alpha1\xa2\x02\x03SXX\xaa\x02\x0fSchema.V1alpha1\xca\x02\x0fSchema\\V1alpha1\xe2\x02\x1bSchema\\V1alpha1\\GPBMetadata\xea\x02\x10Schema::V1alpha1b\x06proto3"
+
+var (
+	file_schema_v1alpha1_eventlog_proto_rawDescOnce sync.Once
+	file_schema_v1alpha1_eventlog_proto_rawDescData []byte
+)
+
+func file_schema_v1alpha1_eventlog_proto_rawDescGZIP() []byte {
+	file_schema_v1alpha1_eventlog_proto_rawDescOnce.Do(func() {
+		file_schema_v1alpha1_eventlog_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_schema_v1alpha1_eventlog_proto_rawDesc), len(file_schema_v1alpha1_eventlog_proto_rawDesc)))
+	})
+	return file_schema_v1alpha1_eventlog_proto_rawDescData
+}
+
+var file_schema_v1alpha1_eventlog_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_schema_v1alpha1_eventlog_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_schema_v1alpha1_eventlog_proto_goTypes = []any{
+	(EventSeverity)(0),                        // 0: schema.v1alpha1.EventSeverity
+	(EventSource)(0),                          // 1: schema.v1alpha1.EventSource
+	(*SystemEventLogEntry)(nil),               // 2: schema.v1alpha1.SystemEventLogEntry
+	(*GetSystemEventLogInfoRequest)(nil),      // 3: schema.v1alpha1.GetSystemEventLogInfoRequest
+	(*GetSystemEventLogInfoResponse)(nil),     // 4: schema.v1alpha1.GetSystemEventLogInfoResponse
+	(*GetSystemEventLogEntryRequest)(nil),     // 5: schema.v1alpha1.GetSystemEventLogEntryRequest
+	(*GetSystemEventLogEntryResponse)(nil),    // 6: schema.v1alpha1.GetSystemEventLogEntryResponse
+	(*ListSystemEventLogEntriesRequest)(nil),  // 7: schema.v1alpha1.ListSystemEventLogEntriesRequest
+	(*ListSystemEventLogEntriesResponse)(nil), // 8: schema.v1alpha1.ListSystemEventLogEntriesResponse
+	(*ClearSystemEventLogRequest)(nil),        // 9: schema.v1alpha1.ClearSystemEventLogRequest
+	(*ClearSystemEventLogResponse)(nil),       // 10: schema.v1alpha1.ClearSystemEventLogResponse
+	(*timestamppb.Timestamp)(nil),             // 11: google.protobuf.Timestamp
+}
+var file_schema_v1alpha1_eventlog_proto_depIdxs = []int32{
+	11, // 0: schema.v1alpha1.SystemEventLogEntry.timestamp:type_name -> google.protobuf.Timestamp
+	0,  // 1: schema.v1alpha1.SystemEventLogEntry.severity:type_name -> schema.v1alpha1.EventSeverity
+	1,  // 2: schema.v1alpha1.SystemEventLogEntry.source:type_name -> schema.v1alpha1.EventSource
+	11, // 3: schema.v1alpha1.GetSystemEventLogInfoResponse.last_addition:type_name -> google.protobuf.Timestamp
+	11, // 4: schema.v1alpha1.GetSystemEventLogInfoResponse.last_erase:type_name -> google.protobuf.Timestamp
+	2,  // 5: schema.v1alpha1.GetSystemEventLogEntryResponse.entry:type_name -> schema.v1alpha1.SystemEventLogEntry
+	0,  // 6: schema.v1alpha1.ListSystemEventLogEntriesRequest.min_severity:type_name -> schema.v1alpha1.EventSeverity
+	2,  // 7: schema.v1alpha1.ListSystemEventLogEntriesResponse.entries:type_name -> schema.v1alpha1.SystemEventLogEntry
+	8,  // [8:8] is the sub-list for method output_type
+	8,  // [8:8] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_schema_v1alpha1_eventlog_proto_init() }
+func file_schema_v1alpha1_eventlog_proto_init() {
+	if File_schema_v1alpha1_eventlog_proto != nil {
+		return
+	}
+	file_schema_v1alpha1_eventlog_proto_msgTypes[0].OneofWrappers = []any{}
+	file_schema_v1alpha1_eventlog_proto_msgTypes[2].OneofWrappers = []any{}
+	file_schema_v1alpha1_eventlog_proto_msgTypes[5].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_schema_v1alpha1_eventlog_proto_rawDesc), len(file_schema_v1alpha1_eventlog_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_schema_v1alpha1_eventlog_proto_goTypes,
+		DependencyIndexes: file_schema_v1alpha1_eventlog_proto_depIdxs,
+		EnumInfos:         file_schema_v1alpha1_eventlog_proto_enumTypes,
+		MessageInfos:      file_schema_v1alpha1_eventlog_proto_msgTypes,
+	}.Build()
+	File_schema_v1alpha1_eventlog_proto = out.File
+	file_schema_v1alpha1_eventlog_proto_goTypes = nil
+	file_schema_v1alpha1_eventlog_proto_depIdxs = nil
+}
@@ -0,0 +1,1167 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: schema/v1alpha1/eventlog.proto
+
+package schemav1alpha1
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = anypb.Any{}
+	_ = sort.Sort
+)
+
+// Validate checks the field values on SystemEventLogEntry with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *SystemEventLogEntry) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SystemEventLogEntry with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SystemEventLogEntryMultiError, or nil if none found.
+func (m *SystemEventLogEntry) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SystemEventLogEntry) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for RecordId
+
+	if all {
+		switch v := interface{}(m.GetTimestamp()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, SystemEventLogEntryValidationError{
+					field:  "Timestamp",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, SystemEventLogEntryValidationError{
+					field:  "Timestamp",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTimestamp()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return SystemEventLogEntryValidationError{
+				field:  "Timestamp",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Severity
+
+	// no validation rules for Source
+
+	// no validation rules for Origin
+
+	// no validation rules for Message
+
+	// no validation rules for IpmiRecord
+
+	if m.SensorId != nil {
+		// no validation rules for SensorId
+	}
+
+	if m.Reading != nil {
+		// no validation rules for Reading
+	}
+
+	if m.Threshold != nil {
+		// no validation rules for Threshold
+	}
+
+	if len(errors) > 0 {
+		return SystemEventLogEntryMultiError(errors)
+	}
+
+	return nil
+}
+
+// SystemEventLogEntryMultiError is an error wrapping multiple validation
+// errors returned by SystemEventLogEntry.ValidateAll() if the designated
+// constraints aren't met.
+type SystemEventLogEntryMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SystemEventLogEntryMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SystemEventLogEntryMultiError) AllErrors() []error { return m }
+
+// SystemEventLogEntryValidationError is the validation error returned by
+// SystemEventLogEntry.Validate if the designated constraints aren't met.
+type SystemEventLogEntryValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SystemEventLogEntryValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SystemEventLogEntryValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SystemEventLogEntryValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SystemEventLogEntryValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SystemEventLogEntryValidationError) ErrorName() string {
+	return "SystemEventLogEntryValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SystemEventLogEntryValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSystemEventLogEntry.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SystemEventLogEntryValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SystemEventLogEntryValidationError{}
+
+// Validate checks the field values on GetSystemEventLogInfoRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetSystemEventLogInfoRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetSystemEventLogInfoRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetSystemEventLogInfoRequestMultiError, or nil if none found.
+func (m *GetSystemEventLogInfoRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetSystemEventLogInfoRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return GetSystemEventLogInfoRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetSystemEventLogInfoRequestMultiError is an error wrapping multiple
+// validation errors returned by GetSystemEventLogInfoRequest.ValidateAll() if
+// the designated constraints aren't met.
+type GetSystemEventLogInfoRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetSystemEventLogInfoRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetSystemEventLogInfoRequestMultiError) AllErrors() []error { return m }
+
+// GetSystemEventLogInfoRequestValidationError is the validation error returned
+// by GetSystemEventLogInfoRequest.Validate if the designated constraints
+// aren't met.
+type GetSystemEventLogInfoRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetSystemEventLogInfoRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetSystemEventLogInfoRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetSystemEventLogInfoRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetSystemEventLogInfoRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetSystemEventLogInfoRequestValidationError) ErrorName() string {
+	return "GetSystemEventLogInfoRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetSystemEventLogInfoRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetSystemEventLogInfoRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetSystemEventLogInfoRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetSystemEventLogInfoRequestValidationError{}
+
+// Validate checks the field values on GetSystemEventLogInfoResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetSystemEventLogInfoResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetSystemEventLogInfoResponse with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// GetSystemEventLogInfoResponseMultiError, or nil if none found.
+func (m *GetSystemEventLogInfoResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetSystemEventLogInfoResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Entries
+
+	// no validation rules for MaxEntries
+
+	// no validation rules for Overflow
+
+	if m.LastAddition != nil {
+
+		if all {
+			switch v := interface{}(m.GetLastAddition()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, GetSystemEventLogInfoResponseValidationError{
+						field:  "LastAddition",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, GetSystemEventLogInfoResponseValidationError{
+						field:  "LastAddition",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetLastAddition()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return GetSystemEventLogInfoResponseValidationError{
+					field:  "LastAddition",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if m.LastErase != nil {
+
+		if all {
+			switch v := interface{}(m.GetLastErase()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, GetSystemEventLogInfoResponseValidationError{
+						field:  "LastErase",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, GetSystemEventLogInfoResponseValidationError{
+						field:  "LastErase",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetLastErase()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return GetSystemEventLogInfoResponseValidationError{
+					field:  "LastErase",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return GetSystemEventLogInfoResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetSystemEventLogInfoResponseMultiError is an error wrapping multiple
+// validation errors returned by GetSystemEventLogInfoResponse.ValidateAll()
+// if the designated constraints aren't met.
+type GetSystemEventLogInfoResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetSystemEventLogInfoResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetSystemEventLogInfoResponseMultiError) AllErrors() []error { return m }
+
+// GetSystemEventLogInfoResponseValidationError is the validation error
+// returned by GetSystemEventLogInfoResponse.Validate if the designated
+// constraints aren't met.
+type GetSystemEventLogInfoResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetSystemEventLogInfoResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetSystemEventLogInfoResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetSystemEventLogInfoResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetSystemEventLogInfoResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetSystemEventLogInfoResponseValidationError) ErrorName() string {
+	return "GetSystemEventLogInfoResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetSystemEventLogInfoResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetSystemEventLogInfoResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetSystemEventLogInfoResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetSystemEventLogInfoResponseValidationError{}
+
+// Validate checks the field values on GetSystemEventLogEntryRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetSystemEventLogEntryRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetSystemEventLogEntryRequest with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// GetSystemEventLogEntryRequestMultiError, or nil if none found.
+func (m *GetSystemEventLogEntryRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetSystemEventLogEntryRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for RecordId
+
+	if len(errors) > 0 {
+		return GetSystemEventLogEntryRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetSystemEventLogEntryRequestMultiError is an error wrapping multiple
+// validation errors returned by GetSystemEventLogEntryRequest.ValidateAll()
+// if the designated constraints aren't met.
+type GetSystemEventLogEntryRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetSystemEventLogEntryRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetSystemEventLogEntryRequestMultiError) AllErrors() []error { return m }
+
+// GetSystemEventLogEntryRequestValidationError is the validation error
+// returned by GetSystemEventLogEntryRequest.Validate if the designated
+// constraints aren't met.
+type GetSystemEventLogEntryRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetSystemEventLogEntryRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetSystemEventLogEntryRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetSystemEventLogEntryRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetSystemEventLogEntryRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetSystemEventLogEntryRequestValidationError) ErrorName() string {
+	return "GetSystemEventLogEntryRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetSystemEventLogEntryRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetSystemEventLogEntryRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetSystemEventLogEntryRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetSystemEventLogEntryRequestValidationError{}
+
+// Validate checks the field values on GetSystemEventLogEntryResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetSystemEventLogEntryResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetSystemEventLogEntryResponse with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// GetSystemEventLogEntryResponseMultiError, or nil if none found.
+func (m *GetSystemEventLogEntryResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetSystemEventLogEntryResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetEntry()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetSystemEventLogEntryResponseValidationError{
+					field:  "Entry",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetSystemEventLogEntryResponseValidationError{
+					field:  "Entry",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetEntry()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetSystemEventLogEntryResponseValidationError{
+				field:  "Entry",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for NextRecordId
+
+	if len(errors) > 0 {
+		return GetSystemEventLogEntryResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetSystemEventLogEntryResponseMultiError is an error wrapping multiple
+// validation errors returned by GetSystemEventLogEntryResponse.ValidateAll()
+// if the designated constraints aren't met.
+type GetSystemEventLogEntryResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetSystemEventLogEntryResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetSystemEventLogEntryResponseMultiError) AllErrors() []error { return m }
+
+// GetSystemEventLogEntryResponseValidationError is the validation error
+// returned by GetSystemEventLogEntryResponse.Validate if the designated
+// constraints aren't met.
+type GetSystemEventLogEntryResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetSystemEventLogEntryResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetSystemEventLogEntryResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetSystemEventLogEntryResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetSystemEventLogEntryResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetSystemEventLogEntryResponseValidationError) ErrorName() string {
+	return "GetSystemEventLogEntryResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetSystemEventLogEntryResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetSystemEventLogEntryResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetSystemEventLogEntryResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetSystemEventLogEntryResponseValidationError{}
+
+// Validate checks the field values on ListSystemEventLogEntriesRequest with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the first error encountered is returned, or nil if there are
+// no violations.
+func (m *ListSystemEventLogEntriesRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListSystemEventLogEntriesRequest with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// ListSystemEventLogEntriesRequestMultiError, or nil if none found.
+func (m *ListSystemEventLogEntriesRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListSystemEventLogEntriesRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.MaxEntries != nil {
+		// no validation rules for MaxEntries
+	}
+
+	if m.MinSeverity != nil {
+		// no validation rules for MinSeverity
+	}
+
+	if len(errors) > 0 {
+		return ListSystemEventLogEntriesRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListSystemEventLogEntriesRequestMultiError is an error wrapping multiple
+// validation errors returned by
+// ListSystemEventLogEntriesRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ListSystemEventLogEntriesRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListSystemEventLogEntriesRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListSystemEventLogEntriesRequestMultiError) AllErrors() []error { return m }
+
+// ListSystemEventLogEntriesRequestValidationError is the validation error
+// returned by ListSystemEventLogEntriesRequest.Validate if the designated
+// constraints aren't met.
+type ListSystemEventLogEntriesRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListSystemEventLogEntriesRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListSystemEventLogEntriesRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListSystemEventLogEntriesRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListSystemEventLogEntriesRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListSystemEventLogEntriesRequestValidationError) ErrorName() string {
+	return "ListSystemEventLogEntriesRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListSystemEventLogEntriesRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListSystemEventLogEntriesRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListSystemEventLogEntriesRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListSystemEventLogEntriesRequestValidationError{}
+
+// Validate checks the field values on ListSystemEventLogEntriesResponse with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the first error encountered is returned, or nil if there are
+// no violations.
+func (m *ListSystemEventLogEntriesResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListSystemEventLogEntriesResponse
+// with the rules defined in the proto definition for this message. If any
+// rules are violated, the result is a list of violation errors wrapped in
+// ListSystemEventLogEntriesResponseMultiError, or nil if none found.
+func (m *ListSystemEventLogEntriesResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListSystemEventLogEntriesResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetEntries() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListSystemEventLogEntriesResponseValidationError{
+						field:  fmt.Sprintf("Entries[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListSystemEventLogEntriesResponseValidationError{
+						field:  fmt.Sprintf("Entries[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListSystemEventLogEntriesResponseValidationError{
+					field:  fmt.Sprintf("Entries[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListSystemEventLogEntriesResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListSystemEventLogEntriesResponseMultiError is an error wrapping multiple
+// validation errors returned by
+// ListSystemEventLogEntriesResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ListSystemEventLogEntriesResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListSystemEventLogEntriesResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListSystemEventLogEntriesResponseMultiError) AllErrors() []error { return m }
+
+// ListSystemEventLogEntriesResponseValidationError is the validation error
+// returned by ListSystemEventLogEntriesResponse.Validate if the designated
+// constraints aren't met.
+type ListSystemEventLogEntriesResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListSystemEventLogEntriesResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListSystemEventLogEntriesResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListSystemEventLogEntriesResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListSystemEventLogEntriesResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListSystemEventLogEntriesResponseValidationError) ErrorName() string {
+	return "ListSystemEventLogEntriesResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListSystemEventLogEntriesResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListSystemEventLogEntriesResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListSystemEventLogEntriesResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListSystemEventLogEntriesResponseValidationError{}
+
+// Validate checks the field values on ClearSystemEventLogRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ClearSystemEventLogRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ClearSystemEventLogRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ClearSystemEventLogRequestMultiError, or nil if none found.
+func (m *ClearSystemEventLogRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ClearSystemEventLogRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return ClearSystemEventLogRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ClearSystemEventLogRequestMultiError is an error wrapping multiple
+// validation errors returned by ClearSystemEventLogRequest.ValidateAll() if
+// the designated constraints aren't met.
+type ClearSystemEventLogRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ClearSystemEventLogRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ClearSystemEventLogRequestMultiError) AllErrors() []error { return m }
+
+// ClearSystemEventLogRequestValidationError is the validation error returned
+// by ClearSystemEventLogRequest.Validate if the designated constraints aren't met.
+type ClearSystemEventLogRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ClearSystemEventLogRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ClearSystemEventLogRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ClearSystemEventLogRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ClearSystemEventLogRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ClearSystemEventLogRequestValidationError) ErrorName() string {
+	return "ClearSystemEventLogRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ClearSystemEventLogRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sClearSystemEventLogRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ClearSystemEventLogRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ClearSystemEventLogRequestValidationError{}
+
+// Validate checks the field values on ClearSystemEventLogResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ClearSystemEventLogResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ClearSystemEventLogResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ClearSystemEventLogResponseMultiError, or nil if none found.
+func (m *ClearSystemEventLogResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ClearSystemEventLogResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for ClearedEntries
+
+	if len(errors) > 0 {
+		return ClearSystemEventLogResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ClearSystemEventLogResponseMultiError is an error wrapping multiple
+// validation errors returned by ClearSystemEventLogResponse.ValidateAll() if
+// the designated constraints aren't met.
+type ClearSystemEventLogResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ClearSystemEventLogResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ClearSystemEventLogResponseMultiError) AllErrors() []error { return m }
+
+// ClearSystemEventLogResponseValidationError is the validation error returned
+// by ClearSystemEventLogResponse.Validate if the designated constraints
+// aren't met.
+type ClearSystemEventLogResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ClearSystemEventLogResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ClearSystemEventLogResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ClearSystemEventLogResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ClearSystemEventLogResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ClearSystemEventLogResponseValidationError) ErrorName() string {
+	return "ClearSystemEventLogResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ClearSystemEventLogResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sClearSystemEventLogResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ClearSystemEventLogResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ClearSystemEventLogResponseValidationError{}
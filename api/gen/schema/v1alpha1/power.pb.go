@@ -0,0 +1,734 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: schema/v1alpha1/power.proto
+
+package schemav1alpha1
+
+import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PowerLimitExceptionAction int32
+
+const (
+	PowerLimitExceptionAction_POWER_LIMIT_EXCEPTION_ACTION_UNSPECIFIED    PowerLimitExceptionAction = 0
+	PowerLimitExceptionAction_POWER_LIMIT_EXCEPTION_ACTION_NONE           PowerLimitExceptionAction = 1
+	PowerLimitExceptionAction_POWER_LIMIT_EXCEPTION_ACTION_HARD_POWER_OFF PowerLimitExceptionAction = 2
+	PowerLimitExceptionAction_POWER_LIMIT_EXCEPTION_ACTION_LOG_EVENT      PowerLimitExceptionAction = 3
+)
+
+// Enum value maps for PowerLimitExceptionAction.
+var (
+	PowerLimitExceptionAction_name = map[int32]string{
+		0: "POWER_LIMIT_EXCEPTION_ACTION_UNSPECIFIED",
+		1: "POWER_LIMIT_EXCEPTION_ACTION_NONE",
+		2: "POWER_LIMIT_EXCEPTION_ACTION_HARD_POWER_OFF",
+		3: "POWER_LIMIT_EXCEPTION_ACTION_LOG_EVENT",
+	}
+	PowerLimitExceptionAction_value = map[string]int32{
+		"POWER_LIMIT_EXCEPTION_ACTION_UNSPECIFIED":    0,
+		"POWER_LIMIT_EXCEPTION_ACTION_NONE":           1,
+		"POWER_LIMIT_EXCEPTION_ACTION_HARD_POWER_OFF": 2,
+		"POWER_LIMIT_EXCEPTION_ACTION_LOG_EVENT":      3,
+	}
+)
+
+func (x PowerLimitExceptionAction) Enum() *PowerLimitExceptionAction {
+	p := new(PowerLimitExceptionAction)
+	*p = x
+	return p
+}
+
+func (x PowerLimitExceptionAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PowerLimitExceptionAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_schema_v1alpha1_power_proto_enumTypes[0].Descriptor()
+}
+
+func (PowerLimitExceptionAction) Type() protoreflect.EnumType {
+	return &file_schema_v1alpha1_power_proto_enumTypes[0]
+}
+
+func (x PowerLimitExceptionAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PowerLimitExceptionAction.Descriptor instead.
+func (PowerLimitExceptionAction) EnumDescriptor() ([]byte, []int) {
+	return file_schema_v1alpha1_power_proto_rawDescGZIP(), []int{0}
+}
+
+type PowerLimit struct {
+	state           protoimpl.MessageState    `protogen:"open.v1"`
+	LimitWatts      uint32                    `protobuf:"varint,1,opt,name=limit_watts,json=limitWatts,proto3" json:"limit_watts,omitempty"`
+	ExceptionAction PowerLimitExceptionAction `protobuf:"varint,2,opt,name=exception_action,json=exceptionAction,proto3,enum=schema.v1alpha1.PowerLimitExceptionAction" json:"exception_action,omitempty"`
+	CorrectionTime  *durationpb.Duration      `protobuf:"bytes,3,opt,name=correction_time,json=correctionTime,proto3" json:"correction_time,omitempty"`
+	SamplingPeriod  *durationpb.Duration      `protobuf:"bytes,4,opt,name=sampling_period,json=samplingPeriod,proto3" json:"sampling_period,omitempty"`
+	Active          bool                      `protobuf:"varint,5,opt,name=active,proto3" json:"active,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PowerLimit) Reset() {
+	*x = PowerLimit{}
+	mi := &file_schema_v1alpha1_power_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PowerLimit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PowerLimit) ProtoMessage() {}
+
+func (x *PowerLimit) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_v1alpha1_power_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PowerLimit.ProtoReflect.Descriptor instead.
+func (*PowerLimit) Descriptor() ([]byte, []int) {
+	return file_schema_v1alpha1_power_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PowerLimit) GetLimitWatts() uint32 {
+	if x != nil {
+		return x.LimitWatts
+	}
+	return 0
+}
+
+func (x *PowerLimit) GetExceptionAction() PowerLimitExceptionAction {
+	if x != nil {
+		return x.ExceptionAction
+	}
+	return PowerLimitExceptionAction_POWER_LIMIT_EXCEPTION_ACTION_UNSPECIFIED
+}
+
+func (x *PowerLimit) GetCorrectionTime() *durationpb.Duration {
+	if x != nil {
+		return x.CorrectionTime
+	}
+	return nil
+}
+
+func (x *PowerLimit) GetSamplingPeriod() *durationpb.Duration {
+	if x != nil {
+		return x.SamplingPeriod
+	}
+	return nil
+}
+
+func (x *PowerLimit) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+type PowerReading struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	CurrentWatts     float64                `protobuf:"fixed64,1,opt,name=current_watts,json=currentWatts,proto3" json:"current_watts,omitempty"`
+	MinimumWatts     float64                `protobuf:"fixed64,2,opt,name=minimum_watts,json=minimumWatts,proto3" json:"minimum_watts,omitempty"`
+	MaximumWatts     float64                `protobuf:"fixed64,3,opt,name=maximum_watts,json=maximumWatts,proto3" json:"maximum_watts,omitempty"`
+	AverageWatts     float64                `protobuf:"fixed64,4,opt,name=average_watts,json=averageWatts,proto3" json:"average_watts,omitempty"`
+	Timestamp        *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	StatisticsPeriod *durationpb.Duration   `protobuf:"bytes,6,opt,name=statistics_period,json=statisticsPeriod,proto3" json:"statistics_period,omitempty"`
+	SensorIds        []string               `protobuf:"bytes,7,rep,name=sensor_ids,json=sensorIds,proto3" json:"sensor_ids,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *PowerReading) Reset() {
+	*x = PowerReading{}
+	mi := &file_schema_v1alpha1_power_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PowerReading) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PowerReading) ProtoMessage() {}
+
+func (x *PowerReading) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_v1alpha1_power_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PowerReading.ProtoReflect.Descriptor instead.
+func (*PowerReading) Descriptor() ([]byte, []int) {
+	return file_schema_v1alpha1_power_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PowerReading) GetCurrentWatts() float64 {
+	if x != nil {
+		return x.CurrentWatts
+	}
+	return 0
+}
+
+func (x *PowerReading) GetMinimumWatts() float64 {
+	if x != nil {
+		return x.MinimumWatts
+	}
+	return 0
+}
+
+func (x *PowerReading) GetMaximumWatts() float64 {
+	if x != nil {
+		return x.MaximumWatts
+	}
+	return 0
+}
+
+func (x *PowerReading) GetAverageWatts() float64 {
+	if x != nil {
+		return x.AverageWatts
+	}
+	return 0
+}
+
+func (x *PowerReading) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *PowerReading) GetStatisticsPeriod() *durationpb.Duration {
+	if x != nil {
+		return x.StatisticsPeriod
+	}
+	return nil
+}
+
+func (x *PowerReading) GetSensorIds() []string {
+	if x != nil {
+		return x.SensorIds
+	}
+	return nil
+}
+
+type GetPowerReadingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPowerReadingRequest) Reset() {
+	*x = GetPowerReadingRequest{}
+	mi := &file_schema_v1alpha1_power_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPowerReadingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPowerReadingRequest) ProtoMessage() {}
+
+func (x *GetPowerReadingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_v1alpha1_power_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPowerReadingRequest.ProtoReflect.Descriptor instead.
+func (*GetPowerReadingRequest) Descriptor() ([]byte, []int) {
+	return file_schema_v1alpha1_power_proto_rawDescGZIP(), []int{2}
+}
+
+type GetPowerReadingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reading       *PowerReading          `protobuf:"bytes,1,opt,name=reading,proto3" json:"reading,omitempty"`
+	LimitActive   bool                   `protobuf:"varint,2,opt,name=limit_active,json=limitActive,proto3" json:"limit_active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPowerReadingResponse) Reset() {
+	*x = GetPowerReadingResponse{}
+	mi := &file_schema_v1alpha1_power_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPowerReadingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPowerReadingResponse) ProtoMessage() {}
+
+func (x *GetPowerReadingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_v1alpha1_power_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPowerReadingResponse.ProtoReflect.Descriptor instead.
+func (*GetPowerReadingResponse) Descriptor() ([]byte, []int) {
+	return file_schema_v1alpha1_power_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetPowerReadingResponse) GetReading() *PowerReading {
+	if x != nil {
+		return x.Reading
+	}
+	return nil
+}
+
+func (x *GetPowerReadingResponse) GetLimitActive() bool {
+	if x != nil {
+		return x.LimitActive
+	}
+	return false
+}
+
+type GetPowerLimitRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPowerLimitRequest) Reset() {
+	*x = GetPowerLimitRequest{}
+	mi := &file_schema_v1alpha1_power_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPowerLimitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPowerLimitRequest) ProtoMessage() {}
+
+func (x *GetPowerLimitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_v1alpha1_power_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPowerLimitRequest.ProtoReflect.Descriptor instead.
+func (*GetPowerLimitRequest) Descriptor() ([]byte, []int) {
+	return file_schema_v1alpha1_power_proto_rawDescGZIP(), []int{4}
+}
+
+type GetPowerLimitResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         *PowerLimit            `protobuf:"bytes,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPowerLimitResponse) Reset() {
+	*x = GetPowerLimitResponse{}
+	mi := &file_schema_v1alpha1_power_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPowerLimitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPowerLimitResponse) ProtoMessage() {}
+
+func (x *GetPowerLimitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_v1alpha1_power_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPowerLimitResponse.ProtoReflect.Descriptor instead.
+func (*GetPowerLimitResponse) Descriptor() ([]byte, []int) {
+	return file_schema_v1alpha1_power_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetPowerLimitResponse) GetLimit() *PowerLimit {
+	if x != nil {
+		return x.Limit
+	}
+	return nil
+}
+
+type SetPowerLimitRequest struct {
+	state           protoimpl.MessageState     `protogen:"open.v1"`
+	LimitWatts      *uint32                    `protobuf:"varint,1,opt,name=limit_watts,json=limitWatts,proto3,oneof" json:"limit_watts,omitempty"`
+	ExceptionAction *PowerLimitExceptionAction `protobuf:"varint,2,opt,name=exception_action,json=exceptionAction,proto3,enum=schema.v1alpha1.PowerLimitExceptionAction,oneof" json:"exception_action,omitempty"`
+	CorrectionTime  *durationpb.Duration       `protobuf:"bytes,3,opt,name=correction_time,json=correctionTime,proto3,oneof" json:"correction_time,omitempty"`
+	SamplingPeriod  *durationpb.Duration       `protobuf:"bytes,4,opt,name=sampling_period,json=samplingPeriod,proto3,oneof" json:"sampling_period,omitempty"`
+	Active          *bool                      `protobuf:"varint,5,opt,name=active,proto3,oneof" json:"active,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SetPowerLimitRequest) Reset() {
+	*x = SetPowerLimitRequest{}
+	mi := &file_schema_v1alpha1_power_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPowerLimitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPowerLimitRequest) ProtoMessage() {}
+
+func (x *SetPowerLimitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_v1alpha1_power_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPowerLimitRequest.ProtoReflect.Descriptor instead.
+func (*SetPowerLimitRequest) Descriptor() ([]byte, []int) {
+	return file_schema_v1alpha1_power_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SetPowerLimitRequest) GetLimitWatts() uint32 {
+	if x != nil && x.LimitWatts != nil {
+		return *x.LimitWatts
+	}
+	return 0
+}
+
+func (x *SetPowerLimitRequest) GetExceptionAction() PowerLimitExceptionAction {
+	if x != nil && x.ExceptionAction != nil {
+		return *x.ExceptionAction
+	}
+	return PowerLimitExceptionAction_POWER_LIMIT_EXCEPTION_ACTION_UNSPECIFIED
+}
+
+func (x *SetPowerLimitRequest) GetCorrectionTime() *durationpb.Duration {
+	if x != nil {
+		return x.CorrectionTime
+	}
+	return nil
+}
+
+func (x *SetPowerLimitRequest) GetSamplingPeriod() *durationpb.Duration {
+	if x != nil {
+		return x.SamplingPeriod
+	}
+	return nil
+}
+
+func (x *SetPowerLimitRequest) GetActive() bool {
+	if x != nil && x.Active != nil {
+		return *x.Active
+	}
+	return false
+}
+
+type SetPowerLimitResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         *PowerLimit            `protobuf:"bytes,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPowerLimitResponse) Reset() {
+	*x = SetPowerLimitResponse{}
+	mi := &file_schema_v1alpha1_power_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPowerLimitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPowerLimitResponse) ProtoMessage() {}
+
+func (x *SetPowerLimitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_v1alpha1_power_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPowerLimitResponse.ProtoReflect.Descriptor instead.
+func (*SetPowerLimitResponse) Descriptor() ([]byte, []int) {
+	return file_schema_v1alpha1_power_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SetPowerLimitResponse) GetLimit() *PowerLimit {
+	if x != nil {
+		return x.Limit
+	}
+	return nil
+}
+
+type PowerLimitEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         *PowerLimit            `protobuf:"bytes,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	ReadingWatts  float64                `protobuf:"fixed64,2,opt,name=reading_watts,json=readingWatts,proto3" json:"reading_watts,omitempty"`
+	Exceeded      bool                   `protobuf:"varint,3,opt,name=exceeded,proto3" json:"exceeded,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PowerLimitEvent) Reset() {
+	*x = PowerLimitEvent{}
+	mi := &file_schema_v1alpha1_power_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PowerLimitEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PowerLimitEvent) ProtoMessage() {}
+
+func (x *PowerLimitEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_v1alpha1_power_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PowerLimitEvent.ProtoReflect.Descriptor instead.
+func (*PowerLimitEvent) Descriptor() ([]byte, []int) {
+	return file_schema_v1alpha1_power_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PowerLimitEvent) GetLimit() *PowerLimit {
+	if x != nil {
+		return x.Limit
+	}
+	return nil
+}
+
+func (x *PowerLimitEvent) GetReadingWatts() float64 {
+	if x != nil {
+		return x.ReadingWatts
+	}
+	return 0
+}
+
+func (x *PowerLimitEvent) GetExceeded() bool {
+	if x != nil {
+		return x.Exceeded
+	}
+	return false
+}
+
+func (x *PowerLimitEvent) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+var File_schema_v1alpha1_power_proto protoreflect.FileDescriptor
+
+const file_schema_v1alpha1_power_proto_rawDesc = "" +
+	"\n" +
+	"\x1bschema/v1alpha1/power.proto\x12\x0fschema.v1alpha1\x1a\x1bbuf/validate/validate.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xae\x02\n" +
+	"\n" +
+	"PowerLimit\x12\x1f\n" +
+	"\vlimit_watts\x18\x01 \x01(\rR\n" +
+	"limitWatts\x12_\n" +
+	"\x10exception_action\x18\x02 \x01(\x0e2*.schema.v1alpha1.PowerLimitExceptionActionB\b\xbaH\x05\x82\x01\x02\x10\x01R\x0fexceptionAction\x12B\n" +
+	"\x0fcorrection_time\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x0ecorrectionTime\x12B\n" +
+	"\x0fsampling_period\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\x0esamplingPeriod\x12\x16\n" +
+	"\x06active\x18\x05 \x01(\bR\x06active\"\xc3\x02\n" +
+	"\fPowerReading\x12#\n" +
+	"\rcurrent_watts\x18\x01 \x01(\x01R\fcurrentWatts\x12#\n" +
+	"\rminimum_watts\x18\x02 \x01(\x01R\fminimumWatts\x12#\n" +
+	"\rmaximum_watts\x18\x03 \x01(\x01R\fmaximumWatts\x12#\n" +
+	"\raverage_watts\x18\x04 \x01(\x01R\faverageWatts\x128\n" +
+	"\ttimestamp\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12F\n" +
+	"\x11statistics_period\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\x10statisticsPeriod\x12\x1d\n" +
+	"\n" +
+	"sensor_ids\x18\a \x03(\tR\tsensorIds\"\x18\n" +
+	"\x16GetPowerReadingRequest\"u\n" +
+	"\x17GetPowerReadingResponse\x127\n" +
+	"\areading\x18\x01 \x01(\v2\x1d.schema.v1alpha1.PowerReadingR\areading\x12!\n" +
+	"\flimit_active\x18\x02 \x01(\bR\vlimitActive\"\x16\n" +
+	"\x14GetPowerLimitRequest\"J\n" +
+	"\x15GetPowerLimitResponse\x121\n" +
+	"\x05limit\x18\x01 \x01(\v2\x1b.schema.v1alpha1.PowerLimitR\x05limit\"\xb2\x03\n" +
+	"\x14SetPowerLimitRequest\x12-\n" +
+	"\vlimit_watts\x18\x01 \x01(\rB\a\xbaH\x04*\x02 \x00H\x00R\n" +
+	"limitWatts\x88\x01\x01\x12d\n" +
+	"\x10exception_action\x18\x02 \x01(\x0e2*.schema.v1alpha1.PowerLimitExceptionActionB\b\xbaH\x05\x82\x01\x02\x10\x01H\x01R\x0fexceptionAction\x88\x01\x01\x12G\n" +
+	"\x0fcorrection_time\x18\x03 \x01(\v2\x19.google.protobuf.DurationH\x02R\x0ecorrectionTime\x88\x01\x01\x12G\n" +
+	"\x0fsampling_period\x18\x04 \x01(\v2\x19.google.protobuf.DurationH\x03R\x0esamplingPeriod\x88\x01\x01\x12\x1b\n" +
+	"\x06active\x18\x05 \x01(\bH\x04R\x06active\x88\x01\x01B\x0e\n" +
+	"\f_limit_wattsB\x13\n" +
+	"\x11_exception_actionB\x12\n" +
+	"\x10_correction_timeB\x12\n" +
+	"\x10_sampling_periodB\t\n" +
+	"\a_active\"J\n" +
+	"\x15SetPowerLimitResponse\x121\n" +
+	"\x05limit\x18\x01 \x01(\v2\x1b.schema.v1alpha1.PowerLimitR\x05limit\"\xbf\x01\n" +
+	"\x0fPowerLimitEvent\x121\n" +
+	"\x05limit\x18\x01 \x01(\v2\x1b.schema.v1alpha1.PowerLimitR\x05limit\x12#\n" +
+	"\rreading_watts\x18\x02 \x01(\x01R\freadingWatts\x12\x1a\n" +
+	"\bexceeded\x18\x03 \x01(\bR\bexceeded\x128\n" +
+	"\ttimestamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp*\xcd\x01\n" +
+	"\x19PowerLimitExceptionAction\x12,\n" +
+	"(POWER_LIMIT_EXCEPTION_ACTION_UNSPECIFIED\x10\x00\x12%\n" +
+	"!POWER_LIMIT_EXCEPTION_ACTION_NONE\x10\x01\x12/\n" +
+	"+POWER_LIMIT_EXCEPTION_ACTION_HARD_POWER_OFF\x10\x02\x12*\n" +
+	"&POWER_LIMIT_EXCEPTION_ACTION_LOG_EVENT\x10\x03B\xbd\x01\n" +
+	"\x13com.schema.v1alpha1B\n" +
+	"PowerProtoP\x01Z=github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1;schemav1alpha1\xa2\x02\x03SXX\xaa\x02\x0fSchema.V1alpha1\xca\x02\x0fSchema\\V1alpha1\xe2\x02\x1bSchema\\V1alpha1\\GPBMetadata\xea\x02\x10Schema::V1alpha1b\x06proto3"
+
+var (
+	file_schema_v1alpha1_power_proto_rawDescOnce sync.Once
+	file_schema_v1alpha1_power_proto_rawDescData []byte
+)
+
+func file_schema_v1alpha1_power_proto_rawDescGZIP() []byte {
+	file_schema_v1alpha1_power_proto_rawDescOnce.Do(func() {
+		file_schema_v1alpha1_power_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_schema_v1alpha1_power_proto_rawDesc), len(file_schema_v1alpha1_power_proto_rawDesc)))
+	})
+	return file_schema_v1alpha1_power_proto_rawDescData
+}
+
+var file_schema_v1alpha1_power_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_schema_v1alpha1_power_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_schema_v1alpha1_power_proto_goTypes = []any{
+	(PowerLimitExceptionAction)(0),  // 0: schema.v1alpha1.PowerLimitExceptionAction
+	(*PowerLimit)(nil),              // 1: schema.v1alpha1.PowerLimit
+	(*PowerReading)(nil),            // 2: schema.v1alpha1.PowerReading
+	(*GetPowerReadingRequest)(nil),  // 3: schema.v1alpha1.GetPowerReadingRequest
+	(*GetPowerReadingResponse)(nil), // 4: schema.v1alpha1.GetPowerReadingResponse
+	(*GetPowerLimitRequest)(nil),    // 5: schema.v1alpha1.GetPowerLimitRequest
+	(*GetPowerLimitResponse)(nil),   // 6: schema.v1alpha1.GetPowerLimitResponse
+	(*SetPowerLimitRequest)(nil),    // 7: schema.v1alpha1.SetPowerLimitRequest
+	(*SetPowerLimitResponse)(nil),   // 8: schema.v1alpha1.SetPowerLimitResponse
+	(*PowerLimitEvent)(nil),         // 9: schema.v1alpha1.PowerLimitEvent
+	(*durationpb.Duration)(nil),     // 10: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),   // 11: google.protobuf.Timestamp
+}
+var file_schema_v1alpha1_power_proto_depIdxs = []int32{
+	0,  // 0: schema.v1alpha1.PowerLimit.exception_action:type_name -> schema.v1alpha1.PowerLimitExceptionAction
+	10, // 1: schema.v1alpha1.PowerLimit.correction_time:type_name -> google.protobuf.Duration
+	10, // 2: schema.v1alpha1.PowerLimit.sampling_period:type_name -> google.protobuf.Duration
+	11, // 3: schema.v1alpha1.PowerReading.timestamp:type_name -> google.protobuf.Timestamp
+	10, // 4: schema.v1alpha1.PowerReading.statistics_period:type_name -> google.protobuf.Duration
+	2,  // 5: schema.v1alpha1.GetPowerReadingResponse.reading:type_name -> schema.v1alpha1.PowerReading
+	1,  // 6: schema.v1alpha1.GetPowerLimitResponse.limit:type_name -> schema.v1alpha1.PowerLimit
+	0,  // 7: schema.v1alpha1.SetPowerLimitRequest.exception_action:type_name -> schema.v1alpha1.PowerLimitExceptionAction
+	10, // 8: schema.v1alpha1.SetPowerLimitRequest.correction_time:type_name -> google.protobuf.Duration
+	10, // 9: schema.v1alpha1.SetPowerLimitRequest.sampling_period:type_name -> google.protobuf.Duration
+	1,  // 10: schema.v1alpha1.SetPowerLimitResponse.limit:type_name -> schema.v1alpha1.PowerLimit
+	1,  // 11: schema.v1alpha1.PowerLimitEvent.limit:type_name -> schema.v1alpha1.PowerLimit
+	11, // 12: schema.v1alpha1.PowerLimitEvent.timestamp:type_name -> google.protobuf.Timestamp
+	13, // [13:13] is the sub-list for method output_type
+	13, // [13:13] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_schema_v1alpha1_power_proto_init() }
+func file_schema_v1alpha1_power_proto_init() {
+	if File_schema_v1alpha1_power_proto != nil {
+		return
+	}
+	file_schema_v1alpha1_power_proto_msgTypes[6].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_schema_v1alpha1_power_proto_rawDesc), len(file_schema_v1alpha1_power_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_schema_v1alpha1_power_proto_goTypes,
+		DependencyIndexes: file_schema_v1alpha1_power_proto_depIdxs,
+		EnumInfos:         file_schema_v1alpha1_power_proto_enumTypes,
+		MessageInfos:      file_schema_v1alpha1_power_proto_msgTypes,
+	}.Build()
+	File_schema_v1alpha1_power_proto = out.File
+	file_schema_v1alpha1_power_proto_goTypes = nil
+	file_schema_v1alpha1_power_proto_depIdxs = nil
+}
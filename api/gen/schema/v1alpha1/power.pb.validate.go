@@ -0,0 +1,1305 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: schema/v1alpha1/power.proto
+
+package schemav1alpha1
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = anypb.Any{}
+	_ = sort.Sort
+)
+
+// Validate checks the field values on PowerLimit with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *PowerLimit) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PowerLimit with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in PowerLimitMultiError, or
+// nil if none found.
+func (m *PowerLimit) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PowerLimit) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for LimitWatts
+
+	// no validation rules for ExceptionAction
+
+	if all {
+		switch v := interface{}(m.GetCorrectionTime()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, PowerLimitValidationError{
+					field:  "CorrectionTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, PowerLimitValidationError{
+					field:  "CorrectionTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCorrectionTime()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return PowerLimitValidationError{
+				field:  "CorrectionTime",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetSamplingPeriod()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, PowerLimitValidationError{
+					field:  "SamplingPeriod",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, PowerLimitValidationError{
+					field:  "SamplingPeriod",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetSamplingPeriod()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return PowerLimitValidationError{
+				field:  "SamplingPeriod",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Active
+
+	if len(errors) > 0 {
+		return PowerLimitMultiError(errors)
+	}
+
+	return nil
+}
+
+// PowerLimitMultiError is an error wrapping multiple validation errors
+// returned by PowerLimit.ValidateAll() if the designated constraints aren't met.
+type PowerLimitMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PowerLimitMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PowerLimitMultiError) AllErrors() []error { return m }
+
+// PowerLimitValidationError is the validation error returned by
+// PowerLimit.Validate if the designated constraints aren't met.
+type PowerLimitValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PowerLimitValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PowerLimitValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PowerLimitValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PowerLimitValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PowerLimitValidationError) ErrorName() string { return "PowerLimitValidationError" }
+
+// Error satisfies the builtin error interface
+func (e PowerLimitValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPowerLimit.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PowerLimitValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PowerLimitValidationError{}
+
+// Validate checks the field values on PowerReading with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *PowerReading) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PowerReading with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in PowerReadingMultiError, or
+// nil if none found.
+func (m *PowerReading) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PowerReading) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for CurrentWatts
+
+	// no validation rules for MinimumWatts
+
+	// no validation rules for MaximumWatts
+
+	// no validation rules for AverageWatts
+
+	if all {
+		switch v := interface{}(m.GetTimestamp()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, PowerReadingValidationError{
+					field:  "Timestamp",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, PowerReadingValidationError{
+					field:  "Timestamp",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTimestamp()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return PowerReadingValidationError{
+				field:  "Timestamp",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetStatisticsPeriod()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, PowerReadingValidationError{
+					field:  "StatisticsPeriod",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, PowerReadingValidationError{
+					field:  "StatisticsPeriod",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetStatisticsPeriod()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return PowerReadingValidationError{
+				field:  "StatisticsPeriod",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return PowerReadingMultiError(errors)
+	}
+
+	return nil
+}
+
+// PowerReadingMultiError is an error wrapping multiple validation errors
+// returned by PowerReading.ValidateAll() if the designated constraints aren't met.
+type PowerReadingMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PowerReadingMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PowerReadingMultiError) AllErrors() []error { return m }
+
+// PowerReadingValidationError is the validation error returned by
+// PowerReading.Validate if the designated constraints aren't met.
+type PowerReadingValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PowerReadingValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PowerReadingValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PowerReadingValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PowerReadingValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PowerReadingValidationError) ErrorName() string { return "PowerReadingValidationError" }
+
+// Error satisfies the builtin error interface
+func (e PowerReadingValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPowerReading.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PowerReadingValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PowerReadingValidationError{}
+
+// Validate checks the field values on GetPowerReadingRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetPowerReadingRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetPowerReadingRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetPowerReadingRequestMultiError, or nil if none found.
+func (m *GetPowerReadingRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetPowerReadingRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return GetPowerReadingRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetPowerReadingRequestMultiError is an error wrapping multiple validation
+// errors returned by GetPowerReadingRequest.ValidateAll() if the designated
+// constraints aren't met.
+type GetPowerReadingRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetPowerReadingRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetPowerReadingRequestMultiError) AllErrors() []error { return m }
+
+// GetPowerReadingRequestValidationError is the validation error returned by
+// GetPowerReadingRequest.Validate if the designated constraints aren't met.
+type GetPowerReadingRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetPowerReadingRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetPowerReadingRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetPowerReadingRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetPowerReadingRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetPowerReadingRequestValidationError) ErrorName() string {
+	return "GetPowerReadingRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetPowerReadingRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetPowerReadingRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetPowerReadingRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetPowerReadingRequestValidationError{}
+
+// Validate checks the field values on GetPowerReadingResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetPowerReadingResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetPowerReadingResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetPowerReadingResponseMultiError, or nil if none found.
+func (m *GetPowerReadingResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetPowerReadingResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetReading()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetPowerReadingResponseValidationError{
+					field:  "Reading",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetPowerReadingResponseValidationError{
+					field:  "Reading",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetReading()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetPowerReadingResponseValidationError{
+				field:  "Reading",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for LimitActive
+
+	if len(errors) > 0 {
+		return GetPowerReadingResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetPowerReadingResponseMultiError is an error wrapping multiple validation
+// errors returned by GetPowerReadingResponse.ValidateAll() if the designated
+// constraints aren't met.
+type GetPowerReadingResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetPowerReadingResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetPowerReadingResponseMultiError) AllErrors() []error { return m }
+
+// GetPowerReadingResponseValidationError is the validation error returned by
+// GetPowerReadingResponse.Validate if the designated constraints aren't met.
+type GetPowerReadingResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetPowerReadingResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetPowerReadingResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetPowerReadingResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetPowerReadingResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetPowerReadingResponseValidationError) ErrorName() string {
+	return "GetPowerReadingResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetPowerReadingResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetPowerReadingResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetPowerReadingResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetPowerReadingResponseValidationError{}
+
+// Validate checks the field values on GetPowerLimitRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetPowerLimitRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetPowerLimitRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetPowerLimitRequestMultiError, or nil if none found.
+func (m *GetPowerLimitRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetPowerLimitRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return GetPowerLimitRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetPowerLimitRequestMultiError is an error wrapping multiple validation
+// errors returned by GetPowerLimitRequest.ValidateAll() if the designated
+// constraints aren't met.
+type GetPowerLimitRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetPowerLimitRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetPowerLimitRequestMultiError) AllErrors() []error { return m }
+
+// GetPowerLimitRequestValidationError is the validation error returned by
+// GetPowerLimitRequest.Validate if the designated constraints aren't met.
+type GetPowerLimitRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetPowerLimitRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetPowerLimitRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetPowerLimitRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetPowerLimitRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetPowerLimitRequestValidationError) ErrorName() string {
+	return "GetPowerLimitRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetPowerLimitRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetPowerLimitRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetPowerLimitRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetPowerLimitRequestValidationError{}
+
+// Validate checks the field values on GetPowerLimitResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetPowerLimitResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetPowerLimitResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetPowerLimitResponseMultiError, or nil if none found.
+func (m *GetPowerLimitResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetPowerLimitResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetLimit()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetPowerLimitResponseValidationError{
+					field:  "Limit",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetPowerLimitResponseValidationError{
+					field:  "Limit",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetLimit()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetPowerLimitResponseValidationError{
+				field:  "Limit",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return GetPowerLimitResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetPowerLimitResponseMultiError is an error wrapping multiple validation
+// errors returned by GetPowerLimitResponse.ValidateAll() if the designated
+// constraints aren't met.
+type GetPowerLimitResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetPowerLimitResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetPowerLimitResponseMultiError) AllErrors() []error { return m }
+
+// GetPowerLimitResponseValidationError is the validation error returned by
+// GetPowerLimitResponse.Validate if the designated constraints aren't met.
+type GetPowerLimitResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetPowerLimitResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetPowerLimitResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetPowerLimitResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetPowerLimitResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetPowerLimitResponseValidationError) ErrorName() string {
+	return "GetPowerLimitResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetPowerLimitResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetPowerLimitResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetPowerLimitResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetPowerLimitResponseValidationError{}
+
+// Validate checks the field values on SetPowerLimitRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *SetPowerLimitRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SetPowerLimitRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SetPowerLimitRequestMultiError, or nil if none found.
+func (m *SetPowerLimitRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SetPowerLimitRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.LimitWatts != nil {
+		// no validation rules for LimitWatts
+	}
+
+	if m.ExceptionAction != nil {
+		// no validation rules for ExceptionAction
+	}
+
+	if m.CorrectionTime != nil {
+
+		if all {
+			switch v := interface{}(m.GetCorrectionTime()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, SetPowerLimitRequestValidationError{
+						field:  "CorrectionTime",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, SetPowerLimitRequestValidationError{
+						field:  "CorrectionTime",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetCorrectionTime()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return SetPowerLimitRequestValidationError{
+					field:  "CorrectionTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if m.SamplingPeriod != nil {
+
+		if all {
+			switch v := interface{}(m.GetSamplingPeriod()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, SetPowerLimitRequestValidationError{
+						field:  "SamplingPeriod",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, SetPowerLimitRequestValidationError{
+						field:  "SamplingPeriod",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetSamplingPeriod()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return SetPowerLimitRequestValidationError{
+					field:  "SamplingPeriod",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if m.Active != nil {
+		// no validation rules for Active
+	}
+
+	if len(errors) > 0 {
+		return SetPowerLimitRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// SetPowerLimitRequestMultiError is an error wrapping multiple validation
+// errors returned by SetPowerLimitRequest.ValidateAll() if the designated
+// constraints aren't met.
+type SetPowerLimitRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SetPowerLimitRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SetPowerLimitRequestMultiError) AllErrors() []error { return m }
+
+// SetPowerLimitRequestValidationError is the validation error returned by
+// SetPowerLimitRequest.Validate if the designated constraints aren't met.
+type SetPowerLimitRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SetPowerLimitRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SetPowerLimitRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SetPowerLimitRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SetPowerLimitRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SetPowerLimitRequestValidationError) ErrorName() string {
+	return "SetPowerLimitRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SetPowerLimitRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSetPowerLimitRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SetPowerLimitRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SetPowerLimitRequestValidationError{}
+
+// Validate checks the field values on SetPowerLimitResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *SetPowerLimitResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SetPowerLimitResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SetPowerLimitResponseMultiError, or nil if none found.
+func (m *SetPowerLimitResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SetPowerLimitResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetLimit()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, SetPowerLimitResponseValidationError{
+					field:  "Limit",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, SetPowerLimitResponseValidationError{
+					field:  "Limit",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetLimit()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return SetPowerLimitResponseValidationError{
+				field:  "Limit",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return SetPowerLimitResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// SetPowerLimitResponseMultiError is an error wrapping multiple validation
+// errors returned by SetPowerLimitResponse.ValidateAll() if the designated
+// constraints aren't met.
+type SetPowerLimitResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SetPowerLimitResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SetPowerLimitResponseMultiError) AllErrors() []error { return m }
+
+// SetPowerLimitResponseValidationError is the validation error returned by
+// SetPowerLimitResponse.Validate if the designated constraints aren't met.
+type SetPowerLimitResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SetPowerLimitResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SetPowerLimitResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SetPowerLimitResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SetPowerLimitResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SetPowerLimitResponseValidationError) ErrorName() string {
+	return "SetPowerLimitResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SetPowerLimitResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSetPowerLimitResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SetPowerLimitResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SetPowerLimitResponseValidationError{}
+
+// Validate checks the field values on PowerLimitEvent with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *PowerLimitEvent) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PowerLimitEvent with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// PowerLimitEventMultiError, or nil if none found.
+func (m *PowerLimitEvent) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PowerLimitEvent) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetLimit()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, PowerLimitEventValidationError{
+					field:  "Limit",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, PowerLimitEventValidationError{
+					field:  "Limit",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetLimit()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return PowerLimitEventValidationError{
+				field:  "Limit",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for ReadingWatts
+
+	// no validation rules for Exceeded
+
+	if all {
+		switch v := interface{}(m.GetTimestamp()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, PowerLimitEventValidationError{
+					field:  "Timestamp",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, PowerLimitEventValidationError{
+					field:  "Timestamp",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTimestamp()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return PowerLimitEventValidationError{
+				field:  "Timestamp",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return PowerLimitEventMultiError(errors)
+	}
+
+	return nil
+}
+
+// PowerLimitEventMultiError is an error wrapping multiple validation errors
+// returned by PowerLimitEvent.ValidateAll() if the designated constraints
+// aren't met.
+type PowerLimitEventMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PowerLimitEventMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PowerLimitEventMultiError) AllErrors() []error { return m }
+
+// PowerLimitEventValidationError is the validation error returned by
+// PowerLimitEvent.Validate if the designated constraints aren't met.
+type PowerLimitEventValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PowerLimitEventValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PowerLimitEventValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PowerLimitEventValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PowerLimitEventValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PowerLimitEventValidationError) ErrorName() string { return "PowerLimitEventValidationError" }
+
+// Error satisfies the builtin error interface
+func (e PowerLimitEventValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPowerLimitEvent.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PowerLimitEventValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PowerLimitEventValidationError{}
@@ -79,6 +79,12 @@ const (
 	BMCServiceListSensorsProcedure = "/schema.v1alpha1.BMCService/ListSensors"
 	// BMCServiceGetSensorProcedure is the fully-qualified name of the BMCService's GetSensor RPC.
 	BMCServiceGetSensorProcedure = "/schema.v1alpha1.BMCService/GetSensor"
+	// BMCServiceListSystemEventLogEntriesProcedure is the fully-qualified name of the BMCService's
+	// ListSystemEventLogEntries RPC.
+	BMCServiceListSystemEventLogEntriesProcedure = "/schema.v1alpha1.BMCService/ListSystemEventLogEntries"
+	// BMCServiceClearSystemEventLogProcedure is the fully-qualified name of the BMCService's
+	// ClearSystemEventLog RPC.
+	BMCServiceClearSystemEventLogProcedure = "/schema.v1alpha1.BMCService/ClearSystemEventLog"
 	// BMCServiceGetThermalZoneProcedure is the fully-qualified name of the BMCService's GetThermalZone
 	// RPC.
 	BMCServiceGetThermalZoneProcedure = "/schema.v1alpha1.BMCService/GetThermalZone"
@@ -107,6 +113,15 @@ const (
 	// BMCServiceAuthenticateUserProcedure is the fully-qualified name of the BMCService's
 	// AuthenticateUser RPC.
 	BMCServiceAuthenticateUserProcedure = "/schema.v1alpha1.BMCService/AuthenticateUser"
+	// BMCServiceEnrollTotpProcedure is the fully-qualified name of the BMCService's EnrollTotp RPC.
+	BMCServiceEnrollTotpProcedure = "/schema.v1alpha1.BMCService/EnrollTotp"
+	// BMCServiceConfirmTotpProcedure is the fully-qualified name of the BMCService's ConfirmTotp RPC.
+	BMCServiceConfirmTotpProcedure = "/schema.v1alpha1.BMCService/ConfirmTotp"
+	// BMCServiceDisableTotpProcedure is the fully-qualified name of the BMCService's DisableTotp RPC.
+	BMCServiceDisableTotpProcedure = "/schema.v1alpha1.BMCService/DisableTotp"
+	// BMCServiceRegenerateRecoveryCodesProcedure is the fully-qualified name of the BMCService's
+	// RegenerateRecoveryCodes RPC.
+	BMCServiceRegenerateRecoveryCodesProcedure = "/schema.v1alpha1.BMCService/RegenerateRecoveryCodes"
 )
 
 // BMCServiceClient is a client for the schema.v1alpha1.BMCService service.
@@ -129,6 +144,8 @@ type BMCServiceClient interface {
 	ChangeManagementControllerState(context.Context, *connect.Request[v1alpha1.ChangeManagementControllerStateRequest]) (*connect.Response[v1alpha1.ChangeManagementControllerStateResponse], error)
 	ListSensors(context.Context, *connect.Request[v1alpha1.ListSensorsRequest]) (*connect.Response[v1alpha1.ListSensorsResponse], error)
 	GetSensor(context.Context, *connect.Request[v1alpha1.GetSensorRequest]) (*connect.Response[v1alpha1.GetSensorResponse], error)
+	ListSystemEventLogEntries(context.Context, *connect.Request[v1alpha1.ListSystemEventLogEntriesRequest]) (*connect.Response[v1alpha1.ListSystemEventLogEntriesResponse], error)
+	ClearSystemEventLog(context.Context, *connect.Request[v1alpha1.ClearSystemEventLogRequest]) (*connect.Response[v1alpha1.ClearSystemEventLogResponse], error)
 	GetThermalZone(context.Context, *connect.Request[v1alpha1.GetThermalZoneRequest]) (*connect.Response[v1alpha1.GetThermalZoneResponse], error)
 	SetThermalZone(context.Context, *connect.Request[v1alpha1.SetThermalZoneRequest]) (*connect.Response[v1alpha1.SetThermalZoneResponse], error)
 	ListThermalZones(context.Context, *connect.Request[v1alpha1.ListThermalZonesRequest]) (*connect.Response[v1alpha1.ListThermalZonesResponse], error)
@@ -140,6 +157,10 @@ type BMCServiceClient interface {
 	ChangePassword(context.Context, *connect.Request[v1alpha1.ChangePasswordRequest]) (*connect.Response[v1alpha1.ChangePasswordResponse], error)
 	ResetPassword(context.Context, *connect.Request[v1alpha1.ResetPasswordRequest]) (*connect.Response[v1alpha1.ResetPasswordResponse], error)
 	AuthenticateUser(context.Context, *connect.Request[v1alpha1.AuthenticateUserRequest]) (*connect.Response[v1alpha1.AuthenticateUserResponse], error)
+	EnrollTotp(context.Context, *connect.Request[v1alpha1.EnrollTotpRequest]) (*connect.Response[v1alpha1.EnrollTotpResponse], error)
+	ConfirmTotp(context.Context, *connect.Request[v1alpha1.ConfirmTotpRequest]) (*connect.Response[v1alpha1.ConfirmTotpResponse], error)
+	DisableTotp(context.Context, *connect.Request[v1alpha1.DisableTotpRequest]) (*connect.Response[v1alpha1.DisableTotpResponse], error)
+	RegenerateRecoveryCodes(context.Context, *connect.Request[v1alpha1.RegenerateRecoveryCodesRequest]) (*connect.Response[v1alpha1.RegenerateRecoveryCodesResponse], error)
 }
 
 // NewBMCServiceClient constructs a client for the schema.v1alpha1.BMCService service. By default,
@@ -261,6 +282,18 @@ func NewBMCServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...
 			connect.WithSchema(bMCServiceMethods.ByName("GetSensor")),
 			connect.WithClientOptions(opts...),
 		),
+		listSystemEventLogEntries: connect.NewClient[v1alpha1.ListSystemEventLogEntriesRequest, v1alpha1.ListSystemEventLogEntriesResponse](
+			httpClient,
+			baseURL+BMCServiceListSystemEventLogEntriesProcedure,
+			connect.WithSchema(bMCServiceMethods.ByName("ListSystemEventLogEntries")),
+			connect.WithClientOptions(opts...),
+		),
+		clearSystemEventLog: connect.NewClient[v1alpha1.ClearSystemEventLogRequest, v1alpha1.ClearSystemEventLogResponse](
+			httpClient,
+			baseURL+BMCServiceClearSystemEventLogProcedure,
+			connect.WithSchema(bMCServiceMethods.ByName("ClearSystemEventLog")),
+			connect.WithClientOptions(opts...),
+		),
 		getThermalZone: connect.NewClient[v1alpha1.GetThermalZoneRequest, v1alpha1.GetThermalZoneResponse](
 			httpClient,
 			baseURL+BMCServiceGetThermalZoneProcedure,
@@ -327,6 +360,30 @@ func NewBMCServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...
 			connect.WithSchema(bMCServiceMethods.ByName("AuthenticateUser")),
 			connect.WithClientOptions(opts...),
 		),
+		enrollTotp: connect.NewClient[v1alpha1.EnrollTotpRequest, v1alpha1.EnrollTotpResponse](
+			httpClient,
+			baseURL+BMCServiceEnrollTotpProcedure,
+			connect.WithSchema(bMCServiceMethods.ByName("EnrollTotp")),
+			connect.WithClientOptions(opts...),
+		),
+		confirmTotp: connect.NewClient[v1alpha1.ConfirmTotpRequest, v1alpha1.ConfirmTotpResponse](
+			httpClient,
+			baseURL+BMCServiceConfirmTotpProcedure,
+			connect.WithSchema(bMCServiceMethods.ByName("ConfirmTotp")),
+			connect.WithClientOptions(opts...),
+		),
+		disableTotp: connect.NewClient[v1alpha1.DisableTotpRequest, v1alpha1.DisableTotpResponse](
+			httpClient,
+			baseURL+BMCServiceDisableTotpProcedure,
+			connect.WithSchema(bMCServiceMethods.ByName("DisableTotp")),
+			connect.WithClientOptions(opts...),
+		),
+		regenerateRecoveryCodes: connect.NewClient[v1alpha1.RegenerateRecoveryCodesRequest, v1alpha1.RegenerateRecoveryCodesResponse](
+			httpClient,
+			baseURL+BMCServiceRegenerateRecoveryCodesProcedure,
+			connect.WithSchema(bMCServiceMethods.ByName("RegenerateRecoveryCodes")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -350,6 +407,8 @@ type bMCServiceClient struct {
 	changeManagementControllerState *connect.Client[v1alpha1.ChangeManagementControllerStateRequest, v1alpha1.ChangeManagementControllerStateResponse]
 	listSensors                     *connect.Client[v1alpha1.ListSensorsRequest, v1alpha1.ListSensorsResponse]
 	getSensor                       *connect.Client[v1alpha1.GetSensorRequest, v1alpha1.GetSensorResponse]
+	listSystemEventLogEntries       *connect.Client[v1alpha1.ListSystemEventLogEntriesRequest, v1alpha1.ListSystemEventLogEntriesResponse]
+	clearSystemEventLog             *connect.Client[v1alpha1.ClearSystemEventLogRequest, v1alpha1.ClearSystemEventLogResponse]
 	getThermalZone                  *connect.Client[v1alpha1.GetThermalZoneRequest, v1alpha1.GetThermalZoneResponse]
 	setThermalZone                  *connect.Client[v1alpha1.SetThermalZoneRequest, v1alpha1.SetThermalZoneResponse]
 	listThermalZones                *connect.Client[v1alpha1.ListThermalZonesRequest, v1alpha1.ListThermalZonesResponse]
@@ -361,6 +420,10 @@ type bMCServiceClient struct {
 	changePassword                  *connect.Client[v1alpha1.ChangePasswordRequest, v1alpha1.ChangePasswordResponse]
 	resetPassword                   *connect.Client[v1alpha1.ResetPasswordRequest, v1alpha1.ResetPasswordResponse]
 	authenticateUser                *connect.Client[v1alpha1.AuthenticateUserRequest, v1alpha1.AuthenticateUserResponse]
+	enrollTotp                      *connect.Client[v1alpha1.EnrollTotpRequest, v1alpha1.EnrollTotpResponse]
+	confirmTotp                     *connect.Client[v1alpha1.ConfirmTotpRequest, v1alpha1.ConfirmTotpResponse]
+	disableTotp                     *connect.Client[v1alpha1.DisableTotpRequest, v1alpha1.DisableTotpResponse]
+	regenerateRecoveryCodes         *connect.Client[v1alpha1.RegenerateRecoveryCodesRequest, v1alpha1.RegenerateRecoveryCodesResponse]
 }
 
 // GetSystemInfo calls schema.v1alpha1.BMCService.GetSystemInfo.
@@ -453,6 +516,16 @@ func (c *bMCServiceClient) GetSensor(ctx context.Context, req *connect.Request[v
 	return c.getSensor.CallUnary(ctx, req)
 }
 
+// ListSystemEventLogEntries calls schema.v1alpha1.BMCService.ListSystemEventLogEntries.
+func (c *bMCServiceClient) ListSystemEventLogEntries(ctx context.Context, req *connect.Request[v1alpha1.ListSystemEventLogEntriesRequest]) (*connect.Response[v1alpha1.ListSystemEventLogEntriesResponse], error) {
+	return c.listSystemEventLogEntries.CallUnary(ctx, req)
+}
+
+// ClearSystemEventLog calls schema.v1alpha1.BMCService.ClearSystemEventLog.
+func (c *bMCServiceClient) ClearSystemEventLog(ctx context.Context, req *connect.Request[v1alpha1.ClearSystemEventLogRequest]) (*connect.Response[v1alpha1.ClearSystemEventLogResponse], error) {
+	return c.clearSystemEventLog.CallUnary(ctx, req)
+}
+
 // GetThermalZone calls schema.v1alpha1.BMCService.GetThermalZone.
 func (c *bMCServiceClient) GetThermalZone(ctx context.Context, req *connect.Request[v1alpha1.GetThermalZoneRequest]) (*connect.Response[v1alpha1.GetThermalZoneResponse], error) {
 	return c.getThermalZone.CallUnary(ctx, req)
@@ -508,6 +581,26 @@ func (c *bMCServiceClient) AuthenticateUser(ctx context.Context, req *connect.Re
 	return c.authenticateUser.CallUnary(ctx, req)
 }
 
+// EnrollTotp calls schema.v1alpha1.BMCService.EnrollTotp.
+func (c *bMCServiceClient) EnrollTotp(ctx context.Context, req *connect.Request[v1alpha1.EnrollTotpRequest]) (*connect.Response[v1alpha1.EnrollTotpResponse], error) {
+	return c.enrollTotp.CallUnary(ctx, req)
+}
+
+// ConfirmTotp calls schema.v1alpha1.BMCService.ConfirmTotp.
+func (c *bMCServiceClient) ConfirmTotp(ctx context.Context, req *connect.Request[v1alpha1.ConfirmTotpRequest]) (*connect.Response[v1alpha1.ConfirmTotpResponse], error) {
+	return c.confirmTotp.CallUnary(ctx, req)
+}
+
+// DisableTotp calls schema.v1alpha1.BMCService.DisableTotp.
+func (c *bMCServiceClient) DisableTotp(ctx context.Context, req *connect.Request[v1alpha1.DisableTotpRequest]) (*connect.Response[v1alpha1.DisableTotpResponse], error) {
+	return c.disableTotp.CallUnary(ctx, req)
+}
+
+// RegenerateRecoveryCodes calls schema.v1alpha1.BMCService.RegenerateRecoveryCodes.
+func (c *bMCServiceClient) RegenerateRecoveryCodes(ctx context.Context, req *connect.Request[v1alpha1.RegenerateRecoveryCodesRequest]) (*connect.Response[v1alpha1.RegenerateRecoveryCodesResponse], error) {
+	return c.regenerateRecoveryCodes.CallUnary(ctx, req)
+}
+
 // BMCServiceHandler is an implementation of the schema.v1alpha1.BMCService service.
 type BMCServiceHandler interface {
 	GetSystemInfo(context.Context, *connect.Request[v1alpha1.GetSystemInfoRequest]) (*connect.Response[v1alpha1.GetSystemInfoResponse], error)
@@ -528,6 +621,8 @@ type BMCServiceHandler interface {
 	ChangeManagementControllerState(context.Context, *connect.Request[v1alpha1.ChangeManagementControllerStateRequest]) (*connect.Response[v1alpha1.ChangeManagementControllerStateResponse], error)
 	ListSensors(context.Context, *connect.Request[v1alpha1.ListSensorsRequest]) (*connect.Response[v1alpha1.ListSensorsResponse], error)
 	GetSensor(context.Context, *connect.Request[v1alpha1.GetSensorRequest]) (*connect.Response[v1alpha1.GetSensorResponse], error)
+	ListSystemEventLogEntries(context.Context, *connect.Request[v1alpha1.ListSystemEventLogEntriesRequest]) (*connect.Response[v1alpha1.ListSystemEventLogEntriesResponse], error)
+	ClearSystemEventLog(context.Context, *connect.Request[v1alpha1.ClearSystemEventLogRequest]) (*connect.Response[v1alpha1.ClearSystemEventLogResponse], error)
 	GetThermalZone(context.Context, *connect.Request[v1alpha1.GetThermalZoneRequest]) (*connect.Response[v1alpha1.GetThermalZoneResponse], error)
 	SetThermalZone(context.Context, *connect.Request[v1alpha1.SetThermalZoneRequest]) (*connect.Response[v1alpha1.SetThermalZoneResponse], error)
 	ListThermalZones(context.Context, *connect.Request[v1alpha1.ListThermalZonesRequest]) (*connect.Response[v1alpha1.ListThermalZonesResponse], error)
@@ -539,6 +634,10 @@ type BMCServiceHandler interface {
 	ChangePassword(context.Context, *connect.Request[v1alpha1.ChangePasswordRequest]) (*connect.Response[v1alpha1.ChangePasswordResponse], error)
 	ResetPassword(context.Context, *connect.Request[v1alpha1.ResetPasswordRequest]) (*connect.Response[v1alpha1.ResetPasswordResponse], error)
 	AuthenticateUser(context.Context, *connect.Request[v1alpha1.AuthenticateUserRequest]) (*connect.Response[v1alpha1.AuthenticateUserResponse], error)
+	EnrollTotp(context.Context, *connect.Request[v1alpha1.EnrollTotpRequest]) (*connect.Response[v1alpha1.EnrollTotpResponse], error)
+	ConfirmTotp(context.Context, *connect.Request[v1alpha1.ConfirmTotpRequest]) (*connect.Response[v1alpha1.ConfirmTotpResponse], error)
+	DisableTotp(context.Context, *connect.Request[v1alpha1.DisableTotpRequest]) (*connect.Response[v1alpha1.DisableTotpResponse], error)
+	RegenerateRecoveryCodes(context.Context, *connect.Request[v1alpha1.RegenerateRecoveryCodesRequest]) (*connect.Response[v1alpha1.RegenerateRecoveryCodesResponse], error)
 }
 
 // NewBMCServiceHandler builds an HTTP handler from the service implementation. It returns the path
@@ -656,6 +755,18 @@ func NewBMCServiceHandler(svc BMCServiceHandler, opts ...connect.HandlerOption)
 		connect.WithSchema(bMCServiceMethods.ByName("GetSensor")),
 		connect.WithHandlerOptions(opts...),
 	)
+	bMCServiceListSystemEventLogEntriesHandler := connect.NewUnaryHandler(
+		BMCServiceListSystemEventLogEntriesProcedure,
+		svc.ListSystemEventLogEntries,
+		connect.WithSchema(bMCServiceMethods.ByName("ListSystemEventLogEntries")),
+		connect.WithHandlerOptions(opts...),
+	)
+	bMCServiceClearSystemEventLogHandler := connect.NewUnaryHandler(
+		BMCServiceClearSystemEventLogProcedure,
+		svc.ClearSystemEventLog,
+		connect.WithSchema(bMCServiceMethods.ByName("ClearSystemEventLog")),
+		connect.WithHandlerOptions(opts...),
+	)
 	bMCServiceGetThermalZoneHandler := connect.NewUnaryHandler(
 		BMCServiceGetThermalZoneProcedure,
 		svc.GetThermalZone,
@@ -722,6 +833,30 @@ func NewBMCServiceHandler(svc BMCServiceHandler, opts ...connect.HandlerOption)
 		connect.WithSchema(bMCServiceMethods.ByName("AuthenticateUser")),
 		connect.WithHandlerOptions(opts...),
 	)
+	bMCServiceEnrollTotpHandler := connect.NewUnaryHandler(
+		BMCServiceEnrollTotpProcedure,
+		svc.EnrollTotp,
+		connect.WithSchema(bMCServiceMethods.ByName("EnrollTotp")),
+		connect.WithHandlerOptions(opts...),
+	)
+	bMCServiceConfirmTotpHandler := connect.NewUnaryHandler(
+		BMCServiceConfirmTotpProcedure,
+		svc.ConfirmTotp,
+		connect.WithSchema(bMCServiceMethods.ByName("ConfirmTotp")),
+		connect.WithHandlerOptions(opts...),
+	)
+	bMCServiceDisableTotpHandler := connect.NewUnaryHandler(
+		BMCServiceDisableTotpProcedure,
+		svc.DisableTotp,
+		connect.WithSchema(bMCServiceMethods.ByName("DisableTotp")),
+		connect.WithHandlerOptions(opts...),
+	)
+	bMCServiceRegenerateRecoveryCodesHandler := connect.NewUnaryHandler(
+		BMCServiceRegenerateRecoveryCodesProcedure,
+		svc.RegenerateRecoveryCodes,
+		connect.WithSchema(bMCServiceMethods.ByName("RegenerateRecoveryCodes")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/schema.v1alpha1.BMCService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case BMCServiceGetSystemInfoProcedure:
@@ -760,6 +895,10 @@ func NewBMCServiceHandler(svc BMCServiceHandler, opts ...connect.HandlerOption)
 			bMCServiceListSensorsHandler.ServeHTTP(w, r)
 		case BMCServiceGetSensorProcedure:
 			bMCServiceGetSensorHandler.ServeHTTP(w, r)
+		case BMCServiceListSystemEventLogEntriesProcedure:
+			bMCServiceListSystemEventLogEntriesHandler.ServeHTTP(w, r)
+		case BMCServiceClearSystemEventLogProcedure:
+			bMCServiceClearSystemEventLogHandler.ServeHTTP(w, r)
 		case BMCServiceGetThermalZoneProcedure:
 			bMCServiceGetThermalZoneHandler.ServeHTTP(w, r)
 		case BMCServiceSetThermalZoneProcedure:
@@ -782,6 +921,14 @@ func NewBMCServiceHandler(svc BMCServiceHandler, opts ...connect.HandlerOption)
 			bMCServiceResetPasswordHandler.ServeHTTP(w, r)
 		case BMCServiceAuthenticateUserProcedure:
 			bMCServiceAuthenticateUserHandler.ServeHTTP(w, r)
+		case BMCServiceEnrollTotpProcedure:
+			bMCServiceEnrollTotpHandler.ServeHTTP(w, r)
+		case BMCServiceConfirmTotpProcedure:
+			bMCServiceConfirmTotpHandler.ServeHTTP(w, r)
+		case BMCServiceDisableTotpProcedure:
+			bMCServiceDisableTotpHandler.ServeHTTP(w, r)
+		case BMCServiceRegenerateRecoveryCodesProcedure:
+			bMCServiceRegenerateRecoveryCodesHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -863,6 +1010,14 @@ func (UnimplementedBMCServiceHandler) GetSensor(context.Context, *connect.Reques
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("schema.v1alpha1.BMCService.GetSensor is not implemented"))
 }
 
+func (UnimplementedBMCServiceHandler) ListSystemEventLogEntries(context.Context, *connect.Request[v1alpha1.ListSystemEventLogEntriesRequest]) (*connect.Response[v1alpha1.ListSystemEventLogEntriesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("schema.v1alpha1.BMCService.ListSystemEventLogEntries is not implemented"))
+}
+
+func (UnimplementedBMCServiceHandler) ClearSystemEventLog(context.Context, *connect.Request[v1alpha1.ClearSystemEventLogRequest]) (*connect.Response[v1alpha1.ClearSystemEventLogResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("schema.v1alpha1.BMCService.ClearSystemEventLog is not implemented"))
+}
+
 func (UnimplementedBMCServiceHandler) GetThermalZone(context.Context, *connect.Request[v1alpha1.GetThermalZoneRequest]) (*connect.Response[v1alpha1.GetThermalZoneResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("schema.v1alpha1.BMCService.GetThermalZone is not implemented"))
 }
@@ -906,3 +1061,19 @@ func (UnimplementedBMCServiceHandler) ResetPassword(context.Context, *connect.Re
 func (UnimplementedBMCServiceHandler) AuthenticateUser(context.Context, *connect.Request[v1alpha1.AuthenticateUserRequest]) (*connect.Response[v1alpha1.AuthenticateUserResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("schema.v1alpha1.BMCService.AuthenticateUser is not implemented"))
 }
+
+func (UnimplementedBMCServiceHandler) EnrollTotp(context.Context, *connect.Request[v1alpha1.EnrollTotpRequest]) (*connect.Response[v1alpha1.EnrollTotpResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("schema.v1alpha1.BMCService.EnrollTotp is not implemented"))
+}
+
+func (UnimplementedBMCServiceHandler) ConfirmTotp(context.Context, *connect.Request[v1alpha1.ConfirmTotpRequest]) (*connect.Response[v1alpha1.ConfirmTotpResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("schema.v1alpha1.BMCService.ConfirmTotp is not implemented"))
+}
+
+func (UnimplementedBMCServiceHandler) DisableTotp(context.Context, *connect.Request[v1alpha1.DisableTotpRequest]) (*connect.Response[v1alpha1.DisableTotpResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("schema.v1alpha1.BMCService.DisableTotp is not implemented"))
+}
+
+func (UnimplementedBMCServiceHandler) RegenerateRecoveryCodes(context.Context, *connect.Request[v1alpha1.RegenerateRecoveryCodesRequest]) (*connect.Response[v1alpha1.RegenerateRecoveryCodesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("schema.v1alpha1.BMCService.RegenerateRecoveryCodes is not implemented"))
+}
@@ -579,7 +579,7 @@ var File_schema_v1alpha1_system_proto protoreflect.FileDescriptor
 
 const file_schema_v1alpha1_system_proto_rawDesc = "" +
 	"\n" +
-	"\x1cschema/v1alpha1/system.proto\x12\x0fschema.v1alpha1\x1a\x1bbuf/validate/validate.proto\x1a\x1cgoogle/api/annotations.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1bschema/v1alpha1/asset.proto\x1a\x1dschema/v1alpha1/chassis.proto\x1a\x1dschema/v1alpha1/contact.proto\x1a\x1aschema/v1alpha1/host.proto\x1a*schema/v1alpha1/managementcontroller.proto\x1a\x1cschema/v1alpha1/sensor.proto\x1a\x1dschema/v1alpha1/thermal.proto\x1a\x1aschema/v1alpha1/user.proto\"\xe5\x02\n" +
+	"\x1cschema/v1alpha1/system.proto\x12\x0fschema.v1alpha1\x1a\x1bbuf/validate/validate.proto\x1a\x1cgoogle/api/annotations.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1bschema/v1alpha1/asset.proto\x1a\x1dschema/v1alpha1/chassis.proto\x1a\x1dschema/v1alpha1/contact.proto\x1a\x1eschema/v1alpha1/eventlog.proto\x1a\x1aschema/v1alpha1/host.proto\x1a*schema/v1alpha1/managementcontroller.proto\x1a\x1cschema/v1alpha1/sensor.proto\x1a\x1dschema/v1alpha1/thermal.proto\x1a\x1aschema/v1alpha1/user.proto\"\xe5\x02\n" +
 	"\x06Health\x12?\n" +
 	"\x06status\x18\x01 \x01(\x0e2\x1d.schema.v1alpha1.HealthStatusB\b\xbaH\x05\x82\x01\x02\x10\x01R\x06status\x122\n" +
 	"\x12status_description\x18\x02 \x01(\tH\x00R\x11statusDescription\x88\x01\x01\x127\n" +
@@ -646,7 +646,7 @@ const file_schema_v1alpha1_system_proto_rawDesc = "" +
 	"\x14SYSTEM_STATE_STANDBY\x10\x04\x12\x19\n" +
 	"\x15SYSTEM_STATE_QUIESCED\x10\x05\x12\x18\n" +
 	"\x14SYSTEM_STATE_IN_TEST\x10\x06\x12\x19\n" +
-	"\x15SYSTEM_STATE_UPDATING\x10\a2\xfa\x1f\n" +
+	"\x15SYSTEM_STATE_UPDATING\x10\a2\xad'\n" +
 	"\n" +
 	"BMCService\x12\x81\x01\n" +
 	"\rGetSystemInfo\x12%.schema.v1alpha1.GetSystemInfoRequest\x1a&.schema.v1alpha1.GetSystemInfoResponse\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/api/v1alpha1/system/info\x12w\n" +
@@ -668,7 +668,9 @@ const file_schema_v1alpha1_system_proto_rawDesc = "" +
 	"\x1aUpdateManagementController\x122.schema.v1alpha1.UpdateManagementControllerRequest\x1a3.schema.v1alpha1.UpdateManagementControllerResponse\"A\x82\xd3\xe4\x93\x02;:\x01*26/api/v1alpha1/management-controllers/{controller_name}\x12\xdd\x01\n" +
 	"\x1fChangeManagementControllerState\x127.schema.v1alpha1.ChangeManagementControllerStateRequest\x1a8.schema.v1alpha1.ChangeManagementControllerStateResponse\"G\x82\xd3\xe4\x93\x02A:\x01*\"</api/v1alpha1/management-controllers/{controller_name}/state\x12w\n" +
 	"\vListSensors\x12#.schema.v1alpha1.ListSensorsRequest\x1a$.schema.v1alpha1.ListSensorsResponse\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/api/v1alpha1/sensors\x12v\n" +
-	"\tGetSensor\x12!.schema.v1alpha1.GetSensorRequest\x1a\".schema.v1alpha1.GetSensorResponse\"\"\x82\xd3\xe4\x93\x02\x1c\x12\x1a/api/v1alpha1/sensors/{id}\x12\x8d\x01\n" +
+	"\tGetSensor\x12!.schema.v1alpha1.GetSensorRequest\x1a\".schema.v1alpha1.GetSensorResponse\"\"\x82\xd3\xe4\x93\x02\x1c\x12\x1a/api/v1alpha1/sensors/{id}\x12\xb2\x01\n" +
+	"\x19ListSystemEventLogEntries\x121.schema.v1alpha1.ListSystemEventLogEntriesRequest\x1a2.schema.v1alpha1.ListSystemEventLogEntriesResponse\".\x82\xd3\xe4\x93\x02(\x12&/api/v1alpha1/system-event-log/entries\x12\xa0\x01\n" +
+	"\x13ClearSystemEventLog\x12+.schema.v1alpha1.ClearSystemEventLogRequest\x1a,.schema.v1alpha1.ClearSystemEventLogResponse\".\x82\xd3\xe4\x93\x02(*&/api/v1alpha1/system-event-log/entries\x12\x8d\x01\n" +
 	"\x0eGetThermalZone\x12&.schema.v1alpha1.GetThermalZoneRequest\x1a'.schema.v1alpha1.GetThermalZoneResponse\"*\x82\xd3\xe4\x93\x02$\x12\"/api/v1alpha1/thermal-zones/{name}\x12\x90\x01\n" +
 	"\x0eSetThermalZone\x12&.schema.v1alpha1.SetThermalZoneRequest\x1a'.schema.v1alpha1.SetThermalZoneResponse\"-\x82\xd3\xe4\x93\x02':\x01*\x1a\"/api/v1alpha1/thermal-zones/{name}\x12\x8c\x01\n" +
 	"\x10ListThermalZones\x12(.schema.v1alpha1.ListThermalZonesRequest\x1a).schema.v1alpha1.ListThermalZonesResponse\"#\x82\xd3\xe4\x93\x02\x1d\x12\x1b/api/v1alpha1/thermal-zones\x12u\n" +
@@ -682,7 +684,12 @@ const file_schema_v1alpha1_system_proto_rawDesc = "" +
 	"\tListUsers\x12!.schema.v1alpha1.ListUsersRequest\x1a\".schema.v1alpha1.ListUsersResponse\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/api/v1alpha1/users\x12\x96\x01\n" +
 	"\x0eChangePassword\x12&.schema.v1alpha1.ChangePasswordRequest\x1a'.schema.v1alpha1.ChangePasswordResponse\"3\x82\xd3\xe4\x93\x02-:\x01*\"(/api/v1alpha1/users/{id}/change-password\x12\x92\x01\n" +
 	"\rResetPassword\x12%.schema.v1alpha1.ResetPasswordRequest\x1a&.schema.v1alpha1.ResetPasswordResponse\"2\x82\xd3\xe4\x93\x02,:\x01*\"'/api/v1alpha1/users/{id}/reset-password\x12\x93\x01\n" +
-	"\x10AuthenticateUser\x12(.schema.v1alpha1.AuthenticateUserRequest\x1a).schema.v1alpha1.AuthenticateUserResponse\"*\x82\xd3\xe4\x93\x02$:\x01*\"\x1f/api/v1alpha1/auth/authenticateB\xbe\x01\n" +
+	"\x10AuthenticateUser\x12(.schema.v1alpha1.AuthenticateUserRequest\x1a).schema.v1alpha1.AuthenticateUserResponse\"*\x82\xd3\xe4\x93\x02$:\x01*\"\x1f/api/v1alpha1/auth/authenticate\x12\x86\x01\n" +
+	"\n" +
+	"EnrollTotp\x12\".schema.v1alpha1.EnrollTotpRequest\x1a#.schema.v1alpha1.EnrollTotpResponse\"/\x82\xd3\xe4\x93\x02):\x01*\"$/api/v1alpha1/users/{id}/totp/enroll\x12\x8a\x01\n" +
+	"\vConfirmTotp\x12#.schema.v1alpha1.ConfirmTotpRequest\x1a$.schema.v1alpha1.ConfirmTotpResponse\"0\x82\xd3\xe4\x93\x02*:\x01*\"%/api/v1alpha1/users/{id}/totp/confirm\x12\x8a\x01\n" +
+	"\vDisableTotp\x12#.schema.v1alpha1.DisableTotpRequest\x1a$.schema.v1alpha1.DisableTotpResponse\"0\x82\xd3\xe4\x93\x02*:\x01*\"%/api/v1alpha1/users/{id}/totp/disable\x12\xb5\x01\n" +
+	"\x17RegenerateRecoveryCodes\x12/.schema.v1alpha1.RegenerateRecoveryCodesRequest\x1a0.schema.v1alpha1.RegenerateRecoveryCodesResponse\"7\x82\xd3\xe4\x93\x021:\x01*\",/api/v1alpha1/users/{id}/totp/recovery-codesB\xbe\x01\n" +
 	"\x13com.schema.v1alpha1B\vSystemProtoP\x01Z=github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1;schemav1alpha1\xa2\x02\x03SXX\xaa\x02\x0fSchema.V1alpha1\xca\x02\x0fSchema\\V1alpha1\xe2\x02\x1bSchema\\V1alpha1\\GPBMetadata\xea\x02\x10Schema::V1alpha1b\x06proto3"
 
 var (
@@ -730,44 +737,56 @@ var file_schema_v1alpha1_system_proto_goTypes = []any{
 	(*ChangeManagementControllerStateRequest)(nil),  // 27: schema.v1alpha1.ChangeManagementControllerStateRequest
 	(*ListSensorsRequest)(nil),                      // 28: schema.v1alpha1.ListSensorsRequest
 	(*GetSensorRequest)(nil),                        // 29: schema.v1alpha1.GetSensorRequest
-	(*GetThermalZoneRequest)(nil),                   // 30: schema.v1alpha1.GetThermalZoneRequest
-	(*SetThermalZoneRequest)(nil),                   // 31: schema.v1alpha1.SetThermalZoneRequest
-	(*ListThermalZonesRequest)(nil),                 // 32: schema.v1alpha1.ListThermalZonesRequest
-	(*CreateUserRequest)(nil),                       // 33: schema.v1alpha1.CreateUserRequest
-	(*GetUserRequest)(nil),                          // 34: schema.v1alpha1.GetUserRequest
-	(*UpdateUserRequest)(nil),                       // 35: schema.v1alpha1.UpdateUserRequest
-	(*DeleteUserRequest)(nil),                       // 36: schema.v1alpha1.DeleteUserRequest
-	(*ListUsersRequest)(nil),                        // 37: schema.v1alpha1.ListUsersRequest
-	(*ChangePasswordRequest)(nil),                   // 38: schema.v1alpha1.ChangePasswordRequest
-	(*ResetPasswordRequest)(nil),                    // 39: schema.v1alpha1.ResetPasswordRequest
-	(*AuthenticateUserRequest)(nil),                 // 40: schema.v1alpha1.AuthenticateUserRequest
-	(*GetAssetInfoResponse)(nil),                    // 41: schema.v1alpha1.GetAssetInfoResponse
-	(*SetAssetInfoResponse)(nil),                    // 42: schema.v1alpha1.SetAssetInfoResponse
-	(*GetChassisResponse)(nil),                      // 43: schema.v1alpha1.GetChassisResponse
-	(*ListChassisResponse)(nil),                     // 44: schema.v1alpha1.ListChassisResponse
-	(*UpdateChassisResponse)(nil),                   // 45: schema.v1alpha1.UpdateChassisResponse
-	(*ChangeChassisStateResponse)(nil),              // 46: schema.v1alpha1.ChangeChassisStateResponse
-	(*GetHostResponse)(nil),                         // 47: schema.v1alpha1.GetHostResponse
-	(*ListHostsResponse)(nil),                       // 48: schema.v1alpha1.ListHostsResponse
-	(*UpdateHostResponse)(nil),                      // 49: schema.v1alpha1.UpdateHostResponse
-	(*ChangeHostStateResponse)(nil),                 // 50: schema.v1alpha1.ChangeHostStateResponse
-	(*GetManagementControllerResponse)(nil),         // 51: schema.v1alpha1.GetManagementControllerResponse
-	(*ListManagementControllersResponse)(nil),       // 52: schema.v1alpha1.ListManagementControllersResponse
-	(*UpdateManagementControllerResponse)(nil),      // 53: schema.v1alpha1.UpdateManagementControllerResponse
-	(*ChangeManagementControllerStateResponse)(nil), // 54: schema.v1alpha1.ChangeManagementControllerStateResponse
-	(*ListSensorsResponse)(nil),                     // 55: schema.v1alpha1.ListSensorsResponse
-	(*GetSensorResponse)(nil),                       // 56: schema.v1alpha1.GetSensorResponse
-	(*GetThermalZoneResponse)(nil),                  // 57: schema.v1alpha1.GetThermalZoneResponse
-	(*SetThermalZoneResponse)(nil),                  // 58: schema.v1alpha1.SetThermalZoneResponse
-	(*ListThermalZonesResponse)(nil),                // 59: schema.v1alpha1.ListThermalZonesResponse
-	(*CreateUserResponse)(nil),                      // 60: schema.v1alpha1.CreateUserResponse
-	(*GetUserResponse)(nil),                         // 61: schema.v1alpha1.GetUserResponse
-	(*UpdateUserResponse)(nil),                      // 62: schema.v1alpha1.UpdateUserResponse
-	(*DeleteUserResponse)(nil),                      // 63: schema.v1alpha1.DeleteUserResponse
-	(*ListUsersResponse)(nil),                       // 64: schema.v1alpha1.ListUsersResponse
-	(*ChangePasswordResponse)(nil),                  // 65: schema.v1alpha1.ChangePasswordResponse
-	(*ResetPasswordResponse)(nil),                   // 66: schema.v1alpha1.ResetPasswordResponse
-	(*AuthenticateUserResponse)(nil),                // 67: schema.v1alpha1.AuthenticateUserResponse
+	(*ListSystemEventLogEntriesRequest)(nil),        // 30: schema.v1alpha1.ListSystemEventLogEntriesRequest
+	(*ClearSystemEventLogRequest)(nil),              // 31: schema.v1alpha1.ClearSystemEventLogRequest
+	(*GetThermalZoneRequest)(nil),                   // 32: schema.v1alpha1.GetThermalZoneRequest
+	(*SetThermalZoneRequest)(nil),                   // 33: schema.v1alpha1.SetThermalZoneRequest
+	(*ListThermalZonesRequest)(nil),                 // 34: schema.v1alpha1.ListThermalZonesRequest
+	(*CreateUserRequest)(nil),                       // 35: schema.v1alpha1.CreateUserRequest
+	(*GetUserRequest)(nil),                          // 36: schema.v1alpha1.GetUserRequest
+	(*UpdateUserRequest)(nil),                       // 37: schema.v1alpha1.UpdateUserRequest
+	(*DeleteUserRequest)(nil),                       // 38: schema.v1alpha1.DeleteUserRequest
+	(*ListUsersRequest)(nil),                        // 39: schema.v1alpha1.ListUsersRequest
+	(*ChangePasswordRequest)(nil),                   // 40: schema.v1alpha1.ChangePasswordRequest
+	(*ResetPasswordRequest)(nil),                    // 41: schema.v1alpha1.ResetPasswordRequest
+	(*AuthenticateUserRequest)(nil),                 // 42: schema.v1alpha1.AuthenticateUserRequest
+	(*EnrollTotpRequest)(nil),                       // 43: schema.v1alpha1.EnrollTotpRequest
+	(*ConfirmTotpRequest)(nil),                      // 44: schema.v1alpha1.ConfirmTotpRequest
+	(*DisableTotpRequest)(nil),                      // 45: schema.v1alpha1.DisableTotpRequest
+	(*RegenerateRecoveryCodesRequest)(nil),          // 46: schema.v1alpha1.RegenerateRecoveryCodesRequest
+	(*GetAssetInfoResponse)(nil),                    // 47: schema.v1alpha1.GetAssetInfoResponse
+	(*SetAssetInfoResponse)(nil),                    // 48: schema.v1alpha1.SetAssetInfoResponse
+	(*GetChassisResponse)(nil),                      // 49: schema.v1alpha1.GetChassisResponse
+	(*ListChassisResponse)(nil),                     // 50: schema.v1alpha1.ListChassisResponse
+	(*UpdateChassisResponse)(nil),                   // 51: schema.v1alpha1.UpdateChassisResponse
+	(*ChangeChassisStateResponse)(nil),              // 52: schema.v1alpha1.ChangeChassisStateResponse
+	(*GetHostResponse)(nil),                         // 53: schema.v1alpha1.GetHostResponse
+	(*ListHostsResponse)(nil),                       // 54: schema.v1alpha1.ListHostsResponse
+	(*UpdateHostResponse)(nil),                      // 55: schema.v1alpha1.UpdateHostResponse
+	(*ChangeHostStateResponse)(nil),                 // 56: schema.v1alpha1.ChangeHostStateResponse
+	(*GetManagementControllerResponse)(nil),         // 57: schema.v1alpha1.GetManagementControllerResponse
+	(*ListManagementControllersResponse)(nil),       // 58: schema.v1alpha1.ListManagementControllersResponse
+	(*UpdateManagementControllerResponse)(nil),      // 59: schema.v1alpha1.UpdateManagementControllerResponse
+	(*ChangeManagementControllerStateResponse)(nil), // 60: schema.v1alpha1.ChangeManagementControllerStateResponse
+	(*ListSensorsResponse)(nil),                     // 61: schema.v1alpha1.ListSensorsResponse
+	(*GetSensorResponse)(nil),                       // 62: schema.v1alpha1.GetSensorResponse
+	(*ListSystemEventLogEntriesResponse)(nil),       // 63: schema.v1alpha1.ListSystemEventLogEntriesResponse
+	(*ClearSystemEventLogResponse)(nil),             // 64: schema.v1alpha1.ClearSystemEventLogResponse
+	(*GetThermalZoneResponse)(nil),                  // 65: schema.v1alpha1.GetThermalZoneResponse
+	(*SetThermalZoneResponse)(nil),                  // 66: schema.v1alpha1.SetThermalZoneResponse
+	(*ListThermalZonesResponse)(nil),                // 67: schema.v1alpha1.ListThermalZonesResponse
+	(*CreateUserResponse)(nil),                      // 68: schema.v1alpha1.CreateUserResponse
+	(*GetUserResponse)(nil),                         // 69: schema.v1alpha1.GetUserResponse
+	(*UpdateUserResponse)(nil),                      // 70: schema.v1alpha1.UpdateUserResponse
+	(*DeleteUserResponse)(nil),                      // 71: schema.v1alpha1.DeleteUserResponse
+	(*ListUsersResponse)(nil),                       // 72: schema.v1alpha1.ListUsersResponse
+	(*ChangePasswordResponse)(nil),                  // 73: schema.v1alpha1.ChangePasswordResponse
+	(*ResetPasswordResponse)(nil),                   // 74: schema.v1alpha1.ResetPasswordResponse
+	(*AuthenticateUserResponse)(nil),                // 75: schema.v1alpha1.AuthenticateUserResponse
+	(*EnrollTotpResponse)(nil),                      // 76: schema.v1alpha1.EnrollTotpResponse
+	(*ConfirmTotpResponse)(nil),                     // 77: schema.v1alpha1.ConfirmTotpResponse
+	(*DisableTotpResponse)(nil),                     // 78: schema.v1alpha1.DisableTotpResponse
+	(*RegenerateRecoveryCodesResponse)(nil),         // 79: schema.v1alpha1.RegenerateRecoveryCodesResponse
 }
 var file_schema_v1alpha1_system_proto_depIdxs = []int32{
 	0,  // 0: schema.v1alpha1.Health.status:type_name -> schema.v1alpha1.HealthStatus
@@ -804,48 +823,60 @@ var file_schema_v1alpha1_system_proto_depIdxs = []int32{
 	27, // 31: schema.v1alpha1.BMCService.ChangeManagementControllerState:input_type -> schema.v1alpha1.ChangeManagementControllerStateRequest
 	28, // 32: schema.v1alpha1.BMCService.ListSensors:input_type -> schema.v1alpha1.ListSensorsRequest
 	29, // 33: schema.v1alpha1.BMCService.GetSensor:input_type -> schema.v1alpha1.GetSensorRequest
-	30, // 34: schema.v1alpha1.BMCService.GetThermalZone:input_type -> schema.v1alpha1.GetThermalZoneRequest
-	31, // 35: schema.v1alpha1.BMCService.SetThermalZone:input_type -> schema.v1alpha1.SetThermalZoneRequest
-	32, // 36: schema.v1alpha1.BMCService.ListThermalZones:input_type -> schema.v1alpha1.ListThermalZonesRequest
-	33, // 37: schema.v1alpha1.BMCService.CreateUser:input_type -> schema.v1alpha1.CreateUserRequest
-	34, // 38: schema.v1alpha1.BMCService.GetUser:input_type -> schema.v1alpha1.GetUserRequest
-	35, // 39: schema.v1alpha1.BMCService.UpdateUser:input_type -> schema.v1alpha1.UpdateUserRequest
-	36, // 40: schema.v1alpha1.BMCService.DeleteUser:input_type -> schema.v1alpha1.DeleteUserRequest
-	37, // 41: schema.v1alpha1.BMCService.ListUsers:input_type -> schema.v1alpha1.ListUsersRequest
-	38, // 42: schema.v1alpha1.BMCService.ChangePassword:input_type -> schema.v1alpha1.ChangePasswordRequest
-	39, // 43: schema.v1alpha1.BMCService.ResetPassword:input_type -> schema.v1alpha1.ResetPasswordRequest
-	40, // 44: schema.v1alpha1.BMCService.AuthenticateUser:input_type -> schema.v1alpha1.AuthenticateUserRequest
-	6,  // 45: schema.v1alpha1.BMCService.GetSystemInfo:output_type -> schema.v1alpha1.GetSystemInfoResponse
-	8,  // 46: schema.v1alpha1.BMCService.GetHealth:output_type -> schema.v1alpha1.GetHealthResponse
-	41, // 47: schema.v1alpha1.BMCService.GetAssetInfo:output_type -> schema.v1alpha1.GetAssetInfoResponse
-	42, // 48: schema.v1alpha1.BMCService.SetAssetInfo:output_type -> schema.v1alpha1.SetAssetInfoResponse
-	43, // 49: schema.v1alpha1.BMCService.GetChassis:output_type -> schema.v1alpha1.GetChassisResponse
-	44, // 50: schema.v1alpha1.BMCService.ListChassis:output_type -> schema.v1alpha1.ListChassisResponse
-	45, // 51: schema.v1alpha1.BMCService.UpdateChassis:output_type -> schema.v1alpha1.UpdateChassisResponse
-	46, // 52: schema.v1alpha1.BMCService.ChangeChassisState:output_type -> schema.v1alpha1.ChangeChassisStateResponse
-	47, // 53: schema.v1alpha1.BMCService.GetHost:output_type -> schema.v1alpha1.GetHostResponse
-	48, // 54: schema.v1alpha1.BMCService.ListHosts:output_type -> schema.v1alpha1.ListHostsResponse
-	49, // 55: schema.v1alpha1.BMCService.UpdateHost:output_type -> schema.v1alpha1.UpdateHostResponse
-	50, // 56: schema.v1alpha1.BMCService.ChangeHostState:output_type -> schema.v1alpha1.ChangeHostStateResponse
-	51, // 57: schema.v1alpha1.BMCService.GetManagementController:output_type -> schema.v1alpha1.GetManagementControllerResponse
-	52, // 58: schema.v1alpha1.BMCService.ListManagementControllers:output_type -> schema.v1alpha1.ListManagementControllersResponse
-	53, // 59: schema.v1alpha1.BMCService.UpdateManagementController:output_type -> schema.v1alpha1.UpdateManagementControllerResponse
-	54, // 60: schema.v1alpha1.BMCService.ChangeManagementControllerState:output_type -> schema.v1alpha1.ChangeManagementControllerStateResponse
-	55, // 61: schema.v1alpha1.BMCService.ListSensors:output_type -> schema.v1alpha1.ListSensorsResponse
-	56, // 62: schema.v1alpha1.BMCService.GetSensor:output_type -> schema.v1alpha1.GetSensorResponse
-	57, // 63: schema.v1alpha1.BMCService.GetThermalZone:output_type -> schema.v1alpha1.GetThermalZoneResponse
-	58, // 64: schema.v1alpha1.BMCService.SetThermalZone:output_type -> schema.v1alpha1.SetThermalZoneResponse
-	59, // 65: schema.v1alpha1.BMCService.ListThermalZones:output_type -> schema.v1alpha1.ListThermalZonesResponse
-	60, // 66: schema.v1alpha1.BMCService.CreateUser:output_type -> schema.v1alpha1.CreateUserResponse
-	61, // 67: schema.v1alpha1.BMCService.GetUser:output_type -> schema.v1alpha1.GetUserResponse
-	62, // 68: schema.v1alpha1.BMCService.UpdateUser:output_type -> schema.v1alpha1.UpdateUserResponse
-	63, // 69: schema.v1alpha1.BMCService.DeleteUser:output_type -> schema.v1alpha1.DeleteUserResponse
-	64, // 70: schema.v1alpha1.BMCService.ListUsers:output_type -> schema.v1alpha1.ListUsersResponse
-	65, // 71: schema.v1alpha1.BMCService.ChangePassword:output_type -> schema.v1alpha1.ChangePasswordResponse
-	66, // 72: schema.v1alpha1.BMCService.ResetPassword:output_type -> schema.v1alpha1.ResetPasswordResponse
-	67, // 73: schema.v1alpha1.BMCService.AuthenticateUser:output_type -> schema.v1alpha1.AuthenticateUserResponse
-	45, // [45:74] is the sub-list for method output_type
-	16, // [16:45] is the sub-list for method input_type
+	30, // 34: schema.v1alpha1.BMCService.ListSystemEventLogEntries:input_type -> schema.v1alpha1.ListSystemEventLogEntriesRequest
+	31, // 35: schema.v1alpha1.BMCService.ClearSystemEventLog:input_type -> schema.v1alpha1.ClearSystemEventLogRequest
+	32, // 36: schema.v1alpha1.BMCService.GetThermalZone:input_type -> schema.v1alpha1.GetThermalZoneRequest
+	33, // 37: schema.v1alpha1.BMCService.SetThermalZone:input_type -> schema.v1alpha1.SetThermalZoneRequest
+	34, // 38: schema.v1alpha1.BMCService.ListThermalZones:input_type -> schema.v1alpha1.ListThermalZonesRequest
+	35, // 39: schema.v1alpha1.BMCService.CreateUser:input_type -> schema.v1alpha1.CreateUserRequest
+	36, // 40: schema.v1alpha1.BMCService.GetUser:input_type -> schema.v1alpha1.GetUserRequest
+	37, // 41: schema.v1alpha1.BMCService.UpdateUser:input_type -> schema.v1alpha1.UpdateUserRequest
+	38, // 42: schema.v1alpha1.BMCService.DeleteUser:input_type -> schema.v1alpha1.DeleteUserRequest
+	39, // 43: schema.v1alpha1.BMCService.ListUsers:input_type -> schema.v1alpha1.ListUsersRequest
+	40, // 44: schema.v1alpha1.BMCService.ChangePassword:input_type -> schema.v1alpha1.ChangePasswordRequest
+	41, // 45: schema.v1alpha1.BMCService.ResetPassword:input_type -> schema.v1alpha1.ResetPasswordRequest
+	42, // 46: schema.v1alpha1.BMCService.AuthenticateUser:input_type -> schema.v1alpha1.AuthenticateUserRequest
+	43, // 47: schema.v1alpha1.BMCService.EnrollTotp:input_type -> schema.v1alpha1.EnrollTotpRequest
+	44, // 48: schema.v1alpha1.BMCService.ConfirmTotp:input_type -> schema.v1alpha1.ConfirmTotpRequest
+	45, // 49: schema.v1alpha1.BMCService.DisableTotp:input_type -> schema.v1alpha1.DisableTotpRequest
+	46, // 50: schema.v1alpha1.BMCService.RegenerateRecoveryCodes:input_type -> schema.v1alpha1.RegenerateRecoveryCodesRequest
+	6,  // 51: schema.v1alpha1.BMCService.GetSystemInfo:output_type -> schema.v1alpha1.GetSystemInfoResponse
+	8,  // 52: schema.v1alpha1.BMCService.GetHealth:output_type -> schema.v1alpha1.GetHealthResponse
+	47, // 53: schema.v1alpha1.BMCService.GetAssetInfo:output_type -> schema.v1alpha1.GetAssetInfoResponse
+	48, // 54: schema.v1alpha1.BMCService.SetAssetInfo:output_type -> schema.v1alpha1.SetAssetInfoResponse
+	49, // 55: schema.v1alpha1.BMCService.GetChassis:output_type -> schema.v1alpha1.GetChassisResponse
+	50, // 56: schema.v1alpha1.BMCService.ListChassis:output_type -> schema.v1alpha1.ListChassisResponse
+	51, // 57: schema.v1alpha1.BMCService.UpdateChassis:output_type -> schema.v1alpha1.UpdateChassisResponse
+	52, // 58: schema.v1alpha1.BMCService.ChangeChassisState:output_type -> schema.v1alpha1.ChangeChassisStateResponse
+	53, // 59: schema.v1alpha1.BMCService.GetHost:output_type -> schema.v1alpha1.GetHostResponse
+	54, // 60: schema.v1alpha1.BMCService.ListHosts:output_type -> schema.v1alpha1.ListHostsResponse
+	55, // 61: schema.v1alpha1.BMCService.UpdateHost:output_type -> schema.v1alpha1.UpdateHostResponse
+	56, // 62: schema.v1alpha1.BMCService.ChangeHostState:output_type -> schema.v1alpha1.ChangeHostStateResponse
+	57, // 63: schema.v1alpha1.BMCService.GetManagementController:output_type -> schema.v1alpha1.GetManagementControllerResponse
+	58, // 64: schema.v1alpha1.BMCService.ListManagementControllers:output_type -> schema.v1alpha1.ListManagementControllersResponse
+	59, // 65: schema.v1alpha1.BMCService.UpdateManagementController:output_type -> schema.v1alpha1.UpdateManagementControllerResponse
+	60, // 66: schema.v1alpha1.BMCService.ChangeManagementControllerState:output_type -> schema.v1alpha1.ChangeManagementControllerStateResponse
+	61, // 67: schema.v1alpha1.BMCService.ListSensors:output_type -> schema.v1alpha1.ListSensorsResponse
+	62, // 68: schema.v1alpha1.BMCService.GetSensor:output_type -> schema.v1alpha1.GetSensorResponse
+	63, // 69: schema.v1alpha1.BMCService.ListSystemEventLogEntries:output_type -> schema.v1alpha1.ListSystemEventLogEntriesResponse
+	64, // 70: schema.v1alpha1.BMCService.ClearSystemEventLog:output_type -> schema.v1alpha1.ClearSystemEventLogResponse
+	65, // 71: schema.v1alpha1.BMCService.GetThermalZone:output_type -> schema.v1alpha1.GetThermalZoneResponse
+	66, // 72: schema.v1alpha1.BMCService.SetThermalZone:output_type -> schema.v1alpha1.SetThermalZoneResponse
+	67, // 73: schema.v1alpha1.BMCService.ListThermalZones:output_type -> schema.v1alpha1.ListThermalZonesResponse
+	68, // 74: schema.v1alpha1.BMCService.CreateUser:output_type -> schema.v1alpha1.CreateUserResponse
+	69, // 75: schema.v1alpha1.BMCService.GetUser:output_type -> schema.v1alpha1.GetUserResponse
+	70, // 76: schema.v1alpha1.BMCService.UpdateUser:output_type -> schema.v1alpha1.UpdateUserResponse
+	71, // 77: schema.v1alpha1.BMCService.DeleteUser:output_type -> schema.v1alpha1.DeleteUserResponse
+	72, // 78: schema.v1alpha1.BMCService.ListUsers:output_type -> schema.v1alpha1.ListUsersResponse
+	73, // 79: schema.v1alpha1.BMCService.ChangePassword:output_type -> schema.v1alpha1.ChangePasswordResponse
+	74, // 80: schema.v1alpha1.BMCService.ResetPassword:output_type -> schema.v1alpha1.ResetPasswordResponse
+	75, // 81: schema.v1alpha1.BMCService.AuthenticateUser:output_type -> schema.v1alpha1.AuthenticateUserResponse
+	76, // 82: schema.v1alpha1.BMCService.EnrollTotp:output_type -> schema.v1alpha1.EnrollTotpResponse
+	77, // 83: schema.v1alpha1.BMCService.ConfirmTotp:output_type -> schema.v1alpha1.ConfirmTotpResponse
+	78, // 84: schema.v1alpha1.BMCService.DisableTotp:output_type -> schema.v1alpha1.DisableTotpResponse
+	79, // 85: schema.v1alpha1.BMCService.RegenerateRecoveryCodes:output_type -> schema.v1alpha1.RegenerateRecoveryCodesResponse
+	51, // [51:86] is the sub-list for method output_type
+	16, // [16:51] is the sub-list for method input_type
 	16, // [16:16] is the sub-list for extension type_name
 	16, // [16:16] is the sub-list for extension extendee
 	0,  // [0:16] is the sub-list for field type_name
@@ -859,6 +890,7 @@ func file_schema_v1alpha1_system_proto_init() {
 	file_schema_v1alpha1_asset_proto_init()
 	file_schema_v1alpha1_chassis_proto_init()
 	file_schema_v1alpha1_contact_proto_init()
+	file_schema_v1alpha1_eventlog_proto_init()
 	file_schema_v1alpha1_host_proto_init()
 	file_schema_v1alpha1_managementcontroller_proto_init()
 	file_schema_v1alpha1_sensor_proto_init()
@@ -417,6 +417,8 @@ type BMCServiceClient interface {
 	ChangeManagementControllerState(ctx context.Context, in *ChangeManagementControllerStateRequest, opts ...grpc.CallOption) (*ChangeManagementControllerStateResponse, error)
 	ListSensors(ctx context.Context, in *ListSensorsRequest, opts ...grpc.CallOption) (*ListSensorsResponse, error)
 	GetSensor(ctx context.Context, in *GetSensorRequest, opts ...grpc.CallOption) (*GetSensorResponse, error)
+	ListSystemEventLogEntries(ctx context.Context, in *ListSystemEventLogEntriesRequest, opts ...grpc.CallOption) (*ListSystemEventLogEntriesResponse, error)
+	ClearSystemEventLog(ctx context.Context, in *ClearSystemEventLogRequest, opts ...grpc.CallOption) (*ClearSystemEventLogResponse, error)
 	GetThermalZone(ctx context.Context, in *GetThermalZoneRequest, opts ...grpc.CallOption) (*GetThermalZoneResponse, error)
 	SetThermalZone(ctx context.Context, in *SetThermalZoneRequest, opts ...grpc.CallOption) (*SetThermalZoneResponse, error)
 	ListThermalZones(ctx context.Context, in *ListThermalZonesRequest, opts ...grpc.CallOption) (*ListThermalZonesResponse, error)
@@ -428,6 +430,10 @@ type BMCServiceClient interface {
 	ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*ChangePasswordResponse, error)
 	ResetPassword(ctx context.Context, in *ResetPasswordRequest, opts ...grpc.CallOption) (*ResetPasswordResponse, error)
 	AuthenticateUser(ctx context.Context, in *AuthenticateUserRequest, opts ...grpc.CallOption) (*AuthenticateUserResponse, error)
+	EnrollTotp(ctx context.Context, in *EnrollTotpRequest, opts ...grpc.CallOption) (*EnrollTotpResponse, error)
+	ConfirmTotp(ctx context.Context, in *ConfirmTotpRequest, opts ...grpc.CallOption) (*ConfirmTotpResponse, error)
+	DisableTotp(ctx context.Context, in *DisableTotpRequest, opts ...grpc.CallOption) (*DisableTotpResponse, error)
+	RegenerateRecoveryCodes(ctx context.Context, in *RegenerateRecoveryCodesRequest, opts ...grpc.CallOption) (*RegenerateRecoveryCodesResponse, error)
 }
 
 type bMCServiceClient struct {
@@ -600,6 +606,24 @@ func (c *bMCServiceClient) GetSensor(ctx context.Context, in *GetSensorRequest,
 	return out, nil
 }
 
+func (c *bMCServiceClient) ListSystemEventLogEntries(ctx context.Context, in *ListSystemEventLogEntriesRequest, opts ...grpc.CallOption) (*ListSystemEventLogEntriesResponse, error) {
+	out := new(ListSystemEventLogEntriesResponse)
+	err := c.cc.Invoke(ctx, "/schema.v1alpha1.BMCService/ListSystemEventLogEntries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bMCServiceClient) ClearSystemEventLog(ctx context.Context, in *ClearSystemEventLogRequest, opts ...grpc.CallOption) (*ClearSystemEventLogResponse, error) {
+	out := new(ClearSystemEventLogResponse)
+	err := c.cc.Invoke(ctx, "/schema.v1alpha1.BMCService/ClearSystemEventLog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *bMCServiceClient) GetThermalZone(ctx context.Context, in *GetThermalZoneRequest, opts ...grpc.CallOption) (*GetThermalZoneResponse, error) {
 	out := new(GetThermalZoneResponse)
 	err := c.cc.Invoke(ctx, "/schema.v1alpha1.BMCService/GetThermalZone", in, out, opts...)
@@ -699,6 +723,42 @@ func (c *bMCServiceClient) AuthenticateUser(ctx context.Context, in *Authenticat
 	return out, nil
 }
 
+func (c *bMCServiceClient) EnrollTotp(ctx context.Context, in *EnrollTotpRequest, opts ...grpc.CallOption) (*EnrollTotpResponse, error) {
+	out := new(EnrollTotpResponse)
+	err := c.cc.Invoke(ctx, "/schema.v1alpha1.BMCService/EnrollTotp", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bMCServiceClient) ConfirmTotp(ctx context.Context, in *ConfirmTotpRequest, opts ...grpc.CallOption) (*ConfirmTotpResponse, error) {
+	out := new(ConfirmTotpResponse)
+	err := c.cc.Invoke(ctx, "/schema.v1alpha1.BMCService/ConfirmTotp", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bMCServiceClient) DisableTotp(ctx context.Context, in *DisableTotpRequest, opts ...grpc.CallOption) (*DisableTotpResponse, error) {
+	out := new(DisableTotpResponse)
+	err := c.cc.Invoke(ctx, "/schema.v1alpha1.BMCService/DisableTotp", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bMCServiceClient) RegenerateRecoveryCodes(ctx context.Context, in *RegenerateRecoveryCodesRequest, opts ...grpc.CallOption) (*RegenerateRecoveryCodesResponse, error) {
+	out := new(RegenerateRecoveryCodesResponse)
+	err := c.cc.Invoke(ctx, "/schema.v1alpha1.BMCService/RegenerateRecoveryCodes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BMCServiceServer is the server API for BMCService service.
 // All implementations must embed UnimplementedBMCServiceServer
 // for forward compatibility
@@ -721,6 +781,8 @@ type BMCServiceServer interface {
 	ChangeManagementControllerState(context.Context, *ChangeManagementControllerStateRequest) (*ChangeManagementControllerStateResponse, error)
 	ListSensors(context.Context, *ListSensorsRequest) (*ListSensorsResponse, error)
 	GetSensor(context.Context, *GetSensorRequest) (*GetSensorResponse, error)
+	ListSystemEventLogEntries(context.Context, *ListSystemEventLogEntriesRequest) (*ListSystemEventLogEntriesResponse, error)
+	ClearSystemEventLog(context.Context, *ClearSystemEventLogRequest) (*ClearSystemEventLogResponse, error)
 	GetThermalZone(context.Context, *GetThermalZoneRequest) (*GetThermalZoneResponse, error)
 	SetThermalZone(context.Context, *SetThermalZoneRequest) (*SetThermalZoneResponse, error)
 	ListThermalZones(context.Context, *ListThermalZonesRequest) (*ListThermalZonesResponse, error)
@@ -732,6 +794,10 @@ type BMCServiceServer interface {
 	ChangePassword(context.Context, *ChangePasswordRequest) (*ChangePasswordResponse, error)
 	ResetPassword(context.Context, *ResetPasswordRequest) (*ResetPasswordResponse, error)
 	AuthenticateUser(context.Context, *AuthenticateUserRequest) (*AuthenticateUserResponse, error)
+	EnrollTotp(context.Context, *EnrollTotpRequest) (*EnrollTotpResponse, error)
+	ConfirmTotp(context.Context, *ConfirmTotpRequest) (*ConfirmTotpResponse, error)
+	DisableTotp(context.Context, *DisableTotpRequest) (*DisableTotpResponse, error)
+	RegenerateRecoveryCodes(context.Context, *RegenerateRecoveryCodesRequest) (*RegenerateRecoveryCodesResponse, error)
 	mustEmbedUnimplementedBMCServiceServer()
 }
 
@@ -793,6 +859,12 @@ func (UnimplementedBMCServiceServer) ListSensors(context.Context, *ListSensorsRe
 func (UnimplementedBMCServiceServer) GetSensor(context.Context, *GetSensorRequest) (*GetSensorResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetSensor not implemented")
 }
+func (UnimplementedBMCServiceServer) ListSystemEventLogEntries(context.Context, *ListSystemEventLogEntriesRequest) (*ListSystemEventLogEntriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSystemEventLogEntries not implemented")
+}
+func (UnimplementedBMCServiceServer) ClearSystemEventLog(context.Context, *ClearSystemEventLogRequest) (*ClearSystemEventLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearSystemEventLog not implemented")
+}
 func (UnimplementedBMCServiceServer) GetThermalZone(context.Context, *GetThermalZoneRequest) (*GetThermalZoneResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetThermalZone not implemented")
 }
@@ -826,6 +898,18 @@ func (UnimplementedBMCServiceServer) ResetPassword(context.Context, *ResetPasswo
 func (UnimplementedBMCServiceServer) AuthenticateUser(context.Context, *AuthenticateUserRequest) (*AuthenticateUserResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AuthenticateUser not implemented")
 }
+func (UnimplementedBMCServiceServer) EnrollTotp(context.Context, *EnrollTotpRequest) (*EnrollTotpResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnrollTotp not implemented")
+}
+func (UnimplementedBMCServiceServer) ConfirmTotp(context.Context, *ConfirmTotpRequest) (*ConfirmTotpResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmTotp not implemented")
+}
+func (UnimplementedBMCServiceServer) DisableTotp(context.Context, *DisableTotpRequest) (*DisableTotpResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisableTotp not implemented")
+}
+func (UnimplementedBMCServiceServer) RegenerateRecoveryCodes(context.Context, *RegenerateRecoveryCodesRequest) (*RegenerateRecoveryCodesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegenerateRecoveryCodes not implemented")
+}
 func (UnimplementedBMCServiceServer) mustEmbedUnimplementedBMCServiceServer() {}
 
 // UnsafeBMCServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -1163,6 +1247,42 @@ func _BMCService_GetSensor_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BMCService_ListSystemEventLogEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSystemEventLogEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BMCServiceServer).ListSystemEventLogEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/schema.v1alpha1.BMCService/ListSystemEventLogEntries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BMCServiceServer).ListSystemEventLogEntries(ctx, req.(*ListSystemEventLogEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BMCService_ClearSystemEventLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearSystemEventLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BMCServiceServer).ClearSystemEventLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/schema.v1alpha1.BMCService/ClearSystemEventLog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BMCServiceServer).ClearSystemEventLog(ctx, req.(*ClearSystemEventLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BMCService_GetThermalZone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetThermalZoneRequest)
 	if err := dec(in); err != nil {
@@ -1361,6 +1481,78 @@ func _BMCService_AuthenticateUser_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BMCService_EnrollTotp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnrollTotpRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BMCServiceServer).EnrollTotp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/schema.v1alpha1.BMCService/EnrollTotp",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BMCServiceServer).EnrollTotp(ctx, req.(*EnrollTotpRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BMCService_ConfirmTotp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmTotpRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BMCServiceServer).ConfirmTotp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/schema.v1alpha1.BMCService/ConfirmTotp",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BMCServiceServer).ConfirmTotp(ctx, req.(*ConfirmTotpRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BMCService_DisableTotp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisableTotpRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BMCServiceServer).DisableTotp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/schema.v1alpha1.BMCService/DisableTotp",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BMCServiceServer).DisableTotp(ctx, req.(*DisableTotpRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BMCService_RegenerateRecoveryCodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegenerateRecoveryCodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BMCServiceServer).RegenerateRecoveryCodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/schema.v1alpha1.BMCService/RegenerateRecoveryCodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BMCServiceServer).RegenerateRecoveryCodes(ctx, req.(*RegenerateRecoveryCodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // BMCService_ServiceDesc is the grpc.ServiceDesc for BMCService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -1440,6 +1632,14 @@ var BMCService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetSensor",
 			Handler:    _BMCService_GetSensor_Handler,
 		},
+		{
+			MethodName: "ListSystemEventLogEntries",
+			Handler:    _BMCService_ListSystemEventLogEntries_Handler,
+		},
+		{
+			MethodName: "ClearSystemEventLog",
+			Handler:    _BMCService_ClearSystemEventLog_Handler,
+		},
 		{
 			MethodName: "GetThermalZone",
 			Handler:    _BMCService_GetThermalZone_Handler,
@@ -1484,6 +1684,22 @@ var BMCService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AuthenticateUser",
 			Handler:    _BMCService_AuthenticateUser_Handler,
 		},
+		{
+			MethodName: "EnrollTotp",
+			Handler:    _BMCService_EnrollTotp_Handler,
+		},
+		{
+			MethodName: "ConfirmTotp",
+			Handler:    _BMCService_ConfirmTotp_Handler,
+		},
+		{
+			MethodName: "DisableTotp",
+			Handler:    _BMCService_DisableTotp_Handler,
+		},
+		{
+			MethodName: "RegenerateRecoveryCodes",
+			Handler:    _BMCService_RegenerateRecoveryCodes_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "schema/v1alpha1/system.proto",
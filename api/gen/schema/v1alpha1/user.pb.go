@@ -38,20 +38,24 @@ const (
 	UserSource_USER_SOURCE_NATS         UserSource = 6
 	UserSource_USER_SOURCE_UNIX         UserSource = 7
 	UserSource_USER_SOURCE_EXTERNAL_API UserSource = 8
+	UserSource_USER_SOURCE_RADIUS       UserSource = 9
+	UserSource_USER_SOURCE_TACACS       UserSource = 10
 )
 
 // Enum value maps for UserSource.
 var (
 	UserSource_name = map[int32]string{
-		0: "USER_SOURCE_UNSPECIFIED",
-		1: "USER_SOURCE_LOCAL",
-		2: "USER_SOURCE_LDAP",
-		3: "USER_SOURCE_AD",
-		4: "USER_SOURCE_IPMI",
-		5: "USER_SOURCE_REDFISH",
-		6: "USER_SOURCE_NATS",
-		7: "USER_SOURCE_UNIX",
-		8: "USER_SOURCE_EXTERNAL_API",
+		0:  "USER_SOURCE_UNSPECIFIED",
+		1:  "USER_SOURCE_LOCAL",
+		2:  "USER_SOURCE_LDAP",
+		3:  "USER_SOURCE_AD",
+		4:  "USER_SOURCE_IPMI",
+		5:  "USER_SOURCE_REDFISH",
+		6:  "USER_SOURCE_NATS",
+		7:  "USER_SOURCE_UNIX",
+		8:  "USER_SOURCE_EXTERNAL_API",
+		9:  "USER_SOURCE_RADIUS",
+		10: "USER_SOURCE_TACACS",
 	}
 	UserSource_value = map[string]int32{
 		"USER_SOURCE_UNSPECIFIED":  0,
@@ -63,6 +67,8 @@ var (
 		"USER_SOURCE_NATS":         6,
 		"USER_SOURCE_UNIX":         7,
 		"USER_SOURCE_EXTERNAL_API": 8,
+		"USER_SOURCE_RADIUS":       9,
+		"USER_SOURCE_TACACS":       10,
 	}
 )
 
@@ -273,6 +279,73 @@ func (LockoutReason) EnumDescriptor() ([]byte, []int) {
 	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{3}
 }
 
+type AuthenticationFailure int32
+
+const (
+	AuthenticationFailure_AUTHENTICATION_FAILURE_UNSPECIFIED              AuthenticationFailure = 0
+	AuthenticationFailure_AUTHENTICATION_FAILURE_INVALID_CREDENTIALS      AuthenticationFailure = 1
+	AuthenticationFailure_AUTHENTICATION_FAILURE_ACCOUNT_LOCKED           AuthenticationFailure = 2
+	AuthenticationFailure_AUTHENTICATION_FAILURE_ACCOUNT_DISABLED         AuthenticationFailure = 3
+	AuthenticationFailure_AUTHENTICATION_FAILURE_PASSWORD_EXPIRED         AuthenticationFailure = 4
+	AuthenticationFailure_AUTHENTICATION_FAILURE_ACCOUNT_EXPIRED          AuthenticationFailure = 5
+	AuthenticationFailure_AUTHENTICATION_FAILURE_SECOND_FACTOR_REQUIRED   AuthenticationFailure = 6
+	AuthenticationFailure_AUTHENTICATION_FAILURE_INVALID_SECOND_FACTOR    AuthenticationFailure = 7
+	AuthenticationFailure_AUTHENTICATION_FAILURE_PASSWORD_CHANGE_REQUIRED AuthenticationFailure = 8
+)
+
+// Enum value maps for AuthenticationFailure.
+var (
+	AuthenticationFailure_name = map[int32]string{
+		0: "AUTHENTICATION_FAILURE_UNSPECIFIED",
+		1: "AUTHENTICATION_FAILURE_INVALID_CREDENTIALS",
+		2: "AUTHENTICATION_FAILURE_ACCOUNT_LOCKED",
+		3: "AUTHENTICATION_FAILURE_ACCOUNT_DISABLED",
+		4: "AUTHENTICATION_FAILURE_PASSWORD_EXPIRED",
+		5: "AUTHENTICATION_FAILURE_ACCOUNT_EXPIRED",
+		6: "AUTHENTICATION_FAILURE_SECOND_FACTOR_REQUIRED",
+		7: "AUTHENTICATION_FAILURE_INVALID_SECOND_FACTOR",
+		8: "AUTHENTICATION_FAILURE_PASSWORD_CHANGE_REQUIRED",
+	}
+	AuthenticationFailure_value = map[string]int32{
+		"AUTHENTICATION_FAILURE_UNSPECIFIED":              0,
+		"AUTHENTICATION_FAILURE_INVALID_CREDENTIALS":      1,
+		"AUTHENTICATION_FAILURE_ACCOUNT_LOCKED":           2,
+		"AUTHENTICATION_FAILURE_ACCOUNT_DISABLED":         3,
+		"AUTHENTICATION_FAILURE_PASSWORD_EXPIRED":         4,
+		"AUTHENTICATION_FAILURE_ACCOUNT_EXPIRED":          5,
+		"AUTHENTICATION_FAILURE_SECOND_FACTOR_REQUIRED":   6,
+		"AUTHENTICATION_FAILURE_INVALID_SECOND_FACTOR":    7,
+		"AUTHENTICATION_FAILURE_PASSWORD_CHANGE_REQUIRED": 8,
+	}
+)
+
+func (x AuthenticationFailure) Enum() *AuthenticationFailure {
+	p := new(AuthenticationFailure)
+	*p = x
+	return p
+}
+
+func (x AuthenticationFailure) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AuthenticationFailure) Descriptor() protoreflect.EnumDescriptor {
+	return file_schema_v1alpha1_user_proto_enumTypes[4].Descriptor()
+}
+
+func (AuthenticationFailure) Type() protoreflect.EnumType {
+	return &file_schema_v1alpha1_user_proto_enumTypes[4]
+}
+
+func (x AuthenticationFailure) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AuthenticationFailure.Descriptor instead.
+func (AuthenticationFailure) EnumDescriptor() ([]byte, []int) {
+	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{4}
+}
+
 type UserLinkAction int32
 
 const (
@@ -309,11 +382,11 @@ func (x UserLinkAction) String() string {
 }
 
 func (UserLinkAction) Descriptor() protoreflect.EnumDescriptor {
-	return file_schema_v1alpha1_user_proto_enumTypes[4].Descriptor()
+	return file_schema_v1alpha1_user_proto_enumTypes[5].Descriptor()
 }
 
 func (UserLinkAction) Type() protoreflect.EnumType {
-	return &file_schema_v1alpha1_user_proto_enumTypes[4]
+	return &file_schema_v1alpha1_user_proto_enumTypes[5]
 }
 
 func (x UserLinkAction) Number() protoreflect.EnumNumber {
@@ -322,7 +395,7 @@ func (x UserLinkAction) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use UserLinkAction.Descriptor instead.
 func (UserLinkAction) EnumDescriptor() ([]byte, []int) {
-	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{4}
+	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{5}
 }
 
 type User struct {
@@ -343,6 +416,7 @@ type User struct {
 	RedfishInfo       *RedfishAccountInfo    `protobuf:"bytes,14,opt,name=redfish_info,json=redfishInfo,proto3,oneof" json:"redfish_info,omitempty"`
 	NatsInfo          *NatsAccountInfo       `protobuf:"bytes,15,opt,name=nats_info,json=natsInfo,proto3,oneof" json:"nats_info,omitempty"`
 	CustomAttributes  map[string]string      `protobuf:"bytes,16,rep,name=custom_attributes,json=customAttributes,proto3" json:"custom_attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	AccountExpiresAt  *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=account_expires_at,json=accountExpiresAt,proto3,oneof" json:"account_expires_at,omitempty"`
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
@@ -489,15 +563,25 @@ func (x *User) GetCustomAttributes() map[string]string {
 	return nil
 }
 
+func (x *User) GetAccountExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AccountExpiresAt
+	}
+	return nil
+}
+
 type AuthenticationData struct {
-	state               protoimpl.MessageState `protogen:"open.v1"`
-	PasswordHash        string                 `protobuf:"bytes,1,opt,name=password_hash,json=passwordHash,proto3" json:"password_hash,omitempty"`
-	PasswordSalt        *string                `protobuf:"bytes,2,opt,name=password_salt,json=passwordSalt,proto3,oneof" json:"password_salt,omitempty"`
-	HashAlgorithm       PasswordHashAlgorithm  `protobuf:"varint,3,opt,name=hash_algorithm,json=hashAlgorithm,proto3,enum=schema.v1alpha1.PasswordHashAlgorithm" json:"hash_algorithm,omitempty"`
-	Iterations          int32                  `protobuf:"varint,4,opt,name=iterations,proto3" json:"iterations,omitempty"`
-	PasswordLastChanged *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=password_last_changed,json=passwordLastChanged,proto3,oneof" json:"password_last_changed,omitempty"`
-	PasswordExpiresAt   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=password_expires_at,json=passwordExpiresAt,proto3,oneof" json:"password_expires_at,omitempty"`
-	LockoutInfo         *AccountLockoutInfo    `protobuf:"bytes,7,opt,name=lockout_info,json=lockoutInfo,proto3,oneof" json:"lockout_info,omitempty"`
+	state               protoimpl.MessageState  `protogen:"open.v1"`
+	PasswordHash        string                  `protobuf:"bytes,1,opt,name=password_hash,json=passwordHash,proto3" json:"password_hash,omitempty"`
+	PasswordSalt        *string                 `protobuf:"bytes,2,opt,name=password_salt,json=passwordSalt,proto3,oneof" json:"password_salt,omitempty"`
+	HashAlgorithm       PasswordHashAlgorithm   `protobuf:"varint,3,opt,name=hash_algorithm,json=hashAlgorithm,proto3,enum=schema.v1alpha1.PasswordHashAlgorithm" json:"hash_algorithm,omitempty"`
+	Iterations          int32                   `protobuf:"varint,4,opt,name=iterations,proto3" json:"iterations,omitempty"`
+	PasswordLastChanged *timestamppb.Timestamp  `protobuf:"bytes,5,opt,name=password_last_changed,json=passwordLastChanged,proto3,oneof" json:"password_last_changed,omitempty"`
+	PasswordExpiresAt   *timestamppb.Timestamp  `protobuf:"bytes,6,opt,name=password_expires_at,json=passwordExpiresAt,proto3,oneof" json:"password_expires_at,omitempty"`
+	LockoutInfo         *AccountLockoutInfo     `protobuf:"bytes,7,opt,name=lockout_info,json=lockoutInfo,proto3,oneof" json:"lockout_info,omitempty"`
+	PasswordHistory     []*PasswordHistoryEntry `protobuf:"bytes,8,rep,name=password_history,json=passwordHistory,proto3" json:"password_history,omitempty"`
+	Totp                *TotpData               `protobuf:"bytes,9,opt,name=totp,proto3,oneof" json:"totp,omitempty"`
+	TotpRequired        *bool                   `protobuf:"varint,10,opt,name=totp_required,json=totpRequired,proto3,oneof" json:"totp_required,omitempty"`
 	unknownFields       protoimpl.UnknownFields
 	sizeCache           protoimpl.SizeCache
 }
@@ -581,6 +665,179 @@ func (x *AuthenticationData) GetLockoutInfo() *AccountLockoutInfo {
 	return nil
 }
 
+func (x *AuthenticationData) GetPasswordHistory() []*PasswordHistoryEntry {
+	if x != nil {
+		return x.PasswordHistory
+	}
+	return nil
+}
+
+func (x *AuthenticationData) GetTotp() *TotpData {
+	if x != nil {
+		return x.Totp
+	}
+	return nil
+}
+
+func (x *AuthenticationData) GetTotpRequired() bool {
+	if x != nil && x.TotpRequired != nil {
+		return *x.TotpRequired
+	}
+	return false
+}
+
+type PasswordHistoryEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PasswordHash  string                 `protobuf:"bytes,1,opt,name=password_hash,json=passwordHash,proto3" json:"password_hash,omitempty"`
+	PasswordSalt  *string                `protobuf:"bytes,2,opt,name=password_salt,json=passwordSalt,proto3,oneof" json:"password_salt,omitempty"`
+	HashAlgorithm PasswordHashAlgorithm  `protobuf:"varint,3,opt,name=hash_algorithm,json=hashAlgorithm,proto3,enum=schema.v1alpha1.PasswordHashAlgorithm" json:"hash_algorithm,omitempty"`
+	Iterations    int32                  `protobuf:"varint,4,opt,name=iterations,proto3" json:"iterations,omitempty"`
+	ChangedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=changed_at,json=changedAt,proto3,oneof" json:"changed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PasswordHistoryEntry) Reset() {
+	*x = PasswordHistoryEntry{}
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PasswordHistoryEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PasswordHistoryEntry) ProtoMessage() {}
+
+func (x *PasswordHistoryEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PasswordHistoryEntry.ProtoReflect.Descriptor instead.
+func (*PasswordHistoryEntry) Descriptor() ([]byte, []int) {
+	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PasswordHistoryEntry) GetPasswordHash() string {
+	if x != nil {
+		return x.PasswordHash
+	}
+	return ""
+}
+
+func (x *PasswordHistoryEntry) GetPasswordSalt() string {
+	if x != nil && x.PasswordSalt != nil {
+		return *x.PasswordSalt
+	}
+	return ""
+}
+
+func (x *PasswordHistoryEntry) GetHashAlgorithm() PasswordHashAlgorithm {
+	if x != nil {
+		return x.HashAlgorithm
+	}
+	return PasswordHashAlgorithm_PASSWORD_HASH_ALGORITHM_UNSPECIFIED
+}
+
+func (x *PasswordHistoryEntry) GetIterations() int32 {
+	if x != nil {
+		return x.Iterations
+	}
+	return 0
+}
+
+func (x *PasswordHistoryEntry) GetChangedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return nil
+}
+
+type TotpData struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Enabled         bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	EncryptedSecret []byte                 `protobuf:"bytes,2,opt,name=encrypted_secret,json=encryptedSecret,proto3" json:"encrypted_secret,omitempty"`
+	EnrolledAt      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=enrolled_at,json=enrolledAt,proto3,oneof" json:"enrolled_at,omitempty"`
+	RecoveryCodes   []string               `protobuf:"bytes,4,rep,name=recovery_codes,json=recoveryCodes,proto3" json:"recovery_codes,omitempty"`
+	LastUsedStep    int64                  `protobuf:"varint,5,opt,name=last_used_step,json=lastUsedStep,proto3" json:"last_used_step,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TotpData) Reset() {
+	*x = TotpData{}
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TotpData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TotpData) ProtoMessage() {}
+
+func (x *TotpData) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TotpData.ProtoReflect.Descriptor instead.
+func (*TotpData) Descriptor() ([]byte, []int) {
+	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TotpData) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *TotpData) GetEncryptedSecret() []byte {
+	if x != nil {
+		return x.EncryptedSecret
+	}
+	return nil
+}
+
+func (x *TotpData) GetEnrolledAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EnrolledAt
+	}
+	return nil
+}
+
+func (x *TotpData) GetRecoveryCodes() []string {
+	if x != nil {
+		return x.RecoveryCodes
+	}
+	return nil
+}
+
+func (x *TotpData) GetLastUsedStep() int64 {
+	if x != nil {
+		return x.LastUsedStep
+	}
+	return 0
+}
+
 type AccountLockoutInfo struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
 	Locked            bool                   `protobuf:"varint,1,opt,name=locked,proto3" json:"locked,omitempty"`
@@ -595,7 +852,7 @@ type AccountLockoutInfo struct {
 
 func (x *AccountLockoutInfo) Reset() {
 	*x = AccountLockoutInfo{}
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[2]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -607,7 +864,7 @@ func (x *AccountLockoutInfo) String() string {
 func (*AccountLockoutInfo) ProtoMessage() {}
 
 func (x *AccountLockoutInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[2]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -620,7 +877,7 @@ func (x *AccountLockoutInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AccountLockoutInfo.ProtoReflect.Descriptor instead.
 func (*AccountLockoutInfo) Descriptor() ([]byte, []int) {
-	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{2}
+	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *AccountLockoutInfo) GetLocked() bool {
@@ -679,7 +936,7 @@ type UnixUserInfo struct {
 
 func (x *UnixUserInfo) Reset() {
 	*x = UnixUserInfo{}
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[3]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -691,7 +948,7 @@ func (x *UnixUserInfo) String() string {
 func (*UnixUserInfo) ProtoMessage() {}
 
 func (x *UnixUserInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[3]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -704,7 +961,7 @@ func (x *UnixUserInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UnixUserInfo.ProtoReflect.Descriptor instead.
 func (*UnixUserInfo) Descriptor() ([]byte, []int) {
-	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{3}
+	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *UnixUserInfo) GetUid() int32 {
@@ -766,7 +1023,7 @@ type LdapUserInfo struct {
 
 func (x *LdapUserInfo) Reset() {
 	*x = LdapUserInfo{}
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[4]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -778,7 +1035,7 @@ func (x *LdapUserInfo) String() string {
 func (*LdapUserInfo) ProtoMessage() {}
 
 func (x *LdapUserInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[4]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -791,7 +1048,7 @@ func (x *LdapUserInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LdapUserInfo.ProtoReflect.Descriptor instead.
 func (*LdapUserInfo) Descriptor() ([]byte, []int) {
-	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{4}
+	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *LdapUserInfo) GetLdapDn() string {
@@ -870,7 +1127,7 @@ type RedfishAccountInfo struct {
 
 func (x *RedfishAccountInfo) Reset() {
 	*x = RedfishAccountInfo{}
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[5]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -882,7 +1139,7 @@ func (x *RedfishAccountInfo) String() string {
 func (*RedfishAccountInfo) ProtoMessage() {}
 
 func (x *RedfishAccountInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[5]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -895,7 +1152,7 @@ func (x *RedfishAccountInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RedfishAccountInfo.ProtoReflect.Descriptor instead.
 func (*RedfishAccountInfo) Descriptor() ([]byte, []int) {
-	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{5}
+	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *RedfishAccountInfo) GetAccountId() string {
@@ -944,7 +1201,7 @@ type RedfishLockoutPolicy struct {
 
 func (x *RedfishLockoutPolicy) Reset() {
 	*x = RedfishLockoutPolicy{}
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[6]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -956,7 +1213,7 @@ func (x *RedfishLockoutPolicy) String() string {
 func (*RedfishLockoutPolicy) ProtoMessage() {}
 
 func (x *RedfishLockoutPolicy) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[6]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -969,7 +1226,7 @@ func (x *RedfishLockoutPolicy) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RedfishLockoutPolicy.ProtoReflect.Descriptor instead.
 func (*RedfishLockoutPolicy) Descriptor() ([]byte, []int) {
-	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{6}
+	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *RedfishLockoutPolicy) GetThreshold() int32 {
@@ -1008,7 +1265,7 @@ type NatsAccountInfo struct {
 
 func (x *NatsAccountInfo) Reset() {
 	*x = NatsAccountInfo{}
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[7]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1020,7 +1277,7 @@ func (x *NatsAccountInfo) String() string {
 func (*NatsAccountInfo) ProtoMessage() {}
 
 func (x *NatsAccountInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[7]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1033,7 +1290,7 @@ func (x *NatsAccountInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NatsAccountInfo.ProtoReflect.Descriptor instead.
 func (*NatsAccountInfo) Descriptor() ([]byte, []int) {
-	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{7}
+	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *NatsAccountInfo) GetAccount() string {
@@ -1097,7 +1354,7 @@ type NatsPermissions struct {
 
 func (x *NatsPermissions) Reset() {
 	*x = NatsPermissions{}
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[8]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1109,7 +1366,7 @@ func (x *NatsPermissions) String() string {
 func (*NatsPermissions) ProtoMessage() {}
 
 func (x *NatsPermissions) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[8]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1122,7 +1379,7 @@ func (x *NatsPermissions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NatsPermissions.ProtoReflect.Descriptor instead.
 func (*NatsPermissions) Descriptor() ([]byte, []int) {
-	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{8}
+	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *NatsPermissions) GetPublish() []string {
@@ -1166,7 +1423,7 @@ type NatsLimits struct {
 
 func (x *NatsLimits) Reset() {
 	*x = NatsLimits{}
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[9]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1178,7 +1435,7 @@ func (x *NatsLimits) String() string {
 func (*NatsLimits) ProtoMessage() {}
 
 func (x *NatsLimits) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[9]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1191,7 +1448,7 @@ func (x *NatsLimits) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NatsLimits.ProtoReflect.Descriptor instead.
 func (*NatsLimits) Descriptor() ([]byte, []int) {
-	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{9}
+	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *NatsLimits) GetData() int64 {
@@ -1245,7 +1502,7 @@ type UserLinkingOptions struct {
 
 func (x *UserLinkingOptions) Reset() {
 	*x = UserLinkingOptions{}
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[10]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1257,7 +1514,7 @@ func (x *UserLinkingOptions) String() string {
 func (*UserLinkingOptions) ProtoMessage() {}
 
 func (x *UserLinkingOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[10]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1270,7 +1527,7 @@ func (x *UserLinkingOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserLinkingOptions.ProtoReflect.Descriptor instead.
 func (*UserLinkingOptions) Descriptor() ([]byte, []int) {
-	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{10}
+	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *UserLinkingOptions) GetUnixAction() UserLinkAction {
@@ -1341,7 +1598,7 @@ type CreateUserRequest struct {
 
 func (x *CreateUserRequest) Reset() {
 	*x = CreateUserRequest{}
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[11]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1353,7 +1610,7 @@ func (x *CreateUserRequest) String() string {
 func (*CreateUserRequest) ProtoMessage() {}
 
 func (x *CreateUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[11]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1366,7 +1623,7 @@ func (x *CreateUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateUserRequest.ProtoReflect.Descriptor instead.
 func (*CreateUserRequest) Descriptor() ([]byte, []int) {
-	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{11}
+	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *CreateUserRequest) GetUser() *User {
@@ -1408,7 +1665,7 @@ type CreateUserResponse struct {
 
 func (x *CreateUserResponse) Reset() {
 	*x = CreateUserResponse{}
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[12]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1420,7 +1677,7 @@ func (x *CreateUserResponse) String() string {
 func (*CreateUserResponse) ProtoMessage() {}
 
 func (x *CreateUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[12]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1433,7 +1690,7 @@ func (x *CreateUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateUserResponse.ProtoReflect.Descriptor instead.
 func (*CreateUserResponse) Descriptor() ([]byte, []int) {
-	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{12}
+	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *CreateUserResponse) GetUser() *User {
@@ -1472,7 +1729,7 @@ type GetUserRequest struct {
 
 func (x *GetUserRequest) Reset() {
 	*x = GetUserRequest{}
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[13]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1484,7 +1741,7 @@ func (x *GetUserRequest) String() string {
 func (*GetUserRequest) ProtoMessage() {}
 
 func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[13]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1497,7 +1754,7 @@ func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
 func (*GetUserRequest) Descriptor() ([]byte, []int) {
-	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{13}
+	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *GetUserRequest) GetIdentifier() isGetUserRequest_Identifier {
@@ -1572,7 +1829,7 @@ type GetUserResponse struct {
 
 func (x *GetUserResponse) Reset() {
 	*x = GetUserResponse{}
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[14]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1584,7 +1841,7 @@ func (x *GetUserResponse) String() string {
 func (*GetUserResponse) ProtoMessage() {}
 
 func (x *GetUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[14]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1597,7 +1854,7 @@ func (x *GetUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserResponse.ProtoReflect.Descriptor instead.
 func (*GetUserResponse) Descriptor() ([]byte, []int) {
-	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{14}
+	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *GetUserResponse) GetUser() *User {
@@ -1618,7 +1875,7 @@ type UpdateUserRequest struct {
 
 func (x *UpdateUserRequest) Reset() {
 	*x = UpdateUserRequest{}
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[15]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1630,7 +1887,7 @@ func (x *UpdateUserRequest) String() string {
 func (*UpdateUserRequest) ProtoMessage() {}
 
 func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[15]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1643,7 +1900,7 @@ func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateUserRequest.ProtoReflect.Descriptor instead.
 func (*UpdateUserRequest) Descriptor() ([]byte, []int) {
-	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{15}
+	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *UpdateUserRequest) GetUser() *User {
@@ -1677,7 +1934,7 @@ type UpdateUserResponse struct {
 
 func (x *UpdateUserResponse) Reset() {
 	*x = UpdateUserResponse{}
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[16]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1689,7 +1946,7 @@ func (x *UpdateUserResponse) String() string {
 func (*UpdateUserResponse) ProtoMessage() {}
 
 func (x *UpdateUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[16]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1702,7 +1959,7 @@ func (x *UpdateUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateUserResponse.ProtoReflect.Descriptor instead.
 func (*UpdateUserResponse) Descriptor() ([]byte, []int) {
-	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{16}
+	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *UpdateUserResponse) GetUser() *User {
@@ -1730,7 +1987,7 @@ type DeleteUserRequest struct {
 
 func (x *DeleteUserRequest) Reset() {
 	*x = DeleteUserRequest{}
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[17]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1742,7 +1999,7 @@ func (x *DeleteUserRequest) String() string {
 func (*DeleteUserRequest) ProtoMessage() {}
 
 func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[17]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1755,7 +2012,7 @@ func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteUserRequest.ProtoReflect.Descriptor instead.
 func (*DeleteUserRequest) Descriptor() ([]byte, []int) {
-	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{17}
+	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *DeleteUserRequest) GetId() string {
@@ -1790,7 +2047,7 @@ type DeleteUserResponse struct {
 
 func (x *DeleteUserResponse) Reset() {
 	*x = DeleteUserResponse{}
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[18]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1802,7 +2059,7 @@ func (x *DeleteUserResponse) String() string {
 func (*DeleteUserResponse) ProtoMessage() {}
 
 func (x *DeleteUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[18]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1815,7 +2072,7 @@ func (x *DeleteUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteUserResponse.ProtoReflect.Descriptor instead.
 func (*DeleteUserResponse) Descriptor() ([]byte, []int) {
-	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{18}
+	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *DeleteUserResponse) GetSuccess() bool {
@@ -1853,7 +2110,7 @@ type ListUsersRequest struct {
 
 func (x *ListUsersRequest) Reset() {
 	*x = ListUsersRequest{}
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[19]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1865,7 +2122,7 @@ func (x *ListUsersRequest) String() string {
 func (*ListUsersRequest) ProtoMessage() {}
 
 func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[19]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1878,7 +2135,7 @@ func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
 func (*ListUsersRequest) Descriptor() ([]byte, []int) {
-	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{19}
+	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *ListUsersRequest) GetSource() UserSource {
@@ -1933,7 +2190,7 @@ type ListUsersResponse struct {
 
 func (x *ListUsersResponse) Reset() {
 	*x = ListUsersResponse{}
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[20]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1945,7 +2202,7 @@ func (x *ListUsersResponse) String() string {
 func (*ListUsersResponse) ProtoMessage() {}
 
 func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[20]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1958,7 +2215,7 @@ func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
 func (*ListUsersResponse) Descriptor() ([]byte, []int) {
-	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{20}
+	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *ListUsersResponse) GetUsers() []*User {
@@ -1986,7 +2243,7 @@ type ChangePasswordRequest struct {
 
 func (x *ChangePasswordRequest) Reset() {
 	*x = ChangePasswordRequest{}
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[21]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1998,7 +2255,7 @@ func (x *ChangePasswordRequest) String() string {
 func (*ChangePasswordRequest) ProtoMessage() {}
 
 func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[21]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2011,7 +2268,7 @@ func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ChangePasswordRequest.ProtoReflect.Descriptor instead.
 func (*ChangePasswordRequest) Descriptor() ([]byte, []int) {
-	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{21}
+	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *ChangePasswordRequest) GetId() string {
@@ -2045,7 +2302,7 @@ type ChangePasswordResponse struct {
 
 func (x *ChangePasswordResponse) Reset() {
 	*x = ChangePasswordResponse{}
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[22]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2057,7 +2314,7 @@ func (x *ChangePasswordResponse) String() string {
 func (*ChangePasswordResponse) ProtoMessage() {}
 
 func (x *ChangePasswordResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[22]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2070,7 +2327,7 @@ func (x *ChangePasswordResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ChangePasswordResponse.ProtoReflect.Descriptor instead.
 func (*ChangePasswordResponse) Descriptor() ([]byte, []int) {
-	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{22}
+	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *ChangePasswordResponse) GetSuccess() bool {
@@ -2099,7 +2356,7 @@ type ResetPasswordRequest struct {
 
 func (x *ResetPasswordRequest) Reset() {
 	*x = ResetPasswordRequest{}
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[23]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2111,7 +2368,7 @@ func (x *ResetPasswordRequest) String() string {
 func (*ResetPasswordRequest) ProtoMessage() {}
 
 func (x *ResetPasswordRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[23]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2124,7 +2381,7 @@ func (x *ResetPasswordRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResetPasswordRequest.ProtoReflect.Descriptor instead.
 func (*ResetPasswordRequest) Descriptor() ([]byte, []int) {
-	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{23}
+	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *ResetPasswordRequest) GetId() string {
@@ -2166,7 +2423,7 @@ type ResetPasswordResponse struct {
 
 func (x *ResetPasswordResponse) Reset() {
 	*x = ResetPasswordResponse{}
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[24]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2178,7 +2435,7 @@ func (x *ResetPasswordResponse) String() string {
 func (*ResetPasswordResponse) ProtoMessage() {}
 
 func (x *ResetPasswordResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[24]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2191,7 +2448,7 @@ func (x *ResetPasswordResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResetPasswordResponse.ProtoReflect.Descriptor instead.
 func (*ResetPasswordResponse) Descriptor() ([]byte, []int) {
-	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{24}
+	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *ResetPasswordResponse) GetNewPassword() string {
@@ -2216,18 +2473,21 @@ func (x *ResetPasswordResponse) GetFailureReason() string {
 }
 
 type AuthenticateUserRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
-	SourceIp      *string                `protobuf:"bytes,3,opt,name=source_ip,json=sourceIp,proto3,oneof" json:"source_ip,omitempty"`
-	UserAgent     *string                `protobuf:"bytes,4,opt,name=user_agent,json=userAgent,proto3,oneof" json:"user_agent,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	Username                string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Password                string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	SourceIp                *string                `protobuf:"bytes,3,opt,name=source_ip,json=sourceIp,proto3,oneof" json:"source_ip,omitempty"`
+	UserAgent               *string                `protobuf:"bytes,4,opt,name=user_agent,json=userAgent,proto3,oneof" json:"user_agent,omitempty"`
+	SecondFactor            *string                `protobuf:"bytes,5,opt,name=second_factor,json=secondFactor,proto3,oneof" json:"second_factor,omitempty"`
+	SecondFactorSupported   bool                   `protobuf:"varint,6,opt,name=second_factor_supported,json=secondFactorSupported,proto3" json:"second_factor_supported,omitempty"`
+	PasswordChangeSupported bool                   `protobuf:"varint,7,opt,name=password_change_supported,json=passwordChangeSupported,proto3" json:"password_change_supported,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
 }
 
 func (x *AuthenticateUserRequest) Reset() {
 	*x = AuthenticateUserRequest{}
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[25]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2239,7 +2499,7 @@ func (x *AuthenticateUserRequest) String() string {
 func (*AuthenticateUserRequest) ProtoMessage() {}
 
 func (x *AuthenticateUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[25]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2252,7 +2512,7 @@ func (x *AuthenticateUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthenticateUserRequest.ProtoReflect.Descriptor instead.
 func (*AuthenticateUserRequest) Descriptor() ([]byte, []int) {
-	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{25}
+	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *AuthenticateUserRequest) GetUsername() string {
@@ -2283,20 +2543,44 @@ func (x *AuthenticateUserRequest) GetUserAgent() string {
 	return ""
 }
 
+func (x *AuthenticateUserRequest) GetSecondFactor() string {
+	if x != nil && x.SecondFactor != nil {
+		return *x.SecondFactor
+	}
+	return ""
+}
+
+func (x *AuthenticateUserRequest) GetSecondFactorSupported() bool {
+	if x != nil {
+		return x.SecondFactorSupported
+	}
+	return false
+}
+
+func (x *AuthenticateUserRequest) GetPasswordChangeSupported() bool {
+	if x != nil {
+		return x.PasswordChangeSupported
+	}
+	return false
+}
+
 type AuthenticateUserResponse struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Success        bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	UserId         *string                `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"`
-	Token          *string                `protobuf:"bytes,3,opt,name=token,proto3,oneof" json:"token,omitempty"`
-	FailureReason  *string                `protobuf:"bytes,4,opt,name=failure_reason,json=failureReason,proto3,oneof" json:"failure_reason,omitempty"`
-	TokenExpiresAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=token_expires_at,json=tokenExpiresAt,proto3,oneof" json:"token_expires_at,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	state                          protoimpl.MessageState `protogen:"open.v1"`
+	Success                        bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	UserId                         *string                `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"`
+	Token                          *string                `protobuf:"bytes,3,opt,name=token,proto3,oneof" json:"token,omitempty"`
+	FailureReason                  *string                `protobuf:"bytes,4,opt,name=failure_reason,json=failureReason,proto3,oneof" json:"failure_reason,omitempty"`
+	TokenExpiresAt                 *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=token_expires_at,json=tokenExpiresAt,proto3,oneof" json:"token_expires_at,omitempty"`
+	Failure                        *AuthenticationFailure `protobuf:"varint,6,opt,name=failure,proto3,enum=schema.v1alpha1.AuthenticationFailure,oneof" json:"failure,omitempty"`
+	SecondFactorEnrollmentRequired bool                   `protobuf:"varint,7,opt,name=second_factor_enrollment_required,json=secondFactorEnrollmentRequired,proto3" json:"second_factor_enrollment_required,omitempty"`
+	PasswordChangeRequired         bool                   `protobuf:"varint,8,opt,name=password_change_required,json=passwordChangeRequired,proto3" json:"password_change_required,omitempty"`
+	unknownFields                  protoimpl.UnknownFields
+	sizeCache                      protoimpl.SizeCache
 }
 
 func (x *AuthenticateUserResponse) Reset() {
 	*x = AuthenticateUserResponse{}
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[26]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2308,7 +2592,7 @@ func (x *AuthenticateUserResponse) String() string {
 func (*AuthenticateUserResponse) ProtoMessage() {}
 
 func (x *AuthenticateUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_v1alpha1_user_proto_msgTypes[26]
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2321,7 +2605,7 @@ func (x *AuthenticateUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthenticateUserResponse.ProtoReflect.Descriptor instead.
 func (*AuthenticateUserResponse) Descriptor() ([]byte, []int) {
-	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{26}
+	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *AuthenticateUserResponse) GetSuccess() bool {
@@ -2359,11 +2643,536 @@ func (x *AuthenticateUserResponse) GetTokenExpiresAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *AuthenticateUserResponse) GetFailure() AuthenticationFailure {
+	if x != nil && x.Failure != nil {
+		return *x.Failure
+	}
+	return AuthenticationFailure_AUTHENTICATION_FAILURE_UNSPECIFIED
+}
+
+func (x *AuthenticateUserResponse) GetSecondFactorEnrollmentRequired() bool {
+	if x != nil {
+		return x.SecondFactorEnrollmentRequired
+	}
+	return false
+}
+
+func (x *AuthenticateUserResponse) GetPasswordChangeRequired() bool {
+	if x != nil {
+		return x.PasswordChangeRequired
+	}
+	return false
+}
+
+type CheckLoginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	SourceIp      *string                `protobuf:"bytes,2,opt,name=source_ip,json=sourceIp,proto3,oneof" json:"source_ip,omitempty"`
+	ProofFailed   bool                   `protobuf:"varint,3,opt,name=proof_failed,json=proofFailed,proto3" json:"proof_failed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckLoginRequest) Reset() {
+	*x = CheckLoginRequest{}
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckLoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckLoginRequest) ProtoMessage() {}
+
+func (x *CheckLoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckLoginRequest.ProtoReflect.Descriptor instead.
+func (*CheckLoginRequest) Descriptor() ([]byte, []int) {
+	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CheckLoginRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *CheckLoginRequest) GetSourceIp() string {
+	if x != nil && x.SourceIp != nil {
+		return *x.SourceIp
+	}
+	return ""
+}
+
+func (x *CheckLoginRequest) GetProofFailed() bool {
+	if x != nil {
+		return x.ProofFailed
+	}
+	return false
+}
+
+type CheckLoginResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Allowed       bool                   `protobuf:"varint,1,opt,name=allowed,proto3" json:"allowed,omitempty"`
+	FailureReason *string                `protobuf:"bytes,2,opt,name=failure_reason,json=failureReason,proto3,oneof" json:"failure_reason,omitempty"`
+	Failure       *AuthenticationFailure `protobuf:"varint,3,opt,name=failure,proto3,enum=schema.v1alpha1.AuthenticationFailure,oneof" json:"failure,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckLoginResponse) Reset() {
+	*x = CheckLoginResponse{}
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckLoginResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckLoginResponse) ProtoMessage() {}
+
+func (x *CheckLoginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckLoginResponse.ProtoReflect.Descriptor instead.
+func (*CheckLoginResponse) Descriptor() ([]byte, []int) {
+	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CheckLoginResponse) GetAllowed() bool {
+	if x != nil {
+		return x.Allowed
+	}
+	return false
+}
+
+func (x *CheckLoginResponse) GetFailureReason() string {
+	if x != nil && x.FailureReason != nil {
+		return *x.FailureReason
+	}
+	return ""
+}
+
+func (x *CheckLoginResponse) GetFailure() AuthenticationFailure {
+	if x != nil && x.Failure != nil {
+		return *x.Failure
+	}
+	return AuthenticationFailure_AUTHENTICATION_FAILURE_UNSPECIFIED
+}
+
+type EnrollTotpRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollTotpRequest) Reset() {
+	*x = EnrollTotpRequest{}
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollTotpRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollTotpRequest) ProtoMessage() {}
+
+func (x *EnrollTotpRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollTotpRequest.ProtoReflect.Descriptor instead.
+func (*EnrollTotpRequest) Descriptor() ([]byte, []int) {
+	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *EnrollTotpRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type EnrollTotpResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Secret        string                 `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	Uri           string                 `protobuf:"bytes,2,opt,name=uri,proto3" json:"uri,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollTotpResponse) Reset() {
+	*x = EnrollTotpResponse{}
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollTotpResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollTotpResponse) ProtoMessage() {}
+
+func (x *EnrollTotpResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollTotpResponse.ProtoReflect.Descriptor instead.
+func (*EnrollTotpResponse) Descriptor() ([]byte, []int) {
+	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *EnrollTotpResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *EnrollTotpResponse) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+type ConfirmTotpRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmTotpRequest) Reset() {
+	*x = ConfirmTotpRequest{}
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmTotpRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmTotpRequest) ProtoMessage() {}
+
+func (x *ConfirmTotpRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmTotpRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmTotpRequest) Descriptor() ([]byte, []int) {
+	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ConfirmTotpRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ConfirmTotpRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type ConfirmTotpResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	FailureReason *string                `protobuf:"bytes,2,opt,name=failure_reason,json=failureReason,proto3,oneof" json:"failure_reason,omitempty"`
+	RecoveryCodes []string               `protobuf:"bytes,3,rep,name=recovery_codes,json=recoveryCodes,proto3" json:"recovery_codes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmTotpResponse) Reset() {
+	*x = ConfirmTotpResponse{}
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmTotpResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmTotpResponse) ProtoMessage() {}
+
+func (x *ConfirmTotpResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmTotpResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmTotpResponse) Descriptor() ([]byte, []int) {
+	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ConfirmTotpResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ConfirmTotpResponse) GetFailureReason() string {
+	if x != nil && x.FailureReason != nil {
+		return *x.FailureReason
+	}
+	return ""
+}
+
+func (x *ConfirmTotpResponse) GetRecoveryCodes() []string {
+	if x != nil {
+		return x.RecoveryCodes
+	}
+	return nil
+}
+
+type DisableTotpRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableTotpRequest) Reset() {
+	*x = DisableTotpRequest{}
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableTotpRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableTotpRequest) ProtoMessage() {}
+
+func (x *DisableTotpRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableTotpRequest.ProtoReflect.Descriptor instead.
+func (*DisableTotpRequest) Descriptor() ([]byte, []int) {
+	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *DisableTotpRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DisableTotpResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableTotpResponse) Reset() {
+	*x = DisableTotpResponse{}
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableTotpResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableTotpResponse) ProtoMessage() {}
+
+func (x *DisableTotpResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableTotpResponse.ProtoReflect.Descriptor instead.
+func (*DisableTotpResponse) Descriptor() ([]byte, []int) {
+	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *DisableTotpResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type RegenerateRecoveryCodesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegenerateRecoveryCodesRequest) Reset() {
+	*x = RegenerateRecoveryCodesRequest{}
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegenerateRecoveryCodesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegenerateRecoveryCodesRequest) ProtoMessage() {}
+
+func (x *RegenerateRecoveryCodesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegenerateRecoveryCodesRequest.ProtoReflect.Descriptor instead.
+func (*RegenerateRecoveryCodesRequest) Descriptor() ([]byte, []int) {
+	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *RegenerateRecoveryCodesRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RegenerateRecoveryCodesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecoveryCodes []string               `protobuf:"bytes,1,rep,name=recovery_codes,json=recoveryCodes,proto3" json:"recovery_codes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegenerateRecoveryCodesResponse) Reset() {
+	*x = RegenerateRecoveryCodesResponse{}
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegenerateRecoveryCodesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegenerateRecoveryCodesResponse) ProtoMessage() {}
+
+func (x *RegenerateRecoveryCodesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_v1alpha1_user_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegenerateRecoveryCodesResponse.ProtoReflect.Descriptor instead.
+func (*RegenerateRecoveryCodesResponse) Descriptor() ([]byte, []int) {
+	return file_schema_v1alpha1_user_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *RegenerateRecoveryCodesResponse) GetRecoveryCodes() []string {
+	if x != nil {
+		return x.RecoveryCodes
+	}
+	return nil
+}
+
 var File_schema_v1alpha1_user_proto protoreflect.FileDescriptor
 
 const file_schema_v1alpha1_user_proto_rawDesc = "" +
 	"\n" +
-	"\x1aschema/v1alpha1/user.proto\x12\x0fschema.v1alpha1\x1a\x1bbuf/validate/validate.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a google/protobuf/field_mask.proto\"\xc5\x0f\n" +
+	"\x1aschema/v1alpha1/user.proto\x12\x0fschema.v1alpha1\x1a\x1bbuf/validate/validate.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a google/protobuf/field_mask.proto\"\xab\x10\n" +
 	"\x04User\x12\x17\n" +
 	"\x02id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x02id\x128\n" +
 	"\busername\x18\x02 \x01(\tB\x1c\xbaH\x19r\x17\x10\x01\x18@2\x11^[a-zA-Z0-9._-]+$R\busername\x12,\n" +
@@ -2386,7 +3195,8 @@ const file_schema_v1alpha1_user_proto_rawDesc = "" +
 	"\tldap_info\x18\r \x01(\v2\x1d.schema.v1alpha1.LdapUserInfoH\x05R\bldapInfo\x88\x01\x01\x12K\n" +
 	"\fredfish_info\x18\x0e \x01(\v2#.schema.v1alpha1.RedfishAccountInfoH\x06R\vredfishInfo\x88\x01\x01\x12B\n" +
 	"\tnats_info\x18\x0f \x01(\v2 .schema.v1alpha1.NatsAccountInfoH\aR\bnatsInfo\x88\x01\x01\x12X\n" +
-	"\x11custom_attributes\x18\x10 \x03(\v2+.schema.v1alpha1.User.CustomAttributesEntryR\x10customAttributes\x1aC\n" +
+	"\x11custom_attributes\x18\x10 \x03(\v2+.schema.v1alpha1.User.CustomAttributesEntryR\x10customAttributes\x12M\n" +
+	"\x12account_expires_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampH\bR\x10accountExpiresAt\x88\x01\x01\x1aC\n" +
 	"\x15CustomAttributesEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01:\x96\x06\xbaH\x92\x06\x1a\xcc\x03\n" +
@@ -2405,8 +3215,8 @@ const file_schema_v1alpha1_user_proto_rawDesc = "" +
 	"_ldap_infoB\x0f\n" +
 	"\r_redfish_infoB\f\n" +
 	"\n" +
-	"_nats_info\"\x81\n" +
-	"\n" +
+	"_nats_infoB\x15\n" +
+	"\x13_account_expires_at\"\xd6\v\n" +
 	"\x12AuthenticationData\x12,\n" +
 	"\rpassword_hash\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\fpasswordHash\x121\n" +
 	"\rpassword_salt\x18\x02 \x01(\tB\a\xbaH\x04r\x02\x10\x01H\x00R\fpasswordSalt\x88\x01\x01\x12W\n" +
@@ -2416,13 +3226,38 @@ const file_schema_v1alpha1_user_proto_rawDesc = "" +
 	"iterations\x12S\n" +
 	"\x15password_last_changed\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampH\x01R\x13passwordLastChanged\x88\x01\x01\x12O\n" +
 	"\x13password_expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampH\x02R\x11passwordExpiresAt\x88\x01\x01\x12K\n" +
-	"\flockout_info\x18\a \x01(\v2#.schema.v1alpha1.AccountLockoutInfoH\x03R\vlockoutInfo\x88\x01\x01:\xbf\x05\xbaH\xbb\x05\x1a\xde\x01\n" +
+	"\flockout_info\x18\a \x01(\v2#.schema.v1alpha1.AccountLockoutInfoH\x03R\vlockoutInfo\x88\x01\x01\x12Z\n" +
+	"\x10password_history\x18\b \x03(\v2%.schema.v1alpha1.PasswordHistoryEntryB\b\xbaH\x05\x92\x01\x02\x10\x18R\x0fpasswordHistory\x122\n" +
+	"\x04totp\x18\t \x01(\v2\x19.schema.v1alpha1.TotpDataH\x04R\x04totp\x88\x01\x01\x12(\n" +
+	"\rtotp_required\x18\n" +
+	" \x01(\bH\x05R\ftotpRequired\x88\x01\x01:\xbf\x05\xbaH\xbb\x05\x1a\xde\x01\n" +
 	"&auth_data_password_expiry_after_change\x127password_expires_at must be after password_last_changed\x1a{!has(this.password_last_changed) || !has(this.password_expires_at) || this.password_last_changed < this.password_expires_at\x1a\xd7\x03\n" +
 	"\"auth_data_iterations_for_algorithm\x121iterations must be appropriate for hash algorithm\x1a\xfd\x02(this.hash_algorithm == 1 && this.iterations >= 10 && this.iterations <= 15) || (this.hash_algorithm == 2 && this.iterations >= 1 && this.iterations <= 10) || (this.hash_algorithm == 3 && this.iterations >= 14 && this.iterations <= 20) || (this.hash_algorithm == 4 && this.iterations >= 100000) || (this.hash_algorithm == 5 && this.iterations >= 100000) || this.hash_algorithm == 0B\x10\n" +
 	"\x0e_password_saltB\x18\n" +
 	"\x16_password_last_changedB\x16\n" +
 	"\x14_password_expires_atB\x0f\n" +
-	"\r_lockout_info\"\x94\x06\n" +
+	"\r_lockout_infoB\a\n" +
+	"\x05_totpB\x10\n" +
+	"\x0e_totp_required\"\xda\x02\n" +
+	"\x14PasswordHistoryEntry\x12,\n" +
+	"\rpassword_hash\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\fpasswordHash\x121\n" +
+	"\rpassword_salt\x18\x02 \x01(\tB\a\xbaH\x04r\x02\x10\x01H\x00R\fpasswordSalt\x88\x01\x01\x12W\n" +
+	"\x0ehash_algorithm\x18\x03 \x01(\x0e2&.schema.v1alpha1.PasswordHashAlgorithmB\b\xbaH\x05\x82\x01\x02\x10\x01R\rhashAlgorithm\x12'\n" +
+	"\n" +
+	"iterations\x18\x04 \x01(\x05B\a\xbaH\x04\x1a\x02(\x01R\n" +
+	"iterations\x12>\n" +
+	"\n" +
+	"changed_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampH\x01R\tchangedAt\x88\x01\x01B\x10\n" +
+	"\x0e_password_saltB\r\n" +
+	"\v_changed_at\"\x8a\x02\n" +
+	"\bTotpData\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x122\n" +
+	"\x10encrypted_secret\x18\x02 \x01(\fB\a\xbaH\x04z\x02\x10\x01R\x0fencryptedSecret\x12@\n" +
+	"\venrolled_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\n" +
+	"enrolledAt\x88\x01\x01\x12/\n" +
+	"\x0erecovery_codes\x18\x04 \x03(\tB\b\xbaH\x05\x92\x01\x02\x10\x10R\rrecoveryCodes\x12-\n" +
+	"\x0elast_used_step\x18\x05 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\flastUsedStepB\x0e\n" +
+	"\f_enrolled_at\"\x94\x06\n" +
 	"\x12AccountLockoutInfo\x12\x16\n" +
 	"\x06locked\x18\x01 \x01(\bR\x06locked\x12E\n" +
 	"\x06reason\x18\x02 \x01(\x0e2\x1e.schema.v1alpha1.LockoutReasonB\b\xbaH\x05\x82\x01\x02\x10\x01H\x00R\x06reason\x88\x01\x01\x12B\n" +
@@ -2617,27 +3452,70 @@ const file_schema_v1alpha1_user_proto_rawDesc = "" +
 	"\fnew_password\x18\x01 \x01(\tR\vnewPassword\x12\x18\n" +
 	"\asuccess\x18\x02 \x01(\bR\asuccess\x12*\n" +
 	"\x0efailure_reason\x18\x03 \x01(\tH\x00R\rfailureReason\x88\x01\x01B\x11\n" +
-	"\x0f_failure_reason\"\xc6\x01\n" +
+	"\x0f_failure_reason\"\xff\x02\n" +
 	"\x17AuthenticateUserRequest\x12#\n" +
 	"\busername\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\busername\x12#\n" +
 	"\bpassword\x18\x02 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\bpassword\x12 \n" +
 	"\tsource_ip\x18\x03 \x01(\tH\x00R\bsourceIp\x88\x01\x01\x12\"\n" +
 	"\n" +
-	"user_agent\x18\x04 \x01(\tH\x01R\tuserAgent\x88\x01\x01B\f\n" +
+	"user_agent\x18\x04 \x01(\tH\x01R\tuserAgent\x88\x01\x01\x121\n" +
+	"\rsecond_factor\x18\x05 \x01(\tB\a\xbaH\x04r\x02\x10\x01H\x02R\fsecondFactor\x88\x01\x01\x126\n" +
+	"\x17second_factor_supported\x18\x06 \x01(\bR\x15secondFactorSupported\x12:\n" +
+	"\x19password_change_supported\x18\a \x01(\bR\x17passwordChangeSupportedB\f\n" +
 	"\n" +
 	"_source_ipB\r\n" +
-	"\v_user_agent\"\xa2\x02\n" +
+	"\v_user_agentB\x10\n" +
+	"\x0e_second_factor\"\x84\x04\n" +
 	"\x18AuthenticateUserResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1c\n" +
 	"\auser_id\x18\x02 \x01(\tH\x00R\x06userId\x88\x01\x01\x12\x19\n" +
 	"\x05token\x18\x03 \x01(\tH\x01R\x05token\x88\x01\x01\x12*\n" +
 	"\x0efailure_reason\x18\x04 \x01(\tH\x02R\rfailureReason\x88\x01\x01\x12I\n" +
-	"\x10token_expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampH\x03R\x0etokenExpiresAt\x88\x01\x01B\n" +
+	"\x10token_expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampH\x03R\x0etokenExpiresAt\x88\x01\x01\x12O\n" +
+	"\afailure\x18\x06 \x01(\x0e2&.schema.v1alpha1.AuthenticationFailureB\b\xbaH\x05\x82\x01\x02\x10\x01H\x04R\afailure\x88\x01\x01\x12I\n" +
+	"!second_factor_enrollment_required\x18\a \x01(\bR\x1esecondFactorEnrollmentRequired\x128\n" +
+	"\x18password_change_required\x18\b \x01(\bR\x16passwordChangeRequiredB\n" +
 	"\n" +
 	"\b_user_idB\b\n" +
 	"\x06_tokenB\x11\n" +
 	"\x0f_failure_reasonB\x13\n" +
-	"\x11_token_expires_at*\xe3\x01\n" +
+	"\x11_token_expires_atB\n" +
+	"\n" +
+	"\b_failure\"\x8b\x01\n" +
+	"\x11CheckLoginRequest\x12#\n" +
+	"\busername\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\busername\x12 \n" +
+	"\tsource_ip\x18\x02 \x01(\tH\x00R\bsourceIp\x88\x01\x01\x12!\n" +
+	"\fproof_failed\x18\x03 \x01(\bR\vproofFailedB\f\n" +
+	"\n" +
+	"_source_ip\"\xca\x01\n" +
+	"\x12CheckLoginResponse\x12\x18\n" +
+	"\aallowed\x18\x01 \x01(\bR\aallowed\x12*\n" +
+	"\x0efailure_reason\x18\x02 \x01(\tH\x00R\rfailureReason\x88\x01\x01\x12O\n" +
+	"\afailure\x18\x03 \x01(\x0e2&.schema.v1alpha1.AuthenticationFailureB\b\xbaH\x05\x82\x01\x02\x10\x01H\x01R\afailure\x88\x01\x01B\x11\n" +
+	"\x0f_failure_reasonB\n" +
+	"\n" +
+	"\b_failure\",\n" +
+	"\x11EnrollTotpRequest\x12\x17\n" +
+	"\x02id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x02id\">\n" +
+	"\x12EnrollTotpResponse\x12\x16\n" +
+	"\x06secret\x18\x01 \x01(\tR\x06secret\x12\x10\n" +
+	"\x03uri\x18\x02 \x01(\tR\x03uri\"J\n" +
+	"\x12ConfirmTotpRequest\x12\x17\n" +
+	"\x02id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x02id\x12\x1b\n" +
+	"\x04code\x18\x02 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x04code\"\x95\x01\n" +
+	"\x13ConfirmTotpResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12*\n" +
+	"\x0efailure_reason\x18\x02 \x01(\tH\x00R\rfailureReason\x88\x01\x01\x12%\n" +
+	"\x0erecovery_codes\x18\x03 \x03(\tR\rrecoveryCodesB\x11\n" +
+	"\x0f_failure_reason\"-\n" +
+	"\x12DisableTotpRequest\x12\x17\n" +
+	"\x02id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x02id\"/\n" +
+	"\x13DisableTotpResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"9\n" +
+	"\x1eRegenerateRecoveryCodesRequest\x12\x17\n" +
+	"\x02id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x02id\"H\n" +
+	"\x1fRegenerateRecoveryCodesResponse\x12%\n" +
+	"\x0erecovery_codes\x18\x01 \x03(\tR\rrecoveryCodes*\x93\x02\n" +
 	"\n" +
 	"UserSource\x12\x1b\n" +
 	"\x17USER_SOURCE_UNSPECIFIED\x10\x00\x12\x15\n" +
@@ -2648,7 +3526,10 @@ const file_schema_v1alpha1_user_proto_rawDesc = "" +
 	"\x13USER_SOURCE_REDFISH\x10\x05\x12\x14\n" +
 	"\x10USER_SOURCE_NATS\x10\x06\x12\x14\n" +
 	"\x10USER_SOURCE_UNIX\x10\a\x12\x1c\n" +
-	"\x18USER_SOURCE_EXTERNAL_API\x10\b*\xde\x02\n" +
+	"\x18USER_SOURCE_EXTERNAL_API\x10\b\x12\x16\n" +
+	"\x12USER_SOURCE_RADIUS\x10\t\x12\x16\n" +
+	"\x12USER_SOURCE_TACACS\x10\n" +
+	"*\xde\x02\n" +
 	"\x15UserCreationInterface\x12'\n" +
 	"#USER_CREATION_INTERFACE_UNSPECIFIED\x10\x00\x12&\n" +
 	"\"USER_CREATION_INTERFACE_SCHEMA_API\x10\x01\x12(\n" +
@@ -2671,7 +3552,17 @@ const file_schema_v1alpha1_user_proto_rawDesc = "" +
 	"\x1dLOCKOUT_REASON_ADMINISTRATIVE\x10\x02\x12#\n" +
 	"\x1fLOCKOUT_REASON_PASSWORD_EXPIRED\x10\x03\x12\"\n" +
 	"\x1eLOCKOUT_REASON_ACCOUNT_EXPIRED\x10\x04\x12\"\n" +
-	"\x1eLOCKOUT_REASON_SECURITY_POLICY\x10\x05*\x97\x01\n" +
+	"\x1eLOCKOUT_REASON_SECURITY_POLICY\x10\x05*\xba\x03\n" +
+	"\x15AuthenticationFailure\x12&\n" +
+	"\"AUTHENTICATION_FAILURE_UNSPECIFIED\x10\x00\x12.\n" +
+	"*AUTHENTICATION_FAILURE_INVALID_CREDENTIALS\x10\x01\x12)\n" +
+	"%AUTHENTICATION_FAILURE_ACCOUNT_LOCKED\x10\x02\x12+\n" +
+	"'AUTHENTICATION_FAILURE_ACCOUNT_DISABLED\x10\x03\x12+\n" +
+	"'AUTHENTICATION_FAILURE_PASSWORD_EXPIRED\x10\x04\x12*\n" +
+	"&AUTHENTICATION_FAILURE_ACCOUNT_EXPIRED\x10\x05\x121\n" +
+	"-AUTHENTICATION_FAILURE_SECOND_FACTOR_REQUIRED\x10\x06\x120\n" +
+	",AUTHENTICATION_FAILURE_INVALID_SECOND_FACTOR\x10\a\x123\n" +
+	"/AUTHENTICATION_FAILURE_PASSWORD_CHANGE_REQUIRED\x10\b*\x97\x01\n" +
 	"\x0eUserLinkAction\x12 \n" +
 	"\x1cUSER_LINK_ACTION_UNSPECIFIED\x10\x00\x12\"\n" +
 	"\x1eUSER_LINK_ACTION_LINK_EXISTING\x10\x01\x12\x1f\n" +
@@ -2691,92 +3582,113 @@ func file_schema_v1alpha1_user_proto_rawDescGZIP() []byte {
 	return file_schema_v1alpha1_user_proto_rawDescData
 }
 
-var file_schema_v1alpha1_user_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
-var file_schema_v1alpha1_user_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
+var file_schema_v1alpha1_user_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_schema_v1alpha1_user_proto_msgTypes = make([]protoimpl.MessageInfo, 40)
 var file_schema_v1alpha1_user_proto_goTypes = []any{
-	(UserSource)(0),                  // 0: schema.v1alpha1.UserSource
-	(UserCreationInterface)(0),       // 1: schema.v1alpha1.UserCreationInterface
-	(PasswordHashAlgorithm)(0),       // 2: schema.v1alpha1.PasswordHashAlgorithm
-	(LockoutReason)(0),               // 3: schema.v1alpha1.LockoutReason
-	(UserLinkAction)(0),              // 4: schema.v1alpha1.UserLinkAction
-	(*User)(nil),                     // 5: schema.v1alpha1.User
-	(*AuthenticationData)(nil),       // 6: schema.v1alpha1.AuthenticationData
-	(*AccountLockoutInfo)(nil),       // 7: schema.v1alpha1.AccountLockoutInfo
-	(*UnixUserInfo)(nil),             // 8: schema.v1alpha1.UnixUserInfo
-	(*LdapUserInfo)(nil),             // 9: schema.v1alpha1.LdapUserInfo
-	(*RedfishAccountInfo)(nil),       // 10: schema.v1alpha1.RedfishAccountInfo
-	(*RedfishLockoutPolicy)(nil),     // 11: schema.v1alpha1.RedfishLockoutPolicy
-	(*NatsAccountInfo)(nil),          // 12: schema.v1alpha1.NatsAccountInfo
-	(*NatsPermissions)(nil),          // 13: schema.v1alpha1.NatsPermissions
-	(*NatsLimits)(nil),               // 14: schema.v1alpha1.NatsLimits
-	(*UserLinkingOptions)(nil),       // 15: schema.v1alpha1.UserLinkingOptions
-	(*CreateUserRequest)(nil),        // 16: schema.v1alpha1.CreateUserRequest
-	(*CreateUserResponse)(nil),       // 17: schema.v1alpha1.CreateUserResponse
-	(*GetUserRequest)(nil),           // 18: schema.v1alpha1.GetUserRequest
-	(*GetUserResponse)(nil),          // 19: schema.v1alpha1.GetUserResponse
-	(*UpdateUserRequest)(nil),        // 20: schema.v1alpha1.UpdateUserRequest
-	(*UpdateUserResponse)(nil),       // 21: schema.v1alpha1.UpdateUserResponse
-	(*DeleteUserRequest)(nil),        // 22: schema.v1alpha1.DeleteUserRequest
-	(*DeleteUserResponse)(nil),       // 23: schema.v1alpha1.DeleteUserResponse
-	(*ListUsersRequest)(nil),         // 24: schema.v1alpha1.ListUsersRequest
-	(*ListUsersResponse)(nil),        // 25: schema.v1alpha1.ListUsersResponse
-	(*ChangePasswordRequest)(nil),    // 26: schema.v1alpha1.ChangePasswordRequest
-	(*ChangePasswordResponse)(nil),   // 27: schema.v1alpha1.ChangePasswordResponse
-	(*ResetPasswordRequest)(nil),     // 28: schema.v1alpha1.ResetPasswordRequest
-	(*ResetPasswordResponse)(nil),    // 29: schema.v1alpha1.ResetPasswordResponse
-	(*AuthenticateUserRequest)(nil),  // 30: schema.v1alpha1.AuthenticateUserRequest
-	(*AuthenticateUserResponse)(nil), // 31: schema.v1alpha1.AuthenticateUserResponse
-	nil,                              // 32: schema.v1alpha1.User.CustomAttributesEntry
-	(*timestamppb.Timestamp)(nil),    // 33: google.protobuf.Timestamp
-	(*fieldmaskpb.FieldMask)(nil),    // 34: google.protobuf.FieldMask
+	(UserSource)(0),                         // 0: schema.v1alpha1.UserSource
+	(UserCreationInterface)(0),              // 1: schema.v1alpha1.UserCreationInterface
+	(PasswordHashAlgorithm)(0),              // 2: schema.v1alpha1.PasswordHashAlgorithm
+	(LockoutReason)(0),                      // 3: schema.v1alpha1.LockoutReason
+	(AuthenticationFailure)(0),              // 4: schema.v1alpha1.AuthenticationFailure
+	(UserLinkAction)(0),                     // 5: schema.v1alpha1.UserLinkAction
+	(*User)(nil),                            // 6: schema.v1alpha1.User
+	(*AuthenticationData)(nil),              // 7: schema.v1alpha1.AuthenticationData
+	(*PasswordHistoryEntry)(nil),            // 8: schema.v1alpha1.PasswordHistoryEntry
+	(*TotpData)(nil),                        // 9: schema.v1alpha1.TotpData
+	(*AccountLockoutInfo)(nil),              // 10: schema.v1alpha1.AccountLockoutInfo
+	(*UnixUserInfo)(nil),                    // 11: schema.v1alpha1.UnixUserInfo
+	(*LdapUserInfo)(nil),                    // 12: schema.v1alpha1.LdapUserInfo
+	(*RedfishAccountInfo)(nil),              // 13: schema.v1alpha1.RedfishAccountInfo
+	(*RedfishLockoutPolicy)(nil),            // 14: schema.v1alpha1.RedfishLockoutPolicy
+	(*NatsAccountInfo)(nil),                 // 15: schema.v1alpha1.NatsAccountInfo
+	(*NatsPermissions)(nil),                 // 16: schema.v1alpha1.NatsPermissions
+	(*NatsLimits)(nil),                      // 17: schema.v1alpha1.NatsLimits
+	(*UserLinkingOptions)(nil),              // 18: schema.v1alpha1.UserLinkingOptions
+	(*CreateUserRequest)(nil),               // 19: schema.v1alpha1.CreateUserRequest
+	(*CreateUserResponse)(nil),              // 20: schema.v1alpha1.CreateUserResponse
+	(*GetUserRequest)(nil),                  // 21: schema.v1alpha1.GetUserRequest
+	(*GetUserResponse)(nil),                 // 22: schema.v1alpha1.GetUserResponse
+	(*UpdateUserRequest)(nil),               // 23: schema.v1alpha1.UpdateUserRequest
+	(*UpdateUserResponse)(nil),              // 24: schema.v1alpha1.UpdateUserResponse
+	(*DeleteUserRequest)(nil),               // 25: schema.v1alpha1.DeleteUserRequest
+	(*DeleteUserResponse)(nil),              // 26: schema.v1alpha1.DeleteUserResponse
+	(*ListUsersRequest)(nil),                // 27: schema.v1alpha1.ListUsersRequest
+	(*ListUsersResponse)(nil),               // 28: schema.v1alpha1.ListUsersResponse
+	(*ChangePasswordRequest)(nil),           // 29: schema.v1alpha1.ChangePasswordRequest
+	(*ChangePasswordResponse)(nil),          // 30: schema.v1alpha1.ChangePasswordResponse
+	(*ResetPasswordRequest)(nil),            // 31: schema.v1alpha1.ResetPasswordRequest
+	(*ResetPasswordResponse)(nil),           // 32: schema.v1alpha1.ResetPasswordResponse
+	(*AuthenticateUserRequest)(nil),         // 33: schema.v1alpha1.AuthenticateUserRequest
+	(*AuthenticateUserResponse)(nil),        // 34: schema.v1alpha1.AuthenticateUserResponse
+	(*CheckLoginRequest)(nil),               // 35: schema.v1alpha1.CheckLoginRequest
+	(*CheckLoginResponse)(nil),              // 36: schema.v1alpha1.CheckLoginResponse
+	(*EnrollTotpRequest)(nil),               // 37: schema.v1alpha1.EnrollTotpRequest
+	(*EnrollTotpResponse)(nil),              // 38: schema.v1alpha1.EnrollTotpResponse
+	(*ConfirmTotpRequest)(nil),              // 39: schema.v1alpha1.ConfirmTotpRequest
+	(*ConfirmTotpResponse)(nil),             // 40: schema.v1alpha1.ConfirmTotpResponse
+	(*DisableTotpRequest)(nil),              // 41: schema.v1alpha1.DisableTotpRequest
+	(*DisableTotpResponse)(nil),             // 42: schema.v1alpha1.DisableTotpResponse
+	(*RegenerateRecoveryCodesRequest)(nil),  // 43: schema.v1alpha1.RegenerateRecoveryCodesRequest
+	(*RegenerateRecoveryCodesResponse)(nil), // 44: schema.v1alpha1.RegenerateRecoveryCodesResponse
+	nil,                                     // 45: schema.v1alpha1.User.CustomAttributesEntry
+	(*timestamppb.Timestamp)(nil),           // 46: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),           // 47: google.protobuf.FieldMask
 }
 var file_schema_v1alpha1_user_proto_depIdxs = []int32{
-	33, // 0: schema.v1alpha1.User.created_at:type_name -> google.protobuf.Timestamp
-	33, // 1: schema.v1alpha1.User.updated_at:type_name -> google.protobuf.Timestamp
-	33, // 2: schema.v1alpha1.User.last_login:type_name -> google.protobuf.Timestamp
+	46, // 0: schema.v1alpha1.User.created_at:type_name -> google.protobuf.Timestamp
+	46, // 1: schema.v1alpha1.User.updated_at:type_name -> google.protobuf.Timestamp
+	46, // 2: schema.v1alpha1.User.last_login:type_name -> google.protobuf.Timestamp
 	0,  // 3: schema.v1alpha1.User.source_system:type_name -> schema.v1alpha1.UserSource
 	1,  // 4: schema.v1alpha1.User.creation_interface:type_name -> schema.v1alpha1.UserCreationInterface
-	6,  // 5: schema.v1alpha1.User.auth_data:type_name -> schema.v1alpha1.AuthenticationData
-	8,  // 6: schema.v1alpha1.User.unix_info:type_name -> schema.v1alpha1.UnixUserInfo
-	9,  // 7: schema.v1alpha1.User.ldap_info:type_name -> schema.v1alpha1.LdapUserInfo
-	10, // 8: schema.v1alpha1.User.redfish_info:type_name -> schema.v1alpha1.RedfishAccountInfo
-	12, // 9: schema.v1alpha1.User.nats_info:type_name -> schema.v1alpha1.NatsAccountInfo
-	32, // 10: schema.v1alpha1.User.custom_attributes:type_name -> schema.v1alpha1.User.CustomAttributesEntry
-	2,  // 11: schema.v1alpha1.AuthenticationData.hash_algorithm:type_name -> schema.v1alpha1.PasswordHashAlgorithm
-	33, // 12: schema.v1alpha1.AuthenticationData.password_last_changed:type_name -> google.protobuf.Timestamp
-	33, // 13: schema.v1alpha1.AuthenticationData.password_expires_at:type_name -> google.protobuf.Timestamp
-	7,  // 14: schema.v1alpha1.AuthenticationData.lockout_info:type_name -> schema.v1alpha1.AccountLockoutInfo
-	3,  // 15: schema.v1alpha1.AccountLockoutInfo.reason:type_name -> schema.v1alpha1.LockoutReason
-	33, // 16: schema.v1alpha1.AccountLockoutInfo.lockout_time:type_name -> google.protobuf.Timestamp
-	33, // 17: schema.v1alpha1.AccountLockoutInfo.attempts_reset_time:type_name -> google.protobuf.Timestamp
-	33, // 18: schema.v1alpha1.LdapUserInfo.account_expires:type_name -> google.protobuf.Timestamp
-	33, // 19: schema.v1alpha1.LdapUserInfo.pwd_last_set:type_name -> google.protobuf.Timestamp
-	11, // 20: schema.v1alpha1.RedfishAccountInfo.lockout_policy:type_name -> schema.v1alpha1.RedfishLockoutPolicy
-	13, // 21: schema.v1alpha1.NatsAccountInfo.permissions:type_name -> schema.v1alpha1.NatsPermissions
-	14, // 22: schema.v1alpha1.NatsAccountInfo.limits:type_name -> schema.v1alpha1.NatsLimits
-	33, // 23: schema.v1alpha1.NatsAccountInfo.jwt_expires_at:type_name -> google.protobuf.Timestamp
-	4,  // 24: schema.v1alpha1.UserLinkingOptions.unix_action:type_name -> schema.v1alpha1.UserLinkAction
-	4,  // 25: schema.v1alpha1.UserLinkingOptions.ldap_action:type_name -> schema.v1alpha1.UserLinkAction
-	4,  // 26: schema.v1alpha1.UserLinkingOptions.redfish_action:type_name -> schema.v1alpha1.UserLinkAction
-	4,  // 27: schema.v1alpha1.UserLinkingOptions.nats_action:type_name -> schema.v1alpha1.UserLinkAction
-	5,  // 28: schema.v1alpha1.CreateUserRequest.user:type_name -> schema.v1alpha1.User
-	15, // 29: schema.v1alpha1.CreateUserRequest.linking_options:type_name -> schema.v1alpha1.UserLinkingOptions
-	5,  // 30: schema.v1alpha1.CreateUserResponse.user:type_name -> schema.v1alpha1.User
-	34, // 31: schema.v1alpha1.GetUserRequest.field_mask:type_name -> google.protobuf.FieldMask
-	5,  // 32: schema.v1alpha1.GetUserResponse.user:type_name -> schema.v1alpha1.User
-	5,  // 33: schema.v1alpha1.UpdateUserRequest.user:type_name -> schema.v1alpha1.User
-	34, // 34: schema.v1alpha1.UpdateUserRequest.field_mask:type_name -> google.protobuf.FieldMask
-	15, // 35: schema.v1alpha1.UpdateUserRequest.linking_options:type_name -> schema.v1alpha1.UserLinkingOptions
-	5,  // 36: schema.v1alpha1.UpdateUserResponse.user:type_name -> schema.v1alpha1.User
-	0,  // 37: schema.v1alpha1.ListUsersRequest.source:type_name -> schema.v1alpha1.UserSource
-	34, // 38: schema.v1alpha1.ListUsersRequest.field_mask:type_name -> google.protobuf.FieldMask
-	5,  // 39: schema.v1alpha1.ListUsersResponse.users:type_name -> schema.v1alpha1.User
-	33, // 40: schema.v1alpha1.AuthenticateUserResponse.token_expires_at:type_name -> google.protobuf.Timestamp
-	41, // [41:41] is the sub-list for method output_type
-	41, // [41:41] is the sub-list for method input_type
-	41, // [41:41] is the sub-list for extension type_name
-	41, // [41:41] is the sub-list for extension extendee
-	0,  // [0:41] is the sub-list for field type_name
+	7,  // 5: schema.v1alpha1.User.auth_data:type_name -> schema.v1alpha1.AuthenticationData
+	11, // 6: schema.v1alpha1.User.unix_info:type_name -> schema.v1alpha1.UnixUserInfo
+	12, // 7: schema.v1alpha1.User.ldap_info:type_name -> schema.v1alpha1.LdapUserInfo
+	13, // 8: schema.v1alpha1.User.redfish_info:type_name -> schema.v1alpha1.RedfishAccountInfo
+	15, // 9: schema.v1alpha1.User.nats_info:type_name -> schema.v1alpha1.NatsAccountInfo
+	45, // 10: schema.v1alpha1.User.custom_attributes:type_name -> schema.v1alpha1.User.CustomAttributesEntry
+	46, // 11: schema.v1alpha1.User.account_expires_at:type_name -> google.protobuf.Timestamp
+	2,  // 12: schema.v1alpha1.AuthenticationData.hash_algorithm:type_name -> schema.v1alpha1.PasswordHashAlgorithm
+	46, // 13: schema.v1alpha1.AuthenticationData.password_last_changed:type_name -> google.protobuf.Timestamp
+	46, // 14: schema.v1alpha1.AuthenticationData.password_expires_at:type_name -> google.protobuf.Timestamp
+	10, // 15: schema.v1alpha1.AuthenticationData.lockout_info:type_name -> schema.v1alpha1.AccountLockoutInfo
+	8,  // 16: schema.v1alpha1.AuthenticationData.password_history:type_name -> schema.v1alpha1.PasswordHistoryEntry
+	9,  // 17: schema.v1alpha1.AuthenticationData.totp:type_name -> schema.v1alpha1.TotpData
+	2,  // 18: schema.v1alpha1.PasswordHistoryEntry.hash_algorithm:type_name -> schema.v1alpha1.PasswordHashAlgorithm
+	46, // 19: schema.v1alpha1.PasswordHistoryEntry.changed_at:type_name -> google.protobuf.Timestamp
+	46, // 20: schema.v1alpha1.TotpData.enrolled_at:type_name -> google.protobuf.Timestamp
+	3,  // 21: schema.v1alpha1.AccountLockoutInfo.reason:type_name -> schema.v1alpha1.LockoutReason
+	46, // 22: schema.v1alpha1.AccountLockoutInfo.lockout_time:type_name -> google.protobuf.Timestamp
+	46, // 23: schema.v1alpha1.AccountLockoutInfo.attempts_reset_time:type_name -> google.protobuf.Timestamp
+	46, // 24: schema.v1alpha1.LdapUserInfo.account_expires:type_name -> google.protobuf.Timestamp
+	46, // 25: schema.v1alpha1.LdapUserInfo.pwd_last_set:type_name -> google.protobuf.Timestamp
+	14, // 26: schema.v1alpha1.RedfishAccountInfo.lockout_policy:type_name -> schema.v1alpha1.RedfishLockoutPolicy
+	16, // 27: schema.v1alpha1.NatsAccountInfo.permissions:type_name -> schema.v1alpha1.NatsPermissions
+	17, // 28: schema.v1alpha1.NatsAccountInfo.limits:type_name -> schema.v1alpha1.NatsLimits
+	46, // 29: schema.v1alpha1.NatsAccountInfo.jwt_expires_at:type_name -> google.protobuf.Timestamp
+	5,  // 30: schema.v1alpha1.UserLinkingOptions.unix_action:type_name -> schema.v1alpha1.UserLinkAction
+	5,  // 31: schema.v1alpha1.UserLinkingOptions.ldap_action:type_name -> schema.v1alpha1.UserLinkAction
+	5,  // 32: schema.v1alpha1.UserLinkingOptions.redfish_action:type_name -> schema.v1alpha1.UserLinkAction
+	5,  // 33: schema.v1alpha1.UserLinkingOptions.nats_action:type_name -> schema.v1alpha1.UserLinkAction
+	6,  // 34: schema.v1alpha1.CreateUserRequest.user:type_name -> schema.v1alpha1.User
+	18, // 35: schema.v1alpha1.CreateUserRequest.linking_options:type_name -> schema.v1alpha1.UserLinkingOptions
+	6,  // 36: schema.v1alpha1.CreateUserResponse.user:type_name -> schema.v1alpha1.User
+	47, // 37: schema.v1alpha1.GetUserRequest.field_mask:type_name -> google.protobuf.FieldMask
+	6,  // 38: schema.v1alpha1.GetUserResponse.user:type_name -> schema.v1alpha1.User
+	6,  // 39: schema.v1alpha1.UpdateUserRequest.user:type_name -> schema.v1alpha1.User
+	47, // 40: schema.v1alpha1.UpdateUserRequest.field_mask:type_name -> google.protobuf.FieldMask
+	18, // 41: schema.v1alpha1.UpdateUserRequest.linking_options:type_name -> schema.v1alpha1.UserLinkingOptions
+	6,  // 42: schema.v1alpha1.UpdateUserResponse.user:type_name -> schema.v1alpha1.User
+	0,  // 43: schema.v1alpha1.ListUsersRequest.source:type_name -> schema.v1alpha1.UserSource
+	47, // 44: schema.v1alpha1.ListUsersRequest.field_mask:type_name -> google.protobuf.FieldMask
+	6,  // 45: schema.v1alpha1.ListUsersResponse.users:type_name -> schema.v1alpha1.User
+	46, // 46: schema.v1alpha1.AuthenticateUserResponse.token_expires_at:type_name -> google.protobuf.Timestamp
+	4,  // 47: schema.v1alpha1.AuthenticateUserResponse.failure:type_name -> schema.v1alpha1.AuthenticationFailure
+	4,  // 48: schema.v1alpha1.CheckLoginResponse.failure:type_name -> schema.v1alpha1.AuthenticationFailure
+	49, // [49:49] is the sub-list for method output_type
+	49, // [49:49] is the sub-list for method input_type
+	49, // [49:49] is the sub-list for extension type_name
+	49, // [49:49] is the sub-list for extension extendee
+	0,  // [0:49] is the sub-list for field type_name
 }
 
 func init() { file_schema_v1alpha1_user_proto_init() }
@@ -2792,31 +3704,36 @@ func file_schema_v1alpha1_user_proto_init() {
 	file_schema_v1alpha1_user_proto_msgTypes[5].OneofWrappers = []any{}
 	file_schema_v1alpha1_user_proto_msgTypes[6].OneofWrappers = []any{}
 	file_schema_v1alpha1_user_proto_msgTypes[7].OneofWrappers = []any{}
+	file_schema_v1alpha1_user_proto_msgTypes[8].OneofWrappers = []any{}
 	file_schema_v1alpha1_user_proto_msgTypes[9].OneofWrappers = []any{}
-	file_schema_v1alpha1_user_proto_msgTypes[10].OneofWrappers = []any{}
 	file_schema_v1alpha1_user_proto_msgTypes[11].OneofWrappers = []any{}
-	file_schema_v1alpha1_user_proto_msgTypes[13].OneofWrappers = []any{
+	file_schema_v1alpha1_user_proto_msgTypes[12].OneofWrappers = []any{}
+	file_schema_v1alpha1_user_proto_msgTypes[13].OneofWrappers = []any{}
+	file_schema_v1alpha1_user_proto_msgTypes[15].OneofWrappers = []any{
 		(*GetUserRequest_Id)(nil),
 		(*GetUserRequest_Username)(nil),
 		(*GetUserRequest_Email)(nil),
 	}
-	file_schema_v1alpha1_user_proto_msgTypes[15].OneofWrappers = []any{}
 	file_schema_v1alpha1_user_proto_msgTypes[17].OneofWrappers = []any{}
-	file_schema_v1alpha1_user_proto_msgTypes[18].OneofWrappers = []any{}
 	file_schema_v1alpha1_user_proto_msgTypes[19].OneofWrappers = []any{}
 	file_schema_v1alpha1_user_proto_msgTypes[20].OneofWrappers = []any{}
+	file_schema_v1alpha1_user_proto_msgTypes[21].OneofWrappers = []any{}
 	file_schema_v1alpha1_user_proto_msgTypes[22].OneofWrappers = []any{}
-	file_schema_v1alpha1_user_proto_msgTypes[23].OneofWrappers = []any{}
 	file_schema_v1alpha1_user_proto_msgTypes[24].OneofWrappers = []any{}
 	file_schema_v1alpha1_user_proto_msgTypes[25].OneofWrappers = []any{}
 	file_schema_v1alpha1_user_proto_msgTypes[26].OneofWrappers = []any{}
+	file_schema_v1alpha1_user_proto_msgTypes[27].OneofWrappers = []any{}
+	file_schema_v1alpha1_user_proto_msgTypes[28].OneofWrappers = []any{}
+	file_schema_v1alpha1_user_proto_msgTypes[29].OneofWrappers = []any{}
+	file_schema_v1alpha1_user_proto_msgTypes[30].OneofWrappers = []any{}
+	file_schema_v1alpha1_user_proto_msgTypes[34].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_schema_v1alpha1_user_proto_rawDesc), len(file_schema_v1alpha1_user_proto_rawDesc)),
-			NumEnums:      5,
-			NumMessages:   28,
+			NumEnums:      6,
+			NumMessages:   40,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -332,6 +332,39 @@ func (m *User) validate(all bool) error {
 
 	}
 
+	if m.AccountExpiresAt != nil {
+
+		if all {
+			switch v := interface{}(m.GetAccountExpiresAt()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, UserValidationError{
+						field:  "AccountExpiresAt",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, UserValidationError{
+						field:  "AccountExpiresAt",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetAccountExpiresAt()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return UserValidationError{
+					field:  "AccountExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
 	if len(errors) > 0 {
 		return UserMultiError(errors)
 	}
@@ -437,6 +470,40 @@ func (m *AuthenticationData) validate(all bool) error {
 
 	// no validation rules for Iterations
 
+	for idx, item := range m.GetPasswordHistory() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, AuthenticationDataValidationError{
+						field:  fmt.Sprintf("PasswordHistory[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, AuthenticationDataValidationError{
+						field:  fmt.Sprintf("PasswordHistory[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return AuthenticationDataValidationError{
+					field:  fmt.Sprintf("PasswordHistory[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
 	if m.PasswordSalt != nil {
 		// no validation rules for PasswordSalt
 	}
@@ -540,6 +607,43 @@ func (m *AuthenticationData) validate(all bool) error {
 
 	}
 
+	if m.Totp != nil {
+
+		if all {
+			switch v := interface{}(m.GetTotp()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, AuthenticationDataValidationError{
+						field:  "Totp",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, AuthenticationDataValidationError{
+						field:  "Totp",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetTotp()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return AuthenticationDataValidationError{
+					field:  "Totp",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if m.TotpRequired != nil {
+		// no validation rules for TotpRequired
+	}
+
 	if len(errors) > 0 {
 		return AuthenticationDataMultiError(errors)
 	}
@@ -620,94 +724,63 @@ var _ interface {
 	ErrorName() string
 } = AuthenticationDataValidationError{}
 
-// Validate checks the field values on AccountLockoutInfo with the rules
+// Validate checks the field values on PasswordHistoryEntry with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *AccountLockoutInfo) Validate() error {
+func (m *PasswordHistoryEntry) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on AccountLockoutInfo with the rules
+// ValidateAll checks the field values on PasswordHistoryEntry with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// AccountLockoutInfoMultiError, or nil if none found.
-func (m *AccountLockoutInfo) ValidateAll() error {
+// PasswordHistoryEntryMultiError, or nil if none found.
+func (m *PasswordHistoryEntry) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *AccountLockoutInfo) validate(all bool) error {
+func (m *PasswordHistoryEntry) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Locked
-
-	// no validation rules for FailedAttempts
-
-	if m.Reason != nil {
-		// no validation rules for Reason
-	}
+	// no validation rules for PasswordHash
 
-	if m.LockoutTime != nil {
+	// no validation rules for HashAlgorithm
 
-		if all {
-			switch v := interface{}(m.GetLockoutTime()).(type) {
-			case interface{ ValidateAll() error }:
-				if err := v.ValidateAll(); err != nil {
-					errors = append(errors, AccountLockoutInfoValidationError{
-						field:  "LockoutTime",
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			case interface{ Validate() error }:
-				if err := v.Validate(); err != nil {
-					errors = append(errors, AccountLockoutInfoValidationError{
-						field:  "LockoutTime",
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			}
-		} else if v, ok := interface{}(m.GetLockoutTime()).(interface{ Validate() error }); ok {
-			if err := v.Validate(); err != nil {
-				return AccountLockoutInfoValidationError{
-					field:  "LockoutTime",
-					reason: "embedded message failed validation",
-					cause:  err,
-				}
-			}
-		}
+	// no validation rules for Iterations
 
+	if m.PasswordSalt != nil {
+		// no validation rules for PasswordSalt
 	}
 
-	if m.AttemptsResetTime != nil {
+	if m.ChangedAt != nil {
 
 		if all {
-			switch v := interface{}(m.GetAttemptsResetTime()).(type) {
+			switch v := interface{}(m.GetChangedAt()).(type) {
 			case interface{ ValidateAll() error }:
 				if err := v.ValidateAll(); err != nil {
-					errors = append(errors, AccountLockoutInfoValidationError{
-						field:  "AttemptsResetTime",
+					errors = append(errors, PasswordHistoryEntryValidationError{
+						field:  "ChangedAt",
 						reason: "embedded message failed validation",
 						cause:  err,
 					})
 				}
 			case interface{ Validate() error }:
 				if err := v.Validate(); err != nil {
-					errors = append(errors, AccountLockoutInfoValidationError{
-						field:  "AttemptsResetTime",
+					errors = append(errors, PasswordHistoryEntryValidationError{
+						field:  "ChangedAt",
 						reason: "embedded message failed validation",
 						cause:  err,
 					})
 				}
 			}
-		} else if v, ok := interface{}(m.GetAttemptsResetTime()).(interface{ Validate() error }); ok {
+		} else if v, ok := interface{}(m.GetChangedAt()).(interface{ Validate() error }); ok {
 			if err := v.Validate(); err != nil {
-				return AccountLockoutInfoValidationError{
-					field:  "AttemptsResetTime",
+				return PasswordHistoryEntryValidationError{
+					field:  "ChangedAt",
 					reason: "embedded message failed validation",
 					cause:  err,
 				}
@@ -716,24 +789,20 @@ func (m *AccountLockoutInfo) validate(all bool) error {
 
 	}
 
-	if m.MaxFailedAttempts != nil {
-		// no validation rules for MaxFailedAttempts
-	}
-
 	if len(errors) > 0 {
-		return AccountLockoutInfoMultiError(errors)
+		return PasswordHistoryEntryMultiError(errors)
 	}
 
 	return nil
 }
 
-// AccountLockoutInfoMultiError is an error wrapping multiple validation errors
-// returned by AccountLockoutInfo.ValidateAll() if the designated constraints
-// aren't met.
-type AccountLockoutInfoMultiError []error
+// PasswordHistoryEntryMultiError is an error wrapping multiple validation
+// errors returned by PasswordHistoryEntry.ValidateAll() if the designated
+// constraints aren't met.
+type PasswordHistoryEntryMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m AccountLockoutInfoMultiError) Error() string {
+func (m PasswordHistoryEntryMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -742,11 +811,11 @@ func (m AccountLockoutInfoMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m AccountLockoutInfoMultiError) AllErrors() []error { return m }
+func (m PasswordHistoryEntryMultiError) AllErrors() []error { return m }
 
-// AccountLockoutInfoValidationError is the validation error returned by
-// AccountLockoutInfo.Validate if the designated constraints aren't met.
-type AccountLockoutInfoValidationError struct {
+// PasswordHistoryEntryValidationError is the validation error returned by
+// PasswordHistoryEntry.Validate if the designated constraints aren't met.
+type PasswordHistoryEntryValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -754,24 +823,24 @@ type AccountLockoutInfoValidationError struct {
 }
 
 // Field function returns field value.
-func (e AccountLockoutInfoValidationError) Field() string { return e.field }
+func (e PasswordHistoryEntryValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e AccountLockoutInfoValidationError) Reason() string { return e.reason }
+func (e PasswordHistoryEntryValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e AccountLockoutInfoValidationError) Cause() error { return e.cause }
+func (e PasswordHistoryEntryValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e AccountLockoutInfoValidationError) Key() bool { return e.key }
+func (e PasswordHistoryEntryValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e AccountLockoutInfoValidationError) ErrorName() string {
-	return "AccountLockoutInfoValidationError"
+func (e PasswordHistoryEntryValidationError) ErrorName() string {
+	return "PasswordHistoryEntryValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e AccountLockoutInfoValidationError) Error() string {
+func (e PasswordHistoryEntryValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -783,14 +852,14 @@ func (e AccountLockoutInfoValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sAccountLockoutInfo.%s: %s%s",
+		"invalid %sPasswordHistoryEntry.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = AccountLockoutInfoValidationError{}
+var _ error = PasswordHistoryEntryValidationError{}
 
 var _ interface {
 	Field() string
@@ -798,57 +867,82 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = AccountLockoutInfoValidationError{}
+} = PasswordHistoryEntryValidationError{}
 
-// Validate checks the field values on UnixUserInfo with the rules defined in
-// the proto definition for this message. If any rules are violated, the first
+// Validate checks the field values on TotpData with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
-func (m *UnixUserInfo) Validate() error {
+func (m *TotpData) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on UnixUserInfo with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in UnixUserInfoMultiError, or
-// nil if none found.
-func (m *UnixUserInfo) ValidateAll() error {
+// ValidateAll checks the field values on TotpData with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in TotpDataMultiError, or nil
+// if none found.
+func (m *TotpData) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *UnixUserInfo) validate(all bool) error {
+func (m *TotpData) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Uid
+	// no validation rules for Enabled
 
-	// no validation rules for Gid
+	// no validation rules for EncryptedSecret
 
-	// no validation rules for HomeDirectory
+	// no validation rules for LastUsedStep
 
-	if m.Shell != nil {
-		// no validation rules for Shell
-	}
+	if m.EnrolledAt != nil {
+
+		if all {
+			switch v := interface{}(m.GetEnrolledAt()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, TotpDataValidationError{
+						field:  "EnrolledAt",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, TotpDataValidationError{
+						field:  "EnrolledAt",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetEnrolledAt()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return TotpDataValidationError{
+					field:  "EnrolledAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
 
-	if m.Gecos != nil {
-		// no validation rules for Gecos
 	}
 
 	if len(errors) > 0 {
-		return UnixUserInfoMultiError(errors)
+		return TotpDataMultiError(errors)
 	}
 
 	return nil
 }
 
-// UnixUserInfoMultiError is an error wrapping multiple validation errors
-// returned by UnixUserInfo.ValidateAll() if the designated constraints aren't met.
-type UnixUserInfoMultiError []error
+// TotpDataMultiError is an error wrapping multiple validation errors returned
+// by TotpData.ValidateAll() if the designated constraints aren't met.
+type TotpDataMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m UnixUserInfoMultiError) Error() string {
+func (m TotpDataMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -857,11 +951,11 @@ func (m UnixUserInfoMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m UnixUserInfoMultiError) AllErrors() []error { return m }
+func (m TotpDataMultiError) AllErrors() []error { return m }
 
-// UnixUserInfoValidationError is the validation error returned by
-// UnixUserInfo.Validate if the designated constraints aren't met.
-type UnixUserInfoValidationError struct {
+// TotpDataValidationError is the validation error returned by
+// TotpData.Validate if the designated constraints aren't met.
+type TotpDataValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -869,22 +963,22 @@ type UnixUserInfoValidationError struct {
 }
 
 // Field function returns field value.
-func (e UnixUserInfoValidationError) Field() string { return e.field }
+func (e TotpDataValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e UnixUserInfoValidationError) Reason() string { return e.reason }
+func (e TotpDataValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e UnixUserInfoValidationError) Cause() error { return e.cause }
+func (e TotpDataValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e UnixUserInfoValidationError) Key() bool { return e.key }
+func (e TotpDataValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e UnixUserInfoValidationError) ErrorName() string { return "UnixUserInfoValidationError" }
+func (e TotpDataValidationError) ErrorName() string { return "TotpDataValidationError" }
 
 // Error satisfies the builtin error interface
-func (e UnixUserInfoValidationError) Error() string {
+func (e TotpDataValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -896,14 +990,14 @@ func (e UnixUserInfoValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sUnixUserInfo.%s: %s%s",
+		"invalid %sTotpData.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = UnixUserInfoValidationError{}
+var _ error = TotpDataValidationError{}
 
 var _ interface {
 	Field() string
@@ -911,69 +1005,63 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = UnixUserInfoValidationError{}
+} = TotpDataValidationError{}
 
-// Validate checks the field values on LdapUserInfo with the rules defined in
-// the proto definition for this message. If any rules are violated, the first
-// error encountered is returned, or nil if there are no violations.
-func (m *LdapUserInfo) Validate() error {
+// Validate checks the field values on AccountLockoutInfo with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *AccountLockoutInfo) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on LdapUserInfo with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in LdapUserInfoMultiError, or
-// nil if none found.
-func (m *LdapUserInfo) ValidateAll() error {
+// ValidateAll checks the field values on AccountLockoutInfo with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// AccountLockoutInfoMultiError, or nil if none found.
+func (m *AccountLockoutInfo) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *LdapUserInfo) validate(all bool) error {
+func (m *AccountLockoutInfo) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for LdapDn
+	// no validation rules for Locked
 
-	if m.ObjectGuid != nil {
-		// no validation rules for ObjectGuid
-	}
+	// no validation rules for FailedAttempts
 
-	if m.SamAccountName != nil {
-		// no validation rules for SamAccountName
+	if m.Reason != nil {
+		// no validation rules for Reason
 	}
 
-	if m.UserPrincipalName != nil {
-		// no validation rules for UserPrincipalName
-	}
-
-	if m.AccountExpires != nil {
+	if m.LockoutTime != nil {
 
 		if all {
-			switch v := interface{}(m.GetAccountExpires()).(type) {
+			switch v := interface{}(m.GetLockoutTime()).(type) {
 			case interface{ ValidateAll() error }:
 				if err := v.ValidateAll(); err != nil {
-					errors = append(errors, LdapUserInfoValidationError{
-						field:  "AccountExpires",
+					errors = append(errors, AccountLockoutInfoValidationError{
+						field:  "LockoutTime",
 						reason: "embedded message failed validation",
 						cause:  err,
 					})
 				}
 			case interface{ Validate() error }:
 				if err := v.Validate(); err != nil {
-					errors = append(errors, LdapUserInfoValidationError{
-						field:  "AccountExpires",
+					errors = append(errors, AccountLockoutInfoValidationError{
+						field:  "LockoutTime",
 						reason: "embedded message failed validation",
 						cause:  err,
 					})
 				}
 			}
-		} else if v, ok := interface{}(m.GetAccountExpires()).(interface{ Validate() error }); ok {
+		} else if v, ok := interface{}(m.GetLockoutTime()).(interface{ Validate() error }); ok {
 			if err := v.Validate(); err != nil {
-				return LdapUserInfoValidationError{
-					field:  "AccountExpires",
+				return AccountLockoutInfoValidationError{
+					field:  "LockoutTime",
 					reason: "embedded message failed validation",
 					cause:  err,
 				}
@@ -982,31 +1070,31 @@ func (m *LdapUserInfo) validate(all bool) error {
 
 	}
 
-	if m.PwdLastSet != nil {
+	if m.AttemptsResetTime != nil {
 
 		if all {
-			switch v := interface{}(m.GetPwdLastSet()).(type) {
+			switch v := interface{}(m.GetAttemptsResetTime()).(type) {
 			case interface{ ValidateAll() error }:
 				if err := v.ValidateAll(); err != nil {
-					errors = append(errors, LdapUserInfoValidationError{
-						field:  "PwdLastSet",
+					errors = append(errors, AccountLockoutInfoValidationError{
+						field:  "AttemptsResetTime",
 						reason: "embedded message failed validation",
 						cause:  err,
 					})
 				}
 			case interface{ Validate() error }:
 				if err := v.Validate(); err != nil {
-					errors = append(errors, LdapUserInfoValidationError{
-						field:  "PwdLastSet",
+					errors = append(errors, AccountLockoutInfoValidationError{
+						field:  "AttemptsResetTime",
 						reason: "embedded message failed validation",
 						cause:  err,
 					})
 				}
 			}
-		} else if v, ok := interface{}(m.GetPwdLastSet()).(interface{ Validate() error }); ok {
+		} else if v, ok := interface{}(m.GetAttemptsResetTime()).(interface{ Validate() error }); ok {
 			if err := v.Validate(); err != nil {
-				return LdapUserInfoValidationError{
-					field:  "PwdLastSet",
+				return AccountLockoutInfoValidationError{
+					field:  "AttemptsResetTime",
 					reason: "embedded message failed validation",
 					cause:  err,
 				}
@@ -1015,27 +1103,24 @@ func (m *LdapUserInfo) validate(all bool) error {
 
 	}
 
-	if m.Domain != nil {
-		// no validation rules for Domain
-	}
-
-	if m.OrganizationalUnit != nil {
-		// no validation rules for OrganizationalUnit
+	if m.MaxFailedAttempts != nil {
+		// no validation rules for MaxFailedAttempts
 	}
 
 	if len(errors) > 0 {
-		return LdapUserInfoMultiError(errors)
+		return AccountLockoutInfoMultiError(errors)
 	}
 
 	return nil
 }
 
-// LdapUserInfoMultiError is an error wrapping multiple validation errors
-// returned by LdapUserInfo.ValidateAll() if the designated constraints aren't met.
-type LdapUserInfoMultiError []error
+// AccountLockoutInfoMultiError is an error wrapping multiple validation errors
+// returned by AccountLockoutInfo.ValidateAll() if the designated constraints
+// aren't met.
+type AccountLockoutInfoMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m LdapUserInfoMultiError) Error() string {
+func (m AccountLockoutInfoMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1044,11 +1129,11 @@ func (m LdapUserInfoMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m LdapUserInfoMultiError) AllErrors() []error { return m }
+func (m AccountLockoutInfoMultiError) AllErrors() []error { return m }
 
-// LdapUserInfoValidationError is the validation error returned by
-// LdapUserInfo.Validate if the designated constraints aren't met.
-type LdapUserInfoValidationError struct {
+// AccountLockoutInfoValidationError is the validation error returned by
+// AccountLockoutInfo.Validate if the designated constraints aren't met.
+type AccountLockoutInfoValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1056,22 +1141,24 @@ type LdapUserInfoValidationError struct {
 }
 
 // Field function returns field value.
-func (e LdapUserInfoValidationError) Field() string { return e.field }
+func (e AccountLockoutInfoValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e LdapUserInfoValidationError) Reason() string { return e.reason }
+func (e AccountLockoutInfoValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e LdapUserInfoValidationError) Cause() error { return e.cause }
+func (e AccountLockoutInfoValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e LdapUserInfoValidationError) Key() bool { return e.key }
+func (e AccountLockoutInfoValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e LdapUserInfoValidationError) ErrorName() string { return "LdapUserInfoValidationError" }
+func (e AccountLockoutInfoValidationError) ErrorName() string {
+	return "AccountLockoutInfoValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e LdapUserInfoValidationError) Error() string {
+func (e AccountLockoutInfoValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1083,14 +1170,14 @@ func (e LdapUserInfoValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sLdapUserInfo.%s: %s%s",
+		"invalid %sAccountLockoutInfo.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = LdapUserInfoValidationError{}
+var _ error = AccountLockoutInfoValidationError{}
 
 var _ interface {
 	Field() string
@@ -1098,87 +1185,57 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = LdapUserInfoValidationError{}
+} = AccountLockoutInfoValidationError{}
 
-// Validate checks the field values on RedfishAccountInfo with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the first error encountered is returned, or nil if there are no violations.
-func (m *RedfishAccountInfo) Validate() error {
+// Validate checks the field values on UnixUserInfo with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *UnixUserInfo) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on RedfishAccountInfo with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the result is a list of violation errors wrapped in
-// RedfishAccountInfoMultiError, or nil if none found.
-func (m *RedfishAccountInfo) ValidateAll() error {
+// ValidateAll checks the field values on UnixUserInfo with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in UnixUserInfoMultiError, or
+// nil if none found.
+func (m *UnixUserInfo) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *RedfishAccountInfo) validate(all bool) error {
+func (m *UnixUserInfo) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for RoleId
-
-	if m.AccountId != nil {
-		// no validation rules for AccountId
-	}
+	// no validation rules for Uid
 
-	if m.LockoutPolicy != nil {
+	// no validation rules for Gid
 
-		if all {
-			switch v := interface{}(m.GetLockoutPolicy()).(type) {
-			case interface{ ValidateAll() error }:
-				if err := v.ValidateAll(); err != nil {
-					errors = append(errors, RedfishAccountInfoValidationError{
-						field:  "LockoutPolicy",
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			case interface{ Validate() error }:
-				if err := v.Validate(); err != nil {
-					errors = append(errors, RedfishAccountInfoValidationError{
-						field:  "LockoutPolicy",
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			}
-		} else if v, ok := interface{}(m.GetLockoutPolicy()).(interface{ Validate() error }); ok {
-			if err := v.Validate(); err != nil {
-				return RedfishAccountInfoValidationError{
-					field:  "LockoutPolicy",
-					reason: "embedded message failed validation",
-					cause:  err,
-				}
-			}
-		}
+	// no validation rules for HomeDirectory
 
+	if m.Shell != nil {
+		// no validation rules for Shell
 	}
 
-	if m.PasswordChangeRequired != nil {
-		// no validation rules for PasswordChangeRequired
+	if m.Gecos != nil {
+		// no validation rules for Gecos
 	}
 
 	if len(errors) > 0 {
-		return RedfishAccountInfoMultiError(errors)
+		return UnixUserInfoMultiError(errors)
 	}
 
 	return nil
 }
 
-// RedfishAccountInfoMultiError is an error wrapping multiple validation errors
-// returned by RedfishAccountInfo.ValidateAll() if the designated constraints
-// aren't met.
-type RedfishAccountInfoMultiError []error
+// UnixUserInfoMultiError is an error wrapping multiple validation errors
+// returned by UnixUserInfo.ValidateAll() if the designated constraints aren't met.
+type UnixUserInfoMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m RedfishAccountInfoMultiError) Error() string {
+func (m UnixUserInfoMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1187,11 +1244,11 @@ func (m RedfishAccountInfoMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m RedfishAccountInfoMultiError) AllErrors() []error { return m }
+func (m UnixUserInfoMultiError) AllErrors() []error { return m }
 
-// RedfishAccountInfoValidationError is the validation error returned by
-// RedfishAccountInfo.Validate if the designated constraints aren't met.
-type RedfishAccountInfoValidationError struct {
+// UnixUserInfoValidationError is the validation error returned by
+// UnixUserInfo.Validate if the designated constraints aren't met.
+type UnixUserInfoValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1199,24 +1256,22 @@ type RedfishAccountInfoValidationError struct {
 }
 
 // Field function returns field value.
-func (e RedfishAccountInfoValidationError) Field() string { return e.field }
+func (e UnixUserInfoValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e RedfishAccountInfoValidationError) Reason() string { return e.reason }
+func (e UnixUserInfoValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e RedfishAccountInfoValidationError) Cause() error { return e.cause }
+func (e UnixUserInfoValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e RedfishAccountInfoValidationError) Key() bool { return e.key }
+func (e UnixUserInfoValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e RedfishAccountInfoValidationError) ErrorName() string {
-	return "RedfishAccountInfoValidationError"
-}
+func (e UnixUserInfoValidationError) ErrorName() string { return "UnixUserInfoValidationError" }
 
 // Error satisfies the builtin error interface
-func (e RedfishAccountInfoValidationError) Error() string {
+func (e UnixUserInfoValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1228,14 +1283,14 @@ func (e RedfishAccountInfoValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sRedfishAccountInfo.%s: %s%s",
+		"invalid %sUnixUserInfo.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = RedfishAccountInfoValidationError{}
+var _ error = UnixUserInfoValidationError{}
 
 var _ interface {
 	Field() string
@@ -1243,92 +1298,167 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = RedfishAccountInfoValidationError{}
+} = UnixUserInfoValidationError{}
 
-// Validate checks the field values on RedfishLockoutPolicy with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the first error encountered is returned, or nil if there are no violations.
-func (m *RedfishLockoutPolicy) Validate() error {
+// Validate checks the field values on LdapUserInfo with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *LdapUserInfo) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on RedfishLockoutPolicy with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the result is a list of violation errors wrapped in
-// RedfishLockoutPolicyMultiError, or nil if none found.
-func (m *RedfishLockoutPolicy) ValidateAll() error {
+// ValidateAll checks the field values on LdapUserInfo with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in LdapUserInfoMultiError, or
+// nil if none found.
+func (m *LdapUserInfo) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *RedfishLockoutPolicy) validate(all bool) error {
+func (m *LdapUserInfo) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Threshold
-
-	if m.Duration != nil {
-		// no validation rules for Duration
-	}
+	// no validation rules for LdapDn
 
-	if m.ResetAfter != nil {
-		// no validation rules for ResetAfter
+	if m.ObjectGuid != nil {
+		// no validation rules for ObjectGuid
 	}
 
-	if len(errors) > 0 {
-		return RedfishLockoutPolicyMultiError(errors)
+	if m.SamAccountName != nil {
+		// no validation rules for SamAccountName
 	}
 
-	return nil
-}
-
-// RedfishLockoutPolicyMultiError is an error wrapping multiple validation
-// errors returned by RedfishLockoutPolicy.ValidateAll() if the designated
-// constraints aren't met.
-type RedfishLockoutPolicyMultiError []error
-
-// Error returns a concatenation of all the error messages it wraps.
-func (m RedfishLockoutPolicyMultiError) Error() string {
-	msgs := make([]string, 0, len(m))
-	for _, err := range m {
-		msgs = append(msgs, err.Error())
+	if m.UserPrincipalName != nil {
+		// no validation rules for UserPrincipalName
 	}
-	return strings.Join(msgs, "; ")
-}
 
-// AllErrors returns a list of validation violation errors.
-func (m RedfishLockoutPolicyMultiError) AllErrors() []error { return m }
-
-// RedfishLockoutPolicyValidationError is the validation error returned by
-// RedfishLockoutPolicy.Validate if the designated constraints aren't met.
-type RedfishLockoutPolicyValidationError struct {
-	field  string
-	reason string
-	cause  error
-	key    bool
-}
-
-// Field function returns field value.
-func (e RedfishLockoutPolicyValidationError) Field() string { return e.field }
+	if m.AccountExpires != nil {
 
-// Reason function returns reason value.
-func (e RedfishLockoutPolicyValidationError) Reason() string { return e.reason }
+		if all {
+			switch v := interface{}(m.GetAccountExpires()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, LdapUserInfoValidationError{
+						field:  "AccountExpires",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, LdapUserInfoValidationError{
+						field:  "AccountExpires",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetAccountExpires()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return LdapUserInfoValidationError{
+					field:  "AccountExpires",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if m.PwdLastSet != nil {
+
+		if all {
+			switch v := interface{}(m.GetPwdLastSet()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, LdapUserInfoValidationError{
+						field:  "PwdLastSet",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, LdapUserInfoValidationError{
+						field:  "PwdLastSet",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetPwdLastSet()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return LdapUserInfoValidationError{
+					field:  "PwdLastSet",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if m.Domain != nil {
+		// no validation rules for Domain
+	}
+
+	if m.OrganizationalUnit != nil {
+		// no validation rules for OrganizationalUnit
+	}
+
+	if len(errors) > 0 {
+		return LdapUserInfoMultiError(errors)
+	}
+
+	return nil
+}
+
+// LdapUserInfoMultiError is an error wrapping multiple validation errors
+// returned by LdapUserInfo.ValidateAll() if the designated constraints aren't met.
+type LdapUserInfoMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m LdapUserInfoMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m LdapUserInfoMultiError) AllErrors() []error { return m }
+
+// LdapUserInfoValidationError is the validation error returned by
+// LdapUserInfo.Validate if the designated constraints aren't met.
+type LdapUserInfoValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e LdapUserInfoValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e LdapUserInfoValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e RedfishLockoutPolicyValidationError) Cause() error { return e.cause }
+func (e LdapUserInfoValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e RedfishLockoutPolicyValidationError) Key() bool { return e.key }
+func (e LdapUserInfoValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e RedfishLockoutPolicyValidationError) ErrorName() string {
-	return "RedfishLockoutPolicyValidationError"
-}
+func (e LdapUserInfoValidationError) ErrorName() string { return "LdapUserInfoValidationError" }
 
 // Error satisfies the builtin error interface
-func (e RedfishLockoutPolicyValidationError) Error() string {
+func (e LdapUserInfoValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1340,14 +1470,14 @@ func (e RedfishLockoutPolicyValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sRedfishLockoutPolicy.%s: %s%s",
+		"invalid %sLdapUserInfo.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = RedfishLockoutPolicyValidationError{}
+var _ error = LdapUserInfoValidationError{}
 
 var _ interface {
 	Field() string
@@ -1355,57 +1485,61 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = RedfishLockoutPolicyValidationError{}
+} = LdapUserInfoValidationError{}
 
-// Validate checks the field values on NatsAccountInfo with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// first error encountered is returned, or nil if there are no violations.
-func (m *NatsAccountInfo) Validate() error {
+// Validate checks the field values on RedfishAccountInfo with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RedfishAccountInfo) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on NatsAccountInfo with the rules
+// ValidateAll checks the field values on RedfishAccountInfo with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// NatsAccountInfoMultiError, or nil if none found.
-func (m *NatsAccountInfo) ValidateAll() error {
+// RedfishAccountInfoMultiError, or nil if none found.
+func (m *RedfishAccountInfo) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *NatsAccountInfo) validate(all bool) error {
+func (m *RedfishAccountInfo) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Account
+	// no validation rules for RoleId
 
-	if m.Permissions != nil {
+	if m.AccountId != nil {
+		// no validation rules for AccountId
+	}
+
+	if m.LockoutPolicy != nil {
 
 		if all {
-			switch v := interface{}(m.GetPermissions()).(type) {
+			switch v := interface{}(m.GetLockoutPolicy()).(type) {
 			case interface{ ValidateAll() error }:
 				if err := v.ValidateAll(); err != nil {
-					errors = append(errors, NatsAccountInfoValidationError{
-						field:  "Permissions",
+					errors = append(errors, RedfishAccountInfoValidationError{
+						field:  "LockoutPolicy",
 						reason: "embedded message failed validation",
 						cause:  err,
 					})
 				}
 			case interface{ Validate() error }:
 				if err := v.Validate(); err != nil {
-					errors = append(errors, NatsAccountInfoValidationError{
-						field:  "Permissions",
+					errors = append(errors, RedfishAccountInfoValidationError{
+						field:  "LockoutPolicy",
 						reason: "embedded message failed validation",
 						cause:  err,
 					})
 				}
 			}
-		} else if v, ok := interface{}(m.GetPermissions()).(interface{ Validate() error }); ok {
+		} else if v, ok := interface{}(m.GetLockoutPolicy()).(interface{ Validate() error }); ok {
 			if err := v.Validate(); err != nil {
-				return NatsAccountInfoValidationError{
-					field:  "Permissions",
+				return RedfishAccountInfoValidationError{
+					field:  "LockoutPolicy",
 					reason: "embedded message failed validation",
 					cause:  err,
 				}
@@ -1414,98 +1548,1730 @@ func (m *NatsAccountInfo) validate(all bool) error {
 
 	}
 
-	if m.Limits != nil {
+	if m.PasswordChangeRequired != nil {
+		// no validation rules for PasswordChangeRequired
+	}
 
-		if all {
-			switch v := interface{}(m.GetLimits()).(type) {
-			case interface{ ValidateAll() error }:
-				if err := v.ValidateAll(); err != nil {
-					errors = append(errors, NatsAccountInfoValidationError{
-						field:  "Limits",
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			case interface{ Validate() error }:
-				if err := v.Validate(); err != nil {
-					errors = append(errors, NatsAccountInfoValidationError{
-						field:  "Limits",
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			}
-		} else if v, ok := interface{}(m.GetLimits()).(interface{ Validate() error }); ok {
-			if err := v.Validate(); err != nil {
-				return NatsAccountInfoValidationError{
-					field:  "Limits",
-					reason: "embedded message failed validation",
-					cause:  err,
-				}
-			}
-		}
+	if len(errors) > 0 {
+		return RedfishAccountInfoMultiError(errors)
+	}
+
+	return nil
+}
+
+// RedfishAccountInfoMultiError is an error wrapping multiple validation errors
+// returned by RedfishAccountInfo.ValidateAll() if the designated constraints
+// aren't met.
+type RedfishAccountInfoMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RedfishAccountInfoMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RedfishAccountInfoMultiError) AllErrors() []error { return m }
+
+// RedfishAccountInfoValidationError is the validation error returned by
+// RedfishAccountInfo.Validate if the designated constraints aren't met.
+type RedfishAccountInfoValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RedfishAccountInfoValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RedfishAccountInfoValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RedfishAccountInfoValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RedfishAccountInfoValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RedfishAccountInfoValidationError) ErrorName() string {
+	return "RedfishAccountInfoValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RedfishAccountInfoValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRedfishAccountInfo.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RedfishAccountInfoValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RedfishAccountInfoValidationError{}
+
+// Validate checks the field values on RedfishLockoutPolicy with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RedfishLockoutPolicy) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RedfishLockoutPolicy with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RedfishLockoutPolicyMultiError, or nil if none found.
+func (m *RedfishLockoutPolicy) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RedfishLockoutPolicy) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Threshold
+
+	if m.Duration != nil {
+		// no validation rules for Duration
+	}
+
+	if m.ResetAfter != nil {
+		// no validation rules for ResetAfter
+	}
+
+	if len(errors) > 0 {
+		return RedfishLockoutPolicyMultiError(errors)
+	}
+
+	return nil
+}
+
+// RedfishLockoutPolicyMultiError is an error wrapping multiple validation
+// errors returned by RedfishLockoutPolicy.ValidateAll() if the designated
+// constraints aren't met.
+type RedfishLockoutPolicyMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RedfishLockoutPolicyMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RedfishLockoutPolicyMultiError) AllErrors() []error { return m }
+
+// RedfishLockoutPolicyValidationError is the validation error returned by
+// RedfishLockoutPolicy.Validate if the designated constraints aren't met.
+type RedfishLockoutPolicyValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RedfishLockoutPolicyValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RedfishLockoutPolicyValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RedfishLockoutPolicyValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RedfishLockoutPolicyValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RedfishLockoutPolicyValidationError) ErrorName() string {
+	return "RedfishLockoutPolicyValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RedfishLockoutPolicyValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRedfishLockoutPolicy.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RedfishLockoutPolicyValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RedfishLockoutPolicyValidationError{}
+
+// Validate checks the field values on NatsAccountInfo with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *NatsAccountInfo) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on NatsAccountInfo with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// NatsAccountInfoMultiError, or nil if none found.
+func (m *NatsAccountInfo) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *NatsAccountInfo) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Account
+
+	if m.Permissions != nil {
+
+		if all {
+			switch v := interface{}(m.GetPermissions()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, NatsAccountInfoValidationError{
+						field:  "Permissions",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, NatsAccountInfoValidationError{
+						field:  "Permissions",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetPermissions()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return NatsAccountInfoValidationError{
+					field:  "Permissions",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if m.Limits != nil {
+
+		if all {
+			switch v := interface{}(m.GetLimits()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, NatsAccountInfoValidationError{
+						field:  "Limits",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, NatsAccountInfoValidationError{
+						field:  "Limits",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetLimits()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return NatsAccountInfoValidationError{
+					field:  "Limits",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if m.UserJwt != nil {
+		// no validation rules for UserJwt
+	}
+
+	if m.UserKey != nil {
+		// no validation rules for UserKey
+	}
+
+	if m.UserCred != nil {
+		// no validation rules for UserCred
+	}
+
+	if m.JwtExpiresAt != nil {
+
+		if all {
+			switch v := interface{}(m.GetJwtExpiresAt()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, NatsAccountInfoValidationError{
+						field:  "JwtExpiresAt",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, NatsAccountInfoValidationError{
+						field:  "JwtExpiresAt",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetJwtExpiresAt()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return NatsAccountInfoValidationError{
+					field:  "JwtExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return NatsAccountInfoMultiError(errors)
+	}
+
+	return nil
+}
+
+// NatsAccountInfoMultiError is an error wrapping multiple validation errors
+// returned by NatsAccountInfo.ValidateAll() if the designated constraints
+// aren't met.
+type NatsAccountInfoMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m NatsAccountInfoMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m NatsAccountInfoMultiError) AllErrors() []error { return m }
+
+// NatsAccountInfoValidationError is the validation error returned by
+// NatsAccountInfo.Validate if the designated constraints aren't met.
+type NatsAccountInfoValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e NatsAccountInfoValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e NatsAccountInfoValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e NatsAccountInfoValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e NatsAccountInfoValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e NatsAccountInfoValidationError) ErrorName() string { return "NatsAccountInfoValidationError" }
+
+// Error satisfies the builtin error interface
+func (e NatsAccountInfoValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sNatsAccountInfo.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = NatsAccountInfoValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = NatsAccountInfoValidationError{}
+
+// Validate checks the field values on NatsPermissions with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *NatsPermissions) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on NatsPermissions with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// NatsPermissionsMultiError, or nil if none found.
+func (m *NatsPermissions) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *NatsPermissions) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return NatsPermissionsMultiError(errors)
+	}
+
+	return nil
+}
+
+// NatsPermissionsMultiError is an error wrapping multiple validation errors
+// returned by NatsPermissions.ValidateAll() if the designated constraints
+// aren't met.
+type NatsPermissionsMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m NatsPermissionsMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m NatsPermissionsMultiError) AllErrors() []error { return m }
+
+// NatsPermissionsValidationError is the validation error returned by
+// NatsPermissions.Validate if the designated constraints aren't met.
+type NatsPermissionsValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e NatsPermissionsValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e NatsPermissionsValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e NatsPermissionsValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e NatsPermissionsValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e NatsPermissionsValidationError) ErrorName() string { return "NatsPermissionsValidationError" }
+
+// Error satisfies the builtin error interface
+func (e NatsPermissionsValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sNatsPermissions.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = NatsPermissionsValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = NatsPermissionsValidationError{}
+
+// Validate checks the field values on NatsLimits with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *NatsLimits) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on NatsLimits with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in NatsLimitsMultiError, or
+// nil if none found.
+func (m *NatsLimits) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *NatsLimits) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Data
+
+	// no validation rules for Payload
+
+	// no validation rules for Subs
+
+	if m.Conn != nil {
+		// no validation rules for Conn
+	}
+
+	if m.Leaf != nil {
+		// no validation rules for Leaf
+	}
+
+	if len(errors) > 0 {
+		return NatsLimitsMultiError(errors)
+	}
+
+	return nil
+}
+
+// NatsLimitsMultiError is an error wrapping multiple validation errors
+// returned by NatsLimits.ValidateAll() if the designated constraints aren't met.
+type NatsLimitsMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m NatsLimitsMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m NatsLimitsMultiError) AllErrors() []error { return m }
+
+// NatsLimitsValidationError is the validation error returned by
+// NatsLimits.Validate if the designated constraints aren't met.
+type NatsLimitsValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e NatsLimitsValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e NatsLimitsValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e NatsLimitsValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e NatsLimitsValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e NatsLimitsValidationError) ErrorName() string { return "NatsLimitsValidationError" }
+
+// Error satisfies the builtin error interface
+func (e NatsLimitsValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sNatsLimits.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = NatsLimitsValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = NatsLimitsValidationError{}
+
+// Validate checks the field values on UserLinkingOptions with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UserLinkingOptions) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UserLinkingOptions with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UserLinkingOptionsMultiError, or nil if none found.
+func (m *UserLinkingOptions) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UserLinkingOptions) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for UnixAction
+
+	// no validation rules for LdapAction
+
+	// no validation rules for RedfishAction
+
+	// no validation rules for NatsAction
+
+	if m.ExistingUnixUsername != nil {
+		// no validation rules for ExistingUnixUsername
+	}
+
+	if m.ExistingLdapDn != nil {
+		// no validation rules for ExistingLdapDn
+	}
+
+	if m.ExistingRedfishAccountId != nil {
+		// no validation rules for ExistingRedfishAccountId
+	}
+
+	if m.ExistingNatsAccount != nil {
+		// no validation rules for ExistingNatsAccount
+	}
+
+	if len(errors) > 0 {
+		return UserLinkingOptionsMultiError(errors)
+	}
+
+	return nil
+}
+
+// UserLinkingOptionsMultiError is an error wrapping multiple validation errors
+// returned by UserLinkingOptions.ValidateAll() if the designated constraints
+// aren't met.
+type UserLinkingOptionsMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UserLinkingOptionsMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UserLinkingOptionsMultiError) AllErrors() []error { return m }
+
+// UserLinkingOptionsValidationError is the validation error returned by
+// UserLinkingOptions.Validate if the designated constraints aren't met.
+type UserLinkingOptionsValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UserLinkingOptionsValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UserLinkingOptionsValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UserLinkingOptionsValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UserLinkingOptionsValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UserLinkingOptionsValidationError) ErrorName() string {
+	return "UserLinkingOptionsValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UserLinkingOptionsValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUserLinkingOptions.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UserLinkingOptionsValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UserLinkingOptionsValidationError{}
+
+// Validate checks the field values on CreateUserRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *CreateUserRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateUserRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateUserRequestMultiError, or nil if none found.
+func (m *CreateUserRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateUserRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetUser()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, CreateUserRequestValidationError{
+					field:  "User",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, CreateUserRequestValidationError{
+					field:  "User",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetUser()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return CreateUserRequestValidationError{
+				field:  "User",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if m.Password != nil {
+		// no validation rules for Password
+	}
+
+	if m.LinkingOptions != nil {
+
+		if all {
+			switch v := interface{}(m.GetLinkingOptions()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, CreateUserRequestValidationError{
+						field:  "LinkingOptions",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, CreateUserRequestValidationError{
+						field:  "LinkingOptions",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetLinkingOptions()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return CreateUserRequestValidationError{
+					field:  "LinkingOptions",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if m.DryRun != nil {
+		// no validation rules for DryRun
+	}
+
+	if len(errors) > 0 {
+		return CreateUserRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateUserRequestMultiError is an error wrapping multiple validation errors
+// returned by CreateUserRequest.ValidateAll() if the designated constraints
+// aren't met.
+type CreateUserRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateUserRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateUserRequestMultiError) AllErrors() []error { return m }
+
+// CreateUserRequestValidationError is the validation error returned by
+// CreateUserRequest.Validate if the designated constraints aren't met.
+type CreateUserRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateUserRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateUserRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateUserRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateUserRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateUserRequestValidationError) ErrorName() string {
+	return "CreateUserRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateUserRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateUserRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateUserRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateUserRequestValidationError{}
+
+// Validate checks the field values on CreateUserResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CreateUserResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateUserResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateUserResponseMultiError, or nil if none found.
+func (m *CreateUserResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateUserResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetUser()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, CreateUserResponseValidationError{
+					field:  "User",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, CreateUserResponseValidationError{
+					field:  "User",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetUser()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return CreateUserResponseValidationError{
+				field:  "User",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return CreateUserResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateUserResponseMultiError is an error wrapping multiple validation errors
+// returned by CreateUserResponse.ValidateAll() if the designated constraints
+// aren't met.
+type CreateUserResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateUserResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateUserResponseMultiError) AllErrors() []error { return m }
+
+// CreateUserResponseValidationError is the validation error returned by
+// CreateUserResponse.Validate if the designated constraints aren't met.
+type CreateUserResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateUserResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateUserResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateUserResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateUserResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateUserResponseValidationError) ErrorName() string {
+	return "CreateUserResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateUserResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateUserResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateUserResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateUserResponseValidationError{}
+
+// Validate checks the field values on GetUserRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *GetUserRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetUserRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in GetUserRequestMultiError,
+// or nil if none found.
+func (m *GetUserRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetUserRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	switch v := m.Identifier.(type) {
+	case *GetUserRequest_Id:
+		if v == nil {
+			err := GetUserRequestValidationError{
+				field:  "Identifier",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		// no validation rules for Id
+	case *GetUserRequest_Username:
+		if v == nil {
+			err := GetUserRequestValidationError{
+				field:  "Identifier",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		// no validation rules for Username
+	case *GetUserRequest_Email:
+		if v == nil {
+			err := GetUserRequestValidationError{
+				field:  "Identifier",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		// no validation rules for Email
+	default:
+		_ = v // ensures v is used
+	}
+
+	if m.FieldMask != nil {
+
+		if all {
+			switch v := interface{}(m.GetFieldMask()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, GetUserRequestValidationError{
+						field:  "FieldMask",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, GetUserRequestValidationError{
+						field:  "FieldMask",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetFieldMask()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return GetUserRequestValidationError{
+					field:  "FieldMask",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return GetUserRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetUserRequestMultiError is an error wrapping multiple validation errors
+// returned by GetUserRequest.ValidateAll() if the designated constraints
+// aren't met.
+type GetUserRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetUserRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetUserRequestMultiError) AllErrors() []error { return m }
+
+// GetUserRequestValidationError is the validation error returned by
+// GetUserRequest.Validate if the designated constraints aren't met.
+type GetUserRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetUserRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetUserRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetUserRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetUserRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetUserRequestValidationError) ErrorName() string { return "GetUserRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GetUserRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetUserRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetUserRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetUserRequestValidationError{}
+
+// Validate checks the field values on GetUserResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *GetUserResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetUserResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetUserResponseMultiError, or nil if none found.
+func (m *GetUserResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetUserResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetUser()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetUserResponseValidationError{
+					field:  "User",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetUserResponseValidationError{
+					field:  "User",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetUser()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetUserResponseValidationError{
+				field:  "User",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return GetUserResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetUserResponseMultiError is an error wrapping multiple validation errors
+// returned by GetUserResponse.ValidateAll() if the designated constraints
+// aren't met.
+type GetUserResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetUserResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetUserResponseMultiError) AllErrors() []error { return m }
+
+// GetUserResponseValidationError is the validation error returned by
+// GetUserResponse.Validate if the designated constraints aren't met.
+type GetUserResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetUserResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetUserResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetUserResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetUserResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetUserResponseValidationError) ErrorName() string { return "GetUserResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GetUserResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetUserResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetUserResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetUserResponseValidationError{}
+
+// Validate checks the field values on UpdateUserRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *UpdateUserRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UpdateUserRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UpdateUserRequestMultiError, or nil if none found.
+func (m *UpdateUserRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UpdateUserRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetUser()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UpdateUserRequestValidationError{
+					field:  "User",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UpdateUserRequestValidationError{
+					field:  "User",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetUser()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UpdateUserRequestValidationError{
+				field:  "User",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetFieldMask()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UpdateUserRequestValidationError{
+					field:  "FieldMask",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UpdateUserRequestValidationError{
+					field:  "FieldMask",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetFieldMask()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UpdateUserRequestValidationError{
+				field:  "FieldMask",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if m.LinkingOptions != nil {
+
+		if all {
+			switch v := interface{}(m.GetLinkingOptions()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, UpdateUserRequestValidationError{
+						field:  "LinkingOptions",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, UpdateUserRequestValidationError{
+						field:  "LinkingOptions",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetLinkingOptions()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return UpdateUserRequestValidationError{
+					field:  "LinkingOptions",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return UpdateUserRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// UpdateUserRequestMultiError is an error wrapping multiple validation errors
+// returned by UpdateUserRequest.ValidateAll() if the designated constraints
+// aren't met.
+type UpdateUserRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UpdateUserRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UpdateUserRequestMultiError) AllErrors() []error { return m }
+
+// UpdateUserRequestValidationError is the validation error returned by
+// UpdateUserRequest.Validate if the designated constraints aren't met.
+type UpdateUserRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UpdateUserRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UpdateUserRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UpdateUserRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UpdateUserRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UpdateUserRequestValidationError) ErrorName() string {
+	return "UpdateUserRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UpdateUserRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpdateUserRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UpdateUserRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UpdateUserRequestValidationError{}
+
+// Validate checks the field values on UpdateUserResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UpdateUserResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UpdateUserResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UpdateUserResponseMultiError, or nil if none found.
+func (m *UpdateUserResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UpdateUserResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetUser()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UpdateUserResponseValidationError{
+					field:  "User",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UpdateUserResponseValidationError{
+					field:  "User",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetUser()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UpdateUserResponseValidationError{
+				field:  "User",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return UpdateUserResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// UpdateUserResponseMultiError is an error wrapping multiple validation errors
+// returned by UpdateUserResponse.ValidateAll() if the designated constraints
+// aren't met.
+type UpdateUserResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UpdateUserResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UpdateUserResponseMultiError) AllErrors() []error { return m }
+
+// UpdateUserResponseValidationError is the validation error returned by
+// UpdateUserResponse.Validate if the designated constraints aren't met.
+type UpdateUserResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UpdateUserResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UpdateUserResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UpdateUserResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UpdateUserResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UpdateUserResponseValidationError) ErrorName() string {
+	return "UpdateUserResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UpdateUserResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpdateUserResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UpdateUserResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UpdateUserResponseValidationError{}
 
-	}
+// Validate checks the field values on DeleteUserRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *DeleteUserRequest) Validate() error {
+	return m.validate(false)
+}
 
-	if m.UserJwt != nil {
-		// no validation rules for UserJwt
-	}
+// ValidateAll checks the field values on DeleteUserRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DeleteUserRequestMultiError, or nil if none found.
+func (m *DeleteUserRequest) ValidateAll() error {
+	return m.validate(true)
+}
 
-	if m.UserKey != nil {
-		// no validation rules for UserKey
+func (m *DeleteUserRequest) validate(all bool) error {
+	if m == nil {
+		return nil
 	}
 
-	if m.UserCred != nil {
-		// no validation rules for UserCred
-	}
+	var errors []error
 
-	if m.JwtExpiresAt != nil {
+	// no validation rules for Id
 
-		if all {
-			switch v := interface{}(m.GetJwtExpiresAt()).(type) {
-			case interface{ ValidateAll() error }:
-				if err := v.ValidateAll(); err != nil {
-					errors = append(errors, NatsAccountInfoValidationError{
-						field:  "JwtExpiresAt",
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			case interface{ Validate() error }:
-				if err := v.Validate(); err != nil {
-					errors = append(errors, NatsAccountInfoValidationError{
-						field:  "JwtExpiresAt",
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			}
-		} else if v, ok := interface{}(m.GetJwtExpiresAt()).(interface{ Validate() error }); ok {
-			if err := v.Validate(); err != nil {
-				return NatsAccountInfoValidationError{
-					field:  "JwtExpiresAt",
-					reason: "embedded message failed validation",
-					cause:  err,
-				}
-			}
-		}
+	if m.CascadeDelete != nil {
+		// no validation rules for CascadeDelete
+	}
 
+	if m.BackupData != nil {
+		// no validation rules for BackupData
 	}
 
 	if len(errors) > 0 {
-		return NatsAccountInfoMultiError(errors)
+		return DeleteUserRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// NatsAccountInfoMultiError is an error wrapping multiple validation errors
-// returned by NatsAccountInfo.ValidateAll() if the designated constraints
+// DeleteUserRequestMultiError is an error wrapping multiple validation errors
+// returned by DeleteUserRequest.ValidateAll() if the designated constraints
 // aren't met.
-type NatsAccountInfoMultiError []error
+type DeleteUserRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m NatsAccountInfoMultiError) Error() string {
+func (m DeleteUserRequestMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1514,11 +3280,11 @@ func (m NatsAccountInfoMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m NatsAccountInfoMultiError) AllErrors() []error { return m }
+func (m DeleteUserRequestMultiError) AllErrors() []error { return m }
 
-// NatsAccountInfoValidationError is the validation error returned by
-// NatsAccountInfo.Validate if the designated constraints aren't met.
-type NatsAccountInfoValidationError struct {
+// DeleteUserRequestValidationError is the validation error returned by
+// DeleteUserRequest.Validate if the designated constraints aren't met.
+type DeleteUserRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1526,22 +3292,24 @@ type NatsAccountInfoValidationError struct {
 }
 
 // Field function returns field value.
-func (e NatsAccountInfoValidationError) Field() string { return e.field }
+func (e DeleteUserRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e NatsAccountInfoValidationError) Reason() string { return e.reason }
+func (e DeleteUserRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e NatsAccountInfoValidationError) Cause() error { return e.cause }
+func (e DeleteUserRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e NatsAccountInfoValidationError) Key() bool { return e.key }
+func (e DeleteUserRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e NatsAccountInfoValidationError) ErrorName() string { return "NatsAccountInfoValidationError" }
+func (e DeleteUserRequestValidationError) ErrorName() string {
+	return "DeleteUserRequestValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e NatsAccountInfoValidationError) Error() string {
+func (e DeleteUserRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1553,14 +3321,14 @@ func (e NatsAccountInfoValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sNatsAccountInfo.%s: %s%s",
+		"invalid %sDeleteUserRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = NatsAccountInfoValidationError{}
+var _ error = DeleteUserRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -1568,44 +3336,50 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = NatsAccountInfoValidationError{}
+} = DeleteUserRequestValidationError{}
 
-// Validate checks the field values on NatsPermissions with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// first error encountered is returned, or nil if there are no violations.
-func (m *NatsPermissions) Validate() error {
+// Validate checks the field values on DeleteUserResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *DeleteUserResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on NatsPermissions with the rules
+// ValidateAll checks the field values on DeleteUserResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// NatsPermissionsMultiError, or nil if none found.
-func (m *NatsPermissions) ValidateAll() error {
+// DeleteUserResponseMultiError, or nil if none found.
+func (m *DeleteUserResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *NatsPermissions) validate(all bool) error {
+func (m *DeleteUserResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
+	// no validation rules for Success
+
+	if m.BackupLocation != nil {
+		// no validation rules for BackupLocation
+	}
+
 	if len(errors) > 0 {
-		return NatsPermissionsMultiError(errors)
+		return DeleteUserResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// NatsPermissionsMultiError is an error wrapping multiple validation errors
-// returned by NatsPermissions.ValidateAll() if the designated constraints
+// DeleteUserResponseMultiError is an error wrapping multiple validation errors
+// returned by DeleteUserResponse.ValidateAll() if the designated constraints
 // aren't met.
-type NatsPermissionsMultiError []error
+type DeleteUserResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m NatsPermissionsMultiError) Error() string {
+func (m DeleteUserResponseMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1614,11 +3388,11 @@ func (m NatsPermissionsMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m NatsPermissionsMultiError) AllErrors() []error { return m }
+func (m DeleteUserResponseMultiError) AllErrors() []error { return m }
 
-// NatsPermissionsValidationError is the validation error returned by
-// NatsPermissions.Validate if the designated constraints aren't met.
-type NatsPermissionsValidationError struct {
+// DeleteUserResponseValidationError is the validation error returned by
+// DeleteUserResponse.Validate if the designated constraints aren't met.
+type DeleteUserResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1626,22 +3400,24 @@ type NatsPermissionsValidationError struct {
 }
 
 // Field function returns field value.
-func (e NatsPermissionsValidationError) Field() string { return e.field }
+func (e DeleteUserResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e NatsPermissionsValidationError) Reason() string { return e.reason }
+func (e DeleteUserResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e NatsPermissionsValidationError) Cause() error { return e.cause }
+func (e DeleteUserResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e NatsPermissionsValidationError) Key() bool { return e.key }
+func (e DeleteUserResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e NatsPermissionsValidationError) ErrorName() string { return "NatsPermissionsValidationError" }
+func (e DeleteUserResponseValidationError) ErrorName() string {
+	return "DeleteUserResponseValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e NatsPermissionsValidationError) Error() string {
+func (e DeleteUserResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1653,14 +3429,14 @@ func (e NatsPermissionsValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sNatsPermissions.%s: %s%s",
+		"invalid %sDeleteUserResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = NatsPermissionsValidationError{}
+var _ error = DeleteUserResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -1668,57 +3444,97 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = NatsPermissionsValidationError{}
+} = DeleteUserResponseValidationError{}
 
-// Validate checks the field values on NatsLimits with the rules defined in the
-// proto definition for this message. If any rules are violated, the first
-// error encountered is returned, or nil if there are no violations.
-func (m *NatsLimits) Validate() error {
+// Validate checks the field values on ListUsersRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ListUsersRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on NatsLimits with the rules defined in
-// the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in NatsLimitsMultiError, or
-// nil if none found.
-func (m *NatsLimits) ValidateAll() error {
+// ValidateAll checks the field values on ListUsersRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListUsersRequestMultiError, or nil if none found.
+func (m *ListUsersRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *NatsLimits) validate(all bool) error {
+func (m *ListUsersRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Data
+	if m.Source != nil {
+		// no validation rules for Source
+	}
 
-	// no validation rules for Payload
+	if m.Enabled != nil {
+		// no validation rules for Enabled
+	}
 
-	// no validation rules for Subs
+	if m.UsernamePrefix != nil {
+		// no validation rules for UsernamePrefix
+	}
+
+	if m.FieldMask != nil {
+
+		if all {
+			switch v := interface{}(m.GetFieldMask()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListUsersRequestValidationError{
+						field:  "FieldMask",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListUsersRequestValidationError{
+						field:  "FieldMask",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetFieldMask()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListUsersRequestValidationError{
+					field:  "FieldMask",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
 
-	if m.Conn != nil {
-		// no validation rules for Conn
 	}
 
-	if m.Leaf != nil {
-		// no validation rules for Leaf
+	if m.PageSize != nil {
+		// no validation rules for PageSize
+	}
+
+	if m.PageToken != nil {
+		// no validation rules for PageToken
 	}
 
 	if len(errors) > 0 {
-		return NatsLimitsMultiError(errors)
+		return ListUsersRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// NatsLimitsMultiError is an error wrapping multiple validation errors
-// returned by NatsLimits.ValidateAll() if the designated constraints aren't met.
-type NatsLimitsMultiError []error
+// ListUsersRequestMultiError is an error wrapping multiple validation errors
+// returned by ListUsersRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ListUsersRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m NatsLimitsMultiError) Error() string {
+func (m ListUsersRequestMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1727,11 +3543,11 @@ func (m NatsLimitsMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m NatsLimitsMultiError) AllErrors() []error { return m }
+func (m ListUsersRequestMultiError) AllErrors() []error { return m }
 
-// NatsLimitsValidationError is the validation error returned by
-// NatsLimits.Validate if the designated constraints aren't met.
-type NatsLimitsValidationError struct {
+// ListUsersRequestValidationError is the validation error returned by
+// ListUsersRequest.Validate if the designated constraints aren't met.
+type ListUsersRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1739,22 +3555,22 @@ type NatsLimitsValidationError struct {
 }
 
 // Field function returns field value.
-func (e NatsLimitsValidationError) Field() string { return e.field }
+func (e ListUsersRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e NatsLimitsValidationError) Reason() string { return e.reason }
+func (e ListUsersRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e NatsLimitsValidationError) Cause() error { return e.cause }
+func (e ListUsersRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e NatsLimitsValidationError) Key() bool { return e.key }
+func (e ListUsersRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e NatsLimitsValidationError) ErrorName() string { return "NatsLimitsValidationError" }
+func (e ListUsersRequestValidationError) ErrorName() string { return "ListUsersRequestValidationError" }
 
 // Error satisfies the builtin error interface
-func (e NatsLimitsValidationError) Error() string {
+func (e ListUsersRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1766,14 +3582,14 @@ func (e NatsLimitsValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sNatsLimits.%s: %s%s",
+		"invalid %sListUsersRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = NatsLimitsValidationError{}
+var _ error = ListUsersRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -1781,68 +3597,82 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = NatsLimitsValidationError{}
+} = ListUsersRequestValidationError{}
 
-// Validate checks the field values on UserLinkingOptions with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the first error encountered is returned, or nil if there are no violations.
-func (m *UserLinkingOptions) Validate() error {
+// Validate checks the field values on ListUsersResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ListUsersResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on UserLinkingOptions with the rules
+// ValidateAll checks the field values on ListUsersResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// UserLinkingOptionsMultiError, or nil if none found.
-func (m *UserLinkingOptions) ValidateAll() error {
+// ListUsersResponseMultiError, or nil if none found.
+func (m *ListUsersResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *UserLinkingOptions) validate(all bool) error {
+func (m *ListUsersResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for UnixAction
-
-	// no validation rules for LdapAction
-
-	// no validation rules for RedfishAction
-
-	// no validation rules for NatsAction
-
-	if m.ExistingUnixUsername != nil {
-		// no validation rules for ExistingUnixUsername
-	}
+	for idx, item := range m.GetUsers() {
+		_, _ = idx, item
 
-	if m.ExistingLdapDn != nil {
-		// no validation rules for ExistingLdapDn
-	}
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListUsersResponseValidationError{
+						field:  fmt.Sprintf("Users[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListUsersResponseValidationError{
+						field:  fmt.Sprintf("Users[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListUsersResponseValidationError{
+					field:  fmt.Sprintf("Users[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
 
-	if m.ExistingRedfishAccountId != nil {
-		// no validation rules for ExistingRedfishAccountId
 	}
 
-	if m.ExistingNatsAccount != nil {
-		// no validation rules for ExistingNatsAccount
+	if m.NextPageToken != nil {
+		// no validation rules for NextPageToken
 	}
 
 	if len(errors) > 0 {
-		return UserLinkingOptionsMultiError(errors)
+		return ListUsersResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// UserLinkingOptionsMultiError is an error wrapping multiple validation errors
-// returned by UserLinkingOptions.ValidateAll() if the designated constraints
+// ListUsersResponseMultiError is an error wrapping multiple validation errors
+// returned by ListUsersResponse.ValidateAll() if the designated constraints
 // aren't met.
-type UserLinkingOptionsMultiError []error
+type ListUsersResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m UserLinkingOptionsMultiError) Error() string {
+func (m ListUsersResponseMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1851,11 +3681,11 @@ func (m UserLinkingOptionsMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m UserLinkingOptionsMultiError) AllErrors() []error { return m }
+func (m ListUsersResponseMultiError) AllErrors() []error { return m }
 
-// UserLinkingOptionsValidationError is the validation error returned by
-// UserLinkingOptions.Validate if the designated constraints aren't met.
-type UserLinkingOptionsValidationError struct {
+// ListUsersResponseValidationError is the validation error returned by
+// ListUsersResponse.Validate if the designated constraints aren't met.
+type ListUsersResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1863,24 +3693,24 @@ type UserLinkingOptionsValidationError struct {
 }
 
 // Field function returns field value.
-func (e UserLinkingOptionsValidationError) Field() string { return e.field }
+func (e ListUsersResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e UserLinkingOptionsValidationError) Reason() string { return e.reason }
+func (e ListUsersResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e UserLinkingOptionsValidationError) Cause() error { return e.cause }
+func (e ListUsersResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e UserLinkingOptionsValidationError) Key() bool { return e.key }
+func (e ListUsersResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e UserLinkingOptionsValidationError) ErrorName() string {
-	return "UserLinkingOptionsValidationError"
+func (e ListUsersResponseValidationError) ErrorName() string {
+	return "ListUsersResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e UserLinkingOptionsValidationError) Error() string {
+func (e ListUsersResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1892,14 +3722,14 @@ func (e UserLinkingOptionsValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sUserLinkingOptions.%s: %s%s",
+		"invalid %sListUsersResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = UserLinkingOptionsValidationError{}
+var _ error = ListUsersResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -1907,114 +3737,50 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = UserLinkingOptionsValidationError{}
+} = ListUsersResponseValidationError{}
 
-// Validate checks the field values on CreateUserRequest with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// first error encountered is returned, or nil if there are no violations.
-func (m *CreateUserRequest) Validate() error {
+// Validate checks the field values on ChangePasswordRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ChangePasswordRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on CreateUserRequest with the rules
+// ValidateAll checks the field values on ChangePasswordRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// CreateUserRequestMultiError, or nil if none found.
-func (m *CreateUserRequest) ValidateAll() error {
+// ChangePasswordRequestMultiError, or nil if none found.
+func (m *ChangePasswordRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *CreateUserRequest) validate(all bool) error {
+func (m *ChangePasswordRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if all {
-		switch v := interface{}(m.GetUser()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, CreateUserRequestValidationError{
-					field:  "User",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, CreateUserRequestValidationError{
-					field:  "User",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetUser()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return CreateUserRequestValidationError{
-				field:  "User",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
-		}
-	}
-
-	if m.Password != nil {
-		// no validation rules for Password
-	}
-
-	if m.LinkingOptions != nil {
-
-		if all {
-			switch v := interface{}(m.GetLinkingOptions()).(type) {
-			case interface{ ValidateAll() error }:
-				if err := v.ValidateAll(); err != nil {
-					errors = append(errors, CreateUserRequestValidationError{
-						field:  "LinkingOptions",
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			case interface{ Validate() error }:
-				if err := v.Validate(); err != nil {
-					errors = append(errors, CreateUserRequestValidationError{
-						field:  "LinkingOptions",
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			}
-		} else if v, ok := interface{}(m.GetLinkingOptions()).(interface{ Validate() error }); ok {
-			if err := v.Validate(); err != nil {
-				return CreateUserRequestValidationError{
-					field:  "LinkingOptions",
-					reason: "embedded message failed validation",
-					cause:  err,
-				}
-			}
-		}
+	// no validation rules for Id
 
-	}
+	// no validation rules for CurrentPassword
 
-	if m.DryRun != nil {
-		// no validation rules for DryRun
-	}
+	// no validation rules for NewPassword
 
 	if len(errors) > 0 {
-		return CreateUserRequestMultiError(errors)
+		return ChangePasswordRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// CreateUserRequestMultiError is an error wrapping multiple validation errors
-// returned by CreateUserRequest.ValidateAll() if the designated constraints
-// aren't met.
-type CreateUserRequestMultiError []error
+// ChangePasswordRequestMultiError is an error wrapping multiple validation
+// errors returned by ChangePasswordRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ChangePasswordRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m CreateUserRequestMultiError) Error() string {
+func (m ChangePasswordRequestMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2023,11 +3789,11 @@ func (m CreateUserRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m CreateUserRequestMultiError) AllErrors() []error { return m }
+func (m ChangePasswordRequestMultiError) AllErrors() []error { return m }
 
-// CreateUserRequestValidationError is the validation error returned by
-// CreateUserRequest.Validate if the designated constraints aren't met.
-type CreateUserRequestValidationError struct {
+// ChangePasswordRequestValidationError is the validation error returned by
+// ChangePasswordRequest.Validate if the designated constraints aren't met.
+type ChangePasswordRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2035,24 +3801,24 @@ type CreateUserRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e CreateUserRequestValidationError) Field() string { return e.field }
+func (e ChangePasswordRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e CreateUserRequestValidationError) Reason() string { return e.reason }
+func (e ChangePasswordRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e CreateUserRequestValidationError) Cause() error { return e.cause }
+func (e ChangePasswordRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e CreateUserRequestValidationError) Key() bool { return e.key }
+func (e ChangePasswordRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e CreateUserRequestValidationError) ErrorName() string {
-	return "CreateUserRequestValidationError"
+func (e ChangePasswordRequestValidationError) ErrorName() string {
+	return "ChangePasswordRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e CreateUserRequestValidationError) Error() string {
+func (e ChangePasswordRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2064,14 +3830,14 @@ func (e CreateUserRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sCreateUserRequest.%s: %s%s",
+		"invalid %sChangePasswordRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = CreateUserRequestValidationError{}
+var _ error = ChangePasswordRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -2079,73 +3845,50 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = CreateUserRequestValidationError{}
+} = ChangePasswordRequestValidationError{}
 
-// Validate checks the field values on CreateUserResponse with the rules
+// Validate checks the field values on ChangePasswordResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *CreateUserResponse) Validate() error {
+func (m *ChangePasswordResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on CreateUserResponse with the rules
+// ValidateAll checks the field values on ChangePasswordResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// CreateUserResponseMultiError, or nil if none found.
-func (m *CreateUserResponse) ValidateAll() error {
+// ChangePasswordResponseMultiError, or nil if none found.
+func (m *ChangePasswordResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *CreateUserResponse) validate(all bool) error {
+func (m *ChangePasswordResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if all {
-		switch v := interface{}(m.GetUser()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, CreateUserResponseValidationError{
-					field:  "User",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, CreateUserResponseValidationError{
-					field:  "User",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetUser()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return CreateUserResponseValidationError{
-				field:  "User",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
-		}
+	// no validation rules for Success
+
+	if m.FailureReason != nil {
+		// no validation rules for FailureReason
 	}
 
 	if len(errors) > 0 {
-		return CreateUserResponseMultiError(errors)
+		return ChangePasswordResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// CreateUserResponseMultiError is an error wrapping multiple validation errors
-// returned by CreateUserResponse.ValidateAll() if the designated constraints
-// aren't met.
-type CreateUserResponseMultiError []error
+// ChangePasswordResponseMultiError is an error wrapping multiple validation
+// errors returned by ChangePasswordResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ChangePasswordResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m CreateUserResponseMultiError) Error() string {
+func (m ChangePasswordResponseMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2154,11 +3897,11 @@ func (m CreateUserResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m CreateUserResponseMultiError) AllErrors() []error { return m }
+func (m ChangePasswordResponseMultiError) AllErrors() []error { return m }
 
-// CreateUserResponseValidationError is the validation error returned by
-// CreateUserResponse.Validate if the designated constraints aren't met.
-type CreateUserResponseValidationError struct {
+// ChangePasswordResponseValidationError is the validation error returned by
+// ChangePasswordResponse.Validate if the designated constraints aren't met.
+type ChangePasswordResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2166,24 +3909,24 @@ type CreateUserResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e CreateUserResponseValidationError) Field() string { return e.field }
+func (e ChangePasswordResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e CreateUserResponseValidationError) Reason() string { return e.reason }
+func (e ChangePasswordResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e CreateUserResponseValidationError) Cause() error { return e.cause }
+func (e ChangePasswordResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e CreateUserResponseValidationError) Key() bool { return e.key }
+func (e ChangePasswordResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e CreateUserResponseValidationError) ErrorName() string {
-	return "CreateUserResponseValidationError"
+func (e ChangePasswordResponseValidationError) ErrorName() string {
+	return "ChangePasswordResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e CreateUserResponseValidationError) Error() string {
+func (e ChangePasswordResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2195,14 +3938,14 @@ func (e CreateUserResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sCreateUserResponse.%s: %s%s",
+		"invalid %sChangePasswordResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = CreateUserResponseValidationError{}
+var _ error = ChangePasswordResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -2210,118 +3953,58 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = CreateUserResponseValidationError{}
+} = ChangePasswordResponseValidationError{}
 
-// Validate checks the field values on GetUserRequest with the rules defined in
-// the proto definition for this message. If any rules are violated, the first
-// error encountered is returned, or nil if there are no violations.
-func (m *GetUserRequest) Validate() error {
+// Validate checks the field values on ResetPasswordRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ResetPasswordRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on GetUserRequest with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in GetUserRequestMultiError,
-// or nil if none found.
-func (m *GetUserRequest) ValidateAll() error {
+// ValidateAll checks the field values on ResetPasswordRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ResetPasswordRequestMultiError, or nil if none found.
+func (m *ResetPasswordRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *GetUserRequest) validate(all bool) error {
+func (m *ResetPasswordRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	switch v := m.Identifier.(type) {
-	case *GetUserRequest_Id:
-		if v == nil {
-			err := GetUserRequestValidationError{
-				field:  "Identifier",
-				reason: "oneof value cannot be a typed-nil",
-			}
-			if !all {
-				return err
-			}
-			errors = append(errors, err)
-		}
-		// no validation rules for Id
-	case *GetUserRequest_Username:
-		if v == nil {
-			err := GetUserRequestValidationError{
-				field:  "Identifier",
-				reason: "oneof value cannot be a typed-nil",
-			}
-			if !all {
-				return err
-			}
-			errors = append(errors, err)
-		}
-		// no validation rules for Username
-	case *GetUserRequest_Email:
-		if v == nil {
-			err := GetUserRequestValidationError{
-				field:  "Identifier",
-				reason: "oneof value cannot be a typed-nil",
-			}
-			if !all {
-				return err
-			}
-			errors = append(errors, err)
-		}
-		// no validation rules for Email
-	default:
-		_ = v // ensures v is used
-	}
+	// no validation rules for Id
 
-	if m.FieldMask != nil {
+	if m.NewPassword != nil {
+		// no validation rules for NewPassword
+	}
 
-		if all {
-			switch v := interface{}(m.GetFieldMask()).(type) {
-			case interface{ ValidateAll() error }:
-				if err := v.ValidateAll(); err != nil {
-					errors = append(errors, GetUserRequestValidationError{
-						field:  "FieldMask",
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			case interface{ Validate() error }:
-				if err := v.Validate(); err != nil {
-					errors = append(errors, GetUserRequestValidationError{
-						field:  "FieldMask",
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			}
-		} else if v, ok := interface{}(m.GetFieldMask()).(interface{ Validate() error }); ok {
-			if err := v.Validate(); err != nil {
-				return GetUserRequestValidationError{
-					field:  "FieldMask",
-					reason: "embedded message failed validation",
-					cause:  err,
-				}
-			}
-		}
+	if m.Force != nil {
+		// no validation rules for Force
+	}
 
+	if m.GeneratePassword != nil {
+		// no validation rules for GeneratePassword
 	}
 
 	if len(errors) > 0 {
-		return GetUserRequestMultiError(errors)
+		return ResetPasswordRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// GetUserRequestMultiError is an error wrapping multiple validation errors
-// returned by GetUserRequest.ValidateAll() if the designated constraints
-// aren't met.
-type GetUserRequestMultiError []error
+// ResetPasswordRequestMultiError is an error wrapping multiple validation
+// errors returned by ResetPasswordRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ResetPasswordRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m GetUserRequestMultiError) Error() string {
+func (m ResetPasswordRequestMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2330,11 +4013,11 @@ func (m GetUserRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m GetUserRequestMultiError) AllErrors() []error { return m }
+func (m ResetPasswordRequestMultiError) AllErrors() []error { return m }
 
-// GetUserRequestValidationError is the validation error returned by
-// GetUserRequest.Validate if the designated constraints aren't met.
-type GetUserRequestValidationError struct {
+// ResetPasswordRequestValidationError is the validation error returned by
+// ResetPasswordRequest.Validate if the designated constraints aren't met.
+type ResetPasswordRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2342,22 +4025,24 @@ type GetUserRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e GetUserRequestValidationError) Field() string { return e.field }
+func (e ResetPasswordRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e GetUserRequestValidationError) Reason() string { return e.reason }
+func (e ResetPasswordRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e GetUserRequestValidationError) Cause() error { return e.cause }
+func (e ResetPasswordRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e GetUserRequestValidationError) Key() bool { return e.key }
+func (e ResetPasswordRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e GetUserRequestValidationError) ErrorName() string { return "GetUserRequestValidationError" }
+func (e ResetPasswordRequestValidationError) ErrorName() string {
+	return "ResetPasswordRequestValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e GetUserRequestValidationError) Error() string {
+func (e ResetPasswordRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2369,14 +4054,14 @@ func (e GetUserRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sGetUserRequest.%s: %s%s",
+		"invalid %sResetPasswordRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = GetUserRequestValidationError{}
+var _ error = ResetPasswordRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -2384,73 +4069,52 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = GetUserRequestValidationError{}
+} = ResetPasswordRequestValidationError{}
 
-// Validate checks the field values on GetUserResponse with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// first error encountered is returned, or nil if there are no violations.
-func (m *GetUserResponse) Validate() error {
+// Validate checks the field values on ResetPasswordResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ResetPasswordResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on GetUserResponse with the rules
+// ValidateAll checks the field values on ResetPasswordResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// GetUserResponseMultiError, or nil if none found.
-func (m *GetUserResponse) ValidateAll() error {
+// ResetPasswordResponseMultiError, or nil if none found.
+func (m *ResetPasswordResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *GetUserResponse) validate(all bool) error {
+func (m *ResetPasswordResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if all {
-		switch v := interface{}(m.GetUser()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, GetUserResponseValidationError{
-					field:  "User",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, GetUserResponseValidationError{
-					field:  "User",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetUser()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return GetUserResponseValidationError{
-				field:  "User",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
-		}
+	// no validation rules for NewPassword
+
+	// no validation rules for Success
+
+	if m.FailureReason != nil {
+		// no validation rules for FailureReason
 	}
 
 	if len(errors) > 0 {
-		return GetUserResponseMultiError(errors)
+		return ResetPasswordResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// GetUserResponseMultiError is an error wrapping multiple validation errors
-// returned by GetUserResponse.ValidateAll() if the designated constraints
-// aren't met.
-type GetUserResponseMultiError []error
+// ResetPasswordResponseMultiError is an error wrapping multiple validation
+// errors returned by ResetPasswordResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ResetPasswordResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m GetUserResponseMultiError) Error() string {
+func (m ResetPasswordResponseMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2459,11 +4123,11 @@ func (m GetUserResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m GetUserResponseMultiError) AllErrors() []error { return m }
+func (m ResetPasswordResponseMultiError) AllErrors() []error { return m }
 
-// GetUserResponseValidationError is the validation error returned by
-// GetUserResponse.Validate if the designated constraints aren't met.
-type GetUserResponseValidationError struct {
+// ResetPasswordResponseValidationError is the validation error returned by
+// ResetPasswordResponse.Validate if the designated constraints aren't met.
+type ResetPasswordResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2471,22 +4135,24 @@ type GetUserResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e GetUserResponseValidationError) Field() string { return e.field }
+func (e ResetPasswordResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e GetUserResponseValidationError) Reason() string { return e.reason }
+func (e ResetPasswordResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e GetUserResponseValidationError) Cause() error { return e.cause }
+func (e ResetPasswordResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e GetUserResponseValidationError) Key() bool { return e.key }
+func (e ResetPasswordResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e GetUserResponseValidationError) ErrorName() string { return "GetUserResponseValidationError" }
+func (e ResetPasswordResponseValidationError) ErrorName() string {
+	return "ResetPasswordResponseValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e GetUserResponseValidationError) Error() string {
+func (e ResetPasswordResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2498,14 +4164,14 @@ func (e GetUserResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sGetUserResponse.%s: %s%s",
+		"invalid %sResetPasswordResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = GetUserResponseValidationError{}
+var _ error = ResetPasswordResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -2513,135 +4179,64 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = GetUserResponseValidationError{}
+} = ResetPasswordResponseValidationError{}
 
-// Validate checks the field values on UpdateUserRequest with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// first error encountered is returned, or nil if there are no violations.
-func (m *UpdateUserRequest) Validate() error {
+// Validate checks the field values on AuthenticateUserRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *AuthenticateUserRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on UpdateUserRequest with the rules
-// defined in the proto definition for this message. If any rules are
+// ValidateAll checks the field values on AuthenticateUserRequest with the
+// rules defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// UpdateUserRequestMultiError, or nil if none found.
-func (m *UpdateUserRequest) ValidateAll() error {
+// AuthenticateUserRequestMultiError, or nil if none found.
+func (m *AuthenticateUserRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *UpdateUserRequest) validate(all bool) error {
+func (m *AuthenticateUserRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if all {
-		switch v := interface{}(m.GetUser()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, UpdateUserRequestValidationError{
-					field:  "User",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, UpdateUserRequestValidationError{
-					field:  "User",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetUser()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return UpdateUserRequestValidationError{
-				field:  "User",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
-		}
-	}
+	// no validation rules for Username
 
-	if all {
-		switch v := interface{}(m.GetFieldMask()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, UpdateUserRequestValidationError{
-					field:  "FieldMask",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, UpdateUserRequestValidationError{
-					field:  "FieldMask",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetFieldMask()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return UpdateUserRequestValidationError{
-				field:  "FieldMask",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
-		}
-	}
+	// no validation rules for Password
 
-	if m.LinkingOptions != nil {
+	// no validation rules for SecondFactorSupported
 
-		if all {
-			switch v := interface{}(m.GetLinkingOptions()).(type) {
-			case interface{ ValidateAll() error }:
-				if err := v.ValidateAll(); err != nil {
-					errors = append(errors, UpdateUserRequestValidationError{
-						field:  "LinkingOptions",
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			case interface{ Validate() error }:
-				if err := v.Validate(); err != nil {
-					errors = append(errors, UpdateUserRequestValidationError{
-						field:  "LinkingOptions",
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			}
-		} else if v, ok := interface{}(m.GetLinkingOptions()).(interface{ Validate() error }); ok {
-			if err := v.Validate(); err != nil {
-				return UpdateUserRequestValidationError{
-					field:  "LinkingOptions",
-					reason: "embedded message failed validation",
-					cause:  err,
-				}
-			}
-		}
+	// no validation rules for PasswordChangeSupported
+
+	if m.SourceIp != nil {
+		// no validation rules for SourceIp
+	}
+
+	if m.UserAgent != nil {
+		// no validation rules for UserAgent
+	}
 
+	if m.SecondFactor != nil {
+		// no validation rules for SecondFactor
 	}
 
 	if len(errors) > 0 {
-		return UpdateUserRequestMultiError(errors)
+		return AuthenticateUserRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// UpdateUserRequestMultiError is an error wrapping multiple validation errors
-// returned by UpdateUserRequest.ValidateAll() if the designated constraints
-// aren't met.
-type UpdateUserRequestMultiError []error
+// AuthenticateUserRequestMultiError is an error wrapping multiple validation
+// errors returned by AuthenticateUserRequest.ValidateAll() if the designated
+// constraints aren't met.
+type AuthenticateUserRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m UpdateUserRequestMultiError) Error() string {
+func (m AuthenticateUserRequestMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2650,11 +4245,11 @@ func (m UpdateUserRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m UpdateUserRequestMultiError) AllErrors() []error { return m }
+func (m AuthenticateUserRequestMultiError) AllErrors() []error { return m }
 
-// UpdateUserRequestValidationError is the validation error returned by
-// UpdateUserRequest.Validate if the designated constraints aren't met.
-type UpdateUserRequestValidationError struct {
+// AuthenticateUserRequestValidationError is the validation error returned by
+// AuthenticateUserRequest.Validate if the designated constraints aren't met.
+type AuthenticateUserRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2662,24 +4257,24 @@ type UpdateUserRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e UpdateUserRequestValidationError) Field() string { return e.field }
+func (e AuthenticateUserRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e UpdateUserRequestValidationError) Reason() string { return e.reason }
+func (e AuthenticateUserRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e UpdateUserRequestValidationError) Cause() error { return e.cause }
+func (e AuthenticateUserRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e UpdateUserRequestValidationError) Key() bool { return e.key }
+func (e AuthenticateUserRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e UpdateUserRequestValidationError) ErrorName() string {
-	return "UpdateUserRequestValidationError"
+func (e AuthenticateUserRequestValidationError) ErrorName() string {
+	return "AuthenticateUserRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e UpdateUserRequestValidationError) Error() string {
+func (e AuthenticateUserRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2691,14 +4286,14 @@ func (e UpdateUserRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sUpdateUserRequest.%s: %s%s",
+		"invalid %sAuthenticateUserRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = UpdateUserRequestValidationError{}
+var _ error = AuthenticateUserRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -2706,73 +4301,99 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = UpdateUserRequestValidationError{}
+} = AuthenticateUserRequestValidationError{}
 
-// Validate checks the field values on UpdateUserResponse with the rules
+// Validate checks the field values on AuthenticateUserResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *UpdateUserResponse) Validate() error {
+func (m *AuthenticateUserResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on UpdateUserResponse with the rules
-// defined in the proto definition for this message. If any rules are
+// ValidateAll checks the field values on AuthenticateUserResponse with the
+// rules defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// UpdateUserResponseMultiError, or nil if none found.
-func (m *UpdateUserResponse) ValidateAll() error {
+// AuthenticateUserResponseMultiError, or nil if none found.
+func (m *AuthenticateUserResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *UpdateUserResponse) validate(all bool) error {
+func (m *AuthenticateUserResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if all {
-		switch v := interface{}(m.GetUser()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, UpdateUserResponseValidationError{
-					field:  "User",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
+	// no validation rules for Success
+
+	// no validation rules for SecondFactorEnrollmentRequired
+
+	// no validation rules for PasswordChangeRequired
+
+	if m.UserId != nil {
+		// no validation rules for UserId
+	}
+
+	if m.Token != nil {
+		// no validation rules for Token
+	}
+
+	if m.FailureReason != nil {
+		// no validation rules for FailureReason
+	}
+
+	if m.TokenExpiresAt != nil {
+
+		if all {
+			switch v := interface{}(m.GetTokenExpiresAt()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, AuthenticateUserResponseValidationError{
+						field:  "TokenExpiresAt",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, AuthenticateUserResponseValidationError{
+						field:  "TokenExpiresAt",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
 			}
-		case interface{ Validate() error }:
+		} else if v, ok := interface{}(m.GetTokenExpiresAt()).(interface{ Validate() error }); ok {
 			if err := v.Validate(); err != nil {
-				errors = append(errors, UpdateUserResponseValidationError{
-					field:  "User",
+				return AuthenticateUserResponseValidationError{
+					field:  "TokenExpiresAt",
 					reason: "embedded message failed validation",
 					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetUser()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return UpdateUserResponseValidationError{
-				field:  "User",
-				reason: "embedded message failed validation",
-				cause:  err,
+				}
 			}
 		}
+
+	}
+
+	if m.Failure != nil {
+		// no validation rules for Failure
 	}
 
 	if len(errors) > 0 {
-		return UpdateUserResponseMultiError(errors)
+		return AuthenticateUserResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// UpdateUserResponseMultiError is an error wrapping multiple validation errors
-// returned by UpdateUserResponse.ValidateAll() if the designated constraints
-// aren't met.
-type UpdateUserResponseMultiError []error
+// AuthenticateUserResponseMultiError is an error wrapping multiple validation
+// errors returned by AuthenticateUserResponse.ValidateAll() if the designated
+// constraints aren't met.
+type AuthenticateUserResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m UpdateUserResponseMultiError) Error() string {
+func (m AuthenticateUserResponseMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2781,11 +4402,11 @@ func (m UpdateUserResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m UpdateUserResponseMultiError) AllErrors() []error { return m }
+func (m AuthenticateUserResponseMultiError) AllErrors() []error { return m }
 
-// UpdateUserResponseValidationError is the validation error returned by
-// UpdateUserResponse.Validate if the designated constraints aren't met.
-type UpdateUserResponseValidationError struct {
+// AuthenticateUserResponseValidationError is the validation error returned by
+// AuthenticateUserResponse.Validate if the designated constraints aren't met.
+type AuthenticateUserResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2793,24 +4414,24 @@ type UpdateUserResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e UpdateUserResponseValidationError) Field() string { return e.field }
+func (e AuthenticateUserResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e UpdateUserResponseValidationError) Reason() string { return e.reason }
+func (e AuthenticateUserResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e UpdateUserResponseValidationError) Cause() error { return e.cause }
+func (e AuthenticateUserResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e UpdateUserResponseValidationError) Key() bool { return e.key }
+func (e AuthenticateUserResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e UpdateUserResponseValidationError) ErrorName() string {
-	return "UpdateUserResponseValidationError"
+func (e AuthenticateUserResponseValidationError) ErrorName() string {
+	return "AuthenticateUserResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e UpdateUserResponseValidationError) Error() string {
+func (e AuthenticateUserResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2822,14 +4443,14 @@ func (e UpdateUserResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sUpdateUserResponse.%s: %s%s",
+		"invalid %sAuthenticateUserResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = UpdateUserResponseValidationError{}
+var _ error = AuthenticateUserResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -2837,54 +4458,52 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = UpdateUserResponseValidationError{}
+} = AuthenticateUserResponseValidationError{}
 
-// Validate checks the field values on DeleteUserRequest with the rules defined
+// Validate checks the field values on CheckLoginRequest with the rules defined
 // in the proto definition for this message. If any rules are violated, the
 // first error encountered is returned, or nil if there are no violations.
-func (m *DeleteUserRequest) Validate() error {
+func (m *CheckLoginRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on DeleteUserRequest with the rules
+// ValidateAll checks the field values on CheckLoginRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// DeleteUserRequestMultiError, or nil if none found.
-func (m *DeleteUserRequest) ValidateAll() error {
+// CheckLoginRequestMultiError, or nil if none found.
+func (m *CheckLoginRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *DeleteUserRequest) validate(all bool) error {
+func (m *CheckLoginRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Id
+	// no validation rules for Username
 
-	if m.CascadeDelete != nil {
-		// no validation rules for CascadeDelete
-	}
+	// no validation rules for ProofFailed
 
-	if m.BackupData != nil {
-		// no validation rules for BackupData
+	if m.SourceIp != nil {
+		// no validation rules for SourceIp
 	}
 
 	if len(errors) > 0 {
-		return DeleteUserRequestMultiError(errors)
+		return CheckLoginRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// DeleteUserRequestMultiError is an error wrapping multiple validation errors
-// returned by DeleteUserRequest.ValidateAll() if the designated constraints
+// CheckLoginRequestMultiError is an error wrapping multiple validation errors
+// returned by CheckLoginRequest.ValidateAll() if the designated constraints
 // aren't met.
-type DeleteUserRequestMultiError []error
+type CheckLoginRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m DeleteUserRequestMultiError) Error() string {
+func (m CheckLoginRequestMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2893,11 +4512,11 @@ func (m DeleteUserRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m DeleteUserRequestMultiError) AllErrors() []error { return m }
+func (m CheckLoginRequestMultiError) AllErrors() []error { return m }
 
-// DeleteUserRequestValidationError is the validation error returned by
-// DeleteUserRequest.Validate if the designated constraints aren't met.
-type DeleteUserRequestValidationError struct {
+// CheckLoginRequestValidationError is the validation error returned by
+// CheckLoginRequest.Validate if the designated constraints aren't met.
+type CheckLoginRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2905,24 +4524,24 @@ type DeleteUserRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e DeleteUserRequestValidationError) Field() string { return e.field }
+func (e CheckLoginRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e DeleteUserRequestValidationError) Reason() string { return e.reason }
+func (e CheckLoginRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e DeleteUserRequestValidationError) Cause() error { return e.cause }
+func (e CheckLoginRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e DeleteUserRequestValidationError) Key() bool { return e.key }
+func (e CheckLoginRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e DeleteUserRequestValidationError) ErrorName() string {
-	return "DeleteUserRequestValidationError"
+func (e CheckLoginRequestValidationError) ErrorName() string {
+	return "CheckLoginRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e DeleteUserRequestValidationError) Error() string {
+func (e CheckLoginRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2934,14 +4553,14 @@ func (e DeleteUserRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sDeleteUserRequest.%s: %s%s",
+		"invalid %sCheckLoginRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = DeleteUserRequestValidationError{}
+var _ error = CheckLoginRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -2949,50 +4568,54 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = DeleteUserRequestValidationError{}
+} = CheckLoginRequestValidationError{}
 
-// Validate checks the field values on DeleteUserResponse with the rules
+// Validate checks the field values on CheckLoginResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *DeleteUserResponse) Validate() error {
+func (m *CheckLoginResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on DeleteUserResponse with the rules
+// ValidateAll checks the field values on CheckLoginResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// DeleteUserResponseMultiError, or nil if none found.
-func (m *DeleteUserResponse) ValidateAll() error {
+// CheckLoginResponseMultiError, or nil if none found.
+func (m *CheckLoginResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *DeleteUserResponse) validate(all bool) error {
+func (m *CheckLoginResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Success
+	// no validation rules for Allowed
 
-	if m.BackupLocation != nil {
-		// no validation rules for BackupLocation
+	if m.FailureReason != nil {
+		// no validation rules for FailureReason
+	}
+
+	if m.Failure != nil {
+		// no validation rules for Failure
 	}
 
 	if len(errors) > 0 {
-		return DeleteUserResponseMultiError(errors)
+		return CheckLoginResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// DeleteUserResponseMultiError is an error wrapping multiple validation errors
-// returned by DeleteUserResponse.ValidateAll() if the designated constraints
+// CheckLoginResponseMultiError is an error wrapping multiple validation errors
+// returned by CheckLoginResponse.ValidateAll() if the designated constraints
 // aren't met.
-type DeleteUserResponseMultiError []error
+type CheckLoginResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m DeleteUserResponseMultiError) Error() string {
+func (m CheckLoginResponseMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -3001,11 +4624,11 @@ func (m DeleteUserResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m DeleteUserResponseMultiError) AllErrors() []error { return m }
+func (m CheckLoginResponseMultiError) AllErrors() []error { return m }
 
-// DeleteUserResponseValidationError is the validation error returned by
-// DeleteUserResponse.Validate if the designated constraints aren't met.
-type DeleteUserResponseValidationError struct {
+// CheckLoginResponseValidationError is the validation error returned by
+// CheckLoginResponse.Validate if the designated constraints aren't met.
+type CheckLoginResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -3013,24 +4636,24 @@ type DeleteUserResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e DeleteUserResponseValidationError) Field() string { return e.field }
+func (e CheckLoginResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e DeleteUserResponseValidationError) Reason() string { return e.reason }
+func (e CheckLoginResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e DeleteUserResponseValidationError) Cause() error { return e.cause }
+func (e CheckLoginResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e DeleteUserResponseValidationError) Key() bool { return e.key }
+func (e CheckLoginResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e DeleteUserResponseValidationError) ErrorName() string {
-	return "DeleteUserResponseValidationError"
+func (e CheckLoginResponseValidationError) ErrorName() string {
+	return "CheckLoginResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e DeleteUserResponseValidationError) Error() string {
+func (e CheckLoginResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -3042,14 +4665,14 @@ func (e DeleteUserResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sDeleteUserResponse.%s: %s%s",
+		"invalid %sCheckLoginResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = DeleteUserResponseValidationError{}
+var _ error = CheckLoginResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -3057,97 +4680,46 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = DeleteUserResponseValidationError{}
+} = CheckLoginResponseValidationError{}
 
-// Validate checks the field values on ListUsersRequest with the rules defined
+// Validate checks the field values on EnrollTotpRequest with the rules defined
 // in the proto definition for this message. If any rules are violated, the
 // first error encountered is returned, or nil if there are no violations.
-func (m *ListUsersRequest) Validate() error {
+func (m *EnrollTotpRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on ListUsersRequest with the rules
+// ValidateAll checks the field values on EnrollTotpRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// ListUsersRequestMultiError, or nil if none found.
-func (m *ListUsersRequest) ValidateAll() error {
+// EnrollTotpRequestMultiError, or nil if none found.
+func (m *EnrollTotpRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *ListUsersRequest) validate(all bool) error {
+func (m *EnrollTotpRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if m.Source != nil {
-		// no validation rules for Source
-	}
-
-	if m.Enabled != nil {
-		// no validation rules for Enabled
-	}
-
-	if m.UsernamePrefix != nil {
-		// no validation rules for UsernamePrefix
-	}
-
-	if m.FieldMask != nil {
-
-		if all {
-			switch v := interface{}(m.GetFieldMask()).(type) {
-			case interface{ ValidateAll() error }:
-				if err := v.ValidateAll(); err != nil {
-					errors = append(errors, ListUsersRequestValidationError{
-						field:  "FieldMask",
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			case interface{ Validate() error }:
-				if err := v.Validate(); err != nil {
-					errors = append(errors, ListUsersRequestValidationError{
-						field:  "FieldMask",
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			}
-		} else if v, ok := interface{}(m.GetFieldMask()).(interface{ Validate() error }); ok {
-			if err := v.Validate(); err != nil {
-				return ListUsersRequestValidationError{
-					field:  "FieldMask",
-					reason: "embedded message failed validation",
-					cause:  err,
-				}
-			}
-		}
-
-	}
-
-	if m.PageSize != nil {
-		// no validation rules for PageSize
-	}
-
-	if m.PageToken != nil {
-		// no validation rules for PageToken
-	}
+	// no validation rules for Id
 
 	if len(errors) > 0 {
-		return ListUsersRequestMultiError(errors)
+		return EnrollTotpRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// ListUsersRequestMultiError is an error wrapping multiple validation errors
-// returned by ListUsersRequest.ValidateAll() if the designated constraints
+// EnrollTotpRequestMultiError is an error wrapping multiple validation errors
+// returned by EnrollTotpRequest.ValidateAll() if the designated constraints
 // aren't met.
-type ListUsersRequestMultiError []error
+type EnrollTotpRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m ListUsersRequestMultiError) Error() string {
+func (m EnrollTotpRequestMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -3156,11 +4728,11 @@ func (m ListUsersRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m ListUsersRequestMultiError) AllErrors() []error { return m }
+func (m EnrollTotpRequestMultiError) AllErrors() []error { return m }
 
-// ListUsersRequestValidationError is the validation error returned by
-// ListUsersRequest.Validate if the designated constraints aren't met.
-type ListUsersRequestValidationError struct {
+// EnrollTotpRequestValidationError is the validation error returned by
+// EnrollTotpRequest.Validate if the designated constraints aren't met.
+type EnrollTotpRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -3168,22 +4740,24 @@ type ListUsersRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e ListUsersRequestValidationError) Field() string { return e.field }
+func (e EnrollTotpRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e ListUsersRequestValidationError) Reason() string { return e.reason }
+func (e EnrollTotpRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e ListUsersRequestValidationError) Cause() error { return e.cause }
+func (e EnrollTotpRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e ListUsersRequestValidationError) Key() bool { return e.key }
+func (e EnrollTotpRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e ListUsersRequestValidationError) ErrorName() string { return "ListUsersRequestValidationError" }
+func (e EnrollTotpRequestValidationError) ErrorName() string {
+	return "EnrollTotpRequestValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e ListUsersRequestValidationError) Error() string {
+func (e EnrollTotpRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -3195,14 +4769,14 @@ func (e ListUsersRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sListUsersRequest.%s: %s%s",
+		"invalid %sEnrollTotpRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = ListUsersRequestValidationError{}
+var _ error = EnrollTotpRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -3210,82 +4784,48 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = ListUsersRequestValidationError{}
+} = EnrollTotpRequestValidationError{}
 
-// Validate checks the field values on ListUsersResponse with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// first error encountered is returned, or nil if there are no violations.
-func (m *ListUsersResponse) Validate() error {
+// Validate checks the field values on EnrollTotpResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *EnrollTotpResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on ListUsersResponse with the rules
+// ValidateAll checks the field values on EnrollTotpResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// ListUsersResponseMultiError, or nil if none found.
-func (m *ListUsersResponse) ValidateAll() error {
+// EnrollTotpResponseMultiError, or nil if none found.
+func (m *EnrollTotpResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *ListUsersResponse) validate(all bool) error {
+func (m *EnrollTotpResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	for idx, item := range m.GetUsers() {
-		_, _ = idx, item
-
-		if all {
-			switch v := interface{}(item).(type) {
-			case interface{ ValidateAll() error }:
-				if err := v.ValidateAll(); err != nil {
-					errors = append(errors, ListUsersResponseValidationError{
-						field:  fmt.Sprintf("Users[%v]", idx),
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			case interface{ Validate() error }:
-				if err := v.Validate(); err != nil {
-					errors = append(errors, ListUsersResponseValidationError{
-						field:  fmt.Sprintf("Users[%v]", idx),
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			}
-		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
-			if err := v.Validate(); err != nil {
-				return ListUsersResponseValidationError{
-					field:  fmt.Sprintf("Users[%v]", idx),
-					reason: "embedded message failed validation",
-					cause:  err,
-				}
-			}
-		}
-
-	}
+	// no validation rules for Secret
 
-	if m.NextPageToken != nil {
-		// no validation rules for NextPageToken
-	}
+	// no validation rules for Uri
 
 	if len(errors) > 0 {
-		return ListUsersResponseMultiError(errors)
+		return EnrollTotpResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// ListUsersResponseMultiError is an error wrapping multiple validation errors
-// returned by ListUsersResponse.ValidateAll() if the designated constraints
+// EnrollTotpResponseMultiError is an error wrapping multiple validation errors
+// returned by EnrollTotpResponse.ValidateAll() if the designated constraints
 // aren't met.
-type ListUsersResponseMultiError []error
+type EnrollTotpResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m ListUsersResponseMultiError) Error() string {
+func (m EnrollTotpResponseMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -3294,11 +4834,11 @@ func (m ListUsersResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m ListUsersResponseMultiError) AllErrors() []error { return m }
+func (m EnrollTotpResponseMultiError) AllErrors() []error { return m }
 
-// ListUsersResponseValidationError is the validation error returned by
-// ListUsersResponse.Validate if the designated constraints aren't met.
-type ListUsersResponseValidationError struct {
+// EnrollTotpResponseValidationError is the validation error returned by
+// EnrollTotpResponse.Validate if the designated constraints aren't met.
+type EnrollTotpResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -3306,24 +4846,24 @@ type ListUsersResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e ListUsersResponseValidationError) Field() string { return e.field }
+func (e EnrollTotpResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e ListUsersResponseValidationError) Reason() string { return e.reason }
+func (e EnrollTotpResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e ListUsersResponseValidationError) Cause() error { return e.cause }
+func (e EnrollTotpResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e ListUsersResponseValidationError) Key() bool { return e.key }
+func (e EnrollTotpResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e ListUsersResponseValidationError) ErrorName() string {
-	return "ListUsersResponseValidationError"
+func (e EnrollTotpResponseValidationError) ErrorName() string {
+	return "EnrollTotpResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e ListUsersResponseValidationError) Error() string {
+func (e EnrollTotpResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -3335,14 +4875,14 @@ func (e ListUsersResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sListUsersResponse.%s: %s%s",
+		"invalid %sEnrollTotpResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = ListUsersResponseValidationError{}
+var _ error = EnrollTotpResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -3350,24 +4890,24 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = ListUsersResponseValidationError{}
+} = EnrollTotpResponseValidationError{}
 
-// Validate checks the field values on ChangePasswordRequest with the rules
+// Validate checks the field values on ConfirmTotpRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *ChangePasswordRequest) Validate() error {
+func (m *ConfirmTotpRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on ChangePasswordRequest with the rules
+// ValidateAll checks the field values on ConfirmTotpRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// ChangePasswordRequestMultiError, or nil if none found.
-func (m *ChangePasswordRequest) ValidateAll() error {
+// ConfirmTotpRequestMultiError, or nil if none found.
+func (m *ConfirmTotpRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *ChangePasswordRequest) validate(all bool) error {
+func (m *ConfirmTotpRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
@@ -3376,24 +4916,22 @@ func (m *ChangePasswordRequest) validate(all bool) error {
 
 	// no validation rules for Id
 
-	// no validation rules for CurrentPassword
-
-	// no validation rules for NewPassword
+	// no validation rules for Code
 
 	if len(errors) > 0 {
-		return ChangePasswordRequestMultiError(errors)
+		return ConfirmTotpRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// ChangePasswordRequestMultiError is an error wrapping multiple validation
-// errors returned by ChangePasswordRequest.ValidateAll() if the designated
-// constraints aren't met.
-type ChangePasswordRequestMultiError []error
+// ConfirmTotpRequestMultiError is an error wrapping multiple validation errors
+// returned by ConfirmTotpRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ConfirmTotpRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m ChangePasswordRequestMultiError) Error() string {
+func (m ConfirmTotpRequestMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -3402,11 +4940,11 @@ func (m ChangePasswordRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m ChangePasswordRequestMultiError) AllErrors() []error { return m }
+func (m ConfirmTotpRequestMultiError) AllErrors() []error { return m }
 
-// ChangePasswordRequestValidationError is the validation error returned by
-// ChangePasswordRequest.Validate if the designated constraints aren't met.
-type ChangePasswordRequestValidationError struct {
+// ConfirmTotpRequestValidationError is the validation error returned by
+// ConfirmTotpRequest.Validate if the designated constraints aren't met.
+type ConfirmTotpRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -3414,24 +4952,24 @@ type ChangePasswordRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e ChangePasswordRequestValidationError) Field() string { return e.field }
+func (e ConfirmTotpRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e ChangePasswordRequestValidationError) Reason() string { return e.reason }
+func (e ConfirmTotpRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e ChangePasswordRequestValidationError) Cause() error { return e.cause }
+func (e ConfirmTotpRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e ChangePasswordRequestValidationError) Key() bool { return e.key }
+func (e ConfirmTotpRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e ChangePasswordRequestValidationError) ErrorName() string {
-	return "ChangePasswordRequestValidationError"
+func (e ConfirmTotpRequestValidationError) ErrorName() string {
+	return "ConfirmTotpRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e ChangePasswordRequestValidationError) Error() string {
+func (e ConfirmTotpRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -3443,14 +4981,14 @@ func (e ChangePasswordRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sChangePasswordRequest.%s: %s%s",
+		"invalid %sConfirmTotpRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = ChangePasswordRequestValidationError{}
+var _ error = ConfirmTotpRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -3458,24 +4996,24 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = ChangePasswordRequestValidationError{}
+} = ConfirmTotpRequestValidationError{}
 
-// Validate checks the field values on ChangePasswordResponse with the rules
+// Validate checks the field values on ConfirmTotpResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *ChangePasswordResponse) Validate() error {
+func (m *ConfirmTotpResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on ChangePasswordResponse with the rules
+// ValidateAll checks the field values on ConfirmTotpResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// ChangePasswordResponseMultiError, or nil if none found.
-func (m *ChangePasswordResponse) ValidateAll() error {
+// ConfirmTotpResponseMultiError, or nil if none found.
+func (m *ConfirmTotpResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *ChangePasswordResponse) validate(all bool) error {
+func (m *ConfirmTotpResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
@@ -3489,19 +5027,19 @@ func (m *ChangePasswordResponse) validate(all bool) error {
 	}
 
 	if len(errors) > 0 {
-		return ChangePasswordResponseMultiError(errors)
+		return ConfirmTotpResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// ChangePasswordResponseMultiError is an error wrapping multiple validation
-// errors returned by ChangePasswordResponse.ValidateAll() if the designated
+// ConfirmTotpResponseMultiError is an error wrapping multiple validation
+// errors returned by ConfirmTotpResponse.ValidateAll() if the designated
 // constraints aren't met.
-type ChangePasswordResponseMultiError []error
+type ConfirmTotpResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m ChangePasswordResponseMultiError) Error() string {
+func (m ConfirmTotpResponseMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -3510,11 +5048,11 @@ func (m ChangePasswordResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m ChangePasswordResponseMultiError) AllErrors() []error { return m }
+func (m ConfirmTotpResponseMultiError) AllErrors() []error { return m }
 
-// ChangePasswordResponseValidationError is the validation error returned by
-// ChangePasswordResponse.Validate if the designated constraints aren't met.
-type ChangePasswordResponseValidationError struct {
+// ConfirmTotpResponseValidationError is the validation error returned by
+// ConfirmTotpResponse.Validate if the designated constraints aren't met.
+type ConfirmTotpResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -3522,24 +5060,24 @@ type ChangePasswordResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e ChangePasswordResponseValidationError) Field() string { return e.field }
+func (e ConfirmTotpResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e ChangePasswordResponseValidationError) Reason() string { return e.reason }
+func (e ConfirmTotpResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e ChangePasswordResponseValidationError) Cause() error { return e.cause }
+func (e ConfirmTotpResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e ChangePasswordResponseValidationError) Key() bool { return e.key }
+func (e ConfirmTotpResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e ChangePasswordResponseValidationError) ErrorName() string {
-	return "ChangePasswordResponseValidationError"
+func (e ConfirmTotpResponseValidationError) ErrorName() string {
+	return "ConfirmTotpResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e ChangePasswordResponseValidationError) Error() string {
+func (e ConfirmTotpResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -3551,14 +5089,14 @@ func (e ChangePasswordResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sChangePasswordResponse.%s: %s%s",
+		"invalid %sConfirmTotpResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = ChangePasswordResponseValidationError{}
+var _ error = ConfirmTotpResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -3566,24 +5104,24 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = ChangePasswordResponseValidationError{}
+} = ConfirmTotpResponseValidationError{}
 
-// Validate checks the field values on ResetPasswordRequest with the rules
+// Validate checks the field values on DisableTotpRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *ResetPasswordRequest) Validate() error {
+func (m *DisableTotpRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on ResetPasswordRequest with the rules
+// ValidateAll checks the field values on DisableTotpRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// ResetPasswordRequestMultiError, or nil if none found.
-func (m *ResetPasswordRequest) ValidateAll() error {
+// DisableTotpRequestMultiError, or nil if none found.
+func (m *DisableTotpRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *ResetPasswordRequest) validate(all bool) error {
+func (m *DisableTotpRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
@@ -3592,32 +5130,20 @@ func (m *ResetPasswordRequest) validate(all bool) error {
 
 	// no validation rules for Id
 
-	if m.NewPassword != nil {
-		// no validation rules for NewPassword
-	}
-
-	if m.Force != nil {
-		// no validation rules for Force
-	}
-
-	if m.GeneratePassword != nil {
-		// no validation rules for GeneratePassword
-	}
-
 	if len(errors) > 0 {
-		return ResetPasswordRequestMultiError(errors)
+		return DisableTotpRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// ResetPasswordRequestMultiError is an error wrapping multiple validation
-// errors returned by ResetPasswordRequest.ValidateAll() if the designated
-// constraints aren't met.
-type ResetPasswordRequestMultiError []error
+// DisableTotpRequestMultiError is an error wrapping multiple validation errors
+// returned by DisableTotpRequest.ValidateAll() if the designated constraints
+// aren't met.
+type DisableTotpRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m ResetPasswordRequestMultiError) Error() string {
+func (m DisableTotpRequestMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -3626,11 +5152,11 @@ func (m ResetPasswordRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m ResetPasswordRequestMultiError) AllErrors() []error { return m }
+func (m DisableTotpRequestMultiError) AllErrors() []error { return m }
 
-// ResetPasswordRequestValidationError is the validation error returned by
-// ResetPasswordRequest.Validate if the designated constraints aren't met.
-type ResetPasswordRequestValidationError struct {
+// DisableTotpRequestValidationError is the validation error returned by
+// DisableTotpRequest.Validate if the designated constraints aren't met.
+type DisableTotpRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -3638,24 +5164,24 @@ type ResetPasswordRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e ResetPasswordRequestValidationError) Field() string { return e.field }
+func (e DisableTotpRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e ResetPasswordRequestValidationError) Reason() string { return e.reason }
+func (e DisableTotpRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e ResetPasswordRequestValidationError) Cause() error { return e.cause }
+func (e DisableTotpRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e ResetPasswordRequestValidationError) Key() bool { return e.key }
+func (e DisableTotpRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e ResetPasswordRequestValidationError) ErrorName() string {
-	return "ResetPasswordRequestValidationError"
+func (e DisableTotpRequestValidationError) ErrorName() string {
+	return "DisableTotpRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e ResetPasswordRequestValidationError) Error() string {
+func (e DisableTotpRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -3667,14 +5193,14 @@ func (e ResetPasswordRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sResetPasswordRequest.%s: %s%s",
+		"invalid %sDisableTotpRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = ResetPasswordRequestValidationError{}
+var _ error = DisableTotpRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -3682,52 +5208,46 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = ResetPasswordRequestValidationError{}
+} = DisableTotpRequestValidationError{}
 
-// Validate checks the field values on ResetPasswordResponse with the rules
+// Validate checks the field values on DisableTotpResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *ResetPasswordResponse) Validate() error {
+func (m *DisableTotpResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on ResetPasswordResponse with the rules
+// ValidateAll checks the field values on DisableTotpResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// ResetPasswordResponseMultiError, or nil if none found.
-func (m *ResetPasswordResponse) ValidateAll() error {
+// DisableTotpResponseMultiError, or nil if none found.
+func (m *DisableTotpResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *ResetPasswordResponse) validate(all bool) error {
+func (m *DisableTotpResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for NewPassword
-
 	// no validation rules for Success
 
-	if m.FailureReason != nil {
-		// no validation rules for FailureReason
-	}
-
 	if len(errors) > 0 {
-		return ResetPasswordResponseMultiError(errors)
+		return DisableTotpResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// ResetPasswordResponseMultiError is an error wrapping multiple validation
-// errors returned by ResetPasswordResponse.ValidateAll() if the designated
+// DisableTotpResponseMultiError is an error wrapping multiple validation
+// errors returned by DisableTotpResponse.ValidateAll() if the designated
 // constraints aren't met.
-type ResetPasswordResponseMultiError []error
+type DisableTotpResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m ResetPasswordResponseMultiError) Error() string {
+func (m DisableTotpResponseMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -3736,11 +5256,11 @@ func (m ResetPasswordResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m ResetPasswordResponseMultiError) AllErrors() []error { return m }
+func (m DisableTotpResponseMultiError) AllErrors() []error { return m }
 
-// ResetPasswordResponseValidationError is the validation error returned by
-// ResetPasswordResponse.Validate if the designated constraints aren't met.
-type ResetPasswordResponseValidationError struct {
+// DisableTotpResponseValidationError is the validation error returned by
+// DisableTotpResponse.Validate if the designated constraints aren't met.
+type DisableTotpResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -3748,24 +5268,24 @@ type ResetPasswordResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e ResetPasswordResponseValidationError) Field() string { return e.field }
+func (e DisableTotpResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e ResetPasswordResponseValidationError) Reason() string { return e.reason }
+func (e DisableTotpResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e ResetPasswordResponseValidationError) Cause() error { return e.cause }
+func (e DisableTotpResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e ResetPasswordResponseValidationError) Key() bool { return e.key }
+func (e DisableTotpResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e ResetPasswordResponseValidationError) ErrorName() string {
-	return "ResetPasswordResponseValidationError"
+func (e DisableTotpResponseValidationError) ErrorName() string {
+	return "DisableTotpResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e ResetPasswordResponseValidationError) Error() string {
+func (e DisableTotpResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -3777,14 +5297,14 @@ func (e ResetPasswordResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sResetPasswordResponse.%s: %s%s",
+		"invalid %sDisableTotpResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = ResetPasswordResponseValidationError{}
+var _ error = DisableTotpResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -3792,56 +5312,46 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = ResetPasswordResponseValidationError{}
+} = DisableTotpResponseValidationError{}
 
-// Validate checks the field values on AuthenticateUserRequest with the rules
-// defined in the proto definition for this message. If any rules are
+// Validate checks the field values on RegenerateRecoveryCodesRequest with the
+// rules defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *AuthenticateUserRequest) Validate() error {
+func (m *RegenerateRecoveryCodesRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on AuthenticateUserRequest with the
-// rules defined in the proto definition for this message. If any rules are
-// violated, the result is a list of violation errors wrapped in
-// AuthenticateUserRequestMultiError, or nil if none found.
-func (m *AuthenticateUserRequest) ValidateAll() error {
+// ValidateAll checks the field values on RegenerateRecoveryCodesRequest with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// RegenerateRecoveryCodesRequestMultiError, or nil if none found.
+func (m *RegenerateRecoveryCodesRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *AuthenticateUserRequest) validate(all bool) error {
+func (m *RegenerateRecoveryCodesRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Username
-
-	// no validation rules for Password
-
-	if m.SourceIp != nil {
-		// no validation rules for SourceIp
-	}
-
-	if m.UserAgent != nil {
-		// no validation rules for UserAgent
-	}
+	// no validation rules for Id
 
 	if len(errors) > 0 {
-		return AuthenticateUserRequestMultiError(errors)
+		return RegenerateRecoveryCodesRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// AuthenticateUserRequestMultiError is an error wrapping multiple validation
-// errors returned by AuthenticateUserRequest.ValidateAll() if the designated
-// constraints aren't met.
-type AuthenticateUserRequestMultiError []error
+// RegenerateRecoveryCodesRequestMultiError is an error wrapping multiple
+// validation errors returned by RegenerateRecoveryCodesRequest.ValidateAll()
+// if the designated constraints aren't met.
+type RegenerateRecoveryCodesRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m AuthenticateUserRequestMultiError) Error() string {
+func (m RegenerateRecoveryCodesRequestMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -3850,11 +5360,12 @@ func (m AuthenticateUserRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m AuthenticateUserRequestMultiError) AllErrors() []error { return m }
+func (m RegenerateRecoveryCodesRequestMultiError) AllErrors() []error { return m }
 
-// AuthenticateUserRequestValidationError is the validation error returned by
-// AuthenticateUserRequest.Validate if the designated constraints aren't met.
-type AuthenticateUserRequestValidationError struct {
+// RegenerateRecoveryCodesRequestValidationError is the validation error
+// returned by RegenerateRecoveryCodesRequest.Validate if the designated
+// constraints aren't met.
+type RegenerateRecoveryCodesRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -3862,24 +5373,24 @@ type AuthenticateUserRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e AuthenticateUserRequestValidationError) Field() string { return e.field }
+func (e RegenerateRecoveryCodesRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e AuthenticateUserRequestValidationError) Reason() string { return e.reason }
+func (e RegenerateRecoveryCodesRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e AuthenticateUserRequestValidationError) Cause() error { return e.cause }
+func (e RegenerateRecoveryCodesRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e AuthenticateUserRequestValidationError) Key() bool { return e.key }
+func (e RegenerateRecoveryCodesRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e AuthenticateUserRequestValidationError) ErrorName() string {
-	return "AuthenticateUserRequestValidationError"
+func (e RegenerateRecoveryCodesRequestValidationError) ErrorName() string {
+	return "RegenerateRecoveryCodesRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e AuthenticateUserRequestValidationError) Error() string {
+func (e RegenerateRecoveryCodesRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -3891,14 +5402,14 @@ func (e AuthenticateUserRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sAuthenticateUserRequest.%s: %s%s",
+		"invalid %sRegenerateRecoveryCodesRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = AuthenticateUserRequestValidationError{}
+var _ error = RegenerateRecoveryCodesRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -3906,91 +5417,44 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = AuthenticateUserRequestValidationError{}
+} = RegenerateRecoveryCodesRequestValidationError{}
 
-// Validate checks the field values on AuthenticateUserResponse with the rules
-// defined in the proto definition for this message. If any rules are
+// Validate checks the field values on RegenerateRecoveryCodesResponse with the
+// rules defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *AuthenticateUserResponse) Validate() error {
+func (m *RegenerateRecoveryCodesResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on AuthenticateUserResponse with the
-// rules defined in the proto definition for this message. If any rules are
-// violated, the result is a list of violation errors wrapped in
-// AuthenticateUserResponseMultiError, or nil if none found.
-func (m *AuthenticateUserResponse) ValidateAll() error {
+// ValidateAll checks the field values on RegenerateRecoveryCodesResponse with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// RegenerateRecoveryCodesResponseMultiError, or nil if none found.
+func (m *RegenerateRecoveryCodesResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *AuthenticateUserResponse) validate(all bool) error {
+func (m *RegenerateRecoveryCodesResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Success
-
-	if m.UserId != nil {
-		// no validation rules for UserId
-	}
-
-	if m.Token != nil {
-		// no validation rules for Token
-	}
-
-	if m.FailureReason != nil {
-		// no validation rules for FailureReason
-	}
-
-	if m.TokenExpiresAt != nil {
-
-		if all {
-			switch v := interface{}(m.GetTokenExpiresAt()).(type) {
-			case interface{ ValidateAll() error }:
-				if err := v.ValidateAll(); err != nil {
-					errors = append(errors, AuthenticateUserResponseValidationError{
-						field:  "TokenExpiresAt",
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			case interface{ Validate() error }:
-				if err := v.Validate(); err != nil {
-					errors = append(errors, AuthenticateUserResponseValidationError{
-						field:  "TokenExpiresAt",
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			}
-		} else if v, ok := interface{}(m.GetTokenExpiresAt()).(interface{ Validate() error }); ok {
-			if err := v.Validate(); err != nil {
-				return AuthenticateUserResponseValidationError{
-					field:  "TokenExpiresAt",
-					reason: "embedded message failed validation",
-					cause:  err,
-				}
-			}
-		}
-
-	}
-
 	if len(errors) > 0 {
-		return AuthenticateUserResponseMultiError(errors)
+		return RegenerateRecoveryCodesResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// AuthenticateUserResponseMultiError is an error wrapping multiple validation
-// errors returned by AuthenticateUserResponse.ValidateAll() if the designated
-// constraints aren't met.
-type AuthenticateUserResponseMultiError []error
+// RegenerateRecoveryCodesResponseMultiError is an error wrapping multiple
+// validation errors returned by RegenerateRecoveryCodesResponse.ValidateAll()
+// if the designated constraints aren't met.
+type RegenerateRecoveryCodesResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m AuthenticateUserResponseMultiError) Error() string {
+func (m RegenerateRecoveryCodesResponseMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -3999,11 +5463,12 @@ func (m AuthenticateUserResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m AuthenticateUserResponseMultiError) AllErrors() []error { return m }
+func (m RegenerateRecoveryCodesResponseMultiError) AllErrors() []error { return m }
 
-// AuthenticateUserResponseValidationError is the validation error returned by
-// AuthenticateUserResponse.Validate if the designated constraints aren't met.
-type AuthenticateUserResponseValidationError struct {
+// RegenerateRecoveryCodesResponseValidationError is the validation error
+// returned by RegenerateRecoveryCodesResponse.Validate if the designated
+// constraints aren't met.
+type RegenerateRecoveryCodesResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -4011,24 +5476,24 @@ type AuthenticateUserResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e AuthenticateUserResponseValidationError) Field() string { return e.field }
+func (e RegenerateRecoveryCodesResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e AuthenticateUserResponseValidationError) Reason() string { return e.reason }
+func (e RegenerateRecoveryCodesResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e AuthenticateUserResponseValidationError) Cause() error { return e.cause }
+func (e RegenerateRecoveryCodesResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e AuthenticateUserResponseValidationError) Key() bool { return e.key }
+func (e RegenerateRecoveryCodesResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e AuthenticateUserResponseValidationError) ErrorName() string {
-	return "AuthenticateUserResponseValidationError"
+func (e RegenerateRecoveryCodesResponseValidationError) ErrorName() string {
+	return "RegenerateRecoveryCodesResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e AuthenticateUserResponseValidationError) Error() string {
+func (e RegenerateRecoveryCodesResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -4040,14 +5505,14 @@ func (e AuthenticateUserResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sAuthenticateUserResponse.%s: %s%s",
+		"invalid %sRegenerateRecoveryCodesResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = AuthenticateUserResponseValidationError{}
+var _ error = RegenerateRecoveryCodesResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -4055,4 +5520,4 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = AuthenticateUserResponseValidationError{}
+} = RegenerateRecoveryCodesResponseValidationError{}
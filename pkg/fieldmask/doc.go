@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package fieldmask applies google.protobuf.FieldMask values to protobuf
+// messages. Services that accept a field_mask in their list and get requests
+// use it to trim responses down to the fields a client asked for, so that
+// clients such as the Redfish server can fetch large inventories without
+// transferring every property of every item.
+//
+// # Semantics
+//
+// A mask consists of paths of field names separated by dots, such as
+// "name" or "analog_reading.value". Applying a mask keeps every field named
+// by a path and clears all others. A path naming a message field keeps the
+// whole message; a path continuing into a message field keeps only the
+// named fields below it. Repeated and map fields can only be kept or cleared
+// as a whole. An empty or nil mask keeps the message unchanged.
+//
+// Merging a message into another copies the fields named by the mask and
+// clears those that are unset in the source, which is how update requests
+// carrying a field_mask are applied to stored messages.
+//
+// Paths that do not name a field of the message are rejected with
+// ErrInvalidPath, and the message is left unchanged.
+//
+// # Basic Usage
+//
+// Trimming a sensor to the fields of a list request:
+//
+//	sensor := proto.Clone(stored).(*v1alpha1.Sensor)
+//	if err := fieldmask.Apply(sensor, request.GetFieldMask()); err != nil {
+//		return err
+//	}
+//
+// Updating the fields of a stored user named by an update request:
+//
+//	if err := fieldmask.Merge(stored, request.GetUser(), request.GetFieldMask()); err != nil {
+//		return err
+//	}
+package fieldmask
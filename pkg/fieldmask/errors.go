@@ -0,0 +1,12 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package fieldmask
+
+import "errors"
+
+var (
+	// ErrInvalidPath indicates that a field mask path does not name a field of the message.
+	ErrInvalidPath = errors.New("invalid field mask path")
+	// ErrTypeMismatch indicates that messages of different types were merged.
+	ErrTypeMismatch = errors.New("message type mismatch")
+)
@@ -0,0 +1,153 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package fieldmask
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// Validate checks that every path of mask names a field of m.
+func Validate(m proto.Message, mask *fieldmaskpb.FieldMask) error {
+	desc := m.ProtoReflect().Descriptor()
+	for _, path := range mask.GetPaths() {
+		if err := validatePath(desc, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Apply clears all fields of m that are not named by mask. A nil or empty
+// mask leaves m unchanged.
+func Apply(m proto.Message, mask *fieldmaskpb.FieldMask) error {
+	if len(mask.GetPaths()) == 0 {
+		return nil
+	}
+	if err := Validate(m, mask); err != nil {
+		return err
+	}
+	prune(m.ProtoReflect(), mask.GetPaths())
+	return nil
+}
+
+// Merge copies the fields of src named by mask into dst, which must be of the
+// same message type. A field that is unset in src is cleared in dst. Unlike
+// Apply, a nil or empty mask copies nothing.
+func Merge(dst, src proto.Message, mask *fieldmaskpb.FieldMask) error {
+	if dst.ProtoReflect().Descriptor().FullName() != src.ProtoReflect().Descriptor().FullName() {
+		return fmt.Errorf("%w: %s into %s", ErrTypeMismatch,
+			src.ProtoReflect().Descriptor().FullName(), dst.ProtoReflect().Descriptor().FullName())
+	}
+	if err := Validate(dst, mask); err != nil {
+		return err
+	}
+	for _, path := range mask.GetPaths() {
+		mergePath(dst.ProtoReflect(), src.ProtoReflect(), strings.Split(path, "."))
+	}
+	return nil
+}
+
+// mergePath copies the field named by names, which must be valid for dst,
+// from src into dst.
+func mergePath(dst, src protoreflect.Message, names []string) {
+	fd := dst.Descriptor().Fields().ByName(protoreflect.Name(names[0]))
+	if len(names) > 1 {
+		// An unset message in src clears the named field below it in dst.
+		if src.Has(fd) || dst.Has(fd) {
+			mergePath(dst.Mutable(fd).Message(), src.Get(fd).Message(), names[1:])
+		}
+		return
+	}
+
+	if !src.Has(fd) {
+		dst.Clear(fd)
+		return
+	}
+	switch {
+	case fd.IsList():
+		list := dst.NewField(fd).List()
+		for i, from := 0, src.Get(fd).List(); i < from.Len(); i++ {
+			list.Append(cloneValue(fd, from.Get(i)))
+		}
+		dst.Set(fd, protoreflect.ValueOfList(list))
+	case fd.IsMap():
+		m := dst.NewField(fd).Map()
+		src.Get(fd).Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			m.Set(k, cloneValue(fd.MapValue(), v))
+			return true
+		})
+		dst.Set(fd, protoreflect.ValueOfMap(m))
+	default:
+		dst.Set(fd, cloneValue(fd, src.Get(fd)))
+	}
+}
+
+// cloneValue returns a deep copy of a singular value of field fd.
+func cloneValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) protoreflect.Value {
+	if fd.Message() == nil {
+		return v
+	}
+	return protoreflect.ValueOfMessage(proto.Clone(v.Message().Interface()).ProtoReflect())
+}
+
+// validatePath checks that path names a field below desc.
+func validatePath(desc protoreflect.MessageDescriptor, path string) error {
+	names := strings.Split(path, ".")
+	for i, name := range names {
+		fd := desc.Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			return fmt.Errorf("%w: %q", ErrInvalidPath, path)
+		}
+		if i == len(names)-1 {
+			break
+		}
+		if fd.Message() == nil || fd.IsList() || fd.IsMap() {
+			return fmt.Errorf("%w: %q", ErrInvalidPath, path)
+		}
+		desc = fd.Message()
+	}
+	return nil
+}
+
+// prune clears the populated fields of m that are not named by paths, which
+// must be valid for m.
+func prune(m protoreflect.Message, paths []string) {
+	// keep maps field names to the subpaths to keep below them, where a nil
+	// slice keeps the whole field.
+	keep := make(map[protoreflect.Name][]string, len(paths))
+	for _, path := range paths {
+		name, rest, nested := strings.Cut(path, ".")
+		sub, seen := keep[protoreflect.Name(name)]
+		switch {
+		case !nested:
+			keep[protoreflect.Name(name)] = nil
+		case !seen || sub != nil:
+			keep[protoreflect.Name(name)] = append(sub, rest)
+		}
+	}
+
+	var drop []protoreflect.FieldDescriptor
+	var nested []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		sub, ok := keep[fd.Name()]
+		switch {
+		case !ok:
+			drop = append(drop, fd)
+		case sub != nil:
+			nested = append(nested, fd)
+		}
+		return true
+	})
+
+	for _, fd := range drop {
+		m.Clear(fd)
+	}
+	for _, fd := range nested {
+		prune(m.Mutable(fd).Message(), keep[fd.Name()])
+	}
+}
@@ -0,0 +1,289 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package fru
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// Info area layout.
+const (
+	areaVersion     = 0x01
+	areaVersionMask = 0x0F
+	areaHeaderSize  = 2
+	areaUnit        = 8
+	maxAreaUnits    = 0xFF
+	dateSize        = 3
+	maxDateMinutes  = 1<<24 - 1
+)
+
+// LanguageEnglish is the language code of English, the default language of
+// board and product info areas.
+const LanguageEnglish uint8 = 0x00
+
+// Chassis types (SMBIOS specification, system enclosure types) commonly used in
+// chassis info areas.
+const (
+	ChassisTypeOther     uint8 = 0x01
+	ChassisTypeUnknown   uint8 = 0x02
+	ChassisTypeRackMount uint8 = 0x17
+)
+
+// dateEpochUnix is the reference time of board manufacturing dates,
+// 1996-01-01 00:00 UTC, in seconds since the Unix epoch.
+const dateEpochUnix = 820454400
+
+// ChassisInfo is the chassis info area.
+type ChassisInfo struct {
+	// Type is the SMBIOS chassis type.
+	Type         uint8
+	PartNumber   Field
+	SerialNumber Field
+	// Custom holds the additional custom fields of the area.
+	Custom []Field
+}
+
+// BoardInfo is the board info area.
+type BoardInfo struct {
+	Language uint8
+	// ManufacturingDate is the manufacturing time with minute resolution. The
+	// zero time encodes as unspecified.
+	ManufacturingDate time.Time
+	Manufacturer      Field
+	ProductName       Field
+	SerialNumber      Field
+	PartNumber        Field
+	FileID            Field
+	// Custom holds the additional custom fields of the area.
+	Custom []Field
+}
+
+// ProductInfo is the product info area.
+type ProductInfo struct {
+	Language     uint8
+	Manufacturer Field
+	Name         Field
+	PartNumber   Field
+	Version      Field
+	SerialNumber Field
+	AssetTag     Field
+	FileID       Field
+	// Custom holds the additional custom fields of the area.
+	Custom []Field
+}
+
+// checksum returns the zero checksum of data, the value that makes the sum of
+// data and the checksum zero modulo 256.
+func checksum(data []byte) uint8 {
+	var sum uint8
+	for _, b := range data {
+		sum += b
+	}
+	return -sum
+}
+
+// areaAt returns the info area starting at offset after validating its
+// version, length and checksum. The returned slice excludes the checksum.
+func areaAt(data []byte, offset int, name string) ([]byte, error) {
+	if offset+areaHeaderSize > len(data) {
+		return nil, fmt.Errorf("%w: %s area at offset %d", ErrTruncated, name, offset)
+	}
+	if data[offset]&areaVersionMask != areaVersion {
+		return nil, fmt.Errorf("%w: %s area version 0x%02x", ErrInvalidArea, name, data[offset])
+	}
+	length := int(data[offset+1]) * areaUnit
+	if length < areaHeaderSize+1 {
+		return nil, fmt.Errorf("%w: %s area length %d", ErrInvalidArea, name, length)
+	}
+	if offset+length > len(data) {
+		return nil, fmt.Errorf("%w: %s area of %d bytes at offset %d", ErrTruncated, name, length, offset)
+	}
+	area := data[offset : offset+length]
+	if checksum(area) != 0 {
+		return nil, fmt.Errorf("%w: %s area", ErrChecksum, name)
+	}
+
+	return area[:length-1], nil
+}
+
+// areaBuilder builds an info area.
+type areaBuilder struct {
+	buf []byte
+	err error
+}
+
+func newAreaBuilder() *areaBuilder {
+	return &areaBuilder{buf: []byte{areaVersion, 0}}
+}
+
+func (b *areaBuilder) appendByte(v uint8) {
+	b.buf = append(b.buf, v)
+}
+
+func (b *areaBuilder) field(f Field) {
+	if b.err != nil {
+		return
+	}
+	b.buf, b.err = appendField(b.buf, f)
+}
+
+// finish terminates the fields, pads the area to a multiple of eight bytes and
+// appends the checksum.
+func (b *areaBuilder) finish(name string) ([]byte, error) {
+	if b.err != nil {
+		return nil, fmt.Errorf("%s area: %w", name, b.err)
+	}
+	b.buf = append(b.buf, endOfFields)
+	for (len(b.buf)+1)%areaUnit != 0 {
+		b.buf = append(b.buf, 0)
+	}
+	units := (len(b.buf) + 1) / areaUnit
+	if units > maxAreaUnits {
+		return nil, fmt.Errorf("%w: %s area of %d bytes", ErrAreaTooLarge, name, units*areaUnit)
+	}
+	b.buf[1] = uint8(units)
+
+	return append(b.buf, checksum(b.buf)), nil
+}
+
+func parseChassis(area []byte) (*ChassisInfo, error) {
+	if len(area) < areaHeaderSize+1 {
+		return nil, fmt.Errorf("%w: chassis area too short", ErrTruncated)
+	}
+	c := &ChassisInfo{Type: area[2]}
+	r := &fieldReader{data: area, pos: areaHeaderSize + 1}
+
+	var err error
+	if c.PartNumber, err = r.next(); err != nil {
+		return nil, fmt.Errorf("chassis part number: %w", err)
+	}
+	if c.SerialNumber, err = r.next(); err != nil {
+		return nil, fmt.Errorf("chassis serial number: %w", err)
+	}
+	if c.Custom, err = r.custom(); err != nil {
+		return nil, fmt.Errorf("chassis custom fields: %w", err)
+	}
+
+	return c, nil
+}
+
+func (c *ChassisInfo) marshal() ([]byte, error) {
+	b := newAreaBuilder()
+	b.appendByte(c.Type)
+	b.field(c.PartNumber)
+	b.field(c.SerialNumber)
+	for _, f := range c.Custom {
+		b.field(f)
+	}
+	return b.finish("chassis")
+}
+
+func parseBoard(area []byte) (*BoardInfo, error) {
+	if len(area) < areaHeaderSize+1+dateSize {
+		return nil, fmt.Errorf("%w: board area too short", ErrTruncated)
+	}
+	b := &BoardInfo{Language: area[2]}
+	d := area[3 : 3+dateSize]
+	if minutes := uint32(d[0]) | uint32(d[1])<<8 | uint32(d[2])<<16; minutes != 0 {
+		b.ManufacturingDate = time.Unix(dateEpochUnix, 0).UTC().Add(time.Duration(minutes) * time.Minute)
+	}
+	r := &fieldReader{data: area, pos: areaHeaderSize + 1 + dateSize}
+
+	for _, f := range []struct {
+		name  string
+		field *Field
+	}{
+		{"manufacturer", &b.Manufacturer},
+		{"product name", &b.ProductName},
+		{"serial number", &b.SerialNumber},
+		{"part number", &b.PartNumber},
+		{"FRU file ID", &b.FileID},
+	} {
+		var err error
+		if *f.field, err = r.next(); err != nil {
+			return nil, fmt.Errorf("board %s: %w", f.name, err)
+		}
+	}
+
+	var err error
+	if b.Custom, err = r.custom(); err != nil {
+		return nil, fmt.Errorf("board custom fields: %w", err)
+	}
+
+	return b, nil
+}
+
+func (bi *BoardInfo) marshal() ([]byte, error) {
+	var minutes uint32
+	if !bi.ManufacturingDate.IsZero() {
+		d := bi.ManufacturingDate.Sub(time.Unix(dateEpochUnix, 0))
+		if d < time.Minute || d/time.Minute > maxDateMinutes {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidDate, bi.ManufacturingDate)
+		}
+		minutes = uint32(d / time.Minute)
+	}
+
+	b := newAreaBuilder()
+	b.appendByte(bi.Language)
+	b.buf = binary.LittleEndian.AppendUint32(b.buf, minutes)[:len(b.buf)+dateSize]
+	b.field(bi.Manufacturer)
+	b.field(bi.ProductName)
+	b.field(bi.SerialNumber)
+	b.field(bi.PartNumber)
+	b.field(bi.FileID)
+	for _, f := range bi.Custom {
+		b.field(f)
+	}
+	return b.finish("board")
+}
+
+func parseProduct(area []byte) (*ProductInfo, error) {
+	if len(area) < areaHeaderSize+1 {
+		return nil, fmt.Errorf("%w: product area too short", ErrTruncated)
+	}
+	p := &ProductInfo{Language: area[2]}
+	r := &fieldReader{data: area, pos: areaHeaderSize + 1}
+
+	for _, f := range []struct {
+		name  string
+		field *Field
+	}{
+		{"manufacturer", &p.Manufacturer},
+		{"name", &p.Name},
+		{"part number", &p.PartNumber},
+		{"version", &p.Version},
+		{"serial number", &p.SerialNumber},
+		{"asset tag", &p.AssetTag},
+		{"FRU file ID", &p.FileID},
+	} {
+		var err error
+		if *f.field, err = r.next(); err != nil {
+			return nil, fmt.Errorf("product %s: %w", f.name, err)
+		}
+	}
+
+	var err error
+	if p.Custom, err = r.custom(); err != nil {
+		return nil, fmt.Errorf("product custom fields: %w", err)
+	}
+
+	return p, nil
+}
+
+func (p *ProductInfo) marshal() ([]byte, error) {
+	b := newAreaBuilder()
+	b.appendByte(p.Language)
+	b.field(p.Manufacturer)
+	b.field(p.Name)
+	b.field(p.PartNumber)
+	b.field(p.Version)
+	b.field(p.SerialNumber)
+	b.field(p.AssetTag)
+	b.field(p.FileID)
+	for _, f := range p.Custom {
+		b.field(f)
+	}
+	return b.finish("product")
+}
@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package fru provides parsing and generation of IPMI Platform Management FRU
+// Information Storage Definition v1.0 images, the format used by the EEPROMs
+// that describe field replaceable units such as baseboards, chassis and power
+// supplies. It is a stateless building block; reading and writing the devices
+// that hold FRU images is left to the caller.
+//
+// # Overview
+//
+// A FRU image starts with an 8-byte common header holding the offsets of up to
+// five areas:
+//   - The internal use area, an opaque blob reserved for the manufacturer
+//   - The chassis info area: chassis type, part and serial number
+//   - The board info area: manufacturing date, manufacturer, product name,
+//     serial and part number
+//   - The product info area: manufacturer, name, part number, version, serial
+//     number and asset tag
+//   - The multirecord area, a list of typed records such as power supply
+//     information or management access records
+//
+// The common header, every info area and every multirecord carry zero
+// checksums, which are verified when decoding and computed when encoding.
+//
+// # Field Encodings
+//
+// Info area fields are type/length encoded. The Field type keeps the encoded
+// bytes together with the type code, so decoding and re-encoding an image does
+// not change its fields:
+//   - FieldTypeBinary: binary data, rendered as hex by Field.String
+//   - FieldTypeBCDPlus: digits, space, dash and period packed two per byte
+//   - FieldTypeASCII6: the characters 0x20-0x5F packed four per three bytes
+//   - FieldTypeText: 8-bit ASCII+Latin 1 text
+//
+// Fields are created with Text, Binary, BCDPlus and ASCII6. Text in languages
+// other than English is specified as 16-bit Unicode but is rarely encountered;
+// it is decoded as 8-bit text.
+//
+// # Basic Usage
+//
+// Decoding an image read from an EEPROM:
+//
+//	data, err := os.ReadFile("/sys/bus/i2c/devices/3-0050/eeprom")
+//	if err != nil {
+//		return err
+//	}
+//	info, err := fru.Parse(data)
+//	if err != nil {
+//		return err
+//	}
+//	if info.Board != nil {
+//		fmt.Println(info.Board.Manufacturer, info.Board.SerialNumber)
+//	}
+//
+// Building an image:
+//
+//	info := &fru.FRU{
+//		Board: &fru.BoardInfo{
+//			ManufacturingDate: time.Date(2025, time.March, 1, 8, 0, 0, 0, time.UTC),
+//			Manufacturer:      fru.Text("Example Corp"),
+//			ProductName:       fru.Text("Example Board"),
+//			SerialNumber:      fru.Text("SN0001"),
+//		},
+//	}
+//	data, err := info.MarshalBinary()
+//
+// # Robustness
+//
+// Parse accepts arbitrary input and reports malformed images with the errors
+// of this package instead of panicking, which makes it suitable for fuzzing
+// against golden images. Images produced by MarshalBinary decode to the value
+// they were built from, except that areas lacking trailing fields decode with
+// those fields empty.
+package fru
@@ -0,0 +1,26 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package fru
+
+import "errors"
+
+var (
+	// ErrInvalidHeader indicates that the common header is missing, malformed or has an unsupported version.
+	ErrInvalidHeader = errors.New("invalid FRU common header")
+	// ErrInvalidArea indicates that an info area is malformed or has an unsupported version.
+	ErrInvalidArea = errors.New("invalid FRU info area")
+	// ErrInvalidMultiRecord indicates that a multirecord area record is malformed.
+	ErrInvalidMultiRecord = errors.New("invalid FRU multirecord")
+	// ErrChecksum indicates that a header, area or record checksum does not match its contents.
+	ErrChecksum = errors.New("FRU checksum mismatch")
+	// ErrTruncated indicates that an area or field extends beyond the end of the data.
+	ErrTruncated = errors.New("truncated FRU data")
+	// ErrFieldTooLong indicates that an encoded field exceeds the 63 bytes a type/length byte can describe.
+	ErrFieldTooLong = errors.New("FRU field too long")
+	// ErrInvalidCharacter indicates that a string cannot be represented in the requested field encoding.
+	ErrInvalidCharacter = errors.New("character not representable in FRU field encoding")
+	// ErrInvalidDate indicates that a manufacturing date is outside the range of the FRU date format.
+	ErrInvalidDate = errors.New("FRU manufacturing date out of range")
+	// ErrAreaTooLarge indicates that an area or the image exceeds the offsets and lengths the format can describe.
+	ErrAreaTooLarge = errors.New("FRU area too large")
+)
@@ -0,0 +1,216 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package fru
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// FieldType is the type code of a type/length encoded field.
+type FieldType uint8
+
+// Field type codes (FRU specification section 13).
+const (
+	// FieldTypeBinary marks binary or unspecified data.
+	FieldTypeBinary FieldType = 0
+	// FieldTypeBCDPlus marks BCD plus data: digits, space, dash and period.
+	FieldTypeBCDPlus FieldType = 1
+	// FieldTypeASCII6 marks 6-bit ASCII packed data.
+	FieldTypeASCII6 FieldType = 2
+	// FieldTypeText marks 8-bit ASCII+Latin 1 text.
+	FieldTypeText FieldType = 3
+)
+
+// Type/length byte fields.
+const (
+	typeLengthShift  = 6
+	typeLengthMask   = 0x3F
+	maxFieldLength   = 63
+	endOfFields      = 0xC1
+	ascii6Bits       = 6
+	ascii6Mask       = 0x3F
+	ascii6Base       = 0x20
+	ascii6Last       = 0x5F
+	bcdPlusDigitMask = 0x0F
+)
+
+// bcdPlusDigits maps BCD plus nibbles to characters. Nibbles 0xD-0xF are reserved.
+const bcdPlusDigits = "0123456789 -.???"
+
+// Field is a type/length encoded field of an info area. Data holds the
+// encoded bytes, so parsing and encoding a field is lossless regardless of
+// its type.
+type Field struct {
+	Type FieldType
+	Data []byte
+}
+
+// Text returns an 8-bit text field. Characters outside Latin 1 are replaced
+// by '?'. Single-character strings are padded with a space, since a text
+// field of length one would collide with the end-of-fields marker.
+func Text(s string) Field {
+	data := make([]byte, 0, len(s))
+	for _, r := range s {
+		if r > 0xFF {
+			r = '?'
+		}
+		data = append(data, byte(r))
+	}
+	if len(data) == 1 {
+		data = append(data, ' ')
+	}
+	return Field{Type: FieldTypeText, Data: data}
+}
+
+// Binary returns a binary field.
+func Binary(data []byte) Field {
+	return Field{Type: FieldTypeBinary, Data: data}
+}
+
+// BCDPlus returns a BCD plus field. Only digits, space, dash and period can
+// be encoded; odd-length strings are padded with a space.
+func BCDPlus(s string) (Field, error) {
+	data := make([]byte, (len(s)+1)/2)
+	for i := range len(data) * 2 {
+		c := byte(' ')
+		if i < len(s) {
+			c = s[i]
+		}
+		nibble := strings.IndexByte(bcdPlusDigits[:0xD], c)
+		if nibble < 0 {
+			return Field{}, fmt.Errorf("%w: %q in BCD plus", ErrInvalidCharacter, c)
+		}
+		if i%2 == 0 {
+			data[i/2] = byte(nibble) << 4
+		} else {
+			data[i/2] |= byte(nibble)
+		}
+	}
+	return Field{Type: FieldTypeBCDPlus, Data: data}, nil
+}
+
+// ASCII6 returns a 6-bit ASCII packed field. Only the characters 0x20-0x5F,
+// which exclude lower case letters, can be encoded.
+func ASCII6(s string) (Field, error) {
+	data := make([]byte, (len(s)*ascii6Bits+7)/8)
+	for i := range len(s) {
+		c := s[i]
+		if c < ascii6Base || c > ascii6Last {
+			return Field{}, fmt.Errorf("%w: %q in 6-bit ASCII", ErrInvalidCharacter, c)
+		}
+		v := c - ascii6Base
+		pos := i * ascii6Bits
+		data[pos/8] |= v << (pos % 8)
+		if pos%8 > 8-ascii6Bits {
+			data[pos/8+1] |= v >> (8 - pos%8)
+		}
+	}
+	return Field{Type: FieldTypeASCII6, Data: data}, nil
+}
+
+// IsEmpty reports whether the field carries no data.
+func (f Field) IsEmpty() bool {
+	return len(f.Data) == 0
+}
+
+// String decodes the field. Binary fields are returned hex encoded and
+// trailing padding spaces are removed.
+func (f Field) String() string {
+	var s string
+	switch f.Type {
+	case FieldTypeBinary:
+		return hex.EncodeToString(f.Data)
+	case FieldTypeBCDPlus:
+		var b strings.Builder
+		for _, d := range f.Data {
+			b.WriteByte(bcdPlusDigits[d>>4])
+			b.WriteByte(bcdPlusDigits[d&bcdPlusDigitMask])
+		}
+		s = b.String()
+	case FieldTypeASCII6:
+		var b strings.Builder
+		for i := range len(f.Data) * 8 / ascii6Bits {
+			pos := i * ascii6Bits
+			v := f.Data[pos/8] >> (pos % 8)
+			if pos%8 > 8-ascii6Bits {
+				v |= f.Data[pos/8+1] << (8 - pos%8)
+			}
+			b.WriteByte(v&ascii6Mask + ascii6Base)
+		}
+		s = b.String()
+	default:
+		runes := make([]rune, len(f.Data))
+		for i, c := range f.Data {
+			runes[i] = rune(c)
+		}
+		s = string(runes)
+	}
+	return strings.TrimRight(s, " ")
+}
+
+// appendField appends the type/length encoded field to buf.
+func appendField(buf []byte, f Field) ([]byte, error) {
+	if len(f.Data) > maxFieldLength {
+		return nil, fmt.Errorf("%w: %d bytes", ErrFieldTooLong, len(f.Data))
+	}
+	tl := byte(f.Type)<<typeLengthShift | byte(len(f.Data))
+	if tl == endOfFields {
+		return nil, fmt.Errorf("%w: text field of length 1", ErrInvalidCharacter)
+	}
+	buf = append(buf, tl)
+	return append(buf, f.Data...), nil
+}
+
+// fieldReader reads the type/length encoded fields of an info area.
+type fieldReader struct {
+	data []byte
+	pos  int
+	done bool
+}
+
+// next returns the next field. Once the end-of-fields marker has been read,
+// empty fields are returned, so areas lacking trailing mandatory fields decode.
+func (r *fieldReader) next() (Field, error) {
+	if r.done {
+		return Field{}, nil
+	}
+	if r.pos >= len(r.data) {
+		return Field{}, fmt.Errorf("%w: missing end-of-fields marker", ErrTruncated)
+	}
+
+	tl := r.data[r.pos]
+	if tl == endOfFields {
+		r.done = true
+		r.pos++
+		return Field{}, nil
+	}
+
+	length := int(tl & typeLengthMask)
+	start := r.pos + 1
+	if start+length > len(r.data) {
+		return Field{}, fmt.Errorf("%w: field at offset %d", ErrTruncated, r.pos)
+	}
+	r.pos = start + length
+
+	data := make([]byte, length)
+	copy(data, r.data[start:r.pos])
+
+	return Field{Type: FieldType(tl >> typeLengthShift), Data: data}, nil
+}
+
+// custom returns the remaining fields up to the end-of-fields marker.
+func (r *fieldReader) custom() ([]Field, error) {
+	var fields []Field
+	for {
+		f, err := r.next()
+		if err != nil {
+			return nil, err
+		}
+		if r.done {
+			return fields, nil
+		}
+		fields = append(fields, f)
+	}
+}
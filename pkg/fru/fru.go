@@ -0,0 +1,262 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package fru
+
+import (
+	"fmt"
+)
+
+// Common header layout.
+const (
+	// HeaderSize is the size of the common header in bytes.
+	HeaderSize    = 8
+	headerVersion = 0x01
+
+	offsetInternalUse = 1
+	offsetChassis     = 2
+	offsetBoard       = 3
+	offsetProduct     = 4
+	offsetMultiRecord = 5
+)
+
+// MultiRecord type IDs (FRU specification section 18).
+const (
+	RecordTypePowerSupply           uint8 = 0x00
+	RecordTypeDCOutput              uint8 = 0x01
+	RecordTypeDCLoad                uint8 = 0x02
+	RecordTypeManagementAccess      uint8 = 0x03
+	RecordTypeBaseCompatibility     uint8 = 0x04
+	RecordTypeExtendedCompatibility uint8 = 0x05
+	// RecordTypeOEMFirst is the first of the OEM record types 0xC0-0xFF.
+	RecordTypeOEMFirst uint8 = 0xC0
+)
+
+// MultiRecord header layout.
+const (
+	recordHeaderSize   = 5
+	recordVersion      = 0x02
+	recordVersionMask  = 0x0F
+	recordEndOfList    = 0x80
+	maxRecordDataBytes = 0xFF
+)
+
+// MultiRecord is a record of the multirecord area.
+type MultiRecord struct {
+	// Type is the record type ID.
+	Type uint8
+	// Data holds the record data without the record header.
+	Data []byte
+}
+
+// FRU is the decoded FRU information of a device. Areas that are not present
+// in the image are nil or empty.
+type FRU struct {
+	// InternalUse holds the internal use area without its version byte.
+	InternalUse  []byte
+	Chassis      *ChassisInfo
+	Board        *BoardInfo
+	Product      *ProductInfo
+	MultiRecords []MultiRecord
+}
+
+// Parse decodes a FRU image.
+func Parse(data []byte) (*FRU, error) {
+	f := &FRU{}
+	if err := f.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// UnmarshalBinary decodes a FRU image. Checksums are verified for the common
+// header, every info area and every multirecord. Data following the last area,
+// such as unprogrammed EEPROM space, is ignored.
+func (f *FRU) UnmarshalBinary(data []byte) error {
+	if len(data) < HeaderSize {
+		return fmt.Errorf("%w: got %d bytes", ErrTruncated, len(data))
+	}
+	header := data[:HeaderSize]
+	if header[0]&areaVersionMask != headerVersion {
+		return fmt.Errorf("%w: version 0x%02x", ErrInvalidHeader, header[0])
+	}
+	if checksum(header) != 0 {
+		return fmt.Errorf("%w: common header", ErrChecksum)
+	}
+
+	*f = FRU{}
+
+	offset := func(i int) int {
+		return int(header[i]) * areaUnit
+	}
+
+	if off := offset(offsetInternalUse); off != 0 {
+		// The internal use area has no length; it extends to the next area.
+		end := len(data)
+		for i := offsetChassis; i <= offsetMultiRecord; i++ {
+			if next := offset(i); next > off && next < end {
+				end = next
+			}
+		}
+		if off+1 > end {
+			return fmt.Errorf("%w: internal use area at offset %d", ErrTruncated, off)
+		}
+		if data[off]&areaVersionMask != areaVersion {
+			return fmt.Errorf("%w: internal use area version 0x%02x", ErrInvalidArea, data[off])
+		}
+		f.InternalUse = append([]byte{}, data[off+1:end]...)
+	}
+
+	if off := offset(offsetChassis); off != 0 {
+		area, err := areaAt(data, off, "chassis")
+		if err != nil {
+			return err
+		}
+		if f.Chassis, err = parseChassis(area); err != nil {
+			return err
+		}
+	}
+
+	if off := offset(offsetBoard); off != 0 {
+		area, err := areaAt(data, off, "board")
+		if err != nil {
+			return err
+		}
+		if f.Board, err = parseBoard(area); err != nil {
+			return err
+		}
+	}
+
+	if off := offset(offsetProduct); off != 0 {
+		area, err := areaAt(data, off, "product")
+		if err != nil {
+			return err
+		}
+		if f.Product, err = parseProduct(area); err != nil {
+			return err
+		}
+	}
+
+	if off := offset(offsetMultiRecord); off != 0 {
+		records, err := parseMultiRecords(data, off)
+		if err != nil {
+			return err
+		}
+		f.MultiRecords = records
+	}
+
+	return nil
+}
+
+// MarshalBinary encodes the FRU information as an image. Areas are laid out
+// in the order internal use, chassis, board, product and multirecord
+// directly after the common header.
+func (f *FRU) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, HeaderSize)
+	buf[0] = headerVersion
+
+	place := func(slot int, area []byte) error {
+		if len(buf)/areaUnit > maxAreaUnits {
+			return fmt.Errorf("%w: area offset %d", ErrAreaTooLarge, len(buf))
+		}
+		buf[slot] = uint8(len(buf) / areaUnit)
+		buf = append(buf, area...)
+		return nil
+	}
+
+	if f.InternalUse != nil {
+		area := append([]byte{areaVersion}, f.InternalUse...)
+		for len(area)%areaUnit != 0 {
+			area = append(area, 0)
+		}
+		if err := place(offsetInternalUse, area); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, a := range []struct {
+		slot    int
+		present bool
+		marshal func() ([]byte, error)
+	}{
+		{offsetChassis, f.Chassis != nil, func() ([]byte, error) { return f.Chassis.marshal() }},
+		{offsetBoard, f.Board != nil, func() ([]byte, error) { return f.Board.marshal() }},
+		{offsetProduct, f.Product != nil, func() ([]byte, error) { return f.Product.marshal() }},
+	} {
+		if !a.present {
+			continue
+		}
+		area, err := a.marshal()
+		if err != nil {
+			return nil, err
+		}
+		if err := place(a.slot, area); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(f.MultiRecords) > 0 {
+		area, err := marshalMultiRecords(f.MultiRecords)
+		if err != nil {
+			return nil, err
+		}
+		if err := place(offsetMultiRecord, area); err != nil {
+			return nil, err
+		}
+	}
+
+	buf[HeaderSize-1] = checksum(buf[:HeaderSize-1])
+
+	return buf, nil
+}
+
+// parseMultiRecords decodes the records of the multirecord area starting at offset.
+func parseMultiRecords(data []byte, offset int) ([]MultiRecord, error) {
+	var records []MultiRecord
+	for pos := offset; ; {
+		if pos+recordHeaderSize > len(data) {
+			return nil, fmt.Errorf("%w: multirecord header at offset %d", ErrTruncated, pos)
+		}
+		header := data[pos : pos+recordHeaderSize]
+		if checksum(header) != 0 {
+			return nil, fmt.Errorf("%w: multirecord header at offset %d", ErrChecksum, pos)
+		}
+		if header[1]&recordVersionMask != recordVersion {
+			return nil, fmt.Errorf("%w: version 0x%02x at offset %d", ErrInvalidMultiRecord, header[1], pos)
+		}
+
+		start := pos + recordHeaderSize
+		end := start + int(header[2])
+		if end > len(data) {
+			return nil, fmt.Errorf("%w: multirecord data at offset %d", ErrTruncated, start)
+		}
+		body := data[start:end]
+		if checksum(body) != header[3] {
+			return nil, fmt.Errorf("%w: multirecord data at offset %d", ErrChecksum, start)
+		}
+
+		records = append(records, MultiRecord{Type: header[0], Data: append([]byte{}, body...)})
+		if header[1]&recordEndOfList != 0 {
+			return records, nil
+		}
+		pos = end
+	}
+}
+
+// marshalMultiRecords encodes records as a multirecord area.
+func marshalMultiRecords(records []MultiRecord) ([]byte, error) {
+	var buf []byte
+	for i, r := range records {
+		if len(r.Data) > maxRecordDataBytes {
+			return nil, fmt.Errorf("%w: record %d has %d bytes of data", ErrInvalidMultiRecord, i, len(r.Data))
+		}
+		flags := uint8(recordVersion)
+		if i == len(records)-1 {
+			flags |= recordEndOfList
+		}
+		header := []byte{r.Type, flags, uint8(len(r.Data)), checksum(r.Data)}
+		buf = append(buf, header...)
+		buf = append(buf, checksum(header))
+		buf = append(buf, r.Data...)
+	}
+	return buf, nil
+}
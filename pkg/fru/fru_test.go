@@ -0,0 +1,222 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package fru
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// The images in testdata were assembled byte by byte from the FRU
+// specification rather than with MarshalBinary, so that they check the
+// encoder as well as the decoder.
+
+func mustField(f Field, err error) Field {
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+func readImage(t testing.TB, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestParseGolden(t *testing.T) {
+	board := &BoardInfo{
+		Language:          LanguageEnglish,
+		ManufacturingDate: time.Date(2025, time.March, 1, 8, 0, 0, 0, time.UTC),
+		Manufacturer:      Text("Example Corp"),
+		ProductName:       Text("Example Board"),
+		SerialNumber:      mustField(ASCII6("SN0001")),
+		PartNumber:        mustField(BCDPlus("1234-5678")),
+		FileID:            Binary([]byte{0x01, 0x02}),
+		Custom:            []Field{Text("REV A")},
+	}
+	product := &ProductInfo{
+		Language:     LanguageEnglish,
+		Manufacturer: Text("Example Corp"),
+		Name:         Text("Example Server"),
+		PartNumber:   Text("ES-1000"),
+		Version:      Text("1.0"),
+		SerialNumber: Text("PS0001"),
+		AssetTag:     Text(""),
+		FileID:       Binary([]byte{0x03}),
+	}
+	records := []MultiRecord{
+		{
+			Type: RecordTypePowerSupply,
+			Data: []byte{
+				0x20, 0x03, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0xD0, 0x07, 0xA6, 0x0E, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			Type: RecordTypeOEMFirst,
+			Data: []byte{0x57, 0x01, 0x00, 0xDE, 0xAD, 0xBE, 0xEF},
+		},
+	}
+
+	tests := []struct {
+		name string
+		file string
+		want *FRU
+		// roundTrip is set for images that MarshalBinary reproduces.
+		roundTrip bool
+	}{
+		{
+			name:      "board area",
+			file:      "board.bin",
+			want:      &FRU{Board: board},
+			roundTrip: true,
+		},
+		{
+			name:      "product area",
+			file:      "product.bin",
+			want:      &FRU{Product: product},
+			roundTrip: true,
+		},
+		{
+			name: "product area lacking trailing fields",
+			file: "product-short.bin",
+			want: &FRU{Product: &ProductInfo{
+				Language:     0x19,
+				Manufacturer: Text("Example Corp"),
+				Name:         Text("Example Server"),
+				PartNumber:   Text("ES-1000"),
+				Version:      Text("1.0"),
+				SerialNumber: Text("PS0001"),
+			}},
+		},
+		{
+			name:      "multirecord area",
+			file:      "multirecord.bin",
+			want:      &FRU{MultiRecords: records},
+			roundTrip: true,
+		},
+		{
+			name: "all areas followed by unprogrammed space",
+			file: "full.bin",
+			want: &FRU{
+				InternalUse: []byte{0xAA, 0xBB, 0xCC, 0xDD, 0x00, 0x00, 0x00},
+				Chassis: &ChassisInfo{
+					Type:         ChassisTypeRackMount,
+					PartNumber:   Text("CH-100"),
+					SerialNumber: Text("CS0001"),
+				},
+				Board:        board,
+				Product:      product,
+				MultiRecords: records,
+			},
+			roundTrip: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := readImage(t, tt.file)
+
+			got, err := Parse(data)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %+v, want %+v", got, tt.want)
+			}
+
+			if !tt.roundTrip {
+				return
+			}
+			out, err := got.MarshalBinary()
+			if err != nil {
+				t.Fatalf("MarshalBinary() error = %v", err)
+			}
+			if !bytes.Equal(out, data[:len(out)]) {
+				t.Errorf("MarshalBinary() = % x, want % x", out, data[:len(out)])
+			}
+			for _, b := range data[len(out):] {
+				if b != 0xFF {
+					t.Fatalf("MarshalBinary() dropped % x", data[len(out):])
+				}
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		wantErr error
+	}{
+		{name: "common header checksum", file: "header-checksum.bin", wantErr: ErrChecksum},
+		{name: "common header version", file: "header-version.bin", wantErr: ErrInvalidHeader},
+		{name: "board area checksum", file: "board-checksum.bin", wantErr: ErrChecksum},
+		{name: "truncated board area", file: "board-truncated.bin", wantErr: ErrTruncated},
+		{name: "product area checksum", file: "product-checksum.bin", wantErr: ErrChecksum},
+		{name: "multirecord header checksum", file: "multirecord-header-checksum.bin", wantErr: ErrChecksum},
+		{name: "multirecord data checksum", file: "multirecord-data-checksum.bin", wantErr: ErrChecksum},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(readImage(t, tt.file)); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Parse() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("short image", func(t *testing.T) {
+		if _, err := Parse(readImage(t, "board.bin")[:HeaderSize-1]); !errors.Is(err, ErrTruncated) {
+			t.Errorf("Parse() error = %v, want %v", err, ErrTruncated)
+		}
+	})
+}
+
+// FuzzParse checks that Parse never panics and that whatever it accepts is
+// encoded by MarshalBinary into an image that decodes and encodes to the
+// same bytes again.
+func FuzzParse(f *testing.F) {
+	names, err := filepath.Glob(filepath.Join("testdata", "*.bin"))
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, name := range names {
+		f.Add(readImage(f, filepath.Base(name)))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		info, err := Parse(data)
+		if err != nil {
+			return
+		}
+		out, err := info.MarshalBinary()
+		if err != nil {
+			if !errors.Is(err, ErrAreaTooLarge) {
+				t.Fatalf("MarshalBinary() of a parsed image: %v", err)
+			}
+			return
+		}
+		again, err := Parse(out)
+		if err != nil {
+			t.Fatalf("Parse() of a marshaled image: %v", err)
+		}
+		out2, err := again.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary() of a reparsed image: %v", err)
+		}
+		if !bytes.Equal(out, out2) {
+			t.Fatalf("encoding not stable:\n% x\n% x", out, out2)
+		}
+	})
+}
@@ -55,6 +55,13 @@ const (
 	SubjectUserChangePassword = "user.change_password"
 	SubjectUserResetPassword  = "user.reset_password"
 	SubjectUserAuthenticate   = "user.authenticate"
+	SubjectUserCheckLogin     = "user.check_login"
+
+	// Second factor
+	SubjectUserTOTPEnroll        = "user.totp_enroll"
+	SubjectUserTOTPConfirm       = "user.totp_confirm"
+	SubjectUserTOTPDisable       = "user.totp_disable"
+	SubjectUserTOTPRecoveryCodes = "user.totp_recovery_codes"
 )
 
 // Security Management Service Subjects
@@ -94,6 +101,37 @@ const (
 	SubjectThermalZoneInfo = "thermal_zone.info"
 	SubjectThermalZoneSet  = "thermal_zone.set"
 	SubjectThermalZoneList = "thermal_zone.list"
+
+	// Cooling device management
+	SubjectCoolingDeviceInfo = "cooling_device.info"
+	SubjectCoolingDeviceSet  = "cooling_device.set"
+	SubjectCoolingDeviceList = "cooling_device.list"
+	SubjectCoolingDeviceMode = "cooling_device.mode"
+)
+
+// Update Management Service Subjects
+const (
+	// Firmware update jobs
+	SubjectUpdateStart = "update.start"
+	SubjectUpdateInfo  = "update.info"
+	SubjectUpdateList  = "update.list"
+)
+
+// Virtual Media Service Subjects
+const (
+	// USB mass storage media
+	SubjectVirtualMediaInfo   = "virtual_media.info"
+	SubjectVirtualMediaInsert = "virtual_media.insert"
+	SubjectVirtualMediaEject  = "virtual_media.eject"
+)
+
+// System Event Log Service Subjects
+const (
+	// Event log access
+	SubjectSELInfo  = "sel.info"
+	SubjectSELEntry = "sel.entry"
+	SubjectSELList  = "sel.list"
+	SubjectSELClear = "sel.clear"
 )
 
 // System Information Service Subjects
@@ -109,6 +147,11 @@ const (
 	SubjectPowerAction = "power.action"
 	SubjectPowerResult = "power.result"
 	SubjectPowerStatus = "power.status"
+
+	// Power monitoring and capping
+	SubjectPowerReading   = "power.reading"
+	SubjectPowerLimitInfo = "power_limit.info"
+	SubjectPowerLimitSet  = "power_limit.set"
 )
 
 // LED Management Service Subjects (for coordination)
@@ -127,6 +170,9 @@ const (
 	// System events
 	SubjectSystemEvent = "system.event"
 	SubjectAlertEvent  = "alert.event"
+
+	// Power limit exceptions and recoveries
+	SubjectPowerLimitEvent = "powermgr.events.power_limit"
 )
 
 // Stream Subjects for JetStream Persistence
@@ -137,6 +183,7 @@ const (
 	StreamSubjectSystemEvents    = "system.event.>"
 	StreamSubjectSecurityEvents  = "security.event.>"
 	StreamSubjectInventoryEvents = "inventory.event.>"
+	StreamSubjectSystemEventLog  = "selmgr.record.>"
 )
 
 // Internal IPC Subjects (for service-to-service communication)
@@ -168,6 +215,9 @@ const (
 	QueueGroupThermalManager = "thermalmgr"
 	QueueGroupPowerManager   = "powermgr"
 	QueueGroupLEDManager     = "ledmgr"
+	QueueGroupSELManager     = "selmgr"
+	QueueGroupUpdateManager  = "updatemgr"
+	QueueGroupKVMServer      = "kvmsrv"
 )
 
 // Default Timeouts (in milliseconds)
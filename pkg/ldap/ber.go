@@ -0,0 +1,207 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ldap
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// BER identifier octets used by LDAP (RFC 4511 section 5.1). LDAP only uses
+// tag numbers below 31, so every identifier fits in a single octet.
+const (
+	classUniversal   byte = 0x00
+	classApplication byte = 0x40
+	classContext     byte = 0x80
+	constructed      byte = 0x20
+
+	tagBoolean     = classUniversal | 0x01
+	tagInteger     = classUniversal | 0x02
+	tagOctetString = classUniversal | 0x04
+	tagEnumerated  = classUniversal | 0x0a
+	tagSequence    = classUniversal | constructed | 0x10
+
+	highTagNumber = 0x1f
+)
+
+// maxMessageSize bounds the size of a message read from a server.
+const maxMessageSize = 4 << 20
+
+// element is a decoded BER element. Primitive elements carry their contents
+// in value, constructed ones their nested elements in children.
+type element struct {
+	tag      byte
+	value    []byte
+	children []*element
+}
+
+func (e *element) constructed() bool {
+	return e.tag&constructed != 0
+}
+
+// child returns the i-th nested element or nil.
+func (e *element) child(i int) *element {
+	if e == nil || i >= len(e.children) {
+		return nil
+	}
+	return e.children[i]
+}
+
+func (e *element) String() string {
+	if e == nil {
+		return ""
+	}
+	return string(e.value)
+}
+
+// Int returns the value of an INTEGER or ENUMERATED element.
+func (e *element) Int() int64 {
+	if e == nil || len(e.value) == 0 {
+		return 0
+	}
+	n := int64(int8(e.value[0]))
+	for _, b := range e.value[1:] {
+		n = n<<8 | int64(b)
+	}
+	return n
+}
+
+func newConstructed(tag byte, children ...*element) *element {
+	return &element{tag: tag | constructed, children: children}
+}
+
+func newString(tag byte, s string) *element {
+	return &element{tag: tag, value: []byte(s)}
+}
+
+func newInt(tag byte, n int64) *element {
+	var value []byte
+	for {
+		value = append([]byte{byte(n)}, value...)
+		if (n < 0x80 && n >= -0x80) || len(value) == 8 {
+			break
+		}
+		n >>= 8
+	}
+	return &element{tag: tag, value: value}
+}
+
+func newBool(tag byte, b bool) *element {
+	if b {
+		return &element{tag: tag, value: []byte{0xff}}
+	}
+	return &element{tag: tag, value: []byte{0x00}}
+}
+
+// encode appends the BER encoding of e to b.
+func (e *element) encode(b []byte) []byte {
+	contents := e.value
+	if e.constructed() {
+		contents = nil
+		for _, c := range e.children {
+			contents = c.encode(contents)
+		}
+	}
+
+	b = append(b, e.tag)
+	switch n := len(contents); {
+	case n < 0x80:
+		b = append(b, byte(n))
+	default:
+		var length []byte
+		for ; n > 0; n >>= 8 {
+			length = append([]byte{byte(n)}, length...)
+		}
+		b = append(b, 0x80|byte(len(length)))
+		b = append(b, length...)
+	}
+	return append(b, contents...)
+}
+
+// readElement reads one element from r.
+func readElement(r *bufio.Reader) (*element, error) {
+	tag, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	if tag&highTagNumber == highTagNumber {
+		return nil, fmt.Errorf("%w: unsupported tag 0x%02x", ErrMalformedMessage, tag)
+	}
+
+	first, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	length := int(first)
+	if first&0x80 != 0 {
+		octets := int(first &^ 0x80)
+		if octets == 0 || octets > 4 {
+			return nil, fmt.Errorf("%w: unsupported length encoding", ErrMalformedMessage)
+		}
+		length = 0
+		for range octets {
+			b, err := r.ReadByte()
+			if err != nil {
+				return nil, err
+			}
+			length = length<<8 | int(b)
+		}
+	}
+	if length > maxMessageSize {
+		return nil, fmt.Errorf("%w: message of %d bytes exceeds the limit", ErrMalformedMessage, length)
+	}
+
+	contents := make([]byte, length)
+	if _, err := io.ReadFull(r, contents); err != nil {
+		return nil, err
+	}
+	return parseContents(tag, contents)
+}
+
+// parseElements decodes the concatenated elements of data.
+func parseElements(data []byte) ([]*element, error) {
+	var elements []*element
+	for len(data) > 0 {
+		if len(data) < 2 || data[0]&highTagNumber == highTagNumber {
+			return nil, ErrMalformedMessage
+		}
+		tag, length, header := data[0], int(data[1]), 2
+		if data[1]&0x80 != 0 {
+			octets := int(data[1] &^ 0x80)
+			if octets == 0 || octets > 4 || len(data) < 2+octets {
+				return nil, ErrMalformedMessage
+			}
+			length = 0
+			for _, b := range data[2 : 2+octets] {
+				length = length<<8 | int(b)
+			}
+			header += octets
+		}
+		if length < 0 || length > len(data)-header {
+			return nil, ErrMalformedMessage
+		}
+
+		e, err := parseContents(tag, data[header:header+length])
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, e)
+		data = data[header+length:]
+	}
+	return elements, nil
+}
+
+func parseContents(tag byte, contents []byte) (*element, error) {
+	e := &element{tag: tag}
+	if !e.constructed() {
+		e.value = contents
+		return e, nil
+	}
+	children, err := parseElements(contents)
+	if err != nil {
+		return nil, err
+	}
+	e.children = children
+	return e, nil
+}
@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package ldap provides a minimal LDAPv3 client for authenticating users
+// against a directory such as OpenLDAP or Active Directory. It implements
+// the subset of RFC 4511 a BMC needs to check credentials and look up group
+// memberships, without depending on a full-featured LDAP library.
+//
+// # Overview
+//
+// The client supports:
+//   - Plain ldap:// and TLS secured ldaps:// connections
+//   - The StartTLS extended operation
+//   - Simple binds, refusing empty passwords that servers would treat as
+//     unauthenticated binds
+//   - Searches with filters in the string representation of RFC 4515,
+//     including extensible matches such as the Active Directory
+//     LDAP_MATCHING_RULE_IN_CHAIN rule used to resolve nested groups
+//
+// SASL binds, controls, referral chasing, modifications and asynchronous
+// operations are not supported. Operations on a connection are performed one
+// at a time and honor the deadline and cancellation of their context.
+//
+// # Basic Usage
+//
+// Checking the password of a user and listing the groups it belongs to:
+//
+//	conn, err := ldap.Dial(ctx, "ldaps://dc1.example.com", nil)
+//	if err != nil {
+//		return err
+//	}
+//	defer conn.Close()
+//
+//	if err := conn.Bind(ctx, "cn=bmc,ou=services,dc=example,dc=com", servicePassword); err != nil {
+//		return err
+//	}
+//	entries, err := conn.Search(ctx, &ldap.SearchRequest{
+//		BaseDN:     "dc=example,dc=com",
+//		Scope:      ldap.ScopeWholeSubtree,
+//		Filter:     "(sAMAccountName=" + ldap.EscapeFilter(username) + ")",
+//		Attributes: []string{"memberOf"},
+//		SizeLimit:  2,
+//	})
+//	if err != nil || len(entries) != 1 {
+//		return errors.New("unknown user")
+//	}
+//	if err := conn.Bind(ctx, entries[0].DN, password); errors.Is(err, ldap.ErrInvalidCredentials) {
+//		return errors.New("wrong password")
+//	}
+//
+// # Error Handling
+//
+// Errors wrap one of the package's sentinel errors. ErrInvalidCredentials
+// reports a rejected bind, ErrConnectionFailed and ErrTLSFailed a server that
+// could not be used, which callers typically answer by trying another
+// server. Other results reported by the server wrap ErrOperationFailed along
+// with the result code and diagnostic message.
+//
+// # Testing
+//
+// Package ldaptest provides an in-process directory to run the client and
+// code built on it against.
+package ldap
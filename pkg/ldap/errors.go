@@ -0,0 +1,24 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ldap
+
+import "errors"
+
+var (
+	// ErrInvalidURL indicates that a server URL is malformed or not an ldap:// or ldaps:// URL.
+	ErrInvalidURL = errors.New("invalid LDAP URL")
+	// ErrConnectionFailed indicates that the server could not be reached or the connection broke.
+	ErrConnectionFailed = errors.New("LDAP connection failed")
+	// ErrTLSFailed indicates that securing the connection with TLS failed.
+	ErrTLSFailed = errors.New("LDAP TLS negotiation failed")
+	// ErrMalformedMessage indicates that the server sent a message that is not valid LDAP.
+	ErrMalformedMessage = errors.New("malformed LDAP message")
+	// ErrInvalidFilter indicates that a search filter is not a valid RFC 4515 filter.
+	ErrInvalidFilter = errors.New("invalid LDAP filter")
+	// ErrInvalidCredentials indicates that a bind was rejected because of wrong credentials.
+	ErrInvalidCredentials = errors.New("invalid LDAP credentials")
+	// ErrSizeLimitExceeded indicates that a search matched more entries than its size limit.
+	ErrSizeLimitExceeded = errors.New("LDAP size limit exceeded")
+	// ErrOperationFailed indicates that the server reported an error for an operation.
+	ErrOperationFailed = errors.New("LDAP operation failed")
+)
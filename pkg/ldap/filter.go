@@ -0,0 +1,251 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ldap
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// Filter choices of a SearchRequest (RFC 4511 section 4.5.1.7).
+const (
+	filterAnd             = classContext | constructed | 0
+	filterOr              = classContext | constructed | 1
+	filterNot             = classContext | constructed | 2
+	filterEqualityMatch   = classContext | constructed | 3
+	filterSubstrings      = classContext | constructed | 4
+	filterGreaterOrEqual  = classContext | constructed | 5
+	filterLessOrEqual     = classContext | constructed | 6
+	filterPresent         = classContext | 7
+	filterApproxMatch     = classContext | constructed | 8
+	filterExtensibleMatch = classContext | constructed | 9
+
+	substringInitial = classContext | 0
+	substringAny     = classContext | 1
+	substringFinal   = classContext | 2
+
+	matchingRule = classContext | 1
+	matchingType = classContext | 2
+	matchValue   = classContext | 3
+	matchDNAttrs = classContext | 4
+)
+
+const (
+	// maxFilterDepth bounds the nesting of filters.
+	maxFilterDepth = 32
+	// filterEscapeSet holds the characters EscapeFilter escapes.
+	filterEscapeSet = "\\*()\x00"
+)
+
+// EscapeFilter escapes a value for use in a search filter, so that user
+// input such as a username cannot change the structure of the filter.
+func EscapeFilter(value string) string {
+	var b strings.Builder
+	for i := range len(value) {
+		if c := value[i]; strings.IndexByte(filterEscapeSet, c) >= 0 {
+			fmt.Fprintf(&b, "\\%02x", c)
+		} else {
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// compileFilter compiles a filter in the string representation of RFC 4515.
+func compileFilter(filter string) (*element, error) {
+	p := filterParser{s: filter}
+	e, err := p.filter(0)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q: %w", ErrInvalidFilter, filter, err)
+	}
+	if p.pos != len(p.s) {
+		return nil, fmt.Errorf("%w: %q: trailing characters", ErrInvalidFilter, filter)
+	}
+	return e, nil
+}
+
+type filterParser struct {
+	s   string
+	pos int
+}
+
+func (p *filterParser) filter(depth int) (*element, error) {
+	if depth > maxFilterDepth {
+		return nil, fmt.Errorf("nested too deeply")
+	}
+	if p.pos >= len(p.s) || p.s[p.pos] != '(' {
+		return nil, fmt.Errorf("expected ( at offset %d", p.pos)
+	}
+	p.pos++
+	if p.pos >= len(p.s) {
+		return nil, fmt.Errorf("unexpected end")
+	}
+
+	var e *element
+	var err error
+	switch p.s[p.pos] {
+	case '&', '|':
+		tag := byte(filterAnd)
+		if p.s[p.pos] == '|' {
+			tag = filterOr
+		}
+		p.pos++
+		e = newConstructed(tag)
+		for p.pos < len(p.s) && p.s[p.pos] == '(' {
+			child, err := p.filter(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			e.children = append(e.children, child)
+		}
+		if len(e.children) == 0 {
+			return nil, fmt.Errorf("empty filter list at offset %d", p.pos)
+		}
+	case '!':
+		p.pos++
+		child, err := p.filter(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		e = newConstructed(filterNot, child)
+	default:
+		end := strings.IndexByte(p.s[p.pos:], ')')
+		if end < 0 {
+			return nil, fmt.Errorf("missing ) after offset %d", p.pos)
+		}
+		e, err = item(p.s[p.pos : p.pos+end])
+		if err != nil {
+			return nil, err
+		}
+		p.pos += end
+	}
+
+	if p.pos >= len(p.s) || p.s[p.pos] != ')' {
+		return nil, fmt.Errorf("expected ) at offset %d", p.pos)
+	}
+	p.pos++
+	return e, nil
+}
+
+// item compiles a simple, presence, substring or extensible match.
+func item(s string) (*element, error) {
+	eq := strings.IndexByte(s, '=')
+	if eq <= 0 {
+		return nil, fmt.Errorf("invalid item %q", s)
+	}
+	attr, raw := s[:eq], s[eq+1:]
+
+	tag := byte(filterEqualityMatch)
+	switch attr[len(attr)-1] {
+	case '~':
+		tag, attr = filterApproxMatch, attr[:len(attr)-1]
+	case '>':
+		tag, attr = filterGreaterOrEqual, attr[:len(attr)-1]
+	case '<':
+		tag, attr = filterLessOrEqual, attr[:len(attr)-1]
+	case ':':
+		return extensible(attr[:len(attr)-1], raw)
+	}
+	if attr == "" {
+		return nil, fmt.Errorf("invalid item %q", s)
+	}
+
+	if tag == filterEqualityMatch && raw == "*" {
+		return newString(filterPresent, attr), nil
+	}
+	if tag == filterEqualityMatch && strings.Contains(raw, "*") {
+		return substrings(attr, raw)
+	}
+
+	value, err := unescape(raw)
+	if err != nil {
+		return nil, err
+	}
+	return newConstructed(tag, newString(tagOctetString, attr), newString(tagOctetString, value)), nil
+}
+
+func substrings(attr, raw string) (*element, error) {
+	parts := strings.Split(raw, "*")
+	subs := newConstructed(tagSequence)
+	for i, part := range parts {
+		if part == "" {
+			continue
+		}
+		value, err := unescape(part)
+		if err != nil {
+			return nil, err
+		}
+		tag := byte(substringAny)
+		switch i {
+		case 0:
+			tag = substringInitial
+		case len(parts) - 1:
+			tag = substringFinal
+		}
+		subs.children = append(subs.children, newString(tag, value))
+	}
+	return newConstructed(filterSubstrings, newString(tagOctetString, attr), subs), nil
+}
+
+// extensible compiles the attr[:dn][:rule]:=value form.
+func extensible(left, raw string) (*element, error) {
+	fields := strings.Split(left, ":")
+	e := newConstructed(filterExtensibleMatch)
+	var dnAttrs bool
+	var rule string
+	for i, field := range fields {
+		switch {
+		case i == 0:
+			if field != "" {
+				e.children = append(e.children, newString(matchingType, field))
+			}
+		case field == "dn" && !dnAttrs && rule == "":
+			dnAttrs = true
+		case rule == "" && field != "":
+			rule = field
+		default:
+			return nil, fmt.Errorf("invalid extensible match %q", left)
+		}
+	}
+	if rule == "" && len(e.children) == 0 {
+		return nil, fmt.Errorf("extensible match %q needs a type or a matching rule", left)
+	}
+	if rule != "" {
+		e.children = append([]*element{newString(matchingRule, rule)}, e.children...)
+	}
+
+	value, err := unescape(raw)
+	if err != nil {
+		return nil, err
+	}
+	e.children = append(e.children, newString(matchValue, value))
+	if dnAttrs {
+		e.children = append(e.children, newBool(matchDNAttrs, true))
+	}
+	return e, nil
+}
+
+// unescape decodes the \XX escapes of a filter value.
+func unescape(s string) (string, error) {
+	if !strings.Contains(s, "\\") {
+		return s, nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' {
+			b.WriteByte(s[i])
+			continue
+		}
+		if i+3 > len(s) {
+			return "", fmt.Errorf("truncated escape in %q", s)
+		}
+		c, err := hex.DecodeString(s[i+1 : i+3])
+		if err != nil {
+			return "", fmt.Errorf("invalid escape in %q", s)
+		}
+		b.Write(c)
+		i += 2
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,387 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ldap
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Default ports of the ldap and ldaps URL schemes.
+const (
+	DefaultPort    = "389"
+	DefaultTLSPort = "636"
+)
+
+// Protocol operations (RFC 4511 section 4.2 to 4.12).
+const (
+	opBindRequest     = classApplication | constructed | 0
+	opBindResponse    = classApplication | constructed | 1
+	opUnbindRequest   = classApplication | 2
+	opSearchRequest   = classApplication | constructed | 3
+	opSearchEntry     = classApplication | constructed | 4
+	opSearchDone      = classApplication | constructed | 5
+	opSearchReference = classApplication | constructed | 19
+	opExtendedRequest = classApplication | constructed | 23
+	opExtendedResp    = classApplication | constructed | 24
+
+	authSimple   = classContext | 0
+	extendedName = classContext | 0
+)
+
+// Result codes (RFC 4511 appendix A) the client tells apart.
+const (
+	ResultSuccess            = 0
+	ResultSizeLimitExceeded  = 4
+	ResultInvalidCredentials = 49
+	ResultUnavailable        = 52
+)
+
+const (
+	protocolVersion = 3
+	startTLSOID     = "1.3.6.1.4.1.1466.20037"
+	// unsolicitedNotificationID is the message ID of notifications the
+	// server sends on its own, such as the notice of disconnection.
+	unsolicitedNotificationID = 0
+)
+
+// Scope is the scope of a search.
+type Scope int
+
+// Search scopes.
+const (
+	ScopeBaseObject   Scope = 0
+	ScopeSingleLevel  Scope = 1
+	ScopeWholeSubtree Scope = 2
+)
+
+// SearchRequest describes a search.
+type SearchRequest struct {
+	// BaseDN is the entry the search starts at.
+	BaseDN string
+	// Scope selects the entries below BaseDN that are searched.
+	Scope Scope
+	// Filter is a filter in the string representation of RFC 4515. Values
+	// taken from user input must be escaped with EscapeFilter.
+	Filter string
+	// Attributes lists the attributes to return, all user attributes if empty.
+	Attributes []string
+	// SizeLimit bounds the number of returned entries, zero means no limit.
+	SizeLimit int
+	// TimeLimit bounds the time the server spends on the search.
+	TimeLimit time.Duration
+}
+
+// Entry is an entry returned by a search.
+type Entry struct {
+	DN         string
+	Attributes map[string][]string
+}
+
+// Values returns the values of an attribute, whose name is compared
+// case-insensitively.
+func (e *Entry) Values(name string) []string {
+	for attr, values := range e.Attributes {
+		if strings.EqualFold(attr, name) {
+			return values
+		}
+	}
+	return nil
+}
+
+// Value returns the first value of an attribute or an empty string.
+func (e *Entry) Value(name string) string {
+	if values := e.Values(name); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// Conn is a connection to an LDAP server. Operations are performed one at a
+// time.
+type Conn struct {
+	mu     sync.Mutex
+	conn   net.Conn
+	r      *bufio.Reader
+	host   string
+	nextID int64
+	tls    bool
+}
+
+// Dial connects to the server of an ldap:// or ldaps:// URL. LDAPS
+// connections are secured with tlsConfig, whose ServerName defaults to the
+// host of the URL.
+func Dial(ctx context.Context, rawURL string, tlsConfig *tls.Config) (*Conn, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidURL, err)
+	}
+
+	port := u.Port()
+	switch u.Scheme {
+	case "ldap":
+		if port == "" {
+			port = DefaultPort
+		}
+	case "ldaps":
+		if port == "" {
+			port = DefaultTLSPort
+		}
+	default:
+		return nil, fmt.Errorf("%w: unsupported scheme %q", ErrInvalidURL, u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("%w: missing host", ErrInvalidURL)
+	}
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", net.JoinHostPort(u.Hostname(), port))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrConnectionFailed, err)
+	}
+
+	c := &Conn{conn: conn, r: bufio.NewReader(conn), host: u.Hostname(), nextID: 1}
+	if u.Scheme == "ldaps" {
+		if err := c.handshake(ctx, tlsConfig); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// StartTLS secures a plain connection with the StartTLS extended operation
+// (RFC 4511 section 4.14). The ServerName of tlsConfig defaults to the host
+// the connection was dialed to.
+func (c *Conn) StartTLS(ctx context.Context, tlsConfig *tls.Config) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.tls {
+		return fmt.Errorf("%w: connection already uses TLS", ErrTLSFailed)
+	}
+
+	resp, err := c.roundTrip(ctx, newConstructed(opExtendedRequest, newString(extendedName, startTLSOID)), opExtendedResp)
+	if err != nil {
+		return err
+	}
+	if err := resultError(resp[0]); err != nil {
+		return fmt.Errorf("%w: %w", ErrTLSFailed, err)
+	}
+	return c.handshake(ctx, tlsConfig)
+}
+
+func (c *Conn) handshake(ctx context.Context, tlsConfig *tls.Config) error {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if tlsConfig != nil {
+		cfg = tlsConfig.Clone()
+	}
+	if cfg.ServerName == "" {
+		cfg.ServerName = c.host
+	}
+
+	tlsConn := tls.Client(c.conn, cfg)
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		return fmt.Errorf("%w: %w", ErrTLSFailed, err)
+	}
+	c.conn = tlsConn
+	c.r = bufio.NewReader(tlsConn)
+	c.tls = true
+	return nil
+}
+
+// Bind authenticates the connection with a simple bind. Empty passwords are
+// rejected, since servers treat them as an unauthenticated bind that always
+// succeeds (RFC 4513 section 5.1.2).
+func (c *Conn) Bind(ctx context.Context, dn, password string) error {
+	if password == "" {
+		return fmt.Errorf("%w: empty password", ErrInvalidCredentials)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	resp, err := c.roundTrip(ctx, newConstructed(opBindRequest,
+		newInt(tagInteger, protocolVersion),
+		newString(tagOctetString, dn),
+		newString(authSimple, password),
+	), opBindResponse)
+	if err != nil {
+		return err
+	}
+	return resultError(resp[0])
+}
+
+// Search performs a search and returns the entries found. Search result
+// references are ignored. When the size limit is exceeded, the entries
+// returned so far are returned along with ErrSizeLimitExceeded.
+func (c *Conn) Search(ctx context.Context, req *SearchRequest) ([]*Entry, error) {
+	filter, err := compileFilter(req.Filter)
+	if err != nil {
+		return nil, err
+	}
+	attrs := newConstructed(tagSequence)
+	for _, attr := range req.Attributes {
+		attrs.children = append(attrs.children, newString(tagOctetString, attr))
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	resp, err := c.roundTrip(ctx, newConstructed(opSearchRequest,
+		newString(tagOctetString, req.BaseDN),
+		newInt(tagEnumerated, int64(req.Scope)),
+		newInt(tagEnumerated, 0),
+		newInt(tagInteger, int64(req.SizeLimit)),
+		newInt(tagInteger, int64(req.TimeLimit/time.Second)),
+		newBool(tagBoolean, false),
+		filter,
+		attrs,
+	), opSearchDone)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*Entry, 0, len(resp)-1)
+	for _, op := range resp[:len(resp)-1] {
+		if op.tag != opSearchEntry {
+			continue
+		}
+		entry, err := parseEntry(op)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	done := resp[len(resp)-1]
+	if done.child(0).Int() == ResultSizeLimitExceeded {
+		return entries, fmt.Errorf("%w: %s", ErrSizeLimitExceeded, done.child(2).String())
+	}
+	if err := resultError(done); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Close sends an unbind request and closes the connection.
+func (c *Conn) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	msg := newConstructed(tagSequence, newInt(tagInteger, c.nextID), &element{tag: opUnbindRequest})
+	_ = c.conn.SetWriteDeadline(time.Now().Add(time.Second))
+	_, _ = c.conn.Write(msg.encode(nil))
+	return c.conn.Close()
+}
+
+// roundTrip sends a request and collects the protocol operations of the
+// responses up to and including the one tagged final. The caller must hold
+// c.mu.
+func (c *Conn) roundTrip(ctx context.Context, op *element, final byte) ([]*element, error) {
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = c.conn.SetDeadline(deadline)
+	} else {
+		_ = c.conn.SetDeadline(time.Time{})
+	}
+	stop := context.AfterFunc(ctx, func() {
+		_ = c.conn.SetDeadline(time.Unix(1, 0))
+	})
+	defer stop()
+
+	id := c.nextID
+	c.nextID++
+	msg := newConstructed(tagSequence, newInt(tagInteger, id), op)
+	if _, err := c.conn.Write(msg.encode(nil)); err != nil {
+		return nil, c.connError(ctx, err)
+	}
+
+	var ops []*element
+	for {
+		resp, err := readElement(c.r)
+		if err != nil {
+			return nil, c.connError(ctx, err)
+		}
+		if resp.tag != tagSequence || resp.child(0) == nil || resp.child(1) == nil {
+			return nil, fmt.Errorf("%w: not an LDAP message", ErrMalformedMessage)
+		}
+
+		switch respID, respOp := resp.child(0).Int(), resp.child(1); {
+		case respID == unsolicitedNotificationID:
+			// The only unsolicited notification defined is the notice of
+			// disconnection, after which the server closes the connection.
+			return nil, fmt.Errorf("%w: server disconnected: %s", ErrConnectionFailed, respOp.child(2).String())
+		case respID != id:
+			continue
+		case respOp.tag == final:
+			if !respOp.constructed() || respOp.child(0) == nil {
+				return nil, fmt.Errorf("%w: malformed result", ErrMalformedMessage)
+			}
+			return append(ops, respOp), nil
+		case respOp.tag == opSearchEntry, respOp.tag == opSearchReference:
+			ops = append(ops, respOp)
+		default:
+			return nil, fmt.Errorf("%w: unexpected operation 0x%02x", ErrMalformedMessage, respOp.tag)
+		}
+	}
+}
+
+// connError wraps a failed read or write, reporting canceled contexts as
+// such.
+func (c *Conn) connError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return fmt.Errorf("%w: %w", ErrConnectionFailed, ctx.Err())
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return fmt.Errorf("%w: %w", ErrConnectionFailed, context.DeadlineExceeded)
+	}
+	if errors.Is(err, ErrMalformedMessage) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", ErrConnectionFailed, err)
+}
+
+// resultError returns the error reported by the LDAPResult of op, if any.
+func resultError(op *element) error {
+	code, diagnostic := op.child(0).Int(), op.child(2).String()
+	switch code {
+	case ResultSuccess:
+		return nil
+	case ResultInvalidCredentials:
+		return fmt.Errorf("%w: %s", ErrInvalidCredentials, diagnostic)
+	case ResultUnavailable:
+		return fmt.Errorf("%w: server unavailable: %s", ErrConnectionFailed, diagnostic)
+	default:
+		return fmt.Errorf("%w: result code %d: %s", ErrOperationFailed, code, diagnostic)
+	}
+}
+
+func parseEntry(op *element) (*Entry, error) {
+	attrs := op.child(1)
+	if op.child(0) == nil || attrs == nil {
+		return nil, fmt.Errorf("%w: malformed search entry", ErrMalformedMessage)
+	}
+
+	entry := &Entry{DN: op.child(0).String(), Attributes: make(map[string][]string, len(attrs.children))}
+	for _, attr := range attrs.children {
+		name, vals := attr.child(0), attr.child(1)
+		if name == nil || vals == nil {
+			return nil, fmt.Errorf("%w: malformed attribute of %s", ErrMalformedMessage, entry.DN)
+		}
+		values := make([]string, 0, len(vals.children))
+		for _, v := range vals.children {
+			values = append(values, v.String())
+		}
+		entry.Attributes[name.String()] = append(entry.Attributes[name.String()], values...)
+	}
+	return entry, nil
+}
@@ -0,0 +1,272 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ldap
+
+import (
+	"context"
+	"errors"
+	"net"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/u-bmc/u-bmc/pkg/ldap/ldaptest"
+)
+
+const testBase = "dc=example,dc=com"
+
+func newTestDirectory(t *testing.T) *ldaptest.Server {
+	t.Helper()
+	srv := ldaptest.NewServer(
+		&ldaptest.Entry{
+			DN:       "uid=alice,ou=people," + testBase,
+			Password: "alice-pw",
+			Attributes: map[string][]string{
+				"uid":      {"alice"},
+				"cn":       {"Alice Example"},
+				"memberOf": {"cn=admins,ou=groups," + testBase},
+			},
+		},
+		&ldaptest.Entry{
+			DN:         "uid=bob,ou=people," + testBase,
+			Password:   "bob-pw",
+			Attributes: map[string][]string{"uid": {"bob"}, "cn": {"Bob (Ops) *"}},
+		},
+		&ldaptest.Entry{
+			DN:         "cn=admins,ou=groups," + testBase,
+			Attributes: map[string][]string{"cn": {"admins"}, "member": {"uid=alice,ou=people," + testBase}},
+		},
+	)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func dialTest(t *testing.T, srv *ldaptest.Server) *Conn {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+	conn, err := Dial(ctx, srv.URL, nil)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func TestBind(t *testing.T) {
+	srv := newTestDirectory(t)
+
+	tests := []struct {
+		name     string
+		dn       string
+		password string
+		wantErr  error
+	}{
+		{name: "valid credentials", dn: "uid=alice,ou=people," + testBase, password: "alice-pw"},
+		{name: "DN compared case-insensitively", dn: "UID=bob, OU=people, " + testBase, password: "bob-pw"},
+		{name: "wrong password", dn: "uid=alice,ou=people," + testBase, password: "bob-pw", wantErr: ErrInvalidCredentials},
+		{name: "unknown entry", dn: "uid=carol,ou=people," + testBase, password: "carol-pw", wantErr: ErrInvalidCredentials},
+		{name: "entry without a password", dn: "cn=admins,ou=groups," + testBase, password: "x", wantErr: ErrInvalidCredentials},
+		// The server would accept the unauthenticated bind.
+		{name: "empty password", dn: "uid=alice,ou=people," + testBase, password: "", wantErr: ErrInvalidCredentials},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := dialTest(t, srv)
+			err := conn.Bind(t.Context(), tt.dn, tt.password)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Bind() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if got, want := srv.Binds(), []string{"uid=alice,ou=people," + testBase, "uid=bob,ou=people," + testBase}; !slices.Equal(got, want) {
+		t.Errorf("server saw binds %v, want %v", got, want)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	srv := newTestDirectory(t)
+	conn := dialTest(t, srv)
+
+	dns := func(entries []*Entry) []string {
+		var dns []string
+		for _, e := range entries {
+			dns = append(dns, e.DN)
+		}
+		return dns
+	}
+
+	tests := []struct {
+		name    string
+		req     SearchRequest
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "equality",
+			req:  SearchRequest{BaseDN: testBase, Scope: ScopeWholeSubtree, Filter: "(uid=alice)"},
+			want: []string{"uid=alice,ou=people," + testBase},
+		},
+		{
+			name: "presence and negation",
+			req:  SearchRequest{BaseDN: testBase, Scope: ScopeWholeSubtree, Filter: "(&(uid=*)(!(memberOf=*)))"},
+			want: []string{"uid=bob,ou=people," + testBase},
+		},
+		{
+			name: "substrings",
+			req:  SearchRequest{BaseDN: testBase, Scope: ScopeWholeSubtree, Filter: "(|(cn=al*ple)(cn=*dmin*))"},
+			want: []string{"uid=alice,ou=people," + testBase, "cn=admins,ou=groups," + testBase},
+		},
+		{
+			name: "escaped value",
+			req:  SearchRequest{BaseDN: testBase, Scope: ScopeWholeSubtree, Filter: "(cn=" + EscapeFilter("Bob (Ops) *") + ")"},
+			want: []string{"uid=bob,ou=people," + testBase},
+		},
+		{
+			name: "single level scope",
+			req:  SearchRequest{BaseDN: "ou=groups," + testBase, Scope: ScopeSingleLevel, Filter: "(objectClass=*)"},
+			want: []string{"cn=admins,ou=groups," + testBase},
+		},
+		{
+			name:    "size limit",
+			req:     SearchRequest{BaseDN: testBase, Scope: ScopeWholeSubtree, Filter: "(uid=*)", SizeLimit: 1},
+			want:    []string{"uid=alice,ou=people," + testBase},
+			wantErr: ErrSizeLimitExceeded,
+		},
+		{
+			name:    "invalid filter",
+			req:     SearchRequest{BaseDN: testBase, Scope: ScopeWholeSubtree, Filter: "(uid=alice"},
+			wantErr: ErrInvalidFilter,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := conn.Search(t.Context(), &tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Search() error = %v, want %v", err, tt.wantErr)
+			}
+			if got := dns(entries); !slices.Equal(got, tt.want) {
+				t.Errorf("Search() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("attributes", func(t *testing.T) {
+		entries, err := conn.Search(t.Context(), &SearchRequest{
+			BaseDN:     testBase,
+			Scope:      ScopeWholeSubtree,
+			Filter:     "(uid=alice)",
+			Attributes: []string{"memberof"},
+		})
+		if err != nil || len(entries) != 1 {
+			t.Fatalf("Search() = %v, %v", entries, err)
+		}
+		if got := entries[0].Values("MEMBEROF"); !slices.Equal(got, []string{"cn=admins,ou=groups," + testBase}) {
+			t.Errorf("memberOf = %v", got)
+		}
+		if got := entries[0].Value("cn"); got != "" {
+			t.Errorf("unrequested attribute cn = %q", got)
+		}
+	})
+}
+
+func TestEscapeFilter(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "alice", want: "alice"},
+		{value: "*", want: `\2a`},
+		{value: "alice)(uid=*", want: `alice\29\28uid=\2a`},
+		{value: `a\b`, want: `a\5cb`},
+		{value: "nul\x00", want: `nul\00`},
+		{value: "Jürgen", want: "Jürgen"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			got := EscapeFilter(tt.value)
+			if got != tt.want {
+				t.Errorf("EscapeFilter(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+
+			// The escaped value compiles to an equality match of the
+			// value itself rather than changing the filter.
+			e, err := compileFilter("(uid=" + got + ")")
+			if err != nil {
+				t.Fatalf("compileFilter() error = %v", err)
+			}
+			if e.tag != filterEqualityMatch || e.child(1).String() != tt.value {
+				t.Errorf("compileFilter() = tag 0x%02x value %q, want an equality match of %q", e.tag, e.child(1).String(), tt.value)
+			}
+		})
+	}
+
+	t.Run("injection", func(t *testing.T) {
+		conn := dialTest(t, newTestDirectory(t))
+		for _, value := range []string{"*", "alice)(uid=*", "*)(|(uid=*"} {
+			entries, err := conn.Search(t.Context(), &SearchRequest{
+				BaseDN: testBase,
+				Scope:  ScopeWholeSubtree,
+				Filter: "(uid=" + EscapeFilter(value) + ")",
+			})
+			if err != nil || len(entries) != 0 {
+				t.Errorf("search for %q = %d entries, %v, want none", value, len(entries), err)
+			}
+		}
+	})
+}
+
+func TestCompileFilterErrors(t *testing.T) {
+	for _, filter := range []string{
+		"",
+		"uid=alice",
+		"(uid=alice",
+		"(uid=alice))",
+		"(&)",
+		"(=alice)",
+		`(uid=\2)`,
+		`(uid=\zz)`,
+		"(:dn:=alice)",
+	} {
+		if _, err := compileFilter(filter); !errors.Is(err, ErrInvalidFilter) {
+			t.Errorf("compileFilter(%q) error = %v, want %v", filter, err, ErrInvalidFilter)
+		}
+	}
+}
+
+func TestDialErrors(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closed := "ldap://" + l.Addr().String()
+	_ = l.Close()
+
+	tests := []struct {
+		url     string
+		wantErr error
+	}{
+		{url: "http://example.com", wantErr: ErrInvalidURL},
+		{url: "ldap://", wantErr: ErrInvalidURL},
+		{url: closed, wantErr: ErrConnectionFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			if _, err := Dial(t.Context(), tt.url, nil); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Dial() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("StartTLS refused", func(t *testing.T) {
+		conn := dialTest(t, newTestDirectory(t))
+		if err := conn.StartTLS(t.Context(), nil); !errors.Is(err, ErrTLSFailed) {
+			t.Errorf("StartTLS() error = %v, want %v", err, ErrTLSFailed)
+		}
+	})
+}
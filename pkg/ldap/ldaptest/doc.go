@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package ldaptest provides an in-process LDAP directory for testing code
+// that authenticates users with package ldap, in the spirit of
+// net/http/httptest.
+//
+// # Overview
+//
+// A Server listens on a loopback port and answers the operations the ldap
+// client sends:
+//   - Simple binds, checked against the passwords of its entries
+//   - Searches with base, one level and subtree scope, size limits and
+//     attribute selection
+//   - Filters of any kind, including substrings and the Active Directory
+//     LDAP_MATCHING_RULE_IN_CHAIN rule on the member attribute
+//
+// Extended operations such as StartTLS are refused, and entries cannot be
+// modified over the protocol.
+//
+// # Basic Usage
+//
+//	srv := ldaptest.NewServer(
+//		&ldaptest.Entry{
+//			DN:         "uid=alice,ou=people,dc=example,dc=com",
+//			Password:   "secret",
+//			Attributes: map[string][]string{"uid": {"alice"}},
+//		},
+//		&ldaptest.Entry{
+//			DN:         "cn=admins,ou=groups,dc=example,dc=com",
+//			Attributes: map[string][]string{"member": {"uid=alice,ou=people,dc=example,dc=com"}},
+//		},
+//	)
+//	defer srv.Close()
+//
+//	conn, err := ldap.Dial(ctx, srv.URL, nil)
+//
+// Binds() lists the DNs of the successful binds, to check which accounts
+// the code under test bound as.
+package ldaptest
@@ -0,0 +1,582 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ldaptest
+
+import (
+	"bufio"
+	"encoding/asn1"
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Protocol operations (RFC 4511 section 4.2 to 4.12), all of the
+// application class.
+const (
+	opBindRequest     = 0
+	opBindResponse    = 1
+	opUnbindRequest   = 2
+	opSearchRequest   = 3
+	opSearchEntry     = 4
+	opSearchDone      = 5
+	opExtendedRequest = 23
+	opExtendedResp    = 24
+)
+
+// Filter choices (RFC 4511 section 4.5.1.7), all of the context class.
+const (
+	filterAnd             = 0
+	filterOr              = 1
+	filterNot             = 2
+	filterEqualityMatch   = 3
+	filterSubstrings      = 4
+	filterGreaterOrEqual  = 5
+	filterLessOrEqual     = 6
+	filterPresent         = 7
+	filterApproxMatch     = 8
+	filterExtensibleMatch = 9
+
+	substringInitial = 0
+	substringFinal   = 2
+
+	matchingRule = 1
+	matchingType = 2
+	matchValue   = 3
+)
+
+// Result codes (RFC 4511 appendix A).
+const (
+	resultSuccess            = 0
+	resultProtocolError      = 2
+	resultSizeLimitExceeded  = 4
+	resultInvalidCredentials = 49
+)
+
+const (
+	// matchingRuleInChain is the Active Directory matching rule that
+	// matches the groups a DN is a member of, directly or through nested
+	// groups.
+	matchingRuleInChain = "1.2.840.113556.1.4.1941"
+	// maxMessageSize bounds the size of a request.
+	maxMessageSize = 1 << 20
+)
+
+// Entry is an entry of the directory.
+type Entry struct {
+	DN string
+	// Password is the password a simple bind as the entry must present.
+	// Entries without a password cannot be bound as.
+	Password string
+	// Attributes holds the attributes of the entry, whose names are
+	// compared case-insensitively.
+	Attributes map[string][]string
+}
+
+// values returns the values of an attribute.
+func (e *Entry) values(name string) []string {
+	for attr, values := range e.Attributes {
+		if strings.EqualFold(attr, name) {
+			return values
+		}
+	}
+	return nil
+}
+
+// Server is an LDAP directory listening on a loopback port.
+type Server struct {
+	// URL is the ldap:// URL of the server.
+	URL string
+
+	listener net.Listener
+	entries  []*Entry
+	wg       sync.WaitGroup
+
+	mu     sync.Mutex
+	conns  map[net.Conn]struct{}
+	binds  []string
+	closed bool
+}
+
+// NewServer starts a server holding entries. The caller should call Close
+// when finished, to shut it down.
+func NewServer(entries ...*Entry) *Server {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(fmt.Sprintf("ldaptest: failed to listen on a port: %v", err))
+	}
+
+	s := &Server{
+		URL:      "ldap://" + l.Addr().String(),
+		listener: l,
+		entries:  entries,
+		conns:    make(map[net.Conn]struct{}),
+	}
+	s.wg.Add(1)
+	go s.serve()
+	return s
+}
+
+// Binds returns the DNs of the successful non-anonymous binds, in order.
+func (s *Server) Binds() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.binds)
+}
+
+// Close shuts down the server, closing open connections, and waits for
+// their handlers to return.
+func (s *Server) Close() {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	s.closed = true
+	_ = s.listener.Close()
+	for conn := range s.conns {
+		_ = conn.Close()
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			_ = conn.Close()
+			return
+		}
+		s.conns[conn] = struct{}{}
+		s.wg.Add(1)
+		s.mu.Unlock()
+
+		go s.handle(conn)
+	}
+}
+
+// handle answers the requests of a connection until the client unbinds or
+// sends something the server does not understand.
+func (s *Server) handle(conn net.Conn) {
+	defer s.wg.Done()
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+		_ = conn.Close()
+	}()
+
+	r := bufio.NewReader(conn)
+	for {
+		msg, err := readMessage(r)
+		if err != nil {
+			return
+		}
+		fields, err := children(msg)
+		if err != nil || len(fields) < 2 {
+			return
+		}
+		id, op := integer(fields[0]), fields[1]
+		if op.Class != asn1.ClassApplication {
+			return
+		}
+
+		var resp [][]byte
+		switch op.Tag {
+		case opBindRequest:
+			resp, err = s.bind(op)
+		case opSearchRequest:
+			resp, err = s.search(op)
+		case opExtendedRequest:
+			resp = [][]byte{result(opExtendedResp, resultProtocolError, "extended operations are not supported")}
+		case opUnbindRequest:
+			return
+		default:
+			return
+		}
+		if err != nil {
+			return
+		}
+
+		for _, out := range resp {
+			if _, err := conn.Write(encode(asn1.ClassUniversal, true, asn1.TagSequence, encodeInt(asn1.TagInteger, id), out)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// bind answers a simple bind. Binds without a password are anonymous
+// (RFC 4513 section 5.1.2) and always succeed.
+func (s *Server) bind(op asn1.RawValue) ([][]byte, error) {
+	fields, err := children(op)
+	if err != nil || len(fields) < 3 {
+		return nil, fmt.Errorf("malformed bind request")
+	}
+	dn, auth := string(fields[1].Bytes), fields[2]
+	if auth.Class != asn1.ClassContextSpecific || auth.Tag != 0 {
+		return [][]byte{result(opBindResponse, resultInvalidCredentials, "only simple binds are supported")}, nil
+	}
+	password := string(auth.Bytes)
+	if password == "" {
+		return [][]byte{result(opBindResponse, resultSuccess, "")}, nil
+	}
+
+	entry := s.entry(dn)
+	if entry == nil || entry.Password == "" || entry.Password != password {
+		return [][]byte{result(opBindResponse, resultInvalidCredentials, "invalid credentials")}, nil
+	}
+
+	s.mu.Lock()
+	s.binds = append(s.binds, entry.DN)
+	s.mu.Unlock()
+	return [][]byte{result(opBindResponse, resultSuccess, "")}, nil
+}
+
+// search answers a search with the matching entries and the result.
+func (s *Server) search(op asn1.RawValue) ([][]byte, error) {
+	fields, err := children(op)
+	if err != nil || len(fields) < 8 {
+		return nil, fmt.Errorf("malformed search request")
+	}
+	base, scope, sizeLimit, filter := string(fields[0].Bytes), integer(fields[1]), integer(fields[3]), fields[6]
+	attrs, err := children(fields[7])
+	if err != nil {
+		return nil, err
+	}
+	selected := make([]string, 0, len(attrs))
+	for _, attr := range attrs {
+		selected = append(selected, string(attr.Bytes))
+	}
+
+	var resp [][]byte
+	for _, entry := range s.entries {
+		if !inScope(entry.DN, base, scope) {
+			continue
+		}
+		match, err := s.match(filter, entry)
+		if err != nil {
+			return nil, err
+		}
+		if !match {
+			continue
+		}
+		if sizeLimit > 0 && int64(len(resp)) >= sizeLimit {
+			return append(resp, result(opSearchDone, resultSizeLimitExceeded, "size limit exceeded")), nil
+		}
+		resp = append(resp, searchEntry(entry, selected))
+	}
+	return append(resp, result(opSearchDone, resultSuccess, "")), nil
+}
+
+// match evaluates a filter against an entry.
+func (s *Server) match(filter asn1.RawValue, entry *Entry) (bool, error) {
+	if filter.Class != asn1.ClassContextSpecific {
+		return false, fmt.Errorf("malformed filter")
+	}
+	if filter.Tag == filterPresent {
+		name := string(filter.Bytes)
+		return strings.EqualFold(name, "objectClass") || entry.values(name) != nil, nil
+	}
+
+	fields, err := children(filter)
+	if err != nil {
+		return false, err
+	}
+	switch filter.Tag {
+	case filterAnd, filterOr:
+		for _, f := range fields {
+			match, err := s.match(f, entry)
+			if err != nil {
+				return false, err
+			}
+			if match != (filter.Tag == filterAnd) {
+				return match, nil
+			}
+		}
+		return filter.Tag == filterAnd, nil
+	case filterNot:
+		if len(fields) != 1 {
+			return false, fmt.Errorf("malformed not filter")
+		}
+		match, err := s.match(fields[0], entry)
+		return !match, err
+	case filterEqualityMatch, filterApproxMatch, filterGreaterOrEqual, filterLessOrEqual:
+		if len(fields) != 2 {
+			return false, fmt.Errorf("malformed attribute value assertion")
+		}
+		want := strings.ToLower(string(fields[1].Bytes))
+		return slices.ContainsFunc(entry.values(string(fields[0].Bytes)), func(v string) bool {
+			switch c := strings.Compare(strings.ToLower(v), want); filter.Tag {
+			case filterGreaterOrEqual:
+				return c >= 0
+			case filterLessOrEqual:
+				return c <= 0
+			default:
+				return c == 0
+			}
+		}), nil
+	case filterSubstrings:
+		if len(fields) != 2 {
+			return false, fmt.Errorf("malformed substring filter")
+		}
+		subs, err := children(fields[1])
+		if err != nil {
+			return false, err
+		}
+		return slices.ContainsFunc(entry.values(string(fields[0].Bytes)), func(v string) bool {
+			return matchSubstrings(strings.ToLower(v), subs)
+		}), nil
+	case filterExtensibleMatch:
+		var rule, attr, value string
+		for _, f := range fields {
+			switch f.Tag {
+			case matchingRule:
+				rule = string(f.Bytes)
+			case matchingType:
+				attr = string(f.Bytes)
+			case matchValue:
+				value = string(f.Bytes)
+			}
+		}
+		switch {
+		case rule == matchingRuleInChain && strings.EqualFold(attr, "member"):
+			return s.inChain(entry, value, nil), nil
+		case rule == "" && attr != "":
+			return slices.ContainsFunc(entry.values(attr), func(v string) bool { return strings.EqualFold(v, value) }), nil
+		default:
+			return false, nil
+		}
+	default:
+		return false, fmt.Errorf("unknown filter 0x%02x", filter.Tag)
+	}
+}
+
+// inChain reports whether dn is a member of group, directly or through the
+// groups nested in it.
+func (s *Server) inChain(group *Entry, dn string, seen []*Entry) bool {
+	if slices.Contains(seen, group) {
+		return false
+	}
+	seen = append(seen, group)
+	for _, member := range group.values("member") {
+		if sameDN(member, dn) {
+			return true
+		}
+		if nested := s.entry(member); nested != nil && s.inChain(nested, dn, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// entry returns the entry named dn or nil.
+func (s *Server) entry(dn string) *Entry {
+	for _, entry := range s.entries {
+		if sameDN(entry.DN, dn) {
+			return entry
+		}
+	}
+	return nil
+}
+
+// matchSubstrings matches a lowercase value against the components of a
+// substring filter.
+func matchSubstrings(value string, subs []asn1.RawValue) bool {
+	for i, sub := range subs {
+		part := strings.ToLower(string(sub.Bytes))
+		switch {
+		case sub.Tag == substringInitial && i == 0:
+			if !strings.HasPrefix(value, part) {
+				return false
+			}
+			value = value[len(part):]
+		case sub.Tag == substringFinal && i == len(subs)-1:
+			return strings.HasSuffix(value, part)
+		default:
+			j := strings.Index(value, part)
+			if j < 0 {
+				return false
+			}
+			value = value[j+len(part):]
+		}
+	}
+	return true
+}
+
+// inScope reports whether dn lies within the scope of a search starting at
+// base.
+func inScope(dn, base string, scope int64) bool {
+	dn, base = normalizeDN(dn), normalizeDN(base)
+	switch scope {
+	case 0:
+		return dn == base
+	case 1:
+		_, parent, ok := strings.Cut(dn, ",")
+		return ok && parent == base
+	default:
+		return base == "" || dn == base || strings.HasSuffix(dn, ","+base)
+	}
+}
+
+// sameDN compares two DNs case-insensitively, ignoring spaces around the
+// separators of their components.
+func sameDN(a, b string) bool {
+	return normalizeDN(a) == normalizeDN(b)
+}
+
+func normalizeDN(dn string) string {
+	parts := strings.Split(strings.ToLower(dn), ",")
+	for i, part := range parts {
+		parts[i] = strings.TrimSpace(part)
+	}
+	return strings.Join(parts, ",")
+}
+
+// searchEntry encodes a SearchResultEntry with the selected attributes of
+// entry. No attributes are selected by "1.1", all by an empty list or "*".
+func searchEntry(entry *Entry, selected []string) []byte {
+	all := len(selected) == 0 || slices.Contains(selected, "*")
+	var attrs [][]byte
+	for name, values := range entry.Attributes {
+		if !all && !slices.ContainsFunc(selected, func(s string) bool { return strings.EqualFold(s, name) }) {
+			continue
+		}
+		vals := make([][]byte, 0, len(values))
+		for _, v := range values {
+			vals = append(vals, encodeString(v))
+		}
+		attrs = append(attrs, encode(asn1.ClassUniversal, true, asn1.TagSequence,
+			encodeString(name),
+			encode(asn1.ClassUniversal, true, asn1.TagSet, vals...),
+		))
+	}
+	return encode(asn1.ClassApplication, true, opSearchEntry,
+		encodeString(entry.DN),
+		encode(asn1.ClassUniversal, true, asn1.TagSequence, attrs...),
+	)
+}
+
+// result encodes an LDAPResult with the tag of a response operation.
+func result(op int, code int64, diagnostic string) []byte {
+	return encode(asn1.ClassApplication, true, op,
+		encodeInt(asn1.TagEnum, code),
+		encodeString(""),
+		encodeString(diagnostic),
+	)
+}
+
+func encodeString(s string) []byte {
+	return encode(asn1.ClassUniversal, false, asn1.TagOctetString, []byte(s))
+}
+
+func encodeInt(tag int, n int64) []byte {
+	var value []byte
+	for {
+		value = append([]byte{byte(n)}, value...)
+		if (n < 0x80 && n >= -0x80) || len(value) == 8 {
+			break
+		}
+		n >>= 8
+	}
+	return encode(asn1.ClassUniversal, false, tag, value)
+}
+
+// encode returns the BER encoding of an element with the given contents.
+func encode(class int, compound bool, tag int, contents ...[]byte) []byte {
+	id := byte(class<<6 | tag)
+	if compound {
+		id |= 0x20
+	}
+	content := slices.Concat(contents...)
+
+	b := []byte{id}
+	if n := len(content); n < 0x80 {
+		b = append(b, byte(n))
+	} else {
+		var length []byte
+		for ; n > 0; n >>= 8 {
+			length = append([]byte{byte(n)}, length...)
+		}
+		b = append(b, 0x80|byte(len(length)))
+		b = append(b, length...)
+	}
+	return append(b, content...)
+}
+
+// readMessage reads one LDAPMessage from r.
+func readMessage(r *bufio.Reader) (asn1.RawValue, error) {
+	header := make([]byte, 2, 6)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return asn1.RawValue{}, err
+	}
+	length := int(header[1])
+	if header[1]&0x80 != 0 {
+		octets := int(header[1] &^ 0x80)
+		if octets == 0 || octets > 4 {
+			return asn1.RawValue{}, fmt.Errorf("unsupported length encoding")
+		}
+		header = header[:2+octets]
+		if _, err := io.ReadFull(r, header[2:]); err != nil {
+			return asn1.RawValue{}, err
+		}
+		length = 0
+		for _, b := range header[2:] {
+			length = length<<8 | int(b)
+		}
+	}
+	if length > maxMessageSize {
+		return asn1.RawValue{}, fmt.Errorf("message of %d bytes exceeds the limit", length)
+	}
+
+	msg := make([]byte, len(header)+length)
+	copy(msg, header)
+	if _, err := io.ReadFull(r, msg[len(header):]); err != nil {
+		return asn1.RawValue{}, err
+	}
+	var v asn1.RawValue
+	if _, err := asn1.Unmarshal(msg, &v); err != nil {
+		return asn1.RawValue{}, err
+	}
+	return v, nil
+}
+
+// children decodes the elements nested in a constructed element.
+func children(v asn1.RawValue) ([]asn1.RawValue, error) {
+	if !v.IsCompound {
+		return nil, fmt.Errorf("element 0x%02x is not constructed", v.Tag)
+	}
+	var elements []asn1.RawValue
+	for rest := v.Bytes; len(rest) > 0; {
+		var e asn1.RawValue
+		var err error
+		if rest, err = asn1.Unmarshal(rest, &e); err != nil {
+			return nil, err
+		}
+		elements = append(elements, e)
+	}
+	return elements, nil
+}
+
+// integer decodes the value of an INTEGER or ENUMERATED element.
+func integer(v asn1.RawValue) int64 {
+	if len(v.Bytes) == 0 {
+		return 0
+	}
+	n := int64(int8(v.Bytes[0]))
+	for _, b := range v.Bytes[1:] {
+		n = n<<8 | int64(b)
+	}
+	return n
+}
@@ -0,0 +1,176 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// DefaultScopes are the scopes requested if a client names none.
+var DefaultScopes = []string{"openid", "profile", "email"}
+
+// Token is the response of the token endpoint.
+type Token struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	ExpiresIn    int64  `json:"expires_in,omitempty"`
+	IDToken      string `json:"id_token"`
+}
+
+// Client is a relying party registered with a provider, using the
+// authorization code flow with PKCE (RFC 7636).
+type Client struct {
+	Provider *Provider
+	ClientID string
+	// ClientSecret authenticates confidential clients at the token
+	// endpoint. Public clients leave it empty and rely on PKCE alone.
+	ClientSecret string
+	// RedirectURL is the callback URL registered with the provider.
+	RedirectURL string
+	// Scopes are the requested scopes, DefaultScopes if empty. The openid
+	// scope is always requested.
+	Scopes []string
+}
+
+// NewCodeVerifier returns a random PKCE code verifier.
+func NewCodeVerifier() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// codeChallenge returns the S256 code challenge of verifier.
+func codeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// AuthCodeURL returns the URL of the authorization endpoint the user agent
+// is sent to. state protects the callback against forgery, nonce binds the
+// ID token to the login and the S256 challenge of verifier binds the code to
+// the client.
+func (c *Client) AuthCodeURL(ctx context.Context, state, nonce, verifier string) (string, error) {
+	metadata, err := c.Provider.Metadata(ctx)
+	if err != nil {
+		return "", err
+	}
+	u, err := url.Parse(metadata.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("%w: authorization endpoint: %w", ErrDiscoveryFailed, err)
+	}
+
+	scopes := c.Scopes
+	if len(scopes) == 0 {
+		scopes = DefaultScopes
+	}
+	if !slices.Contains(scopes, "openid") {
+		scopes = append([]string{"openid"}, scopes...)
+	}
+
+	q := u.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", c.ClientID)
+	q.Set("redirect_uri", c.RedirectURL)
+	q.Set("scope", strings.Join(scopes, " "))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+	q.Set("code_challenge", codeChallenge(verifier))
+	q.Set("code_challenge_method", "S256")
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// Exchange redeems an authorization code at the token endpoint. Confidential
+// clients authenticate with client_secret_basic unless the provider only
+// supports client_secret_post.
+func (c *Client) Exchange(ctx context.Context, code, verifier string) (*Token, error) {
+	metadata, err := c.Provider.Metadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {c.RedirectURL},
+		"code_verifier": {verifier},
+	}
+	basic := c.ClientSecret != ""
+	if methods := metadata.TokenEndpointAuthMethodsSupported; basic && len(methods) > 0 &&
+		!slices.Contains(methods, "client_secret_basic") && slices.Contains(methods, "client_secret_post") {
+		basic = false
+		form.Set("client_secret", c.ClientSecret)
+	}
+	if !basic {
+		form.Set("client_id", c.ClientID)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, metadata.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrExchangeFailed, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if basic {
+		req.SetBasicAuth(url.QueryEscape(c.ClientID), url.QueryEscape(c.ClientSecret))
+	}
+
+	resp, err := c.Provider.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrExchangeFailed, err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	var body struct {
+		Token
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&body); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrExchangeFailed, resp.Status, err)
+	}
+	switch {
+	case body.Error != "":
+		return nil, fmt.Errorf("%w: %s: %s", ErrExchangeFailed, body.Error, body.ErrorDescription)
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("%w: token endpoint answered %s", ErrExchangeFailed, resp.Status)
+	case body.IDToken == "":
+		return nil, fmt.Errorf("%w: response lacks an ID token", ErrExchangeFailed)
+	case !strings.EqualFold(body.TokenType, "Bearer"):
+		return nil, fmt.Errorf("%w: unsupported token type %q", ErrExchangeFailed, body.TokenType)
+	}
+	return &body.Token, nil
+}
+
+// VerifyIDToken verifies an ID token issued to the client and checks that
+// it carries nonce (OpenID Connect Core 1.0 section 3.1.3.7).
+func (c *Client) VerifyIDToken(ctx context.Context, raw, nonce string) (Claims, error) {
+	claims, err := c.Provider.Verify(ctx, raw, c.ClientID)
+	if err != nil {
+		return nil, err
+	}
+	if azp := claims.String("azp"); azp != "" && azp != c.ClientID {
+		return nil, fmt.Errorf("%w: authorized party %q is not the client", ErrInvalidClaims, azp)
+	}
+	if subtle.ConstantTimeCompare([]byte(claims.String("nonce")), []byte(nonce)) != 1 {
+		return nil, fmt.Errorf("%w: nonce does not match the login", ErrInvalidClaims)
+	}
+	if claims.Subject() == "" {
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidClaims)
+	}
+	return claims, nil
+}
@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package oidc provides a minimal OpenID Connect relying party, without
+// depending on a full-featured OAuth 2.0 or JOSE library.
+//
+// # Overview
+//
+// The package supports:
+//   - Discovery of the provider endpoints from the discovery document below
+//     the issuer URL, whose issuer must match the configured one
+//   - The authorization code flow with PKCE, using the S256 code challenge
+//     method, and a nonce binding the ID token to the login
+//   - Client authentication with client_secret_basic or client_secret_post,
+//     or none for public clients
+//   - Verification of JWTs signed with RSA (RS256, RS384, RS512, PS256,
+//     PS384, PS512), ECDSA (ES256, ES384, ES512) or Ed25519 (EdDSA), using
+//     the cached JSON Web Key Set of the provider
+//
+// Symmetric signatures, unsigned tokens, encrypted tokens and tokens with
+// critical header parameters are rejected. The key set is cached for the
+// configured TTL and refreshed early when a token names a key it does not
+// hold, at most every 30 seconds, so that rotated keys are picked up quickly.
+//
+// # Basic Usage
+//
+// Sending the user agent to the provider and completing the login in the
+// callback:
+//
+//	provider := oidc.NewProvider("https://idp.example.com/realms/ops", nil, 0)
+//	client := &oidc.Client{
+//		Provider:     provider,
+//		ClientID:     "bmc",
+//		ClientSecret: secret,
+//		RedirectURL:  "https://bmc.example.com/api/auth/oidc/callback",
+//	}
+//
+//	verifier, err := oidc.NewCodeVerifier()
+//	if err != nil {
+//		return err
+//	}
+//	authURL, err := client.AuthCodeURL(ctx, state, nonce, verifier)
+//	if err != nil {
+//		return err
+//	}
+//	http.Redirect(w, r, authURL, http.StatusFound)
+//
+//	// In the callback, after checking the state:
+//	token, err := client.Exchange(ctx, r.URL.Query().Get("code"), verifier)
+//	if err != nil {
+//		return err
+//	}
+//	claims, err := client.VerifyIDToken(ctx, token.IDToken, nonce)
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(claims.String("preferred_username"), claims.Strings("groups"))
+//
+// Resource servers verify bearer tokens issued for their audience directly
+// with the provider:
+//
+//	claims, err := provider.Verify(ctx, bearerToken, "bmc-api")
+//
+// # Error Handling
+//
+// Errors wrap one of the package's sentinel errors. ErrDiscoveryFailed and
+// ErrKeySetFailed report an unreachable or misconfigured provider, while
+// ErrMalformedToken, ErrUnsupportedAlgorithm, ErrUnknownKey,
+// ErrInvalidSignature, ErrInvalidClaims and ErrTokenExpired report tokens
+// that must not be accepted.
+//
+// # Testing
+//
+// Package oidctest provides an in-process provider to run the client and
+// code built on it against.
+package oidc
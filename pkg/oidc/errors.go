@@ -0,0 +1,26 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package oidc
+
+import "errors"
+
+var (
+	// ErrDiscoveryFailed indicates that the discovery document of the provider could not be fetched or is unusable.
+	ErrDiscoveryFailed = errors.New("OIDC discovery failed")
+	// ErrKeySetFailed indicates that the JSON Web Key Set of the provider could not be fetched or parsed.
+	ErrKeySetFailed = errors.New("failed to fetch OIDC key set")
+	// ErrMalformedToken indicates that a token is not a valid compact JWS with a JSON claims set.
+	ErrMalformedToken = errors.New("malformed JWT")
+	// ErrUnsupportedAlgorithm indicates that a token is signed with an algorithm that is not supported or not allowed.
+	ErrUnsupportedAlgorithm = errors.New("unsupported JWT algorithm")
+	// ErrUnknownKey indicates that the key set of the provider holds no key matching a token.
+	ErrUnknownKey = errors.New("unknown JWT signing key")
+	// ErrInvalidSignature indicates that the signature of a token does not verify.
+	ErrInvalidSignature = errors.New("invalid JWT signature")
+	// ErrInvalidClaims indicates that the issuer, audience, nonce or another claim of a token is wrong.
+	ErrInvalidClaims = errors.New("invalid JWT claims")
+	// ErrTokenExpired indicates that a token is expired or not valid yet.
+	ErrTokenExpired = errors.New("JWT expired or not yet valid")
+	// ErrExchangeFailed indicates that the token endpoint refused or failed to exchange an authorization code.
+	ErrExchangeFailed = errors.New("OIDC code exchange failed")
+)
@@ -0,0 +1,224 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package oidc
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// maxTokenLength bounds the size of a token accepted for verification.
+const maxTokenLength = 64 << 10
+
+// Claims are the claims of a verified token.
+type Claims map[string]any
+
+// String returns the string claim name, which may be a dotted path into
+// nested objects such as "realm_access.roles". It is empty if the claim is
+// missing or no string.
+func (c Claims) String(name string) string {
+	s, _ := c.lookup(name).(string)
+	return s
+}
+
+// Strings returns the claim name as list of strings, accepting a single
+// string as well as an array. Other values in an array are skipped.
+func (c Claims) Strings(name string) []string {
+	switch v := c.lookup(name).(type) {
+	case string:
+		return []string{v}
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, e := range v {
+			if s, ok := e.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+// Time returns the NumericDate claim name and whether it is present.
+func (c Claims) Time(name string) (time.Time, bool) {
+	n, ok := c.lookup(name).(json.Number)
+	if !ok {
+		return time.Time{}, false
+	}
+	f, err := n.Float64()
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return time.Time{}, false
+	}
+	sec, frac := math.Modf(f)
+	return time.Unix(int64(sec), int64(frac*1e9)), true
+}
+
+// Subject returns the sub claim, which identifies the user at the issuer.
+func (c Claims) Subject() string {
+	return c.String("sub")
+}
+
+func (c Claims) lookup(name string) any {
+	var v any = map[string]any(c)
+	for _, key := range strings.Split(name, ".") {
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		if v, ok = obj[key]; !ok {
+			return nil
+		}
+	}
+	return v
+}
+
+// jwt is a parsed but not yet verified compact JWS (RFC 7515) carrying a
+// claims set.
+type jwt struct {
+	alg       string
+	kid       string
+	claims    Claims
+	signed    []byte
+	signature []byte
+}
+
+// parseJWT splits and decodes a compact JWS.
+func parseJWT(raw string) (*jwt, error) {
+	if len(raw) > maxTokenLength {
+		return nil, fmt.Errorf("%w: token exceeds %d bytes", ErrMalformedToken, maxTokenLength)
+	}
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: %d parts instead of 3", ErrMalformedToken, len(parts))
+	}
+
+	var header struct {
+		Alg  string   `json:"alg"`
+		Kid  string   `json:"kid"`
+		Crit []string `json:"crit"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: header: %w", ErrMalformedToken, err)
+	}
+	if len(header.Crit) > 0 {
+		return nil, fmt.Errorf("%w: unsupported critical header parameters %q", ErrMalformedToken, header.Crit)
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: claims: %w", ErrMalformedToken, err)
+	}
+	if claims == nil {
+		return nil, fmt.Errorf("%w: claims are no object", ErrMalformedToken)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: signature: %w", ErrMalformedToken, err)
+	}
+
+	return &jwt{
+		alg:       header.Alg,
+		kid:       header.Kid,
+		claims:    claims,
+		signed:    []byte(raw[:len(parts[0])+1+len(parts[1])]),
+		signature: signature,
+	}, nil
+}
+
+// decodeSegment decodes a base64url encoded JSON object, keeping numbers as
+// json.Number.
+func decodeSegment(segment string, v any) error {
+	b, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+// algorithm describes a JWS signature algorithm (RFC 7518 section 3).
+type algorithm struct {
+	hash    crypto.Hash
+	newHash func() hash.Hash
+	// kind is the key type the algorithm uses: RSA, RSA-PSS, EC or OKP.
+	kind string
+	// curveBits is the size of the curve of ECDSA algorithms.
+	curveBits int
+}
+
+// algorithms are the supported signature algorithms. Symmetric algorithms
+// and "none" are deliberately missing.
+var algorithms = map[string]algorithm{
+	"RS256": {hash: crypto.SHA256, newHash: sha256.New, kind: "RSA"},
+	"RS384": {hash: crypto.SHA384, newHash: sha512.New384, kind: "RSA"},
+	"RS512": {hash: crypto.SHA512, newHash: sha512.New, kind: "RSA"},
+	"PS256": {hash: crypto.SHA256, newHash: sha256.New, kind: "RSA-PSS"},
+	"PS384": {hash: crypto.SHA384, newHash: sha512.New384, kind: "RSA-PSS"},
+	"PS512": {hash: crypto.SHA512, newHash: sha512.New, kind: "RSA-PSS"},
+	"ES256": {hash: crypto.SHA256, newHash: sha256.New, kind: "EC", curveBits: 256},
+	"ES384": {hash: crypto.SHA384, newHash: sha512.New384, kind: "EC", curveBits: 384},
+	"ES512": {hash: crypto.SHA512, newHash: sha512.New, kind: "EC", curveBits: 521},
+	"EdDSA": {kind: "OKP"},
+}
+
+// fits reports whether key can verify signatures of the algorithm.
+func (a algorithm) fits(key verificationKey, name string) bool {
+	if key.alg != "" && key.alg != name {
+		return false
+	}
+	switch k := key.key.(type) {
+	case *rsa.PublicKey:
+		return a.kind == "RSA" || a.kind == "RSA-PSS"
+	case *ecdsa.PublicKey:
+		return a.kind == "EC" && k.Curve.Params().BitSize == a.curveBits
+	case ed25519.PublicKey:
+		return a.kind == "OKP"
+	default:
+		return false
+	}
+}
+
+// verify checks the signature of the token with key.
+func (a algorithm) verify(key crypto.PublicKey, signed, signature []byte) bool {
+	if a.kind == "OKP" {
+		k, ok := key.(ed25519.PublicKey)
+		return ok && ed25519.Verify(k, signed, signature)
+	}
+
+	h := a.newHash()
+	h.Write(signed)
+	digest := h.Sum(nil)
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		if a.kind == "RSA-PSS" {
+			return rsa.VerifyPSS(k, a.hash, digest, signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash}) == nil
+		}
+		return rsa.VerifyPKCS1v15(k, a.hash, digest, signature) == nil
+	case *ecdsa.PublicKey:
+		// JWS carries the fixed-size concatenation of r and s.
+		size := (a.curveBits + 7) / 8
+		if len(signature) != 2*size {
+			return false
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		return ecdsa.Verify(k, digest, r, s)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,439 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package oidc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/u-bmc/u-bmc/pkg/oidc/oidctest"
+)
+
+const (
+	testClientID     = "bmc"
+	testClientSecret = "bmc-secret"
+	testRedirectURL  = "https://bmc.example.com/api/auth/oidc/callback"
+)
+
+func newTestServer(t *testing.T, clientSecret string) *oidctest.Server {
+	t.Helper()
+	srv := oidctest.NewServer(testClientID, clientSecret)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newTestClient(srv *oidctest.Server, clientSecret string) *Client {
+	return &Client{
+		Provider:     NewProvider(srv.URL, srv.Client(), 0),
+		ClientID:     testClientID,
+		ClientSecret: clientSecret,
+		RedirectURL:  testRedirectURL,
+	}
+}
+
+// testClaims returns valid claims of a token issued by srv to the client.
+func testClaims(srv *oidctest.Server) map[string]any {
+	now := time.Now()
+	return map[string]any{
+		"iss": srv.URL,
+		"sub": "alice",
+		"aud": testClientID,
+		"iat": now.Unix(),
+		"exp": now.Add(5 * time.Minute).Unix(),
+	}
+}
+
+// with returns claims with the given fields replaced, or removed if nil.
+func with(claims map[string]any, fields map[string]any) map[string]any {
+	out := make(map[string]any, len(claims))
+	for name, value := range claims {
+		out[name] = value
+	}
+	for name, value := range fields {
+		if value == nil {
+			delete(out, name)
+		} else {
+			out[name] = value
+		}
+	}
+	return out
+}
+
+// authorize sends a user agent to the authorization URL of the client and
+// returns the code and state the provider sent it back with.
+func authorize(t *testing.T, srv *oidctest.Server, client *Client, state, nonce, verifier string) (string, string) {
+	t.Helper()
+
+	authURL, err := client.AuthCodeURL(t.Context(), state, nonce, verifier)
+	if err != nil {
+		t.Fatalf("AuthCodeURL() error = %v", err)
+	}
+	agent := srv.Client()
+	agent.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+	resp, err := agent.Get(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("authorization endpoint answered %s", resp.Status)
+	}
+
+	callback, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := callback.Scheme + "://" + callback.Host + callback.Path; got != testRedirectURL {
+		t.Fatalf("redirected to %s, want %s", got, testRedirectURL)
+	}
+	return callback.Query().Get("code"), callback.Query().Get("state")
+}
+
+// expireKeySet lets the cached key set of p expire and makes it due for a
+// refresh.
+func expireKeySet(p *Provider) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.keysFetched = time.Now().Add(-keySetRefreshInterval)
+	p.keysExpire = time.Now()
+}
+
+func TestDiscovery(t *testing.T) {
+	tests := []struct {
+		name    string
+		field   string
+		value   any
+		wantErr error
+	}{
+		{name: "valid"},
+		{name: "other issuer", field: "issuer", value: "https://idp.example.com", wantErr: ErrDiscoveryFailed},
+		{name: "no key set", field: "jwks_uri", wantErr: ErrDiscoveryFailed},
+		{name: "no token endpoint", field: "token_endpoint", wantErr: ErrDiscoveryFailed},
+		{name: "no S256 challenge", field: "code_challenge_methods_supported", value: []string{"plain"}, wantErr: ErrDiscoveryFailed},
+		{name: "challenge methods not listed", field: "code_challenge_methods_supported"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newTestServer(t, testClientSecret)
+			if tt.field != "" {
+				srv.SetMetadata(tt.field, tt.value)
+			}
+			provider := NewProvider(srv.URL, srv.Client(), 0)
+
+			metadata, err := provider.Metadata(t.Context())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Metadata() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && metadata.TokenEndpoint != srv.URL+"/token" {
+				t.Errorf("token endpoint = %q", metadata.TokenEndpoint)
+			}
+		})
+	}
+
+	t.Run("fetched once", func(t *testing.T) {
+		srv := newTestServer(t, testClientSecret)
+		provider := NewProvider(srv.URL, srv.Client(), 0)
+		if _, err := provider.Metadata(t.Context()); err != nil {
+			t.Fatalf("Metadata() error = %v", err)
+		}
+		srv.Close()
+		if _, err := provider.Metadata(t.Context()); err != nil {
+			t.Errorf("Metadata() error = %v after the provider went down", err)
+		}
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		srv := newTestServer(t, testClientSecret)
+		srv.Close()
+		provider := NewProvider(srv.URL, srv.Client(), 0)
+		if _, err := provider.Metadata(t.Context()); !errors.Is(err, ErrDiscoveryFailed) {
+			t.Errorf("Metadata() error = %v, want %v", err, ErrDiscoveryFailed)
+		}
+	})
+}
+
+func TestAuthorizationCodeFlow(t *testing.T) {
+	tests := []struct {
+		name         string
+		clientSecret string
+		authMethods  []string
+	}{
+		{name: "client_secret_basic", clientSecret: testClientSecret},
+		{name: "client_secret_post", clientSecret: testClientSecret, authMethods: []string{"client_secret_post"}},
+		{name: "public client"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newTestServer(t, tt.clientSecret)
+			if tt.authMethods != nil {
+				srv.SetMetadata("token_endpoint_auth_methods_supported", tt.authMethods)
+			}
+			srv.SetClaims(map[string]any{"preferred_username": "alice", "groups": []string{"bmc-admins"}})
+			client := newTestClient(srv, tt.clientSecret)
+
+			verifier, err := NewCodeVerifier()
+			if err != nil {
+				t.Fatal(err)
+			}
+			code, state := authorize(t, srv, client, "state-1", "nonce-1", verifier)
+			if state != "state-1" {
+				t.Errorf("state = %q, want %q", state, "state-1")
+			}
+
+			token, err := client.Exchange(t.Context(), code, verifier)
+			if err != nil {
+				t.Fatalf("Exchange() error = %v", err)
+			}
+			claims, err := client.VerifyIDToken(t.Context(), token.IDToken, "nonce-1")
+			if err != nil {
+				t.Fatalf("VerifyIDToken() error = %v", err)
+			}
+			if got := claims.String("preferred_username"); got != "alice" {
+				t.Errorf("preferred_username = %q, want %q", got, "alice")
+			}
+			if got := claims.Strings("groups"); len(got) != 1 || got[0] != "bmc-admins" {
+				t.Errorf("groups = %q", got)
+			}
+		})
+	}
+}
+
+func TestExchangeErrors(t *testing.T) {
+	srv := newTestServer(t, testClientSecret)
+	client := newTestClient(srv, testClientSecret)
+
+	t.Run("PKCE verifier mismatch", func(t *testing.T) {
+		code, _ := authorize(t, srv, client, "state", "nonce", "verifier-of-the-login-0123456789abcdefghijk")
+		if _, err := client.Exchange(t.Context(), code, "verifier-of-another-login-0123456789abcdefg"); !errors.Is(err, ErrExchangeFailed) {
+			t.Errorf("Exchange() error = %v, want %v", err, ErrExchangeFailed)
+		}
+	})
+
+	t.Run("code redeemed twice", func(t *testing.T) {
+		verifier, err := NewCodeVerifier()
+		if err != nil {
+			t.Fatal(err)
+		}
+		code, _ := authorize(t, srv, client, "state", "nonce", verifier)
+		if _, err := client.Exchange(t.Context(), code, verifier); err != nil {
+			t.Fatalf("Exchange() error = %v", err)
+		}
+		if _, err := client.Exchange(t.Context(), code, verifier); !errors.Is(err, ErrExchangeFailed) {
+			t.Errorf("second Exchange() error = %v, want %v", err, ErrExchangeFailed)
+		}
+	})
+
+	t.Run("wrong client secret", func(t *testing.T) {
+		verifier, err := NewCodeVerifier()
+		if err != nil {
+			t.Fatal(err)
+		}
+		code, _ := authorize(t, srv, client, "state", "nonce", verifier)
+		other := newTestClient(srv, "other-secret")
+		if _, err := other.Exchange(t.Context(), code, verifier); !errors.Is(err, ErrExchangeFailed) {
+			t.Errorf("Exchange() error = %v, want %v", err, ErrExchangeFailed)
+		}
+	})
+}
+
+func TestVerifyIDToken(t *testing.T) {
+	srv := newTestServer(t, testClientSecret)
+	client := newTestClient(srv, testClientSecret)
+	claims := with(testClaims(srv), map[string]any{"nonce": "nonce-1"})
+
+	tests := []struct {
+		name    string
+		claims  map[string]any
+		nonce   string
+		wantErr error
+	}{
+		{name: "valid", claims: claims, nonce: "nonce-1"},
+		{name: "nonce mismatch", claims: claims, nonce: "nonce-2", wantErr: ErrInvalidClaims},
+		{name: "nonce missing", claims: with(claims, map[string]any{"nonce": nil}), nonce: "nonce-1", wantErr: ErrInvalidClaims},
+		{name: "authorized party is the client", claims: with(claims, map[string]any{"azp": testClientID}), nonce: "nonce-1"},
+		{name: "authorized party is another client", claims: with(claims, map[string]any{"azp": "other"}), nonce: "nonce-1", wantErr: ErrInvalidClaims},
+		{name: "subject missing", claims: with(claims, map[string]any{"sub": nil}), nonce: "nonce-1", wantErr: ErrInvalidClaims},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := client.VerifyIDToken(t.Context(), srv.Sign(tt.claims), tt.nonce); !errors.Is(err, tt.wantErr) {
+				t.Errorf("VerifyIDToken() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifyClaims(t *testing.T) {
+	srv := newTestServer(t, testClientSecret)
+	provider := NewProvider(srv.URL, srv.Client(), 0)
+	claims := testClaims(srv)
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		claims  map[string]any
+		wantErr error
+	}{
+		{name: "valid", claims: claims},
+		{name: "audience in a list", claims: with(claims, map[string]any{"aud": []string{"other", testClientID}})},
+		{name: "other audience", claims: with(claims, map[string]any{"aud": "other"}), wantErr: ErrInvalidClaims},
+		{name: "other issuer", claims: with(claims, map[string]any{"iss": "https://idp.example.com"}), wantErr: ErrInvalidClaims},
+		{name: "expired", claims: with(claims, map[string]any{"exp": now.Add(-2 * time.Minute).Unix()}), wantErr: ErrTokenExpired},
+		{name: "expired within the clock skew", claims: with(claims, map[string]any{"exp": now.Add(-DefaultClockSkew / 2).Unix()})},
+		{name: "fractional expiration time", claims: with(claims, map[string]any{"exp": float64(now.Add(time.Minute).UnixMilli()) / 1000})},
+		{name: "expiration time missing", claims: with(claims, map[string]any{"exp": nil}), wantErr: ErrInvalidClaims},
+		{name: "not valid yet", claims: with(claims, map[string]any{"nbf": now.Add(2 * time.Minute).Unix()}), wantErr: ErrTokenExpired},
+		{name: "issued in the future", claims: with(claims, map[string]any{"iat": now.Add(2 * time.Minute).Unix()}), wantErr: ErrTokenExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := provider.Verify(t.Context(), srv.Sign(tt.claims), testClientID); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifyAlgorithms(t *testing.T) {
+	for _, alg := range []string{"RS256", "PS256", "ES256", "ES384", "EdDSA"} {
+		t.Run(alg, func(t *testing.T) {
+			srv := newTestServer(t, testClientSecret)
+			srv.RotateKey(alg)
+			provider := NewProvider(srv.URL, srv.Client(), 0)
+			if _, err := provider.Verify(t.Context(), srv.Sign(testClaims(srv)), testClientID); err != nil {
+				t.Errorf("Verify() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestAlgorithmConfusion(t *testing.T) {
+	srv := newTestServer(t, testClientSecret)
+	provider := NewProvider(srv.URL, srv.Client(), 0)
+	claims := testClaims(srv)
+	rsaKey := srv.KeyID()
+
+	segment := func(v any) string {
+		b, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return base64.RawURLEncoding.EncodeToString(b)
+	}
+	// hs256 signs a token with HMAC, keyed with the public key set of the
+	// provider as an attacker would.
+	hs256 := func() string {
+		resp, err := srv.Client().Get(srv.URL + "/jwks")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close() //nolint:errcheck
+		var set struct {
+			Keys []struct {
+				N string `json:"n"`
+			} `json:"keys"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+			t.Fatal(err)
+		}
+		input := segment(map[string]any{"alg": "HS256", "kid": rsaKey}) + "." + segment(claims)
+		mac := hmac.New(sha256.New, []byte(set.Keys[0].N))
+		mac.Write([]byte(input))
+		return input + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+	}
+	// tampered swaps the claims of a signed token.
+	tampered := func() string {
+		parts := strings.Split(srv.Sign(claims), ".")
+		parts[1] = segment(with(claims, map[string]any{"sub": "admin"}))
+		return strings.Join(parts, ".")
+	}
+	foreign := oidctest.NewServer(testClientID, testClientSecret)
+	defer foreign.Close()
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{name: "valid", token: srv.Sign(claims)},
+		{name: "no key ID", token: srv.SignWithHeader(map[string]any{"kid": nil}, claims)},
+		{name: "unsigned", token: segment(map[string]any{"alg": "none"}) + "." + segment(claims) + ".", wantErr: ErrUnsupportedAlgorithm},
+		{name: "HMAC keyed with the public key", token: hs256(), wantErr: ErrUnsupportedAlgorithm},
+		{name: "algorithm other than the one of the key", token: srv.SignWithHeader(map[string]any{"alg": "PS256"}, claims), wantErr: ErrUnknownKey},
+		{name: "algorithm of another key type", token: srv.SignWithHeader(map[string]any{"alg": "ES256"}, claims), wantErr: ErrUnknownKey},
+		{name: "unknown key ID", token: srv.SignWithHeader(map[string]any{"kid": "key-99"}, claims), wantErr: ErrUnknownKey},
+		{name: "key of another provider with the same ID", token: foreign.Sign(claims), wantErr: ErrInvalidSignature},
+		{name: "tampered claims", token: tampered(), wantErr: ErrInvalidSignature},
+		{name: "critical header", token: srv.SignWithHeader(map[string]any{"crit": []string{"exp"}}, claims), wantErr: ErrMalformedToken},
+		{name: "not a JWS", token: "a.b", wantErr: ErrMalformedToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := provider.Verify(t.Context(), tt.token, testClientID); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestKeySetRotation(t *testing.T) {
+	srv := newTestServer(t, testClientSecret)
+	provider := NewProvider(srv.URL, srv.Client(), 0)
+	claims := testClaims(srv)
+	verify := func(token string) error {
+		_, err := provider.Verify(t.Context(), token, testClientID)
+		return err
+	}
+
+	oldToken := srv.Sign(claims)
+	if err := verify(oldToken); err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+
+	srv.RotateKey("ES256")
+	newToken := srv.Sign(claims)
+	// The key set was just fetched, so an unknown key does not refresh it.
+	if err := verify(newToken); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("Verify() error = %v with a fresh key set, want %v", err, ErrUnknownKey)
+	}
+	if n := srv.KeySetRequests(); n != 1 {
+		t.Errorf("key set fetched %d times, want 1", n)
+	}
+
+	expireKeySet(provider)
+	if err := verify(newToken); err != nil {
+		t.Errorf("Verify() error = %v with the rotated key", err)
+	}
+	if err := verify(oldToken); err != nil {
+		t.Errorf("Verify() error = %v with the previous key", err)
+	}
+	if n := srv.KeySetRequests(); n != 2 {
+		t.Errorf("key set fetched %d times, want 2", n)
+	}
+
+	srv.RetireKeys()
+	expireKeySet(provider)
+	if err := verify(oldToken); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("Verify() error = %v with a retired key, want %v", err, ErrUnknownKey)
+	}
+
+	// While the provider cannot be reached, the expired key set is used.
+	srv.Close()
+	expireKeySet(provider)
+	if err := verify(newToken); err != nil {
+		t.Errorf("Verify() error = %v with the provider down", err)
+	}
+}
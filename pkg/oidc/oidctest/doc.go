@@ -0,0 +1,29 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package oidctest provides an in-process OpenID provider for testing code
+// that logs users in and verifies tokens with package oidc, in the spirit of
+// net/http/httptest.
+//
+// # Overview
+//
+// A Server serves over HTTPS on a loopback port:
+//   - The discovery document below its URL, whose fields can be replaced to
+//     misconfigure it
+//   - An authorization endpoint that lets every user agent in right away and
+//     sends it back to the client with a code
+//   - A token endpoint redeeming codes once, checking the client
+//     authentication, the redirect URI and the PKCE code verifier
+//   - The key set of its signing keys, which can be rotated and retired
+//
+// Sign and SignWithHeader issue tokens with arbitrary claims and headers,
+// to check that expired tokens or tokens misstating their key or algorithm
+// are rejected.
+//
+// # Basic Usage
+//
+//	srv := oidctest.NewServer("bmc", "secret")
+//	defer srv.Close()
+//	srv.SetClaims(map[string]any{"preferred_username": "alice"})
+//
+//	provider := oidc.NewProvider(srv.URL, srv.Client(), 0)
+package oidctest
@@ -0,0 +1,468 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package oidctest
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Paths of the endpoints of a server.
+const (
+	discoveryPath     = "/.well-known/openid-configuration"
+	authorizationPath = "/authorize"
+	tokenPath         = "/token"
+	keySetPath        = "/jwks"
+)
+
+const (
+	// codeLifetime is how long an authorization code can be redeemed.
+	codeLifetime = time.Minute
+	// tokenLifetime is how long issued tokens are valid.
+	tokenLifetime = 5 * time.Minute
+	// rsaKeyBits is the size of generated RSA keys.
+	rsaKeyBits = 2048
+)
+
+// signingKey is a key the server signs tokens with.
+type signingKey struct {
+	id     string
+	alg    string
+	signer crypto.Signer
+}
+
+// grant is an authorization code waiting to be redeemed.
+type grant struct {
+	challenge   string
+	nonce       string
+	redirectURI string
+	expires     time.Time
+}
+
+// Server is an OpenID provider served over HTTPS on a loopback port.
+type Server struct {
+	// URL is the issuer identifier of the server, below which its
+	// discovery document is served.
+	URL string
+
+	srv          *httptest.Server
+	clientID     string
+	clientSecret string
+
+	mu             sync.Mutex
+	keys           []*signingKey
+	keyCount       int
+	claims         map[string]any
+	metadata       map[string]any
+	grants         map[string]grant
+	keySetRequests int
+}
+
+// NewServer starts a provider with a single registered client. Public
+// clients have no secret. The server signs tokens with a fresh RS256 key.
+// The caller should call Close when finished, to shut it down.
+func NewServer(clientID, clientSecret string) *Server {
+	s := &Server{
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		metadata:     make(map[string]any),
+		grants:       make(map[string]grant),
+	}
+	s.RotateKey("RS256")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+discoveryPath, s.handleDiscovery)
+	mux.HandleFunc("GET "+authorizationPath, s.handleAuthorization)
+	mux.HandleFunc("POST "+tokenPath, s.handleToken)
+	mux.HandleFunc("GET "+keySetPath, s.handleKeySet)
+	s.srv = httptest.NewTLSServer(mux)
+	s.URL = s.srv.URL
+	return s
+}
+
+// Client returns an HTTP client trusting the certificate of the server.
+func (s *Server) Client() *http.Client {
+	return s.srv.Client()
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// RotateKey generates a key for alg, one of RS256, PS256, ES256, ES384 and
+// EdDSA, and signs tokens with it from now on. Previous keys stay in the key
+// set until RetireKeys is called. It returns the key ID of the new key.
+func (s *Server) RotateKey(alg string) string {
+	var signer crypto.Signer
+	var err error
+	switch alg {
+	case "RS256", "PS256":
+		signer, err = rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	case "ES256":
+		signer, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case "ES384":
+		signer, err = ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	case "EdDSA":
+		_, signer, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		panic(fmt.Sprintf("oidctest: unsupported algorithm %q", alg))
+	}
+	if err != nil {
+		panic(fmt.Sprintf("oidctest: failed to generate a key: %v", err))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keyCount++
+	key := &signingKey{id: fmt.Sprintf("key-%d", s.keyCount), alg: alg, signer: signer}
+	s.keys = append(s.keys, key)
+	return key.id
+}
+
+// RetireKeys drops all keys but the current one from the key set.
+func (s *Server) RetireKeys() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = s.keys[len(s.keys)-1:]
+}
+
+// KeyID returns the ID of the key tokens are signed with.
+func (s *Server) KeyID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.keys[len(s.keys)-1].id
+}
+
+// SetClaims sets claims added to the ID tokens of later logins, such as the
+// username and groups of the user. They replace the claims the server sets,
+// so that tokens with a wrong nonce or audience can be issued as well.
+func (s *Server) SetClaims(claims map[string]any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.claims = maps.Clone(claims)
+}
+
+// SetMetadata replaces a field of the discovery document, removing it if
+// value is nil. The token endpoint only accepts the client authentication
+// methods the document lists.
+func (s *Server) SetMetadata(name string, value any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.metadata[name] = value
+}
+
+// KeySetRequests returns the number of times the key set was fetched.
+func (s *Server) KeySetRequests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.keySetRequests
+}
+
+// Sign returns a token with claims, signed with the current key.
+func (s *Server) Sign(claims map[string]any) string {
+	return s.SignWithHeader(nil, claims)
+}
+
+// SignWithHeader returns a token with claims, signed with the current key
+// by its algorithm. Fields of header replace those of the JOSE header, or
+// remove them if nil, so that the header can misstate the key or algorithm.
+func (s *Server) SignWithHeader(header, claims map[string]any) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sign(header, claims)
+}
+
+func (s *Server) sign(header, claims map[string]any) string {
+	key := s.keys[len(s.keys)-1]
+	h := map[string]any{"alg": key.alg, "kid": key.id, "typ": "JWT"}
+	override(h, header)
+
+	input := encodeSegment(h) + "." + encodeSegment(claims)
+	signature, err := signJWS(key, []byte(input))
+	if err != nil {
+		panic(fmt.Sprintf("oidctest: failed to sign a token: %v", err))
+	}
+	return input + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// signJWS signs the signing input of a JWS (RFC 7518 section 3).
+func signJWS(key *signingKey, input []byte) ([]byte, error) {
+	switch k := key.signer.(type) {
+	case *rsa.PrivateKey:
+		digest := sha256.Sum256(input)
+		if key.alg == "PS256" {
+			return rsa.SignPSS(rand.Reader, k, crypto.SHA256, digest[:], &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		}
+		return rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:])
+	case *ecdsa.PrivateKey:
+		var digest []byte
+		if key.alg == "ES384" {
+			sum := sha512.Sum384(input)
+			digest = sum[:]
+		} else {
+			sum := sha256.Sum256(input)
+			digest = sum[:]
+		}
+		r, sig, err := ecdsa.Sign(rand.Reader, k, digest)
+		if err != nil {
+			return nil, err
+		}
+		// JWS carries the fixed-size concatenation of r and s.
+		size := (k.Curve.Params().BitSize + 7) / 8
+		out := make([]byte, 2*size)
+		r.FillBytes(out[:size])
+		sig.FillBytes(out[size:])
+		return out, nil
+	case ed25519.PrivateKey:
+		return ed25519.Sign(k, input), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", k)
+	}
+}
+
+// document returns the discovery document.
+func (s *Server) document() map[string]any {
+	methods := []string{"none"}
+	if s.clientSecret != "" {
+		methods = []string{"client_secret_basic", "client_secret_post"}
+	}
+	doc := map[string]any{
+		"issuer":                                s.URL,
+		"authorization_endpoint":                s.URL + authorizationPath,
+		"token_endpoint":                        s.URL + tokenPath,
+		"jwks_uri":                              s.URL + keySetPath,
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256", "PS256", "ES256", "ES384", "EdDSA"},
+		"code_challenge_methods_supported":      []string{"S256"},
+		"token_endpoint_auth_methods_supported": methods,
+	}
+	override(doc, s.metadata)
+	return doc
+}
+
+func (s *Server) handleDiscovery(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	doc := s.document()
+	s.mu.Unlock()
+	writeJSON(w, http.StatusOK, doc)
+}
+
+func (s *Server) handleKeySet(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	s.keySetRequests++
+	keys := make([]map[string]string, 0, len(s.keys))
+	for _, key := range s.keys {
+		keys = append(keys, publicJWK(key))
+	}
+	s.mu.Unlock()
+	writeJSON(w, http.StatusOK, map[string]any{"keys": keys})
+}
+
+// publicJWK returns the public part of key as JSON Web Key (RFC 7517).
+func publicJWK(key *signingKey) map[string]string {
+	jwk := map[string]string{"kid": key.id, "alg": key.alg, "use": "sig"}
+	switch k := key.signer.Public().(type) {
+	case *rsa.PublicKey:
+		jwk["kty"] = "RSA"
+		jwk["n"] = base64.RawURLEncoding.EncodeToString(k.N.Bytes())
+		jwk["e"] = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes())
+	case *ecdsa.PublicKey:
+		size := (k.Curve.Params().BitSize + 7) / 8
+		jwk["kty"] = "EC"
+		jwk["crv"] = k.Curve.Params().Name
+		jwk["x"] = base64.RawURLEncoding.EncodeToString(k.X.FillBytes(make([]byte, size)))
+		jwk["y"] = base64.RawURLEncoding.EncodeToString(k.Y.FillBytes(make([]byte, size)))
+	case ed25519.PublicKey:
+		jwk["kty"] = "OKP"
+		jwk["crv"] = "Ed25519"
+		jwk["x"] = base64.RawURLEncoding.EncodeToString(k)
+	}
+	return jwk
+}
+
+// handleAuthorization lets every user agent in right away and sends it back
+// to the client with an authorization code.
+func (s *Server) handleAuthorization(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	redirectURI, err := url.Parse(q.Get("redirect_uri"))
+	switch {
+	case q.Get("client_id") != s.clientID:
+		http.Error(w, "unknown client", http.StatusBadRequest)
+		return
+	case err != nil || !redirectURI.IsAbs():
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	case q.Get("response_type") != "code":
+		http.Error(w, "unsupported response_type", http.StatusBadRequest)
+		return
+	case !slices.Contains(strings.Fields(q.Get("scope")), "openid"):
+		http.Error(w, "scope lacks openid", http.StatusBadRequest)
+		return
+	case q.Get("code_challenge_method") != "S256" || q.Get("code_challenge") == "":
+		http.Error(w, "PKCE with S256 is required", http.StatusBadRequest)
+		return
+	}
+
+	code := randomHex()
+	s.mu.Lock()
+	s.grants[code] = grant{
+		challenge:   q.Get("code_challenge"),
+		nonce:       q.Get("nonce"),
+		redirectURI: q.Get("redirect_uri"),
+		expires:     time.Now().Add(codeLifetime),
+	}
+	s.mu.Unlock()
+
+	callback := redirectURI.Query()
+	callback.Set("code", code)
+	callback.Set("state", q.Get("state"))
+	redirectURI.RawQuery = callback.Encode()
+	http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+}
+
+// handleToken redeems an authorization code (RFC 6749 section 4.1.3),
+// checking the client authentication and the PKCE code verifier (RFC 7636
+// section 4.6).
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		tokenError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+	if r.PostForm.Get("grant_type") != "authorization_code" {
+		tokenError(w, http.StatusBadRequest, "unsupported_grant_type", "only authorization_code is supported")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.authenticateClient(r) {
+		tokenError(w, http.StatusUnauthorized, "invalid_client", "client authentication failed")
+		return
+	}
+
+	code := r.PostForm.Get("code")
+	g, ok := s.grants[code]
+	// Codes are redeemed at most once.
+	delete(s.grants, code)
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	challenge := base64.RawURLEncoding.EncodeToString(sum[:])
+	switch {
+	case !ok || time.Now().After(g.expires):
+		tokenError(w, http.StatusBadRequest, "invalid_grant", "unknown or expired code")
+		return
+	case r.PostForm.Get("redirect_uri") != g.redirectURI:
+		tokenError(w, http.StatusBadRequest, "invalid_grant", "redirect_uri does not match")
+		return
+	case subtle.ConstantTimeCompare([]byte(challenge), []byte(g.challenge)) != 1:
+		tokenError(w, http.StatusBadRequest, "invalid_grant", "code_verifier does not match the code_challenge")
+		return
+	}
+
+	now := time.Now()
+	claims := map[string]any{
+		"iss":   s.URL,
+		"sub":   "user",
+		"aud":   s.clientID,
+		"iat":   now.Unix(),
+		"exp":   now.Add(tokenLifetime).Unix(),
+		"nonce": g.nonce,
+	}
+	override(claims, s.claims)
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": randomHex(),
+		"token_type":   "Bearer",
+		"expires_in":   int(tokenLifetime / time.Second),
+		"id_token":     s.sign(nil, claims),
+	})
+}
+
+// authenticateClient checks the client authentication of a token request
+// (RFC 6749 section 2.3.1) against the methods the discovery document
+// lists.
+func (s *Server) authenticateClient(r *http.Request) bool {
+	methods, _ := s.document()["token_endpoint_auth_methods_supported"].([]string)
+
+	var method, clientID, secret string
+	if id, password, ok := r.BasicAuth(); ok {
+		// Both are form-encoded before they are put into the header.
+		var err1, err2 error
+		clientID, err1 = url.QueryUnescape(id)
+		secret, err2 = url.QueryUnescape(password)
+		if err1 != nil || err2 != nil {
+			return false
+		}
+		method = "client_secret_basic"
+	} else {
+		clientID, secret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+		method = "none"
+		if secret != "" {
+			method = "client_secret_post"
+		}
+	}
+
+	return slices.Contains(methods, method) &&
+		clientID == s.clientID &&
+		subtle.ConstantTimeCompare([]byte(secret), []byte(s.clientSecret)) == 1
+}
+
+// override sets the fields of dst to those of src, deleting those that are
+// nil in src.
+func override(dst, src map[string]any) {
+	for name, value := range src {
+		if value == nil {
+			delete(dst, name)
+		} else {
+			dst[name] = value
+		}
+	}
+}
+
+func encodeSegment(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("oidctest: failed to encode a token: %v", err))
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func randomHex() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("oidctest: failed to read random bytes: %v", err))
+	}
+	return hex.EncodeToString(b)
+}
+
+func tokenError(w http.ResponseWriter, status int, code, description string) {
+	writeJSON(w, status, map[string]string{"error": code, "error_description": description})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
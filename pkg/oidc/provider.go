@@ -0,0 +1,286 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultKeySetTTL is how long the key set of a provider is cached.
+	DefaultKeySetTTL = 15 * time.Minute
+	// DefaultClockSkew is the clock skew tolerated for the validity period
+	// of tokens.
+	DefaultClockSkew = time.Minute
+
+	// keySetRefreshInterval limits how often a token signed by an unknown
+	// key refreshes the cached key set, so that such tokens cannot make the
+	// provider fetch it for every request.
+	keySetRefreshInterval = 30 * time.Second
+	// maxResponseSize bounds the documents read from the provider.
+	maxResponseSize = 1 << 20
+	// discoveryPath is the path of the discovery document below the issuer.
+	discoveryPath = "/.well-known/openid-configuration"
+)
+
+// Metadata is the part of the discovery document of a provider (OpenID
+// Connect Discovery 1.0) a relying party uses.
+type Metadata struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	UserinfoEndpoint                  string   `json:"userinfo_endpoint,omitempty"`
+	JWKSURI                           string   `json:"jwks_uri"`
+	EndSessionEndpoint                string   `json:"end_session_endpoint,omitempty"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported,omitempty"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported,omitempty"`
+}
+
+// Provider is an OpenID provider. It fetches the discovery document once and
+// caches the key set of the provider, refreshing it when it expires or a
+// token is signed by a key it does not hold yet. While the key set cannot be
+// refreshed, the expired one is used.
+type Provider struct {
+	issuer    string
+	client    *http.Client
+	keySetTTL time.Duration
+
+	mu           sync.Mutex
+	metadata     *Metadata
+	keys         []verificationKey
+	keysFetched  time.Time
+	keysExpire   time.Time
+	keysFetching chan struct{}
+}
+
+// NewProvider returns the provider identified by issuer, fetching its
+// documents with client, http.DefaultClient if nil. Its key set is cached
+// for keySetTTL, DefaultKeySetTTL if zero.
+func NewProvider(issuer string, client *http.Client, keySetTTL time.Duration) *Provider {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if keySetTTL <= 0 {
+		keySetTTL = DefaultKeySetTTL
+	}
+	return &Provider{
+		issuer:    issuer,
+		client:    client,
+		keySetTTL: keySetTTL,
+	}
+}
+
+// Issuer returns the issuer identifier of the provider.
+func (p *Provider) Issuer() string {
+	return p.issuer
+}
+
+// Metadata returns the discovery document of the provider, fetching it on
+// first use. The issuer it names must match the one of the provider.
+func (p *Provider) Metadata(ctx context.Context) (*Metadata, error) {
+	p.mu.Lock()
+	metadata := p.metadata
+	p.mu.Unlock()
+	if metadata != nil {
+		return metadata, nil
+	}
+
+	metadata = &Metadata{}
+	if err := p.fetch(ctx, strings.TrimSuffix(p.issuer, "/")+discoveryPath, metadata); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDiscoveryFailed, err)
+	}
+	switch {
+	case metadata.Issuer != p.issuer:
+		return nil, fmt.Errorf("%w: document names issuer %q instead of %q", ErrDiscoveryFailed, metadata.Issuer, p.issuer)
+	case metadata.AuthorizationEndpoint == "" || metadata.TokenEndpoint == "" || metadata.JWKSURI == "":
+		return nil, fmt.Errorf("%w: document lacks the authorization, token or key set endpoint", ErrDiscoveryFailed)
+	case len(metadata.CodeChallengeMethodsSupported) > 0 && !slices.Contains(metadata.CodeChallengeMethodsSupported, "S256"):
+		return nil, fmt.Errorf("%w: provider does not support the S256 code challenge method", ErrDiscoveryFailed)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.metadata == nil {
+		p.metadata = metadata
+	}
+	return p.metadata, nil
+}
+
+// Verify verifies the signature of a JWT issued by the provider and checks
+// that it is valid now, its issuer is the provider and its audience includes
+// one of audiences. It returns the claims of the token.
+func (p *Provider) Verify(ctx context.Context, raw string, audiences ...string) (Claims, error) {
+	token, err := parseJWT(raw)
+	if err != nil {
+		return nil, err
+	}
+	alg, ok := algorithms[token.alg]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedAlgorithm, token.alg)
+	}
+
+	keys, err := p.signingKeys(ctx, token, alg)
+	if err != nil {
+		return nil, err
+	}
+	verified := false
+	for _, key := range keys {
+		if alg.verify(key.key, token.signed, token.signature) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, ErrInvalidSignature
+	}
+
+	if err := p.checkClaims(token.claims, audiences, time.Now()); err != nil {
+		return nil, err
+	}
+	return token.claims, nil
+}
+
+// checkClaims checks the registered claims of a token (RFC 7519 section 4.1).
+func (p *Provider) checkClaims(claims Claims, audiences []string, now time.Time) error {
+	if iss := claims.String("iss"); iss != p.issuer {
+		return fmt.Errorf("%w: issuer %q instead of %q", ErrInvalidClaims, iss, p.issuer)
+	}
+	if !slices.ContainsFunc(claims.Strings("aud"), func(aud string) bool { return slices.Contains(audiences, aud) }) {
+		return fmt.Errorf("%w: audience %q is not accepted", ErrInvalidClaims, claims.Strings("aud"))
+	}
+
+	exp, ok := claims.Time("exp")
+	if !ok {
+		return fmt.Errorf("%w: missing expiration time", ErrInvalidClaims)
+	}
+	if !now.Before(exp.Add(DefaultClockSkew)) {
+		return fmt.Errorf("%w: expired at %s", ErrTokenExpired, exp.UTC().Format(time.RFC3339))
+	}
+	if nbf, ok := claims.Time("nbf"); ok && now.Add(DefaultClockSkew).Before(nbf) {
+		return fmt.Errorf("%w: not valid before %s", ErrTokenExpired, nbf.UTC().Format(time.RFC3339))
+	}
+	if iat, ok := claims.Time("iat"); ok && now.Add(DefaultClockSkew).Before(iat) {
+		return fmt.Errorf("%w: issued in the future at %s", ErrTokenExpired, iat.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
+// signingKeys returns the keys of the key set that may have signed token,
+// refreshing the key set if it expired or holds no such key.
+func (p *Provider) signingKeys(ctx context.Context, token *jwt, alg algorithm) ([]verificationKey, error) {
+	match := func(keys []verificationKey) []verificationKey {
+		var matching []verificationKey
+		for _, key := range keys {
+			if (token.kid == "" || key.id == token.kid) && alg.fits(key, token.alg) {
+				matching = append(matching, key)
+			}
+		}
+		return matching
+	}
+
+	p.mu.Lock()
+	now := time.Now()
+	keys, fresh := p.keys, now.Before(p.keysExpire)
+	refreshable := now.Sub(p.keysFetched) >= keySetRefreshInterval
+	p.mu.Unlock()
+
+	matching := match(keys)
+	switch {
+	case len(matching) > 0 && fresh:
+		return matching, nil
+	case !refreshable && len(matching) > 0:
+		// The key set expired, but could not be fetched just now.
+		return matching, nil
+	case !refreshable:
+		return nil, fmt.Errorf("%w: key %q", ErrUnknownKey, token.kid)
+	}
+
+	keys, err := p.refreshKeys(ctx)
+	if err != nil {
+		// Keep verifying with the expired key set while the provider
+		// cannot be reached.
+		if len(matching) > 0 {
+			return matching, nil
+		}
+		return nil, err
+	}
+	if matching := match(keys); len(matching) > 0 {
+		return matching, nil
+	}
+	return nil, fmt.Errorf("%w: key %q", ErrUnknownKey, token.kid)
+}
+
+// refreshKeys fetches the key set, sharing a fetch already in progress.
+func (p *Provider) refreshKeys(ctx context.Context) ([]verificationKey, error) {
+	p.mu.Lock()
+	if fetching := p.keysFetching; fetching != nil {
+		p.mu.Unlock()
+		select {
+		case <-fetching:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: %w", ErrKeySetFailed, ctx.Err())
+		}
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if p.keys == nil {
+			return nil, fmt.Errorf("%w: key set unavailable", ErrKeySetFailed)
+		}
+		return p.keys, nil
+	}
+	fetching := make(chan struct{})
+	p.keysFetching = fetching
+	p.mu.Unlock()
+
+	keys, err := p.fetchKeys(ctx)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.keysFetching = nil
+	close(fetching)
+	p.keysFetched = time.Now()
+	if err != nil {
+		return nil, err
+	}
+	p.keys = keys
+	p.keysExpire = p.keysFetched.Add(p.keySetTTL)
+	return keys, nil
+}
+
+func (p *Provider) fetchKeys(ctx context.Context) ([]verificationKey, error) {
+	metadata, err := p.Metadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var raw json.RawMessage
+	if err := p.fetch(ctx, metadata.JWKSURI, &raw); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrKeySetFailed, err)
+	}
+	return parseKeySet(raw)
+}
+
+// fetch reads the JSON document at url into v.
+func (p *Provider) fetch(ctx context.Context, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s answered %s", url, resp.Status)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(v)
+}
@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package radius provides a minimal RADIUS client (RFC 2865) for
+// authenticating users against a RADIUS server, without depending on a
+// full-featured RADIUS library.
+//
+// # Overview
+//
+// The client supports:
+//   - PAP, sending the password hidden with the shared secret, and CHAP,
+//     sending a response to a random challenge
+//   - The Message-Authenticator attribute of RFC 3579, which is sent first in
+//     every request and verified in responses carrying it, or required with
+//     RequireMessageAuthenticator
+//   - Vendor-specific attributes, such as the privilege level or role a
+//     server assigns to a user
+//   - Retransmission of unanswered requests until the context ends
+//
+// Access-Challenge responses, accounting and the EAP methods are not
+// supported. Responses with a wrong identifier or authenticator are dropped,
+// as RFC 2865 requires.
+//
+// # Basic Usage
+//
+// Checking the password of a user and reading the Cisco-AVPair attributes
+// of the Access-Accept:
+//
+//	client := &radius.Client{Address: "radius1.example.com", Secret: []byte(secret)}
+//
+//	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+//	defer cancel()
+//	accept, err := client.Authenticate(ctx, &radius.Request{
+//		Username: username,
+//		Password: password,
+//		Type:     radius.AuthCHAP,
+//		Attributes: []radius.Attribute{
+//			{Type: radius.AttributeNASIdentifier, Value: []byte("bmc-rack4")},
+//		},
+//	})
+//	if errors.Is(err, radius.ErrAccessRejected) {
+//		return errors.New("wrong password")
+//	}
+//	if err != nil {
+//		return err
+//	}
+//	for _, pair := range accept.VendorSpecific(9, 1) {
+//		fmt.Println(string(pair)) // shell:priv-lvl=15
+//	}
+//
+// # Error Handling
+//
+// Errors wrap one of the package's sentinel errors. ErrAccessRejected reports
+// rejected credentials, ErrNoResponse a server that did not answer in time or
+// could not be reached, which callers typically answer by trying another
+// server.
+//
+// # Testing
+//
+// Package radiustest provides an in-process server to run the client and
+// code built on it against.
+package radius
@@ -0,0 +1,20 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package radius
+
+import "errors"
+
+var (
+	// ErrInvalidAddress indicates that a server address is malformed.
+	ErrInvalidAddress = errors.New("invalid RADIUS server address")
+	// ErrNoResponse indicates that the server did not answer before the deadline or could not be reached.
+	ErrNoResponse = errors.New("no RADIUS response")
+	// ErrMalformedPacket indicates that a packet is not a valid RADIUS packet.
+	ErrMalformedPacket = errors.New("malformed RADIUS packet")
+	// ErrInvalidAttribute indicates that an attribute value is too long or otherwise unusable.
+	ErrInvalidAttribute = errors.New("invalid RADIUS attribute")
+	// ErrAccessRejected indicates that the server rejected the credentials.
+	ErrAccessRejected = errors.New("RADIUS access rejected")
+	// ErrChallengeUnsupported indicates that the server asked for a challenge response, which is not supported.
+	ErrChallengeUnsupported = errors.New("RADIUS access challenge not supported")
+)
@@ -0,0 +1,250 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package radius
+
+import (
+	"crypto/hmac"
+	"crypto/md5" //nolint:gosec // RADIUS is defined in terms of MD5
+	"crypto/subtle"
+	"encoding/binary"
+	"fmt"
+)
+
+// Code is the type of a RADIUS packet (RFC 2865 section 3).
+type Code byte
+
+// Packet codes of the authentication exchange.
+const (
+	CodeAccessRequest   Code = 1
+	CodeAccessAccept    Code = 2
+	CodeAccessReject    Code = 3
+	CodeAccessChallenge Code = 11
+)
+
+// AttributeType is the type of a RADIUS attribute.
+type AttributeType byte
+
+// Attribute types used by the client (RFC 2865 section 5, RFC 2869
+// section 5.14).
+const (
+	AttributeUserName             AttributeType = 1
+	AttributeUserPassword         AttributeType = 2
+	AttributeCHAPPassword         AttributeType = 3
+	AttributeNASIPAddress         AttributeType = 4
+	AttributeServiceType          AttributeType = 6
+	AttributeReplyMessage         AttributeType = 18
+	AttributeState                AttributeType = 24
+	AttributeVendorSpecific       AttributeType = 26
+	AttributeCallingStationID     AttributeType = 31
+	AttributeNASIdentifier        AttributeType = 32
+	AttributeCHAPChallenge        AttributeType = 60
+	AttributeMessageAuthenticator AttributeType = 80
+)
+
+const (
+	headerLength        = 20
+	authenticatorLength = 16
+	maxPacketLength     = 4096
+	maxAttributeLength  = 253
+	maxPasswordLength   = 128
+	vendorHeaderLength  = 6
+)
+
+// Attribute is an attribute of a packet.
+type Attribute struct {
+	Type  AttributeType
+	Value []byte
+}
+
+// Packet is a RADIUS packet.
+type Packet struct {
+	Code          Code
+	Identifier    byte
+	Authenticator [authenticatorLength]byte
+	Attributes    []Attribute
+}
+
+// Add appends an attribute.
+func (p *Packet) Add(t AttributeType, value []byte) {
+	p.Attributes = append(p.Attributes, Attribute{Type: t, Value: value})
+}
+
+// AddString appends an attribute with a text value.
+func (p *Packet) AddString(t AttributeType, value string) {
+	p.Add(t, []byte(value))
+}
+
+// Get returns the value of the first attribute of a type or nil.
+func (p *Packet) Get(t AttributeType) []byte {
+	for _, attr := range p.Attributes {
+		if attr.Type == t {
+			return attr.Value
+		}
+	}
+	return nil
+}
+
+// VendorSpecific returns the values of the vendor-specific attributes of a
+// vendor and vendor type (RFC 2865 section 5.26), assuming the recommended
+// format of one-octet vendor types and lengths.
+func (p *Packet) VendorSpecific(vendorID uint32, vendorType byte) [][]byte {
+	var values [][]byte
+	for _, attr := range p.Attributes {
+		if attr.Type != AttributeVendorSpecific || len(attr.Value) < 4 ||
+			binary.BigEndian.Uint32(attr.Value) != vendorID {
+			continue
+		}
+		for data := attr.Value[4:]; len(data) >= 2; {
+			length := int(data[1])
+			if length < 2 || length > len(data) {
+				break
+			}
+			if data[0] == vendorType {
+				values = append(values, data[2:length])
+			}
+			data = data[length:]
+		}
+	}
+	return values
+}
+
+// VendorAttribute returns the value of a vendor-specific attribute holding
+// a single sub-attribute.
+func VendorAttribute(vendorID uint32, vendorType byte, value []byte) []byte {
+	b := binary.BigEndian.AppendUint32(nil, vendorID)
+	b = append(b, vendorType, byte(len(value)+2))
+	return append(b, value...)
+}
+
+// encode returns the wire format of p.
+func (p *Packet) encode() ([]byte, error) {
+	b := make([]byte, headerLength, maxPacketLength)
+	b[0] = byte(p.Code)
+	b[1] = p.Identifier
+	copy(b[4:headerLength], p.Authenticator[:])
+	for _, attr := range p.Attributes {
+		if len(attr.Value) > maxAttributeLength {
+			return nil, fmt.Errorf("%w: attribute %d is %d bytes long", ErrInvalidAttribute, attr.Type, len(attr.Value))
+		}
+		b = append(b, byte(attr.Type), byte(len(attr.Value)+2))
+		b = append(b, attr.Value...)
+	}
+	if len(b) > maxPacketLength {
+		return nil, fmt.Errorf("%w: packet exceeds %d bytes", ErrInvalidAttribute, maxPacketLength)
+	}
+	binary.BigEndian.PutUint16(b[2:4], uint16(len(b))) //nolint:gosec // bounded by maxPacketLength
+	return b, nil
+}
+
+// parsePacket decodes a packet, ignoring bytes beyond its length field.
+func parsePacket(b []byte) (*Packet, error) {
+	if len(b) < headerLength {
+		return nil, fmt.Errorf("%w: %d bytes", ErrMalformedPacket, len(b))
+	}
+	length := int(binary.BigEndian.Uint16(b[2:4]))
+	if length < headerLength || length > len(b) || length > maxPacketLength {
+		return nil, fmt.Errorf("%w: invalid length %d", ErrMalformedPacket, length)
+	}
+
+	p := &Packet{Code: Code(b[0]), Identifier: b[1]}
+	copy(p.Authenticator[:], b[4:headerLength])
+	for data := b[headerLength:length]; len(data) > 0; {
+		if len(data) < 2 || data[1] < 2 || int(data[1]) > len(data) {
+			return nil, fmt.Errorf("%w: truncated attribute", ErrMalformedPacket)
+		}
+		p.Add(AttributeType(data[0]), data[2:data[1]])
+		data = data[data[1]:]
+	}
+	return p, nil
+}
+
+// hidePassword encrypts a User-Password value (RFC 2865 section 5.2).
+func hidePassword(password, secret []byte, authenticator [authenticatorLength]byte) ([]byte, error) {
+	if len(password) > maxPasswordLength {
+		return nil, fmt.Errorf("%w: password longer than %d bytes", ErrInvalidAttribute, maxPasswordLength)
+	}
+	padded := make([]byte, max(authenticatorLength, (len(password)+authenticatorLength-1)/authenticatorLength*authenticatorLength))
+	copy(padded, password)
+
+	prev := authenticator[:]
+	for i := 0; i < len(padded); i += authenticatorLength {
+		h := md5.New() //nolint:gosec // RADIUS is defined in terms of MD5
+		h.Write(secret)
+		h.Write(prev)
+		for j, b := range h.Sum(nil) {
+			padded[i+j] ^= b
+		}
+		prev = padded[i : i+authenticatorLength]
+	}
+	return padded, nil
+}
+
+// chapPassword returns a CHAP-Password value (RFC 2865 section 5.3).
+func chapPassword(id byte, password, challenge []byte) []byte {
+	h := md5.New() //nolint:gosec // CHAP is defined in terms of MD5
+	h.Write([]byte{id})
+	h.Write(password)
+	h.Write(challenge)
+	return h.Sum([]byte{id})
+}
+
+// signRequest fills in the Message-Authenticator attribute of an encoded
+// request, which must already carry a zeroed one (RFC 3579 section 3.2).
+func signRequest(b, secret []byte) {
+	if offset := messageAuthenticatorOffset(b); offset >= 0 {
+		mac := hmac.New(md5.New, secret)
+		mac.Write(b)
+		copy(b[offset:], mac.Sum(nil))
+	}
+}
+
+// verifyResponse checks the Response Authenticator and, if present or
+// required, the Message-Authenticator of a response to a request with the
+// given authenticator.
+func verifyResponse(b []byte, secret []byte, requestAuthenticator [authenticatorLength]byte, requireMessageAuthenticator bool) error {
+	length := int(binary.BigEndian.Uint16(b[2:4]))
+	b = b[:length]
+
+	h := md5.New() //nolint:gosec // RADIUS is defined in terms of MD5
+	h.Write(b[:4])
+	h.Write(requestAuthenticator[:])
+	h.Write(b[headerLength:])
+	h.Write(secret)
+	if subtle.ConstantTimeCompare(h.Sum(nil), b[4:headerLength]) != 1 {
+		return fmt.Errorf("%w: invalid response authenticator", ErrMalformedPacket)
+	}
+
+	offset := messageAuthenticatorOffset(b)
+	switch {
+	case offset < 0 && requireMessageAuthenticator:
+		return fmt.Errorf("%w: missing Message-Authenticator", ErrMalformedPacket)
+	case offset < 0:
+		return nil
+	}
+	signed := make([]byte, len(b))
+	copy(signed, b)
+	copy(signed[4:headerLength], requestAuthenticator[:])
+	clear(signed[offset : offset+authenticatorLength])
+	mac := hmac.New(md5.New, secret)
+	mac.Write(signed)
+	if !hmac.Equal(mac.Sum(nil), b[offset:offset+authenticatorLength]) {
+		return fmt.Errorf("%w: invalid Message-Authenticator", ErrMalformedPacket)
+	}
+	return nil
+}
+
+// messageAuthenticatorOffset returns the offset of the value of the
+// Message-Authenticator attribute of an encoded packet, -1 if it has none.
+func messageAuthenticatorOffset(b []byte) int {
+	for i := headerLength; i+2 <= len(b); {
+		length := int(b[i+1])
+		if length < 2 || i+length > len(b) {
+			return -1
+		}
+		if AttributeType(b[i]) == AttributeMessageAuthenticator && length == authenticatorLength+2 {
+			return i + 2
+		}
+		i += length
+	}
+	return -1
+}
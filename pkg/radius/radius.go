@@ -0,0 +1,207 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package radius
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"time"
+)
+
+// DefaultPort is the port of the RADIUS authentication service.
+const DefaultPort = "1812"
+
+// DefaultRetransmitInterval is the interval unanswered requests are resent
+// in.
+const DefaultRetransmitInterval = time.Second
+
+// defaultTimeout bounds exchanges whose context has no deadline.
+const defaultTimeout = 5 * time.Second
+
+// AuthType selects how the password is sent.
+type AuthType int
+
+// Password authentication types.
+const (
+	// AuthPAP sends the password hidden with the shared secret.
+	AuthPAP AuthType = iota
+	// AuthCHAP sends a CHAP response to a random challenge instead of the
+	// password.
+	AuthCHAP
+)
+
+// Request is an Access-Request for a user.
+type Request struct {
+	Username string
+	Password string
+	Type     AuthType
+	// Attributes are added to the request, such as the NAS-Identifier.
+	Attributes []Attribute
+}
+
+// Client sends requests to a RADIUS server.
+type Client struct {
+	// Address is the host and port of the server. The port defaults to
+	// DefaultPort.
+	Address string
+	// Secret is the secret shared with the server.
+	Secret []byte
+	// RequireMessageAuthenticator rejects responses without a
+	// Message-Authenticator, which servers should send to clients that send
+	// one.
+	RequireMessageAuthenticator bool
+	// RetransmitInterval is the interval unanswered requests are resent in,
+	// DefaultRetransmitInterval if zero.
+	RetransmitInterval time.Duration
+}
+
+// Authenticate sends an Access-Request and returns the Access-Accept of
+// the server. Rejections are reported as ErrAccessRejected along with the
+// Reply-Message of the server. The request is resent until a valid
+// response arrives or the context ends. Requests always carry a
+// Message-Authenticator.
+func (c *Client) Authenticate(ctx context.Context, req *Request) (*Packet, error) {
+	p, err := newAccessRequest(req, c.Secret)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.exchange(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.Code {
+	case CodeAccessAccept:
+		return resp, nil
+	case CodeAccessReject:
+		return nil, fmt.Errorf("%w: %s", ErrAccessRejected, resp.Get(AttributeReplyMessage))
+	case CodeAccessChallenge:
+		return nil, fmt.Errorf("%w: %s", ErrChallengeUnsupported, resp.Get(AttributeReplyMessage))
+	default:
+		return nil, fmt.Errorf("%w: unexpected code %d", ErrMalformedPacket, resp.Code)
+	}
+}
+
+func newAccessRequest(req *Request, secret []byte) (*Packet, error) {
+	var random [2 + 2*authenticatorLength]byte
+	if _, err := rand.Read(random[:]); err != nil {
+		return nil, err
+	}
+	p := &Packet{Code: CodeAccessRequest, Identifier: random[0]}
+	copy(p.Authenticator[:], random[2:])
+
+	// The Message-Authenticator goes first, so that responses cannot be
+	// forged by prefix collisions.
+	p.Add(AttributeMessageAuthenticator, make([]byte, authenticatorLength))
+	p.AddString(AttributeUserName, req.Username)
+	switch req.Type {
+	case AuthPAP:
+		hidden, err := hidePassword([]byte(req.Password), secret, p.Authenticator)
+		if err != nil {
+			return nil, err
+		}
+		p.Add(AttributeUserPassword, hidden)
+	case AuthCHAP:
+		challenge := random[2+authenticatorLength:]
+		p.Add(AttributeCHAPChallenge, challenge)
+		p.Add(AttributeCHAPPassword, chapPassword(random[1], []byte(req.Password), challenge))
+	default:
+		return nil, fmt.Errorf("%w: unknown authentication type %d", ErrInvalidAttribute, req.Type)
+	}
+	p.Attributes = append(p.Attributes, req.Attributes...)
+	return p, nil
+}
+
+// exchange sends a request and waits for the response to it, resending the
+// request while none arrives.
+func (c *Client) exchange(ctx context.Context, req *Packet) (*Packet, error) {
+	host, port, err := net.SplitHostPort(c.Address)
+	if err != nil {
+		host, port = c.Address, ""
+	}
+	if port == "" {
+		port = DefaultPort
+	}
+	if host == "" {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidAddress, c.Address)
+	}
+
+	b, err := req.encode()
+	if err != nil {
+		return nil, err
+	}
+	signRequest(b, c.Secret)
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultTimeout)
+		defer cancel()
+	}
+	deadline, _ := ctx.Deadline()
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "udp", net.JoinHostPort(host, port))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrNoResponse, err)
+	}
+	defer conn.Close() //nolint:errcheck
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.SetDeadline(time.Unix(1, 0))
+	})
+	defer stop()
+
+	interval := c.RetransmitInterval
+	if interval <= 0 {
+		interval = DefaultRetransmitInterval
+	}
+	buf := make([]byte, maxPacketLength)
+	for {
+		if _, err := conn.Write(b); err != nil {
+			return nil, c.responseError(ctx, err)
+		}
+
+		readDeadline := time.Now().Add(interval)
+		if deadline.Before(readDeadline) {
+			readDeadline = deadline
+		}
+		_ = conn.SetReadDeadline(readDeadline)
+		for {
+			n, err := conn.Read(buf)
+			if errors.Is(err, os.ErrDeadlineExceeded) && ctx.Err() == nil && time.Now().Before(deadline) {
+				break
+			}
+			if err != nil {
+				return nil, c.responseError(ctx, err)
+			}
+
+			// Responses that do not match the request are dropped.
+			if n < headerLength || buf[1] != req.Identifier {
+				continue
+			}
+			resp, err := parsePacket(buf[:n])
+			if err != nil {
+				continue
+			}
+			if err := verifyResponse(buf[:n], c.Secret, req.Authenticator, c.RequireMessageAuthenticator); err != nil {
+				continue
+			}
+			return resp, nil
+		}
+	}
+}
+
+// responseError wraps a failed read or write, reporting ended contexts as
+// such.
+func (c *Client) responseError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return fmt.Errorf("%w: %w", ErrNoResponse, ctx.Err())
+	}
+	if errors.Is(err, os.ErrDeadlineExceeded) {
+		return fmt.Errorf("%w: %w", ErrNoResponse, context.DeadlineExceeded)
+	}
+	return fmt.Errorf("%w: %w", ErrNoResponse, err)
+}
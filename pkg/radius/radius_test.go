@@ -0,0 +1,157 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package radius_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/u-bmc/u-bmc/pkg/radius"
+	"github.com/u-bmc/u-bmc/pkg/radius/radiustest"
+)
+
+const testSecret = "s3cret"
+
+func newTestServer(t *testing.T) *radiustest.Server {
+	t.Helper()
+	srv := radiustest.NewServer(testSecret, map[string]radiustest.User{
+		"alice": {
+			Password:   "alice-pw",
+			Attributes: []radiustest.Attribute{radiustest.VendorSpecific(9, 1, "shell:priv-lvl=15")},
+		},
+		"bob": {Password: strings.Repeat("long password ", 5)},
+	})
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func authenticate(t *testing.T, client *radius.Client, req *radius.Request, timeout time.Duration) (*radius.Packet, error) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(t.Context(), timeout)
+	defer cancel()
+	return client.Authenticate(ctx, req)
+}
+
+func TestAuthenticate(t *testing.T) {
+	srv := newTestServer(t)
+	client := &radius.Client{Address: srv.Addr, Secret: []byte(testSecret), RequireMessageAuthenticator: true}
+
+	tests := []struct {
+		name    string
+		req     radius.Request
+		wantErr error
+	}{
+		{name: "PAP", req: radius.Request{Username: "alice", Password: "alice-pw", Type: radius.AuthPAP}},
+		{name: "CHAP", req: radius.Request{Username: "alice", Password: "alice-pw", Type: radius.AuthCHAP}},
+		{name: "PAP password over several blocks", req: radius.Request{Username: "bob", Password: strings.Repeat("long password ", 5)}},
+		{name: "PAP wrong password", req: radius.Request{Username: "alice", Password: "alice-pw2"}, wantErr: radius.ErrAccessRejected},
+		{name: "CHAP wrong password", req: radius.Request{Username: "alice", Password: "bob-pw", Type: radius.AuthCHAP}, wantErr: radius.ErrAccessRejected},
+		{name: "unknown user", req: radius.Request{Username: "mallory", Password: "alice-pw"}, wantErr: radius.ErrAccessRejected},
+		{name: "password too long", req: radius.Request{Username: "alice", Password: strings.Repeat("x", 129)}, wantErr: radius.ErrInvalidAttribute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := authenticate(t, client, &tt.req, 5*time.Second)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Authenticate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if n := srv.Invalid(); n != 0 {
+		t.Errorf("server dropped %d requests with an invalid Message-Authenticator", n)
+	}
+}
+
+func TestAuthenticateAccept(t *testing.T) {
+	srv := newTestServer(t)
+	client := &radius.Client{Address: srv.Addr, Secret: []byte(testSecret)}
+
+	accept, err := authenticate(t, client, &radius.Request{
+		Username:   "alice",
+		Password:   "alice-pw",
+		Attributes: []radius.Attribute{{Type: radius.AttributeNASIdentifier, Value: []byte("bmc-rack4")}},
+	}, 5*time.Second)
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	if accept.Code != radius.CodeAccessAccept {
+		t.Errorf("code = %d, want Access-Accept", accept.Code)
+	}
+	if pairs := accept.VendorSpecific(9, 1); len(pairs) != 1 || string(pairs[0]) != "shell:priv-lvl=15" {
+		t.Errorf("Cisco-AVPair = %q", pairs)
+	}
+
+	reqs := srv.Requests()
+	if len(reqs) != 1 {
+		t.Fatalf("server received %d requests, want 1", len(reqs))
+	}
+	if got := string(reqs[0].Get(byte(radius.AttributeNASIdentifier))); got != "bmc-rack4" {
+		t.Errorf("NAS-Identifier = %q", got)
+	}
+	// The Message-Authenticator is the first attribute of the request.
+	if reqs[0].Attributes[0].Type != byte(radius.AttributeMessageAuthenticator) {
+		t.Errorf("first attribute = %d, want Message-Authenticator", reqs[0].Attributes[0].Type)
+	}
+}
+
+func TestAuthenticateVerifiesResponses(t *testing.T) {
+	const timeout = 300 * time.Millisecond
+
+	tests := []struct {
+		name    string
+		mode    radiustest.Mode
+		secret  string
+		require bool
+		wantErr error
+	}{
+		{name: "forged Response Authenticator", mode: radiustest.ModeForgeAuthenticator, secret: testSecret, wantErr: radius.ErrNoResponse},
+		{name: "client secret differs", mode: radiustest.ModeAnswer, secret: "other", wantErr: radius.ErrNoResponse},
+		{name: "Message-Authenticator optional", mode: radiustest.ModeNoMessageAuthenticator, secret: testSecret},
+		{name: "Message-Authenticator required", mode: radiustest.ModeNoMessageAuthenticator, secret: testSecret, require: true, wantErr: radius.ErrNoResponse},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newTestServer(t)
+			srv.SetMode(tt.mode)
+			client := &radius.Client{
+				Address:                     srv.Addr,
+				Secret:                      []byte(tt.secret),
+				RequireMessageAuthenticator: tt.require,
+				RetransmitInterval:          50 * time.Millisecond,
+			}
+			_, err := authenticate(t, client, &radius.Request{Username: "alice", Password: "alice-pw"}, timeout)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Authenticate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAuthenticateRetransmits(t *testing.T) {
+	srv := newTestServer(t)
+	srv.SetMode(radiustest.ModeSilent)
+	client := &radius.Client{Address: srv.Addr, Secret: []byte(testSecret), RetransmitInterval: 20 * time.Millisecond}
+
+	_, err := authenticate(t, client, &radius.Request{Username: "alice", Password: "alice-pw"}, 200*time.Millisecond)
+	if !errors.Is(err, radius.ErrNoResponse) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Authenticate() error = %v, want %v", err, radius.ErrNoResponse)
+	}
+
+	reqs := srv.Requests()
+	if len(reqs) < 3 {
+		t.Errorf("server received %d requests, want retransmissions", len(reqs))
+	}
+}
+
+func TestAuthenticateInvalidAddress(t *testing.T) {
+	client := &radius.Client{Address: ":1812", Secret: []byte(testSecret)}
+	if _, err := authenticate(t, client, &radius.Request{Username: "alice", Password: "alice-pw"}, time.Second); !errors.Is(err, radius.ErrInvalidAddress) {
+		t.Errorf("Authenticate() error = %v, want %v", err, radius.ErrInvalidAddress)
+	}
+}
@@ -0,0 +1,31 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package radiustest provides an in-process RADIUS server for testing code
+// that authenticates users with package radius, in the spirit of
+// net/http/httptest.
+//
+// # Overview
+//
+// A Server listens on a loopback UDP port and answers Access-Requests:
+//   - It drops requests whose Message-Authenticator is missing or does not
+//     verify with the shared secret, as RFC 3579 requires
+//   - It checks PAP passwords and CHAP responses against its users
+//   - It signs Access-Accept and Access-Reject responses with a Response
+//     Authenticator and a Message-Authenticator
+//
+// Its Mode makes it misbehave like a broken or hostile server: dropping
+// requests, forging the Response Authenticator or leaving out the
+// Message-Authenticator.
+//
+// # Basic Usage
+//
+//	srv := radiustest.NewServer("secret", map[string]radiustest.User{
+//		"alice": {
+//			Password:   "alice-pw",
+//			Attributes: []radiustest.Attribute{radiustest.VendorSpecific(9, 1, "shell:priv-lvl=15")},
+//		},
+//	})
+//	defer srv.Close()
+//
+//	client := &radius.Client{Address: srv.Addr, Secret: []byte("secret")}
+package radiustest
@@ -0,0 +1,321 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package radiustest
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/md5" //nolint:gosec // RADIUS is defined in terms of MD5
+	"encoding/binary"
+	"fmt"
+	"maps"
+	"net"
+	"slices"
+	"sync"
+)
+
+// Packet codes and attribute types (RFC 2865, RFC 2869).
+const (
+	codeAccessRequest = 1
+	codeAccessAccept  = 2
+	codeAccessReject  = 3
+
+	attributeUserName             = 1
+	attributeUserPassword         = 2
+	attributeCHAPPassword         = 3
+	attributeReplyMessage         = 18
+	attributeVendorSpecific       = 26
+	attributeCHAPChallenge        = 60
+	attributeMessageAuthenticator = 80
+
+	headerLength    = 20
+	maxPacketLength = 4096
+)
+
+// Mode selects how a server answers.
+type Mode int
+
+// Server modes.
+const (
+	// ModeAnswer answers requests as a correct server would.
+	ModeAnswer Mode = iota
+	// ModeSilent drops every request.
+	ModeSilent
+	// ModeForgeAuthenticator answers with a Response Authenticator computed
+	// with another secret. The Message-Authenticator is left intact, so that
+	// only the check of the Response Authenticator rejects the response.
+	ModeForgeAuthenticator
+	// ModeNoMessageAuthenticator answers without a Message-Authenticator.
+	ModeNoMessageAuthenticator
+)
+
+// Attribute is an attribute of a response.
+type Attribute struct {
+	Type  byte
+	Value []byte
+}
+
+// VendorSpecific returns a vendor-specific attribute holding a single
+// sub-attribute, such as a Cisco-AVPair.
+func VendorSpecific(vendorID uint32, vendorType byte, value string) Attribute {
+	b := binary.BigEndian.AppendUint32(nil, vendorID)
+	b = append(b, vendorType, byte(len(value)+2))
+	return Attribute{Type: attributeVendorSpecific, Value: append(b, value...)}
+}
+
+// User is a user known to a server.
+type User struct {
+	Password string
+	// Attributes are sent in the Access-Accept of the user.
+	Attributes []Attribute
+}
+
+// Request is an Access-Request a server received.
+type Request struct {
+	Username string
+	// CHAP is set for requests carrying a CHAP-Password rather than a
+	// User-Password.
+	CHAP bool
+	// Attributes holds all attributes of the request.
+	Attributes []Attribute
+}
+
+// Get returns the value of the first attribute of a type or nil.
+func (r *Request) Get(typ byte) []byte {
+	for _, attr := range r.Attributes {
+		if attr.Type == typ {
+			return attr.Value
+		}
+	}
+	return nil
+}
+
+// Server is a RADIUS server listening on a loopback UDP port.
+type Server struct {
+	// Addr is the host and port of the server.
+	Addr string
+
+	conn   net.PacketConn
+	secret []byte
+	done   chan struct{}
+
+	mu       sync.Mutex
+	users    map[string]User
+	mode     Mode
+	requests []Request
+	invalid  int
+}
+
+// NewServer starts a server with a shared secret and users. The caller
+// should call Close when finished, to shut it down.
+func NewServer(secret string, users map[string]User) *Server {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		panic(fmt.Sprintf("radiustest: failed to listen on a port: %v", err))
+	}
+
+	s := &Server{
+		Addr:   conn.LocalAddr().String(),
+		conn:   conn,
+		secret: []byte(secret),
+		done:   make(chan struct{}),
+		users:  users,
+	}
+	go s.serve()
+	return s
+}
+
+// SetMode changes how the server answers.
+func (s *Server) SetMode(mode Mode) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mode = mode
+}
+
+// SetUser adds or replaces a user.
+func (s *Server) SetUser(name string, user User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	users := maps.Clone(s.users)
+	if users == nil {
+		users = make(map[string]User)
+	}
+	users[name] = user
+	s.users = users
+}
+
+// Requests returns the valid requests received so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.requests)
+}
+
+// Invalid returns the number of requests dropped because they were
+// malformed or their Message-Authenticator did not verify.
+func (s *Server) Invalid() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.invalid
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	_ = s.conn.Close()
+	<-s.done
+}
+
+func (s *Server) serve() {
+	defer close(s.done)
+	buf := make([]byte, maxPacketLength)
+	for {
+		n, addr, err := s.conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		if resp := s.answer(buf[:n]); resp != nil {
+			_, _ = s.conn.WriteTo(resp, addr)
+		}
+	}
+}
+
+// answer returns the response to a request, nil if it is dropped.
+func (s *Server) answer(b []byte) []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	req, attrs, ok := s.parse(b)
+	if !ok {
+		s.invalid++
+		return nil
+	}
+	s.requests = append(s.requests, *req)
+	if s.mode == ModeSilent {
+		return nil
+	}
+
+	var authenticator [16]byte
+	copy(authenticator[:], b[4:headerLength])
+
+	user, known := s.users[req.Username]
+	accepted := known
+	if known {
+		if req.CHAP {
+			chap := req.Get(attributeCHAPPassword)
+			challenge := req.Get(attributeCHAPChallenge)
+			if challenge == nil {
+				challenge = authenticator[:]
+			}
+			sum := md5.Sum(slices.Concat(chap[:1], []byte(user.Password), challenge)) //nolint:gosec // CHAP is defined in terms of MD5
+			accepted = hmac.Equal(sum[:], chap[1:])
+		} else {
+			accepted = s.revealPassword(attrs[attributeUserPassword], authenticator) == user.Password
+		}
+	}
+
+	code := byte(codeAccessReject)
+	var replyAttrs []Attribute
+	if accepted {
+		code = codeAccessAccept
+		replyAttrs = user.Attributes
+	} else {
+		replyAttrs = []Attribute{{Type: attributeReplyMessage, Value: []byte("Authentication failed")}}
+	}
+	return s.response(code, b[1], authenticator, replyAttrs)
+}
+
+// parse decodes a request and checks its Message-Authenticator.
+func (s *Server) parse(b []byte) (*Request, map[byte][]byte, bool) {
+	if len(b) < headerLength || b[0] != codeAccessRequest {
+		return nil, nil, false
+	}
+	length := int(binary.BigEndian.Uint16(b[2:4]))
+	if length < headerLength || length > len(b) {
+		return nil, nil, false
+	}
+	b = b[:length]
+
+	req := &Request{}
+	attrs := make(map[byte][]byte)
+	macOffset := -1
+	for i := headerLength; i < len(b); {
+		if i+2 > len(b) || b[i+1] < 2 || i+int(b[i+1]) > len(b) {
+			return nil, nil, false
+		}
+		typ, value := b[i], b[i+2:i+int(b[i+1])]
+		if typ == attributeMessageAuthenticator && len(value) == md5.Size {
+			macOffset = i + 2
+		}
+		if _, ok := attrs[typ]; !ok {
+			attrs[typ] = value
+		}
+		req.Attributes = append(req.Attributes, Attribute{Type: typ, Value: slices.Clone(value)})
+		i += int(b[i+1])
+	}
+	if macOffset < 0 {
+		return nil, nil, false
+	}
+
+	signed := slices.Clone(b)
+	clear(signed[macOffset : macOffset+md5.Size])
+	mac := hmac.New(md5.New, s.secret)
+	mac.Write(signed)
+	if !hmac.Equal(mac.Sum(nil), b[macOffset:macOffset+md5.Size]) {
+		return nil, nil, false
+	}
+
+	req.Username = string(attrs[attributeUserName])
+	req.CHAP = len(attrs[attributeCHAPPassword]) == 1+md5.Size
+	if !req.CHAP && (len(attrs[attributeUserPassword]) == 0 || len(attrs[attributeUserPassword])%md5.Size != 0) {
+		return nil, nil, false
+	}
+	return req, attrs, true
+}
+
+// revealPassword decrypts a User-Password value (RFC 2865 section 5.2).
+func (s *Server) revealPassword(hidden []byte, authenticator [16]byte) string {
+	password := make([]byte, 0, len(hidden))
+	prev := authenticator[:]
+	for i := 0; i < len(hidden); i += md5.Size {
+		pad := md5.Sum(slices.Concat(s.secret, prev)) //nolint:gosec // RADIUS is defined in terms of MD5
+		for j := range md5.Size {
+			password = append(password, hidden[i+j]^pad[j])
+		}
+		prev = hidden[i : i+md5.Size]
+	}
+	return string(bytes.TrimRight(password, "\x00"))
+}
+
+// response encodes and signs a response to the request with the given
+// identifier and authenticator.
+func (s *Server) response(code, identifier byte, requestAuthenticator [16]byte, attrs []Attribute) []byte {
+	b := []byte{code, identifier, 0, 0}
+	b = append(b, requestAuthenticator[:]...)
+	macOffset := -1
+	if s.mode != ModeNoMessageAuthenticator {
+		b = append(b, attributeMessageAuthenticator, 2+md5.Size)
+		macOffset = len(b)
+		b = append(b, make([]byte, md5.Size)...)
+	}
+	for _, attr := range attrs {
+		b = append(b, attr.Type, byte(len(attr.Value)+2))
+		b = append(b, attr.Value...)
+	}
+	binary.BigEndian.PutUint16(b[2:4], uint16(len(b))) //nolint:gosec // bounded by the attributes of the tests
+
+	// The Message-Authenticator is computed over the packet with the
+	// Request Authenticator, before the Response Authenticator replaces it
+	// (RFC 3579 section 3.2).
+	if macOffset >= 0 {
+		mac := hmac.New(md5.New, s.secret)
+		mac.Write(b)
+		copy(b[macOffset:], mac.Sum(nil))
+	}
+	secret := s.secret
+	if s.mode == ModeForgeAuthenticator {
+		secret = append(slices.Clone(secret), "-forged"...)
+	}
+	sum := md5.Sum(append(slices.Clone(b), secret...)) //nolint:gosec // RADIUS is defined in terms of MD5
+	copy(b[4:headerLength], sum[:])
+	return b
+}
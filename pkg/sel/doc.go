@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package sel provides encoding and decoding of IPMI System Event Log (SEL)
+// records. It is a stateless building block shared by the services that
+// produce, store and serve event log entries; it does not store records itself.
+//
+// # Overview
+//
+// A SEL record is a fixed 16-byte structure defined by the IPMI v2.0
+// specification (section 32). This package implements the system event record
+// type (0x02), which carries:
+//   - A 16-bit record ID assigned by the event log owner
+//   - A 32-bit timestamp in seconds since the Unix epoch
+//   - The generator ID of the entity that raised the event
+//   - The sensor type and sensor number the event refers to
+//   - The event direction, event/reading type and three bytes of event data
+//
+// OEM record types (0xC0-0xFF) are not supported.
+//
+// # Basic Usage
+//
+// Building a threshold event for an upper critical temperature excursion:
+//
+//	rec := sel.ThresholdEvent(sel.SensorTypeTemperature, 0x12, sel.OffsetUpperCriticalGoingHigh, false)
+//	rec.Timestamp = time.Now()
+//	data, err := rec.MarshalBinary()
+//	if err != nil {
+//		return err
+//	}
+//
+// Decoding a record read from an event log:
+//
+//	var rec sel.Record
+//	if err := rec.UnmarshalBinary(data); err != nil {
+//		return err
+//	}
+//	fmt.Printf("record %d: sensor 0x%02x offset %d\n", rec.RecordID, rec.SensorNumber, rec.Offset())
+//
+// # Event Data
+//
+// The meaning of the event data bytes depends on the event/reading type. For
+// threshold events, bits [7:4] of event data 1 indicate whether event data 2
+// and 3 carry the trigger reading and threshold as raw sensor values. Since
+// raw values depend on the linearization published in the sensor data record
+// of the sensor, SetThresholdData is typically called by whoever owns the SDR
+// repository rather than by the event producer.
+package sel
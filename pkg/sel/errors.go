@@ -0,0 +1,12 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package sel
+
+import "errors"
+
+var (
+	// ErrInvalidLength indicates that an encoded record does not have the size of a SEL record.
+	ErrInvalidLength = errors.New("invalid SEL record length")
+	// ErrUnsupportedRecordType indicates that an encoded record is not a system event record.
+	ErrUnsupportedRecordType = errors.New("unsupported SEL record type")
+)
@@ -0,0 +1,214 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package sel
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Record layout constants.
+const (
+	// RecordSize is the size of an encoded SEL record in bytes.
+	RecordSize = 16
+	// RecordTypeSystemEvent is the record type of standard system event records.
+	RecordTypeSystemEvent uint8 = 0x02
+	// GeneratorBMC is the generator ID of events raised by the BMC itself
+	// (slave address 0x20, IPMB LUN 0, channel 0).
+	GeneratorBMC uint16 = 0x0020
+	// EvMRevision is the event message format revision for IPMI v2.0.
+	EvMRevision uint8 = 0x04
+	// SensorNumberUnspecified marks a record whose sensor number has not been
+	// resolved against a sensor data record.
+	SensorNumberUnspecified uint8 = 0xFF
+	// TimestampUnspecified is the timestamp of records logged without a valid time.
+	TimestampUnspecified uint32 = 0xFFFFFFFF
+)
+
+// Special record IDs used by Get SEL Entry.
+const (
+	// RecordIDFirst requests the first record of the event log.
+	RecordIDFirst uint16 = 0x0000
+	// RecordIDLast requests the last record of the event log and marks the end of iteration.
+	RecordIDLast uint16 = 0xFFFF
+)
+
+// Sensor types (IPMI v2.0 table 42-3) used by BMC generated events.
+const (
+	SensorTypeTemperature               uint8 = 0x01
+	SensorTypeVoltage                   uint8 = 0x02
+	SensorTypeCurrent                   uint8 = 0x03
+	SensorTypeFan                       uint8 = 0x04
+	SensorTypePowerUnit                 uint8 = 0x09
+	SensorTypeOtherUnits                uint8 = 0x0B
+	SensorTypeSystemACPIPowerState      uint8 = 0x22
+	SensorTypeManagementSubsystemHealth uint8 = 0x28
+)
+
+// Event/reading type codes (IPMI v2.0 table 42-1).
+const (
+	EventTypeThreshold      uint8 = 0x01
+	EventTypeSensorSpecific uint8 = 0x6F
+)
+
+// Threshold event offsets (IPMI v2.0 table 42-2).
+const (
+	OffsetLowerNonCriticalGoingLow  uint8 = 0x00
+	OffsetLowerCriticalGoingLow     uint8 = 0x02
+	OffsetUpperNonCriticalGoingHigh uint8 = 0x07
+	OffsetUpperCriticalGoingHigh    uint8 = 0x09
+)
+
+// Sensor-specific event offsets (IPMI v2.0 table 42-3) used by BMC generated events.
+const (
+	// OffsetPowerUnitPowerOff is the Power Unit "Power Off / Power Down" offset.
+	OffsetPowerUnitPowerOff uint8 = 0x00
+	// OffsetPowerUnitFailure is the Power Unit "Power Unit Failure detected" offset.
+	OffsetPowerUnitFailure uint8 = 0x06
+	// OffsetPowerUnitPredictiveFailure is the Power Unit "Predictive Failure" offset.
+	OffsetPowerUnitPredictiveFailure uint8 = 0x07
+	// OffsetACPIWorking is the ACPI power state "S0/G0 working" offset.
+	OffsetACPIWorking uint8 = 0x00
+	// OffsetACPISoftOff is the ACPI power state "S5/G2 soft-off" offset.
+	OffsetACPISoftOff uint8 = 0x05
+	// OffsetACPIUnknown is the ACPI power state "Unknown" offset.
+	OffsetACPIUnknown uint8 = 0x0E
+	// OffsetManagementControllerOffline is the Management Subsystem Health
+	// "Management Controller off-line" offset.
+	OffsetManagementControllerOffline uint8 = 0x02
+	// OffsetManagementControllerUnavailable is the Management Subsystem Health
+	// "Management Controller unavailable" offset.
+	OffsetManagementControllerUnavailable uint8 = 0x03
+)
+
+// Event data 1 fields.
+const (
+	eventOffsetMask uint8 = 0x0F
+	// eventData2Trigger indicates a trigger reading in event data 2 of threshold events.
+	eventData2Trigger uint8 = 0x40
+	// eventData3Threshold indicates a trigger threshold in event data 3 of threshold events.
+	eventData3Threshold uint8 = 0x10
+	// eventDataUnspecified is the value of unused event data 2 and 3 bytes.
+	eventDataUnspecified uint8 = 0xFF
+	deassertionBit       uint8 = 0x80
+	eventTypeMask        uint8 = 0x7F
+)
+
+// Record is an IPMI system event record.
+type Record struct {
+	// RecordID is assigned by the event log owner when the record is stored.
+	RecordID uint16
+	// Timestamp is the time the event was logged. The zero time encodes as
+	// TimestampUnspecified.
+	Timestamp time.Time
+	// GeneratorID identifies the controller or software that raised the event.
+	GeneratorID uint16
+	// SensorType is the type of the sensor that raised the event.
+	SensorType uint8
+	// SensorNumber is the number of the sensor in the SDR repository.
+	SensorNumber uint8
+	// Deassertion is true for deassertion events.
+	Deassertion bool
+	// EventType is the event/reading type code.
+	EventType uint8
+	// EventData holds the three event data bytes.
+	EventData [3]byte
+}
+
+// ThresholdEvent returns a threshold-based event record for the given offset.
+// The trigger reading and threshold are left unspecified; see SetThresholdData.
+func ThresholdEvent(sensorType, sensorNumber, offset uint8, deassertion bool) Record {
+	return Record{
+		GeneratorID:  GeneratorBMC,
+		SensorType:   sensorType,
+		SensorNumber: sensorNumber,
+		Deassertion:  deassertion,
+		EventType:    EventTypeThreshold,
+		EventData:    [3]byte{offset & eventOffsetMask, eventDataUnspecified, eventDataUnspecified},
+	}
+}
+
+// SensorSpecificEvent returns a sensor-specific discrete event record for the given offset.
+func SensorSpecificEvent(sensorType, sensorNumber, offset uint8, deassertion bool) Record {
+	return Record{
+		GeneratorID:  GeneratorBMC,
+		SensorType:   sensorType,
+		SensorNumber: sensorNumber,
+		Deassertion:  deassertion,
+		EventType:    EventTypeSensorSpecific,
+		EventData:    [3]byte{offset & eventOffsetMask, eventDataUnspecified, eventDataUnspecified},
+	}
+}
+
+// Offset returns the event offset encoded in event data 1.
+func (r *Record) Offset() uint8 {
+	return r.EventData[0] & eventOffsetMask
+}
+
+// SetThresholdData stores the raw trigger reading and threshold of a
+// threshold event in event data 2 and 3.
+func (r *Record) SetThresholdData(reading, threshold uint8) {
+	r.EventData[0] = eventData2Trigger | eventData3Threshold | r.Offset()
+	r.EventData[1] = reading
+	r.EventData[2] = threshold
+}
+
+// MarshalBinary encodes the record in the 16-byte SEL record format.
+func (r *Record) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, RecordSize)
+	binary.LittleEndian.PutUint16(buf[0:2], r.RecordID)
+	buf[2] = RecordTypeSystemEvent
+	binary.LittleEndian.PutUint32(buf[3:7], Timestamp(r.Timestamp))
+	binary.LittleEndian.PutUint16(buf[7:9], r.GeneratorID)
+	buf[9] = EvMRevision
+	buf[10] = r.SensorType
+	buf[11] = r.SensorNumber
+	buf[12] = r.EventType & eventTypeMask
+	if r.Deassertion {
+		buf[12] |= deassertionBit
+	}
+	copy(buf[13:16], r.EventData[:])
+
+	return buf, nil
+}
+
+// UnmarshalBinary decodes a 16-byte system event record.
+func (r *Record) UnmarshalBinary(data []byte) error {
+	if len(data) != RecordSize {
+		return fmt.Errorf("%w: got %d bytes, want %d", ErrInvalidLength, len(data), RecordSize)
+	}
+	if data[2] != RecordTypeSystemEvent {
+		return fmt.Errorf("%w: 0x%02x", ErrUnsupportedRecordType, data[2])
+	}
+
+	*r = Record{
+		RecordID:     binary.LittleEndian.Uint16(data[0:2]),
+		GeneratorID:  binary.LittleEndian.Uint16(data[7:9]),
+		SensorType:   data[10],
+		SensorNumber: data[11],
+		Deassertion:  data[12]&deassertionBit != 0,
+		EventType:    data[12] & eventTypeMask,
+	}
+	if ts := binary.LittleEndian.Uint32(data[3:7]); ts != TimestampUnspecified {
+		r.Timestamp = time.Unix(int64(ts), 0).UTC()
+	}
+	copy(r.EventData[:], data[13:16])
+
+	return nil
+}
+
+// Timestamp converts a time to the 32-bit IPMI timestamp format, seconds since
+// the Unix epoch. The zero time and times outside the representable range
+// encode as TimestampUnspecified.
+func Timestamp(t time.Time) uint32 {
+	if t.IsZero() {
+		return TimestampUnspecified
+	}
+	secs := t.Unix()
+	if secs <= 0 || secs >= math.MaxUint32 {
+		return TimestampUnspecified
+	}
+	return uint32(secs)
+}
@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package tacacs provides a minimal TACACS+ client (RFC 8907) for
+// authenticating and authorizing users against a TACACS+ server, without
+// depending on a full-featured TACACS+ library.
+//
+// # Overview
+//
+// The client supports:
+//   - Authentication of logins with PAP, CHAP or the ASCII type, answering
+//     the username and password prompts of the server
+//   - Authorization requests, returning the attribute value pairs the server
+//     authorizes a user with, such as its privilege level
+//   - Obfuscation of packet bodies with the shared key
+//
+// Every request runs in a session on its own connection; single-connection
+// mode, accounting and the TLS transport of newer drafts are not supported.
+//
+// # Basic Usage
+//
+// Checking the password of a user and asking for its privilege level:
+//
+//	client := &tacacs.Client{Address: "tacacs1.example.com", Secret: []byte(key)}
+//	req := &tacacs.Request{Username: username, Password: password, Port: "https", RemoteAddress: sourceIP}
+//
+//	if err := client.Authenticate(ctx, req); errors.Is(err, tacacs.ErrAuthenticationFailed) {
+//		return errors.New("wrong password")
+//	} else if err != nil {
+//		return err
+//	}
+//	args, err := client.Authorize(ctx, req, []string{"service=shell", "cmd="})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(args) // [service=shell cmd= priv-lvl=15]
+//
+// # Error Handling
+//
+// Errors wrap one of the package's sentinel errors. ErrAuthenticationFailed
+// and ErrAuthorizationFailed report denied requests. ErrConnectionFailed
+// reports a server that could not be reached in time, ErrServerError one
+// that reported an error, and ErrMalformedPacket one that sent an invalid
+// packet, typically because it uses another key. Callers typically answer
+// these three by trying another server.
+//
+// # Testing
+//
+// Package tacacstest provides an in-process server to run the client and
+// code built on it against.
+package tacacs
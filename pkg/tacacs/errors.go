@@ -0,0 +1,20 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tacacs
+
+import "errors"
+
+var (
+	// ErrConnectionFailed indicates that the server could not be reached or the connection broke.
+	ErrConnectionFailed = errors.New("TACACS+ connection failed")
+	// ErrMalformedPacket indicates that the server sent a packet that is not valid TACACS+, or that was obfuscated with another key.
+	ErrMalformedPacket = errors.New("malformed TACACS+ packet")
+	// ErrInvalidRequest indicates that a request field is too long to be sent.
+	ErrInvalidRequest = errors.New("invalid TACACS+ request")
+	// ErrAuthenticationFailed indicates that the server rejected the credentials.
+	ErrAuthenticationFailed = errors.New("TACACS+ authentication failed")
+	// ErrAuthorizationFailed indicates that the server denied the authorization request.
+	ErrAuthorizationFailed = errors.New("TACACS+ authorization failed")
+	// ErrServerError indicates that the server reported an error or asked to use another server.
+	ErrServerError = errors.New("TACACS+ server error")
+)
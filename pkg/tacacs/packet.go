@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tacacs
+
+import (
+	"crypto/md5" //nolint:gosec // TACACS+ obfuscation is defined in terms of MD5
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Packet header fields (RFC 8907 section 4.1).
+const (
+	versionDefault = 0xc0
+	versionOne     = 0xc1
+
+	typeAuthentication = 0x01
+	typeAuthorization  = 0x02
+
+	flagUnencrypted = 0x01
+
+	headerLength = 12
+	// maxBodyLength bounds the size of a packet read from a server.
+	maxBodyLength = 1 << 16
+)
+
+// header is the header of a packet.
+type header struct {
+	version   byte
+	typ       byte
+	seqNo     byte
+	flags     byte
+	sessionID uint32
+	length    uint32
+}
+
+func (h header) encode() []byte {
+	b := []byte{h.version, h.typ, h.seqNo, h.flags}
+	b = binary.BigEndian.AppendUint32(b, h.sessionID)
+	return binary.BigEndian.AppendUint32(b, h.length)
+}
+
+// obfuscate applies the MD5 pseudo-pad of RFC 8907 section 4.5 to body,
+// which both obfuscates and deobfuscates it.
+func obfuscate(body []byte, h header, key []byte) {
+	if len(key) == 0 {
+		return
+	}
+	var prefix []byte
+	prefix = binary.BigEndian.AppendUint32(prefix, h.sessionID)
+	prefix = append(prefix, key...)
+	prefix = append(prefix, h.version, h.seqNo)
+
+	var pad []byte
+	for i := 0; i < len(body); i++ {
+		if i%md5.Size == 0 {
+			sum := md5.New() //nolint:gosec // TACACS+ obfuscation is defined in terms of MD5
+			sum.Write(prefix)
+			sum.Write(pad)
+			pad = sum.Sum(nil)
+		}
+		body[i] ^= pad[i%md5.Size]
+	}
+}
+
+// writePacket obfuscates and sends a packet.
+func writePacket(w io.Writer, h header, body []byte, key []byte) error {
+	if len(key) == 0 {
+		h.flags |= flagUnencrypted
+	}
+	h.length = uint32(len(body)) //nolint:gosec // bodies are built from length-checked fields
+	b := append(h.encode(), body...)
+	obfuscate(b[headerLength:], h, key)
+	_, err := w.Write(b)
+	return err
+}
+
+// readPacket reads and deobfuscates a packet.
+func readPacket(r io.Reader, key []byte) (header, []byte, error) {
+	var b [headerLength]byte
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		return header{}, nil, err
+	}
+	h := header{
+		version:   b[0],
+		typ:       b[1],
+		seqNo:     b[2],
+		flags:     b[3],
+		sessionID: binary.BigEndian.Uint32(b[4:8]),
+		length:    binary.BigEndian.Uint32(b[8:12]),
+	}
+	if h.version&0xf0 != versionDefault&0xf0 {
+		return header{}, nil, fmt.Errorf("%w: unsupported version 0x%02x", ErrMalformedPacket, h.version)
+	}
+	if h.length > maxBodyLength {
+		return header{}, nil, fmt.Errorf("%w: body of %d bytes exceeds the limit", ErrMalformedPacket, h.length)
+	}
+
+	body := make([]byte, h.length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return header{}, nil, err
+	}
+	if h.flags&flagUnencrypted == 0 {
+		obfuscate(body, h, key)
+	} else if len(key) != 0 {
+		return header{}, nil, fmt.Errorf("%w: unobfuscated packet", ErrMalformedPacket)
+	}
+	return h, body, nil
+}
+
+// fields returns the concatenation of fields after checking that each fits
+// its one-octet length field.
+func fields(values ...string) ([]byte, error) {
+	var b []byte
+	for _, v := range values {
+		if len(v) > 0xff {
+			return nil, fmt.Errorf("%w: field of %d bytes exceeds 255 bytes", ErrInvalidRequest, len(v))
+		}
+		b = append(b, v...)
+	}
+	return b, nil
+}
+
+// cut splits the first n bytes off b.
+func cut(b []byte, n int) ([]byte, []byte, error) {
+	if n > len(b) {
+		return nil, nil, fmt.Errorf("%w: truncated body", ErrMalformedPacket)
+	}
+	return b[:n], b[n:], nil
+}
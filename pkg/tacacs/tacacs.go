@@ -0,0 +1,327 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tacacs
+
+import (
+	"bufio"
+	"context"
+	"crypto/md5" //nolint:gosec // CHAP is defined in terms of MD5
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+// DefaultPort is the port of the TACACS+ service.
+const DefaultPort = "49"
+
+// defaultTimeout bounds sessions whose context has no deadline.
+const defaultTimeout = 5 * time.Second
+
+// AuthenType selects how the password is sent.
+type AuthenType byte
+
+// Authentication types (RFC 8907 section 5.1).
+const (
+	// AuthenTypeASCII answers the prompts of the server for the username and
+	// password.
+	AuthenTypeASCII AuthenType = 0x01
+	// AuthenTypePAP sends the password in the start packet.
+	AuthenTypePAP AuthenType = 0x02
+	// AuthenTypeCHAP sends a CHAP response to a random challenge instead of
+	// the password.
+	AuthenTypeCHAP AuthenType = 0x03
+)
+
+// Authentication and authorization packet fields (RFC 8907 sections 5 and
+// 6).
+const (
+	actionLogin            = 0x01
+	serviceLogin           = 0x01
+	authenMethodTACACSPlus = 0x06
+
+	authenStatusPass    = 0x01
+	authenStatusFail    = 0x02
+	authenStatusGetData = 0x03
+	authenStatusGetUser = 0x04
+	authenStatusGetPass = 0x05
+
+	authorStatusPassAdd  = 0x01
+	authorStatusPassRepl = 0x02
+	authorStatusFail     = 0x10
+
+	// maxPrompts bounds the prompts answered in an ASCII login.
+	maxPrompts = 4
+	// chapChallengeLength is the length of the random CHAP challenge.
+	chapChallengeLength = 16
+)
+
+// Request identifies a user and the client port it logs in on.
+type Request struct {
+	Username string
+	// Password is the password checked by Authenticate.
+	Password string
+	// Type is the authentication type, PAP if zero.
+	Type AuthenType
+	// Port names the client port the user logs in on, such as "https".
+	Port string
+	// RemoteAddress is the address the user logs in from.
+	RemoteAddress string
+	// PrivLevel is the privilege level requested.
+	PrivLevel byte
+}
+
+// Client sends requests to a TACACS+ server. Every request uses a session
+// on its own connection.
+type Client struct {
+	// Address is the host and port of the server. The port defaults to
+	// DefaultPort.
+	Address string
+	// Secret is the key packet bodies are obfuscated with. Without one,
+	// packets are sent in the clear.
+	Secret []byte
+}
+
+// session is an authentication or authorization session on a connection.
+type session struct {
+	conn   net.Conn
+	r      *bufio.Reader
+	key    []byte
+	header header
+}
+
+// Authenticate checks the password of a user. Rejections are reported as
+// ErrAuthenticationFailed along with the message of the server.
+func (c *Client) Authenticate(ctx context.Context, req *Request) error {
+	authenType := req.Type
+	if authenType == 0 {
+		authenType = AuthenTypePAP
+	}
+
+	var data []byte
+	version := byte(versionOne)
+	switch authenType {
+	case AuthenTypeASCII:
+		version = versionDefault
+	case AuthenTypePAP:
+		data = []byte(req.Password)
+	case AuthenTypeCHAP:
+		var random [1 + chapChallengeLength]byte
+		if _, err := rand.Read(random[:]); err != nil {
+			return err
+		}
+		h := md5.New() //nolint:gosec // CHAP is defined in terms of MD5
+		h.Write(random[:1])
+		h.Write([]byte(req.Password))
+		h.Write(random[1:])
+		data = h.Sum(random[:])
+	default:
+		return fmt.Errorf("%w: unknown authentication type %d", ErrInvalidRequest, authenType)
+	}
+
+	strs, err := fields(req.Username, req.Port, req.RemoteAddress, string(data))
+	if err != nil {
+		return err
+	}
+	body := []byte{actionLogin, req.PrivLevel, byte(authenType), serviceLogin,
+		byte(len(req.Username)), byte(len(req.Port)), byte(len(req.RemoteAddress)), byte(len(data))}
+	body = append(body, strs...)
+
+	return c.session(ctx, version, typeAuthentication, func(s *session) error {
+		reply, err := s.exchange(body)
+		for prompts := 0; ; prompts++ {
+			if err != nil {
+				return err
+			}
+			if len(reply) < 6 {
+				return fmt.Errorf("%w: short authentication reply", ErrMalformedPacket)
+			}
+			status := reply[0]
+			msgLen, dataLen := int(binary.BigEndian.Uint16(reply[2:4])), int(binary.BigEndian.Uint16(reply[4:6]))
+			msg, _, err := cut(reply[6:], msgLen)
+			if err != nil {
+				return err
+			}
+			if 6+msgLen+dataLen != len(reply) {
+				return fmt.Errorf("%w: authentication reply length mismatch", ErrMalformedPacket)
+			}
+
+			var answer string
+			switch status {
+			case authenStatusPass:
+				return nil
+			case authenStatusFail:
+				return fmt.Errorf("%w: %s", ErrAuthenticationFailed, msg)
+			case authenStatusGetUser:
+				answer = req.Username
+			case authenStatusGetPass, authenStatusGetData:
+				answer = req.Password
+			default:
+				return fmt.Errorf("%w: authentication status 0x%02x: %s", ErrServerError, status, msg)
+			}
+			if authenType != AuthenTypeASCII || prompts == maxPrompts {
+				return fmt.Errorf("%w: unexpected prompt %q", ErrServerError, msg)
+			}
+
+			// A CONTINUE packet carrying the answer as its user message.
+			if len(answer) > 0xffff {
+				return fmt.Errorf("%w: answer too long", ErrInvalidRequest)
+			}
+			cont := binary.BigEndian.AppendUint16(nil, uint16(len(answer))) //nolint:gosec // checked above
+			cont = append(cont, 0, 0, 0)
+			cont = append(cont, answer...)
+			reply, err = s.exchange(cont)
+		}
+	})
+}
+
+// Authorize asks the server to authorize a user, sending the attribute
+// value pairs of args, such as "service=shell". It returns the pairs the
+// user is authorized with, the ones the server added to args or replaced
+// them with. Denials are reported as ErrAuthorizationFailed along with the
+// message of the server.
+func (c *Client) Authorize(ctx context.Context, req *Request, args []string) ([]string, error) {
+	if len(args) > 0xff {
+		return nil, fmt.Errorf("%w: %d arguments exceed 255", ErrInvalidRequest, len(args))
+	}
+	authenType := req.Type
+	if authenType == 0 {
+		authenType = AuthenTypePAP
+	}
+
+	strs, err := fields(append([]string{req.Username, req.Port, req.RemoteAddress}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	body := []byte{authenMethodTACACSPlus, req.PrivLevel, byte(authenType), serviceLogin,
+		byte(len(req.Username)), byte(len(req.Port)), byte(len(req.RemoteAddress)), byte(len(args))}
+	for _, arg := range args {
+		body = append(body, byte(len(arg)))
+	}
+	body = append(body, strs...)
+
+	var result []string
+	err = c.session(ctx, versionDefault, typeAuthorization, func(s *session) error {
+		resp, err := s.exchange(body)
+		if err != nil {
+			return err
+		}
+		if len(resp) < 6 {
+			return fmt.Errorf("%w: short authorization response", ErrMalformedPacket)
+		}
+		status, argCount := resp[0], int(resp[1])
+		msgLen, dataLen := int(binary.BigEndian.Uint16(resp[2:4])), int(binary.BigEndian.Uint16(resp[4:6]))
+		lens, rest, err := cut(resp[6:], argCount)
+		if err != nil {
+			return err
+		}
+		msg, rest, err := cut(rest, msgLen)
+		if err != nil {
+			return err
+		}
+		if _, rest, err = cut(rest, dataLen); err != nil {
+			return err
+		}
+
+		returned := make([]string, 0, argCount)
+		for _, n := range lens {
+			var arg []byte
+			if arg, rest, err = cut(rest, int(n)); err != nil {
+				return err
+			}
+			returned = append(returned, string(arg))
+		}
+
+		switch status {
+		case authorStatusPassAdd:
+			result = append(append(result, args...), returned...)
+			return nil
+		case authorStatusPassRepl:
+			result = returned
+			return nil
+		case authorStatusFail:
+			return fmt.Errorf("%w: %s", ErrAuthorizationFailed, msg)
+		default:
+			return fmt.Errorf("%w: authorization status 0x%02x: %s", ErrServerError, status, msg)
+		}
+	})
+	return result, err
+}
+
+// session opens a connection and runs fn in a new session on it.
+func (c *Client) session(ctx context.Context, version, typ byte, fn func(*session) error) error {
+	host, port, err := net.SplitHostPort(c.Address)
+	if err != nil {
+		host, port = c.Address, ""
+	}
+	if port == "" {
+		port = DefaultPort
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultTimeout)
+		defer cancel()
+	}
+	deadline, _ := ctx.Deadline()
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", net.JoinHostPort(host, port))
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrConnectionFailed, err)
+	}
+	defer conn.Close() //nolint:errcheck
+	_ = conn.SetDeadline(deadline)
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.SetDeadline(time.Unix(1, 0))
+	})
+	defer stop()
+
+	var id [4]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return err
+	}
+	s := &session{
+		conn: conn,
+		r:    bufio.NewReader(conn),
+		key:  c.Secret,
+		header: header{
+			version:   version,
+			typ:       typ,
+			sessionID: binary.BigEndian.Uint32(id[:]),
+		},
+	}
+	if err := fn(s); err != nil {
+		if ctx.Err() != nil && errors.Is(err, ErrConnectionFailed) {
+			return fmt.Errorf("%w: %w", err, ctx.Err())
+		}
+		return err
+	}
+	return nil
+}
+
+// exchange sends the next packet of the session and returns the body of
+// the reply.
+func (s *session) exchange(body []byte) ([]byte, error) {
+	if s.header.seqNo >= 0xfe {
+		return nil, fmt.Errorf("%w: sequence number exhausted", ErrServerError)
+	}
+	s.header.seqNo++
+	if err := writePacket(s.conn, s.header, body, s.key); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrConnectionFailed, err)
+	}
+
+	h, reply, err := readPacket(s.r, s.key)
+	switch {
+	case errors.Is(err, ErrMalformedPacket):
+		return nil, err
+	case err != nil:
+		return nil, fmt.Errorf("%w: %w", ErrConnectionFailed, err)
+	case h.typ != s.header.typ || h.sessionID != s.header.sessionID || h.seqNo != s.header.seqNo+1:
+		return nil, fmt.Errorf("%w: reply does not match the session", ErrMalformedPacket)
+	}
+	s.header.seqNo = h.seqNo
+	return reply, nil
+}
@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tacacs_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/u-bmc/u-bmc/pkg/tacacs"
+	"github.com/u-bmc/u-bmc/pkg/tacacs/tacacstest"
+)
+
+const testKey = "tac-key"
+
+func newTestServer(t *testing.T, key string) *tacacstest.Server {
+	t.Helper()
+	srv := tacacstest.NewServer(key, map[string]tacacstest.User{
+		"alice": {Password: "alice-pw", Args: []string{"priv-lvl=15"}},
+		"bob":   {Password: "bob-pw"},
+	})
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func testContext(t *testing.T) context.Context {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func TestAuthenticate(t *testing.T) {
+	srv := newTestServer(t, testKey)
+	client := &tacacs.Client{Address: srv.Addr, Secret: []byte(testKey)}
+
+	tests := []struct {
+		name    string
+		req     tacacs.Request
+		wantErr error
+	}{
+		{name: "PAP", req: tacacs.Request{Username: "alice", Password: "alice-pw", Type: tacacs.AuthenTypePAP}},
+		{name: "PAP by default", req: tacacs.Request{Username: "alice", Password: "alice-pw"}},
+		{name: "CHAP", req: tacacs.Request{Username: "alice", Password: "alice-pw", Type: tacacs.AuthenTypeCHAP}},
+		{name: "ASCII", req: tacacs.Request{Username: "alice", Password: "alice-pw", Type: tacacs.AuthenTypeASCII}},
+		{name: "PAP wrong password", req: tacacs.Request{Username: "alice", Password: "bob-pw"}, wantErr: tacacs.ErrAuthenticationFailed},
+		{name: "CHAP wrong password", req: tacacs.Request{Username: "alice", Password: "bob-pw", Type: tacacs.AuthenTypeCHAP}, wantErr: tacacs.ErrAuthenticationFailed},
+		{name: "ASCII wrong password", req: tacacs.Request{Username: "alice", Password: "bob-pw", Type: tacacs.AuthenTypeASCII}, wantErr: tacacs.ErrAuthenticationFailed},
+		{name: "unknown user", req: tacacs.Request{Username: "mallory", Password: "alice-pw"}, wantErr: tacacs.ErrAuthenticationFailed},
+		{name: "unknown type", req: tacacs.Request{Username: "alice", Password: "alice-pw", Type: 0x7f}, wantErr: tacacs.ErrInvalidRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := client.Authenticate(testContext(t), &tt.req); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Authenticate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	srv := newTestServer(t, testKey)
+	client := &tacacs.Client{Address: srv.Addr, Secret: []byte(testKey)}
+
+	args, err := client.Authorize(testContext(t), &tacacs.Request{Username: "alice", Port: "https"}, []string{"service=shell", "cmd="})
+	if err != nil {
+		t.Fatalf("Authorize() error = %v", err)
+	}
+	if want := []string{"service=shell", "cmd=", "priv-lvl=15"}; !slices.Equal(args, want) {
+		t.Errorf("Authorize() = %q, want %q", args, want)
+	}
+
+	_, err = client.Authorize(testContext(t), &tacacs.Request{Username: "bob"}, []string{"service=shell"})
+	if !errors.Is(err, tacacs.ErrAuthorizationFailed) {
+		t.Errorf("Authorize() error = %v, want %v", err, tacacs.ErrAuthorizationFailed)
+	}
+}
+
+func TestObfuscation(t *testing.T) {
+	t.Run("bodies obfuscated with the key", func(t *testing.T) {
+		srv := newTestServer(t, testKey)
+		client := &tacacs.Client{Address: srv.Addr, Secret: []byte(testKey)}
+		if err := client.Authenticate(testContext(t), &tacacs.Request{Username: "alice", Password: "alice-pw"}); err != nil {
+			t.Fatalf("Authenticate() error = %v", err)
+		}
+
+		received := srv.Received()
+		if len(received) != 1 {
+			t.Fatalf("server received %d packets, want 1", len(received))
+		}
+		if flags := received[0][3]; flags&0x01 != 0 {
+			t.Errorf("flags = 0x%02x, want the body obfuscated", flags)
+		}
+		if bytes.Contains(received[0], []byte("alice-pw")) || bytes.Contains(received[0], []byte("alice")) {
+			t.Errorf("packet % x carries the credentials in the clear", received[0])
+		}
+	})
+
+	t.Run("keys differ", func(t *testing.T) {
+		srv := newTestServer(t, testKey)
+		client := &tacacs.Client{Address: srv.Addr, Secret: []byte("other-key")}
+		err := client.Authenticate(testContext(t), &tacacs.Request{Username: "alice", Password: "alice-pw"})
+		// The server cannot make sense of the body and hangs up.
+		if !errors.Is(err, tacacs.ErrConnectionFailed) {
+			t.Errorf("Authenticate() error = %v, want %v", err, tacacs.ErrConnectionFailed)
+		}
+	})
+
+	t.Run("client without a key", func(t *testing.T) {
+		srv := newTestServer(t, testKey)
+		client := &tacacs.Client{Address: srv.Addr}
+		if err := client.Authenticate(testContext(t), &tacacs.Request{Username: "alice", Password: "alice-pw"}); !errors.Is(err, tacacs.ErrConnectionFailed) {
+			t.Errorf("Authenticate() error = %v, want %v", err, tacacs.ErrConnectionFailed)
+		}
+	})
+
+	t.Run("cleartext", func(t *testing.T) {
+		srv := newTestServer(t, "")
+		client := &tacacs.Client{Address: srv.Addr}
+		if err := client.Authenticate(testContext(t), &tacacs.Request{Username: "alice", Password: "alice-pw"}); err != nil {
+			t.Errorf("Authenticate() error = %v", err)
+		}
+	})
+}
+
+func TestConnectionFailed(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := l.Addr().String()
+	_ = l.Close()
+
+	client := &tacacs.Client{Address: addr, Secret: []byte(testKey)}
+	if err := client.Authenticate(testContext(t), &tacacs.Request{Username: "alice", Password: "alice-pw"}); !errors.Is(err, tacacs.ErrConnectionFailed) {
+		t.Errorf("Authenticate() error = %v, want %v", err, tacacs.ErrConnectionFailed)
+	}
+}
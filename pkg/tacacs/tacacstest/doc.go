@@ -0,0 +1,28 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package tacacstest provides an in-process TACACS+ server for testing code
+// that authenticates and authorizes users with package tacacs, in the spirit
+// of net/http/httptest.
+//
+// # Overview
+//
+// A Server listens on a loopback TCP port and runs one session per
+// connection:
+//   - Authentication of logins with PAP, CHAP or the ASCII type, prompting
+//     for the password of the latter
+//   - Authorization requests, answered with the arguments of the user
+//   - Obfuscation of packet bodies with its key; packets the server cannot
+//     make sense of end the connection, as with a client using another key
+//
+// Received() returns the packets as they were sent on the wire, to check
+// that bodies are obfuscated.
+//
+// # Basic Usage
+//
+//	srv := tacacstest.NewServer("key", map[string]tacacstest.User{
+//		"alice": {Password: "alice-pw", Args: []string{"priv-lvl=15"}},
+//	})
+//	defer srv.Close()
+//
+//	client := &tacacs.Client{Address: srv.Addr, Secret: []byte("key")}
+package tacacstest
@@ -0,0 +1,400 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package tacacstest
+
+import (
+	"bufio"
+	"crypto/md5" //nolint:gosec // TACACS+ is defined in terms of MD5
+	"crypto/subtle"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"maps"
+	"net"
+	"slices"
+	"sync"
+)
+
+// Packet fields (RFC 8907 sections 4 to 6).
+const (
+	versionMajor   = 0xc0
+	versionDefault = 0xc0
+	versionOne     = 0xc1
+
+	typeAuthentication = 0x01
+	typeAuthorization  = 0x02
+
+	flagUnencrypted = 0x01
+
+	actionLogin  = 0x01
+	serviceLogin = 0x01
+
+	authenTypeASCII = 0x01
+	authenTypePAP   = 0x02
+	authenTypeCHAP  = 0x03
+
+	authenStatusPass    = 0x01
+	authenStatusFail    = 0x02
+	authenStatusGetPass = 0x05
+	replyFlagNoEcho     = 0x01
+
+	authorStatusPassAdd = 0x01
+	authorStatusFail    = 0x10
+
+	headerLength  = 12
+	maxBodyLength = 1 << 16
+	// chapIDLength and chapResponseLength frame the challenge in the data
+	// of a CHAP start packet.
+	chapIDLength       = 1
+	chapResponseLength = md5.Size
+)
+
+// User is a user known to a server.
+type User struct {
+	Password string
+	// Args are the arguments the user is authorized with, added to the
+	// requested ones. Users without arguments are denied authorization.
+	Args []string
+}
+
+// Server is a TACACS+ server listening on a loopback TCP port.
+type Server struct {
+	// Addr is the host and port of the server.
+	Addr string
+
+	listener net.Listener
+	key      []byte
+	wg       sync.WaitGroup
+
+	mu       sync.Mutex
+	users    map[string]User
+	conns    map[net.Conn]struct{}
+	received [][]byte
+	closed   bool
+}
+
+// NewServer starts a server with a key and users. Without a key, packets are
+// exchanged in the clear. The caller should call Close when finished, to
+// shut it down.
+func NewServer(key string, users map[string]User) *Server {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(fmt.Sprintf("tacacstest: failed to listen on a port: %v", err))
+	}
+
+	s := &Server{
+		Addr:     l.Addr().String(),
+		listener: l,
+		key:      []byte(key),
+		users:    users,
+		conns:    make(map[net.Conn]struct{}),
+	}
+	s.wg.Add(1)
+	go s.serve()
+	return s
+}
+
+// SetUser adds or replaces a user.
+func (s *Server) SetUser(name string, user User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	users := maps.Clone(s.users)
+	if users == nil {
+		users = make(map[string]User)
+	}
+	users[name] = user
+	s.users = users
+}
+
+// Received returns the packets received so far, as sent on the wire.
+func (s *Server) Received() [][]byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.received)
+}
+
+// Close shuts down the server, closing open connections, and waits for
+// their sessions to end.
+func (s *Server) Close() {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	s.closed = true
+	_ = s.listener.Close()
+	for conn := range s.conns {
+		_ = conn.Close()
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			_ = conn.Close()
+			return
+		}
+		s.conns[conn] = struct{}{}
+		s.wg.Add(1)
+		s.mu.Unlock()
+
+		go s.handle(conn)
+	}
+}
+
+// header is the header of a packet.
+type header struct {
+	version   byte
+	typ       byte
+	seqNo     byte
+	flags     byte
+	sessionID uint32
+}
+
+// handle runs the session of a connection until the client closes it or
+// sends a packet the server cannot make sense of.
+func (s *Server) handle(conn net.Conn) {
+	defer s.wg.Done()
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+		_ = conn.Close()
+	}()
+
+	r := bufio.NewReader(conn)
+	// prompted holds the username of an ASCII login waiting for the
+	// password.
+	var prompted *string
+	for {
+		h, body, err := s.read(r)
+		if err != nil {
+			return
+		}
+
+		var reply []byte
+		switch {
+		case h.typ == typeAuthentication && h.seqNo == 1:
+			reply, prompted, err = s.start(h, body)
+		case h.typ == typeAuthentication && prompted != nil:
+			reply, err = s.continueLogin(*prompted, body)
+			prompted = nil
+		case h.typ == typeAuthorization && h.seqNo == 1:
+			reply, err = s.authorize(body)
+		default:
+			return
+		}
+		if err != nil {
+			return
+		}
+
+		h.seqNo++
+		if err := s.write(conn, h, reply); err != nil {
+			return
+		}
+	}
+}
+
+// start answers the START packet of an authentication session. ASCII logins
+// are answered with a password prompt, whose username is returned.
+func (s *Server) start(h header, body []byte) ([]byte, *string, error) {
+	if len(body) < 8 || body[0] != actionLogin || body[3] != serviceLogin {
+		return nil, nil, fmt.Errorf("malformed start packet")
+	}
+	authenType := body[2]
+	strs, err := split(body[8:], int(body[4]), int(body[5]), int(body[6]), int(body[7]))
+	if err != nil {
+		return nil, nil, err
+	}
+	username, data := string(strs[0]), strs[3]
+
+	switch {
+	case authenType == authenTypeASCII && h.version == versionDefault:
+		return authenReply(authenStatusGetPass, replyFlagNoEcho, "Password: "), &username, nil
+	case authenType == authenTypePAP && h.version == versionOne:
+		return s.verdict(username, func(password string) bool {
+			return subtle.ConstantTimeCompare([]byte(password), data) == 1
+		}), nil, nil
+	case authenType == authenTypeCHAP && h.version == versionOne:
+		if len(data) <= chapIDLength+chapResponseLength {
+			return nil, nil, fmt.Errorf("malformed CHAP data")
+		}
+		id := data[:chapIDLength]
+		challenge := data[chapIDLength : len(data)-chapResponseLength]
+		response := data[len(data)-chapResponseLength:]
+		return s.verdict(username, func(password string) bool {
+			sum := md5.Sum(slices.Concat(id, []byte(password), challenge)) //nolint:gosec // CHAP is defined in terms of MD5
+			return subtle.ConstantTimeCompare(sum[:], response) == 1
+		}), nil, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported authentication type 0x%02x with version 0x%02x", authenType, h.version)
+	}
+}
+
+// continueLogin answers the CONTINUE packet carrying the password of an
+// ASCII login.
+func (s *Server) continueLogin(username string, body []byte) ([]byte, error) {
+	if len(body) < 5 {
+		return nil, fmt.Errorf("malformed continue packet")
+	}
+	strs, err := split(body[5:], int(binary.BigEndian.Uint16(body[0:2])), int(binary.BigEndian.Uint16(body[2:4])))
+	if err != nil {
+		return nil, err
+	}
+	return s.verdict(username, func(password string) bool {
+		return subtle.ConstantTimeCompare([]byte(password), strs[0]) == 1
+	}), nil
+}
+
+// verdict returns the reply passing or failing a login of username whose
+// password check returns.
+func (s *Server) verdict(username string, check func(password string) bool) []byte {
+	s.mu.Lock()
+	user, ok := s.users[username]
+	s.mu.Unlock()
+	if ok && check(user.Password) {
+		return authenReply(authenStatusPass, 0, "")
+	}
+	return authenReply(authenStatusFail, 0, "Authentication failed")
+}
+
+// authorize answers an authorization request.
+func (s *Server) authorize(body []byte) ([]byte, error) {
+	if len(body) < 8 || len(body) < 8+int(body[7]) {
+		return nil, fmt.Errorf("malformed authorization request")
+	}
+	argc := int(body[7])
+	lengths := []int{int(body[4]), int(body[5]), int(body[6])}
+	for _, n := range body[8 : 8+argc] {
+		lengths = append(lengths, int(n))
+	}
+	strs, err := split(body[8+argc:], lengths...)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	user, ok := s.users[string(strs[0])]
+	s.mu.Unlock()
+	if !ok || len(user.Args) == 0 {
+		msg := "Not authorized"
+		reply := []byte{authorStatusFail, 0}
+		reply = binary.BigEndian.AppendUint16(reply, uint16(len(msg)))
+		reply = append(reply, 0, 0)
+		return append(reply, msg...), nil
+	}
+
+	reply := []byte{authorStatusPassAdd, byte(len(user.Args)), 0, 0, 0, 0}
+	for _, arg := range user.Args {
+		reply = append(reply, byte(len(arg)))
+	}
+	for _, arg := range user.Args {
+		reply = append(reply, arg...)
+	}
+	return reply, nil
+}
+
+// authenReply encodes an authentication REPLY body.
+func authenReply(status, flags byte, msg string) []byte {
+	reply := []byte{status, flags}
+	reply = binary.BigEndian.AppendUint16(reply, uint16(len(msg)))
+	reply = append(reply, 0, 0)
+	return append(reply, msg...)
+}
+
+// read reads and deobfuscates a packet, refusing cleartext packets when the
+// server has a key.
+func (s *Server) read(r io.Reader) (header, []byte, error) {
+	var b [headerLength]byte
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		return header{}, nil, err
+	}
+	h := header{
+		version:   b[0],
+		typ:       b[1],
+		seqNo:     b[2],
+		flags:     b[3],
+		sessionID: binary.BigEndian.Uint32(b[4:8]),
+	}
+	length := binary.BigEndian.Uint32(b[8:12])
+	if h.version&0xf0 != versionMajor || length > maxBodyLength {
+		return header{}, nil, fmt.Errorf("malformed header")
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return header{}, nil, err
+	}
+	s.mu.Lock()
+	s.received = append(s.received, slices.Concat(b[:], body))
+	s.mu.Unlock()
+
+	switch {
+	case h.flags&flagUnencrypted != 0 && len(s.key) > 0:
+		return header{}, nil, fmt.Errorf("cleartext packet")
+	case h.flags&flagUnencrypted == 0:
+		s.pad(body, h)
+	}
+	return h, body, nil
+}
+
+// write obfuscates and sends a packet.
+func (s *Server) write(w io.Writer, h header, body []byte) error {
+	h.flags = 0
+	if len(s.key) == 0 {
+		h.flags = flagUnencrypted
+	} else {
+		s.pad(body, h)
+	}
+	b := []byte{h.version, h.typ, h.seqNo, h.flags}
+	b = binary.BigEndian.AppendUint32(b, h.sessionID)
+	b = binary.BigEndian.AppendUint32(b, uint32(len(body))) //nolint:gosec // bounded by the replies of the tests
+	_, err := w.Write(append(b, body...))
+	return err
+}
+
+// pad applies the MD5 pseudo-pad of RFC 8907 section 4.5: the body is
+// XORed with MD5(session_id, key, version, seq_no), followed by hashes of
+// the same input prefixed to the previous hash.
+func (s *Server) pad(body []byte, h header) {
+	input := binary.BigEndian.AppendUint32(nil, h.sessionID)
+	input = append(input, s.key...)
+	input = append(input, h.version, h.seqNo)
+
+	var hash []byte
+	for i := range body {
+		if i%md5.Size == 0 {
+			sum := md5.Sum(slices.Concat(input, hash)) //nolint:gosec // TACACS+ is defined in terms of MD5
+			hash = sum[:]
+		}
+		body[i] ^= hash[i%md5.Size]
+	}
+}
+
+// split cuts b into fields of the given lengths, which must add up to its
+// length.
+func split(b []byte, lengths ...int) ([][]byte, error) {
+	fields := make([][]byte, 0, len(lengths))
+	for _, n := range lengths {
+		if n > len(b) {
+			return nil, fmt.Errorf("truncated body")
+		}
+		fields = append(fields, b[:n])
+		b = b[n:]
+	}
+	if len(b) != 0 {
+		return nil, fmt.Errorf("body length mismatch")
+	}
+	return fields, nil
+}
@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package threshold decodes the threshold violations broadcast by sensormon
+// and tracks which of them are asserted, for the services that turn them
+// into log entries or events.
+//
+// # Overview
+//
+// sensormon publishes an Event on sensormon.events.threshold.<severity>
+// every time a threshold check finds a sensor outside one of its
+// thresholds. As violations are repeated on every check, consumers use a
+// Tracker to tell new violations from repeated ones:
+//   - Report returns true for the first report of a violation and when its
+//     type changes, such as a warning turning critical
+//   - Expire returns the violations that were not reported again within
+//     the timeout, which consumers treat as deasserted
+//
+// Upper and lower thresholds of a sensor are tracked independently, while
+// the warning and critical threshold of a direction share one violation.
+//
+// # Basic Usage
+//
+//	tracker := threshold.NewTracker(threshold.DefaultTimeout)
+//	go tracker.Run(ctx, func(event threshold.Event, now time.Time) {
+//		fmt.Printf("%s returned within its %s threshold\n", event.Name(), event.Direction())
+//	})
+//
+//	sub, err := nc.Subscribe(threshold.Subject, func(msg *nats.Msg) {
+//		event, err := threshold.ParseEvent(msg.Data)
+//		if err != nil {
+//			return
+//		}
+//		if tracker.Report(event, time.Now()) {
+//			fmt.Printf("%s crossed its %s threshold\n", event.Name(), event.ViolationType)
+//		}
+//	})
+//
+// # Error Handling
+//
+// ParseEvent wraps ErrMalformedEvent for messages that cannot be decoded and
+// ErrUnknownViolation for violation types it does not know, which consumers
+// typically log and skip.
+package threshold
@@ -0,0 +1,12 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package threshold
+
+import "errors"
+
+var (
+	// ErrMalformedEvent indicates that a threshold event is not valid JSON or names no sensor.
+	ErrMalformedEvent = errors.New("malformed threshold event")
+	// ErrUnknownViolation indicates that a threshold event reports an unknown violation type.
+	ErrUnknownViolation = errors.New("unknown threshold violation type")
+)
@@ -0,0 +1,162 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package threshold
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// Subject matches the subjects sensormon broadcasts threshold
+	// violations on, one per severity.
+	Subject = "sensormon.events.threshold.*"
+	// DefaultTimeout is how long a violation stays asserted without being
+	// reported again.
+	DefaultTimeout = 30 * time.Second
+)
+
+// Violation types reported by sensormon.
+const (
+	UpperCritical = "upper_critical"
+	UpperWarning  = "upper_warning"
+	LowerCritical = "lower_critical"
+	LowerWarning  = "lower_warning"
+)
+
+// Event is the threshold violation message broadcast by sensormon.
+type Event struct {
+	SensorID       string  `json:"sensor_id"`
+	SensorName     string  `json:"sensor_name"`
+	ViolationType  string  `json:"violation_type"`
+	CurrentValue   float64 `json:"current_value"`
+	ThresholdValue float64 `json:"threshold_value"`
+	Severity       string  `json:"severity"`
+	Timestamp      string  `json:"timestamp"`
+}
+
+// ParseEvent decodes a threshold event and checks that it names a sensor
+// and a known violation type.
+func ParseEvent(data []byte) (Event, error) {
+	var event Event
+	if err := json.Unmarshal(data, &event); err != nil {
+		return Event{}, fmt.Errorf("%w: %w", ErrMalformedEvent, err)
+	}
+	if event.SensorID == "" {
+		return Event{}, fmt.Errorf("%w: missing sensor ID", ErrMalformedEvent)
+	}
+	switch event.ViolationType {
+	case UpperCritical, UpperWarning, LowerCritical, LowerWarning:
+	default:
+		return Event{}, fmt.Errorf("%w: %q of sensor %s", ErrUnknownViolation, event.ViolationType, event.SensorID)
+	}
+	return event, nil
+}
+
+// Name returns the name of the sensor, its ID if it has none.
+func (e Event) Name() string {
+	if e.SensorName != "" {
+		return e.SensorName
+	}
+	return e.SensorID
+}
+
+// Direction returns the direction of the violated threshold, upper or
+// lower.
+func (e Event) Direction() string {
+	direction, _, _ := strings.Cut(e.ViolationType, "_")
+	return direction
+}
+
+// Time returns the time sensormon detected the violation and whether it is
+// set.
+func (e Event) Time() (time.Time, bool) {
+	t, err := time.Parse(time.RFC3339, e.Timestamp)
+	return t, err == nil
+}
+
+// violation is an asserted threshold violation.
+type violation struct {
+	event    Event
+	lastSeen time.Time
+}
+
+// Tracker tracks the asserted threshold violations of sensors. It is safe
+// for concurrent use.
+type Tracker struct {
+	timeout time.Duration
+
+	mu         sync.Mutex
+	violations map[string]*violation
+}
+
+// NewTracker returns a tracker deasserting violations that are not reported
+// again within timeout, DefaultTimeout if not positive.
+func NewTracker(timeout time.Duration) *Tracker {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Tracker{
+		timeout:    timeout,
+		violations: make(map[string]*violation),
+	}
+}
+
+// key identifies the threshold direction of a sensor.
+func key(event Event) string {
+	return event.SensorID + "/" + event.Direction()
+}
+
+// Report records a report of a violation at now and reports whether it is
+// newly asserted: the first report for its sensor and direction, or one
+// whose violation type changed. Repeated reports only keep it asserted.
+func (t *Tracker) Report(event Event, now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	k := key(event)
+	if active, ok := t.violations[k]; ok && active.event.ViolationType == event.ViolationType {
+		active.lastSeen = now
+		return false
+	}
+	t.violations[k] = &violation{event: event, lastSeen: now}
+	return true
+}
+
+// Expire removes and returns the violations that were not reported within
+// the timeout before now.
+func (t *Tracker) Expire(now time.Time) []Event {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var expired []Event
+	for k, active := range t.violations {
+		if now.Sub(active.lastSeen) >= t.timeout {
+			expired = append(expired, active.event)
+			delete(t.violations, k)
+		}
+	}
+	return expired
+}
+
+// Run expires violations every half timeout until ctx is canceled, calling
+// deassert for each expired one.
+func (t *Tracker) Run(ctx context.Context, deassert func(event Event, now time.Time)) {
+	ticker := time.NewTicker(t.timeout / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			for _, event := range t.Expire(now) {
+				deassert(event, now)
+			}
+		}
+	}
+}
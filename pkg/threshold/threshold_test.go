@@ -0,0 +1,160 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package threshold
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseEvent(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    Event
+		wantErr error
+	}{
+		{
+			name: "sensormon message",
+			data: `{
+				"sensor_id": "cpu0_temp",
+				"sensor_name": "CPU0 Temp",
+				"violation_type": "upper_critical",
+				"current_value": 97.500000,
+				"threshold_value": 95.000000,
+				"severity": "critical",
+				"timestamp": "2026-10-16T12:00:00Z"
+			}`,
+			want: Event{
+				SensorID:       "cpu0_temp",
+				SensorName:     "CPU0 Temp",
+				ViolationType:  UpperCritical,
+				CurrentValue:   97.5,
+				ThresholdValue: 95,
+				Severity:       "critical",
+				Timestamp:      "2026-10-16T12:00:00Z",
+			},
+		},
+		{name: "not JSON", data: `upper_critical`, wantErr: ErrMalformedEvent},
+		{name: "no sensor", data: `{"violation_type": "upper_critical"}`, wantErr: ErrMalformedEvent},
+		{name: "unknown violation type", data: `{"sensor_id": "fan0", "violation_type": "sideways"}`, wantErr: ErrUnknownViolation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseEvent([]byte(tt.data))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseEvent() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseEvent() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvent(t *testing.T) {
+	event := Event{SensorID: "fan0", ViolationType: LowerWarning, Timestamp: "2026-10-16T12:00:00Z"}
+	if got := event.Name(); got != "fan0" {
+		t.Errorf("Name() = %q, want the sensor ID", got)
+	}
+	if got := event.Direction(); got != "lower" {
+		t.Errorf("Direction() = %q, want %q", got, "lower")
+	}
+	if got, ok := event.Time(); !ok || !got.Equal(time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("Time() = %v, %v", got, ok)
+	}
+
+	event.SensorName = "Fan 0"
+	event.Timestamp = ""
+	if got := event.Name(); got != "Fan 0" {
+		t.Errorf("Name() = %q, want the sensor name", got)
+	}
+	if _, ok := event.Time(); ok {
+		t.Error("Time() reported a missing timestamp as set")
+	}
+}
+
+func TestTracker(t *testing.T) {
+	start := time.Now()
+	at := func(d time.Duration) time.Time { return start.Add(d) }
+	upperWarning := Event{SensorID: "cpu0_temp", ViolationType: UpperWarning}
+	upperCritical := Event{SensorID: "cpu0_temp", ViolationType: UpperCritical}
+	lowerWarning := Event{SensorID: "cpu0_temp", ViolationType: LowerWarning}
+	otherSensor := Event{SensorID: "cpu1_temp", ViolationType: UpperWarning}
+
+	tracker := NewTracker(10 * time.Second)
+	steps := []struct {
+		event Event
+		at    time.Duration
+		want  bool
+	}{
+		{event: upperWarning, at: 0, want: true},
+		{event: upperWarning, at: time.Second},
+		{event: otherSensor, at: time.Second, want: true},
+		{event: lowerWarning, at: 2 * time.Second, want: true},
+		{event: upperCritical, at: 3 * time.Second, want: true},
+		{event: upperCritical, at: 4 * time.Second},
+		{event: upperWarning, at: 5 * time.Second, want: true},
+	}
+	for _, step := range steps {
+		if got := tracker.Report(step.event, at(step.at)); got != step.want {
+			t.Errorf("Report(%s %s) at %v = %v, want %v", step.event.SensorID, step.event.ViolationType, step.at, got, step.want)
+		}
+	}
+
+	if expired := tracker.Expire(at(10 * time.Second)); len(expired) != 0 {
+		t.Errorf("Expire() = %v before the timeout", expired)
+	}
+	expired := tracker.Expire(at(12 * time.Second))
+	if len(expired) != 2 {
+		t.Fatalf("Expire() = %v, want the violations of cpu1_temp and the lower threshold", expired)
+	}
+	for _, event := range expired {
+		if event != otherSensor && event != lowerWarning {
+			t.Errorf("Expire() returned %+v", event)
+		}
+	}
+	if expired := tracker.Expire(at(12 * time.Second)); len(expired) != 0 {
+		t.Errorf("Expire() = %v again", expired)
+	}
+
+	if got := tracker.Report(upperWarning, at(14*time.Second)); got {
+		t.Error("Report() asserted a violation that is still asserted")
+	}
+	if expired := tracker.Expire(at(24 * time.Second)); len(expired) != 1 || expired[0] != upperWarning {
+		t.Errorf("Expire() = %v, want the upper warning", expired)
+	}
+	if got := tracker.Report(upperWarning, at(25*time.Second)); !got {
+		t.Error("Report() did not assert a violation again after it expired")
+	}
+}
+
+func TestTrackerRun(t *testing.T) {
+	tracker := NewTracker(20 * time.Millisecond)
+	event := Event{SensorID: "psu0_vout", ViolationType: LowerCritical}
+	tracker.Report(event, time.Now())
+
+	ctx, cancel := context.WithCancel(t.Context())
+	deasserted := make(chan Event, 1)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		tracker.Run(ctx, func(event Event, _ time.Time) {
+			deasserted <- event
+		})
+	}()
+
+	select {
+	case got := <-deasserted:
+		if got != event {
+			t.Errorf("deasserted %+v, want %+v", got, event)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("violation not deasserted")
+	}
+	cancel()
+	<-done
+}
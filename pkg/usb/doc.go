@@ -64,6 +64,12 @@
 //	// Unmount
 //	err = usb.SetMassStorageFile(ctx, "", false)
 //
+// The CD-ROM and read-only flags can only be changed while no file is attached.
+// EjectMassStorageFile detaches the current file without touching them and forces
+// the ejection even if the host has locked the medium:
+//
+//	err = usb.EjectMassStorageFile(ctx, "kvm-gadget")
+//
 // # Configuration Management
 //
 // The package handles configfs operations transparently:
@@ -51,6 +51,46 @@ func SetMassStorageFile(ctx context.Context, gadgetName, filePath string, cdromM
 	return nil
 }
 
+// EjectMassStorageFile detaches the backing file from the mass storage function.
+// Unlike SetMassStorageFile with an empty path it leaves the CD-ROM mode untouched,
+// which the kernel refuses to change while a file is attached, and it uses forced
+// ejection where available so that a host locking the medium cannot prevent it.
+func EjectMassStorageFile(ctx context.Context, gadgetName string) error {
+	if gadgetName == "" {
+		return ErrInvalidConfig
+	}
+
+	gadgetDir := filepath.Join(gadgetPath, gadgetName)
+
+	// Check if gadget exists
+	if _, err := os.Stat(gadgetDir); os.IsNotExist(err) {
+		return ErrGadgetNotFound
+	}
+
+	// Get mass storage function path
+	functionDir := filepath.Join(gadgetDir, "functions/mass_storage.usb0/lun.0")
+	if _, err := os.Stat(functionDir); os.IsNotExist(err) {
+		return fmt.Errorf("mass storage function not found: %w", err)
+	}
+
+	// Prefer forced ejection, available since Linux 4.20
+	forcedEjectPath := filepath.Join(functionDir, "forced_eject")
+	if _, err := os.Stat(forcedEjectPath); err == nil {
+		if err := writeFile(forcedEjectPath, "1"); err != nil {
+			return fmt.Errorf("failed to eject mass storage file: %w", err)
+		}
+		return nil
+	}
+
+	// Clear the file path
+	fileSysPath := filepath.Join(functionDir, "file")
+	if err := writeFile(fileSysPath, ""); err != nil {
+		return fmt.Errorf("failed to clear mass storage file: %w", err)
+	}
+
+	return nil
+}
+
 // GetMassStorageFile returns the current backing file for the mass storage function.
 func GetMassStorageFile(ctx context.Context, gadgetName string) (string, bool, error) {
 	if gadgetName == "" {
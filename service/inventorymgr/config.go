@@ -2,9 +2,19 @@
 
 package inventorymgr
 
+// Default configuration constants.
+const (
+	// DefaultFRUName is the name of the FRU device describing the baseboard.
+	DefaultFRUName = "baseboard"
+	// DefaultFRUPath is the path of the baseboard FRU image.
+	DefaultFRUPath = "/etc/fru/baseboard.fru.bin"
+)
+
 // config holds the configuration for the inventory manager service.
 type config struct {
 	name string
+	// fruDevices maps FRU device names to the paths of their FRU images.
+	fruDevices map[string]string
 }
 
 // Option represents a configuration option for the inventory manager service.
@@ -25,3 +35,26 @@ func WithServiceName(name string) Option {
 		name: name,
 	}
 }
+
+type fruDeviceOption struct {
+	name string
+	path string
+}
+
+func (o *fruDeviceOption) apply(c *config) {
+	if o.path == "" {
+		delete(c.fruDevices, o.name)
+		return
+	}
+	c.fruDevices[o.name] = o.path
+}
+
+// WithFRUDevice adds a FRU device whose image at path, such as an EEPROM
+// exposed through sysfs, is reported as an asset. Using DefaultFRUName
+// replaces the baseboard FRU and an empty path removes a device.
+func WithFRUDevice(name, path string) Option {
+	return &fruDeviceOption{
+		name: name,
+		path: path,
+	}
+}
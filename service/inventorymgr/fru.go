@@ -0,0 +1,179 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package inventorymgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"strconv"
+
+	schemav1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+	"github.com/u-bmc/u-bmc/pkg/fru"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// AssetInfoFromFRU decodes a FRU image and converts it to asset information.
+//
+// Product info area fields take precedence over board info area fields, which
+// take precedence over chassis info area fields. The board manufacturing date
+// becomes the manufacturing date and all fields that are not mapped to
+// dedicated asset fields, including custom fields, are kept as custom
+// attributes prefixed with the area name. The product name is empty if
+// neither the product nor the board info area names the product.
+func AssetInfoFromFRU(data []byte) (*schemav1alpha1.AssetInfo, error) {
+	info, err := fru.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	asset := &schemav1alpha1.AssetInfo{
+		CustomAttributes: make(map[string]string),
+	}
+	attrs := asset.CustomAttributes
+
+	set := func(dst **string, f fru.Field) {
+		if *dst == nil && !f.IsEmpty() {
+			s := f.String()
+			*dst = &s
+		}
+	}
+	attr := func(key string, f fru.Field) {
+		if !f.IsEmpty() {
+			attrs[key] = f.String()
+		}
+	}
+	custom := func(prefix string, fields []fru.Field) {
+		for i, f := range fields {
+			attr(prefix+".custom."+strconv.Itoa(i), f)
+		}
+	}
+
+	if p := info.Product; p != nil {
+		asset.ProductName = p.Name.String()
+		set(&asset.Manufacturer, p.Manufacturer)
+		set(&asset.PartNumber, p.PartNumber)
+		set(&asset.Revision, p.Version)
+		set(&asset.SerialNumber, p.SerialNumber)
+		set(&asset.AssetTag, p.AssetTag)
+		attr("product.file_id", p.FileID)
+		custom("product", p.Custom)
+	}
+
+	if b := info.Board; b != nil {
+		if asset.ProductName == "" {
+			asset.ProductName = b.ProductName.String()
+		}
+		set(&asset.Manufacturer, b.Manufacturer)
+		set(&asset.SerialNumber, b.SerialNumber)
+		set(&asset.PartNumber, b.PartNumber)
+		if !b.ManufacturingDate.IsZero() {
+			asset.ManufacturingDate = timestamppb.New(b.ManufacturingDate)
+		}
+		attr("board.manufacturer", b.Manufacturer)
+		attr("board.product_name", b.ProductName)
+		attr("board.serial_number", b.SerialNumber)
+		attr("board.part_number", b.PartNumber)
+		attr("board.file_id", b.FileID)
+		custom("board", b.Custom)
+	}
+
+	if c := info.Chassis; c != nil {
+		set(&asset.SerialNumber, c.SerialNumber)
+		set(&asset.PartNumber, c.PartNumber)
+		attrs["chassis.type"] = strconv.Itoa(int(c.Type))
+		attr("chassis.part_number", c.PartNumber)
+		attr("chassis.serial_number", c.SerialNumber)
+		custom("chassis", c.Custom)
+	}
+
+	return asset, nil
+}
+
+// loadAssets reads and converts the images of all configured FRU devices.
+// Devices whose image is missing or invalid are skipped, so a single broken
+// EEPROM does not hide the remaining inventory. Images are read on every
+// request, so changes made through IPMI Write FRU Data are visible at once.
+func (s *InventoryMgr) loadAssets(ctx context.Context) []*schemav1alpha1.AssetInfo {
+	names := make([]string, 0, len(s.config.fruDevices))
+	for name := range s.config.fruDevices {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	assets := make([]*schemav1alpha1.AssetInfo, 0, len(names))
+	for _, name := range names {
+		path := s.config.fruDevices[name]
+		asset, err := loadAsset(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			s.logger.DebugContext(ctx, "FRU image not present", "device", name, "path", path)
+			continue
+		}
+		if err != nil {
+			s.logger.WarnContext(ctx, "Failed to load FRU image", "device", name, "path", path, "error", err)
+			continue
+		}
+
+		if asset.ProductName == "" {
+			asset.ProductName = name
+		}
+		asset.CustomAttributes["fru.device"] = name
+		assets = append(assets, asset)
+	}
+
+	return assets
+}
+
+func loadAsset(path string) (*schemav1alpha1.AssetInfo, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	asset, err := AssetInfoFromFRU(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse FRU image: %w", err)
+	}
+	return asset, nil
+}
+
+// matchesAsset reports whether asset matches the identifier of a request.
+// Contact identifiers are not supported and never match.
+func matchesAsset(asset *schemav1alpha1.AssetInfo, req *schemav1alpha1.GetAssetInfoRequest) bool {
+	sameTime := func(a, b *timestamppb.Timestamp) bool {
+		return a != nil && b != nil && a.AsTime().Equal(b.AsTime())
+	}
+
+	switch id := req.GetIdentifier().(type) {
+	case *schemav1alpha1.GetAssetInfoRequest_ProductName:
+		return asset.GetProductName() == id.ProductName
+	case *schemav1alpha1.GetAssetInfoRequest_AssetTag:
+		return asset.AssetTag != nil && asset.GetAssetTag() == id.AssetTag
+	case *schemav1alpha1.GetAssetInfoRequest_PartNumber:
+		return asset.PartNumber != nil && asset.GetPartNumber() == id.PartNumber
+	case *schemav1alpha1.GetAssetInfoRequest_SerialNumber:
+		return asset.SerialNumber != nil && asset.GetSerialNumber() == id.SerialNumber
+	case *schemav1alpha1.GetAssetInfoRequest_Manufacturer:
+		return asset.Manufacturer != nil && asset.GetManufacturer() == id.Manufacturer
+	case *schemav1alpha1.GetAssetInfoRequest_Revision:
+		return asset.Revision != nil && asset.GetRevision() == id.Revision
+	case *schemav1alpha1.GetAssetInfoRequest_Uuid:
+		return asset.Uuid != nil && asset.GetUuid() == id.Uuid
+	case *schemav1alpha1.GetAssetInfoRequest_Sku:
+		return asset.Sku != nil && asset.GetSku() == id.Sku
+	case *schemav1alpha1.GetAssetInfoRequest_ManufacturingDate:
+		return sameTime(asset.GetManufacturingDate(), id.ManufacturingDate)
+	case *schemav1alpha1.GetAssetInfoRequest_PurchaseDate:
+		return sameTime(asset.GetPurchaseDate(), id.PurchaseDate)
+	case *schemav1alpha1.GetAssetInfoRequest_WarrantyExpires:
+		return sameTime(asset.GetWarrantyExpires(), id.WarrantyExpires)
+	case *schemav1alpha1.GetAssetInfoRequest_InstallationDate:
+		return sameTime(asset.GetInstallationDate(), id.InstallationDate)
+	case *schemav1alpha1.GetAssetInfoRequest_DecommissionDate:
+		return sameTime(asset.GetDecommissionDate(), id.DecommissionDate)
+	default:
+		return false
+	}
+}
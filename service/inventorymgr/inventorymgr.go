@@ -35,6 +35,9 @@ type InventoryMgr struct {
 func New(opts ...Option) *InventoryMgr {
 	cfg := &config{
 		name: "inventorymgr",
+		fruDevices: map[string]string{
+			DefaultFRUName: DefaultFRUPath,
+		},
 	}
 	for _, opt := range opts {
 		opt.apply(cfg)
@@ -131,18 +134,11 @@ func (s *InventoryMgr) handleAssetInfo(ctx context.Context, req micro.Request) {
 		return
 	}
 
-	// Mock response for now
-	manufacturer := "Mock Manufacturer"
-	serialNumber := "MOCK-PN-001"
-	partNumber := "MOCK-PN-001"
-	response := &schemav1alpha1.GetAssetInfoResponse{
-		AssetInfo: []*schemav1alpha1.AssetInfo{
-			{
-				Manufacturer: &manufacturer,
-				SerialNumber: &serialNumber,
-				PartNumber:   &partNumber,
-			},
-		},
+	response := &schemav1alpha1.GetAssetInfoResponse{}
+	for _, asset := range s.loadAssets(ctx) {
+		if matchesAsset(asset, &request) {
+			response.AssetInfo = append(response.AssetInfo, asset)
+		}
 	}
 
 	data, err := response.MarshalVT()
@@ -162,18 +158,8 @@ func (s *InventoryMgr) handleAssetList(ctx context.Context, req micro.Request) {
 		defer span.End()
 	}
 
-	// Mock response for now
-	manufacturer := "Mock Manufacturer"
-	serialNumber := "MOCK-PN-001"
-	partNumber := "MOCK-PN-001"
 	response := &schemav1alpha1.GetAssetInfoResponse{
-		AssetInfo: []*schemav1alpha1.AssetInfo{
-			{
-				Manufacturer: &manufacturer,
-				SerialNumber: &serialNumber,
-				PartNumber:   &partNumber,
-			},
-		},
+		AssetInfo: s.loadAssets(ctx),
 	}
 
 	data, err := response.MarshalVT()
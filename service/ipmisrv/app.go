@@ -0,0 +1,271 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ipmisrv
+
+import (
+	"context"
+	"encoding/binary"
+
+	v1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+)
+
+// Application network function commands.
+const (
+	cmdGetDeviceID              uint8 = 0x01
+	cmdColdReset                uint8 = 0x02
+	cmdWarmReset                uint8 = 0x03
+	cmdGetSelfTestResults       uint8 = 0x04
+	cmdGetSystemGUID            uint8 = 0x37
+	cmdGetChannelAuthCaps       uint8 = 0x38
+	cmdSetSessionPrivilegeLevel uint8 = 0x3B
+	cmdCloseSession             uint8 = 0x3C
+	cmdGetChannelCipherSuites   uint8 = 0x54
+)
+
+// Get Device ID and Get Self Test Results response fields.
+const (
+	ipmiVersion20          uint8 = 0x02
+	deviceSupportSensor    uint8 = 0x01
+	deviceSupportSDRRepo   uint8 = 0x02
+	deviceSupportSEL       uint8 = 0x04
+	deviceSupportFRU       uint8 = 0x08
+	deviceSupportChassis   uint8 = 0x80
+	selfTestNoError        uint8 = 0x55
+	deviceRevisionMask     uint8 = 0x0F
+	manufacturerIDByteSize       = 3
+)
+
+// Channel and authentication capability fields.
+const (
+	channelCurrent               uint8 = 0x0E
+	channelMask                  uint8 = 0x0F
+	authCapsExtendedData         uint8 = 0x80
+	authCapsNonNullUsernames     uint8 = 0x04
+	authCapsKGSet                uint8 = 0x20
+	authCapsIPMIv20              uint8 = 0x02
+	cipherSuiteListBySuite       uint8 = 0x80
+	cipherSuiteListIndexMask     uint8 = 0x3F
+	cipherSuiteRecordsPerRequest       = 16
+)
+
+// registerAppCommands registers the application network function commands.
+func (s *IPMISrv) registerAppCommands() {
+	s.dispatcher.register(NetFnApp, cmdGetDeviceID, PrivilegeUser, s.handleGetDeviceID)
+	s.dispatcher.register(NetFnApp, cmdColdReset, PrivilegeAdmin, s.handleColdReset)
+	s.dispatcher.register(NetFnApp, cmdWarmReset, PrivilegeAdmin, s.handleWarmReset)
+	s.dispatcher.register(NetFnApp, cmdGetSelfTestResults, PrivilegeUser, s.handleGetSelfTestResults)
+	s.dispatcher.register(NetFnApp, cmdGetSystemGUID, PrivilegeNone, s.handleGetSystemGUID)
+	s.dispatcher.register(NetFnApp, cmdGetChannelAuthCaps, PrivilegeNone, s.handleGetChannelAuthCaps)
+	s.dispatcher.register(NetFnApp, cmdGetChannelCipherSuites, PrivilegeNone, s.handleGetChannelCipherSuites)
+	s.dispatcher.register(NetFnApp, cmdSetSessionPrivilegeLevel, PrivilegeUser, s.handleSetSessionPrivilegeLevel)
+	s.dispatcher.register(NetFnApp, cmdCloseSession, PrivilegeCallback, s.handleCloseSession)
+}
+
+// resolveChannel maps the "current channel" alias to the request channel.
+func resolveChannel(req *request, channel uint8) uint8 {
+	channel &= channelMask
+	if channel == channelCurrent {
+		return req.channel
+	}
+	return channel
+}
+
+func (s *IPMISrv) handleGetDeviceID(_ context.Context, req *request) ([]byte, uint8) {
+	if len(req.msg.Data) != 0 {
+		return nil, CCInvalidLength
+	}
+
+	out := make([]byte, 0, 15)
+	out = append(out,
+		s.config.deviceID,
+		s.config.deviceRevision&deviceRevisionMask,
+		s.config.firmwareMajor,
+		s.config.firmwareMinor/10<<4|s.config.firmwareMinor%10,
+		ipmiVersion20,
+		s.deviceSupport(),
+	)
+	out = binary.LittleEndian.AppendUint32(out, s.config.manufacturerID)[:len(out)+manufacturerIDByteSize]
+	out = binary.LittleEndian.AppendUint16(out, s.config.productID)
+	out = append(out, s.config.auxFirmwareRev[:]...)
+
+	return out, CCSuccess
+}
+
+// deviceSupport derives the additional device support field of Get Device ID
+// from the registered commands.
+func (s *IPMISrv) deviceSupport() uint8 {
+	var support uint8
+	if s.dispatcher.has(NetFnSensor, cmdGetSensorReading) {
+		support |= deviceSupportSensor
+	}
+	if s.dispatcher.has(NetFnStorage, cmdGetSDR) {
+		support |= deviceSupportSDRRepo
+	}
+	if s.dispatcher.has(NetFnStorage, cmdGetSELEntry) {
+		support |= deviceSupportSEL
+	}
+	if s.dispatcher.has(NetFnStorage, cmdReadFRUData) {
+		support |= deviceSupportFRU
+	}
+	if s.dispatcher.has(NetFnChassis, cmdGetChassisStatus) {
+		support |= deviceSupportChassis
+	}
+	return support
+}
+
+func (s *IPMISrv) handleColdReset(ctx context.Context, req *request) ([]byte, uint8) {
+	return s.resetManagementController(ctx, req, v1alpha1.ManagementControllerAction_MANAGEMENT_CONTROLLER_ACTION_COLD_RESET)
+}
+
+func (s *IPMISrv) handleWarmReset(ctx context.Context, req *request) ([]byte, uint8) {
+	return s.resetManagementController(ctx, req, v1alpha1.ManagementControllerAction_MANAGEMENT_CONTROLLER_ACTION_WARM_RESET)
+}
+
+func (s *IPMISrv) resetManagementController(ctx context.Context, req *request, action v1alpha1.ManagementControllerAction) ([]byte, uint8) {
+	if len(req.msg.Data) != 0 {
+		return nil, CCInvalidLength
+	}
+
+	s.logger.InfoContext(ctx, "IPMI BMC reset requested", "action", action.String(), "remote", req.remoteAddr)
+
+	// The reset is acknowledged before it is carried out so the response can
+	// still be delivered.
+	s.submit(ctx, "bmc reset", func(ctx context.Context) error {
+		return s.changeManagementControllerState(ctx, action)
+	})
+
+	return nil, CCSuccess
+}
+
+func (s *IPMISrv) handleGetSelfTestResults(_ context.Context, req *request) ([]byte, uint8) {
+	if len(req.msg.Data) != 0 {
+		return nil, CCInvalidLength
+	}
+
+	return []byte{selfTestNoError, 0x00}, CCSuccess
+}
+
+func (s *IPMISrv) handleGetSystemGUID(_ context.Context, _ *request) ([]byte, uint8) {
+	return append([]byte(nil), s.guid[:]...), CCSuccess
+}
+
+func (s *IPMISrv) handleGetChannelAuthCaps(_ context.Context, req *request) ([]byte, uint8) {
+	if len(req.msg.Data) != 2 {
+		return nil, CCInvalidLength
+	}
+
+	channel := resolveChannel(req, req.msg.Data[0])
+	if channel != s.config.lanChannel {
+		return nil, CCInvalidField
+	}
+	if !PrivilegeLevel(req.msg.Data[1] & channelMask).Valid() {
+		return nil, CCInvalidField
+	}
+
+	// Only RMCP+ is supported, so no IPMI v1.5 authentication types are advertised.
+	var authTypes, extended uint8
+	if req.msg.Data[0]&authCapsExtendedData != 0 {
+		authTypes = authCapsExtendedData
+		extended = authCapsIPMIv20
+	}
+
+	status := authCapsNonNullUsernames
+	if len(s.config.kg) > 0 {
+		status |= authCapsKGSet
+	}
+
+	return []byte{channel, authTypes, status, extended, 0x00, 0x00, 0x00, 0x00}, CCSuccess
+}
+
+func (s *IPMISrv) handleGetChannelCipherSuites(_ context.Context, req *request) ([]byte, uint8) {
+	if len(req.msg.Data) != 3 {
+		return nil, CCInvalidLength
+	}
+
+	channel := resolveChannel(req, req.msg.Data[0])
+	if channel != s.config.lanChannel {
+		return nil, CCInvalidField
+	}
+	if req.msg.Data[1] != payloadTypeIPMI {
+		return nil, CCInvalidField
+	}
+
+	var records []byte
+	if req.msg.Data[2]&cipherSuiteListBySuite != 0 {
+		for _, cs := range supportedCipherSuites() {
+			records = append(records, cs.record()...)
+		}
+	} else {
+		seen := make(map[uint8]bool)
+		for _, cs := range supportedCipherSuites() {
+			for _, alg := range []uint8{cs.auth, cipherSuiteTagIntegrity | cs.integrity, cipherSuiteTagConf | cs.confidentiality} {
+				if !seen[alg] {
+					seen[alg] = true
+					records = append(records, alg)
+				}
+			}
+		}
+	}
+
+	out := []byte{channel}
+	start := int(req.msg.Data[2]&cipherSuiteListIndexMask) * cipherSuiteRecordsPerRequest
+	if start < len(records) {
+		end := min(start+cipherSuiteRecordsPerRequest, len(records))
+		out = append(out, records[start:end]...)
+	}
+
+	return out, CCSuccess
+}
+
+func (s *IPMISrv) handleSetSessionPrivilegeLevel(_ context.Context, req *request) ([]byte, uint8) {
+	if len(req.msg.Data) != 1 {
+		return nil, CCInvalidLength
+	}
+
+	sess := req.session
+	if sess == nil {
+		return nil, CCNotSupportedInState
+	}
+
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+
+	requested := PrivilegeLevel(req.msg.Data[0] & channelMask)
+	if requested == PrivilegeNone {
+		return []byte{uint8(sess.privilege)}, CCSuccess
+	}
+	if !requested.Valid() {
+		return nil, CCInvalidField
+	}
+	if requested > sess.maxPrivilege {
+		return nil, CCSessionPrivExceedsLimit
+	}
+
+	sess.privilege = requested
+
+	return []byte{uint8(sess.privilege)}, CCSuccess
+}
+
+func (s *IPMISrv) handleCloseSession(ctx context.Context, req *request) ([]byte, uint8) {
+	if len(req.msg.Data) < 4 {
+		return nil, CCInvalidLength
+	}
+
+	id := binary.LittleEndian.Uint32(req.msg.Data[0:4])
+	if id == 0 {
+		return nil, CCSessionInvalidID
+	}
+
+	own := req.session != nil && req.session.id == id
+	if !own && req.privilege < PrivilegeAdmin {
+		return nil, CCInsufficientPrivilege
+	}
+
+	if !s.sessions.remove(id) {
+		return nil, CCSessionInvalidID
+	}
+
+	s.logger.InfoContext(ctx, "RMCP+ session closed", "session_id", id, "remote", req.remoteAddr)
+
+	return nil, CCSuccess
+}
@@ -0,0 +1,179 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ipmisrv
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	v1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+	"github.com/u-bmc/u-bmc/pkg/ipc"
+)
+
+// keyLength is the size of an IPMI v2.0 user password and BMC key (Kg) in bytes.
+const keyLength = 20
+
+// UserKey holds the RAKP key material and the channel privilege limit for a user.
+type UserKey struct {
+	// Password is the cleartext IPMI password (K_UID), at most 20 bytes.
+	Password []byte
+	// Privilege is the highest privilege level the user may request on the LAN channel.
+	Privilege PrivilegeLevel
+}
+
+// KeyStore provides the cleartext user keys required by the RAKP handshake.
+//
+// RAKP proves knowledge of the user password through HMACs keyed with the
+// password itself, so the BMC must have access to it in cleartext. The key store
+// is kept separate from usermgr, which only stores password hashes; every key
+// whose knowledge a remote console proved is additionally verified against
+// usermgr before a session is established.
+type KeyStore interface {
+	// Lookup returns the key for the given username or ErrUserNotFound.
+	Lookup(ctx context.Context, username string) (UserKey, error)
+}
+
+// KeyStoreWriter is implemented by key stores whose keys can be changed
+// through the IPMI user management commands. Set User Name and Set User
+// Password require the key store to implement it.
+type KeyStoreWriter interface {
+	KeyStore
+	// Set stores the key for the given username, replacing any existing entry.
+	Set(username string, key UserKey) error
+	// Delete removes the key for the given username.
+	Delete(username string) error
+}
+
+// MemoryKeyStore is a KeyStore backed by an in-memory map. Its keys are lost
+// on restart.
+type MemoryKeyStore struct {
+	mu   sync.RWMutex
+	keys map[string]UserKey
+}
+
+// NewMemoryKeyStore creates an empty MemoryKeyStore.
+func NewMemoryKeyStore() *MemoryKeyStore {
+	return &MemoryKeyStore{
+		keys: make(map[string]UserKey),
+	}
+}
+
+// Lookup returns the key for the given username.
+func (m *MemoryKeyStore) Lookup(_ context.Context, username string) (UserKey, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	key, ok := m.keys[username]
+	if !ok {
+		return UserKey{}, ErrUserNotFound
+	}
+
+	return UserKey{
+		Password:  append([]byte(nil), key.Password...),
+		Privilege: key.Privilege,
+	}, nil
+}
+
+// validateUserKey checks a key before it is stored for username.
+func validateUserKey(username string, key UserKey) error {
+	if len(username) == 0 || len(username) > maxUsernameLength {
+		return fmt.Errorf("username must be between 1 and %d bytes", maxUsernameLength)
+	}
+	if len(key.Password) > keyLength {
+		return fmt.Errorf("password must not exceed %d bytes", keyLength)
+	}
+	if !key.Privilege.Valid() {
+		return fmt.Errorf("invalid privilege level %s", key.Privilege)
+	}
+	return nil
+}
+
+// Set stores the key for the given username, replacing any existing entry.
+func (m *MemoryKeyStore) Set(username string, key UserKey) error {
+	if err := validateUserKey(username, key); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.keys[username] = UserKey{
+		Password:  append([]byte(nil), key.Password...),
+		Privilege: key.Privilege,
+	}
+
+	return nil
+}
+
+// Delete removes the key for the given username.
+func (m *MemoryKeyStore) Delete(username string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.keys, username)
+	return nil
+}
+
+// checkLogin asks usermgr whether the user may log in before the remote
+// console has proven knowledge of its key, which checks the account state
+// without recording a login. With proofFailed set, it reports a failed proof
+// instead, which counts towards the lockout threshold of the account.
+func (s *IPMISrv) checkLogin(ctx context.Context, username, sourceIP string, proofFailed bool) error {
+	ctx, span := s.tracer.Start(ctx, "ipmisrv.checkLogin")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.config.authTimeout)
+	defer cancel()
+
+	req := &v1alpha1.CheckLoginRequest{
+		Username:    username,
+		ProofFailed: proofFailed,
+	}
+	if sourceIP != "" {
+		req.SourceIp = &sourceIP
+	}
+
+	resp := &v1alpha1.CheckLoginResponse{}
+	if err := s.requestNATS(ctx, ipc.SubjectUserCheckLogin, req, resp); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("%w: %w", ErrAuthenticationFailed, err)
+	}
+
+	if !resp.GetAllowed() {
+		return fmt.Errorf("%w: %s", ErrAuthenticationFailed, resp.GetFailureReason())
+	}
+
+	return nil
+}
+
+// authenticate verifies the user credentials against usermgr and records the
+// login. It is only called once the remote console has proven knowledge of
+// the password.
+func (s *IPMISrv) authenticate(ctx context.Context, username string, password []byte, sourceIP string) error {
+	ctx, span := s.tracer.Start(ctx, "ipmisrv.authenticate")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, s.config.authTimeout)
+	defer cancel()
+
+	req := &v1alpha1.AuthenticateUserRequest{
+		Username: username,
+		Password: string(password),
+	}
+	if sourceIP != "" {
+		req.SourceIp = &sourceIP
+	}
+
+	resp := &v1alpha1.AuthenticateUserResponse{}
+	if err := s.requestNATS(ctx, ipc.SubjectUserAuthenticate, req, resp); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("%w: %w", ErrAuthenticationFailed, err)
+	}
+
+	if !resp.GetSuccess() {
+		return fmt.Errorf("%w: %s", ErrAuthenticationFailed, resp.GetFailureReason())
+	}
+
+	return nil
+}
@@ -0,0 +1,220 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ipmisrv
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+	"github.com/u-bmc/u-bmc/pkg/ipc"
+)
+
+// Chassis network function commands.
+const (
+	cmdGetChassisStatus uint8 = 0x01
+	cmdChassisControl   uint8 = 0x02
+	cmdChassisIdentify  uint8 = 0x04
+)
+
+// Chassis Control request values.
+const (
+	chassisControlPowerDown    uint8 = 0x00
+	chassisControlPowerUp      uint8 = 0x01
+	chassisControlPowerCycle   uint8 = 0x02
+	chassisControlHardReset    uint8 = 0x03
+	chassisControlSoftShutdown uint8 = 0x05
+	chassisControlMask         uint8 = 0x0F
+)
+
+// Get Chassis Status response fields.
+const (
+	chassisPowerOn              uint8 = 0x01
+	chassisRestorePolicyUnknown uint8 = 0x60
+	chassisIdentifySupported    uint8 = 0x40
+	chassisIdentifyShift              = 4
+	chassisIdentifyForceOn      uint8 = 0x01
+)
+
+// identifyState is the chassis identify state as reported by Get Chassis Status.
+type identifyState uint8
+
+const (
+	identifyOff        identifyState = 0x00
+	identifyTemporary  identifyState = 0x01
+	identifyIndefinite identifyState = 0x02
+)
+
+// registerChassisCommands registers the chassis network function commands.
+func (s *IPMISrv) registerChassisCommands() {
+	s.dispatcher.register(NetFnChassis, cmdGetChassisStatus, PrivilegeUser, s.handleGetChassisStatus)
+	s.dispatcher.register(NetFnChassis, cmdChassisControl, PrivilegeOperator, s.handleChassisControl)
+	s.dispatcher.register(NetFnChassis, cmdChassisIdentify, PrivilegeOperator, s.handleChassisIdentify)
+}
+
+func (s *IPMISrv) handleGetChassisStatus(ctx context.Context, req *request) ([]byte, uint8) {
+	if len(req.msg.Data) != 0 {
+		return nil, CCInvalidLength
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.config.requestTimeout)
+	defer cancel()
+
+	hostReq := &v1alpha1.GetHostRequest{
+		Identifier: &v1alpha1.GetHostRequest_Name{Name: s.config.hostName},
+	}
+	hostResp := &v1alpha1.GetHostResponse{}
+	if err := s.requestNATS(ctx, ipc.SubjectHostState, hostReq, hostResp); err != nil {
+		s.logger.WarnContext(ctx, "Failed to get host state", "host", s.config.hostName, "error", err)
+		return nil, CCDestinationUnavail
+	}
+
+	power := chassisRestorePolicyUnknown
+	for _, host := range hostResp.GetHosts() {
+		if host.GetName() == s.config.hostName && host.GetStatus() == v1alpha1.HostStatus_HOST_STATUS_ON {
+			power |= chassisPowerOn
+		}
+	}
+
+	s.identifyMu.Lock()
+	misc := chassisIdentifySupported | uint8(s.identify)<<chassisIdentifyShift
+	s.identifyMu.Unlock()
+
+	return []byte{power, 0x00, misc}, CCSuccess
+}
+
+func (s *IPMISrv) handleChassisControl(ctx context.Context, req *request) ([]byte, uint8) {
+	if len(req.msg.Data) != 1 {
+		return nil, CCInvalidLength
+	}
+
+	var (
+		hostAction    v1alpha1.HostAction
+		chassisAction v1alpha1.ChassisAction
+	)
+	switch req.msg.Data[0] & chassisControlMask {
+	case chassisControlPowerDown:
+		hostAction = v1alpha1.HostAction_HOST_ACTION_FORCE_OFF
+	case chassisControlPowerUp:
+		hostAction = v1alpha1.HostAction_HOST_ACTION_ON
+	case chassisControlPowerCycle:
+		chassisAction = v1alpha1.ChassisAction_CHASSIS_ACTION_POWER_CYCLE
+	case chassisControlHardReset:
+		hostAction = v1alpha1.HostAction_HOST_ACTION_FORCE_RESTART
+	case chassisControlSoftShutdown:
+		hostAction = v1alpha1.HostAction_HOST_ACTION_OFF
+	default:
+		// Diagnostic interrupts have no statemgr equivalent.
+		return nil, CCInvalidField
+	}
+
+	// Power transitions can take longer than IPMI clients wait for a response,
+	// so the request is handed to statemgr and acknowledged immediately.
+	if chassisAction != v1alpha1.ChassisAction_CHASSIS_ACTION_UNSPECIFIED {
+		s.submit(ctx, "chassis control", func(ctx context.Context) error {
+			return s.changeChassisState(ctx, chassisAction)
+		})
+	} else {
+		s.submit(ctx, "chassis control", func(ctx context.Context) error {
+			return s.changeHostState(ctx, hostAction)
+		})
+	}
+
+	s.logger.InfoContext(ctx, "IPMI chassis control requested",
+		"control", req.msg.Data[0]&chassisControlMask,
+		"remote", req.remoteAddr)
+
+	return nil, CCSuccess
+}
+
+func (s *IPMISrv) handleChassisIdentify(ctx context.Context, req *request) ([]byte, uint8) {
+	if len(req.msg.Data) > 2 {
+		return nil, CCInvalidLength
+	}
+
+	interval := DefaultIdentifyInterval
+	if len(req.msg.Data) >= 1 {
+		interval = time.Duration(req.msg.Data[0]) * time.Second
+	}
+	force := len(req.msg.Data) == 2 && req.msg.Data[1]&chassisIdentifyForceOn != 0
+
+	s.identifyMu.Lock()
+	defer s.identifyMu.Unlock()
+
+	if s.identifyTimer != nil {
+		s.identifyTimer.Stop()
+		s.identifyTimer = nil
+	}
+
+	switch {
+	case force:
+		s.identify = identifyIndefinite
+	case interval == 0:
+		s.identify = identifyOff
+	default:
+		s.identify = identifyTemporary
+		s.identifyTimer = time.AfterFunc(interval, func() {
+			s.identifyMu.Lock()
+			s.identify = identifyOff
+			s.identifyTimer = nil
+			s.identifyMu.Unlock()
+
+			s.submit(ctx, "chassis identify", func(ctx context.Context) error {
+				return s.changeChassisState(ctx, v1alpha1.ChassisAction_CHASSIS_ACTION_IDENTIFY_OFF)
+			})
+		})
+	}
+
+	action := v1alpha1.ChassisAction_CHASSIS_ACTION_IDENTIFY_ON
+	if s.identify == identifyOff {
+		action = v1alpha1.ChassisAction_CHASSIS_ACTION_IDENTIFY_OFF
+	}
+	s.submit(ctx, "chassis identify", func(ctx context.Context) error {
+		return s.changeChassisState(ctx, action)
+	})
+
+	return nil, CCSuccess
+}
+
+// changeHostState requests a host state transition from statemgr.
+func (s *IPMISrv) changeHostState(ctx context.Context, action v1alpha1.HostAction) error {
+	req := &v1alpha1.ChangeHostStateRequest{
+		HostName: s.config.hostName,
+		Action:   action,
+	}
+	return s.requestNATS(ctx, ipc.SubjectHostControl, req, &v1alpha1.ChangeHostStateResponse{})
+}
+
+// changeChassisState requests a chassis state transition from statemgr.
+func (s *IPMISrv) changeChassisState(ctx context.Context, action v1alpha1.ChassisAction) error {
+	req := &v1alpha1.ChangeChassisStateRequest{
+		ChassisName: s.config.chassisName,
+		Action:      action,
+	}
+	return s.requestNATS(ctx, ipc.SubjectChassisControl, req, &v1alpha1.ChangeChassisStateResponse{})
+}
+
+// changeManagementControllerState requests a BMC state transition from statemgr.
+func (s *IPMISrv) changeManagementControllerState(ctx context.Context, action v1alpha1.ManagementControllerAction) error {
+	req := &v1alpha1.ChangeManagementControllerStateRequest{
+		ControllerName: s.config.bmcName,
+		Action:         action,
+	}
+	return s.requestNATS(ctx, ipc.SubjectBMCControl, req, &v1alpha1.ChangeManagementControllerStateResponse{})
+}
+
+// submit runs fn in the background with the configured request timeout and
+// logs its failure. It is used for actions that outlive the IPMI response.
+func (s *IPMISrv) submit(ctx context.Context, what string, fn func(ctx context.Context) error) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.config.requestTimeout)
+		defer cancel()
+
+		if err := fn(ctx); err != nil {
+			s.logger.ErrorContext(ctx, "IPMI action failed", "action", what, "error", err)
+		}
+	}()
+}
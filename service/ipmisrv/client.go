@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ipmisrv
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nats-io/nats.go/micro"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type vtMessage interface {
+	MarshalVT() ([]byte, error)
+}
+
+type vtUnmarshaler interface {
+	UnmarshalVT([]byte) error
+}
+
+// requestNATS sends a request to another service and decodes its response.
+func (s *IPMISrv) requestNATS(ctx context.Context, subject string, req vtMessage, resp vtUnmarshaler) error {
+	ctx, span := s.tracer.Start(ctx, "ipmisrv.requestNATS")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("nats.subject", subject))
+
+	data, err := req.MarshalVT()
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	msg, err := s.nc.RequestWithContext(ctx, subject, data)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("%w: %s: %w", ErrRequestFailed, subject, err)
+	}
+
+	if desc := msg.Header.Get(micro.ErrorHeader); desc != "" {
+		cause := ErrRequestFailed
+		switch msg.Header.Get(micro.ErrorCodeHeader) {
+		case "400":
+			cause = ErrInvalidArgument
+		case "404":
+			cause = ErrNotFound
+		}
+		err := fmt.Errorf("%w: %s: %s", cause, subject, desc)
+		span.RecordError(err)
+		return err
+	}
+
+	if err := resp.UnmarshalVT(msg.Data); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return nil
+}
@@ -2,9 +2,83 @@
 
 package ipmisrv
 
+import (
+	"fmt"
+	"time"
+)
+
+// Default configuration constants.
+const (
+	DefaultServiceName        = "ipmisrv"
+	DefaultServiceDescription = "IPMI server for out-of-band and in-band BMC management"
+	DefaultServiceVersion     = "1.0.0"
+	DefaultLANAddress         = ":623"
+	DefaultLANChannel         = 1
+	DefaultMaxSessions        = 16
+	DefaultSessionTimeout     = 60 * time.Second
+	DefaultAuthTimeout        = 5 * time.Second
+	DefaultGUIDPath           = "/var/ipmisrv/id"
+	DefaultKeyStorePath       = "/var/ipmisrv/keys"
+	DefaultMaxInflight        = 64
+	DefaultMaxUsers           = 15
+	DefaultRequestTimeout     = 10 * time.Second
+	DefaultHostName           = "host.0"
+	DefaultChassisName        = "chassis.0"
+	DefaultBMCName            = "bmc.0"
+	DefaultIdentifyInterval   = 15 * time.Second
+	DefaultSDRRefreshInterval = 30 * time.Second
+	DefaultFRUPath            = "/etc/fru/baseboard.fru.bin"
+	DefaultKCSDevice          = "/dev/ipmi-kcs3"
+)
+
 // config holds the configuration for the IPMI server service.
 type config struct {
-	name string
+	name        string
+	description string
+	version     string
+
+	// LAN (RMCP+) configuration
+	enableLAN      bool
+	lanAddress     string
+	lanChannel     uint8
+	maxSessions    int
+	sessionTimeout time.Duration
+	authTimeout    time.Duration
+	maxInflight    int
+	maxUsers       int
+
+	// In-band host interfaces
+	hostInterfaces []HostInterface
+
+	// Security configuration
+	kg           []byte
+	keyStore     KeyStore
+	keyStorePath string
+	guidPath     string
+
+	// Managed component configuration
+	hostName       string
+	chassisName    string
+	bmcName        string
+	requestTimeout time.Duration
+
+	// Sensor data repository configuration
+	sdrRefreshInterval time.Duration
+
+	// FRU device ID to FRU image path
+	fruDevices map[uint8]string
+
+	// Sensor ID to DCMI temperature entity ID
+	dcmiTemperatureSensors map[string]uint8
+
+	// Device identity reported by Get Device ID
+	deviceID       uint8
+	deviceRevision uint8
+	firmwareMajor  uint8
+	firmwareMinor  uint8
+	manufacturerID uint32
+	productID      uint16
+	auxFirmwareRev [4]byte
 }
 
 // Option represents a configuration option for the IPMI server service.
@@ -20,8 +94,459 @@ func (o *nameOption) apply(c *config) {
 	c.name = o.name
 }
 
+// WithServiceName sets the service name.
 func WithServiceName(name string) Option {
 	return &nameOption{
 		name: name,
 	}
 }
+
+type descriptionOption struct {
+	description string
+}
+
+func (o *descriptionOption) apply(c *config) {
+	c.description = o.description
+}
+
+// WithServiceDescription sets the service description.
+func WithServiceDescription(description string) Option {
+	return &descriptionOption{description: description}
+}
+
+type versionOption struct {
+	version string
+}
+
+func (o *versionOption) apply(c *config) {
+	c.version = o.version
+}
+
+// WithServiceVersion sets the service version.
+func WithServiceVersion(version string) Option {
+	return &versionOption{version: version}
+}
+
+type lanOption struct {
+	enable bool
+}
+
+func (o *lanOption) apply(c *config) {
+	c.enableLAN = o.enable
+}
+
+// WithLAN enables or disables the IPMI-over-LAN (RMCP+) listener.
+func WithLAN(enable bool) Option {
+	return &lanOption{enable: enable}
+}
+
+type lanAddressOption struct {
+	address string
+}
+
+func (o *lanAddressOption) apply(c *config) {
+	c.lanAddress = o.address
+}
+
+// WithLANAddress sets the UDP address the RMCP+ listener binds to.
+func WithLANAddress(address string) Option {
+	return &lanAddressOption{address: address}
+}
+
+type lanChannelOption struct {
+	channel uint8
+}
+
+func (o *lanChannelOption) apply(c *config) {
+	c.lanChannel = o.channel
+}
+
+// WithLANChannel sets the IPMI channel number reported for the LAN interface.
+func WithLANChannel(channel uint8) Option {
+	return &lanChannelOption{channel: channel}
+}
+
+type maxSessionsOption struct {
+	maxSessions int
+}
+
+func (o *maxSessionsOption) apply(c *config) {
+	c.maxSessions = o.maxSessions
+}
+
+// WithMaxSessions sets the maximum number of concurrent RMCP+ sessions.
+func WithMaxSessions(maxSessions int) Option {
+	return &maxSessionsOption{maxSessions: maxSessions}
+}
+
+type sessionTimeoutOption struct {
+	timeout time.Duration
+}
+
+func (o *sessionTimeoutOption) apply(c *config) {
+	c.sessionTimeout = o.timeout
+}
+
+// WithSessionTimeout sets the idle timeout after which RMCP+ sessions are closed.
+func WithSessionTimeout(timeout time.Duration) Option {
+	return &sessionTimeoutOption{timeout: timeout}
+}
+
+type authTimeoutOption struct {
+	timeout time.Duration
+}
+
+func (o *authTimeoutOption) apply(c *config) {
+	c.authTimeout = o.timeout
+}
+
+// WithAuthTimeout sets the timeout for credential checks against usermgr.
+func WithAuthTimeout(timeout time.Duration) Option {
+	return &authTimeoutOption{timeout: timeout}
+}
+
+type maxInflightOption struct {
+	maxInflight int
+}
+
+func (o *maxInflightOption) apply(c *config) {
+	c.maxInflight = o.maxInflight
+}
+
+// WithMaxInflight sets the maximum number of LAN packets processed concurrently.
+func WithMaxInflight(maxInflight int) Option {
+	return &maxInflightOption{maxInflight: maxInflight}
+}
+
+type hostInterfaceOption struct {
+	iface HostInterface
+}
+
+func (o *hostInterfaceOption) apply(c *config) {
+	c.hostInterfaces = append(c.hostInterfaces, o.iface)
+}
+
+// WithHostInterface adds an in-band host interface. Unset names default to the
+// device path, unset channels to the system interface and unset privilege
+// levels to administrator.
+func WithHostInterface(iface HostInterface) Option {
+	if iface.Name == "" {
+		iface.Name = iface.Path
+	}
+	if iface.Channel == 0 {
+		iface.Channel = ChannelSystemInterface
+	}
+	if iface.Privilege == PrivilegeNone {
+		iface.Privilege = PrivilegeAdmin
+	}
+	return &hostInterfaceOption{iface: iface}
+}
+
+// WithKCSDevice adds a KCS host interface on the system interface channel
+// served from the given character device, e.g. DefaultKCSDevice.
+func WithKCSDevice(path string) Option {
+	return WithHostInterface(HostInterface{
+		Path:    path,
+		Framing: HostFramingKCS,
+	})
+}
+
+type maxUsersOption struct {
+	maxUsers int
+}
+
+func (o *maxUsersOption) apply(c *config) {
+	c.maxUsers = o.maxUsers
+}
+
+// WithMaxUsers sets the number of IPMI user IDs reported by Get User Access.
+// User ID 1 is the reserved null user, so at least two are required.
+func WithMaxUsers(maxUsers int) Option {
+	return &maxUsersOption{maxUsers: maxUsers}
+}
+
+type kgOption struct {
+	kg []byte
+}
+
+func (o *kgOption) apply(c *config) {
+	c.kg = o.kg
+}
+
+// WithKG sets the BMC key (Kg) used to derive session integrity keys.
+// When unset, the user key is used as specified by IPMI v2.0.
+func WithKG(kg []byte) Option {
+	return &kgOption{kg: kg}
+}
+
+type keyStoreOption struct {
+	keyStore KeyStore
+}
+
+func (o *keyStoreOption) apply(c *config) {
+	c.keyStore = o.keyStore
+}
+
+// WithKeyStore sets the store that provides the per-user RAKP keys. When
+// unset, the keys are kept in a FileKeyStore at the WithKeyStorePath path.
+func WithKeyStore(keyStore KeyStore) Option {
+	return &keyStoreOption{keyStore: keyStore}
+}
+
+type keyStorePathOption struct {
+	path string
+}
+
+func (o *keyStorePathOption) apply(c *config) {
+	c.keyStorePath = o.path
+}
+
+// WithKeyStorePath sets the file of the default FileKeyStore. Its
+// encryption key is kept next to it with a .key suffix.
+func WithKeyStorePath(path string) Option {
+	return &keyStorePathOption{path: path}
+}
+
+type guidPathOption struct {
+	path string
+}
+
+func (o *guidPathOption) apply(c *config) {
+	c.guidPath = o.path
+}
+
+// WithGUIDPath sets the directory in which the persistent system GUID is stored.
+func WithGUIDPath(path string) Option {
+	return &guidPathOption{path: path}
+}
+
+type componentNamesOption struct {
+	hostName    string
+	chassisName string
+	bmcName     string
+}
+
+func (o *componentNamesOption) apply(c *config) {
+	c.hostName = o.hostName
+	c.chassisName = o.chassisName
+	c.bmcName = o.bmcName
+}
+
+// WithComponentNames sets the statemgr component names controlled through IPMI.
+func WithComponentNames(hostName, chassisName, bmcName string) Option {
+	return &componentNamesOption{
+		hostName:    hostName,
+		chassisName: chassisName,
+		bmcName:     bmcName,
+	}
+}
+
+type requestTimeoutOption struct {
+	timeout time.Duration
+}
+
+func (o *requestTimeoutOption) apply(c *config) {
+	c.requestTimeout = o.timeout
+}
+
+// WithRequestTimeout sets the timeout for requests to other services.
+func WithRequestTimeout(timeout time.Duration) Option {
+	return &requestTimeoutOption{timeout: timeout}
+}
+
+type sdrRefreshIntervalOption struct {
+	interval time.Duration
+}
+
+func (o *sdrRefreshIntervalOption) apply(c *config) {
+	c.sdrRefreshInterval = o.interval
+}
+
+// WithSDRRefreshInterval sets how often the sensor list is polled to detect
+// added or removed sensors and regenerate the SDR repository.
+func WithSDRRefreshInterval(interval time.Duration) Option {
+	return &sdrRefreshIntervalOption{interval: interval}
+}
+
+type fruDeviceOption struct {
+	id   uint8
+	path string
+}
+
+func (o *fruDeviceOption) apply(c *config) {
+	if o.path == "" {
+		delete(c.fruDevices, o.id)
+		return
+	}
+	c.fruDevices[o.id] = o.path
+}
+
+// WithFRUDevice serves the FRU image at path, such as an EEPROM exposed
+// through sysfs, as the logical FRU device with the given ID. Device 0 is the
+// BMC's own FRU and defaults to DefaultFRUPath; an empty path removes a device.
+func WithFRUDevice(id uint8, path string) Option {
+	return &fruDeviceOption{id: id, path: path}
+}
+
+type dcmiTemperatureSensorOption struct {
+	entity uint8
+	ids    []string
+}
+
+func (o *dcmiTemperatureSensorOption) apply(c *config) {
+	for _, id := range o.ids {
+		c.dcmiTemperatureSensors[id] = o.entity
+	}
+}
+
+// WithDCMITemperatureSensor reports the temperature sensors with the given IDs
+// as DCMIEntityInlet, DCMIEntityCPU or DCMIEntityBaseboard in DCMI Get
+// Temperature Reading. Sensors without an explicit entity are classified by
+// their ID and name.
+func WithDCMITemperatureSensor(entity uint8, ids ...string) Option {
+	return &dcmiTemperatureSensorOption{entity: entity, ids: ids}
+}
+
+type deviceIDOption struct {
+	deviceID       uint8
+	deviceRevision uint8
+}
+
+func (o *deviceIDOption) apply(c *config) {
+	c.deviceID = o.deviceID
+	c.deviceRevision = o.deviceRevision
+}
+
+// WithDeviceID sets the device ID and device revision reported by Get Device ID.
+func WithDeviceID(deviceID, deviceRevision uint8) Option {
+	return &deviceIDOption{
+		deviceID:       deviceID,
+		deviceRevision: deviceRevision,
+	}
+}
+
+type firmwareRevisionOption struct {
+	major uint8
+	minor uint8
+	aux   [4]byte
+}
+
+func (o *firmwareRevisionOption) apply(c *config) {
+	c.firmwareMajor = o.major
+	c.firmwareMinor = o.minor
+	c.auxFirmwareRev = o.aux
+}
+
+// WithFirmwareRevision sets the firmware revision reported by Get Device ID.
+func WithFirmwareRevision(major, minor uint8, aux [4]byte) Option {
+	return &firmwareRevisionOption{
+		major: major,
+		minor: minor,
+		aux:   aux,
+	}
+}
+
+type manufacturerOption struct {
+	manufacturerID uint32
+	productID      uint16
+}
+
+func (o *manufacturerOption) apply(c *config) {
+	c.manufacturerID = o.manufacturerID
+	c.productID = o.productID
+}
+
+// WithManufacturer sets the IANA manufacturer ID and product ID reported by Get Device ID.
+func WithManufacturer(manufacturerID uint32, productID uint16) Option {
+	return &manufacturerOption{
+		manufacturerID: manufacturerID,
+		productID:      productID,
+	}
+}
+
+// Validate validates the configuration.
+func (c *config) Validate() error {
+	if c.name == "" {
+		return fmt.Errorf("service name cannot be empty")
+	}
+
+	if c.enableLAN && c.lanAddress == "" {
+		return fmt.Errorf("LAN address cannot be empty when LAN is enabled")
+	}
+
+	if c.lanChannel == 0 || c.lanChannel > 0x0B {
+		return fmt.Errorf("LAN channel must be between 1 and 11")
+	}
+
+	if c.maxSessions <= 0 {
+		return fmt.Errorf("maximum sessions must be positive")
+	}
+
+	if c.sessionTimeout <= 0 {
+		return fmt.Errorf("session timeout must be positive")
+	}
+
+	if c.authTimeout <= 0 {
+		return fmt.Errorf("auth timeout must be positive")
+	}
+
+	if c.maxInflight <= 0 {
+		return fmt.Errorf("maximum inflight packets must be positive")
+	}
+
+	for i := range c.hostInterfaces {
+		if err := c.hostInterfaces[i].validate(c.lanChannel); err != nil {
+			return err
+		}
+	}
+
+	if c.maxUsers < 2 || c.maxUsers > int(userIDMask) {
+		return fmt.Errorf("maximum users must be between 2 and %d", userIDMask)
+	}
+
+	if c.keyStore == nil && c.keyStorePath == "" {
+		return fmt.Errorf("key store path cannot be empty without a key store")
+	}
+
+	if len(c.kg) > keyLength {
+		return fmt.Errorf("KG must not exceed %d bytes", keyLength)
+	}
+
+	if c.keyStore == nil {
+		return fmt.Errorf("key store cannot be nil")
+	}
+
+	if c.hostName == "" || c.chassisName == "" || c.bmcName == "" {
+		return fmt.Errorf("component names cannot be empty")
+	}
+
+	if c.requestTimeout <= 0 {
+		return fmt.Errorf("request timeout must be positive")
+	}
+
+	if c.sdrRefreshInterval <= 0 {
+		return fmt.Errorf("SDR refresh interval must be positive")
+	}
+
+	if _, ok := c.fruDevices[fruDeviceReserved]; ok {
+		return fmt.Errorf("FRU device ID 0xFF is reserved")
+	}
+
+	for id, entity := range c.dcmiTemperatureSensors {
+		switch entity {
+		case DCMIEntityInlet, DCMIEntityCPU, DCMIEntityBaseboard:
+		default:
+			return fmt.Errorf("invalid DCMI entity 0x%02x for sensor %q", entity, id)
+		}
+	}
+
+	if c.firmwareMajor > 0x7F || c.firmwareMinor > 99 {
+		return fmt.Errorf("firmware revision must be at most 127.99")
+	}
+
+	if c.manufacturerID > 0xFFFFF {
+		return fmt.Errorf("manufacturer ID must fit in 20 bits")
+	}
+
+	return nil
+}
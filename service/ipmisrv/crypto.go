@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ipmisrv
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec // RAKP-HMAC-SHA1 is mandated by cipher suite 3
+	"crypto/sha256"
+	"hash"
+)
+
+// Authentication algorithm numbers.
+const (
+	authRAKPHMACSHA1   uint8 = 0x01
+	authRAKPHMACSHA256 uint8 = 0x03
+)
+
+// Integrity algorithm numbers.
+const (
+	integrityHMACSHA196    uint8 = 0x01
+	integrityHMACSHA256128 uint8 = 0x04
+)
+
+// Confidentiality algorithm numbers.
+const (
+	confidentialityAESCBC128 uint8 = 0x01
+)
+
+// Cipher suite record tags used by Get Channel Cipher Suites.
+const (
+	cipherSuiteRecordStandard uint8 = 0xC0
+	cipherSuiteTagIntegrity   uint8 = 0x40
+	cipherSuiteTagConf        uint8 = 0x80
+)
+
+const aesBlockSize = aes.BlockSize
+
+// cipherSuite describes a supported combination of RMCP+ algorithms.
+type cipherSuite struct {
+	id              uint8
+	auth            uint8
+	integrity       uint8
+	confidentiality uint8
+	// newHash is the hash function used for RAKP, SIK and integrity.
+	newHash func() hash.Hash
+	// icvLen is the truncated length of the RAKP4 and session integrity codes.
+	icvLen int
+}
+
+// supportedCipherSuites returns the cipher suites accepted on the LAN channel,
+// ordered from most to least preferred.
+func supportedCipherSuites() []cipherSuite {
+	return []cipherSuite{
+		{
+			id:              17,
+			auth:            authRAKPHMACSHA256,
+			integrity:       integrityHMACSHA256128,
+			confidentiality: confidentialityAESCBC128,
+			newHash:         sha256.New,
+			icvLen:          16,
+		},
+		{
+			id:              3,
+			auth:            authRAKPHMACSHA1,
+			integrity:       integrityHMACSHA196,
+			confidentiality: confidentialityAESCBC128,
+			newHash:         sha1.New,
+			icvLen:          12,
+		},
+	}
+}
+
+// record returns the Get Channel Cipher Suites record for the suite.
+func (cs cipherSuite) record() []byte {
+	return []byte{
+		cipherSuiteRecordStandard,
+		cs.id,
+		cs.auth,
+		cipherSuiteTagIntegrity | cs.integrity,
+		cipherSuiteTagConf | cs.confidentiality,
+	}
+}
+
+// hmac computes the HMAC of the concatenated parts with the suite hash.
+func (cs cipherSuite) hmac(key []byte, parts ...[]byte) []byte {
+	mac := hmac.New(cs.newHash, key)
+	for _, p := range parts {
+		mac.Write(p)
+	}
+	return mac.Sum(nil)
+}
+
+// deriveKeys derives the additional keying material K1 and K2 from the SIK.
+func (cs cipherSuite) deriveKeys(sik []byte) ([]byte, []byte) {
+	return cs.hmac(sik, bytes.Repeat([]byte{0x01}, keyLength)),
+		cs.hmac(sik, bytes.Repeat([]byte{0x02}, keyLength))
+}
+
+// authCode computes the truncated session integrity code over data using K1.
+func (cs cipherSuite) authCode(k1, data []byte) []byte {
+	return cs.hmac(k1, data)[:cs.icvLen]
+}
+
+// encryptPayload encrypts payload with AES-CBC-128 using the first 16 bytes of K2.
+// The result is the random IV followed by the ciphertext.
+func encryptPayload(k2, payload []byte) ([]byte, error) {
+	block, err := aes.NewCipher(k2[:aesBlockSize])
+	if err != nil {
+		return nil, err
+	}
+
+	padLen := (aesBlockSize - (len(payload)+1)%aesBlockSize) % aesBlockSize
+	plain := make([]byte, 0, len(payload)+padLen+1)
+	plain = append(plain, payload...)
+	for i := 1; i <= padLen; i++ {
+		plain = append(plain, byte(i))
+	}
+	plain = append(plain, byte(padLen))
+
+	out := make([]byte, aesBlockSize+len(plain))
+	if _, err := rand.Read(out[:aesBlockSize]); err != nil {
+		return nil, err
+	}
+	cipher.NewCBCEncrypter(block, out[:aesBlockSize]).CryptBlocks(out[aesBlockSize:], plain)
+
+	return out, nil
+}
+
+// decryptPayload reverses encryptPayload and validates the confidentiality trailer.
+func decryptPayload(k2, data []byte) ([]byte, error) {
+	if len(data) < 2*aesBlockSize || len(data)%aesBlockSize != 0 {
+		return nil, ErrDecryptionFailed
+	}
+
+	block, err := aes.NewCipher(k2[:aesBlockSize])
+	if err != nil {
+		return nil, err
+	}
+
+	plain := make([]byte, len(data)-aesBlockSize)
+	cipher.NewCBCDecrypter(block, data[:aesBlockSize]).CryptBlocks(plain, data[aesBlockSize:])
+
+	padLen := int(plain[len(plain)-1])
+	if padLen >= aesBlockSize || padLen+1 > len(plain) {
+		return nil, ErrDecryptionFailed
+	}
+	pad := plain[len(plain)-1-padLen : len(plain)-1]
+	for i, b := range pad {
+		if b != byte(i+1) {
+			return nil, ErrDecryptionFailed
+		}
+	}
+
+	return plain[:len(plain)-1-padLen], nil
+}
@@ -0,0 +1,447 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ipmisrv
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	v1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+	"github.com/u-bmc/u-bmc/pkg/ipc"
+	"github.com/u-bmc/u-bmc/pkg/sel"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Group extension network function DCMI commands.
+const (
+	cmdGetDCMICapabilities       uint8 = 0x01
+	cmdGetPowerReading           uint8 = 0x02
+	cmdGetPowerLimit             uint8 = 0x03
+	cmdSetPowerLimit             uint8 = 0x04
+	cmdActivatePowerLimit        uint8 = 0x05
+	cmdGetDCMITemperatureReading uint8 = 0x10
+)
+
+// DCMI entity IDs of the temperature sensors reported by Get Temperature
+// Reading.
+const (
+	DCMIEntityInlet     uint8 = 0x40
+	DCMIEntityCPU       uint8 = 0x41
+	DCMIEntityBaseboard uint8 = 0x42
+)
+
+// IPMI entity IDs accepted in place of the DCMI entity IDs.
+const (
+	entityProcessor uint8 = 0x03
+	entityAirInlet  uint8 = 0x37
+)
+
+// DCMI command fields.
+const (
+	dcmiGroupExtension        uint8 = 0xDC
+	dcmiVersionMajor          uint8 = 0x01
+	dcmiVersionMinor          uint8 = 0x05
+	dcmiParameterRevision     uint8 = 0x02
+	dcmiUnsupportedChannel    uint8 = 0xFF
+	dcmiPowerManagement       uint8 = 0x01
+	dcmiPowerControllerAddr   uint8 = 0x20
+	dcmiPrimaryLANOOB         uint8 = 0x08
+	dcmiSELEntriesMask              = 0x0FFF
+	dcmiPowerModeSystem       uint8 = 0x01
+	dcmiPowerMeasurementOn    uint8 = 0x40
+	dcmiPowerReadingLen             = 3
+	dcmiPowerReadingRespLen         = 17
+	dcmiGetPowerLimitLen            = 2
+	dcmiSetPowerLimitLen            = 14
+	dcmiActivatePowerLimitLen       = 3
+	dcmiActionNone            uint8 = 0x00
+	dcmiActionHardPowerOff    uint8 = 0x01
+	dcmiActionLogEvent        uint8 = 0x11
+	dcmiMaxCorrectionTimeMs         = 3600 * 1000
+	dcmiMaxSamplingPeriod           = 3600
+	dcmiTemperatureReadingLen       = 4
+	dcmiSensorTypeTemperature uint8 = 0x01
+	dcmiMaxTemperatures             = 8
+	dcmiTemperatureSign       uint8 = 0x80
+	dcmiTemperatureMax              = 0x7F
+)
+
+// DCMI capability parameter selectors.
+const (
+	dcmiParamSupportedCapabilities uint8 = 0x01
+	dcmiParamPlatformAttributes    uint8 = 0x02
+	dcmiParamOptionalAttributes    uint8 = 0x03
+	dcmiParamAccessAttributes      uint8 = 0x04
+	dcmiParamPowerStatistics       uint8 = 0x05
+)
+
+// DCMI completion codes.
+const (
+	dcmiCCNoActivePowerLimit       uint8 = 0x80
+	dcmiCCPowerLimitOutOfRange     uint8 = 0x84
+	dcmiCCCorrectionTimeOutOfRange uint8 = 0x85
+	dcmiCCSamplingPeriodOutOfRange uint8 = 0x89
+)
+
+// dcmiHandler processes the data of a DCMI request following the group
+// extension ID and returns the response data without it.
+type dcmiHandler func(ctx context.Context, data []byte) ([]byte, uint8)
+
+// dcmi wraps a DCMI command handler, verifying the group extension ID of the
+// request and prepending it to the response.
+func dcmi(handler dcmiHandler) commandHandler {
+	return func(ctx context.Context, req *request) ([]byte, uint8) {
+		if len(req.msg.Data) == 0 {
+			return nil, CCInvalidLength
+		}
+		if req.msg.Data[0] != dcmiGroupExtension {
+			return nil, CCInvalidField
+		}
+		data, cc := handler(ctx, req.msg.Data[1:])
+		return append([]byte{dcmiGroupExtension}, data...), cc
+	}
+}
+
+// registerDCMICommands registers the DCMI power and thermal management commands.
+func (s *IPMISrv) registerDCMICommands() {
+	s.dispatcher.register(NetFnGroupExt, cmdGetDCMICapabilities, PrivilegeUser, dcmi(s.handleGetDCMICapabilities))
+	s.dispatcher.register(NetFnGroupExt, cmdGetPowerReading, PrivilegeUser, dcmi(s.handleGetPowerReading))
+	s.dispatcher.register(NetFnGroupExt, cmdGetPowerLimit, PrivilegeUser, dcmi(s.handleGetPowerLimit))
+	s.dispatcher.register(NetFnGroupExt, cmdSetPowerLimit, PrivilegeOperator, dcmi(s.handleSetPowerLimit))
+	s.dispatcher.register(NetFnGroupExt, cmdActivatePowerLimit, PrivilegeOperator, dcmi(s.handleActivatePowerLimit))
+	s.dispatcher.register(NetFnGroupExt, cmdGetDCMITemperatureReading, PrivilegeUser, dcmi(s.handleGetDCMITemperatureReading))
+}
+
+func (s *IPMISrv) handleGetDCMICapabilities(ctx context.Context, data []byte) ([]byte, uint8) {
+	if len(data) != 1 {
+		return nil, CCInvalidLength
+	}
+
+	out := []byte{dcmiVersionMajor, dcmiVersionMinor, dcmiParameterRevision}
+	switch data[0] {
+	case dcmiParamSupportedCapabilities:
+		var access uint8
+		if s.config.enableLAN {
+			access |= dcmiPrimaryLANOOB
+		}
+		return append(out, 0x00, dcmiPowerManagement, access), CCSuccess
+
+	case dcmiParamPlatformAttributes:
+		ctx, cancel := context.WithTimeout(ctx, s.config.requestTimeout)
+		defer cancel()
+
+		resp := &v1alpha1.GetSystemEventLogInfoResponse{}
+		if err := s.requestNATS(ctx, ipc.SubjectSELInfo, &v1alpha1.GetSystemEventLogInfoRequest{}, resp); err != nil {
+			s.logger.WarnContext(ctx, "Failed to get SEL info", "error", err)
+			return nil, CCDestinationUnavail
+		}
+
+		// The temperature sampling frequency is left unspecified since
+		// sensors are polled by sensormon, not by the IPMI server.
+		out = binary.LittleEndian.AppendUint16(out, uint16(min(resp.GetMaxEntries(), dcmiSELEntriesMask)))
+		return append(out, 0x00, 0x00, 0x00), CCSuccess
+
+	case dcmiParamOptionalAttributes:
+		return append(out, dcmiPowerControllerAddr, 0x00), CCSuccess
+
+	case dcmiParamAccessAttributes:
+		lan := dcmiUnsupportedChannel
+		if s.config.enableLAN {
+			lan = s.config.lanChannel
+		}
+		return append(out, lan, dcmiUnsupportedChannel, dcmiUnsupportedChannel), CCSuccess
+
+	case dcmiParamPowerStatistics:
+		// Only the system power statistics mode is supported, which has no
+		// rolling average periods.
+		return append(out, 0x00), CCSuccess
+
+	default:
+		return nil, CCParameterOutOfRange
+	}
+}
+
+// dcmiWatts converts a power value to the 16-bit watts of DCMI responses.
+func dcmiWatts(watts float64) uint16 {
+	return uint16(math.Round(max(0, min(watts, math.MaxUint16))))
+}
+
+func (s *IPMISrv) handleGetPowerReading(ctx context.Context, data []byte) ([]byte, uint8) {
+	if len(data) != dcmiPowerReadingLen {
+		return nil, CCInvalidLength
+	}
+	if data[0] != dcmiPowerModeSystem {
+		return nil, CCInvalidField
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.config.requestTimeout)
+	defer cancel()
+
+	resp := &v1alpha1.GetPowerReadingResponse{}
+	err := s.requestNATS(ctx, ipc.SubjectPowerReading, &v1alpha1.GetPowerReadingRequest{}, resp)
+	switch {
+	case errors.Is(err, ErrNotFound):
+		// No power sensor has been read yet; report the measurement as
+		// inactive rather than failing the command.
+		return make([]byte, dcmiPowerReadingRespLen), CCSuccess
+	case err != nil:
+		s.logger.WarnContext(ctx, "Failed to get power reading", "error", err)
+		return nil, CCDestinationUnavail
+	}
+
+	reading := resp.GetReading()
+	var timestamp time.Time
+	if reading.GetTimestamp() != nil {
+		timestamp = reading.GetTimestamp().AsTime()
+	}
+	period := reading.GetStatisticsPeriod().AsDuration().Milliseconds()
+
+	out := make([]byte, 0, dcmiPowerReadingRespLen)
+	out = binary.LittleEndian.AppendUint16(out, dcmiWatts(reading.GetCurrentWatts()))
+	out = binary.LittleEndian.AppendUint16(out, dcmiWatts(reading.GetMinimumWatts()))
+	out = binary.LittleEndian.AppendUint16(out, dcmiWatts(reading.GetMaximumWatts()))
+	out = binary.LittleEndian.AppendUint16(out, dcmiWatts(reading.GetAverageWatts()))
+	out = binary.LittleEndian.AppendUint32(out, sel.Timestamp(timestamp))
+	out = binary.LittleEndian.AppendUint32(out, uint32(min(period, math.MaxUint32)))
+	out = append(out, dcmiPowerMeasurementOn)
+
+	return out, CCSuccess
+}
+
+// dcmiExceptionAction maps a power limit exception action to its DCMI value.
+func dcmiExceptionAction(action v1alpha1.PowerLimitExceptionAction) uint8 {
+	switch action {
+	case v1alpha1.PowerLimitExceptionAction_POWER_LIMIT_EXCEPTION_ACTION_HARD_POWER_OFF:
+		return dcmiActionHardPowerOff
+	case v1alpha1.PowerLimitExceptionAction_POWER_LIMIT_EXCEPTION_ACTION_LOG_EVENT:
+		return dcmiActionLogEvent
+	default:
+		return dcmiActionNone
+	}
+}
+
+func (s *IPMISrv) handleGetPowerLimit(ctx context.Context, data []byte) ([]byte, uint8) {
+	if len(data) != dcmiGetPowerLimitLen {
+		return nil, CCInvalidLength
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.config.requestTimeout)
+	defer cancel()
+
+	resp := &v1alpha1.GetPowerLimitResponse{}
+	if err := s.requestNATS(ctx, ipc.SubjectPowerLimitInfo, &v1alpha1.GetPowerLimitRequest{}, resp); err != nil {
+		s.logger.WarnContext(ctx, "Failed to get power limit", "error", err)
+		return nil, CCDestinationUnavail
+	}
+
+	limit := resp.GetLimit()
+	correction := limit.GetCorrectionTime().AsDuration().Milliseconds()
+	sampling := limit.GetSamplingPeriod().AsDuration() / time.Second
+
+	out := make([]byte, 0, 13)
+	out = append(out, 0x00, 0x00, dcmiExceptionAction(limit.GetExceptionAction()))
+	out = binary.LittleEndian.AppendUint16(out, uint16(min(limit.GetLimitWatts(), math.MaxUint16)))
+	out = binary.LittleEndian.AppendUint32(out, uint32(min(correction, math.MaxUint32)))
+	out = append(out, 0x00, 0x00)
+	out = binary.LittleEndian.AppendUint16(out, uint16(min(sampling, math.MaxUint16)))
+
+	// The stored limit is returned along with the completion code so
+	// clients can show it even while it is not enforced.
+	if !limit.GetActive() {
+		return out, dcmiCCNoActivePowerLimit
+	}
+	return out, CCSuccess
+}
+
+func (s *IPMISrv) handleSetPowerLimit(ctx context.Context, data []byte) ([]byte, uint8) {
+	if len(data) != dcmiSetPowerLimitLen {
+		return nil, CCInvalidLength
+	}
+
+	var action v1alpha1.PowerLimitExceptionAction
+	switch data[3] {
+	case dcmiActionNone:
+		action = v1alpha1.PowerLimitExceptionAction_POWER_LIMIT_EXCEPTION_ACTION_NONE
+	case dcmiActionHardPowerOff:
+		action = v1alpha1.PowerLimitExceptionAction_POWER_LIMIT_EXCEPTION_ACTION_HARD_POWER_OFF
+	case dcmiActionLogEvent:
+		action = v1alpha1.PowerLimitExceptionAction_POWER_LIMIT_EXCEPTION_ACTION_LOG_EVENT
+	default:
+		return nil, CCInvalidField
+	}
+
+	watts := uint32(binary.LittleEndian.Uint16(data[4:6]))
+	correction := binary.LittleEndian.Uint32(data[6:10])
+	sampling := binary.LittleEndian.Uint16(data[12:14])
+
+	switch {
+	case watts == 0:
+		return nil, dcmiCCPowerLimitOutOfRange
+	case correction == 0 || correction > dcmiMaxCorrectionTimeMs:
+		return nil, dcmiCCCorrectionTimeOutOfRange
+	case sampling == 0 || sampling > dcmiMaxSamplingPeriod:
+		return nil, dcmiCCSamplingPeriodOutOfRange
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.config.requestTimeout)
+	defer cancel()
+
+	req := &v1alpha1.SetPowerLimitRequest{
+		LimitWatts:      &watts,
+		ExceptionAction: &action,
+		CorrectionTime:  durationpb.New(time.Duration(correction) * time.Millisecond),
+		SamplingPeriod:  durationpb.New(time.Duration(sampling) * time.Second),
+	}
+	err := s.requestNATS(ctx, ipc.SubjectPowerLimitSet, req, &v1alpha1.SetPowerLimitResponse{})
+	switch {
+	case errors.Is(err, ErrInvalidArgument):
+		s.logger.DebugContext(ctx, "Power limit rejected", "error", err)
+		return nil, CCInvalidField
+	case err != nil:
+		s.logger.WarnContext(ctx, "Failed to set power limit", "error", err)
+		return nil, CCDestinationUnavail
+	}
+
+	return nil, CCSuccess
+}
+
+func (s *IPMISrv) handleActivatePowerLimit(ctx context.Context, data []byte) ([]byte, uint8) {
+	if len(data) != dcmiActivatePowerLimitLen {
+		return nil, CCInvalidLength
+	}
+	if data[0] > 0x01 {
+		return nil, CCInvalidField
+	}
+	active := data[0] == 0x01
+
+	ctx, cancel := context.WithTimeout(ctx, s.config.requestTimeout)
+	defer cancel()
+
+	req := &v1alpha1.SetPowerLimitRequest{Active: &active}
+	err := s.requestNATS(ctx, ipc.SubjectPowerLimitSet, req, &v1alpha1.SetPowerLimitResponse{})
+	switch {
+	case errors.Is(err, ErrInvalidArgument):
+		// Activation is only rejected while no power limit has been set.
+		return nil, dcmiCCNoActivePowerLimit
+	case err != nil:
+		s.logger.WarnContext(ctx, "Failed to activate power limit", "active", active, "error", err)
+		return nil, CCDestinationUnavail
+	}
+
+	return nil, CCSuccess
+}
+
+// dcmiEntity maps a requested entity ID to the DCMI entity ID.
+func dcmiEntity(entity uint8) (uint8, bool) {
+	switch entity {
+	case DCMIEntityInlet, entityAirInlet:
+		return DCMIEntityInlet, true
+	case DCMIEntityCPU, entityProcessor:
+		return DCMIEntityCPU, true
+	case DCMIEntityBaseboard, entitySystemBoard:
+		return DCMIEntityBaseboard, true
+	default:
+		return 0, false
+	}
+}
+
+// temperatureEntity returns the DCMI entity of a temperature sensor, either as
+// configured or derived from the sensor ID and name.
+func (s *IPMISrv) temperatureEntity(sensor *v1alpha1.Sensor) uint8 {
+	if entity, ok := s.config.dcmiTemperatureSensors[sensor.GetId()]; ok {
+		return entity
+	}
+
+	name := strings.ToLower(sensor.GetId() + " " + sensor.GetName())
+	switch {
+	case strings.Contains(name, "inlet"), strings.Contains(name, "ambient"):
+		return DCMIEntityInlet
+	case strings.Contains(name, "cpu"), strings.Contains(name, "processor"):
+		return DCMIEntityCPU
+	default:
+		return DCMIEntityBaseboard
+	}
+}
+
+// dcmiTemperature encodes a temperature reading in degrees Celsius as a
+// sign-magnitude byte.
+func dcmiTemperature(sensor *v1alpha1.Sensor) uint8 {
+	if !readingAvailable(sensor) {
+		return 0x00
+	}
+
+	value := sensor.GetAnalogReading().GetValue()
+	switch sensor.GetUnit() {
+	case v1alpha1.SensorUnit_SENSOR_UNIT_FAHRENHEIT:
+		value = (value - 32) * 5 / 9
+	case v1alpha1.SensorUnit_SENSOR_UNIT_KELVIN:
+		value -= 273.15
+	}
+
+	magnitude := uint8(min(math.Round(math.Abs(value)), dcmiTemperatureMax))
+	if value < 0 && magnitude != 0 {
+		return dcmiTemperatureSign | magnitude
+	}
+	return magnitude
+}
+
+func (s *IPMISrv) handleGetDCMITemperatureReading(ctx context.Context, data []byte) ([]byte, uint8) {
+	if len(data) != dcmiTemperatureReadingLen {
+		return nil, CCInvalidLength
+	}
+	if data[0] != dcmiSensorTypeTemperature {
+		return nil, CCInvalidField
+	}
+	entity, ok := dcmiEntity(data[1])
+	if !ok {
+		return nil, CCInvalidField
+	}
+	instance, start := int(data[2]), int(data[3])
+
+	ctx, cancel := context.WithTimeout(ctx, s.config.requestTimeout)
+	defer cancel()
+
+	resp := &v1alpha1.ListSensorsResponse{}
+	if err := s.requestNATS(ctx, ipc.SubjectSensorList, &v1alpha1.ListSensorsRequest{}, resp); err != nil {
+		s.logger.WarnContext(ctx, "Failed to list sensors", "error", err)
+		return nil, CCDestinationUnavail
+	}
+
+	// Instances are numbered from 1 in sensor ID order.
+	var sensors []*v1alpha1.Sensor
+	for _, sensor := range resp.GetSensor() {
+		if sensor.GetContext() == v1alpha1.SensorContext_SENSOR_CONTEXT_TEMPERATURE &&
+			sensor.GetAnalogReading() != nil &&
+			s.temperatureEntity(sensor) == entity {
+			sensors = append(sensors, sensor)
+		}
+	}
+	slices.SortFunc(sensors, func(a, b *v1alpha1.Sensor) int {
+		return strings.Compare(a.GetId(), b.GetId())
+	})
+	total := min(len(sensors), math.MaxUint8)
+
+	first, last := instance, instance
+	if instance == 0 {
+		first = max(start, 1)
+		last = min(total, first+dcmiMaxTemperatures-1)
+	}
+	if total == 0 && instance == 0 {
+		return []byte{0x00, 0x00}, CCSuccess
+	}
+	if first > total {
+		return nil, CCParameterOutOfRange
+	}
+
+	out := []byte{uint8(total), uint8(last - first + 1)}
+	for i := first; i <= last; i++ {
+		out = append(out, dcmiTemperature(sensors[i-1]), uint8(i))
+	}
+
+	return out, CCSuccess
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ipmisrv
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// request is an IPMI request as seen by command handlers, independent of the
+// transport it arrived on.
+type request struct {
+	msg *Message
+	// channel is the IPMI channel the request was received on.
+	channel uint8
+	// privilege is the operating privilege level of the requester.
+	privilege PrivilegeLevel
+	// session is the RMCP+ session the request belongs to, if any.
+	session    *session
+	remoteAddr string
+}
+
+// commandHandler processes a request and returns the response data and
+// completion code.
+type commandHandler func(ctx context.Context, req *request) ([]byte, uint8)
+
+type commandKey struct {
+	netFn   uint8
+	command uint8
+}
+
+type command struct {
+	privilege PrivilegeLevel
+	handler   commandHandler
+}
+
+// dispatcher routes IPMI requests to command handlers and enforces the
+// privilege level required by each command.
+type dispatcher struct {
+	commands map[commandKey]command
+}
+
+// newDispatcher creates an empty dispatcher.
+func newDispatcher() *dispatcher {
+	return &dispatcher{
+		commands: make(map[commandKey]command),
+	}
+}
+
+// register adds a handler for the given network function and command.
+func (d *dispatcher) register(netFn, cmd uint8, privilege PrivilegeLevel, handler commandHandler) {
+	d.commands[commandKey{netFn: netFn, command: cmd}] = command{
+		privilege: privilege,
+		handler:   handler,
+	}
+}
+
+// has reports whether a handler is registered for the network function and command.
+func (d *dispatcher) has(netFn, cmd uint8) bool {
+	_, ok := d.commands[commandKey{netFn: netFn, command: cmd}]
+	return ok
+}
+
+// dispatch looks up the handler for the request and invokes it.
+func (s *IPMISrv) dispatch(ctx context.Context, req *request) ([]byte, uint8) {
+	ctx, span := s.tracer.Start(ctx, "ipmisrv.dispatch")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int("ipmi.netfn", int(req.msg.NetFn)),
+		attribute.Int("ipmi.command", int(req.msg.Command)),
+		attribute.Int("ipmi.channel", int(req.channel)),
+	)
+
+	cmd, ok := s.dispatcher.commands[commandKey{netFn: req.msg.NetFn, command: req.msg.Command}]
+	if !ok {
+		return nil, CCInvalidCommand
+	}
+
+	if req.privilege < cmd.privilege {
+		return nil, CCInsufficientPrivilege
+	}
+
+	data, cc := cmd.handler(ctx, req)
+	span.SetAttributes(attribute.Int("ipmi.completion_code", int(cc)))
+
+	return data, cc
+}
@@ -0,0 +1,223 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package ipmisrv provides an IPMI v2.0 server for out-of-band management of the BMC.
+//
+// The IPMI server exposes the Intelligent Platform Management Interface to legacy
+// tooling such as ipmitool and data center management software. Requests arriving
+// on any transport are routed through a common command dispatcher that enforces
+// the IPMI privilege model and translates commands into NATS IPC requests to the
+// other u-bmc services.
+//
+// # IPMI over LAN (RMCP+)
+//
+// The LAN transport listens on UDP port 623 and implements RMCP and RMCP+ as
+// specified in IPMI v2.0 section 13:
+//   - ASF presence ping/pong for discovery
+//   - Unauthenticated IPMI v1.5 and v2.0 session-less messages for capability
+//     discovery (Get Channel Authentication Capabilities, Get Channel Cipher
+//     Suites, Get System GUID)
+//   - RMCP+ Open Session and the RAKP 1-4 handshake
+//   - Authenticated and encrypted session traffic
+//
+// IPMI v1.5 sessions (MD2, MD5, straight password) are intentionally not supported.
+//
+// # Cipher Suites
+//
+// Only cipher suites providing both integrity and confidentiality are accepted:
+//
+//	Suite 17: RAKP-HMAC-SHA256, HMAC-SHA256-128, AES-CBC-128 (preferred)
+//	Suite  3: RAKP-HMAC-SHA1,   HMAC-SHA1-96,    AES-CBC-128
+//
+// # Session Establishment
+//
+// A session is established in four steps:
+//  1. The remote console proposes algorithms with Open Session and receives a
+//     BMC session ID
+//  2. RAKP1 carries the user name and requested role; the server looks up the user
+//     key, checks with usermgr via user.check_login that the account may log in
+//     and replies with RAKP2
+//  3. RAKP3 proves the remote console knows the user key. A wrong proof is
+//     reported to usermgr as a failed login, a correct one is verified with
+//     usermgr via user.authenticate, which records the login
+//  4. The session integrity key (SIK) and the derived keys K1 and K2 are computed
+//     and RAKP4 completes the handshake
+//
+// New sessions operate at User privilege and may be raised up to the negotiated
+// maximum with Set Session Privilege Level. Idle sessions are closed after the
+// configured session timeout.
+//
+// # User Keys
+//
+// RAKP authenticates users with HMACs keyed by the cleartext password, which
+// usermgr does not store. The server therefore obtains user keys from a KeyStore
+// and additionally checks every login against usermgr, so disabled or locked
+// accounts are rejected even when a key is present. RAKP has no way to pass a
+// second factor or change a password, so accounts with TOTP or required to use
+// it and accounts whose password must be changed, such as the default account,
+// are rejected as well.
+//
+// Unless WithKeyStore sets another store, the keys are kept in a FileKeyStore
+// at WithKeyStorePath, which Run opens on start. The file is encrypted with
+// AES-256-GCM under a key stored next to it with a .key suffix, created on
+// first start, so keys set through Set User Password survive a restart.
+// Stores such as MemoryKeyStore can be provisioned directly:
+//
+//	keys := ipmisrv.NewMemoryKeyStore()
+//	_ = keys.Set("admin", ipmisrv.UserKey{
+//		Password:  []byte("secret"),
+//		Privilege: ipmisrv.PrivilegeAdmin,
+//	})
+//
+// # Chassis and Application Commands
+//
+// Chassis and BMC control commands are translated into the same statemgr
+// requests used by the ConnectRPC API, so both interfaces share a single
+// state machine path:
+//
+//	Chassis Control power up      -> host.control   HOST_ACTION_ON
+//	Chassis Control power down    -> host.control   HOST_ACTION_FORCE_OFF
+//	Chassis Control soft shutdown -> host.control   HOST_ACTION_OFF
+//	Chassis Control hard reset    -> host.control   HOST_ACTION_FORCE_RESTART
+//	Chassis Control power cycle   -> chassis.control CHASSIS_ACTION_POWER_CYCLE
+//	Chassis Identify              -> chassis.control CHASSIS_ACTION_IDENTIFY_ON/OFF
+//	Cold Reset / Warm Reset       -> bmc.control    MANAGEMENT_CONTROLLER_ACTION_COLD_RESET/WARM_RESET
+//
+// Control commands are acknowledged once accepted and carried out in the
+// background, since power transitions regularly take longer than IPMI clients
+// wait for a response. Get Chassis Status reports the host power state as seen
+// by statemgr. The target components default to host.0, chassis.0 and bmc.0 and
+// can be changed with WithComponentNames.
+//
+// # Sensor Data Repository
+//
+// The SDR repository is synthesized from the sensors returned by sensormon on
+// sensor.list. Analog sensors are described by Full Sensor Records (type 01h)
+// whose linearization factors are chosen to cover the sensor's readings and
+// thresholds; discrete sensors are described by Compact Sensor Records (type
+// 02h) as digital asserted/deasserted sensors. Warning thresholds are reported
+// as non-critical and critical thresholds as critical thresholds.
+//
+// The sensor list is polled at the SDR refresh interval and the repository is
+// regenerated whenever sensors are added, removed or change their description,
+// which also cancels outstanding reservations. Sensor numbers stay stable for
+// as long as a sensor exists. Get Sensor Reading and Get Sensor Thresholds
+// query sensormon on sensor.info, so readings are always live:
+//
+//	ipmitool -I lanplus -C 17 -H <bmc> -U admin -P secret sdr list
+//	ipmitool -I lanplus -C 17 -H <bmc> -U admin -P secret sensor reading "CPU Temp"
+//
+// Sensors with thresholds advertise threshold events in their SDR, matching
+// the threshold crossings selmgr records in the system event log.
+//
+// # System Event Log
+//
+// The SEL is owned by selmgr; Get SEL Info, Get SEL Entry and Clear SEL are
+// forwarded to it on sel.info, sel.entry and sel.clear. Reservations for
+// partial reads and clearing are tracked by ipmisrv. selmgr does not know SDR
+// sensor numbers, so records of sensor events are resolved against the SDR
+// repository by sensor ID when served, including the raw trigger reading and
+// threshold of threshold events:
+//
+//	ipmitool -I lanplus -C 17 -H <bmc> -U admin -P secret sel info
+//	ipmitool -I lanplus -C 17 -H <bmc> -U admin -P secret sel elist
+//	ipmitool -I lanplus -C 17 -H <bmc> -U admin -P secret sel clear
+//
+// # FRU Inventory
+//
+// Get FRU Inventory Area Info, Read FRU Data and Write FRU Data give access to
+// the raw FRU images configured with WithFRUDevice. Device 0 is the BMC's own
+// FRU and is read from DefaultFRUPath by default. Images are accessed as files,
+// so both EEPROMs exposed through sysfs and plain files work; their size is
+// the size of the inventory area and writes never extend it. The images are
+// served as stored, their contents can be decoded with the pkg/fru package:
+//
+//	ipmitool -I lanplus -C 17 -H <bmc> -U admin -P secret fru print 0
+//	ipmitool -I lanplus -C 17 -H <bmc> -U admin -P secret fru write 0 fru.bin
+//
+// # DCMI
+//
+// The DCMI 1.5 power and thermal management commands are served on the group
+// extension network function with the DCMI group extension ID 0xDC:
+//   - Get DCMI Capabilities Info reports the supported parameters
+//   - Get Power Reading returns the system power statistics of powermgr,
+//     derived from the PMBus power sensors of sensormon
+//   - Get Power Limit, Set Power Limit and Activate/Deactivate Power Limit
+//     manage the power limit enforced by powermgr; setting a limit requires
+//     operator privilege
+//   - Get Temperature Reading returns the inlet, CPU and baseboard temperatures
+//
+// Temperature sensors are assigned to the inlet, CPU or baseboard entity by
+// their ID and name, with sensors mentioning "inlet" or "ambient" reported as
+// inlet and sensors mentioning "cpu" or "processor" as CPU temperatures.
+// WithDCMITemperatureSensor assigns sensors explicitly:
+//
+//	ipmitool -I lanplus -C 17 -H <bmc> -U admin -P secret dcmi power reading
+//	ipmitool -I lanplus -C 17 -H <bmc> -U admin -P secret dcmi power set_limit limit 400
+//	ipmitool -I lanplus -C 17 -H <bmc> -U admin -P secret dcmi get_temp_reading
+//
+// # Host Interfaces
+//
+// The host reaches the BMC in-band through KCS or BT host interfaces. Each
+// HostInterface reads framed requests from a HostTransport, typically one of
+// the Linux KCS or BT BMC character devices, and dispatches them to the same
+// command handlers as LAN requests. The system interface is session-less, so
+// every request operates at the privilege level configured for its channel:
+//
+//	srv := ipmisrv.New(
+//		ipmisrv.WithKCSDevice(ipmisrv.DefaultKCSDevice),
+//		ipmisrv.WithHostInterface(ipmisrv.HostInterface{
+//			Path:      "/dev/ipmi-bt-host",
+//			Framing:   ipmisrv.HostFramingBT,
+//			Channel:   0x0C,
+//			Privilege: ipmisrv.PrivilegeOperator,
+//		}),
+//	)
+//
+// NewHostPipe provides an in-memory transport pair for exercising the in-band
+// path without a device.
+//
+// # User Management
+//
+// The user and channel management commands let IPMI tooling provision BMC
+// accounts. Set User Name, Set User Password and Set User Access require
+// administrator privilege; the corresponding Get commands, Get Channel Info and
+// Get Channel Access are available to operators and users respectively.
+//
+// Each user ID maps to a usermgr user. Users named through Set User Name are
+// created in usermgr with the IPMI user source and creation interface, or
+// linked when a user with the same name already exists, and the user ID and
+// channel access are stored as custom attributes of the user. User ID 1 is the
+// reserved null user, and WithMaxUsers sets the number of user IDs.
+//
+// Set User Password resets the usermgr password and stores the cleartext key
+// for RAKP, which requires the key store to implement KeyStoreWriter.
+// Passwords that do not meet the usermgr password policy are refused with
+// the Invalid Data Field completion code. Sessions
+// of users that occupy a user ID are only established while the user is
+// enabled, has IPMI messaging enabled on the LAN channel and requests at most
+// its channel privilege limit:
+//
+//	ipmitool -I lanplus -C 17 -H <bmc> -U admin -P secret user set name 3 operator
+//	ipmitool -I lanplus -C 17 -H <bmc> -U admin -P secret user set password 3 'Op3rator-pw' 20
+//	ipmitool -I lanplus -C 17 -H <bmc> -U admin -P secret channel setaccess 1 3 ipmi=on privilege=3
+//	ipmitool -I lanplus -C 17 -H <bmc> -U admin -P secret user enable 3
+//	ipmitool -I lanplus -C 17 -H <bmc> -U admin -P secret user summary 1
+//
+// # Basic Usage
+//
+//	srv := ipmisrv.New(
+//		ipmisrv.WithServiceName("ipmisrv"),
+//		ipmisrv.WithLANAddress(":623"),
+//		ipmisrv.WithKeyStore(keys),
+//		ipmisrv.WithSessionTimeout(60*time.Second),
+//	)
+//
+//	if err := srv.Run(ctx, ipcConn); err != nil {
+//		log.Fatal(err)
+//	}
+//
+// The listener can be exercised with ipmitool:
+//
+//	ipmitool -I lanplus -C 17 -H <bmc> -U admin -P secret mc guid
+//	ipmitool -I lanplus -C 17 -H <bmc> -U admin -P secret chassis power status
+package ipmisrv
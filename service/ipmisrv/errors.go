@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ipmisrv
+
+import "errors"
+
+var (
+	// ErrInvalidConfiguration indicates the service configuration is invalid.
+	ErrInvalidConfiguration = errors.New("invalid service configuration")
+	// ErrNATSConnectionFailed indicates the connection to the NATS server failed.
+	ErrNATSConnectionFailed = errors.New("failed to connect to NATS server")
+	// ErrListenFailed indicates the LAN listener could not be started.
+	ErrListenFailed = errors.New("failed to start LAN listener")
+	// ErrHostInterfaceFailed indicates a host interface device could not be opened.
+	ErrHostInterfaceFailed = errors.New("failed to open host interface")
+
+	// ErrPacketTooShort indicates a received packet is shorter than its headers require.
+	ErrPacketTooShort = errors.New("packet too short")
+	// ErrInvalidRMCPHeader indicates a packet does not carry a valid RMCP header.
+	ErrInvalidRMCPHeader = errors.New("invalid RMCP header")
+	// ErrUnsupportedAuthType indicates the session header uses an unsupported authentication type.
+	ErrUnsupportedAuthType = errors.New("unsupported authentication type")
+	// ErrUnsupportedPayload indicates the payload type is not supported.
+	ErrUnsupportedPayload = errors.New("unsupported payload type")
+	// ErrInvalidHostFrame indicates a host interface frame is malformed.
+	ErrInvalidHostFrame = errors.New("invalid host interface frame")
+	// ErrInvalidChecksum indicates an IPMI message checksum mismatch.
+	ErrInvalidChecksum = errors.New("invalid IPMI message checksum")
+	// ErrIntegrityCheckFailed indicates the session integrity check value did not match.
+	ErrIntegrityCheckFailed = errors.New("integrity check failed")
+	// ErrDecryptionFailed indicates an encrypted payload could not be decrypted.
+	ErrDecryptionFailed = errors.New("payload decryption failed")
+	// ErrInvalidSequence indicates a session sequence number is outside the accepted window.
+	ErrInvalidSequence = errors.New("invalid session sequence number")
+
+	// ErrSessionNotFound indicates no session exists for the given session ID.
+	ErrSessionNotFound = errors.New("session not found")
+	// ErrSessionLimitReached indicates no more sessions can be established.
+	ErrSessionLimitReached = errors.New("session limit reached")
+	// ErrSessionNotActive indicates the session has not completed RAKP yet.
+	ErrSessionNotActive = errors.New("session not active")
+
+	// ErrKeyStoreFailed indicates the RAKP keys could not be loaded or stored.
+	ErrKeyStoreFailed = errors.New("key store failed")
+	// ErrUserNotFound indicates no RAKP key is available for the user.
+	ErrUserNotFound = errors.New("user not found")
+	// ErrAuthenticationFailed indicates usermgr rejected the user credentials.
+	ErrAuthenticationFailed = errors.New("authentication failed")
+	// ErrRequestFailed indicates a request to another service failed.
+	ErrRequestFailed = errors.New("service request failed")
+	// ErrNotFound indicates the service a request was sent to does not know the requested item.
+	ErrNotFound = errors.New("not found")
+	// ErrInvalidArgument indicates the service a request was sent to rejected its arguments.
+	ErrInvalidArgument = errors.New("invalid argument")
+)
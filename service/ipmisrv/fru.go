@@ -0,0 +1,178 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ipmisrv
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+)
+
+// Storage network function FRU commands.
+const (
+	cmdGetFRUInventoryAreaInfo uint8 = 0x10
+	cmdReadFRUData             uint8 = 0x11
+	cmdWriteFRUData            uint8 = 0x12
+)
+
+// FRU command fields.
+const (
+	fruDeviceReserved   uint8 = 0xFF
+	fruAccessByBytes    uint8 = 0x00
+	fruAreaInfoLen            = 1
+	fruReadLen                = 4
+	fruWriteMinLen            = 3
+	fruMaxAreaSize            = 0xFFFF
+	fruMaxReadCount           = 0x80
+	fruCCWriteProtected uint8 = 0x80
+)
+
+// registerFRUCommands registers the FRU inventory commands.
+func (s *IPMISrv) registerFRUCommands() {
+	s.dispatcher.register(NetFnStorage, cmdGetFRUInventoryAreaInfo, PrivilegeUser, s.handleGetFRUInventoryAreaInfo)
+	s.dispatcher.register(NetFnStorage, cmdReadFRUData, PrivilegeUser, s.handleReadFRUData)
+	s.dispatcher.register(NetFnStorage, cmdWriteFRUData, PrivilegeOperator, s.handleWriteFRUData)
+}
+
+// fruSize returns the size of an opened FRU image, limited to the 16-bit
+// offsets of the FRU commands.
+func fruSize(f *os.File) (int, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+	return int(min(info.Size(), fruMaxAreaSize)), nil
+}
+
+// openFRU opens the image of a FRU device. Images that cannot be opened for
+// writing due to their permissions are reported as write-protected.
+func (s *IPMISrv) openFRU(ctx context.Context, id uint8, flag int) (*os.File, uint8) {
+	path, ok := s.config.fruDevices[id]
+	if !ok {
+		return nil, CCNotPresent
+	}
+
+	f, err := os.OpenFile(path, flag, 0)
+	switch {
+	case err == nil:
+		return f, CCSuccess
+	case errors.Is(err, fs.ErrNotExist):
+		return nil, CCNotPresent
+	case errors.Is(err, fs.ErrPermission) && flag != os.O_RDONLY:
+		return nil, fruCCWriteProtected
+	default:
+		s.logger.WarnContext(ctx, "Failed to open FRU image", "device", id, "path", path, "error", err)
+		return nil, CCUnspecified
+	}
+}
+
+func (s *IPMISrv) handleGetFRUInventoryAreaInfo(ctx context.Context, req *request) ([]byte, uint8) {
+	if len(req.msg.Data) != fruAreaInfoLen {
+		return nil, CCInvalidLength
+	}
+
+	s.fruMu.Lock()
+	defer s.fruMu.Unlock()
+
+	f, cc := s.openFRU(ctx, req.msg.Data[0], os.O_RDONLY)
+	if cc != CCSuccess {
+		return nil, cc
+	}
+	defer f.Close() //nolint:errcheck
+
+	size, err := fruSize(f)
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to stat FRU image", "device", req.msg.Data[0], "error", err)
+		return nil, CCUnspecified
+	}
+
+	out := binary.LittleEndian.AppendUint16(nil, uint16(size))
+	return append(out, fruAccessByBytes), CCSuccess
+}
+
+func (s *IPMISrv) handleReadFRUData(ctx context.Context, req *request) ([]byte, uint8) {
+	data := req.msg.Data
+	if len(data) != fruReadLen {
+		return nil, CCInvalidLength
+	}
+	id := data[0]
+	offset := int(binary.LittleEndian.Uint16(data[1:3]))
+	count := min(int(data[3]), fruMaxReadCount)
+
+	s.fruMu.Lock()
+	defer s.fruMu.Unlock()
+
+	f, cc := s.openFRU(ctx, id, os.O_RDONLY)
+	if cc != CCSuccess {
+		return nil, cc
+	}
+	defer f.Close() //nolint:errcheck
+
+	size, err := fruSize(f)
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to stat FRU image", "device", id, "error", err)
+		return nil, CCUnspecified
+	}
+	if offset >= size {
+		return nil, CCParameterOutOfRange
+	}
+	count = min(count, size-offset)
+
+	out := make([]byte, 1+count)
+	n, err := f.ReadAt(out[1:], int64(offset))
+	if err != nil && !errors.Is(err, io.EOF) {
+		s.logger.WarnContext(ctx, "Failed to read FRU image", "device", id, "offset", offset, "error", err)
+		return nil, CCUnspecified
+	}
+	out[0] = uint8(n)
+
+	return out[:1+n], CCSuccess
+}
+
+func (s *IPMISrv) handleWriteFRUData(ctx context.Context, req *request) ([]byte, uint8) {
+	data := req.msg.Data
+	if len(data) < fruWriteMinLen {
+		return nil, CCInvalidLength
+	}
+	id := data[0]
+	offset := int(binary.LittleEndian.Uint16(data[1:3]))
+	payload := data[3:]
+
+	s.fruMu.Lock()
+	defer s.fruMu.Unlock()
+
+	f, cc := s.openFRU(ctx, id, os.O_WRONLY)
+	if cc != CCSuccess {
+		return nil, cc
+	}
+	defer f.Close() //nolint:errcheck
+
+	size, err := fruSize(f)
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to stat FRU image", "device", id, "error", err)
+		return nil, CCUnspecified
+	}
+	if offset >= size {
+		return nil, CCParameterOutOfRange
+	}
+	if offset+len(payload) > size {
+		return nil, CCLengthExceeded
+	}
+
+	n, err := f.WriteAt(payload, int64(offset))
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to write FRU image", "device", id, "offset", offset, "error", err)
+		return nil, CCUnspecified
+	}
+
+	s.logger.DebugContext(ctx, "FRU data written",
+		"device", id,
+		"offset", offset,
+		"bytes", n,
+		"remote_addr", req.remoteAddr)
+
+	return []byte{uint8(n)}, CCSuccess
+}
@@ -0,0 +1,288 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ipmisrv
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// ChannelSystemInterface is the IPMI channel number of the system interface.
+const ChannelSystemInterface uint8 = 0x0F
+
+// Host interface framing fields.
+const (
+	maxHostMessageSize        = 1024
+	kcsRequestMinLen          = 2
+	btRequestMinLen           = 4
+	btMaxLen                  = 0xFF
+	hostBMCAddr         uint8 = 0x20
+	hostSoftwareAddr    uint8 = 0x81
+	channelMediumSystem uint8 = 0x0C
+	channelProtocolKCS  uint8 = 0x05
+	channelProtocolBT   uint8 = 0x08
+	channelSessionless  uint8 = 0x00
+)
+
+// HostFraming selects how IPMI messages are framed on a host interface.
+type HostFraming uint8
+
+// Supported host interface framings.
+const (
+	// HostFramingKCS frames messages as NetFn/LUN, command and data, as read
+	// from and written to the Linux KCS BMC character devices.
+	HostFramingKCS HostFraming = iota + 1
+	// HostFramingBT frames messages as length, NetFn/LUN, sequence, command
+	// and data, as read from and written to the Linux BT BMC character device.
+	HostFramingBT
+)
+
+// String returns the name of the framing.
+func (f HostFraming) String() string {
+	switch f {
+	case HostFramingKCS:
+		return "kcs"
+	case HostFramingBT:
+		return "bt"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(f))
+	}
+}
+
+// protocol returns the channel protocol type reported by Get Channel Info.
+func (f HostFraming) protocol() uint8 {
+	if f == HostFramingBT {
+		return channelProtocolBT
+	}
+	return channelProtocolKCS
+}
+
+// decode parses a request frame.
+func (f HostFraming) decode(b []byte) (*Message, error) {
+	msg := &Message{
+		RsAddr: hostBMCAddr,
+		RqAddr: hostSoftwareAddr,
+	}
+
+	switch f {
+	case HostFramingKCS:
+		if len(b) < kcsRequestMinLen {
+			return nil, ErrPacketTooShort
+		}
+		msg.NetFn, msg.RsLUN = b[0]>>netFnShift, b[0]&lunMask
+		msg.Command = b[1]
+		msg.Data = b[kcsRequestMinLen:]
+	case HostFramingBT:
+		if len(b) < btRequestMinLen {
+			return nil, ErrPacketTooShort
+		}
+		if int(b[0]) != len(b)-1 {
+			return nil, ErrInvalidHostFrame
+		}
+		msg.NetFn, msg.RsLUN = b[1]>>netFnShift, b[1]&lunMask
+		msg.RqSeq = b[2]
+		msg.Command = b[3]
+		msg.Data = b[btRequestMinLen:]
+	default:
+		return nil, ErrInvalidHostFrame
+	}
+
+	return msg, nil
+}
+
+// encode builds the response frame for req carrying cc and data.
+func (f HostFraming) encode(req *Message, cc uint8, data []byte) []byte {
+	netFn := (req.NetFn|netFnResponseOr)<<netFnShift | req.RsLUN
+
+	if f == HostFramingBT {
+		// The length byte limits BT responses, so oversized data is
+		// reported as truncated rather than sent.
+		if 4+len(data) > btMaxLen {
+			cc, data = CCCannotReturnBytes, nil
+		}
+		out := []byte{uint8(4 + len(data)), netFn, req.RqSeq, req.Command, cc}
+		return append(out, data...)
+	}
+
+	out := []byte{netFn, req.Command, cc}
+	return append(out, data...)
+}
+
+// HostTransport carries framed IPMI messages between the host and the BMC.
+// Every Read returns exactly one request frame and every Write sends exactly
+// one response frame. Close unblocks pending reads.
+//
+// The Linux KCS and BT BMC character devices, such as /dev/ipmi-kcs3, behave
+// this way and are used as transport when a HostInterface is configured with
+// a device path.
+type HostTransport interface {
+	io.ReadWriteCloser
+}
+
+// HostInterface configures an in-band interface through which the host sends
+// IPMI requests. Requests are dispatched like LAN requests, operating at the
+// privilege level of the interface since the system interface is
+// session-less.
+type HostInterface struct {
+	// Name identifies the interface in logs. It defaults to the device path.
+	Name string
+	// Path is the character device to open when Transport is nil.
+	Path string
+	// Transport carries the messages of the interface.
+	Transport HostTransport
+	// Framing is the message framing used on the transport.
+	Framing HostFraming
+	// Channel is the IPMI channel of the interface and defaults to
+	// ChannelSystemInterface.
+	Channel uint8
+	// Privilege is the privilege level of requests received on the interface
+	// and defaults to PrivilegeAdmin.
+	Privilege PrivilegeLevel
+}
+
+// validate validates the host interface configuration.
+func (h *HostInterface) validate(lanChannel uint8) error {
+	if h.Transport == nil && h.Path == "" {
+		return fmt.Errorf("host interface %q requires a device path or transport", h.Name)
+	}
+	if h.Framing != HostFramingKCS && h.Framing != HostFramingBT {
+		return fmt.Errorf("host interface %q has unsupported framing %s", h.Name, h.Framing)
+	}
+	if h.Channel > channelMask || h.Channel == channelCurrent || h.Channel == lanChannel {
+		return fmt.Errorf("host interface %q has invalid channel %d", h.Name, h.Channel)
+	}
+	if !h.Privilege.Valid() {
+		return fmt.Errorf("host interface %q has invalid privilege level %s", h.Name, h.Privilege)
+	}
+	return nil
+}
+
+// HostPipe is one end of an in-memory HostTransport pair that preserves
+// message boundaries. It allows the host interface to be exercised without
+// a KCS or BT device.
+type HostPipe struct {
+	rx   <-chan []byte
+	tx   chan<- []byte
+	done chan struct{}
+	once *sync.Once
+}
+
+// NewHostPipe creates a connected pair of in-memory host transports. Messages
+// written to one end are read from the other; closing either end closes both.
+func NewHostPipe() (*HostPipe, *HostPipe) {
+	a := make(chan []byte)
+	b := make(chan []byte)
+	done := make(chan struct{})
+	once := &sync.Once{}
+
+	return &HostPipe{rx: a, tx: b, done: done, once: once},
+		&HostPipe{rx: b, tx: a, done: done, once: once}
+}
+
+// Read reads the next message into p.
+func (p *HostPipe) Read(b []byte) (int, error) {
+	select {
+	case msg := <-p.rx:
+		if len(msg) > len(b) {
+			return 0, io.ErrShortBuffer
+		}
+		return copy(b, msg), nil
+	case <-p.done:
+		return 0, io.ErrClosedPipe
+	}
+}
+
+// Write sends b as a single message and blocks until it is read.
+func (p *HostPipe) Write(b []byte) (int, error) {
+	select {
+	case p.tx <- bytes.Clone(b):
+		return len(b), nil
+	case <-p.done:
+		return 0, io.ErrClosedPipe
+	}
+}
+
+// Close closes both ends of the pipe.
+func (p *HostPipe) Close() error {
+	p.once.Do(func() { close(p.done) })
+	return nil
+}
+
+// hostInterface returns the host interface serving a channel.
+func (s *IPMISrv) hostInterface(channel uint8) (*HostInterface, bool) {
+	for i := range s.config.hostInterfaces {
+		if s.config.hostInterfaces[i].Channel == channel {
+			return &s.config.hostInterfaces[i], true
+		}
+	}
+	return nil, false
+}
+
+// openHostInterfaces opens the transports of all configured host interfaces.
+func (s *IPMISrv) openHostInterfaces() error {
+	for i := range s.config.hostInterfaces {
+		h := &s.config.hostInterfaces[i]
+		if h.Transport != nil {
+			continue
+		}
+		f, err := os.OpenFile(h.Path, os.O_RDWR, 0)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrHostInterfaceFailed, h.Name, err)
+		}
+		h.Transport = f
+	}
+	return nil
+}
+
+// closeHostInterfaces closes the transports of all host interfaces, which
+// stops their serving goroutines.
+func (s *IPMISrv) closeHostInterfaces() {
+	for i := range s.config.hostInterfaces {
+		if t := s.config.hostInterfaces[i].Transport; t != nil {
+			_ = t.Close()
+		}
+	}
+}
+
+// serveHost reads requests from a host interface and answers them until the
+// context is canceled or the transport is closed. Requests are processed one
+// at a time since KCS and BT allow a single outstanding request.
+func (s *IPMISrv) serveHost(ctx context.Context, h *HostInterface) {
+	buf := make([]byte, maxHostMessageSize)
+
+	for {
+		n, err := h.Transport.Read(buf)
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, os.ErrClosed) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrClosedPipe) {
+				return
+			}
+			s.logger.WarnContext(ctx, "Failed to read host request", "interface", h.Name, "error", err)
+			continue
+		}
+
+		msg, err := h.Framing.decode(bytes.Clone(buf[:n]))
+		if err != nil {
+			s.logger.DebugContext(ctx, "Dropping host request", "interface", h.Name, "error", err)
+			continue
+		}
+
+		data, cc := s.dispatch(ctx, &request{
+			msg:        msg,
+			channel:    h.Channel,
+			privilege:  h.Privilege,
+			remoteAddr: h.Name,
+		})
+
+		if _, err := h.Transport.Write(h.Framing.encode(msg, cc, data)); err != nil {
+			if ctx.Err() != nil || errors.Is(err, os.ErrClosed) || errors.Is(err, io.ErrClosedPipe) {
+				return
+			}
+			s.logger.WarnContext(ctx, "Failed to write host response", "interface", h.Name, "error", err)
+		}
+	}
+}
@@ -0,0 +1,295 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ipmisrv
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+const (
+	cmdTestEcho      uint8 = 0x01
+	cmdTestOversized uint8 = 0x02
+)
+
+// newTestHost serves a host interface with the given framing over a
+// HostPipe and returns the host end of the pipe. The OEM echo command
+// returns its request data along with the channel and privilege level it was
+// received with; the oversized command returns more data than a BT frame can
+// carry.
+func newTestHost(t *testing.T, framing HostFraming) (*IPMISrv, *HostPipe) {
+	t.Helper()
+
+	host, bmc := NewHostPipe()
+	s, _ := newTestServer(t, WithHostInterface(HostInterface{Name: "pipe", Transport: bmc, Framing: framing}))
+	s.dispatcher.register(NetFnOEM, cmdTestEcho, PrivilegeUser, func(_ context.Context, req *request) ([]byte, uint8) {
+		return append([]byte{req.channel, uint8(req.privilege)}, req.msg.Data...), CCSuccess
+	})
+	s.dispatcher.register(NetFnOEM, cmdTestOversized, PrivilegeUser, func(context.Context, *request) ([]byte, uint8) {
+		return make([]byte, btMaxLen), CCSuccess
+	})
+
+	h, ok := s.hostInterface(ChannelSystemInterface)
+	if !ok {
+		t.Fatal("host interface not configured")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.serveHost(ctx, h)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		host.Close()
+		s.wg.Wait()
+	})
+
+	return s, host
+}
+
+// hostExchange writes a request frame and returns the next response frame.
+func hostExchange(t *testing.T, host *HostPipe, frame []byte) []byte {
+	t.Helper()
+
+	if _, err := host.Write(frame); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	type result struct {
+		b   []byte
+		err error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		buf := make([]byte, maxHostMessageSize)
+		n, err := host.Read(buf)
+		ch <- result{buf[:n], err}
+	}()
+
+	select {
+	case r := <-ch:
+		if r.err != nil {
+			t.Fatalf("read: %v", r.err)
+		}
+		return r.b
+	case <-time.After(5 * time.Second):
+		t.Fatal("no response")
+		return nil
+	}
+}
+
+func TestHostKCS(t *testing.T) {
+	s, host := newTestHost(t, HostFramingKCS)
+
+	tests := []struct {
+		name    string
+		request []byte
+		want    []byte
+	}{
+		{
+			name:    "Get System GUID",
+			request: []byte{NetFnApp << netFnShift, cmdGetSystemGUID},
+			want:    append([]byte{(NetFnApp | netFnResponseOr) << netFnShift, cmdGetSystemGUID, CCSuccess}, s.guid[:]...),
+		},
+		{
+			name:    "request data and LUN",
+			request: []byte{NetFnOEM<<netFnShift | 0x02, cmdTestEcho, 0xDE, 0xAD},
+			want: []byte{
+				(NetFnOEM|netFnResponseOr)<<netFnShift | 0x02, cmdTestEcho, CCSuccess,
+				ChannelSystemInterface, uint8(PrivilegeAdmin), 0xDE, 0xAD,
+			},
+		},
+		{
+			name:    "unknown command",
+			request: []byte{NetFnOEM << netFnShift, 0xFF},
+			want:    []byte{(NetFnOEM | netFnResponseOr) << netFnShift, 0xFF, CCInvalidCommand},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hostExchange(t, host, tt.request); !bytes.Equal(got, tt.want) {
+				t.Errorf("response = % x, want % x", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHostBT(t *testing.T) {
+	s, host := newTestHost(t, HostFramingBT)
+
+	tests := []struct {
+		name    string
+		request []byte
+		want    []byte
+	}{
+		{
+			name:    "Get System GUID",
+			request: []byte{3, NetFnApp << netFnShift, 0x42, cmdGetSystemGUID},
+			want:    append([]byte{4 + 16, (NetFnApp | netFnResponseOr) << netFnShift, 0x42, cmdGetSystemGUID, CCSuccess}, s.guid[:]...),
+		},
+		{
+			name:    "request data",
+			request: []byte{5, NetFnOEM << netFnShift, 0x07, cmdTestEcho, 0xBE, 0xEF},
+			want: []byte{
+				8, (NetFnOEM | netFnResponseOr) << netFnShift, 0x07, cmdTestEcho, CCSuccess,
+				ChannelSystemInterface, uint8(PrivilegeAdmin), 0xBE, 0xEF,
+			},
+		},
+		{
+			name:    "oversized response",
+			request: []byte{3, NetFnOEM << netFnShift, 0x08, cmdTestOversized},
+			want:    []byte{4, (NetFnOEM | netFnResponseOr) << netFnShift, 0x08, cmdTestOversized, CCCannotReturnBytes},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hostExchange(t, host, tt.request); !bytes.Equal(got, tt.want) {
+				t.Errorf("response = % x, want % x", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestHostInvalidFrames checks that malformed frames are dropped without a
+// response. Requests are answered in order, so the response to the valid
+// request that follows must be the next frame read.
+func TestHostInvalidFrames(t *testing.T) {
+	tests := []struct {
+		name    string
+		framing HostFraming
+		invalid []byte
+		valid   []byte
+	}{
+		{
+			name:    "empty KCS frame",
+			framing: HostFramingKCS,
+			invalid: []byte{},
+			valid:   []byte{NetFnOEM << netFnShift, cmdTestEcho},
+		},
+		{
+			name:    "short KCS frame",
+			framing: HostFramingKCS,
+			invalid: []byte{NetFnApp << netFnShift},
+			valid:   []byte{NetFnOEM << netFnShift, cmdTestEcho},
+		},
+		{
+			name:    "short BT frame",
+			framing: HostFramingBT,
+			invalid: []byte{2, NetFnApp << netFnShift, 0x01},
+			valid:   []byte{3, NetFnOEM << netFnShift, 0x02, cmdTestEcho},
+		},
+		{
+			name:    "BT length beyond the frame",
+			framing: HostFramingBT,
+			invalid: []byte{5, NetFnApp << netFnShift, 0x01, cmdGetSystemGUID},
+			valid:   []byte{3, NetFnOEM << netFnShift, 0x02, cmdTestEcho},
+		},
+		{
+			name:    "BT length short of the frame",
+			framing: HostFramingBT,
+			invalid: []byte{3, NetFnApp << netFnShift, 0x01, cmdGetSystemGUID, 0x00},
+			valid:   []byte{3, NetFnOEM << netFnShift, 0x02, cmdTestEcho},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, host := newTestHost(t, tt.framing)
+
+			if _, err := host.Write(tt.invalid); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+			resp := hostExchange(t, host, tt.valid)
+
+			cmd := resp[1]
+			if tt.framing == HostFramingBT {
+				cmd = resp[3]
+			}
+			if cmd != cmdTestEcho {
+				t.Errorf("response % x does not answer the valid request", resp)
+			}
+		})
+	}
+}
+
+func TestHostFramingDecode(t *testing.T) {
+	tests := []struct {
+		name      string
+		framing   HostFraming
+		frame     []byte
+		wantErr   error
+		wantNetFn uint8
+		wantLUN   uint8
+		wantSeq   uint8
+		wantCmd   uint8
+		wantData  []byte
+	}{
+		{
+			name:      "KCS",
+			framing:   HostFramingKCS,
+			frame:     []byte{NetFnStorage<<netFnShift | 0x01, 0x10, 0xAA},
+			wantNetFn: NetFnStorage,
+			wantLUN:   0x01,
+			wantCmd:   0x10,
+			wantData:  []byte{0xAA},
+		},
+		{
+			name:      "BT",
+			framing:   HostFramingBT,
+			frame:     []byte{4, NetFnStorage << netFnShift, 0x33, 0x10, 0xAA},
+			wantNetFn: NetFnStorage,
+			wantSeq:   0x33,
+			wantCmd:   0x10,
+			wantData:  []byte{0xAA},
+		},
+		{
+			name:    "short KCS",
+			framing: HostFramingKCS,
+			frame:   []byte{NetFnApp << netFnShift},
+			wantErr: ErrPacketTooShort,
+		},
+		{
+			name:    "short BT",
+			framing: HostFramingBT,
+			frame:   []byte{2, NetFnApp << netFnShift, 0x00},
+			wantErr: ErrPacketTooShort,
+		},
+		{
+			name:    "BT length mismatch",
+			framing: HostFramingBT,
+			frame:   []byte{4, NetFnApp << netFnShift, 0x00, 0x01},
+			wantErr: ErrInvalidHostFrame,
+		},
+		{
+			name:    "unknown framing",
+			framing: 0,
+			frame:   []byte{NetFnApp << netFnShift, 0x01},
+			wantErr: ErrInvalidHostFrame,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, err := tt.framing.decode(tt.frame)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("decode() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if msg.NetFn != tt.wantNetFn || msg.RsLUN != tt.wantLUN || msg.RqSeq != tt.wantSeq || msg.Command != tt.wantCmd {
+				t.Errorf("decode() = netfn %#x lun %d seq %#x cmd %#x", msg.NetFn, msg.RsLUN, msg.RqSeq, msg.Command)
+			}
+			if !bytes.Equal(msg.Data, tt.wantData) {
+				t.Errorf("data = % x, want % x", msg.Data, tt.wantData)
+			}
+		})
+	}
+}
@@ -4,10 +4,20 @@ package ipmisrv
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"sync"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/nats-io/nats.go"
+	"github.com/u-bmc/u-bmc/pkg/id"
 	"github.com/u-bmc/u-bmc/pkg/log"
 	"github.com/u-bmc/u-bmc/service"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Compile-time assertion that IPMISrv implements service.Service.
@@ -15,13 +25,52 @@ var _ service.Service = (*IPMISrv)(nil)
 
 // IPMISrv provides IPMI server functionality for BMC management.
 type IPMISrv struct {
-	config config
+	config     config
+	nc         *nats.Conn
+	logger     *slog.Logger
+	tracer     trace.Tracer
+	guid       [16]byte
+	sessions   *sessionTable
+	dispatcher *dispatcher
+	sdr        *sdrRepository
+	users      *userTable
+	lanConn    net.PacketConn
+	wg         sync.WaitGroup
+
+	selReservation selReservation
+
+	// fruMu serializes access to the FRU images.
+	fruMu         sync.Mutex
+	identifyMu    sync.Mutex
+	identify      identifyState
+	identifyTimer *time.Timer
 }
 
 // New creates a new IPMISrv instance with the provided options.
 func New(opts ...Option) *IPMISrv {
 	cfg := &config{
-		name: "ipmisrv",
+		name:               DefaultServiceName,
+		description:        DefaultServiceDescription,
+		version:            DefaultServiceVersion,
+		enableLAN:          true,
+		lanAddress:         DefaultLANAddress,
+		lanChannel:         DefaultLANChannel,
+		maxSessions:        DefaultMaxSessions,
+		sessionTimeout:     DefaultSessionTimeout,
+		authTimeout:        DefaultAuthTimeout,
+		maxInflight:        DefaultMaxInflight,
+		maxUsers:           DefaultMaxUsers,
+		guidPath:           DefaultGUIDPath,
+		keyStorePath:       DefaultKeyStorePath,
+		hostName:           DefaultHostName,
+		chassisName:        DefaultChassisName,
+		bmcName:            DefaultBMCName,
+		requestTimeout:     DefaultRequestTimeout,
+		sdrRefreshInterval: DefaultSDRRefreshInterval,
+		fruDevices: map[uint8]string{
+			0: DefaultFRUPath,
+		},
+		dcmiTemperatureSensors: make(map[string]uint8),
 	}
 	for _, opt := range opts {
 		opt.apply(cfg)
@@ -31,17 +80,176 @@ func New(opts ...Option) *IPMISrv {
 	}
 }
 
+// Name returns the service name.
 func (s *IPMISrv) Name() string {
 	return s.config.name
 }
 
+// Run starts the IPMI server and serves the configured transports until the
+// context is canceled.
 func (s *IPMISrv) Run(ctx context.Context, ipcConn nats.InProcessConnProvider) error {
-	l := log.GetGlobalLogger()
+	s.tracer = otel.Tracer(s.config.name)
+
+	ctx, span := s.tracer.Start(ctx, "ipmisrv.Run")
+	defer span.End()
+
+	s.logger = log.GetGlobalLogger().With("service", s.config.name)
+	s.logger.InfoContext(ctx, "Starting IPMI server",
+		"version", s.config.version,
+		"lan_enabled", s.config.enableLAN,
+		"lan_address", s.config.lanAddress)
+
+	if err := s.config.Validate(); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("%w: %w", ErrInvalidConfiguration, err)
+	}
+
+	if s.config.keyStore == nil {
+		keyStore, err := OpenFileKeyStore(s.config.keyStorePath)
+		if err != nil {
+			span.RecordError(err)
+			return err
+		}
+		s.config.keyStore = keyStore
+	}
+
+	nc, err := nats.Connect("", nats.InProcessServer(ipcConn))
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("%w: %w", ErrNATSConnectionFailed, err)
+	}
+	s.nc = nc
+	defer nc.Drain() //nolint:errcheck
+
+	s.guid = s.loadSystemGUID(ctx)
+	s.sessions = newSessionTable(s.config.maxSessions, s.config.sessionTimeout)
+	s.dispatcher = newDispatcher()
+	s.sdr = newSDRRepository()
+	s.users = newUserTable()
+	s.registerAppCommands()
+	s.registerChassisCommands()
+	s.registerSDRCommands()
+	s.registerSensorCommands()
+	s.registerSELCommands()
+	s.registerFRUCommands()
+	s.registerDCMICommands()
+	s.registerUserCommands()
+
+	// Open every transport before starting any goroutine, so that a failure
+	// leaves nothing behind.
+	if s.config.enableLAN {
+		lc := net.ListenConfig{}
+		conn, err := lc.ListenPacket(ctx, "udp", s.config.lanAddress)
+		if err != nil {
+			span.RecordError(err)
+			return fmt.Errorf("%w: %w", ErrListenFailed, err)
+		}
+		s.lanConn = conn
+	}
+
+	if err := s.openHostInterfaces(); err != nil {
+		span.RecordError(err)
+		s.closeHostInterfaces()
+		if s.lanConn != nil {
+			_ = s.lanConn.Close()
+		}
+		return err
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.refreshSDR(ctx)
+	}()
+
+	if conn := s.lanConn; conn != nil {
+		s.wg.Add(2)
+		go func() {
+			defer s.wg.Done()
+			s.serveLAN(ctx, conn)
+		}()
+		go func() {
+			defer s.wg.Done()
+			s.expireSessions(ctx)
+		}()
+
+		s.logger.InfoContext(ctx, "RMCP+ listener started",
+			"address", conn.LocalAddr().String(),
+			"channel", s.config.lanChannel)
+	}
 
-	l.InfoContext(ctx, "Starting IPMI server", "service", s.config.name)
+	for i := range s.config.hostInterfaces {
+		h := &s.config.hostInterfaces[i]
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.serveHost(ctx, h)
+		}()
+
+		s.logger.InfoContext(ctx, "Host interface started",
+			"interface", h.Name,
+			"framing", h.Framing.String(),
+			"channel", h.Channel,
+			"privilege", h.Privilege.String())
+	}
+
+	span.SetAttributes(
+		attribute.String("service.name", s.config.name),
+		attribute.String("service.version", s.config.version),
+		attribute.Bool("lan.enabled", s.config.enableLAN),
+		attribute.Int("host.interfaces", len(s.config.hostInterfaces)),
+	)
 
 	<-ctx.Done()
-	l.InfoContext(ctx, "Stopping IPMI server", "service", s.config.name, "reason", ctx.Err())
 
-	return ctx.Err()
+	err = ctx.Err()
+	ctx = context.WithoutCancel(ctx)
+	s.logger.InfoContext(ctx, "Stopping IPMI server", "reason", err)
+	s.shutdown()
+
+	return err
+}
+
+// loadSystemGUID returns the persistent system GUID in the SMBIOS byte order
+// used by IPMI. If it cannot be persisted, an ephemeral GUID is used instead.
+func (s *IPMISrv) loadSystemGUID(ctx context.Context) [16]byte {
+	guidStr, err := id.GetOrCreatePersistentID("guid", s.config.guidPath)
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to load persistent system GUID, using ephemeral GUID", "error", err)
+		guidStr = id.NewID()
+	}
+
+	u, err := uuid.Parse(guidStr)
+	if err != nil {
+		s.logger.WarnContext(ctx, "Invalid system GUID, using ephemeral GUID", "error", err)
+		u = uuid.New()
+	}
+
+	guid := [16]byte(u)
+	// Time low, time mid and time high fields are little-endian in SMBIOS format.
+	guid[0], guid[1], guid[2], guid[3] = guid[3], guid[2], guid[1], guid[0]
+	guid[4], guid[5] = guid[5], guid[4]
+	guid[6], guid[7] = guid[7], guid[6]
+
+	return guid
+}
+
+func (s *IPMISrv) shutdown() {
+	if s.lanConn != nil {
+		_ = s.lanConn.Close()
+	}
+	s.closeHostInterfaces()
+
+	s.identifyMu.Lock()
+	if s.identifyTimer != nil {
+		s.identifyTimer.Stop()
+		s.identifyTimer = nil
+	}
+	s.identifyMu.Unlock()
+
+	s.wg.Wait()
+
+	if s.sessions != nil {
+		s.sessions.clear()
+	}
 }
@@ -0,0 +1,481 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ipmisrv
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"log/slog"
+	"net"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	v1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+	"github.com/u-bmc/u-bmc/pkg/ipc"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const (
+	testUser     = "admin"
+	testPassword = "secret"
+	testRemote   = "192.0.2.10:623"
+)
+
+// fakeUserMgr answers the usermgr requests of the IPMI server and records
+// the login checks and authentications it receives. Password resets to one
+// of the rejected passwords fail.
+type fakeUserMgr struct {
+	mu       sync.Mutex
+	refuse   map[string]bool
+	rejected map[string]bool
+	checks   []*v1alpha1.CheckLoginRequest
+	authens  []*v1alpha1.AuthenticateUserRequest
+	resets   []*v1alpha1.ResetPasswordRequest
+}
+
+func (f *fakeUserMgr) recorded() ([]*v1alpha1.CheckLoginRequest, []*v1alpha1.AuthenticateUserRequest) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*v1alpha1.CheckLoginRequest(nil), f.checks...),
+		append([]*v1alpha1.AuthenticateUserRequest(nil), f.authens...)
+}
+
+func (f *fakeUserMgr) subscribe(t *testing.T, nc *nats.Conn) {
+	t.Helper()
+
+	handlers := map[string]nats.MsgHandler{
+		ipc.SubjectUserList: func(msg *nats.Msg) {
+			respond(t, msg, &v1alpha1.ListUsersResponse{})
+		},
+		ipc.SubjectUserCheckLogin: func(msg *nats.Msg) {
+			req := &v1alpha1.CheckLoginRequest{}
+			if err := req.UnmarshalVT(msg.Data); err != nil {
+				t.Errorf("unmarshal check login request: %v", err)
+				return
+			}
+			f.mu.Lock()
+			f.checks = append(f.checks, req)
+			allowed := !f.refuse[req.GetUsername()] && !req.GetProofFailed()
+			f.mu.Unlock()
+			respond(t, msg, &v1alpha1.CheckLoginResponse{Allowed: allowed})
+		},
+		ipc.SubjectUserAuthenticate: func(msg *nats.Msg) {
+			req := &v1alpha1.AuthenticateUserRequest{}
+			if err := req.UnmarshalVT(msg.Data); err != nil {
+				t.Errorf("unmarshal authenticate request: %v", err)
+				return
+			}
+			f.mu.Lock()
+			f.authens = append(f.authens, req)
+			success := !f.refuse[req.GetUsername()] && req.GetPassword() == testPassword
+			f.mu.Unlock()
+			respond(t, msg, &v1alpha1.AuthenticateUserResponse{Success: success})
+		},
+		ipc.SubjectUserResetPassword: func(msg *nats.Msg) {
+			req := &v1alpha1.ResetPasswordRequest{}
+			if err := req.UnmarshalVT(msg.Data); err != nil {
+				t.Errorf("unmarshal reset password request: %v", err)
+				return
+			}
+			f.mu.Lock()
+			f.resets = append(f.resets, req)
+			rejected := f.rejected[req.GetNewPassword()]
+			f.mu.Unlock()
+			if rejected {
+				reason := "invalid password: must not be a common password"
+				respond(t, msg, &v1alpha1.ResetPasswordResponse{FailureReason: &reason})
+				return
+			}
+			respond(t, msg, &v1alpha1.ResetPasswordResponse{Success: true})
+		},
+	}
+	for subject, handler := range handlers {
+		if _, err := nc.Subscribe(subject, handler); err != nil {
+			t.Fatalf("subscribe %s: %v", subject, err)
+		}
+	}
+}
+
+func respond(t *testing.T, msg *nats.Msg, resp vtMessage) {
+	t.Helper()
+
+	data, err := resp.MarshalVT()
+	if err != nil {
+		t.Errorf("marshal response: %v", err)
+		return
+	}
+	if err := msg.Respond(data); err != nil {
+		t.Errorf("respond: %v", err)
+	}
+}
+
+// newTestNATS starts an in-process NATS server.
+func newTestNATS(t *testing.T) *server.Server {
+	t.Helper()
+
+	ns, err := server.NewServer(&server.Options{DontListen: true, NoLog: true, NoSigs: true})
+	if err != nil {
+		t.Fatalf("create NATS server: %v", err)
+	}
+	go ns.Start()
+	t.Cleanup(ns.Shutdown)
+	if !ns.ReadyForConnections(5 * time.Second) {
+		t.Fatal("NATS server not ready")
+	}
+	return ns
+}
+
+// newTestServer returns an IPMI server wired to an in-process NATS server
+// with a fake usermgr. The key store holds testUser with testPassword.
+func newTestServer(t *testing.T, opts ...Option) (*IPMISrv, *fakeUserMgr) {
+	t.Helper()
+
+	nc, err := nats.Connect("", nats.InProcessServer(newTestNATS(t)))
+	if err != nil {
+		t.Fatalf("connect to NATS: %v", err)
+	}
+	t.Cleanup(nc.Close)
+
+	users := &fakeUserMgr{refuse: make(map[string]bool), rejected: make(map[string]bool)}
+	users.subscribe(t, nc)
+
+	ks := NewMemoryKeyStore()
+	if err := ks.Set(testUser, UserKey{Password: []byte(testPassword), Privilege: PrivilegeAdmin}); err != nil {
+		t.Fatalf("set key: %v", err)
+	}
+
+	s := New(append([]Option{WithKeyStore(ks)}, opts...)...)
+	s.nc = nc
+	s.logger = slog.New(slog.DiscardHandler)
+	s.tracer = noop.NewTracerProvider().Tracer("ipmisrv")
+	copy(s.guid[:], bytes.Repeat([]byte{0xA5}, len(s.guid)))
+	s.sessions = newSessionTable(s.config.maxSessions, s.config.sessionTimeout)
+	s.dispatcher = newDispatcher()
+	s.users = newUserTable()
+	s.registerAppCommands()
+
+	return s, users
+}
+
+// testConsole is a minimal RMCP+ remote console using cipher suite 17
+// (RAKP-HMAC-SHA256, HMAC-SHA256-128, AES-CBC-128).
+type testConsole struct {
+	t        *testing.T
+	exchange func(pkt []byte) []byte
+
+	remoteID  uint32
+	managedID uint32
+	username  string
+	role      uint8
+	rm        []byte
+	rc        []byte
+	guid      []byte
+	sik       []byte
+	k1        []byte
+	k2        []byte
+	seq       uint32
+	rqSeq     uint8
+}
+
+func newTestConsole(t *testing.T, exchange func(pkt []byte) []byte) *testConsole {
+	return &testConsole{
+		t:        t,
+		exchange: exchange,
+		remoteID: 0xA0A1A2A3,
+		rm:       bytes.Repeat([]byte{0x42}, 16),
+	}
+}
+
+func hmacSHA256(key []byte, parts ...[]byte) []byte {
+	m := hmac.New(sha256.New, key)
+	for _, p := range parts {
+		m.Write(p)
+	}
+	return m.Sum(nil)
+}
+
+func le32(v uint32) []byte {
+	return binary.LittleEndian.AppendUint32(nil, v)
+}
+
+// v20Packet builds an RMCP+ packet without a session trailer.
+func v20Packet(payloadType uint8, sessionID, seq uint32, payload []byte) []byte {
+	b := append(rmcpHeader(rmcpClassIPMI), authTypeRMCPPlus, payloadType)
+	b = binary.LittleEndian.AppendUint32(b, sessionID)
+	b = binary.LittleEndian.AppendUint32(b, seq)
+	b = binary.LittleEndian.AppendUint16(b, uint16(len(payload)))
+	return append(b, payload...)
+}
+
+// ipmiRequest encodes an IPMI LAN request message.
+func ipmiRequest(netFn, cmd, rqSeq uint8, data []byte) []byte {
+	b := []byte{0x20, netFn << 2}
+	b = append(b, -(b[0] + b[1]))
+	body := append([]byte{0x81, rqSeq << 2, cmd}, data...)
+	var sum uint8
+	for _, x := range body {
+		sum += x
+	}
+	return append(append(b, body...), -sum)
+}
+
+// handshake sends a session-less payload and returns the response payload.
+func (c *testConsole) handshake(payloadType uint8, payload []byte) []byte {
+	c.t.Helper()
+
+	resp := c.exchange(v20Packet(payloadType, 0, 0, payload))
+	if len(resp) < 16 {
+		c.t.Fatalf("payload type %#x: short response % x", payloadType, resp)
+	}
+	if resp[5] != payloadType+1 {
+		c.t.Fatalf("payload type %#x: response payload type %#x", payloadType, resp[5])
+	}
+	return resp[16:]
+}
+
+// openSession requests a session with the given algorithms and returns the
+// response payload.
+func (c *testConsole) openSession(role, auth, integrity, confidentiality uint8) []byte {
+	c.t.Helper()
+
+	algorithm := func(payloadType, number uint8) []byte {
+		if number == algorithmAny {
+			return []byte{payloadType, 0, 0, 0, 0, 0, 0, 0}
+		}
+		return []byte{payloadType, 0, 0, algorithmPayloadLen, number, 0, 0, 0}
+	}
+	p := []byte{0x11, role, 0, 0}
+	p = append(p, le32(c.remoteID)...)
+	p = append(p, algorithm(algorithmPayloadAuth, auth)...)
+	p = append(p, algorithm(algorithmPayloadIntegrity, integrity)...)
+	p = append(p, algorithm(algorithmPayloadConfidentiality, confidentiality)...)
+
+	resp := c.handshake(payloadTypeOpenSessionRequest, p)
+	if resp[1] == rakpStatusOK {
+		c.managedID = binary.LittleEndian.Uint32(resp[8:12])
+	}
+	return resp
+}
+
+// rakp1 sends RAKP Message 1 and verifies the key exchange authentication
+// code of a successful RAKP Message 2 against password.
+func (c *testConsole) rakp1(username string, role uint8, password string) uint8 {
+	c.t.Helper()
+
+	c.username, c.role = username, role
+	p := []byte{0x12, 0, 0, 0}
+	p = append(p, le32(c.managedID)...)
+	p = append(p, c.rm...)
+	p = append(p, role, 0, 0, uint8(len(username)))
+	p = append(p, username...)
+
+	resp := c.handshake(payloadTypeRAKP1, p)
+	if resp[1] != rakpStatusOK {
+		return resp[1]
+	}
+	if len(resp) != 40+sha256.Size {
+		c.t.Fatalf("RAKP2 length %d", len(resp))
+	}
+	c.rc = append([]byte(nil), resp[8:24]...)
+	c.guid = append([]byte(nil), resp[24:40]...)
+
+	want := hmacSHA256([]byte(password), le32(c.remoteID), le32(c.managedID), c.rm, c.rc, c.guid,
+		[]byte{role, uint8(len(username))}, []byte(username))
+	if !bytes.Equal(want, resp[40:]) {
+		c.t.Fatal("RAKP2 key exchange authentication code mismatch")
+	}
+	return rakpStatusOK
+}
+
+// rakp3 sends RAKP Message 3 proving knowledge of password and verifies the
+// integrity check value of a successful RAKP Message 4.
+func (c *testConsole) rakp3(password string) uint8 {
+	c.t.Helper()
+
+	kuid := []byte(password)
+	roleAndName := append([]byte{c.role, uint8(len(c.username))}, c.username...)
+	p := []byte{0x13, 0, 0, 0}
+	p = append(p, le32(c.managedID)...)
+	p = append(p, hmacSHA256(kuid, c.rc, le32(c.remoteID), roleAndName)...)
+
+	resp := c.handshake(payloadTypeRAKP3, p)
+	if resp[1] != rakpStatusOK {
+		return resp[1]
+	}
+
+	c.sik = hmacSHA256(kuid, c.rm, c.rc, roleAndName)
+	if !bytes.Equal(hmacSHA256(c.sik, c.rm, le32(c.managedID), c.guid)[:16], resp[8:]) {
+		c.t.Fatal("RAKP4 integrity check value mismatch")
+	}
+	c.k1 = hmacSHA256(c.sik, bytes.Repeat([]byte{0x01}, 20))
+	c.k2 = hmacSHA256(c.sik, bytes.Repeat([]byte{0x02}, 20))
+	return rakpStatusOK
+}
+
+// login establishes a session with administrator privilege.
+func (c *testConsole) login() {
+	c.t.Helper()
+
+	if resp := c.openSession(uint8(PrivilegeAdmin), algorithmAny, algorithmAny, algorithmAny); resp[1] != rakpStatusOK {
+		c.t.Fatalf("Open Session status %#x", resp[1])
+	}
+	if status := c.rakp1(testUser, uint8(PrivilegeAdmin), testPassword); status != rakpStatusOK {
+		c.t.Fatalf("RAKP2 status %#x", status)
+	}
+	if status := c.rakp3(testPassword); status != rakpStatusOK {
+		c.t.Fatalf("RAKP4 status %#x", status)
+	}
+}
+
+// sealRequest builds an encrypted, authenticated IPMI request packet.
+func (c *testConsole) sealRequest(netFn, cmd uint8, data []byte) []byte {
+	c.t.Helper()
+
+	c.seq++
+	c.rqSeq++
+	msg := ipmiRequest(netFn, cmd, c.rqSeq&0x3F, data)
+
+	pad := (aes.BlockSize - (len(msg)+1)%aes.BlockSize) % aes.BlockSize
+	for i := 1; i <= pad; i++ {
+		msg = append(msg, byte(i))
+	}
+	msg = append(msg, byte(pad))
+
+	block, err := aes.NewCipher(c.k2[:16])
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	iv := bytes.Repeat([]byte{byte(c.seq)}, aes.BlockSize)
+	ct := make([]byte, len(msg))
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(ct, msg)
+
+	pkt := v20Packet(payloadTypeIPMI|payloadEncrypted|payloadAuthenticated, c.managedID, c.seq, append(iv, ct...))
+	n := (4 - (len(pkt)-rmcpHeaderLen+2)%4) % 4
+	for range n {
+		pkt = append(pkt, 0xFF)
+	}
+	pkt = append(pkt, byte(n), nextHeaderIPMI)
+	return append(pkt, hmacSHA256(c.k1, pkt[rmcpHeaderLen:])[:16]...)
+}
+
+// openResponse verifies and decrypts an IPMI response packet and returns its
+// completion code and data.
+func (c *testConsole) openResponse(resp []byte) (uint8, []byte) {
+	c.t.Helper()
+
+	if len(resp) < rmcpHeaderLen+12+16 {
+		c.t.Fatalf("short response % x", resp)
+	}
+	icv := resp[len(resp)-16:]
+	if !bytes.Equal(hmacSHA256(c.k1, resp[rmcpHeaderLen:len(resp)-16])[:16], icv) {
+		c.t.Fatal("response integrity check value mismatch")
+	}
+	if resp[5] != payloadTypeIPMI|payloadEncrypted|payloadAuthenticated {
+		c.t.Fatalf("response payload type %#x", resp[5])
+	}
+	if id := binary.LittleEndian.Uint32(resp[6:10]); id != c.remoteID {
+		c.t.Fatalf("response session ID %#x, want %#x", id, c.remoteID)
+	}
+
+	payloadLen := int(binary.LittleEndian.Uint16(resp[14:16]))
+	payload := resp[16 : 16+payloadLen]
+	if len(payload) < 2*aes.BlockSize || len(payload)%aes.BlockSize != 0 {
+		c.t.Fatalf("bad encrypted payload length %d", len(payload))
+	}
+	block, err := aes.NewCipher(c.k2[:16])
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	pt := make([]byte, len(payload)-aes.BlockSize)
+	cipher.NewCBCDecrypter(block, payload[:aes.BlockSize]).CryptBlocks(pt, payload[aes.BlockSize:])
+	pt = pt[:len(pt)-1-int(pt[len(pt)-1])]
+
+	if len(pt) < 8 {
+		c.t.Fatalf("short response message % x", pt)
+	}
+	return pt[6], pt[7 : len(pt)-1]
+}
+
+// send sends an encrypted request within the session and returns the
+// completion code and data of the response.
+func (c *testConsole) send(netFn, cmd uint8, data []byte) (uint8, []byte) {
+	c.t.Helper()
+
+	resp := c.exchange(c.sealRequest(netFn, cmd, data))
+	if resp == nil {
+		c.t.Fatalf("no response to netfn %#x cmd %#x", netFn, cmd)
+	}
+	return c.openResponse(resp)
+}
+
+func TestRunCleansUpOnFailure(t *testing.T) {
+	busy, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer busy.Close()
+
+	host, bmc := NewHostPipe()
+	defer host.Close()
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantErr error
+	}{
+		{
+			name: "LAN address in use",
+			opts: []Option{
+				WithLANAddress(busy.LocalAddr().String()),
+				WithHostInterface(HostInterface{Name: "pipe", Transport: bmc, Framing: HostFramingKCS}),
+			},
+			wantErr: ErrListenFailed,
+		},
+		{
+			name:    "missing host interface device",
+			opts:    []Option{WithLANAddress("127.0.0.1:0"), WithKCSDevice(filepath.Join(t.TempDir(), "kcs"))},
+			wantErr: ErrHostInterfaceFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]Option{WithKeyStore(NewMemoryKeyStore()), WithGUIDPath(t.TempDir())}, tt.opts...)
+			s := New(opts...)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if err := s.Run(ctx, newTestNATS(t)); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Run() error = %v, want %v", err, tt.wantErr)
+			}
+
+			// No goroutine may outlive a failed start.
+			done := make(chan struct{})
+			go func() {
+				s.wg.Wait()
+				close(done)
+			}()
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+				t.Fatal("goroutines still running after Run failed")
+			}
+
+			if s.lanConn != nil {
+				if _, _, err := s.lanConn.ReadFrom(make([]byte, 1)); !errors.Is(err, net.ErrClosed) {
+					t.Errorf("LAN listener not closed: %v", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,264 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ipmisrv
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	// keyStoreSecretSuffix is appended to the path of a FileKeyStore to name
+	// the file holding its encryption key.
+	keyStoreSecretSuffix = ".key"
+	keyStoreSecretSize   = 32
+)
+
+// keyStoreAAD is authenticated along with the key store file, so that other
+// files encrypted under the same key are not accepted as key store.
+var keyStoreAAD = []byte("u-bmc ipmisrv key store v1")
+
+// storedKey is the persisted form of a UserKey.
+type storedKey struct {
+	Password  []byte         `json:"password"`
+	Privilege PrivilegeLevel `json:"privilege"`
+}
+
+// FileKeyStore is a KeyStoreWriter that persists the keys in a file, so that
+// keys set through Set User Password survive a restart. The file is encrypted
+// with AES-256-GCM under a key kept in a second file next to it, which is
+// created on first use. Every change rewrites the file atomically.
+type FileKeyStore struct {
+	mu   sync.RWMutex
+	path string
+	aead cipher.AEAD
+	keys map[string]UserKey
+}
+
+// OpenFileKeyStore loads the keys stored at path. A missing file is an empty
+// key store.
+func OpenFileKeyStore(path string) (*FileKeyStore, error) {
+	secret, err := loadKeyStoreSecret(path + keyStoreSecretSuffix)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrKeyStoreFailed, err)
+	}
+	block, err := aes.NewCipher(secret)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrKeyStoreFailed, err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrKeyStoreFailed, err)
+	}
+
+	ks := &FileKeyStore{
+		path: path,
+		aead: aead,
+		keys: make(map[string]UserKey),
+	}
+	if err := ks.load(); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrKeyStoreFailed, path, err)
+	}
+
+	return ks, nil
+}
+
+// loadKeyStoreSecret reads the encryption key of a key store, creating it
+// if it does not exist.
+func loadKeyStoreSecret(path string) ([]byte, error) {
+	secret, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if len(secret) != keyStoreSecretSize {
+			return nil, fmt.Errorf("%s must hold %d bytes", path, keyStoreSecretSize)
+		}
+		return secret, nil
+	case !errors.Is(err, fs.ErrNotExist):
+		return nil, err
+	}
+
+	secret = make([]byte, keyStoreSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := f.Write(secret); err != nil {
+		_ = f.Close()
+		_ = os.Remove(path)
+		return nil, err
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(path)
+		return nil, err
+	}
+
+	return secret, nil
+}
+
+// load decrypts the key store file into ks.keys.
+func (ks *FileKeyStore) load() error {
+	data, err := os.ReadFile(ks.path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return nil
+	case err != nil:
+		return err
+	}
+
+	nonceSize := ks.aead.NonceSize()
+	if len(data) < nonceSize {
+		return errors.New("malformed key store")
+	}
+	plaintext, err := ks.aead.Open(nil, data[:nonceSize], data[nonceSize:], keyStoreAAD)
+	if err != nil {
+		return err
+	}
+	defer clear(plaintext)
+
+	var stored map[string]storedKey
+	if err := json.Unmarshal(plaintext, &stored); err != nil {
+		return err
+	}
+	for username, sk := range stored {
+		key := UserKey(sk)
+		if err := validateUserKey(username, key); err != nil {
+			return fmt.Errorf("key of %q: %w", username, err)
+		}
+		ks.keys[username] = key
+	}
+
+	return nil
+}
+
+// save encrypts ks.keys and replaces the key store file. The caller must
+// hold ks.mu.
+func (ks *FileKeyStore) save() error {
+	stored := make(map[string]storedKey, len(ks.keys))
+	for username, key := range ks.keys {
+		stored[username] = storedKey(key)
+	}
+	plaintext, err := json.Marshal(stored)
+	if err != nil {
+		return err
+	}
+	defer clear(plaintext)
+
+	nonce := make([]byte, ks.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	return writeFileAtomic(ks.path, ks.aead.Seal(nonce, nonce, plaintext, keyStoreAAD))
+}
+
+// writeFileAtomic replaces the file at path with data through a temporary
+// file in the same directory, so that readers see either the old or the new
+// content.
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp.*")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+
+	return nil
+}
+
+// Lookup returns the key for the given username.
+func (ks *FileKeyStore) Lookup(_ context.Context, username string) (UserKey, error) {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	key, ok := ks.keys[username]
+	if !ok {
+		return UserKey{}, ErrUserNotFound
+	}
+
+	return UserKey{
+		Password:  append([]byte(nil), key.Password...),
+		Privilege: key.Privilege,
+	}, nil
+}
+
+// Set stores the key for the given username, replacing any existing entry.
+// The key is only kept if it could be persisted.
+func (ks *FileKeyStore) Set(username string, key UserKey) error {
+	if err := validateUserKey(username, key); err != nil {
+		return err
+	}
+
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	previous, existed := ks.keys[username]
+	ks.keys[username] = UserKey{
+		Password:  append([]byte(nil), key.Password...),
+		Privilege: key.Privilege,
+	}
+	if err := ks.save(); err != nil {
+		if existed {
+			ks.keys[username] = previous
+		} else {
+			delete(ks.keys, username)
+		}
+		return fmt.Errorf("%w: %w", ErrKeyStoreFailed, err)
+	}
+
+	return nil
+}
+
+// Delete removes the key for the given username.
+func (ks *FileKeyStore) Delete(username string) error {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	previous, existed := ks.keys[username]
+	if !existed {
+		return nil
+	}
+	delete(ks.keys, username)
+	if err := ks.save(); err != nil {
+		ks.keys[username] = previous
+		return fmt.Errorf("%w: %w", ErrKeyStoreFailed, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ipmisrv
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileKeyStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "ipmisrv", "keys")
+
+	ks, err := OpenFileKeyStore(path)
+	if err != nil {
+		t.Fatalf("open empty key store: %v", err)
+	}
+	if _, err := ks.Lookup(ctx, testUser); !errors.Is(err, ErrUserNotFound) {
+		t.Fatalf("lookup in empty key store: %v", err)
+	}
+	if err := ks.Set(testUser, UserKey{Password: []byte(testPassword), Privilege: PrivilegeAdmin}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if err := ks.Set("operator", UserKey{Password: []byte("operator"), Privilege: PrivilegeOperator}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if err := ks.Set("", UserKey{Privilege: PrivilegeUser}); err == nil {
+		t.Error("empty username accepted")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read key store: %v", err)
+	}
+	if bytes.Contains(data, []byte(testPassword)) {
+		t.Error("key store file contains a cleartext password")
+	}
+	if info, err := os.Stat(path + keyStoreSecretSuffix); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("encryption key file: %v %v", info, err)
+	}
+
+	// The keys survive reopening, as after a restart.
+	ks, err = OpenFileKeyStore(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	key, err := ks.Lookup(ctx, testUser)
+	if err != nil {
+		t.Fatalf("lookup after reopen: %v", err)
+	}
+	if string(key.Password) != testPassword || key.Privilege != PrivilegeAdmin {
+		t.Errorf("key after reopen = %q %s", key.Password, key.Privilege)
+	}
+
+	if err := ks.Delete("operator"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	ks, err = OpenFileKeyStore(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	if _, err := ks.Lookup(ctx, "operator"); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("deleted key still present: %v", err)
+	}
+
+	// A modified file is refused rather than silently dropping keys.
+	data[len(data)-1] ^= 0x01
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenFileKeyStore(path); !errors.Is(err, ErrKeyStoreFailed) {
+		t.Errorf("open modified key store: %v", err)
+	}
+}
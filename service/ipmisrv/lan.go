@@ -0,0 +1,284 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ipmisrv
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/binary"
+	"errors"
+	"net"
+	"time"
+)
+
+// maxPacketSize bounds the size of a single RMCP datagram.
+const maxPacketSize = 2048
+
+// serveLAN reads RMCP datagrams from conn and answers them until the context is
+// canceled or the connection is closed.
+func (s *IPMISrv) serveLAN(ctx context.Context, conn net.PacketConn) {
+	sem := make(chan struct{}, s.config.maxInflight)
+	buf := make([]byte, maxPacketSize)
+
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, net.ErrClosed) {
+				return
+			}
+			s.logger.WarnContext(ctx, "Failed to read LAN packet", "error", err)
+			continue
+		}
+
+		pkt := make([]byte, n)
+		copy(pkt, buf[:n])
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer func() { <-sem }()
+
+			resp := s.handlePacket(ctx, pkt, addr.String())
+			if resp == nil {
+				return
+			}
+			if _, err := conn.WriteTo(resp, addr); err != nil {
+				s.logger.WarnContext(ctx, "Failed to write LAN packet", "remote", addr.String(), "error", err)
+			}
+		}()
+	}
+}
+
+// expireSessions periodically removes idle RMCP+ sessions.
+func (s *IPMISrv) expireSessions(ctx context.Context) {
+	ticker := time.NewTicker(s.config.sessionTimeout / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			for _, id := range s.sessions.expire(now) {
+				s.logger.InfoContext(ctx, "RMCP+ session expired", "session_id", id)
+			}
+		}
+	}
+}
+
+// handlePacket processes a single RMCP datagram and returns the response, if any.
+func (s *IPMISrv) handlePacket(ctx context.Context, pkt []byte, remoteAddr string) []byte {
+	if len(pkt) < rmcpHeaderLen || pkt[0] != rmcpVersion {
+		return nil
+	}
+
+	body := pkt[rmcpHeaderLen:]
+	switch pkt[3] & rmcpClassMask {
+	case rmcpClassASF:
+		return handleASF(body)
+	case rmcpClassIPMI:
+		if len(body) == 0 {
+			return nil
+		}
+		var (
+			resp []byte
+			err  error
+		)
+		switch body[0] {
+		case authTypeNone:
+			resp, err = s.handleV15(ctx, body, remoteAddr)
+		case authTypeRMCPPlus:
+			resp, err = s.handleV20(ctx, body, remoteAddr)
+		default:
+			err = ErrUnsupportedAuthType
+		}
+		if err != nil {
+			s.logger.DebugContext(ctx, "Dropping LAN packet", "remote", remoteAddr, "error", err)
+			return nil
+		}
+		return resp
+	default:
+		return nil
+	}
+}
+
+// handleV15 processes unauthenticated, session-less IPMI v1.5 messages. These
+// are only used by clients to discover channel capabilities before RMCP+.
+func (s *IPMISrv) handleV15(ctx context.Context, b []byte, remoteAddr string) ([]byte, error) {
+	if len(b) < sessionV15HeaderLen {
+		return nil, ErrPacketTooShort
+	}
+	if binary.LittleEndian.Uint32(b[5:9]) != 0 {
+		return nil, ErrUnsupportedAuthType
+	}
+
+	msgLen := int(b[9])
+	if len(b) < sessionV15HeaderLen+msgLen {
+		return nil, ErrPacketTooShort
+	}
+
+	msg, err := decodeMessage(b[sessionV15HeaderLen : sessionV15HeaderLen+msgLen])
+	if err != nil {
+		return nil, err
+	}
+
+	data, cc := s.dispatch(ctx, &request{
+		msg:        msg,
+		channel:    s.config.lanChannel,
+		privilege:  PrivilegeNone,
+		remoteAddr: remoteAddr,
+	})
+
+	return buildV15(encodeResponse(msg, cc, data)), nil
+}
+
+// handleV20 processes RMCP+ (IPMI v2.0) packets, both session-less handshake
+// payloads and authenticated, encrypted session traffic.
+func (s *IPMISrv) handleV20(ctx context.Context, b []byte, remoteAddr string) ([]byte, error) {
+	if len(b) < sessionV20HeaderLen {
+		return nil, ErrPacketTooShort
+	}
+
+	flags := b[1] &^ payloadTypeMask
+	payloadType := b[1] & payloadTypeMask
+	if payloadType == payloadTypeOEM {
+		return nil, ErrUnsupportedPayload
+	}
+
+	sessionID := binary.LittleEndian.Uint32(b[2:6])
+	seq := binary.LittleEndian.Uint32(b[6:10])
+	payloadLen := int(binary.LittleEndian.Uint16(b[10:12]))
+	if len(b) < sessionV20HeaderLen+payloadLen {
+		return nil, ErrPacketTooShort
+	}
+	payload := b[sessionV20HeaderLen : sessionV20HeaderLen+payloadLen]
+
+	if sessionID == 0 {
+		if flags != 0 {
+			return nil, ErrUnsupportedPayload
+		}
+		return s.handleSessionless(ctx, payloadType, payload, remoteAddr)
+	}
+
+	sess, err := s.sessions.get(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	sess.mu.Lock()
+	if sess.state != sessionStateActive {
+		sess.mu.Unlock()
+		return nil, ErrSessionNotActive
+	}
+	suite, k1, k2, remoteID := sess.suite, sess.k1, sess.k2, sess.remoteID
+	sess.mu.Unlock()
+
+	// Every supported cipher suite requires integrity and confidentiality.
+	if flags != payloadEncrypted|payloadAuthenticated || payloadType != payloadTypeIPMI {
+		return nil, ErrUnsupportedPayload
+	}
+
+	if err := verifyTrailer(b, payloadLen, &suite, k1); err != nil {
+		return nil, err
+	}
+
+	sess.mu.Lock()
+	ok := sess.acceptSequence(seq)
+	sess.mu.Unlock()
+	if !ok {
+		return nil, ErrInvalidSequence
+	}
+
+	plain, err := decryptPayload(k2, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := decodeMessage(plain)
+	if err != nil {
+		return nil, err
+	}
+
+	sess.mu.Lock()
+	privilege := sess.privilege
+	sess.mu.Unlock()
+
+	data, cc := s.dispatch(ctx, &request{
+		msg:        msg,
+		channel:    s.config.lanChannel,
+		privilege:  privilege,
+		session:    sess,
+		remoteAddr: remoteAddr,
+	})
+
+	enc, err := encryptPayload(k2, encodeResponse(msg, cc, data))
+	if err != nil {
+		return nil, err
+	}
+
+	sess.mu.Lock()
+	outSeq := sess.nextOutSeq()
+	sess.mu.Unlock()
+
+	return buildV20(payloadEncrypted|payloadAuthenticated|payloadTypeIPMI, remoteID, outSeq, enc, &suite, k1), nil
+}
+
+// verifyTrailer checks the RMCP+ session trailer and integrity code of b.
+func verifyTrailer(b []byte, payloadLen int, suite *cipherSuite, k1 []byte) error {
+	trailerStart := sessionV20HeaderLen + payloadLen
+	if len(b) < trailerStart+2+suite.icvLen {
+		return ErrPacketTooShort
+	}
+
+	icvStart := len(b) - suite.icvLen
+	padLen := int(b[icvStart-2])
+	if b[icvStart-1] != nextHeaderIPMI || trailerStart+padLen+2 != icvStart {
+		return ErrIntegrityCheckFailed
+	}
+
+	if !hmac.Equal(suite.authCode(k1, b[:icvStart]), b[icvStart:]) {
+		return ErrIntegrityCheckFailed
+	}
+
+	return nil
+}
+
+// handleSessionless processes RMCP+ payloads sent outside of a session.
+func (s *IPMISrv) handleSessionless(ctx context.Context, payloadType uint8, payload []byte, remoteAddr string) ([]byte, error) {
+	switch payloadType {
+	case payloadTypeOpenSessionRequest:
+		return sessionlessResponse(payloadTypeOpenSessionResponse, s.handleOpenSession(ctx, payload, remoteAddr))
+	case payloadTypeRAKP1:
+		return sessionlessResponse(payloadTypeRAKP2, s.handleRAKP1(ctx, payload, remoteAddr))
+	case payloadTypeRAKP3:
+		return sessionlessResponse(payloadTypeRAKP4, s.handleRAKP3(ctx, payload))
+	case payloadTypeIPMI:
+		msg, err := decodeMessage(payload)
+		if err != nil {
+			return nil, err
+		}
+		data, cc := s.dispatch(ctx, &request{
+			msg:        msg,
+			channel:    s.config.lanChannel,
+			privilege:  PrivilegeNone,
+			remoteAddr: remoteAddr,
+		})
+		return buildV20(payloadTypeIPMI, 0, 0, encodeResponse(msg, cc, data), nil, nil), nil
+	default:
+		return nil, ErrUnsupportedPayload
+	}
+}
+
+// sessionlessResponse wraps a handshake response payload for transmission.
+func sessionlessResponse(payloadType uint8, payload []byte) ([]byte, error) {
+	if payload == nil {
+		return nil, nil
+	}
+	return buildV20(payloadType, 0, 0, payload, nil, nil), nil
+}
@@ -0,0 +1,164 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ipmisrv
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"testing"
+	"time"
+)
+
+// v15Request builds an unauthenticated IPMI v1.5 LAN packet.
+func v15Request(msg []byte) []byte {
+	b := append(rmcpHeader(rmcpClassIPMI), authTypeNone, 0, 0, 0, 0, 0, 0, 0, 0, uint8(len(msg)))
+	return append(b, msg...)
+}
+
+func TestGetChannelAuthCaps(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   []byte
+		wantCC uint8
+		want   []byte
+	}{
+		{
+			name: "IPMI v2.0 extended data",
+			data: []byte{authCapsExtendedData | channelCurrent, uint8(PrivilegeAdmin)},
+			want: []byte{DefaultLANChannel, authCapsExtendedData, authCapsNonNullUsernames, authCapsIPMIv20, 0, 0, 0, 0},
+		},
+		{
+			name: "IPMI v1.5 request",
+			data: []byte{DefaultLANChannel, uint8(PrivilegeUser)},
+			want: []byte{DefaultLANChannel, 0, authCapsNonNullUsernames, 0, 0, 0, 0, 0},
+		},
+		{
+			name:   "other channel",
+			data:   []byte{DefaultLANChannel + 1, uint8(PrivilegeAdmin)},
+			wantCC: CCInvalidField,
+		},
+		{
+			name:   "invalid privilege",
+			data:   []byte{channelCurrent, 0x0F},
+			wantCC: CCInvalidField,
+		},
+		{
+			name:   "short request",
+			data:   []byte{channelCurrent},
+			wantCC: CCInvalidLength,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := newTestServer(t)
+
+			resp := s.handlePacket(context.Background(), v15Request(ipmiRequest(NetFnApp, cmdGetChannelAuthCaps, 1, tt.data)), testRemote)
+			if len(resp) < rmcpHeaderLen+sessionV15HeaderLen+8 {
+				t.Fatalf("short response % x", resp)
+			}
+			msg := resp[rmcpHeaderLen+sessionV15HeaderLen:]
+			if len(msg) != int(resp[rmcpHeaderLen+sessionV15HeaderLen-1]) {
+				t.Fatalf("message length %d, header says %d", len(msg), resp[rmcpHeaderLen+sessionV15HeaderLen-1])
+			}
+			if cc := msg[6]; cc != tt.wantCC {
+				t.Fatalf("completion code = %#x, want %#x", cc, tt.wantCC)
+			}
+			if data := msg[7 : len(msg)-1]; tt.wantCC == CCSuccess && !bytes.Equal(data, tt.want) {
+				t.Errorf("response data = % x, want % x", data, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetChannelAuthCapsKG(t *testing.T) {
+	s, _ := newTestServer(t, WithKG(bytes.Repeat([]byte{0x11}, keyLength)))
+
+	resp := s.handlePacket(context.Background(), v15Request(ipmiRequest(NetFnApp, cmdGetChannelAuthCaps, 1,
+		[]byte{authCapsExtendedData | channelCurrent, uint8(PrivilegeAdmin)})), testRemote)
+	msg := resp[rmcpHeaderLen+sessionV15HeaderLen:]
+	if status := msg[9]; status&authCapsKGSet == 0 {
+		t.Errorf("status = %#x, KG set bit missing", status)
+	}
+}
+
+// TestEncryptedSession establishes a cipher suite 17 session over a loopback
+// UDP socket and exchanges AES-CBC-128 encrypted, HMAC-SHA256-128
+// authenticated messages.
+func TestEncryptedSession(t *testing.T) {
+	s, _ := newTestServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.serveLAN(ctx, conn)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		conn.Close()
+		s.wg.Wait()
+	})
+
+	client, err := net.Dial("udp", conn.LocalAddr().String())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer client.Close()
+
+	c := newTestConsole(t, func(pkt []byte) []byte {
+		if _, err := client.Write(pkt); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+		if err := client.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+			t.Fatal(err)
+		}
+		buf := make([]byte, maxPacketSize)
+		n, err := client.Read(buf)
+		if err != nil {
+			t.Fatalf("read: %v", err)
+		}
+		return buf[:n]
+	})
+	c.login()
+
+	cc, data := c.send(NetFnApp, cmdGetSystemGUID, nil)
+	if cc != CCSuccess {
+		t.Fatalf("Get System GUID completion code = %#x", cc)
+	}
+	if !bytes.Equal(data, s.guid[:]) {
+		t.Errorf("GUID = % x, want % x", data, s.guid[:])
+	}
+
+	cc, data = c.send(NetFnApp, cmdSetSessionPrivilegeLevel, []byte{uint8(PrivilegeAdmin)})
+	if cc != CCSuccess || len(data) != 1 || PrivilegeLevel(data[0]) != PrivilegeAdmin {
+		t.Fatalf("Set Session Privilege Level = %#x % x", cc, data)
+	}
+
+	// Tampered and replayed packets are dropped without a response.
+	pkt := c.sealRequest(NetFnApp, cmdGetSystemGUID, nil)
+	tampered := append([]byte(nil), pkt...)
+	tampered[len(tampered)-1] ^= 0x01
+	if resp := s.handlePacket(ctx, tampered, client.LocalAddr().String()); resp != nil {
+		t.Error("response to a packet with an invalid integrity check value")
+	}
+	if resp := s.handlePacket(ctx, pkt, client.LocalAddr().String()); resp == nil {
+		t.Fatal("no response to a valid packet")
+	}
+	if resp := s.handlePacket(ctx, pkt, client.LocalAddr().String()); resp != nil {
+		t.Error("response to a replayed packet")
+	}
+
+	cc, _ = c.send(NetFnApp, cmdCloseSession, le32(c.managedID))
+	if cc != CCSuccess {
+		t.Fatalf("Close Session completion code = %#x", cc)
+	}
+	if _, err := s.sessions.get(c.managedID); err == nil {
+		t.Error("session still open after Close Session")
+	}
+}
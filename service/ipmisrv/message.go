@@ -0,0 +1,164 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ipmisrv
+
+import "fmt"
+
+// Network function codes (request variants).
+const (
+	NetFnChassis   uint8 = 0x00
+	NetFnBridge    uint8 = 0x02
+	NetFnSensor    uint8 = 0x04
+	NetFnApp       uint8 = 0x06
+	NetFnFirmware  uint8 = 0x08
+	NetFnStorage   uint8 = 0x0A
+	NetFnTransport uint8 = 0x0C
+	NetFnGroupExt  uint8 = 0x2C
+	NetFnOEM       uint8 = 0x2E
+)
+
+// Completion codes returned in IPMI responses.
+const (
+	CCSuccess               uint8 = 0x00
+	CCNodeBusy              uint8 = 0xC0
+	CCInvalidCommand        uint8 = 0xC1
+	CCInvalidForLUN         uint8 = 0xC2
+	CCTimeout               uint8 = 0xC3
+	CCOutOfSpace            uint8 = 0xC4
+	CCInvalidReservation    uint8 = 0xC5
+	CCRequestTruncated      uint8 = 0xC6
+	CCInvalidLength         uint8 = 0xC7
+	CCLengthExceeded        uint8 = 0xC8
+	CCParameterOutOfRange   uint8 = 0xC9
+	CCCannotReturnBytes     uint8 = 0xCA
+	CCNotPresent            uint8 = 0xCB
+	CCInvalidField          uint8 = 0xCC
+	CCIllegalCommand        uint8 = 0xCD
+	CCCannotRespond         uint8 = 0xCE
+	CCDuplicateRequest      uint8 = 0xCF
+	CCSDRUpdateMode         uint8 = 0xD0
+	CCFirmwareUpdateMode    uint8 = 0xD1
+	CCInitInProgress        uint8 = 0xD2
+	CCDestinationUnavail    uint8 = 0xD3
+	CCInsufficientPrivilege uint8 = 0xD4
+	CCNotSupportedInState   uint8 = 0xD5
+	CCSubFunctionDisabled   uint8 = 0xD6
+	CCUnspecified           uint8 = 0xFF
+)
+
+// Command specific completion codes for session management commands.
+const (
+	CCSessionInvalidID        uint8 = 0x87
+	CCSessionPrivExceedsLimit uint8 = 0x81
+)
+
+// IPMI message framing fields.
+const (
+	minMessageLen         = 7
+	lunMask         uint8 = 0x03
+	rqSeqShift            = 2
+	netFnShift            = 2
+	netFnResponseOr uint8 = 0x01
+)
+
+// PrivilegeLevel is an IPMI privilege level as carried on the wire.
+type PrivilegeLevel uint8
+
+// IPMI privilege levels.
+const (
+	PrivilegeNone     PrivilegeLevel = 0x00
+	PrivilegeCallback PrivilegeLevel = 0x01
+	PrivilegeUser     PrivilegeLevel = 0x02
+	PrivilegeOperator PrivilegeLevel = 0x03
+	PrivilegeAdmin    PrivilegeLevel = 0x04
+	PrivilegeOEM      PrivilegeLevel = 0x05
+)
+
+// String returns the name of the privilege level.
+func (p PrivilegeLevel) String() string {
+	switch p {
+	case PrivilegeNone:
+		return "none"
+	case PrivilegeCallback:
+		return "callback"
+	case PrivilegeUser:
+		return "user"
+	case PrivilegeOperator:
+		return "operator"
+	case PrivilegeAdmin:
+		return "administrator"
+	case PrivilegeOEM:
+		return "oem"
+	default:
+		return fmt.Sprintf("unknown(0x%02x)", uint8(p))
+	}
+}
+
+// Valid reports whether p is a privilege level that can be requested by a client.
+func (p PrivilegeLevel) Valid() bool {
+	return p >= PrivilegeCallback && p <= PrivilegeOEM
+}
+
+// Message is a decoded IPMI request or response as carried on IPMB and LAN.
+type Message struct {
+	RsAddr  uint8
+	NetFn   uint8
+	RsLUN   uint8
+	RqAddr  uint8
+	RqSeq   uint8
+	RqLUN   uint8
+	Command uint8
+	Data    []byte
+}
+
+// checksum computes the two's complement checksum over b.
+func checksum(b []byte) uint8 {
+	var sum uint8
+	for _, v := range b {
+		sum += v
+	}
+	return -sum
+}
+
+// decodeMessage parses an IPMI LAN message and validates both checksums.
+func decodeMessage(b []byte) (*Message, error) {
+	if len(b) < minMessageLen {
+		return nil, ErrPacketTooShort
+	}
+	if checksum(b[:2]) != b[2] {
+		return nil, fmt.Errorf("%w: header", ErrInvalidChecksum)
+	}
+	if checksum(b[3:len(b)-1]) != b[len(b)-1] {
+		return nil, fmt.Errorf("%w: body", ErrInvalidChecksum)
+	}
+
+	return &Message{
+		RsAddr:  b[0],
+		NetFn:   b[1] >> netFnShift,
+		RsLUN:   b[1] & lunMask,
+		RqAddr:  b[3],
+		RqSeq:   b[4] >> rqSeqShift,
+		RqLUN:   b[4] & lunMask,
+		Command: b[5],
+		Data:    b[6 : len(b)-1],
+	}, nil
+}
+
+// encodeResponse builds the response message for req carrying cc and data.
+func encodeResponse(req *Message, cc uint8, data []byte) []byte {
+	out := make([]byte, 0, minMessageLen+1+len(data))
+	out = append(out,
+		req.RqAddr,
+		(req.NetFn|netFnResponseOr)<<netFnShift|req.RqLUN,
+	)
+	out = append(out, checksum(out[:2]))
+	out = append(out,
+		req.RsAddr,
+		req.RqSeq<<rqSeqShift|req.RsLUN,
+		req.Command,
+		cc,
+	)
+	out = append(out, data...)
+	out = append(out, checksum(out[3:]))
+	return out
+}
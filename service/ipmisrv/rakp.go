@@ -0,0 +1,344 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ipmisrv
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"net"
+)
+
+// RMCP+ and RAKP message status codes.
+const (
+	rakpStatusOK                    uint8 = 0x00
+	rakpStatusInsufficientResources uint8 = 0x01
+	rakpStatusInvalidSessionID      uint8 = 0x02
+	rakpStatusInvalidRole           uint8 = 0x09
+	rakpStatusUnauthorizedRole      uint8 = 0x0A
+	rakpStatusInvalidNameLength     uint8 = 0x0C
+	rakpStatusUnauthorizedName      uint8 = 0x0D
+	rakpStatusInvalidIntegrityValue uint8 = 0x0F
+	rakpStatusNoCipherSuiteMatch    uint8 = 0x11
+	rakpStatusIllegalParameter      uint8 = 0x12
+)
+
+// Message layout constants for Open Session and RAKP payloads.
+const (
+	openSessionRequestLen = 32
+	openSessionErrorLen   = 8
+	algorithmPayloadLen   = 8
+	rakp1MinLen           = 28
+	rakp3MinLen           = 8
+	maxUsernameLength     = 16
+	rakpRolePrivilegeMask = 0x0F
+)
+
+// Algorithm payload types within Open Session.
+const (
+	algorithmPayloadAuth            uint8 = 0x00
+	algorithmPayloadIntegrity       uint8 = 0x01
+	algorithmPayloadConfidentiality uint8 = 0x02
+)
+
+// algorithmAny marks an algorithm payload of length zero, which lets the BMC
+// choose the algorithm.
+const algorithmAny = 0xFF
+
+// parseAlgorithm extracts the algorithm from an Open Session algorithm payload.
+func parseAlgorithm(b []byte, payloadType uint8) (uint8, bool) {
+	if b[0] != payloadType {
+		return 0, false
+	}
+	switch b[3] {
+	case 0:
+		return algorithmAny, true
+	case algorithmPayloadLen:
+		return b[4] & 0x3F, true
+	default:
+		return 0, false
+	}
+}
+
+// matchCipherSuite returns the preferred cipher suite for the proposed algorithms.
+func matchCipherSuite(auth, integrity, confidentiality uint8) (cipherSuite, bool) {
+	for _, cs := range supportedCipherSuites() {
+		if (auth == algorithmAny || auth == cs.auth) &&
+			(integrity == algorithmAny || integrity == cs.integrity) &&
+			(confidentiality == algorithmAny || confidentiality == cs.confidentiality) {
+			return cs, true
+		}
+	}
+	return cipherSuite{}, false
+}
+
+// rakpError builds the short error form shared by Open Session, RAKP2 and RAKP4.
+func rakpError(tag, status uint8, remoteID uint32) []byte {
+	out := make([]byte, 4, openSessionErrorLen)
+	out[0] = tag
+	out[1] = status
+	return binary.LittleEndian.AppendUint32(out, remoteID)
+}
+
+// handleOpenSession processes an RMCP+ Open Session Request and allocates a
+// session in the open state.
+func (s *IPMISrv) handleOpenSession(ctx context.Context, p []byte, remoteAddr string) []byte {
+	if len(p) < openSessionErrorLen {
+		return nil
+	}
+
+	tag := p[0]
+	remoteID := binary.LittleEndian.Uint32(p[4:8])
+	if len(p) < openSessionRequestLen || remoteID == 0 {
+		return rakpError(tag, rakpStatusIllegalParameter, remoteID)
+	}
+
+	maxPrivilege := PrivilegeLevel(p[1] & rakpRolePrivilegeMask)
+	if maxPrivilege == PrivilegeNone {
+		maxPrivilege = PrivilegeAdmin
+	}
+	if !maxPrivilege.Valid() {
+		return rakpError(tag, rakpStatusInvalidRole, remoteID)
+	}
+
+	auth, okAuth := parseAlgorithm(p[8:16], algorithmPayloadAuth)
+	integrity, okIntegrity := parseAlgorithm(p[16:24], algorithmPayloadIntegrity)
+	confidentiality, okConf := parseAlgorithm(p[24:32], algorithmPayloadConfidentiality)
+	if !okAuth || !okIntegrity || !okConf {
+		return rakpError(tag, rakpStatusIllegalParameter, remoteID)
+	}
+
+	suite, ok := matchCipherSuite(auth, integrity, confidentiality)
+	if !ok {
+		return rakpError(tag, rakpStatusNoCipherSuiteMatch, remoteID)
+	}
+
+	sess, err := s.sessions.create(remoteID, remoteAddr, suite, maxPrivilege)
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to create RMCP+ session", "remote", remoteAddr, "error", err)
+		return rakpError(tag, rakpStatusInsufficientResources, remoteID)
+	}
+
+	out := make([]byte, 0, 36)
+	out = append(out, tag, rakpStatusOK, uint8(maxPrivilege), 0x00)
+	out = binary.LittleEndian.AppendUint32(out, remoteID)
+	out = binary.LittleEndian.AppendUint32(out, sess.id)
+	out = append(out, algorithmPayloadAuth, 0x00, 0x00, algorithmPayloadLen, suite.auth, 0x00, 0x00, 0x00)
+	out = append(out, algorithmPayloadIntegrity, 0x00, 0x00, algorithmPayloadLen, suite.integrity, 0x00, 0x00, 0x00)
+	out = append(out, algorithmPayloadConfidentiality, 0x00, 0x00, algorithmPayloadLen, suite.confidentiality, 0x00, 0x00, 0x00)
+
+	return out
+}
+
+// handleRAKP1 processes RAKP Message 1. It looks up the user key, checks with
+// usermgr that the account may log in and answers with RAKP Message 2. The
+// remote console has not proven anything yet, so no login is recorded.
+func (s *IPMISrv) handleRAKP1(ctx context.Context, p []byte, remoteAddr string) []byte {
+	if len(p) < rakp1MinLen {
+		return nil
+	}
+
+	tag := p[0]
+	sessionID := binary.LittleEndian.Uint32(p[4:8])
+
+	sess, err := s.sessions.get(sessionID)
+	if err != nil {
+		return rakpError(tag, rakpStatusInvalidSessionID, 0)
+	}
+
+	sess.mu.Lock()
+	state, remoteID, maxPrivilege, suite := sess.state, sess.remoteID, sess.maxPrivilege, sess.suite
+	sess.mu.Unlock()
+
+	if state != sessionStateOpen {
+		return rakpError(tag, rakpStatusInvalidSessionID, remoteID)
+	}
+
+	fail := func(status uint8) []byte {
+		s.sessions.remove(sessionID)
+		return rakpError(tag, status, remoteID)
+	}
+
+	userLen := int(p[27])
+	if userLen > maxUsernameLength || len(p) < rakp1MinLen+userLen {
+		return fail(rakpStatusInvalidNameLength)
+	}
+	// Anonymous (null user name) logins are not supported.
+	if userLen == 0 {
+		return fail(rakpStatusUnauthorizedName)
+	}
+	username := string(p[rakp1MinLen : rakp1MinLen+userLen])
+
+	role := p[24]
+	privilege := PrivilegeLevel(role & rakpRolePrivilegeMask)
+	if !privilege.Valid() {
+		return fail(rakpStatusInvalidRole)
+	}
+	if privilege > maxPrivilege {
+		return fail(rakpStatusUnauthorizedRole)
+	}
+
+	key, err := s.config.keyStore.Lookup(ctx, username)
+	if err != nil {
+		if !errors.Is(err, ErrUserNotFound) {
+			s.logger.WarnContext(ctx, "Failed to look up IPMI user key", "user", username, "error", err)
+		}
+		return fail(rakpStatusUnauthorizedName)
+	}
+	if privilege > key.Privilege || !s.userAllowed(ctx, username, privilege) {
+		return fail(rakpStatusUnauthorizedRole)
+	}
+
+	if err := s.checkLogin(ctx, username, hostOf(remoteAddr), false); err != nil {
+		s.logger.WarnContext(ctx, "IPMI login refused", "user", username, "remote", remoteAddr, "error", err)
+		return fail(rakpStatusUnauthorizedName)
+	}
+
+	var rc [16]byte
+	if _, err := rand.Read(rc[:]); err != nil {
+		return fail(rakpStatusInsufficientResources)
+	}
+
+	sess.mu.Lock()
+	if sess.state != sessionStateOpen {
+		sess.mu.Unlock()
+		return rakpError(tag, rakpStatusInvalidSessionID, remoteID)
+	}
+	copy(sess.rm[:], p[8:24])
+	sess.rc = rc
+	sess.role = role
+	sess.username = username
+	sess.userKey = key.Password
+	sess.maxPrivilege = privilege
+	sess.state = sessionStateRAKP2
+	authCode := suite.hmac(sess.userKey,
+		binary.LittleEndian.AppendUint32(nil, remoteID),
+		binary.LittleEndian.AppendUint32(nil, sessionID),
+		sess.rm[:],
+		sess.rc[:],
+		s.guid[:],
+		[]byte{role, uint8(userLen)},
+		[]byte(username),
+	)
+	sess.mu.Unlock()
+
+	out := make([]byte, 0, 40+len(authCode))
+	out = append(out, tag, rakpStatusOK, 0x00, 0x00)
+	out = binary.LittleEndian.AppendUint32(out, remoteID)
+	out = append(out, rc[:]...)
+	out = append(out, s.guid[:]...)
+	out = append(out, authCode...)
+
+	return out
+}
+
+// handleRAKP3 processes RAKP Message 3. It validates the remote console's key
+// exchange code, reports the outcome to usermgr, derives the session keys and
+// answers with RAKP Message 4. Failed proofs count towards the lockout
+// threshold of the account, and successful ones are verified against usermgr,
+// which records the login.
+func (s *IPMISrv) handleRAKP3(ctx context.Context, p []byte) []byte {
+	if len(p) < rakp3MinLen {
+		return nil
+	}
+
+	tag := p[0]
+	status := p[1]
+	sessionID := binary.LittleEndian.Uint32(p[4:8])
+
+	sess, err := s.sessions.get(sessionID)
+	if err != nil {
+		return rakpError(tag, rakpStatusInvalidSessionID, 0)
+	}
+
+	// A non-zero status means the remote console rejected RAKP2.
+	if status != rakpStatusOK {
+		s.sessions.remove(sessionID)
+		return nil
+	}
+
+	sess.mu.Lock()
+	if sess.state != sessionStateRAKP2 {
+		remoteID := sess.remoteID
+		sess.mu.Unlock()
+		return rakpError(tag, rakpStatusInvalidSessionID, remoteID)
+	}
+
+	suite := sess.suite
+	remoteID := sess.remoteID
+	username, sourceIP := sess.username, hostOf(sess.remoteAddr)
+	roleAndName := append([]byte{sess.role, uint8(len(sess.username))}, sess.username...)
+
+	expected := suite.hmac(sess.userKey,
+		sess.rc[:],
+		binary.LittleEndian.AppendUint32(nil, remoteID),
+		roleAndName,
+	)
+	if !hmac.Equal(expected, p[rakp3MinLen:]) {
+		sess.mu.Unlock()
+		s.sessions.remove(sessionID)
+		s.logger.WarnContext(ctx, "RAKP3 integrity check failed", "user", username, "session_id", sessionID)
+		if err := s.checkLogin(ctx, username, sourceIP, true); err != nil && !errors.Is(err, ErrAuthenticationFailed) {
+			s.logger.WarnContext(ctx, "Failed to report failed IPMI login", "user", username, "error", err)
+		}
+		return rakpError(tag, rakpStatusInvalidIntegrityValue, remoteID)
+	}
+
+	// Duplicate RAKP3 messages are refused while usermgr is asked.
+	sess.state = sessionStateRAKP3
+	userKey := append([]byte(nil), sess.userKey...)
+	sess.mu.Unlock()
+
+	err = s.authenticate(ctx, username, userKey, sourceIP)
+	clear(userKey)
+	if err != nil {
+		s.sessions.remove(sessionID)
+		s.logger.WarnContext(ctx, "IPMI authentication rejected", "user", username, "session_id", sessionID, "error", err)
+		return rakpError(tag, rakpStatusUnauthorizedName, remoteID)
+	}
+
+	sess.mu.Lock()
+	kg := s.config.kg
+	if len(kg) == 0 {
+		kg = sess.userKey
+	}
+	sess.sik = suite.hmac(kg, sess.rm[:], sess.rc[:], roleAndName)
+	sess.k1, sess.k2 = suite.deriveKeys(sess.sik)
+
+	icv := suite.hmac(sess.sik,
+		sess.rm[:],
+		binary.LittleEndian.AppendUint32(nil, sessionID),
+		s.guid[:],
+	)[:suite.icvLen]
+
+	sess.privilege = min(PrivilegeUser, sess.maxPrivilege)
+	sess.state = sessionStateActive
+	clear(sess.userKey)
+	sess.userKey = nil
+	privilege := sess.maxPrivilege
+	sess.mu.Unlock()
+
+	s.logger.InfoContext(ctx, "RMCP+ session established",
+		"user", username,
+		"session_id", sessionID,
+		"cipher_suite", suite.id,
+		"max_privilege", privilege.String())
+
+	out := make([]byte, 0, 8+len(icv))
+	out = append(out, tag, rakpStatusOK, 0x00, 0x00)
+	out = binary.LittleEndian.AppendUint32(out, remoteID)
+	out = append(out, icv...)
+
+	return out
+}
+
+// hostOf returns the host part of a network address.
+func hostOf(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
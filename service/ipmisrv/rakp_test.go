@@ -0,0 +1,277 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ipmisrv
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+)
+
+func TestOpenSession(t *testing.T) {
+	tests := []struct {
+		name            string
+		role            uint8
+		remoteID        uint32
+		auth            uint8
+		integrity       uint8
+		confidentiality uint8
+		wantStatus      uint8
+		wantAlgorithms  [3]uint8
+		wantPrivilege   PrivilegeLevel
+	}{
+		{
+			name:            "any algorithms select cipher suite 17",
+			role:            uint8(PrivilegeAdmin),
+			remoteID:        1,
+			auth:            algorithmAny,
+			integrity:       algorithmAny,
+			confidentiality: algorithmAny,
+			wantAlgorithms:  [3]uint8{authRAKPHMACSHA256, integrityHMACSHA256128, confidentialityAESCBC128},
+			wantPrivilege:   PrivilegeAdmin,
+		},
+		{
+			name:            "cipher suite 3",
+			role:            uint8(PrivilegeOperator),
+			remoteID:        1,
+			auth:            authRAKPHMACSHA1,
+			integrity:       integrityHMACSHA196,
+			confidentiality: confidentialityAESCBC128,
+			wantAlgorithms:  [3]uint8{authRAKPHMACSHA1, integrityHMACSHA196, confidentialityAESCBC128},
+			wantPrivilege:   PrivilegeOperator,
+		},
+		{
+			name:            "highest privilege defaults to administrator",
+			role:            0,
+			remoteID:        1,
+			auth:            algorithmAny,
+			integrity:       algorithmAny,
+			confidentiality: algorithmAny,
+			wantAlgorithms:  [3]uint8{authRAKPHMACSHA256, integrityHMACSHA256128, confidentialityAESCBC128},
+			wantPrivilege:   PrivilegeAdmin,
+		},
+		{
+			name:            "unencrypted sessions are refused",
+			role:            uint8(PrivilegeAdmin),
+			remoteID:        1,
+			auth:            algorithmAny,
+			integrity:       algorithmAny,
+			confidentiality: 0x00,
+			wantStatus:      rakpStatusNoCipherSuiteMatch,
+		},
+		{
+			name:            "invalid role",
+			role:            0x0F,
+			remoteID:        1,
+			auth:            algorithmAny,
+			integrity:       algorithmAny,
+			confidentiality: algorithmAny,
+			wantStatus:      rakpStatusInvalidRole,
+		},
+		{
+			name:            "zero remote session ID",
+			role:            uint8(PrivilegeAdmin),
+			auth:            algorithmAny,
+			integrity:       algorithmAny,
+			confidentiality: algorithmAny,
+			wantStatus:      rakpStatusIllegalParameter,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := newTestServer(t)
+			c := newTestConsole(t, func(pkt []byte) []byte {
+				return s.handlePacket(context.Background(), pkt, testRemote)
+			})
+			c.remoteID = tt.remoteID
+
+			resp := c.openSession(tt.role, tt.auth, tt.integrity, tt.confidentiality)
+			if resp[1] != tt.wantStatus {
+				t.Fatalf("status = %#x, want %#x", resp[1], tt.wantStatus)
+			}
+			if got := binary.LittleEndian.Uint32(resp[4:8]); got != tt.remoteID {
+				t.Errorf("remote session ID = %#x, want %#x", got, tt.remoteID)
+			}
+			if tt.wantStatus != rakpStatusOK {
+				if len(resp) != openSessionErrorLen {
+					t.Errorf("error response length = %d, want %d", len(resp), openSessionErrorLen)
+				}
+				if n := s.sessions.count(); n != 0 {
+					t.Errorf("%d sessions allocated for a refused request", n)
+				}
+				return
+			}
+
+			if len(resp) != 36 {
+				t.Fatalf("response length = %d, want 36", len(resp))
+			}
+			if got := PrivilegeLevel(resp[2]); got != tt.wantPrivilege {
+				t.Errorf("privilege = %s, want %s", got, tt.wantPrivilege)
+			}
+			got := [3]uint8{resp[16], resp[24], resp[32]}
+			if got != tt.wantAlgorithms {
+				t.Errorf("algorithms = %v, want %v", got, tt.wantAlgorithms)
+			}
+			if _, err := s.sessions.get(c.managedID); err != nil {
+				t.Errorf("session %#x not allocated: %v", c.managedID, err)
+			}
+		})
+	}
+}
+
+func TestOpenSessionShortRequest(t *testing.T) {
+	s, _ := newTestServer(t)
+
+	p := []byte{0x11, uint8(PrivilegeAdmin), 0, 0}
+	p = append(p, le32(1)...)
+	resp := s.handlePacket(context.Background(), v20Packet(payloadTypeOpenSessionRequest, 0, 0, p), testRemote)
+	if len(resp) != 16+openSessionErrorLen {
+		t.Fatalf("response length = %d", len(resp))
+	}
+	if status := resp[17]; status != rakpStatusIllegalParameter {
+		t.Errorf("status = %#x, want %#x", status, rakpStatusIllegalParameter)
+	}
+}
+
+func TestRAKP(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("valid proof establishes a session", func(t *testing.T) {
+		s, users := newTestServer(t)
+		c := newTestConsole(t, func(pkt []byte) []byte { return s.handlePacket(ctx, pkt, testRemote) })
+
+		c.openSession(uint8(PrivilegeAdmin), algorithmAny, algorithmAny, algorithmAny)
+		if status := c.rakp1(testUser, uint8(PrivilegeAdmin), testPassword); status != rakpStatusOK {
+			t.Fatalf("RAKP2 status = %#x", status)
+		}
+
+		// Nothing has been proven yet, so no login may have been recorded.
+		checks, authens := users.recorded()
+		if len(checks) != 1 || checks[0].GetProofFailed() || checks[0].GetUsername() != testUser {
+			t.Fatalf("login checks after RAKP1 = %v", checks)
+		}
+		if checks[0].GetSourceIp() != "192.0.2.10" {
+			t.Errorf("source IP = %q", checks[0].GetSourceIp())
+		}
+		if len(authens) != 0 {
+			t.Fatalf("%d authentications before RAKP3", len(authens))
+		}
+
+		if status := c.rakp3(testPassword); status != rakpStatusOK {
+			t.Fatalf("RAKP4 status = %#x", status)
+		}
+
+		checks, authens = users.recorded()
+		if len(checks) != 1 {
+			t.Errorf("%d login checks, want 1", len(checks))
+		}
+		if len(authens) != 1 || authens[0].GetUsername() != testUser || authens[0].GetPassword() != testPassword {
+			t.Fatalf("authentications = %v", authens)
+		}
+
+		sess, err := s.sessions.get(c.managedID)
+		if err != nil {
+			t.Fatalf("session not found: %v", err)
+		}
+		sess.mu.Lock()
+		defer sess.mu.Unlock()
+		if sess.state != sessionStateActive {
+			t.Errorf("session state = %v, want active", sess.state)
+		}
+		if sess.userKey != nil {
+			t.Error("user key retained in active session")
+		}
+	})
+
+	t.Run("invalid proof is reported as a failed login", func(t *testing.T) {
+		s, users := newTestServer(t)
+		c := newTestConsole(t, func(pkt []byte) []byte { return s.handlePacket(ctx, pkt, testRemote) })
+
+		c.openSession(uint8(PrivilegeAdmin), algorithmAny, algorithmAny, algorithmAny)
+		if status := c.rakp1(testUser, uint8(PrivilegeAdmin), testPassword); status != rakpStatusOK {
+			t.Fatalf("RAKP2 status = %#x", status)
+		}
+		if status := c.rakp3("wrong"); status != rakpStatusInvalidIntegrityValue {
+			t.Fatalf("RAKP4 status = %#x, want %#x", status, rakpStatusInvalidIntegrityValue)
+		}
+
+		checks, authens := users.recorded()
+		if len(checks) != 2 || checks[0].GetProofFailed() || !checks[1].GetProofFailed() {
+			t.Fatalf("login checks = %v, want a check followed by a failed proof", checks)
+		}
+		if len(authens) != 0 {
+			t.Errorf("%d authentications for an invalid proof", len(authens))
+		}
+		if _, err := s.sessions.get(c.managedID); err == nil {
+			t.Error("session kept after an invalid proof")
+		}
+	})
+
+	t.Run("refused account", func(t *testing.T) {
+		s, users := newTestServer(t)
+		users.refuse[testUser] = true
+		c := newTestConsole(t, func(pkt []byte) []byte { return s.handlePacket(ctx, pkt, testRemote) })
+
+		c.openSession(uint8(PrivilegeAdmin), algorithmAny, algorithmAny, algorithmAny)
+		if status := c.rakp1(testUser, uint8(PrivilegeAdmin), testPassword); status != rakpStatusUnauthorizedName {
+			t.Fatalf("RAKP2 status = %#x, want %#x", status, rakpStatusUnauthorizedName)
+		}
+		if _, authens := users.recorded(); len(authens) != 0 {
+			t.Errorf("%d authentications for a refused account", len(authens))
+		}
+		if _, err := s.sessions.get(c.managedID); err == nil {
+			t.Error("session kept for a refused account")
+		}
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		s, users := newTestServer(t)
+		c := newTestConsole(t, func(pkt []byte) []byte { return s.handlePacket(ctx, pkt, testRemote) })
+
+		c.openSession(uint8(PrivilegeAdmin), algorithmAny, algorithmAny, algorithmAny)
+		if status := c.rakp1("nobody", uint8(PrivilegeAdmin), testPassword); status != rakpStatusUnauthorizedName {
+			t.Fatalf("RAKP2 status = %#x, want %#x", status, rakpStatusUnauthorizedName)
+		}
+		if checks, authens := users.recorded(); len(checks) != 0 || len(authens) != 0 {
+			t.Errorf("usermgr asked about an unknown user: %v %v", checks, authens)
+		}
+	})
+
+	t.Run("privilege above the session limit", func(t *testing.T) {
+		s, _ := newTestServer(t)
+		c := newTestConsole(t, func(pkt []byte) []byte { return s.handlePacket(ctx, pkt, testRemote) })
+
+		c.openSession(uint8(PrivilegeOperator), algorithmAny, algorithmAny, algorithmAny)
+		if status := c.rakp1(testUser, uint8(PrivilegeAdmin), testPassword); status != rakpStatusUnauthorizedRole {
+			t.Fatalf("RAKP2 status = %#x, want %#x", status, rakpStatusUnauthorizedRole)
+		}
+	})
+
+	t.Run("RAKP3 without RAKP1", func(t *testing.T) {
+		s, users := newTestServer(t)
+		c := newTestConsole(t, func(pkt []byte) []byte { return s.handlePacket(ctx, pkt, testRemote) })
+
+		c.openSession(uint8(PrivilegeAdmin), algorithmAny, algorithmAny, algorithmAny)
+		c.username, c.role = testUser, uint8(PrivilegeAdmin)
+		if status := c.rakp3(testPassword); status != rakpStatusInvalidSessionID {
+			t.Fatalf("RAKP4 status = %#x, want %#x", status, rakpStatusInvalidSessionID)
+		}
+		if _, authens := users.recorded(); len(authens) != 0 {
+			t.Errorf("%d authentications without RAKP1", len(authens))
+		}
+	})
+
+	t.Run("replayed RAKP3", func(t *testing.T) {
+		s, users := newTestServer(t)
+		c := newTestConsole(t, func(pkt []byte) []byte { return s.handlePacket(ctx, pkt, testRemote) })
+
+		c.login()
+		if status := c.rakp3(testPassword); status != rakpStatusInvalidSessionID {
+			t.Fatalf("RAKP4 status = %#x, want %#x", status, rakpStatusInvalidSessionID)
+		}
+		if _, authens := users.recorded(); len(authens) != 1 {
+			t.Errorf("%d authentications, want 1", len(authens))
+		}
+	})
+}
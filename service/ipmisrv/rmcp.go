@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ipmisrv
+
+import (
+	"encoding/binary"
+)
+
+// RMCP header fields.
+const (
+	rmcpHeaderLen       = 4
+	rmcpVersion   uint8 = 0x06
+	rmcpSeqNoAck  uint8 = 0xFF
+	rmcpClassMask uint8 = 0x1F
+	rmcpClassASF  uint8 = 0x06
+	rmcpClassIPMI uint8 = 0x07
+)
+
+// ASF presence ping/pong fields.
+const (
+	asfHeaderLen        = 8
+	asfIANA      uint32 = 0x000011BE
+	asfTypePing  uint8  = 0x80
+	asfTypePong  uint8  = 0x40
+	asfPongLen   uint8  = 0x10
+	// asfEntitiesIPMI indicates IPMI support and ASF version 1.0.
+	asfEntitiesIPMI uint8 = 0x81
+)
+
+// Session header authentication types.
+const (
+	authTypeNone     uint8 = 0x00
+	authTypeRMCPPlus uint8 = 0x06
+)
+
+// IPMI v1.5 and v2.0 session header lengths.
+const (
+	sessionV15HeaderLen = 10
+	sessionV20HeaderLen = 12
+)
+
+// RMCP+ payload types and flags.
+const (
+	payloadTypeIPMI                uint8 = 0x00
+	payloadTypeOEM                 uint8 = 0x02
+	payloadTypeOpenSessionRequest  uint8 = 0x10
+	payloadTypeOpenSessionResponse uint8 = 0x11
+	payloadTypeRAKP1               uint8 = 0x12
+	payloadTypeRAKP2               uint8 = 0x13
+	payloadTypeRAKP3               uint8 = 0x14
+	payloadTypeRAKP4               uint8 = 0x15
+
+	payloadEncrypted     uint8 = 0x80
+	payloadAuthenticated uint8 = 0x40
+	payloadTypeMask      uint8 = 0x3F
+)
+
+// nextHeaderIPMI is the next header value of the RMCP+ session trailer.
+const nextHeaderIPMI uint8 = 0x07
+
+// rmcpHeader returns an RMCP header of the given message class.
+func rmcpHeader(class uint8) []byte {
+	return []byte{rmcpVersion, 0x00, rmcpSeqNoAck, class}
+}
+
+// handleASF answers ASF presence pings. All other ASF messages are ignored.
+func handleASF(b []byte) []byte {
+	if len(b) < asfHeaderLen {
+		return nil
+	}
+	if binary.BigEndian.Uint32(b[0:4]) != asfIANA || b[4] != asfTypePing {
+		return nil
+	}
+
+	out := rmcpHeader(rmcpClassASF)
+	out = binary.BigEndian.AppendUint32(out, asfIANA)
+	out = append(out, asfTypePong, b[5], 0x00, asfPongLen)
+	out = binary.BigEndian.AppendUint32(out, asfIANA)
+	out = binary.BigEndian.AppendUint32(out, 0) // OEM defined
+	out = append(out, asfEntitiesIPMI, 0x00)
+	out = append(out, make([]byte, 6)...)
+
+	return out
+}
+
+// buildV15 wraps msg in an unauthenticated IPMI v1.5 session header.
+func buildV15(msg []byte) []byte {
+	out := rmcpHeader(rmcpClassIPMI)
+	out = append(out, authTypeNone)
+	out = binary.LittleEndian.AppendUint32(out, 0)
+	out = binary.LittleEndian.AppendUint32(out, 0)
+	out = append(out, byte(len(msg)))
+	out = append(out, msg...)
+
+	return out
+}
+
+// buildV20 wraps payload in an IPMI v2.0 session header. When suite is non-nil
+// the session trailer and integrity code computed with k1 are appended.
+func buildV20(payloadType uint8, sessionID, seq uint32, payload []byte, suite *cipherSuite, k1 []byte) []byte {
+	out := rmcpHeader(rmcpClassIPMI)
+	start := len(out)
+	out = append(out, authTypeRMCPPlus, payloadType)
+	out = binary.LittleEndian.AppendUint32(out, sessionID)
+	out = binary.LittleEndian.AppendUint32(out, seq)
+	out = binary.LittleEndian.AppendUint16(out, uint16(len(payload)))
+	out = append(out, payload...)
+
+	if suite == nil {
+		return out
+	}
+
+	padLen := (4 - (len(out)-start+2)%4) % 4
+	for range padLen {
+		out = append(out, 0xFF)
+	}
+	out = append(out, byte(padLen), nextHeaderIPMI)
+	out = append(out, suite.authCode(k1, out[start:])...)
+
+	return out
+}
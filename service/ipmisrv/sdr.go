@@ -0,0 +1,668 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ipmisrv
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	v1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+	"github.com/u-bmc/u-bmc/pkg/ipc"
+)
+
+// Storage network function SDR repository commands.
+const (
+	cmdGetSDRRepositoryInfo uint8 = 0x20
+	cmdReserveSDRRepository uint8 = 0x22
+	cmdGetSDR               uint8 = 0x23
+)
+
+// SDR repository and record layout constants.
+const (
+	sdrVersion           uint8  = 0x51
+	sdrTypeFullSensor    uint8  = 0x01
+	sdrTypeCompactSensor uint8  = 0x02
+	sdrHeaderLen                = 5
+	sdrGetSDRRequestLen         = 6
+	sdrOwnerBMC          uint8  = 0x20
+	sdrMaxIDLength              = 16
+	sdrIDTypeASCII       uint8  = 0xC0
+	sdrFirstRecord       uint16 = 0x0000
+	sdrLastRecord        uint16 = 0xFFFF
+	sdrReadEntireRecord  uint8  = 0xFF
+	// sdrOperationSupport advertises non-modal updates and Reserve SDR Repository.
+	sdrOperationSupport uint8 = 0x22
+	maxSensorNumber           = 0xFE
+)
+
+// Sensor record field values.
+const (
+	sensorInitDefault            uint8  = 0x63
+	sensorCapsAutoRearm          uint8  = 0x40
+	sensorCapsThresholdsReadable uint8  = 0x04
+	sensorCapsNoEvents           uint8  = 0x03
+	sensorCapsGlobalDisableOnly  uint8  = 0x02
+	readingTypeThreshold         uint8  = 0x01
+	readingTypeDigital           uint8  = 0x03
+	digitalReadingMask           uint16 = 0x0003
+	thresholdMaskShift                  = 12
+	upperThresholdShift                 = 3
+	sensorMaxReading             uint8  = 0xFF
+	sensorMinReading             uint8  = 0x00
+	unitsRatePerMinute           uint8  = 0x04 << 3
+	unitsPercentage              uint8  = 0x01
+	sensorRangeHeadroom                 = 1.25
+)
+
+// IPMI sensor types.
+const (
+	sensorTypeTemperature uint8 = 0x01
+	sensorTypeVoltage     uint8 = 0x02
+	sensorTypeCurrent     uint8 = 0x03
+	sensorTypeFan         uint8 = 0x04
+	sensorTypeOtherUnits  uint8 = 0x0B
+)
+
+// IPMI entity IDs.
+const (
+	entitySystemBoard uint8 = 0x07
+	entityFan         uint8 = 0x1D
+)
+
+// IPMI sensor base unit codes.
+const (
+	unitUnspecified uint8 = 0
+	unitCelsius     uint8 = 1
+	unitFahrenheit  uint8 = 2
+	unitKelvin      uint8 = 3
+	unitVolts       uint8 = 4
+	unitAmps        uint8 = 5
+	unitWatts       uint8 = 6
+	unitJoules      uint8 = 7
+	unitKilopascal  uint8 = 14
+	unitRPM         uint8 = 18
+	unitHertz       uint8 = 19
+	unitMeters      uint8 = 34
+	unitLiters      uint8 = 37
+)
+
+// linearization holds the linear conversion factors of a full sensor record.
+// A raw reading x converts to y = (M*x + B*10^Bexp) * 10^Rexp.
+type linearization struct {
+	m    int
+	b    int
+	bExp int
+	rExp int
+}
+
+// newLinearization returns conversion factors covering lo to hi with the 8-bit
+// raw reading range. The resolution is rounded up to 1, 2 or 5 times a power of
+// ten so that readings display without rounding noise.
+func newLinearization(lo, hi float64) linearization {
+	step := (hi - lo) / float64(sensorMaxReading)
+	if !(step > 0) || math.IsInf(step, 0) {
+		step = 1
+	}
+
+	rExp := int(math.Floor(math.Log10(step)))
+	m := 10
+	switch mantissa := step / math.Pow10(rExp); {
+	case mantissa <= 1:
+		m = 1
+	case mantissa <= 2:
+		m = 2
+	case mantissa <= 5:
+		m = 5
+	}
+	if m == 10 {
+		m = 1
+		rExp++
+	}
+	rExp = min(max(rExp, -8), 7)
+
+	b := math.Floor(lo/(float64(m)*math.Pow10(rExp))) * float64(m)
+	bExp := 0
+	for math.Abs(b) > 511 && bExp < 7 {
+		b = math.Floor(b / 10)
+		bExp++
+	}
+
+	return linearization{m: m, b: int(b), bExp: bExp, rExp: rExp}
+}
+
+// raw converts a value into the raw reading of the sensor record.
+func (l linearization) raw(v float64) uint8 {
+	x := (v/math.Pow10(l.rExp) - float64(l.b)*math.Pow10(l.bExp)) / float64(l.m)
+	return uint8(min(max(math.Round(x), float64(sensorMinReading)), float64(sensorMaxReading)))
+}
+
+// appendTo appends the M, B, accuracy and exponent bytes of a full sensor record.
+func (l linearization) appendTo(out []byte) []byte {
+	m := uint16(int16(l.m)) & 0x3FF
+	b := uint16(int16(l.b)) & 0x3FF
+	return append(out,
+		uint8(m), uint8(m>>8)<<6,
+		uint8(b), uint8(b>>8)<<6,
+		0x00,
+		uint8(l.rExp&0x0F)<<4|uint8(l.bExp&0x0F),
+	)
+}
+
+// sdrSensor is the information needed to answer sensor commands for a sensor
+// in the SDR repository.
+type sdrSensor struct {
+	id         string
+	sensorType uint8
+	discrete   bool
+	// scale converts sensormon readings into the unit of the sensor record.
+	scale float64
+	conv  linearization
+}
+
+// sdrRepository holds the sensor data records synthesized from the sensors
+// reported by sensormon. Sensor numbers are kept stable while a sensor exists.
+type sdrRepository struct {
+	mu          sync.RWMutex
+	records     [][]byte
+	sensors     map[uint8]*sdrSensor
+	numbers     map[string]uint8
+	fingerprint string
+	reservation uint16
+	updated     time.Time
+}
+
+// newSDRRepository creates an empty SDR repository.
+func newSDRRepository() *sdrRepository {
+	return &sdrRepository{
+		sensors: make(map[uint8]*sdrSensor),
+		numbers: make(map[string]uint8),
+	}
+}
+
+// update regenerates the records if the sensors changed since the last update.
+// It reports whether the repository changed and how many sensors did not fit.
+func (r *sdrRepository) update(sensors []*v1alpha1.Sensor, now time.Time) (bool, int) {
+	sensors = slices.Clone(sensors)
+	slices.SortFunc(sensors, func(a, b *v1alpha1.Sensor) int {
+		return strings.Compare(a.GetId(), b.GetId())
+	})
+	fingerprint := sensorFingerprint(sensors)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if fingerprint == r.fingerprint {
+		return false, 0
+	}
+
+	present := make(map[string]bool, len(sensors))
+	for _, sensor := range sensors {
+		present[sensor.GetId()] = true
+	}
+	for id := range r.numbers {
+		if !present[id] {
+			delete(r.numbers, id)
+		}
+	}
+
+	used := make(map[uint8]bool, len(r.numbers))
+	for _, number := range r.numbers {
+		used[number] = true
+	}
+
+	byNumber := make(map[uint8]*v1alpha1.Sensor, len(sensors))
+	skipped := 0
+	next := uint8(1)
+	for _, sensor := range sensors {
+		number, ok := r.numbers[sensor.GetId()]
+		if !ok {
+			for next <= maxSensorNumber && used[next] {
+				next++
+			}
+			if next > maxSensorNumber {
+				skipped++
+				continue
+			}
+			number = next
+			used[number] = true
+			r.numbers[sensor.GetId()] = number
+		}
+		byNumber[number] = sensor
+	}
+
+	numbers := make([]uint8, 0, len(byNumber))
+	for number := range byNumber {
+		numbers = append(numbers, number)
+	}
+	slices.Sort(numbers)
+
+	instances := make(map[uint8]uint8)
+	r.records = make([][]byte, 0, len(numbers))
+	r.sensors = make(map[uint8]*sdrSensor, len(numbers))
+	for i, number := range numbers {
+		recordID := uint16(i + 1)
+		sensor := byNumber[number]
+		_, entity := sensorTypeAndEntity(sensor.GetContext())
+		instances[entity]++
+
+		var (
+			record []byte
+			info   *sdrSensor
+		)
+		if sensor.GetDiscreteReading() != nil {
+			record, info = compactSensorRecord(recordID, number, instances[entity], sensor)
+		} else {
+			record, info = fullSensorRecord(recordID, number, instances[entity], sensor)
+		}
+		r.records = append(r.records, record)
+		r.sensors[number] = info
+	}
+
+	r.fingerprint = fingerprint
+	r.updated = now
+	// Changing the repository cancels any outstanding reservation.
+	r.reservation++
+
+	return true, skipped
+}
+
+// info returns the number of records and the time of the last update.
+func (r *sdrRepository) info() (int, time.Time) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.records), r.updated
+}
+
+// reserve returns a new reservation ID, canceling the previous one.
+func (r *sdrRepository) reserve() uint16 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reservation++
+	if r.reservation == 0 {
+		r.reservation++
+	}
+	return r.reservation
+}
+
+// get returns the record with the given ID and the ID of the next record. The
+// reservation is only checked for partial reads, as required by Get SDR.
+func (r *sdrRepository) get(reservation, recordID uint16, partial bool) ([]byte, uint16, uint8) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if partial && (reservation == 0 || reservation != r.reservation) {
+		return nil, 0, CCInvalidReservation
+	}
+
+	var index int
+	switch recordID {
+	case sdrFirstRecord:
+		index = 0
+	case sdrLastRecord:
+		index = len(r.records) - 1
+	default:
+		index = int(recordID) - 1
+	}
+	if index < 0 || index >= len(r.records) {
+		return nil, 0, CCNotPresent
+	}
+
+	next := sdrLastRecord
+	if index+1 < len(r.records) {
+		next = uint16(index + 2)
+	}
+
+	return r.records[index], next, CCSuccess
+}
+
+// sensor returns the sensor with the given sensor number.
+func (r *sdrRepository) sensor(number uint8) (*sdrSensor, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	sensor, ok := r.sensors[number]
+	return sensor, ok
+}
+
+// lookup returns the sensor number and sensor with the given sensormon ID.
+func (r *sdrRepository) lookup(id string) (uint8, *sdrSensor, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	number, ok := r.numbers[id]
+	if !ok {
+		return 0, nil, false
+	}
+	sensor, ok := r.sensors[number]
+	return number, sensor, ok
+}
+
+// sensorFingerprint summarizes the properties of the sensors that end up in
+// the SDR records, excluding the readings themselves.
+func sensorFingerprint(sensors []*v1alpha1.Sensor) string {
+	var b strings.Builder
+	for _, sensor := range sensors {
+		fmt.Fprintf(&b, "%s|%s|%d|%d|%t", sensor.GetId(), sensor.GetName(),
+			sensor.GetContext(), sensor.GetUnit(), sensor.GetDiscreteReading() != nil)
+		thresholds := thresholdsOf(sensor.GetAnalogReading())
+		for _, bit := range thresholdBits() {
+			if value, ok := thresholds[bit]; ok {
+				fmt.Fprintf(&b, "|%d=%g", bit, value)
+			}
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// fullSensorRecord builds a Full Sensor Record (type 01h) for an analog sensor.
+func fullSensorRecord(recordID uint16, number, instance uint8, sensor *v1alpha1.Sensor) ([]byte, *sdrSensor) {
+	sensorType, entity := sensorTypeAndEntity(sensor.GetContext())
+	units, baseUnit, scale := sensorUnits(sensor.GetUnit())
+	analog := sensor.GetAnalogReading()
+
+	lo, hi := sensorRange(sensor.GetContext(), analog, scale)
+	conv := newLinearization(lo, hi)
+
+	thresholds := thresholdsOf(analog)
+	mask := thresholdMask(thresholds)
+	rawThreshold := func(bit uint8) uint8 {
+		if value, ok := thresholds[bit]; ok {
+			return conv.raw(value * scale)
+		}
+		return 0x00
+	}
+
+	// Threshold crossings are logged to the SEL by selmgr, so sensors with
+	// thresholds generate events that can only be disabled globally.
+	caps := sensorCapsAutoRearm | sensorCapsNoEvents
+	if mask != 0 {
+		caps = sensorCapsAutoRearm | sensorCapsThresholdsReadable | sensorCapsGlobalDisableOnly
+	}
+	lowerMask := mask & (thresholdLowerNonCritical | thresholdLowerCritical)
+	upperMask := (mask & (thresholdUpperNonCritical | thresholdUpperCritical)) >> upperThresholdShift
+	events := thresholdEventMask(mask)
+
+	out := make([]byte, 0, 64)
+	out = binary.LittleEndian.AppendUint16(out, recordID)
+	out = append(out, sdrVersion, sdrTypeFullSensor, 0x00)
+	out = append(out, sdrOwnerBMC, 0x00, number, entity, instance, sensorInitDefault, caps, sensorType, readingTypeThreshold)
+	out = binary.LittleEndian.AppendUint16(out, uint16(lowerMask)<<thresholdMaskShift|events)
+	out = binary.LittleEndian.AppendUint16(out, uint16(upperMask)<<thresholdMaskShift|events)
+	out = append(out, mask, 0x00)
+	out = append(out, units, baseUnit, 0x00, 0x00)
+	out = conv.appendTo(out)
+	// No nominal or normal range; the full raw range is valid.
+	out = append(out, 0x00, 0x00, 0x00, 0x00, sensorMaxReading, sensorMinReading)
+	out = append(out,
+		0x00,
+		rawThreshold(thresholdUpperCritical),
+		rawThreshold(thresholdUpperNonCritical),
+		0x00,
+		rawThreshold(thresholdLowerCritical),
+		rawThreshold(thresholdLowerNonCritical),
+	)
+	// Hysteresis, reserved and OEM bytes.
+	out = append(out, 0x00, 0x00, 0x00, 0x00, 0x00)
+	out = appendIDString(out, sensor.GetName())
+	out[4] = uint8(len(out) - sdrHeaderLen)
+
+	return out, &sdrSensor{
+		id:         sensor.GetId(),
+		sensorType: sensorType,
+		scale:      scale,
+		conv:       conv,
+	}
+}
+
+// compactSensorRecord builds a Compact Sensor Record (type 02h) for a discrete
+// sensor, reported as a digital asserted/deasserted sensor.
+func compactSensorRecord(recordID uint16, number, instance uint8, sensor *v1alpha1.Sensor) ([]byte, *sdrSensor) {
+	sensorType, entity := sensorTypeAndEntity(sensor.GetContext())
+	units, baseUnit, scale := sensorUnits(sensor.GetUnit())
+
+	out := make([]byte, 0, 48)
+	out = binary.LittleEndian.AppendUint16(out, recordID)
+	out = append(out, sdrVersion, sdrTypeCompactSensor, 0x00)
+	out = append(out, sdrOwnerBMC, 0x00, number, entity, instance, sensorInitDefault,
+		sensorCapsAutoRearm|sensorCapsNoEvents, sensorType, readingTypeDigital)
+	out = binary.LittleEndian.AppendUint16(out, 0x0000)
+	out = binary.LittleEndian.AppendUint16(out, 0x0000)
+	out = binary.LittleEndian.AppendUint16(out, digitalReadingMask)
+	out = append(out, units, baseUnit, 0x00)
+	// Record sharing, hysteresis, reserved and OEM bytes.
+	out = append(out, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00)
+	out = appendIDString(out, sensor.GetName())
+	out[4] = uint8(len(out) - sdrHeaderLen)
+
+	return out, &sdrSensor{
+		id:         sensor.GetId(),
+		sensorType: sensorType,
+		discrete:   true,
+		scale:      scale,
+	}
+}
+
+// appendIDString appends the ID string type/length byte and the ID string,
+// truncated to the 16 bytes an SDR can hold.
+func appendIDString(out []byte, name string) []byte {
+	if len(name) > sdrMaxIDLength {
+		name = name[:sdrMaxIDLength]
+	}
+	out = append(out, sdrIDTypeASCII|uint8(len(name)))
+	return append(out, name...)
+}
+
+// sensorTypeAndEntity maps a sensor context to the IPMI sensor type and entity ID.
+func sensorTypeAndEntity(sensorContext v1alpha1.SensorContext) (uint8, uint8) {
+	switch sensorContext {
+	case v1alpha1.SensorContext_SENSOR_CONTEXT_TEMPERATURE:
+		return sensorTypeTemperature, entitySystemBoard
+	case v1alpha1.SensorContext_SENSOR_CONTEXT_VOLTAGE:
+		return sensorTypeVoltage, entitySystemBoard
+	case v1alpha1.SensorContext_SENSOR_CONTEXT_CURRENT:
+		return sensorTypeCurrent, entitySystemBoard
+	case v1alpha1.SensorContext_SENSOR_CONTEXT_TACH:
+		return sensorTypeFan, entityFan
+	default:
+		return sensorTypeOtherUnits, entitySystemBoard
+	}
+}
+
+// sensorUnits maps a sensor unit to the IPMI sensor units 1 byte, the base unit
+// and the factor converting readings into that unit.
+func sensorUnits(unit v1alpha1.SensorUnit) (uint8, uint8, float64) {
+	switch unit {
+	case v1alpha1.SensorUnit_SENSOR_UNIT_CELSIUS:
+		return 0x00, unitCelsius, 1
+	case v1alpha1.SensorUnit_SENSOR_UNIT_FAHRENHEIT:
+		return 0x00, unitFahrenheit, 1
+	case v1alpha1.SensorUnit_SENSOR_UNIT_KELVIN:
+		return 0x00, unitKelvin, 1
+	case v1alpha1.SensorUnit_SENSOR_UNIT_VOLTS:
+		return 0x00, unitVolts, 1
+	case v1alpha1.SensorUnit_SENSOR_UNIT_AMPS:
+		return 0x00, unitAmps, 1
+	case v1alpha1.SensorUnit_SENSOR_UNIT_WATTS:
+		return 0x00, unitWatts, 1
+	case v1alpha1.SensorUnit_SENSOR_UNIT_JOULES:
+		return 0x00, unitJoules, 1
+	case v1alpha1.SensorUnit_SENSOR_UNIT_PASCALS:
+		return 0x00, unitKilopascal, 0.001
+	case v1alpha1.SensorUnit_SENSOR_UNIT_PERCENT:
+		return unitsPercentage, unitUnspecified, 1
+	case v1alpha1.SensorUnit_SENSOR_UNIT_RPM:
+		return 0x00, unitRPM, 1
+	case v1alpha1.SensorUnit_SENSOR_UNIT_HERTZ:
+		return 0x00, unitHertz, 1
+	case v1alpha1.SensorUnit_SENSOR_UNIT_METERS:
+		return 0x00, unitMeters, 1
+	case v1alpha1.SensorUnit_SENSOR_UNIT_LITERS_PER_MINUTE:
+		return unitsRatePerMinute, unitLiters, 1
+	default:
+		return 0x00, unitUnspecified, 1
+	}
+}
+
+// sensorRange returns the span of values the record of an analog sensor must
+// cover. It includes every known reading and threshold with some headroom and
+// falls back to a typical range for the sensor context.
+func sensorRange(sensorContext v1alpha1.SensorContext, analog *v1alpha1.AnalogSensorReading, scale float64) (float64, float64) {
+	values := []float64{analog.GetValue()}
+	for _, value := range thresholdsOf(analog) {
+		values = append(values, value)
+	}
+	if recorded := analog.GetMinMaxRecorded(); recorded != nil {
+		values = append(values, recorded.GetMinValue(), recorded.GetMaxValue())
+	}
+
+	lo, hi := 0.0, 0.0
+	for _, value := range values {
+		lo = min(lo, value*scale)
+		hi = max(hi, value*scale)
+	}
+
+	if hi > 0 {
+		hi *= sensorRangeHeadroom
+	} else {
+		hi = defaultSensorMaximum(sensorContext)
+	}
+	lo *= sensorRangeHeadroom
+
+	return lo, hi
+}
+
+// defaultSensorMaximum returns the upper end of the range used for sensors
+// without any known reading.
+func defaultSensorMaximum(sensorContext v1alpha1.SensorContext) float64 {
+	switch sensorContext {
+	case v1alpha1.SensorContext_SENSOR_CONTEXT_VOLTAGE:
+		return 25.5
+	case v1alpha1.SensorContext_SENSOR_CONTEXT_CURRENT:
+		return 51
+	case v1alpha1.SensorContext_SENSOR_CONTEXT_TACH:
+		return 25500
+	case v1alpha1.SensorContext_SENSOR_CONTEXT_POWER, v1alpha1.SensorContext_SENSOR_CONTEXT_ENERGY:
+		return 2550
+	default:
+		return 255
+	}
+}
+
+// ipmiTimestamp converts a time into an IPMI timestamp, seconds since the epoch.
+func ipmiTimestamp(t time.Time) uint32 {
+	if t.IsZero() {
+		return 0
+	}
+	return uint32(t.Unix())
+}
+
+// refreshSDR keeps the SDR repository in sync with the sensors reported by
+// sensormon until the context is canceled.
+func (s *IPMISrv) refreshSDR(ctx context.Context) {
+	ticker := time.NewTicker(s.config.sdrRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		s.updateSDR(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// updateSDR fetches the sensor list and regenerates the SDR repository if
+// sensors were added or removed.
+func (s *IPMISrv) updateSDR(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.requestTimeout)
+	defer cancel()
+
+	resp := &v1alpha1.ListSensorsResponse{}
+	if err := s.requestNATS(ctx, ipc.SubjectSensorList, &v1alpha1.ListSensorsRequest{}, resp); err != nil {
+		s.logger.DebugContext(ctx, "Failed to list sensors", "error", err)
+		return
+	}
+
+	changed, skipped := s.sdr.update(resp.GetSensor(), time.Now())
+	if !changed {
+		return
+	}
+
+	records, _ := s.sdr.info()
+	s.logger.InfoContext(ctx, "SDR repository regenerated", "records", records)
+	if skipped > 0 {
+		s.logger.WarnContext(ctx, "Sensors exceed the IPMI sensor number space", "skipped", skipped)
+	}
+}
+
+// registerSDRCommands registers the SDR repository commands.
+func (s *IPMISrv) registerSDRCommands() {
+	s.dispatcher.register(NetFnStorage, cmdGetSDRRepositoryInfo, PrivilegeUser, s.handleGetSDRRepositoryInfo)
+	s.dispatcher.register(NetFnStorage, cmdReserveSDRRepository, PrivilegeUser, s.handleReserveSDRRepository)
+	s.dispatcher.register(NetFnStorage, cmdGetSDR, PrivilegeUser, s.handleGetSDR)
+}
+
+func (s *IPMISrv) handleGetSDRRepositoryInfo(_ context.Context, req *request) ([]byte, uint8) {
+	if len(req.msg.Data) != 0 {
+		return nil, CCInvalidLength
+	}
+
+	records, updated := s.sdr.info()
+
+	out := make([]byte, 0, 14)
+	out = append(out, sdrVersion)
+	out = binary.LittleEndian.AppendUint16(out, uint16(records))
+	// The repository is generated, so no free space is available.
+	out = binary.LittleEndian.AppendUint16(out, 0x0000)
+	out = binary.LittleEndian.AppendUint32(out, ipmiTimestamp(updated))
+	out = binary.LittleEndian.AppendUint32(out, 0x00000000)
+	out = append(out, sdrOperationSupport)
+
+	return out, CCSuccess
+}
+
+func (s *IPMISrv) handleReserveSDRRepository(_ context.Context, req *request) ([]byte, uint8) {
+	if len(req.msg.Data) != 0 {
+		return nil, CCInvalidLength
+	}
+
+	return binary.LittleEndian.AppendUint16(nil, s.sdr.reserve()), CCSuccess
+}
+
+func (s *IPMISrv) handleGetSDR(_ context.Context, req *request) ([]byte, uint8) {
+	data := req.msg.Data
+	if len(data) != sdrGetSDRRequestLen {
+		return nil, CCInvalidLength
+	}
+
+	reservation := binary.LittleEndian.Uint16(data[0:2])
+	recordID := binary.LittleEndian.Uint16(data[2:4])
+	offset := int(data[4])
+	count := data[5]
+
+	record, next, cc := s.sdr.get(reservation, recordID, offset != 0)
+	if cc != CCSuccess {
+		return nil, cc
+	}
+	if offset > len(record) {
+		return nil, CCParameterOutOfRange
+	}
+
+	end := len(record)
+	if count != sdrReadEntireRecord {
+		end = min(offset+int(count), len(record))
+	}
+
+	out := make([]byte, 0, 2+end-offset)
+	out = binary.LittleEndian.AppendUint16(out, next)
+	out = append(out, record[offset:end]...)
+
+	return out, CCSuccess
+}
@@ -0,0 +1,259 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ipmisrv
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"sync"
+	"time"
+
+	v1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+	"github.com/u-bmc/u-bmc/pkg/ipc"
+	"github.com/u-bmc/u-bmc/pkg/sel"
+)
+
+// Storage network function SEL commands.
+const (
+	cmdGetSELInfo  uint8 = 0x40
+	cmdReserveSEL  uint8 = 0x42
+	cmdGetSELEntry uint8 = 0x43
+	cmdClearSEL    uint8 = 0x47
+	cmdGetSELTime  uint8 = 0x48
+)
+
+// SEL command fields.
+const (
+	selVersion           uint8 = 0x51
+	selOverflow          uint8 = 0x80
+	selReserveSupported  uint8 = 0x02
+	selGetEntryLen             = 6
+	selClearLen                = 6
+	selClearInitiate     uint8 = 0xAA
+	selClearGetStatus    uint8 = 0x00
+	selEraseCompleted    uint8 = 0x01
+	selReadEntireRecord  uint8 = 0xFF
+	maxSELFreeSpaceBytes       = 0xFFFF
+)
+
+// selReservation tracks the reservation ID required for partial Get SEL Entry
+// reads and Clear SEL. Reserving again or clearing the SEL cancels it.
+type selReservation struct {
+	mu sync.Mutex
+	id uint16
+}
+
+// reserve returns a new reservation ID, canceling the previous one.
+func (r *selReservation) reserve() uint16 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.id++
+	if r.id == 0 {
+		r.id++
+	}
+	return r.id
+}
+
+// valid reports whether id is the current reservation.
+func (r *selReservation) valid(id uint16) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return id != 0 && id == r.id
+}
+
+// cancel invalidates the current reservation.
+func (r *selReservation) cancel() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.id++
+	if r.id == 0 {
+		r.id++
+	}
+}
+
+// registerSELCommands registers the system event log commands.
+func (s *IPMISrv) registerSELCommands() {
+	s.dispatcher.register(NetFnStorage, cmdGetSELInfo, PrivilegeUser, s.handleGetSELInfo)
+	s.dispatcher.register(NetFnStorage, cmdReserveSEL, PrivilegeUser, s.handleReserveSEL)
+	s.dispatcher.register(NetFnStorage, cmdGetSELEntry, PrivilegeUser, s.handleGetSELEntry)
+	s.dispatcher.register(NetFnStorage, cmdClearSEL, PrivilegeOperator, s.handleClearSEL)
+	s.dispatcher.register(NetFnStorage, cmdGetSELTime, PrivilegeUser, s.handleGetSELTime)
+}
+
+func (s *IPMISrv) handleGetSELInfo(ctx context.Context, req *request) ([]byte, uint8) {
+	if len(req.msg.Data) != 0 {
+		return nil, CCInvalidLength
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.config.requestTimeout)
+	defer cancel()
+
+	resp := &v1alpha1.GetSystemEventLogInfoResponse{}
+	if err := s.requestNATS(ctx, ipc.SubjectSELInfo, &v1alpha1.GetSystemEventLogInfoRequest{}, resp); err != nil {
+		s.logger.WarnContext(ctx, "Failed to get SEL info", "error", err)
+		return nil, CCDestinationUnavail
+	}
+
+	free := 0
+	if resp.GetMaxEntries() > resp.GetEntries() {
+		free = min(int(resp.GetMaxEntries()-resp.GetEntries())*sel.RecordSize, maxSELFreeSpaceBytes)
+	}
+	support := selReserveSupported
+	if resp.GetOverflow() {
+		support |= selOverflow
+	}
+
+	var lastAddition, lastErase time.Time
+	if resp.GetLastAddition() != nil {
+		lastAddition = resp.GetLastAddition().AsTime()
+	}
+	if resp.GetLastErase() != nil {
+		lastErase = resp.GetLastErase().AsTime()
+	}
+
+	out := make([]byte, 0, 14)
+	out = append(out, selVersion)
+	out = binary.LittleEndian.AppendUint16(out, uint16(min(resp.GetEntries(), 0xFFFF)))
+	out = binary.LittleEndian.AppendUint16(out, uint16(free))
+	out = binary.LittleEndian.AppendUint32(out, sel.Timestamp(lastAddition))
+	out = binary.LittleEndian.AppendUint32(out, sel.Timestamp(lastErase))
+	out = append(out, support)
+
+	return out, CCSuccess
+}
+
+func (s *IPMISrv) handleReserveSEL(_ context.Context, req *request) ([]byte, uint8) {
+	if len(req.msg.Data) != 0 {
+		return nil, CCInvalidLength
+	}
+
+	return binary.LittleEndian.AppendUint16(nil, s.selReservation.reserve()), CCSuccess
+}
+
+func (s *IPMISrv) handleGetSELEntry(ctx context.Context, req *request) ([]byte, uint8) {
+	data := req.msg.Data
+	if len(data) != selGetEntryLen {
+		return nil, CCInvalidLength
+	}
+
+	reservation := binary.LittleEndian.Uint16(data[0:2])
+	recordID := binary.LittleEndian.Uint16(data[2:4])
+	offset, count := int(data[4]), data[5]
+
+	// A reservation is only required for partial reads.
+	if (offset != 0 || count != selReadEntireRecord) && !s.selReservation.valid(reservation) {
+		return nil, CCInvalidReservation
+	}
+	if offset >= sel.RecordSize {
+		return nil, CCParameterOutOfRange
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.config.requestTimeout)
+	defer cancel()
+
+	resp := &v1alpha1.GetSystemEventLogEntryResponse{}
+	err := s.requestNATS(ctx, ipc.SubjectSELEntry,
+		&v1alpha1.GetSystemEventLogEntryRequest{RecordId: uint32(recordID)}, resp)
+	if errors.Is(err, ErrNotFound) {
+		return nil, CCNotPresent
+	}
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to get SEL entry", "record_id", recordID, "error", err)
+		return nil, CCDestinationUnavail
+	}
+
+	record, ok := s.resolveSELRecord(resp.GetEntry())
+	if !ok {
+		s.logger.WarnContext(ctx, "Invalid SEL record", "record_id", recordID)
+		return nil, CCUnspecified
+	}
+
+	end := sel.RecordSize
+	if count != selReadEntireRecord {
+		end = min(offset+int(count), sel.RecordSize)
+	}
+
+	out := make([]byte, 0, 2+end-offset)
+	out = binary.LittleEndian.AppendUint16(out, uint16(resp.GetNextRecordId()))
+	out = append(out, record[offset:end]...)
+
+	return out, CCSuccess
+}
+
+// resolveSELRecord returns the IPMI record of an entry. selmgr does not know
+// the SDR sensor numbers, so sensor events are resolved against the SDR
+// repository by sensor ID, including the raw trigger reading and threshold.
+func (s *IPMISrv) resolveSELRecord(entry *v1alpha1.SystemEventLogEntry) ([]byte, bool) {
+	var rec sel.Record
+	if err := rec.UnmarshalBinary(entry.GetIpmiRecord()); err != nil {
+		return nil, false
+	}
+
+	if entry.GetSource() == v1alpha1.EventSource_EVENT_SOURCE_SENSOR && entry.SensorId != nil {
+		if number, sensor, ok := s.sdr.lookup(entry.GetSensorId()); ok {
+			rec.SensorNumber = number
+			rec.SensorType = sensor.sensorType
+			if rec.EventType == sel.EventTypeThreshold && !sensor.discrete &&
+				entry.Reading != nil && entry.Threshold != nil {
+				rec.SetThresholdData(
+					sensor.conv.raw(entry.GetReading()*sensor.scale),
+					sensor.conv.raw(entry.GetThreshold()*sensor.scale),
+				)
+			}
+		}
+	}
+
+	data, err := rec.MarshalBinary()
+	if err != nil {
+		return nil, false
+	}
+
+	return data, true
+}
+
+func (s *IPMISrv) handleClearSEL(ctx context.Context, req *request) ([]byte, uint8) {
+	data := req.msg.Data
+	if len(data) != selClearLen {
+		return nil, CCInvalidLength
+	}
+	if data[2] != 'C' || data[3] != 'L' || data[4] != 'R' {
+		return nil, CCInvalidField
+	}
+	if !s.selReservation.valid(binary.LittleEndian.Uint16(data[0:2])) {
+		return nil, CCInvalidReservation
+	}
+
+	switch data[5] {
+	case selClearGetStatus:
+		// Erasure completes synchronously.
+		return []byte{selEraseCompleted}, CCSuccess
+	case selClearInitiate:
+	default:
+		return nil, CCInvalidField
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.config.requestTimeout)
+	defer cancel()
+
+	resp := &v1alpha1.ClearSystemEventLogResponse{}
+	if err := s.requestNATS(ctx, ipc.SubjectSELClear, &v1alpha1.ClearSystemEventLogRequest{}, resp); err != nil {
+		s.logger.WarnContext(ctx, "Failed to clear SEL", "error", err)
+		return nil, CCDestinationUnavail
+	}
+	s.selReservation.cancel()
+
+	s.logger.InfoContext(ctx, "SEL cleared",
+		"cleared_entries", resp.GetClearedEntries(),
+		"remote_addr", req.remoteAddr)
+
+	return []byte{selEraseCompleted}, CCSuccess
+}
+
+func (s *IPMISrv) handleGetSELTime(_ context.Context, req *request) ([]byte, uint8) {
+	if len(req.msg.Data) != 0 {
+		return nil, CCInvalidLength
+	}
+
+	return binary.LittleEndian.AppendUint32(nil, sel.Timestamp(time.Now())), CCSuccess
+}
@@ -0,0 +1,241 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ipmisrv
+
+import (
+	"context"
+	"strings"
+
+	v1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+	"github.com/u-bmc/u-bmc/pkg/ipc"
+	"github.com/u-bmc/u-bmc/pkg/sel"
+)
+
+// Sensor/Event network function commands.
+const (
+	cmdGetSensorThresholds uint8 = 0x27
+	cmdGetSensorReading    uint8 = 0x2D
+)
+
+// Threshold bits shared by the SDR threshold masks, Get Sensor Thresholds and
+// the threshold comparison status of Get Sensor Reading.
+const (
+	thresholdLowerNonCritical uint8 = 0x01
+	thresholdLowerCritical    uint8 = 0x02
+	thresholdUpperNonCritical uint8 = 0x08
+	thresholdUpperCritical    uint8 = 0x10
+)
+
+// Get Sensor Reading response fields.
+const (
+	sensorEventsEnabled      uint8 = 0x80
+	sensorScanningEnabled    uint8 = 0x40
+	sensorReadingUnavailable uint8 = 0x20
+	digitalStateDeasserted   uint8 = 0x01
+	digitalStateAsserted     uint8 = 0x02
+	discreteStateReserved    uint8 = 0x80
+)
+
+// thresholdBits returns the supported thresholds in Get Sensor Thresholds order.
+func thresholdBits() []uint8 {
+	return []uint8{
+		thresholdLowerNonCritical,
+		thresholdLowerCritical,
+		thresholdUpperNonCritical,
+		thresholdUpperCritical,
+	}
+}
+
+// thresholdEventMask returns the assertion and deassertion event mask of an
+// SDR for the given threshold mask. Only the going-low lower and going-high
+// upper threshold events are generated.
+func thresholdEventMask(mask uint8) uint16 {
+	var events uint16
+	if mask&thresholdLowerNonCritical != 0 {
+		events |= 1 << sel.OffsetLowerNonCriticalGoingLow
+	}
+	if mask&thresholdLowerCritical != 0 {
+		events |= 1 << sel.OffsetLowerCriticalGoingLow
+	}
+	if mask&thresholdUpperNonCritical != 0 {
+		events |= 1 << sel.OffsetUpperNonCriticalGoingHigh
+	}
+	if mask&thresholdUpperCritical != 0 {
+		events |= 1 << sel.OffsetUpperCriticalGoingHigh
+	}
+	return events
+}
+
+// thresholdsOf returns the thresholds of an analog reading keyed by threshold bit.
+// Warning thresholds map to non-critical and critical thresholds to critical.
+func thresholdsOf(analog *v1alpha1.AnalogSensorReading) map[uint8]float64 {
+	thresholds := make(map[uint8]float64, len(thresholdBits()))
+	if lower := analog.GetLowerThresholds(); lower != nil {
+		if lower.Warning != nil {
+			thresholds[thresholdLowerNonCritical] = lower.GetWarning()
+		}
+		if lower.Critical != nil {
+			thresholds[thresholdLowerCritical] = lower.GetCritical()
+		}
+	}
+	if upper := analog.GetUpperThresholds(); upper != nil {
+		if upper.Warning != nil {
+			thresholds[thresholdUpperNonCritical] = upper.GetWarning()
+		}
+		if upper.Critical != nil {
+			thresholds[thresholdUpperCritical] = upper.GetCritical()
+		}
+	}
+	return thresholds
+}
+
+// thresholdMask returns the mask of the thresholds present.
+func thresholdMask(thresholds map[uint8]float64) uint8 {
+	var mask uint8
+	for bit := range thresholds {
+		mask |= bit
+	}
+	return mask
+}
+
+// thresholdStatus returns the threshold comparison status of a value.
+func thresholdStatus(value float64, thresholds map[uint8]float64) uint8 {
+	var status uint8
+	for bit, threshold := range thresholds {
+		switch bit {
+		case thresholdLowerNonCritical, thresholdLowerCritical:
+			if value <= threshold {
+				status |= bit
+			}
+		case thresholdUpperNonCritical, thresholdUpperCritical:
+			if value >= threshold {
+				status |= bit
+			}
+		}
+	}
+	return status
+}
+
+// digitalState maps a discrete sensor state to the digital discrete state bits.
+func digitalState(state string) uint8 {
+	switch strings.ToLower(state) {
+	case "asserted", "active", "enabled", "on", "true", "1":
+		return digitalStateAsserted
+	default:
+		return digitalStateDeasserted
+	}
+}
+
+// readingAvailable reports whether sensormon has a usable reading for the sensor.
+func readingAvailable(sensor *v1alpha1.Sensor) bool {
+	switch sensor.GetStatus() {
+	case v1alpha1.SensorStatus_SENSOR_STATUS_DISABLED,
+		v1alpha1.SensorStatus_SENSOR_STATUS_NOT_PRESENT,
+		v1alpha1.SensorStatus_SENSOR_STATUS_ERROR:
+		return false
+	default:
+		return true
+	}
+}
+
+// registerSensorCommands registers the sensor network function commands.
+func (s *IPMISrv) registerSensorCommands() {
+	s.dispatcher.register(NetFnSensor, cmdGetSensorThresholds, PrivilegeUser, s.handleGetSensorThresholds)
+	s.dispatcher.register(NetFnSensor, cmdGetSensorReading, PrivilegeUser, s.handleGetSensorReading)
+}
+
+func (s *IPMISrv) handleGetSensorReading(ctx context.Context, req *request) ([]byte, uint8) {
+	if len(req.msg.Data) != 1 {
+		return nil, CCInvalidLength
+	}
+
+	sensor, ok := s.sdr.sensor(req.msg.Data[0])
+	if !ok {
+		return nil, CCNotPresent
+	}
+
+	live, cc := s.readSensor(ctx, sensor.id)
+	if cc != CCSuccess {
+		return nil, cc
+	}
+
+	flags := sensorEventsEnabled | sensorScanningEnabled
+	if !readingAvailable(live) {
+		return []byte{0x00, flags | sensorReadingUnavailable, 0x00}, CCSuccess
+	}
+
+	if sensor.discrete {
+		state := digitalState(live.GetDiscreteReading().GetState())
+		return []byte{0x00, flags, state, discreteStateReserved}, CCSuccess
+	}
+
+	analog := live.GetAnalogReading()
+	if analog == nil {
+		return []byte{0x00, flags | sensorReadingUnavailable, 0x00}, CCSuccess
+	}
+
+	value := analog.GetValue()
+	raw := sensor.conv.raw(value * sensor.scale)
+
+	return []byte{raw, flags, thresholdStatus(value, thresholdsOf(analog))}, CCSuccess
+}
+
+func (s *IPMISrv) handleGetSensorThresholds(ctx context.Context, req *request) ([]byte, uint8) {
+	if len(req.msg.Data) != 1 {
+		return nil, CCInvalidLength
+	}
+
+	sensor, ok := s.sdr.sensor(req.msg.Data[0])
+	if !ok {
+		return nil, CCNotPresent
+	}
+	if sensor.discrete {
+		return nil, CCIllegalCommand
+	}
+
+	live, cc := s.readSensor(ctx, sensor.id)
+	if cc != CCSuccess {
+		return nil, cc
+	}
+
+	thresholds := thresholdsOf(live.GetAnalogReading())
+	raw := func(bit uint8) uint8 {
+		if value, ok := thresholds[bit]; ok {
+			return sensor.conv.raw(value * sensor.scale)
+		}
+		return 0x00
+	}
+
+	// Non-recoverable thresholds are not modeled and reported as unreadable.
+	return []byte{
+		thresholdMask(thresholds),
+		raw(thresholdLowerNonCritical),
+		raw(thresholdLowerCritical),
+		0x00,
+		raw(thresholdUpperNonCritical),
+		raw(thresholdUpperCritical),
+		0x00,
+	}, CCSuccess
+}
+
+// readSensor requests a live reading of a sensor from sensormon.
+func (s *IPMISrv) readSensor(ctx context.Context, id string) (*v1alpha1.Sensor, uint8) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.requestTimeout)
+	defer cancel()
+
+	req := &v1alpha1.GetSensorRequest{
+		Identifier: &v1alpha1.GetSensorRequest_Id{Id: id},
+	}
+	resp := &v1alpha1.GetSensorResponse{}
+	if err := s.requestNATS(ctx, ipc.SubjectSensorInfo, req, resp); err != nil {
+		s.logger.WarnContext(ctx, "Failed to read sensor", "sensor_id", id, "error", err)
+		return nil, CCDestinationUnavail
+	}
+
+	sensors := resp.GetSensors()
+	if len(sensors) == 0 {
+		return nil, CCNotPresent
+	}
+
+	return sensors[0], CCSuccess
+}
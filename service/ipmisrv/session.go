@@ -0,0 +1,214 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ipmisrv
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"sync"
+	"time"
+)
+
+// sequenceWindow is the number of sequence numbers accepted behind the highest
+// one received, to tolerate reordering of UDP packets.
+const sequenceWindow = 16
+
+// sessionState tracks the progress of the RMCP+ session handshake.
+type sessionState int
+
+const (
+	// sessionStateOpen indicates Open Session completed and RAKP1 is expected.
+	sessionStateOpen sessionState = iota
+	// sessionStateRAKP2 indicates RAKP2 was sent and RAKP3 is expected.
+	sessionStateRAKP2
+	// sessionStateRAKP3 indicates RAKP3 was verified and the login is being
+	// recorded with usermgr.
+	sessionStateRAKP3
+	// sessionStateActive indicates the session is established.
+	sessionStateActive
+)
+
+// session is an RMCP+ session between a remote console and the BMC.
+type session struct {
+	mu sync.Mutex
+
+	id         uint32
+	remoteID   uint32
+	remoteAddr string
+	state      sessionState
+	suite      cipherSuite
+
+	// maxPrivilege is the privilege limit negotiated during RAKP.
+	maxPrivilege PrivilegeLevel
+	// privilege is the current operating privilege of the session.
+	privilege PrivilegeLevel
+
+	// RAKP exchange values.
+	rm       [16]byte
+	rc       [16]byte
+	role     uint8
+	username string
+	userKey  []byte
+
+	// Session keys derived after RAKP3.
+	sik []byte
+	k1  []byte
+	k2  []byte
+
+	inSeq      uint32
+	inSeqMask  uint32
+	outSeq     uint32
+	lastActive time.Time
+}
+
+// acceptSequence validates an inbound session sequence number against the
+// replay window and records it. Accepted packets refresh the idle timer.
+func (s *session) acceptSequence(seq uint32) bool {
+	if seq == 0 {
+		return false
+	}
+
+	if seq > s.inSeq {
+		shift := seq - s.inSeq
+		if shift >= sequenceWindow {
+			s.inSeqMask = 0
+		} else {
+			s.inSeqMask <<= shift
+		}
+		s.inSeqMask |= 1
+		s.inSeq = seq
+		s.lastActive = time.Now()
+		return true
+	}
+
+	diff := s.inSeq - seq
+	if diff >= sequenceWindow || s.inSeqMask&(1<<diff) != 0 {
+		return false
+	}
+	s.inSeqMask |= 1 << diff
+	s.lastActive = time.Now()
+
+	return true
+}
+
+// nextOutSeq returns the next outbound session sequence number.
+func (s *session) nextOutSeq() uint32 {
+	s.outSeq++
+	if s.outSeq == 0 {
+		s.outSeq = 1
+	}
+	return s.outSeq
+}
+
+// sessionTable holds all RMCP+ sessions keyed by the BMC session ID.
+type sessionTable struct {
+	mu          sync.Mutex
+	sessions    map[uint32]*session
+	maxSessions int
+	timeout     time.Duration
+}
+
+// newSessionTable creates a session table with the given limits.
+func newSessionTable(maxSessions int, timeout time.Duration) *sessionTable {
+	return &sessionTable{
+		sessions:    make(map[uint32]*session),
+		maxSessions: maxSessions,
+		timeout:     timeout,
+	}
+}
+
+// create allocates a new session with a random, non-zero BMC session ID.
+func (t *sessionTable) create(remoteID uint32, remoteAddr string, suite cipherSuite, maxPrivilege PrivilegeLevel) (*session, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.sessions) >= t.maxSessions {
+		return nil, ErrSessionLimitReached
+	}
+
+	var id uint32
+	var buf [4]byte
+	for id == 0 || t.sessions[id] != nil {
+		if _, err := rand.Read(buf[:]); err != nil {
+			return nil, err
+		}
+		id = binary.LittleEndian.Uint32(buf[:])
+	}
+
+	sess := &session{
+		id:           id,
+		remoteID:     remoteID,
+		remoteAddr:   remoteAddr,
+		state:        sessionStateOpen,
+		suite:        suite,
+		maxPrivilege: maxPrivilege,
+		lastActive:   time.Now(),
+	}
+	t.sessions[id] = sess
+
+	return sess, nil
+}
+
+// get returns the session for the given BMC session ID.
+func (t *sessionTable) get(id uint32) (*session, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	sess, ok := t.sessions[id]
+	if !ok {
+		return nil, ErrSessionNotFound
+	}
+
+	return sess, nil
+}
+
+// remove deletes the session with the given BMC session ID.
+func (t *sessionTable) remove(id uint32) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.sessions[id]; !ok {
+		return false
+	}
+	delete(t.sessions, id)
+
+	return true
+}
+
+// expire removes sessions that have been idle for longer than the timeout and
+// returns their IDs.
+func (t *sessionTable) expire(now time.Time) []uint32 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var expired []uint32
+	for id, sess := range t.sessions {
+		sess.mu.Lock()
+		idle := now.Sub(sess.lastActive)
+		sess.mu.Unlock()
+
+		if idle > t.timeout {
+			delete(t.sessions, id)
+			expired = append(expired, id)
+		}
+	}
+
+	return expired
+}
+
+// count returns the number of sessions, including those still being
+// established.
+func (t *sessionTable) count() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return len(t.sessions)
+}
+
+// clear removes all sessions.
+func (t *sessionTable) clear() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.sessions = make(map[uint32]*session)
+}
@@ -0,0 +1,826 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ipmisrv
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+
+	v1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+	"github.com/u-bmc/u-bmc/pkg/ipc"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Application network function user and channel commands.
+const (
+	cmdGetChannelAccess  uint8 = 0x41
+	cmdGetChannelInfo    uint8 = 0x42
+	cmdSetUserAccess     uint8 = 0x43
+	cmdGetUserAccess     uint8 = 0x44
+	cmdSetUserName       uint8 = 0x45
+	cmdGetUserName       uint8 = 0x46
+	cmdSetUserPassword   uint8 = 0x47
+	userIDMask           uint8 = 0x3F
+	nullUserID           uint8 = 0x01
+	fixedUserNames             = 1
+	privilegeNoAccess    uint8 = 0x0F
+	privilegeLimitMask   uint8 = 0x0F
+	userAccessChange     uint8 = 0x80
+	userCallbackOnly     uint8 = 0x40
+	userLinkAuth         uint8 = 0x20
+	userIPMIMessaging    uint8 = 0x10
+	userStatusEnabled    uint8 = 0x40
+	userStatusDisabled   uint8 = 0x80
+	passwordSize20       uint8 = 0x80
+	passwordOpMask       uint8 = 0x03
+	passwordOpDisable    uint8 = 0x00
+	passwordOpEnable     uint8 = 0x01
+	passwordOpSet        uint8 = 0x02
+	passwordOpTest       uint8 = 0x03
+	passwordLen16              = 16
+	passwordCCTestFailed uint8 = 0x80
+	passwordCCWrongSize  uint8 = 0x81
+)
+
+// Get Channel Info and Get Channel Access fields.
+const (
+	channelMediumLAN         uint8 = 0x04
+	channelProtocolIPMB      uint8 = 0x01
+	channelMultiSession      uint8 = 0x80
+	channelSessionCountMask        = 0x3F
+	channelAccessTypeMask    uint8 = 0xC0
+	channelAccessNonVolatile uint8 = 0x40
+	channelAccessVolatile    uint8 = 0x80
+	channelAlertingDisabled  uint8 = 0x20
+	channelAlwaysAvailable   uint8 = 0x02
+)
+
+// usermgr custom attributes holding the IPMI settings of a user.
+const (
+	attrIPMIUserID        = "ipmi.user_id"
+	attrChannelPrefix     = "ipmi.channel."
+	attrPrivilege         = "privilege"
+	attrIPMIMessaging     = "ipmi_messaging"
+	attrLinkAuth          = "link_auth"
+	attrCallbackOnly      = "callback_only"
+	privilegeNameNoAccess = "no_access"
+)
+
+// IANA enterprise number of the IPMI forum, reported as channel vendor.
+const ipmiForumIANA uint32 = 0x0001F2
+
+// userChannelAccess is the access of a user to one channel.
+type userChannelAccess struct {
+	privilege     uint8
+	ipmiMessaging bool
+	linkAuth      bool
+	callbackOnly  bool
+}
+
+// ipmiUser is a user occupying an IPMI user ID.
+type ipmiUser struct {
+	// userID is the ID of the corresponding usermgr user.
+	userID  string
+	name    string
+	enabled bool
+	// passwordSize is the size of the password as last set through IPMI,
+	// zero when it is unknown.
+	passwordSize int
+	access       map[uint8]userChannelAccess
+}
+
+// channelAccess returns the access of the user to a channel. Users have no
+// access to channels they have not been granted access to.
+func (u *ipmiUser) channelAccess(channel uint8) userChannelAccess {
+	if access, ok := u.access[channel]; ok {
+		return access
+	}
+	return userChannelAccess{privilege: privilegeNoAccess}
+}
+
+// attributes returns the usermgr custom attributes describing the user.
+func (u *ipmiUser) attributes(id uint8) map[string]string {
+	attrs := map[string]string{
+		attrIPMIUserID: strconv.Itoa(int(id)),
+	}
+	for channel, access := range u.access {
+		prefix := attrChannelPrefix + strconv.Itoa(int(channel)) + "."
+		attrs[prefix+attrPrivilege] = privilegeName(access.privilege)
+		attrs[prefix+attrIPMIMessaging] = strconv.FormatBool(access.ipmiMessaging)
+		attrs[prefix+attrLinkAuth] = strconv.FormatBool(access.linkAuth)
+		attrs[prefix+attrCallbackOnly] = strconv.FormatBool(access.callbackOnly)
+	}
+	return attrs
+}
+
+// privilegeName returns the attribute value of a channel privilege limit.
+func privilegeName(privilege uint8) string {
+	if !PrivilegeLevel(privilege).Valid() {
+		return privilegeNameNoAccess
+	}
+	return PrivilegeLevel(privilege).String()
+}
+
+// parsePrivilegeName parses the attribute value of a channel privilege limit.
+func parsePrivilegeName(name string) uint8 {
+	for p := PrivilegeCallback; p <= PrivilegeOEM; p++ {
+		if p.String() == name {
+			return uint8(p)
+		}
+	}
+	return privilegeNoAccess
+}
+
+// userFromAttributes restores an IPMI user from a usermgr user. It returns
+// false for users that do not occupy an IPMI user ID.
+func userFromAttributes(user *v1alpha1.User) (uint8, *ipmiUser, bool) {
+	attrs := user.GetCustomAttributes()
+	id, err := strconv.ParseUint(attrs[attrIPMIUserID], 10, 8)
+	if err != nil || uint8(id) <= nullUserID || uint8(id) > userIDMask {
+		return 0, nil, false
+	}
+
+	u := &ipmiUser{
+		userID:  user.GetId(),
+		name:    user.GetUsername(),
+		enabled: user.GetEnabled(),
+		access:  make(map[uint8]userChannelAccess),
+	}
+	for key, value := range attrs {
+		rest, ok := strings.CutPrefix(key, attrChannelPrefix)
+		if !ok {
+			continue
+		}
+		channelStr, field, ok := strings.Cut(rest, ".")
+		if !ok {
+			continue
+		}
+		channel, err := strconv.ParseUint(channelStr, 10, 8)
+		if err != nil || uint8(channel) > channelMask {
+			continue
+		}
+
+		access := u.channelAccess(uint8(channel))
+		switch field {
+		case attrPrivilege:
+			access.privilege = parsePrivilegeName(value)
+		case attrIPMIMessaging:
+			access.ipmiMessaging = value == "true"
+		case attrLinkAuth:
+			access.linkAuth = value == "true"
+		case attrCallbackOnly:
+			access.callbackOnly = value == "true"
+		default:
+			continue
+		}
+		u.access[uint8(channel)] = access
+	}
+
+	return uint8(id), u, true
+}
+
+// userTable maps IPMI user IDs to users. usermgr is the persistent store; the
+// table is loaded from it on first use and every change is written through.
+type userTable struct {
+	mu     sync.Mutex
+	loaded bool
+	users  map[uint8]*ipmiUser
+}
+
+func newUserTable() *userTable {
+	return &userTable{
+		users: make(map[uint8]*ipmiUser),
+	}
+}
+
+// byName returns the user ID of the user with the given name.
+func (t *userTable) byName(name string) (uint8, bool) {
+	for id, u := range t.users {
+		if u.name == name {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+// enabledCount returns the number of enabled users.
+func (t *userTable) enabledCount() int {
+	n := 0
+	for _, u := range t.users {
+		if u.enabled {
+			n++
+		}
+	}
+	return n
+}
+
+// allows reports whether a user may establish a session with the given
+// privilege on a channel. Users that do not occupy an IPMI user ID are only
+// governed by their key store entry. The caller must hold t.mu.
+func (t *userTable) allows(name string, channel uint8, privilege PrivilegeLevel) bool {
+	id, ok := t.byName(name)
+	if !ok {
+		return true
+	}
+	u := t.users[id]
+	access := u.channelAccess(channel)
+
+	return u.enabled && access.ipmiMessaging && PrivilegeLevel(access.privilege).Valid() &&
+		privilege <= PrivilegeLevel(access.privilege)
+}
+
+// registerUserCommands registers the user and channel management commands.
+func (s *IPMISrv) registerUserCommands() {
+	s.dispatcher.register(NetFnApp, cmdGetChannelAccess, PrivilegeUser, s.handleGetChannelAccess)
+	s.dispatcher.register(NetFnApp, cmdGetChannelInfo, PrivilegeUser, s.handleGetChannelInfo)
+	s.dispatcher.register(NetFnApp, cmdSetUserAccess, PrivilegeAdmin, s.handleSetUserAccess)
+	s.dispatcher.register(NetFnApp, cmdGetUserAccess, PrivilegeOperator, s.handleGetUserAccess)
+	s.dispatcher.register(NetFnApp, cmdSetUserName, PrivilegeAdmin, s.handleSetUserName)
+	s.dispatcher.register(NetFnApp, cmdGetUserName, PrivilegeOperator, s.handleGetUserName)
+	s.dispatcher.register(NetFnApp, cmdSetUserPassword, PrivilegeAdmin, s.handleSetUserPassword)
+}
+
+// userChannel reports whether users can be granted access to a channel.
+func (s *IPMISrv) userChannel(channel uint8) bool {
+	return channel == s.config.lanChannel
+}
+
+// loadUsers loads the IPMI users from usermgr unless they have been loaded
+// already. The caller must hold s.users.mu.
+func (s *IPMISrv) loadUsers(ctx context.Context) uint8 {
+	if s.users.loaded {
+		return CCSuccess
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.config.requestTimeout)
+	defer cancel()
+
+	// Users created through other interfaces may have been linked to an IPMI
+	// user ID, so all users are listed rather than only those of IPMI origin.
+	resp := &v1alpha1.ListUsersResponse{}
+	if err := s.requestNATS(ctx, ipc.SubjectUserList, &v1alpha1.ListUsersRequest{}, resp); err != nil {
+		s.logger.WarnContext(ctx, "Failed to load IPMI users", "error", err)
+		return CCDestinationUnavail
+	}
+
+	for _, user := range resp.GetUsers() {
+		id, u, ok := userFromAttributes(user)
+		if !ok || int(id) > s.config.maxUsers {
+			continue
+		}
+		if _, taken := s.users.users[id]; taken {
+			s.logger.WarnContext(ctx, "Ignoring user with duplicate IPMI user ID", "user", u.name, "user_id", id)
+			continue
+		}
+		s.users.users[id] = u
+	}
+	s.users.loaded = true
+
+	return CCSuccess
+}
+
+// lockUsers locks and loads the user table for a user command. The caller
+// must unlock s.users.mu when the returned completion code is CCSuccess.
+func (s *IPMISrv) lockUsers(ctx context.Context) uint8 {
+	s.users.mu.Lock()
+	if cc := s.loadUsers(ctx); cc != CCSuccess {
+		s.users.mu.Unlock()
+		return cc
+	}
+	return CCSuccess
+}
+
+// userAllowed reports whether the IPMI user settings permit a session for
+// the user with the given privilege on the LAN channel. If the users cannot be
+// loaded from usermgr, only the key store entry governs access.
+func (s *IPMISrv) userAllowed(ctx context.Context, username string, privilege PrivilegeLevel) bool {
+	s.users.mu.Lock()
+	defer s.users.mu.Unlock()
+
+	s.loadUsers(ctx)
+
+	return s.users.allows(username, s.config.lanChannel, privilege)
+}
+
+// userID validates the user ID field of a request.
+func (s *IPMISrv) userID(b uint8) (uint8, bool) {
+	id := b & userIDMask
+	return id, id != 0 && int(id) <= s.config.maxUsers
+}
+
+// keyStoreWriter returns the key store if it can be written to.
+func (s *IPMISrv) keyStoreWriter() (KeyStoreWriter, bool) {
+	w, ok := s.config.keyStore.(KeyStoreWriter)
+	return w, ok
+}
+
+// syncUserKey updates the privilege of the key of u to its LAN channel
+// privilege limit. Access is additionally checked against the user table, so
+// users without LAN access keep the lowest privilege.
+func (s *IPMISrv) syncUserKey(ctx context.Context, u *ipmiUser, password []byte) error {
+	w, ok := s.keyStoreWriter()
+	if !ok {
+		return nil
+	}
+
+	if password == nil {
+		key, err := w.Lookup(ctx, u.name)
+		if errors.Is(err, ErrUserNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		password = key.Password
+	}
+
+	privilege := PrivilegeLevel(u.channelAccess(s.config.lanChannel).privilege)
+	if !privilege.Valid() {
+		privilege = PrivilegeCallback
+	}
+
+	return w.Set(u.name, UserKey{Password: password, Privilege: privilege})
+}
+
+// createUser creates the usermgr user for a new IPMI user, or links an
+// existing usermgr user with the same name.
+func (s *IPMISrv) createUser(ctx context.Context, id uint8, u *ipmiUser) uint8 {
+	ctx, cancel := context.WithTimeout(ctx, s.config.requestTimeout)
+	defer cancel()
+
+	existing := &v1alpha1.GetUserResponse{}
+	err := s.requestNATS(ctx, ipc.SubjectUserInfo, &v1alpha1.GetUserRequest{
+		Identifier: &v1alpha1.GetUserRequest_Username{Username: u.name},
+	}, existing)
+	switch {
+	case err == nil && existing.GetUser().GetUsername() == u.name:
+		if _, _, linked := userFromAttributes(existing.GetUser()); linked {
+			return CCInvalidField
+		}
+		u.userID = existing.GetUser().GetId()
+		u.enabled = existing.GetUser().GetEnabled()
+		return s.updateUser(ctx, id, u, "custom_attributes")
+	case err != nil && !errors.Is(err, ErrNotFound):
+		s.logger.WarnContext(ctx, "Failed to look up user", "user", u.name, "error", err)
+		return CCDestinationUnavail
+	}
+
+	now := timestamppb.Now()
+	resp := &v1alpha1.CreateUserResponse{}
+	if err := s.requestNATS(ctx, ipc.SubjectUserCreate, &v1alpha1.CreateUserRequest{
+		User: &v1alpha1.User{
+			Username:          u.name,
+			Enabled:           u.enabled,
+			CreatedAt:         now,
+			UpdatedAt:         now,
+			SourceSystem:      v1alpha1.UserSource_USER_SOURCE_IPMI,
+			CreationInterface: v1alpha1.UserCreationInterface_USER_CREATION_INTERFACE_IPMI_USER_MGMT,
+			CustomAttributes:  u.attributes(id),
+		},
+	}, resp); err != nil {
+		s.logger.WarnContext(ctx, "Failed to create user", "user", u.name, "error", err)
+		if errors.Is(err, ErrInvalidArgument) {
+			return CCInvalidField
+		}
+		return CCDestinationUnavail
+	}
+	u.userID = resp.GetUser().GetId()
+
+	return CCSuccess
+}
+
+// updateUser writes the given fields of an IPMI user to usermgr.
+func (s *IPMISrv) updateUser(ctx context.Context, id uint8, u *ipmiUser, paths ...string) uint8 {
+	ctx, cancel := context.WithTimeout(ctx, s.config.requestTimeout)
+	defer cancel()
+
+	req := &v1alpha1.UpdateUserRequest{
+		User: &v1alpha1.User{
+			Id:               u.userID,
+			Username:         u.name,
+			Enabled:          u.enabled,
+			UpdatedAt:        timestamppb.Now(),
+			CustomAttributes: u.attributes(id),
+		},
+		FieldMask: &fieldmaskpb.FieldMask{Paths: append(paths, "updated_at")},
+	}
+	if err := s.requestNATS(ctx, ipc.SubjectUserUpdate, req, &v1alpha1.UpdateUserResponse{}); err != nil {
+		s.logger.WarnContext(ctx, "Failed to update user", "user", u.name, "fields", paths, "error", err)
+		if errors.Is(err, ErrInvalidArgument) {
+			return CCInvalidField
+		}
+		return CCDestinationUnavail
+	}
+
+	return CCSuccess
+}
+
+// deleteUser deletes the usermgr user of an IPMI user.
+func (s *IPMISrv) deleteUser(ctx context.Context, u *ipmiUser) uint8 {
+	ctx, cancel := context.WithTimeout(ctx, s.config.requestTimeout)
+	defer cancel()
+
+	err := s.requestNATS(ctx, ipc.SubjectUserDelete, &v1alpha1.DeleteUserRequest{Id: u.userID}, &v1alpha1.DeleteUserResponse{})
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		s.logger.WarnContext(ctx, "Failed to delete user", "user", u.name, "error", err)
+		return CCDestinationUnavail
+	}
+
+	return CCSuccess
+}
+
+func (s *IPMISrv) handleGetChannelInfo(_ context.Context, req *request) ([]byte, uint8) {
+	if len(req.msg.Data) != 1 {
+		return nil, CCInvalidLength
+	}
+
+	channel := resolveChannel(req, req.msg.Data[0])
+	h, host := s.hostInterface(channel)
+
+	var out []byte
+	switch {
+	case channel == s.config.lanChannel:
+		sessions := min(s.sessions.count(), channelSessionCountMask)
+		out = []byte{channel, channelMediumLAN, channelProtocolIPMB, channelMultiSession | uint8(sessions)}
+	case host:
+		out = []byte{channel, channelMediumSystem, h.Framing.protocol(), channelSessionless}
+	default:
+		return nil, CCInvalidField
+	}
+
+	out = binary.LittleEndian.AppendUint32(out, ipmiForumIANA)[:len(out)+manufacturerIDByteSize]
+	return append(out, 0x00, 0x00), CCSuccess
+}
+
+func (s *IPMISrv) handleGetChannelAccess(_ context.Context, req *request) ([]byte, uint8) {
+	if len(req.msg.Data) != 2 {
+		return nil, CCInvalidLength
+	}
+
+	channel := resolveChannel(req, req.msg.Data[0])
+	limit := PrivilegeAdmin
+	if channel != s.config.lanChannel {
+		h, ok := s.hostInterface(channel)
+		if !ok {
+			return nil, CCInvalidField
+		}
+		limit = h.Privilege
+	}
+	switch req.msg.Data[1] & channelAccessTypeMask {
+	case channelAccessNonVolatile, channelAccessVolatile:
+	default:
+		return nil, CCInvalidField
+	}
+
+	// The channel is always available. PEF alerting is not implemented and is
+	// reported as disabled.
+	access := channelAlertingDisabled | channelAlwaysAvailable
+	return []byte{access, uint8(limit)}, CCSuccess
+}
+
+func (s *IPMISrv) handleGetUserAccess(ctx context.Context, req *request) ([]byte, uint8) {
+	if len(req.msg.Data) != 2 {
+		return nil, CCInvalidLength
+	}
+
+	channel := resolveChannel(req, req.msg.Data[0])
+	if !s.userChannel(channel) {
+		return nil, CCInvalidField
+	}
+	id, ok := s.userID(req.msg.Data[1])
+	if !ok {
+		return nil, CCParameterOutOfRange
+	}
+
+	if cc := s.lockUsers(ctx); cc != CCSuccess {
+		return nil, cc
+	}
+	defer s.users.mu.Unlock()
+
+	status := userStatusDisabled
+	access := userChannelAccess{privilege: privilegeNoAccess}
+	if u, ok := s.users.users[id]; ok {
+		if u.enabled {
+			status = userStatusEnabled
+		}
+		access = u.channelAccess(channel)
+	}
+
+	flags := access.privilege
+	if access.callbackOnly {
+		flags |= userCallbackOnly
+	}
+	if access.linkAuth {
+		flags |= userLinkAuth
+	}
+	if access.ipmiMessaging {
+		flags |= userIPMIMessaging
+	}
+
+	return []byte{
+		uint8(s.config.maxUsers),
+		status | uint8(s.users.enabledCount()),
+		fixedUserNames,
+		flags,
+	}, CCSuccess
+}
+
+func (s *IPMISrv) handleSetUserAccess(ctx context.Context, req *request) ([]byte, uint8) {
+	data := req.msg.Data
+	if len(data) != 3 && len(data) != 4 {
+		return nil, CCInvalidLength
+	}
+
+	channel := resolveChannel(req, data[0])
+	if !s.userChannel(channel) {
+		return nil, CCInvalidField
+	}
+	id, ok := s.userID(data[1])
+	if !ok || id == nullUserID {
+		return nil, CCParameterOutOfRange
+	}
+	privilege := data[2] & privilegeLimitMask
+	if privilege != privilegeNoAccess && !PrivilegeLevel(privilege).Valid() {
+		return nil, CCInvalidField
+	}
+
+	if cc := s.lockUsers(ctx); cc != CCSuccess {
+		return nil, cc
+	}
+	defer s.users.mu.Unlock()
+
+	u, ok := s.users.users[id]
+	if !ok {
+		return nil, CCNotPresent
+	}
+
+	previous := u.channelAccess(channel)
+	access := previous
+	access.privilege = privilege
+	if data[0]&userAccessChange != 0 {
+		access.callbackOnly = data[0]&userCallbackOnly != 0
+		access.linkAuth = data[0]&userLinkAuth != 0
+		access.ipmiMessaging = data[0]&userIPMIMessaging != 0
+	}
+
+	u.access[channel] = access
+	if cc := s.updateUser(ctx, id, u, "custom_attributes"); cc != CCSuccess {
+		u.access[channel] = previous
+		return nil, cc
+	}
+	if err := s.syncUserKey(ctx, u, nil); err != nil {
+		s.logger.WarnContext(ctx, "Failed to update IPMI user key", "user", u.name, "error", err)
+	}
+
+	s.logger.InfoContext(ctx, "IPMI user access changed",
+		"user", u.name,
+		"user_id", id,
+		"channel", channel,
+		"privilege", privilegeName(privilege),
+		"ipmi_messaging", access.ipmiMessaging,
+		"remote_addr", req.remoteAddr)
+
+	return nil, CCSuccess
+}
+
+func (s *IPMISrv) handleGetUserName(ctx context.Context, req *request) ([]byte, uint8) {
+	if len(req.msg.Data) != 1 {
+		return nil, CCInvalidLength
+	}
+	id, ok := s.userID(req.msg.Data[0])
+	if !ok {
+		return nil, CCParameterOutOfRange
+	}
+
+	if cc := s.lockUsers(ctx); cc != CCSuccess {
+		return nil, cc
+	}
+	defer s.users.mu.Unlock()
+
+	out := make([]byte, maxUsernameLength)
+	if u, ok := s.users.users[id]; ok {
+		copy(out, u.name)
+	}
+
+	return out, CCSuccess
+}
+
+func (s *IPMISrv) handleSetUserName(ctx context.Context, req *request) ([]byte, uint8) {
+	data := req.msg.Data
+	if len(data) != 1+maxUsernameLength {
+		return nil, CCInvalidLength
+	}
+	id, ok := s.userID(data[0])
+	if !ok || id == nullUserID {
+		return nil, CCParameterOutOfRange
+	}
+	name := string(bytes.TrimRight(data[1:], "\x00"))
+	if strings.ContainsRune(name, 0) {
+		return nil, CCInvalidField
+	}
+
+	if cc := s.lockUsers(ctx); cc != CCSuccess {
+		return nil, cc
+	}
+	defer s.users.mu.Unlock()
+
+	u, exists := s.users.users[id]
+	if exists && u.name == name {
+		return nil, CCSuccess
+	}
+	if other, taken := s.users.byName(name); taken && other != id {
+		return nil, CCInvalidField
+	}
+
+	w, writable := s.keyStoreWriter()
+
+	switch {
+	case name == "" && !exists:
+		return nil, CCSuccess
+
+	case name == "":
+		// Clearing the name frees the user ID and deletes the user.
+		if cc := s.deleteUser(ctx, u); cc != CCSuccess {
+			return nil, cc
+		}
+		if writable {
+			if err := w.Delete(u.name); err != nil {
+				s.logger.WarnContext(ctx, "Failed to delete IPMI user key", "user", u.name, "error", err)
+			}
+		}
+		delete(s.users.users, id)
+
+	case !exists:
+		u = &ipmiUser{
+			name:   name,
+			access: make(map[uint8]userChannelAccess),
+		}
+		if cc := s.createUser(ctx, id, u); cc != CCSuccess {
+			return nil, cc
+		}
+		s.users.users[id] = u
+
+	default:
+		if !writable {
+			return nil, CCSubFunctionDisabled
+		}
+		previous := u.name
+		u.name = name
+		if cc := s.updateUser(ctx, id, u, "username"); cc != CCSuccess {
+			u.name = previous
+			return nil, cc
+		}
+		if key, err := w.Lookup(ctx, previous); err == nil {
+			if err := w.Set(name, key); err != nil {
+				s.logger.WarnContext(ctx, "Failed to move IPMI user key", "user", name, "error", err)
+			}
+			if err := w.Delete(previous); err != nil {
+				s.logger.WarnContext(ctx, "Failed to delete IPMI user key", "user", previous, "error", err)
+			}
+		}
+	}
+
+	s.logger.InfoContext(ctx, "IPMI user name changed",
+		"user_id", id,
+		"user", name,
+		"remote_addr", req.remoteAddr)
+
+	return nil, CCSuccess
+}
+
+func (s *IPMISrv) handleSetUserPassword(ctx context.Context, req *request) ([]byte, uint8) {
+	data := req.msg.Data
+	if len(data) < 2 {
+		return nil, CCInvalidLength
+	}
+	id, ok := s.userID(data[0])
+	if !ok || id == nullUserID {
+		return nil, CCParameterOutOfRange
+	}
+	op := data[1] & passwordOpMask
+
+	size := passwordLen16
+	if data[0]&passwordSize20 != 0 {
+		size = keyLength
+	}
+	var password []byte
+	if op == passwordOpSet || op == passwordOpTest {
+		if len(data) != 2+size {
+			return nil, CCInvalidLength
+		}
+		password = data[2:]
+	}
+
+	if cc := s.lockUsers(ctx); cc != CCSuccess {
+		return nil, cc
+	}
+	defer s.users.mu.Unlock()
+
+	u, ok := s.users.users[id]
+	if !ok {
+		return nil, CCNotPresent
+	}
+
+	switch op {
+	case passwordOpDisable, passwordOpEnable:
+		enabled := op == passwordOpEnable
+		if u.enabled == enabled {
+			return nil, CCSuccess
+		}
+		u.enabled = enabled
+		if cc := s.updateUser(ctx, id, u, "enabled"); cc != CCSuccess {
+			u.enabled = !enabled
+			return nil, cc
+		}
+		s.logger.InfoContext(ctx, "IPMI user enabled state changed",
+			"user", u.name,
+			"user_id", id,
+			"enabled", enabled,
+			"remote_addr", req.remoteAddr)
+		return nil, CCSuccess
+
+	case passwordOpSet:
+		return nil, s.setUserPassword(ctx, id, u, password, req.remoteAddr)
+
+	default:
+		return nil, s.testUserPassword(ctx, u, password)
+	}
+}
+
+// setUserPassword resets the password hash kept by usermgr and, once usermgr
+// has accepted the password, stores the cleartext key required by RAKP.
+func (s *IPMISrv) setUserPassword(ctx context.Context, id uint8, u *ipmiUser, password []byte, remoteAddr string) uint8 {
+	if _, ok := s.keyStoreWriter(); !ok {
+		return CCSubFunctionDisabled
+	}
+	secret := bytes.TrimRight(password, "\x00")
+
+	rctx, cancel := context.WithTimeout(ctx, s.config.requestTimeout)
+	defer cancel()
+
+	newPassword := string(secret)
+	force := true
+	resp := &v1alpha1.ResetPasswordResponse{}
+	if err := s.requestNATS(rctx, ipc.SubjectUserResetPassword, &v1alpha1.ResetPasswordRequest{
+		Id:          u.userID,
+		NewPassword: &newPassword,
+		Force:       &force,
+	}, resp); err != nil {
+		s.logger.WarnContext(ctx, "Failed to set user password", "user", u.name, "error", err)
+		if errors.Is(err, ErrInvalidArgument) {
+			return CCInvalidField
+		}
+		return CCDestinationUnavail
+	}
+	if !resp.GetSuccess() {
+		s.logger.WarnContext(ctx, "User password rejected", "user", u.name, "reason", resp.GetFailureReason())
+		return CCInvalidField
+	}
+
+	if err := s.syncUserKey(ctx, u, secret); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to store IPMI user key", "user", u.name, "error", err)
+		return CCUnspecified
+	}
+	u.passwordSize = len(password)
+
+	s.logger.InfoContext(ctx, "IPMI user password changed",
+		"user", u.name,
+		"user_id", id,
+		"remote_addr", remoteAddr)
+
+	return CCSuccess
+}
+
+// testUserPassword compares password with the stored key of u.
+func (s *IPMISrv) testUserPassword(ctx context.Context, u *ipmiUser, password []byte) uint8 {
+	key, err := s.config.keyStore.Lookup(ctx, u.name)
+	if errors.Is(err, ErrUserNotFound) {
+		return passwordCCTestFailed
+	}
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to look up IPMI user key", "user", u.name, "error", err)
+		return CCUnspecified
+	}
+
+	if u.passwordSize != 0 && u.passwordSize != len(password) {
+		return passwordCCWrongSize
+	}
+	if len(key.Password) > len(password) {
+		return passwordCCTestFailed
+	}
+
+	stored := make([]byte, len(password))
+	copy(stored, key.Password)
+	if subtle.ConstantTimeCompare(stored, password) != 1 {
+		return passwordCCTestFailed
+	}
+
+	return CCSuccess
+}
@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package ipmisrv
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestSetUserPassword(t *testing.T) {
+	const (
+		userID   = 3
+		username = "operator"
+		oldKey   = "old-secret"
+	)
+
+	tests := []struct {
+		name     string
+		password string
+		reject   bool
+		wantCC   uint8
+	}{
+		{name: "accepted", password: "n3w-Secret", wantCC: CCSuccess},
+		{name: "rejected by usermgr", password: "password", reject: true, wantCC: CCInvalidField},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, users := newTestServer(t)
+			users.rejected[tt.password] = tt.reject
+			if err := s.config.keyStore.(KeyStoreWriter).Set(username, UserKey{Password: []byte(oldKey), Privilege: PrivilegeOperator}); err != nil {
+				t.Fatalf("set key: %v", err)
+			}
+			s.users.loaded = true
+			s.users.users[userID] = &ipmiUser{userID: "user-3", name: username, enabled: true}
+
+			data := append([]byte{userID, passwordOpSet}, make([]byte, passwordLen16)...)
+			copy(data[2:], tt.password)
+			_, cc := s.handleSetUserPassword(context.Background(), &request{
+				msg:        &Message{NetFn: NetFnApp, Command: cmdSetUserPassword, Data: data},
+				privilege:  PrivilegeAdmin,
+				remoteAddr: testRemote,
+			})
+			if cc != tt.wantCC {
+				t.Fatalf("completion code = %#x, want %#x", cc, tt.wantCC)
+			}
+
+			users.mu.Lock()
+			resets := users.resets
+			users.mu.Unlock()
+			if len(resets) != 1 || resets[0].GetId() != "user-3" || resets[0].GetNewPassword() != tt.password {
+				t.Fatalf("usermgr received resets %v, want one of user-3 to %q", resets, tt.password)
+			}
+
+			key, err := s.config.keyStore.Lookup(context.Background(), username)
+			if err != nil {
+				t.Fatalf("Lookup() error = %v", err)
+			}
+			wantKey, wantSize := tt.password, passwordLen16
+			if tt.reject {
+				// The key must keep matching the hash usermgr still holds.
+				wantKey, wantSize = oldKey, 0
+			}
+			if !bytes.Equal(key.Password, []byte(wantKey)) {
+				t.Errorf("stored key = %q, want %q", key.Password, wantKey)
+			}
+			if got := s.users.users[userID].passwordSize; got != wantSize {
+				t.Errorf("password size = %d, want %d", got, wantSize)
+			}
+		})
+	}
+}
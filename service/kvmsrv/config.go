@@ -33,6 +33,9 @@ const (
 	DefaultClientTimeout      = 30 * time.Minute
 	DefaultFrameTimeout       = 5 * time.Second
 	DefaultBufferCount        = 4
+	DefaultMediaDir           = "/var/lib/u-bmc/media"
+	DefaultMaxMediaSize       = 16 << 30
+	DefaultMediaTimeout       = time.Hour
 )
 
 // config represents the internal configuration for the KVM service.
@@ -77,6 +80,11 @@ type config struct {
 	usbProduct      string
 	usbSerialNumber string
 
+	// Virtual media configuration
+	mediaDir     string
+	maxMediaSize int64
+	mediaTimeout time.Duration
+
 	// Client configuration
 	clientTimeout time.Duration
 }
@@ -334,6 +342,47 @@ func WithBufferCount(count uint32) Option {
 	return &bufferCountOption{count: count}
 }
 
+type mediaDirOption struct {
+	dir string
+}
+
+func (o *mediaDirOption) apply(c *config) {
+	c.mediaDir = o.dir
+}
+
+// WithMediaDir sets the directory virtual media images are kept in. Local
+// images must lie in this directory, and images downloaded from a URL are
+// stored there until they are ejected.
+func WithMediaDir(dir string) Option {
+	return &mediaDirOption{dir: dir}
+}
+
+type maxMediaSizeOption struct {
+	size int64
+}
+
+func (o *maxMediaSizeOption) apply(c *config) {
+	c.maxMediaSize = o.size
+}
+
+// WithMaxMediaSize sets the maximum size in bytes of a downloaded virtual media image.
+func WithMaxMediaSize(size int64) Option {
+	return &maxMediaSizeOption{size: size}
+}
+
+type mediaTimeoutOption struct {
+	timeout time.Duration
+}
+
+func (o *mediaTimeoutOption) apply(c *config) {
+	c.mediaTimeout = o.timeout
+}
+
+// WithMediaTimeout sets how long downloading a virtual media image may take.
+func WithMediaTimeout(timeout time.Duration) Option {
+	return &mediaTimeoutOption{timeout: timeout}
+}
+
 // WithName is a backward compatibility alias for WithServiceName.
 // Deprecated: Use WithServiceName instead.
 func WithName(name string) Option {
@@ -435,6 +484,18 @@ func (c *config) Validate() error {
 		c.bufferCount = DefaultBufferCount
 	}
 
+	if c.mediaDir == "" {
+		c.mediaDir = DefaultMediaDir
+	}
+
+	if c.maxMediaSize <= 0 {
+		c.maxMediaSize = DefaultMaxMediaSize
+	}
+
+	if c.mediaTimeout <= 0 {
+		c.mediaTimeout = DefaultMediaTimeout
+	}
+
 	return nil
 }
 
@@ -8,7 +8,7 @@
 //
 // # Service Overview
 //
-// The KVM service operates independently with minimal IPC dependencies:
+// The KVM service operates largely independently, using IPC only for virtual media:
 //   - Captures video from /dev/video0 or configured V4L2 device
 //   - Provides USB HID keyboard and mouse emulation
 //   - Serves VNC on standard port 5900 (configurable)
@@ -37,6 +37,19 @@
 //   - Supports mass storage for virtual media mounting
 //   - Handles gadget lifecycle (creation, binding, cleanup)
 //
+// # Virtual Media
+//
+// The mass storage function is managed through NATS, which websrv uses to
+// serve Redfish VirtualMedia:
+//   - virtual_media.info reports the inserted image and its state
+//   - virtual_media.insert presents an image as CD-ROM or removable disk
+//   - virtual_media.eject detaches the image
+//
+// Images are either HTTP or HTTPS URLs, downloaded in the background to the
+// directory set with WithMediaDir and removed again on ejection, or local
+// images that already lie in that directory. Downloads are bounded by
+// WithMaxMediaSize and WithMediaTimeout. Only one image is inserted at a time.
+//
 // # Video Processing
 //
 // Efficient video frame processing pipeline:
@@ -37,4 +37,25 @@ var (
 
 	// ErrInvalidFrame indicates that an invalid video frame was provided.
 	ErrInvalidFrame = errors.New("invalid video frame")
+
+	// ErrNATSConnectionFailed indicates that the NATS connection could not be established.
+	ErrNATSConnectionFailed = errors.New("failed to connect to NATS")
+
+	// ErrMicroServiceCreationFailed indicates that micro service creation failed.
+	ErrMicroServiceCreationFailed = errors.New("failed to create micro service")
+
+	// ErrEndpointRegistrationFailed indicates that endpoint registration failed.
+	ErrEndpointRegistrationFailed = errors.New("failed to register endpoint")
+
+	// ErrInvalidMediaRequest indicates a malformed virtual media request.
+	ErrInvalidMediaRequest = errors.New("invalid virtual media request")
+
+	// ErrMediaInUse indicates that virtual media is already inserted.
+	ErrMediaInUse = errors.New("virtual media in use")
+
+	// ErrMediaTooLarge indicates that a virtual media image exceeds the maximum size.
+	ErrMediaTooLarge = errors.New("virtual media image too large")
+
+	// ErrMediaDownloadFailed indicates that a virtual media image could not be downloaded.
+	ErrMediaDownloadFailed = errors.New("failed to download virtual media image")
 )
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/micro"
 	"github.com/u-bmc/u-bmc/pkg/log"
 	"github.com/u-bmc/u-bmc/service"
 	"go.opentelemetry.io/otel"
@@ -33,6 +34,16 @@ type KVMSrv struct {
 	running bool
 	mu      sync.RWMutex
 
+	// IPC
+	nc           *nats.Conn
+	microService micro.Service
+
+	// Virtual media
+	ctx     context.Context //nolint:containedctx // bounds media downloads, which outlive the requests starting them
+	wg      sync.WaitGroup
+	mediaMu sync.Mutex
+	media   virtualMedia
+
 	// Observability
 	logger *slog.Logger
 	tracer trace.Tracer
@@ -72,6 +83,9 @@ func New(opts ...Option) *KVMSrv {
 		clientTimeout:      DefaultClientTimeout,
 		frameTimeout:       DefaultFrameTimeout,
 		bufferCount:        DefaultBufferCount,
+		mediaDir:           DefaultMediaDir,
+		maxMediaSize:       DefaultMaxMediaSize,
+		mediaTimeout:       DefaultMediaTimeout,
 	}
 
 	for _, opt := range opts {
@@ -139,6 +153,19 @@ func (s *KVMSrv) Run(ctx context.Context, ipcConn nats.InProcessConnProvider) er
 		return err
 	}
 
+	// Serve virtual media requests
+	mediaCtx, cancelMedia := context.WithCancel(ctx)
+	defer cancelMedia()
+	s.ctx = mediaCtx
+
+	if err := s.startIPC(ctx, ipcConn); err != nil {
+		span.RecordError(err)
+		s.logger.ErrorContext(ctx, "Failed to start IPC", "error", err)
+		s.cleanupComponents(ctx)
+		return err
+	}
+	defer s.nc.Drain() //nolint:errcheck
+
 	s.logger.InfoContext(ctx, "KVM service started successfully")
 
 	// Wait for shutdown signal
@@ -150,12 +177,42 @@ func (s *KVMSrv) Run(ctx context.Context, ipcConn nats.InProcessConnProvider) er
 	}
 
 	// Cleanup
+	_ = s.microService.Stop()
+	cancelMedia()
+	s.releaseMedia(context.WithoutCancel(ctx))
 	s.cleanupComponents(ctx)
 	s.logger.InfoContext(ctx, "KVM service stopped")
 
 	return ctx.Err()
 }
 
+// startIPC connects to NATS and registers the virtual media endpoints.
+func (s *KVMSrv) startIPC(ctx context.Context, ipcConn nats.InProcessConnProvider) error {
+	nc, err := nats.Connect("", nats.InProcessServer(ipcConn))
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrNATSConnectionFailed, err)
+	}
+	s.nc = nc
+
+	s.microService, err = micro.AddService(nc, micro.Config{
+		Name:        s.config.serviceName,
+		Description: s.config.serviceDescription,
+		Version:     s.config.serviceVersion,
+	})
+	if err != nil {
+		nc.Close()
+		return fmt.Errorf("%w: %w", ErrMicroServiceCreationFailed, err)
+	}
+
+	if err := s.registerEndpoints(ctx); err != nil {
+		_ = s.microService.Stop()
+		nc.Close()
+		return err
+	}
+
+	return nil
+}
+
 // initializeComponents initializes all service components.
 func (s *KVMSrv) initializeComponents(ctx context.Context) error {
 	var err error
@@ -173,6 +173,30 @@ func (um *usbManager) setMassStorageFile(ctx context.Context, filePath string, c
 	return usb.SetMassStorageFile(ctx, um.gadget, filePath, cdromMode)
 }
 
+// insertMedia attaches the image at filePath to the mass storage function.
+// The CD-ROM and read-only flags are applied first, as the kernel only
+// accepts them while no image is attached.
+func (um *usbManager) insertMedia(ctx context.Context, filePath string, cdromMode, readOnly bool) error {
+	if !um.massStorageReady.Load() {
+		return ErrResourceUnavailable
+	}
+
+	if err := usb.SetMassStorageReadOnly(ctx, um.gadget, readOnly || cdromMode); err != nil {
+		return err
+	}
+
+	return usb.SetMassStorageFile(ctx, um.gadget, filePath, cdromMode)
+}
+
+// ejectMedia detaches the image from the mass storage function.
+func (um *usbManager) ejectMedia(ctx context.Context) error {
+	if !um.massStorageReady.Load() {
+		return ErrResourceUnavailable
+	}
+
+	return usb.EjectMassStorageFile(ctx, um.gadget)
+}
+
 // createGadget creates the USB gadget.
 func (um *usbManager) createGadget(ctx context.Context) error {
 	l := log.GetGlobalLogger()
@@ -0,0 +1,474 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package kvmsrv
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/nats-io/nats.go/micro"
+	"github.com/u-bmc/u-bmc/pkg/ipc"
+	"github.com/u-bmc/u-bmc/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Virtual media states.
+const (
+	MediaStateEjected   = "ejected"
+	MediaStateInserting = "inserting"
+	MediaStateInserted  = "inserted"
+)
+
+// InsertMediaRequest is the request of the virtual media insert endpoint.
+type InsertMediaRequest struct {
+	// Image is an HTTP or HTTPS URL the image is downloaded from, or the
+	// path of an image in the media directory, optionally as file URL.
+	Image string `json:"image"`
+	// Username and Password authenticate the image download.
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	// CDROM presents the image as CD-ROM instead of removable disk.
+	CDROM bool `json:"cdrom"`
+	// WriteProtected prevents the host from writing to a removable disk.
+	// CD-ROM images are always write protected.
+	WriteProtected bool `json:"write_protected"`
+}
+
+// EjectMediaRequest is the request of the virtual media eject endpoint.
+type EjectMediaRequest struct {
+	// CDROM selects the kind of media to eject. Ejecting media of the kind
+	// not inserted does nothing.
+	CDROM bool `json:"cdrom"`
+}
+
+// MediaStatus describes the virtual media presented to the host.
+type MediaStatus struct {
+	// Available reports whether the USB mass storage function is ready.
+	Available bool `json:"available"`
+	// State is one of the MediaState constants.
+	State string `json:"state"`
+	// Image is the image as requested, without credentials.
+	Image string `json:"image,omitempty"`
+	// ImageName is the file name of the image.
+	ImageName      string `json:"image_name,omitempty"`
+	CDROM          bool   `json:"cdrom"`
+	WriteProtected bool   `json:"write_protected"`
+	// Message explains why the last insertion failed.
+	Message string `json:"message,omitempty"`
+}
+
+// virtualMedia is the media attached to the mass storage function.
+type virtualMedia struct {
+	MediaStatus
+	// file is the image file, which is removed on ejection if the service
+	// downloaded it.
+	file       string
+	downloaded bool
+	// generation changes whenever media is inserted or ejected, so that a
+	// download finishing late can tell that its insertion was abandoned.
+	generation uint64
+	cancel     context.CancelFunc
+}
+
+// mediaUSB returns the USB manager if it provides mass storage.
+func (s *KVMSrv) mediaUSB() *usbManager {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.usbGadget == nil || !s.usbGadget.massStorageReady.Load() {
+		return nil
+	}
+	return s.usbGadget
+}
+
+// mediaStatus returns the current virtual media status. The caller must hold
+// the media mutex.
+func (s *KVMSrv) mediaStatus() MediaStatus {
+	status := s.media.MediaStatus
+	status.Available = s.mediaUSB() != nil
+	if status.State == "" {
+		status.State = MediaStateEjected
+	}
+	return status
+}
+
+// mediaImage resolves the image of req. It returns the path of a local image,
+// or the URL to download the image from, and the image as it is reported.
+func (s *KVMSrv) mediaImage(req *InsertMediaRequest) (string, *url.URL, string, error) {
+	uri, err := url.Parse(req.Image)
+	if err != nil || req.Image == "" {
+		return "", nil, "", fmt.Errorf("%w: invalid image %q", ErrInvalidMediaRequest, req.Image)
+	}
+
+	switch uri.Scheme {
+	case "http", "https":
+		if uri.Host == "" {
+			return "", nil, "", fmt.Errorf("%w: invalid image %q", ErrInvalidMediaRequest, req.Image)
+		}
+		redacted := *uri
+		redacted.User = nil
+		return "", uri, redacted.String(), nil
+	case "file", "":
+		if uri.Scheme == "" {
+			uri.Path = req.Image
+		}
+		image, err := s.localImage(uri.Path)
+		if err != nil {
+			return "", nil, "", err
+		}
+		return image, nil, req.Image, nil
+	default:
+		return "", nil, "", fmt.Errorf("%w: unsupported image scheme %q", ErrInvalidMediaRequest, uri.Scheme)
+	}
+}
+
+// localImage resolves p and checks that it is a regular file in the media
+// directory.
+func (s *KVMSrv) localImage(p string) (string, error) {
+	dir, err := filepath.Abs(s.config.mediaDir)
+	if err != nil {
+		return "", err
+	}
+	if !filepath.IsAbs(p) {
+		return "", fmt.Errorf("%w: image path %q is not absolute", ErrInvalidMediaRequest, p)
+	}
+	image := filepath.Clean(p)
+	if rel, err := filepath.Rel(dir, image); err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("%w: image %q is not in the media directory", ErrInvalidMediaRequest, p)
+	}
+
+	info, err := os.Stat(image)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidMediaRequest, err)
+	}
+	if !info.Mode().IsRegular() {
+		return "", fmt.Errorf("%w: image %q is not a regular file", ErrInvalidMediaRequest, p)
+	}
+
+	return image, nil
+}
+
+// insertMedia attaches the image of req, downloading it in the background if
+// it is remote. Only one image can be inserted at a time.
+func (s *KVMSrv) insertMedia(ctx context.Context, req *InsertMediaRequest) (MediaStatus, error) {
+	file, uri, image, err := s.mediaImage(req)
+	if err != nil {
+		return MediaStatus{}, err
+	}
+
+	s.mediaMu.Lock()
+	defer s.mediaMu.Unlock()
+
+	um := s.mediaUSB()
+	if um == nil {
+		return MediaStatus{}, ErrResourceUnavailable
+	}
+	if s.media.State != "" && s.media.State != MediaStateEjected {
+		return MediaStatus{}, fmt.Errorf("%w: %s", ErrMediaInUse, s.media.Image)
+	}
+
+	s.media = virtualMedia{
+		MediaStatus: MediaStatus{
+			State:          MediaStateInserting,
+			Image:          image,
+			CDROM:          req.CDROM,
+			WriteProtected: req.CDROM || req.WriteProtected,
+		},
+		generation: s.media.generation + 1,
+	}
+
+	if uri == nil {
+		s.media.ImageName = filepath.Base(file)
+		if err := um.insertMedia(ctx, file, s.media.CDROM, s.media.WriteProtected); err != nil {
+			s.media = virtualMedia{generation: s.media.generation}
+			return MediaStatus{}, err
+		}
+		s.media.State = MediaStateInserted
+		s.media.file = file
+		s.logger.InfoContext(ctx, "Virtual media inserted", "image", image, "cdrom", req.CDROM)
+		return s.mediaStatus(), nil
+	}
+
+	s.media.ImageName = path.Base(uri.Path)
+	downloadCtx, cancel := context.WithTimeout(s.ctx, s.config.mediaTimeout)
+	s.media.cancel = cancel
+
+	s.wg.Add(1)
+	go func(generation uint64) {
+		defer s.wg.Done()
+		defer cancel()
+		s.downloadMedia(downloadCtx, generation, image, uri, req)
+	}(s.media.generation)
+
+	return s.mediaStatus(), nil
+}
+
+// downloadMedia downloads the image at uri and attaches it, unless the
+// insertion of the given generation was abandoned meanwhile.
+func (s *KVMSrv) downloadMedia(ctx context.Context, generation uint64, image string, uri *url.URL, req *InsertMediaRequest) {
+	s.logger.InfoContext(ctx, "Downloading virtual media image", "image", image)
+
+	file, err := s.download(ctx, uri, req.Username, req.Password)
+
+	s.mediaMu.Lock()
+	defer s.mediaMu.Unlock()
+
+	if s.media.generation != generation {
+		if file != "" {
+			_ = os.Remove(file)
+		}
+		return
+	}
+	s.media.cancel = nil
+
+	if err == nil {
+		um := s.mediaUSB()
+		if um == nil {
+			err = ErrResourceUnavailable
+		} else {
+			err = um.insertMedia(ctx, file, s.media.CDROM, s.media.WriteProtected)
+		}
+	}
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to insert virtual media", "image", image, "error", err)
+		if file != "" {
+			_ = os.Remove(file)
+		}
+		s.media = virtualMedia{
+			MediaStatus: MediaStatus{State: MediaStateEjected, CDROM: s.media.CDROM, Message: err.Error()},
+			generation:  generation,
+		}
+		return
+	}
+
+	s.media.State = MediaStateInserted
+	s.media.file = file
+	s.media.downloaded = true
+	s.logger.InfoContext(ctx, "Virtual media inserted", "image", image, "cdrom", s.media.CDROM)
+}
+
+// download fetches the image at uri into the media directory and returns the
+// path of the downloaded file.
+func (s *KVMSrv) download(ctx context.Context, uri *url.URL, username, password string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri.String(), nil)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrMediaDownloadFailed, err)
+	}
+	if username != "" {
+		req.SetBasicAuth(username, password)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrMediaDownloadFailed, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: %s", ErrMediaDownloadFailed, resp.Status)
+	}
+	if resp.ContentLength > s.config.maxMediaSize {
+		return "", fmt.Errorf("%w: %d bytes", ErrMediaTooLarge, resp.ContentLength)
+	}
+
+	if err := os.MkdirAll(s.config.mediaDir, 0o750); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrMediaDownloadFailed, err)
+	}
+	f, err := os.CreateTemp(s.config.mediaDir, "download-*.img")
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrMediaDownloadFailed, err)
+	}
+
+	n, err := io.Copy(f, io.LimitReader(resp.Body, s.config.maxMediaSize+1))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && n > s.config.maxMediaSize {
+		err = fmt.Errorf("%w: more than %d bytes", ErrMediaTooLarge, s.config.maxMediaSize)
+	} else if err != nil {
+		err = fmt.Errorf("%w: %w", ErrMediaDownloadFailed, err)
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return "", err
+	}
+
+	return f.Name(), nil
+}
+
+// ejectMedia detaches the inserted image if it is of the kind given by cdrom,
+// abandoning a download in progress.
+func (s *KVMSrv) ejectMedia(ctx context.Context, cdrom bool) (MediaStatus, error) {
+	s.mediaMu.Lock()
+	defer s.mediaMu.Unlock()
+
+	switch {
+	case s.media.State == "" || s.media.State == MediaStateEjected || s.media.CDROM != cdrom:
+		return s.mediaStatus(), nil
+	case s.media.State == MediaStateInserting:
+		if s.media.cancel != nil {
+			s.media.cancel()
+		}
+	default:
+		if um := s.mediaUSB(); um != nil {
+			if err := um.ejectMedia(ctx); err != nil {
+				return MediaStatus{}, err
+			}
+		}
+		if s.media.downloaded {
+			if err := os.Remove(s.media.file); err != nil && !errors.Is(err, os.ErrNotExist) {
+				s.logger.WarnContext(ctx, "Failed to remove virtual media image", "file", s.media.file, "error", err)
+			}
+		}
+	}
+
+	s.logger.InfoContext(ctx, "Virtual media ejected", "image", s.media.Image)
+	s.media = virtualMedia{
+		MediaStatus: MediaStatus{State: MediaStateEjected},
+		generation:  s.media.generation + 1,
+	}
+
+	return s.mediaStatus(), nil
+}
+
+// releaseMedia abandons a download in progress and removes a downloaded image
+// when the service stops. The gadget itself is destroyed with the USB manager.
+func (s *KVMSrv) releaseMedia(ctx context.Context) {
+	s.mediaMu.Lock()
+	if s.media.cancel != nil {
+		s.media.cancel()
+	}
+	file := ""
+	if s.media.downloaded {
+		file = s.media.file
+	}
+	s.media = virtualMedia{generation: s.media.generation + 1}
+	s.mediaMu.Unlock()
+
+	s.wg.Wait()
+
+	if file != "" {
+		if err := os.Remove(file); err != nil && !errors.Is(err, os.ErrNotExist) {
+			s.logger.WarnContext(ctx, "Failed to remove virtual media image", "file", file, "error", err)
+		}
+	}
+}
+
+func (s *KVMSrv) registerEndpoints(ctx context.Context) error {
+	groups := make(map[string]micro.Group)
+
+	endpoints := []struct {
+		subject string
+		handler func(context.Context, micro.Request)
+	}{
+		{ipc.SubjectVirtualMediaInfo, s.handleMediaInfo},
+		{ipc.SubjectVirtualMediaInsert, s.handleInsertMedia},
+		{ipc.SubjectVirtualMediaEject, s.handleEjectMedia},
+	}
+	for _, ep := range endpoints {
+		if err := ipc.RegisterEndpointWithGroupCache(s.microService, ep.subject,
+			micro.HandlerFunc(s.createRequestHandler(ctx, ep.handler)), groups); err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrEndpointRegistrationFailed, ep.subject, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *KVMSrv) createRequestHandler(parentCtx context.Context, handler func(context.Context, micro.Request)) micro.HandlerFunc {
+	return func(req micro.Request) {
+		ctx := context.WithoutCancel(telemetry.GetCtxFromReq(req))
+		if parentCtx.Err() != nil {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithCancel(ctx)
+			cancel()
+		}
+
+		ctx, span := s.tracer.Start(ctx, "kvmsrv.handleRequest")
+		span.SetAttributes(
+			attribute.String("subject", req.Subject()),
+			attribute.String("service", s.config.serviceName),
+		)
+		defer span.End()
+
+		handler(ctx, req) //nolint:contextcheck
+	}
+}
+
+// handleMediaInfo responds with the virtual media status.
+func (s *KVMSrv) handleMediaInfo(ctx context.Context, req micro.Request) {
+	s.mediaMu.Lock()
+	status := s.mediaStatus()
+	s.mediaMu.Unlock()
+
+	s.respond(ctx, req, &status)
+}
+
+// handleInsertMedia inserts an image and responds with the virtual media
+// status, which reports a remote image as inserting until it is downloaded.
+func (s *KVMSrv) handleInsertMedia(ctx context.Context, req micro.Request) {
+	var request InsertMediaRequest
+	if err := json.Unmarshal(req.Data(), &request); err != nil {
+		_ = req.Error("400", "invalid request format", nil)
+		return
+	}
+
+	status, err := s.insertMedia(ctx, &request)
+	switch {
+	case err == nil:
+	case errors.Is(err, ErrInvalidMediaRequest):
+		_ = req.Error("400", err.Error(), nil)
+		return
+	case errors.Is(err, ErrMediaInUse), errors.Is(err, ErrResourceUnavailable):
+		_ = req.Error("409", err.Error(), nil)
+		return
+	default:
+		s.logger.ErrorContext(ctx, "Failed to insert virtual media", "error", err)
+		_ = req.Error("500", "failed to insert virtual media", nil)
+		return
+	}
+
+	s.respond(ctx, req, &status)
+}
+
+// handleEjectMedia ejects the inserted image and responds with the virtual
+// media status.
+func (s *KVMSrv) handleEjectMedia(ctx context.Context, req micro.Request) {
+	var request EjectMediaRequest
+	if err := json.Unmarshal(req.Data(), &request); err != nil {
+		_ = req.Error("400", "invalid request format", nil)
+		return
+	}
+
+	status, err := s.ejectMedia(ctx, request.CDROM)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to eject virtual media", "error", err)
+		_ = req.Error("500", "failed to eject virtual media", nil)
+		return
+	}
+
+	s.respond(ctx, req, &status)
+}
+
+// respond sends v as JSON response to req.
+func (s *KVMSrv) respond(ctx context.Context, req micro.Request, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to marshal response", "subject", req.Subject(), "error", err)
+		_ = req.Error("500", "failed to marshal response", nil)
+		return
+	}
+
+	if err := req.Respond(data); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to send response", "subject", req.Subject(), "error", err)
+	}
+}
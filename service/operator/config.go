@@ -17,6 +17,7 @@ import (
 	"github.com/u-bmc/u-bmc/service/ledmgr"
 	"github.com/u-bmc/u-bmc/service/powermgr"
 	"github.com/u-bmc/u-bmc/service/securitymgr"
+	"github.com/u-bmc/u-bmc/service/selmgr"
 	"github.com/u-bmc/u-bmc/service/sensormon"
 	"github.com/u-bmc/u-bmc/service/statemgr"
 	"github.com/u-bmc/u-bmc/service/telemetry"
@@ -83,6 +84,7 @@ type config struct {
 	Ledmgr       service.Service
 	Powermgr     service.Service
 	Securitymgr  service.Service
+	Selmgr       service.Service
 	Sensormon    service.Service
 	Statemgr     service.Service
 	Telemetry    service.Service
@@ -369,6 +371,22 @@ func WithSecuritymgr(opts ...securitymgr.Option) Option {
 	}
 }
 
+type selmgrOption struct {
+	selmgr service.Service
+}
+
+func (o *selmgrOption) apply(c *config) {
+	c.Selmgr = o.selmgr
+}
+
+// WithSelmgr configures the system event log manager with the provided options.
+// This service records sensor and state events in the persistent system event log.
+func WithSelmgr(opts ...selmgr.Option) Option {
+	return &selmgrOption{
+		selmgr: selmgr.New(opts...),
+	}
+}
+
 type sensormonOption struct {
 	sensormon service.Service
 }
@@ -530,6 +548,13 @@ func WithoutSecuritymgr() Option {
 	}
 }
 
+// WithoutSelmgr disables the system event log manager by replacing it with a stub.
+func WithoutSelmgr() Option {
+	return &selmgrOption{
+		selmgr: process.NewStub("selmgr-stub"),
+	}
+}
+
 // WithoutSensormon disables the sensor monitoring service by replacing it with a stub.
 func WithoutSensormon() Option {
 	return &sensormonOption{
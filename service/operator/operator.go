@@ -31,6 +31,7 @@ import (
 	"github.com/u-bmc/u-bmc/service/ledmgr"
 	"github.com/u-bmc/u-bmc/service/powermgr"
 	"github.com/u-bmc/u-bmc/service/securitymgr"
+	"github.com/u-bmc/u-bmc/service/selmgr"
 	"github.com/u-bmc/u-bmc/service/sensormon"
 	"github.com/u-bmc/u-bmc/service/statemgr"
 	telemetrySrv "github.com/u-bmc/u-bmc/service/telemetry"
@@ -81,6 +82,7 @@ func New(opts ...Option) *Operator {
 		Ledmgr:       ledmgr.New(),
 		Powermgr:     powermgr.New(),
 		Securitymgr:  securitymgr.New(),
+		Selmgr:       selmgr.New(),
 		Sensormon:    sensormon.New(),
 		Statemgr:     statemgr.New(),
 		Telemetry:    telemetrySrv.New(),
@@ -48,6 +48,21 @@ const (
 	DefaultPowerOffDelay      = 200 * time.Millisecond
 	DefaultResetDelay         = 100 * time.Millisecond
 	DefaultForceOffDelay      = 4 * time.Second
+
+	// DefaultPowerSamplingInterval is how often the power sensors are read.
+	DefaultPowerSamplingInterval = time.Second
+	// DefaultPowerStatisticsPeriod is the default period power readings are
+	// averaged over for statistics and power limiting.
+	DefaultPowerStatisticsPeriod = 60 * time.Second
+	// DefaultPowerCorrectionTime is the default time the average power may
+	// exceed an active power limit before the exception action is taken.
+	DefaultPowerCorrectionTime = 10 * time.Second
+	// MaxPowerStatisticsPeriod is the longest supported power limit sampling
+	// period.
+	MaxPowerStatisticsPeriod = time.Hour
+	// MaxPowerCorrectionTime is the longest supported power limit correction
+	// time.
+	MaxPowerCorrectionTime = time.Hour
 )
 
 type BackendType string
@@ -154,6 +169,11 @@ type config struct {
 	maxEmergencyAttempts        int
 	emergencyAttemptInterval    time.Duration
 
+	// Power monitoring and capping
+	enablePowerMonitoring bool
+	powerSensors          []string
+	powerSamplingInterval time.Duration
+
 	// Callback support
 	callbacks          PowerCallbacks
 	enableMockBackends bool
@@ -472,6 +492,52 @@ func WithEmergencyAttemptInterval(interval time.Duration) Option {
 	return &emergencyAttemptIntervalOption{interval: interval}
 }
 
+type enablePowerMonitoringOption struct {
+	enable bool
+}
+
+func (o *enablePowerMonitoringOption) apply(c *config) {
+	c.enablePowerMonitoring = o.enable
+}
+
+// WithPowerMonitoring enables or disables power monitoring and power limiting.
+func WithPowerMonitoring(enable bool) Option {
+	return &enablePowerMonitoringOption{enable: enable}
+}
+
+func WithoutPowerMonitoring() Option {
+	return &enablePowerMonitoringOption{enable: false}
+}
+
+type powerSensorsOption struct {
+	sensors []string
+}
+
+func (o *powerSensorsOption) apply(c *config) {
+	c.powerSensors = o.sensors
+}
+
+// WithPowerSensors sets the IDs of the sensormon power sensors whose readings
+// add up to the system power consumption. By default all power sensors
+// reporting watts are added up, which counts power twice on platforms that
+// measure both the input and the rails of a power supply.
+func WithPowerSensors(sensors []string) Option {
+	return &powerSensorsOption{sensors: sensors}
+}
+
+type powerSamplingIntervalOption struct {
+	interval time.Duration
+}
+
+func (o *powerSamplingIntervalOption) apply(c *config) {
+	c.powerSamplingInterval = o.interval
+}
+
+// WithPowerSamplingInterval sets how often the power sensors are read.
+func WithPowerSamplingInterval(interval time.Duration) Option {
+	return &powerSamplingIntervalOption{interval: interval}
+}
+
 type callbacksOption struct {
 	callbacks PowerCallbacks
 }
@@ -565,6 +631,10 @@ func (c *config) Validate() error {
 		}
 	}
 
+	if c.enablePowerMonitoring && c.powerSamplingInterval <= 0 {
+		return fmt.Errorf("%w: power sampling interval must be positive when power monitoring is enabled", ErrInvalidConfiguration)
+	}
+
 	for name, component := range c.components {
 		if err := c.validateComponentConfig(name, component); err != nil {
 			return err
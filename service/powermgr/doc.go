@@ -228,6 +228,28 @@
 //  6. statemgr updates host state to ON or ERROR
 //  7. statemgr triggers LED updates for visual feedback
 //
+// # Power Monitoring and Limiting
+//
+// The service samples the system power consumption every second by adding up
+// the power sensors in watts reported by sensormon. WithPowerSensors restricts
+// the sum to specific sensors, such as the input sensors of the power supplies,
+// so rail sensors are not counted twice. Readings are served on the
+// power.reading endpoint as current, minimum, maximum and average power over
+// the last minute.
+//
+// A power limit is read and changed through the power_limit.info and
+// power_limit.set endpoints. While the limit is active, the average power over
+// its sampling period is compared with the limit. When the limit stays exceeded
+// for longer than the correction time, the exception action is taken:
+//   - NONE: nothing but the published event
+//   - HARD_POWER_OFF: all hosts are forced off
+//   - LOG_EVENT: selmgr records a system event log entry
+//
+// A PowerLimitEvent is published on powermgr.events.power_limit when the
+// limit is exceeded and again when the power drops below the limit. The
+// service has no means to throttle the host itself, so limits are enforced
+// only through the exception action.
+//
 // # Metrics and Observability
 //
 // The service provides comprehensive metrics:
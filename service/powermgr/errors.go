@@ -135,6 +135,12 @@ var (
 	// ErrBMCNotReady indicates the BMC is not ready for power operations.
 	ErrBMCNotReady = errors.New("BMC not ready")
 
+	// Power monitoring errors
+	// ErrNoPowerReading indicates no power sensor reading is available yet.
+	ErrNoPowerReading = errors.New("no power reading available")
+	// ErrInvalidPowerLimit indicates a power limit setting is out of range.
+	ErrInvalidPowerLimit = errors.New("invalid power limit")
+
 	// Validation errors
 	// ErrInvalidComponentID indicates an invalid component ID was provided.
 	ErrInvalidComponentID = errors.New("invalid component ID")
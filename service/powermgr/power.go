@@ -0,0 +1,414 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package powermgr
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go/micro"
+	v1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+	"github.com/u-bmc/u-bmc/pkg/ipc"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// powerSample is a single system power consumption reading.
+type powerSample struct {
+	at    time.Time
+	watts float64
+}
+
+// powerMonitor keeps the recent power readings and the power limit. The
+// power limit is enforced by comparing the average power over the limit's
+// sampling period with the limit; when the limit stays exceeded for longer
+// than the correction time, the exception action is taken once until the
+// power drops below the limit again.
+type powerMonitor struct {
+	mu             sync.Mutex
+	limit          *v1alpha1.PowerLimit
+	samples        []powerSample
+	sensorIDs      []string
+	exceededSince  time.Time
+	exceptionTaken bool
+}
+
+func newPowerMonitor() *powerMonitor {
+	return &powerMonitor{
+		limit: &v1alpha1.PowerLimit{
+			ExceptionAction: v1alpha1.PowerLimitExceptionAction_POWER_LIMIT_EXCEPTION_ACTION_NONE,
+			CorrectionTime:  durationpb.New(DefaultPowerCorrectionTime),
+			SamplingPeriod:  durationpb.New(DefaultPowerStatisticsPeriod),
+		},
+	}
+}
+
+// average returns the average of the samples taken within period before now.
+func (m *powerMonitor) average(now time.Time, period time.Duration) float64 {
+	var sum float64
+	var n int
+	for _, s := range m.samples {
+		if now.Sub(s.at) <= period {
+			sum += s.watts
+			n++
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+	return sum / float64(n)
+}
+
+// record adds a reading and evaluates the power limit. It returns an event when
+// the limit has been exceeded for longer than the correction time or when the
+// power drops below the limit after the exception action was taken.
+func (m *powerMonitor) record(now time.Time, watts float64, sensorIDs []string) *v1alpha1.PowerLimitEvent {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	samplingPeriod := m.limit.GetSamplingPeriod().AsDuration()
+	window := max(DefaultPowerStatisticsPeriod, samplingPeriod)
+
+	m.samples = append(m.samples, powerSample{at: now, watts: watts})
+	m.samples = slices.DeleteFunc(m.samples, func(s powerSample) bool {
+		return now.Sub(s.at) > window
+	})
+	m.sensorIDs = sensorIDs
+
+	if !m.limit.GetActive() {
+		m.exceededSince = time.Time{}
+		m.exceptionTaken = false
+		return nil
+	}
+
+	avg := m.average(now, samplingPeriod)
+	if avg > float64(m.limit.GetLimitWatts()) {
+		if m.exceededSince.IsZero() {
+			m.exceededSince = now
+		}
+		if m.exceptionTaken || now.Sub(m.exceededSince) < m.limit.GetCorrectionTime().AsDuration() {
+			return nil
+		}
+		m.exceptionTaken = true
+		return m.event(now, avg, true)
+	}
+
+	m.exceededSince = time.Time{}
+	if !m.exceptionTaken {
+		return nil
+	}
+	m.exceptionTaken = false
+	return m.event(now, avg, false)
+}
+
+func (m *powerMonitor) event(now time.Time, watts float64, exceeded bool) *v1alpha1.PowerLimitEvent {
+	return &v1alpha1.PowerLimitEvent{
+		Limit:        m.limit.CloneVT(),
+		ReadingWatts: watts,
+		Exceeded:     exceeded,
+		Timestamp:    timestamppb.New(now),
+	}
+}
+
+// reading returns the power statistics over the default statistics period.
+func (m *powerMonitor) reading(now time.Time) (*v1alpha1.PowerReading, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.samples) == 0 {
+		return nil, false, ErrNoPowerReading
+	}
+
+	latest := m.samples[len(m.samples)-1]
+	reading := &v1alpha1.PowerReading{
+		CurrentWatts:     latest.watts,
+		MinimumWatts:     latest.watts,
+		MaximumWatts:     latest.watts,
+		Timestamp:        timestamppb.New(latest.at),
+		StatisticsPeriod: durationpb.New(DefaultPowerStatisticsPeriod),
+		SensorIds:        slices.Clone(m.sensorIDs),
+	}
+
+	var sum float64
+	var n int
+	for _, s := range m.samples {
+		if now.Sub(s.at) > DefaultPowerStatisticsPeriod {
+			continue
+		}
+		reading.MinimumWatts = min(reading.MinimumWatts, s.watts)
+		reading.MaximumWatts = max(reading.MaximumWatts, s.watts)
+		sum += s.watts
+		n++
+	}
+	reading.AverageWatts = latest.watts
+	if n > 0 {
+		reading.AverageWatts = sum / float64(n)
+	}
+
+	return reading, m.limit.GetActive(), nil
+}
+
+// getLimit returns a copy of the power limit.
+func (m *powerMonitor) getLimit() *v1alpha1.PowerLimit {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.limit.CloneVT()
+}
+
+// setLimit applies the fields present in req to the power limit. Changing the
+// limit restarts the correction time.
+func (m *powerMonitor) setLimit(req *v1alpha1.SetPowerLimitRequest, minSamplingPeriod time.Duration) (*v1alpha1.PowerLimit, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	limit := m.limit.CloneVT()
+	if req.LimitWatts != nil {
+		if req.GetLimitWatts() == 0 {
+			return nil, fmt.Errorf("%w: limit must be positive", ErrInvalidPowerLimit)
+		}
+		limit.LimitWatts = req.GetLimitWatts()
+	}
+	if req.ExceptionAction != nil {
+		switch req.GetExceptionAction() {
+		case v1alpha1.PowerLimitExceptionAction_POWER_LIMIT_EXCEPTION_ACTION_NONE,
+			v1alpha1.PowerLimitExceptionAction_POWER_LIMIT_EXCEPTION_ACTION_HARD_POWER_OFF,
+			v1alpha1.PowerLimitExceptionAction_POWER_LIMIT_EXCEPTION_ACTION_LOG_EVENT:
+			limit.ExceptionAction = req.GetExceptionAction()
+		default:
+			return nil, fmt.Errorf("%w: unsupported exception action %s", ErrInvalidPowerLimit, req.GetExceptionAction())
+		}
+	}
+	if req.CorrectionTime != nil {
+		d := req.GetCorrectionTime().AsDuration()
+		if d <= 0 || d > MaxPowerCorrectionTime {
+			return nil, fmt.Errorf("%w: correction time %s out of range", ErrInvalidPowerLimit, d)
+		}
+		limit.CorrectionTime = durationpb.New(d)
+	}
+	if req.SamplingPeriod != nil {
+		d := req.GetSamplingPeriod().AsDuration()
+		if d < minSamplingPeriod || d > MaxPowerStatisticsPeriod {
+			return nil, fmt.Errorf("%w: sampling period %s out of range", ErrInvalidPowerLimit, d)
+		}
+		limit.SamplingPeriod = durationpb.New(d)
+	}
+	if req.Active != nil {
+		limit.Active = req.GetActive()
+	}
+	if limit.GetActive() && limit.GetLimitWatts() == 0 {
+		return nil, fmt.Errorf("%w: cannot activate a power limit of 0 W", ErrInvalidPowerLimit)
+	}
+
+	m.limit = limit
+	m.exceededSince = time.Time{}
+	m.exceptionTaken = false
+
+	return limit.CloneVT(), nil
+}
+
+// monitorPower samples the system power consumption until ctx is canceled.
+func (p *PowerMgr) monitorPower(ctx context.Context) {
+	ticker := time.NewTicker(p.config.powerSamplingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.samplePower(ctx)
+		}
+	}
+}
+
+func (p *PowerMgr) samplePower(ctx context.Context) {
+	watts, sensorIDs, err := p.readPower(ctx)
+	if err != nil {
+		p.logger.DebugContext(ctx, "Failed to read system power", "error", err)
+		return
+	}
+
+	if event := p.power.record(time.Now(), watts, sensorIDs); event != nil {
+		p.handlePowerLimitEvent(ctx, event)
+	}
+}
+
+// readPower returns the sum of the power sensors reported by sensormon.
+func (p *PowerMgr) readPower(ctx context.Context) (float64, []string, error) {
+	data, err := (&v1alpha1.ListSensorsRequest{}).MarshalVT()
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.config.powerSamplingInterval)
+	defer cancel()
+
+	msg, err := p.nc.RequestWithContext(ctx, ipc.SubjectSensorList, data)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to list sensors: %w", err)
+	}
+	if desc := msg.Header.Get(micro.ErrorHeader); desc != "" {
+		return 0, nil, fmt.Errorf("failed to list sensors: %s", desc)
+	}
+
+	var resp v1alpha1.ListSensorsResponse
+	if err := resp.UnmarshalVT(msg.Data); err != nil {
+		return 0, nil, fmt.Errorf("failed to unmarshal sensor list: %w", err)
+	}
+
+	var watts float64
+	var ids []string
+	for _, sensor := range resp.GetSensor() {
+		if sensor.GetContext() != v1alpha1.SensorContext_SENSOR_CONTEXT_POWER ||
+			sensor.GetUnit() != v1alpha1.SensorUnit_SENSOR_UNIT_WATTS ||
+			sensor.GetAnalogReading() == nil {
+			continue
+		}
+		if len(p.config.powerSensors) > 0 && !slices.Contains(p.config.powerSensors, sensor.GetId()) {
+			continue
+		}
+		watts += sensor.GetAnalogReading().GetValue()
+		ids = append(ids, sensor.GetId())
+	}
+	if len(ids) == 0 {
+		return 0, nil, ErrNoPowerReading
+	}
+
+	return watts, ids, nil
+}
+
+// handlePowerLimitEvent takes the exception action of an exceeded power limit
+// and publishes the event for the event log.
+func (p *PowerMgr) handlePowerLimitEvent(ctx context.Context, event *v1alpha1.PowerLimitEvent) {
+	p.logger.WarnContext(ctx, "Power limit state changed",
+		"exceeded", event.GetExceeded(),
+		"reading_watts", event.GetReadingWatts(),
+		"limit_watts", event.GetLimit().GetLimitWatts(),
+		"action", event.GetLimit().GetExceptionAction())
+
+	if event.GetExceeded() &&
+		event.GetLimit().GetExceptionAction() == v1alpha1.PowerLimitExceptionAction_POWER_LIMIT_EXCEPTION_ACTION_HARD_POWER_OFF {
+		go p.powerOffHosts(context.WithoutCancel(ctx))
+	}
+
+	data, err := event.MarshalVT()
+	if err != nil {
+		p.logger.ErrorContext(ctx, "Failed to marshal power limit event", "error", err)
+		return
+	}
+	if err := p.nc.Publish(ipc.SubjectPowerLimitEvent, data); err != nil {
+		p.logger.WarnContext(ctx, "Failed to publish power limit event", "error", err)
+	}
+}
+
+// powerOffHosts forces all hosts off as the power limit exception action.
+func (p *PowerMgr) powerOffHosts(ctx context.Context) {
+	var hosts []string
+	for name, component := range p.config.components {
+		if component.Type == "host" {
+			hosts = append(hosts, name)
+		}
+	}
+	slices.Sort(hosts)
+
+	for _, name := range hosts {
+		err := p.shutdownComponentWithRetry(ctx, name, true)
+		if err != nil {
+			p.logger.ErrorContext(ctx, "Failed to power off host after power limit exception",
+				"component", name,
+				"error", err)
+		}
+		p.reportStateChange(ctx, name, "force_off", err == nil)
+	}
+}
+
+// registerPowerEndpoints registers the power reading and power limit endpoints.
+func (p *PowerMgr) registerPowerEndpoints(ctx context.Context, groups map[string]micro.Group) error {
+	if err := ipc.RegisterEndpointWithGroupCache(p.microService, ipc.SubjectPowerReading,
+		micro.HandlerFunc(p.createRequestHandler(ctx, p.handleGetPowerReading)), groups); err != nil {
+		return fmt.Errorf("failed to register power reading endpoint: %w", err)
+	}
+	if err := ipc.RegisterEndpointWithGroupCache(p.microService, ipc.SubjectPowerLimitInfo,
+		micro.HandlerFunc(p.createRequestHandler(ctx, p.handleGetPowerLimit)), groups); err != nil {
+		return fmt.Errorf("failed to register power limit info endpoint: %w", err)
+	}
+	if err := ipc.RegisterEndpointWithGroupCache(p.microService, ipc.SubjectPowerLimitSet,
+		micro.HandlerFunc(p.createRequestHandler(ctx, p.handleSetPowerLimit)), groups); err != nil {
+		return fmt.Errorf("failed to register power limit set endpoint: %w", err)
+	}
+	return nil
+}
+
+type vtMessage interface {
+	MarshalVT() ([]byte, error)
+}
+
+// respond sends a protobuf response and logs failures.
+func (p *PowerMgr) respond(ctx context.Context, req micro.Request, resp vtMessage) {
+	data, err := resp.MarshalVT()
+	if err != nil {
+		p.logger.ErrorContext(ctx, "Failed to marshal response", "subject", req.Subject(), "error", err)
+		_ = req.Error("500", "failed to marshal response", nil)
+		return
+	}
+
+	if err := req.Respond(data); err != nil {
+		p.logger.ErrorContext(ctx, "Failed to send response", "subject", req.Subject(), "error", err)
+	}
+}
+
+func (p *PowerMgr) handleGetPowerReading(ctx context.Context, req micro.Request) {
+	var request v1alpha1.GetPowerReadingRequest
+	if err := request.UnmarshalVT(req.Data()); err != nil {
+		_ = req.Error("400", "invalid request format", nil)
+		return
+	}
+
+	reading, active, err := p.power.reading(time.Now())
+	if err != nil {
+		_ = req.Error("404", err.Error(), nil)
+		return
+	}
+
+	p.respond(ctx, req, &v1alpha1.GetPowerReadingResponse{
+		Reading:     reading,
+		LimitActive: active,
+	})
+}
+
+func (p *PowerMgr) handleGetPowerLimit(ctx context.Context, req micro.Request) {
+	var request v1alpha1.GetPowerLimitRequest
+	if err := request.UnmarshalVT(req.Data()); err != nil {
+		_ = req.Error("400", "invalid request format", nil)
+		return
+	}
+
+	p.respond(ctx, req, &v1alpha1.GetPowerLimitResponse{Limit: p.power.getLimit()})
+}
+
+func (p *PowerMgr) handleSetPowerLimit(ctx context.Context, req micro.Request) {
+	var request v1alpha1.SetPowerLimitRequest
+	if err := request.UnmarshalVT(req.Data()); err != nil {
+		_ = req.Error("400", "invalid request format", nil)
+		return
+	}
+
+	limit, err := p.power.setLimit(&request, p.config.powerSamplingInterval)
+	if err != nil {
+		_ = req.Error("400", err.Error(), nil)
+		return
+	}
+
+	p.logger.InfoContext(ctx, "Power limit updated",
+		"limit_watts", limit.GetLimitWatts(),
+		"action", limit.GetExceptionAction(),
+		"correction_time", limit.GetCorrectionTime().AsDuration(),
+		"sampling_period", limit.GetSamplingPeriod().AsDuration(),
+		"active", limit.GetActive())
+
+	p.respond(ctx, req, &v1alpha1.SetPowerLimitResponse{Limit: limit})
+}
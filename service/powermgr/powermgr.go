@@ -587,6 +587,7 @@ type PowerMgr struct {
 	cancel       context.CancelFunc
 	started      bool
 	mu           sync.RWMutex
+	power        *powerMonitor
 
 	// Metrics
 	powerOperationsTotal   metric.Int64Counter
@@ -620,6 +621,8 @@ func New(opts ...Option) *PowerMgr {
 		shutdownComponents:          []string{},
 		maxEmergencyAttempts:        3,
 		emergencyAttemptInterval:    30 * time.Second,
+		enablePowerMonitoring:       true,
+		powerSamplingInterval:       DefaultPowerSamplingInterval,
 	}
 
 	for _, opt := range opts {
@@ -631,6 +634,7 @@ func New(opts ...Option) *PowerMgr {
 	return &PowerMgr{
 		config:   cfg,
 		backends: make(map[BackendType]PowerBackend),
+		power:    newPowerMonitor(),
 	}
 }
 
@@ -714,6 +718,10 @@ func (p *PowerMgr) Run(ctx context.Context, ipcConn nats.InProcessConnProvider)
 		return err
 	}
 
+	if p.config.enablePowerMonitoring {
+		go p.monitorPower(ctx)
+	}
+
 	p.logger.InfoContext(ctx, "Power manager service started successfully",
 		"endpoints_registered", true,
 		"backends_initialized", len(p.backends))
@@ -833,6 +841,12 @@ func (p *PowerMgr) registerEndpoints(ctx context.Context) error {
 		return fmt.Errorf("failed to register power status endpoint: %w", err)
 	}
 
+	if p.config.enablePowerMonitoring {
+		if err := p.registerPowerEndpoints(ctx, groups); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,265 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package selmgr
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/u-bmc/u-bmc/pkg/threshold"
+)
+
+// Default configuration constants.
+const (
+	DefaultServiceName        = "selmgr"
+	DefaultServiceDescription = "System event log manager for BMC systems"
+	DefaultServiceVersion     = "1.0.0"
+	DefaultStreamName         = "SEL"
+	DefaultStreamSubject      = "selmgr.record"
+	DefaultMaxEntries         = 1024
+	DefaultOverflowPolicy     = OverflowPolicyWrap
+	DefaultSensorEventSubject = threshold.Subject
+	DefaultStateEventSubject  = "statemgr.event.>"
+	DefaultPowerEventSubject  = "powermgr.events.power_limit"
+	DefaultViolationTimeout   = threshold.DefaultTimeout
+	DefaultRequestTimeout     = 5 * time.Second
+
+	// maxEntriesLimit keeps record IDs derived from stream sequences unique.
+	// 0x0000 and 0xFFFF are reserved by Get SEL Entry.
+	maxEntriesLimit = 0xFFFE
+)
+
+// OverflowPolicy defines what happens when an event is logged to a full event log.
+type OverflowPolicy string
+
+const (
+	// OverflowPolicyWrap discards the oldest entry to make room for the new one.
+	OverflowPolicyWrap OverflowPolicy = "wrap"
+	// OverflowPolicyStop keeps the existing entries and drops the new event
+	// until the event log is cleared.
+	OverflowPolicyStop OverflowPolicy = "stop"
+)
+
+// config holds the configuration for the system event log manager.
+type config struct {
+	name        string
+	description string
+	version     string
+
+	// Persistence configuration
+	streamName     string
+	streamSubject  string
+	maxEntries     int
+	overflowPolicy OverflowPolicy
+
+	// Event sources
+	sensorEventSubject string
+	stateEventSubject  string
+	powerEventSubject  string
+	violationTimeout   time.Duration
+	requestTimeout     time.Duration
+}
+
+// Option represents a configuration option for the system event log manager.
+type Option interface {
+	apply(*config)
+}
+
+type nameOption struct {
+	name string
+}
+
+func (o *nameOption) apply(c *config) {
+	c.name = o.name
+}
+
+// WithServiceName sets the service name.
+func WithServiceName(name string) Option {
+	return &nameOption{name: name}
+}
+
+type descriptionOption struct {
+	description string
+}
+
+func (o *descriptionOption) apply(c *config) {
+	c.description = o.description
+}
+
+// WithServiceDescription sets the service description.
+func WithServiceDescription(description string) Option {
+	return &descriptionOption{description: description}
+}
+
+type versionOption struct {
+	version string
+}
+
+func (o *versionOption) apply(c *config) {
+	c.version = o.version
+}
+
+// WithServiceVersion sets the service version.
+func WithServiceVersion(version string) Option {
+	return &versionOption{version: version}
+}
+
+type streamNameOption struct {
+	name string
+}
+
+func (o *streamNameOption) apply(c *config) {
+	c.streamName = o.name
+}
+
+// WithStreamName sets the name of the JetStream stream holding the event log.
+func WithStreamName(name string) Option {
+	return &streamNameOption{name: name}
+}
+
+type streamSubjectOption struct {
+	subject string
+}
+
+func (o *streamSubjectOption) apply(c *config) {
+	c.streamSubject = o.subject
+}
+
+// WithStreamSubject sets the subject prefix entries are stored under.
+func WithStreamSubject(subject string) Option {
+	return &streamSubjectOption{subject: subject}
+}
+
+type maxEntriesOption struct {
+	entries int
+}
+
+func (o *maxEntriesOption) apply(c *config) {
+	c.maxEntries = o.entries
+}
+
+// WithMaxEntries sets the maximum number of entries kept in the event log.
+func WithMaxEntries(entries int) Option {
+	return &maxEntriesOption{entries: entries}
+}
+
+type overflowPolicyOption struct {
+	policy OverflowPolicy
+}
+
+func (o *overflowPolicyOption) apply(c *config) {
+	c.overflowPolicy = o.policy
+}
+
+// WithOverflowPolicy sets the behavior when logging to a full event log.
+func WithOverflowPolicy(policy OverflowPolicy) Option {
+	return &overflowPolicyOption{policy: policy}
+}
+
+type sensorEventSubjectOption struct {
+	subject string
+}
+
+func (o *sensorEventSubjectOption) apply(c *config) {
+	c.sensorEventSubject = o.subject
+}
+
+// WithSensorEventSubject sets the subject threshold violations are received on.
+// An empty subject disables sensor events.
+func WithSensorEventSubject(subject string) Option {
+	return &sensorEventSubjectOption{subject: subject}
+}
+
+type stateEventSubjectOption struct {
+	subject string
+}
+
+func (o *stateEventSubjectOption) apply(c *config) {
+	c.stateEventSubject = o.subject
+}
+
+// WithStateEventSubject sets the subject state transitions are received on.
+// An empty subject disables state events.
+func WithStateEventSubject(subject string) Option {
+	return &stateEventSubjectOption{subject: subject}
+}
+
+type powerEventSubjectOption struct {
+	subject string
+}
+
+func (o *powerEventSubjectOption) apply(c *config) {
+	c.powerEventSubject = o.subject
+}
+
+// WithPowerEventSubject sets the subject power limit events are received on.
+// An empty subject disables power limit events.
+func WithPowerEventSubject(subject string) Option {
+	return &powerEventSubjectOption{subject: subject}
+}
+
+type violationTimeoutOption struct {
+	timeout time.Duration
+}
+
+func (o *violationTimeoutOption) apply(c *config) {
+	c.violationTimeout = o.timeout
+}
+
+// WithViolationTimeout sets how long a threshold violation stays asserted
+// without being reported again before a deassertion event is logged.
+func WithViolationTimeout(timeout time.Duration) Option {
+	return &violationTimeoutOption{timeout: timeout}
+}
+
+type requestTimeoutOption struct {
+	timeout time.Duration
+}
+
+func (o *requestTimeoutOption) apply(c *config) {
+	c.requestTimeout = o.timeout
+}
+
+// WithRequestTimeout sets the timeout for JetStream operations.
+func WithRequestTimeout(timeout time.Duration) Option {
+	return &requestTimeoutOption{timeout: timeout}
+}
+
+// Validate checks that the configuration is usable.
+func (c *config) Validate() error {
+	if c.name == "" {
+		return fmt.Errorf("service name cannot be empty")
+	}
+
+	if c.version == "" {
+		return fmt.Errorf("service version cannot be empty")
+	}
+
+	if c.streamName == "" {
+		return fmt.Errorf("stream name cannot be empty")
+	}
+
+	if c.streamSubject == "" {
+		return fmt.Errorf("stream subject cannot be empty")
+	}
+
+	if c.maxEntries <= 0 || c.maxEntries > maxEntriesLimit {
+		return fmt.Errorf("maximum entries must be between 1 and %d", maxEntriesLimit)
+	}
+
+	switch c.overflowPolicy {
+	case OverflowPolicyWrap, OverflowPolicyStop:
+	default:
+		return fmt.Errorf("unknown overflow policy %q", c.overflowPolicy)
+	}
+
+	if c.violationTimeout <= 0 {
+		return fmt.Errorf("violation timeout must be positive")
+	}
+
+	if c.requestTimeout <= 0 {
+		return fmt.Errorf("request timeout must be positive")
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package selmgr provides the system event log (SEL) manager for BMC systems.
+// It turns the events other services broadcast over NATS into persistent event
+// log entries and serves the log to the IPMI server, the web server and any
+// other service over NATS IPC.
+//
+// # Overview
+//
+// Services such as sensormon and statemgr publish events as fire-and-forget
+// NATS messages. The SEL manager subscribes to them and records each relevant
+// event as a SystemEventLogEntry, which carries both a descriptive form
+// (severity, source, origin, message, readings) and the equivalent 16-byte
+// IPMI system event record built with the pkg/sel package.
+//
+// Entries are stored in a JetStream stream limited to a configurable number of
+// entries, so the log survives restarts of the BMC software.
+//
+// # Event Sources
+//
+// Threshold violations are received on sensormon.events.threshold.* and
+// recorded as threshold events. sensormon repeats a violation on every
+// threshold check, so only the first report is logged as an assertion. When a
+// violation is not reported again within the violation timeout, a deassertion
+// is logged. Threshold events are only broadcast when sensormon is configured
+// with WithBroadcastSensorReadings(true).
+//
+// State transitions are received on statemgr.event.<component>.transition and
+// recorded as sensor-specific events:
+//   - Host power on, off and error map to System ACPI Power State (0x22)
+//   - Chassis power and failure states map to Power Unit (0x09)
+//   - BMC readiness and errors map to Management Subsystem Health (0x28)
+//
+// Transitional states and transitions that do not change the status are not
+// logged.
+//
+// Power limit events are received on powermgr.events.power_limit. Exceptions
+// of power limits whose exception action is LOG_EVENT or HARD_POWER_OFF are
+// recorded as upper critical threshold events of the "system_power" origin,
+// with a deassertion once the power drops below the limit again.
+//
+// The SEL records use the BMC generator ID (0x0020) and leave the sensor
+// number unresolved (0xFF). Sensor numbers are owned by the SDR repository of
+// the IPMI server, which resolves them for threshold events by sensor ID when
+// serving the log.
+//
+// # Record IDs and Overflow
+//
+// Record IDs are derived from the stream sequence of an entry and cycle
+// through 0x0001-0xFFFE, as required by Get SEL Entry. They remain stable for
+// the lifetime of an entry but are not reused after the log is cleared.
+//
+// When the log is full, the overflow policy decides what happens to new
+// events:
+//   - OverflowPolicyWrap discards the oldest entry (default)
+//   - OverflowPolicyStop drops new events until the log is cleared
+//
+// In both cases the overflow flag reported by the info endpoint is set until
+// the log is cleared. The overflow flag and the time of the last erase are
+// kept in memory only.
+//
+// # IPC Endpoints
+//
+// The service exposes the following NATS endpoints:
+//   - sel.info - Entry count, capacity, timestamps and overflow flag
+//   - sel.entry - A single entry by record ID, 0x0000 for the first and 0xFFFF for the last
+//   - sel.list - The most recent entries, optionally filtered by minimum severity
+//   - sel.clear - Erase all entries
+//
+// # Usage
+//
+//	svc := selmgr.New(
+//		selmgr.WithMaxEntries(2048),
+//		selmgr.WithOverflowPolicy(selmgr.OverflowPolicyStop),
+//		selmgr.WithViolationTimeout(time.Minute),
+//	)
+//
+//	if err := svc.Run(ctx, ipcConn); err != nil {
+//		log.Fatal(err)
+//	}
+//
+// Listing the critical entries via NATS:
+//
+//	severity := v1alpha1.EventSeverity_EVENT_SEVERITY_CRITICAL
+//	req := &v1alpha1.ListSystemEventLogEntriesRequest{MinSeverity: &severity}
+//	data, _ := req.MarshalVT()
+//	msg, err := nc.Request(ipc.SubjectSELList, data, 5*time.Second)
+package selmgr
@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package selmgr
+
+import "errors"
+
+var (
+	// ErrInvalidConfiguration indicates that the service configuration is invalid.
+	ErrInvalidConfiguration = errors.New("invalid selmgr configuration")
+	// ErrNATSConnectionFailed indicates that the NATS connection could not be established.
+	ErrNATSConnectionFailed = errors.New("failed to connect to NATS")
+	// ErrJetStreamInitFailed indicates that JetStream initialization failed.
+	ErrJetStreamInitFailed = errors.New("failed to initialize JetStream")
+	// ErrStreamCreationFailed indicates that the event log stream could not be created.
+	ErrStreamCreationFailed = errors.New("failed to create event log stream")
+	// ErrMicroServiceCreationFailed indicates that micro service creation failed.
+	ErrMicroServiceCreationFailed = errors.New("failed to create micro service")
+	// ErrEndpointRegistrationFailed indicates that endpoint registration failed.
+	ErrEndpointRegistrationFailed = errors.New("failed to register endpoint")
+	// ErrSubscriptionFailed indicates that subscribing to an event source failed.
+	ErrSubscriptionFailed = errors.New("failed to subscribe to event source")
+	// ErrEventLogFull indicates that the event log is full and the overflow policy drops new events.
+	ErrEventLogFull = errors.New("system event log full")
+	// ErrEntryNotFound indicates that the requested event log entry does not exist.
+	ErrEntryNotFound = errors.New("event log entry not found")
+	// ErrStorageFailed indicates that reading or writing the event log stream failed.
+	ErrStorageFailed = errors.New("event log storage failed")
+	// ErrUnmarshalingFailed indicates that a request or stored entry could not be decoded.
+	ErrUnmarshalingFailed = errors.New("unmarshaling failed")
+	// ErrMarshalingFailed indicates that a response or entry could not be encoded.
+	ErrMarshalingFailed = errors.New("marshaling failed")
+	// ErrInvalidEvent indicates that a received event could not be converted to an entry.
+	ErrInvalidEvent = errors.New("invalid event")
+)
@@ -0,0 +1,388 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package selmgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	v1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+	"github.com/u-bmc/u-bmc/pkg/sel"
+	"github.com/u-bmc/u-bmc/pkg/threshold"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// thresholdOffset maps a sensormon violation type to its threshold event
+// offset, severity and a human-readable description.
+func thresholdOffset(violationType string) (uint8, v1alpha1.EventSeverity, string, bool) {
+	switch violationType {
+	case threshold.UpperCritical:
+		return sel.OffsetUpperCriticalGoingHigh, v1alpha1.EventSeverity_EVENT_SEVERITY_CRITICAL, "upper critical", true
+	case threshold.UpperWarning:
+		return sel.OffsetUpperNonCriticalGoingHigh, v1alpha1.EventSeverity_EVENT_SEVERITY_WARNING, "upper non-critical", true
+	case threshold.LowerCritical:
+		return sel.OffsetLowerCriticalGoingLow, v1alpha1.EventSeverity_EVENT_SEVERITY_CRITICAL, "lower critical", true
+	case threshold.LowerWarning:
+		return sel.OffsetLowerNonCriticalGoingLow, v1alpha1.EventSeverity_EVENT_SEVERITY_WARNING, "lower non-critical", true
+	default:
+		return 0, v1alpha1.EventSeverity_EVENT_SEVERITY_UNSPECIFIED, "", false
+	}
+}
+
+// subscribeEvents subscribes to the configured event sources.
+func (s *SELMgr) subscribeEvents(ctx context.Context) error {
+	sources := []struct {
+		subject string
+		handler func(context.Context, *nats.Msg)
+	}{
+		{s.config.sensorEventSubject, s.handleThresholdEvent},
+		{s.config.stateEventSubject, s.handleStateEvent},
+		{s.config.powerEventSubject, s.handlePowerLimitEvent},
+	}
+
+	for _, source := range sources {
+		if source.subject == "" {
+			continue
+		}
+
+		handler := source.handler
+		sub, err := s.nc.Subscribe(source.subject, func(msg *nats.Msg) {
+			handler(ctx, msg)
+		})
+		if err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrSubscriptionFailed, source.subject, err)
+		}
+		s.subs = append(s.subs, sub)
+
+		s.logger.InfoContext(ctx, "Subscribed to event source", "subject", source.subject)
+	}
+
+	return nil
+}
+
+// record appends an entry to the event log and logs failures.
+func (s *SELMgr) record(ctx context.Context, entry *v1alpha1.SystemEventLogEntry, rec sel.Record) {
+	rec.Timestamp = entry.GetTimestamp().AsTime()
+	data, err := rec.MarshalBinary()
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to encode SEL record", "origin", entry.GetOrigin(), "error", err)
+		return
+	}
+	entry.IpmiRecord = data
+
+	if err := s.appendEntry(ctx, entry); err != nil {
+		s.logger.WarnContext(ctx, "Failed to record system event",
+			"origin", entry.GetOrigin(),
+			"message", entry.GetMessage(),
+			"error", err)
+		return
+	}
+
+	s.logger.DebugContext(ctx, "Recorded system event",
+		"origin", entry.GetOrigin(),
+		"severity", entry.GetSeverity().String(),
+		"message", entry.GetMessage())
+}
+
+// handleThresholdEvent records the first report of a threshold violation.
+// sensormon repeats violations on every threshold check, so repeated reports
+// only keep the violation asserted until it expires.
+func (s *SELMgr) handleThresholdEvent(ctx context.Context, msg *nats.Msg) {
+	event, err := threshold.ParseEvent(msg.Data)
+	switch {
+	case errors.Is(err, threshold.ErrUnknownViolation):
+		s.logger.WarnContext(ctx, "Ignoring unknown threshold violation type", "subject", msg.Subject, "error", err)
+		return
+	case err != nil:
+		s.logger.WarnContext(ctx, "Ignoring malformed threshold event", "subject", msg.Subject, "error", err)
+		return
+	}
+
+	now := time.Now()
+	if !s.violations.Report(event, now) {
+		return
+	}
+
+	timestamp, ok := event.Time()
+	if !ok {
+		timestamp = now
+	}
+
+	entry, rec := thresholdEntry(event, false, timestamp)
+	s.record(ctx, entry, rec)
+}
+
+// deassertThreshold logs the deassertion of a threshold violation that is no
+// longer reported by sensormon.
+func (s *SELMgr) deassertThreshold(ctx context.Context, event threshold.Event, now time.Time) {
+	entry, rec := thresholdEntry(event, true, now)
+	s.record(ctx, entry, rec)
+}
+
+// thresholdEntry converts a threshold violation into an event log entry. The
+// SEL record leaves the sensor number unresolved since sensor numbers are
+// assigned by the SDR repository of the IPMI server.
+func thresholdEntry(event threshold.Event, deassertion bool, timestamp time.Time) (*v1alpha1.SystemEventLogEntry, sel.Record) {
+	offset, severity, description, _ := thresholdOffset(event.ViolationType)
+
+	name := event.Name()
+	message := fmt.Sprintf("%s crossed %s threshold: reading %g, threshold %g",
+		name, description, event.CurrentValue, event.ThresholdValue)
+	if deassertion {
+		severity = v1alpha1.EventSeverity_EVENT_SEVERITY_OK
+		message = fmt.Sprintf("%s returned within %s threshold %g", name, description, event.ThresholdValue)
+	}
+
+	entry := &v1alpha1.SystemEventLogEntry{
+		Timestamp: timestamppb.New(timestamp),
+		Severity:  severity,
+		Source:    v1alpha1.EventSource_EVENT_SOURCE_SENSOR,
+		Origin:    event.SensorID,
+		Message:   message,
+		SensorId:  &event.SensorID,
+		Threshold: &event.ThresholdValue,
+	}
+	if !deassertion {
+		entry.Reading = &event.CurrentValue
+	}
+
+	return entry, sel.ThresholdEvent(sel.SensorTypeOtherUnits, sel.SensorNumberUnspecified, offset, deassertion)
+}
+
+// handlePowerLimitEvent records power limit exceptions published by powermgr
+// for power limits whose exception action is logging or powering off.
+func (s *SELMgr) handlePowerLimitEvent(ctx context.Context, msg *nats.Msg) {
+	var event v1alpha1.PowerLimitEvent
+	if err := event.UnmarshalVT(msg.Data); err != nil {
+		s.logger.WarnContext(ctx, "Ignoring malformed power limit event", "subject", msg.Subject, "error", err)
+		return
+	}
+
+	entry, rec, ok := powerLimitEntry(&event)
+	if !ok {
+		return
+	}
+
+	s.record(ctx, entry, rec)
+}
+
+// powerLimitEntry converts a power limit event into an event log entry.
+func powerLimitEntry(event *v1alpha1.PowerLimitEvent) (*v1alpha1.SystemEventLogEntry, sel.Record, bool) {
+	var severity v1alpha1.EventSeverity
+	switch event.GetLimit().GetExceptionAction() {
+	case v1alpha1.PowerLimitExceptionAction_POWER_LIMIT_EXCEPTION_ACTION_HARD_POWER_OFF:
+		severity = v1alpha1.EventSeverity_EVENT_SEVERITY_CRITICAL
+	case v1alpha1.PowerLimitExceptionAction_POWER_LIMIT_EXCEPTION_ACTION_LOG_EVENT:
+		severity = v1alpha1.EventSeverity_EVENT_SEVERITY_WARNING
+	default:
+		return nil, sel.Record{}, false
+	}
+
+	origin := "system_power"
+	reading := event.GetReadingWatts()
+	limit := float64(event.GetLimit().GetLimitWatts())
+
+	message := fmt.Sprintf("System power exceeded power limit: reading %g W, limit %g W", reading, limit)
+	if event.GetLimit().GetExceptionAction() == v1alpha1.PowerLimitExceptionAction_POWER_LIMIT_EXCEPTION_ACTION_HARD_POWER_OFF {
+		message += ", powering off hosts"
+	}
+	if !event.GetExceeded() {
+		severity = v1alpha1.EventSeverity_EVENT_SEVERITY_OK
+		message = fmt.Sprintf("System power returned within power limit %g W", limit)
+	}
+
+	timestamp := time.Now()
+	if event.GetTimestamp() != nil {
+		timestamp = event.GetTimestamp().AsTime()
+	}
+
+	entry := &v1alpha1.SystemEventLogEntry{
+		Timestamp: timestamppb.New(timestamp),
+		Severity:  severity,
+		Source:    v1alpha1.EventSource_EVENT_SOURCE_SENSOR,
+		Origin:    origin,
+		Message:   message,
+		SensorId:  &origin,
+		Threshold: &limit,
+	}
+	if event.GetExceeded() {
+		entry.Reading = &reading
+	}
+
+	rec := sel.ThresholdEvent(sel.SensorTypeOtherUnits, sel.SensorNumberUnspecified,
+		sel.OffsetUpperCriticalGoingHigh, !event.GetExceeded())
+	return entry, rec, true
+}
+
+// handleStateEvent records state transitions broadcast by statemgr on
+// statemgr.event.<component>.transition, where component is e.g. host.0.
+func (s *SELMgr) handleStateEvent(ctx context.Context, msg *nats.Msg) {
+	tokens := strings.Split(msg.Subject, ".")
+	if len(tokens) < 4 || tokens[len(tokens)-1] != "transition" {
+		return
+	}
+	kind := tokens[len(tokens)-3]
+
+	var (
+		entry *v1alpha1.SystemEventLogEntry
+		rec   sel.Record
+		err   error
+	)
+	switch kind {
+	case "host":
+		entry, rec, err = hostStateEntry(msg.Data)
+	case "chassis":
+		entry, rec, err = chassisStateEntry(msg.Data)
+	case "bmc":
+		entry, rec, err = managementControllerStateEntry(msg.Data)
+	default:
+		return
+	}
+	if err != nil {
+		s.logger.WarnContext(ctx, "Ignoring malformed state event", "subject", msg.Subject, "error", err)
+		return
+	}
+	if entry == nil {
+		return
+	}
+
+	s.record(ctx, entry, rec)
+}
+
+// stateEntry builds the common part of a state transition entry.
+func stateEntry(source v1alpha1.EventSource, origin, previous, current string, changedAt *timestamppb.Timestamp, severity v1alpha1.EventSeverity) *v1alpha1.SystemEventLogEntry {
+	if changedAt == nil {
+		changedAt = timestamppb.Now()
+	}
+	return &v1alpha1.SystemEventLogEntry{
+		Timestamp: changedAt,
+		Severity:  severity,
+		Source:    source,
+		Origin:    origin,
+		Message:   fmt.Sprintf("%s changed state from %s to %s", origin, previous, current),
+	}
+}
+
+// statusName returns the lower case name of a status enum value without its prefix.
+func statusName(value fmt.Stringer, prefix string) string {
+	return strings.ToLower(strings.TrimPrefix(value.String(), prefix))
+}
+
+// hostStateEntry maps host power transitions to System ACPI Power State events.
+// Transitional states are not logged.
+func hostStateEntry(data []byte) (*v1alpha1.SystemEventLogEntry, sel.Record, error) {
+	var change v1alpha1.HostStateChange
+	if err := change.UnmarshalVT(data); err != nil {
+		return nil, sel.Record{}, fmt.Errorf("%w: %w", ErrInvalidEvent, err)
+	}
+	if change.GetPreviousStatus() == change.GetCurrentStatus() {
+		return nil, sel.Record{}, nil
+	}
+
+	var (
+		offset   uint8
+		severity = v1alpha1.EventSeverity_EVENT_SEVERITY_OK
+	)
+	switch change.GetCurrentStatus() {
+	case v1alpha1.HostStatus_HOST_STATUS_ON:
+		offset = sel.OffsetACPIWorking
+	case v1alpha1.HostStatus_HOST_STATUS_OFF:
+		offset = sel.OffsetACPISoftOff
+	case v1alpha1.HostStatus_HOST_STATUS_ERROR:
+		offset = sel.OffsetACPIUnknown
+		severity = v1alpha1.EventSeverity_EVENT_SEVERITY_CRITICAL
+	default:
+		return nil, sel.Record{}, nil
+	}
+
+	const prefix = "HOST_STATUS_"
+	entry := stateEntry(v1alpha1.EventSource_EVENT_SOURCE_HOST, change.GetHostName(),
+		statusName(change.GetPreviousStatus(), prefix), statusName(change.GetCurrentStatus(), prefix),
+		change.GetChangedAt(), severity)
+
+	return entry, sel.SensorSpecificEvent(sel.SensorTypeSystemACPIPowerState, sel.SensorNumberUnspecified, offset, false), nil
+}
+
+// chassisStateEntry maps chassis transitions to Power Unit events. Powering
+// on deasserts the power off offset.
+func chassisStateEntry(data []byte) (*v1alpha1.SystemEventLogEntry, sel.Record, error) {
+	var change v1alpha1.ChassisStateChange
+	if err := change.UnmarshalVT(data); err != nil {
+		return nil, sel.Record{}, fmt.Errorf("%w: %w", ErrInvalidEvent, err)
+	}
+	if change.GetPreviousStatus() == change.GetCurrentStatus() {
+		return nil, sel.Record{}, nil
+	}
+
+	var (
+		offset      uint8
+		deassertion bool
+		severity    = v1alpha1.EventSeverity_EVENT_SEVERITY_OK
+	)
+	switch change.GetCurrentStatus() {
+	case v1alpha1.ChassisStatus_CHASSIS_STATUS_OFF:
+		offset = sel.OffsetPowerUnitPowerOff
+	case v1alpha1.ChassisStatus_CHASSIS_STATUS_ON:
+		offset = sel.OffsetPowerUnitPowerOff
+		deassertion = true
+	case v1alpha1.ChassisStatus_CHASSIS_STATUS_WARNING:
+		offset = sel.OffsetPowerUnitPredictiveFailure
+		severity = v1alpha1.EventSeverity_EVENT_SEVERITY_WARNING
+	case v1alpha1.ChassisStatus_CHASSIS_STATUS_CRITICAL, v1alpha1.ChassisStatus_CHASSIS_STATUS_FAILED:
+		offset = sel.OffsetPowerUnitFailure
+		severity = v1alpha1.EventSeverity_EVENT_SEVERITY_CRITICAL
+	default:
+		return nil, sel.Record{}, nil
+	}
+
+	const prefix = "CHASSIS_STATUS_"
+	entry := stateEntry(v1alpha1.EventSource_EVENT_SOURCE_CHASSIS, change.GetChassisName(),
+		statusName(change.GetPreviousStatus(), prefix), statusName(change.GetCurrentStatus(), prefix),
+		change.GetChangedAt(), severity)
+
+	return entry, sel.SensorSpecificEvent(sel.SensorTypePowerUnit, sel.SensorNumberUnspecified, offset, deassertion), nil
+}
+
+// managementControllerStateEntry maps BMC transitions to Management Subsystem
+// Health events. Becoming ready deasserts the off-line offset.
+func managementControllerStateEntry(data []byte) (*v1alpha1.SystemEventLogEntry, sel.Record, error) {
+	var change v1alpha1.ManagementControllerStateChange
+	if err := change.UnmarshalVT(data); err != nil {
+		return nil, sel.Record{}, fmt.Errorf("%w: %w", ErrInvalidEvent, err)
+	}
+	if change.GetPreviousStatus() == change.GetCurrentStatus() {
+		return nil, sel.Record{}, nil
+	}
+
+	var (
+		offset      uint8
+		deassertion bool
+		severity    = v1alpha1.EventSeverity_EVENT_SEVERITY_WARNING
+	)
+	switch change.GetCurrentStatus() {
+	case v1alpha1.ManagementControllerStatus_MANAGEMENT_CONTROLLER_STATUS_READY:
+		offset = sel.OffsetManagementControllerOffline
+		deassertion = true
+		severity = v1alpha1.EventSeverity_EVENT_SEVERITY_OK
+	case v1alpha1.ManagementControllerStatus_MANAGEMENT_CONTROLLER_STATUS_NOT_READY,
+		v1alpha1.ManagementControllerStatus_MANAGEMENT_CONTROLLER_STATUS_DISABLED,
+		v1alpha1.ManagementControllerStatus_MANAGEMENT_CONTROLLER_STATUS_QUIESCED:
+		offset = sel.OffsetManagementControllerOffline
+	case v1alpha1.ManagementControllerStatus_MANAGEMENT_CONTROLLER_STATUS_ERROR:
+		offset = sel.OffsetManagementControllerUnavailable
+		severity = v1alpha1.EventSeverity_EVENT_SEVERITY_CRITICAL
+	default:
+		return nil, sel.Record{}, nil
+	}
+
+	const prefix = "MANAGEMENT_CONTROLLER_STATUS_"
+	entry := stateEntry(v1alpha1.EventSource_EVENT_SOURCE_MANAGEMENT_CONTROLLER, change.GetControllerName(),
+		statusName(change.GetPreviousStatus(), prefix), statusName(change.GetCurrentStatus(), prefix),
+		change.GetChangedAt(), severity)
+
+	return entry, sel.SensorSpecificEvent(sel.SensorTypeManagementSubsystemHealth, sel.SensorNumberUnspecified, offset, deassertion), nil
+}
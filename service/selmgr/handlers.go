@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package selmgr
+
+import (
+	"context"
+	"errors"
+
+	"github.com/nats-io/nats.go/micro"
+	v1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+)
+
+type vtMessage interface {
+	MarshalVT() ([]byte, error)
+}
+
+// respond sends a protobuf response and logs failures.
+func (s *SELMgr) respond(ctx context.Context, req micro.Request, resp vtMessage) {
+	data, err := resp.MarshalVT()
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to marshal response", "subject", req.Subject(), "error", err)
+		_ = req.Error("500", "failed to marshal response", nil)
+		return
+	}
+
+	if err := req.Respond(data); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to send response", "subject", req.Subject(), "error", err)
+	}
+}
+
+// respondError maps an event log error to a micro error response.
+func (s *SELMgr) respondError(ctx context.Context, req micro.Request, err error) {
+	if errors.Is(err, ErrEntryNotFound) {
+		_ = req.Error("404", err.Error(), nil)
+		return
+	}
+
+	s.logger.ErrorContext(ctx, "Request failed", "subject", req.Subject(), "error", err)
+	_ = req.Error("500", err.Error(), nil)
+}
+
+// handleGetInfo handles requests for the event log summary.
+func (s *SELMgr) handleGetInfo(ctx context.Context, req micro.Request) {
+	var request v1alpha1.GetSystemEventLogInfoRequest
+	if err := request.UnmarshalVT(req.Data()); err != nil {
+		_ = req.Error("400", "invalid request format", nil)
+		return
+	}
+
+	resp, err := s.logInfo(ctx)
+	if err != nil {
+		s.respondError(ctx, req, err)
+		return
+	}
+
+	s.respond(ctx, req, resp)
+}
+
+// handleGetEntry handles requests for a single entry by record ID.
+func (s *SELMgr) handleGetEntry(ctx context.Context, req micro.Request) {
+	var request v1alpha1.GetSystemEventLogEntryRequest
+	if err := request.UnmarshalVT(req.Data()); err != nil {
+		_ = req.Error("400", "invalid request format", nil)
+		return
+	}
+	if request.GetRecordId() > 0xFFFF {
+		_ = req.Error("400", "record ID out of range", nil)
+		return
+	}
+
+	entry, next, err := s.getEntry(ctx, uint16(request.GetRecordId()))
+	if err != nil {
+		s.respondError(ctx, req, err)
+		return
+	}
+
+	s.respond(ctx, req, &v1alpha1.GetSystemEventLogEntryResponse{
+		Entry:        entry,
+		NextRecordId: uint32(next),
+	})
+}
+
+// handleListEntries handles requests to list the event log.
+func (s *SELMgr) handleListEntries(ctx context.Context, req micro.Request) {
+	var request v1alpha1.ListSystemEventLogEntriesRequest
+	if err := request.UnmarshalVT(req.Data()); err != nil {
+		_ = req.Error("400", "invalid request format", nil)
+		return
+	}
+
+	entries, err := s.listEntries(ctx, int(request.GetMaxEntries()), request.GetMinSeverity())
+	if err != nil {
+		s.respondError(ctx, req, err)
+		return
+	}
+
+	s.respond(ctx, req, &v1alpha1.ListSystemEventLogEntriesResponse{Entries: entries})
+}
+
+// handleClear handles requests to erase the event log.
+func (s *SELMgr) handleClear(ctx context.Context, req micro.Request) {
+	var request v1alpha1.ClearSystemEventLogRequest
+	if err := request.UnmarshalVT(req.Data()); err != nil {
+		_ = req.Error("400", "invalid request format", nil)
+		return
+	}
+
+	cleared, err := s.clearEntries(ctx)
+	if err != nil {
+		s.respondError(ctx, req, err)
+		return
+	}
+
+	s.logger.InfoContext(ctx, "System event log cleared", "entries", cleared)
+
+	s.respond(ctx, req, &v1alpha1.ClearSystemEventLogResponse{ClearedEntries: uint32(cleared)})
+}
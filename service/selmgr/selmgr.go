@@ -0,0 +1,249 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package selmgr
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/nats-io/nats.go/micro"
+	"github.com/u-bmc/u-bmc/pkg/ipc"
+	"github.com/u-bmc/u-bmc/pkg/log"
+	"github.com/u-bmc/u-bmc/pkg/telemetry"
+	"github.com/u-bmc/u-bmc/pkg/threshold"
+	"github.com/u-bmc/u-bmc/service"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Compile-time assertion that SELMgr implements service.Service.
+var _ service.Service = (*SELMgr)(nil)
+
+// SELMgr collects system events from other services into a persistent,
+// bounded system event log and serves it over NATS IPC.
+type SELMgr struct {
+	config       config
+	nc           *nats.Conn
+	js           jetstream.JetStream
+	stream       jetstream.Stream
+	microService micro.Service
+	logger       *slog.Logger
+	tracer       trace.Tracer
+	subs         []*nats.Subscription
+	wg           sync.WaitGroup
+
+	// mu serializes writes to the event log so that the overflow state
+	// matches the stored entries.
+	mu        sync.Mutex
+	overflow  bool
+	lastErase time.Time
+
+	violations *threshold.Tracker
+}
+
+// New creates a new SELMgr instance with the provided options.
+func New(opts ...Option) *SELMgr {
+	cfg := &config{
+		name:               DefaultServiceName,
+		description:        DefaultServiceDescription,
+		version:            DefaultServiceVersion,
+		streamName:         DefaultStreamName,
+		streamSubject:      DefaultStreamSubject,
+		maxEntries:         DefaultMaxEntries,
+		overflowPolicy:     DefaultOverflowPolicy,
+		sensorEventSubject: DefaultSensorEventSubject,
+		stateEventSubject:  DefaultStateEventSubject,
+		powerEventSubject:  DefaultPowerEventSubject,
+		violationTimeout:   DefaultViolationTimeout,
+		requestTimeout:     DefaultRequestTimeout,
+	}
+	for _, opt := range opts {
+		opt.apply(cfg)
+	}
+	return &SELMgr{
+		config:     *cfg,
+		violations: threshold.NewTracker(cfg.violationTimeout),
+	}
+}
+
+// Name returns the service name.
+func (s *SELMgr) Name() string {
+	return s.config.name
+}
+
+// Run starts the system event log manager and records events until the
+// context is canceled.
+func (s *SELMgr) Run(ctx context.Context, ipcConn nats.InProcessConnProvider) error {
+	s.tracer = otel.Tracer(s.config.name)
+
+	ctx, span := s.tracer.Start(ctx, "selmgr.Run")
+	defer span.End()
+
+	s.logger = log.GetGlobalLogger().With("service", s.config.name)
+	s.logger.InfoContext(ctx, "Starting system event log manager",
+		"version", s.config.version,
+		"max_entries", s.config.maxEntries,
+		"overflow_policy", s.config.overflowPolicy)
+
+	if err := s.config.Validate(); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("%w: %w", ErrInvalidConfiguration, err)
+	}
+
+	nc, err := nats.Connect("", nats.InProcessServer(ipcConn))
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("%w: %w", ErrNATSConnectionFailed, err)
+	}
+	s.nc = nc
+	defer nc.Drain() //nolint:errcheck
+
+	s.js, err = jetstream.New(nc)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("%w: %w", ErrJetStreamInitFailed, err)
+	}
+
+	if err := s.setupJetStream(ctx); err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	s.microService, err = micro.AddService(nc, micro.Config{
+		Name:        s.config.name,
+		Description: s.config.description,
+		Version:     s.config.version,
+	})
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("%w: %w", ErrMicroServiceCreationFailed, err)
+	}
+
+	if err := s.registerEndpoints(ctx); err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	if err := s.subscribeEvents(ctx); err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.violations.Run(ctx, func(event threshold.Event, now time.Time) {
+			s.deassertThreshold(ctx, event, now)
+		})
+	}()
+
+	span.SetAttributes(
+		attribute.String("service.name", s.config.name),
+		attribute.String("service.version", s.config.version),
+		attribute.Int("sel.max_entries", s.config.maxEntries),
+	)
+
+	<-ctx.Done()
+
+	err = ctx.Err()
+	ctx = context.WithoutCancel(ctx)
+	s.logger.InfoContext(ctx, "Stopping system event log manager", "reason", err)
+	s.shutdown()
+
+	return err
+}
+
+// setupJetStream creates or updates the bounded stream holding the event log.
+func (s *SELMgr) setupJetStream(ctx context.Context) error {
+	discard := jetstream.DiscardOld
+	if s.config.overflowPolicy == OverflowPolicyStop {
+		discard = jetstream.DiscardNew
+	}
+
+	stream, err := s.js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:        s.config.streamName,
+		Description: "System event log",
+		Subjects:    []string{s.config.streamSubject + ".>"},
+		Retention:   jetstream.LimitsPolicy,
+		Discard:     discard,
+		Storage:     jetstream.FileStorage,
+		Replicas:    1,
+		MaxMsgs:     int64(s.config.maxEntries),
+		MaxBytes:    -1,
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrStreamCreationFailed, err)
+	}
+	s.stream = stream
+
+	info, err := stream.Info(ctx)
+	if err == nil {
+		s.logger.InfoContext(ctx, "JetStream stream configured",
+			"name", info.Config.Name,
+			"subjects", info.Config.Subjects,
+			"entries", info.State.Msgs)
+	}
+
+	return nil
+}
+
+func (s *SELMgr) registerEndpoints(ctx context.Context) error {
+	groups := make(map[string]micro.Group)
+
+	endpoints := []struct {
+		subject string
+		handler func(context.Context, micro.Request)
+	}{
+		{ipc.SubjectSELInfo, s.handleGetInfo},
+		{ipc.SubjectSELEntry, s.handleGetEntry},
+		{ipc.SubjectSELList, s.handleListEntries},
+		{ipc.SubjectSELClear, s.handleClear},
+	}
+	for _, ep := range endpoints {
+		if err := ipc.RegisterEndpointWithGroupCache(s.microService, ep.subject,
+			micro.HandlerFunc(s.createRequestHandler(ctx, ep.handler)), groups); err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrEndpointRegistrationFailed, ep.subject, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *SELMgr) createRequestHandler(parentCtx context.Context, handler func(context.Context, micro.Request)) micro.HandlerFunc {
+	return func(req micro.Request) {
+		ctx := context.WithoutCancel(telemetry.GetCtxFromReq(req))
+		if parentCtx.Err() != nil {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithCancel(ctx)
+			cancel()
+		}
+
+		ctx, span := s.tracer.Start(ctx, "selmgr.handleRequest")
+		span.SetAttributes(
+			attribute.String("subject", req.Subject()),
+			attribute.String("service", s.config.name),
+		)
+		defer span.End()
+
+		handler(ctx, req) //nolint:contextcheck
+	}
+}
+
+func (s *SELMgr) shutdown() {
+	for _, sub := range s.subs {
+		_ = sub.Unsubscribe()
+	}
+	s.subs = nil
+
+	s.wg.Wait()
+
+	if s.microService != nil {
+		_ = s.microService.Stop()
+	}
+}
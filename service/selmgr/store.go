@@ -0,0 +1,234 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package selmgr
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+	v1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+	"github.com/u-bmc/u-bmc/pkg/sel"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// recordID derives the 16-bit record ID of an entry from its stream sequence.
+// IDs cycle through 0x0001-0xFFFE, so they are unique as long as the stream
+// holds fewer than maxEntriesLimit entries.
+func recordID(seq uint64) uint16 {
+	return uint16((seq-1)%maxEntriesLimit + 1)
+}
+
+// sequenceOf returns the stream sequence of the entry with the given record ID.
+func sequenceOf(state jetstream.StreamState, id uint16) (uint64, bool) {
+	if state.Msgs == 0 {
+		return 0, false
+	}
+
+	switch id {
+	case sel.RecordIDFirst:
+		return state.FirstSeq, true
+	case sel.RecordIDLast:
+		return state.LastSeq, true
+	}
+
+	first := uint64(recordID(state.FirstSeq))
+	seq := state.FirstSeq + (uint64(id)+maxEntriesLimit-first)%maxEntriesLimit
+	if seq > state.LastSeq {
+		return 0, false
+	}
+
+	return seq, true
+}
+
+// sourceToken returns the subject token entries of a source are stored under.
+func sourceToken(source v1alpha1.EventSource) string {
+	return strings.ToLower(strings.TrimPrefix(source.String(), "EVENT_SOURCE_"))
+}
+
+// appendEntry stores an entry in the event log, applying the overflow policy when
+// the log is full.
+func (s *SELMgr) appendEntry(ctx context.Context, entry *v1alpha1.SystemEventLogEntry) error {
+	ctx, cancel := context.WithTimeout(ctx, s.config.requestTimeout)
+	defer cancel()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := s.stream.Info(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrStorageFailed, err)
+	}
+
+	if info.State.Msgs >= uint64(s.config.maxEntries) {
+		if !s.overflow {
+			s.logger.WarnContext(ctx, "System event log full",
+				"entries", info.State.Msgs,
+				"overflow_policy", s.config.overflowPolicy)
+		}
+		s.overflow = true
+		if s.config.overflowPolicy == OverflowPolicyStop {
+			return ErrEventLogFull
+		}
+	}
+
+	data, err := entry.MarshalVT()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrMarshalingFailed, err)
+	}
+
+	subject := s.config.streamSubject + "." + sourceToken(entry.GetSource())
+	if _, err := s.js.Publish(ctx, subject, data); err != nil {
+		return fmt.Errorf("%w: %w", ErrStorageFailed, err)
+	}
+
+	return nil
+}
+
+// loadEntry reads the entry stored at the given stream sequence and assigns its record ID.
+func (s *SELMgr) loadEntry(ctx context.Context, seq uint64) (*v1alpha1.SystemEventLogEntry, error) {
+	msg, err := s.stream.GetMsg(ctx, seq)
+	if errors.Is(err, jetstream.ErrMsgNotFound) {
+		return nil, ErrEntryNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrStorageFailed, err)
+	}
+
+	entry := &v1alpha1.SystemEventLogEntry{}
+	if err := entry.UnmarshalVT(msg.Data); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnmarshalingFailed, err)
+	}
+
+	id := recordID(seq)
+	entry.RecordId = uint32(id)
+	if len(entry.GetIpmiRecord()) == sel.RecordSize {
+		binary.LittleEndian.PutUint16(entry.IpmiRecord[0:2], id)
+	}
+
+	return entry, nil
+}
+
+// getEntry returns the entry with the given record ID and the record ID of the
+// entry following it, or sel.RecordIDLast if it is the last one.
+func (s *SELMgr) getEntry(ctx context.Context, id uint16) (*v1alpha1.SystemEventLogEntry, uint16, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.requestTimeout)
+	defer cancel()
+
+	info, err := s.stream.Info(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: %w", ErrStorageFailed, err)
+	}
+
+	seq, ok := sequenceOf(info.State, id)
+	if !ok {
+		return nil, 0, ErrEntryNotFound
+	}
+
+	entry, err := s.loadEntry(ctx, seq)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	next := sel.RecordIDLast
+	if seq < info.State.LastSeq {
+		next = recordID(seq + 1)
+	}
+
+	return entry, next, nil
+}
+
+// listEntries returns up to limit of the most recent entries with at least the given
+// severity, oldest first. A limit of zero returns all matching entries.
+func (s *SELMgr) listEntries(ctx context.Context, limit int, minSeverity v1alpha1.EventSeverity) ([]*v1alpha1.SystemEventLogEntry, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.requestTimeout)
+	defer cancel()
+
+	info, err := s.stream.Info(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrStorageFailed, err)
+	}
+
+	entries := make([]*v1alpha1.SystemEventLogEntry, 0, min(int(info.State.Msgs), s.config.maxEntries))
+	if info.State.Msgs == 0 {
+		return entries, nil
+	}
+
+	for seq := info.State.LastSeq; seq >= info.State.FirstSeq && seq > 0; seq-- {
+		if limit > 0 && len(entries) >= limit {
+			break
+		}
+
+		entry, err := s.loadEntry(ctx, seq)
+		if errors.Is(err, ErrEntryNotFound) {
+			// Discarded by the overflow policy while listing.
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if entry.GetSeverity() >= minSeverity {
+			entries = append(entries, entry)
+		}
+	}
+	slices.Reverse(entries)
+
+	return entries, nil
+}
+
+// clearEntries erases all entries and returns how many were removed.
+func (s *SELMgr) clearEntries(ctx context.Context) (uint64, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.requestTimeout)
+	defer cancel()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := s.stream.Info(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrStorageFailed, err)
+	}
+
+	if err := s.stream.Purge(ctx); err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrStorageFailed, err)
+	}
+
+	s.overflow = false
+	s.lastErase = time.Now()
+
+	return info.State.Msgs, nil
+}
+
+// logInfo summarizes the state of the event log.
+func (s *SELMgr) logInfo(ctx context.Context) (*v1alpha1.GetSystemEventLogInfoResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.requestTimeout)
+	defer cancel()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := s.stream.Info(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrStorageFailed, err)
+	}
+
+	resp := &v1alpha1.GetSystemEventLogInfoResponse{
+		Entries:    uint32(info.State.Msgs),
+		MaxEntries: uint32(s.config.maxEntries),
+		Overflow:   s.overflow,
+	}
+	if info.State.LastSeq > 0 && !info.State.LastTime.IsZero() {
+		resp.LastAddition = timestamppb.New(info.State.LastTime)
+	}
+	if !s.lastErase.IsZero() {
+		resp.LastErase = timestamppb.New(s.lastErase)
+	}
+
+	return resp, nil
+}
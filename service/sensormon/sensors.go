@@ -11,9 +11,9 @@ import (
 
 	"github.com/nats-io/nats.go/micro"
 	v1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+	"github.com/u-bmc/u-bmc/pkg/fieldmask"
 	"github.com/u-bmc/u-bmc/pkg/hwmon"
 	"google.golang.org/protobuf/proto"
-	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -26,15 +26,19 @@ func (s *SensorMon) handleListSensors(ctx context.Context, req micro.Request) {
 		return
 	}
 
+	if err := fieldmask.Validate(&v1alpha1.Sensor{}, request.FieldMask); err != nil {
+		s.logger.WarnContext(ctx, "Invalid field mask in list sensors request", "error", err)
+		_ = req.Error("400", fmt.Sprintf("%s: %s", ErrInvalidFieldMask, err), nil)
+		return
+	}
+
 	s.mu.RLock()
 	sensors := make([]*v1alpha1.Sensor, 0, len(s.sensors))
 	for _, sensorInfo := range s.sensors {
 		sensor := proto.Clone(sensorInfo.Sensor).(*v1alpha1.Sensor)
 
-		// Apply field mask if provided
-		if request.FieldMask != nil {
-			sensor = s.applySensorFieldMask(sensor, request.FieldMask)
-		}
+		// The field mask was validated above
+		_ = fieldmask.Apply(sensor, request.FieldMask)
 
 		sensors = append(sensors, sensor)
 	}
@@ -105,8 +109,10 @@ func (s *SensorMon) handleGetSensor(ctx context.Context, req micro.Request) {
 	sensor := proto.Clone(sensorInfo.Sensor).(*v1alpha1.Sensor)
 
 	// Apply field mask if provided
-	if request.FieldMask != nil {
-		sensor = s.applySensorFieldMask(sensor, request.FieldMask)
+	if err := fieldmask.Apply(sensor, request.FieldMask); err != nil {
+		s.logger.WarnContext(ctx, "Invalid field mask in get sensor request", "error", err)
+		_ = req.Error("400", fmt.Sprintf("%s: %s", ErrInvalidFieldMask, err), nil)
+		return
 	}
 
 	response := &v1alpha1.GetSensorResponse{
@@ -404,10 +410,3 @@ func (s *SensorMon) readMockSensorValue(ctx context.Context, sensorInfo *sensorI
 
 	return nil
 }
-
-// applySensorFieldMask applies a field mask to a sensor to return only requested fields.
-func (s *SensorMon) applySensorFieldMask(sensor *v1alpha1.Sensor, fieldMask *fieldmaskpb.FieldMask) *v1alpha1.Sensor {
-	// Field mask implementation would filter the sensor fields based on the mask
-	// For now, return the complete sensor
-	return sensor
-}
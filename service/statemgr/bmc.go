@@ -333,7 +333,7 @@ func (s *StateMgr) handleManagementControllerInfo(ctx context.Context, req micro
 func (s *StateMgr) handleGetManagementControllerState(ctx context.Context, req micro.Request, controllerName string) {
 	sm, exists := s.getStateMachine(controllerName)
 	if !exists {
-		ipc.RespondWithError(ctx, req, ErrComponentNotFound, fmt.Sprintf("Management Controller %s not found", controllerName))
+		respondNotFound(req, fmt.Sprintf("Management Controller %s not found", controllerName))
 		return
 	}
 
@@ -364,7 +364,7 @@ func (s *StateMgr) handleManagementControllerControlRequest(ctx context.Context,
 
 	sm, exists := s.getStateMachine(controllerName)
 	if !exists {
-		ipc.RespondWithError(ctx, req, ErrComponentNotFound, fmt.Sprintf("Management Controller %s not found", controllerName))
+		respondNotFound(req, fmt.Sprintf("Management Controller %s not found", controllerName))
 		return
 	}
 
@@ -400,7 +400,7 @@ func (s *StateMgr) handleManagementControllerControlRequest(ctx context.Context,
 func (s *StateMgr) handleGetManagementControllerInfo(ctx context.Context, req micro.Request, controllerName string) {
 	sm, exists := s.getStateMachine(controllerName)
 	if !exists {
-		ipc.RespondWithError(ctx, req, ErrComponentNotFound, fmt.Sprintf("Management Controller %s not found", controllerName))
+		respondNotFound(req, fmt.Sprintf("Management Controller %s not found", controllerName))
 		return
 	}
 
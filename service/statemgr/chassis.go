@@ -384,7 +384,7 @@ func (s *StateMgr) handleChassisInfo(ctx context.Context, req micro.Request) {
 func (s *StateMgr) handleGetChassisState(ctx context.Context, req micro.Request, chassisName string) {
 	sm, exists := s.getStateMachine(chassisName)
 	if !exists {
-		ipc.RespondWithError(ctx, req, ErrComponentNotFound, fmt.Sprintf("chassis %s not found", chassisName))
+		respondNotFound(req, fmt.Sprintf("chassis %s not found", chassisName))
 		return
 	}
 
@@ -414,7 +414,7 @@ func (s *StateMgr) handleGetChassisState(ctx context.Context, req micro.Request,
 func (s *StateMgr) handleChassisControlRequest(ctx context.Context, req micro.Request, chassisName string, start time.Time, request *schemav1alpha1.ChangeChassisStateRequest) {
 	sm, exists := s.getStateMachine(chassisName)
 	if !exists {
-		ipc.RespondWithError(ctx, req, ErrComponentNotFound, fmt.Sprintf("chassis %s not found", chassisName))
+		respondNotFound(req, fmt.Sprintf("chassis %s not found", chassisName))
 		return
 	}
 
@@ -423,6 +423,13 @@ func (s *StateMgr) handleChassisControlRequest(ctx context.Context, req micro.Re
 		return
 	}
 
+	// Identify only drives the chassis LED and is not a state transition.
+	if request.Action == schemav1alpha1.ChassisAction_CHASSIS_ACTION_IDENTIFY_ON ||
+		request.Action == schemav1alpha1.ChassisAction_CHASSIS_ACTION_IDENTIFY_OFF {
+		s.handleChassisIdentifyRequest(ctx, req, chassisName, sm.State(ctx), request.Action)
+		return
+	}
+
 	previousState := sm.State(ctx)
 	trigger := request.Action.String()
 	if trigger == "" {
@@ -478,7 +485,7 @@ func (s *StateMgr) handleChassisControlRequest(ctx context.Context, req micro.Re
 func (s *StateMgr) handleGetChassisInfo(ctx context.Context, req micro.Request, chassisName string) {
 	sm, exists := s.getStateMachine(chassisName)
 	if !exists {
-		ipc.RespondWithError(ctx, req, ErrComponentNotFound, fmt.Sprintf("chassis %s not found", chassisName))
+		respondNotFound(req, fmt.Sprintf("chassis %s not found", chassisName))
 		return
 	}
 
@@ -514,3 +521,29 @@ func chassisActionStringToEnum(actionName string) schemav1alpha1.ChassisAction {
 	}
 	return schemav1alpha1.ChassisAction_CHASSIS_ACTION_UNSPECIFIED
 }
+
+func (s *StateMgr) handleChassisIdentifyRequest(ctx context.Context, req micro.Request, chassisName, currentState string, action schemav1alpha1.ChassisAction) {
+	ledAction := "identify_off"
+	if action == schemav1alpha1.ChassisAction_CHASSIS_ACTION_IDENTIFY_ON {
+		ledAction = "identify_on"
+	}
+
+	if err := s.requestLEDAction(ctx, chassisName, ledAction); err != nil {
+		ipc.RespondWithError(ctx, req, ErrStateTransitionFailed, err.Error())
+		return
+	}
+
+	response := &schemav1alpha1.ChangeChassisStateResponse{
+		CurrentStatus: chassisStatusStringToEnum(currentState),
+	}
+
+	responseData, err := response.MarshalVT()
+	if err != nil {
+		ipc.RespondWithError(ctx, req, ErrMarshalingFailed, err.Error())
+		return
+	}
+
+	if err := req.Respond(responseData); err != nil && s.logger != nil {
+		s.logger.ErrorContext(ctx, "Failed to respond to request", "error", err)
+	}
+}
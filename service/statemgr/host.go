@@ -336,7 +336,7 @@ func (s *StateMgr) handleHostInfo(ctx context.Context, req micro.Request) {
 func (s *StateMgr) handleGetHostState(ctx context.Context, req micro.Request, hostName string) {
 	sm, exists := s.getStateMachine(hostName)
 	if !exists {
-		ipc.RespondWithError(ctx, req, ErrComponentNotFound, fmt.Sprintf("host %s not found", hostName))
+		respondNotFound(req, fmt.Sprintf("host %s not found", hostName))
 		return
 	}
 
@@ -366,7 +366,7 @@ func (s *StateMgr) handleGetHostState(ctx context.Context, req micro.Request, ho
 func (s *StateMgr) handleHostControlRequest(ctx context.Context, req micro.Request, hostName string, start time.Time, request *schemav1alpha1.ChangeHostStateRequest) {
 	sm, exists := s.getStateMachine(hostName)
 	if !exists {
-		ipc.RespondWithError(ctx, req, ErrComponentNotFound, fmt.Sprintf("host %s not found", hostName))
+		respondNotFound(req, fmt.Sprintf("host %s not found", hostName))
 		return
 	}
 
@@ -449,7 +449,7 @@ func (s *StateMgr) handleHostControlRequest(ctx context.Context, req micro.Reque
 func (s *StateMgr) handleGetHostInfo(ctx context.Context, req micro.Request, hostName string) {
 	sm, exists := s.getStateMachine(hostName)
 	if !exists {
-		ipc.RespondWithError(ctx, req, ErrComponentNotFound, fmt.Sprintf("host %s not found", hostName))
+		respondNotFound(req, fmt.Sprintf("host %s not found", hostName))
 		return
 	}
 
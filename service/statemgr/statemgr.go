@@ -797,6 +797,12 @@ func (s *StateMgr) getComponentType(componentName string) string {
 	return "unknown"
 }
 
+// respondNotFound sends a not found error response for an unknown component,
+// allowing clients to tell missing components apart from failed requests.
+func respondNotFound(req micro.Request, details string) {
+	_ = req.Error("404", fmt.Sprintf("%v: %s", ErrComponentNotFound, details), nil)
+}
+
 func (s *StateMgr) handleListHosts(ctx context.Context, req micro.Request) {
 	if s.tracer != nil {
 		var span trace.Span
@@ -54,10 +54,13 @@
 //   - thermalmgr.zone.control - Control thermal zone operation
 //
 // Cooling Device Management:
-//   - thermalmgr.devices.list - List all cooling devices
-//   - thermalmgr.device.get - Get cooling device information
-//   - thermalmgr.device.set - Update cooling device configuration
-//   - thermalmgr.device.control - Control cooling device operation
+//   - cooling_device.list - List all cooling devices
+//   - cooling_device.info - Get cooling device information
+//   - cooling_device.set - Set the power of a cooling device, placing it under manual control
+//   - cooling_device.mode - Switch a cooling device between automatic and manual control
+//
+// Cooling devices under manual control keep their power while the control loop
+// drives the remaining devices of their zone. Emergency cooling overrides them.
 //
 // Thermal Control:
 //   - thermalmgr.control.start - Start thermal management
@@ -5,10 +5,12 @@ package thermalmgr
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/nats-io/nats.go/micro"
 	v1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+	"github.com/u-bmc/u-bmc/pkg/fieldmask"
 	"github.com/u-bmc/u-bmc/pkg/thermal"
 )
 
@@ -20,12 +22,49 @@ type ThermalControlStatusResponse struct {
 	LastUpdate         string   `json:"last_update"`
 }
 
+// Cooling device control modes.
+const (
+	// CoolingDeviceModeAutomatic lets the thermal control loop drive a cooling device.
+	CoolingDeviceModeAutomatic = "automatic"
+	// CoolingDeviceModeManual keeps a cooling device at the power it was last set to.
+	CoolingDeviceModeManual = "manual"
+)
+
+// CoolingDeviceModeRequest represents a request to change the control mode of
+// a cooling device, optionally setting its power in manual mode.
+type CoolingDeviceModeRequest struct {
+	Name         string   `json:"name"`
+	Mode         string   `json:"mode"`
+	PowerPercent *float64 `json:"power_percent,omitempty"`
+}
+
+// CoolingDeviceModeResponse represents the control mode of a cooling device.
+type CoolingDeviceModeResponse struct {
+	Name         string  `json:"name"`
+	Mode         string  `json:"mode"`
+	PowerPercent float64 `json:"power_percent"`
+}
+
 // handleListThermalZones handles requests to list all thermal zones.
 func (t *ThermalMgr) handleListThermalZones(ctx context.Context, req micro.Request) {
+	var request v1alpha1.ListThermalZonesRequest
+	if err := request.UnmarshalVT(req.Data()); err != nil {
+		t.logger.WarnContext(ctx, "Invalid list thermal zones request",
+			"error", err)
+		_ = req.Error("400", "invalid request format", nil)
+		return
+	}
+	if err := fieldmask.Validate(&v1alpha1.ThermalZone{}, request.GetFieldMask()); err != nil {
+		_ = req.Error("400", fmt.Sprintf("%s: %s", ErrInvalidThermalRequest, err), nil)
+		return
+	}
+
 	t.mu.RLock()
 	zones := make([]*v1alpha1.ThermalZone, 0, len(t.thermalZones))
 	for _, zone := range t.thermalZones {
 		protoZone := t.convertThermalZoneToProto(zone)
+		// The field mask was validated above
+		_ = fieldmask.Apply(protoZone, request.GetFieldMask())
 		zones = append(zones, protoZone)
 	}
 	t.mu.RUnlock()
@@ -77,6 +116,10 @@ func (t *ThermalMgr) handleGetThermalZone(ctx context.Context, req micro.Request
 	}
 
 	protoZone := t.convertThermalZoneToProto(zone)
+	if err := fieldmask.Apply(protoZone, request.GetFieldMask()); err != nil {
+		_ = req.Error("400", fmt.Sprintf("%s: %s", ErrInvalidThermalRequest, err), nil)
+		return
+	}
 	response := &v1alpha1.GetThermalZoneResponse{
 		ThermalZones: []*v1alpha1.ThermalZone{protoZone},
 	}
@@ -183,13 +226,32 @@ func (t *ThermalMgr) handleSetThermalZone(ctx context.Context, req micro.Request
 
 // handleListCoolingDevices handles requests to list all cooling devices.
 func (t *ThermalMgr) handleListCoolingDevices(ctx context.Context, req micro.Request) {
+	var request v1alpha1.ListCoolingDevicesRequest
+	if err := request.UnmarshalVT(req.Data()); err != nil {
+		t.logger.WarnContext(ctx, "Invalid list cooling devices request",
+			"error", err)
+		_ = req.Error("400", "invalid request format", nil)
+		return
+	}
+	if err := fieldmask.Validate(&v1alpha1.CoolingDevice{}, request.GetFieldMask()); err != nil {
+		_ = req.Error("400", fmt.Sprintf("%s: %s", ErrInvalidThermalRequest, err), nil)
+		return
+	}
+
 	t.mu.RLock()
-	devices := make([]*v1alpha1.CoolingDevice, 0, len(t.coolingDevices))
+	coolingDevices := make([]*thermal.CoolingDevice, 0, len(t.coolingDevices))
 	for _, device := range t.coolingDevices {
+		coolingDevices = append(coolingDevices, device)
+	}
+	t.mu.RUnlock()
+
+	devices := make([]*v1alpha1.CoolingDevice, 0, len(coolingDevices))
+	for _, device := range coolingDevices {
 		protoDevice := t.convertCoolingDeviceToProto(device)
+		// The field mask was validated above
+		_ = fieldmask.Apply(protoDevice, request.GetFieldMask())
 		devices = append(devices, protoDevice)
 	}
-	t.mu.RUnlock()
 
 	response := &v1alpha1.ListCoolingDevicesResponse{
 		CoolingDevices: devices,
@@ -277,17 +339,22 @@ func (t *ThermalMgr) handleSetCoolingDevice(ctx context.Context, req micro.Reque
 		return
 	}
 
-	// Update device power if provided
-	// Update power percentage if provided
+	// Update power percentage if provided. Setting the power places the
+	// device under manual control, so that the control loop keeps it.
 	if request.PowerPercent != nil {
 		if err := thermal.SetCoolingDevicePower(ctx, device, *request.PowerPercent); err != nil {
 			t.logger.ErrorContext(ctx, "Failed to set cooling device power",
 				"device", request.Name,
 				"power", *request.PowerPercent,
 				"error", err)
+			if errors.Is(err, thermal.ErrInvalidCoolingPower) {
+				_ = req.Error("400", err.Error(), nil)
+				return
+			}
 			_ = req.Error("500", "failed to set cooling device power", nil)
 			return
 		}
+		t.setManualCoolingDevice(request.Name, true)
 
 		t.logger.InfoContext(ctx, "Updated cooling device power",
 			"device", request.Name,
@@ -317,6 +384,84 @@ func (t *ThermalMgr) handleSetCoolingDevice(ctx context.Context, req micro.Reque
 		"power_percent", request.PowerPercent)
 }
 
+// handleSetCoolingDeviceMode handles requests to change the control mode of a
+// cooling device. Devices under manual control keep their power until they are
+// returned to automatic control, except during emergency cooling.
+func (t *ThermalMgr) handleSetCoolingDeviceMode(ctx context.Context, req micro.Request) {
+	var request CoolingDeviceModeRequest
+	if err := json.Unmarshal(req.Data(), &request); err != nil {
+		t.logger.WarnContext(ctx, "Invalid cooling device mode request",
+			"error", err)
+		_ = req.Error("400", "invalid request format", nil)
+		return
+	}
+
+	if request.Name == "" {
+		_ = req.Error("400", "device name required", nil)
+		return
+	}
+
+	device, exists := t.getCoolingDevice(request.Name)
+	if !exists {
+		_ = req.Error("404", fmt.Sprintf("cooling device not found: %s", request.Name), nil)
+		return
+	}
+
+	switch request.Mode {
+	case CoolingDeviceModeAutomatic:
+		if request.PowerPercent != nil {
+			_ = req.Error("400", "power percent requires manual control mode", nil)
+			return
+		}
+		t.setManualCoolingDevice(request.Name, false)
+
+	case CoolingDeviceModeManual:
+		if request.PowerPercent != nil {
+			if err := thermal.SetCoolingDevicePower(ctx, device, *request.PowerPercent); err != nil {
+				t.logger.ErrorContext(ctx, "Failed to set cooling device power",
+					"device", request.Name,
+					"power", *request.PowerPercent,
+					"error", err)
+				if errors.Is(err, thermal.ErrInvalidCoolingPower) {
+					_ = req.Error("400", err.Error(), nil)
+					return
+				}
+				_ = req.Error("500", "failed to set cooling device power", nil)
+				return
+			}
+		}
+		t.setManualCoolingDevice(request.Name, true)
+
+	default:
+		_ = req.Error("400", fmt.Sprintf("unsupported control mode: %s", request.Mode), nil)
+		return
+	}
+
+	response := CoolingDeviceModeResponse{
+		Name:         request.Name,
+		Mode:         request.Mode,
+		PowerPercent: device.CurrentPower,
+	}
+
+	responseData, err := json.Marshal(response)
+	if err != nil {
+		t.logger.ErrorContext(ctx, "Failed to marshal cooling device mode response",
+			"device", request.Name,
+			"error", err)
+		_ = req.Error("500", "failed to marshal response", nil)
+		return
+	}
+
+	if err := req.Respond(responseData); err != nil {
+		t.logger.ErrorContext(ctx, "Failed to send cooling device mode response",
+			"error", err)
+	}
+
+	t.logger.InfoContext(ctx, "Updated cooling device control mode",
+		"device", request.Name,
+		"mode", request.Mode)
+}
+
 // handleSensorDataForThermalZone demonstrates proper cross-schema usage.
 // This handler shows how the thermal service can consume sensor data (sensor.proto)
 // to make thermal management decisions (thermal.proto).
@@ -598,6 +743,9 @@ func (t *ThermalMgr) convertCoolingDeviceToProto(device *thermal.CoolingDevice)
 	maxPower := device.MaxPower
 	status := device.Status
 	controlMode := v1alpha1.CoolingDeviceControlMode_COOLING_DEVICE_CONTROL_MODE_AUTOMATIC
+	if t.isManualCoolingDevice(device.Name) {
+		controlMode = v1alpha1.CoolingDeviceControlMode_COOLING_DEVICE_CONTROL_MODE_MANUAL
+	}
 
 	protoDevice := &v1alpha1.CoolingDevice{
 		Name:                   device.Name,
@@ -35,6 +35,9 @@ type ThermalMgr struct {
 	microService   micro.Service
 	thermalZones   map[string]*thermal.Zone
 	coolingDevices map[string]*thermal.CoolingDevice
+	// manualDevices holds the names of the cooling devices under manual
+	// control, whose power the control loop leaves unchanged.
+	manualDevices  map[string]bool
 	controlRunning bool
 	controlStop    chan struct{}
 	emergencyStop  chan struct{}
@@ -88,6 +91,7 @@ func New(opts ...Option) *ThermalMgr {
 		config:         cfg,
 		thermalZones:   make(map[string]*thermal.Zone),
 		coolingDevices: make(map[string]*thermal.CoolingDevice),
+		manualDevices:  make(map[string]bool),
 		controlStop:    make(chan struct{}),
 		emergencyStop:  make(chan struct{}),
 	}
@@ -331,6 +335,22 @@ func (t *ThermalMgr) registerEndpoints(ctx context.Context) error {
 		micro.HandlerFunc(t.createRequestHandler(ctx, t.handleSetThermalZone)), groups); err != nil {
 		return fmt.Errorf("failed to register thermal zone set endpoint: %w", err)
 	}
+	if err := ipc.RegisterEndpointWithGroupCache(t.microService, ipc.SubjectCoolingDeviceList,
+		micro.HandlerFunc(t.createRequestHandler(ctx, t.handleListCoolingDevices)), groups); err != nil {
+		return fmt.Errorf("failed to register cooling device list endpoint: %w", err)
+	}
+	if err := ipc.RegisterEndpointWithGroupCache(t.microService, ipc.SubjectCoolingDeviceInfo,
+		micro.HandlerFunc(t.createRequestHandler(ctx, t.handleGetCoolingDevice)), groups); err != nil {
+		return fmt.Errorf("failed to register cooling device info endpoint: %w", err)
+	}
+	if err := ipc.RegisterEndpointWithGroupCache(t.microService, ipc.SubjectCoolingDeviceSet,
+		micro.HandlerFunc(t.createRequestHandler(ctx, t.handleSetCoolingDevice)), groups); err != nil {
+		return fmt.Errorf("failed to register cooling device set endpoint: %w", err)
+	}
+	if err := ipc.RegisterEndpointWithGroupCache(t.microService, ipc.SubjectCoolingDeviceMode,
+		micro.HandlerFunc(t.createRequestHandler(ctx, t.handleSetCoolingDeviceMode)), groups); err != nil {
+		return fmt.Errorf("failed to register cooling device mode endpoint: %w", err)
+	}
 
 	return nil
 }
@@ -422,7 +442,7 @@ func (t *ThermalMgr) updateThermalZone(ctx context.Context, zone *thermal.Zone)
 	}
 
 	// Apply cooling output
-	if err := thermal.SetCoolingOutput(ctx, zone, output); err != nil {
+	if err := t.applyCoolingOutput(ctx, zone, output); err != nil {
 		return fmt.Errorf("failed to set cooling output: %w", err)
 	}
 
@@ -435,6 +455,41 @@ func (t *ThermalMgr) updateThermalZone(ctx context.Context, zone *thermal.Zone)
 	return nil
 }
 
+// applyCoolingOutput sets the power of the cooling devices of a zone that are
+// under automatic control. Emergency cooling uses thermal.SetCoolingOutput
+// instead, overriding manually controlled devices as well.
+func (t *ThermalMgr) applyCoolingOutput(ctx context.Context, zone *thermal.Zone, outputPercent float64) error {
+	t.mu.RLock()
+	devices := make([]*thermal.CoolingDevice, 0, len(zone.CoolingDevices))
+	for _, device := range zone.CoolingDevices {
+		if !t.manualDevices[device.Name] {
+			devices = append(devices, device)
+		}
+	}
+	t.mu.RUnlock()
+
+	var lastErr error
+	successCount := 0
+	for _, device := range devices {
+		if err := thermal.SetCoolingDevicePower(ctx, device, outputPercent); err != nil {
+			lastErr = err
+			t.logger.WarnContext(ctx, "Failed to set cooling device power",
+				"zone", zone.Name,
+				"device", device.Name,
+				"power", outputPercent,
+				"error", err)
+			continue
+		}
+		successCount++
+	}
+
+	if successCount == 0 && lastErr != nil {
+		return fmt.Errorf("%w: %w", thermal.ErrCoolingControlFailure, lastErr)
+	}
+
+	return nil
+}
+
 func (t *ThermalMgr) runEmergencyMonitoring(ctx context.Context) {
 	t.logger.InfoContext(ctx, "Starting emergency thermal monitoring")
 
@@ -549,3 +604,21 @@ func (t *ThermalMgr) getCoolingDevice(name string) (*thermal.CoolingDevice, bool
 	device, exists := t.coolingDevices[name]
 	return device, exists
 }
+
+// isManualCoolingDevice reports whether a cooling device is under manual control.
+func (t *ThermalMgr) isManualCoolingDevice(name string) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.manualDevices[name]
+}
+
+// setManualCoolingDevice places a cooling device under manual or automatic control.
+func (t *ThermalMgr) setManualCoolingDevice(name string, manual bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if manual {
+		t.manualDevices[name] = true
+	} else {
+		delete(t.manualDevices, name)
+	}
+}
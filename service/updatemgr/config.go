@@ -2,9 +2,35 @@
 
 package updatemgr
 
+import (
+	"fmt"
+	"time"
+)
+
+// Default configuration constants.
+const (
+	DefaultServiceName     = "updatemgr"
+	DefaultDescription     = "Firmware update management service"
+	DefaultServiceVersion  = "1.0.0"
+	DefaultStagingDir      = "/var/lib/u-bmc/update"
+	DefaultInstallDir      = "/var/lib/u-bmc/firmware"
+	DefaultMaxImageSize    = 64 << 20
+	DefaultTransferTimeout = 10 * time.Minute
+	DefaultJobHistory      = 16
+	DefaultTarget          = "bmc.0"
+	DefaultRequestTimeout  = 5 * time.Second
+)
+
 // config holds the configuration for the update manager service.
 type config struct {
-	name string
+	name            string
+	stagingDir      string
+	installer       Installer
+	maxImageSize    int64
+	transferTimeout time.Duration
+	jobHistory      int
+	defaultTargets  []string
+	requestTimeout  time.Duration
 }
 
 // Option represents a configuration option for the update manager service.
@@ -25,3 +51,146 @@ func WithServiceName(name string) Option {
 		name: name,
 	}
 }
+
+type stagingDirOption struct {
+	dir string
+}
+
+func (o *stagingDirOption) apply(c *config) {
+	c.stagingDir = o.dir
+}
+
+// WithStagingDir sets the directory update images are staged in. Images to
+// install must be placed in this directory, and images downloaded by the
+// update manager are stored there until their job ends.
+func WithStagingDir(dir string) Option {
+	return &stagingDirOption{
+		dir: dir,
+	}
+}
+
+type installerOption struct {
+	installer Installer
+}
+
+func (o *installerOption) apply(c *config) {
+	c.installer = o.installer
+}
+
+// WithInstaller sets the installer that writes update images to their
+// targets. It defaults to a FileInstaller below DefaultInstallDir without
+// public keys, which rejects every image.
+func WithInstaller(installer Installer) Option {
+	return &installerOption{
+		installer: installer,
+	}
+}
+
+type maxImageSizeOption struct {
+	size int64
+}
+
+func (o *maxImageSizeOption) apply(c *config) {
+	c.maxImageSize = o.size
+}
+
+// WithMaxImageSize sets the maximum size of an update image in bytes.
+func WithMaxImageSize(size int64) Option {
+	return &maxImageSizeOption{
+		size: size,
+	}
+}
+
+type transferTimeoutOption struct {
+	timeout time.Duration
+}
+
+func (o *transferTimeoutOption) apply(c *config) {
+	c.transferTimeout = o.timeout
+}
+
+// WithTransferTimeout sets how long downloading an update image may take.
+func WithTransferTimeout(timeout time.Duration) Option {
+	return &transferTimeoutOption{
+		timeout: timeout,
+	}
+}
+
+type jobHistoryOption struct {
+	jobs int
+}
+
+func (o *jobHistoryOption) apply(c *config) {
+	c.jobHistory = o.jobs
+}
+
+// WithJobHistory sets how many finished update jobs are kept for inspection.
+func WithJobHistory(jobs int) Option {
+	return &jobHistoryOption{
+		jobs: jobs,
+	}
+}
+
+type defaultTargetsOption struct {
+	targets []string
+}
+
+func (o *defaultTargetsOption) apply(c *config) {
+	c.defaultTargets = o.targets
+}
+
+// WithDefaultTargets sets the targets of update jobs that do not name any.
+func WithDefaultTargets(targets ...string) Option {
+	return &defaultTargetsOption{
+		targets: targets,
+	}
+}
+
+type requestTimeoutOption struct {
+	timeout time.Duration
+}
+
+func (o *requestTimeoutOption) apply(c *config) {
+	c.requestTimeout = o.timeout
+}
+
+// WithRequestTimeout sets the timeout of requests to other services, such as
+// the BMC reset that activates an update.
+func WithRequestTimeout(timeout time.Duration) Option {
+	return &requestTimeoutOption{
+		timeout: timeout,
+	}
+}
+
+// Validate checks that the configuration is usable.
+func (c *config) Validate() error {
+	if c.name == "" {
+		return fmt.Errorf("service name cannot be empty")
+	}
+
+	if c.stagingDir == "" {
+		return fmt.Errorf("staging directory cannot be empty")
+	}
+
+	if c.installer == nil {
+		return fmt.Errorf("installer cannot be nil")
+	}
+
+	if c.maxImageSize <= 0 {
+		return fmt.Errorf("maximum image size must be positive")
+	}
+
+	if c.transferTimeout <= 0 || c.requestTimeout <= 0 {
+		return fmt.Errorf("timeouts must be positive")
+	}
+
+	if c.jobHistory < 0 {
+		return fmt.Errorf("job history cannot be negative")
+	}
+
+	if len(c.defaultTargets) == 0 {
+		return fmt.Errorf("at least one default target is required")
+	}
+
+	return nil
+}
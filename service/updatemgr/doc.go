@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package updatemgr provides the firmware update manager for BMC systems. It
+// runs update jobs that bring an update image onto one or more firmware
+// targets and reports their progress over NATS IPC, so that the web server,
+// the IPMI server and other services can start and track updates.
+//
+// # Update Jobs
+//
+// An update job is started with an image that was either placed in the
+// staging directory by the requester, such as a Redfish multipart upload, or
+// that the update manager downloads from an HTTP or HTTPS URI. Each job is
+// tracked by a firmware update state machine from pkg/state and passes its
+// phases in order:
+//   - downloading: the image is downloaded, or taken from the staging directory
+//   - validating: the installer checks the image for every target
+//   - updating: the installer writes the image to every target
+//   - verifying: the installer checks that every target holds the image
+//
+// A job ends in the complete or failed state. Its progress is reported in
+// percent, with 40% after the download, 90% after the installation and 100%
+// once the image is verified. The image is removed from the staging
+// directory when the job ends.
+//
+// Only one job runs at a time. Finished jobs are kept in memory up to the
+// configured job history.
+//
+// # Targets and Activation
+//
+// Targets are named after the component whose firmware they hold, such as
+// "bmc.0" or "host.0", and default to "bmc.0". The Installer interface
+// decouples the update manager from the flash layout of a platform. The
+// default FileInstaller writes images atomically as <target>.img files below
+// DefaultInstallDir, from where the boot loader or a platform specific
+// service picks them up.
+//
+// # Image Signatures
+//
+// The FileInstaller only installs images with a detached signature of their
+// SHA-256 digest that verifies with one of its ECDSA or RSA public keys. The
+// signature is named after the image with SignatureSuffix appended. Staged
+// images are expected to come with it, and for downloaded images it is
+// downloaded from the image URI with the suffix appended. The default
+// FileInstaller has no public keys and rejects every image, so platforms
+// must configure their signing keys:
+//
+//	key, err := updatemgr.ParsePublicKey(pemData)
+//	if err != nil {
+//		return err
+//	}
+//	installer := updatemgr.NewFileInstaller("/var/lib/u-bmc/firmware", key)
+//
+// With the Immediate apply time, BMC targets are reset through the state
+// manager once their image is verified. With OnReset, and for all other
+// targets, the image is activated on the next reset of the target.
+//
+// # NATS Endpoints
+//
+// Requests and responses are JSON encoded:
+//   - update.start: start a job from a StartRequest, responds with the Job
+//   - update.info: get a Job by its ID from an InfoRequest
+//   - update.list: list all retained jobs in a ListResponse
+//
+// update.start fails with code 400 for invalid requests and 409 while another
+// job is running. update.info fails with code 404 for unknown jobs.
+//
+// # Usage
+//
+//	updater := updatemgr.New(
+//		updatemgr.WithStagingDir("/var/lib/u-bmc/update"),
+//		updatemgr.WithInstaller(updatemgr.NewFileInstaller("/var/lib/u-bmc/firmware", key)),
+//		updatemgr.WithDefaultTargets("bmc.0"),
+//	)
+package updatemgr
@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package updatemgr
+
+import "errors"
+
+var (
+	// ErrInvalidConfiguration indicates that the service configuration is invalid.
+	ErrInvalidConfiguration = errors.New("invalid updatemgr configuration")
+	// ErrNATSConnectionFailed indicates that the NATS connection could not be established.
+	ErrNATSConnectionFailed = errors.New("failed to connect to NATS")
+	// ErrMicroServiceCreationFailed indicates that micro service creation failed.
+	ErrMicroServiceCreationFailed = errors.New("failed to create micro service")
+	// ErrEndpointRegistrationFailed indicates that endpoint registration failed.
+	ErrEndpointRegistrationFailed = errors.New("failed to register endpoint")
+	// ErrInvalidRequest indicates a malformed update request.
+	ErrInvalidRequest = errors.New("invalid update request")
+	// ErrJobNotFound indicates the requested update job does not exist.
+	ErrJobNotFound = errors.New("update job not found")
+	// ErrUpdateInProgress indicates another update job is still running.
+	ErrUpdateInProgress = errors.New("update in progress")
+	// ErrInvalidTarget indicates an update target the installer does not support.
+	ErrInvalidTarget = errors.New("invalid update target")
+	// ErrImageTooLarge indicates an update image exceeds the maximum image size.
+	ErrImageTooLarge = errors.New("update image too large")
+	// ErrDownloadFailed indicates the update image could not be downloaded.
+	ErrDownloadFailed = errors.New("failed to download update image")
+	// ErrValidationFailed indicates the update image was rejected.
+	ErrValidationFailed = errors.New("update image validation failed")
+	// ErrInvalidSignature indicates an update image whose signature does not verify.
+	ErrInvalidSignature = errors.New("invalid update image signature")
+	// ErrInstallFailed indicates the update image could not be written to its target.
+	ErrInstallFailed = errors.New("failed to install update image")
+	// ErrVerificationFailed indicates a target does not hold the installed image.
+	ErrVerificationFailed = errors.New("update verification failed")
+)
@@ -0,0 +1,261 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package updatemgr
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SignatureSuffix is appended to the name of an update image to name its
+// detached signature.
+const SignatureSuffix = ".sig"
+
+// maxSignatureSize limits the size of detached signatures, which is far
+// above the size of an RSA-8192 signature.
+const maxSignatureSize = 4 << 10
+
+// Installer writes update images to firmware targets. Targets are named after
+// the component whose firmware they hold, such as "bmc.0" or "host.0".
+type Installer interface {
+	// Validate checks that the image at path is suitable for target.
+	Validate(ctx context.Context, target, path string) error
+	// Install writes the image at path to target and reports its progress
+	// in percent.
+	Install(ctx context.Context, target, path string, progress func(percent int)) error
+	// Verify checks that target holds the image at path.
+	Verify(ctx context.Context, target, path string) error
+}
+
+// FileInstaller installs update images as files named after their target in
+// a directory, from where the boot loader or a platform specific service
+// activates them on the next reset of the target.
+//
+// Images must come with a detached signature of their SHA-256 digest next to
+// them, named after the image with SignatureSuffix appended, as created by
+//
+//	openssl dgst -sha256 -sign key.pem -out image.bin.sig image.bin
+//
+// The signature is an ASN.1 encoded ECDSA or a PKCS #1 v1.5 RSA signature
+// and must verify with one of the public keys of the installer. Without
+// public keys, every image is rejected.
+type FileInstaller struct {
+	dir        string
+	publicKeys []crypto.PublicKey
+}
+
+// NewFileInstaller creates a FileInstaller that installs images in dir that
+// are signed with the private key of one of publicKeys, which must be ECDSA
+// or RSA keys.
+func NewFileInstaller(dir string, publicKeys ...crypto.PublicKey) *FileInstaller {
+	return &FileInstaller{dir: dir, publicKeys: publicKeys}
+}
+
+// ParsePublicKey parses a PEM encoded ECDSA or RSA public key to verify
+// update images with.
+func ParsePublicKey(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, fmt.Errorf("%w: no PEM encoded public key found", ErrInvalidConfiguration)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidConfiguration, err)
+	}
+	switch key.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported public key type %T", ErrInvalidConfiguration, key)
+	}
+}
+
+// targetPath returns the file an image for target is installed to.
+func (i *FileInstaller) targetPath(target string) (string, error) {
+	if target == "" || target == "." || target == ".." || strings.ContainsAny(target, `/\`) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidTarget, target)
+	}
+	return filepath.Join(i.dir, target+".img"), nil
+}
+
+// Validate checks the target name, that the image is not empty and that its
+// detached signature verifies with one of the public keys.
+func (i *FileInstaller) Validate(_ context.Context, target, path string) error {
+	if _, err := i.targetPath(target); err != nil {
+		return err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	if info.Size() == 0 {
+		return fmt.Errorf("%w: image is empty", ErrValidationFailed)
+	}
+	return i.verifySignature(path)
+}
+
+// verifySignature checks the detached signature of the image at path.
+func (i *FileInstaller) verifySignature(path string) error {
+	if len(i.publicKeys) == 0 {
+		return fmt.Errorf("%w: no public key configured to verify image signatures", ErrValidationFailed)
+	}
+
+	signature, err := readSignature(path + SignatureSuffix)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	digest, err := fileDigest(path)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+
+	for _, key := range i.publicKeys {
+		switch key := key.(type) {
+		case *ecdsa.PublicKey:
+			if ecdsa.VerifyASN1(key, digest, signature) {
+				return nil
+			}
+		case *rsa.PublicKey:
+			if rsa.VerifyPKCS1v15(key, crypto.SHA256, digest, signature) == nil {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("%w: %w", ErrValidationFailed, ErrInvalidSignature)
+}
+
+// readSignature reads the detached signature at path.
+func readSignature(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	signature, err := io.ReadAll(io.LimitReader(f, maxSignatureSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(signature) > maxSignatureSize {
+		return nil, fmt.Errorf("signature %s is larger than %d bytes", path, maxSignatureSize)
+	}
+	return signature, nil
+}
+
+// Install copies the image to a temporary file next to the target file and
+// renames it into place once it is completely written.
+func (i *FileInstaller) Install(ctx context.Context, target, path string, progress func(percent int)) error {
+	dst, err := i.targetPath(target)
+	if err != nil {
+		return err
+	}
+
+	src, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInstallFailed, err)
+	}
+	defer src.Close()
+
+	info, err := src.Stat()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInstallFailed, err)
+	}
+
+	if err := os.MkdirAll(i.dir, 0o750); err != nil {
+		return fmt.Errorf("%w: %w", ErrInstallFailed, err)
+	}
+	tmp, err := os.CreateTemp(i.dir, target+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInstallFailed, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = io.Copy(tmp, &progressReader{ctx: ctx, r: src, total: info.Size(), progress: progress})
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), dst)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInstallFailed, err)
+	}
+	return nil
+}
+
+// Verify compares the SHA-256 digests of the image and the target file.
+func (i *FileInstaller) Verify(_ context.Context, target, path string) error {
+	dst, err := i.targetPath(target)
+	if err != nil {
+		return err
+	}
+
+	want, err := fileDigest(path)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrVerificationFailed, err)
+	}
+	got, err := fileDigest(dst)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrVerificationFailed, err)
+	}
+	if !bytes.Equal(want, got) {
+		return fmt.Errorf("%w: %s does not match the image", ErrVerificationFailed, dst)
+	}
+	return nil
+}
+
+// fileDigest returns the SHA-256 digest of the file at path.
+func fileDigest(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+// progressReader reports the share of total bytes read in percent and stops
+// reading once ctx is canceled.
+type progressReader struct {
+	ctx      context.Context //nolint:containedctx // cancels the copy of the image
+	r        io.Reader
+	read     int64
+	total    int64
+	percent  int
+	progress func(percent int)
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	if err := p.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	n, err := p.r.Read(b)
+	p.read += int64(n)
+	if p.total > 0 && p.progress != nil {
+		if percent := int(p.read * 100 / p.total); percent != p.percent {
+			p.percent = percent
+			p.progress(percent)
+		}
+	}
+	return n, err
+}
@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package updatemgr
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileInstallerSignature(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	image := []byte("u-bmc firmware image")
+	digest := sha256.Sum256(image)
+	ecSignature, err := ecdsa.SignASN1(rand.Reader, ecKey, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaSignature, err := rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		keys      []crypto.PublicKey
+		image     []byte
+		signature []byte
+		wantErr   error
+	}{
+		{name: "ECDSA", keys: []crypto.PublicKey{&ecKey.PublicKey}, image: image, signature: ecSignature},
+		{name: "RSA", keys: []crypto.PublicKey{&rsaKey.PublicKey}, image: image, signature: rsaSignature},
+		{name: "second key", keys: []crypto.PublicKey{&otherKey.PublicKey, &rsaKey.PublicKey}, image: image, signature: rsaSignature},
+		{name: "other key", keys: []crypto.PublicKey{&otherKey.PublicKey}, image: image, signature: ecSignature, wantErr: ErrInvalidSignature},
+		{name: "tampered image", keys: []crypto.PublicKey{&ecKey.PublicKey}, image: []byte("u-bmc firmware imagf"), signature: ecSignature, wantErr: ErrInvalidSignature},
+		{name: "RSA signature for ECDSA key", keys: []crypto.PublicKey{&ecKey.PublicKey}, image: image, signature: rsaSignature, wantErr: ErrInvalidSignature},
+		{name: "no signature", keys: []crypto.PublicKey{&ecKey.PublicKey}, image: image, wantErr: os.ErrNotExist},
+		{name: "oversized signature", keys: []crypto.PublicKey{&ecKey.PublicKey}, image: image, signature: bytes.Repeat(ecSignature, maxSignatureSize), wantErr: ErrValidationFailed},
+		{name: "no public key", image: image, signature: ecSignature, wantErr: ErrValidationFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "image.bin")
+			if err := os.WriteFile(path, tt.image, 0o600); err != nil {
+				t.Fatal(err)
+			}
+			if tt.signature != nil {
+				if err := os.WriteFile(path+SignatureSuffix, tt.signature, 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			installer := NewFileInstaller(filepath.Join(dir, "firmware"), tt.keys...)
+			err := installer.Validate(t.Context(), "bmc.0", path)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrValidationFailed) {
+				t.Errorf("Validate() error = %v, want %v", err, ErrValidationFailed)
+			}
+		})
+	}
+}
+
+func TestParsePublicKey(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edKey, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encode := func(key any) []byte {
+		der, err := x509.MarshalPKIXPublicKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr error
+	}{
+		{name: "ECDSA", data: encode(&ecKey.PublicKey)},
+		{name: "Ed25519", data: encode(edKey), wantErr: ErrInvalidConfiguration},
+		{name: "not PEM", data: []byte("ssh-ed25519 AAAA"), wantErr: ErrInvalidConfiguration},
+		{name: "certificate", data: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte{0x30}}), wantErr: ErrInvalidConfiguration},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := ParsePublicKey(tt.data)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParsePublicKey() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && !ecKey.PublicKey.Equal(key) {
+				t.Errorf("ParsePublicKey() = %v, want the encoded key", key)
+			}
+		})
+	}
+}
@@ -0,0 +1,525 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package updatemgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+	"github.com/u-bmc/u-bmc/pkg/ipc"
+	"github.com/u-bmc/u-bmc/pkg/state"
+)
+
+// Apply times of update jobs.
+const (
+	// ApplyTimeImmediate resets BMC targets as soon as their image is installed.
+	ApplyTimeImmediate = "Immediate"
+	// ApplyTimeOnReset installs the image and leaves its activation to the
+	// next reset of the target.
+	ApplyTimeOnReset = "OnReset"
+)
+
+// Job states, which are the states of the firmware update state machine.
+const (
+	JobStateIdle        = "idle"
+	JobStateDownloading = "downloading"
+	JobStateValidating  = "validating"
+	JobStateUpdating    = "updating"
+	JobStateVerifying   = "verifying"
+	JobStateComplete    = "complete"
+	JobStateFailed      = "failed"
+)
+
+// Progress in percent at the end of each job phase.
+const (
+	progressDownloaded = 40
+	progressInstalled  = 90
+	progressVerified   = 100
+)
+
+// bmcTargetPrefix prefixes the names of BMC update targets.
+const bmcTargetPrefix = "bmc."
+
+// StartRequest is the request to start an update job. Exactly one of
+// ImagePath and ImageURI must be set.
+type StartRequest struct {
+	// ImagePath is an image in the staging directory. The update manager
+	// takes ownership of it and of its detached signature, named after it
+	// with SignatureSuffix appended, and removes both when the job ends.
+	ImagePath string `json:"image_path,omitempty"`
+	// ImageURI is an HTTP or HTTPS URI the image is downloaded from. Its
+	// detached signature is downloaded from the same URI with
+	// SignatureSuffix appended to the path, if the server has one.
+	ImageURI string `json:"image_uri,omitempty"`
+	// Username and Password authenticate the download of ImageURI.
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	// Targets names the components to update. The configured default
+	// targets are used if it is empty.
+	Targets []string `json:"targets,omitempty"`
+	// ApplyTime is ApplyTimeImmediate or ApplyTimeOnReset and defaults to
+	// ApplyTimeImmediate.
+	ApplyTime string `json:"apply_time,omitempty"`
+}
+
+// InfoRequest is the request for a single update job.
+type InfoRequest struct {
+	ID string `json:"id"`
+}
+
+// ListResponse holds the active and the retained finished update jobs.
+type ListResponse struct {
+	Jobs []Job `json:"jobs"`
+}
+
+// Job describes an update job.
+type Job struct {
+	ID              string     `json:"id"`
+	State           string     `json:"state"`
+	PercentComplete int        `json:"percent_complete"`
+	Targets         []string   `json:"targets"`
+	ImageURI        string     `json:"image_uri,omitempty"`
+	ApplyTime       string     `json:"apply_time"`
+	Message         string     `json:"message,omitempty"`
+	StartTime       time.Time  `json:"start_time"`
+	EndTime         *time.Time `json:"end_time,omitempty"`
+}
+
+// Finished reports whether the job has ended.
+func (j *Job) Finished() bool {
+	return j.State == JobStateComplete || j.State == JobStateFailed
+}
+
+// job is an update job and the state machine tracking its phases.
+type job struct {
+	Job
+	image   string
+	staged  bool
+	request StartRequest
+	machine *state.Machine
+}
+
+// newJob validates req and creates the job for it.
+func (s *Updatemgr) newJob(req *StartRequest) (*job, error) {
+	if (req.ImagePath == "") == (req.ImageURI == "") {
+		return nil, fmt.Errorf("%w: exactly one of image path and image URI is required", ErrInvalidRequest)
+	}
+
+	j := &job{request: *req}
+
+	if req.ImagePath != "" {
+		image, err := s.stagedImage(req.ImagePath)
+		if err != nil {
+			return nil, err
+		}
+		j.image = image
+		j.staged = true
+	} else {
+		uri, err := url.Parse(req.ImageURI)
+		if err != nil || (uri.Scheme != "http" && uri.Scheme != "https") || uri.Host == "" {
+			return nil, fmt.Errorf("%w: unsupported image URI %q", ErrInvalidRequest, req.ImageURI)
+		}
+		uri.User = nil
+		j.ImageURI = uri.String()
+	}
+
+	switch req.ApplyTime {
+	case "":
+		j.ApplyTime = ApplyTimeImmediate
+	case ApplyTimeImmediate, ApplyTimeOnReset:
+		j.ApplyTime = req.ApplyTime
+	default:
+		return nil, fmt.Errorf("%w: unsupported apply time %q", ErrInvalidRequest, req.ApplyTime)
+	}
+
+	targets := req.Targets
+	if len(targets) == 0 {
+		targets = s.config.defaultTargets
+	}
+	for _, target := range targets {
+		if target == "" {
+			return nil, fmt.Errorf("%w: empty target", ErrInvalidTarget)
+		}
+		if !slices.Contains(j.Targets, target) {
+			j.Targets = append(j.Targets, target)
+		}
+	}
+
+	s.nextID++
+	j.ID = strconv.FormatUint(s.nextID, 10)
+	j.State = JobStateIdle
+	j.StartTime = time.Now().UTC()
+
+	machine, err := state.NewFirmwareUpdateStateMachine("update."+j.ID, state.WithStateEntry(
+		func(_ context.Context, _, to string) error {
+			s.mu.Lock()
+			j.State = to
+			s.mu.Unlock()
+			return nil
+		}))
+	if err != nil {
+		return nil, err
+	}
+	j.machine = machine
+
+	return j, nil
+}
+
+// stagedImage resolves path and checks that it lies in the staging directory.
+func (s *Updatemgr) stagedImage(path string) (string, error) {
+	dir, err := filepath.Abs(s.config.stagingDir)
+	if err != nil {
+		return "", err
+	}
+	image, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	if rel, err := filepath.Rel(dir, image); err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("%w: image %q is not in the staging directory", ErrInvalidRequest, path)
+	}
+
+	info, err := os.Stat(image)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+	if !info.Mode().IsRegular() {
+		return "", fmt.Errorf("%w: image %q is not a regular file", ErrInvalidRequest, path)
+	}
+	if info.Size() > s.config.maxImageSize {
+		return "", fmt.Errorf("%w: %d bytes", ErrImageTooLarge, info.Size())
+	}
+
+	return image, nil
+}
+
+// startJob creates a job for req and runs it in the background until it ends
+// or the service stops. Only one job may be active at a time.
+func (s *Updatemgr) startJob(ctx context.Context, req *StartRequest) (Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.active != nil {
+		return Job{}, fmt.Errorf("%w: job %s", ErrUpdateInProgress, s.active.ID)
+	}
+
+	j, err := s.newJob(req)
+	if err != nil {
+		return Job{}, err
+	}
+	if err := j.machine.Start(ctx); err != nil {
+		return Job{}, err
+	}
+
+	s.active = j
+	s.jobs = append(s.jobs, j)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.runJob(s.ctx, j)
+	}()
+
+	return j.snapshot(), nil
+}
+
+// snapshot returns a copy of the job description. The caller must hold the
+// service mutex.
+func (j *job) snapshot() Job {
+	job := j.Job
+	job.Targets = slices.Clone(j.Targets)
+	return job
+}
+
+// setProgress records the progress of j in percent.
+func (s *Updatemgr) setProgress(j *job, percent int) {
+	s.mu.Lock()
+	j.PercentComplete = percent
+	s.mu.Unlock()
+}
+
+// runJob walks j through its phases and records the outcome.
+func (s *Updatemgr) runJob(ctx context.Context, j *job) {
+	s.logger.InfoContext(ctx, "Starting update job",
+		"job", j.ID,
+		"targets", j.Targets,
+		"apply_time", j.ApplyTime)
+
+	err := s.runPhases(ctx, j)
+
+	s.mu.Lock()
+	end := time.Now().UTC()
+	j.EndTime = &end
+	if err != nil {
+		j.Message = err.Error()
+	} else {
+		j.PercentComplete = progressVerified
+	}
+	s.active = nil
+	s.pruneJobs()
+	s.mu.Unlock()
+
+	if j.image != "" {
+		for _, file := range []string{j.image, j.image + SignatureSuffix} {
+			if err := os.Remove(file); err != nil && !errors.Is(err, os.ErrNotExist) {
+				s.logger.WarnContext(ctx, "Failed to remove update image", "job", j.ID, "image", file, "error", err)
+			}
+		}
+	}
+
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Update job failed", "job", j.ID, "error", err)
+		return
+	}
+
+	s.logger.InfoContext(ctx, "Update job completed", "job", j.ID)
+
+	if j.ApplyTime == ApplyTimeImmediate {
+		s.activate(ctx, j)
+	}
+}
+
+// runPhases downloads, validates, installs and verifies the image of j,
+// firing the matching state machine triggers along the way.
+func (s *Updatemgr) runPhases(ctx context.Context, j *job) error {
+	phases := []struct {
+		run     func(context.Context, *job) error
+		success string
+		failure string
+	}{
+		{s.download, "download_complete", "download_failed"},
+		{s.validate, "validation_complete", "validation_failed"},
+		{s.install, "update_complete", "update_failed"},
+		{s.verify, "verification_complete", "verification_failed"},
+	}
+
+	if err := j.machine.Fire(ctx, "start_download"); err != nil {
+		s.mu.Lock()
+		j.State = JobStateFailed
+		s.mu.Unlock()
+		return err
+	}
+
+	for _, phase := range phases {
+		if err := phase.run(ctx, j); err != nil {
+			if fireErr := j.machine.Fire(context.WithoutCancel(ctx), phase.failure); fireErr != nil {
+				s.mu.Lock()
+				j.State = JobStateFailed
+				s.mu.Unlock()
+			}
+			return err
+		}
+		if err := j.machine.Fire(ctx, phase.success); err != nil {
+			s.mu.Lock()
+			j.State = JobStateFailed
+			s.mu.Unlock()
+			return err
+		}
+	}
+
+	return nil
+}
+
+// download fetches the image of j and its signature into the staging
+// directory unless they were staged by the requester.
+func (s *Updatemgr) download(ctx context.Context, j *job) error {
+	if j.staged {
+		s.setProgress(j, progressDownloaded)
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.config.transferTimeout)
+	defer cancel()
+
+	resp, err := s.get(ctx, j, j.request.ImageURI)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrDownloadFailed, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: %s", ErrDownloadFailed, resp.Status)
+	}
+	if resp.ContentLength > s.config.maxImageSize {
+		return fmt.Errorf("%w: %d bytes", ErrImageTooLarge, resp.ContentLength)
+	}
+
+	if err := os.MkdirAll(s.config.stagingDir, 0o750); err != nil {
+		return fmt.Errorf("%w: %w", ErrDownloadFailed, err)
+	}
+	f, err := os.CreateTemp(s.config.stagingDir, "download-*.img")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrDownloadFailed, err)
+	}
+	s.mu.Lock()
+	j.image = f.Name()
+	s.mu.Unlock()
+
+	body := &progressReader{
+		ctx:   ctx,
+		r:     io.LimitReader(resp.Body, s.config.maxImageSize+1),
+		total: resp.ContentLength,
+		progress: func(percent int) {
+			s.setProgress(j, percent*progressDownloaded/100)
+		},
+	}
+	n, err := io.Copy(f, body)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrDownloadFailed, err)
+	}
+	if n > s.config.maxImageSize {
+		return fmt.Errorf("%w: more than %d bytes", ErrImageTooLarge, s.config.maxImageSize)
+	}
+
+	if err := s.downloadSignature(ctx, j); err != nil {
+		return fmt.Errorf("%w: %w", ErrDownloadFailed, err)
+	}
+
+	s.setProgress(j, progressDownloaded)
+	return nil
+}
+
+// downloadSignature fetches the detached signature of the image of j from
+// next to the image URI. A missing signature is left to the installer to
+// reject.
+func (s *Updatemgr) downloadSignature(ctx context.Context, j *job) error {
+	uri, err := url.Parse(j.request.ImageURI)
+	if err != nil {
+		return err
+	}
+	uri.Path += SignatureSuffix
+	if uri.RawPath != "" {
+		uri.RawPath += SignatureSuffix
+	}
+
+	resp, err := s.get(ctx, j, uri.String())
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("signature: %s", resp.Status)
+	}
+
+	signature, err := io.ReadAll(io.LimitReader(resp.Body, maxSignatureSize+1))
+	if err != nil {
+		return err
+	}
+	if len(signature) > maxSignatureSize {
+		return fmt.Errorf("signature larger than %d bytes", maxSignatureSize)
+	}
+	return os.WriteFile(j.image+SignatureSuffix, signature, 0o600)
+}
+
+// get sends a GET request for uri with the credentials of j.
+func (s *Updatemgr) get(ctx context.Context, j *job, uri string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	if j.request.Username != "" {
+		req.SetBasicAuth(j.request.Username, j.request.Password)
+	}
+	return http.DefaultClient.Do(req)
+}
+
+// validate lets the installer check the image for every target.
+func (s *Updatemgr) validate(ctx context.Context, j *job) error {
+	for _, target := range j.Targets {
+		if err := s.config.installer.Validate(ctx, target, j.image); err != nil {
+			return fmt.Errorf("%s: %w", target, err)
+		}
+	}
+	return nil
+}
+
+// install writes the image to every target.
+func (s *Updatemgr) install(ctx context.Context, j *job) error {
+	span := progressInstalled - progressDownloaded
+	for i, target := range j.Targets {
+		progress := func(percent int) {
+			s.setProgress(j, progressDownloaded+(i*100+percent)*span/(len(j.Targets)*100))
+		}
+		if err := s.config.installer.Install(ctx, target, j.image, progress); err != nil {
+			return fmt.Errorf("%s: %w", target, err)
+		}
+		s.logger.InfoContext(ctx, "Installed update image", "job", j.ID, "target", target)
+	}
+	s.setProgress(j, progressInstalled)
+	return nil
+}
+
+// verify checks that every target holds the image.
+func (s *Updatemgr) verify(ctx context.Context, j *job) error {
+	span := progressVerified - progressInstalled
+	for i, target := range j.Targets {
+		if err := s.config.installer.Verify(ctx, target, j.image); err != nil {
+			return fmt.Errorf("%s: %w", target, err)
+		}
+		s.setProgress(j, progressInstalled+(i+1)*span/len(j.Targets))
+	}
+	return nil
+}
+
+// activate resets the BMC targets of j so that they boot the new image.
+// Other targets pick up their image on their next reset.
+func (s *Updatemgr) activate(ctx context.Context, j *job) {
+	for _, target := range j.Targets {
+		if !strings.HasPrefix(target, bmcTargetPrefix) {
+			continue
+		}
+
+		data, err := (&schemav1alpha1.ChangeManagementControllerStateRequest{
+			ControllerName: target,
+			Action:         schemav1alpha1.ManagementControllerAction_MANAGEMENT_CONTROLLER_ACTION_REBOOT,
+		}).MarshalVT()
+		if err == nil {
+			_, err = s.nc.Request(ipc.SubjectBMCControl, data, s.config.requestTimeout)
+		}
+		if err != nil {
+			s.logger.ErrorContext(ctx, "Failed to reset BMC after update", "job", j.ID, "target", target, "error", err)
+			continue
+		}
+		s.logger.InfoContext(ctx, "Reset BMC to activate update", "job", j.ID, "target", target)
+	}
+}
+
+// pruneJobs drops the oldest finished jobs beyond the configured history. The
+// caller must hold the service mutex.
+func (s *Updatemgr) pruneJobs() {
+	finished := 0
+	for _, j := range s.jobs {
+		if j.Finished() {
+			finished++
+		}
+	}
+
+	jobs := s.jobs[:0]
+	for _, j := range s.jobs {
+		if j.Finished() && finished > s.config.jobHistory {
+			finished--
+			continue
+		}
+		jobs = append(jobs, j)
+	}
+	clear(s.jobs[len(jobs):])
+	s.jobs = jobs
+}
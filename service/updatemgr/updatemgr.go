@@ -4,10 +4,21 @@ package updatemgr
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
 
 	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/micro"
+	"github.com/u-bmc/u-bmc/pkg/ipc"
 	"github.com/u-bmc/u-bmc/pkg/log"
+	"github.com/u-bmc/u-bmc/pkg/telemetry"
 	"github.com/u-bmc/u-bmc/service"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Compile-time assertion that UpdateMgr implements service.Service.
@@ -15,13 +26,31 @@ var _ service.Service = (*Updatemgr)(nil)
 
 // Updatemgr provides firmware and software update management for BMC components.
 type Updatemgr struct {
-	config config
+	config       config
+	nc           *nats.Conn
+	microService micro.Service
+	logger       *slog.Logger
+	tracer       trace.Tracer
+	ctx          context.Context //nolint:containedctx // bounds update jobs, which outlive the requests starting them
+	wg           sync.WaitGroup
+
+	mu     sync.Mutex
+	jobs   []*job
+	active *job
+	nextID uint64
 }
 
 // New creates a new Updatemgr instance with the provided options.
 func New(opts ...Option) *Updatemgr {
 	cfg := &config{
-		name: "updatemgr",
+		name:            DefaultServiceName,
+		stagingDir:      DefaultStagingDir,
+		installer:       NewFileInstaller(DefaultInstallDir),
+		maxImageSize:    DefaultMaxImageSize,
+		transferTimeout: DefaultTransferTimeout,
+		jobHistory:      DefaultJobHistory,
+		defaultTargets:  []string{DefaultTarget},
+		requestTimeout:  DefaultRequestTimeout,
 	}
 	for _, opt := range opts {
 		opt.apply(cfg)
@@ -31,17 +60,186 @@ func New(opts ...Option) *Updatemgr {
 	}
 }
 
+// Name returns the service name.
 func (s *Updatemgr) Name() string {
 	return s.config.name
 }
 
+// Run starts the update manager and serves update jobs until the context is
+// canceled.
 func (s *Updatemgr) Run(ctx context.Context, ipcConn nats.InProcessConnProvider) error {
-	l := log.GetGlobalLogger()
+	s.tracer = otel.Tracer(s.config.name)
+
+	ctx, span := s.tracer.Start(ctx, "updatemgr.Run")
+	defer span.End()
 
-	l.InfoContext(ctx, "Starting update manager", "service", s.config.name)
+	s.logger = log.GetGlobalLogger().With("service", s.config.name)
+	s.logger.InfoContext(ctx, "Starting update manager",
+		"staging_dir", s.config.stagingDir,
+		"max_image_size", s.config.maxImageSize)
+
+	if err := s.config.Validate(); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("%w: %w", ErrInvalidConfiguration, err)
+	}
+
+	nc, err := nats.Connect("", nats.InProcessServer(ipcConn))
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("%w: %w", ErrNATSConnectionFailed, err)
+	}
+	s.nc = nc
+	defer nc.Drain() //nolint:errcheck
+
+	s.microService, err = micro.AddService(nc, micro.Config{
+		Name:        s.config.name,
+		Description: DefaultDescription,
+		Version:     DefaultServiceVersion,
+	})
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("%w: %w", ErrMicroServiceCreationFailed, err)
+	}
+
+	s.ctx = ctx
+
+	if err := s.registerEndpoints(ctx); err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	span.SetAttributes(attribute.String("service.name", s.config.name))
 
 	<-ctx.Done()
-	l.InfoContext(ctx, "Stopping update manager", "service", s.config.name, "reason", ctx.Err())
 
-	return ctx.Err()
+	err = ctx.Err()
+	ctx = context.WithoutCancel(ctx)
+	s.logger.InfoContext(ctx, "Stopping update manager", "reason", err)
+
+	if s.microService != nil {
+		_ = s.microService.Stop()
+	}
+	s.wg.Wait()
+
+	return err
+}
+
+func (s *Updatemgr) registerEndpoints(ctx context.Context) error {
+	groups := make(map[string]micro.Group)
+
+	endpoints := []struct {
+		subject string
+		handler func(context.Context, micro.Request)
+	}{
+		{ipc.SubjectUpdateStart, s.handleStart},
+		{ipc.SubjectUpdateInfo, s.handleInfo},
+		{ipc.SubjectUpdateList, s.handleList},
+	}
+	for _, ep := range endpoints {
+		if err := ipc.RegisterEndpointWithGroupCache(s.microService, ep.subject,
+			micro.HandlerFunc(s.createRequestHandler(ctx, ep.handler)), groups); err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrEndpointRegistrationFailed, ep.subject, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Updatemgr) createRequestHandler(parentCtx context.Context, handler func(context.Context, micro.Request)) micro.HandlerFunc {
+	return func(req micro.Request) {
+		ctx := context.WithoutCancel(telemetry.GetCtxFromReq(req))
+		if parentCtx.Err() != nil {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithCancel(ctx)
+			cancel()
+		}
+
+		ctx, span := s.tracer.Start(ctx, "updatemgr.handleRequest")
+		span.SetAttributes(
+			attribute.String("subject", req.Subject()),
+			attribute.String("service", s.config.name),
+		)
+		defer span.End()
+
+		handler(ctx, req) //nolint:contextcheck
+	}
+}
+
+// handleStart starts an update job and responds with its initial description.
+func (s *Updatemgr) handleStart(ctx context.Context, req micro.Request) {
+	var request StartRequest
+	if err := json.Unmarshal(req.Data(), &request); err != nil {
+		_ = req.Error("400", "invalid request format", nil)
+		return
+	}
+
+	job, err := s.startJob(ctx, &request)
+	switch {
+	case err == nil:
+	case errors.Is(err, ErrInvalidRequest), errors.Is(err, ErrInvalidTarget), errors.Is(err, ErrImageTooLarge):
+		_ = req.Error("400", err.Error(), nil)
+		return
+	case errors.Is(err, ErrUpdateInProgress):
+		_ = req.Error("409", err.Error(), nil)
+		return
+	default:
+		s.logger.ErrorContext(ctx, "Failed to start update job", "error", err)
+		_ = req.Error("500", "failed to start update job", nil)
+		return
+	}
+
+	s.respond(ctx, req, job)
+}
+
+// handleInfo responds with the description of a single update job.
+func (s *Updatemgr) handleInfo(ctx context.Context, req micro.Request) {
+	var request InfoRequest
+	if err := json.Unmarshal(req.Data(), &request); err != nil {
+		_ = req.Error("400", "invalid request format", nil)
+		return
+	}
+
+	s.mu.Lock()
+	var job *Job
+	for _, j := range s.jobs {
+		if j.ID == request.ID {
+			snapshot := j.snapshot()
+			job = &snapshot
+			break
+		}
+	}
+	s.mu.Unlock()
+
+	if job == nil {
+		_ = req.Error("404", fmt.Sprintf("%s: %s", ErrJobNotFound, request.ID), nil)
+		return
+	}
+
+	s.respond(ctx, req, job)
+}
+
+// handleList responds with all retained update jobs, oldest first.
+func (s *Updatemgr) handleList(ctx context.Context, req micro.Request) {
+	s.mu.Lock()
+	response := ListResponse{Jobs: make([]Job, 0, len(s.jobs))}
+	for _, j := range s.jobs {
+		response.Jobs = append(response.Jobs, j.snapshot())
+	}
+	s.mu.Unlock()
+
+	s.respond(ctx, req, &response)
+}
+
+// respond sends v as JSON response to req.
+func (s *Updatemgr) respond(ctx context.Context, req micro.Request, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to marshal response", "subject", req.Subject(), "error", err)
+		_ = req.Error("500", "failed to marshal response", nil)
+		return
+	}
+
+	if err := req.Respond(data); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to send response", "subject", req.Subject(), "error", err)
+	}
 }
@@ -2,9 +2,232 @@
 
 package usermgr
 
+import (
+	"crypto/tls"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	schemav1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+	"github.com/u-bmc/u-bmc/pkg/tacacs"
+)
+
+// Default configuration constants.
+const (
+	DefaultServiceName          = "usermgr"
+	DefaultServiceDescription   = "User management service"
+	DefaultServiceVersion       = "1.0.0"
+	DefaultBucket               = "usermgr_users"
+	DefaultHashAlgorithm        = schemav1alpha1.PasswordHashAlgorithm_PASSWORD_HASH_ALGORITHM_ARGON2ID
+	DefaultAdminUsername        = "admin"
+	DefaultAdminPassword        = "admin"
+	DefaultAdminRole            = "Administrator"
+	DefaultRequestTimeout       = 5 * time.Second
+	DefaultGeneratedPasswordLen = 16
+
+	DefaultLockoutThreshold         = 5
+	DefaultLockoutDuration          = 5 * time.Minute
+	DefaultLockoutResetAfter        = 5 * time.Minute
+	DefaultPasswordMinLength        = 8
+	DefaultPasswordCharacterClasses = 3
+	DefaultPasswordHistory          = 5
+	DefaultPasswordMaxAge           = 0
+
+	DefaultSecretKeyPath = "/var/lib/u-bmc/usermgr/secret.key"
+	DefaultTOTPIssuer    = "u-bmc"
+	DefaultTOTPRequired  = false
+
+	DefaultLDAPTimeout    = 5 * time.Second
+	DefaultLDAPUserFilter = "(uid=%s)"
+	DefaultADUserFilter   = "(sAMAccountName=%s)"
+
+	DefaultRemoteTimeout      = 5 * time.Second
+	DefaultRADIUSVendorID     = 9 // Cisco
+	DefaultRADIUSVendorType   = 1 // Cisco-AVPair
+	DefaultRADIUSPrivilegeKey = "shell:priv-lvl"
+	DefaultTACACSService      = "shell"
+	DefaultTACACSPrivilegeKey = "priv-lvl"
+	DefaultTACACSAuthenType   = tacacs.AuthenTypePAP
+)
+
+// AuthMethod names a way of authenticating users.
+type AuthMethod string
+
+// Authentication methods.
+const (
+	AuthMethodLocal  AuthMethod = "local"
+	AuthMethodLDAP   AuthMethod = "ldap"
+	AuthMethodRADIUS AuthMethod = "radius"
+	AuthMethodTACACS AuthMethod = "tacacs"
+)
+
+// LDAPGroupRole maps the members of a directory group to a role.
+type LDAPGroupRole struct {
+	Group string // DN of the group, compared case-insensitively
+	Role  string // role of its members, such as Administrator
+}
+
+// LDAPConfig configures authentication against an LDAP directory or Active
+// Directory.
+type LDAPConfig struct {
+	// URLs are the ldap:// or ldaps:// URLs of the directory servers, tried
+	// in order until one can be reached.
+	URLs []string
+	// StartTLS secures ldap:// connections with StartTLS.
+	StartTLS bool
+	// TLSConfig verifies the servers of LDAPS and StartTLS connections.
+	TLSConfig *tls.Config
+	// BindDN and BindPassword are the service account users are looked up
+	// with. Without them, the lookup uses an anonymous bind.
+	BindDN       string
+	BindPassword string
+	// SearchBase is the subtree users and groups are searched in.
+	SearchBase string
+	// UserFilter finds the entry of a user, %s being replaced by the escaped
+	// username. It defaults to DefaultLDAPUserFilter or DefaultADUserFilter.
+	UserFilter string
+	// GroupRoles maps groups to roles. The first group a user is a member of
+	// decides its role.
+	GroupRoles []LDAPGroupRole
+	// DefaultRole is the role of users that are in none of the groups. If
+	// empty, such users are refused.
+	DefaultRole string
+	// ActiveDirectory marks the directory as Active Directory, whose users
+	// are recorded with the AD source and whose nested groups are resolved
+	// by the server.
+	ActiveDirectory bool
+	// NestedGroups also maps users to the roles of the groups their groups
+	// are members of.
+	NestedGroups bool
+	// Primary consults the directory before local accounts instead of
+	// after them. WithAuthenticationOrder takes precedence.
+	Primary bool
+	// Timeout bounds each attempt to reach a server.
+	Timeout time.Duration
+	// CacheTTL is how long a successful login is remembered to let the user
+	// in while no server can be reached. Zero disables the cache.
+	CacheTTL time.Duration
+}
+
+// RADIUSServer is a RADIUS server.
+type RADIUSServer struct {
+	// Address is the host and port of the server, the port defaulting to
+	// 1812.
+	Address string
+	// Secret is the secret shared with the server.
+	Secret string
+	// Timeout bounds the wait for an answer of the server, DefaultRemoteTimeout if zero.
+	Timeout time.Duration
+}
+
+// RADIUSConfig configures authentication against RADIUS servers.
+type RADIUSConfig struct {
+	// Servers are tried in order until one of them answers.
+	Servers []RADIUSServer
+	// CHAP sends a CHAP response instead of the password hidden with PAP.
+	CHAP bool
+	// NASIdentifier identifies the BMC to the servers, the service name if
+	// empty.
+	NASIdentifier string
+	// RequireMessageAuthenticator rejects answers without a
+	// Message-Authenticator attribute.
+	RequireMessageAuthenticator bool
+	// PrivilegeVendorID and PrivilegeVendorType select the vendor-specific
+	// attribute carrying the privilege of a user, Cisco-AVPair by default.
+	PrivilegeVendorID   uint32
+	PrivilegeVendorType byte
+	// PrivilegeKey names the attribute value pair of the vendor attribute
+	// holding the privilege, shell:priv-lvl for the default attribute. If
+	// empty, the whole attribute value is the privilege.
+	PrivilegeKey string
+	// PrivilegeRoles maps privileges to roles, the first matching mapping
+	// deciding the role of a user.
+	PrivilegeRoles []PrivilegeRole
+	// DefaultRole is the role of users whose privilege is not mapped. If
+	// empty, such users are refused.
+	DefaultRole string
+	// CacheTTL is how long a successful login is remembered to let the user
+	// in while no server can be reached. Zero disables the cache.
+	CacheTTL time.Duration
+}
+
+// TACACSServer is a TACACS+ server.
+type TACACSServer struct {
+	// Address is the host and port of the server, the port defaulting to 49.
+	Address string
+	// Secret is the key shared with the server.
+	Secret string
+	// Timeout bounds the authentication and authorization of a user by the
+	// server, DefaultRemoteTimeout if zero.
+	Timeout time.Duration
+}
+
+// TACACSConfig configures authentication and authorization against TACACS+
+// servers.
+type TACACSConfig struct {
+	// Servers are tried in order until one of them answers.
+	Servers []TACACSServer
+	// AuthenType is the authentication type, DefaultTACACSAuthenType if zero.
+	AuthenType tacacs.AuthenType
+	// Service is the service users are authorized for,
+	// DefaultTACACSService if empty.
+	Service string
+	// PrivilegeKey names the argument of the authorization holding the
+	// privilege of a user, DefaultTACACSPrivilegeKey if empty.
+	PrivilegeKey string
+	// PrivilegeRoles maps privileges to roles, the first matching mapping
+	// deciding the role of a user.
+	PrivilegeRoles []PrivilegeRole
+	// DefaultRole is the role of users whose privilege is not mapped. If
+	// empty, such users are refused.
+	DefaultRole string
+	// CacheTTL is how long a successful login is remembered to let the user
+	// in while no server can be reached. Zero disables the cache.
+	CacheTTL time.Duration
+}
+
 // config holds the configuration for the user manager service.
 type config struct {
-	name string
+	name        string
+	description string
+	version     string
+
+	// Persistence configuration
+	bucket         string
+	requestTimeout time.Duration
+
+	// Password configuration
+	hashAlgorithm        schemav1alpha1.PasswordHashAlgorithm
+	generatedPasswordLen int
+
+	// Lockout policy of accounts without a Redfish lockout policy
+	lockoutThreshold  int32
+	lockoutDuration   time.Duration
+	lockoutResetAfter time.Duration
+
+	// Password policy
+	passwordMinLength        int
+	passwordCharacterClasses int
+	passwordHistory          int
+	passwordMaxAge           time.Duration
+	dictionaryPath           string
+
+	// Second factor
+	secretKeyPath string
+	totpIssuer    string
+	totpRequired  bool
+
+	// Remote authentication, nil if disabled, and the order of the methods
+	ldap      *LDAPConfig
+	radius    *RADIUSConfig
+	tacacs    *TACACSConfig
+	authOrder []AuthMethod
+
+	// Default account created on first boot
+	adminUsername string
+	adminPassword string
+	adminRole     string
 }
 
 // Option represents a configuration option for the user manager service.
@@ -20,8 +243,471 @@ func (o *nameOption) apply(c *config) {
 	c.name = o.name
 }
 
+// WithServiceName sets the service name.
 func WithServiceName(name string) Option {
 	return &nameOption{
 		name: name,
 	}
 }
+
+type descriptionOption struct {
+	description string
+}
+
+func (o *descriptionOption) apply(c *config) {
+	c.description = o.description
+}
+
+// WithServiceDescription sets the service description.
+func WithServiceDescription(description string) Option {
+	return &descriptionOption{description: description}
+}
+
+type versionOption struct {
+	version string
+}
+
+func (o *versionOption) apply(c *config) {
+	c.version = o.version
+}
+
+// WithServiceVersion sets the service version.
+func WithServiceVersion(version string) Option {
+	return &versionOption{version: version}
+}
+
+type bucketOption struct {
+	bucket string
+}
+
+func (o *bucketOption) apply(c *config) {
+	c.bucket = o.bucket
+}
+
+// WithBucket sets the JetStream key-value bucket users are persisted in.
+func WithBucket(bucket string) Option {
+	return &bucketOption{bucket: bucket}
+}
+
+type requestTimeoutOption struct {
+	timeout time.Duration
+}
+
+func (o *requestTimeoutOption) apply(c *config) {
+	c.requestTimeout = o.timeout
+}
+
+// WithRequestTimeout sets the timeout for JetStream operations.
+func WithRequestTimeout(timeout time.Duration) Option {
+	return &requestTimeoutOption{timeout: timeout}
+}
+
+type hashAlgorithmOption struct {
+	algorithm schemav1alpha1.PasswordHashAlgorithm
+}
+
+func (o *hashAlgorithmOption) apply(c *config) {
+	c.hashAlgorithm = o.algorithm
+}
+
+// WithHashAlgorithm sets the algorithm new passwords are hashed with.
+// Passwords hashed with another algorithm, such as imported bcrypt hashes,
+// are rehashed with it on their next successful authentication.
+func WithHashAlgorithm(algorithm schemav1alpha1.PasswordHashAlgorithm) Option {
+	return &hashAlgorithmOption{algorithm: algorithm}
+}
+
+type generatedPasswordLenOption struct {
+	length int
+}
+
+func (o *generatedPasswordLenOption) apply(c *config) {
+	c.generatedPasswordLen = o.length
+}
+
+// WithGeneratedPasswordLength sets the length of the passwords generated by
+// password resets that ask for one.
+func WithGeneratedPasswordLength(length int) Option {
+	return &generatedPasswordLenOption{length: length}
+}
+
+type defaultAdminOption struct {
+	username string
+	password string
+	role     string
+}
+
+func (o *defaultAdminOption) apply(c *config) {
+	c.adminUsername = o.username
+	c.adminPassword = o.password
+	c.adminRole = o.role
+}
+
+// WithDefaultAdmin sets the account created when the user store is empty.
+// The password must be changed on first login. An empty username disables
+// the default account.
+func WithDefaultAdmin(username, password, role string) Option {
+	return &defaultAdminOption{
+		username: username,
+		password: password,
+		role:     role,
+	}
+}
+
+type lockoutPolicyOption struct {
+	threshold  int32
+	duration   time.Duration
+	resetAfter time.Duration
+}
+
+func (o *lockoutPolicyOption) apply(c *config) {
+	c.lockoutThreshold = o.threshold
+	c.lockoutDuration = o.duration
+	c.lockoutResetAfter = o.resetAfter
+}
+
+// WithLockoutPolicy sets the lockout policy of accounts without a Redfish
+// lockout policy. An account is locked for duration after threshold failed
+// logins, each of which happened within resetAfter of the previous one. A
+// zero threshold or duration disables the lockout. The policy is stored as
+// the Redfish lockout policy of the default account.
+func WithLockoutPolicy(threshold int32, duration, resetAfter time.Duration) Option {
+	return &lockoutPolicyOption{
+		threshold:  threshold,
+		duration:   duration,
+		resetAfter: resetAfter,
+	}
+}
+
+type passwordMinLengthOption struct {
+	length int
+}
+
+func (o *passwordMinLengthOption) apply(c *config) {
+	c.passwordMinLength = o.length
+}
+
+// WithPasswordMinLength sets the minimum length of new passwords, at least 8.
+func WithPasswordMinLength(length int) Option {
+	return &passwordMinLengthOption{length: length}
+}
+
+type passwordCharacterClassesOption struct {
+	classes int
+}
+
+func (o *passwordCharacterClassesOption) apply(c *config) {
+	c.passwordCharacterClasses = o.classes
+}
+
+// WithPasswordCharacterClasses sets how many of lowercase letters, uppercase
+// letters, digits and symbols new passwords must contain.
+func WithPasswordCharacterClasses(classes int) Option {
+	return &passwordCharacterClassesOption{classes: classes}
+}
+
+type passwordHistoryOption struct {
+	history int
+}
+
+func (o *passwordHistoryOption) apply(c *config) {
+	c.passwordHistory = o.history
+}
+
+// WithPasswordHistory sets how many previous passwords are remembered and
+// may not be reused. The current password can never be reused.
+func WithPasswordHistory(history int) Option {
+	return &passwordHistoryOption{history: history}
+}
+
+type passwordMaxAgeOption struct {
+	maxAge time.Duration
+}
+
+func (o *passwordMaxAgeOption) apply(c *config) {
+	c.passwordMaxAge = o.maxAge
+}
+
+// WithPasswordMaxAge sets the time after which passwords expire and must be
+// changed with ChangePassword. Zero disables password expiry.
+func WithPasswordMaxAge(maxAge time.Duration) Option {
+	return &passwordMaxAgeOption{maxAge: maxAge}
+}
+
+type dictionaryPathOption struct {
+	path string
+}
+
+func (o *dictionaryPathOption) apply(c *config) {
+	c.dictionaryPath = o.path
+}
+
+// WithPasswordDictionary sets a file of words, one per line, that new
+// passwords may not be, in addition to a built-in list of common passwords.
+func WithPasswordDictionary(path string) Option {
+	return &dictionaryPathOption{path: path}
+}
+
+type secretKeyPathOption struct {
+	path string
+}
+
+func (o *secretKeyPathOption) apply(c *config) {
+	c.secretKeyPath = o.path
+}
+
+// WithSecretKeyPath sets the file holding the key TOTP secrets are encrypted
+// with in the user bucket. The key is created on first start.
+func WithSecretKeyPath(path string) Option {
+	return &secretKeyPathOption{path: path}
+}
+
+type totpIssuerOption struct {
+	issuer string
+}
+
+func (o *totpIssuerOption) apply(c *config) {
+	c.totpIssuer = o.issuer
+}
+
+// WithTOTPIssuer sets the issuer authenticator apps show for TOTP secrets.
+func WithTOTPIssuer(issuer string) Option {
+	return &totpIssuerOption{issuer: issuer}
+}
+
+type totpRequiredOption struct {
+	required bool
+}
+
+func (o *totpRequiredOption) apply(c *config) {
+	c.totpRequired = o.required
+}
+
+// WithTOTPRequired requires a TOTP second factor of all accounts, not only
+// of those with auth_data.totp_required set.
+func WithTOTPRequired(required bool) Option {
+	return &totpRequiredOption{required: required}
+}
+
+type ldapOption struct {
+	config LDAPConfig
+}
+
+func (o *ldapOption) apply(c *config) {
+	cfg := o.config
+	if cfg.UserFilter == "" {
+		cfg.UserFilter = DefaultLDAPUserFilter
+		if cfg.ActiveDirectory {
+			cfg.UserFilter = DefaultADUserFilter
+		}
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = DefaultLDAPTimeout
+	}
+	c.ldap = &cfg
+}
+
+// WithLDAP authenticates users against an LDAP directory or Active
+// Directory, recording the users it lets in as accounts with the LDAP or AD
+// source and the role their groups map to.
+func WithLDAP(config LDAPConfig) Option {
+	return &ldapOption{config: config}
+}
+
+type radiusOption struct {
+	config RADIUSConfig
+}
+
+func (o *radiusOption) apply(c *config) {
+	cfg := o.config
+	cfg.Servers = slices.Clone(cfg.Servers)
+	for i := range cfg.Servers {
+		if cfg.Servers[i].Timeout == 0 {
+			cfg.Servers[i].Timeout = DefaultRemoteTimeout
+		}
+	}
+	if cfg.PrivilegeVendorID == 0 && cfg.PrivilegeVendorType == 0 {
+		cfg.PrivilegeVendorID = DefaultRADIUSVendorID
+		cfg.PrivilegeVendorType = DefaultRADIUSVendorType
+		if cfg.PrivilegeKey == "" {
+			cfg.PrivilegeKey = DefaultRADIUSPrivilegeKey
+		}
+	}
+	c.radius = &cfg
+}
+
+// WithRADIUS authenticates users against RADIUS servers, recording the
+// users they let in as accounts with the RADIUS source and the role their
+// privilege maps to.
+func WithRADIUS(config RADIUSConfig) Option {
+	return &radiusOption{config: config}
+}
+
+type tacacsOption struct {
+	config TACACSConfig
+}
+
+func (o *tacacsOption) apply(c *config) {
+	cfg := o.config
+	cfg.Servers = slices.Clone(cfg.Servers)
+	for i := range cfg.Servers {
+		if cfg.Servers[i].Timeout == 0 {
+			cfg.Servers[i].Timeout = DefaultRemoteTimeout
+		}
+	}
+	if cfg.AuthenType == 0 {
+		cfg.AuthenType = DefaultTACACSAuthenType
+	}
+	if cfg.Service == "" {
+		cfg.Service = DefaultTACACSService
+	}
+	if cfg.PrivilegeKey == "" {
+		cfg.PrivilegeKey = DefaultTACACSPrivilegeKey
+	}
+	c.tacacs = &cfg
+}
+
+// WithTACACS authenticates and authorizes users against TACACS+ servers,
+// recording the users they let in as accounts with the TACACS source and
+// the role their authorized privilege maps to.
+func WithTACACS(config TACACSConfig) Option {
+	return &tacacsOption{config: config}
+}
+
+type authOrderOption struct {
+	methods []AuthMethod
+}
+
+func (o *authOrderOption) apply(c *config) {
+	c.authOrder = o.methods
+}
+
+// WithAuthenticationOrder sets the order the authentication methods are
+// tried in. A method passes a login on to the next one unless it let the
+// user in before or it lets the user in now, so with remote methods first,
+// local accounts serve as a fallback for when the servers are down. Every
+// method must be configured. By default, local accounts come first,
+// followed by LDAP, RADIUS and TACACS+ as configured.
+func WithAuthenticationOrder(methods ...AuthMethod) Option {
+	return &authOrderOption{methods: methods}
+}
+
+// authenticationOrder returns the order of the authentication methods.
+func (c *config) authenticationOrder() []AuthMethod {
+	if len(c.authOrder) > 0 {
+		return c.authOrder
+	}
+	var order []AuthMethod
+	if c.ldap != nil && c.ldap.Primary {
+		order = append(order, AuthMethodLDAP)
+	}
+	order = append(order, AuthMethodLocal)
+	if c.ldap != nil && !c.ldap.Primary {
+		order = append(order, AuthMethodLDAP)
+	}
+	if c.radius != nil {
+		order = append(order, AuthMethodRADIUS)
+	}
+	if c.tacacs != nil {
+		order = append(order, AuthMethodTACACS)
+	}
+	return order
+}
+
+// Validate checks that the configuration is usable.
+func (c *config) Validate() error {
+	if c.name == "" {
+		return fmt.Errorf("service name cannot be empty")
+	}
+
+	if c.version == "" {
+		return fmt.Errorf("service version cannot be empty")
+	}
+
+	if c.bucket == "" {
+		return fmt.Errorf("bucket cannot be empty")
+	}
+
+	if c.requestTimeout <= 0 {
+		return fmt.Errorf("request timeout must be positive")
+	}
+
+	if _, ok := hashers()[c.hashAlgorithm]; !ok {
+		return fmt.Errorf("%w: %s", ErrUnsupportedHashAlgorithm, c.hashAlgorithm)
+	}
+
+	if c.lockoutThreshold < 0 || c.lockoutThreshold > maxLockoutThreshold {
+		return fmt.Errorf("lockout threshold must be between 0 and %d", maxLockoutThreshold)
+	}
+
+	if c.lockoutDuration < 0 || c.lockoutResetAfter < 0 || c.lockoutResetAfter > c.lockoutDuration {
+		return fmt.Errorf("lockout reset period must be between 0 and the lockout duration")
+	}
+
+	if c.passwordMinLength < minPasswordLength || c.passwordMinLength > maxPasswordLength {
+		return fmt.Errorf("minimum password length must be between %d and %d", minPasswordLength, maxPasswordLength)
+	}
+
+	if c.passwordCharacterClasses < 0 || c.passwordCharacterClasses > characterClassCount {
+		return fmt.Errorf("password character classes must be between 0 and %d", characterClassCount)
+	}
+
+	if c.passwordHistory < 0 || c.passwordHistory > maxPasswordHistory {
+		return fmt.Errorf("password history must be between 0 and %d", maxPasswordHistory)
+	}
+
+	if c.passwordMaxAge < 0 {
+		return fmt.Errorf("password maximum age cannot be negative")
+	}
+
+	if c.generatedPasswordLen < c.passwordMinLength || c.generatedPasswordLen > maxPasswordLength {
+		return fmt.Errorf("generated password length must be between %d and %d", c.passwordMinLength, maxPasswordLength)
+	}
+
+	if c.secretKeyPath == "" {
+		return fmt.Errorf("secret key path cannot be empty")
+	}
+
+	if c.totpIssuer == "" || strings.Contains(c.totpIssuer, ":") {
+		return fmt.Errorf("TOTP issuer must be non-empty and must not contain a colon")
+	}
+
+	if c.ldap != nil {
+		if err := c.ldap.validate(); err != nil {
+			return err
+		}
+	}
+
+	if c.radius != nil {
+		if err := c.radius.validate(); err != nil {
+			return err
+		}
+	}
+
+	if c.tacacs != nil {
+		if err := c.tacacs.validate(); err != nil {
+			return err
+		}
+	}
+
+	configured := map[AuthMethod]bool{
+		AuthMethodLocal:  true,
+		AuthMethodLDAP:   c.ldap != nil,
+		AuthMethodRADIUS: c.radius != nil,
+		AuthMethodTACACS: c.tacacs != nil,
+	}
+	for i, method := range c.authOrder {
+		if !configured[method] {
+			return fmt.Errorf("authentication method %q is unknown or not configured", method)
+		}
+		if slices.Contains(c.authOrder[:i], method) {
+			return fmt.Errorf("authentication method %q is listed twice", method)
+		}
+	}
+
+	if c.adminUsername != "" && (!validUsername(c.adminUsername) || c.adminPassword == "") {
+		return fmt.Errorf("default admin account requires a valid username and a password")
+	}
+
+	return nil
+}
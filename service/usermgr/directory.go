@@ -0,0 +1,293 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package usermgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	schemav1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+	"github.com/u-bmc/u-bmc/pkg/ldap"
+)
+
+const (
+	// adMatchingRuleInChain is the Active Directory matching rule that
+	// matches the members of a group and of all groups nested in it.
+	adMatchingRuleInChain = "1.2.840.113556.1.4.1941"
+	// maxGroupDepth bounds the levels of nested groups resolved on
+	// directories other than Active Directory.
+	maxGroupDepth = 8
+	// maxGroups bounds the number of groups resolved for a user.
+	maxGroups = 256
+	// maxSAMAccountNameLength is the length limit of sAMAccountName.
+	maxSAMAccountNameLength = 20
+)
+
+// validate checks that the directory configuration is usable.
+func (c *LDAPConfig) validate() error {
+	if len(c.URLs) == 0 {
+		return fmt.Errorf("LDAP requires at least one server URL")
+	}
+	for _, raw := range c.URLs {
+		u, err := url.Parse(raw)
+		if err != nil || (u.Scheme != "ldap" && u.Scheme != "ldaps") || u.Hostname() == "" {
+			return fmt.Errorf("invalid LDAP server URL %q", raw)
+		}
+		if c.StartTLS && u.Scheme == "ldaps" {
+			return fmt.Errorf("LDAP server URL %q already uses TLS, StartTLS requires ldap://", raw)
+		}
+	}
+	if c.SearchBase == "" {
+		return fmt.Errorf("LDAP search base cannot be empty")
+	}
+	if strings.Count(c.UserFilter, "%s") != 1 {
+		return fmt.Errorf("LDAP user filter must contain %%s exactly once")
+	}
+	if c.BindDN != "" && c.BindPassword == "" {
+		return fmt.Errorf("LDAP bind DN requires a bind password")
+	}
+	for _, m := range c.GroupRoles {
+		if m.Group == "" || m.Role == "" {
+			return fmt.Errorf("LDAP group role mappings require a group and a role")
+		}
+	}
+	if len(c.GroupRoles) == 0 && c.DefaultRole == "" {
+		return fmt.Errorf("LDAP requires group role mappings or a default role")
+	}
+	if c.Timeout <= 0 || c.CacheTTL < 0 {
+		return fmt.Errorf("LDAP timeout must be positive and cache TTL cannot be negative")
+	}
+	return nil
+}
+
+// directory authenticates users against the configured LDAP servers.
+type directory struct {
+	config *LDAPConfig
+}
+
+func newDirectory(config *LDAPConfig) *directory {
+	return &directory{config: config}
+}
+
+// source returns the source of the users of the directory.
+func (d *directory) source() schemav1alpha1.UserSource {
+	if d.config.ActiveDirectory {
+		return schemav1alpha1.UserSource_USER_SOURCE_AD
+	}
+	return schemav1alpha1.UserSource_USER_SOURCE_LDAP
+}
+
+func (d *directory) cacheTTL() time.Duration {
+	return d.config.CacheTTL
+}
+
+// authenticate checks the credentials of a user against the servers in
+// order, moving on to the next one while a server cannot be reached or
+// fails.
+func (d *directory) authenticate(ctx context.Context, req *schemav1alpha1.AuthenticateUserRequest) (*remoteIdentity, error) {
+	return failover(d.config.URLs, func(server string) (*remoteIdentity, error) {
+		identity, err := d.login(ctx, server, req.GetUsername(), req.GetPassword())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", server, err)
+		}
+		return identity, nil
+	})
+}
+
+// login looks up a user with the service account, binds as the user to
+// check its password and resolves its groups to a role.
+func (d *directory) login(ctx context.Context, server, username, password string) (*remoteIdentity, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.config.Timeout)
+	defer cancel()
+
+	conn, err := ldap.Dial(ctx, server, d.config.TLSConfig)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close() //nolint:errcheck
+
+	if d.config.StartTLS {
+		if err := conn.StartTLS(ctx, d.config.TLSConfig); err != nil {
+			return nil, err
+		}
+	}
+	if err := d.bindService(ctx, conn); err != nil {
+		return nil, err
+	}
+
+	entries, err := conn.Search(ctx, &ldap.SearchRequest{
+		BaseDN:     d.config.SearchBase,
+		Scope:      ldap.ScopeWholeSubtree,
+		Filter:     strings.Replace(d.config.UserFilter, "%s", ldap.EscapeFilter(username), 1),
+		Attributes: []string{"memberOf", "sAMAccountName", "userPrincipalName"},
+		SizeLimit:  2,
+		TimeLimit:  d.config.Timeout,
+	})
+	switch {
+	case errors.Is(err, ldap.ErrSizeLimitExceeded), err == nil && len(entries) > 1:
+		return nil, fmt.Errorf("%w: %s matches several entries", ErrRemoteUserNotFound, username)
+	case err != nil:
+		return nil, err
+	case len(entries) == 0:
+		return nil, fmt.Errorf("%w: %s", ErrRemoteUserNotFound, username)
+	}
+	user := entries[0]
+
+	if err := conn.Bind(ctx, user.DN, password); err != nil {
+		if errors.Is(err, ldap.ErrInvalidCredentials) {
+			return nil, fmt.Errorf("%w: %w", ErrRemoteRejected, err)
+		}
+		return nil, err
+	}
+	if err := d.bindService(ctx, conn); err != nil {
+		return nil, err
+	}
+
+	groups, err := d.groups(ctx, conn, user)
+	if err != nil {
+		return nil, err
+	}
+	role := d.role(groups)
+	if role == "" {
+		return nil, fmt.Errorf("%w: %s", ErrRemoteNoRole, username)
+	}
+
+	info := &schemav1alpha1.LdapUserInfo{
+		LdapDn:   user.DN,
+		MemberOf: groups,
+	}
+	if name := user.Value("sAMAccountName"); name != "" && len(name) <= maxSAMAccountNameLength {
+		info.SamAccountName = &name
+	}
+	if upn := user.Value("userPrincipalName"); upn != "" {
+		info.UserPrincipalName = &upn
+	}
+	if dom := domain(user.DN); dom != "" {
+		info.Domain = &dom
+	}
+	return &remoteIdentity{role: role, ldapInfo: info}, nil
+}
+
+// bindService binds as the service account, if one is configured.
+// Otherwise the connection stays anonymous, or bound as the user after its
+// password was checked.
+func (d *directory) bindService(ctx context.Context, conn *ldap.Conn) error {
+	if d.config.BindDN == "" {
+		return nil
+	}
+	if err := conn.Bind(ctx, d.config.BindDN, d.config.BindPassword); err != nil {
+		return fmt.Errorf("service account bind failed: %w", err)
+	}
+	return nil
+}
+
+// groups returns the DNs of the groups user is a member of. Besides its
+// memberOf attribute, groups listing the user as a member are searched for.
+// With nested groups, Active Directory resolves them with the in-chain
+// matching rule, other directories level by level.
+func (d *directory) groups(ctx context.Context, conn *ldap.Conn, user *ldap.Entry) ([]string, error) {
+	groups := slices.Clone(user.Values("memberOf"))
+	add := func(dns []string) []string {
+		var added []string
+		for _, dn := range dns {
+			if len(groups) < maxGroups && !slices.ContainsFunc(groups, func(g string) bool { return strings.EqualFold(g, dn) }) {
+				groups = append(groups, dn)
+				added = append(added, dn)
+			}
+		}
+		return added
+	}
+
+	if d.config.ActiveDirectory {
+		if !d.config.NestedGroups {
+			return groups, nil
+		}
+		dns, err := d.searchGroups(ctx, conn, "(member:"+adMatchingRuleInChain+":="+ldap.EscapeFilter(user.DN)+")")
+		if err != nil {
+			return nil, err
+		}
+		add(dns)
+		return groups, nil
+	}
+
+	level := []string{user.DN}
+	if d.config.NestedGroups {
+		level = append(level, groups...)
+	}
+	for depth := 0; len(level) > 0 && depth < maxGroupDepth; depth++ {
+		var next []string
+		for _, dn := range level {
+			escaped := ldap.EscapeFilter(dn)
+			dns, err := d.searchGroups(ctx, conn, "(|(member="+escaped+")(uniqueMember="+escaped+"))")
+			if err != nil {
+				return nil, err
+			}
+			next = append(next, add(dns)...)
+		}
+		if !d.config.NestedGroups {
+			break
+		}
+		level = next
+	}
+	return groups, nil
+}
+
+// searchGroups returns the DNs of the entries matching filter.
+func (d *directory) searchGroups(ctx context.Context, conn *ldap.Conn, filter string) ([]string, error) {
+	entries, err := conn.Search(ctx, &ldap.SearchRequest{
+		BaseDN:     d.config.SearchBase,
+		Scope:      ldap.ScopeWholeSubtree,
+		Filter:     filter,
+		Attributes: []string{"1.1"},
+		SizeLimit:  maxGroups,
+		TimeLimit:  d.config.Timeout,
+	})
+	if err != nil && !errors.Is(err, ldap.ErrSizeLimitExceeded) {
+		return nil, err
+	}
+	dns := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		dns = append(dns, entry.DN)
+	}
+	return dns, nil
+}
+
+// role returns the role of the first mapping naming one of groups, the
+// default role if none does.
+func (d *directory) role(groups []string) string {
+	for _, m := range d.config.GroupRoles {
+		if slices.ContainsFunc(groups, func(g string) bool { return sameDN(g, m.Group) }) {
+			return m.Role
+		}
+	}
+	return d.config.DefaultRole
+}
+
+// sameDN compares two DNs case-insensitively, ignoring spaces around the
+// separators of their components.
+func sameDN(a, b string) bool {
+	normalize := func(dn string) string {
+		parts := strings.Split(dn, ",")
+		for i, part := range parts {
+			parts[i] = strings.TrimSpace(part)
+		}
+		return strings.Join(parts, ",")
+	}
+	return strings.EqualFold(normalize(a), normalize(b))
+}
+
+// domain returns the DNS domain named by the dc components of a DN.
+func domain(dn string) string {
+	var labels []string
+	for part := range strings.SplitSeq(dn, ",") {
+		if key, value, ok := strings.Cut(strings.TrimSpace(part), "="); ok && strings.EqualFold(key, "dc") {
+			labels = append(labels, value)
+		}
+	}
+	return strings.Join(labels, ".")
+}
@@ -0,0 +1,302 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package usermgr
+
+import (
+	"errors"
+	"net"
+	"slices"
+	"testing"
+	"time"
+
+	schemav1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+	"github.com/u-bmc/u-bmc/pkg/ldap/ldaptest"
+)
+
+const (
+	testBase      = "dc=example,dc=com"
+	testServiceDN = "cn=bmc,ou=services," + testBase
+	testAdmins    = "cn=admins,ou=groups," + testBase
+	testOperators = "cn=operators,ou=groups," + testBase
+	testOpsLeads  = "cn=ops-leads,ou=groups," + testBase
+	testHelpdesk  = "cn=helpdesk,ou=groups," + testBase
+)
+
+func testPerson(uid string, attrs map[string][]string) *ldaptest.Entry {
+	if attrs == nil {
+		attrs = make(map[string][]string)
+	}
+	attrs["uid"] = []string{uid}
+	attrs["objectClass"] = []string{"inetOrgPerson"}
+	return &ldaptest.Entry{
+		DN:         "uid=" + uid + ",ou=people," + testBase,
+		Password:   uid + "-pw",
+		Attributes: attrs,
+	}
+}
+
+func testGroup(dn, attr string, members ...string) *ldaptest.Entry {
+	return &ldaptest.Entry{
+		DN:         dn,
+		Attributes: map[string][]string{"objectClass": {"groupOfNames"}, attr: members},
+	}
+}
+
+func personDN(uid string) string {
+	return "uid=" + uid + ",ou=people," + testBase
+}
+
+// newTestLDAP starts a directory in the style of OpenLDAP, whose groups
+// list their members.
+func newTestLDAP(t *testing.T) *ldaptest.Server {
+	t.Helper()
+	srv := ldaptest.NewServer(
+		&ldaptest.Entry{DN: testServiceDN, Password: "service-pw"},
+		testPerson("alice", nil),
+		testPerson("bob", nil),
+		testPerson("carol", nil),
+		testPerson("dave", nil),
+		testPerson("dup", nil),
+		&ldaptest.Entry{DN: "uid=dup,ou=contractors," + testBase, Password: "dup-pw", Attributes: map[string][]string{"uid": {"dup"}}},
+		testGroup(testAdmins, "member", personDN("alice")),
+		testGroup(testOperators, "member", testOpsLeads),
+		testGroup(testOpsLeads, "member", personDN("bob")),
+		testGroup("cn=staff,ou=groups,"+testBase, "uniqueMember", personDN("dave")),
+	)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// newTestAD starts a directory in the style of Active Directory, whose
+// users list their groups in memberOf.
+func newTestAD(t *testing.T) *ldaptest.Server {
+	t.Helper()
+	user := func(name string, groups ...string) *ldaptest.Entry {
+		return &ldaptest.Entry{
+			DN:       "CN=" + name + ",CN=Users,DC=corp,DC=example,DC=com",
+			Password: name + "-pw",
+			Attributes: map[string][]string{
+				"sAMAccountName":    {name},
+				"userPrincipalName": {name + "@corp.example.com"},
+				"memberOf":          groups,
+			},
+		}
+	}
+	srv := ldaptest.NewServer(
+		&ldaptest.Entry{DN: "CN=bmc,CN=Users,DC=corp,DC=example,DC=com", Password: "service-pw"},
+		user("erin", "CN=Helpdesk,CN=Groups,DC=corp,DC=example,DC=com"),
+		user("frank", "CN=Admins,CN=Groups,DC=corp,DC=example,DC=com"),
+		&ldaptest.Entry{
+			DN:         "CN=Helpdesk,CN=Groups,DC=corp,DC=example,DC=com",
+			Attributes: map[string][]string{"member": {"CN=erin,CN=Users,DC=corp,DC=example,DC=com"}},
+		},
+		&ldaptest.Entry{
+			DN:         "CN=Operators,CN=Groups,DC=corp,DC=example,DC=com",
+			Attributes: map[string][]string{"member": {"CN=Helpdesk,CN=Groups,DC=corp,DC=example,DC=com"}},
+		},
+	)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func testLDAPConfig(urls ...string) *LDAPConfig {
+	return &LDAPConfig{
+		URLs:         urls,
+		BindDN:       testServiceDN,
+		BindPassword: "service-pw",
+		SearchBase:   testBase,
+		UserFilter:   DefaultLDAPUserFilter,
+		GroupRoles: []LDAPGroupRole{
+			// Mappings are compared ignoring case and spaces.
+			{Group: "CN=Admins, OU=Groups, DC=example, DC=com", Role: "Administrator"},
+			{Group: testOperators, Role: "Operator"},
+			{Group: "cn=staff,ou=groups," + testBase, Role: "ReadOnly"},
+		},
+		Timeout: 5 * time.Second,
+	}
+}
+
+func closedLDAPURL(t *testing.T) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	url := "ldap://" + l.Addr().String()
+	_ = l.Close()
+	return url
+}
+
+func authenticateLDAP(t *testing.T, config *LDAPConfig, username, password string) (*remoteIdentity, error) {
+	t.Helper()
+	if err := config.validate(); err != nil {
+		t.Fatalf("validate() error = %v", err)
+	}
+	return newDirectory(config).authenticate(t.Context(), &schemav1alpha1.AuthenticateUserRequest{
+		Username: username,
+		Password: password,
+	})
+}
+
+func TestDirectoryAuthenticate(t *testing.T) {
+	srv := newTestLDAP(t)
+
+	tests := []struct {
+		name     string
+		config   func(c *LDAPConfig)
+		username string
+		password string
+		wantRole string
+		wantErr  error
+	}{
+		{name: "group member", username: "alice", password: "alice-pw", wantRole: "Administrator"},
+		{name: "uniqueMember of a group", username: "dave", password: "dave-pw", wantRole: "ReadOnly"},
+		{name: "wrong password", username: "alice", password: "bob-pw", wantErr: ErrRemoteRejected},
+		{name: "unknown user", username: "mallory", password: "mallory-pw", wantErr: ErrRemoteUserNotFound},
+		{name: "ambiguous user", username: "dup", password: "dup-pw", wantErr: ErrRemoteUserNotFound},
+		{name: "member of no mapped group", username: "carol", password: "carol-pw", wantErr: ErrRemoteNoRole},
+		{
+			name:     "default role",
+			config:   func(c *LDAPConfig) { c.DefaultRole = "ReadOnly" },
+			username: "carol", password: "carol-pw", wantRole: "ReadOnly",
+		},
+		{name: "nested group ignored", username: "bob", password: "bob-pw", wantErr: ErrRemoteNoRole},
+		{
+			name:     "nested group",
+			config:   func(c *LDAPConfig) { c.NestedGroups = true },
+			username: "bob", password: "bob-pw", wantRole: "Operator",
+		},
+		{
+			name:     "anonymous lookup",
+			config:   func(c *LDAPConfig) { c.BindDN, c.BindPassword = "", "" },
+			username: "alice", password: "alice-pw", wantRole: "Administrator",
+		},
+		// Unescaped, the wildcard would find alice and let her in.
+		{name: "wildcard username", username: "ali*", password: "alice-pw", wantErr: ErrRemoteUserNotFound},
+		{name: "filter injection", username: "alice)(uid=*", password: "alice-pw", wantErr: ErrRemoteUserNotFound},
+		{
+			name:     "service account rejected",
+			config:   func(c *LDAPConfig) { c.BindPassword = "wrong" },
+			username: "alice", password: "alice-pw", wantErr: ErrRemoteUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := testLDAPConfig(srv.URL)
+			if tt.config != nil {
+				tt.config(config)
+			}
+			identity, err := authenticateLDAP(t, config, tt.username, tt.password)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("authenticate() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && identity.role != tt.wantRole {
+				t.Errorf("role = %q, want %q", identity.role, tt.wantRole)
+			}
+		})
+	}
+}
+
+func TestDirectoryIdentity(t *testing.T) {
+	srv := newTestLDAP(t)
+
+	identity, err := authenticateLDAP(t, testLDAPConfig(srv.URL), "alice", "alice-pw")
+	if err != nil {
+		t.Fatalf("authenticate() error = %v", err)
+	}
+	info := identity.ldapInfo
+	if info.GetLdapDn() != personDN("alice") || info.GetDomain() != "example.com" {
+		t.Errorf("LDAP info = %v", info)
+	}
+	if !slices.Equal(info.GetMemberOf(), []string{testAdmins}) {
+		t.Errorf("groups = %v, want %v", info.GetMemberOf(), []string{testAdmins})
+	}
+
+	// The service account looks the user up, the user's bind checks the
+	// password and the service account resolves the groups.
+	if got, want := srv.Binds(), []string{testServiceDN, personDN("alice"), testServiceDN}; !slices.Equal(got, want) {
+		t.Errorf("binds = %v, want %v", got, want)
+	}
+}
+
+func TestDirectoryActiveDirectory(t *testing.T) {
+	srv := newTestAD(t)
+
+	config := func(nested bool) *LDAPConfig {
+		return &LDAPConfig{
+			URLs:            []string{srv.URL},
+			BindDN:          "CN=bmc,CN=Users,DC=corp,DC=example,DC=com",
+			BindPassword:    "service-pw",
+			SearchBase:      "DC=corp,DC=example,DC=com",
+			UserFilter:      DefaultADUserFilter,
+			ActiveDirectory: true,
+			NestedGroups:    nested,
+			GroupRoles: []LDAPGroupRole{
+				{Group: "CN=Admins,CN=Groups,DC=corp,DC=example,DC=com", Role: "Administrator"},
+				{Group: "CN=Operators,CN=Groups,DC=corp,DC=example,DC=com", Role: "Operator"},
+			},
+			Timeout: 5 * time.Second,
+		}
+	}
+
+	t.Run("memberOf", func(t *testing.T) {
+		identity, err := authenticateLDAP(t, config(false), "frank", "frank-pw")
+		if err != nil {
+			t.Fatalf("authenticate() error = %v", err)
+		}
+		info := identity.ldapInfo
+		if identity.role != "Administrator" || info.GetSamAccountName() != "frank" ||
+			info.GetUserPrincipalName() != "frank@corp.example.com" || info.GetDomain() != "corp.example.com" {
+			t.Errorf("identity = %s %v", identity.role, info)
+		}
+		if src := newDirectory(config(false)).source(); src != schemav1alpha1.UserSource_USER_SOURCE_AD {
+			t.Errorf("source = %v", src)
+		}
+	})
+
+	t.Run("nested group ignored", func(t *testing.T) {
+		if _, err := authenticateLDAP(t, config(false), "erin", "erin-pw"); !errors.Is(err, ErrRemoteNoRole) {
+			t.Errorf("authenticate() error = %v, want %v", err, ErrRemoteNoRole)
+		}
+	})
+
+	t.Run("nested group resolved in chain", func(t *testing.T) {
+		identity, err := authenticateLDAP(t, config(true), "erin", "erin-pw")
+		if err != nil {
+			t.Fatalf("authenticate() error = %v", err)
+		}
+		if identity.role != "Operator" {
+			t.Errorf("role = %q, want Operator", identity.role)
+		}
+	})
+}
+
+func TestDirectoryFailover(t *testing.T) {
+	srv := newTestLDAP(t)
+	down := closedLDAPURL(t)
+
+	t.Run("unreachable server skipped", func(t *testing.T) {
+		identity, err := authenticateLDAP(t, testLDAPConfig(down, srv.URL), "alice", "alice-pw")
+		if err != nil || identity.role != "Administrator" {
+			t.Errorf("authenticate() = %v, %v", identity, err)
+		}
+	})
+
+	t.Run("rejection not retried", func(t *testing.T) {
+		other := newTestLDAP(t)
+		before := len(other.Binds())
+		if _, err := authenticateLDAP(t, testLDAPConfig(srv.URL, other.URL), "alice", "wrong"); !errors.Is(err, ErrRemoteRejected) {
+			t.Fatalf("authenticate() error = %v, want %v", err, ErrRemoteRejected)
+		}
+		if len(other.Binds()) != before {
+			t.Error("second server consulted after the first rejected the password")
+		}
+	})
+
+	t.Run("all servers unreachable", func(t *testing.T) {
+		if _, err := authenticateLDAP(t, testLDAPConfig(down), "alice", "alice-pw"); !errors.Is(err, ErrRemoteUnavailable) {
+			t.Errorf("authenticate() error = %v, want %v", err, ErrRemoteUnavailable)
+		}
+	})
+}
@@ -0,0 +1,208 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package usermgr provides the user manager for BMC systems. It owns the user
+// accounts of the BMC and serves account management and authentication to
+// the web server, the IPMI server and any other service over NATS IPC.
+//
+// # Storage
+//
+// Users are stored as v1alpha1.User messages in a JetStream key-value bucket
+// keyed by user ID, so accounts survive restarts of the BMC software. The
+// bucket is loaded into memory on start and every change is written through
+// to it. Usernames are unique.
+//
+// # Passwords
+//
+// Passwords are hashed with the configured algorithm, argon2id by default.
+// Hashes produced with bcrypt, scrypt, PBKDF2-SHA256 or PBKDF2-SHA512 are
+// accepted as well, so that accounts can be imported from other systems by
+// passing the hash, salt, algorithm and iteration count in the
+// authentication data of a create request. Such passwords are rehashed with
+// the configured algorithm on their next successful authentication. Hashes
+// with an empty salt or key, or with work factors beyond what the BMC can
+// afford on every login, are rejected on import and never verify.
+//
+// The stored hashes and salts are never returned by any endpoint.
+//
+// # Password Policy
+//
+// New passwords set with CreateUser, ChangePassword and ResetPassword must:
+//   - Be between WithPasswordMinLength, 8 by default, and 128 characters long
+//   - Contain WithPasswordCharacterClasses of lowercase letters, uppercase
+//     letters, digits and symbols, 3 by default
+//   - Not contain the username
+//   - Not be a common password or a word of the WithPasswordDictionary file,
+//     also when followed by digits and symbols
+//   - Differ from the current password and the WithPasswordHistory previous
+//     ones, 5 by default
+//
+// Forced password resets, which administrators use for the accounts of
+// others, skip the history check but must meet the rest of the policy. With
+// WithPasswordMaxAge, passwords expire after the given time. Expired
+// passwords no longer authenticate but can still be changed with
+// ChangePassword. Accounts whose account_expires_at has passed no longer
+// authenticate either.
+//
+// # Account Lockout
+//
+// Failed logins are counted per account. Once the lockout threshold is
+// reached with no more than the reset period between two failures, the
+// account is locked for the lockout duration and rejected without checking
+// the password. The policy is the Redfish lockout policy of an account if it
+// has one, and the one set with WithLockoutPolicy otherwise, 5 failures
+// within 5 minutes locking for 5 minutes by default. The default account is
+// created with the configured policy as its Redfish lockout policy.
+//
+// Administrators lock an account by updating auth_data.lockout_info with
+// locked set, which lasts until they unlock it by updating it with locked
+// unset.
+//
+// Authentication failures carry the reason in the failure field of the
+// response: invalid credentials, a locked, disabled or expired account or an
+// expired password. All but the first two are only reported once the
+// password has been verified.
+//
+// # Second Factor
+//
+// Accounts can add an RFC 6238 TOTP second factor. EnrollTotp generates a
+// secret and returns it along with an otpauth:// URI to show as a QR code,
+// and ConfirmTotp activates it given a code of an authenticator app. The
+// confirmation returns ten single-use recovery codes, which can be replaced
+// with RegenerateRecoveryCodes. DisableTotp removes the second factor.
+//
+// Secrets are stored encrypted with AES-256-GCM under a key kept outside of
+// the bucket at WithSecretKeyPath, which is created on first start. Recovery
+// codes are stored as HMACs under the same key.
+//
+// Once enrolled, AuthenticateUser requires the current code or an unused
+// recovery code in second_factor. Codes are accepted 30 seconds early or
+// late and only once, wrong ones count as failed logins. Clients that cannot
+// prompt for a second factor, such as IPMI and HTTP Basic authentication,
+// leave second_factor_supported unset and are refused. With WithTOTPRequired
+// or auth_data.totp_required set, accounts without a second factor are
+// refused by such clients as well, and others let them in with
+// second_factor_enrollment_required set so that they enroll.
+//
+// # Directory Authentication
+//
+// With WithLDAP, users are also authenticated against an LDAP directory or
+// Active Directory, over LDAPS or StartTLS with the servers tried in order.
+// A user is looked up below the search base with the user filter, bound as
+// the service account, and its password checked by binding as the user. Its
+// groups are taken from its memberOf attribute and the groups listing it as
+// a member, including nested groups if enabled, which Active Directory
+// resolves itself. The first group role mapping naming one of its groups
+// decides its role, the default role applies otherwise and users without a
+// role are refused.
+//
+// Directory users are recorded as accounts with the LDAP or AD source and
+// their DN, groups and role in ldap_info and redfish_info, which are
+// refreshed on every login. They have no local password, but can be
+// disabled, locked and enroll a second factor like local accounts.
+//
+// Successful logins are cached for the configured cache TTL, hashed with
+// argon2id and in memory only. While no server can be reached, directory
+// users are let in if their password matches the cached one.
+//
+// # RADIUS and TACACS+
+//
+// With WithRADIUS, users are authenticated with an Access-Request using PAP
+// or CHAP, which carries a Message-Authenticator and can require one in the
+// answer. The privilege of a user is read from a vendor-specific attribute
+// of the Access-Accept, shell:priv-lvl of the Cisco-AVPair by default. With
+// WithTACACS, users are authenticated and then authorized for a service,
+// and their privilege is read from the priv-lvl argument of the
+// authorization. The first privilege role mapping naming the privilege
+// decides the role, the default role applies otherwise and users without a
+// role are refused. Like directory users, they are recorded as accounts with
+// the RADIUS or TACACS source, refreshed on every login, and their logins
+// can be cached.
+//
+// Every server has its own timeout. Servers are tried in order while they
+// do not answer, and the first answer decides.
+//
+// # Authentication Order
+//
+// The methods are tried in the order of WithAuthenticationOrder. A remote
+// method passes a login on to the next method if none of its servers can be
+// reached or it rejects a user it has not let in before, as RADIUS and
+// TACACS+ servers cannot tell unknown users from wrong passwords. Its
+// rejection of one of its own users is final and counts towards the
+// lockout threshold. Local accounts are only checked by the local method,
+// remote users only by the method of their source, and usernames of local
+// accounts that a remote method lets in are refused. By default local
+// accounts come first, followed by LDAP, RADIUS and TACACS+ as configured,
+// and a primary directory comes before local accounts.
+//
+// # Default Account
+//
+// When the bucket holds no users on start, a default administrator account
+// is created, "admin" with the password "admin" unless configured otherwise
+// with WithDefaultAdmin. Its Redfish information requires a password change,
+// which a successful ChangePassword request clears. Until then,
+// AuthenticateUser refuses it with PASSWORD_CHANGE_REQUIRED unless the client
+// sets password_change_supported, in which case the response carries
+// password_change_required and the client must only allow the change. This
+// applies to every account whose Redfish information requires a change, and
+// keeps the default password from working over IPMI.
+//
+// # IPC Endpoints
+//
+// The service exposes the following NATS endpoints:
+//   - user.create - Create a user with a password or an imported hash, or validate it with dry_run
+//   - user.info - A single user by ID, username or email
+//   - user.update - Replace the fields of a user named by a field mask
+//   - user.delete - Delete a user by ID
+//   - user.list - Users ordered by username, filtered by source, state and username prefix
+//   - user.change_password - Change a password given the current one
+//   - user.reset_password - Set or generate a new password
+//   - user.authenticate - Check a username, password and second factor
+//   - user.check_login - Check whether an account may log in before a protocol such as RAKP verifies the password, or report a failed verification
+//   - user.totp_enroll - Generate a TOTP secret to confirm
+//   - user.totp_confirm - Activate a TOTP secret given a code and return recovery codes
+//   - user.totp_disable - Remove the TOTP second factor
+//   - user.totp_recovery_codes - Replace the recovery codes
+//
+// Missing users are reported with the error code 404, taken usernames and
+// TOTP requests in the wrong enrollment state with 409 and malformed
+// requests with 400. Rejected passwords and failed
+// authentications are successful responses carrying a failure reason.
+//
+// # Usage
+//
+//	svc := usermgr.New(
+//		usermgr.WithHashAlgorithm(v1alpha1.PasswordHashAlgorithm_PASSWORD_HASH_ALGORITHM_ARGON2ID),
+//		usermgr.WithDefaultAdmin("root", "0penBmc", "Administrator"),
+//		usermgr.WithLockoutPolicy(3, 15*time.Minute, 15*time.Minute),
+//		usermgr.WithPasswordMaxAge(90*24*time.Hour),
+//		usermgr.WithTOTPRequired(true),
+//		usermgr.WithLDAP(usermgr.LDAPConfig{
+//			URLs:            []string{"ldaps://dc1.example.com", "ldaps://dc2.example.com"},
+//			BindDN:          "CN=bmc,OU=Services,DC=example,DC=com",
+//			BindPassword:    servicePassword,
+//			SearchBase:      "DC=example,DC=com",
+//			GroupRoles:      []usermgr.LDAPGroupRole{{Group: "CN=BMC Admins,OU=Groups,DC=example,DC=com", Role: "Administrator"}},
+//			ActiveDirectory: true,
+//			NestedGroups:    true,
+//			CacheTTL:        15 * time.Minute,
+//		}),
+//		usermgr.WithTACACS(usermgr.TACACSConfig{
+//			Servers: []usermgr.TACACSServer{
+//				{Address: "tacacs1.example.com", Secret: tacacsKey, Timeout: 3 * time.Second},
+//				{Address: "tacacs2.example.com", Secret: tacacsKey, Timeout: 3 * time.Second},
+//			},
+//			PrivilegeRoles: []usermgr.PrivilegeRole{{Privilege: "15", Role: "Administrator"}},
+//		}),
+//		usermgr.WithAuthenticationOrder(usermgr.AuthMethodTACACS, usermgr.AuthMethodLDAP, usermgr.AuthMethodLocal),
+//	)
+//
+//	if err := svc.Run(ctx, ipcConn); err != nil {
+//		log.Fatal(err)
+//	}
+//
+// Authenticating a user via NATS:
+//
+//	req := &v1alpha1.AuthenticateUserRequest{Username: "admin", Password: "secret"}
+//	data, _ := req.MarshalVT()
+//	msg, err := nc.Request(ipc.SubjectUserAuthenticate, data, 5*time.Second)
+package usermgr
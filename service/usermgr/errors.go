@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package usermgr
+
+import "errors"
+
+var (
+	// ErrInvalidConfiguration indicates that the service configuration is invalid.
+	ErrInvalidConfiguration = errors.New("invalid usermgr configuration")
+	// ErrNATSConnectionFailed indicates that the NATS connection could not be established.
+	ErrNATSConnectionFailed = errors.New("failed to connect to NATS")
+	// ErrJetStreamInitFailed indicates that JetStream initialization failed.
+	ErrJetStreamInitFailed = errors.New("failed to initialize JetStream")
+	// ErrMicroServiceCreationFailed indicates that micro service creation failed.
+	ErrMicroServiceCreationFailed = errors.New("failed to create micro service")
+	// ErrEndpointRegistrationFailed indicates that endpoint registration failed.
+	ErrEndpointRegistrationFailed = errors.New("failed to register endpoint")
+	// ErrStorageFailed indicates that reading or writing the user bucket failed.
+	ErrStorageFailed = errors.New("user storage failed")
+	// ErrUserNotFound indicates that the requested user does not exist.
+	ErrUserNotFound = errors.New("user not found")
+	// ErrUserExists indicates that a user with the requested username already exists.
+	ErrUserExists = errors.New("user already exists")
+	// ErrInvalidUser indicates that a user or user request is malformed.
+	ErrInvalidUser = errors.New("invalid user")
+	// ErrInvalidPassword indicates that a password does not meet the password requirements.
+	ErrInvalidPassword = errors.New("invalid password")
+	// ErrUnsupportedHashAlgorithm indicates that a password hash algorithm is not supported.
+	ErrUnsupportedHashAlgorithm = errors.New("unsupported password hash algorithm")
+	// ErrInvalidHash indicates that a stored or imported password hash is malformed.
+	ErrInvalidHash = errors.New("invalid password hash")
+	// ErrSecretKeyFailed indicates that the key TOTP secrets are encrypted with could not be loaded or used.
+	ErrSecretKeyFailed = errors.New("TOTP secret key failed")
+	// ErrTOTPNotEnrolled indicates that a user has no TOTP enrollment to confirm, disable or use.
+	ErrTOTPNotEnrolled = errors.New("TOTP not enrolled")
+	// ErrTOTPAlreadyEnrolled indicates that a user has already confirmed a TOTP enrollment.
+	ErrTOTPAlreadyEnrolled = errors.New("TOTP already enrolled")
+	// ErrInvalidSecondFactor indicates that a TOTP code or recovery code is wrong or was already used.
+	ErrInvalidSecondFactor = errors.New("invalid second factor")
+	// ErrRemoteUnavailable indicates that none of the LDAP, RADIUS or TACACS+ servers of a method could be reached or used.
+	ErrRemoteUnavailable = errors.New("authentication servers unavailable")
+	// ErrRemoteUserNotFound indicates that the authentication servers know no single user of a username.
+	ErrRemoteUserNotFound = errors.New("user not found by authentication servers")
+	// ErrRemoteRejected indicates that the authentication servers rejected the credentials of a user.
+	ErrRemoteRejected = errors.New("authentication servers rejected credentials")
+	// ErrRemoteNoRole indicates that the groups or privileges of a remote user map to no role.
+	ErrRemoteNoRole = errors.New("no role for remote user")
+	// ErrRemoteConflict indicates that a remote user has the username of an account of another source.
+	ErrRemoteConflict = errors.New("remote user conflicts with an account of another source")
+)
@@ -0,0 +1,265 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package usermgr
+
+import (
+	"context"
+	"errors"
+
+	"github.com/nats-io/nats.go/micro"
+	schemav1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+)
+
+type vtMessage interface {
+	MarshalVT() ([]byte, error)
+}
+
+// respond sends a protobuf response and logs failures.
+func (s *UserMgr) respond(ctx context.Context, req micro.Request, resp vtMessage) {
+	data, err := resp.MarshalVT()
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to marshal response", "subject", req.Subject(), "error", err)
+		_ = req.Error("500", "failed to marshal response", nil)
+		return
+	}
+
+	if err := req.Respond(data); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to send response", "subject", req.Subject(), "error", err)
+	}
+}
+
+// respondError maps a user management error to a micro error response.
+func (s *UserMgr) respondError(ctx context.Context, req micro.Request, err error) {
+	switch {
+	case errors.Is(err, ErrUserNotFound):
+		_ = req.Error("404", err.Error(), nil)
+	case errors.Is(err, ErrUserExists), errors.Is(err, ErrTOTPNotEnrolled), errors.Is(err, ErrTOTPAlreadyEnrolled):
+		_ = req.Error("409", err.Error(), nil)
+	case errors.Is(err, ErrInvalidUser), errors.Is(err, ErrInvalidPassword),
+		errors.Is(err, ErrInvalidHash), errors.Is(err, ErrUnsupportedHashAlgorithm):
+		_ = req.Error("400", err.Error(), nil)
+	default:
+		s.logger.ErrorContext(ctx, "Request failed", "subject", req.Subject(), "error", err)
+		_ = req.Error("500", err.Error(), nil)
+	}
+}
+
+// handleUserCreate handles requests to create a user.
+func (s *UserMgr) handleUserCreate(ctx context.Context, req micro.Request) {
+	var request schemav1alpha1.CreateUserRequest
+	if err := request.UnmarshalVT(req.Data()); err != nil {
+		_ = req.Error("400", "invalid request format", nil)
+		return
+	}
+
+	resp, err := s.createUser(ctx, &request)
+	if err != nil {
+		s.respondError(ctx, req, err)
+		return
+	}
+
+	s.respond(ctx, req, resp)
+}
+
+// handleUserInfo handles requests for a single user by ID, username or email.
+func (s *UserMgr) handleUserInfo(ctx context.Context, req micro.Request) {
+	var request schemav1alpha1.GetUserRequest
+	if err := request.UnmarshalVT(req.Data()); err != nil {
+		_ = req.Error("400", "invalid request format", nil)
+		return
+	}
+
+	user, err := s.getUser(&request)
+	if err != nil {
+		s.respondError(ctx, req, err)
+		return
+	}
+
+	s.respond(ctx, req, &schemav1alpha1.GetUserResponse{User: user})
+}
+
+// handleUserUpdate handles requests to update a user.
+func (s *UserMgr) handleUserUpdate(ctx context.Context, req micro.Request) {
+	var request schemav1alpha1.UpdateUserRequest
+	if err := request.UnmarshalVT(req.Data()); err != nil {
+		_ = req.Error("400", "invalid request format", nil)
+		return
+	}
+
+	resp, err := s.updateUser(ctx, &request)
+	if err != nil {
+		s.respondError(ctx, req, err)
+		return
+	}
+
+	s.respond(ctx, req, resp)
+}
+
+// handleUserDelete handles requests to delete a user.
+func (s *UserMgr) handleUserDelete(ctx context.Context, req micro.Request) {
+	var request schemav1alpha1.DeleteUserRequest
+	if err := request.UnmarshalVT(req.Data()); err != nil {
+		_ = req.Error("400", "invalid request format", nil)
+		return
+	}
+
+	if err := s.deleteUser(ctx, &request); err != nil {
+		s.respondError(ctx, req, err)
+		return
+	}
+
+	s.respond(ctx, req, &schemav1alpha1.DeleteUserResponse{Success: true})
+}
+
+// handleUserList handles requests to list users.
+func (s *UserMgr) handleUserList(ctx context.Context, req micro.Request) {
+	var request schemav1alpha1.ListUsersRequest
+	if err := request.UnmarshalVT(req.Data()); err != nil {
+		_ = req.Error("400", "invalid request format", nil)
+		return
+	}
+
+	resp, err := s.listUsers(&request)
+	if err != nil {
+		s.respondError(ctx, req, err)
+		return
+	}
+
+	s.respond(ctx, req, resp)
+}
+
+// handleUserChangePassword handles requests to change a password.
+func (s *UserMgr) handleUserChangePassword(ctx context.Context, req micro.Request) {
+	var request schemav1alpha1.ChangePasswordRequest
+	if err := request.UnmarshalVT(req.Data()); err != nil {
+		_ = req.Error("400", "invalid request format", nil)
+		return
+	}
+
+	resp, err := s.changePassword(ctx, &request)
+	if err != nil {
+		s.respondError(ctx, req, err)
+		return
+	}
+
+	s.respond(ctx, req, resp)
+}
+
+// handleUserResetPassword handles requests to reset a password.
+func (s *UserMgr) handleUserResetPassword(ctx context.Context, req micro.Request) {
+	var request schemav1alpha1.ResetPasswordRequest
+	if err := request.UnmarshalVT(req.Data()); err != nil {
+		_ = req.Error("400", "invalid request format", nil)
+		return
+	}
+
+	resp, err := s.resetPassword(ctx, &request)
+	if err != nil {
+		s.respondError(ctx, req, err)
+		return
+	}
+
+	s.respond(ctx, req, resp)
+}
+
+// handleUserAuthenticate handles requests to authenticate a user.
+func (s *UserMgr) handleUserAuthenticate(ctx context.Context, req micro.Request) {
+	var request schemav1alpha1.AuthenticateUserRequest
+	if err := request.UnmarshalVT(req.Data()); err != nil {
+		_ = req.Error("400", "invalid request format", nil)
+		return
+	}
+
+	resp, err := s.authenticate(ctx, &request)
+	if err != nil {
+		s.respondError(ctx, req, err)
+		return
+	}
+
+	s.respond(ctx, req, resp)
+}
+
+// handleUserCheckLogin handles requests to check whether an account may log
+// in through a protocol that verifies the password itself.
+func (s *UserMgr) handleUserCheckLogin(ctx context.Context, req micro.Request) {
+	var request schemav1alpha1.CheckLoginRequest
+	if err := request.UnmarshalVT(req.Data()); err != nil {
+		_ = req.Error("400", "invalid request format", nil)
+		return
+	}
+
+	resp, err := s.checkLogin(ctx, &request)
+	if err != nil {
+		s.respondError(ctx, req, err)
+		return
+	}
+
+	s.respond(ctx, req, resp)
+}
+
+// handleUserTOTPEnroll handles requests to start a TOTP enrollment.
+func (s *UserMgr) handleUserTOTPEnroll(ctx context.Context, req micro.Request) {
+	var request schemav1alpha1.EnrollTotpRequest
+	if err := request.UnmarshalVT(req.Data()); err != nil {
+		_ = req.Error("400", "invalid request format", nil)
+		return
+	}
+
+	resp, err := s.enrollTotp(ctx, &request)
+	if err != nil {
+		s.respondError(ctx, req, err)
+		return
+	}
+
+	s.respond(ctx, req, resp)
+}
+
+// handleUserTOTPConfirm handles requests to confirm a TOTP enrollment.
+func (s *UserMgr) handleUserTOTPConfirm(ctx context.Context, req micro.Request) {
+	var request schemav1alpha1.ConfirmTotpRequest
+	if err := request.UnmarshalVT(req.Data()); err != nil {
+		_ = req.Error("400", "invalid request format", nil)
+		return
+	}
+
+	resp, err := s.confirmTotp(ctx, &request)
+	if err != nil {
+		s.respondError(ctx, req, err)
+		return
+	}
+
+	s.respond(ctx, req, resp)
+}
+
+// handleUserTOTPDisable handles requests to remove a TOTP enrollment.
+func (s *UserMgr) handleUserTOTPDisable(ctx context.Context, req micro.Request) {
+	var request schemav1alpha1.DisableTotpRequest
+	if err := request.UnmarshalVT(req.Data()); err != nil {
+		_ = req.Error("400", "invalid request format", nil)
+		return
+	}
+
+	if err := s.disableTotp(ctx, &request); err != nil {
+		s.respondError(ctx, req, err)
+		return
+	}
+
+	s.respond(ctx, req, &schemav1alpha1.DisableTotpResponse{Success: true})
+}
+
+// handleUserTOTPRecoveryCodes handles requests to regenerate the TOTP recovery codes.
+func (s *UserMgr) handleUserTOTPRecoveryCodes(ctx context.Context, req micro.Request) {
+	var request schemav1alpha1.RegenerateRecoveryCodesRequest
+	if err := request.UnmarshalVT(req.Data()); err != nil {
+		_ = req.Error("400", "invalid request format", nil)
+		return
+	}
+
+	resp, err := s.regenerateRecoveryCodes(ctx, &request)
+	if err != nil {
+		s.respondError(ctx, req, err)
+		return
+	}
+
+	s.respond(ctx, req, resp)
+}
@@ -0,0 +1,347 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package usermgr
+
+import (
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"math/big"
+	"strings"
+	"unicode"
+
+	schemav1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/scrypt"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Password hashing parameters. The argon2id parameters follow the OWASP
+// recommendation for memory-constrained systems. The maximums bound the work
+// a stored or imported hash may cause on every login attempt.
+const (
+	minPasswordLength   = 8
+	maxPasswordLength   = 128
+	maxUsernameLength   = 64
+	saltLength          = 16
+	keyLength           = 32
+	argon2Time          = 2
+	argon2Memory        = 19 * 1024
+	argon2Threads       = 1
+	bcryptCost          = 12
+	scryptLogN          = 15
+	scryptR             = 8
+	scryptP             = 1
+	pbkdf2SHA256Rounds  = 600000
+	pbkdf2SHA512Rounds  = 210000
+	minKeyLength        = 16
+	maxKeyLength        = 64
+	maxBcryptCost       = 14
+	maxScryptLogN       = 16
+	maxArgon2Time       = 10
+	maxArgon2Memory     = 64 * 1024
+	maxArgon2Threads    = 8
+	maxPBKDF2Rounds     = 1000000
+	passwordAlphabet    = "abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789!#%+-.:=@_"
+	argon2PHCIdentifier = "argon2id"
+)
+
+// passwordHasher hashes and verifies passwords with one algorithm.
+type passwordHasher struct {
+	// hash returns the hash, the salt, which is empty if the hash embeds
+	// it, and the work factor of a password.
+	hash func(password string) (string, string, int32, error)
+	// verify reports whether password matches the stored hash.
+	verify func(auth *schemav1alpha1.AuthenticationData, password string) (bool, error)
+}
+
+// hashers returns the supported password hash algorithms.
+func hashers() map[schemav1alpha1.PasswordHashAlgorithm]passwordHasher {
+	return map[schemav1alpha1.PasswordHashAlgorithm]passwordHasher{
+		schemav1alpha1.PasswordHashAlgorithm_PASSWORD_HASH_ALGORITHM_ARGON2ID:      {hashArgon2id, verifyArgon2id},
+		schemav1alpha1.PasswordHashAlgorithm_PASSWORD_HASH_ALGORITHM_BCRYPT:        {hashBcrypt, verifyBcrypt},
+		schemav1alpha1.PasswordHashAlgorithm_PASSWORD_HASH_ALGORITHM_SCRYPT:        {hashScrypt, verifyScrypt},
+		schemav1alpha1.PasswordHashAlgorithm_PASSWORD_HASH_ALGORITHM_PBKDF2_SHA256: pbkdf2Hasher(sha256.New, pbkdf2SHA256Rounds),
+		schemav1alpha1.PasswordHashAlgorithm_PASSWORD_HASH_ALGORITHM_PBKDF2_SHA512: pbkdf2Hasher(sha512.New, pbkdf2SHA512Rounds),
+	}
+}
+
+// newAuthData hashes password with algorithm and returns the authentication
+// data holding it.
+func newAuthData(algorithm schemav1alpha1.PasswordHashAlgorithm, password string) (*schemav1alpha1.AuthenticationData, error) {
+	h, ok := hashers()[algorithm]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedHashAlgorithm, algorithm)
+	}
+
+	digest, salt, iterations, err := h.hash(password)
+	if err != nil {
+		return nil, err
+	}
+
+	auth := &schemav1alpha1.AuthenticationData{
+		PasswordHash:        digest,
+		HashAlgorithm:       algorithm,
+		Iterations:          iterations,
+		PasswordLastChanged: timestamppb.Now(),
+	}
+	if salt != "" {
+		auth.PasswordSalt = &salt
+	}
+	return auth, nil
+}
+
+// verifyPassword reports whether password matches the hash of auth.
+func verifyPassword(auth *schemav1alpha1.AuthenticationData, password string) (bool, error) {
+	if auth.GetPasswordHash() == "" {
+		return false, nil
+	}
+	h, ok := hashers()[auth.GetHashAlgorithm()]
+	if !ok {
+		return false, fmt.Errorf("%w: %s", ErrUnsupportedHashAlgorithm, auth.GetHashAlgorithm())
+	}
+	return h.verify(auth, password)
+}
+
+// checkImportedHash checks that an imported password hash can be verified
+// within the parameter maximums.
+func checkImportedHash(auth *schemav1alpha1.AuthenticationData) error {
+	if auth.GetPasswordHash() == "" {
+		return fmt.Errorf("%w: empty hash", ErrInvalidHash)
+	}
+	if _, err := verifyPassword(auth, ""); err != nil {
+		return err
+	}
+	return nil
+}
+
+// randomBytes returns n random bytes.
+func randomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// encode and decode convert binary hashes and salts to their stored form.
+func encode(b []byte) string {
+	return base64.RawStdEncoding.EncodeToString(b)
+}
+
+func decode(s string) ([]byte, error) {
+	b, err := base64.RawStdEncoding.DecodeString(strings.TrimRight(s, "="))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidHash, err)
+	}
+	return b, nil
+}
+
+// decodeSaltAndKey decodes the salt and the derived key of a hash and checks
+// that neither is empty and that the key is long enough to be compared
+// meaningfully.
+func decodeSaltAndKey(salt, key string) ([]byte, []byte, error) {
+	s, err := decode(salt)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(s) == 0 {
+		return nil, nil, fmt.Errorf("%w: empty salt", ErrInvalidHash)
+	}
+	k, err := decode(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(k) < minKeyLength || len(k) > maxKeyLength {
+		return nil, nil, fmt.Errorf("%w: key length %d out of range", ErrInvalidHash, len(k))
+	}
+	return s, k, nil
+}
+
+// hashArgon2id hashes a password with argon2id. The hash is stored in the
+// PHC string format, which carries the salt and the memory and parallelism
+// parameters, so imported hashes with other parameters remain verifiable.
+func hashArgon2id(password string) (string, string, int32, error) {
+	salt, err := randomBytes(saltLength)
+	if err != nil {
+		return "", "", 0, err
+	}
+	key := argon2.IDKey([]byte(password), salt, argon2Time, argon2Memory, argon2Threads, keyLength)
+	return fmt.Sprintf("$%s$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2PHCIdentifier, argon2.Version,
+		argon2Memory, argon2Time, argon2Threads, encode(salt), encode(key)), "", argon2Time, nil
+}
+
+func verifyArgon2id(auth *schemav1alpha1.AuthenticationData, password string) (bool, error) {
+	parts := strings.Split(auth.GetPasswordHash(), "$")
+	if len(parts) != 6 || parts[1] != argon2PHCIdentifier {
+		return false, fmt.Errorf("%w: not an argon2id PHC string", ErrInvalidHash)
+	}
+
+	var version int
+	var memory, time uint32
+	var threads uint8
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return false, fmt.Errorf("%w: unsupported argon2 version %q", ErrInvalidHash, parts[2])
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &time, &threads); err != nil ||
+		time == 0 || time > maxArgon2Time || threads == 0 || threads > maxArgon2Threads || memory > maxArgon2Memory {
+		return false, fmt.Errorf("%w: invalid argon2 parameters %q", ErrInvalidHash, parts[3])
+	}
+	salt, key, err := decodeSaltAndKey(parts[4], parts[5])
+	if err != nil {
+		return false, err
+	}
+
+	computed := argon2.IDKey([]byte(password), salt, time, memory, threads, uint32(len(key))) //nolint:gosec // key length of a decoded hash
+	return subtle.ConstantTimeCompare(computed, key) == 1, nil
+}
+
+func hashBcrypt(password string) (string, string, int32, error) {
+	digest, err := bcrypt.GenerateFromPassword([]byte(password), bcryptCost)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("%w: %w", ErrInvalidPassword, err)
+	}
+	return string(digest), "", bcryptCost, nil
+}
+
+func verifyBcrypt(auth *schemav1alpha1.AuthenticationData, password string) (bool, error) {
+	cost, err := bcrypt.Cost([]byte(auth.GetPasswordHash()))
+	if err != nil {
+		return false, fmt.Errorf("%w: %w", ErrInvalidHash, err)
+	}
+	if cost > maxBcryptCost {
+		return false, fmt.Errorf("%w: bcrypt cost %d out of range", ErrInvalidHash, cost)
+	}
+	err = bcrypt.CompareHashAndPassword([]byte(auth.GetPasswordHash()), []byte(password))
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, bcrypt.ErrMismatchedHashAndPassword), errors.Is(err, bcrypt.ErrPasswordTooLong):
+		return false, nil
+	default:
+		return false, fmt.Errorf("%w: %w", ErrInvalidHash, err)
+	}
+}
+
+// hashScrypt hashes a password with scrypt. The iterations are the binary
+// logarithm of the cost parameter N.
+func hashScrypt(password string) (string, string, int32, error) {
+	salt, err := randomBytes(saltLength)
+	if err != nil {
+		return "", "", 0, err
+	}
+	key, err := scrypt.Key([]byte(password), salt, 1<<scryptLogN, scryptR, scryptP, keyLength)
+	if err != nil {
+		return "", "", 0, err
+	}
+	return encode(key), encode(salt), scryptLogN, nil
+}
+
+func verifyScrypt(auth *schemav1alpha1.AuthenticationData, password string) (bool, error) {
+	logN := auth.GetIterations()
+	if logN < 1 || logN > maxScryptLogN {
+		return false, fmt.Errorf("%w: scrypt cost 2^%d out of range", ErrInvalidHash, logN)
+	}
+	salt, key, err := decodeSaltAndKey(auth.GetPasswordSalt(), auth.GetPasswordHash())
+	if err != nil {
+		return false, err
+	}
+
+	computed, err := scrypt.Key([]byte(password), salt, 1<<logN, scryptR, scryptP, len(key))
+	if err != nil {
+		return false, fmt.Errorf("%w: %w", ErrInvalidHash, err)
+	}
+	return subtle.ConstantTimeCompare(computed, key) == 1, nil
+}
+
+// pbkdf2Hasher returns a PBKDF2 hasher using the hash function h with the
+// given number of rounds for new hashes.
+func pbkdf2Hasher[H hash.Hash](h func() H, rounds int32) passwordHasher {
+	return passwordHasher{
+		hash: func(password string) (string, string, int32, error) {
+			salt, err := randomBytes(saltLength)
+			if err != nil {
+				return "", "", 0, err
+			}
+			key, err := pbkdf2.Key(h, password, salt, int(rounds), keyLength)
+			if err != nil {
+				return "", "", 0, err
+			}
+			return encode(key), encode(salt), rounds, nil
+		},
+		verify: func(auth *schemav1alpha1.AuthenticationData, password string) (bool, error) {
+			if auth.GetIterations() < 1 || auth.GetIterations() > maxPBKDF2Rounds {
+				return false, fmt.Errorf("%w: PBKDF2 iteration count %d out of range", ErrInvalidHash, auth.GetIterations())
+			}
+			salt, key, err := decodeSaltAndKey(auth.GetPasswordSalt(), auth.GetPasswordHash())
+			if err != nil {
+				return false, err
+			}
+
+			computed, err := pbkdf2.Key(h, password, salt, int(auth.GetIterations()), len(key))
+			if err != nil {
+				return false, fmt.Errorf("%w: %w", ErrInvalidHash, err)
+			}
+			return subtle.ConstantTimeCompare(computed, key) == 1, nil
+		},
+	}
+}
+
+// generatePassword returns a random password of the given length that
+// contains lowercase and uppercase letters, digits and symbols.
+func generatePassword(length int) (string, error) {
+	limit := big.NewInt(int64(len(passwordAlphabet)))
+	for {
+		var b strings.Builder
+		for range length {
+			n, err := rand.Int(rand.Reader, limit)
+			if err != nil {
+				return "", err
+			}
+			b.WriteByte(passwordAlphabet[n.Int64()])
+		}
+		if password := b.String(); characterClasses(password) == characterClassCount {
+			return password, nil
+		}
+	}
+}
+
+// characterClasses returns how many of lowercase letters, uppercase
+// letters, digits and symbols password contains.
+func characterClasses(password string) int {
+	var lower, upper, digit, symbol int
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			symbol = 1
+		}
+	}
+	return lower + upper + digit + symbol
+}
+
+// validUsername reports whether username consists of 1 to 64 letters,
+// digits, dots, underscores and hyphens.
+func validUsername(username string) bool {
+	if username == "" || len(username) > maxUsernameLength {
+		return false
+	}
+	for _, r := range username {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') && r != '.' && r != '_' && r != '-' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,225 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package usermgr
+
+import (
+	"errors"
+	"testing"
+
+	schemav1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+)
+
+const (
+	algArgon2id     = schemav1alpha1.PasswordHashAlgorithm_PASSWORD_HASH_ALGORITHM_ARGON2ID
+	algBcrypt       = schemav1alpha1.PasswordHashAlgorithm_PASSWORD_HASH_ALGORITHM_BCRYPT
+	algScrypt       = schemav1alpha1.PasswordHashAlgorithm_PASSWORD_HASH_ALGORITHM_SCRYPT
+	algPBKDF2SHA256 = schemav1alpha1.PasswordHashAlgorithm_PASSWORD_HASH_ALGORITHM_PBKDF2_SHA256
+	algPBKDF2SHA512 = schemav1alpha1.PasswordHashAlgorithm_PASSWORD_HASH_ALGORITHM_PBKDF2_SHA512
+)
+
+// importedHash returns the authentication data of an imported hash.
+func importedHash(algorithm schemav1alpha1.PasswordHashAlgorithm, hash, salt string, iterations int32) *schemav1alpha1.AuthenticationData {
+	auth := &schemav1alpha1.AuthenticationData{
+		PasswordHash:  hash,
+		HashAlgorithm: algorithm,
+		Iterations:    iterations,
+	}
+	if salt != "" {
+		auth.PasswordSalt = &salt
+	}
+	return auth
+}
+
+func TestPasswordRoundTrip(t *testing.T) {
+	for _, algorithm := range []schemav1alpha1.PasswordHashAlgorithm{
+		algArgon2id, algBcrypt, algScrypt, algPBKDF2SHA256, algPBKDF2SHA512,
+	} {
+		t.Run(algorithm.String(), func(t *testing.T) {
+			auth, err := newAuthData(algorithm, "Tr0ub4dor&3")
+			if err != nil {
+				t.Fatalf("newAuthData() error = %v", err)
+			}
+			if err := checkImportedHash(auth); err != nil {
+				t.Errorf("checkImportedHash() error = %v", err)
+			}
+
+			for password, want := range map[string]bool{
+				"Tr0ub4dor&3": true,
+				"Tr0ub4dor&4": false,
+				"":            false,
+			} {
+				ok, err := verifyPassword(auth, password)
+				if err != nil {
+					t.Fatalf("verifyPassword(%q) error = %v", password, err)
+				}
+				if ok != want {
+					t.Errorf("verifyPassword(%q) = %v, want %v", password, ok, want)
+				}
+			}
+		})
+	}
+}
+
+// The vectors are taken from the reference implementations: the OpenWall
+// crypt_blowfish tests, the argon2 command line tool, RFC 7914 and Python's
+// hashlib.
+func TestPasswordKnownAnswers(t *testing.T) {
+	tests := []struct {
+		name     string
+		auth     *schemav1alpha1.AuthenticationData
+		password string
+	}{
+		{
+			name:     "bcrypt",
+			auth:     importedHash(algBcrypt, "$2a$05$CCCCCCCCCCCCCCCCCCCCC.E5YPO9kmyuRGyh0XouQYb4YMJKvyOeW", "", 5),
+			password: "U*U",
+		},
+		{
+			name:     "argon2id",
+			auth:     importedHash(algArgon2id, "$argon2id$v=19$m=65536,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc", "", 2),
+			password: "password",
+		},
+		{
+			name:     "scrypt",
+			auth:     importedHash(algScrypt, "6g3umF+uVrJsObaTZhIbbTlgrvOEFcCItdwSjtPF67M", "MDEyMzQ1Njc4OWFiY2RlZg", 10),
+			password: "correct horse",
+		},
+		{
+			name: "PBKDF2-SHA256",
+			auth: importedHash(algPBKDF2SHA256,
+				"VawEblbjCJ/sFpHCJUS2BflBhSFt3gRl5oudV8INrLxJypzM8Xm2RZkWZLOdd+8xfHG4RbHjC9UJESBB06GXgw", "c2FsdA", 1),
+			password: "passwd",
+		},
+		{
+			name:     "PBKDF2-SHA512",
+			auth:     importedHash(algPBKDF2SHA512, "OM0FAoIqCVK1sWtxDiffVlBejtLa+ks4TP71JiecwuQ", "MDEyMzQ1Njc4OWFiY2RlZg", 1000),
+			password: "correct horse",
+		},
+		{
+			name:     "padded base64",
+			auth:     importedHash(algPBKDF2SHA512, "OM0FAoIqCVK1sWtxDiffVlBejtLa+ks4TP71JiecwuQ=", "MDEyMzQ1Njc4OWFiY2RlZg==", 1000),
+			password: "correct horse",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkImportedHash(tt.auth); err != nil {
+				t.Fatalf("checkImportedHash() error = %v", err)
+			}
+			if ok, err := verifyPassword(tt.auth, tt.password); err != nil || !ok {
+				t.Errorf("verifyPassword() = %v, %v, want true", ok, err)
+			}
+			if ok, err := verifyPassword(tt.auth, tt.password+"x"); err != nil || ok {
+				t.Errorf("verifyPassword() with another password = %v, %v, want false", ok, err)
+			}
+		})
+	}
+}
+
+func TestPasswordMalformedHash(t *testing.T) {
+	const (
+		salt = "MDEyMzQ1Njc4OWFiY2RlZg"
+		key  = "OM0FAoIqCVK1sWtxDiffVlBejtLa+ks4TP71JiecwuQ"
+	)
+
+	tests := []struct {
+		name    string
+		auth    *schemav1alpha1.AuthenticationData
+		wantErr error
+	}{
+		{name: "empty hash", auth: importedHash(algScrypt, "", salt, 10), wantErr: ErrInvalidHash},
+		{name: "unsupported algorithm", auth: importedHash(schemav1alpha1.PasswordHashAlgorithm(99), key, salt, 10), wantErr: ErrUnsupportedHashAlgorithm},
+		{name: "scrypt empty salt", auth: importedHash(algScrypt, key, "", 10), wantErr: ErrInvalidHash},
+		{name: "scrypt empty key", auth: importedHash(algScrypt, "=", salt, 10), wantErr: ErrInvalidHash},
+		{name: "scrypt short key", auth: importedHash(algScrypt, "AAAA", salt, 10), wantErr: ErrInvalidHash},
+		{name: "scrypt cost too high", auth: importedHash(algScrypt, key, salt, maxScryptLogN+1), wantErr: ErrInvalidHash},
+		{name: "scrypt cost zero", auth: importedHash(algScrypt, key, salt, 0), wantErr: ErrInvalidHash},
+		{name: "PBKDF2 empty salt", auth: importedHash(algPBKDF2SHA256, key, "", 1000), wantErr: ErrInvalidHash},
+		{name: "PBKDF2 empty key", auth: importedHash(algPBKDF2SHA512, "==", salt, 1000), wantErr: ErrInvalidHash},
+		{name: "PBKDF2 bad base64", auth: importedHash(algPBKDF2SHA256, "not base64!", salt, 1000), wantErr: ErrInvalidHash},
+		{name: "PBKDF2 no iterations", auth: importedHash(algPBKDF2SHA256, key, salt, 0), wantErr: ErrInvalidHash},
+		{name: "PBKDF2 iterations too high", auth: importedHash(algPBKDF2SHA512, key, salt, maxPBKDF2Rounds+1), wantErr: ErrInvalidHash},
+		{name: "bcrypt garbage", auth: importedHash(algBcrypt, "$2a$05$short", "", 5), wantErr: ErrInvalidHash},
+		{
+			name:    "bcrypt cost too high",
+			auth:    importedHash(algBcrypt, "$2a$31$CCCCCCCCCCCCCCCCCCCCC.E5YPO9kmyuRGyh0XouQYb4YMJKvyOeW", "", 31),
+			wantErr: ErrInvalidHash,
+		},
+		{name: "argon2id not PHC", auth: importedHash(algArgon2id, key, salt, 2), wantErr: ErrInvalidHash},
+		{name: "argon2id wrong version", auth: importedHash(algArgon2id, "$argon2id$v=16$m=65536,t=2,p=1$"+salt+"$"+key, "", 2), wantErr: ErrInvalidHash},
+		{name: "argon2id empty salt", auth: importedHash(algArgon2id, "$argon2id$v=19$m=65536,t=2,p=1$$"+key, "", 2), wantErr: ErrInvalidHash},
+		{name: "argon2id empty key", auth: importedHash(algArgon2id, "$argon2id$v=19$m=65536,t=2,p=1$"+salt+"$", "", 2), wantErr: ErrInvalidHash},
+		{name: "argon2id no time", auth: importedHash(algArgon2id, "$argon2id$v=19$m=65536,t=0,p=1$"+salt+"$"+key, "", 0), wantErr: ErrInvalidHash},
+		{name: "argon2id time too high", auth: importedHash(algArgon2id, "$argon2id$v=19$m=65536,t=11,p=1$"+salt+"$"+key, "", 11), wantErr: ErrInvalidHash},
+		{name: "argon2id memory too high", auth: importedHash(algArgon2id, "$argon2id$v=19$m=4194304,t=2,p=1$"+salt+"$"+key, "", 2), wantErr: ErrInvalidHash},
+		{name: "argon2id threads too high", auth: importedHash(algArgon2id, "$argon2id$v=19$m=65536,t=2,p=9$"+salt+"$"+key, "", 2), wantErr: ErrInvalidHash},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkImportedHash(tt.auth); !errors.Is(err, tt.wantErr) {
+				t.Errorf("checkImportedHash() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPasswordRehashOnLogin(t *testing.T) {
+	s := newTestUserMgr(t)
+
+	_, err := s.createUser(t.Context(), &schemav1alpha1.CreateUserRequest{
+		User: &schemav1alpha1.User{
+			Username:     "imported",
+			Enabled:      true,
+			SourceSystem: schemav1alpha1.UserSource_USER_SOURCE_LOCAL,
+			AuthData:     importedHash(algScrypt, "6g3umF+uVrJsObaTZhIbbTlgrvOEFcCItdwSjtPF67M", "MDEyMzQ1Njc4OWFiY2RlZg", 10),
+		},
+	})
+	if err != nil {
+		t.Fatalf("createUser() error = %v", err)
+	}
+
+	if login(t, s, "imported", "correct horsex") {
+		t.Fatal("login with a wrong password let in")
+	}
+	if got := userAuth(t, s, "imported").GetHashAlgorithm(); got != algScrypt {
+		t.Fatalf("algorithm after a failed login = %v, want %v", got, algScrypt)
+	}
+
+	if !login(t, s, "imported", "correct horse") {
+		t.Fatal("login with the imported password failed")
+	}
+	if got := userAuth(t, s, "imported").GetHashAlgorithm(); got != s.config.hashAlgorithm {
+		t.Errorf("algorithm after login = %v, want %v", got, s.config.hashAlgorithm)
+	}
+	if !login(t, s, "imported", "correct horse") {
+		t.Error("login with the rehashed password failed")
+	}
+}
+
+func TestPasswordImportRejectsMalformedHash(t *testing.T) {
+	s := newTestUserMgr(t)
+
+	_, err := s.createUser(t.Context(), &schemav1alpha1.CreateUserRequest{
+		User: &schemav1alpha1.User{
+			Username:     "imported",
+			Enabled:      true,
+			SourceSystem: schemav1alpha1.UserSource_USER_SOURCE_LOCAL,
+			AuthData:     importedHash(algPBKDF2SHA256, "VawEblbjCJ/sFpHCJUS2BflBhSFt3gRl5oudV8INrLxJypzM8Xm2RZkWZLOdd+8xfHG4RbHjC9UJESBB06GXgw", "", 1),
+		},
+	})
+	if !errors.Is(err, ErrInvalidHash) {
+		t.Errorf("createUser() error = %v, want %v", err, ErrInvalidHash)
+	}
+}
+
+// userAuth returns the stored authentication data of a user.
+func userAuth(t *testing.T, s *UserMgr, username string) *schemav1alpha1.AuthenticationData {
+	t.Helper()
+	user, err := s.store.byUsername(username)
+	if err != nil {
+		t.Fatalf("byUsername(%q) error = %v", username, err)
+	}
+	return user.GetAuthData()
+}
@@ -0,0 +1,301 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package usermgr
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	schemav1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Password and lockout policy limits.
+const (
+	maxLockoutThreshold    = 999
+	maxPasswordHistory     = 24
+	characterClassCount    = 4
+	minUsernameMatchLength = 3
+)
+
+// commonPasswords returns the passwords the dictionary check rejects in
+// addition to the words of the configured dictionary file.
+func commonPasswords() []string {
+	return []string{
+		"password", "passw0rd", "p@ssw0rd", "p@ssword", "qwerty", "qwertyuiop", "azerty",
+		"letmein", "welcome", "changeme", "default", "administrator", "admin", "root",
+		"toor", "calvin", "openbmc", "0penbmc", "superuser", "supervisor", "operator",
+		"iloveyou", "trustno1", "monkey", "dragon", "master", "sunshine", "princess",
+		"football", "baseball", "abc", "abcdef", "abcdefgh", "abcd1234", "1q2w3e4r",
+		"12345678", "123456789", "1234567890", "87654321", "11111111", "00000000",
+	}
+}
+
+// lockoutPolicy decides when failed logins lock an account. A zero
+// threshold or duration disables the lockout, a zero reset period keeps
+// counting failed logins until the next successful one.
+type lockoutPolicy struct {
+	threshold  int32
+	duration   time.Duration
+	resetAfter time.Duration
+}
+
+// lockoutPolicy returns the lockout policy of user, which is the Redfish
+// lockout policy stored with the account if there is one and the configured
+// policy otherwise.
+func (s *UserMgr) lockoutPolicy(user *schemav1alpha1.User) lockoutPolicy {
+	if p := user.GetRedfishInfo().GetLockoutPolicy(); p != nil {
+		return lockoutPolicy{
+			threshold:  p.GetThreshold(),
+			duration:   parseLockoutDuration(p.GetDuration()),
+			resetAfter: parseLockoutDuration(p.GetResetAfter()),
+		}
+	}
+	return lockoutPolicy{
+		threshold:  s.config.lockoutThreshold,
+		duration:   s.config.lockoutDuration,
+		resetAfter: s.config.lockoutResetAfter,
+	}
+}
+
+// redfishLockoutPolicy returns the configured lockout policy in the form it
+// is stored with Redfish accounts.
+func (s *UserMgr) redfishLockoutPolicy() *schemav1alpha1.RedfishLockoutPolicy {
+	duration := fmt.Sprintf("PT%dS", int64(s.config.lockoutDuration/time.Second))
+	resetAfter := fmt.Sprintf("PT%dS", int64(s.config.lockoutResetAfter/time.Second))
+	return &schemav1alpha1.RedfishLockoutPolicy{
+		Threshold:  s.config.lockoutThreshold,
+		Duration:   &duration,
+		ResetAfter: &resetAfter,
+	}
+}
+
+// parseLockoutDuration parses a duration of RedfishLockoutPolicy such as
+// PT30S or PT5M. Malformed durations yield zero.
+func parseLockoutDuration(s string) time.Duration {
+	rest, ok := strings.CutPrefix(s, "PT")
+	if !ok || len(rest) < 2 {
+		return 0
+	}
+	n, err := strconv.ParseUint(rest[:len(rest)-1], 10, 32)
+	if err != nil {
+		return 0
+	}
+	switch rest[len(rest)-1] {
+	case 'H':
+		return time.Duration(n) * time.Hour
+	case 'M':
+		return time.Duration(n) * time.Minute
+	case 'S':
+		return time.Duration(n) * time.Second
+	default:
+		return 0
+	}
+}
+
+// enabled reports whether the policy locks accounts at all.
+func (p lockoutPolicy) enabled() bool {
+	return p.threshold > 0 && p.duration > 0
+}
+
+// locked reports whether the lock recorded in li is still in effect at now.
+// Locks caused by failed logins lift once the lockout duration has passed,
+// all other locks last until an administrator unlocks the account.
+func (p lockoutPolicy) locked(li *schemav1alpha1.AccountLockoutInfo, now time.Time) bool {
+	if !li.GetLocked() {
+		return false
+	}
+	if li.GetReason() != schemav1alpha1.LockoutReason_LOCKOUT_REASON_FAILED_LOGIN_ATTEMPTS {
+		return true
+	}
+	return p.enabled() && now.Before(li.GetLockoutTime().AsTime().Add(p.duration))
+}
+
+// recordFailure counts a failed login in li and locks it once the threshold
+// is reached within the reset period. It reports whether li got locked.
+func (p lockoutPolicy) recordFailure(li *schemav1alpha1.AccountLockoutInfo, now time.Time) bool {
+	if !p.enabled() {
+		return false
+	}
+
+	if li.AttemptsResetTime != nil && !now.Before(li.GetAttemptsResetTime().AsTime()) {
+		li.FailedAttempts = 0
+	}
+	li.FailedAttempts++
+	threshold := p.threshold
+	li.MaxFailedAttempts = &threshold
+	li.AttemptsResetTime = nil
+	if p.resetAfter > 0 {
+		li.AttemptsResetTime = timestamppb.New(now.Add(p.resetAfter))
+	}
+	if li.GetFailedAttempts() < p.threshold {
+		return false
+	}
+
+	reason := schemav1alpha1.LockoutReason_LOCKOUT_REASON_FAILED_LOGIN_ATTEMPTS
+	li.Locked = true
+	li.Reason = &reason
+	li.LockoutTime = timestamppb.New(now)
+	return true
+}
+
+// passwordExpired reports whether the password of auth has expired at now.
+// Passwords without an expiry time expire after the configured maximum age.
+func (s *UserMgr) passwordExpired(auth *schemav1alpha1.AuthenticationData, now time.Time) bool {
+	if auth.PasswordExpiresAt != nil {
+		return !now.Before(auth.GetPasswordExpiresAt().AsTime())
+	}
+	return s.config.passwordMaxAge > 0 && auth.PasswordLastChanged != nil &&
+		!now.Before(auth.GetPasswordLastChanged().AsTime().Add(s.config.passwordMaxAge))
+}
+
+// accountExpired reports whether user has expired at now.
+func accountExpired(user *schemav1alpha1.User, now time.Time) bool {
+	return user.AccountExpiresAt != nil && !now.Before(user.GetAccountExpiresAt().AsTime())
+}
+
+// loadDictionary builds the set of passwords rejected by the dictionary
+// check from the built-in common passwords and the configured dictionary
+// file, which holds one word per line. Empty lines and lines starting with #
+// are ignored.
+func (s *UserMgr) loadDictionary(ctx context.Context) error {
+	s.dictionary = make(map[string]struct{})
+	for _, word := range commonPasswords() {
+		s.dictionary[word] = struct{}{}
+	}
+	if s.config.dictionaryPath == "" {
+		return nil
+	}
+
+	f, err := os.Open(s.config.dictionaryPath)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidConfiguration, err)
+	}
+	defer f.Close() //nolint:errcheck
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		word := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		if word == "" || strings.HasPrefix(word, "#") {
+			continue
+		}
+		s.dictionary[word] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidConfiguration, err)
+	}
+
+	s.logger.InfoContext(ctx, "Loaded password dictionary", "path", s.config.dictionaryPath, "words", len(s.dictionary))
+
+	return nil
+}
+
+// checkPassword checks that a new password of user meets the password
+// policy: its strength and that it differs from the current and the
+// remembered previous passwords.
+func (s *UserMgr) checkPassword(user *schemav1alpha1.User, password string) error {
+	if err := s.checkPasswordStrength(user, password); err != nil {
+		return err
+	}
+
+	if s.passwordReused(user.GetAuthData(), password) {
+		if s.config.passwordHistory == 0 {
+			return fmt.Errorf("%w: must differ from the current password", ErrInvalidPassword)
+		}
+		return fmt.Errorf("%w: must differ from the current and the last %d passwords",
+			ErrInvalidPassword, s.config.passwordHistory)
+	}
+
+	return nil
+}
+
+// checkPasswordStrength checks the length of a new password of user, the
+// character classes it contains and that it neither contains the username
+// nor is a dictionary word, optionally followed by digits and symbols.
+func (s *UserMgr) checkPasswordStrength(user *schemav1alpha1.User, password string) error {
+	if n := utf8.RuneCountInString(password); n < s.config.passwordMinLength || n > maxPasswordLength {
+		return fmt.Errorf("%w: must be between %d and %d characters long",
+			ErrInvalidPassword, s.config.passwordMinLength, maxPasswordLength)
+	}
+
+	if characterClasses(password) < s.config.passwordCharacterClasses {
+		return fmt.Errorf("%w: must contain at least %d of lowercase letters, uppercase letters, digits and symbols",
+			ErrInvalidPassword, s.config.passwordCharacterClasses)
+	}
+
+	lower := strings.ToLower(password)
+	if name := strings.ToLower(user.GetUsername()); len(name) >= minUsernameMatchLength && strings.Contains(lower, name) {
+		return fmt.Errorf("%w: must not contain the username", ErrInvalidPassword)
+	}
+	stem := strings.TrimRightFunc(lower, func(r rune) bool { return !unicode.IsLetter(r) })
+	for _, word := range []string{lower, stem} {
+		if _, ok := s.dictionary[word]; ok {
+			return fmt.Errorf("%w: must not be a common password", ErrInvalidPassword)
+		}
+	}
+
+	return nil
+}
+
+// passwordReused reports whether password matches the current password of
+// auth or one of its remembered previous passwords. Unverifiable hashes are
+// skipped.
+func (s *UserMgr) passwordReused(auth *schemav1alpha1.AuthenticationData, password string) bool {
+	candidates := []*schemav1alpha1.AuthenticationData{auth}
+	for i, entry := range auth.GetPasswordHistory() {
+		if i == s.config.passwordHistory {
+			break
+		}
+		candidates = append(candidates, &schemav1alpha1.AuthenticationData{
+			PasswordHash:  entry.GetPasswordHash(),
+			PasswordSalt:  entry.PasswordSalt,
+			HashAlgorithm: entry.GetHashAlgorithm(),
+			Iterations:    entry.GetIterations(),
+		})
+	}
+
+	for _, candidate := range candidates {
+		if ok, err := verifyPassword(candidate, password); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// setPassword replaces the password of user, remembering the previous one
+// for the history check. The lockout state and the second factor are kept,
+// and the new password expires after the configured maximum age.
+func (s *UserMgr) setPassword(user *schemav1alpha1.User, password string) error {
+	next, err := newAuthData(s.config.hashAlgorithm, password)
+	if err != nil {
+		return err
+	}
+
+	prev := user.GetAuthData()
+	if prev.GetPasswordHash() != "" && s.config.passwordHistory > 0 {
+		history := append([]*schemav1alpha1.PasswordHistoryEntry{{
+			PasswordHash:  prev.GetPasswordHash(),
+			PasswordSalt:  prev.PasswordSalt,
+			HashAlgorithm: prev.GetHashAlgorithm(),
+			Iterations:    prev.GetIterations(),
+			ChangedAt:     prev.GetPasswordLastChanged(),
+		}}, prev.GetPasswordHistory()...)
+		next.PasswordHistory = history[:min(len(history), s.config.passwordHistory)]
+	}
+	next.LockoutInfo = prev.GetLockoutInfo()
+	next.Totp = prev.GetTotp()
+	next.TotpRequired = prev.TotpRequired
+	if s.config.passwordMaxAge > 0 {
+		next.PasswordExpiresAt = timestamppb.New(next.GetPasswordLastChanged().AsTime().Add(s.config.passwordMaxAge))
+	}
+
+	user.AuthData = next
+	return nil
+}
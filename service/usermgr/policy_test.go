@@ -0,0 +1,285 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package usermgr
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	schemav1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// createTestUser creates an enabled local user with password and returns
+// it.
+func createTestUser(t *testing.T, s *UserMgr, username, password string) *schemav1alpha1.User {
+	t.Helper()
+	resp, err := s.createUser(t.Context(), &schemav1alpha1.CreateUserRequest{
+		User: &schemav1alpha1.User{
+			Username:     username,
+			Enabled:      true,
+			SourceSystem: schemav1alpha1.UserSource_USER_SOURCE_LOCAL,
+		},
+		Password: &password,
+	})
+	if err != nil {
+		t.Fatalf("createUser(%q) error = %v", username, err)
+	}
+	return resp.GetUser()
+}
+
+// authenticateFailure authenticates a user and returns the failure, which
+// is unspecified if it was let in.
+func authenticateFailure(t *testing.T, s *UserMgr, username, password string) schemav1alpha1.AuthenticationFailure {
+	t.Helper()
+	resp, err := s.authenticate(t.Context(), &schemav1alpha1.AuthenticateUserRequest{
+		Username: username,
+		Password: password,
+	})
+	if err != nil {
+		t.Fatalf("authenticate() error = %v", err)
+	}
+	return resp.GetFailure()
+}
+
+func TestCheckPassword(t *testing.T) {
+	dictionary := filepath.Join(t.TempDir(), "words")
+	if err := os.WriteFile(dictionary, []byte("# site words\nRackspace\n\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	s := newTestUserMgr(t, WithPasswordDictionary(dictionary))
+	user := &schemav1alpha1.User{Username: "alice"}
+
+	tests := []struct {
+		name     string
+		password string
+		wantErr  error
+	}{
+		{name: "meets the policy", password: "Tr0ub4dor&3"},
+		{name: "too short", password: "Tr0b&3x", wantErr: ErrInvalidPassword},
+		{name: "too long", password: "Tr0ub4dor&3" + strings.Repeat("x", maxPasswordLength), wantErr: ErrInvalidPassword},
+		{name: "length counts characters", password: "Trüb4dor&äö"},
+		{name: "two character classes", password: "troubadour33", wantErr: ErrInvalidPassword},
+		{name: "three character classes", password: "troubadour&3"},
+		{name: "contains the username", password: "xAlice-2024!", wantErr: ErrInvalidPassword},
+		{name: "common password", password: "P@ssw0rd", wantErr: ErrInvalidPassword},
+		{name: "common password followed by digits", password: "Password2024!", wantErr: ErrInvalidPassword},
+		{name: "dictionary word", password: "RACKSPACE#1", wantErr: ErrInvalidPassword},
+		{name: "dictionary word within", password: "myRackspace#1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := s.checkPassword(user, tt.password); !errors.Is(err, tt.wantErr) {
+				t.Errorf("checkPassword(%q) error = %v, want %v", tt.password, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckPasswordShortUsername(t *testing.T) {
+	s := newTestUserMgr(t)
+
+	// Usernames shorter than minUsernameMatchLength are not looked for.
+	if err := s.checkPassword(&schemav1alpha1.User{Username: "ab"}, "Grab-b4g-now"); err != nil {
+		t.Errorf("checkPassword() error = %v", err)
+	}
+}
+
+func TestPasswordHistory(t *testing.T) {
+	passwords := []string{"Tr0ub4dor&0", "Tr0ub4dor&1", "Tr0ub4dor&2", "Tr0ub4dor&3"}
+
+	tests := []struct {
+		name    string
+		history int
+		// reusable is the index of the newest password that may be set again.
+		reusable int
+	}{
+		{name: "current only", history: 0, reusable: 2},
+		{name: "depth 1", history: 1, reusable: 1},
+		{name: "depth 2", history: 2, reusable: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestUserMgr(t, WithPasswordHistory(tt.history))
+			id := createTestUser(t, s, "alice", passwords[0]).GetId()
+			for _, password := range passwords[1:] {
+				resp, err := s.resetPassword(t.Context(), &schemav1alpha1.ResetPasswordRequest{Id: id, NewPassword: &password})
+				if err != nil || !resp.GetSuccess() {
+					t.Fatalf("resetPassword(%q) = %v, %v", password, resp.GetFailureReason(), err)
+				}
+			}
+
+			user, err := s.store.get(id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := len(user.GetAuthData().GetPasswordHistory()); got != tt.history {
+				t.Errorf("%d remembered passwords, want %d", got, tt.history)
+			}
+			for i, password := range passwords {
+				err := s.checkPassword(user, password)
+				if wantReused := i > tt.reusable; errors.Is(err, ErrInvalidPassword) != wantReused {
+					t.Errorf("checkPassword(%q) error = %v, want reuse rejected = %v", password, err, wantReused)
+				}
+			}
+		})
+	}
+}
+
+func TestForcedResetPassword(t *testing.T) {
+	s := newTestUserMgr(t)
+	id := createTestUser(t, s, "alice", "Tr0ub4dor&3").GetId()
+	force := true
+
+	tests := []struct {
+		name        string
+		password    string
+		force       bool
+		wantSuccess bool
+	}{
+		{name: "current password", password: "Tr0ub4dor&3", wantSuccess: false},
+		{name: "forced current password", password: "Tr0ub4dor&3", force: true, wantSuccess: true},
+		{name: "forced too short", password: "Tr0&3x", force: true, wantSuccess: false},
+		{name: "forced common password", password: "Password1!", force: true, wantSuccess: false},
+		{name: "forced with username", password: "alice-Tr0ub4dor", force: true, wantSuccess: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &schemav1alpha1.ResetPasswordRequest{Id: id, NewPassword: &tt.password}
+			if tt.force {
+				req.Force = &force
+			}
+			resp, err := s.resetPassword(t.Context(), req)
+			if err != nil {
+				t.Fatalf("resetPassword() error = %v", err)
+			}
+			if resp.GetSuccess() != tt.wantSuccess {
+				t.Errorf("resetPassword(%q) success = %v (%s), want %v", tt.password, resp.GetSuccess(), resp.GetFailureReason(), tt.wantSuccess)
+			}
+		})
+	}
+}
+
+func TestLockoutPolicy(t *testing.T) {
+	policy := lockoutPolicy{threshold: 3, duration: 5 * time.Minute, resetAfter: time.Minute}
+	start := time.Now()
+	li := &schemav1alpha1.AccountLockoutInfo{}
+
+	// Failures further apart than the reset period start counting over.
+	policy.recordFailure(li, start)
+	policy.recordFailure(li, start.Add(30*time.Second))
+	if policy.recordFailure(li, start.Add(2*time.Minute)) {
+		t.Fatal("locked after failures spread beyond the reset period")
+	}
+	if li.GetFailedAttempts() != 1 {
+		t.Fatalf("failed attempts = %d, want 1", li.GetFailedAttempts())
+	}
+
+	policy.recordFailure(li, start.Add(2*time.Minute+10*time.Second))
+	locked := start.Add(2*time.Minute + 20*time.Second)
+	if !policy.recordFailure(li, locked) {
+		t.Fatal("not locked at the threshold")
+	}
+	if !policy.locked(li, locked.Add(5*time.Minute-time.Second)) {
+		t.Error("lock lifted before the lockout duration")
+	}
+	if policy.locked(li, locked.Add(5*time.Minute)) {
+		t.Error("lock still in place after the lockout duration")
+	}
+
+	// Locks by administrators only lift when they unlock the account.
+	reason := schemav1alpha1.LockoutReason_LOCKOUT_REASON_ADMINISTRATIVE
+	li.Reason = &reason
+	if !policy.locked(li, locked.Add(time.Hour)) {
+		t.Error("administrative lock lifted by time")
+	}
+
+	if (lockoutPolicy{threshold: 0, duration: time.Minute}).recordFailure(&schemav1alpha1.AccountLockoutInfo{}, start) {
+		t.Error("a zero threshold locked an account")
+	}
+}
+
+func TestLockout(t *testing.T) {
+	s := newTestUserMgr(t, WithLockoutPolicy(3, time.Minute, time.Minute))
+	id := createTestUser(t, s, "alice", "Tr0ub4dor&3").GetId()
+
+	for range 2 {
+		if login(t, s, "alice", "wrong") {
+			t.Fatal("wrong password let in")
+		}
+	}
+	if !login(t, s, "alice", "Tr0ub4dor&3") {
+		t.Fatal("login below the threshold failed")
+	}
+
+	// The successful login reset the count.
+	for range 2 {
+		login(t, s, "alice", "wrong")
+	}
+	if got := authenticateFailure(t, s, "alice", "wrong"); got != schemav1alpha1.AuthenticationFailure_AUTHENTICATION_FAILURE_INVALID_CREDENTIALS {
+		t.Fatalf("third failure = %v, want invalid credentials", got)
+	}
+	if got := authenticateFailure(t, s, "alice", "Tr0ub4dor&3"); got != schemav1alpha1.AuthenticationFailure_AUTHENTICATION_FAILURE_ACCOUNT_LOCKED {
+		t.Fatalf("login after the threshold = %v, want account locked", got)
+	}
+
+	if _, err := s.store.update(t.Context(), id, func(user *schemav1alpha1.User) error {
+		user.AuthData.LockoutInfo.LockoutTime = timestamppb.New(time.Now().Add(-time.Minute))
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if !login(t, s, "alice", "Tr0ub4dor&3") {
+		t.Error("login after the lockout duration failed")
+	}
+}
+
+func TestPasswordExpiry(t *testing.T) {
+	s := newTestUserMgr(t, WithPasswordMaxAge(time.Hour))
+	id := createTestUser(t, s, "alice", "Tr0ub4dor&3").GetId()
+
+	if !login(t, s, "alice", "Tr0ub4dor&3") {
+		t.Fatal("login with a fresh password failed")
+	}
+
+	if _, err := s.store.update(t.Context(), id, func(user *schemav1alpha1.User) error {
+		user.AuthData.PasswordExpiresAt = timestamppb.New(time.Now().Add(-time.Second))
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if got := authenticateFailure(t, s, "alice", "Tr0ub4dor&3"); got != schemav1alpha1.AuthenticationFailure_AUTHENTICATION_FAILURE_PASSWORD_EXPIRED {
+		t.Fatalf("login with an expired password = %v, want password expired", got)
+	}
+	// Wrong passwords are not told that the password expired.
+	if got := authenticateFailure(t, s, "alice", "wrong"); got != schemav1alpha1.AuthenticationFailure_AUTHENTICATION_FAILURE_INVALID_CREDENTIALS {
+		t.Errorf("wrong password = %v, want invalid credentials", got)
+	}
+
+	resp, err := s.changePassword(t.Context(), &schemav1alpha1.ChangePasswordRequest{
+		Id:              id,
+		CurrentPassword: "Tr0ub4dor&3",
+		NewPassword:     "Corr3ct-Horse",
+	})
+	if err != nil || !resp.GetSuccess() {
+		t.Fatalf("changePassword() = %v, %v", resp.GetFailureReason(), err)
+	}
+	if !login(t, s, "alice", "Corr3ct-Horse") {
+		t.Error("login after changing the expired password failed")
+	}
+
+	user, err := s.store.get(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !s.passwordExpired(user.GetAuthData(), time.Now().Add(time.Hour)) {
+		t.Error("new password does not expire after the maximum age")
+	}
+}
@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package usermgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	schemav1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+	"github.com/u-bmc/u-bmc/pkg/radius"
+)
+
+// serviceTypeLogin is the Login value of the Service-Type attribute.
+const serviceTypeLogin = 1
+
+// validate checks that the RADIUS configuration is usable.
+func (c *RADIUSConfig) validate() error {
+	if len(c.Servers) == 0 {
+		return fmt.Errorf("RADIUS requires at least one server")
+	}
+	for _, server := range c.Servers {
+		if server.Address == "" || server.Secret == "" || server.Timeout <= 0 {
+			return fmt.Errorf("RADIUS servers require an address, a secret and a positive timeout")
+		}
+	}
+	if len(c.NASIdentifier) > 253 {
+		return fmt.Errorf("RADIUS NAS identifier cannot exceed 253 bytes")
+	}
+	return validatePrivilegeRoles("RADIUS", c.PrivilegeRoles, c.DefaultRole, c.CacheTTL)
+}
+
+// validatePrivilegeRoles checks the role mapping and cache TTL of RADIUS or
+// TACACS+.
+func validatePrivilegeRoles(name string, mappings []PrivilegeRole, defaultRole string, cacheTTL time.Duration) error {
+	for _, m := range mappings {
+		if m.Privilege == "" || m.Role == "" {
+			return fmt.Errorf("%s privilege role mappings require a privilege and a role", name)
+		}
+	}
+	if len(mappings) == 0 && defaultRole == "" {
+		return fmt.Errorf("%s requires privilege role mappings or a default role", name)
+	}
+	if cacheTTL < 0 {
+		return fmt.Errorf("%s cache TTL cannot be negative", name)
+	}
+	return nil
+}
+
+// radiusAuthenticator authenticates users against the configured RADIUS
+// servers.
+type radiusAuthenticator struct {
+	config        *RADIUSConfig
+	nasIdentifier string
+}
+
+func newRADIUSAuthenticator(config *RADIUSConfig, serviceName string) *radiusAuthenticator {
+	nasIdentifier := config.NASIdentifier
+	if nasIdentifier == "" {
+		nasIdentifier = serviceName
+	}
+	return &radiusAuthenticator{config: config, nasIdentifier: nasIdentifier}
+}
+
+func (r *radiusAuthenticator) source() schemav1alpha1.UserSource {
+	return schemav1alpha1.UserSource_USER_SOURCE_RADIUS
+}
+
+func (r *radiusAuthenticator) cacheTTL() time.Duration {
+	return r.config.CacheTTL
+}
+
+// authenticate sends an Access-Request to the servers in order, moving on
+// to the next one while a server does not answer within its timeout. The
+// role of the user is taken from the privilege vendor attribute of the
+// Access-Accept.
+func (r *radiusAuthenticator) authenticate(ctx context.Context, req *schemav1alpha1.AuthenticateUserRequest) (*remoteIdentity, error) {
+	request := &radius.Request{
+		Username: req.GetUsername(),
+		Password: req.GetPassword(),
+		Type:     radius.AuthPAP,
+		Attributes: []radius.Attribute{
+			{Type: radius.AttributeServiceType, Value: []byte{0, 0, 0, serviceTypeLogin}},
+			{Type: radius.AttributeNASIdentifier, Value: []byte(r.nasIdentifier)},
+		},
+	}
+	if r.config.CHAP {
+		request.Type = radius.AuthCHAP
+	}
+	if ip := net.ParseIP(req.GetSourceIp()); ip != nil {
+		request.Attributes = append(request.Attributes,
+			radius.Attribute{Type: radius.AttributeCallingStationID, Value: []byte(ip.String())})
+	}
+
+	return failover(r.config.Servers, func(server RADIUSServer) (*remoteIdentity, error) {
+		ctx, cancel := context.WithTimeout(ctx, server.Timeout)
+		defer cancel()
+
+		client := &radius.Client{
+			Address:                     server.Address,
+			Secret:                      []byte(server.Secret),
+			RequireMessageAuthenticator: r.config.RequireMessageAuthenticator,
+		}
+		accept, err := client.Authenticate(ctx, request)
+		switch {
+		case errors.Is(err, radius.ErrAccessRejected), errors.Is(err, radius.ErrChallengeUnsupported):
+			return nil, fmt.Errorf("%w: %w", ErrRemoteRejected, err)
+		case err != nil:
+			return nil, fmt.Errorf("%s: %w", server.Address, err)
+		}
+
+		var pairs []string
+		for _, value := range accept.VendorSpecific(r.config.PrivilegeVendorID, r.config.PrivilegeVendorType) {
+			pairs = append(pairs, string(value))
+		}
+		privileges := pairValues(pairs, r.config.PrivilegeKey)
+		role := privilegeRole(r.config.PrivilegeRoles, r.config.DefaultRole, privileges)
+		if role == "" {
+			return nil, fmt.Errorf("%w: %s has privileges %q", ErrRemoteNoRole, req.GetUsername(), privileges)
+		}
+		return &remoteIdentity{role: role}, nil
+	})
+}
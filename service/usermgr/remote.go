@@ -0,0 +1,290 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package usermgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	schemav1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+	"github.com/u-bmc/u-bmc/pkg/id"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// authenticator checks credentials against remote authentication servers,
+// such as an LDAP directory or RADIUS and TACACS+ servers.
+type authenticator interface {
+	// source returns the source of the users it lets in.
+	source() schemav1alpha1.UserSource
+	// authenticate checks the username and password of req and returns
+	// what the servers know about the user. Errors are ErrRemoteUnavailable
+	// if no server could answer, ErrRemoteUserNotFound, ErrRemoteRejected
+	// or ErrRemoteNoRole otherwise.
+	authenticate(ctx context.Context, req *schemav1alpha1.AuthenticateUserRequest) (*remoteIdentity, error)
+	// cacheTTL returns how long successful logins are remembered.
+	cacheTTL() time.Duration
+}
+
+// remoteIdentity is what a remote login learned about a user.
+type remoteIdentity struct {
+	role string
+	// ldapInfo is the directory entry of directory users.
+	ldapInfo *schemav1alpha1.LdapUserInfo
+}
+
+// remoteUser reports whether user was let in by a remote authenticator.
+func remoteUser(user *schemav1alpha1.User) bool {
+	switch user.GetSourceSystem() {
+	case schemav1alpha1.UserSource_USER_SOURCE_LDAP, schemav1alpha1.UserSource_USER_SOURCE_AD,
+		schemav1alpha1.UserSource_USER_SOURCE_RADIUS, schemav1alpha1.UserSource_USER_SOURCE_TACACS:
+		return true
+	default:
+		return false
+	}
+}
+
+// failover calls login for each server in turn until one of them answers
+// about the user. Servers that cannot be reached or fail are skipped.
+func failover[S any](servers []S, login func(S) (*remoteIdentity, error)) (*remoteIdentity, error) {
+	errs := make([]error, 0, len(servers))
+	for _, server := range servers {
+		identity, err := login(server)
+		if err == nil || errors.Is(err, ErrRemoteRejected) ||
+			errors.Is(err, ErrRemoteUserNotFound) || errors.Is(err, ErrRemoteNoRole) {
+			return identity, err
+		}
+		errs = append(errs, err)
+	}
+	return nil, fmt.Errorf("%w: %w", ErrRemoteUnavailable, errors.Join(errs...))
+}
+
+// PrivilegeRole maps a privilege sent by a RADIUS or TACACS+ server, such as
+// the privilege level 15, to a role.
+type PrivilegeRole struct {
+	Privilege string
+	Role      string
+}
+
+// privilegeRole returns the role of the first mapping naming one of
+// privileges, defaultRole if none does.
+func privilegeRole(mappings []PrivilegeRole, defaultRole string, privileges []string) string {
+	for _, m := range mappings {
+		for _, privilege := range privileges {
+			if strings.EqualFold(strings.TrimSpace(privilege), m.Privilege) {
+				return m.Role
+			}
+		}
+	}
+	return defaultRole
+}
+
+// pairValues returns the values of the attribute value pairs named key,
+// such as 15 of "shell:priv-lvl=15". Values of optional pairs, separated by
+// * instead of =, are returned as well. An empty key returns pairs as they
+// are.
+func pairValues(pairs []string, key string) []string {
+	if key == "" {
+		return pairs
+	}
+	var values []string
+	for _, pair := range pairs {
+		i := strings.IndexAny(pair, "=*")
+		if i >= 0 && strings.EqualFold(strings.TrimSpace(pair[:i]), key) {
+			values = append(values, pair[i+1:])
+		}
+	}
+	return values
+}
+
+// loginCache remembers successful remote logins for when the servers
+// cannot be reached. Passwords are kept as argon2id hashes.
+type loginCache struct {
+	mu     sync.Mutex
+	logins map[loginCacheKey]cachedLogin
+}
+
+type loginCacheKey struct {
+	source   schemav1alpha1.UserSource
+	username string
+}
+
+type cachedLogin struct {
+	auth    *schemav1alpha1.AuthenticationData
+	expires time.Time
+}
+
+func newLoginCache() *loginCache {
+	return &loginCache{logins: make(map[loginCacheKey]cachedLogin)}
+}
+
+// remember caches a successful login for ttl, dropping expired ones.
+func (c *loginCache) remember(source schemav1alpha1.UserSource, username, password string, ttl time.Duration, now time.Time) error {
+	if ttl <= 0 {
+		return nil
+	}
+	auth, err := newAuthData(schemav1alpha1.PasswordHashAlgorithm_PASSWORD_HASH_ALGORITHM_ARGON2ID, password)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, login := range c.logins {
+		if !now.Before(login.expires) {
+			delete(c.logins, key)
+		}
+	}
+	c.logins[loginCacheKey{source, username}] = cachedLogin{auth: auth, expires: now.Add(ttl)}
+	return nil
+}
+
+// matches reports whether a login of username with password is cached.
+func (c *loginCache) matches(source schemav1alpha1.UserSource, username, password string, now time.Time) bool {
+	c.mu.Lock()
+	login, ok := c.logins[loginCacheKey{source, username}]
+	c.mu.Unlock()
+	if !ok || !now.Before(login.expires) {
+		return false
+	}
+	ok, err := verifyPassword(login.auth, password)
+	return err == nil && ok
+}
+
+// forget drops the cached login of username.
+func (c *loginCache) forget(source schemav1alpha1.UserSource, username string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.logins, loginCacheKey{source, username})
+}
+
+// setupAuthenticators creates the configured remote authenticators.
+func (s *UserMgr) setupAuthenticators() {
+	s.authenticators = make(map[AuthMethod]authenticator)
+	if s.config.ldap != nil {
+		s.authenticators[AuthMethodLDAP] = newDirectory(s.config.ldap)
+	}
+	if s.config.radius != nil {
+		s.authenticators[AuthMethodRADIUS] = newRADIUSAuthenticator(s.config.radius, s.config.name)
+	}
+	if s.config.tacacs != nil {
+		s.authenticators[AuthMethodTACACS] = newTACACSAuthenticator(s.config.tacacs)
+	}
+	s.authOrder = s.config.authenticationOrder()
+	s.loginCache = newLoginCache()
+}
+
+// remoteLogin authenticates a user with a remote authenticator and returns
+// its local record, provisioned or updated with the role and directory
+// entry the servers reported. user is the current record, nil if there is
+// none. While no server can be reached, users of the authenticator whose
+// login with the same password is cached are let in with their current
+// record.
+func (s *UserMgr) remoteLogin(ctx context.Context, req *schemav1alpha1.AuthenticateUserRequest, user *schemav1alpha1.User, a authenticator) (*schemav1alpha1.User, error) {
+	username := req.GetUsername()
+	if !validUsername(username) {
+		return nil, fmt.Errorf("%w: invalid username", ErrRemoteUserNotFound)
+	}
+
+	now := time.Now()
+	identity, err := a.authenticate(ctx, req)
+	switch {
+	case errors.Is(err, ErrRemoteUnavailable):
+		if user.GetSourceSystem() == a.source() && s.loginCache.matches(a.source(), username, req.GetPassword(), now) {
+			s.logger.WarnContext(ctx, "Authentication servers unreachable, authenticated with a cached login",
+				"user", username,
+				"source", a.source(),
+				"error", err)
+			return user, nil
+		}
+		return nil, err
+	case err != nil:
+		s.loginCache.forget(a.source(), username)
+		return nil, err
+	}
+
+	if user != nil && user.GetSourceSystem() != a.source() {
+		return nil, fmt.Errorf("%w: %s is a %s account", ErrRemoteConflict, username, user.GetSourceSystem())
+	}
+	user, err = s.provisionRemoteUser(ctx, user, username, a.source(), identity)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.loginCache.remember(a.source(), username, req.GetPassword(), a.cacheTTL(), now); err != nil {
+		s.logger.WarnContext(ctx, "Failed to cache login", "user", username, "error", err)
+	}
+	return user, nil
+}
+
+// provisionRemoteUser creates or updates the local record of a remote user.
+// Its role follows what the servers report, while local settings such as
+// its enabled state and second factor are kept.
+func (s *UserMgr) provisionRemoteUser(ctx context.Context, user *schemav1alpha1.User, username string, source schemav1alpha1.UserSource, identity *remoteIdentity) (*schemav1alpha1.User, error) {
+	var previousRole string
+	apply := func(user *schemav1alpha1.User) error {
+		if user.GetSourceSystem() != source {
+			return fmt.Errorf("%w: %s is a %s account", ErrRemoteConflict, user.GetUsername(), user.GetSourceSystem())
+		}
+		if identity.ldapInfo != nil {
+			user.LdapInfo = identity.ldapInfo
+		}
+		if user.RedfishInfo == nil {
+			name := user.GetUsername()
+			user.RedfishInfo = &schemav1alpha1.RedfishAccountInfo{AccountId: &name}
+		}
+		previousRole = user.RedfishInfo.GetRoleId()
+		user.RedfishInfo.RoleId = identity.role
+		if user.AuthData == nil {
+			user.AuthData = &schemav1alpha1.AuthenticationData{}
+		}
+		user.UpdatedAt = timestamppb.Now()
+		return nil
+	}
+
+	if user == nil {
+		now := timestamppb.Now()
+		user = &schemav1alpha1.User{
+			Id:           id.NewID(),
+			Username:     username,
+			Enabled:      true,
+			CreatedAt:    now,
+			SourceSystem: source,
+		}
+		if err := apply(user); err != nil {
+			return nil, err
+		}
+		err := s.store.create(ctx, user)
+		if err == nil {
+			s.logger.InfoContext(ctx, "Remote user provisioned",
+				"user", username,
+				"id", user.GetId(),
+				"source", source,
+				"role", identity.role)
+			return user, nil
+		}
+		if !errors.Is(err, ErrUserExists) {
+			return nil, err
+		}
+		// A concurrent login provisioned the user first.
+		if user, err = s.store.byUsername(username); err != nil {
+			return nil, err
+		}
+	}
+
+	updated, err := s.store.update(ctx, user.GetId(), apply)
+	if err != nil {
+		return nil, err
+	}
+	if previousRole != identity.role {
+		s.logger.InfoContext(ctx, "Remote user role changed",
+			"user", username,
+			"source", source,
+			"previous_role", previousRole,
+			"role", identity.role)
+	}
+	return updated, nil
+}
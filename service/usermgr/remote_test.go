@@ -0,0 +1,379 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package usermgr
+
+import (
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+
+	schemav1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+	"github.com/u-bmc/u-bmc/pkg/radius"
+	"github.com/u-bmc/u-bmc/pkg/radius/radiustest"
+	"github.com/u-bmc/u-bmc/pkg/tacacs"
+	"github.com/u-bmc/u-bmc/pkg/tacacs/tacacstest"
+)
+
+const (
+	testRADIUSSecret = "radius-secret"
+	testTACACSKey    = "tacacs-key"
+	// testRemoteTimeout bounds each attempt to reach a test server, so that
+	// silent servers fail quickly.
+	testRemoteTimeout = 300 * time.Millisecond
+)
+
+var testPrivilegeRoles = []PrivilegeRole{{Privilege: "15", Role: "Administrator"}}
+
+func newTestNATS(t *testing.T) *nats.Conn {
+	t.Helper()
+
+	ns, err := server.NewServer(&server.Options{
+		DontListen: true,
+		NoLog:      true,
+		NoSigs:     true,
+		JetStream:  true,
+		StoreDir:   t.TempDir(),
+	})
+	if err != nil {
+		t.Fatalf("create NATS server: %v", err)
+	}
+	ns.Start()
+	t.Cleanup(ns.Shutdown)
+	if !ns.ReadyForConnections(5 * time.Second) {
+		t.Fatal("NATS server not ready")
+	}
+
+	nc, err := nats.Connect("", nats.InProcessServer(ns))
+	if err != nil {
+		t.Fatalf("connect to NATS: %v", err)
+	}
+	t.Cleanup(nc.Close)
+	return nc
+}
+
+// newTestUserMgr sets up a user manager as Run does, without registering
+// its endpoints.
+func newTestUserMgr(t *testing.T, opts ...Option) *UserMgr {
+	t.Helper()
+	ctx := t.Context()
+
+	s := New(append([]Option{WithSecretKeyPath(filepath.Join(t.TempDir(), "secret.key"))}, opts...)...)
+	s.logger = slog.New(slog.DiscardHandler)
+	if err := s.config.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if err := s.loadDictionary(ctx); err != nil {
+		t.Fatalf("loadDictionary() error = %v", err)
+	}
+	if err := s.loadSecretKey(ctx); err != nil {
+		t.Fatalf("loadSecretKey() error = %v", err)
+	}
+	s.setupAuthenticators()
+
+	js, err := jetstream.New(newTestNATS(t))
+	if err != nil {
+		t.Fatalf("jetstream.New() error = %v", err)
+	}
+	if s.store, err = openStore(ctx, js, s.config.bucket, s.config.requestTimeout); err != nil {
+		t.Fatalf("openStore() error = %v", err)
+	}
+	if s.dummyAuth, err = newAuthData(s.config.hashAlgorithm, DefaultAdminPassword); err != nil {
+		t.Fatalf("newAuthData() error = %v", err)
+	}
+	if err := s.ensureDefaultAdmin(ctx); err != nil {
+		t.Fatalf("ensureDefaultAdmin() error = %v", err)
+	}
+	return s
+}
+
+func newTestRADIUS(t *testing.T) *radiustest.Server {
+	t.Helper()
+	srv := radiustest.NewServer(testRADIUSSecret, map[string]radiustest.User{
+		"alice": {
+			Password:   "alice-pw",
+			Attributes: []radiustest.Attribute{radiustest.VendorSpecific(DefaultRADIUSVendorID, DefaultRADIUSVendorType, "shell:priv-lvl=15")},
+		},
+		"bob": {Password: "bob-pw"},
+	})
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newTestTACACS(t *testing.T, key string) *tacacstest.Server {
+	t.Helper()
+	srv := tacacstest.NewServer(key, map[string]tacacstest.User{
+		"alice": {Password: "alice-pw", Args: []string{"priv-lvl=15"}},
+		"bob":   {Password: "bob-pw"},
+	})
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// login authenticates a user from 10.0.0.5 and reports whether it was let
+// in.
+func login(t *testing.T, s *UserMgr, username, password string) bool {
+	t.Helper()
+	sourceIP := "10.0.0.5"
+	resp, err := s.authenticate(t.Context(), &schemav1alpha1.AuthenticateUserRequest{
+		Username: username,
+		Password: password,
+		SourceIp: &sourceIP,
+	})
+	if err != nil {
+		t.Fatalf("authenticate() error = %v", err)
+	}
+	return resp.GetSuccess()
+}
+
+// wantRemoteUser checks the local record of a remote user.
+func wantRemoteUser(t *testing.T, s *UserMgr, username string, source schemav1alpha1.UserSource, role string) {
+	t.Helper()
+	user, err := s.store.byUsername(username)
+	if err != nil {
+		t.Fatalf("byUsername(%q) error = %v", username, err)
+	}
+	if user.GetSourceSystem() != source {
+		t.Errorf("source = %v, want %v", user.GetSourceSystem(), source)
+	}
+	if got := user.GetRedfishInfo().GetRoleId(); got != role {
+		t.Errorf("role = %q, want %q", got, role)
+	}
+}
+
+// expireCachedLogins lets every cached login expire.
+func expireCachedLogins(s *UserMgr) {
+	s.loginCache.mu.Lock()
+	defer s.loginCache.mu.Unlock()
+	for key, login := range s.loginCache.logins {
+		login.expires = time.Now().Add(-time.Second)
+		s.loginCache.logins[key] = login
+	}
+}
+
+func TestRADIUSLogin(t *testing.T) {
+	tests := []struct {
+		name     string
+		chap     bool
+		username string
+		password string
+		want     bool
+	}{
+		{name: "PAP", username: "alice", password: "alice-pw", want: true},
+		{name: "CHAP", chap: true, username: "alice", password: "alice-pw", want: true},
+		{name: "wrong password", username: "alice", password: "bob-pw"},
+		{name: "unknown user", username: "mallory", password: "alice-pw"},
+		{name: "no privilege", username: "bob", password: "bob-pw"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newTestRADIUS(t)
+			s := newTestUserMgr(t, WithRADIUS(RADIUSConfig{
+				Servers:                     []RADIUSServer{{Address: srv.Addr, Secret: testRADIUSSecret, Timeout: testRemoteTimeout}},
+				CHAP:                        tt.chap,
+				NASIdentifier:               "bmc-rack4",
+				RequireMessageAuthenticator: true,
+				PrivilegeRoles:              testPrivilegeRoles,
+			}))
+
+			if got := login(t, s, tt.username, tt.password); got != tt.want {
+				t.Fatalf("login = %v, want %v", got, tt.want)
+			}
+			if tt.want {
+				wantRemoteUser(t, s, tt.username, schemav1alpha1.UserSource_USER_SOURCE_RADIUS, "Administrator")
+			} else if _, err := s.store.byUsername(tt.username); err == nil {
+				t.Errorf("%s was provisioned without being let in", tt.username)
+			}
+
+			reqs := srv.Requests()
+			if len(reqs) != 1 {
+				t.Fatalf("server received %d requests, want 1", len(reqs))
+			}
+			if reqs[0].CHAP != tt.chap {
+				t.Errorf("CHAP = %v, want %v", reqs[0].CHAP, tt.chap)
+			}
+			if got := string(reqs[0].Get(byte(radius.AttributeNASIdentifier))); got != "bmc-rack4" {
+				t.Errorf("NAS-Identifier = %q, want %q", got, "bmc-rack4")
+			}
+			if got := string(reqs[0].Get(byte(radius.AttributeCallingStationID))); got != "10.0.0.5" {
+				t.Errorf("Calling-Station-Id = %q, want %q", got, "10.0.0.5")
+			}
+		})
+	}
+}
+
+func TestRADIUSVerifiesResponses(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    radiustest.Mode
+		require bool
+		want    bool
+	}{
+		{name: "forged Response Authenticator", mode: radiustest.ModeForgeAuthenticator},
+		{name: "Message-Authenticator missing", mode: radiustest.ModeNoMessageAuthenticator, require: true},
+		{name: "Message-Authenticator optional", mode: radiustest.ModeNoMessageAuthenticator, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newTestRADIUS(t)
+			srv.SetMode(tt.mode)
+			s := newTestUserMgr(t, WithRADIUS(RADIUSConfig{
+				Servers:                     []RADIUSServer{{Address: srv.Addr, Secret: testRADIUSSecret, Timeout: testRemoteTimeout}},
+				RequireMessageAuthenticator: tt.require,
+				PrivilegeRoles:              testPrivilegeRoles,
+			}))
+
+			if got := login(t, s, "alice", "alice-pw"); got != tt.want {
+				t.Errorf("login = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRADIUSFailover(t *testing.T) {
+	down := newTestRADIUS(t)
+	down.SetMode(radiustest.ModeSilent)
+	up := newTestRADIUS(t)
+	s := newTestUserMgr(t, WithRADIUS(RADIUSConfig{
+		Servers: []RADIUSServer{
+			{Address: down.Addr, Secret: testRADIUSSecret, Timeout: testRemoteTimeout},
+			{Address: up.Addr, Secret: testRADIUSSecret, Timeout: testRemoteTimeout},
+		},
+		PrivilegeRoles: testPrivilegeRoles,
+	}))
+
+	if !login(t, s, "alice", "alice-pw") {
+		t.Fatal("login failed")
+	}
+	if len(down.Requests()) == 0 || len(up.Requests()) != 1 {
+		t.Errorf("servers received %d and %d requests, want the first one tried before the second",
+			len(down.Requests()), len(up.Requests()))
+	}
+}
+
+func TestTACACSLogin(t *testing.T) {
+	tests := []struct {
+		name       string
+		authenType tacacs.AuthenType
+		key        string
+		username   string
+		password   string
+		want       bool
+	}{
+		{name: "PAP", authenType: tacacs.AuthenTypePAP, key: testTACACSKey, username: "alice", password: "alice-pw", want: true},
+		{name: "CHAP", authenType: tacacs.AuthenTypeCHAP, key: testTACACSKey, username: "alice", password: "alice-pw", want: true},
+		{name: "ASCII", authenType: tacacs.AuthenTypeASCII, key: testTACACSKey, username: "alice", password: "alice-pw", want: true},
+		{name: "wrong password", key: testTACACSKey, username: "alice", password: "bob-pw"},
+		{name: "authorization denied", key: testTACACSKey, username: "bob", password: "bob-pw"},
+		{name: "keys differ", key: "other-key", username: "alice", password: "alice-pw"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newTestTACACS(t, tt.key)
+			s := newTestUserMgr(t, WithTACACS(TACACSConfig{
+				Servers:        []TACACSServer{{Address: srv.Addr, Secret: testTACACSKey, Timeout: testRemoteTimeout}},
+				AuthenType:     tt.authenType,
+				PrivilegeRoles: testPrivilegeRoles,
+			}))
+
+			if got := login(t, s, tt.username, tt.password); got != tt.want {
+				t.Fatalf("login = %v, want %v", got, tt.want)
+			}
+			if tt.want {
+				wantRemoteUser(t, s, tt.username, schemav1alpha1.UserSource_USER_SOURCE_TACACS, "Administrator")
+			}
+		})
+	}
+}
+
+func TestRemoteLoginCache(t *testing.T) {
+	setup := func(t *testing.T, cacheTTL time.Duration) (*radiustest.Server, *UserMgr) {
+		t.Helper()
+		srv := newTestRADIUS(t)
+		s := newTestUserMgr(t,
+			WithRADIUS(RADIUSConfig{
+				Servers:        []RADIUSServer{{Address: srv.Addr, Secret: testRADIUSSecret, Timeout: testRemoteTimeout}},
+				PrivilegeRoles: testPrivilegeRoles,
+				CacheTTL:       cacheTTL,
+			}),
+			WithAuthenticationOrder(AuthMethodRADIUS, AuthMethodLocal),
+		)
+		if !login(t, s, "alice", "alice-pw") {
+			t.Fatal("login failed while the server is up")
+		}
+		return srv, s
+	}
+
+	t.Run("cached login while the servers are down", func(t *testing.T) {
+		srv, s := setup(t, time.Hour)
+		srv.SetMode(radiustest.ModeSilent)
+
+		if !login(t, s, "alice", "alice-pw") {
+			t.Error("cached login failed")
+		}
+		if login(t, s, "alice", "bob-pw") {
+			t.Error("login with another password let in from the cache")
+		}
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		srv, s := setup(t, time.Hour)
+		srv.SetMode(radiustest.ModeSilent)
+		expireCachedLogins(s)
+
+		if login(t, s, "alice", "alice-pw") {
+			t.Error("expired login let in")
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		srv, s := setup(t, 0)
+		srv.SetMode(radiustest.ModeSilent)
+
+		if login(t, s, "alice", "alice-pw") {
+			t.Error("login let in without a cache")
+		}
+	})
+
+	t.Run("forgotten on rejection", func(t *testing.T) {
+		srv, s := setup(t, time.Hour)
+		srv.SetUser("alice", radiustest.User{
+			Password:   "new-pw",
+			Attributes: []radiustest.Attribute{radiustest.VendorSpecific(DefaultRADIUSVendorID, DefaultRADIUSVendorType, "shell:priv-lvl=15")},
+		})
+		if login(t, s, "alice", "alice-pw") {
+			t.Fatal("old password let in after the server changed it")
+		}
+
+		srv.SetMode(radiustest.ModeSilent)
+		if login(t, s, "alice", "alice-pw") {
+			t.Error("old password let in from the cache after a rejection")
+		}
+	})
+
+	t.Run("local accounts as fallback", func(t *testing.T) {
+		srv, s := setup(t, 0)
+		srv.SetMode(radiustest.ModeSilent)
+
+		// The default account must change its password, which is only
+		// allowed to clients that let it.
+		resp, err := s.authenticate(t.Context(), &schemav1alpha1.AuthenticateUserRequest{
+			Username:                DefaultAdminUsername,
+			Password:                DefaultAdminPassword,
+			PasswordChangeSupported: true,
+		})
+		if err != nil {
+			t.Fatalf("authenticate() error = %v", err)
+		}
+		if !resp.GetSuccess() {
+			t.Errorf("local login failed: %s", resp.GetFailureReason())
+		}
+	})
+}
@@ -0,0 +1,209 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package usermgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+	schemav1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+)
+
+// userStore keeps the users in memory and persists every change to a
+// JetStream key-value bucket keyed by user ID.
+type userStore struct {
+	kv      jetstream.KeyValue
+	timeout time.Duration
+
+	// mu serializes changes so that usernames stay unique.
+	mu    sync.RWMutex
+	users map[string]*schemav1alpha1.User
+}
+
+// openStore opens the user bucket and loads its users.
+func openStore(ctx context.Context, js jetstream.JetStream, bucket string, timeout time.Duration) (*userStore, error) {
+	kv, err := js.CreateOrUpdateKeyValue(ctx, jetstream.KeyValueConfig{
+		Bucket:      bucket,
+		Description: "User accounts",
+		Storage:     jetstream.FileStorage,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrStorageFailed, err)
+	}
+
+	s := &userStore{
+		kv:      kv,
+		timeout: timeout,
+		users:   make(map[string]*schemav1alpha1.User),
+	}
+
+	keys, err := kv.ListKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrStorageFailed, err)
+	}
+	for key := range keys.Keys() {
+		entry, err := kv.Get(ctx, key)
+		if err != nil {
+			return nil, fmt.Errorf("%w: user %s: %w", ErrStorageFailed, key, err)
+		}
+		var user schemav1alpha1.User
+		if err := user.UnmarshalVT(entry.Value()); err != nil || user.GetId() != key {
+			return nil, fmt.Errorf("%w: malformed user %s", ErrStorageFailed, key)
+		}
+		s.users[key] = &user
+	}
+
+	return s, nil
+}
+
+// empty reports whether the store holds no users.
+func (s *userStore) empty() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.users) == 0
+}
+
+// find returns a copy of the first user match selects.
+func (s *userStore) find(match func(*schemav1alpha1.User) bool) (*schemav1alpha1.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, user := range s.users {
+		if match(user) {
+			return user.CloneVT(), nil
+		}
+	}
+	return nil, ErrUserNotFound
+}
+
+// get returns a copy of the user with the given ID.
+func (s *userStore) get(id string) (*schemav1alpha1.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	user, ok := s.users[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUserNotFound, id)
+	}
+	return user.CloneVT(), nil
+}
+
+// byUsername returns a copy of the user with the given username.
+func (s *userStore) byUsername(username string) (*schemav1alpha1.User, error) {
+	user, err := s.find(func(u *schemav1alpha1.User) bool { return u.GetUsername() == username })
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, username)
+	}
+	return user, nil
+}
+
+// list returns copies of all users ordered by username.
+func (s *userStore) list() []*schemav1alpha1.User {
+	s.mu.RLock()
+	users := make([]*schemav1alpha1.User, 0, len(s.users))
+	for _, id := range slices.Sorted(maps.Keys(s.users)) {
+		users = append(users, s.users[id].CloneVT())
+	}
+	s.mu.RUnlock()
+
+	slices.SortStableFunc(users, func(a, b *schemav1alpha1.User) int {
+		return strings.Compare(a.GetUsername(), b.GetUsername())
+	})
+	return users
+}
+
+// create persists a new user, whose username must not be taken.
+func (s *userStore) create(ctx context.Context, user *schemav1alpha1.User) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.users[user.GetId()]; exists || s.usernameTaken(user.GetUsername(), "") {
+		return fmt.Errorf("%w: %s", ErrUserExists, user.GetUsername())
+	}
+	if err := s.put(ctx, user); err != nil {
+		return err
+	}
+	s.users[user.GetId()] = user.CloneVT()
+	return nil
+}
+
+// update applies fn to a copy of the user with the given ID and persists
+// the result, which is returned. Errors returned by fn abort the update.
+func (s *userStore) update(ctx context.Context, id string, fn func(*schemav1alpha1.User) error) (*schemav1alpha1.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cur, ok := s.users[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUserNotFound, id)
+	}
+	user := cur.CloneVT()
+	if err := fn(user); err != nil {
+		return nil, err
+	}
+	if user.GetId() != id {
+		return nil, fmt.Errorf("%w: the ID of a user cannot be changed", ErrInvalidUser)
+	}
+	if user.GetUsername() != cur.GetUsername() && s.usernameTaken(user.GetUsername(), id) {
+		return nil, fmt.Errorf("%w: %s", ErrUserExists, user.GetUsername())
+	}
+	if err := s.put(ctx, user); err != nil {
+		return nil, err
+	}
+	s.users[id] = user
+	return user.CloneVT(), nil
+}
+
+// remove deletes the user with the given ID.
+func (s *userStore) remove(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[id]; !ok {
+		return fmt.Errorf("%w: %s", ErrUserNotFound, id)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if err := s.kv.Delete(ctx, id); err != nil && !errors.Is(err, jetstream.ErrKeyNotFound) {
+		return fmt.Errorf("%w: %w", ErrStorageFailed, err)
+	}
+	delete(s.users, id)
+	return nil
+}
+
+// usernameTaken reports whether a user other than the one with the given ID
+// has username. The caller must hold s.mu.
+func (s *userStore) usernameTaken(username, id string) bool {
+	for _, user := range s.users {
+		if user.GetUsername() == username && user.GetId() != id {
+			return true
+		}
+	}
+	return false
+}
+
+// put writes a user to the bucket.
+func (s *userStore) put(ctx context.Context, user *schemav1alpha1.User) error {
+	data, err := user.MarshalVT()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrStorageFailed, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if _, err := s.kv.Put(ctx, user.GetId(), data); err != nil {
+		return fmt.Errorf("%w: %w", ErrStorageFailed, err)
+	}
+	return nil
+}
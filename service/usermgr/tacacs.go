@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package usermgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	schemav1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+	"github.com/u-bmc/u-bmc/pkg/tacacs"
+)
+
+// tacacsPort names the port users log in on in TACACS+ requests.
+const tacacsPort = "bmc"
+
+// validate checks that the TACACS+ configuration is usable.
+func (c *TACACSConfig) validate() error {
+	if len(c.Servers) == 0 {
+		return fmt.Errorf("TACACS+ requires at least one server")
+	}
+	for _, server := range c.Servers {
+		if server.Address == "" || server.Secret == "" || server.Timeout <= 0 {
+			return fmt.Errorf("TACACS+ servers require an address, a secret and a positive timeout")
+		}
+	}
+	switch c.AuthenType {
+	case tacacs.AuthenTypeASCII, tacacs.AuthenTypePAP, tacacs.AuthenTypeCHAP:
+	default:
+		return fmt.Errorf("unsupported TACACS+ authentication type %d", c.AuthenType)
+	}
+	if c.Service == "" || c.PrivilegeKey == "" {
+		return fmt.Errorf("TACACS+ service and privilege key cannot be empty")
+	}
+	return validatePrivilegeRoles("TACACS+", c.PrivilegeRoles, c.DefaultRole, c.CacheTTL)
+}
+
+// tacacsAuthenticator authenticates and authorizes users against the
+// configured TACACS+ servers.
+type tacacsAuthenticator struct {
+	config *TACACSConfig
+}
+
+func newTACACSAuthenticator(config *TACACSConfig) *tacacsAuthenticator {
+	return &tacacsAuthenticator{config: config}
+}
+
+func (t *tacacsAuthenticator) source() schemav1alpha1.UserSource {
+	return schemav1alpha1.UserSource_USER_SOURCE_TACACS
+}
+
+func (t *tacacsAuthenticator) cacheTTL() time.Duration {
+	return t.config.CacheTTL
+}
+
+// authenticate authenticates a user with the servers in order, moving on to
+// the next one while a server cannot be reached or fails within its
+// timeout. The server that authenticated the user is asked to authorize it
+// for the configured service, and the role of the user is taken from the
+// privilege argument of its answer.
+func (t *tacacsAuthenticator) authenticate(ctx context.Context, req *schemav1alpha1.AuthenticateUserRequest) (*remoteIdentity, error) {
+	request := &tacacs.Request{
+		Username:      req.GetUsername(),
+		Password:      req.GetPassword(),
+		Type:          t.config.AuthenType,
+		Port:          tacacsPort,
+		RemoteAddress: req.GetSourceIp(),
+	}
+
+	return failover(t.config.Servers, func(server TACACSServer) (*remoteIdentity, error) {
+		ctx, cancel := context.WithTimeout(ctx, server.Timeout)
+		defer cancel()
+
+		client := &tacacs.Client{Address: server.Address, Secret: []byte(server.Secret)}
+		if err := client.Authenticate(ctx, request); err != nil {
+			if errors.Is(err, tacacs.ErrAuthenticationFailed) {
+				return nil, fmt.Errorf("%w: %w", ErrRemoteRejected, err)
+			}
+			return nil, fmt.Errorf("%s: %w", server.Address, err)
+		}
+
+		args, err := client.Authorize(ctx, request, []string{"service=" + t.config.Service})
+		switch {
+		case errors.Is(err, tacacs.ErrAuthorizationFailed):
+			return nil, fmt.Errorf("%w: %w", ErrRemoteNoRole, err)
+		case err != nil:
+			return nil, fmt.Errorf("%s: %w", server.Address, err)
+		}
+
+		privileges := pairValues(args, t.config.PrivilegeKey)
+		role := privilegeRole(t.config.PrivilegeRoles, t.config.DefaultRole, privileges)
+		if role == "" {
+			return nil, fmt.Errorf("%w: %s has privileges %q", ErrRemoteNoRole, req.GetUsername(), privileges)
+		}
+		return &remoteIdentity{role: role}, nil
+	})
+}
@@ -0,0 +1,372 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package usermgr
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // RFC 6238 authenticator apps only support HMAC-SHA1 reliably
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	schemav1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// TOTP parameters. Codes are the RFC 6238 defaults understood by all
+// authenticator apps, and are accepted one time step early or late to allow
+// for clock drift.
+const (
+	totpDigits     = 6
+	totpPeriod     = 30 * time.Second
+	totpSkew       = 1
+	totpSecretSize = 20
+
+	recoveryCodeCount = 10
+	recoveryCodeSize  = 10
+	recoveryGroupLen  = 4
+	secretKeySize     = 32
+)
+
+// base32NoPadding is the encoding of TOTP secrets and recovery codes.
+func base32NoPadding() *base32.Encoding {
+	return base32.StdEncoding.WithPadding(base32.NoPadding)
+}
+
+// loadSecretKey reads the key TOTP secrets are encrypted with, creating it
+// on first start.
+func (s *UserMgr) loadSecretKey(ctx context.Context) error {
+	key, err := os.ReadFile(s.config.secretKeyPath)
+	switch {
+	case err == nil:
+		if len(key) != secretKeySize {
+			return fmt.Errorf("%w: %s must hold %d bytes", ErrSecretKeyFailed, s.config.secretKeyPath, secretKeySize)
+		}
+		s.secretKey = key
+		return nil
+	case !errors.Is(err, fs.ErrNotExist):
+		return fmt.Errorf("%w: %w", ErrSecretKeyFailed, err)
+	}
+
+	if key, err = randomBytes(secretKeySize); err != nil {
+		return fmt.Errorf("%w: %w", ErrSecretKeyFailed, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.config.secretKeyPath), 0o700); err != nil {
+		return fmt.Errorf("%w: %w", ErrSecretKeyFailed, err)
+	}
+	f, err := os.OpenFile(s.config.secretKeyPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrSecretKeyFailed, err)
+	}
+	if _, err := f.Write(key); err != nil {
+		_ = f.Close()
+		_ = os.Remove(s.config.secretKeyPath)
+		return fmt.Errorf("%w: %w", ErrSecretKeyFailed, err)
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(s.config.secretKeyPath)
+		return fmt.Errorf("%w: %w", ErrSecretKeyFailed, err)
+	}
+
+	s.logger.InfoContext(ctx, "Created TOTP secret key", "path", s.config.secretKeyPath)
+
+	s.secretKey = key
+	return nil
+}
+
+// encryptSecret encrypts the TOTP secret of a user with AES-256-GCM. The
+// user ID is authenticated along with it, so that a secret cannot be moved
+// to another account.
+func (s *UserMgr) encryptSecret(userID string, secret []byte) ([]byte, error) {
+	aead, err := s.secretCipher()
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := randomBytes(aead.NonceSize())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSecretKeyFailed, err)
+	}
+	return aead.Seal(nonce, nonce, secret, []byte(userID)), nil
+}
+
+// decryptSecret decrypts a TOTP secret encrypted by encryptSecret.
+func (s *UserMgr) decryptSecret(userID string, data []byte) ([]byte, error) {
+	aead, err := s.secretCipher()
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < aead.NonceSize() {
+		return nil, fmt.Errorf("%w: malformed TOTP secret", ErrSecretKeyFailed)
+	}
+	secret, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], []byte(userID))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSecretKeyFailed, err)
+	}
+	return secret, nil
+}
+
+func (s *UserMgr) secretCipher() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(s.secretKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSecretKeyFailed, err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSecretKeyFailed, err)
+	}
+	return aead, nil
+}
+
+// totpCode returns the RFC 6238 code of secret for a time step.
+func totpCode(secret []byte, step int64) string {
+	return hotpCode(sha1.New, secret, step, totpDigits)
+}
+
+// hotpCode returns the RFC 4226 code with the given number of digits of
+// secret for a counter, using the HMAC of the hash function h.
+func hotpCode(h func() hash.Hash, secret []byte, counter int64, digits int) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(counter)) //nolint:gosec // time steps are positive
+
+	mac := hmac.New(h, secret)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := uint64(binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff)
+	modulus := uint64(1)
+	for range digits {
+		modulus *= 10
+	}
+	code := strconv.FormatUint(value%modulus, 10)
+	return strings.Repeat("0", digits-len(code)) + code
+}
+
+// verifyTOTP checks a code against the time steps around now that are later
+// than lastStep, so that each code is accepted only once. It returns the
+// matching time step.
+func verifyTOTP(secret []byte, code string, now time.Time, lastStep int64) (int64, bool) {
+	current := now.Unix() / int64(totpPeriod/time.Second)
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if step <= lastStep {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(totpCode(secret, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// totpURI returns the otpauth:// URI of a TOTP secret, which authenticator
+// apps read from a QR code.
+func totpURI(issuer, username string, secret []byte) string {
+	query := url.Values{}
+	query.Set("secret", base32NoPadding().EncodeToString(secret))
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", strconv.Itoa(totpDigits))
+	query.Set("period", strconv.Itoa(int(totpPeriod/time.Second)))
+
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + username,
+		RawQuery: query.Encode(),
+	}
+	return u.String()
+}
+
+// generateRecoveryCodes returns new recovery codes and the hashes they are
+// stored as.
+func (s *UserMgr) generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for range recoveryCodeCount {
+		b, err := randomBytes(recoveryCodeSize)
+		if err != nil {
+			return nil, nil, err
+		}
+		code := base32NoPadding().EncodeToString(b)
+		groups := make([]string, 0, len(code)/recoveryGroupLen)
+		for i := 0; i < len(code); i += recoveryGroupLen {
+			groups = append(groups, code[i:i+recoveryGroupLen])
+		}
+		codes = append(codes, strings.Join(groups, "-"))
+		hashes = append(hashes, s.hashRecoveryCode(code))
+	}
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode returns the stored form of a recovery code, an HMAC keyed
+// with the secret key so that stolen hashes cannot be checked offline.
+func (s *UserMgr) hashRecoveryCode(code string) string {
+	code = strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(code))
+	mac := hmac.New(sha256.New, s.secretKey)
+	mac.Write([]byte(code))
+	return encode(mac.Sum(nil))
+}
+
+// useSecondFactor checks a TOTP code or recovery code of the user with the
+// given ID and consumes it. It reports whether a recovery code was used.
+func (s *UserMgr) useSecondFactor(userID string, totp *schemav1alpha1.TotpData, code string, now time.Time) (bool, error) {
+	secret, err := s.decryptSecret(userID, totp.GetEncryptedSecret())
+	if err != nil {
+		return false, err
+	}
+	if step, ok := verifyTOTP(secret, strings.ReplaceAll(code, " ", ""), now, totp.GetLastUsedStep()); ok {
+		totp.LastUsedStep = step
+		return false, nil
+	}
+
+	hash := s.hashRecoveryCode(code)
+	for i, stored := range totp.GetRecoveryCodes() {
+		if subtle.ConstantTimeCompare([]byte(stored), []byte(hash)) == 1 {
+			totp.RecoveryCodes = append(totp.RecoveryCodes[:i], totp.RecoveryCodes[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, ErrInvalidSecondFactor
+}
+
+// enrollTotp generates a TOTP secret for a user, replacing any pending
+// enrollment. The enrollment takes effect once confirmed with a code.
+func (s *UserMgr) enrollTotp(ctx context.Context, req *schemav1alpha1.EnrollTotpRequest) (*schemav1alpha1.EnrollTotpResponse, error) {
+	secret, err := randomBytes(totpSecretSize)
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := s.encryptSecret(req.GetId(), secret)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.store.update(ctx, req.GetId(), func(user *schemav1alpha1.User) error {
+		if user.GetAuthData().GetTotp().GetEnabled() {
+			return fmt.Errorf("%w: %s", ErrTOTPAlreadyEnrolled, user.GetUsername())
+		}
+		if user.AuthData == nil {
+			user.AuthData = &schemav1alpha1.AuthenticationData{}
+		}
+		user.AuthData.Totp = &schemav1alpha1.TotpData{EncryptedSecret: encrypted}
+		user.UpdatedAt = timestamppb.Now()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "TOTP enrollment started", "user", user.GetUsername())
+
+	return &schemav1alpha1.EnrollTotpResponse{
+		Secret: base32NoPadding().EncodeToString(secret),
+		Uri:    totpURI(s.config.totpIssuer, user.GetUsername(), secret),
+	}, nil
+}
+
+// confirmTotp completes a pending enrollment given a code of the new secret
+// and returns the recovery codes of the account.
+func (s *UserMgr) confirmTotp(ctx context.Context, req *schemav1alpha1.ConfirmTotpRequest) (*schemav1alpha1.ConfirmTotpResponse, error) {
+	codes, hashes, err := s.generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	var rejected bool
+	user, err := s.store.update(ctx, req.GetId(), func(user *schemav1alpha1.User) error {
+		totp := user.GetAuthData().GetTotp()
+		switch {
+		case totp == nil:
+			return fmt.Errorf("%w: %s", ErrTOTPNotEnrolled, user.GetUsername())
+		case totp.GetEnabled():
+			return fmt.Errorf("%w: %s", ErrTOTPAlreadyEnrolled, user.GetUsername())
+		}
+		secret, err := s.decryptSecret(user.GetId(), totp.GetEncryptedSecret())
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		step, ok := verifyTOTP(secret, strings.ReplaceAll(req.GetCode(), " ", ""), now, 0)
+		if !ok {
+			rejected = true
+			return ErrInvalidSecondFactor
+		}
+		totp.Enabled = true
+		totp.EnrolledAt = timestamppb.New(now)
+		totp.LastUsedStep = step
+		totp.RecoveryCodes = hashes
+		user.UpdatedAt = timestamppb.New(now)
+		return nil
+	})
+	if rejected {
+		reason := reasonInvalidCode
+		s.logger.WarnContext(ctx, "TOTP confirmation rejected", "id", req.GetId(), "reason", reason)
+		return &schemav1alpha1.ConfirmTotpResponse{FailureReason: &reason}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "TOTP enrolled", "user", user.GetUsername())
+
+	return &schemav1alpha1.ConfirmTotpResponse{Success: true, RecoveryCodes: codes}, nil
+}
+
+// disableTotp removes the TOTP enrollment of a user. Users that are required
+// to use a second factor have to enroll again on their next login.
+func (s *UserMgr) disableTotp(ctx context.Context, req *schemav1alpha1.DisableTotpRequest) error {
+	user, err := s.store.update(ctx, req.GetId(), func(user *schemav1alpha1.User) error {
+		if user.GetAuthData().GetTotp() == nil {
+			return fmt.Errorf("%w: %s", ErrTOTPNotEnrolled, user.GetUsername())
+		}
+		user.AuthData.Totp = nil
+		user.UpdatedAt = timestamppb.Now()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.logger.InfoContext(ctx, "TOTP disabled", "user", user.GetUsername())
+
+	return nil
+}
+
+// regenerateRecoveryCodes replaces the recovery codes of an enrolled user.
+func (s *UserMgr) regenerateRecoveryCodes(ctx context.Context, req *schemav1alpha1.RegenerateRecoveryCodesRequest) (*schemav1alpha1.RegenerateRecoveryCodesResponse, error) {
+	codes, hashes, err := s.generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.store.update(ctx, req.GetId(), func(user *schemav1alpha1.User) error {
+		totp := user.GetAuthData().GetTotp()
+		if !totp.GetEnabled() {
+			return fmt.Errorf("%w: %s", ErrTOTPNotEnrolled, user.GetUsername())
+		}
+		totp.RecoveryCodes = hashes
+		user.UpdatedAt = timestamppb.Now()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "TOTP recovery codes regenerated", "user", user.GetUsername())
+
+	return &schemav1alpha1.RegenerateRecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
@@ -0,0 +1,200 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package usermgr
+
+import (
+	"crypto/sha1" //nolint:gosec // RFC 6238 test vectors
+	"crypto/sha256"
+	"crypto/sha512"
+	"hash"
+	"strings"
+	"testing"
+	"time"
+
+	schemav1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+)
+
+// TestHOTPCode checks the test values of RFC 4226 Appendix D.
+func TestHOTPCode(t *testing.T) {
+	secret := []byte("12345678901234567890")
+	want := []string{"755224", "287082", "359152", "969429", "338314", "254676", "287922", "162583", "399871", "520489"}
+
+	for counter, code := range want {
+		if got := totpCode(secret, int64(counter)); got != code {
+			t.Errorf("totpCode(%d) = %s, want %s", counter, got, code)
+		}
+	}
+}
+
+// TestTOTPCode checks the test vectors of RFC 6238 Appendix B.
+func TestTOTPCode(t *testing.T) {
+	seeds := []struct {
+		name   string
+		h      func() hash.Hash
+		secret string
+	}{
+		{name: "SHA1", h: sha1.New, secret: "12345678901234567890"},
+		{name: "SHA256", h: sha256.New, secret: "12345678901234567890123456789012"},
+		{name: "SHA512", h: sha512.New, secret: "1234567890123456789012345678901234567890123456789012345678901234"},
+	}
+	tests := []struct {
+		time int64
+		want [3]string
+	}{
+		{time: 59, want: [3]string{"94287082", "46119246", "90693936"}},
+		{time: 1111111109, want: [3]string{"07081804", "68084774", "25091201"}},
+		{time: 1111111111, want: [3]string{"14050471", "67062674", "99943326"}},
+		{time: 1234567890, want: [3]string{"89005924", "91819424", "93441116"}},
+		{time: 2000000000, want: [3]string{"69279037", "90698825", "38618901"}},
+		{time: 20000000000, want: [3]string{"65353130", "77737706", "47863826"}},
+	}
+
+	for i, seed := range seeds {
+		t.Run(seed.name, func(t *testing.T) {
+			for _, tt := range tests {
+				step := tt.time / int64(totpPeriod/time.Second)
+				if got := hotpCode(seed.h, []byte(seed.secret), step, 8); got != tt.want[i] {
+					t.Errorf("code at %d = %s, want %s", tt.time, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestVerifyTOTP(t *testing.T) {
+	secret := []byte("12345678901234567890")
+	now := time.Unix(1111111111, 0)
+	current := now.Unix() / int64(totpPeriod/time.Second)
+
+	tests := []struct {
+		name     string
+		step     int64
+		lastStep int64
+		want     bool
+	}{
+		{name: "current step", step: current, want: true},
+		{name: "one step early", step: current - 1, want: true},
+		{name: "one step late", step: current + 1, want: true},
+		{name: "two steps early", step: current - 2},
+		{name: "two steps late", step: current + 2},
+		{name: "replayed step", step: current, lastStep: current},
+		{name: "earlier than the last step", step: current - 1, lastStep: current},
+		{name: "later than the last step", step: current + 1, lastStep: current, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := verifyTOTP(secret, totpCode(secret, tt.step), now, tt.lastStep)
+			if ok != tt.want {
+				t.Fatalf("verifyTOTP() = %v, want %v", ok, tt.want)
+			}
+			if ok && step != tt.step {
+				t.Errorf("verifyTOTP() step = %d, want %d", step, tt.step)
+			}
+		})
+	}
+}
+
+// enrollTestTOTP enrolls a TOTP second factor for the user with the given
+// ID and returns its secret and recovery codes.
+func enrollTestTOTP(t *testing.T, s *UserMgr, id string) ([]byte, []string) {
+	t.Helper()
+	enrolled, err := s.enrollTotp(t.Context(), &schemav1alpha1.EnrollTotpRequest{Id: id})
+	if err != nil {
+		t.Fatalf("enrollTotp() error = %v", err)
+	}
+	secret, err := base32NoPadding().DecodeString(enrolled.GetSecret())
+	if err != nil {
+		t.Fatalf("decode secret: %v", err)
+	}
+
+	confirmed, err := s.confirmTotp(t.Context(), &schemav1alpha1.ConfirmTotpRequest{
+		Id:   id,
+		Code: totpCode(secret, time.Now().Unix()/int64(totpPeriod/time.Second)),
+	})
+	if err != nil || !confirmed.GetSuccess() {
+		t.Fatalf("confirmTotp() = %v, %v", confirmed.GetFailureReason(), err)
+	}
+	if len(confirmed.GetRecoveryCodes()) != recoveryCodeCount {
+		t.Fatalf("%d recovery codes, want %d", len(confirmed.GetRecoveryCodes()), recoveryCodeCount)
+	}
+	return secret, confirmed.GetRecoveryCodes()
+}
+
+// loginWithSecondFactor authenticates a user with a second factor and
+// reports whether it was let in.
+func loginWithSecondFactor(t *testing.T, s *UserMgr, username, password, code string) bool {
+	t.Helper()
+	resp, err := s.authenticate(t.Context(), &schemav1alpha1.AuthenticateUserRequest{
+		Username:              username,
+		Password:              password,
+		SecondFactor:          &code,
+		SecondFactorSupported: true,
+	})
+	if err != nil {
+		t.Fatalf("authenticate() error = %v", err)
+	}
+	return resp.GetSuccess()
+}
+
+func TestSecondFactorReplay(t *testing.T) {
+	s := newTestUserMgr(t)
+	id := createTestUser(t, s, "alice", "Tr0ub4dor&3").GetId()
+	secret, _ := enrollTestTOTP(t, s, id)
+
+	user, err := s.store.get(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	current := user.GetAuthData().GetTotp().GetLastUsedStep()
+	if loginWithSecondFactor(t, s, "alice", "Tr0ub4dor&3", totpCode(secret, current)) {
+		t.Error("code used for the confirmation let in again")
+	}
+
+	next := totpCode(secret, current+1)
+	if !loginWithSecondFactor(t, s, "alice", "Tr0ub4dor&3", next) {
+		t.Fatal("login with the code of the next step failed")
+	}
+	if loginWithSecondFactor(t, s, "alice", "Tr0ub4dor&3", next) {
+		t.Error("replayed code let in")
+	}
+	if login(t, s, "alice", "Tr0ub4dor&3") {
+		t.Error("client without second factor support let in")
+	}
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	s := newTestUserMgr(t)
+	id := createTestUser(t, s, "alice", "Tr0ub4dor&3").GetId()
+	_, codes := enrollTestTOTP(t, s, id)
+
+	// Recovery codes are accepted without their separators and in lower case.
+	if !loginWithSecondFactor(t, s, "alice", "Tr0ub4dor&3", strings.ToLower(strings.ReplaceAll(codes[0], "-", ""))) {
+		t.Fatal("login with a recovery code failed")
+	}
+	if loginWithSecondFactor(t, s, "alice", "Tr0ub4dor&3", codes[0]) {
+		t.Error("used recovery code let in again")
+	}
+	if !loginWithSecondFactor(t, s, "alice", "Tr0ub4dor&3", codes[1]) {
+		t.Error("login with another recovery code failed")
+	}
+
+	user, err := s.store.get(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(user.GetAuthData().GetTotp().GetRecoveryCodes()); got != recoveryCodeCount-2 {
+		t.Errorf("%d recovery codes left, want %d", got, recoveryCodeCount-2)
+	}
+
+	regenerated, err := s.regenerateRecoveryCodes(t.Context(), &schemav1alpha1.RegenerateRecoveryCodesRequest{Id: id})
+	if err != nil {
+		t.Fatalf("regenerateRecoveryCodes() error = %v", err)
+	}
+	if loginWithSecondFactor(t, s, "alice", "Tr0ub4dor&3", codes[2]) {
+		t.Error("replaced recovery code let in")
+	}
+	if !loginWithSecondFactor(t, s, "alice", "Tr0ub4dor&3", regenerated.GetRecoveryCodes()[0]) {
+		t.Error("login with a regenerated recovery code failed")
+	}
+}
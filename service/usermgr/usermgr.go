@@ -8,6 +8,7 @@ import (
 	"log/slog"
 
 	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
 	"github.com/nats-io/nats.go/micro"
 	schemav1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
 	"github.com/u-bmc/u-bmc/pkg/ipc"
@@ -26,15 +27,50 @@ var _ service.Service = (*UserMgr)(nil)
 type UserMgr struct {
 	config       config
 	nc           *nats.Conn
+	js           jetstream.JetStream
 	microService micro.Service
 	logger       *slog.Logger
 	tracer       trace.Tracer
+
+	store *userStore
+	// dictionary holds the lowercase words passwords may not be.
+	dictionary map[string]struct{}
+	// secretKey encrypts the TOTP secrets and hashes the recovery codes.
+	secretKey []byte
+	// authenticators are the configured remote authenticators, tried in
+	// authOrder along with local accounts.
+	authenticators map[AuthMethod]authenticator
+	authOrder      []AuthMethod
+	// loginCache remembers remote logins for when the servers are down.
+	loginCache *loginCache
+	// dummyAuth is verified against when authenticating unknown users so
+	// that they take as long as known ones.
+	dummyAuth *schemav1alpha1.AuthenticationData
 }
 
 // New creates a new UserMgr instance with the provided options.
 func New(opts ...Option) *UserMgr {
 	cfg := &config{
-		name: "usermgr",
+		name:                     DefaultServiceName,
+		description:              DefaultServiceDescription,
+		version:                  DefaultServiceVersion,
+		bucket:                   DefaultBucket,
+		requestTimeout:           DefaultRequestTimeout,
+		hashAlgorithm:            DefaultHashAlgorithm,
+		generatedPasswordLen:     DefaultGeneratedPasswordLen,
+		lockoutThreshold:         DefaultLockoutThreshold,
+		lockoutDuration:          DefaultLockoutDuration,
+		lockoutResetAfter:        DefaultLockoutResetAfter,
+		passwordMinLength:        DefaultPasswordMinLength,
+		passwordCharacterClasses: DefaultPasswordCharacterClasses,
+		passwordHistory:          DefaultPasswordHistory,
+		passwordMaxAge:           DefaultPasswordMaxAge,
+		secretKeyPath:            DefaultSecretKeyPath,
+		totpIssuer:               DefaultTOTPIssuer,
+		totpRequired:             DefaultTOTPRequired,
+		adminUsername:            DefaultAdminUsername,
+		adminPassword:            DefaultAdminPassword,
+		adminRole:                DefaultAdminRole,
 	}
 	for _, opt := range opts {
 		opt.apply(cfg)
@@ -44,320 +80,154 @@ func New(opts ...Option) *UserMgr {
 	}
 }
 
+// Name returns the service name.
 func (s *UserMgr) Name() string {
 	return s.config.name
 }
 
+// Run loads the user store, creates the default administrator account on
+// first boot and serves user requests until the context is canceled.
 func (s *UserMgr) Run(ctx context.Context, ipcConn nats.InProcessConnProvider) error {
 	s.tracer = otel.Tracer(s.config.name)
-	s.logger = log.GetGlobalLogger().With("service", s.config.name)
-
-	s.logger.InfoContext(ctx, "Starting user manager", "service", s.config.name)
-
-	var err error
-	s.nc, err = nats.Connect("", nats.InProcessServer(ipcConn))
-	if err != nil {
-		return fmt.Errorf("failed to connect to NATS: %w", err)
-	}
-	defer s.nc.Drain()
-
-	s.microService, err = micro.AddService(s.nc, micro.Config{
-		Name:        s.config.name,
-		Description: "User management service",
-		Version:     "1.0.0",
-	})
-	if err != nil {
-		return fmt.Errorf("failed to create micro service: %w", err)
-	}
-
-	if err := s.registerEndpoints(ctx); err != nil {
-		return fmt.Errorf("failed to register endpoints: %w", err)
-	}
 
-	s.logger.InfoContext(ctx, "User manager started successfully")
+	ctx, span := s.tracer.Start(ctx, "usermgr.Run")
+	defer span.End()
 
-	<-ctx.Done()
-	s.logger.InfoContext(ctx, "Stopping user manager", "service", s.config.name, "reason", ctx.Err())
-
-	return ctx.Err()
-}
-
-func (s *UserMgr) registerEndpoints(ctx context.Context) error {
-	groups := make(map[string]micro.Group)
-
-	if err := ipc.RegisterEndpointWithGroupCache(s.microService, ipc.SubjectUserCreate,
-		micro.HandlerFunc(s.createRequestHandler(ctx, s.handleUserCreate)), groups); err != nil {
-		return fmt.Errorf("failed to register user create endpoint: %w", err)
-	}
-	if err := ipc.RegisterEndpointWithGroupCache(s.microService, ipc.SubjectUserInfo,
-		micro.HandlerFunc(s.createRequestHandler(ctx, s.handleUserInfo)), groups); err != nil {
-		return fmt.Errorf("failed to register user info endpoint: %w", err)
-	}
-	if err := ipc.RegisterEndpointWithGroupCache(s.microService, ipc.SubjectUserUpdate,
-		micro.HandlerFunc(s.createRequestHandler(ctx, s.handleUserUpdate)), groups); err != nil {
-		return fmt.Errorf("failed to register user update endpoint: %w", err)
-	}
-	if err := ipc.RegisterEndpointWithGroupCache(s.microService, ipc.SubjectUserDelete,
-		micro.HandlerFunc(s.createRequestHandler(ctx, s.handleUserDelete)), groups); err != nil {
-		return fmt.Errorf("failed to register user delete endpoint: %w", err)
-	}
-	if err := ipc.RegisterEndpointWithGroupCache(s.microService, ipc.SubjectUserList,
-		micro.HandlerFunc(s.createRequestHandler(ctx, s.handleUserList)), groups); err != nil {
-		return fmt.Errorf("failed to register user list endpoint: %w", err)
-	}
-	if err := ipc.RegisterEndpointWithGroupCache(s.microService, ipc.SubjectUserChangePassword,
-		micro.HandlerFunc(s.createRequestHandler(ctx, s.handleUserChangePassword)), groups); err != nil {
-		return fmt.Errorf("failed to register user change password endpoint: %w", err)
-	}
-	if err := ipc.RegisterEndpointWithGroupCache(s.microService, ipc.SubjectUserResetPassword,
-		micro.HandlerFunc(s.createRequestHandler(ctx, s.handleUserResetPassword)), groups); err != nil {
-		return fmt.Errorf("failed to register user reset password endpoint: %w", err)
-	}
-	if err := ipc.RegisterEndpointWithGroupCache(s.microService, ipc.SubjectUserAuthenticate,
-		micro.HandlerFunc(s.createRequestHandler(ctx, s.handleUserAuthenticate)), groups); err != nil {
-		return fmt.Errorf("failed to register user authenticate endpoint: %w", err)
-	}
-
-	return nil
-}
-
-func (s *UserMgr) createRequestHandler(parentCtx context.Context, handler func(context.Context, micro.Request)) micro.HandlerFunc {
-	return func(req micro.Request) {
-		ctx := telemetry.GetCtxFromReq(req)
-		ctx = context.WithoutCancel(ctx)
-
-		if s.tracer != nil {
-			_, span := s.tracer.Start(ctx, "usermgr.handleRequest")
-			span.SetAttributes(
-				attribute.String("subject", req.Subject()),
-				attribute.String("service", s.config.name),
-			)
-			defer span.End()
-		}
+	s.logger = log.GetGlobalLogger().With("service", s.config.name)
+	s.logger.InfoContext(ctx, "Starting user manager",
+		"version", s.config.version,
+		"hash_algorithm", s.config.hashAlgorithm)
 
-		handler(ctx, req)
+	if err := s.config.Validate(); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("%w: %w", ErrInvalidConfiguration, err)
 	}
-}
 
-func (s *UserMgr) handleUserCreate(ctx context.Context, req micro.Request) {
-	if s.tracer != nil {
-		_, span := s.tracer.Start(ctx, "usermgr.handleUserCreate")
-		defer span.End()
+	if err := s.loadDictionary(ctx); err != nil {
+		span.RecordError(err)
+		return err
 	}
 
-	var request schemav1alpha1.CreateUserRequest
-	if err := request.UnmarshalVT(req.Data()); err != nil {
-		ipc.RespondWithError(ctx, req, ipc.ErrUnmarshalingFailed, err.Error())
-		return
+	if err := s.loadSecretKey(ctx); err != nil {
+		span.RecordError(err)
+		return err
 	}
 
-	// Mock response for now
-	response := &schemav1alpha1.CreateUserResponse{
-		User: &schemav1alpha1.User{
-			Username: request.User.Username,
-		},
+	s.setupAuthenticators()
+	if len(s.authenticators) > 0 {
+		s.logger.InfoContext(ctx, "Remote authentication enabled", "order", s.authOrder)
 	}
 
-	data, err := response.MarshalVT()
+	nc, err := nats.Connect("", nats.InProcessServer(ipcConn))
 	if err != nil {
-		ipc.RespondWithError(ctx, req, ipc.ErrMarshalingFailed, err.Error())
-		return
+		span.RecordError(err)
+		return fmt.Errorf("%w: %w", ErrNATSConnectionFailed, err)
 	}
+	s.nc = nc
+	defer nc.Drain() //nolint:errcheck
 
-	if err := req.Respond(data); err != nil && s.logger != nil {
-		s.logger.ErrorContext(ctx, "Failed to respond to request", "error", err)
-	}
-}
-
-func (s *UserMgr) handleUserInfo(ctx context.Context, req micro.Request) {
-	if s.tracer != nil {
-		_, span := s.tracer.Start(ctx, "usermgr.handleUserInfo")
-		defer span.End()
-	}
-
-	var request schemav1alpha1.GetUserRequest
-	if err := request.UnmarshalVT(req.Data()); err != nil {
-		ipc.RespondWithError(ctx, req, ipc.ErrUnmarshalingFailed, err.Error())
-		return
-	}
-
-	// Mock response for now
-	response := &schemav1alpha1.GetUserResponse{
-		User: &schemav1alpha1.User{
-			Username: "mockuser",
-		},
-	}
-
-	data, err := response.MarshalVT()
+	s.js, err = jetstream.New(nc)
 	if err != nil {
-		ipc.RespondWithError(ctx, req, ipc.ErrMarshalingFailed, err.Error())
-		return
-	}
-
-	if err := req.Respond(data); err != nil && s.logger != nil {
-		s.logger.ErrorContext(ctx, "Failed to respond to request", "error", err)
-	}
-}
-
-func (s *UserMgr) handleUserUpdate(ctx context.Context, req micro.Request) {
-	if s.tracer != nil {
-		_, span := s.tracer.Start(ctx, "usermgr.handleUserUpdate")
-		defer span.End()
+		span.RecordError(err)
+		return fmt.Errorf("%w: %w", ErrJetStreamInitFailed, err)
 	}
 
-	var request schemav1alpha1.UpdateUserRequest
-	if err := request.UnmarshalVT(req.Data()); err != nil {
-		ipc.RespondWithError(ctx, req, ipc.ErrUnmarshalingFailed, err.Error())
-		return
-	}
-
-	// Mock response for now
-	response := &schemav1alpha1.UpdateUserResponse{
-		User: request.User,
-	}
-
-	data, err := response.MarshalVT()
+	s.store, err = openStore(ctx, s.js, s.config.bucket, s.config.requestTimeout)
 	if err != nil {
-		ipc.RespondWithError(ctx, req, ipc.ErrMarshalingFailed, err.Error())
-		return
-	}
-
-	if err := req.Respond(data); err != nil && s.logger != nil {
-		s.logger.ErrorContext(ctx, "Failed to respond to request", "error", err)
-	}
-}
-
-func (s *UserMgr) handleUserDelete(ctx context.Context, req micro.Request) {
-	if s.tracer != nil {
-		_, span := s.tracer.Start(ctx, "usermgr.handleUserDelete")
-		defer span.End()
+		span.RecordError(err)
+		return err
 	}
 
-	var request schemav1alpha1.DeleteUserRequest
-	if err := request.UnmarshalVT(req.Data()); err != nil {
-		ipc.RespondWithError(ctx, req, ipc.ErrUnmarshalingFailed, err.Error())
-		return
-	}
-
-	// Mock response for now
-	response := &schemav1alpha1.DeleteUserResponse{}
-
-	data, err := response.MarshalVT()
+	s.dummyAuth, err = newAuthData(s.config.hashAlgorithm, DefaultAdminPassword)
 	if err != nil {
-		ipc.RespondWithError(ctx, req, ipc.ErrMarshalingFailed, err.Error())
-		return
+		span.RecordError(err)
+		return err
 	}
 
-	if err := req.Respond(data); err != nil && s.logger != nil {
-		s.logger.ErrorContext(ctx, "Failed to respond to request", "error", err)
+	if err := s.ensureDefaultAdmin(ctx); err != nil {
+		span.RecordError(err)
+		return err
 	}
-}
 
-func (s *UserMgr) handleUserList(ctx context.Context, req micro.Request) {
-	if s.tracer != nil {
-		_, span := s.tracer.Start(ctx, "usermgr.handleUserList")
-		defer span.End()
-	}
-
-	var request schemav1alpha1.ListUsersRequest
-	if err := request.UnmarshalVT(req.Data()); err != nil {
-		ipc.RespondWithError(ctx, req, ipc.ErrUnmarshalingFailed, err.Error())
-		return
-	}
-
-	// Mock response for now
-	response := &schemav1alpha1.ListUsersResponse{
-		Users: []*schemav1alpha1.User{
-			{Username: "admin"},
-			{Username: "operator"},
-		},
-	}
-
-	data, err := response.MarshalVT()
+	s.microService, err = micro.AddService(nc, micro.Config{
+		Name:        s.config.name,
+		Description: s.config.description,
+		Version:     s.config.version,
+	})
 	if err != nil {
-		ipc.RespondWithError(ctx, req, ipc.ErrMarshalingFailed, err.Error())
-		return
+		span.RecordError(err)
+		return fmt.Errorf("%w: %w", ErrMicroServiceCreationFailed, err)
 	}
 
-	if err := req.Respond(data); err != nil && s.logger != nil {
-		s.logger.ErrorContext(ctx, "Failed to respond to request", "error", err)
+	if err := s.registerEndpoints(ctx); err != nil {
+		span.RecordError(err)
+		return err
 	}
-}
 
-func (s *UserMgr) handleUserChangePassword(ctx context.Context, req micro.Request) {
-	if s.tracer != nil {
-		_, span := s.tracer.Start(ctx, "usermgr.handleUserChangePassword")
-		defer span.End()
-	}
+	span.SetAttributes(
+		attribute.String("service.name", s.config.name),
+		attribute.String("service.version", s.config.version),
+	)
 
-	var request schemav1alpha1.ChangePasswordRequest
-	if err := request.UnmarshalVT(req.Data()); err != nil {
-		ipc.RespondWithError(ctx, req, ipc.ErrUnmarshalingFailed, err.Error())
-		return
-	}
+	s.logger.InfoContext(ctx, "User manager started successfully", "users", len(s.store.list()))
 
-	// Mock response for now
-	response := &schemav1alpha1.ChangePasswordResponse{}
+	<-ctx.Done()
 
-	data, err := response.MarshalVT()
-	if err != nil {
-		ipc.RespondWithError(ctx, req, ipc.ErrMarshalingFailed, err.Error())
-		return
-	}
+	err = ctx.Err()
+	ctx = context.WithoutCancel(ctx)
+	s.logger.InfoContext(ctx, "Stopping user manager", "reason", err)
+	_ = s.microService.Stop()
 
-	if err := req.Respond(data); err != nil && s.logger != nil {
-		s.logger.ErrorContext(ctx, "Failed to respond to request", "error", err)
-	}
+	return err
 }
 
-func (s *UserMgr) handleUserResetPassword(ctx context.Context, req micro.Request) {
-	if s.tracer != nil {
-		_, span := s.tracer.Start(ctx, "usermgr.handleUserResetPassword")
-		defer span.End()
-	}
-
-	var request schemav1alpha1.ResetPasswordRequest
-	if err := request.UnmarshalVT(req.Data()); err != nil {
-		ipc.RespondWithError(ctx, req, ipc.ErrUnmarshalingFailed, err.Error())
-		return
-	}
-
-	// Mock response for now
-	response := &schemav1alpha1.ResetPasswordResponse{}
+func (s *UserMgr) registerEndpoints(ctx context.Context) error {
+	groups := make(map[string]micro.Group)
 
-	data, err := response.MarshalVT()
-	if err != nil {
-		ipc.RespondWithError(ctx, req, ipc.ErrMarshalingFailed, err.Error())
-		return
+	endpoints := []struct {
+		subject string
+		handler func(context.Context, micro.Request)
+	}{
+		{ipc.SubjectUserCreate, s.handleUserCreate},
+		{ipc.SubjectUserInfo, s.handleUserInfo},
+		{ipc.SubjectUserUpdate, s.handleUserUpdate},
+		{ipc.SubjectUserDelete, s.handleUserDelete},
+		{ipc.SubjectUserList, s.handleUserList},
+		{ipc.SubjectUserChangePassword, s.handleUserChangePassword},
+		{ipc.SubjectUserResetPassword, s.handleUserResetPassword},
+		{ipc.SubjectUserAuthenticate, s.handleUserAuthenticate},
+		{ipc.SubjectUserCheckLogin, s.handleUserCheckLogin},
+		{ipc.SubjectUserTOTPEnroll, s.handleUserTOTPEnroll},
+		{ipc.SubjectUserTOTPConfirm, s.handleUserTOTPConfirm},
+		{ipc.SubjectUserTOTPDisable, s.handleUserTOTPDisable},
+		{ipc.SubjectUserTOTPRecoveryCodes, s.handleUserTOTPRecoveryCodes},
+	}
+	for _, ep := range endpoints {
+		if err := ipc.RegisterEndpointWithGroupCache(s.microService, ep.subject,
+			micro.HandlerFunc(s.createRequestHandler(ctx, ep.handler)), groups); err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrEndpointRegistrationFailed, ep.subject, err)
+		}
 	}
 
-	if err := req.Respond(data); err != nil && s.logger != nil {
-		s.logger.ErrorContext(ctx, "Failed to respond to request", "error", err)
-	}
+	return nil
 }
 
-func (s *UserMgr) handleUserAuthenticate(ctx context.Context, req micro.Request) {
-	if s.tracer != nil {
-		_, span := s.tracer.Start(ctx, "usermgr.handleUserAuthenticate")
-		defer span.End()
-	}
-
-	var request schemav1alpha1.AuthenticateUserRequest
-	if err := request.UnmarshalVT(req.Data()); err != nil {
-		ipc.RespondWithError(ctx, req, ipc.ErrUnmarshalingFailed, err.Error())
-		return
-	}
-
-	// Mock response for now
-	response := &schemav1alpha1.AuthenticateUserResponse{}
+func (s *UserMgr) createRequestHandler(parentCtx context.Context, handler func(context.Context, micro.Request)) micro.HandlerFunc {
+	return func(req micro.Request) {
+		ctx := context.WithoutCancel(telemetry.GetCtxFromReq(req))
+		if parentCtx.Err() != nil {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithCancel(ctx)
+			cancel()
+		}
 
-	data, err := response.MarshalVT()
-	if err != nil {
-		ipc.RespondWithError(ctx, req, ipc.ErrMarshalingFailed, err.Error())
-		return
-	}
+		ctx, span := s.tracer.Start(ctx, "usermgr.handleRequest")
+		span.SetAttributes(
+			attribute.String("subject", req.Subject()),
+			attribute.String("service", s.config.name),
+		)
+		defer span.End()
 
-	if err := req.Respond(data); err != nil && s.logger != nil {
-		s.logger.ErrorContext(ctx, "Failed to respond to request", "error", err)
+		handler(ctx, req) //nolint:contextcheck
 	}
 }
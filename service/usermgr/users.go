@@ -0,0 +1,773 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package usermgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	schemav1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+	"github.com/u-bmc/u-bmc/pkg/fieldmask"
+	"github.com/u-bmc/u-bmc/pkg/id"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Failure reasons reported to clients. Authentication failures do not tell
+// unknown users from wrong passwords.
+const (
+	reasonInvalidCredentials = "invalid username or password"
+	reasonAccountLocked      = "account is locked"
+	reasonAccountDisabled    = "account is disabled"
+	reasonAccountExpired     = "account has expired"
+	reasonPasswordExpired    = "password has expired"
+	reasonNoPassword         = "account has no password"
+	reasonWrongPassword      = "current password is incorrect"
+	reasonNoNewPassword      = "either a new password or generate_password is required"
+	reasonSecondFactor       = "a second factor is required"
+	reasonInvalidFactor      = "invalid second factor"
+	reasonInvalidCode        = "invalid code"
+	reasonPasswordChange     = "password must be changed"
+)
+
+// authenticationFailureReason returns the failure reason reported for an
+// authentication failure.
+func authenticationFailureReason(failure schemav1alpha1.AuthenticationFailure) string {
+	switch failure {
+	case schemav1alpha1.AuthenticationFailure_AUTHENTICATION_FAILURE_ACCOUNT_LOCKED:
+		return reasonAccountLocked
+	case schemav1alpha1.AuthenticationFailure_AUTHENTICATION_FAILURE_ACCOUNT_DISABLED:
+		return reasonAccountDisabled
+	case schemav1alpha1.AuthenticationFailure_AUTHENTICATION_FAILURE_ACCOUNT_EXPIRED:
+		return reasonAccountExpired
+	case schemav1alpha1.AuthenticationFailure_AUTHENTICATION_FAILURE_PASSWORD_EXPIRED:
+		return reasonPasswordExpired
+	case schemav1alpha1.AuthenticationFailure_AUTHENTICATION_FAILURE_SECOND_FACTOR_REQUIRED:
+		return reasonSecondFactor
+	case schemav1alpha1.AuthenticationFailure_AUTHENTICATION_FAILURE_INVALID_SECOND_FACTOR:
+		return reasonInvalidFactor
+	case schemav1alpha1.AuthenticationFailure_AUTHENTICATION_FAILURE_PASSWORD_CHANGE_REQUIRED:
+		return reasonPasswordChange
+	default:
+		return reasonInvalidCredentials
+	}
+}
+
+// defaultUpdatePaths returns the fields an update request without field mask
+// replaces.
+func defaultUpdatePaths() []string {
+	return []string{
+		"username", "full_name", "email", "enabled", "unix_info", "ldap_info",
+		"redfish_info", "nats_info", "custom_attributes", "account_expires_at",
+	}
+}
+
+// checkUpdatePaths rejects field mask paths naming fields that cannot be
+// updated. Passwords are changed with ChangePassword and ResetPassword, TOTP
+// enrollments with the TOTP endpoints.
+func checkUpdatePaths(paths []string) error {
+	for _, path := range paths {
+		switch {
+		case path == "id", path == "created_at", path == "last_login":
+			return fmt.Errorf("%w: %s cannot be updated", ErrInvalidUser, path)
+		case path == "auth_data.lockout_info", strings.HasPrefix(path, "auth_data.lockout_info."),
+			path == "auth_data.password_expires_at", path == "auth_data.totp_required":
+		case path == "auth_data", strings.HasPrefix(path, "auth_data."):
+			return fmt.Errorf("%w: %s cannot be updated, passwords are changed with ChangePassword or ResetPassword",
+				ErrInvalidUser, path)
+		}
+	}
+	return nil
+}
+
+// touchesLockout reports whether a field mask names the lockout state.
+func touchesLockout(paths []string) bool {
+	for _, path := range paths {
+		if path == "auth_data.lockout_info" || strings.HasPrefix(path, "auth_data.lockout_info.") {
+			return true
+		}
+	}
+	return false
+}
+
+// publicUser returns a copy of user without its password hashes and salts,
+// TOTP secret and recovery codes.
+func publicUser(user *schemav1alpha1.User) *schemav1alpha1.User {
+	user = user.CloneVT()
+	if auth := user.GetAuthData(); auth != nil {
+		auth.PasswordHash = ""
+		auth.PasswordSalt = nil
+		auth.PasswordHistory = nil
+		if totp := auth.GetTotp(); totp != nil {
+			totp.EncryptedSecret = nil
+			totp.RecoveryCodes = nil
+		}
+	}
+	return user
+}
+
+// ensureDefaultAdmin creates the default administrator account if the store
+// is empty. Its password must be changed on first login.
+func (s *UserMgr) ensureDefaultAdmin(ctx context.Context) error {
+	if s.config.adminUsername == "" || !s.store.empty() {
+		return nil
+	}
+
+	auth, err := newAuthData(s.config.hashAlgorithm, s.config.adminPassword)
+	if err != nil {
+		return err
+	}
+
+	username := s.config.adminUsername
+	changeRequired := true
+	now := timestamppb.Now()
+	user := &schemav1alpha1.User{
+		Id:           id.NewID(),
+		Username:     username,
+		Enabled:      true,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+		SourceSystem: schemav1alpha1.UserSource_USER_SOURCE_LOCAL,
+		AuthData:     auth,
+		RedfishInfo: &schemav1alpha1.RedfishAccountInfo{
+			AccountId:              &username,
+			RoleId:                 s.config.adminRole,
+			LockoutPolicy:          s.redfishLockoutPolicy(),
+			PasswordChangeRequired: &changeRequired,
+		},
+	}
+	if err := s.store.create(ctx, user); err != nil {
+		return err
+	}
+
+	s.logger.WarnContext(ctx, "Created default administrator account, its password must be changed on first login",
+		"user", username,
+		"role", s.config.adminRole)
+
+	return nil
+}
+
+// createUser creates a user. Local users need a password, which is hashed
+// with the configured algorithm. Instead of a password, the request may carry
+// an existing hash in the authentication data of the user, which imports it.
+func (s *UserMgr) createUser(ctx context.Context, req *schemav1alpha1.CreateUserRequest) (*schemav1alpha1.CreateUserResponse, error) {
+	if req.GetUser() == nil {
+		return nil, fmt.Errorf("%w: user is required", ErrInvalidUser)
+	}
+	if !validUsername(req.GetUser().GetUsername()) {
+		return nil, fmt.Errorf("%w: invalid username %q", ErrInvalidUser, req.GetUser().GetUsername())
+	}
+
+	user := req.GetUser().CloneVT()
+	user.Id = id.NewID()
+	now := timestamppb.Now()
+	if user.GetCreatedAt() == nil {
+		user.CreatedAt = now
+	}
+	user.UpdatedAt = now
+	user.LastLogin = nil
+	if user.AuthData != nil {
+		// Second factors are only enrolled through the TOTP endpoints.
+		user.AuthData.Totp = nil
+	}
+
+	switch {
+	case req.Password != nil:
+		requested := user.GetAuthData()
+		user.AuthData = &schemav1alpha1.AuthenticationData{LockoutInfo: requested.GetLockoutInfo()}
+		if requested.GetTotpRequired() {
+			required := true
+			user.AuthData.TotpRequired = &required
+		}
+		if err := s.checkPassword(user, req.GetPassword()); err != nil {
+			return nil, err
+		}
+		if err := s.setPassword(user, req.GetPassword()); err != nil {
+			return nil, err
+		}
+		if requested.GetPasswordExpiresAt() != nil {
+			user.AuthData.PasswordExpiresAt = requested.GetPasswordExpiresAt()
+		}
+	case user.GetAuthData().GetPasswordHash() != "":
+		if err := checkImportedHash(user.GetAuthData()); err != nil {
+			return nil, err
+		}
+		if user.GetAuthData().GetPasswordLastChanged() == nil {
+			user.AuthData.PasswordLastChanged = now
+		}
+	case user.GetSourceSystem() == schemav1alpha1.UserSource_USER_SOURCE_LOCAL:
+		return nil, fmt.Errorf("%w: a password is required for local users", ErrInvalidPassword)
+	}
+
+	resp := &schemav1alpha1.CreateUserResponse{}
+	if req.GetLinkingOptions() != nil {
+		resp.Warnings = append(resp.Warnings, "linking options are not supported and were ignored")
+	}
+
+	if !req.GetDryRun() {
+		if err := s.store.create(ctx, user); err != nil {
+			return nil, err
+		}
+		s.logger.InfoContext(ctx, "User created",
+			"user", user.GetUsername(),
+			"id", user.GetId(),
+			"source", user.GetSourceSystem())
+	}
+
+	resp.User = publicUser(user)
+	return resp, nil
+}
+
+// getUser returns the user selected by a request.
+func (s *UserMgr) getUser(req *schemav1alpha1.GetUserRequest) (*schemav1alpha1.User, error) {
+	var user *schemav1alpha1.User
+	var err error
+	switch ident := req.GetIdentifier().(type) {
+	case *schemav1alpha1.GetUserRequest_Id:
+		user, err = s.store.get(ident.Id)
+	case *schemav1alpha1.GetUserRequest_Username:
+		user, err = s.store.byUsername(ident.Username)
+	case *schemav1alpha1.GetUserRequest_Email:
+		user, err = s.store.find(func(u *schemav1alpha1.User) bool {
+			return u.Email != nil && strings.EqualFold(u.GetEmail(), ident.Email)
+		})
+	default:
+		return nil, fmt.Errorf("%w: an identifier is required", ErrInvalidUser)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	user = publicUser(user)
+	if err := fieldmask.Apply(user, req.GetFieldMask()); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidUser, err)
+	}
+	return user, nil
+}
+
+// updateUser replaces the fields of a stored user named by the field mask of
+// a request. The user is identified by its ID, or by its username if the
+// request carries no ID.
+//
+// Administrators lock and unlock accounts by updating their lockout state.
+// Locking records the lockout time and defaults the reason to an
+// administrative lock, unlocking clears the failed login count as well.
+func (s *UserMgr) updateUser(ctx context.Context, req *schemav1alpha1.UpdateUserRequest) (*schemav1alpha1.UpdateUserResponse, error) {
+	if req.GetUser() == nil {
+		return nil, fmt.Errorf("%w: user is required", ErrInvalidUser)
+	}
+
+	mask := req.GetFieldMask()
+	if len(mask.GetPaths()) == 0 {
+		mask = &fieldmaskpb.FieldMask{Paths: defaultUpdatePaths()}
+	}
+	if err := checkUpdatePaths(mask.GetPaths()); err != nil {
+		return nil, err
+	}
+
+	userID := req.GetUser().GetId()
+	if userID == "" {
+		cur, err := s.store.byUsername(req.GetUser().GetUsername())
+		if err != nil {
+			return nil, err
+		}
+		userID = cur.GetId()
+	}
+
+	var locked, unlocked bool
+	user, err := s.store.update(ctx, userID, func(user *schemav1alpha1.User) error {
+		wasLocked := user.GetAuthData().GetLockoutInfo().GetLocked()
+		if err := fieldmask.Merge(user, req.GetUser(), mask); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidUser, err)
+		}
+		if !validUsername(user.GetUsername()) {
+			return fmt.Errorf("%w: invalid username %q", ErrInvalidUser, user.GetUsername())
+		}
+		now := timestamppb.Now()
+		if li := user.GetAuthData().GetLockoutInfo(); touchesLockout(mask.GetPaths()) {
+			switch {
+			case li.GetLocked() && !wasLocked:
+				if li.GetReason() == schemav1alpha1.LockoutReason_LOCKOUT_REASON_UNSPECIFIED {
+					reason := schemav1alpha1.LockoutReason_LOCKOUT_REASON_ADMINISTRATIVE
+					li.Reason = &reason
+				}
+				li.LockoutTime = now
+				locked = true
+			case !li.GetLocked() && user.GetAuthData() != nil:
+				user.AuthData.LockoutInfo = nil
+				unlocked = wasLocked
+			}
+		}
+		user.UpdatedAt = now
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case locked:
+		s.logger.WarnContext(ctx, "User locked",
+			"user", user.GetUsername(),
+			"reason", user.GetAuthData().GetLockoutInfo().GetReason())
+	case unlocked:
+		s.logger.InfoContext(ctx, "User unlocked", "user", user.GetUsername())
+	}
+
+	resp := &schemav1alpha1.UpdateUserResponse{User: publicUser(user)}
+	if req.GetLinkingOptions() != nil {
+		resp.Warnings = append(resp.Warnings, "linking options are not supported and were ignored")
+	}
+	return resp, nil
+}
+
+// deleteUser deletes a user.
+func (s *UserMgr) deleteUser(ctx context.Context, req *schemav1alpha1.DeleteUserRequest) error {
+	user, err := s.store.get(req.GetId())
+	if err != nil {
+		return err
+	}
+	if err := s.store.remove(ctx, req.GetId()); err != nil {
+		return err
+	}
+
+	s.logger.InfoContext(ctx, "User deleted", "user", user.GetUsername(), "id", user.GetId())
+
+	return nil
+}
+
+// listUsers returns the users matching the filters of a request, ordered by
+// username. The page token is the username of the last user of the previous
+// page.
+func (s *UserMgr) listUsers(req *schemav1alpha1.ListUsersRequest) (*schemav1alpha1.ListUsersResponse, error) {
+	resp := &schemav1alpha1.ListUsersResponse{}
+	for _, user := range s.store.list() {
+		switch {
+		case req.Source != nil && user.GetSourceSystem() != req.GetSource():
+			continue
+		case req.Enabled != nil && user.GetEnabled() != req.GetEnabled():
+			continue
+		case !strings.HasPrefix(user.GetUsername(), req.GetUsernamePrefix()):
+			continue
+		case req.PageToken != nil && user.GetUsername() <= req.GetPageToken():
+			continue
+		}
+
+		if req.PageSize != nil && len(resp.Users) == int(req.GetPageSize()) {
+			token := resp.Users[len(resp.Users)-1].GetUsername()
+			resp.NextPageToken = &token
+			break
+		}
+
+		user = publicUser(user)
+		if err := fieldmask.Apply(user, req.GetFieldMask()); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidUser, err)
+		}
+		resp.Users = append(resp.Users, user)
+	}
+	return resp, nil
+}
+
+// changePassword replaces the password of a user who knows the current one.
+// Expired passwords can still be changed. Wrong current passwords count as
+// failed logins. A successful change satisfies a pending password change
+// requirement.
+func (s *UserMgr) changePassword(ctx context.Context, req *schemav1alpha1.ChangePasswordRequest) (*schemav1alpha1.ChangePasswordResponse, error) {
+	user, err := s.store.get(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	failure := func(reason string) (*schemav1alpha1.ChangePasswordResponse, error) {
+		s.logger.WarnContext(ctx, "Password change rejected", "user", user.GetUsername(), "reason", reason)
+		return &schemav1alpha1.ChangePasswordResponse{FailureReason: &reason}, nil
+	}
+
+	now := time.Now()
+	if s.lockoutPolicy(user).locked(user.GetAuthData().GetLockoutInfo(), now) {
+		return failure(reasonAccountLocked)
+	}
+
+	ok, err := verifyPassword(user.GetAuthData(), req.GetCurrentPassword())
+	switch {
+	case err != nil:
+		return nil, err
+	case user.GetAuthData().GetPasswordHash() == "":
+		return failure(reasonNoPassword)
+	case !ok:
+		s.recordFailedLogin(ctx, user, now)
+		return failure(reasonWrongPassword)
+	case !user.GetEnabled():
+		return failure(reasonAccountDisabled)
+	case accountExpired(user, now):
+		return failure(reasonAccountExpired)
+	}
+	if err := s.checkPassword(user, req.GetNewPassword()); err != nil {
+		return failure(err.Error())
+	}
+
+	if _, err := s.store.update(ctx, user.GetId(), func(user *schemav1alpha1.User) error {
+		if err := s.setPassword(user, req.GetNewPassword()); err != nil {
+			return err
+		}
+		user.AuthData.LockoutInfo = nil
+		if info := user.GetRedfishInfo(); info != nil && info.PasswordChangeRequired != nil {
+			changeRequired := false
+			info.PasswordChangeRequired = &changeRequired
+		}
+		user.UpdatedAt = timestamppb.Now()
+		return nil
+	}); err != nil {
+		if errors.Is(err, ErrInvalidPassword) {
+			return failure(err.Error())
+		}
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "User password changed", "user", user.GetUsername())
+
+	return &schemav1alpha1.ChangePasswordResponse{Success: true}, nil
+}
+
+// resetPassword sets the password of a user without knowing the current
+// one, generating it if requested. Forced resets by administrators of
+// another account skip the history check, which only the account's own
+// changes have to pass, but are held to the rest of the password policy.
+func (s *UserMgr) resetPassword(ctx context.Context, req *schemav1alpha1.ResetPasswordRequest) (*schemav1alpha1.ResetPasswordResponse, error) {
+	user, err := s.store.get(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	failure := func(reason string) (*schemav1alpha1.ResetPasswordResponse, error) {
+		s.logger.WarnContext(ctx, "Password reset rejected", "user", user.GetUsername(), "reason", reason)
+		return &schemav1alpha1.ResetPasswordResponse{FailureReason: &reason}, nil
+	}
+
+	resp := &schemav1alpha1.ResetPasswordResponse{}
+	password := req.GetNewPassword()
+	switch {
+	case req.GetGeneratePassword():
+		if password, err = generatePassword(s.config.generatedPasswordLen); err != nil {
+			return nil, err
+		}
+		resp.NewPassword = password
+	case password == "":
+		return failure(reasonNoNewPassword)
+	case req.GetForce():
+		if err := s.checkPasswordStrength(user, password); err != nil {
+			return failure(err.Error())
+		}
+	default:
+		if err := s.checkPassword(user, password); err != nil {
+			return failure(err.Error())
+		}
+	}
+
+	if _, err := s.store.update(ctx, user.GetId(), func(user *schemav1alpha1.User) error {
+		if err := s.setPassword(user, password); err != nil {
+			return err
+		}
+		user.UpdatedAt = timestamppb.Now()
+		return nil
+	}); err != nil {
+		if errors.Is(err, ErrInvalidPassword) {
+			return failure(err.Error())
+		}
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "User password reset", "user", user.GetUsername(), "generated", req.GetGeneratePassword())
+
+	resp.Success = true
+	return resp, nil
+}
+
+// recordFailedLogin counts a failed login of user under its lockout policy
+// and locks the account once the threshold is reached.
+func (s *UserMgr) recordFailedLogin(ctx context.Context, user *schemav1alpha1.User, now time.Time) {
+	policy := s.lockoutPolicy(user)
+	if !policy.enabled() {
+		return
+	}
+
+	var locked bool
+	updated, err := s.store.update(ctx, user.GetId(), func(user *schemav1alpha1.User) error {
+		if user.AuthData == nil {
+			user.AuthData = &schemav1alpha1.AuthenticationData{}
+		}
+		if user.AuthData.LockoutInfo == nil || user.AuthData.LockoutInfo.GetLocked() {
+			// A lock still in place here has lifted, so counting starts over.
+			user.AuthData.LockoutInfo = &schemav1alpha1.AccountLockoutInfo{}
+		}
+		locked = policy.recordFailure(user.AuthData.LockoutInfo, now)
+		return nil
+	})
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to record failed login", "user", user.GetUsername(), "error", err)
+		return
+	}
+
+	if locked {
+		s.logger.WarnContext(ctx, "User locked after failed logins",
+			"user", user.GetUsername(),
+			"failed_attempts", updated.GetAuthData().GetLockoutInfo().GetFailedAttempts(),
+			"duration", policy.duration)
+	}
+}
+
+// authenticationFailure logs a failed authentication and returns the
+// response reporting it.
+func (s *UserMgr) authenticationFailure(ctx context.Context, req *schemav1alpha1.AuthenticateUserRequest, kind schemav1alpha1.AuthenticationFailure, detail string) (*schemav1alpha1.AuthenticateUserResponse, error) {
+	s.logger.WarnContext(ctx, "Authentication failed",
+		"user", req.GetUsername(),
+		"source_ip", req.GetSourceIp(),
+		"failure", kind,
+		"reason", detail)
+	reason := authenticationFailureReason(kind)
+	return &schemav1alpha1.AuthenticateUserResponse{FailureReason: &reason, Failure: &kind}, nil
+}
+
+// authenticate checks the credentials of a user. Users of every source with
+// a stored password can authenticate. Locked accounts are rejected without
+// checking the password, wrong passwords count towards the lockout
+// threshold. Disabled and expired accounts and expired passwords are only
+// reported to users who know the password. Passwords hashed with another
+// algorithm than the configured one are rehashed on success.
+//
+// The authentication methods are tried in the configured order. Local
+// accounts are only checked by the local method and remote users only by
+// the method of their source. A remote method passes a login on to the next
+// method if none of its servers can be reached or it rejects a user that is
+// not one of its own, so that local accounts can serve as a fallback.
+// Remote users are provisioned with the role their servers report.
+//
+// Users with a TOTP enrollment must also pass a code or a recovery code,
+// which is consumed, and wrong ones count towards the lockout threshold as
+// well. Clients that cannot ask for a second factor are refused for them and
+// for users required to use one. Required users that have not enrolled yet
+// are let in with second_factor_enrollment_required set.
+//
+// Accounts whose password must be changed, such as the default account, are
+// refused by clients that cannot let users change it. Others let them in with
+// password_change_required set and only allow the change.
+func (s *UserMgr) authenticate(ctx context.Context, req *schemav1alpha1.AuthenticateUserRequest) (*schemav1alpha1.AuthenticateUserResponse, error) {
+	user, err := s.store.byUsername(req.GetUsername())
+	if err != nil && !errors.Is(err, ErrUserNotFound) {
+		return nil, err
+	}
+
+	now := time.Now()
+	if li := user.GetAuthData().GetLockoutInfo(); user != nil && s.lockoutPolicy(user).locked(li, now) {
+		return s.authenticationFailure(ctx, req, schemav1alpha1.AuthenticationFailure_AUTHENTICATION_FAILURE_ACCOUNT_LOCKED,
+			li.GetReason().String())
+	}
+
+	detail := "unknown user"
+	for _, method := range s.authOrder {
+		if method == AuthMethodLocal {
+			if user != nil && !remoteUser(user) {
+				return s.localLogin(ctx, req, user, now)
+			}
+			continue
+		}
+
+		a := s.authenticators[method]
+		if remoteUser(user) && user.GetSourceSystem() != a.source() {
+			continue
+		}
+		authenticated, err := s.remoteLogin(ctx, req, user, a)
+		switch {
+		case err == nil:
+			return s.completeLogin(ctx, req, authenticated, now)
+		case errors.Is(err, ErrRemoteRejected) && user.GetSourceSystem() == a.source():
+			s.recordFailedLogin(ctx, user, now)
+			return s.authenticationFailure(ctx, req, schemav1alpha1.AuthenticationFailure_AUTHENTICATION_FAILURE_INVALID_CREDENTIALS,
+				err.Error())
+		case errors.Is(err, ErrRemoteUnavailable), errors.Is(err, ErrRemoteUserNotFound), errors.Is(err, ErrRemoteRejected):
+			// RADIUS and TACACS+ servers reject unknown users like wrong
+			// passwords, so only users of the method end with a rejection.
+			s.logger.DebugContext(ctx, "Authentication method passed on login",
+				"user", req.GetUsername(),
+				"method", method,
+				"error", err)
+			detail = err.Error()
+		default:
+			return s.authenticationFailure(ctx, req, schemav1alpha1.AuthenticationFailure_AUTHENTICATION_FAILURE_INVALID_CREDENTIALS,
+				err.Error())
+		}
+	}
+
+	// Spend the time of a verification so unknown users cannot be told apart
+	// by the response time.
+	_, _ = verifyPassword(s.dummyAuth, req.GetPassword())
+	return s.authenticationFailure(ctx, req, schemav1alpha1.AuthenticationFailure_AUTHENTICATION_FAILURE_INVALID_CREDENTIALS,
+		detail)
+}
+
+// checkLogin checks whether an account may log in, for protocols such as
+// RAKP that prove knowledge of the password without passing it. It checks
+// the account state without recording a login, which the protocol reports
+// with AuthenticateUser once the proof succeeded. A failed proof is reported
+// with proof_failed set and counts towards the lockout threshold. Accounts
+// with a second factor or a pending password change are refused, as such
+// protocols cannot pass or change either.
+func (s *UserMgr) checkLogin(ctx context.Context, req *schemav1alpha1.CheckLoginRequest) (*schemav1alpha1.CheckLoginResponse, error) {
+	refuse := func(kind schemav1alpha1.AuthenticationFailure, detail string) (*schemav1alpha1.CheckLoginResponse, error) {
+		s.logger.WarnContext(ctx, "Login refused",
+			"user", req.GetUsername(),
+			"source_ip", req.GetSourceIp(),
+			"failure", kind,
+			"reason", detail)
+		reason := authenticationFailureReason(kind)
+		return &schemav1alpha1.CheckLoginResponse{FailureReason: &reason, Failure: &kind}, nil
+	}
+
+	user, err := s.store.byUsername(req.GetUsername())
+	switch {
+	case errors.Is(err, ErrUserNotFound):
+		return refuse(schemav1alpha1.AuthenticationFailure_AUTHENTICATION_FAILURE_INVALID_CREDENTIALS, "unknown user")
+	case err != nil:
+		return nil, err
+	}
+
+	now := time.Now()
+	auth := user.GetAuthData()
+	switch {
+	case s.lockoutPolicy(user).locked(auth.GetLockoutInfo(), now):
+		return refuse(schemav1alpha1.AuthenticationFailure_AUTHENTICATION_FAILURE_ACCOUNT_LOCKED,
+			auth.GetLockoutInfo().GetReason().String())
+	case req.GetProofFailed():
+		s.recordFailedLogin(ctx, user, now)
+		return refuse(schemav1alpha1.AuthenticationFailure_AUTHENTICATION_FAILURE_INVALID_CREDENTIALS, "wrong password")
+	case !user.GetEnabled():
+		return refuse(schemav1alpha1.AuthenticationFailure_AUTHENTICATION_FAILURE_ACCOUNT_DISABLED, reasonAccountDisabled)
+	case accountExpired(user, now):
+		return refuse(schemav1alpha1.AuthenticationFailure_AUTHENTICATION_FAILURE_ACCOUNT_EXPIRED, reasonAccountExpired)
+	case auth != nil && s.passwordExpired(auth, now):
+		return refuse(schemav1alpha1.AuthenticationFailure_AUTHENTICATION_FAILURE_PASSWORD_EXPIRED, reasonPasswordExpired)
+	case auth.GetTotp().GetEnabled() || s.config.totpRequired || auth.GetTotpRequired():
+		return refuse(schemav1alpha1.AuthenticationFailure_AUTHENTICATION_FAILURE_SECOND_FACTOR_REQUIRED,
+			"client does not support a second factor")
+	case user.GetRedfishInfo().GetPasswordChangeRequired():
+		return refuse(schemav1alpha1.AuthenticationFailure_AUTHENTICATION_FAILURE_PASSWORD_CHANGE_REQUIRED,
+			"client does not support a password change")
+	}
+
+	return &schemav1alpha1.CheckLoginResponse{Allowed: true}, nil
+}
+
+// localLogin verifies the password of a local account.
+func (s *UserMgr) localLogin(ctx context.Context, req *schemav1alpha1.AuthenticateUserRequest, user *schemav1alpha1.User, now time.Time) (*schemav1alpha1.AuthenticateUserResponse, error) {
+	ok, err := verifyPassword(user.GetAuthData(), req.GetPassword())
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to verify password", "user", user.GetUsername(), "error", err)
+		return s.authenticationFailure(ctx, req, schemav1alpha1.AuthenticationFailure_AUTHENTICATION_FAILURE_INVALID_CREDENTIALS,
+			err.Error())
+	}
+	switch {
+	case user.GetAuthData().GetPasswordHash() == "":
+		return s.authenticationFailure(ctx, req, schemav1alpha1.AuthenticationFailure_AUTHENTICATION_FAILURE_INVALID_CREDENTIALS,
+			reasonNoPassword)
+	case !ok:
+		s.recordFailedLogin(ctx, user, now)
+		return s.authenticationFailure(ctx, req, schemav1alpha1.AuthenticationFailure_AUTHENTICATION_FAILURE_INVALID_CREDENTIALS,
+			"wrong password")
+	}
+
+	return s.completeLogin(ctx, req, user, now)
+}
+
+// completeLogin finishes the authentication of a user whose password was
+// verified, locally or by remote servers. It checks the account state and the
+// second factor and records the login.
+func (s *UserMgr) completeLogin(ctx context.Context, req *schemav1alpha1.AuthenticateUserRequest, user *schemav1alpha1.User, now time.Time) (*schemav1alpha1.AuthenticateUserResponse, error) {
+	switch {
+	case !user.GetEnabled():
+		return s.authenticationFailure(ctx, req, schemav1alpha1.AuthenticationFailure_AUTHENTICATION_FAILURE_ACCOUNT_DISABLED,
+			reasonAccountDisabled)
+	case accountExpired(user, now):
+		return s.authenticationFailure(ctx, req, schemav1alpha1.AuthenticationFailure_AUTHENTICATION_FAILURE_ACCOUNT_EXPIRED,
+			reasonAccountExpired)
+	case user.GetAuthData() != nil && s.passwordExpired(user.GetAuthData(), now):
+		return s.authenticationFailure(ctx, req, schemav1alpha1.AuthenticationFailure_AUTHENTICATION_FAILURE_PASSWORD_EXPIRED,
+			reasonPasswordExpired)
+	}
+
+	enrolled := user.GetAuthData().GetTotp().GetEnabled()
+	required := s.config.totpRequired || user.GetAuthData().GetTotpRequired()
+	switch {
+	case (enrolled || required) && !req.GetSecondFactorSupported():
+		return s.authenticationFailure(ctx, req, schemav1alpha1.AuthenticationFailure_AUTHENTICATION_FAILURE_SECOND_FACTOR_REQUIRED,
+			"client does not support a second factor")
+	case enrolled && req.GetSecondFactor() == "":
+		return s.authenticationFailure(ctx, req, schemav1alpha1.AuthenticationFailure_AUTHENTICATION_FAILURE_SECOND_FACTOR_REQUIRED,
+			reasonSecondFactor)
+	}
+	changeRequired := user.GetRedfishInfo().GetPasswordChangeRequired()
+	if changeRequired && !req.GetPasswordChangeSupported() {
+		return s.authenticationFailure(ctx, req, schemav1alpha1.AuthenticationFailure_AUTHENTICATION_FAILURE_PASSWORD_CHANGE_REQUIRED,
+			"client does not support a password change")
+	}
+
+	var recoveryCodeUsed bool
+	_, err := s.store.update(ctx, user.GetId(), func(user *schemav1alpha1.User) error {
+		if user.AuthData == nil {
+			user.AuthData = &schemav1alpha1.AuthenticationData{}
+		}
+		auth := user.GetAuthData()
+		if enrolled {
+			if !auth.GetTotp().GetEnabled() {
+				return fmt.Errorf("%w: %s", ErrTOTPNotEnrolled, user.GetUsername())
+			}
+			var err error
+			if recoveryCodeUsed, err = s.useSecondFactor(user.GetId(), auth.GetTotp(), req.GetSecondFactor(), now); err != nil {
+				return err
+			}
+		}
+		user.LastLogin = timestamppb.New(now)
+		auth.LockoutInfo = nil
+		if auth.GetPasswordHash() != "" && auth.GetHashAlgorithm() != s.config.hashAlgorithm && !remoteUser(user) {
+			next, err := newAuthData(s.config.hashAlgorithm, req.GetPassword())
+			if err != nil {
+				return err
+			}
+			auth.PasswordHash = next.GetPasswordHash()
+			auth.PasswordSalt = next.PasswordSalt
+			auth.HashAlgorithm = next.GetHashAlgorithm()
+			auth.Iterations = next.GetIterations()
+		}
+		return nil
+	})
+	switch {
+	case errors.Is(err, ErrInvalidSecondFactor):
+		s.recordFailedLogin(ctx, user, now)
+		return s.authenticationFailure(ctx, req, schemav1alpha1.AuthenticationFailure_AUTHENTICATION_FAILURE_INVALID_SECOND_FACTOR,
+			err.Error())
+	case errors.Is(err, ErrTOTPNotEnrolled):
+		return s.authenticationFailure(ctx, req, schemav1alpha1.AuthenticationFailure_AUTHENTICATION_FAILURE_INVALID_SECOND_FACTOR,
+			err.Error())
+	case err != nil && enrolled:
+		// Without storing the used code it could be replayed.
+		return nil, err
+	case err != nil:
+		s.logger.WarnContext(ctx, "Failed to record login", "user", user.GetUsername(), "error", err)
+	}
+
+	if recoveryCodeUsed {
+		s.logger.WarnContext(ctx, "User authenticated with a recovery code",
+			"user", user.GetUsername(),
+			"source_ip", req.GetSourceIp())
+	}
+
+	userID := user.GetId()
+	return &schemav1alpha1.AuthenticateUserResponse{
+		Success:                        true,
+		UserId:                         &userID,
+		SecondFactorEnrollmentRequired: required && !enrolled,
+		PasswordChangeRequired:         changeRequired,
+	}, nil
+}
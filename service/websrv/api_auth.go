@@ -0,0 +1,182 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package websrv
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1/schemav1alpha1connect"
+)
+
+// procedurePrivilege returns the Redfish privilege a Connect RPC procedure
+// requires, mirroring the privileges of the matching Redfish resources.
+func procedurePrivilege(procedure string) string {
+	switch procedure {
+	case schemav1alpha1connect.BMCServiceAuthenticateUserProcedure:
+		return privilegeNone
+	case schemav1alpha1connect.BMCServiceChangePasswordProcedure:
+		// The current password has to be provided as well.
+		return privilegeConfigureSelf
+	case schemav1alpha1connect.BMCServiceCreateUserProcedure,
+		schemav1alpha1connect.BMCServiceGetUserProcedure,
+		schemav1alpha1connect.BMCServiceUpdateUserProcedure,
+		schemav1alpha1connect.BMCServiceDeleteUserProcedure,
+		schemav1alpha1connect.BMCServiceListUsersProcedure,
+		schemav1alpha1connect.BMCServiceResetPasswordProcedure,
+		schemav1alpha1connect.BMCServiceEnrollTotpProcedure,
+		schemav1alpha1connect.BMCServiceConfirmTotpProcedure,
+		schemav1alpha1connect.BMCServiceDisableTotpProcedure,
+		schemav1alpha1connect.BMCServiceRegenerateRecoveryCodesProcedure:
+		return privilegeConfigureUsers
+	case schemav1alpha1connect.BMCServiceSetAssetInfoProcedure,
+		schemav1alpha1connect.BMCServiceUpdateManagementControllerProcedure,
+		schemav1alpha1connect.BMCServiceChangeManagementControllerStateProcedure:
+		return privilegeConfigureManager
+	}
+
+	method := procedure[strings.LastIndex(procedure, "/")+1:]
+	if strings.HasPrefix(method, "Get") || strings.HasPrefix(method, "List") {
+		return privilegeLogin
+	}
+	return privilegeConfigureComponents
+}
+
+// apiAuthInterceptor authenticates Connect RPC requests with a session token
+// issued by AuthenticateUser or the Redfish SessionService, the session
+// cookie of the Web UI or, with single sign-on enabled, a bearer token issued
+// by the OpenID provider, and checks the privilege the procedure requires.
+type apiAuthInterceptor struct {
+	logger   *slog.Logger
+	sessions *sessionStore
+	sso      *oidcServer
+}
+
+func newAPIAuthInterceptor(logger *slog.Logger, sessions *sessionStore, sso *oidcServer) *apiAuthInterceptor {
+	return &apiAuthInterceptor{
+		logger:   logger,
+		sessions: sessions,
+		sso:      sso,
+	}
+}
+
+// WrapUnary implements connect.Interceptor.
+func (i *apiAuthInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if req.Spec().IsClient {
+			return next(ctx, req)
+		}
+		ctx, err := i.authorize(ctx, req.Spec().Procedure, req.Any(), req.Header(), req.Peer().Addr)
+		if err != nil {
+			return nil, err
+		}
+		return next(ctx, req)
+	}
+}
+
+// WrapStreamingClient implements connect.Interceptor.
+func (i *apiAuthInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler implements connect.Interceptor.
+func (i *apiAuthInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		ctx, err := i.authorize(ctx, conn.Spec().Procedure, nil, conn.RequestHeader(), conn.Peer().Addr)
+		if err != nil {
+			return err
+		}
+		return next(ctx, conn)
+	}
+}
+
+// selfEnrollment reports whether msg asks procedure to enroll a second
+// factor for the account of sess, which only requires the ConfigureSelf
+// privilege.
+func selfEnrollment(procedure string, msg any, sess *redfishSession) bool {
+	switch procedure {
+	case schemav1alpha1connect.BMCServiceEnrollTotpProcedure,
+		schemav1alpha1connect.BMCServiceConfirmTotpProcedure:
+	default:
+		return false
+	}
+	req, ok := msg.(interface{ GetId() string })
+	return ok && sess.userID != "" && req.GetId() == sess.userID
+}
+
+// authorize returns ctx carrying the session of the request if it may call
+// procedure with msg, and a Connect error otherwise.
+func (i *apiAuthInterceptor) authorize(ctx context.Context, procedure string, msg any, header http.Header, peerAddr string) (context.Context, error) {
+	privilege := procedurePrivilege(procedure)
+	if privilege == privilegeNone {
+		return ctx, nil
+	}
+
+	sess, err := i.authenticate(ctx, header, clientAddress(peerAddr))
+	if err != nil {
+		return ctx, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+	self := selfEnrollment(procedure, msg, sess)
+	if self {
+		privilege = privilegeConfigureSelf
+	}
+	changePassword := procedure == schemav1alpha1connect.BMCServiceChangePasswordProcedure
+	if sess.passwordChangeRequired && !changePassword {
+		return ctx, connect.NewError(connect.CodePermissionDenied, errors.New("password change required"))
+	}
+	if sess.secondFactorEnrollmentRequired && !changePassword && !self {
+		return ctx, connect.NewError(connect.CodePermissionDenied, errors.New("second factor enrollment required"))
+	}
+	if !sess.hasPrivilege(privilege) {
+		return ctx, connect.NewError(connect.CodePermissionDenied, errors.New("insufficient privilege"))
+	}
+	return context.WithValue(ctx, sessionContextKey{}, sess), nil
+}
+
+// authenticate returns the session of the credentials in header.
+func (i *apiAuthInterceptor) authenticate(ctx context.Context, header http.Header, clientAddr string) (*redfishSession, error) {
+	if scheme, token, ok := strings.Cut(header.Get("Authorization"), " "); ok && strings.EqualFold(scheme, "Bearer") {
+		if i.sso == nil {
+			return nil, errors.New("bearer tokens require single sign-on")
+		}
+		sess, err := i.sso.verifyBearer(ctx, strings.TrimSpace(token), clientAddr)
+		if err != nil {
+			i.logger.WarnContext(ctx, "API bearer token rejected", "client", clientAddr, "error", err)
+			return nil, errors.New("invalid bearer token")
+		}
+		return sess, nil
+	}
+
+	token := header.Get(authTokenHeader)
+	if token == "" {
+		token = sessionCookie(header)
+	}
+	if token == "" {
+		return nil, errors.New("authentication required")
+	}
+	sess, ok := i.sessions.lookup(token)
+	if !ok {
+		return nil, errors.New("invalid session")
+	}
+	return sess, nil
+}
+
+// sessionCookie returns the value of the session cookie in header.
+func sessionCookie(header http.Header) string {
+	for _, line := range header.Values("Cookie") {
+		cookies, err := http.ParseCookie(line)
+		if err != nil {
+			continue
+		}
+		for _, cookie := range cookies {
+			if cookie.Name == sessionCookieName {
+				return cookie.Value
+			}
+		}
+	}
+	return ""
+}
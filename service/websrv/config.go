@@ -3,11 +3,58 @@
 package websrv
 
 import (
+	"net/http"
 	"time"
 
 	"github.com/u-bmc/u-bmc/pkg/cert"
 )
 
+// OIDCClaimRole maps the users whose role claim holds Value to a Redfish
+// role.
+type OIDCClaimRole struct {
+	Value string
+	Role  string
+}
+
+// OIDCConfig configures single sign-on with an OpenID provider.
+type OIDCConfig struct {
+	// Issuer is the issuer URL of the provider, below which its discovery
+	// document is served.
+	Issuer string
+	// ClientID and ClientSecret identify the web server at the provider.
+	// Public clients leave the secret empty.
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is the callback URL registered with the provider, such
+	// as https://bmc.example.com/api/auth/oidc/callback.
+	RedirectURL string
+	// Scopes are the requested scopes, openid, profile and email if empty.
+	Scopes []string
+	// UsernameClaim names the claim holding the username of a session,
+	// preferred_username if empty. The subject is used if the claim is
+	// missing.
+	UsernameClaim string
+	// RoleClaim names the claim the role of a user is derived from, groups
+	// if empty. Dotted names select nested claims such as
+	// realm_access.roles.
+	RoleClaim string
+	// ClaimRoles map claim values to roles, the first matching mapping
+	// deciding the role of a user.
+	ClaimRoles []OIDCClaimRole
+	// DefaultRole is the role of users without a matching claim value. If
+	// empty, such users are refused.
+	DefaultRole string
+	// Audiences are the audiences bearer tokens on the Connect RPC API may
+	// be issued for, the client ID if empty.
+	Audiences []string
+	// KeySetTTL is how long the key set of the provider is cached, 15
+	// minutes if zero.
+	KeySetTTL time.Duration
+	// HTTPClient fetches the documents of the provider and redeems codes,
+	// a client with a 10 second timeout if nil.
+	HTTPClient *http.Client
+}
+
 type config struct {
 	name         string
 	addr         string
@@ -19,6 +66,36 @@ type config struct {
 	rmemMax      string
 	wmemMax      string
 	certConfig   *cert.Config
+
+	// Redfish configuration
+	redfish        bool
+	redfishTimeout time.Duration
+
+	// Redfish event service configuration
+	redfishEventBucket        string
+	redfishEventRetryAttempts int
+	redfishEventRetryInterval time.Duration
+
+	// Redfish session service configuration
+	redfishSessionTimeout time.Duration
+
+	// Redfish update service configuration
+	redfishUpdateStagingDir string
+	redfishMaxImageSize     int64
+
+	// Redfish telemetry service configuration
+	redfishTelemetryBucket string
+
+	// Redfish log service configuration
+	redfishEventLogSubjects []string
+
+	// Redfish aggregation service configuration
+	redfishAggregationBucket     string
+	redfishAggregationInterval   time.Duration
+	redfishAggregationSkipVerify bool
+
+	// Single sign-on configuration, nil if disabled
+	oidc *OIDCConfig
 }
 
 type Option interface {
@@ -74,6 +151,237 @@ func WithWebUI(webui bool) Option {
 	}
 }
 
+type redfishOption struct {
+	redfish bool
+}
+
+func (o *redfishOption) apply(c *config) {
+	c.redfish = o.redfish
+}
+
+// WithRedfish enables or disables the Redfish API served under /redfish/v1.
+func WithRedfish(redfish bool) Option {
+	return &redfishOption{
+		redfish: redfish,
+	}
+}
+
+type redfishTimeoutOption struct {
+	redfishTimeout time.Duration
+}
+
+func (o *redfishTimeoutOption) apply(c *config) {
+	c.redfishTimeout = o.redfishTimeout
+}
+
+// WithRedfishTimeout sets the timeout for the requests a Redfish operation sends
+// to the backend services.
+func WithRedfishTimeout(redfishTimeout time.Duration) Option {
+	return &redfishTimeoutOption{
+		redfishTimeout: redfishTimeout,
+	}
+}
+
+type redfishEventBucketOption struct {
+	bucket string
+}
+
+func (o *redfishEventBucketOption) apply(c *config) {
+	c.redfishEventBucket = o.bucket
+}
+
+// WithRedfishEventBucket sets the JetStream key-value bucket Redfish event
+// subscriptions are persisted in.
+func WithRedfishEventBucket(bucket string) Option {
+	return &redfishEventBucketOption{
+		bucket: bucket,
+	}
+}
+
+type redfishEventRetryAttemptsOption struct {
+	attempts int
+}
+
+func (o *redfishEventRetryAttemptsOption) apply(c *config) {
+	c.redfishEventRetryAttempts = o.attempts
+}
+
+// WithRedfishEventRetryAttempts sets how often the delivery of a Redfish event
+// to a push destination is retried before the subscription's retry policy
+// applies.
+func WithRedfishEventRetryAttempts(attempts int) Option {
+	return &redfishEventRetryAttemptsOption{
+		attempts: attempts,
+	}
+}
+
+type redfishEventRetryIntervalOption struct {
+	interval time.Duration
+}
+
+func (o *redfishEventRetryIntervalOption) apply(c *config) {
+	c.redfishEventRetryInterval = o.interval
+}
+
+// WithRedfishEventRetryInterval sets the delay before the first retry of a
+// failed Redfish event delivery. The delay doubles with every further retry.
+func WithRedfishEventRetryInterval(interval time.Duration) Option {
+	return &redfishEventRetryIntervalOption{
+		interval: interval,
+	}
+}
+
+type redfishSessionTimeoutOption struct {
+	timeout time.Duration
+}
+
+func (o *redfishSessionTimeoutOption) apply(c *config) {
+	c.redfishSessionTimeout = o.timeout
+}
+
+// WithRedfishSessionTimeout sets the initial idle timeout after which unused
+// Redfish sessions are closed. Clients may change it through the
+// SessionTimeout property of the SessionService.
+func WithRedfishSessionTimeout(timeout time.Duration) Option {
+	return &redfishSessionTimeoutOption{
+		timeout: timeout,
+	}
+}
+
+type redfishUpdateStagingDirOption struct {
+	dir string
+}
+
+func (o *redfishUpdateStagingDirOption) apply(c *config) {
+	c.redfishUpdateStagingDir = o.dir
+}
+
+// WithRedfishUpdateStagingDir sets the directory images pushed to the Redfish
+// UpdateService are stored in before they are handed to the update manager.
+// It must match the staging directory of the update manager.
+func WithRedfishUpdateStagingDir(dir string) Option {
+	return &redfishUpdateStagingDirOption{
+		dir: dir,
+	}
+}
+
+type redfishMaxImageSizeOption struct {
+	size int64
+}
+
+func (o *redfishMaxImageSizeOption) apply(c *config) {
+	c.redfishMaxImageSize = o.size
+}
+
+// WithRedfishMaxImageSize sets the maximum size in bytes of an image pushed to
+// the Redfish UpdateService.
+func WithRedfishMaxImageSize(size int64) Option {
+	return &redfishMaxImageSizeOption{
+		size: size,
+	}
+}
+
+type redfishTelemetryBucketOption struct {
+	bucket string
+}
+
+func (o *redfishTelemetryBucketOption) apply(c *config) {
+	c.redfishTelemetryBucket = o.bucket
+}
+
+// WithRedfishTelemetryBucket sets the JetStream key-value bucket Redfish metric
+// report definitions are persisted in.
+func WithRedfishTelemetryBucket(bucket string) Option {
+	return &redfishTelemetryBucketOption{
+		bucket: bucket,
+	}
+}
+
+type redfishEventLogSubjectsOption struct {
+	subjects []string
+}
+
+func (o *redfishEventLogSubjectsOption) apply(c *config) {
+	c.redfishEventLogSubjects = o.subjects
+}
+
+// WithRedfishEventLogSubjects sets the subjects of the persistent event history
+// the Redfish EventLog LogServices are built from. Each subject must be
+// captured by a JetStream stream.
+func WithRedfishEventLogSubjects(subjects ...string) Option {
+	return &redfishEventLogSubjectsOption{
+		subjects: subjects,
+	}
+}
+
+type redfishAggregationBucketOption struct {
+	bucket string
+}
+
+func (o *redfishAggregationBucketOption) apply(c *config) {
+	c.redfishAggregationBucket = o.bucket
+}
+
+// WithRedfishAggregationBucket sets the JetStream key-value bucket the
+// downstream Redfish services of the AggregationService are persisted in.
+func WithRedfishAggregationBucket(bucket string) Option {
+	return &redfishAggregationBucketOption{
+		bucket: bucket,
+	}
+}
+
+type redfishAggregationIntervalOption struct {
+	interval time.Duration
+}
+
+func (o *redfishAggregationIntervalOption) apply(c *config) {
+	c.redfishAggregationInterval = o.interval
+}
+
+// WithRedfishAggregationInterval sets how often the downstream Redfish services
+// of the AggregationService are crawled for their Systems and Chassis.
+func WithRedfishAggregationInterval(interval time.Duration) Option {
+	return &redfishAggregationIntervalOption{
+		interval: interval,
+	}
+}
+
+type redfishAggregationSkipVerifyOption struct {
+	skipVerify bool
+}
+
+func (o *redfishAggregationSkipVerifyOption) apply(c *config) {
+	c.redfishAggregationSkipVerify = o.skipVerify
+}
+
+// WithRedfishAggregationSkipVerify disables the verification of the TLS
+// certificates of downstream Redfish services, which node BMCs commonly
+// serve self-signed.
+func WithRedfishAggregationSkipVerify(skipVerify bool) Option {
+	return &redfishAggregationSkipVerifyOption{
+		skipVerify: skipVerify,
+	}
+}
+
+type oidcOption struct {
+	config OIDCConfig
+}
+
+func (o *oidcOption) apply(c *config) {
+	cfg := o.config
+	c.oidc = &cfg
+}
+
+// WithOIDC enables single sign-on with an OpenID provider. Web UI users log
+// in through the provider and get a session, and the Connect RPC API
+// accepts bearer tokens issued by the provider. With single sign-on enabled,
+// every Connect RPC request but AuthenticateUser requires authentication.
+func WithOIDC(config OIDCConfig) Option {
+	return &oidcOption{
+		config: config,
+	}
+}
+
 type certConfigOption struct {
 	certConfig *cert.Config
 }
@@ -16,6 +16,7 @@
 //   - Automatic TLS certificate management (self-signed or Let's Encrypt)
 //   - Connect RPC API serving with protocol transcoding
 //   - Optional static web UI file serving
+//   - Optional single sign-on through an OpenID Connect provider
 //   - Automatic HTTP to HTTPS redirection
 //   - OpenTelemetry integration for observability
 //   - Request validation and CORS support
@@ -100,6 +101,282 @@
 //  4. Response unmarshaling and validation
 //  5. OpenTelemetry tracing and logging
 //
+// Every Connect RPC request but AuthenticateUser has to be authenticated,
+// with the first of:
+//
+//   - An Authorization: Bearer header carrying a JWT issued by the OpenID
+//     provider, if single sign-on is enabled
+//   - An X-Auth-Token header carrying the token returned by a successful
+//     AuthenticateUser call or a Redfish session token
+//   - The session cookie of the Web UI
+//
+// AuthenticateUser opens a WebUI session in the store of the Redfish
+// SessionService, so API sessions share its idle timeout. Procedures require
+// the privilege of the matching Redfish operation: Login to read,
+// ConfigureComponents to change components, ConfigureManager for the
+// management controller and asset information, and ConfigureUsers for the
+// user API apart from ChangePassword. EnrollTotp and ConfirmTotp only
+// require ConfigureSelf for the account of the session. Sessions of accounts
+// whose password must be changed may only call ChangePassword, and sessions of
+// accounts that have to enroll a second factor may additionally only enroll
+// their own.
+//
+// The REST mapping of the API, which the Connect RPC handlers serve through
+// the google.api.http annotations of the BMCService, is described by an
+// OpenAPI 3 document at /api/v1alpha1/openapi.json. It is derived from the
+// registered service descriptors on start, so it always matches the served
+// API and can be fed to client generators:
+//
+//	curl -k https://bmc/api/v1alpha1/openapi.json -o u-bmc.json
+//
+// # Redfish
+//
+// Unless disabled with WithRedfish(false), the service also serves a Redfish v1
+// API below /redfish/v1. The ServiceRoot links the Systems, Chassis and Managers
+// collections, whose members map onto the hosts, chassis and management
+// controllers known to statemgr:
+//
+//   - /redfish/v1/Systems/{id} is served from the host state subjects
+//   - /redfish/v1/Chassis/{id} is served from the chassis state subjects
+//   - /redfish/v1/Managers/{id} is served from the BMC state subjects
+//   - /redfish/v1/Chassis/{id}/Sensors/{id} is served from sensormon
+//   - /redfish/v1/Chassis/{id}/ThermalSubsystem and PowerSubsystem are served
+//     from the cooling devices of thermalmgr, the sensors of sensormon and the
+//     power supplies of the chassis
+//   - /redfish/v1/Managers/{id}/VirtualMedia is served from kvmsrv
+//   - /redfish/v1/Managers/{id}/LogServices and /redfish/v1/Systems/{id}/LogServices
+//     are served from the event history in JetStream and from selmgr
+//   - /redfish/v1/TelemetryService is served from the readings of sensormon
+//   - /redfish/v1/AggregationService fronts the Systems and Chassis of
+//     downstream Redfish services
+//
+// The CSDL metadata document at /redfish/v1/$metadata references the DMTF
+// schema of every resource type the service emits, and /redfish/v1/odata
+// holds the OData service document. Both are served without authentication,
+// as required by the Redfish Service Validator.
+//
+// Every resource carries @odata.id, @odata.type and a weak @odata.etag that is
+// also returned in the ETag header, so clients can use If-None-Match to poll
+// cheaply. The ComputerSystem.Reset, Chassis.Reset and Manager.Reset actions
+// send the same state change requests as the Connect RPC API. Errors are
+// reported as Redfish error responses using Base registry messages.
+//
+// ## Events
+//
+// The EventService turns state transitions (state.event), sensor alerts
+// (alert.event) and sensor threshold violations into Redfish events whose
+// MessageIds refer to the built-in UBMC message registry served below
+// /redfish/v1/Registries. Events are delivered in two ways:
+//
+//   - Push subscriptions created at /redfish/v1/EventService/Subscriptions
+//     receive events as HTTP POST requests. Failed deliveries are retried with
+//     exponential backoff, after which the DeliveryRetryPolicy of the
+//     subscription decides whether it is deleted, suspended or retried further.
+//     Subscriptions are persisted in a JetStream key-value bucket.
+//   - /redfish/v1/EventService/SSE streams events as Server-Sent Events,
+//     optionally narrowed with a $filter such as "MessageId eq 'UBMC.1.0.TestEvent'".
+//
+// Delivery is tuned with WithRedfishEventRetryAttempts and
+// WithRedfishEventRetryInterval, the bucket is set with WithRedfishEventBucket.
+//
+// ## Logs
+//
+// The first manager provides an EventLog LogService holding the latest events
+// of the persistent event history, which is replayed from JetStream on start
+// and followed afterwards. By default these are the state transitions
+// statemgr publishes on statemgr.event.> and the sensor alerts published on
+// system.event.>, the subjects are set with WithRedfishEventLogSubjects. The
+// EventLog of a system only holds the events originating from it. Entries
+// are identified by the stream and sequence they were stored at, so their
+// IDs remain stable across restarts.
+//
+// Each system additionally provides a SEL LogService holding the IPMI system
+// event log of selmgr. Its entries refer to the ResourceEvent registry, with
+// threshold violations reported as ResourceErrorThresholdExceeded or
+// ResourceWarningThresholdExceeded, and carry the SensorType and EntryCode
+// of the IPMI record. The ClearLog action clears the system event log.
+//
+// The Base, ResourceEvent and TaskEvent registries the messages of the
+// service refer to are served below /redfish/v1/Registries alongside the
+// UBMC registry.
+//
+// ## Sessions and Accounts
+//
+// Apart from the service root, every Redfish resource requires authentication.
+// Clients either send HTTP Basic credentials with each request or create a
+// session with POST /redfish/v1/SessionService/Sessions and pass the returned
+// X-Auth-Token header on subsequent requests. Sessions are closed with DELETE
+// on the session resource or after being idle for the SessionTimeout of the
+// SessionService, initially set with WithRedfishSessionTimeout.
+//
+// Credentials are checked by usermgr, and the AccountService manages the same
+// accounts as the Connect RPC user API. The RoleId of an account is kept in
+// its Redfish account information and selects one of the predefined
+// Administrator, Operator and ReadOnly roles, whose privileges decide which
+// operations a session may perform. The AccountLockoutThreshold,
+// AccountLockoutDuration and AccountLockoutCounterResetAfter properties are
+// stored as the lockout policy of every account. Accounts flagged with
+// PasswordChangeRequired may only change their password until they do.
+// Passwords set by PATCH must meet the usermgr password policy, and users
+// changing their own may not reuse a recent one.
+//
+// Accounts with a TOTP second factor, enrolled through the EnrollTotp and
+// ConfirmTotp RPCs, pass the current code or a recovery code in the Token
+// property of the session creation request. They cannot use HTTP Basic
+// credentials, and neither can accounts required to use a second factor.
+// Sessions of required accounts that have not enrolled yet may only read
+// their account and session, and enroll through the API with their session
+// token until ConfirmTotp succeeds.
+//
+// ## Firmware Updates
+//
+// The UpdateService accepts images pushed as multipart/form-data to its
+// MultipartHttpPushUri, with an UpdateFile part holding the image, an
+// OemUpdateSignature part holding its detached signature and an optional
+// UpdateParameters part holding Targets and @Redfish.OperationApplyTime:
+//
+//	curl -k -u admin -F 'UpdateParameters={"Targets":[]};type=application/json' \
+//		-F UpdateFile=@image.bin -F OemUpdateSignature=@image.bin.sig \
+//		https://bmc/redfish/v1/UpdateService/update-multipart
+//
+// The signature is stored next to the image for the installer of updatemgr
+// to verify.
+//
+// Pushed images are stored in the staging directory set with
+// WithRedfishUpdateStagingDir, which must match the one of updatemgr, and
+// are limited to WithRedfishMaxImageSize bytes. The SimpleUpdate action lets
+// updatemgr download the image from an HTTP or HTTPS ImageURI instead, and
+// its signature from the same URI with .sig appended.
+//
+// Both start an updatemgr job and answer with 202 Accepted, a Task in the
+// body and its task monitor in the Location header. The task monitor answers
+// with 202 Accepted until the job ends and with the final Task afterwards.
+// The TaskService lists the tasks of all retained jobs.
+//
+// The FirmwareInventory holds one updateable member per BMC and host, named
+// after it, built from its Firmware, and a read-only member per firmware
+// component named <firmware>.<component>. Targets reference these members,
+// or the Managers and Systems they belong to.
+//
+// ## Thermal and Power
+//
+// Each chassis links a ThermalSubsystem holding its Fans and ThermalMetrics, a
+// PowerSubsystem holding its PowerSupplies, and EnvironmentMetrics, as well as
+// the deprecated Thermal and Power resources for older clients. Sensors and
+// cooling devices without a chassis location belong to the first chassis,
+// which also reports the power consumption and limit managed by powermgr.
+//
+// Fans are switched between automatic and manual control through their Oem
+// properties, which are forwarded to thermalmgr. A fan under manual control
+// keeps its speed until it is returned to automatic control:
+//
+//	curl -k -u admin -X PATCH -H 'Content-Type: application/json' \
+//		-d '{"Oem":{"UBMC":{"ControlMode":"Manual","SpeedControlPercent":60}}}' \
+//		https://bmc/redfish/v1/Chassis/chassis.0/ThermalSubsystem/Fans/fan0_pwm1
+//
+// The power limit is set with the PowerLimitWatts SetPoint of the
+// EnvironmentMetrics, enabled with its ControlMode Automatic and lifted with
+// Disabled, or with the PowerLimit of the deprecated PowerControl.
+//
+// ## Virtual Media
+//
+// The first manager, which is the BMC itself, provides the VirtualMedia
+// resources CD1 and USB1. Both are backed by the USB mass storage function of
+// kvmsrv, so only one of them holds media at a time. CD1 presents its image as
+// read-only CD-ROM, USB1 as removable disk that is write protected unless
+// WriteProtected is false.
+//
+// The InsertMedia action takes an HTTP or HTTPS URL, which kvmsrv downloads
+// to its media directory before presenting the image, or the path of an image
+// already in that directory:
+//
+//	curl -k -u admin -H 'Content-Type: application/json' \
+//		-d '{"Image":"https://images.example.com/installer.iso"}' \
+//		https://bmc/redfish/v1/Managers/bmc.0/VirtualMedia/CD1/Actions/VirtualMedia.InsertMedia
+//
+// While the image downloads the resource reports Status.State Starting, and
+// Inserted once the host sees the media. EjectMedia detaches the image and
+// removes it again if it was downloaded.
+//
+// ## Telemetry
+//
+// The TelemetryService offers the sensor readings of sensormon as metrics.
+// Every reading type of the present sensors has a MetricDefinition, such as
+// Temperature or Voltage, whose MetricProperties are the Reading properties of
+// the matching Sensor resources.
+//
+// MetricReportDefinitions select metrics by MetricId, by MetricProperties or
+// both, and select every metric if they have no Metrics. Their
+// MetricReportDefinitionType decides when the MetricReport is generated:
+//
+//   - Periodic definitions report every RecurrenceInterval of their Schedule
+//   - OnChange definitions sample every MinCollectionInterval and report the
+//     metrics whose values changed
+//   - OnRequest definitions report whenever their MetricReport is read
+//
+// With the LogToMetricReportsCollection action the latest report is kept in
+// the MetricReports collection, replacing or extended by the previous one
+// according to ReportUpdates. With the RedfishEvent action reports are sent to
+// the event subscriptions with EventFormatType MetricReport, which may be
+// narrowed to some definitions with their MetricReportDefinitions property:
+//
+//	curl -k -u admin -H 'Content-Type: application/json' \
+//		-d '{"Id":"Thermal","MetricReportDefinitionType":"Periodic","Schedule":{"RecurrenceInterval":"PT1M"},"ReportActions":["RedfishEvent"],"Metrics":[{"MetricId":"Temperature"}]}' \
+//		https://bmc/redfish/v1/TelemetryService/MetricReportDefinitions
+//
+// Definitions are persisted in a JetStream key-value bucket set with
+// WithRedfishTelemetryBucket, while reports only live in memory.
+//
+// ## Aggregation
+//
+// A u-bmc acting as chassis manager can front the BMCs of its nodes. Every
+// downstream Redfish service is registered as an AggregationSource with its
+// HostName and the credentials used to access it:
+//
+//	curl -k -u admin -H 'Content-Type: application/json' \
+//		-d '{"HostName":"https://node1.bmc","UserName":"admin","Password":"secret"}' \
+//		https://bmc/redfish/v1/AggregationService/AggregationSources
+//
+// The source is assigned a random ID such as 5B247A and crawled every
+// WithRedfishAggregationInterval. The members of its Systems and Chassis
+// collections are added to the local collections with the ID of the source
+// and an underscore prepended, so /redfish/v1/Systems/5B247A_1 is the system
+// 1 of the source. Requests for these resources and everything below them,
+// including actions, are forwarded to the source with the credentials of the
+// source, and the links to its Systems and Chassis in the responses are
+// rewritten accordingly. The Links.ResourcesAccessed property of the source
+// lists the aggregated resources and its Status.Health reports whether the
+// last crawl succeeded.
+//
+// Sources, including their passwords, are persisted in a JetStream key-value
+// bucket set with WithRedfishAggregationBucket. Node BMCs serving self-signed
+// certificates require WithRedfishAggregationSkipVerify(true).
+//
+// ## Query Parameters
+//
+// GET requests support the query parameters advertised in the
+// ProtocolFeaturesSupported property of the ServiceRoot:
+//
+//   - $expand=*, . or ~ expands all references, those outside of Links or
+//     those within Links, optionally down to ($levels=n) levels
+//   - $select=A,B/C returns only the listed properties, with nested
+//     properties separated by slashes
+//   - $filter keeps the collection members matching an expression of the
+//     DSP0266 grammar, such as "Reading gt 40 and not (Status/Health eq 'OK')"
+//   - $top and $skip page through collections, adding a
+//     Members@odata.nextLink while more members remain
+//
+// Query parameters are applied to the response of the resource handler,
+// which fetches expanded resources through internal requests on behalf of
+// the same session. Handlers of large collections, such as the sensors of a
+// chassis, embed their members from a single backend request instead and
+// translate $select into the field mask of the request, so that a complete
+// sensor inventory takes one round trip:
+//
+//	curl -k -u admin 'https://bmc/redfish/v1/Chassis/chassis.0/Sensors?$expand=.&$select=Reading,ReadingUnits'
+//
+// Other parameters starting with $ are rejected with 501 Not Implemented.
+//
 // # Service Integration
 //
 // The websrv service integrates with other BMC services via NATS messaging:
@@ -156,6 +433,55 @@
 //   - API requests (Content-Type: application/*) → Connect RPC handlers
 //   - Browser requests (HTML, CSS, JS) → Static file server
 //
+// # Single Sign-On
+//
+// WithOIDC lets operators log into the Web UI through an OpenID provider,
+// such as a corporate identity provider. The web server is a relying party
+// using the authorization code flow with PKCE. It finds the provider
+// endpoints in the discovery document below the issuer URL and caches the
+// key set of the provider for KeySetTTL:
+//
+//   - GET /api/auth/oidc/login?redirect=/path sends the browser to the
+//     provider, remembering the page to return to
+//   - GET /api/auth/oidc/callback redeems the authorization code, verifies
+//     the ID token and opens a WebUI session, whose token is kept in the
+//     HttpOnly ubmc_session cookie
+//   - GET /api/auth/validate returns the username and role of the session
+//     cookie, or 401 without a valid session
+//   - POST /api/auth/logout closes the session of the cookie
+//
+// The username is taken from UsernameClaim, falling back to the subject.
+// The values of RoleClaim are mapped to the predefined Redfish roles by
+// ClaimRoles, the first matching mapping winning. Users without a matching
+// value get DefaultRole, or are refused if it is empty. Single sign-on
+// sessions are no usermgr accounts, but share the session store and idle
+// timeout of the Redfish SessionService, which lists them with the WebUI
+// SessionType. They own no account, not even one of the same username, so
+// they can neither read nor change an account without ConfigureUsers.
+//
+// With single sign-on enabled, the Connect RPC API also accepts an
+// Authorization: Bearer header carrying a JWT issued by the provider for one
+// of Audiences, mapped to a role like an ID token.
+//
+//	ws := websrv.New(
+//		websrv.WithOIDC(websrv.OIDCConfig{
+//			Issuer:       "https://idp.example.com/realms/ops",
+//			ClientID:     "bmc",
+//			ClientSecret: secret,
+//			RedirectURL:  "https://bmc.example.com/api/auth/oidc/callback",
+//			RoleClaim:    "groups",
+//			ClaimRoles: []websrv.OIDCClaimRole{
+//				{Value: "bmc-admins", Role: "Administrator"},
+//				{Value: "bmc-operators", Role: "Operator"},
+//			},
+//			DefaultRole: "ReadOnly",
+//		}),
+//	)
+//
+// For development, an httptest.Server serving a discovery document, a key
+// set and a token endpoint can stand in for the provider, passing its
+// Client() as HTTPClient.
+//
 // # Security Features
 //
 // ## Transport Security
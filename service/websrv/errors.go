@@ -37,4 +37,50 @@ var (
 	ErrCreateOpenTelemetryInterceptor = errors.New("failed to create OpenTelemetry interceptor")
 	// ErrCreateTranscoder indicates a failure to create the protocol transcoder for gRPC/Connect services.
 	ErrCreateTranscoder = errors.New("failed to create transcoder")
+	// ErrCreateOpenAPIDocument indicates a failure to create the OpenAPI document of the Connect RPC services.
+	ErrCreateOpenAPIDocument = errors.New("failed to create OpenAPI document")
+	// ErrRequestFailed indicates a request to a backend service failed.
+	ErrRequestFailed = errors.New("service request failed")
+	// ErrNotFound indicates the backend service does not know the requested item.
+	ErrNotFound = errors.New("not found")
+	// ErrInvalidArgument indicates the backend service rejected the request arguments.
+	ErrInvalidArgument = errors.New("invalid argument")
+	// ErrConflict indicates the backend service cannot process the request in its current state.
+	ErrConflict = errors.New("conflict")
+	// ErrEventSourceFailed indicates a failure to subscribe to a source of Redfish events.
+	ErrEventSourceFailed = errors.New("failed to subscribe to event source")
+	// ErrEventDeliveryFailed indicates a Redfish event could not be delivered to a subscription.
+	ErrEventDeliveryFailed = errors.New("event delivery failed")
+	// ErrSubscriptionStoreFailed indicates a failure to persist a Redfish event subscription.
+	ErrSubscriptionStoreFailed = errors.New("failed to store event subscription")
+	// ErrTooManySubscriptions indicates the maximum number of Redfish event subscriptions is reached.
+	ErrTooManySubscriptions = errors.New("too many event subscriptions")
+	// ErrInvalidEventFilter indicates a malformed Server-Sent Events filter.
+	ErrInvalidEventFilter = errors.New("invalid event filter")
+	// ErrAuthenticationFailed indicates that Redfish credentials were rejected.
+	ErrAuthenticationFailed = errors.New("authentication failed")
+	// ErrSecondFactorRequired indicates that a login lacks the second factor the account requires.
+	ErrSecondFactorRequired = errors.New("second factor required")
+	// ErrTooManySessions indicates the maximum number of Redfish sessions is reached.
+	ErrTooManySessions = errors.New("too many sessions")
+	// ErrImageTooLarge indicates an update image pushed to the UpdateService exceeds the maximum image size.
+	ErrImageTooLarge = errors.New("update image too large")
+	// ErrDefinitionStoreFailed indicates a failure to persist a Redfish metric report definition.
+	ErrDefinitionStoreFailed = errors.New("failed to store metric report definition")
+	// ErrTooManyDefinitions indicates the maximum number of Redfish metric report definitions is reached.
+	ErrTooManyDefinitions = errors.New("too many metric report definitions")
+	// ErrDefinitionExists indicates a Redfish metric report definition with the requested ID already exists.
+	ErrDefinitionExists = errors.New("metric report definition already exists")
+	// ErrEventLogFailed indicates a failure to follow the event history the Redfish event log is built from.
+	ErrEventLogFailed = errors.New("failed to follow event history")
+	// ErrAggregationStoreFailed indicates a failure to persist a Redfish aggregation source.
+	ErrAggregationStoreFailed = errors.New("failed to store aggregation source")
+	// ErrTooManyAggregationSources indicates the maximum number of Redfish aggregation sources is reached.
+	ErrTooManyAggregationSources = errors.New("too many aggregation sources")
+	// ErrAggregationRequestFailed indicates a request to a downstream Redfish service failed.
+	ErrAggregationRequestFailed = errors.New("downstream Redfish request failed")
+	// ErrInvalidOIDCConfig indicates an incomplete or inconsistent single sign-on configuration.
+	ErrInvalidOIDCConfig = errors.New("invalid single sign-on configuration")
+	// ErrSingleSignOnFailed indicates that a login through the OpenID provider was not completed.
+	ErrSingleSignOnFailed = errors.New("single sign-on failed")
 )
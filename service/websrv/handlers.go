@@ -17,22 +17,26 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // ProtoServer implements all the Connect RPC service handlers for the BMC API.
 type ProtoServer struct {
 	schemav1alpha1connect.UnimplementedBMCServiceHandler
-	nc     *nats.Conn
-	logger *slog.Logger
-	tracer trace.Tracer
+	nc       *nats.Conn
+	logger   *slog.Logger
+	tracer   trace.Tracer
+	sessions *sessionStore
 }
 
-// NewProtoServer creates a new ProtoServer instance.
-func NewProtoServer(nc *nats.Conn, logger *slog.Logger) *ProtoServer {
+// NewProtoServer creates a new ProtoServer instance. Successful calls of
+// AuthenticateUser open a session in sessions.
+func NewProtoServer(nc *nats.Conn, logger *slog.Logger, sessions *sessionStore) *ProtoServer {
 	return &ProtoServer{
-		nc:     nc,
-		logger: logger,
-		tracer: otel.Tracer("websrv"),
+		nc:       nc,
+		logger:   logger,
+		tracer:   otel.Tracer("websrv"),
+		sessions: sessions,
 	}
 }
 
@@ -486,6 +490,54 @@ func (s *ProtoServer) GetSensor(ctx context.Context, req *connect.Request[schema
 	return connect.NewResponse(&sensorResp), nil
 }
 
+// ListSystemEventLogEntries handles the ListSystemEventLogEntries RPC call.
+func (s *ProtoServer) ListSystemEventLogEntries(ctx context.Context, req *connect.Request[schemav1alpha1.ListSystemEventLogEntriesRequest]) (*connect.Response[schemav1alpha1.ListSystemEventLogEntriesResponse], error) {
+	ctx, span := s.tracer.Start(ctx, "ProtoServer.ListSystemEventLogEntries")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("rpc.service", "BMCService"),
+		attribute.String("rpc.method", "ListSystemEventLogEntries"),
+	)
+
+	s.logger.DebugContext(ctx, "Processing ListSystemEventLogEntries request")
+
+	var selResp schemav1alpha1.ListSystemEventLogEntriesResponse
+	if err := s.requestNATS(ctx, ipc.SubjectSELList, req.Msg, &selResp); err != nil {
+		span.RecordError(err)
+		s.logger.ErrorContext(ctx, "Failed to process ListSystemEventLogEntries request", "error", err)
+		return nil, err
+	}
+
+	s.logger.DebugContext(ctx, "Successfully processed ListSystemEventLogEntries request",
+		slog.Int("entries", len(selResp.GetEntries())))
+	return connect.NewResponse(&selResp), nil
+}
+
+// ClearSystemEventLog handles the ClearSystemEventLog RPC call.
+func (s *ProtoServer) ClearSystemEventLog(ctx context.Context, req *connect.Request[schemav1alpha1.ClearSystemEventLogRequest]) (*connect.Response[schemav1alpha1.ClearSystemEventLogResponse], error) {
+	ctx, span := s.tracer.Start(ctx, "ProtoServer.ClearSystemEventLog")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("rpc.service", "BMCService"),
+		attribute.String("rpc.method", "ClearSystemEventLog"),
+	)
+
+	s.logger.DebugContext(ctx, "Processing ClearSystemEventLog request")
+
+	var selResp schemav1alpha1.ClearSystemEventLogResponse
+	if err := s.requestNATS(ctx, ipc.SubjectSELClear, req.Msg, &selResp); err != nil {
+		span.RecordError(err)
+		s.logger.ErrorContext(ctx, "Failed to process ClearSystemEventLog request", "error", err)
+		return nil, err
+	}
+
+	s.logger.InfoContext(ctx, "Cleared system event log",
+		slog.Uint64("cleared_entries", uint64(selResp.GetClearedEntries())))
+	return connect.NewResponse(&selResp), nil
+}
+
 // GetThermalZone handles the GetThermalZone RPC call.
 func (s *ProtoServer) GetThermalZone(ctx context.Context, req *connect.Request[schemav1alpha1.GetThermalZoneRequest]) (*connect.Response[schemav1alpha1.GetThermalZoneResponse], error) {
 	ctx, span := s.tracer.Start(ctx, "ProtoServer.GetThermalZone")
@@ -709,6 +761,11 @@ func (s *ProtoServer) ChangePassword(ctx context.Context, req *connect.Request[s
 		return nil, err
 	}
 
+	// Lift the password change requirement from the sessions of the account
+	if sess, ok := ctx.Value(sessionContextKey{}).(*redfishSession); ok && userResp.GetSuccess() && sess.userID == req.Msg.GetId() {
+		s.sessions.passwordChanged(sess.owner())
+	}
+
 	s.logger.DebugContext(ctx, "Successfully processed ChangePassword request",
 		slog.String("user_id", req.Msg.GetId()))
 	return connect.NewResponse(&userResp), nil
@@ -754,18 +811,179 @@ func (s *ProtoServer) AuthenticateUser(ctx context.Context, req *connect.Request
 	s.logger.DebugContext(ctx, "Processing AuthenticateUser request",
 		slog.String("user_name", req.Msg.GetUsername()))
 
+	// The client address is taken from the connection rather than the
+	// request, and sessions of accounts whose password must be changed may
+	// only change the password.
+	clientAddr := clientAddress(req.Peer().Addr)
+	userAgent := req.Header().Get("User-Agent")
+	authReq := req.Msg.CloneVT()
+	authReq.SourceIp = &clientAddr
+	authReq.UserAgent = &userAgent
+	authReq.PasswordChangeSupported = true
+
 	var userResp schemav1alpha1.AuthenticateUserResponse
-	if err := s.requestNATS(ctx, ipc.SubjectUserAuthenticate, req.Msg, &userResp); err != nil {
+	if err := s.requestNATS(ctx, ipc.SubjectUserAuthenticate, authReq, &userResp); err != nil {
 		span.RecordError(err)
 		s.logger.ErrorContext(ctx, "Failed to process AuthenticateUser request", "error", err)
 		return nil, err
 	}
 
+	if userResp.GetSuccess() {
+		if err := s.openSession(ctx, &userResp, req.Msg.GetUsername(), clientAddr); err != nil {
+			span.RecordError(err)
+			s.logger.ErrorContext(ctx, "Failed to open API session", "user", req.Msg.GetUsername(), "error", err)
+			return nil, err
+		}
+	}
+
 	s.logger.DebugContext(ctx, "Successfully processed AuthenticateUser request",
 		slog.String("user_name", req.Msg.GetUsername()))
 	return connect.NewResponse(&userResp), nil
 }
 
+// openSession stores a session for the user authenticated by resp and
+// returns its token in resp. The token authenticates API requests in the
+// X-Auth-Token header and Redfish requests alike.
+func (s *ProtoServer) openSession(ctx context.Context, resp *schemav1alpha1.AuthenticateUserResponse, username, clientAddr string) error {
+	var userResp schemav1alpha1.GetUserResponse
+	if err := s.requestNATS(ctx, ipc.SubjectUserInfo, &schemav1alpha1.GetUserRequest{
+		Identifier: &schemav1alpha1.GetUserRequest_Username{Username: username},
+	}, &userResp); err != nil {
+		return err
+	}
+	user := userResp.GetUser()
+	if user == nil {
+		return connect.NewError(connect.CodeNotFound, fmt.Errorf("%w: user %s", ErrNotFound, username))
+	}
+
+	sess := &redfishSession{
+		origin:                         originAccount,
+		userID:                         user.GetId(),
+		username:                       user.GetUsername(),
+		role:                           accountRole(user),
+		clientAddr:                     clientAddr,
+		sessionType:                    sessionTypeWebUI,
+		passwordChangeRequired:         resp.GetPasswordChangeRequired(),
+		secondFactorEnrollmentRequired: resp.GetSecondFactorEnrollmentRequired(),
+	}
+	if err := s.sessions.add(sess); err != nil {
+		return connect.NewError(connect.CodeResourceExhausted, err)
+	}
+
+	resp.Token = &sess.token
+	resp.TokenExpiresAt = timestamppb.New(sess.created.Add(s.sessions.idleTimeout()))
+	return nil
+}
+
+// EnrollTotp handles the EnrollTotp RPC call.
+func (s *ProtoServer) EnrollTotp(ctx context.Context, req *connect.Request[schemav1alpha1.EnrollTotpRequest]) (*connect.Response[schemav1alpha1.EnrollTotpResponse], error) {
+	ctx, span := s.tracer.Start(ctx, "ProtoServer.EnrollTotp")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("rpc.service", "BMCService"),
+		attribute.String("rpc.method", "EnrollTotp"),
+		attribute.String("user.name", req.Msg.GetId()),
+	)
+
+	s.logger.DebugContext(ctx, "Processing EnrollTotp request",
+		slog.String("user_id", req.Msg.GetId()))
+
+	var userResp schemav1alpha1.EnrollTotpResponse
+	if err := s.requestNATS(ctx, ipc.SubjectUserTOTPEnroll, req.Msg, &userResp); err != nil {
+		span.RecordError(err)
+		s.logger.ErrorContext(ctx, "Failed to process EnrollTotp request", "error", err)
+		return nil, err
+	}
+
+	s.logger.DebugContext(ctx, "Successfully processed EnrollTotp request",
+		slog.String("user_id", req.Msg.GetId()))
+	return connect.NewResponse(&userResp), nil
+}
+
+// ConfirmTotp handles the ConfirmTotp RPC call.
+func (s *ProtoServer) ConfirmTotp(ctx context.Context, req *connect.Request[schemav1alpha1.ConfirmTotpRequest]) (*connect.Response[schemav1alpha1.ConfirmTotpResponse], error) {
+	ctx, span := s.tracer.Start(ctx, "ProtoServer.ConfirmTotp")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("rpc.service", "BMCService"),
+		attribute.String("rpc.method", "ConfirmTotp"),
+		attribute.String("user.name", req.Msg.GetId()),
+	)
+
+	s.logger.DebugContext(ctx, "Processing ConfirmTotp request",
+		slog.String("user_id", req.Msg.GetId()))
+
+	var userResp schemav1alpha1.ConfirmTotpResponse
+	if err := s.requestNATS(ctx, ipc.SubjectUserTOTPConfirm, req.Msg, &userResp); err != nil {
+		span.RecordError(err)
+		s.logger.ErrorContext(ctx, "Failed to process ConfirmTotp request", "error", err)
+		return nil, err
+	}
+
+	// Lift the enrollment requirement from the sessions of the account
+	if sess, ok := ctx.Value(sessionContextKey{}).(*redfishSession); ok && userResp.GetSuccess() && sess.userID == req.Msg.GetId() {
+		s.sessions.secondFactorEnrolled(sess.owner())
+	}
+
+	s.logger.DebugContext(ctx, "Successfully processed ConfirmTotp request",
+		slog.String("user_id", req.Msg.GetId()))
+	return connect.NewResponse(&userResp), nil
+}
+
+// DisableTotp handles the DisableTotp RPC call.
+func (s *ProtoServer) DisableTotp(ctx context.Context, req *connect.Request[schemav1alpha1.DisableTotpRequest]) (*connect.Response[schemav1alpha1.DisableTotpResponse], error) {
+	ctx, span := s.tracer.Start(ctx, "ProtoServer.DisableTotp")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("rpc.service", "BMCService"),
+		attribute.String("rpc.method", "DisableTotp"),
+		attribute.String("user.name", req.Msg.GetId()),
+	)
+
+	s.logger.DebugContext(ctx, "Processing DisableTotp request",
+		slog.String("user_id", req.Msg.GetId()))
+
+	var userResp schemav1alpha1.DisableTotpResponse
+	if err := s.requestNATS(ctx, ipc.SubjectUserTOTPDisable, req.Msg, &userResp); err != nil {
+		span.RecordError(err)
+		s.logger.ErrorContext(ctx, "Failed to process DisableTotp request", "error", err)
+		return nil, err
+	}
+
+	s.logger.DebugContext(ctx, "Successfully processed DisableTotp request",
+		slog.String("user_id", req.Msg.GetId()))
+	return connect.NewResponse(&userResp), nil
+}
+
+// RegenerateRecoveryCodes handles the RegenerateRecoveryCodes RPC call.
+func (s *ProtoServer) RegenerateRecoveryCodes(ctx context.Context, req *connect.Request[schemav1alpha1.RegenerateRecoveryCodesRequest]) (*connect.Response[schemav1alpha1.RegenerateRecoveryCodesResponse], error) {
+	ctx, span := s.tracer.Start(ctx, "ProtoServer.RegenerateRecoveryCodes")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("rpc.service", "BMCService"),
+		attribute.String("rpc.method", "RegenerateRecoveryCodes"),
+		attribute.String("user.name", req.Msg.GetId()),
+	)
+
+	s.logger.DebugContext(ctx, "Processing RegenerateRecoveryCodes request",
+		slog.String("user_id", req.Msg.GetId()))
+
+	var userResp schemav1alpha1.RegenerateRecoveryCodesResponse
+	if err := s.requestNATS(ctx, ipc.SubjectUserTOTPRecoveryCodes, req.Msg, &userResp); err != nil {
+		span.RecordError(err)
+		s.logger.ErrorContext(ctx, "Failed to process RegenerateRecoveryCodes request", "error", err)
+		return nil, err
+	}
+
+	s.logger.DebugContext(ctx, "Successfully processed RegenerateRecoveryCodes request",
+		slog.String("user_id", req.Msg.GetId()))
+	return connect.NewResponse(&userResp), nil
+}
+
 type vtMessage interface {
 	MarshalVT() ([]byte, error)
 }
@@ -0,0 +1,400 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package websrv
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/u-bmc/u-bmc/pkg/oidc"
+)
+
+// Single sign-on constants.
+const (
+	oidcLoginPath    = "/api/auth/oidc/login"
+	oidcCallbackPath = "/api/auth/oidc/callback"
+	authValidatePath = "/api/auth/validate"
+	authLogoutPath   = "/api/auth/logout"
+
+	sessionCookieName   = "ubmc_session"
+	oidcStateCookieName = "ubmc_oidc_state"
+
+	defaultOIDCUsernameClaim = "preferred_username"
+	defaultOIDCRoleClaim     = "groups"
+	defaultOIDCHTTPTimeout   = 10 * time.Second
+
+	// oidcLoginTimeout is how long a user may take to log in at the provider.
+	oidcLoginTimeout = 10 * time.Minute
+	// maxPendingLogins bounds the logins waiting for their callback.
+	maxPendingLogins = 256
+)
+
+// pendingLogin is a login sent to the provider, waiting for its callback.
+type pendingLogin struct {
+	verifier string
+	nonce    string
+	redirect string
+	expires  time.Time
+}
+
+// oidcServer is the OpenID Connect relying party of the Web UI. Successful
+// logins get a stored session of the WebUI type, identified by an HttpOnly
+// cookie.
+type oidcServer struct {
+	logger    *slog.Logger
+	config    OIDCConfig
+	provider  *oidc.Provider
+	client    *oidc.Client
+	sessions  *sessionStore
+	audiences []string
+
+	mu      sync.Mutex
+	pending map[string]pendingLogin
+}
+
+// newOIDCServer creates the relying party configured by cfg, keeping the
+// sessions of logged in users in sessions.
+func newOIDCServer(logger *slog.Logger, cfg *OIDCConfig, sessions *sessionStore) (*oidcServer, error) {
+	c := *cfg
+	switch {
+	case c.Issuer == "":
+		return nil, fmt.Errorf("%w: issuer is required", ErrInvalidOIDCConfig)
+	case c.ClientID == "":
+		return nil, fmt.Errorf("%w: client ID is required", ErrInvalidOIDCConfig)
+	case c.RedirectURL == "":
+		return nil, fmt.Errorf("%w: redirect URL is required", ErrInvalidOIDCConfig)
+	case len(c.ClaimRoles) == 0 && c.DefaultRole == "":
+		return nil, fmt.Errorf("%w: claim roles or a default role are required", ErrInvalidOIDCConfig)
+	case c.DefaultRole != "" && !slices.Contains(roleIDs(), c.DefaultRole):
+		return nil, fmt.Errorf("%w: unknown default role %q", ErrInvalidOIDCConfig, c.DefaultRole)
+	}
+	for _, mapping := range c.ClaimRoles {
+		if mapping.Value == "" || !slices.Contains(roleIDs(), mapping.Role) {
+			return nil, fmt.Errorf("%w: invalid claim role mapping %q to %q", ErrInvalidOIDCConfig, mapping.Value, mapping.Role)
+		}
+	}
+
+	if c.UsernameClaim == "" {
+		c.UsernameClaim = defaultOIDCUsernameClaim
+	}
+	if c.RoleClaim == "" {
+		c.RoleClaim = defaultOIDCRoleClaim
+	}
+	if c.HTTPClient == nil {
+		c.HTTPClient = &http.Client{Timeout: defaultOIDCHTTPTimeout}
+	}
+	audiences := c.Audiences
+	if len(audiences) == 0 {
+		audiences = []string{c.ClientID}
+	}
+
+	provider := oidc.NewProvider(c.Issuer, c.HTTPClient, c.KeySetTTL)
+	return &oidcServer{
+		logger:   logger,
+		config:   c,
+		provider: provider,
+		client: &oidc.Client{
+			Provider:     provider,
+			ClientID:     c.ClientID,
+			ClientSecret: c.ClientSecret,
+			RedirectURL:  c.RedirectURL,
+			Scopes:       c.Scopes,
+		},
+		sessions:  sessions,
+		audiences: audiences,
+		pending:   make(map[string]pendingLogin),
+	}, nil
+}
+
+// register mounts the login and session endpoints of the Web UI on mux.
+func (o *oidcServer) register(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+oidcLoginPath, o.handleLogin)
+	mux.HandleFunc("GET "+oidcCallbackPath, o.handleCallback)
+	mux.HandleFunc("GET "+authValidatePath, o.handleValidate)
+	mux.HandleFunc("POST "+authLogoutPath, o.handleLogout)
+}
+
+// start fetches the discovery document of the provider in the background,
+// so that a misconfigured provider shows up in the log right away.
+func (o *oidcServer) start(ctx context.Context) {
+	go func() {
+		if _, err := o.provider.Metadata(ctx); err != nil {
+			o.logger.WarnContext(ctx, "OpenID provider unavailable", "issuer", o.provider.Issuer(), "error", err)
+		}
+	}()
+}
+
+// identity returns the username and role of the user described by claims.
+func (o *oidcServer) identity(claims oidc.Claims) (string, string, error) {
+	username := claims.String(o.config.UsernameClaim)
+	if username == "" {
+		username = claims.Subject()
+	}
+	if username == "" {
+		return "", "", fmt.Errorf("%w: token names no user", ErrSingleSignOnFailed)
+	}
+
+	values := claims.Strings(o.config.RoleClaim)
+	for _, mapping := range o.config.ClaimRoles {
+		if slices.Contains(values, mapping.Value) {
+			return username, mapping.Role, nil
+		}
+	}
+	if o.config.DefaultRole != "" {
+		return username, o.config.DefaultRole, nil
+	}
+	return "", "", fmt.Errorf("%w: no role is mapped to user %s", ErrSingleSignOnFailed, username)
+}
+
+// verifyBearer returns an unstored session for a bearer token issued by the
+// provider for one of the accepted audiences.
+func (o *oidcServer) verifyBearer(ctx context.Context, token, clientAddr string) (*redfishSession, error) {
+	claims, err := o.provider.Verify(ctx, token, o.audiences...)
+	if err != nil {
+		return nil, err
+	}
+	username, role, err := o.identity(claims)
+	if err != nil {
+		return nil, err
+	}
+	return &redfishSession{
+		origin:      originSingleSignOn,
+		username:    username,
+		role:        role,
+		clientAddr:  clientAddr,
+		sessionType: sessionTypeWebUI,
+	}, nil
+}
+
+// addPending stores a login under its state, dropping expired logins.
+func (o *oidcServer) addPending(state string, login pendingLogin) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	now := time.Now()
+	for s, l := range o.pending {
+		if now.After(l.expires) {
+			delete(o.pending, s)
+		}
+	}
+	if len(o.pending) >= maxPendingLogins {
+		return false
+	}
+	o.pending[state] = login
+	return true
+}
+
+// takePending removes and returns the unexpired login with the given state.
+func (o *oidcServer) takePending(state string) (pendingLogin, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	login, ok := o.pending[state]
+	delete(o.pending, state)
+	if !ok || time.Now().After(login.expires) {
+		return pendingLogin{}, false
+	}
+	return login, true
+}
+
+// localPath reports whether p is a path on this server, so that logins
+// cannot be abused to redirect users to other sites.
+func localPath(p string) bool {
+	return strings.HasPrefix(p, "/") && !strings.HasPrefix(p, "//") && !strings.HasPrefix(p, "/\\")
+}
+
+// handleLogin sends the user agent to the authorization endpoint of the
+// provider. The redirect query parameter names the page to return to.
+func (o *oidcServer) handleLogin(w http.ResponseWriter, r *http.Request) {
+	redirect := r.URL.Query().Get("redirect")
+	if !localPath(redirect) {
+		redirect = "/"
+	}
+
+	state, err := randomHex(16)
+	if err != nil {
+		o.fail(w, r, err)
+		return
+	}
+	nonce, err := randomHex(16)
+	if err != nil {
+		o.fail(w, r, err)
+		return
+	}
+	verifier, err := oidc.NewCodeVerifier()
+	if err != nil {
+		o.fail(w, r, err)
+		return
+	}
+	authURL, err := o.client.AuthCodeURL(r.Context(), state, nonce, verifier)
+	if err != nil {
+		o.fail(w, r, err)
+		return
+	}
+
+	if !o.addPending(state, pendingLogin{
+		verifier: verifier,
+		nonce:    nonce,
+		redirect: redirect,
+		expires:  time.Now().Add(oidcLoginTimeout),
+	}) {
+		http.Error(w, "Too many pending logins", http.StatusServiceUnavailable)
+		return
+	}
+
+	// The cookie binds the state to the user agent that started the login.
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcStateCookieName,
+		Value:    state,
+		Path:     oidcCallbackPath,
+		MaxAge:   int(oidcLoginTimeout / time.Second),
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// handleCallback completes a login: it redeems the authorization code,
+// verifies the ID token, maps its claims to a role and opens a session.
+func (o *oidcServer) handleCallback(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	state := query.Get("state")
+
+	cookie, err := r.Cookie(oidcStateCookieName)
+	if err != nil || state == "" || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(state)) != 1 {
+		http.Error(w, "Invalid login state", http.StatusBadRequest)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcStateCookieName,
+		Path:     oidcCallbackPath,
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	login, ok := o.takePending(state)
+	if !ok {
+		http.Error(w, "Login expired", http.StatusBadRequest)
+		return
+	}
+	if code := query.Get("error"); code != "" {
+		o.fail(w, r, fmt.Errorf("%w: provider answered %s: %s", ErrSingleSignOnFailed, code, query.Get("error_description")))
+		return
+	}
+
+	token, err := o.client.Exchange(r.Context(), query.Get("code"), login.verifier)
+	if err != nil {
+		o.fail(w, r, err)
+		return
+	}
+	claims, err := o.client.VerifyIDToken(r.Context(), token.IDToken, login.nonce)
+	if err != nil {
+		o.fail(w, r, err)
+		return
+	}
+	username, role, err := o.identity(claims)
+	if err != nil {
+		o.fail(w, r, err)
+		return
+	}
+
+	sess := &redfishSession{
+		origin:      originSingleSignOn,
+		username:    username,
+		role:        role,
+		clientAddr:  clientAddress(r.RemoteAddr),
+		sessionType: sessionTypeWebUI,
+	}
+	if err := o.sessions.add(sess); err != nil {
+		o.fail(w, r, err)
+		return
+	}
+
+	o.logger.InfoContext(r.Context(), "Single sign-on session created",
+		"session", sess.id,
+		"user", sess.username,
+		"role", sess.role,
+		"client", sess.clientAddr)
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    sess.token,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, login.redirect, http.StatusFound)
+}
+
+// handleValidate reports the user of the session cookie to the Web UI.
+func (o *oidcServer) handleValidate(w http.ResponseWriter, r *http.Request) {
+	cookie, err := r.Cookie(sessionCookieName)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+	sess, ok := o.sessions.lookup(cookie.Value)
+	if !ok {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentTypeJSON)
+	w.Header().Set("Cache-Control", "no-store")
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"username": sess.username,
+		"role":     sess.role,
+	})
+}
+
+// handleLogout closes the session of the session cookie.
+func (o *oidcServer) handleLogout(w http.ResponseWriter, r *http.Request) {
+	if cookie, err := r.Cookie(sessionCookieName); err == nil {
+		if sess, ok := o.sessions.lookup(cookie.Value); ok {
+			_ = o.sessions.remove(sess.id)
+			o.logger.InfoContext(r.Context(), "Single sign-on session closed", "session", sess.id, "user", sess.username)
+		}
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookieName,
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// fail logs a failed login and writes the matching error response. Details
+// are only logged, as they may help attackers.
+func (o *oidcServer) fail(w http.ResponseWriter, r *http.Request, err error) {
+	o.logger.WarnContext(r.Context(), "Single sign-on failed", "error", err)
+
+	switch {
+	case errors.Is(err, oidc.ErrDiscoveryFailed), errors.Is(err, oidc.ErrKeySetFailed), errors.Is(err, oidc.ErrExchangeFailed):
+		http.Error(w, "OpenID provider unavailable", http.StatusBadGateway)
+	case errors.Is(err, ErrTooManySessions):
+		http.Error(w, "Too many sessions", http.StatusServiceUnavailable)
+	case errors.Is(err, ErrSingleSignOnFailed):
+		http.Error(w, "Login refused", http.StatusForbidden)
+	case errors.Is(err, oidc.ErrMalformedToken), errors.Is(err, oidc.ErrUnsupportedAlgorithm), errors.Is(err, oidc.ErrUnknownKey),
+		errors.Is(err, oidc.ErrInvalidSignature), errors.Is(err, oidc.ErrInvalidClaims), errors.Is(err, oidc.ErrTokenExpired):
+		http.Error(w, "Invalid ID token", http.StatusUnauthorized)
+	default:
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
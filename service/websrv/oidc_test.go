@@ -0,0 +1,332 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package websrv
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/u-bmc/u-bmc/pkg/oidc"
+	"github.com/u-bmc/u-bmc/pkg/oidc/oidctest"
+)
+
+const testOIDCRedirectURL = "https://bmc.example.com" + oidcCallbackPath
+
+// newTestOIDC starts a provider whose users are in the bmc-admins group and
+// returns it with the endpoints of a relying party registered with it.
+func newTestOIDC(t *testing.T, cfg OIDCConfig) (*oidctest.Server, *oidcServer, *http.ServeMux) {
+	t.Helper()
+
+	srv := oidctest.NewServer("bmc", "bmc-secret")
+	t.Cleanup(srv.Close)
+	srv.SetClaims(map[string]any{"preferred_username": "alice", "groups": []string{"bmc-admins"}})
+
+	cfg.Issuer = srv.URL
+	cfg.ClientID = "bmc"
+	cfg.ClientSecret = "bmc-secret"
+	cfg.RedirectURL = testOIDCRedirectURL
+	cfg.HTTPClient = srv.Client()
+	if cfg.ClaimRoles == nil && cfg.DefaultRole == "" {
+		cfg.ClaimRoles = []OIDCClaimRole{{Value: "bmc-admins", Role: roleAdministrator}}
+	}
+	o, err := newOIDCServer(slog.New(slog.DiscardHandler), &cfg, newSessionStore(time.Hour))
+	if err != nil {
+		t.Fatalf("newOIDCServer() error = %v", err)
+	}
+	mux := http.NewServeMux()
+	o.register(mux)
+	return srv, o, mux
+}
+
+// serve sends a GET request for target with cookies to mux.
+func serve(mux http.Handler, target string, cookies ...*http.Cookie) *http.Response {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	return w.Result()
+}
+
+// cookie returns the cookie a response sets.
+func cookie(resp *http.Response, name string) *http.Cookie {
+	for _, c := range resp.Cookies() {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// startLogin starts a login returning to redirect and lets the user agent
+// log in at the provider. It returns the state cookie and the callback
+// target the provider sent the user agent back to.
+func startLogin(t *testing.T, srv *oidctest.Server, mux http.Handler, redirect string) (*http.Cookie, string) {
+	t.Helper()
+
+	resp := serve(mux, oidcLoginPath+"?redirect="+url.QueryEscape(redirect))
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("login answered %s", resp.Status)
+	}
+	state := cookie(resp, oidcStateCookieName)
+	if state == nil || !state.HttpOnly || !state.Secure || state.Path != oidcCallbackPath {
+		t.Fatalf("state cookie = %v", state)
+	}
+
+	agent := srv.Client()
+	agent.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+	resp, err := agent.Get(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("authorization endpoint answered %s", resp.Status)
+	}
+	callback := resp.Header.Get("Location")
+	if !strings.HasPrefix(callback, testOIDCRedirectURL+"?") {
+		t.Fatalf("provider redirected to %s", callback)
+	}
+	return state, strings.TrimPrefix(callback, "https://bmc.example.com")
+}
+
+func TestOIDCLogin(t *testing.T) {
+	tests := []struct {
+		name         string
+		redirect     string
+		wantRedirect string
+	}{
+		{name: "local page", redirect: "/systems?tab=power", wantRedirect: "/systems?tab=power"},
+		{name: "other site", redirect: "//evil.example.com/", wantRedirect: "/"},
+		{name: "backslash", redirect: "/\\evil.example.com", wantRedirect: "/"},
+		{name: "absolute URL", redirect: "https://evil.example.com/", wantRedirect: "/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, _, mux := newTestOIDC(t, OIDCConfig{})
+			state, callback := startLogin(t, srv, mux, tt.redirect)
+
+			resp := serve(mux, callback, state)
+			if resp.StatusCode != http.StatusFound {
+				t.Fatalf("callback answered %s", resp.Status)
+			}
+			if got := resp.Header.Get("Location"); got != tt.wantRedirect {
+				t.Errorf("redirected to %q, want %q", got, tt.wantRedirect)
+			}
+			session := cookie(resp, sessionCookieName)
+			if session == nil || !session.HttpOnly || !session.Secure {
+				t.Fatalf("session cookie = %v", session)
+			}
+			if cleared := cookie(resp, oidcStateCookieName); cleared == nil || cleared.MaxAge >= 0 {
+				t.Errorf("state cookie not cleared: %v", cleared)
+			}
+
+			resp = serve(mux, authValidatePath, session)
+			var user map[string]string
+			if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+				t.Fatalf("validate answered %s: %v", resp.Status, err)
+			}
+			if user["username"] != "alice" || user["role"] != roleAdministrator {
+				t.Errorf("validate = %v", user)
+			}
+		})
+	}
+}
+
+func TestOIDCLogout(t *testing.T) {
+	srv, _, mux := newTestOIDC(t, OIDCConfig{})
+	state, callback := startLogin(t, srv, mux, "/")
+	session := cookie(serve(mux, callback, state), sessionCookieName)
+	if session == nil {
+		t.Fatal("no session cookie")
+	}
+
+	req := httptest.NewRequest(http.MethodPost, authLogoutPath, nil)
+	req.AddCookie(session)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	if w.Code != http.StatusNoContent {
+		t.Errorf("logout answered %d", w.Code)
+	}
+	if resp := serve(mux, authValidatePath, session); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("validate answered %s after logout", resp.Status)
+	}
+}
+
+func TestOIDCCallbackState(t *testing.T) {
+	tests := []struct {
+		name string
+		// callback returns the cookies and target of the callback request.
+		callback func(t *testing.T, srv *oidctest.Server, mux http.Handler) ([]*http.Cookie, string)
+	}{
+		{
+			name: "no state cookie",
+			callback: func(t *testing.T, srv *oidctest.Server, mux http.Handler) ([]*http.Cookie, string) {
+				_, callback := startLogin(t, srv, mux, "/")
+				return nil, callback
+			},
+		},
+		{
+			name: "state of another login",
+			callback: func(t *testing.T, srv *oidctest.Server, mux http.Handler) ([]*http.Cookie, string) {
+				_, callback := startLogin(t, srv, mux, "/")
+				other, _ := startLogin(t, srv, mux, "/")
+				return []*http.Cookie{other}, callback
+			},
+		},
+		{
+			name: "state missing",
+			callback: func(t *testing.T, srv *oidctest.Server, mux http.Handler) ([]*http.Cookie, string) {
+				state, callback := startLogin(t, srv, mux, "/")
+				u, err := url.Parse(callback)
+				if err != nil {
+					t.Fatal(err)
+				}
+				q := u.Query()
+				q.Del("state")
+				u.RawQuery = q.Encode()
+				return []*http.Cookie{state}, u.String()
+			},
+		},
+		{
+			name: "callback replayed",
+			callback: func(t *testing.T, srv *oidctest.Server, mux http.Handler) ([]*http.Cookie, string) {
+				state, callback := startLogin(t, srv, mux, "/")
+				if resp := serve(mux, callback, state); resp.StatusCode != http.StatusFound {
+					t.Fatalf("callback answered %s", resp.Status)
+				}
+				return []*http.Cookie{state}, callback
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, _, mux := newTestOIDC(t, OIDCConfig{})
+			cookies, callback := tt.callback(t, srv, mux)
+
+			resp := serve(mux, callback, cookies...)
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Errorf("callback answered %s, want %d", resp.Status, http.StatusBadRequest)
+			}
+			if cookie(resp, sessionCookieName) != nil {
+				t.Error("callback opened a session")
+			}
+		})
+	}
+}
+
+func TestOIDCCallbackRefused(t *testing.T) {
+	tests := []struct {
+		name       string
+		cfg        OIDCConfig
+		claims     map[string]any
+		wantStatus int
+	}{
+		{
+			name:       "default role",
+			cfg:        OIDCConfig{DefaultRole: roleReadOnly},
+			claims:     map[string]any{"preferred_username": "bob", "groups": []string{"staff"}},
+			wantStatus: http.StatusFound,
+		},
+		{
+			name:       "no mapped role",
+			claims:     map[string]any{"preferred_username": "bob", "groups": []string{"staff"}},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "nonce of another login",
+			claims:     map[string]any{"preferred_username": "alice", "groups": []string{"bmc-admins"}, "nonce": "other"},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "expired ID token",
+			claims:     map[string]any{"preferred_username": "alice", "groups": []string{"bmc-admins"}, "exp": time.Now().Add(-time.Hour).Unix()},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "issued for another client",
+			claims:     map[string]any{"preferred_username": "alice", "groups": []string{"bmc-admins"}, "aud": "other"},
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, _, mux := newTestOIDC(t, tt.cfg)
+			srv.SetClaims(tt.claims)
+			state, callback := startLogin(t, srv, mux, "/")
+
+			resp := serve(mux, callback, state)
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("callback answered %s, want %d", resp.Status, tt.wantStatus)
+			}
+			if opened := cookie(resp, sessionCookieName) != nil; opened != (tt.wantStatus == http.StatusFound) {
+				t.Errorf("session opened = %v", opened)
+			}
+		})
+	}
+
+	t.Run("PKCE verifier of another login", func(t *testing.T) {
+		srv, o, mux := newTestOIDC(t, OIDCConfig{})
+		state, callback := startLogin(t, srv, mux, "/")
+		o.mu.Lock()
+		login := o.pending[state.Value]
+		login.verifier = "verifier-of-another-login-0123456789abcdefg"
+		o.pending[state.Value] = login
+		o.mu.Unlock()
+
+		if resp := serve(mux, callback, state); resp.StatusCode != http.StatusBadGateway {
+			t.Errorf("callback answered %s, want %d", resp.Status, http.StatusBadGateway)
+		}
+	})
+
+	t.Run("provider unavailable", func(t *testing.T) {
+		srv, _, mux := newTestOIDC(t, OIDCConfig{})
+		srv.Close()
+		if resp := serve(mux, oidcLoginPath); resp.StatusCode != http.StatusBadGateway {
+			t.Errorf("login answered %s, want %d", resp.Status, http.StatusBadGateway)
+		}
+	})
+}
+
+func TestOIDCVerifyBearer(t *testing.T) {
+	srv, o, _ := newTestOIDC(t, OIDCConfig{Audiences: []string{"bmc-api"}})
+	now := time.Now()
+	claims := map[string]any{
+		"iss":                srv.URL,
+		"sub":                "f81d4fae",
+		"aud":                "bmc-api",
+		"exp":                now.Add(time.Minute).Unix(),
+		"preferred_username": "alice",
+		"groups":             []string{"bmc-admins"},
+	}
+
+	sess, err := o.verifyBearer(t.Context(), srv.Sign(claims), "192.0.2.1")
+	if err != nil {
+		t.Fatalf("verifyBearer() error = %v", err)
+	}
+	if sess.username != "alice" || sess.role != roleAdministrator || sess.sessionType != sessionTypeWebUI {
+		t.Errorf("session = %+v", sess)
+	}
+
+	claims["aud"] = "bmc"
+	if _, err := o.verifyBearer(t.Context(), srv.Sign(claims), "192.0.2.1"); !errors.Is(err, oidc.ErrInvalidClaims) {
+		t.Errorf("verifyBearer() error = %v for the client ID, want %v", err, oidc.ErrInvalidClaims)
+	}
+
+	claims["aud"] = "bmc-api"
+	claims["groups"] = []string{"staff"}
+	if _, err := o.verifyBearer(t.Context(), srv.Sign(claims), "192.0.2.1"); !errors.Is(err, ErrSingleSignOnFailed) {
+		t.Errorf("verifyBearer() error = %v without a role, want %v", err, ErrSingleSignOnFailed)
+	}
+}
@@ -0,0 +1,451 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package websrv
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// OpenAPI document constants.
+const (
+	openAPIPath      = "/api/v1alpha1/openapi.json"
+	openAPIVersion   = "3.0.3"
+	openAPISchemaRef = "#/components/schemas/"
+	rpcStatusSchema  = "google.rpc.Status"
+)
+
+// openAPIDocument is an OpenAPI 3 document describing the REST transcoding of
+// the Connect RPC services.
+type openAPIDocument struct {
+	OpenAPI    string                                  `json:"openapi"`
+	Info       openAPIInfo                             `json:"info"`
+	Tags       []openAPITag                            `json:"tags"`
+	Paths      map[string]map[string]*openAPIOperation `json:"paths"`
+	Components struct {
+		Schemas map[string]*openAPISchema `json:"schemas"`
+	} `json:"components"`
+}
+
+// openAPIInfo is the info object of an OpenAPI document.
+type openAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// openAPITag groups the operations of a service.
+type openAPITag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// openAPIOperation is a single API operation on a path.
+type openAPIOperation struct {
+	OperationID string                      `json:"operationId"`
+	Summary     string                      `json:"summary,omitempty"`
+	Tags        []string                    `json:"tags"`
+	Parameters  []openAPIParameter          `json:"parameters,omitempty"`
+	RequestBody *openAPIRequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*openAPIResponse `json:"responses"`
+}
+
+// openAPIParameter is a path or query parameter of an operation.
+type openAPIParameter struct {
+	Name     string         `json:"name"`
+	In       string         `json:"in"`
+	Required bool           `json:"required,omitempty"`
+	Schema   *openAPISchema `json:"schema"`
+}
+
+// openAPIRequestBody is the request body of an operation.
+type openAPIRequestBody struct {
+	Required bool                        `json:"required"`
+	Content  map[string]openAPIMediaType `json:"content"`
+}
+
+// openAPIResponse is a response of an operation.
+type openAPIResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]openAPIMediaType `json:"content,omitempty"`
+}
+
+// openAPIMediaType is the schema of a request or response body.
+type openAPIMediaType struct {
+	Schema *openAPISchema `json:"schema"`
+}
+
+// openAPISchema is the subset of the OpenAPI schema object needed to describe
+// the JSON mapping of protobuf messages.
+type openAPISchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Pattern              string                    `json:"pattern,omitempty"`
+	Enum                 []string                  `json:"enum,omitempty"`
+	Items                *openAPISchema            `json:"items,omitempty"`
+	Properties           map[string]*openAPISchema `json:"properties,omitempty"`
+	AdditionalProperties *openAPISchema            `json:"additionalProperties,omitempty"`
+}
+
+// schemaRef returns a reference to the component schema with the given name.
+func schemaRef(name protoreflect.FullName) *openAPISchema {
+	return &openAPISchema{Ref: openAPISchemaRef + string(name)}
+}
+
+// jsonContent returns the content of a JSON body with the given schema.
+func jsonContent(schema *openAPISchema) map[string]openAPIMediaType {
+	return map[string]openAPIMediaType{"application/json": {Schema: schema}}
+}
+
+// wellKnownSchema returns the schema of the JSON mapping of a well-known type,
+// which does not follow the mapping of regular messages.
+func wellKnownSchema(md protoreflect.MessageDescriptor) (*openAPISchema, bool) {
+	switch md.FullName() {
+	case "google.protobuf.Timestamp":
+		return &openAPISchema{Type: "string", Format: "date-time"}, true
+	case "google.protobuf.Duration":
+		return &openAPISchema{Type: "string", Pattern: `^-?[0-9]+(\.[0-9]{1,9})?s$`}, true
+	case "google.protobuf.FieldMask":
+		return &openAPISchema{Type: "string"}, true
+	case "google.protobuf.Struct", "google.protobuf.Empty", "google.protobuf.Any":
+		return &openAPISchema{Type: "object", AdditionalProperties: &openAPISchema{}}, true
+	case "google.protobuf.Value":
+		return &openAPISchema{}, true
+	case "google.protobuf.ListValue":
+		return &openAPISchema{Type: "array", Items: &openAPISchema{}}, true
+	case "google.protobuf.DoubleValue", "google.protobuf.FloatValue",
+		"google.protobuf.Int64Value", "google.protobuf.UInt64Value",
+		"google.protobuf.Int32Value", "google.protobuf.UInt32Value",
+		"google.protobuf.BoolValue", "google.protobuf.StringValue", "google.protobuf.BytesValue":
+		return scalarSchema(md.Fields().ByName("value")), true
+	default:
+		return nil, false
+	}
+}
+
+// scalarSchema returns the schema of the JSON mapping of a scalar field.
+// 64-bit integers are encoded as strings to preserve their precision.
+func scalarSchema(fd protoreflect.FieldDescriptor) *openAPISchema {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return &openAPISchema{Type: "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return &openAPISchema{Type: "integer", Format: "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return &openAPISchema{Type: "integer", Format: "int64"}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return &openAPISchema{Type: "string", Format: "int64"}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return &openAPISchema{Type: "string", Format: "uint64"}
+	case protoreflect.FloatKind:
+		return &openAPISchema{Type: "number", Format: "float"}
+	case protoreflect.DoubleKind:
+		return &openAPISchema{Type: "number", Format: "double"}
+	case protoreflect.BytesKind:
+		return &openAPISchema{Type: "string", Format: "byte"}
+	default:
+		return &openAPISchema{Type: "string"}
+	}
+}
+
+// httpRuleBinding is an HTTP binding of an RPC method.
+type httpRuleBinding struct {
+	method string
+	path   string
+	body   string
+}
+
+// httpRuleBindings returns the HTTP bindings of an RPC method, including the
+// additional bindings of its google.api.http annotation.
+func httpRuleBindings(md protoreflect.MethodDescriptor) []httpRuleBinding {
+	rule, ok := proto.GetExtension(md.Options(), annotations.E_Http).(*annotations.HttpRule)
+	if !ok || rule == nil {
+		return nil
+	}
+
+	rules := append([]*annotations.HttpRule{rule}, rule.GetAdditionalBindings()...)
+	bindings := make([]httpRuleBinding, 0, len(rules))
+	for _, rule := range rules {
+		binding := httpRuleBinding{body: rule.GetBody()}
+		switch pattern := rule.GetPattern().(type) {
+		case *annotations.HttpRule_Get:
+			binding.method, binding.path = http.MethodGet, pattern.Get
+		case *annotations.HttpRule_Put:
+			binding.method, binding.path = http.MethodPut, pattern.Put
+		case *annotations.HttpRule_Post:
+			binding.method, binding.path = http.MethodPost, pattern.Post
+		case *annotations.HttpRule_Delete:
+			binding.method, binding.path = http.MethodDelete, pattern.Delete
+		case *annotations.HttpRule_Patch:
+			binding.method, binding.path = http.MethodPatch, pattern.Patch
+		case *annotations.HttpRule_Custom:
+			binding.method, binding.path = pattern.Custom.GetKind(), pattern.Custom.GetPath()
+		default:
+			continue
+		}
+		bindings = append(bindings, binding)
+	}
+	return bindings
+}
+
+// pathTemplate converts an HTTP rule path template into an OpenAPI path and
+// returns the field paths of its variables. Variable patterns such as
+// {name=chassis/*} are dropped, as OpenAPI parameters cannot express them.
+func pathTemplate(tmpl string) (string, []string) {
+	var (
+		path strings.Builder
+		vars []string
+	)
+	for {
+		start := strings.IndexByte(tmpl, '{')
+		end := strings.IndexByte(tmpl, '}')
+		if start < 0 || end < start {
+			path.WriteString(tmpl)
+			break
+		}
+		name, _, _ := strings.Cut(tmpl[start+1:end], "=")
+		path.WriteString(tmpl[:start] + "{" + name + "}")
+		vars = append(vars, name)
+		tmpl = tmpl[end+1:]
+	}
+	return path.String(), vars
+}
+
+// fieldByPath resolves a dotted path of field names within md.
+func fieldByPath(md protoreflect.MessageDescriptor, path string) protoreflect.FieldDescriptor {
+	var fd protoreflect.FieldDescriptor
+	for name := range strings.SplitSeq(path, ".") {
+		if md == nil {
+			return nil
+		}
+		if fd = md.Fields().ByName(protoreflect.Name(name)); fd == nil {
+			return nil
+		}
+		md = fd.Message()
+	}
+	return fd
+}
+
+// openAPIBuilder collects the operations and schemas of an OpenAPI document.
+type openAPIBuilder struct {
+	doc *openAPIDocument
+}
+
+// fieldSchema returns the schema of a field, adding the schemas of the
+// messages and enums it refers to.
+func (b *openAPIBuilder) fieldSchema(fd protoreflect.FieldDescriptor) *openAPISchema {
+	if fd.IsMap() {
+		return &openAPISchema{Type: "object", AdditionalProperties: b.valueSchema(fd.MapValue())}
+	}
+	schema := b.valueSchema(fd)
+	if fd.IsList() {
+		schema = &openAPISchema{Type: "array", Items: schema}
+	}
+	return schema
+}
+
+// valueSchema returns the schema of a single value of a field.
+func (b *openAPIBuilder) valueSchema(fd protoreflect.FieldDescriptor) *openAPISchema {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return b.messageSchema(fd.Message())
+	case protoreflect.EnumKind:
+		return b.enumSchema(fd.Enum())
+	default:
+		return scalarSchema(fd)
+	}
+}
+
+// enumSchema returns a reference to the component schema of an enum, which
+// is encoded by the names of its values.
+func (b *openAPIBuilder) enumSchema(ed protoreflect.EnumDescriptor) *openAPISchema {
+	if _, ok := b.doc.Components.Schemas[string(ed.FullName())]; !ok {
+		values := ed.Values()
+		schema := &openAPISchema{Type: "string", Enum: make([]string, 0, values.Len())}
+		for i := range values.Len() {
+			schema.Enum = append(schema.Enum, string(values.Get(i).Name()))
+		}
+		b.doc.Components.Schemas[string(ed.FullName())] = schema
+	}
+	return schemaRef(ed.FullName())
+}
+
+// messageSchema returns the schema of a message. Regular messages become
+// component schemas, so recursive messages refer to themselves.
+func (b *openAPIBuilder) messageSchema(md protoreflect.MessageDescriptor) *openAPISchema {
+	if schema, ok := wellKnownSchema(md); ok {
+		return schema
+	}
+	if _, ok := b.doc.Components.Schemas[string(md.FullName())]; ok {
+		return schemaRef(md.FullName())
+	}
+
+	fields := md.Fields()
+	schema := &openAPISchema{Type: "object", Properties: make(map[string]*openAPISchema, fields.Len())}
+	b.doc.Components.Schemas[string(md.FullName())] = schema
+	for i := range fields.Len() {
+		fd := fields.Get(i)
+		schema.Properties[fd.JSONName()] = b.fieldSchema(fd)
+	}
+	return schemaRef(md.FullName())
+}
+
+// queryParameters returns the query parameters of a binding, which are the
+// top-level fields of input that are neither bound to the path nor the body
+// and have a string representation.
+func (b *openAPIBuilder) queryParameters(input protoreflect.MessageDescriptor, vars []string, body string) []openAPIParameter {
+	if body == "*" {
+		return nil
+	}
+
+	var params []openAPIParameter
+	fields := input.Fields()
+	for i := range fields.Len() {
+		fd := fields.Get(i)
+		if string(fd.Name()) == body || fd.IsMap() {
+			continue
+		}
+		bound := false
+		for _, v := range vars {
+			if root, _, _ := strings.Cut(v, "."); root == string(fd.Name()) {
+				bound = true
+			}
+		}
+		if bound {
+			continue
+		}
+
+		schema := b.fieldSchema(fd)
+		value := schema
+		if fd.IsList() {
+			value = schema.Items
+		}
+		if value.Ref != "" && fd.Kind() != protoreflect.EnumKind {
+			continue
+		}
+		params = append(params, openAPIParameter{Name: fd.JSONName(), In: "query", Schema: schema})
+	}
+	return params
+}
+
+// addMethod adds the operations of the HTTP bindings of an RPC method, or the
+// Connect procedure of methods without bindings.
+func (b *openAPIBuilder) addMethod(tag string, md protoreflect.MethodDescriptor) {
+	bindings := httpRuleBindings(md)
+	if len(bindings) == 0 {
+		bindings = []httpRuleBinding{{
+			method: http.MethodPost,
+			path:   "/" + string(md.Parent().FullName()) + "/" + string(md.Name()),
+			body:   "*",
+		}}
+	}
+
+	for i, binding := range bindings {
+		path, vars := pathTemplate(binding.path)
+		op := &openAPIOperation{
+			OperationID: string(md.Name()),
+			Summary:     string(md.Name()),
+			Tags:        []string{tag},
+			Responses: map[string]*openAPIResponse{
+				"200": {Description: "OK", Content: jsonContent(b.messageSchema(md.Output()))},
+				"default": {
+					Description: "Error",
+					Content:     jsonContent(&openAPISchema{Ref: openAPISchemaRef + rpcStatusSchema}),
+				},
+			},
+		}
+		if i > 0 {
+			op.OperationID = fmt.Sprintf("%s%d", md.Name(), i+1)
+		}
+
+		for _, v := range vars {
+			schema := &openAPISchema{Type: "string"}
+			if fd := fieldByPath(md.Input(), v); fd != nil {
+				schema = b.fieldSchema(fd)
+			}
+			op.Parameters = append(op.Parameters, openAPIParameter{Name: v, In: "path", Required: true, Schema: schema})
+		}
+		op.Parameters = append(op.Parameters, b.queryParameters(md.Input(), vars, binding.body)...)
+
+		switch binding.body {
+		case "":
+		case "*":
+			op.RequestBody = &openAPIRequestBody{Required: true, Content: jsonContent(b.messageSchema(md.Input()))}
+		default:
+			if fd := md.Input().Fields().ByName(protoreflect.Name(binding.body)); fd != nil {
+				op.RequestBody = &openAPIRequestBody{Required: true, Content: jsonContent(b.fieldSchema(fd))}
+			}
+		}
+
+		if b.doc.Paths[path] == nil {
+			b.doc.Paths[path] = make(map[string]*openAPIOperation)
+		}
+		b.doc.Paths[path][strings.ToLower(binding.method)] = op
+	}
+}
+
+// newOpenAPIDocument creates the OpenAPI document of the Connect RPC services
+// with the given fully-qualified names from their registered descriptors.
+func newOpenAPIDocument(title, version string, serviceNames ...string) (*openAPIDocument, error) {
+	doc := &openAPIDocument{
+		OpenAPI: openAPIVersion,
+		Info:    openAPIInfo{Title: title, Version: version},
+		Paths:   make(map[string]map[string]*openAPIOperation),
+	}
+	doc.Components.Schemas = map[string]*openAPISchema{
+		rpcStatusSchema: {
+			Type: "object",
+			Properties: map[string]*openAPISchema{
+				"code":    {Type: "integer", Format: "int32"},
+				"message": {Type: "string"},
+				"details": {Type: "array", Items: &openAPISchema{Type: "object", AdditionalProperties: &openAPISchema{}}},
+			},
+		},
+	}
+
+	b := &openAPIBuilder{doc: doc}
+	for _, name := range serviceNames {
+		desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(name))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrCreateOpenAPIDocument, name, err)
+		}
+		sd, ok := desc.(protoreflect.ServiceDescriptor)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s is not a service", ErrCreateOpenAPIDocument, name)
+		}
+
+		tag := string(sd.Name())
+		doc.Tags = append(doc.Tags, openAPITag{Name: tag, Description: name})
+		methods := sd.Methods()
+		for i := range methods.Len() {
+			b.addMethod(tag, methods.Get(i))
+		}
+	}
+
+	return doc, nil
+}
+
+// openAPIHandler serves an OpenAPI document.
+func openAPIHandler(doc *openAPIDocument) (http.Handler, error) {
+	body, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCreateOpenAPIDocument, err)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", contentTypeJSON)
+		_, _ = w.Write(body)
+	}), nil
+}
@@ -0,0 +1,622 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package websrv
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/micro"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Redfish service constants.
+const (
+	redfishRoot         = "/redfish/v1"
+	redfishVersion      = "1.17.0"
+	odataVersion        = "4.0"
+	redfishMaxBodySize  = 64 << 10
+	redfishBaseRegistry = "Base.1.16.0"
+	redfishMessageType  = "#Message.v1_1_1.Message"
+	contentTypeJSON     = "application/json; charset=utf-8"
+	messageGeneralError = "A general error has occurred. See Resolution for information on how to resolve the error."
+)
+
+// Redfish resource types.
+const (
+	odataTypeServiceRoot = "#ServiceRoot.v1_15_0.ServiceRoot"
+)
+
+// odataLink references another resource.
+type odataLink struct {
+	ODataID string `json:"@odata.id"`
+}
+
+// link returns a reference to the resource at path.
+func link(path string) odataLink {
+	return odataLink{ODataID: path}
+}
+
+// odataHeader holds the OData annotations common to all Redfish resources.
+type odataHeader struct {
+	ODataID   string `json:"@odata.id"`
+	ODataType string `json:"@odata.type"`
+	ODataEtag string `json:"@odata.etag,omitempty"`
+}
+
+func (h *odataHeader) setETag(etag string) {
+	h.ODataEtag = etag
+}
+
+// resource is a Redfish resource whose entity tag is derived from its content.
+type resource interface {
+	setETag(etag string)
+}
+
+// resourceStatus is the Redfish Status property.
+type resourceStatus struct {
+	State  string `json:"State,omitempty"`
+	Health string `json:"Health,omitempty"`
+}
+
+// resourceCollection is a Redfish resource collection.
+type resourceCollection struct {
+	odataHeader
+	Name         string      `json:"Name"`
+	Members      []odataLink `json:"Members"`
+	MembersCount int         `json:"Members@odata.count"`
+}
+
+// newCollection creates a collection of the member resources below path.
+func newCollection(path, odataType, name string, ids []string) *resourceCollection {
+	members := make([]odataLink, 0, len(ids))
+	for _, id := range ids {
+		members = append(members, link(path+"/"+url.PathEscape(id)))
+	}
+	return &resourceCollection{
+		odataHeader:  odataHeader{ODataID: path, ODataType: odataType},
+		Name:         name,
+		Members:      members,
+		MembersCount: len(members),
+	}
+}
+
+// resetAction is a Redfish Reset action with its allowable reset types.
+type resetAction struct {
+	Target     string   `json:"target"`
+	ResetTypes []string `json:"ResetType@Redfish.AllowableValues"`
+}
+
+// actionTarget is a Redfish action without parameter annotations.
+type actionTarget struct {
+	Target string `json:"target"`
+}
+
+// resetRequest is the body of a Reset action.
+type resetRequest struct {
+	ResetType string `json:"ResetType"`
+}
+
+// serviceRoot is the Redfish ServiceRoot resource.
+type serviceRoot struct {
+	odataHeader
+	ID                 string    `json:"Id"`
+	Name               string    `json:"Name"`
+	RedfishVersion     string    `json:"RedfishVersion"`
+	Product            string    `json:"Product"`
+	Vendor             string    `json:"Vendor"`
+	Systems            odataLink `json:"Systems"`
+	Chassis            odataLink `json:"Chassis"`
+	Managers           odataLink `json:"Managers"`
+	EventService       odataLink `json:"EventService"`
+	Registries         odataLink `json:"Registries"`
+	SessionService     odataLink `json:"SessionService"`
+	AccountService     odataLink `json:"AccountService"`
+	UpdateService      odataLink `json:"UpdateService"`
+	Tasks              odataLink `json:"Tasks"`
+	TelemetryService   odataLink `json:"TelemetryService"`
+	AggregationService odataLink `json:"AggregationService"`
+
+	ProtocolFeaturesSupported protocolFeatures `json:"ProtocolFeaturesSupported"`
+
+	Links struct {
+		Sessions odataLink `json:"Sessions"`
+	} `json:"Links"`
+}
+
+// redfishMessage is a message of the Base message registry.
+type redfishMessage struct {
+	ODataType   string   `json:"@odata.type"`
+	MessageID   string   `json:"MessageId"`
+	Message     string   `json:"Message"`
+	MessageArgs []string `json:"MessageArgs,omitempty"`
+	Severity    string   `json:"MessageSeverity"`
+	Resolution  string   `json:"Resolution,omitempty"`
+}
+
+// redfishError is the body of a Redfish error response.
+type redfishError struct {
+	Error struct {
+		Code         string           `json:"code"`
+		Message      string           `json:"message"`
+		ExtendedInfo []redfishMessage `json:"@Message.ExtendedInfo"`
+	} `json:"error"`
+}
+
+// redfishServer serves the Redfish API by translating resources and actions
+// into NATS requests to the backend services.
+type redfishServer struct {
+	nc      *nats.Conn
+	logger  *slog.Logger
+	tracer  trace.Tracer
+	config  *config
+	timeout time.Duration
+	mux     *http.ServeMux
+	// methods lists the methods registered for each path.
+	methods    map[string][]string
+	events     *eventBroker
+	sessions   *sessionStore
+	telemetry  *metricReporter
+	eventLog   *eventLog
+	aggregator *aggregator
+}
+
+// newRedfishServer creates a Redfish server using nc to reach the backend
+// services, keeping its sessions in sessions.
+func newRedfishServer(nc *nats.Conn, logger *slog.Logger, cfg *config, sessions *sessionStore) *redfishServer {
+	s := &redfishServer{
+		nc:      nc,
+		logger:  logger,
+		tracer:  otel.Tracer("websrv"),
+		config:  cfg,
+		timeout: cfg.redfishTimeout,
+		mux:     http.NewServeMux(),
+		methods: make(map[string][]string),
+		events:  newEventBroker(nc, logger, cfg),
+
+		sessions:   sessions,
+		eventLog:   newEventLog(nc, logger, cfg),
+		aggregator: newAggregator(nc, logger, cfg),
+	}
+	s.telemetry = newMetricReporter(nc, logger, cfg, s.events, s.sampleMetrics)
+
+	s.mux.HandleFunc("/redfish/", s.handleUnknown)
+	s.handlePrivileged(http.MethodGet, "/redfish", privilegeNone, s.handleVersions)
+	s.handlePrivileged(http.MethodGet, redfishRoot, privilegeNone, s.handleServiceRoot)
+	s.registerMetadata()
+	s.registerSystems()
+	s.registerChassis()
+	s.registerSensors()
+	s.registerThermal()
+	s.registerPower()
+	s.registerManagers()
+	s.registerVirtualMedia()
+	s.registerLogServices()
+	s.registerEventService()
+	s.registerRegistries()
+	s.registerSessionService()
+	s.registerAccountService()
+	s.registerUpdateService()
+	s.registerTaskService()
+	s.registerTelemetryService()
+	s.registerAggregationService()
+
+	return s
+}
+
+// start starts the background work of the Redfish server, which stops when
+// ctx is canceled.
+func (s *redfishServer) start(ctx context.Context) error {
+	if err := s.events.start(ctx); err != nil {
+		return err
+	}
+	s.telemetry.start(ctx)
+	s.eventLog.start(ctx)
+	s.aggregator.start(ctx)
+	return nil
+}
+
+// handle registers handler for requests with the given method and path that
+// require the default privilege of the method.
+func (s *redfishServer) handle(method, path string, handler http.HandlerFunc) {
+	s.handlePrivileged(method, path, defaultPrivilege(method), handler)
+}
+
+// handlePrivileged registers handler for requests with the given method and
+// path from sessions whose role grants privilege. Other methods on the same
+// path are answered with 405 Method Not Allowed.
+func (s *redfishServer) handlePrivileged(method, path, privilege string, handler http.HandlerFunc) {
+	s.mux.HandleFunc(method+" "+path, s.authorize(privilege, handler))
+
+	if _, ok := s.methods[path]; !ok {
+		s.mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Allow", strings.Join(s.methods[path], ", "))
+			s.writeError(w, http.StatusMethodNotAllowed, "OperationNotAllowed",
+				fmt.Sprintf("The %s operation is not allowed on the resource at %s.", r.Method, r.URL.Path))
+		})
+	}
+	s.methods[path] = append(s.methods[path], method)
+}
+
+// ServeHTTP serves a Redfish request. Trailing slashes are ignored, so every
+// resource is reachable with and without one. Requests for aggregated
+// resources are forwarded to their downstream service as they are. The query
+// parameters of other GET requests are parsed up front and applied by
+// writeResource.
+func (s *redfishServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("OData-Version", odataVersion)
+
+	if path := strings.TrimRight(r.URL.Path, "/"); path != r.URL.Path {
+		r2 := new(http.Request)
+		*r2 = *r
+		r2.URL = new(url.URL)
+		*r2.URL = *r.URL
+		r2.URL.Path = path
+		r2.URL.RawPath = ""
+		r = r2
+	}
+
+	if _, _, ok := s.aggregator.resolve(r.URL.Path); ok {
+		s.authorize(defaultPrivilege(r.Method), s.handleAggregated)(w, r)
+		return
+	}
+
+	if r.Method == http.MethodGet {
+		q, qerr := parseQuery(r.URL.Query())
+		if qerr != nil {
+			s.writeError(w, qerr.status, qerr.messageID, qerr.message, qerr.args...)
+			return
+		}
+		if q != nil {
+			r = r.WithContext(context.WithValue(r.Context(), queryContextKey{}, q))
+		}
+	}
+
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *redfishServer) handleVersions(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", contentTypeJSON)
+	_ = json.NewEncoder(w).Encode(map[string]string{"v1": redfishRoot + "/"})
+}
+
+func (s *redfishServer) handleServiceRoot(w http.ResponseWriter, r *http.Request) {
+	root := &serviceRoot{
+		odataHeader:        odataHeader{ODataID: redfishRoot, ODataType: odataTypeServiceRoot},
+		ID:                 "RootService",
+		Name:               "Root Service",
+		RedfishVersion:     redfishVersion,
+		Product:            "u-bmc",
+		Vendor:             "u-bmc",
+		Systems:            link(systemsPath),
+		Chassis:            link(chassisPath),
+		Managers:           link(managersPath),
+		EventService:       link(eventServicePath),
+		Registries:         link(registriesPath),
+		SessionService:     link(sessionServicePath),
+		AccountService:     link(accountServicePath),
+		UpdateService:      link(updateServicePath),
+		Tasks:              link(taskServicePath),
+		TelemetryService:   link(telemetryServicePath),
+		AggregationService: link(aggregationServicePath),
+
+		ProtocolFeaturesSupported: newProtocolFeatures(),
+	}
+	root.Links.Sessions = link(sessionsPath)
+	s.writeResource(w, r, root)
+}
+
+func (s *redfishServer) handleUnknown(w http.ResponseWriter, r *http.Request) {
+	s.writeError(w, http.StatusNotFound, "ResourceMissingAtURI",
+		fmt.Sprintf("The resource at the URI %s was not found.", r.URL.Path), r.URL.Path)
+}
+
+// writeResource writes res as JSON with an entity tag computed over its
+// content. Requests whose If-None-Match header matches the tag are answered
+// with 304 Not Modified.
+func (s *redfishServer) writeResource(w http.ResponseWriter, r *http.Request, res resource) {
+	body, err := json.Marshal(res)
+	if err != nil {
+		s.writeInternalError(w, r, err)
+		return
+	}
+	if q := requestQuery(r); q != nil {
+		s.writeQueryResult(w, r, body, q)
+		return
+	}
+
+	etag := resourceETag(body)
+	res.setETag(etag)
+	if body, err = json.Marshal(res); err != nil {
+		s.writeInternalError(w, r, err)
+		return
+	}
+	s.writeTagged(w, r, etag, body)
+}
+
+// writeQueryResult writes the resource encoded in body after applying the
+// query parameters of the request.
+func (s *redfishServer) writeQueryResult(w http.ResponseWriter, r *http.Request, body []byte, q *redfishQuery) {
+	obj, err := decodeObject(body)
+	if err != nil {
+		s.writeInternalError(w, r, err)
+		return
+	}
+
+	if err := s.applyQuery(r, obj, q); err != nil {
+		var qerr *queryError
+		if errors.As(err, &qerr) {
+			s.writeError(w, qerr.status, qerr.messageID, qerr.message, qerr.args...)
+			return
+		}
+		s.writeInternalError(w, r, err)
+		return
+	}
+
+	delete(obj, "@odata.etag")
+	if body, err = json.Marshal(obj); err != nil {
+		s.writeInternalError(w, r, err)
+		return
+	}
+	etag := resourceETag(body)
+	obj["@odata.etag"] = etag
+	if body, err = json.Marshal(obj); err != nil {
+		s.writeInternalError(w, r, err)
+		return
+	}
+	s.writeTagged(w, r, etag, body)
+}
+
+// resourceETag computes the entity tag of an encoded resource.
+func resourceETag(body []byte) string {
+	return fmt.Sprintf(`W/"%08X"`, crc32.ChecksumIEEE(body))
+}
+
+// writeTagged writes body with the entity tag etag, or 304 Not Modified if
+// the If-None-Match header of the request matches the tag.
+func (s *redfishServer) writeTagged(w http.ResponseWriter, r *http.Request, etag string, body []byte) {
+	w.Header().Set("ETag", etag)
+	for _, match := range strings.Split(r.Header.Get("If-None-Match"), ",") {
+		if match = strings.TrimSpace(match); match == etag || match == "*" {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", contentTypeJSON)
+	_, _ = w.Write(body)
+}
+
+// writeError writes a Redfish error response carrying a single message of the
+// Base message registry.
+func (s *redfishServer) writeError(w http.ResponseWriter, status int, messageID, message string, args ...string) {
+	var body redfishError
+	body.Error.Code = redfishBaseRegistry + "." + messageID
+	body.Error.Message = message
+	body.Error.ExtendedInfo = []redfishMessage{{
+		ODataType:   redfishMessageType,
+		MessageID:   redfishBaseRegistry + "." + messageID,
+		Message:     message,
+		MessageArgs: args,
+		Severity:    "Critical",
+	}}
+	if status < http.StatusInternalServerError {
+		body.Error.ExtendedInfo[0].Severity = "Warning"
+	}
+
+	w.Header().Set("Content-Type", contentTypeJSON)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(&body)
+}
+
+// writeInternalError logs err and writes an internal error response.
+func (s *redfishServer) writeInternalError(w http.ResponseWriter, r *http.Request, err error) {
+	s.logger.ErrorContext(r.Context(), "Redfish request failed", "path", r.URL.Path, "error", err)
+	s.writeError(w, http.StatusInternalServerError, "InternalError",
+		"The request failed due to an internal service error. The service is still operational.")
+}
+
+// writeRequestError writes the response for a failed backend request about
+// the resource of the given type and ID.
+func (s *redfishServer) writeRequestError(w http.ResponseWriter, r *http.Request, err error, resourceType, id string) {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		s.writeError(w, http.StatusNotFound, "ResourceNotFound",
+			fmt.Sprintf("The requested resource of type %s named '%s' was not found.", resourceType, id), resourceType, id)
+	case errors.Is(err, ErrInvalidArgument):
+		s.logger.WarnContext(r.Context(), "Redfish request rejected", "path", r.URL.Path, "error", err)
+		s.writeError(w, http.StatusBadRequest, "GeneralError", messageGeneralError)
+	case errors.Is(err, ErrConflict):
+		s.logger.WarnContext(r.Context(), "Redfish request conflicts", "path", r.URL.Path, "error", err)
+		s.writeError(w, http.StatusConflict, "ResourceInUse",
+			"The change to the requested resource failed because the resource is in use or in transition.")
+	case errors.Is(err, nats.ErrNoResponders), errors.Is(err, context.DeadlineExceeded):
+		s.logger.WarnContext(r.Context(), "Redfish backend unavailable", "path", r.URL.Path, "error", err)
+		s.writeError(w, http.StatusServiceUnavailable, "ServiceTemporarilyUnavailable",
+			"The service is temporarily unavailable. Retry in 5 seconds.", "5")
+	default:
+		s.writeInternalError(w, r, err)
+	}
+}
+
+// readAction decodes the JSON body of an action request into v.
+func (s *redfishServer) readAction(w http.ResponseWriter, r *http.Request, v any) bool {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, redfishMaxBodySize))
+	if err == nil && len(body) > 0 {
+		err = json.Unmarshal(body, v)
+	}
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, "MalformedJSON",
+			"The request body submitted was malformed JSON and could not be parsed by the receiving service.")
+		return false
+	}
+	return true
+}
+
+// readProperties decodes the JSON object in the body of a POST or PATCH
+// request. Properties other than the writable ones are rejected, reporting
+// the readOnly ones as not writable and all others as unknown.
+func (s *redfishServer) readProperties(w http.ResponseWriter, r *http.Request, writable, readOnly []string) (map[string]json.RawMessage, bool) {
+	var props map[string]json.RawMessage
+	if !s.readAction(w, r, &props) {
+		return nil, false
+	}
+
+	for name := range props {
+		switch {
+		case slices.Contains(writable, name) || strings.HasPrefix(name, "@odata."):
+		case slices.Contains(readOnly, name):
+			s.writeError(w, http.StatusBadRequest, "PropertyNotWritable",
+				fmt.Sprintf("The property %s is a read only property and cannot be assigned a value.", name), name)
+			return nil, false
+		default:
+			s.writeError(w, http.StatusBadRequest, "PropertyUnknown",
+				fmt.Sprintf("The property %s is not in the list of valid properties for the resource.", name), name)
+			return nil, false
+		}
+	}
+	return props, true
+}
+
+// decodeProperty decodes the property name of props into v. Absent
+// properties leave v unchanged.
+func (s *redfishServer) decodeProperty(w http.ResponseWriter, props map[string]json.RawMessage, name string, v any) bool {
+	raw, ok := props[name]
+	if !ok {
+		return true
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		s.writeError(w, http.StatusBadRequest, "PropertyValueTypeError",
+			fmt.Sprintf("The value '%s' for the property %s is of a different type than the property can accept.", raw, name),
+			string(raw), name)
+		return false
+	}
+	return true
+}
+
+// checkPropertyValues checks that every value of a property is one of the
+// allowable values.
+func (s *redfishServer) checkPropertyValues(w http.ResponseWriter, name string, values, allowed []string) bool {
+	for _, v := range values {
+		if !slices.Contains(allowed, v) {
+			s.writeError(w, http.StatusBadRequest, "PropertyValueNotInList",
+				fmt.Sprintf("The value '%s' for the property %s is not in the list of acceptable values.", v, name), v, name)
+			return false
+		}
+	}
+	return true
+}
+
+// readResetType decodes the ResetType parameter of a Reset action and checks
+// it against the allowable values.
+func (s *redfishServer) readResetType(w http.ResponseWriter, r *http.Request, action string, allowed []string) (string, bool) {
+	var req resetRequest
+	if !s.readAction(w, r, &req) {
+		return "", false
+	}
+
+	if req.ResetType == "" {
+		s.writeError(w, http.StatusBadRequest, "ActionParameterMissing",
+			fmt.Sprintf("The action %s requires the parameter ResetType to be present in the request body.", action),
+			action, "ResetType")
+		return "", false
+	}
+	for _, t := range allowed {
+		if t == req.ResetType {
+			return req.ResetType, true
+		}
+	}
+
+	s.writeError(w, http.StatusBadRequest, "ActionParameterValueNotInList",
+		fmt.Sprintf("The value '%s' for the parameter ResetType in the action %s is not in the list of acceptable values.", req.ResetType, action),
+		req.ResetType, "ResetType", action)
+	return "", false
+}
+
+// requestNATS sends a protobuf request to a backend service and decodes its
+// response.
+func (s *redfishServer) requestNATS(ctx context.Context, subject string, req vtMessage, resp vtUnmarshaler) error {
+	data, err := req.MarshalVT()
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	data, err = s.request(ctx, subject, data)
+	if err != nil {
+		return err
+	}
+
+	if err := resp.UnmarshalVT(data); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return nil
+}
+
+// requestJSON sends a JSON request to a backend service and decodes its
+// response.
+func (s *redfishServer) requestJSON(ctx context.Context, subject string, req, resp any) error {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	data, err = s.request(ctx, subject, data)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(data, resp); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return nil
+}
+
+// request sends data to a backend service and returns its response, mapping
+// micro service error codes to errors.
+func (s *redfishServer) request(ctx context.Context, subject string, data []byte) ([]byte, error) {
+	ctx, span := s.tracer.Start(ctx, "redfishServer.request")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("nats.subject", subject))
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	msg, err := s.nc.RequestWithContext(ctx, subject, data)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("%w: %s: %w", ErrRequestFailed, subject, err)
+	}
+
+	if desc := msg.Header.Get(micro.ErrorHeader); desc != "" {
+		cause := ErrRequestFailed
+		switch msg.Header.Get(micro.ErrorCodeHeader) {
+		case "400":
+			cause = ErrInvalidArgument
+		case "404":
+			cause = ErrNotFound
+		case "409":
+			cause = ErrConflict
+		}
+		err := fmt.Errorf("%w: %s: %s", cause, subject, desc)
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return msg.Data, nil
+}
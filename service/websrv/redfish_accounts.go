@@ -0,0 +1,676 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package websrv
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	schemav1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+	"github.com/u-bmc/u-bmc/pkg/ipc"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// AccountService resource types.
+const (
+	odataTypeAccountService           = "#AccountService.v1_15_0.AccountService"
+	odataTypeManagerAccountCollection = "#ManagerAccountCollection.ManagerAccountCollection"
+	odataTypeManagerAccount           = "#ManagerAccount.v1_12_0.ManagerAccount"
+	odataTypeRoleCollection           = "#RoleCollection.RoleCollection"
+	odataTypeRole                     = "#Role.v1_3_2.Role"
+	accountServicePath                = redfishRoot + "/AccountService"
+	accountsPath                      = accountServicePath + "/Accounts"
+	rolesPath                         = accountServicePath + "/Roles"
+	accountResourceName               = "ManagerAccount"
+	roleResourceName                  = "Role"
+	accountTypeRedfish                = "Redfish"
+	minPasswordLength                 = 8
+	maxPasswordLength                 = 128
+	maxLockoutThreshold               = 999
+	propertyUserName                  = "UserName"
+	propertyPassword                  = "Password"
+	propertyRoleID                    = "RoleId"
+	propertyEnabled                   = "Enabled"
+	propertyLocked                    = "Locked"
+	propertyPasswordChangeRequired    = "PasswordChangeRequired"
+	propertyAccountTypes              = "AccountTypes"
+	propertyLockoutThreshold          = "AccountLockoutThreshold"
+	propertyLockoutDuration           = "AccountLockoutDuration"
+	propertyLockoutCounterResetAfter  = "AccountLockoutCounterResetAfter"
+)
+
+// accountService is the Redfish AccountService resource.
+type accountService struct {
+	odataHeader
+	ID                              string         `json:"Id"`
+	Name                            string         `json:"Name"`
+	ServiceEnabled                  bool           `json:"ServiceEnabled"`
+	MinPasswordLength               int            `json:"MinPasswordLength"`
+	MaxPasswordLength               int            `json:"MaxPasswordLength"`
+	AccountLockoutThreshold         int32          `json:"AccountLockoutThreshold"`
+	AccountLockoutDuration          int64          `json:"AccountLockoutDuration"`
+	AccountLockoutCounterResetAfter int64          `json:"AccountLockoutCounterResetAfter"`
+	Accounts                        odataLink      `json:"Accounts"`
+	Roles                           odataLink      `json:"Roles"`
+	Status                          resourceStatus `json:"Status"`
+}
+
+// managerAccount is the Redfish ManagerAccount resource.
+type managerAccount struct {
+	odataHeader
+	ID                     string   `json:"Id"`
+	Name                   string   `json:"Name"`
+	UserName               string   `json:"UserName"`
+	Password               *string  `json:"Password"`
+	RoleID                 string   `json:"RoleId"`
+	Enabled                bool     `json:"Enabled"`
+	Locked                 bool     `json:"Locked"`
+	LockedAllowableValues  []string `json:"Locked@Redfish.AllowableValues"`
+	PasswordChangeRequired bool     `json:"PasswordChangeRequired"`
+	AccountTypes           []string `json:"AccountTypes"`
+	Links                  struct {
+		Role odataLink `json:"Role"`
+	} `json:"Links"`
+}
+
+// roleResource is the Redfish Role resource.
+type roleResource struct {
+	odataHeader
+	ID                 string   `json:"Id"`
+	Name               string   `json:"Name"`
+	RoleID             string   `json:"RoleId"`
+	IsPredefined       bool     `json:"IsPredefined"`
+	AssignedPrivileges []string `json:"AssignedPrivileges"`
+}
+
+// lockoutPolicy is the account lockout policy of the AccountService. It is
+// stored as RedfishLockoutPolicy with every account, so usermgr enforces it
+// for all interfaces sharing the account database.
+type lockoutPolicy struct {
+	threshold  int32
+	duration   time.Duration
+	resetAfter time.Duration
+}
+
+// parseISODuration parses an ISO 8601 duration of days, hours, minutes and
+// seconds such as PT30S of RedfishLockoutPolicy or P1DT12H of a metric report
+// schedule. Malformed durations yield zero.
+func parseISODuration(s string) time.Duration {
+	rest, ok := strings.CutPrefix(s, "P")
+	if !ok || rest == "" || strings.HasSuffix(rest, "T") {
+		return 0
+	}
+
+	var d time.Duration
+	units := "DT"
+	for rest != "" {
+		if rest[0] == 'T' {
+			if units != "DT" && units != "T" {
+				return 0
+			}
+			units, rest = "HMS", rest[1:]
+			continue
+		}
+		i := strings.IndexFunc(rest, func(r rune) bool { return r < '0' || r > '9' })
+		if i <= 0 {
+			return 0
+		}
+		n, err := strconv.ParseUint(rest[:i], 10, 32)
+		unit := strings.IndexByte(units, rest[i])
+		if err != nil || unit < 0 || rest[i] == 'T' {
+			return 0
+		}
+		units, rest = units[unit+1:], rest[i+1:]
+
+		switch units {
+		case "T":
+			d += time.Duration(n) * 24 * time.Hour
+		case "MS":
+			d += time.Duration(n) * time.Hour
+		case "S":
+			d += time.Duration(n) * time.Minute
+		case "":
+			d += time.Duration(n) * time.Second
+		}
+	}
+	return d
+}
+
+// formatISODuration formats d in seconds, the form RedfishLockoutPolicy
+// expects.
+func formatISODuration(d time.Duration) string {
+	return fmt.Sprintf("PT%dS", int64(d/time.Second))
+}
+
+func newLockoutPolicy(p *schemav1alpha1.RedfishLockoutPolicy) lockoutPolicy {
+	return lockoutPolicy{
+		threshold:  p.GetThreshold(),
+		duration:   parseISODuration(p.GetDuration()),
+		resetAfter: parseISODuration(p.GetResetAfter()),
+	}
+}
+
+// proto returns the policy as stored with the accounts.
+func (p lockoutPolicy) proto() *schemav1alpha1.RedfishLockoutPolicy {
+	duration := formatISODuration(p.duration)
+	resetAfter := formatISODuration(p.resetAfter)
+	return &schemav1alpha1.RedfishLockoutPolicy{
+		Threshold:  p.threshold,
+		Duration:   &duration,
+		ResetAfter: &resetAfter,
+	}
+}
+
+// accountLockoutPolicy returns the lockout policy of the accounts, taken from
+// the first account that has one.
+func accountLockoutPolicy(users []*schemav1alpha1.User) lockoutPolicy {
+	for _, user := range users {
+		if p := user.GetRedfishInfo().GetLockoutPolicy(); p != nil {
+			return newLockoutPolicy(p)
+		}
+	}
+	return lockoutPolicy{}
+}
+
+// redfishAccountInfo returns a copy of the Redfish account information of a
+// user, filling in the role for users created through other interfaces.
+func redfishAccountInfo(user *schemav1alpha1.User) *schemav1alpha1.RedfishAccountInfo {
+	if info := user.GetRedfishInfo(); info != nil {
+		return info.CloneVT()
+	}
+	username := user.GetUsername()
+	return &schemav1alpha1.RedfishAccountInfo{
+		AccountId: &username,
+		RoleId:    accountRole(user),
+	}
+}
+
+func newManagerAccount(user *schemav1alpha1.User) *managerAccount {
+	role := accountRole(user)
+	acc := &managerAccount{
+		odataHeader:            odataHeader{ODataID: accountsPath + "/" + user.GetUsername(), ODataType: odataTypeManagerAccount},
+		ID:                     user.GetUsername(),
+		Name:                   "User Account",
+		UserName:               user.GetUsername(),
+		RoleID:                 role,
+		Enabled:                user.GetEnabled(),
+		Locked:                 user.GetAuthData().GetLockoutInfo().GetLocked(),
+		LockedAllowableValues:  []string{"false"},
+		PasswordChangeRequired: user.GetRedfishInfo().GetPasswordChangeRequired(),
+		AccountTypes:           []string{accountTypeRedfish},
+	}
+	acc.Links.Role = link(rolesPath + "/" + role)
+	return acc
+}
+
+func newRoleResource(id string) *roleResource {
+	return &roleResource{
+		odataHeader:        odataHeader{ODataID: rolesPath + "/" + id, ODataType: odataTypeRole},
+		ID:                 id,
+		Name:               "User Role",
+		RoleID:             id,
+		IsPredefined:       true,
+		AssignedPrivileges: rolePrivileges()[id],
+	}
+}
+
+func (s *redfishServer) registerAccountService() {
+	s.handle(http.MethodGet, accountServicePath, s.handleAccountService)
+	s.handlePrivileged(http.MethodPatch, accountServicePath, privilegeConfigureUsers, s.handlePatchAccountService)
+	s.handle(http.MethodGet, accountsPath, s.handleAccounts)
+	s.handlePrivileged(http.MethodPost, accountsPath, privilegeConfigureUsers, s.handleCreateAccount)
+	s.handlePrivileged(http.MethodGet, accountsPath+"/{id}", privilegeConfigureSelf, s.handleAccount)
+	s.handlePrivileged(http.MethodPatch, accountsPath+"/{id}", privilegeConfigureSelf, s.handlePatchAccount)
+	s.handlePrivileged(http.MethodDelete, accountsPath+"/{id}", privilegeConfigureUsers, s.handleDeleteAccount)
+	s.handle(http.MethodGet, rolesPath, s.handleRoles)
+	s.handle(http.MethodGet, rolesPath+"/{id}", s.handleRole)
+}
+
+// listUsers returns all usermgr users.
+func (s *redfishServer) listUsers(ctx context.Context) ([]*schemav1alpha1.User, error) {
+	var resp schemav1alpha1.ListUsersResponse
+	if err := s.requestNATS(ctx, ipc.SubjectUserList, &schemav1alpha1.ListUsersRequest{}, &resp); err != nil {
+		return nil, err
+	}
+	return resp.GetUsers(), nil
+}
+
+// updateUser writes the given fields of user to usermgr.
+func (s *redfishServer) updateUser(ctx context.Context, user *schemav1alpha1.User, paths ...string) error {
+	user.UpdatedAt = timestamppb.Now()
+	return s.requestNATS(ctx, ipc.SubjectUserUpdate, &schemav1alpha1.UpdateUserRequest{
+		User:      user,
+		FieldMask: &fieldmaskpb.FieldMask{Paths: append(paths, "updated_at")},
+	}, &schemav1alpha1.UpdateUserResponse{})
+}
+
+func (s *redfishServer) handleAccountService(w http.ResponseWriter, r *http.Request) {
+	users, err := s.listUsers(r.Context())
+	if err != nil {
+		s.writeRequestError(w, r, err, "AccountService", "AccountService")
+		return
+	}
+	s.writeAccountService(w, r, accountLockoutPolicy(users))
+}
+
+func (s *redfishServer) writeAccountService(w http.ResponseWriter, r *http.Request, policy lockoutPolicy) {
+	s.writeResource(w, r, &accountService{
+		odataHeader:                     odataHeader{ODataID: accountServicePath, ODataType: odataTypeAccountService},
+		ID:                              "AccountService",
+		Name:                            "Account Service",
+		ServiceEnabled:                  true,
+		MinPasswordLength:               minPasswordLength,
+		MaxPasswordLength:               maxPasswordLength,
+		AccountLockoutThreshold:         policy.threshold,
+		AccountLockoutDuration:          int64(policy.duration / time.Second),
+		AccountLockoutCounterResetAfter: int64(policy.resetAfter / time.Second),
+		Accounts:                        link(accountsPath),
+		Roles:                           link(rolesPath),
+		Status:                          resourceStatus{State: "Enabled", Health: "OK"},
+	})
+}
+
+// writeOutOfRange writes the response for a property value outside the
+// supported range.
+func (s *redfishServer) writeOutOfRange(w http.ResponseWriter, name string, value any) {
+	v := fmt.Sprint(value)
+	s.writeError(w, http.StatusBadRequest, "PropertyValueOutOfRange",
+		fmt.Sprintf("The value '%s' for the property %s is not in the supported range of acceptable values.", v, name), v, name)
+}
+
+func (s *redfishServer) handlePatchAccountService(w http.ResponseWriter, r *http.Request) {
+	props, ok := s.readProperties(w, r,
+		[]string{propertyLockoutThreshold, propertyLockoutDuration, propertyLockoutCounterResetAfter},
+		[]string{"Id", "Name", "ServiceEnabled", "MinPasswordLength", "MaxPasswordLength", "Accounts", "Roles", "Status"})
+	if !ok {
+		return
+	}
+
+	users, err := s.listUsers(r.Context())
+	if err != nil {
+		s.writeRequestError(w, r, err, "AccountService", "AccountService")
+		return
+	}
+	policy := accountLockoutPolicy(users)
+
+	duration := int64(policy.duration / time.Second)
+	resetAfter := int64(policy.resetAfter / time.Second)
+	if !s.decodeProperty(w, props, propertyLockoutThreshold, &policy.threshold) ||
+		!s.decodeProperty(w, props, propertyLockoutDuration, &duration) ||
+		!s.decodeProperty(w, props, propertyLockoutCounterResetAfter, &resetAfter) {
+		return
+	}
+	switch {
+	case policy.threshold < 0 || policy.threshold > maxLockoutThreshold:
+		s.writeOutOfRange(w, propertyLockoutThreshold, int64(policy.threshold))
+		return
+	case duration < 0:
+		s.writeOutOfRange(w, propertyLockoutDuration, duration)
+		return
+	case resetAfter < 0:
+		s.writeOutOfRange(w, propertyLockoutCounterResetAfter, resetAfter)
+		return
+	case duration > 0 && resetAfter > duration:
+		s.writeError(w, http.StatusBadRequest, "PropertyValueConflict",
+			fmt.Sprintf("The property '%s' could not be written because its value would conflict with the value of the '%s' property.",
+				propertyLockoutCounterResetAfter, propertyLockoutDuration),
+			propertyLockoutCounterResetAfter, propertyLockoutDuration)
+		return
+	}
+	policy.duration = time.Duration(duration) * time.Second
+	policy.resetAfter = time.Duration(resetAfter) * time.Second
+
+	for _, user := range users {
+		info := redfishAccountInfo(user)
+		info.LockoutPolicy = policy.proto()
+		if err := s.updateUser(r.Context(), &schemav1alpha1.User{Id: user.GetId(), RedfishInfo: info}, "redfish_info"); err != nil {
+			s.writeRequestError(w, r, err, accountResourceName, user.GetUsername())
+			return
+		}
+	}
+
+	s.logger.InfoContext(r.Context(), "Redfish account lockout policy changed",
+		"threshold", policy.threshold,
+		"duration", policy.duration,
+		"reset_after", policy.resetAfter)
+
+	s.writeAccountService(w, r, policy)
+}
+
+func (s *redfishServer) handleAccounts(w http.ResponseWriter, r *http.Request) {
+	users, err := s.listUsers(r.Context())
+	if err != nil {
+		s.writeRequestError(w, r, err, accountResourceName, "")
+		return
+	}
+
+	sess := requestSession(r)
+	ids := make([]string, 0, len(users))
+	for _, user := range users {
+		if sess.ownsAccount(user) || sess.hasPrivilege(privilegeConfigureUsers) {
+			ids = append(ids, user.GetUsername())
+		}
+	}
+	s.writeResource(w, r, newCollection(accountsPath, odataTypeManagerAccountCollection, "Accounts Collection", ids))
+}
+
+// checkPassword checks a new password against the length limits.
+func (s *redfishServer) checkPassword(w http.ResponseWriter, password string) bool {
+	if len(password) < minPasswordLength || len(password) > maxPasswordLength {
+		s.writeError(w, http.StatusBadRequest, "PropertyValueFormatError",
+			fmt.Sprintf("The value provided for the property %s is not in a valid format. It must have between %d and %d characters.",
+				propertyPassword, minPasswordLength, maxPasswordLength), "******", propertyPassword)
+		return false
+	}
+	return true
+}
+
+// checkLocked rejects attempts to lock an account, which only the lockout
+// policy does.
+func (s *redfishServer) checkLocked(w http.ResponseWriter, locked bool) bool {
+	if locked {
+		s.writeError(w, http.StatusBadRequest, "PropertyValueNotInList",
+			fmt.Sprintf("The value '%t' for the property %s is not in the list of acceptable values.", locked, propertyLocked),
+			strconv.FormatBool(locked), propertyLocked)
+		return false
+	}
+	return true
+}
+
+func (s *redfishServer) handleCreateAccount(w http.ResponseWriter, r *http.Request) {
+	props, ok := s.readProperties(w, r,
+		[]string{propertyUserName, propertyPassword, propertyRoleID, propertyEnabled, propertyLocked,
+			propertyPasswordChangeRequired, propertyAccountTypes},
+		[]string{"Id", "Name", "Links"})
+	if !ok {
+		return
+	}
+
+	for _, name := range []string{propertyUserName, propertyPassword, propertyRoleID} {
+		if _, ok := props[name]; !ok {
+			s.writeError(w, http.StatusBadRequest, "PropertyMissing",
+				fmt.Sprintf("The property %s is a required property and must be included in the request.", name), name)
+			return
+		}
+	}
+
+	var (
+		username, password, role string
+		locked, changeRequired   bool
+		accountTypes             []string
+	)
+	enabled := true
+	if !s.decodeProperty(w, props, propertyUserName, &username) ||
+		!s.decodeProperty(w, props, propertyPassword, &password) ||
+		!s.decodeProperty(w, props, propertyRoleID, &role) ||
+		!s.decodeProperty(w, props, propertyEnabled, &enabled) ||
+		!s.decodeProperty(w, props, propertyLocked, &locked) ||
+		!s.decodeProperty(w, props, propertyPasswordChangeRequired, &changeRequired) ||
+		!s.decodeProperty(w, props, propertyAccountTypes, &accountTypes) {
+		return
+	}
+	if !s.checkPropertyValues(w, propertyRoleID, []string{role}, roleIDs()) ||
+		!s.checkPropertyValues(w, propertyAccountTypes, accountTypes, []string{accountTypeRedfish}) ||
+		!s.checkPassword(w, password) || !s.checkLocked(w, locked) {
+		return
+	}
+
+	_, err := s.lookupUser(r.Context(), username)
+	switch {
+	case err == nil:
+		s.writeError(w, http.StatusConflict, "ResourceAlreadyExists",
+			fmt.Sprintf("The requested resource of type %s with the property %s with the value '%s' already exists.",
+				accountResourceName, propertyUserName, username), accountResourceName, propertyUserName, username)
+		return
+	case !errors.Is(err, ErrNotFound):
+		s.writeRequestError(w, r, err, accountResourceName, username)
+		return
+	}
+
+	users, err := s.listUsers(r.Context())
+	if err != nil {
+		s.writeRequestError(w, r, err, accountResourceName, username)
+		return
+	}
+
+	now := timestamppb.Now()
+	var resp schemav1alpha1.CreateUserResponse
+	if err := s.requestNATS(r.Context(), ipc.SubjectUserCreate, &schemav1alpha1.CreateUserRequest{
+		User: &schemav1alpha1.User{
+			Username:          username,
+			Enabled:           enabled,
+			CreatedAt:         now,
+			UpdatedAt:         now,
+			SourceSystem:      schemav1alpha1.UserSource_USER_SOURCE_REDFISH,
+			CreationInterface: schemav1alpha1.UserCreationInterface_USER_CREATION_INTERFACE_REDFISH_API,
+			RedfishInfo: &schemav1alpha1.RedfishAccountInfo{
+				AccountId:              &username,
+				RoleId:                 role,
+				LockoutPolicy:          accountLockoutPolicy(users).proto(),
+				PasswordChangeRequired: &changeRequired,
+			},
+		},
+		Password: &password,
+	}, &resp); err != nil {
+		s.writeRequestError(w, r, err, accountResourceName, username)
+		return
+	}
+
+	s.logger.InfoContext(r.Context(), "Redfish account created",
+		"user", username,
+		"role", role,
+		"by", requestSession(r).username)
+
+	acc := newManagerAccount(resp.GetUser())
+	w.Header().Set("Location", acc.ODataID)
+	w.Header().Set("Content-Type", contentTypeJSON)
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(acc)
+}
+
+// accountOf returns the user of the account with the given ID if the session
+// of r may access it, which requires it to be the session's own account or
+// the ConfigureUsers privilege. Otherwise it writes an error response.
+func (s *redfishServer) accountOf(w http.ResponseWriter, r *http.Request, id string) (*schemav1alpha1.User, bool) {
+	sess := requestSession(r)
+	if sess.hasPrivilege(privilegeConfigureUsers) {
+		user, err := s.lookupUser(r.Context(), id)
+		if err != nil {
+			s.writeRequestError(w, r, err, accountResourceName, id)
+			return nil, false
+		}
+		return user, true
+	}
+
+	// Sessions without a usermgr account own none, and the accounts of
+	// others are refused alike whether they exist or not.
+	if sess.origin != originAccount {
+		s.writeForbidden(w)
+		return nil, false
+	}
+	user, err := s.lookupUser(r.Context(), id)
+	if errors.Is(err, ErrNotFound) || err == nil && !sess.ownsAccount(user) {
+		s.writeForbidden(w)
+		return nil, false
+	}
+	if err != nil {
+		s.writeRequestError(w, r, err, accountResourceName, id)
+		return nil, false
+	}
+	return user, true
+}
+
+func (s *redfishServer) handleAccount(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.accountOf(w, r, r.PathValue("id"))
+	if !ok {
+		return
+	}
+	s.writeResource(w, r, newManagerAccount(user))
+}
+
+func (s *redfishServer) handlePatchAccount(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	user, ok := s.accountOf(w, r, id)
+	if !ok {
+		return
+	}
+
+	props, ok := s.readProperties(w, r,
+		[]string{propertyUserName, propertyPassword, propertyRoleID, propertyEnabled, propertyLocked, propertyPasswordChangeRequired},
+		[]string{"Id", "Name", propertyAccountTypes, "Links"})
+	if !ok {
+		return
+	}
+
+	// Without the ConfigureUsers privilege, users may only change their own password.
+	sess := requestSession(r)
+	if !sess.hasPrivilege(privilegeConfigureUsers) {
+		for name := range props {
+			if name != propertyPassword && !strings.HasPrefix(name, "@odata.") {
+				s.writeForbidden(w)
+				return
+			}
+		}
+	}
+
+	var (
+		password string
+		locked   bool
+	)
+	update := &schemav1alpha1.User{
+		Id:          user.GetId(),
+		Username:    user.GetUsername(),
+		Enabled:     user.GetEnabled(),
+		RedfishInfo: redfishAccountInfo(user),
+	}
+	changeRequired := update.GetRedfishInfo().GetPasswordChangeRequired()
+	if !s.decodeProperty(w, props, propertyUserName, &update.Username) ||
+		!s.decodeProperty(w, props, propertyPassword, &password) ||
+		!s.decodeProperty(w, props, propertyRoleID, &update.RedfishInfo.RoleId) ||
+		!s.decodeProperty(w, props, propertyEnabled, &update.Enabled) ||
+		!s.decodeProperty(w, props, propertyLocked, &locked) ||
+		!s.decodeProperty(w, props, propertyPasswordChangeRequired, &changeRequired) {
+		return
+	}
+	if !s.checkPropertyValues(w, propertyRoleID, []string{update.GetRedfishInfo().GetRoleId()}, roleIDs()) ||
+		!s.checkLocked(w, locked) {
+		return
+	}
+
+	var paths []string
+	if _, ok := props[propertyUserName]; ok && update.GetUsername() != user.GetUsername() {
+		if _, err := s.lookupUser(r.Context(), update.GetUsername()); err == nil {
+			s.writeError(w, http.StatusConflict, "ResourceAlreadyExists",
+				fmt.Sprintf("The requested resource of type %s with the property %s with the value '%s' already exists.",
+					accountResourceName, propertyUserName, update.GetUsername()), accountResourceName, propertyUserName, update.GetUsername())
+			return
+		}
+		update.RedfishInfo.AccountId = &update.Username
+		paths = append(paths, "username")
+	}
+	if _, ok := props[propertyEnabled]; ok {
+		paths = append(paths, "enabled")
+	}
+	if _, ok := props[propertyLocked]; ok {
+		update.AuthData = &schemav1alpha1.AuthenticationData{LockoutInfo: &schemav1alpha1.AccountLockoutInfo{}}
+		paths = append(paths, "auth_data.lockout_info")
+	}
+
+	if _, ok := props[propertyPassword]; ok {
+		if !s.checkPassword(w, password) {
+			return
+		}
+		// Users changing their own password are held to the whole password
+		// policy. Administrators resetting another account skip only the
+		// history check, which is not theirs to pass.
+		reset := &schemav1alpha1.ResetPasswordRequest{
+			Id:          user.GetId(),
+			NewPassword: &password,
+		}
+		if !sess.ownsAccount(user) {
+			force := true
+			reset.Force = &force
+		}
+		var resp schemav1alpha1.ResetPasswordResponse
+		if err := s.requestNATS(r.Context(), ipc.SubjectUserResetPassword, reset, &resp); err != nil {
+			s.writeRequestError(w, r, err, accountResourceName, id)
+			return
+		}
+		if !resp.GetSuccess() {
+			s.writeError(w, http.StatusBadRequest, "PropertyValueFormatError",
+				fmt.Sprintf("The value provided for the property %s is not in a valid format: %s.",
+					propertyPassword, resp.GetFailureReason()), "******", propertyPassword)
+			return
+		}
+		// Changing the password satisfies a pending change requirement
+		// unless the request sets it again.
+		if _, ok := props[propertyPasswordChangeRequired]; !ok {
+			changeRequired = false
+		}
+		s.logger.InfoContext(r.Context(), "Redfish account password changed", "user", user.GetUsername(), "by", sess.username)
+	}
+	update.RedfishInfo.PasswordChangeRequired = &changeRequired
+	paths = append(paths, "redfish_info")
+
+	if err := s.updateUser(r.Context(), update, paths...); err != nil {
+		s.writeRequestError(w, r, err, accountResourceName, id)
+		return
+	}
+
+	// Close the sessions of accounts that were renamed, disabled or got a
+	// different role, so they authenticate again with the new settings.
+	if update.GetUsername() != user.GetUsername() || !update.GetEnabled() ||
+		update.GetRedfishInfo().GetRoleId() != accountRole(user) {
+		s.sessions.removeUser(accountOwner(user.GetId()))
+	} else if !changeRequired {
+		s.sessions.passwordChanged(accountOwner(user.GetId()))
+	}
+
+	updated, err := s.lookupUser(r.Context(), update.GetUsername())
+	if err != nil {
+		s.writeRequestError(w, r, err, accountResourceName, update.GetUsername())
+		return
+	}
+	s.writeResource(w, r, newManagerAccount(updated))
+}
+
+func (s *redfishServer) handleDeleteAccount(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	user, err := s.lookupUser(r.Context(), id)
+	if err != nil {
+		s.writeRequestError(w, r, err, accountResourceName, id)
+		return
+	}
+	if err := s.requestNATS(r.Context(), ipc.SubjectUserDelete, &schemav1alpha1.DeleteUserRequest{Id: user.GetId()},
+		&schemav1alpha1.DeleteUserResponse{}); err != nil {
+		s.writeRequestError(w, r, err, accountResourceName, id)
+		return
+	}
+	s.sessions.removeUser(accountOwner(user.GetId()))
+
+	s.logger.InfoContext(r.Context(), "Redfish account deleted", "user", id, "by", requestSession(r).username)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *redfishServer) handleRoles(w http.ResponseWriter, r *http.Request) {
+	s.writeResource(w, r, newCollection(rolesPath, odataTypeRoleCollection, "Roles Collection", roleIDs()))
+}
+
+func (s *redfishServer) handleRole(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if _, ok := rolePrivileges()[id]; !ok {
+		s.writeRequestError(w, r, ErrNotFound, roleResourceName, id)
+		return
+	}
+	s.writeResource(w, r, newRoleResource(id))
+}
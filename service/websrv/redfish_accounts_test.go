@@ -0,0 +1,183 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package websrv
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+)
+
+// ssoLogin stores a single sign-on session as the OpenID relying party does
+// and returns its token.
+func ssoLogin(t *testing.T, rs *redfishServer, username, role string) string {
+	t.Helper()
+
+	sess := &redfishSession{origin: originSingleSignOn, username: username, role: role, sessionType: sessionTypeWebUI}
+	if err := rs.sessions.add(sess); err != nil {
+		t.Fatalf("add session: %v", err)
+	}
+	return sess.token
+}
+
+// collectionMembers returns the member links of the collection at path.
+func collectionMembers(t *testing.T, srv *httptest.Server, path, token string) []string {
+	t.Helper()
+
+	resp, data := testRequest{method: http.MethodGet, path: path, token: token}.do(t, srv)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET %s = %s %s", path, resp.Status, data)
+	}
+	var coll resourceCollection
+	if err := json.Unmarshal(data, &coll); err != nil {
+		t.Fatal(err)
+	}
+	links := make([]string, 0, len(coll.Members))
+	for _, m := range coll.Members {
+		links = append(links, m.ODataID)
+	}
+	return links
+}
+
+func TestRedfishAccountOwnership(t *testing.T) {
+	rs, srv := newTestRedfish(t)
+	readerToken, _ := login(t, srv, "reader")
+	adminToken, _ := login(t, srv, "admin")
+	// A single sign-on user that happens to share the name of an account.
+	ssoToken := ssoLogin(t, rs, "reader", roleReadOnly)
+
+	tests := []struct {
+		name  string
+		token string
+		path  string
+		want  int
+	}{
+		{name: "own account", token: readerToken, path: accountsPath + "/reader", want: http.StatusOK},
+		{name: "other account", token: readerToken, path: accountsPath + "/admin", want: http.StatusForbidden},
+		{name: "missing account", token: readerToken, path: accountsPath + "/nobody", want: http.StatusForbidden},
+		{name: "missing account with ConfigureUsers", token: adminToken, path: accountsPath + "/nobody", want: http.StatusNotFound},
+		{name: "other account with ConfigureUsers", token: adminToken, path: accountsPath + "/reader", want: http.StatusOK},
+		{name: "single sign-on user of the same name", token: ssoToken, path: accountsPath + "/reader", want: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, data := testRequest{method: http.MethodGet, path: tt.path, token: tt.token}.do(t, srv)
+			if resp.StatusCode != tt.want {
+				t.Errorf("GET %s = %s %s, want %d", tt.path, resp.Status, data, tt.want)
+			}
+		})
+	}
+
+	t.Run("password of a single sign-on user of the same name", func(t *testing.T) {
+		resp, data := testRequest{
+			method: http.MethodPatch,
+			path:   accountsPath + "/reader",
+			body:   `{"Password": "Tr0ub4dor&3"}`,
+			token:  ssoToken,
+		}.do(t, srv)
+		if resp.StatusCode != http.StatusForbidden {
+			t.Errorf("PATCH = %s %s, want 403", resp.Status, data)
+		}
+	})
+
+	t.Run("collection", func(t *testing.T) {
+		if got := collectionMembers(t, srv, accountsPath, readerToken); !slices.Equal(got, []string{accountsPath + "/reader"}) {
+			t.Errorf("accounts visible to ReadOnly = %v, want only its own", got)
+		}
+		if got := collectionMembers(t, srv, accountsPath, ssoToken); len(got) != 0 {
+			t.Errorf("accounts visible to a single sign-on user = %v, want none", got)
+		}
+		if got := collectionMembers(t, srv, accountsPath, adminToken); len(got) != len(testAccounts()) {
+			t.Errorf("accounts visible to Administrator = %v, want all", got)
+		}
+	})
+
+	t.Run("sessions", func(t *testing.T) {
+		if got := collectionMembers(t, srv, sessionsPath, readerToken); len(got) != 1 {
+			t.Errorf("sessions visible to ReadOnly = %v, want only its own", got)
+		}
+		if got := collectionMembers(t, srv, sessionsPath, ssoToken); len(got) != 1 {
+			t.Errorf("sessions visible to a single sign-on user = %v, want only its own", got)
+		}
+	})
+}
+
+func TestSessionStoreOwners(t *testing.T) {
+	st := newSessionStore(time.Hour)
+	local := &redfishSession{origin: originAccount, userID: "id-alice", username: "alice", passwordChangeRequired: true}
+	// The account was renamed after this session was opened.
+	renamed := &redfishSession{origin: originAccount, userID: "id-alice", username: "alicia", passwordChangeRequired: true}
+	sso := &redfishSession{origin: originSingleSignOn, username: "alice", passwordChangeRequired: true}
+	other := &redfishSession{origin: originAccount, userID: "id-bob", username: "bob", passwordChangeRequired: true}
+	for _, sess := range []*redfishSession{local, renamed, sso, other} {
+		if err := st.add(sess); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := []string{local.id, renamed.id}
+	slices.Sort(want)
+	if got := st.ids(local, false); !slices.Equal(got, want) {
+		t.Errorf("sessions of the account = %v, want %v", got, want)
+	}
+
+	st.passwordChanged(accountOwner("id-alice"))
+	for _, sess := range []*redfishSession{local, renamed, sso, other} {
+		got, _ := st.get(sess.id)
+		if want := sess.origin != originAccount || sess.userID != "id-alice"; got.passwordChangeRequired != want {
+			t.Errorf("session of %s/%s requires a password change = %v, want %v", sess.origin, sess.username, got.passwordChangeRequired, want)
+		}
+	}
+
+	st.removeUser(accountOwner("id-alice"))
+	for _, sess := range []*redfishSession{local, renamed, sso, other} {
+		_, ok := st.get(sess.id)
+		if want := sess.origin != originAccount || sess.userID != "id-alice"; ok != want {
+			t.Errorf("session of %s/%s open = %v, want %v", sess.origin, sess.username, ok, want)
+		}
+	}
+}
+
+func TestRedfishAccountPasswordReuse(t *testing.T) {
+	_, srv := newTestRedfish(t)
+	readerToken, _ := login(t, srv, "reader")
+	adminToken, _ := login(t, srv, "admin")
+
+	tests := []struct {
+		name      string
+		token     string
+		password  string
+		want      int
+		wantError string
+	}{
+		{name: "own current password", token: readerToken, password: "reader-pw", want: http.StatusBadRequest, wantError: "PropertyValueFormatError"},
+		{name: "own recent password", token: readerToken, password: "reader-old-pw", want: http.StatusBadRequest, wantError: "PropertyValueFormatError"},
+		{name: "own new password", token: readerToken, password: "Tr0ub4dor&3", want: http.StatusOK},
+		// Administrators resetting another account do not know its history.
+		{name: "current password of another account", token: adminToken, password: "reader-pw", want: http.StatusOK},
+		{name: "recent password of another account", token: adminToken, password: "reader-old-pw", want: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, data := testRequest{
+				method: http.MethodPatch,
+				path:   accountsPath + "/reader",
+				body:   `{"Password": "` + tt.password + `"}`,
+				token:  tt.token,
+			}.do(t, srv)
+			if resp.StatusCode != tt.want {
+				t.Fatalf("PATCH = %s %s, want %d", resp.Status, data, tt.want)
+			}
+			if tt.wantError != "" {
+				if got := errorMessageID(t, data); got != tt.wantError {
+					t.Errorf("error = %s, want %s", got, tt.wantError)
+				}
+			}
+		})
+	}
+}
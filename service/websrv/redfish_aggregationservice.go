@@ -0,0 +1,230 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package websrv
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// AggregationService resource types.
+const (
+	odataTypeAggregationService          = "#AggregationService.v1_0_3.AggregationService"
+	odataTypeAggregationSourceCollection = "#AggregationSourceCollection.AggregationSourceCollection"
+	odataTypeAggregationSource           = "#AggregationSource.v1_4_3.AggregationSource"
+	aggregationServicePath               = redfishRoot + "/AggregationService"
+	aggregationSourcesPath               = aggregationServicePath + "/AggregationSources"
+	aggregationSourceResourceName        = "AggregationSource"
+	propertyHostName                     = "HostName"
+)
+
+// aggregationService is the Redfish AggregationService resource.
+type aggregationService struct {
+	odataHeader
+	ID                 string         `json:"Id"`
+	Name               string         `json:"Name"`
+	Description        string         `json:"Description"`
+	ServiceEnabled     bool           `json:"ServiceEnabled"`
+	Status             resourceStatus `json:"Status"`
+	AggregationSources odataLink      `json:"AggregationSources"`
+}
+
+// aggregationSourceResource is the Redfish AggregationSource resource.
+type aggregationSourceResource struct {
+	odataHeader
+	ID       string         `json:"Id"`
+	Name     string         `json:"Name"`
+	HostName string         `json:"HostName"`
+	UserName string         `json:"UserName"`
+	Password *string        `json:"Password"`
+	Status   resourceStatus `json:"Status"`
+	Links    struct {
+		ResourcesAccessed []odataLink `json:"ResourcesAccessed"`
+	} `json:"Links"`
+}
+
+// newAggregationSourceResource returns the Redfish representation of a
+// source. The password is write-only and always returned as null.
+func newAggregationSourceResource(as *aggregatedSource) *aggregationSourceResource {
+	src := &as.source
+	res := &aggregationSourceResource{
+		odataHeader: odataHeader{
+			ODataID:   aggregationSourcesPath + "/" + url.PathEscape(src.ID),
+			ODataType: odataTypeAggregationSource,
+		},
+		ID:       src.ID,
+		Name:     "Aggregation Source " + src.ID,
+		HostName: src.HostName,
+		UserName: src.UserName,
+		Status:   resourceStatus{State: "Enabled", Health: as.health()},
+	}
+	res.Links.ResourcesAccessed = make([]odataLink, 0, len(as.systems)+len(as.chassis))
+	for _, id := range as.systems {
+		res.Links.ResourcesAccessed = append(res.Links.ResourcesAccessed, link(systemsPath+"/"+url.PathEscape(src.prefix()+id)))
+	}
+	for _, id := range as.chassis {
+		res.Links.ResourcesAccessed = append(res.Links.ResourcesAccessed, link(chassisPath+"/"+url.PathEscape(src.prefix()+id)))
+	}
+	return res
+}
+
+// normalizeHostName turns the HostName of a source into the base URL of the
+// downstream service. Host names without a scheme use HTTPS.
+func normalizeHostName(hostName string) (string, bool) {
+	if !strings.Contains(hostName, "://") {
+		hostName = "https://" + hostName
+	}
+	u, err := url.Parse(hostName)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" ||
+		strings.Trim(u.Path, "/") != "" || u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+		return "", false
+	}
+	return u.Scheme + "://" + u.Host, true
+}
+
+func (s *redfishServer) registerAggregationService() {
+	s.handle(http.MethodGet, aggregationServicePath, s.handleAggregationService)
+	s.handle(http.MethodGet, aggregationSourcesPath, s.handleAggregationSources)
+	s.handlePrivileged(http.MethodPost, aggregationSourcesPath, privilegeConfigureManager, s.handleCreateAggregationSource)
+	s.handle(http.MethodGet, aggregationSourcesPath+"/{id}", s.handleAggregationSource)
+	s.handlePrivileged(http.MethodPatch, aggregationSourcesPath+"/{id}", privilegeConfigureManager, s.handlePatchAggregationSource)
+	s.handlePrivileged(http.MethodDelete, aggregationSourcesPath+"/{id}", privilegeConfigureManager, s.handleDeleteAggregationSource)
+}
+
+func (s *redfishServer) handleAggregationService(w http.ResponseWriter, r *http.Request) {
+	s.writeResource(w, r, &aggregationService{
+		odataHeader:        odataHeader{ODataID: aggregationServicePath, ODataType: odataTypeAggregationService},
+		ID:                 "AggregationService",
+		Name:               "Aggregation Service",
+		Description:        "Aggregates the Systems and Chassis of downstream Redfish services",
+		ServiceEnabled:     true,
+		Status:             resourceStatus{State: "Enabled", Health: "OK"},
+		AggregationSources: link(aggregationSourcesPath),
+	})
+}
+
+func (s *redfishServer) handleAggregationSources(w http.ResponseWriter, r *http.Request) {
+	s.writeResource(w, r, newCollection(aggregationSourcesPath, odataTypeAggregationSourceCollection,
+		"Aggregation Source Collection", s.aggregator.sourceIDs()))
+}
+
+func (s *redfishServer) handleAggregationSource(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	as, ok := s.aggregator.source(id)
+	if !ok {
+		s.writeRequestError(w, r, ErrNotFound, aggregationSourceResourceName, id)
+		return
+	}
+
+	s.writeResource(w, r, newAggregationSourceResource(&as))
+}
+
+func (s *redfishServer) handleCreateAggregationSource(w http.ResponseWriter, r *http.Request) {
+	props, ok := s.readProperties(w, r, []string{propertyHostName, propertyUserName, propertyPassword},
+		[]string{"Id", "Name", "Status", "Links"})
+	if !ok {
+		return
+	}
+
+	if _, ok := props[propertyHostName]; !ok {
+		s.writeError(w, http.StatusBadRequest, "PropertyMissing",
+			"The property HostName is a required property and must be included in the request.", propertyHostName)
+		return
+	}
+
+	var src aggregationSource
+	if !s.applyAggregationSourceProperties(w, props, &src) {
+		return
+	}
+
+	src, err := s.aggregator.create(r.Context(), src)
+	if err != nil {
+		if errors.Is(err, ErrTooManyAggregationSources) {
+			s.writeError(w, http.StatusBadRequest, "CreateLimitReachedForResource",
+				"The create operation failed because the resource has reached the limit of possible resources.")
+			return
+		}
+		s.writeInternalError(w, r, err)
+		return
+	}
+
+	s.logger.InfoContext(r.Context(), "Redfish aggregation source created",
+		"source", src.ID,
+		"host_name", src.HostName)
+
+	res := newAggregationSourceResource(&aggregatedSource{source: src})
+	w.Header().Set("Location", res.ODataID)
+	w.Header().Set("Content-Type", contentTypeJSON)
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+// applyAggregationSourceProperties decodes the properties of a source.
+func (s *redfishServer) applyAggregationSourceProperties(w http.ResponseWriter, props map[string]json.RawMessage, src *aggregationSource) bool {
+	hostName := src.HostName
+	if !s.decodeProperty(w, props, propertyHostName, &hostName) ||
+		!s.decodeProperty(w, props, propertyUserName, &src.UserName) ||
+		!s.decodeProperty(w, props, propertyPassword, &src.Password) {
+		return false
+	}
+
+	if _, ok := props[propertyHostName]; ok {
+		normalized, ok := normalizeHostName(hostName)
+		if !ok {
+			s.writeError(w, http.StatusBadRequest, "PropertyValueFormatError",
+				fmt.Sprintf("The value '%s' for the property HostName is of a different format than the property can accept.", hostName),
+				hostName, propertyHostName)
+			return false
+		}
+		src.HostName = normalized
+	}
+	return true
+}
+
+func (s *redfishServer) handlePatchAggregationSource(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	as, ok := s.aggregator.source(id)
+	if !ok {
+		s.writeRequestError(w, r, ErrNotFound, aggregationSourceResourceName, id)
+		return
+	}
+
+	props, ok := s.readProperties(w, r, []string{propertyHostName, propertyUserName, propertyPassword},
+		[]string{"Id", "Name", "Status", "Links"})
+	src := as.source
+	if !ok || !s.applyAggregationSourceProperties(w, props, &src) {
+		return
+	}
+
+	src, err := s.aggregator.update(r.Context(), id, func(cur *aggregationSource) {
+		cur.HostName = src.HostName
+		cur.UserName = src.UserName
+		cur.Password = src.Password
+	})
+	if err != nil {
+		s.writeRequestError(w, r, err, aggregationSourceResourceName, id)
+		return
+	}
+
+	as.source = src
+	s.writeResource(w, r, newAggregationSourceResource(&as))
+}
+
+func (s *redfishServer) handleDeleteAggregationSource(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	if err := s.aggregator.remove(r.Context(), id); err != nil {
+		s.writeRequestError(w, r, err, aggregationSourceResourceName, id)
+		return
+	}
+
+	s.logger.InfoContext(r.Context(), "Redfish aggregation source deleted", "source", id)
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,547 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package websrv
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// Aggregation parameters.
+const (
+	maxAggregationSources     = 32
+	aggregationSourceIDLength = 3
+	aggregationPrefixSep      = "_"
+	maxAggregatedResponseSize = 16 << 20
+)
+
+// aggregationSource is a persisted downstream Redfish service.
+type aggregationSource struct {
+	ID string `json:"id"`
+	// HostName is the base URL of the downstream service, such as
+	// https://node1.example.com.
+	HostName string `json:"host_name"`
+	UserName string `json:"user_name,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// prefix returns the prefix of the IDs of the resources aggregated from the
+// source.
+func (src *aggregationSource) prefix() string {
+	return src.ID + aggregationPrefixSep
+}
+
+// aggregatedSource is a downstream service together with the result of its
+// most recent crawl.
+type aggregatedSource struct {
+	source aggregationSource
+	// systems and chassis are the downstream member IDs, without prefix.
+	systems []string
+	chassis []string
+	// crawled is the time of the most recent crawl and err its failure.
+	crawled time.Time
+	err     error
+}
+
+// health returns the Redfish health of the source.
+func (as *aggregatedSource) health() string {
+	switch {
+	case as.err != nil:
+		return "Critical"
+	case as.crawled.IsZero():
+		return "Warning"
+	default:
+		return "OK"
+	}
+}
+
+// aggregator crawls downstream Redfish services and forwards requests for
+// their resources. The Systems and Chassis of a source appear in the local
+// tree with the ID of the source and an underscore prepended to their IDs.
+type aggregator struct {
+	nc       *nats.Conn
+	logger   *slog.Logger
+	client   *http.Client
+	bucket   string
+	interval time.Duration
+
+	// kv persists sources. It is nil if JetStream is unavailable, in which
+	// case sources only live in memory.
+	kv jetstream.KeyValue
+
+	mu      sync.RWMutex
+	ctx     context.Context //nolint:containedctx // bounds the crawls of sources created by requests
+	sources map[string]*aggregatedSource
+}
+
+// newAggregator creates an aggregator.
+func newAggregator(nc *nats.Conn, logger *slog.Logger, cfg *config) *aggregator {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg.redfishAggregationSkipVerify {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} //nolint:gosec // opt-in for node BMCs with self-signed certificates
+	}
+	return &aggregator{
+		nc:       nc,
+		logger:   logger,
+		client:   &http.Client{Timeout: cfg.redfishTimeout, Transport: transport},
+		bucket:   cfg.redfishAggregationBucket,
+		interval: cfg.redfishAggregationInterval,
+		ctx:      context.Background(),
+		sources:  make(map[string]*aggregatedSource),
+	}
+}
+
+// start restores the persisted sources and crawls them periodically until
+// ctx is canceled.
+func (a *aggregator) start(ctx context.Context) {
+	a.mu.Lock()
+	a.ctx = ctx
+	a.mu.Unlock()
+
+	a.openStore(ctx)
+
+	go a.run(ctx)
+}
+
+// openStore opens the source bucket and restores its sources.
+func (a *aggregator) openStore(ctx context.Context) {
+	js, err := jetstream.New(a.nc)
+	if err == nil {
+		a.kv, err = js.CreateOrUpdateKeyValue(ctx, jetstream.KeyValueConfig{
+			Bucket:      a.bucket,
+			Description: "Redfish aggregation sources",
+		})
+	}
+	if err != nil {
+		a.logger.WarnContext(ctx, "Redfish aggregation sources will not be persisted", "bucket", a.bucket, "error", err)
+		a.kv = nil
+		return
+	}
+
+	keys, err := a.kv.ListKeys(ctx)
+	if err != nil {
+		a.logger.WarnContext(ctx, "Failed to list Redfish aggregation sources", "error", err)
+		return
+	}
+	for key := range keys.Keys() {
+		entry, err := a.kv.Get(ctx, key)
+		if err != nil {
+			a.logger.WarnContext(ctx, "Failed to load Redfish aggregation source", "id", key, "error", err)
+			continue
+		}
+		var src aggregationSource
+		if err := json.Unmarshal(entry.Value(), &src); err != nil || src.ID != key {
+			a.logger.WarnContext(ctx, "Ignoring malformed Redfish aggregation source", "id", key, "error", err)
+			continue
+		}
+		a.mu.Lock()
+		a.sources[src.ID] = &aggregatedSource{source: src}
+		a.mu.Unlock()
+	}
+}
+
+// run crawls every source once per interval.
+func (a *aggregator) run(ctx context.Context) {
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	for {
+		for _, id := range a.sourceIDs() {
+			a.crawl(ctx, id)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// save persists a source.
+func (a *aggregator) save(ctx context.Context, src *aggregationSource) error {
+	if a.kv == nil {
+		return nil
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	if _, err := a.kv.Put(ctx, src.ID, data); err != nil {
+		return fmt.Errorf("%w: %w", ErrAggregationStoreFailed, err)
+	}
+	return nil
+}
+
+// create assigns a random ID to src, persists it and crawls it in the
+// background.
+func (a *aggregator) create(ctx context.Context, src aggregationSource) (aggregationSource, error) {
+	a.mu.Lock()
+	if len(a.sources) >= maxAggregationSources {
+		a.mu.Unlock()
+		return src, ErrTooManyAggregationSources
+	}
+	for src.ID == "" || a.sources[src.ID] != nil {
+		b := make([]byte, aggregationSourceIDLength)
+		_, _ = rand.Read(b)
+		src.ID = strings.ToUpper(hex.EncodeToString(b))
+	}
+	a.sources[src.ID] = &aggregatedSource{source: src}
+	crawlCtx := a.ctx
+	a.mu.Unlock()
+
+	if err := a.save(ctx, &src); err != nil {
+		a.mu.Lock()
+		delete(a.sources, src.ID)
+		a.mu.Unlock()
+		return src, err
+	}
+
+	go a.crawl(crawlCtx, src.ID)
+
+	return src, nil
+}
+
+// source returns a copy of a source and its crawl state.
+func (a *aggregator) source(id string) (aggregatedSource, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	as, ok := a.sources[id]
+	if !ok {
+		return aggregatedSource{}, false
+	}
+	cp := *as
+	cp.systems = slices.Clone(as.systems)
+	cp.chassis = slices.Clone(as.chassis)
+	return cp, true
+}
+
+// sourceIDs returns the IDs of all sources in ascending order.
+func (a *aggregator) sourceIDs() []string {
+	a.mu.RLock()
+	ids := slices.Collect(maps.Keys(a.sources))
+	a.mu.RUnlock()
+
+	slices.Sort(ids)
+	return ids
+}
+
+// update applies fn to a source, persists the result and crawls the source
+// again with the new settings.
+func (a *aggregator) update(ctx context.Context, id string, fn func(*aggregationSource)) (aggregationSource, error) {
+	a.mu.Lock()
+	as, ok := a.sources[id]
+	if !ok {
+		a.mu.Unlock()
+		return aggregationSource{}, ErrNotFound
+	}
+	src := as.source
+	fn(&src)
+	as.source = src
+	crawlCtx := a.ctx
+	a.mu.Unlock()
+
+	if err := a.save(ctx, &src); err != nil {
+		return src, err
+	}
+
+	go a.crawl(crawlCtx, id)
+
+	return src, nil
+}
+
+// remove deletes a source, which removes its resources from the tree.
+func (a *aggregator) remove(ctx context.Context, id string) error {
+	a.mu.Lock()
+	_, ok := a.sources[id]
+	delete(a.sources, id)
+	a.mu.Unlock()
+
+	if !ok {
+		return ErrNotFound
+	}
+	if a.kv != nil {
+		if err := a.kv.Delete(ctx, id); err != nil {
+			return fmt.Errorf("%w: %w", ErrAggregationStoreFailed, err)
+		}
+	}
+	return nil
+}
+
+// memberIDs returns the prefixed IDs of the members aggregated into the
+// local collection at collectionPath.
+func (a *aggregator) memberIDs(collectionPath string) []string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	var ids []string
+	for _, id := range slices.Sorted(maps.Keys(a.sources)) {
+		as := a.sources[id]
+		members := as.systems
+		if collectionPath == chassisPath {
+			members = as.chassis
+		}
+		for _, member := range members {
+			ids = append(ids, as.source.prefix()+member)
+		}
+	}
+	return ids
+}
+
+// crawl reads the Systems and Chassis collections of a source and records
+// their members.
+func (a *aggregator) crawl(ctx context.Context, id string) {
+	as, ok := a.source(id)
+	if !ok {
+		return
+	}
+	src := as.source
+
+	systems, err := a.collectionMembers(ctx, &src, systemsPath)
+	var chassis []string
+	if err == nil {
+		chassis, err = a.collectionMembers(ctx, &src, chassisPath)
+	}
+	if err != nil && ctx.Err() == nil {
+		a.logger.WarnContext(ctx, "Failed to crawl Redfish aggregation source",
+			"source", id,
+			"host_name", src.HostName,
+			"error", err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// The source may have been removed or changed during the crawl.
+	cur, ok := a.sources[id]
+	if !ok || cur.source != src {
+		return
+	}
+	cur.crawled = time.Now()
+	cur.err = err
+	if err == nil {
+		cur.systems = systems
+		cur.chassis = chassis
+	}
+}
+
+// collectionMembers returns the member IDs of a downstream collection.
+func (a *aggregator) collectionMembers(ctx context.Context, src *aggregationSource, collectionPath string) ([]string, error) {
+	resp, err := a.do(ctx, src, http.MethodGet, collectionPath, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %s returned %s", ErrAggregationRequestFailed, collectionPath, resp.Status)
+	}
+
+	var coll struct {
+		Members []odataLink `json:"Members"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxAggregatedResponseSize)).Decode(&coll); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrAggregationRequestFailed, collectionPath, err)
+	}
+
+	ids := make([]string, 0, len(coll.Members))
+	for _, member := range coll.Members {
+		id, err := url.PathUnescape(path.Base(member.ODataID))
+		if err != nil || id == "" || id == "." || id == "/" {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// do sends a request for the resource at resourcePath, which may carry a
+// query, to a source.
+func (a *aggregator) do(ctx context.Context, src *aggregationSource, method, resourcePath string, body io.Reader, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, src.HostName+resourcePath, body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrAggregationRequestFailed, err)
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	req.Header.Set("Accept", contentTypeJSON)
+	if src.UserName != "" {
+		req.SetBasicAuth(src.UserName, src.Password)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrAggregationRequestFailed, err)
+	}
+	return resp, nil
+}
+
+// resolve returns the source owning the resource at resourcePath and the
+// path of the resource on the source. Aggregated resources are the Systems
+// and Chassis whose ID starts with the prefix of a source.
+func (a *aggregator) resolve(resourcePath string) (aggregationSource, string, bool) {
+	for _, collectionPath := range []string{systemsPath, chassisPath} {
+		rest, ok := strings.CutPrefix(resourcePath, collectionPath+"/")
+		if !ok {
+			continue
+		}
+		id, member, ok := strings.Cut(rest, aggregationPrefixSep)
+		if !ok || member == "" {
+			return aggregationSource{}, "", false
+		}
+
+		a.mu.RLock()
+		as, ok := a.sources[id]
+		a.mu.RUnlock()
+		if !ok {
+			return aggregationSource{}, "", false
+		}
+		return as.source, collectionPath + "/" + member, true
+	}
+	return aggregationSource{}, "", false
+}
+
+// forwardedRequestHeaders returns the request headers passed on to a source.
+func forwardedRequestHeaders() []string {
+	return []string{"Content-Type", "If-Match", "If-None-Match"}
+}
+
+// forwardedResponseHeaders returns the response headers passed back from a
+// source.
+func forwardedResponseHeaders() []string {
+	return []string{"Content-Type", "ETag", "Allow", "Retry-After"}
+}
+
+// handleAggregated forwards a request for an aggregated resource to its
+// source. Links to the Systems and Chassis of the source within the response
+// are rewritten to their aggregated paths.
+func (s *redfishServer) handleAggregated(w http.ResponseWriter, r *http.Request) {
+	src, resourcePath, ok := s.aggregator.resolve(r.URL.Path)
+	if !ok {
+		s.handleUnknown(w, r)
+		return
+	}
+	if r.URL.RawQuery != "" {
+		resourcePath += "?" + r.URL.RawQuery
+	}
+
+	header := make(http.Header)
+	for _, name := range forwardedRequestHeaders() {
+		if value := r.Header.Get(name); value != "" {
+			header.Set(name, value)
+		}
+	}
+
+	var body io.Reader
+	if r.Body != nil && r.Method != http.MethodGet && r.Method != http.MethodHead {
+		body = r.Body
+	}
+
+	resp, err := s.aggregator.do(r.Context(), &src, r.Method, resourcePath, body, header)
+	if err != nil {
+		s.logger.WarnContext(r.Context(), "Failed to forward Redfish request",
+			"source", src.ID,
+			"path", r.URL.Path,
+			"error", err)
+		s.writeError(w, http.StatusBadGateway, "ServiceTemporarilyUnavailable",
+			"The service is temporarily unavailable. Retry in 5 seconds.", "5")
+		return
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxAggregatedResponseSize))
+	if err != nil {
+		s.writeInternalError(w, r, fmt.Errorf("%w: %w", ErrAggregationRequestFailed, err))
+		return
+	}
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
+		data = rewriteAggregatedBody(data, src.prefix())
+	}
+
+	for _, name := range forwardedResponseHeaders() {
+		if value := resp.Header.Get(name); value != "" {
+			w.Header().Set(name, value)
+		}
+	}
+	if location := resp.Header.Get("Location"); location != "" {
+		if u, err := url.Parse(location); err == nil {
+			w.Header().Set("Location", rewriteAggregatedPath(u.Path, src.prefix()))
+		}
+	}
+	w.WriteHeader(resp.StatusCode)
+	_, _ = w.Write(data)
+}
+
+// rewriteAggregatedPath prepends prefix to the ID of the System or Chassis
+// at the start of a downstream path. Other paths are returned unchanged.
+func rewriteAggregatedPath(p, prefix string) string {
+	for _, collectionPath := range []string{systemsPath, chassisPath} {
+		if rest, ok := strings.CutPrefix(p, collectionPath+"/"); ok && rest != "" {
+			return collectionPath + "/" + prefix + rest
+		}
+	}
+	return p
+}
+
+// rewriteAggregatedBody rewrites the links to downstream Systems and Chassis
+// within a JSON body to their aggregated paths, and prefixes the Id of an
+// aggregated System or Chassis. Bodies that are not valid JSON are returned
+// unchanged.
+func rewriteAggregatedBody(data []byte, prefix string) []byte {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return data
+	}
+
+	out, err := json.Marshal(rewriteAggregatedValue(v, prefix))
+	if err != nil {
+		return data
+	}
+	return out
+}
+
+// rewriteAggregatedValue rewrites a decoded JSON value for rewriteAggregatedBody.
+func rewriteAggregatedValue(v any, prefix string) any {
+	switch v := v.(type) {
+	case string:
+		return rewriteAggregatedPath(v, prefix)
+	case []any:
+		for i := range v {
+			v[i] = rewriteAggregatedValue(v[i], prefix)
+		}
+		return v
+	case map[string]any:
+		for name, value := range v {
+			v[name] = rewriteAggregatedValue(value, prefix)
+		}
+		odataID, _ := v["@odata.id"].(string)
+		if id, ok := v["Id"].(string); ok &&
+			(odataID == systemsPath+"/"+prefix+url.PathEscape(id) || odataID == chassisPath+"/"+prefix+url.PathEscape(id)) {
+			v["Id"] = prefix + id
+		}
+		return v
+	default:
+		return v
+	}
+}
@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package websrv
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestDownstream starts a downstream Redfish service with a single system
+// that requires the given credentials.
+func newTestDownstream(t *testing.T, username, password string) *httptest.Server {
+	t.Helper()
+
+	resources := map[string]string{
+		systemsPath: `{"@odata.id": "` + systemsPath + `", "Members": [{"@odata.id": "` + systemsPath + `/node"}]}`,
+		chassisPath: `{"@odata.id": "` + chassisPath + `", "Members": [{"@odata.id": "` + chassisPath + `/enclosure"}]}`,
+		systemsPath + "/node": `{"@odata.id": "` + systemsPath + `/node", "Id": "node", "Name": "Node",
+			"Links": {"Chassis": [{"@odata.id": "` + chassisPath + `/enclosure"}], "ManagedBy": [{"@odata.id": "` + managersPath + `/bmc"}]}}`,
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if u, p, ok := r.BasicAuth(); !ok || u != username || p != password {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, ok := resources[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", contentTypeJSON)
+		w.Header().Set("ETag", `W/"downstream"`)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestRedfishAggregation(t *testing.T) {
+	_, srv := newTestRedfish(t)
+	downstream := newTestDownstream(t, "agg", "agg-pw")
+
+	resp, data := testRequest{
+		method: http.MethodPost,
+		path:   aggregationSourcesPath,
+		body:   `{"HostName": "` + downstream.URL + `", "UserName": "agg", "Password": "agg-pw"}`,
+		user:   "admin",
+	}.do(t, srv)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("create aggregation source = %s %s", resp.Status, data)
+	}
+	location := resp.Header.Get("Location")
+	source := decodeJSON(t, data)
+	id, _ := source["Id"].(string)
+	if id == "" || source["Password"] != nil {
+		t.Fatalf("aggregation source = %v", source)
+	}
+	prefix := id + aggregationPrefixSep
+
+	t.Run("crawl", func(t *testing.T) {
+		want := []string{systemsPath + "/" + prefix + "node", chassisPath + "/" + prefix + "enclosure"}
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			_, data := testRequest{method: http.MethodGet, path: location, user: "reader"}.do(t, srv)
+			var res aggregationSourceResource
+			if err := json.Unmarshal(data, &res); err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, l := range res.Links.ResourcesAccessed {
+				got = append(got, l.ODataID)
+			}
+			if len(got) == len(want) && got[0] == want[0] && got[1] == want[1] && res.Status.Health == "OK" {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("resources accessed = %v (health %s), want %v", got, res.Status.Health, want)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	})
+
+	t.Run("forwarded request", func(t *testing.T) {
+		resp, data := testRequest{method: http.MethodGet, path: systemsPath + "/" + prefix + "node", user: "reader"}.do(t, srv)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("status = %s %s", resp.Status, data)
+		}
+		if resp.Header.Get("ETag") != `W/"downstream"` {
+			t.Errorf("ETag = %q, want the downstream tag", resp.Header.Get("ETag"))
+		}
+
+		var system struct {
+			ODataID string `json:"@odata.id"`
+			ID      string `json:"Id"`
+			Links   struct {
+				Chassis   []odataLink `json:"Chassis"`
+				ManagedBy []odataLink `json:"ManagedBy"`
+			} `json:"Links"`
+		}
+		if err := json.Unmarshal(data, &system); err != nil {
+			t.Fatal(err)
+		}
+		if system.ODataID != systemsPath+"/"+prefix+"node" || system.ID != prefix+"node" {
+			t.Errorf("system = %s %s, want the aggregated ID", system.ODataID, system.ID)
+		}
+		if len(system.Links.Chassis) != 1 || system.Links.Chassis[0].ODataID != chassisPath+"/"+prefix+"enclosure" {
+			t.Errorf("chassis link = %v", system.Links.Chassis)
+		}
+		// Only Systems and Chassis are aggregated.
+		if len(system.Links.ManagedBy) != 1 || system.Links.ManagedBy[0].ODataID != managersPath+"/bmc" {
+			t.Errorf("manager link = %v", system.Links.ManagedBy)
+		}
+	})
+
+	t.Run("forwarded requests are authorized locally", func(t *testing.T) {
+		resp, _ := testRequest{method: http.MethodGet, path: systemsPath + "/" + prefix + "node"}.do(t, srv)
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("unauthenticated request = %s, want 401", resp.Status)
+		}
+		resp, _ = testRequest{
+			method: http.MethodPatch,
+			path:   systemsPath + "/" + prefix + "node",
+			body:   `{"AssetTag": "x"}`,
+			user:   "reader",
+		}.do(t, srv)
+		if resp.StatusCode != http.StatusForbidden {
+			t.Errorf("ReadOnly PATCH = %s, want 403", resp.Status)
+		}
+	})
+
+	t.Run("downstream errors", func(t *testing.T) {
+		resp, data := testRequest{method: http.MethodGet, path: systemsPath + "/" + prefix + "missing", user: "reader"}.do(t, srv)
+		if resp.StatusCode != http.StatusNotFound {
+			t.Errorf("missing downstream resource = %s %s", resp.Status, data)
+		}
+
+		downstream.Close()
+		resp, data = testRequest{method: http.MethodGet, path: systemsPath + "/" + prefix + "node", user: "reader"}.do(t, srv)
+		if resp.StatusCode != http.StatusBadGateway || !strings.Contains(string(data), "ServiceTemporarilyUnavailable") {
+			t.Errorf("unreachable source = %s %s", resp.Status, data)
+		}
+	})
+
+	t.Run("removed source", func(t *testing.T) {
+		resp, _ := testRequest{method: http.MethodDelete, path: location, user: "admin"}.do(t, srv)
+		if resp.StatusCode != http.StatusNoContent {
+			t.Fatalf("delete = %s", resp.Status)
+		}
+		resp, _ = testRequest{method: http.MethodGet, path: location, user: "reader"}.do(t, srv)
+		if resp.StatusCode != http.StatusNotFound {
+			t.Errorf("source after delete = %s, want 404", resp.Status)
+		}
+	})
+}
@@ -0,0 +1,533 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package websrv
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	schemav1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+	"github.com/u-bmc/u-bmc/pkg/ipc"
+)
+
+// Redfish privileges.
+const (
+	privilegeNone                = ""
+	privilegeLogin               = "Login"
+	privilegeConfigureManager    = "ConfigureManager"
+	privilegeConfigureUsers      = "ConfigureUsers"
+	privilegeConfigureSelf       = "ConfigureSelf"
+	privilegeConfigureComponents = "ConfigureComponents"
+)
+
+// Predefined Redfish roles.
+const (
+	roleAdministrator = "Administrator"
+	roleOperator      = "Operator"
+	roleReadOnly      = "ReadOnly"
+)
+
+// Session constants.
+const (
+	sessionTypeRedfish = "Redfish"
+	sessionTypeWebUI   = "WebUI"
+	originAccount      = "account"
+	originSingleSignOn = "sso"
+	authTokenHeader    = "X-Auth-Token"
+	authRealm          = `Basic realm="u-bmc", charset="UTF-8"`
+	maxSessions        = 64
+	sessionSweepPeriod = time.Minute
+)
+
+// rolePrivileges returns the privileges assigned to each predefined role.
+func rolePrivileges() map[string][]string {
+	return map[string][]string{
+		roleAdministrator: {
+			privilegeLogin, privilegeConfigureManager, privilegeConfigureUsers,
+			privilegeConfigureSelf, privilegeConfigureComponents,
+		},
+		roleOperator: {privilegeLogin, privilegeConfigureSelf, privilegeConfigureComponents},
+		roleReadOnly: {privilegeLogin, privilegeConfigureSelf},
+	}
+}
+
+// roleIDs returns the IDs of the predefined roles.
+func roleIDs() []string {
+	return []string{roleAdministrator, roleOperator, roleReadOnly}
+}
+
+// accountRole returns the Redfish role of a user. Users created through other
+// interfaces without Redfish account information are read-only.
+func accountRole(user *schemav1alpha1.User) string {
+	if role := user.GetRedfishInfo().GetRoleId(); slices.Contains(roleIDs(), role) {
+		return role
+	}
+	return roleReadOnly
+}
+
+// defaultPrivilege returns the privilege required for requests with the
+// given method unless the resource demands a different one.
+func defaultPrivilege(method string) string {
+	if method == http.MethodGet || method == http.MethodHead {
+		return privilegeLogin
+	}
+	return privilegeConfigureComponents
+}
+
+// redfishSession is an authenticated Redfish client. Requests using HTTP
+// Basic authentication or bearer tokens get a transient session that is
+// never stored. Web UI users logged in through single sign-on have stored
+// sessions of the WebUI type without a usermgr account.
+type redfishSession struct {
+	id    string
+	token string
+	// origin tells whether the session belongs to a usermgr account, whose
+	// ID is userID, or to a single sign-on user without one.
+	origin                 string
+	userID                 string
+	username               string
+	role                   string
+	clientAddr             string
+	sessionType            string
+	created                time.Time
+	lastUsed               time.Time
+	passwordChangeRequired bool
+	// secondFactorEnrollmentRequired restricts the session to enrolling the
+	// second factor its account is required to use.
+	secondFactorEnrollmentRequired bool
+}
+
+// hasPrivilege reports whether the role of the session grants privilege.
+func (sess *redfishSession) hasPrivilege(privilege string) bool {
+	return slices.Contains(rolePrivileges()[sess.role], privilege)
+}
+
+// sessionOwner identifies the user a session belongs to: a usermgr account
+// by its ID, which survives renames, or a single sign-on user by name.
+type sessionOwner struct {
+	origin string
+	id     string
+}
+
+// accountOwner returns the owner of the sessions of the usermgr account with
+// the given ID.
+func accountOwner(userID string) sessionOwner {
+	return sessionOwner{origin: originAccount, id: userID}
+}
+
+// owner returns the user the session belongs to.
+func (sess *redfishSession) owner() sessionOwner {
+	if sess.origin == originAccount {
+		return accountOwner(sess.userID)
+	}
+	return sessionOwner{origin: sess.origin, id: sess.username}
+}
+
+// ownsAccount reports whether the session belongs to the usermgr account of
+// user. Single sign-on sessions own no account, even one of the same name.
+func (sess *redfishSession) ownsAccount(user *schemav1alpha1.User) bool {
+	return sess.origin == originAccount && sess.userID != "" && sess.userID == user.GetId()
+}
+
+// accountPath returns the path of the account the session belongs to.
+func (sess *redfishSession) accountPath() string {
+	return accountsPath + "/" + sess.username
+}
+
+// permitsWhilePasswordChangeRequired reports whether r is one of the few
+// requests allowed until the password of the session's account is changed.
+func (sess *redfishSession) permitsWhilePasswordChangeRequired(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		return r.URL.Path == sess.accountPath() || r.URL.Path == sessionsPath+"/"+sess.id
+	case http.MethodPatch:
+		return r.URL.Path == sess.accountPath()
+	case http.MethodDelete:
+		return r.URL.Path == sessionsPath+"/"+sess.id
+	}
+	return false
+}
+
+// permitsWhileEnrollmentRequired reports whether r is one of the few
+// requests allowed until the session's account enrolls a second factor. The
+// enrollment itself is done through the EnrollTotp and ConfirmTotp
+// procedures of the API, which accepts the session token as well.
+func (sess *redfishSession) permitsWhileEnrollmentRequired(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		return r.URL.Path == sess.accountPath() || r.URL.Path == sessionsPath+"/"+sess.id
+	case http.MethodDelete:
+		return r.URL.Path == sessionsPath+"/"+sess.id
+	}
+	return false
+}
+
+// sessionContextKey is the context key of the session of a request.
+type sessionContextKey struct{}
+
+// requestSession returns the session that authenticated the request.
+func requestSession(r *http.Request) *redfishSession {
+	sess, _ := r.Context().Value(sessionContextKey{}).(*redfishSession)
+	return sess
+}
+
+// sessionStore holds the open Redfish sessions.
+type sessionStore struct {
+	mu       sync.Mutex
+	timeout  time.Duration
+	sessions map[string]*redfishSession
+}
+
+func newSessionStore(timeout time.Duration) *sessionStore {
+	return &sessionStore{
+		timeout:  timeout,
+		sessions: make(map[string]*redfishSession),
+	}
+}
+
+// randomHex returns n random bytes encoded as hexadecimal string.
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// add stores sess under a new ID and authentication token.
+func (st *sessionStore) add(sess *redfishSession) error {
+	id, err := randomHex(8)
+	if err != nil {
+		return err
+	}
+	token, err := randomHex(32)
+	if err != nil {
+		return err
+	}
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if len(st.sessions) >= maxSessions {
+		return ErrTooManySessions
+	}
+	sess.id = id
+	sess.token = token
+	sess.created = time.Now()
+	sess.lastUsed = sess.created
+	st.sessions[id] = sess
+	return nil
+}
+
+// lookup returns a copy of the session authenticated by token and marks the
+// session as used.
+func (st *sessionStore) lookup(token string) (*redfishSession, bool) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	now := time.Now()
+	for id, sess := range st.sessions {
+		if subtle.ConstantTimeCompare([]byte(sess.token), []byte(token)) != 1 {
+			continue
+		}
+		if now.Sub(sess.lastUsed) > st.timeout {
+			delete(st.sessions, id)
+			return nil, false
+		}
+		sess.lastUsed = now
+		found := *sess
+		return &found, true
+	}
+	return nil, false
+}
+
+// get returns a copy of the session with the given ID.
+func (st *sessionStore) get(id string) (redfishSession, bool) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	sess, ok := st.sessions[id]
+	if !ok {
+		return redfishSession{}, false
+	}
+	return *sess, true
+}
+
+// ids returns the sorted IDs of the sessions visible to viewer: all sessions
+// if all is set, the sessions of the viewer's account otherwise.
+func (st *sessionStore) ids(viewer *redfishSession, all bool) []string {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	ids := make([]string, 0, len(st.sessions))
+	for id, sess := range st.sessions {
+		if all || sess.owner() == viewer.owner() {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// remove closes the session with the given ID.
+func (st *sessionStore) remove(id string) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if _, ok := st.sessions[id]; !ok {
+		return ErrNotFound
+	}
+	delete(st.sessions, id)
+	return nil
+}
+
+// removeUser closes all sessions of a user.
+func (st *sessionStore) removeUser(owner sessionOwner) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	for id, sess := range st.sessions {
+		if sess.owner() == owner {
+			delete(st.sessions, id)
+		}
+	}
+}
+
+// secondFactorEnrolled lifts the enrollment requirement from the sessions
+// of a user.
+func (st *sessionStore) secondFactorEnrolled(owner sessionOwner) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	for _, sess := range st.sessions {
+		if sess.owner() == owner {
+			sess.secondFactorEnrollmentRequired = false
+		}
+	}
+}
+
+// passwordChanged lifts the password change requirement from the sessions
+// of a user.
+func (st *sessionStore) passwordChanged(owner sessionOwner) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	for _, sess := range st.sessions {
+		if sess.owner() == owner {
+			sess.passwordChangeRequired = false
+		}
+	}
+}
+
+// idleTimeout returns the idle timeout of sessions.
+func (st *sessionStore) idleTimeout() time.Duration {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	return st.timeout
+}
+
+// setIdleTimeout changes the idle timeout of sessions.
+func (st *sessionStore) setIdleTimeout(timeout time.Duration) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.timeout = timeout
+}
+
+// expire closes the sessions that were idle for longer than the timeout.
+func (st *sessionStore) expire(now time.Time) []string {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	var expired []string
+	for id, sess := range st.sessions {
+		if now.Sub(sess.lastUsed) > st.timeout {
+			delete(st.sessions, id)
+			expired = append(expired, sess.username)
+		}
+	}
+	return expired
+}
+
+// expireSessions closes idle sessions until ctx is canceled.
+func expireSessions(ctx context.Context, sessions *sessionStore, logger *slog.Logger) {
+	ticker := time.NewTicker(sessionSweepPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			for _, username := range sessions.expire(now) {
+				logger.InfoContext(ctx, "Session timed out", "user", username)
+			}
+		}
+	}
+}
+
+// login checks the credentials of a user with usermgr and returns an
+// unstored session for the account. A nil secondFactor tells usermgr that
+// the client cannot provide one, which refuses accounts that require it.
+func (s *redfishServer) login(ctx context.Context, r *http.Request, username, password string, secondFactor *string) (*redfishSession, error) {
+	clientAddr := clientAddress(r.RemoteAddr)
+	userAgent := r.UserAgent()
+
+	authReq := &schemav1alpha1.AuthenticateUserRequest{
+		Username:              username,
+		Password:              password,
+		SourceIp:              &clientAddr,
+		UserAgent:             &userAgent,
+		SecondFactorSupported: secondFactor != nil,
+		// Sessions of such accounts may only change the password.
+		PasswordChangeSupported: true,
+	}
+	if secondFactor != nil && *secondFactor != "" {
+		authReq.SecondFactor = secondFactor
+	}
+
+	var authResp schemav1alpha1.AuthenticateUserResponse
+	if err := s.requestNATS(ctx, ipc.SubjectUserAuthenticate, authReq, &authResp); err != nil {
+		return nil, err
+	}
+	switch {
+	case authResp.GetFailure() == schemav1alpha1.AuthenticationFailure_AUTHENTICATION_FAILURE_SECOND_FACTOR_REQUIRED:
+		return nil, fmt.Errorf("%w: %w", ErrAuthenticationFailed, ErrSecondFactorRequired)
+	case !authResp.GetSuccess():
+		return nil, fmt.Errorf("%w: %s", ErrAuthenticationFailed, authResp.GetFailureReason())
+	}
+
+	user, err := s.lookupUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if !user.GetEnabled() {
+		return nil, fmt.Errorf("%w: account %s is disabled", ErrAuthenticationFailed, username)
+	}
+
+	return &redfishSession{
+		origin:                         originAccount,
+		userID:                         user.GetId(),
+		username:                       user.GetUsername(),
+		role:                           accountRole(user),
+		clientAddr:                     clientAddr,
+		passwordChangeRequired:         authResp.GetPasswordChangeRequired(),
+		secondFactorEnrollmentRequired: authResp.GetSecondFactorEnrollmentRequired(),
+	}, nil
+}
+
+// clientAddress returns the host part of the address of a client.
+func clientAddress(remoteAddr string) string {
+	if host, _, err := net.SplitHostPort(remoteAddr); err == nil {
+		return host
+	}
+	return remoteAddr
+}
+
+// authenticate returns the session of a request authenticated with an
+// X-Auth-Token or HTTP Basic credentials. Otherwise it writes an error
+// response and returns false. Internal requests made while expanding a
+// resource carry the session of the original request.
+func (s *redfishServer) authenticate(w http.ResponseWriter, r *http.Request) (*redfishSession, bool) {
+	if sess := requestSession(r); sess != nil {
+		return sess, true
+	}
+	if token := r.Header.Get(authTokenHeader); token != "" {
+		if sess, ok := s.sessions.lookup(token); ok {
+			return sess, true
+		}
+		s.writeUnauthorized(w)
+		return nil, false
+	}
+
+	username, password, ok := r.BasicAuth()
+	if !ok {
+		s.writeUnauthorized(w)
+		return nil, false
+	}
+	sess, err := s.login(r.Context(), r, username, password, nil)
+	switch {
+	case err == nil:
+		return sess, true
+	case errors.Is(err, ErrAuthenticationFailed), errors.Is(err, ErrNotFound):
+		s.logger.WarnContext(r.Context(), "Redfish authentication failed", "user", username, "error", err)
+		s.writeUnauthorized(w)
+	default:
+		s.writeRequestError(w, r, err, accountResourceName, username)
+	}
+	return nil, false
+}
+
+// authorize wraps handler so it only serves requests of sessions whose role
+// grants privilege.
+func (s *redfishServer) authorize(privilege string, handler http.HandlerFunc) http.HandlerFunc {
+	if privilege == privilegeNone {
+		return handler
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		sess, ok := s.authenticate(w, r)
+		if !ok {
+			return
+		}
+
+		if sess.passwordChangeRequired && !sess.permitsWhilePasswordChangeRequired(r) {
+			s.writeError(w, http.StatusForbidden, "PasswordChangeRequired",
+				fmt.Sprintf("The password provided for this account must be changed before access is granted. "+
+					"PATCH the Password property for this account located at the target URI '%s' to complete this process.",
+					sess.accountPath()), sess.accountPath())
+			return
+		}
+		if sess.secondFactorEnrollmentRequired && !sess.permitsWhileEnrollmentRequired(r) {
+			s.writeError(w, http.StatusForbidden, "InsufficientPrivilege",
+				"A second factor must be enrolled for this account through the EnrollTotp and ConfirmTotp "+
+					"operations of the API before access is granted.")
+			return
+		}
+		if !sess.hasPrivilege(privilege) {
+			s.writeForbidden(w)
+			return
+		}
+
+		handler(w, r.WithContext(context.WithValue(r.Context(), sessionContextKey{}, sess)))
+	}
+}
+
+// writeUnauthorized writes the response for requests without valid credentials.
+func (s *redfishServer) writeUnauthorized(w http.ResponseWriter) {
+	w.Header().Set("WWW-Authenticate", authRealm)
+	s.writeError(w, http.StatusUnauthorized, "NoValidSession",
+		"There is no valid session established with the implementation.")
+}
+
+// writeForbidden writes the response for requests the session lacks the
+// privileges for.
+func (s *redfishServer) writeForbidden(w http.ResponseWriter) {
+	s.writeError(w, http.StatusForbidden, "InsufficientPrivilege",
+		"There are insufficient privileges for the account or credentials associated with the current session to perform the requested operation.")
+}
+
+// lookupUser returns the usermgr user with the given username.
+func (s *redfishServer) lookupUser(ctx context.Context, username string) (*schemav1alpha1.User, error) {
+	var resp schemav1alpha1.GetUserResponse
+	if err := s.requestNATS(ctx, ipc.SubjectUserInfo, &schemav1alpha1.GetUserRequest{
+		Identifier: &schemav1alpha1.GetUserRequest_Username{Username: username},
+	}, &resp); err != nil {
+		return nil, err
+	}
+	if resp.GetUser() == nil {
+		return nil, fmt.Errorf("%w: user %s", ErrNotFound, username)
+	}
+	return resp.GetUser(), nil
+}
@@ -0,0 +1,276 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package websrv
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+
+	schemav1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+	"github.com/u-bmc/u-bmc/pkg/ipc"
+)
+
+// Chassis resource types.
+const (
+	odataTypeChassisCollection = "#ChassisCollection.ChassisCollection"
+	odataTypeChassis           = "#Chassis.v1_25_0.Chassis"
+	chassisPath                = redfishRoot + "/Chassis"
+	actionChassisReset         = "Chassis.Reset"
+)
+
+// chassisResource is the Redfish Chassis resource.
+type chassisResource struct {
+	odataHeader
+	ID                 string         `json:"Id"`
+	Name               string         `json:"Name"`
+	Description        string         `json:"Description,omitempty"`
+	ChassisType        string         `json:"ChassisType"`
+	Manufacturer       string         `json:"Manufacturer,omitempty"`
+	Model              string         `json:"Model,omitempty"`
+	SerialNumber       string         `json:"SerialNumber,omitempty"`
+	PartNumber         string         `json:"PartNumber,omitempty"`
+	SKU                string         `json:"SKU,omitempty"`
+	AssetTag           string         `json:"AssetTag,omitempty"`
+	UUID               string         `json:"UUID,omitempty"`
+	PowerState         string         `json:"PowerState,omitempty"`
+	Status             resourceStatus `json:"Status"`
+	Sensors            odataLink      `json:"Sensors"`
+	ThermalSubsystem   odataLink      `json:"ThermalSubsystem"`
+	PowerSubsystem     odataLink      `json:"PowerSubsystem"`
+	EnvironmentMetrics odataLink      `json:"EnvironmentMetrics"`
+	Thermal            odataLink      `json:"Thermal"`
+	Power              odataLink      `json:"Power"`
+	Links              struct {
+		ComputerSystems []odataLink `json:"ComputerSystems"`
+		ManagedBy       []odataLink `json:"ManagedBy"`
+	} `json:"Links"`
+	Actions struct {
+		Reset resetAction `json:"#Chassis.Reset"`
+	} `json:"Actions"`
+}
+
+// chassisResetTypes returns the Chassis reset types in the order they are
+// advertised.
+func chassisResetTypes() []string {
+	return []string{"On", "ForceOff", "PowerCycle"}
+}
+
+// chassisResetAction maps a Chassis reset type to a chassis action.
+func chassisResetAction(resetType string) schemav1alpha1.ChassisAction {
+	switch resetType {
+	case "On":
+		return schemav1alpha1.ChassisAction_CHASSIS_ACTION_ON
+	case "ForceOff":
+		return schemav1alpha1.ChassisAction_CHASSIS_ACTION_OFF
+	case "PowerCycle":
+		return schemav1alpha1.ChassisAction_CHASSIS_ACTION_POWER_CYCLE
+	default:
+		return schemav1alpha1.ChassisAction_CHASSIS_ACTION_UNSPECIFIED
+	}
+}
+
+// chassisType maps a chassis type to the Redfish chassis type.
+func chassisType(t schemav1alpha1.ChassisType) string {
+	switch t {
+	case schemav1alpha1.ChassisType_CHASSIS_TYPE_RACK_MOUNT:
+		return "RackMount"
+	case schemav1alpha1.ChassisType_CHASSIS_TYPE_BLADE:
+		return "Blade"
+	case schemav1alpha1.ChassisType_CHASSIS_TYPE_STANDALONE:
+		return "StandAlone"
+	case schemav1alpha1.ChassisType_CHASSIS_TYPE_CARD:
+		return "Card"
+	case schemav1alpha1.ChassisType_CHASSIS_TYPE_TOWER:
+		return "Tower"
+	case schemav1alpha1.ChassisType_CHASSIS_TYPE_DESKTOP:
+		return "Desktop"
+	case schemav1alpha1.ChassisType_CHASSIS_TYPE_ENCLOSURE:
+		return "Enclosure"
+	default:
+		return "Other"
+	}
+}
+
+// chassisPowerState maps a chassis status to the Redfish power state and status.
+func chassisPowerState(status schemav1alpha1.ChassisStatus) (string, resourceStatus) {
+	switch status {
+	case schemav1alpha1.ChassisStatus_CHASSIS_STATUS_ON:
+		return "On", resourceStatus{State: "Enabled", Health: "OK"}
+	case schemav1alpha1.ChassisStatus_CHASSIS_STATUS_OFF:
+		return "Off", resourceStatus{State: "StandbyOffline", Health: "OK"}
+	case schemav1alpha1.ChassisStatus_CHASSIS_STATUS_TRANSITIONING:
+		return "", resourceStatus{State: "Starting", Health: "OK"}
+	case schemav1alpha1.ChassisStatus_CHASSIS_STATUS_WARNING:
+		return "On", resourceStatus{State: "Enabled", Health: "Warning"}
+	case schemav1alpha1.ChassisStatus_CHASSIS_STATUS_CRITICAL:
+		return "On", resourceStatus{State: "Enabled", Health: "Critical"}
+	case schemav1alpha1.ChassisStatus_CHASSIS_STATUS_FAILED:
+		return "", resourceStatus{State: "UnavailableOffline", Health: "Critical"}
+	default:
+		return "", resourceStatus{State: "Absent"}
+	}
+}
+
+// chassisScope identifies the chassis that components such as sensors and
+// cooling devices belong to. Components without a chassis location belong to
+// the primary chassis, which is the first one.
+type chassisScope struct {
+	id      string
+	primary bool
+}
+
+// contains reports whether a component at loc belongs to the chassis.
+func (c chassisScope) contains(loc *schemav1alpha1.Location) bool {
+	name := loc.GetChassisLocation().GetName()
+	return name == c.id || (name == "" && c.primary)
+}
+
+func (s *redfishServer) registerChassis() {
+	s.handle(http.MethodGet, chassisPath, s.handleChassisCollection)
+	s.handle(http.MethodGet, chassisPath+"/{id}", s.handleChassis)
+	s.handle(http.MethodPost, chassisPath+"/{id}/Actions/"+actionChassisReset, s.handleChassisReset)
+}
+
+func (s *redfishServer) handleChassisCollection(w http.ResponseWriter, r *http.Request) {
+	var resp schemav1alpha1.ListChassisResponse
+	if err := s.requestNATS(r.Context(), ipc.SubjectChassisList, &schemav1alpha1.ListChassisRequest{}, &resp); err != nil {
+		s.writeRequestError(w, r, err, "ChassisCollection", "Chassis")
+		return
+	}
+
+	ids := make([]string, 0, len(resp.GetChassis()))
+	for _, c := range resp.GetChassis() {
+		ids = append(ids, c.GetName())
+	}
+	ids = append(ids, s.aggregator.memberIDs(chassisPath)...)
+
+	s.writeResource(w, r, newCollection(chassisPath, odataTypeChassisCollection, "Chassis Collection", ids))
+}
+
+// chassisScope returns the scope of the chassis with the given ID, writing an
+// error response if the chassis does not exist.
+func (s *redfishServer) chassisScope(w http.ResponseWriter, r *http.Request, id string) (chassisScope, bool) {
+	var resp schemav1alpha1.ListChassisResponse
+	err := s.requestNATS(r.Context(), ipc.SubjectChassisList, &schemav1alpha1.ListChassisRequest{}, &resp)
+	if err == nil && !slices.ContainsFunc(resp.GetChassis(), func(c *schemav1alpha1.Chassis) bool {
+		return c.GetName() == id
+	}) {
+		err = ErrNotFound
+	}
+	if err != nil {
+		s.writeRequestError(w, r, err, "Chassis", id)
+		return chassisScope{}, false
+	}
+
+	return chassisScope{id: id, primary: resp.GetChassis()[0].GetName() == id}, true
+}
+
+// chassisState fetches the chassis with the given ID, writing an error
+// response if that fails.
+func (s *redfishServer) chassisState(w http.ResponseWriter, r *http.Request, id string) (*schemav1alpha1.Chassis, bool) {
+	var resp schemav1alpha1.GetChassisResponse
+	err := s.requestNATS(r.Context(), ipc.SubjectChassisState, &schemav1alpha1.GetChassisRequest{
+		Identifier: &schemav1alpha1.GetChassisRequest_Name{Name: id},
+	}, &resp)
+	if err == nil && len(resp.GetChassis()) == 0 {
+		err = ErrNotFound
+	}
+	if err != nil {
+		s.writeRequestError(w, r, err, "Chassis", id)
+		return nil, false
+	}
+	return resp.GetChassis()[0], true
+}
+
+func (s *redfishServer) handleChassis(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	c, ok := s.chassisState(w, r, id)
+	if !ok {
+		return
+	}
+
+	path := chassisPath + "/" + url.PathEscape(id)
+	chassis := &chassisResource{
+		odataHeader:        odataHeader{ODataID: path, ODataType: odataTypeChassis},
+		ID:                 id,
+		Name:               id,
+		Description:        c.GetDescription(),
+		ChassisType:        chassisType(c.GetType()),
+		Sensors:            link(path + "/Sensors"),
+		ThermalSubsystem:   link(path + "/ThermalSubsystem"),
+		PowerSubsystem:     link(path + "/PowerSubsystem"),
+		EnvironmentMetrics: link(path + "/EnvironmentMetrics"),
+		Thermal:            link(path + "/Thermal"),
+		Power:              link(path + "/Power"),
+	}
+	if asset := c.GetAsset(); asset != nil {
+		chassis.Manufacturer = asset.GetManufacturer()
+		chassis.Model = asset.GetProductName()
+		chassis.SerialNumber = asset.GetSerialNumber()
+		chassis.PartNumber = asset.GetPartNumber()
+		chassis.SKU = asset.GetSku()
+		chassis.AssetTag = asset.GetAssetTag()
+		chassis.UUID = asset.GetUuid()
+	}
+	chassis.PowerState, chassis.Status = chassisPowerState(c.GetStatus())
+	chassis.Actions.Reset = resetAction{
+		Target:     path + "/Actions/" + actionChassisReset,
+		ResetTypes: chassisResetTypes(),
+	}
+
+	// Chassis that do not list their hosts are assumed to contain all of them.
+	hostNames := c.GetHostNames()
+	if len(hostNames) == 0 {
+		var hosts schemav1alpha1.ListHostsResponse
+		if err := s.requestNATS(r.Context(), ipc.SubjectHostList, &schemav1alpha1.ListHostsRequest{}, &hosts); err != nil {
+			s.writeRequestError(w, r, err, "Chassis", id)
+			return
+		}
+		for _, host := range hosts.GetHosts() {
+			hostNames = append(hostNames, host.GetName())
+		}
+	}
+	chassis.Links.ComputerSystems = make([]odataLink, 0, len(hostNames))
+	for _, name := range hostNames {
+		chassis.Links.ComputerSystems = append(chassis.Links.ComputerSystems, link(systemsPath+"/"+url.PathEscape(name)))
+	}
+
+	_, managers, err := s.topology(r)
+	if err != nil {
+		s.writeRequestError(w, r, err, "Chassis", id)
+		return
+	}
+	chassis.Links.ManagedBy = make([]odataLink, 0, len(managers))
+	for _, m := range managers {
+		chassis.Links.ManagedBy = append(chassis.Links.ManagedBy, link(managersPath+"/"+url.PathEscape(m.GetName())))
+	}
+
+	s.writeResource(w, r, chassis)
+}
+
+func (s *redfishServer) handleChassisReset(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	resetType, ok := s.readResetType(w, r, actionChassisReset, chassisResetTypes())
+	if !ok {
+		return
+	}
+
+	var resp schemav1alpha1.ChangeChassisStateResponse
+	if err := s.requestNATS(r.Context(), ipc.SubjectChassisControl, &schemav1alpha1.ChangeChassisStateRequest{
+		ChassisName: id,
+		Action:      chassisResetAction(resetType),
+	}, &resp); err != nil {
+		s.writeRequestError(w, r, err, "Chassis", id)
+		return
+	}
+
+	s.logger.InfoContext(r.Context(), "Redfish chassis reset",
+		"chassis", id,
+		"reset_type", resetType,
+		"status", resp.GetCurrentStatus().String())
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,766 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package websrv
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	schemav1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+	"github.com/u-bmc/u-bmc/pkg/ipc"
+	"github.com/u-bmc/u-bmc/pkg/threshold"
+)
+
+// Event delivery parameters.
+const (
+	eventMessagePrefix     = eventRegistryPrefix + ".1.0."
+	odataTypeEvent         = "#Event.v1_9_0.Event"
+	eventQueueSize         = 64
+	eventDeliveryTimeout   = 10 * time.Second
+	eventMaxRetryDelay     = 10 * time.Minute
+	maxEventSubscriptions  = 20
+	maxEventContextLength  = 256
+	retryPolicyTerminate   = "TerminateAfterRetries"
+	retryPolicySuspend     = "SuspendRetries"
+	retryPolicyRetryAlways = "RetryForever"
+)
+
+// eventRecord is a single event carried by a Redfish Event.
+type eventRecord struct {
+	EventID           string     `json:"EventId"`
+	EventTimestamp    string     `json:"EventTimestamp"`
+	MemberID          string     `json:"MemberId"`
+	MessageID         string     `json:"MessageId"`
+	Message           string     `json:"Message"`
+	MessageArgs       []string   `json:"MessageArgs"`
+	MessageSeverity   string     `json:"MessageSeverity"`
+	OriginOfCondition *odataLink `json:"OriginOfCondition,omitempty"`
+
+	// resourceType is the schema of the resource the event originates from.
+	resourceType string
+	// report is the metric report carried instead of a message if the
+	// record is a MetricReport.
+	report *metricReport
+}
+
+// newEventRecord creates an event carrying a message of the built-in registry.
+func newEventRecord(key, origin, resourceType string, args ...string) eventRecord {
+	msg := eventRegistryMessages()[key]
+	rec := eventRecord{
+		MessageID:       eventMessagePrefix + key,
+		Message:         formatMessage(msg.Message, args),
+		MessageArgs:     args,
+		MessageSeverity: msg.Severity,
+		resourceType:    resourceType,
+	}
+	if origin != "" {
+		o := link(origin)
+		rec.OriginOfCondition = &o
+	}
+	return rec
+}
+
+// registryPrefix returns the registry prefix of the event's message.
+func (e *eventRecord) registryPrefix() string {
+	prefix, _, _ := strings.Cut(e.MessageID, ".")
+	return prefix
+}
+
+// origin returns the resource the event originates from, if any.
+func (e *eventRecord) origin() string {
+	if e.OriginOfCondition == nil {
+		return ""
+	}
+	return e.OriginOfCondition.ODataID
+}
+
+// formatType returns the EventFormatType of the record.
+func (e *eventRecord) formatType() string {
+	if e.report != nil {
+		return eventFormatTypeMetricReport
+	}
+	return eventFormatTypeEvent
+}
+
+// payload returns the Event or MetricReport sent to a subscriber with the
+// given subscription context.
+func (e *eventRecord) payload(subContext string) any {
+	if e.report != nil {
+		rep := *e.report
+		rep.Context = subContext
+		return &rep
+	}
+	return newEventPayload(*e, subContext)
+}
+
+// eventPayload is the Redfish Event sent to subscribers.
+type eventPayload struct {
+	ODataType string        `json:"@odata.type"`
+	ID        string        `json:"Id"`
+	Name      string        `json:"Name"`
+	Context   string        `json:"Context,omitempty"`
+	Events    []eventRecord `json:"Events"`
+}
+
+// newEventPayload wraps rec into an Event for a subscriber with the given
+// subscription context.
+func newEventPayload(rec eventRecord, subContext string) *eventPayload {
+	return &eventPayload{
+		ODataType: odataTypeEvent,
+		ID:        rec.EventID,
+		Name:      "Event",
+		Context:   subContext,
+		Events:    []eventRecord{rec},
+	}
+}
+
+// messageIDMatches reports whether a message ID equals a message ID filter.
+// Versions are ignored, so UBMC.1.0.TestEvent matches UBMC.1.1.TestEvent.
+func messageIDMatches(filter, id string) bool {
+	fp, _, _ := strings.Cut(filter, ".")
+	ip, _, _ := strings.Cut(id, ".")
+	return fp == ip && filter[strings.LastIndex(filter, ".")+1:] == id[strings.LastIndex(id, ".")+1:]
+}
+
+// eventSubscription is a persisted push subscription.
+type eventSubscription struct {
+	ID                  string            `json:"id"`
+	Destination         string            `json:"destination"`
+	Context             string            `json:"context,omitempty"`
+	RegistryPrefixes    []string          `json:"registry_prefixes,omitempty"`
+	MessageIDs          []string          `json:"message_ids,omitempty"`
+	ResourceTypes       []string          `json:"resource_types,omitempty"`
+	OriginResources     []string          `json:"origin_resources,omitempty"`
+	HTTPHeaders         map[string]string `json:"http_headers,omitempty"`
+	DeliveryRetryPolicy string            `json:"delivery_retry_policy"`
+	Suspended           bool              `json:"suspended,omitempty"`
+	// EventFormatType is empty for subscriptions to Events, which keeps
+	// subscriptions stored before metric reports were supported valid.
+	EventFormatType         string   `json:"event_format_type,omitempty"`
+	MetricReportDefinitions []string `json:"metric_report_definitions,omitempty"`
+}
+
+// formatType returns the EventFormatType of the subscription.
+func (sub *eventSubscription) formatType() string {
+	if sub.EventFormatType == "" {
+		return eventFormatTypeEvent
+	}
+	return sub.EventFormatType
+}
+
+// matches reports whether the subscription selects rec. Empty filters select
+// every event. MetricReport subscriptions only select metric reports, which
+// are filtered by their definition.
+func (sub *eventSubscription) matches(rec *eventRecord) bool {
+	if sub.formatType() != rec.formatType() {
+		return false
+	}
+	if rec.report != nil {
+		return len(sub.MetricReportDefinitions) == 0 ||
+			slices.Contains(sub.MetricReportDefinitions, rec.report.MetricReportDefinition.ODataID)
+	}
+	if len(sub.RegistryPrefixes) > 0 && !slices.Contains(sub.RegistryPrefixes, rec.registryPrefix()) {
+		return false
+	}
+	if len(sub.MessageIDs) > 0 && !slices.ContainsFunc(sub.MessageIDs, func(id string) bool {
+		return messageIDMatches(id, rec.MessageID)
+	}) {
+		return false
+	}
+	if len(sub.ResourceTypes) > 0 && !slices.Contains(sub.ResourceTypes, rec.resourceType) {
+		return false
+	}
+	if len(sub.OriginResources) > 0 && (rec.OriginOfCondition == nil || !slices.Contains(sub.OriginResources, rec.OriginOfCondition.ODataID)) {
+		return false
+	}
+	return true
+}
+
+// eventCondition compares an event property to a value.
+type eventCondition struct {
+	property string
+	value    string
+}
+
+// eventFilter is a Server-Sent Events filter in disjunctive normal form: an
+// event passes if all conditions of any group match. An empty filter passes
+// every event.
+type eventFilter [][]eventCondition
+
+// sseFilterProperties returns the properties Server-Sent Events can be
+// filtered on.
+func sseFilterProperties() []string {
+	return []string{"EventFormatType", "MessageId", "MetricReportDefinition", "OriginResource", "RegistryPrefix", "ResourceType"}
+}
+
+// parseEventFilter parses a $filter expression of the form
+// "Property eq 'value'" whose terms are joined with "and" and "or".
+func parseEventFilter(expr string) (eventFilter, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return nil, nil
+	}
+
+	var filter eventFilter
+	for _, group := range strings.Split(expr, " or ") {
+		var conds []eventCondition
+		for _, term := range strings.Split(group, " and ") {
+			property, value, ok := strings.Cut(strings.TrimSpace(term), " eq ")
+			value = strings.TrimSpace(value)
+			if !ok || !slices.Contains(sseFilterProperties(), strings.TrimSpace(property)) ||
+				len(value) < 2 || value[0] != '\'' || value[len(value)-1] != '\'' {
+				return nil, fmt.Errorf("%w: %q", ErrInvalidEventFilter, term)
+			}
+			conds = append(conds, eventCondition{property: strings.TrimSpace(property), value: value[1 : len(value)-1]})
+		}
+		filter = append(filter, conds)
+	}
+	return filter, nil
+}
+
+// matches reports whether rec passes the filter.
+func (f eventFilter) matches(rec *eventRecord) bool {
+	if len(f) == 0 {
+		return true
+	}
+	for _, group := range f {
+		if !slices.ContainsFunc(group, func(c eventCondition) bool { return !c.matches(rec) }) {
+			return true
+		}
+	}
+	return false
+}
+
+// matches reports whether rec satisfies the condition.
+func (c eventCondition) matches(rec *eventRecord) bool {
+	switch c.property {
+	case "EventFormatType":
+		return c.value == rec.formatType()
+	case "MessageId":
+		return messageIDMatches(c.value, rec.MessageID)
+	case "MetricReportDefinition":
+		return rec.report != nil && rec.report.MetricReportDefinition.ODataID == c.value
+	case "OriginResource":
+		return rec.OriginOfCondition != nil && rec.OriginOfCondition.ODataID == c.value
+	case "RegistryPrefix":
+		return rec.registryPrefix() == c.value
+	case "ResourceType":
+		return rec.resourceType == c.value
+	default:
+		return false
+	}
+}
+
+// subscriber delivers events to a push subscription.
+type subscriber struct {
+	sub    eventSubscription
+	queue  chan eventRecord
+	cancel context.CancelFunc
+}
+
+// sseListener receives the events of a Server-Sent Events stream.
+type sseListener struct {
+	filter eventFilter
+	events chan eventRecord
+}
+
+// eventBroker turns backend notifications into Redfish events and distributes
+// them to push subscriptions and Server-Sent Events streams.
+type eventBroker struct {
+	nc            *nats.Conn
+	logger        *slog.Logger
+	client        *http.Client
+	bucket        string
+	retryAttempts int
+	retryInterval time.Duration
+	seq           atomic.Uint64
+
+	// kv persists subscriptions. It is nil if JetStream is unavailable, in
+	// which case subscriptions only live in memory.
+	kv jetstream.KeyValue
+
+	mu          sync.Mutex
+	ctx         context.Context //nolint:containedctx // bounds the delivery of subscriptions created by requests
+	subscribers map[string]*subscriber
+	listeners   map[*sseListener]struct{}
+	nextID      int
+
+	// violations tracks the asserted threshold violations.
+	violations *threshold.Tracker
+}
+
+// newEventBroker creates an event broker.
+func newEventBroker(nc *nats.Conn, logger *slog.Logger, cfg *config) *eventBroker {
+	return &eventBroker{
+		nc:            nc,
+		logger:        logger,
+		client:        &http.Client{Timeout: eventDeliveryTimeout},
+		bucket:        cfg.redfishEventBucket,
+		retryAttempts: cfg.redfishEventRetryAttempts,
+		retryInterval: cfg.redfishEventRetryInterval,
+		ctx:           context.Background(),
+		subscribers:   make(map[string]*subscriber),
+		listeners:     make(map[*sseListener]struct{}),
+		nextID:        1,
+		violations:    threshold.NewTracker(threshold.DefaultTimeout),
+	}
+}
+
+// start restores the persisted subscriptions and subscribes to the event
+// sources. Event delivery stops when ctx is canceled.
+func (b *eventBroker) start(ctx context.Context) error {
+	b.mu.Lock()
+	b.ctx = ctx
+	b.mu.Unlock()
+
+	b.openStore(ctx)
+
+	sources := []struct {
+		subject string
+		handler func(context.Context, *nats.Msg)
+	}{
+		{ipc.SubjectStateEvent, b.handleStateEvent},
+		{ipc.SubjectAlertEvent, b.handleAlertEvent},
+		{threshold.Subject, b.handleThresholdEvent},
+	}
+	for _, source := range sources {
+		handler := source.handler
+		if _, err := b.nc.Subscribe(source.subject, func(msg *nats.Msg) {
+			handler(ctx, msg)
+		}); err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrEventSourceFailed, source.subject, err)
+		}
+	}
+
+	go b.violations.Run(ctx, func(event threshold.Event, _ time.Time) {
+		b.publish(ctx, newEventRecord(msgReadingNormal, "", "Sensor", event.Name(),
+			strings.ReplaceAll(event.ViolationType, "_", " ")))
+	})
+
+	return nil
+}
+
+// openStore opens the subscription bucket and restores its subscriptions.
+func (b *eventBroker) openStore(ctx context.Context) {
+	js, err := jetstream.New(b.nc)
+	if err == nil {
+		b.kv, err = js.CreateOrUpdateKeyValue(ctx, jetstream.KeyValueConfig{
+			Bucket:      b.bucket,
+			Description: "Redfish event subscriptions",
+		})
+	}
+	if err != nil {
+		b.logger.WarnContext(ctx, "Redfish event subscriptions will not be persisted", "bucket", b.bucket, "error", err)
+		b.kv = nil
+		return
+	}
+
+	keys, err := b.kv.ListKeys(ctx)
+	if err != nil {
+		b.logger.WarnContext(ctx, "Failed to list Redfish event subscriptions", "error", err)
+		return
+	}
+	for key := range keys.Keys() {
+		entry, err := b.kv.Get(ctx, key)
+		if err != nil {
+			b.logger.WarnContext(ctx, "Failed to load Redfish event subscription", "id", key, "error", err)
+			continue
+		}
+		var sub eventSubscription
+		if err := json.Unmarshal(entry.Value(), &sub); err != nil || sub.ID != key {
+			b.logger.WarnContext(ctx, "Ignoring malformed Redfish event subscription", "id", key, "error", err)
+			continue
+		}
+		b.add(sub)
+	}
+}
+
+// save persists a subscription.
+func (b *eventBroker) save(ctx context.Context, sub *eventSubscription) error {
+	if b.kv == nil {
+		return nil
+	}
+	data, err := json.Marshal(sub)
+	if err != nil {
+		return err
+	}
+	if _, err := b.kv.Put(ctx, sub.ID, data); err != nil {
+		return fmt.Errorf("%w: %w", ErrSubscriptionStoreFailed, err)
+	}
+	return nil
+}
+
+// add registers a subscription and starts its delivery.
+func (b *eventBroker) add(sub eventSubscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if id, err := strconv.Atoi(sub.ID); err == nil && id >= b.nextID {
+		b.nextID = id + 1
+	}
+
+	ctx, cancel := context.WithCancel(b.ctx)
+	s := &subscriber{
+		sub:    sub,
+		queue:  make(chan eventRecord, eventQueueSize),
+		cancel: cancel,
+	}
+	b.subscribers[sub.ID] = s
+
+	go b.deliver(ctx, s)
+}
+
+// create assigns an ID to sub, persists it and starts its delivery.
+func (b *eventBroker) create(ctx context.Context, sub eventSubscription) (eventSubscription, error) {
+	b.mu.Lock()
+	if len(b.subscribers) >= maxEventSubscriptions {
+		b.mu.Unlock()
+		return sub, ErrTooManySubscriptions
+	}
+	sub.ID = strconv.Itoa(b.nextID)
+	b.nextID++
+	b.mu.Unlock()
+
+	if err := b.save(ctx, &sub); err != nil {
+		return sub, err
+	}
+	b.add(sub)
+	return sub, nil
+}
+
+// subscription returns a copy of a subscription.
+func (b *eventBroker) subscription(id string) (eventSubscription, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	s, ok := b.subscribers[id]
+	if !ok {
+		return eventSubscription{}, false
+	}
+	sub := s.sub
+	sub.HTTPHeaders = maps.Clone(sub.HTTPHeaders)
+	return sub, true
+}
+
+// subscriptionIDs returns the IDs of all subscriptions in ascending order.
+func (b *eventBroker) subscriptionIDs() []string {
+	b.mu.Lock()
+	ids := slices.Collect(maps.Keys(b.subscribers))
+	b.mu.Unlock()
+
+	slices.SortFunc(ids, func(a, b string) int {
+		x, _ := strconv.Atoi(a)
+		y, _ := strconv.Atoi(b)
+		return x - y
+	})
+	return ids
+}
+
+// update applies fn to a subscription and persists the result.
+func (b *eventBroker) update(ctx context.Context, id string, fn func(*eventSubscription)) (eventSubscription, error) {
+	b.mu.Lock()
+	s, ok := b.subscribers[id]
+	if !ok {
+		b.mu.Unlock()
+		return eventSubscription{}, ErrNotFound
+	}
+	sub := s.sub
+	sub.HTTPHeaders = maps.Clone(sub.HTTPHeaders)
+	fn(&sub)
+	s.sub = sub
+	b.mu.Unlock()
+
+	return sub, b.save(ctx, &sub)
+}
+
+// remove stops the delivery of a subscription and deletes it.
+func (b *eventBroker) remove(ctx context.Context, id string) error {
+	b.mu.Lock()
+	s, ok := b.subscribers[id]
+	if ok {
+		delete(b.subscribers, id)
+		s.cancel()
+	}
+	b.mu.Unlock()
+
+	if !ok {
+		return ErrNotFound
+	}
+	if b.kv != nil {
+		if err := b.kv.Delete(ctx, id); err != nil {
+			return fmt.Errorf("%w: %w", ErrSubscriptionStoreFailed, err)
+		}
+	}
+	return nil
+}
+
+// listen registers a Server-Sent Events listener.
+func (b *eventBroker) listen(filter eventFilter) *sseListener {
+	l := &sseListener{
+		filter: filter,
+		events: make(chan eventRecord, eventQueueSize),
+	}
+
+	b.mu.Lock()
+	b.listeners[l] = struct{}{}
+	b.mu.Unlock()
+
+	return l
+}
+
+// unlisten removes a Server-Sent Events listener.
+func (b *eventBroker) unlisten(l *sseListener) {
+	b.mu.Lock()
+	delete(b.listeners, l)
+	b.mu.Unlock()
+}
+
+// publish assigns an ID to rec and queues it for every subscription and
+// listener that selects it. Events are dropped for receivers that fall behind.
+func (b *eventBroker) publish(ctx context.Context, rec eventRecord) {
+	rec.EventID = strconv.FormatUint(b.seq.Add(1), 10)
+	rec.MemberID = "0"
+	if rec.EventTimestamp == "" {
+		rec.EventTimestamp = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for id, s := range b.subscribers {
+		if s.sub.Suspended || !s.sub.matches(&rec) {
+			continue
+		}
+		select {
+		case s.queue <- rec:
+		default:
+			b.logger.WarnContext(ctx, "Dropping Redfish event for slow subscription", "subscription", id, "message_id", rec.MessageID)
+		}
+	}
+	for l := range b.listeners {
+		if !l.filter.matches(&rec) {
+			continue
+		}
+		select {
+		case l.events <- rec:
+		default:
+			b.logger.DebugContext(ctx, "Dropping Redfish event for slow SSE stream", "message_id", rec.MessageID)
+		}
+	}
+}
+
+// deliver sends the queued events of a subscription until ctx is canceled.
+func (b *eventBroker) deliver(ctx context.Context, s *subscriber) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case rec := <-s.queue:
+			b.deliverEvent(ctx, s.sub.ID, rec)
+		}
+	}
+}
+
+// deliverEvent sends an event to a subscription, retrying with exponential
+// backoff. Once the retry attempts are exhausted the subscription's delivery
+// retry policy decides whether it is deleted, suspended or retried further.
+func (b *eventBroker) deliverEvent(ctx context.Context, id string, rec eventRecord) {
+	delay := b.retryInterval
+	for attempt := 0; ; attempt++ {
+		sub, ok := b.subscription(id)
+		if !ok || sub.Suspended {
+			return
+		}
+
+		err := b.post(ctx, &sub, rec)
+		if err == nil {
+			return
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		if attempt >= b.retryAttempts {
+			switch sub.DeliveryRetryPolicy {
+			case retryPolicyRetryAlways:
+			case retryPolicySuspend:
+				b.logger.WarnContext(ctx, "Suspending Redfish event subscription", "subscription", id, "error", err)
+				if _, err := b.update(ctx, id, func(sub *eventSubscription) { sub.Suspended = true }); err != nil {
+					b.logger.WarnContext(ctx, "Failed to suspend Redfish event subscription", "subscription", id, "error", err)
+				}
+				return
+			default:
+				b.logger.WarnContext(ctx, "Deleting Redfish event subscription", "subscription", id, "error", err)
+				if err := b.remove(ctx, id); err != nil {
+					b.logger.WarnContext(ctx, "Failed to delete Redfish event subscription", "subscription", id, "error", err)
+				}
+				return
+			}
+		}
+
+		b.logger.DebugContext(ctx, "Retrying Redfish event delivery",
+			"subscription", id,
+			"attempt", attempt+1,
+			"delay", delay,
+			"error", err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		delay = min(2*delay, eventMaxRetryDelay)
+	}
+}
+
+// post sends an event to the destination of a subscription.
+func (b *eventBroker) post(ctx context.Context, sub *eventSubscription, rec eventRecord) error {
+	body, err := json.Marshal(rec.payload(sub.Context))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.Destination, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrEventDeliveryFailed, err)
+	}
+	for name, value := range sub.HTTPHeaders {
+		req.Header.Set(name, value)
+	}
+	req.Header.Set("Content-Type", contentTypeJSON)
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrEventDeliveryFailed, err)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%w: destination returned %s", ErrEventDeliveryFailed, resp.Status)
+	}
+	return nil
+}
+
+// componentOrigin returns the Redfish resource and its type for a statemgr
+// component name such as host.0.
+func componentOrigin(name string) (string, string) {
+	kind, _, _ := strings.Cut(name, ".")
+	switch kind {
+	case "host":
+		return systemsPath + "/" + name, "ComputerSystem"
+	case "chassis":
+		return chassisPath + "/" + name, "Chassis"
+	case "bmc":
+		return managersPath + "/" + name, "Manager"
+	default:
+		return "", ""
+	}
+}
+
+// handleStateEvent publishes state transitions reported by statemgr.
+func (b *eventBroker) handleStateEvent(ctx context.Context, msg *nats.Msg) {
+	var n schemav1alpha1.StateTransitionNotification
+	if err := n.UnmarshalVT(msg.Data); err != nil || n.GetComponentName() == "" {
+		b.logger.WarnContext(ctx, "Ignoring malformed state event", "subject", msg.Subject, "error", err)
+		return
+	}
+
+	origin, resourceType := componentOrigin(n.GetComponentName())
+
+	rec := newEventRecord(msgResourceStateChanged, origin, resourceType, n.GetComponentName(), n.GetCurrentState())
+	if !n.GetSuccess() {
+		rec = newEventRecord(msgResourceStateChangeFailed, origin, resourceType, n.GetTrigger(), n.GetComponentName(), n.GetCurrentState())
+	}
+	if n.GetChangedAt() != nil {
+		rec.EventTimestamp = n.GetChangedAt().AsTime().UTC().Format(time.RFC3339)
+	}
+
+	b.publish(ctx, rec)
+}
+
+// handleAlertEvent publishes sensor alerts.
+func (b *eventBroker) handleAlertEvent(ctx context.Context, msg *nats.Msg) {
+	var alert schemav1alpha1.SensorAlert
+	if err := alert.UnmarshalVT(msg.Data); err != nil || alert.GetSensorId() == "" {
+		b.logger.WarnContext(ctx, "Ignoring malformed alert event", "subject", msg.Subject, "error", err)
+		return
+	}
+
+	b.publish(ctx, alertRecord(&alert))
+}
+
+// alertRecord creates the event of a sensor alert.
+func alertRecord(alert *schemav1alpha1.SensorAlert) eventRecord {
+	name := alert.GetSensorName()
+	if name == "" {
+		name = alert.GetSensorId()
+	}
+
+	rec := newEventRecord(msgSensorAlert, "", "Sensor", name, alert.GetMessage())
+	switch strings.ToLower(alert.GetSeverity()) {
+	case "critical", "emergency":
+		rec.MessageSeverity = "Critical"
+	case "info", "ok":
+		rec.MessageSeverity = "OK"
+	}
+	if alert.GetTimestamp() != nil {
+		rec.EventTimestamp = alert.GetTimestamp().AsTime().UTC().Format(time.RFC3339)
+	}
+	return rec
+}
+
+// thresholdMessage returns the registry message for a sensormon violation type.
+func thresholdMessage(violationType string) (string, bool) {
+	switch violationType {
+	case threshold.UpperWarning:
+		return msgReadingAboveUpperWarning, true
+	case threshold.UpperCritical:
+		return msgReadingAboveUpperCritical, true
+	case threshold.LowerWarning:
+		return msgReadingBelowLowerWarning, true
+	case threshold.LowerCritical:
+		return msgReadingBelowLowerCritical, true
+	default:
+		return "", false
+	}
+}
+
+// handleThresholdEvent publishes threshold violations. sensormon repeats
+// violations on every threshold check, so only the first report and changes
+// in severity are published.
+func (b *eventBroker) handleThresholdEvent(ctx context.Context, msg *nats.Msg) {
+	event, err := threshold.ParseEvent(msg.Data)
+	switch {
+	case errors.Is(err, threshold.ErrUnknownViolation):
+		return
+	case err != nil:
+		b.logger.WarnContext(ctx, "Ignoring malformed threshold event", "subject", msg.Subject, "error", err)
+		return
+	}
+	key, ok := thresholdMessage(event.ViolationType)
+	if !ok || !b.violations.Report(event, time.Now()) {
+		return
+	}
+
+	b.publish(ctx, newEventRecord(key, "", "Sensor", event.Name(),
+		strconv.FormatFloat(event.CurrentValue, 'g', -1, 64),
+		strconv.FormatFloat(event.ThresholdValue, 'g', -1, 64)))
+}
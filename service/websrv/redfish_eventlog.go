@@ -0,0 +1,245 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package websrv
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	schemav1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Event log parameters.
+const (
+	maxEventLogEntries    = 1000
+	eventLogRetryInterval = 30 * time.Second
+)
+
+// logRecord is an entry of the event log.
+type logRecord struct {
+	id      string
+	created time.Time
+	event   eventRecord
+}
+
+// eventLog keeps the latest events of the persistent event history that
+// statemgr and sensormon publish to JetStream, replaying the history on start
+// and following it afterwards.
+type eventLog struct {
+	nc       *nats.Conn
+	logger   *slog.Logger
+	subjects []string
+
+	mu      sync.RWMutex
+	records []logRecord
+}
+
+// newEventLog creates an event log following the configured subjects.
+func newEventLog(nc *nats.Conn, logger *slog.Logger, cfg *config) *eventLog {
+	return &eventLog{
+		nc:       nc,
+		logger:   logger,
+		subjects: cfg.redfishEventLogSubjects,
+	}
+}
+
+// start follows the event history until ctx is canceled.
+func (l *eventLog) start(ctx context.Context) {
+	js, err := jetstream.New(l.nc)
+	if err != nil {
+		l.logger.WarnContext(ctx, "Redfish event log is unavailable", "error", err)
+		return
+	}
+	for _, subject := range l.subjects {
+		go l.follow(ctx, js, subject)
+	}
+}
+
+// follow consumes the events published on subject. The stream capturing the
+// subject is created by the publishing service, so it is looked up again until
+// it exists.
+func (l *eventLog) follow(ctx context.Context, js jetstream.JetStream, subject string) {
+	for {
+		err := l.consume(ctx, js, subject)
+		if err == nil {
+			return
+		}
+		l.logger.DebugContext(ctx, "Event history is not available yet", "subject", subject, "error", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(eventLogRetryInterval):
+		}
+	}
+}
+
+// consume replays and follows the events published on subject until ctx is
+// canceled.
+func (l *eventLog) consume(ctx context.Context, js jetstream.JetStream, subject string) error {
+	name, err := js.StreamNameBySubject(ctx, subject)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrEventLogFailed, subject, err)
+	}
+	stream, err := js.Stream(ctx, name)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrEventLogFailed, name, err)
+	}
+	consumer, err := stream.OrderedConsumer(ctx, jetstream.OrderedConsumerConfig{
+		FilterSubjects: []string{subject},
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrEventLogFailed, name, err)
+	}
+	cc, err := consumer.Consume(func(msg jetstream.Msg) {
+		l.handle(ctx, name, msg)
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrEventLogFailed, name, err)
+	}
+
+	l.logger.InfoContext(ctx, "Following event history", "subject", subject, "stream", name)
+	<-ctx.Done()
+	cc.Stop()
+
+	return nil
+}
+
+// handle adds an event of the history to the log. Entries are identified by
+// their stream sequence, so their IDs remain stable across restarts.
+func (l *eventLog) handle(ctx context.Context, stream string, msg jetstream.Msg) {
+	meta, err := msg.Metadata()
+	if err != nil {
+		return
+	}
+
+	var (
+		rec     eventRecord
+		created *timestamppb.Timestamp
+		ok      bool
+	)
+	if strings.HasSuffix(msg.Subject(), ".transition") {
+		rec, created, ok = transitionRecord(msg.Subject(), msg.Data())
+	} else {
+		var alert schemav1alpha1.SensorAlert
+		if ok = alert.UnmarshalVT(msg.Data()) == nil && alert.GetSensorId() != ""; ok {
+			rec, created = alertRecord(&alert), alert.GetTimestamp()
+		}
+	}
+	if !ok {
+		l.logger.DebugContext(ctx, "Ignoring event history entry", "subject", msg.Subject(), "sequence", meta.Sequence.Stream)
+		return
+	}
+
+	entry := logRecord{
+		id:      fmt.Sprintf("%s.%d", stream, meta.Sequence.Stream),
+		created: meta.Timestamp,
+		event:   rec,
+	}
+	if created != nil {
+		entry.created = created.AsTime()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.records = append(l.records, entry)
+	if len(l.records) > maxEventLogEntries {
+		l.records = slices.Delete(l.records, 0, len(l.records)-maxEventLogEntries)
+	}
+}
+
+// entries returns the entries of the log whose origin is accepted by match,
+// oldest first. A nil match accepts every entry.
+func (l *eventLog) entries(match func(origin string) bool) []logRecord {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	records := make([]logRecord, 0, len(l.records))
+	for _, rec := range l.records {
+		if match == nil || match(rec.event.origin()) {
+			records = append(records, rec)
+		}
+	}
+	return records
+}
+
+// entry returns the entry with the given ID.
+func (l *eventLog) entry(id string) (logRecord, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	for _, rec := range l.records {
+		if rec.id == id {
+			return rec, true
+		}
+	}
+	return logRecord{}, false
+}
+
+// statusName returns the lower case name of a status enum value without its prefix.
+func statusName(value fmt.Stringer, prefix string) string {
+	return strings.ToLower(strings.TrimPrefix(value.String(), prefix))
+}
+
+// transitionRecord decodes a state transition statemgr published on
+// statemgr.event.<component>.transition into an event whose severity is the
+// health of the new state. It returns false for malformed transitions and
+// those that did not change the state.
+func transitionRecord(subject string, data []byte) (eventRecord, *timestamppb.Timestamp, bool) {
+	tokens := strings.Split(subject, ".")
+	if len(tokens) < 4 {
+		return eventRecord{}, nil, false
+	}
+
+	var (
+		component, state string
+		status           resourceStatus
+		changedAt        *timestamppb.Timestamp
+	)
+	switch tokens[len(tokens)-3] {
+	case "host":
+		var change schemav1alpha1.HostStateChange
+		if change.UnmarshalVT(data) != nil || change.GetPreviousStatus() == change.GetCurrentStatus() {
+			return eventRecord{}, nil, false
+		}
+		component, changedAt = change.GetHostName(), change.GetChangedAt()
+		state = statusName(change.GetCurrentStatus(), "HOST_STATUS_")
+		_, status = hostPowerState(change.GetCurrentStatus())
+	case "chassis":
+		var change schemav1alpha1.ChassisStateChange
+		if change.UnmarshalVT(data) != nil || change.GetPreviousStatus() == change.GetCurrentStatus() {
+			return eventRecord{}, nil, false
+		}
+		component, changedAt = change.GetChassisName(), change.GetChangedAt()
+		state = statusName(change.GetCurrentStatus(), "CHASSIS_STATUS_")
+		_, status = chassisPowerState(change.GetCurrentStatus())
+	case "bmc":
+		var change schemav1alpha1.ManagementControllerStateChange
+		if change.UnmarshalVT(data) != nil || change.GetPreviousStatus() == change.GetCurrentStatus() {
+			return eventRecord{}, nil, false
+		}
+		component, changedAt = change.GetControllerName(), change.GetChangedAt()
+		state = statusName(change.GetCurrentStatus(), "MANAGEMENT_CONTROLLER_STATUS_")
+		status = managerStatus(change.GetCurrentStatus())
+	default:
+		return eventRecord{}, nil, false
+	}
+	if component == "" {
+		component = strings.Join(tokens[2:len(tokens)-1], ".")
+	}
+
+	origin, resourceType := componentOrigin(component)
+	rec := newEventRecord(msgResourceStateChanged, origin, resourceType, component, state)
+	if status.Health != "" {
+		rec.MessageSeverity = status.Health
+	}
+	return rec, changedAt, true
+}
@@ -0,0 +1,476 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package websrv
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// EventService resource types.
+const (
+	odataTypeEventService               = "#EventService.v1_10_2.EventService"
+	odataTypeEventDestinationCollection = "#EventDestinationCollection.EventDestinationCollection"
+	odataTypeEventDestination           = "#EventDestination.v1_14_1.EventDestination"
+	eventServicePath                    = redfishRoot + "/EventService"
+	subscriptionsPath                   = eventServicePath + "/Subscriptions"
+	ssePath                             = eventServicePath + "/SSE"
+	actionSubmitTestEvent               = "EventService.SubmitTestEvent"
+	actionResumeSubscription            = "EventDestination.ResumeSubscription"
+	sseKeepAliveInterval                = 30 * time.Second
+	contentTypeEventStream              = "text/event-stream"
+	eventDestinationProtocol            = "Redfish"
+	eventDestinationSubscriptionType    = "RedfishEvent"
+	eventFormatTypeEvent                = "Event"
+	eventFormatTypeMetricReport         = "MetricReport"
+	propertyEventFormatType             = "EventFormatType"
+	propertyMetricReportDefinitions     = "MetricReportDefinitions"
+	propertyDestination                 = "Destination"
+	propertyContext                     = "Context"
+	propertyHTTPHeaders                 = "HttpHeaders"
+	propertyDeliveryRetryPolicy         = "DeliveryRetryPolicy"
+	propertyOriginResources             = "OriginResources"
+	eventDestinationResourceName        = "EventDestination"
+)
+
+// eventService is the Redfish EventService resource.
+type eventService struct {
+	odataHeader
+	ID                           string         `json:"Id"`
+	Name                         string         `json:"Name"`
+	ServiceEnabled               bool           `json:"ServiceEnabled"`
+	DeliveryRetryAttempts        int            `json:"DeliveryRetryAttempts"`
+	DeliveryRetryIntervalSeconds int            `json:"DeliveryRetryIntervalSeconds"`
+	EventFormatTypes             []string       `json:"EventFormatTypes"`
+	RegistryPrefixes             []string       `json:"RegistryPrefixes"`
+	ResourceTypes                []string       `json:"ResourceTypes"`
+	ServerSentEventURI           string         `json:"ServerSentEventUri"`
+	SSEFilterPropertiesSupported map[string]any `json:"SSEFilterPropertiesSupported"`
+	Subscriptions                odataLink      `json:"Subscriptions"`
+	Status                       resourceStatus `json:"Status"`
+	Actions                      struct {
+		SubmitTestEvent actionTarget `json:"#EventService.SubmitTestEvent"`
+	} `json:"Actions"`
+}
+
+// eventDestination is the Redfish EventDestination resource.
+type eventDestination struct {
+	odataHeader
+	ID                      string              `json:"Id"`
+	Name                    string              `json:"Name"`
+	Destination             string              `json:"Destination"`
+	Context                 string              `json:"Context"`
+	Protocol                string              `json:"Protocol"`
+	SubscriptionType        string              `json:"SubscriptionType"`
+	EventFormatType         string              `json:"EventFormatType"`
+	MetricReportDefinitions []odataLink         `json:"MetricReportDefinitions"`
+	RegistryPrefixes        []string            `json:"RegistryPrefixes"`
+	MessageIDs              []string            `json:"MessageIds"`
+	ResourceTypes           []string            `json:"ResourceTypes"`
+	OriginResources         []odataLink         `json:"OriginResources"`
+	HTTPHeaders             []map[string]string `json:"HttpHeaders"`
+	DeliveryRetryPolicy     string              `json:"DeliveryRetryPolicy"`
+	Status                  resourceStatus      `json:"Status"`
+	Actions                 struct {
+		ResumeSubscription actionTarget `json:"#EventDestination.ResumeSubscription"`
+	} `json:"Actions"`
+}
+
+// testEventRequest is the body of the SubmitTestEvent action.
+type testEventRequest struct {
+	EventTimestamp    string     `json:"EventTimestamp"`
+	MessageID         string     `json:"MessageId"`
+	Message           string     `json:"Message"`
+	MessageArgs       []string   `json:"MessageArgs"`
+	Severity          string     `json:"Severity"`
+	MessageSeverity   string     `json:"MessageSeverity"`
+	OriginOfCondition *odataLink `json:"OriginOfCondition"`
+}
+
+// eventResourceTypes returns the resource types events originate from.
+func eventResourceTypes() []string {
+	return []string{"Chassis", "ComputerSystem", "Manager", "Sensor"}
+}
+
+// retryPolicies returns the supported delivery retry policies.
+func retryPolicies() []string {
+	return []string{retryPolicyTerminate, retryPolicySuspend, retryPolicyRetryAlways}
+}
+
+// eventMessageIDs returns the message IDs of the built-in registry.
+func eventMessageIDs() []string {
+	messages := eventRegistryMessages()
+	ids := make([]string, 0, len(messages))
+	for key := range messages {
+		ids = append(ids, eventMessagePrefix+key)
+	}
+	return ids
+}
+
+func (s *redfishServer) registerEventService() {
+	s.handle(http.MethodGet, eventServicePath, s.handleEventService)
+	s.handlePrivileged(http.MethodPost, eventServicePath+"/Actions/"+actionSubmitTestEvent, privilegeConfigureManager, s.handleSubmitTestEvent)
+	s.handle(http.MethodGet, ssePath, s.handleSSE)
+	s.handle(http.MethodGet, subscriptionsPath, s.handleSubscriptions)
+	s.handlePrivileged(http.MethodPost, subscriptionsPath, privilegeConfigureManager, s.handleCreateSubscription)
+	s.handle(http.MethodGet, subscriptionsPath+"/{id}", s.handleSubscription)
+	s.handlePrivileged(http.MethodPatch, subscriptionsPath+"/{id}", privilegeConfigureManager, s.handlePatchSubscription)
+	s.handlePrivileged(http.MethodDelete, subscriptionsPath+"/{id}", privilegeConfigureManager, s.handleDeleteSubscription)
+	s.handlePrivileged(http.MethodPost, subscriptionsPath+"/{id}/Actions/"+actionResumeSubscription, privilegeConfigureManager, s.handleResumeSubscription)
+}
+
+func (s *redfishServer) handleEventService(w http.ResponseWriter, r *http.Request) {
+	filterProperties := make(map[string]any)
+	for _, p := range sseFilterProperties() {
+		filterProperties[p] = true
+	}
+
+	svc := &eventService{
+		odataHeader:                  odataHeader{ODataID: eventServicePath, ODataType: odataTypeEventService},
+		ID:                           "EventService",
+		Name:                         "Event Service",
+		ServiceEnabled:               true,
+		DeliveryRetryAttempts:        s.config.redfishEventRetryAttempts,
+		DeliveryRetryIntervalSeconds: int(s.config.redfishEventRetryInterval / time.Second),
+		EventFormatTypes:             []string{eventFormatTypeEvent, eventFormatTypeMetricReport},
+		RegistryPrefixes:             []string{eventRegistryPrefix},
+		ResourceTypes:                eventResourceTypes(),
+		ServerSentEventURI:           ssePath,
+		SSEFilterPropertiesSupported: filterProperties,
+		Subscriptions:                link(subscriptionsPath),
+		Status:                       resourceStatus{State: "Enabled", Health: "OK"},
+	}
+	svc.Actions.SubmitTestEvent = actionTarget{Target: eventServicePath + "/Actions/" + actionSubmitTestEvent}
+
+	s.writeResource(w, r, svc)
+}
+
+func (s *redfishServer) handleSubmitTestEvent(w http.ResponseWriter, r *http.Request) {
+	var req testEventRequest
+	if !s.readAction(w, r, &req) {
+		return
+	}
+
+	message := req.Message
+	if message == "" {
+		message = "Test event submitted through the event service."
+	}
+	rec := newEventRecord(msgTestEvent, "", "", message)
+	if req.MessageID != "" {
+		rec.MessageID = req.MessageID
+		rec.Message = message
+		rec.MessageArgs = req.MessageArgs
+	}
+	switch {
+	case req.MessageSeverity != "":
+		rec.MessageSeverity = req.MessageSeverity
+	case req.Severity != "":
+		rec.MessageSeverity = req.Severity
+	}
+	if rec.MessageArgs == nil {
+		rec.MessageArgs = []string{}
+	}
+	rec.OriginOfCondition = req.OriginOfCondition
+	rec.EventTimestamp = req.EventTimestamp
+
+	s.events.publish(r.Context(), rec)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleSSE streams events as Server-Sent Events until the client
+// disconnects. The $filter query parameter selects the events to stream.
+func (s *redfishServer) handleSSE(w http.ResponseWriter, r *http.Request) {
+	expr := r.URL.Query().Get("$filter")
+	filter, err := parseEventFilter(expr)
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, "QueryParameterValueFormatError",
+			fmt.Sprintf("The value '%s' for the parameter $filter is of a different format than the parameter can accept.", expr),
+			expr, "$filter")
+		return
+	}
+
+	// The stream outlives the write timeout of the server.
+	rc := http.NewResponseController(w)
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", contentTypeEventStream)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		s.logger.WarnContext(r.Context(), "Server-Sent Events are not supported by the connection", "error", err)
+		return
+	}
+
+	l := s.events.listen(filter)
+	defer s.events.unlisten(l)
+
+	ticker := time.NewTicker(sseKeepAliveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case rec := <-l.events:
+			data, err := json.Marshal(rec.payload(""))
+			if err != nil {
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "id: %s\ndata: %s\n\n", rec.EventID, data); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if _, err := io.WriteString(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+func (s *redfishServer) handleSubscriptions(w http.ResponseWriter, r *http.Request) {
+	s.writeResource(w, r, newCollection(subscriptionsPath, odataTypeEventDestinationCollection,
+		"Event Subscriptions Collection", s.events.subscriptionIDs()))
+}
+
+func (s *redfishServer) handleSubscription(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	sub, ok := s.events.subscription(id)
+	if !ok {
+		s.writeRequestError(w, r, ErrNotFound, eventDestinationResourceName, id)
+		return
+	}
+
+	s.writeResource(w, r, newEventDestination(&sub))
+}
+
+// newEventDestination returns the Redfish representation of a subscription.
+// HTTP headers are write-only and never returned.
+func newEventDestination(sub *eventSubscription) *eventDestination {
+	path := subscriptionsPath + "/" + url.PathEscape(sub.ID)
+	dest := &eventDestination{
+		odataHeader:             odataHeader{ODataID: path, ODataType: odataTypeEventDestination},
+		ID:                      sub.ID,
+		Name:                    "Event Subscription " + sub.ID,
+		Destination:             sub.Destination,
+		Context:                 sub.Context,
+		Protocol:                eventDestinationProtocol,
+		SubscriptionType:        eventDestinationSubscriptionType,
+		EventFormatType:         sub.formatType(),
+		MetricReportDefinitions: make([]odataLink, 0, len(sub.MetricReportDefinitions)),
+		RegistryPrefixes:        append([]string{}, sub.RegistryPrefixes...),
+		MessageIDs:              append([]string{}, sub.MessageIDs...),
+		ResourceTypes:           append([]string{}, sub.ResourceTypes...),
+		OriginResources:         make([]odataLink, 0, len(sub.OriginResources)),
+		HTTPHeaders:             []map[string]string{},
+		DeliveryRetryPolicy:     sub.DeliveryRetryPolicy,
+		Status:                  resourceStatus{State: "Enabled", Health: "OK"},
+	}
+	for _, origin := range sub.OriginResources {
+		dest.OriginResources = append(dest.OriginResources, link(origin))
+	}
+	for _, def := range sub.MetricReportDefinitions {
+		dest.MetricReportDefinitions = append(dest.MetricReportDefinitions, link(def))
+	}
+	if sub.Suspended {
+		dest.Status = resourceStatus{State: "Disabled", Health: "Warning"}
+	}
+	dest.Actions.ResumeSubscription = actionTarget{Target: path + "/Actions/" + actionResumeSubscription}
+	return dest
+}
+
+func (s *redfishServer) handleCreateSubscription(w http.ResponseWriter, r *http.Request) {
+	props, ok := s.readProperties(w, r, []string{
+		propertyDestination, propertyContext, "Protocol", "SubscriptionType", propertyEventFormatType,
+		"RegistryPrefixes", "MessageIds", "ResourceTypes", propertyOriginResources,
+		propertyHTTPHeaders, propertyDeliveryRetryPolicy, propertyMetricReportDefinitions,
+	}, []string{"Id", "Name", "Status"})
+	if !ok {
+		return
+	}
+
+	if _, ok := props[propertyDestination]; !ok {
+		s.writeError(w, http.StatusBadRequest, "PropertyMissing",
+			"The property Destination is a required property and must be included in the request.", propertyDestination)
+		return
+	}
+
+	sub := eventSubscription{DeliveryRetryPolicy: retryPolicyTerminate}
+	var protocol, subscriptionType string
+	var origins, definitions []odataLink
+	if !s.decodeProperty(w, props, propertyDestination, &sub.Destination) ||
+		!s.decodeProperty(w, props, "Protocol", &protocol) ||
+		!s.decodeProperty(w, props, "SubscriptionType", &subscriptionType) ||
+		!s.decodeProperty(w, props, propertyEventFormatType, &sub.EventFormatType) ||
+		!s.decodeProperty(w, props, "RegistryPrefixes", &sub.RegistryPrefixes) ||
+		!s.decodeProperty(w, props, "MessageIds", &sub.MessageIDs) ||
+		!s.decodeProperty(w, props, "ResourceTypes", &sub.ResourceTypes) ||
+		!s.decodeProperty(w, props, propertyOriginResources, &origins) ||
+		!s.decodeProperty(w, props, propertyMetricReportDefinitions, &definitions) ||
+		!s.applySubscriptionProperties(w, props, &sub) {
+		return
+	}
+
+	if u, err := url.Parse(sub.Destination); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		s.writeError(w, http.StatusBadRequest, "PropertyValueFormatError",
+			fmt.Sprintf("The value '%s' for the property Destination is of a different format than the property can accept.", sub.Destination),
+			sub.Destination, propertyDestination)
+		return
+	}
+	if (protocol != "" && !s.checkPropertyValues(w, "Protocol", []string{protocol}, []string{eventDestinationProtocol})) ||
+		(subscriptionType != "" && !s.checkPropertyValues(w, "SubscriptionType", []string{subscriptionType}, []string{eventDestinationSubscriptionType})) ||
+		(sub.EventFormatType != "" && !s.checkPropertyValues(w, propertyEventFormatType, []string{sub.EventFormatType},
+			[]string{eventFormatTypeEvent, eventFormatTypeMetricReport})) ||
+		!s.checkPropertyValues(w, "RegistryPrefixes", sub.RegistryPrefixes, []string{eventRegistryPrefix}) ||
+		!s.checkPropertyValues(w, "ResourceTypes", sub.ResourceTypes, eventResourceTypes()) {
+		return
+	}
+	for _, id := range sub.MessageIDs {
+		if !slices.ContainsFunc(eventMessageIDs(), func(m string) bool { return messageIDMatches(id, m) }) {
+			s.writeError(w, http.StatusBadRequest, "PropertyValueNotInList",
+				fmt.Sprintf("The value '%s' for the property MessageIds is not in the list of acceptable values.", id), id, "MessageIds")
+			return
+		}
+	}
+	for _, origin := range origins {
+		sub.OriginResources = append(sub.OriginResources, origin.ODataID)
+	}
+	if sub.EventFormatType == eventFormatTypeEvent {
+		sub.EventFormatType = ""
+	}
+	if len(definitions) > 0 && sub.EventFormatType != eventFormatTypeMetricReport {
+		s.writeError(w, http.StatusBadRequest, "PropertyValueConflict",
+			fmt.Sprintf("The property '%s' could not be written because its value would conflict with the value of the '%s' property.",
+				propertyMetricReportDefinitions, propertyEventFormatType),
+			propertyMetricReportDefinitions, propertyEventFormatType)
+		return
+	}
+	for _, def := range definitions {
+		id, ok := strings.CutPrefix(def.ODataID, metricReportDefinitionsPath+"/")
+		if _, exists := s.telemetry.definition(id); !ok || !exists {
+			s.writeError(w, http.StatusBadRequest, "PropertyValueNotInList",
+				fmt.Sprintf("The value '%s' for the property MetricReportDefinitions is not in the list of acceptable values.", def.ODataID),
+				def.ODataID, propertyMetricReportDefinitions)
+			return
+		}
+		sub.MetricReportDefinitions = append(sub.MetricReportDefinitions, def.ODataID)
+	}
+
+	sub, err := s.events.create(r.Context(), sub)
+	if err != nil {
+		if errors.Is(err, ErrTooManySubscriptions) {
+			s.writeError(w, http.StatusBadRequest, "CreateLimitReachedForResource",
+				"The create operation failed because the resource has reached the limit of possible resources.")
+			return
+		}
+		s.writeInternalError(w, r, err)
+		return
+	}
+
+	s.logger.InfoContext(r.Context(), "Redfish event subscription created",
+		"subscription", sub.ID,
+		"destination", sub.Destination)
+
+	dest := newEventDestination(&sub)
+	w.Header().Set("Location", dest.ODataID)
+	w.Header().Set("Content-Type", contentTypeJSON)
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(dest)
+}
+
+// applySubscriptionProperties decodes the properties of a subscription that
+// can be changed after its creation.
+func (s *redfishServer) applySubscriptionProperties(w http.ResponseWriter, props map[string]json.RawMessage, sub *eventSubscription) bool {
+	var headers []map[string]string
+	if !s.decodeProperty(w, props, propertyContext, &sub.Context) ||
+		!s.decodeProperty(w, props, propertyDeliveryRetryPolicy, &sub.DeliveryRetryPolicy) ||
+		!s.decodeProperty(w, props, propertyHTTPHeaders, &headers) {
+		return false
+	}
+
+	if len(sub.Context) > maxEventContextLength {
+		s.writeError(w, http.StatusBadRequest, "PropertyValueFormatError",
+			fmt.Sprintf("The value '%s' for the property Context is of a different format than the property can accept.", sub.Context),
+			sub.Context, propertyContext)
+		return false
+	}
+	if !s.checkPropertyValues(w, propertyDeliveryRetryPolicy, []string{sub.DeliveryRetryPolicy}, retryPolicies()) {
+		return false
+	}
+	if _, ok := props[propertyHTTPHeaders]; ok {
+		sub.HTTPHeaders = make(map[string]string)
+		for _, h := range headers {
+			for name, value := range h {
+				sub.HTTPHeaders[name] = value
+			}
+		}
+	}
+	return true
+}
+
+func (s *redfishServer) handlePatchSubscription(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	sub, ok := s.events.subscription(id)
+	if !ok {
+		s.writeRequestError(w, r, ErrNotFound, eventDestinationResourceName, id)
+		return
+	}
+
+	props, ok := s.readProperties(w, r,
+		[]string{propertyContext, propertyHTTPHeaders, propertyDeliveryRetryPolicy},
+		[]string{"Id", "Name", "Status", propertyDestination, "Protocol", "SubscriptionType", propertyEventFormatType,
+			"RegistryPrefixes", "MessageIds", "ResourceTypes", propertyOriginResources, propertyMetricReportDefinitions})
+	if !ok || !s.applySubscriptionProperties(w, props, &sub) {
+		return
+	}
+
+	sub, err := s.events.update(r.Context(), id, func(cur *eventSubscription) {
+		cur.Context = sub.Context
+		cur.DeliveryRetryPolicy = sub.DeliveryRetryPolicy
+		cur.HTTPHeaders = sub.HTTPHeaders
+	})
+	if err != nil {
+		s.writeRequestError(w, r, err, eventDestinationResourceName, id)
+		return
+	}
+
+	s.writeResource(w, r, newEventDestination(&sub))
+}
+
+func (s *redfishServer) handleDeleteSubscription(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	if err := s.events.remove(r.Context(), id); err != nil {
+		s.writeRequestError(w, r, err, eventDestinationResourceName, id)
+		return
+	}
+
+	s.logger.InfoContext(r.Context(), "Redfish event subscription deleted", "subscription", id)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *redfishServer) handleResumeSubscription(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	if _, err := s.events.update(r.Context(), id, func(sub *eventSubscription) {
+		sub.Suspended = false
+	}); err != nil {
+		s.writeRequestError(w, r, err, eventDestinationResourceName, id)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,308 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package websrv
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testDestination is an event receiver that fails the first deliveries.
+type testDestination struct {
+	srv *httptest.Server
+
+	mu       sync.Mutex
+	failures int
+	times    []time.Time
+	events   []eventPayload
+	headers  []http.Header
+	posted   chan struct{}
+}
+
+// newTestDestination starts an event receiver answering the first failures
+// deliveries with 503 Service Unavailable. Negative values fail every
+// delivery.
+func newTestDestination(t *testing.T, failures int) *testDestination {
+	t.Helper()
+
+	d := &testDestination{failures: failures, posted: make(chan struct{}, 64)}
+	d.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload eventPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("decode event: %v", err)
+		}
+
+		d.mu.Lock()
+		d.times = append(d.times, time.Now())
+		d.events = append(d.events, payload)
+		d.headers = append(d.headers, r.Header.Clone())
+		fail := d.failures != 0
+		if d.failures > 0 {
+			d.failures--
+		}
+		d.mu.Unlock()
+
+		if fail {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		} else {
+			w.WriteHeader(http.StatusNoContent)
+		}
+		d.posted <- struct{}{}
+	}))
+	t.Cleanup(d.srv.Close)
+	return d
+}
+
+// wait waits for n deliveries.
+func (d *testDestination) wait(t *testing.T, n int) {
+	t.Helper()
+	for i := range n {
+		select {
+		case <-d.posted:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%d of %d deliveries received", i, n)
+		}
+	}
+}
+
+// deliveries returns the delivery times and events received so far.
+func (d *testDestination) deliveries() ([]time.Time, []eventPayload, []http.Header) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]time.Time(nil), d.times...), append([]eventPayload(nil), d.events...), append([]http.Header(nil), d.headers...)
+}
+
+// subscribe creates a push subscription for the destination.
+func subscribe(t *testing.T, srv *httptest.Server, destination, properties string) string {
+	t.Helper()
+
+	body := `{"Destination": "` + destination + `"`
+	if properties != "" {
+		body += ", " + properties
+	}
+	body += "}"
+	resp, data := testRequest{method: http.MethodPost, path: subscriptionsPath, body: body, user: "admin"}.do(t, srv)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("create subscription = %s %s", resp.Status, data)
+	}
+	return resp.Header.Get("Location")
+}
+
+// submitTestEvent publishes a test event with the given message ID.
+func submitTestEvent(t *testing.T, srv *httptest.Server, messageID string) {
+	t.Helper()
+
+	body := `{}`
+	if messageID != "" {
+		body = `{"MessageId": "` + messageID + `", "Message": "Test"}`
+	}
+	resp, data := testRequest{
+		method: http.MethodPost,
+		path:   eventServicePath + "/Actions/" + actionSubmitTestEvent,
+		body:   body,
+		user:   "admin",
+	}.do(t, srv)
+	if resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("submit test event = %s %s", resp.Status, data)
+	}
+}
+
+func TestRedfishEventPushRetry(t *testing.T) {
+	const interval = 50 * time.Millisecond
+	_, srv := newTestRedfish(t, WithRedfishEventRetryAttempts(3), WithRedfishEventRetryInterval(interval))
+
+	dest := newTestDestination(t, 2)
+	location := subscribe(t, srv, dest.srv.URL, `"Context": "rack-1", "HttpHeaders": [{"X-Token": "secret"}]`)
+
+	submitTestEvent(t, srv, "")
+	dest.wait(t, 3)
+
+	times, events, headers := dest.deliveries()
+	if len(times) != 3 {
+		t.Fatalf("%d deliveries, want 3", len(times))
+	}
+	// The retry delay doubles after every failure.
+	for i, want := range []time.Duration{interval, 2 * interval} {
+		if got := times[i+1].Sub(times[i]); got < want {
+			t.Errorf("delay before retry %d = %s, want at least %s", i+1, got, want)
+		}
+	}
+	for i, ev := range events {
+		if ev.Context != "rack-1" || len(ev.Events) != 1 || ev.Events[0].MessageID != eventMessagePrefix+msgTestEvent {
+			t.Errorf("delivery %d = %+v", i, ev)
+		}
+		if ev.Events[0].EventID != events[0].Events[0].EventID {
+			t.Errorf("retry %d carries event %s, want %s", i, ev.Events[0].EventID, events[0].Events[0].EventID)
+		}
+		if headers[i].Get("X-Token") != "secret" {
+			t.Errorf("delivery %d lacks the subscription's HTTP headers", i)
+		}
+	}
+
+	resp, _ := testRequest{method: http.MethodGet, path: location, user: "admin"}.do(t, srv)
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("subscription after a successful retry = %s, want 200", resp.Status)
+	}
+}
+
+func TestRedfishEventRetryPolicies(t *testing.T) {
+	const attempts = 2
+
+	t.Run(retryPolicyTerminate, func(t *testing.T) {
+		_, srv := newTestRedfish(t, WithRedfishEventRetryAttempts(attempts), WithRedfishEventRetryInterval(time.Millisecond))
+		dest := newTestDestination(t, -1)
+		location := subscribe(t, srv, dest.srv.URL, `"DeliveryRetryPolicy": "`+retryPolicyTerminate+`"`)
+
+		submitTestEvent(t, srv, "")
+		dest.wait(t, attempts+1)
+
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			resp, _ := testRequest{method: http.MethodGet, path: location, user: "admin"}.do(t, srv)
+			if resp.StatusCode == http.StatusNotFound {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("subscription still present after the retries: %s", resp.Status)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	})
+
+	t.Run(retryPolicySuspend, func(t *testing.T) {
+		_, srv := newTestRedfish(t, WithRedfishEventRetryAttempts(attempts), WithRedfishEventRetryInterval(time.Millisecond))
+		dest := newTestDestination(t, attempts+1)
+		location := subscribe(t, srv, dest.srv.URL, `"DeliveryRetryPolicy": "`+retryPolicySuspend+`"`)
+
+		submitTestEvent(t, srv, "")
+		dest.wait(t, attempts+1)
+
+		state := func() string {
+			_, data := testRequest{method: http.MethodGet, path: location, user: "admin"}.do(t, srv)
+			var res eventDestination
+			if err := json.Unmarshal(data, &res); err != nil {
+				t.Fatal(err)
+			}
+			return res.Status.State
+		}
+		deadline := time.Now().Add(5 * time.Second)
+		for state() != "Disabled" {
+			if time.Now().After(deadline) {
+				t.Fatal("subscription not suspended after the retries")
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		// Suspended subscriptions receive nothing until they are resumed.
+		submitTestEvent(t, srv, "")
+		resp, data := testRequest{
+			method: http.MethodPost,
+			path:   location + "/Actions/" + actionResumeSubscription,
+			body:   `{}`,
+			user:   "admin",
+		}.do(t, srv)
+		if resp.StatusCode != http.StatusNoContent {
+			t.Fatalf("resume = %s %s", resp.Status, data)
+		}
+		if got := state(); got != "Enabled" {
+			t.Fatalf("state after resume = %s", got)
+		}
+		submitTestEvent(t, srv, "")
+		dest.wait(t, 1)
+
+		_, events, _ := dest.deliveries()
+		if last, first := events[len(events)-1].Events[0].EventID, events[0].Events[0].EventID; len(events) != attempts+2 || last == first {
+			t.Errorf("%d deliveries, last event %s: the event published while suspended was delivered", len(events), last)
+		}
+	})
+}
+
+func TestRedfishSSE(t *testing.T) {
+	rs, srv := newTestRedfish(t)
+
+	filter := url.QueryEscape("MessageId eq '" + eventMessagePrefix + msgTestEvent + "'")
+	req, err := http.NewRequest(http.MethodGet, srv.URL+ssePath+"?$filter="+filter, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetBasicAuth("reader", testAccounts()["reader"].password)
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != contentTypeEventStream {
+		t.Fatalf("SSE response = %s %s", resp.Status, resp.Header.Get("Content-Type"))
+	}
+
+	// The listener is registered after the headers are flushed.
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		rs.events.mu.Lock()
+		n := len(rs.events.listeners)
+		rs.events.mu.Unlock()
+		if n == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("SSE listener not registered")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	submitTestEvent(t, srv, "Other.1.0.Ignored")
+	submitTestEvent(t, srv, "")
+
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+		sc := bufio.NewScanner(resp.Body)
+		for sc.Scan() {
+			lines <- sc.Text()
+		}
+	}()
+
+	var id, data string
+	for data == "" {
+		select {
+		case line := <-lines:
+			if v, ok := strings.CutPrefix(line, "id: "); ok {
+				id = v
+			}
+			if v, ok := strings.CutPrefix(line, "data: "); ok {
+				data = v
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("no event streamed")
+		}
+	}
+
+	var payload eventPayload
+	if err := json.Unmarshal([]byte(data), &payload); err != nil {
+		t.Fatalf("decode event %q: %v", data, err)
+	}
+	if len(payload.Events) != 1 || payload.Events[0].MessageID != eventMessagePrefix+msgTestEvent {
+		t.Fatalf("streamed %+v, want only the event matching the filter", payload)
+	}
+	if payload.Events[0].EventID != id {
+		t.Errorf("event ID %s, SSE id %s", payload.Events[0].EventID, id)
+	}
+
+	t.Run("invalid filter", func(t *testing.T) {
+		resp, data := testRequest{
+			method: http.MethodGet,
+			path:   ssePath + "?$filter=" + url.QueryEscape("Severity eq 'OK'"),
+			user:   "reader",
+		}.do(t, srv)
+		if resp.StatusCode != http.StatusBadRequest || errorMessageID(t, data) != "QueryParameterValueFormatError" {
+			t.Errorf("status = %s %s", resp.Status, data)
+		}
+	})
+}
@@ -0,0 +1,338 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package websrv
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// $filter operators.
+const (
+	filterAnd = "and"
+	filterOr  = "or"
+	filterNot = "not"
+	filterEq  = "eq"
+	filterNe  = "ne"
+	filterGt  = "gt"
+	filterGe  = "ge"
+	filterLt  = "lt"
+	filterLe  = "le"
+)
+
+// filterExpr is a parsed $filter expression evaluated against the members of
+// a collection.
+type filterExpr interface {
+	eval(obj map[string]any) bool
+}
+
+// filterLogical combines two expressions with and or or.
+type filterLogical struct {
+	op          string
+	left, right filterExpr
+}
+
+func (e *filterLogical) eval(obj map[string]any) bool {
+	if e.op == filterAnd {
+		return e.left.eval(obj) && e.right.eval(obj)
+	}
+	return e.left.eval(obj) || e.right.eval(obj)
+}
+
+// filterNegation negates an expression.
+type filterNegation struct {
+	expr filterExpr
+}
+
+func (e *filterNegation) eval(obj map[string]any) bool {
+	return !e.expr.eval(obj)
+}
+
+// filterOperand is a property path or a literal value. Literals are strings,
+// float64 numbers, booleans or nil.
+type filterOperand struct {
+	path  []string
+	value any
+}
+
+// resolve returns the value of the operand for obj. Properties missing from
+// obj are reported as not found.
+func (o *filterOperand) resolve(obj map[string]any) (any, bool) {
+	if o.path == nil {
+		return o.value, true
+	}
+
+	var value any = obj
+	for _, name := range o.path {
+		m, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if value, ok = m[name]; !ok {
+			return nil, false
+		}
+	}
+	if n, ok := value.(json.Number); ok {
+		f, err := n.Float64()
+		if err != nil {
+			return nil, false
+		}
+		return f, true
+	}
+	return value, true
+}
+
+// filterComparison compares two operands.
+type filterComparison struct {
+	op          string
+	left, right filterOperand
+}
+
+func (e *filterComparison) eval(obj map[string]any) bool {
+	left, ok := e.left.resolve(obj)
+	if !ok {
+		return false
+	}
+	right, ok := e.right.resolve(obj)
+	if !ok {
+		return false
+	}
+
+	var cmp int
+	switch l := left.(type) {
+	case float64:
+		r, ok := right.(float64)
+		if !ok {
+			return e.op == filterNe
+		}
+		switch {
+		case l < r:
+			cmp = -1
+		case l > r:
+			cmp = 1
+		}
+	case string:
+		r, ok := right.(string)
+		if !ok {
+			return e.op == filterNe
+		}
+		cmp = strings.Compare(l, r)
+	case bool, nil:
+		// Booleans and null only support equality.
+		equal := left == right
+		switch e.op {
+		case filterEq:
+			return equal
+		case filterNe:
+			return !equal
+		default:
+			return false
+		}
+	default:
+		// Objects and arrays cannot be compared.
+		return false
+	}
+
+	switch e.op {
+	case filterEq:
+		return cmp == 0
+	case filterNe:
+		return cmp != 0
+	case filterGt:
+		return cmp > 0
+	case filterGe:
+		return cmp >= 0
+	case filterLt:
+		return cmp < 0
+	default:
+		return cmp <= 0
+	}
+}
+
+// isComparison reports whether op is a comparison operator.
+func isComparison(op string) bool {
+	switch op {
+	case filterEq, filterNe, filterGt, filterGe, filterLt, filterLe:
+		return true
+	default:
+		return false
+	}
+}
+
+// filterToken is a token of a $filter expression.
+type filterToken struct {
+	text string
+	// quoted marks string literals, whose text is the unquoted string.
+	quoted bool
+}
+
+// filterParser parses $filter expressions following the grammar of DSP0266.
+// In order of precedence, expressions are grouped by parentheses, negated by
+// not, compared by eq, ne, gt, ge, lt and le, and combined by and and or.
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+}
+
+// parseFilter parses a $filter value.
+func parseFilter(value string) (filterExpr, *queryError) {
+	tokens, ok := tokenizeFilter(value)
+	if !ok || len(tokens) == 0 {
+		return nil, errQueryFormat(queryFilter, value)
+	}
+
+	p := &filterParser{tokens: tokens}
+	expr, ok := p.parseOr()
+	if !ok || p.pos != len(p.tokens) {
+		return nil, errQueryFormat(queryFilter, value)
+	}
+	return expr, nil
+}
+
+// tokenizeFilter splits a $filter value into parentheses, string literals
+// and words, which are operators, properties and other literals.
+func tokenizeFilter(value string) ([]filterToken, bool) {
+	var tokens []filterToken
+	for i := 0; i < len(value); {
+		switch c := value[i]; {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '(' || c == ')':
+			tokens = append(tokens, filterToken{text: value[i : i+1]})
+			i++
+		case c == '\'':
+			// Quotes within strings are escaped by doubling them.
+			var b strings.Builder
+			for i++; ; i++ {
+				if i >= len(value) {
+					return nil, false
+				}
+				if value[i] == '\'' {
+					if i+1 < len(value) && value[i+1] == '\'' {
+						i++
+					} else {
+						break
+					}
+				}
+				b.WriteByte(value[i])
+			}
+			tokens = append(tokens, filterToken{text: b.String(), quoted: true})
+			i++
+		default:
+			start := i
+			for i < len(value) && !strings.ContainsRune(" \t()'", rune(value[i])) {
+				i++
+			}
+			tokens = append(tokens, filterToken{text: value[start:i]})
+		}
+	}
+	return tokens, true
+}
+
+// peek returns the next unquoted word, or an empty string.
+func (p *filterParser) peek() string {
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].quoted {
+		return ""
+	}
+	return p.tokens[p.pos].text
+}
+
+func (p *filterParser) parseOr() (filterExpr, bool) {
+	left, ok := p.parseAnd()
+	for ok && p.peek() == filterOr {
+		p.pos++
+		var right filterExpr
+		if right, ok = p.parseAnd(); ok {
+			left = &filterLogical{op: filterOr, left: left, right: right}
+		}
+	}
+	return left, ok
+}
+
+func (p *filterParser) parseAnd() (filterExpr, bool) {
+	left, ok := p.parseUnary()
+	for ok && p.peek() == filterAnd {
+		p.pos++
+		var right filterExpr
+		if right, ok = p.parseUnary(); ok {
+			left = &filterLogical{op: filterAnd, left: left, right: right}
+		}
+	}
+	return left, ok
+}
+
+func (p *filterParser) parseUnary() (filterExpr, bool) {
+	switch p.peek() {
+	case filterNot:
+		p.pos++
+		expr, ok := p.parseUnary()
+		return &filterNegation{expr: expr}, ok
+	case "(":
+		p.pos++
+		expr, ok := p.parseOr()
+		if !ok || p.peek() != ")" {
+			return nil, false
+		}
+		p.pos++
+		return expr, true
+	default:
+		return p.parseComparison()
+	}
+}
+
+func (p *filterParser) parseComparison() (filterExpr, bool) {
+	left, ok := p.parseOperand()
+	if !ok {
+		return nil, false
+	}
+	op := p.peek()
+	if !isComparison(op) {
+		return nil, false
+	}
+	p.pos++
+	right, ok := p.parseOperand()
+	if !ok {
+		return nil, false
+	}
+	return &filterComparison{op: op, left: left, right: right}, true
+}
+
+// parseOperand parses a literal or a property path with its segments
+// separated by slashes.
+func (p *filterParser) parseOperand() (filterOperand, bool) {
+	if p.pos >= len(p.tokens) {
+		return filterOperand{}, false
+	}
+	tok := p.tokens[p.pos]
+	p.pos++
+
+	if tok.quoted {
+		return filterOperand{value: tok.text}, true
+	}
+	switch tok.text {
+	case "true":
+		return filterOperand{value: true}, true
+	case "false":
+		return filterOperand{value: false}, true
+	case "null":
+		return filterOperand{value: nil}, true
+	case "(", ")":
+		return filterOperand{}, false
+	}
+	if c := tok.text[0]; c == '-' || c == '.' || (c >= '0' && c <= '9') {
+		f, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return filterOperand{}, false
+		}
+		return filterOperand{value: f}, true
+	}
+
+	path := strings.Split(tok.text, "/")
+	for _, segment := range path {
+		if !isPropertyName(segment) {
+			return filterOperand{}, false
+		}
+	}
+	return filterOperand{path: path}, true
+}
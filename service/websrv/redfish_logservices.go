@@ -0,0 +1,442 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package websrv
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	schemav1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+	"github.com/u-bmc/u-bmc/pkg/ipc"
+	"github.com/u-bmc/u-bmc/pkg/sel"
+)
+
+// LogService resource types.
+const (
+	odataTypeLogServiceCollection = "#LogServiceCollection.LogServiceCollection"
+	odataTypeLogService           = "#LogService.v1_6_0.LogService"
+	odataTypeLogEntryCollection   = "#LogEntryCollection.LogEntryCollection"
+	odataTypeLogEntry             = "#LogEntry.v1_16_0.LogEntry"
+	actionClearLog                = "LogService.ClearLog"
+	logServiceResourceName        = "LogService"
+	logEntryResourceName          = "LogEntry"
+	eventLogID                    = "EventLog"
+	selLogID                      = "SEL"
+	resourceEventMessagePrefix    = "ResourceEvent.1.3."
+)
+
+// logService is the Redfish LogService resource.
+type logService struct {
+	odataHeader
+	ID                  string         `json:"Id"`
+	Name                string         `json:"Name"`
+	Description         string         `json:"Description"`
+	LogEntryType        string         `json:"LogEntryType"`
+	MaxNumberOfRecords  int            `json:"MaxNumberOfRecords"`
+	OverWritePolicy     string         `json:"OverWritePolicy"`
+	DateTime            string         `json:"DateTime"`
+	DateTimeLocalOffset string         `json:"DateTimeLocalOffset"`
+	ServiceEnabled      bool           `json:"ServiceEnabled"`
+	Status              resourceStatus `json:"Status"`
+	Entries             odataLink      `json:"Entries"`
+	Actions             struct {
+		ClearLog *actionTarget `json:"#LogService.ClearLog,omitempty"`
+	} `json:"Actions"`
+}
+
+// logEntry is the Redfish LogEntry resource.
+type logEntry struct {
+	odataHeader
+	ID           string   `json:"Id"`
+	Name         string   `json:"Name"`
+	EntryType    string   `json:"EntryType"`
+	Severity     string   `json:"Severity"`
+	Created      string   `json:"Created"`
+	Message      string   `json:"Message"`
+	MessageID    string   `json:"MessageId"`
+	MessageArgs  []string `json:"MessageArgs"`
+	SensorType   string   `json:"SensorType,omitempty"`
+	SensorNumber *int     `json:"SensorNumber,omitempty"`
+	EntryCode    string   `json:"EntryCode,omitempty"`
+	Links        struct {
+		OriginOfCondition *odataLink `json:"OriginOfCondition,omitempty"`
+	} `json:"Links"`
+}
+
+// logEntryCollection is a LogEntryCollection whose members are embedded, so
+// that expanding it takes a single request.
+type logEntryCollection struct {
+	odataHeader
+	Name         string      `json:"Name"`
+	Members      []*logEntry `json:"Members"`
+	MembersCount int         `json:"Members@odata.count"`
+}
+
+// logScope is the manager or system whose LogServices a request addresses.
+type logScope struct {
+	path string
+	logs []string
+	// match reports whether an event log entry originating from the given
+	// resource belongs to the scope. It is nil if all entries belong to it.
+	match func(origin string) bool
+}
+
+// logScopeFunc resolves the logScope of a request, writing an error response
+// if the manager or system does not exist.
+type logScopeFunc func(w http.ResponseWriter, r *http.Request) (logScope, bool)
+
+// selSensorType returns the Redfish SensorType of an IPMI sensor type.
+func selSensorType(sensorType uint8) string {
+	switch sensorType {
+	case sel.SensorTypeTemperature:
+		return "Temperature"
+	case sel.SensorTypeVoltage:
+		return "Voltage"
+	case sel.SensorTypeCurrent:
+		return "Current"
+	case sel.SensorTypeFan:
+		return "Fan"
+	case sel.SensorTypePowerUnit:
+		return "Power Unit"
+	case sel.SensorTypeOtherUnits:
+		return "Other Units-based Sensor"
+	case sel.SensorTypeSystemACPIPowerState:
+		return "System ACPI PowerState"
+	case sel.SensorTypeManagementSubsystemHealth:
+		return "Management Subsystem Health"
+	default:
+		return ""
+	}
+}
+
+// selEntryCode returns the Redfish EntryCode of an IPMI system event record.
+func selEntryCode(rec *sel.Record) string {
+	if rec.Deassertion {
+		return "Deassert"
+	}
+	if rec.EventType != sel.EventTypeThreshold {
+		return "Assert"
+	}
+	switch rec.Offset() {
+	case sel.OffsetLowerNonCriticalGoingLow:
+		return "Lower Non-critical - going low"
+	case sel.OffsetLowerCriticalGoingLow:
+		return "Lower Critical - going low"
+	case sel.OffsetUpperNonCriticalGoingHigh:
+		return "Upper Non-critical - going high"
+	case sel.OffsetUpperCriticalGoingHigh:
+		return "Upper Critical - going high"
+	default:
+		return "Assert"
+	}
+}
+
+// selSeverity maps the severity of a system event log entry to the Redfish
+// health it reports.
+func selSeverity(severity schemav1alpha1.EventSeverity) string {
+	switch severity {
+	case schemav1alpha1.EventSeverity_EVENT_SEVERITY_WARNING:
+		return "Warning"
+	case schemav1alpha1.EventSeverity_EVENT_SEVERITY_CRITICAL:
+		return "Critical"
+	default:
+		return "OK"
+	}
+}
+
+// newEventLogEntry creates the LogEntry of an event log entry below path.
+func newEventLogEntry(path string, rec *logRecord) *logEntry {
+	entry := &logEntry{
+		odataHeader: odataHeader{ODataID: path + "/" + url.PathEscape(rec.id), ODataType: odataTypeLogEntry},
+		ID:          rec.id,
+		Name:        "Log Entry " + rec.id,
+		EntryType:   "Event",
+		Severity:    rec.event.MessageSeverity,
+		Created:     rec.created.UTC().Format(time.RFC3339),
+		Message:     rec.event.Message,
+		MessageID:   rec.event.MessageID,
+		MessageArgs: rec.event.MessageArgs,
+	}
+	entry.Links.OriginOfCondition = rec.event.OriginOfCondition
+	return entry
+}
+
+// newSELEntry creates the LogEntry of a system event log entry below path.
+// Threshold violations refer to the threshold messages of the ResourceEvent
+// registry, all other entries to the health change they report.
+func newSELEntry(path string, e *schemav1alpha1.SystemEventLogEntry) *logEntry {
+	id := strconv.FormatUint(uint64(e.GetRecordId()), 10)
+	severity := selSeverity(e.GetSeverity())
+
+	key, args := msgResourceStatusChangedOK, []string{e.GetOrigin(), severity}
+	switch {
+	case e.Threshold != nil && e.Reading != nil && severity == "Critical":
+		key = msgResourceErrorThresholdExceeded
+		args = []string{e.GetOrigin(), strconv.FormatFloat(e.GetThreshold(), 'f', -1, 64)}
+	case e.Threshold != nil && e.Reading != nil && severity == "Warning":
+		key = msgResourceWarningThresholdExceeded
+		args = []string{e.GetOrigin(), strconv.FormatFloat(e.GetThreshold(), 'f', -1, 64)}
+	case severity == "Warning":
+		key = msgResourceStatusChangedWarning
+	case severity == "Critical":
+		key = msgResourceStatusChangedCritical
+	}
+
+	entry := &logEntry{
+		odataHeader: odataHeader{ODataID: path + "/" + id, ODataType: odataTypeLogEntry},
+		ID:          id,
+		Name:        "Log Entry " + id,
+		EntryType:   "SEL",
+		Severity:    severity,
+		Created:     e.GetTimestamp().AsTime().UTC().Format(time.RFC3339),
+		Message:     formatMessage(resourceEventRegistryMessages()[key].Message, args),
+		MessageID:   resourceEventMessagePrefix + key,
+		MessageArgs: args,
+	}
+
+	var rec sel.Record
+	if err := rec.UnmarshalBinary(e.GetIpmiRecord()); err == nil {
+		entry.SensorType = selSensorType(rec.SensorType)
+		entry.EntryCode = selEntryCode(&rec)
+		if rec.SensorNumber != sel.SensorNumberUnspecified {
+			number := int(rec.SensorNumber)
+			entry.SensorNumber = &number
+		}
+	}
+	if e.GetSource() != schemav1alpha1.EventSource_EVENT_SOURCE_SENSOR {
+		if origin, _ := componentOrigin(e.GetOrigin()); origin != "" {
+			l := link(origin)
+			entry.Links.OriginOfCondition = &l
+		}
+	}
+
+	return entry
+}
+
+func (s *redfishServer) registerLogServices() {
+	for _, scope := range []struct {
+		path    string
+		resolve logScopeFunc
+	}{
+		{managersPath, s.managerLogScope},
+		{systemsPath, s.systemLogScope},
+	} {
+		path := scope.path + "/{id}/LogServices"
+		s.handle(http.MethodGet, path, s.handleLogServices(scope.resolve))
+		s.handle(http.MethodGet, path+"/{logId}", s.handleLogService(scope.resolve))
+		s.handle(http.MethodGet, path+"/{logId}/Entries", s.handleLogEntries(scope.resolve))
+		s.handle(http.MethodGet, path+"/{logId}/Entries/{entryId}", s.handleLogEntry(scope.resolve))
+	}
+	s.handle(http.MethodPost, systemsPath+"/{id}/LogServices/"+selLogID+"/Actions/"+actionClearLog, s.handleClearSEL)
+}
+
+// managerLogScope resolves the LogServices of a manager. The event log is only
+// provided by the BMC running this service and holds all events.
+func (s *redfishServer) managerLogScope(w http.ResponseWriter, r *http.Request) (logScope, bool) {
+	id := r.PathValue("id")
+
+	primary, ok := s.primaryManager(w, r, id)
+	if !ok {
+		return logScope{}, false
+	}
+	if !primary {
+		s.writeRequestError(w, r, ErrNotFound, "LogServiceCollection", id)
+		return logScope{}, false
+	}
+
+	return logScope{
+		path: managersPath + "/" + url.PathEscape(id),
+		logs: []string{eventLogID},
+	}, true
+}
+
+// systemLogScope resolves the LogServices of a system, whose event log holds
+// the events originating from the system.
+func (s *redfishServer) systemLogScope(w http.ResponseWriter, r *http.Request) (logScope, bool) {
+	id := r.PathValue("id")
+
+	var resp schemav1alpha1.GetHostResponse
+	err := s.requestNATS(r.Context(), ipc.SubjectHostState, &schemav1alpha1.GetHostRequest{
+		Identifier: &schemav1alpha1.GetHostRequest_Name{Name: id},
+	}, &resp)
+	if err == nil && len(resp.GetHosts()) == 0 {
+		err = ErrNotFound
+	}
+	if err != nil {
+		s.writeRequestError(w, r, err, "ComputerSystem", id)
+		return logScope{}, false
+	}
+
+	path := systemsPath + "/" + url.PathEscape(id)
+	return logScope{
+		path: path,
+		logs: []string{eventLogID, selLogID},
+		match: func(origin string) bool {
+			return origin == path
+		},
+	}, true
+}
+
+// logServiceOf resolves the scope and log of a request, writing an error
+// response if either does not exist.
+func (s *redfishServer) logServiceOf(w http.ResponseWriter, r *http.Request, resolve logScopeFunc) (logScope, string, bool) {
+	scope, ok := resolve(w, r)
+	if !ok {
+		return logScope{}, "", false
+	}
+	logID := r.PathValue("logId")
+	if !slices.Contains(scope.logs, logID) {
+		s.writeRequestError(w, r, ErrNotFound, logServiceResourceName, logID)
+		return logScope{}, "", false
+	}
+	return scope, logID, true
+}
+
+func (s *redfishServer) handleLogServices(resolve logScopeFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		scope, ok := resolve(w, r)
+		if !ok {
+			return
+		}
+		s.writeResource(w, r, newCollection(scope.path+"/LogServices", odataTypeLogServiceCollection,
+			"Log Service Collection", scope.logs))
+	}
+}
+
+func (s *redfishServer) handleLogService(resolve logScopeFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		scope, logID, ok := s.logServiceOf(w, r, resolve)
+		if !ok {
+			return
+		}
+
+		path := scope.path + "/LogServices/" + logID
+		svc := &logService{
+			odataHeader:         odataHeader{ODataID: path, ODataType: odataTypeLogService},
+			ID:                  logID,
+			Name:                "Event Log Service",
+			Description:         "Events of the state transitions and sensor alerts reported by the BMC.",
+			LogEntryType:        "Event",
+			MaxNumberOfRecords:  maxEventLogEntries,
+			OverWritePolicy:     "WrapsWhenFull",
+			DateTime:            time.Now().UTC().Format(time.RFC3339),
+			DateTimeLocalOffset: "+00:00",
+			ServiceEnabled:      true,
+			Status:              resourceStatus{State: "Enabled", Health: "OK"},
+			Entries:             link(path + "/Entries"),
+		}
+
+		if logID == selLogID {
+			var info schemav1alpha1.GetSystemEventLogInfoResponse
+			if err := s.requestNATS(r.Context(), ipc.SubjectSELInfo, &schemav1alpha1.GetSystemEventLogInfoRequest{}, &info); err != nil {
+				s.writeRequestError(w, r, err, logServiceResourceName, logID)
+				return
+			}
+
+			svc.Name = "System Event Log Service"
+			svc.Description = "IPMI system event log recorded by selmgr."
+			svc.LogEntryType = "SEL"
+			svc.MaxNumberOfRecords = int(info.GetMaxEntries())
+			svc.OverWritePolicy = "Unknown"
+			if info.GetOverflow() {
+				svc.Status.Health = "Warning"
+			}
+			svc.Actions.ClearLog = &actionTarget{Target: path + "/Actions/" + actionClearLog}
+		}
+
+		s.writeResource(w, r, svc)
+	}
+}
+
+func (s *redfishServer) handleLogEntries(resolve logScopeFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		scope, logID, ok := s.logServiceOf(w, r, resolve)
+		if !ok {
+			return
+		}
+
+		path := scope.path + "/LogServices/" + logID + "/Entries"
+		var members []*logEntry
+		if logID == selLogID {
+			var resp schemav1alpha1.ListSystemEventLogEntriesResponse
+			if err := s.requestNATS(r.Context(), ipc.SubjectSELList, &schemav1alpha1.ListSystemEventLogEntriesRequest{}, &resp); err != nil {
+				s.writeRequestError(w, r, err, "LogEntryCollection", logID)
+				return
+			}
+			members = make([]*logEntry, 0, len(resp.GetEntries()))
+			for _, e := range resp.GetEntries() {
+				members = append(members, newSELEntry(path, e))
+			}
+		} else {
+			records := s.eventLog.entries(scope.match)
+			members = make([]*logEntry, 0, len(records))
+			for i := range records {
+				members = append(members, newEventLogEntry(path, &records[i]))
+			}
+		}
+
+		s.writeResource(w, r, &logEntryCollection{
+			odataHeader:  odataHeader{ODataID: path, ODataType: odataTypeLogEntryCollection},
+			Name:         "Log Entry Collection",
+			Members:      members,
+			MembersCount: len(members),
+		})
+	}
+}
+
+func (s *redfishServer) handleLogEntry(resolve logScopeFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		scope, logID, ok := s.logServiceOf(w, r, resolve)
+		if !ok {
+			return
+		}
+
+		path := scope.path + "/LogServices/" + logID + "/Entries"
+		entryID := r.PathValue("entryId")
+		if logID == selLogID {
+			recordID, err := strconv.ParseUint(entryID, 10, 32)
+			if err != nil {
+				s.writeRequestError(w, r, ErrNotFound, logEntryResourceName, entryID)
+				return
+			}
+
+			var resp schemav1alpha1.GetSystemEventLogEntryResponse
+			if err := s.requestNATS(r.Context(), ipc.SubjectSELEntry, &schemav1alpha1.GetSystemEventLogEntryRequest{
+				RecordId: uint32(recordID),
+			}, &resp); err != nil {
+				s.writeRequestError(w, r, err, logEntryResourceName, entryID)
+				return
+			}
+			s.writeResource(w, r, newSELEntry(path, resp.GetEntry()))
+			return
+		}
+
+		rec, found := s.eventLog.entry(entryID)
+		if !found || (scope.match != nil && !scope.match(rec.event.origin())) {
+			s.writeRequestError(w, r, ErrNotFound, logEntryResourceName, entryID)
+			return
+		}
+		s.writeResource(w, r, newEventLogEntry(path, &rec))
+	}
+}
+
+func (s *redfishServer) handleClearSEL(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.systemLogScope(w, r); !ok {
+		return
+	}
+
+	var resp schemav1alpha1.ClearSystemEventLogResponse
+	if err := s.requestNATS(r.Context(), ipc.SubjectSELClear, &schemav1alpha1.ClearSystemEventLogRequest{}, &resp); err != nil {
+		s.writeRequestError(w, r, err, logServiceResourceName, selLogID)
+		return
+	}
+
+	s.logger.InfoContext(r.Context(), "Redfish system event log cleared",
+		"system", r.PathValue("id"),
+		"cleared_entries", resp.GetClearedEntries())
+
+	w.WriteHeader(http.StatusNoContent)
+}
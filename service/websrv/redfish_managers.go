@@ -0,0 +1,222 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package websrv
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+
+	schemav1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+	"github.com/u-bmc/u-bmc/pkg/ipc"
+)
+
+// Manager resource types.
+const (
+	odataTypeManagerCollection = "#ManagerCollection.ManagerCollection"
+	odataTypeManager           = "#Manager.v1_19_0.Manager"
+	managersPath               = redfishRoot + "/Managers"
+	actionManagerReset         = "Manager.Reset"
+)
+
+// manager is the Redfish Manager resource.
+type manager struct {
+	odataHeader
+	ID              string         `json:"Id"`
+	Name            string         `json:"Name"`
+	Description     string         `json:"Description,omitempty"`
+	ManagerType     string         `json:"ManagerType"`
+	Manufacturer    string         `json:"Manufacturer,omitempty"`
+	Model           string         `json:"Model,omitempty"`
+	SerialNumber    string         `json:"SerialNumber,omitempty"`
+	PartNumber      string         `json:"PartNumber,omitempty"`
+	UUID            string         `json:"UUID,omitempty"`
+	FirmwareVersion string         `json:"FirmwareVersion,omitempty"`
+	PowerState      string         `json:"PowerState"`
+	Status          resourceStatus `json:"Status"`
+	VirtualMedia    *odataLink     `json:"VirtualMedia,omitempty"`
+	LogServices     *odataLink     `json:"LogServices,omitempty"`
+	Links           struct {
+		ManagerForServers []odataLink `json:"ManagerForServers"`
+		ManagerForChassis []odataLink `json:"ManagerForChassis"`
+	} `json:"Links"`
+	Actions struct {
+		Reset resetAction `json:"#Manager.Reset"`
+	} `json:"Actions"`
+}
+
+// managerResetTypes returns the Manager reset types in the order they are
+// advertised.
+func managerResetTypes() []string {
+	return []string{"GracefulRestart", "ForceRestart"}
+}
+
+// managerResetAction maps a Manager reset type to a management controller
+// action.
+func managerResetAction(resetType string) schemav1alpha1.ManagementControllerAction {
+	switch resetType {
+	case "GracefulRestart":
+		return schemav1alpha1.ManagementControllerAction_MANAGEMENT_CONTROLLER_ACTION_REBOOT
+	case "ForceRestart":
+		return schemav1alpha1.ManagementControllerAction_MANAGEMENT_CONTROLLER_ACTION_HARD_RESET
+	default:
+		return schemav1alpha1.ManagementControllerAction_MANAGEMENT_CONTROLLER_ACTION_UNSPECIFIED
+	}
+}
+
+// managerStatus maps a management controller status to the Redfish status.
+func managerStatus(status schemav1alpha1.ManagementControllerStatus) resourceStatus {
+	switch status {
+	case schemav1alpha1.ManagementControllerStatus_MANAGEMENT_CONTROLLER_STATUS_READY:
+		return resourceStatus{State: "Enabled", Health: "OK"}
+	case schemav1alpha1.ManagementControllerStatus_MANAGEMENT_CONTROLLER_STATUS_NOT_READY:
+		return resourceStatus{State: "Starting", Health: "OK"}
+	case schemav1alpha1.ManagementControllerStatus_MANAGEMENT_CONTROLLER_STATUS_DISABLED:
+		return resourceStatus{State: "Disabled", Health: "OK"}
+	case schemav1alpha1.ManagementControllerStatus_MANAGEMENT_CONTROLLER_STATUS_QUIESCED:
+		return resourceStatus{State: "Quiesced", Health: "OK"}
+	case schemav1alpha1.ManagementControllerStatus_MANAGEMENT_CONTROLLER_STATUS_DIAGNOSTIC:
+		return resourceStatus{State: "InTest", Health: "OK"}
+	case schemav1alpha1.ManagementControllerStatus_MANAGEMENT_CONTROLLER_STATUS_ERROR:
+		return resourceStatus{State: "Enabled", Health: "Critical"}
+	default:
+		return resourceStatus{State: "Absent"}
+	}
+}
+
+// primaryManager reports whether the manager with the given ID is the first
+// one listed, which is the BMC running this service, and writes an error
+// response if the manager does not exist.
+func (s *redfishServer) primaryManager(w http.ResponseWriter, r *http.Request, id string) (bool, bool) {
+	var resp schemav1alpha1.ListManagementControllersResponse
+	err := s.requestNATS(r.Context(), ipc.SubjectBMCList, &schemav1alpha1.ListManagementControllersRequest{}, &resp)
+	if err == nil && !slices.ContainsFunc(resp.GetControllers(), func(m *schemav1alpha1.ManagementController) bool {
+		return m.GetName() == id
+	}) {
+		err = ErrNotFound
+	}
+	if err != nil {
+		s.writeRequestError(w, r, err, "Manager", id)
+		return false, false
+	}
+
+	return resp.GetControllers()[0].GetName() == id, true
+}
+
+func (s *redfishServer) registerManagers() {
+	s.handle(http.MethodGet, managersPath, s.handleManagers)
+	s.handle(http.MethodGet, managersPath+"/{id}", s.handleManager)
+	s.handlePrivileged(http.MethodPost, managersPath+"/{id}/Actions/"+actionManagerReset, privilegeConfigureManager, s.handleManagerReset)
+}
+
+func (s *redfishServer) handleManagers(w http.ResponseWriter, r *http.Request) {
+	var resp schemav1alpha1.ListManagementControllersResponse
+	if err := s.requestNATS(r.Context(), ipc.SubjectBMCList, &schemav1alpha1.ListManagementControllersRequest{}, &resp); err != nil {
+		s.writeRequestError(w, r, err, "ManagerCollection", "Managers")
+		return
+	}
+
+	ids := make([]string, 0, len(resp.GetControllers()))
+	for _, m := range resp.GetControllers() {
+		ids = append(ids, m.GetName())
+	}
+
+	s.writeResource(w, r, newCollection(managersPath, odataTypeManagerCollection, "Manager Collection", ids))
+}
+
+func (s *redfishServer) handleManager(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	var resp schemav1alpha1.GetManagementControllerResponse
+	err := s.requestNATS(r.Context(), ipc.SubjectBMCState, &schemav1alpha1.GetManagementControllerRequest{
+		Identifier: &schemav1alpha1.GetManagementControllerRequest_Name{Name: id},
+	}, &resp)
+	if err == nil && len(resp.GetControllers()) == 0 {
+		err = ErrNotFound
+	}
+	if err != nil {
+		s.writeRequestError(w, r, err, "Manager", id)
+		return
+	}
+	m := resp.GetControllers()[0]
+
+	primary, ok := s.primaryManager(w, r, id)
+	if !ok {
+		return
+	}
+
+	path := managersPath + "/" + url.PathEscape(id)
+	mgr := &manager{
+		odataHeader:     odataHeader{ODataID: path, ODataType: odataTypeManager},
+		ID:              id,
+		Name:            id,
+		Description:     m.GetDescription(),
+		ManagerType:     "BMC",
+		FirmwareVersion: m.GetFirmware().GetVersion(),
+		PowerState:      "On",
+		Status:          managerStatus(m.GetStatus()),
+	}
+	if asset := m.GetAsset(); asset != nil {
+		mgr.Manufacturer = asset.GetManufacturer()
+		mgr.Model = asset.GetProductName()
+		mgr.SerialNumber = asset.GetSerialNumber()
+		mgr.PartNumber = asset.GetPartNumber()
+		mgr.UUID = asset.GetUuid()
+	}
+	if primary {
+		media, logs := link(path+"/VirtualMedia"), link(path+"/LogServices")
+		mgr.VirtualMedia = &media
+		mgr.LogServices = &logs
+	}
+	mgr.Actions.Reset = resetAction{
+		Target:     path + "/Actions/" + actionManagerReset,
+		ResetTypes: managerResetTypes(),
+	}
+
+	var hosts schemav1alpha1.ListHostsResponse
+	if err := s.requestNATS(r.Context(), ipc.SubjectHostList, &schemav1alpha1.ListHostsRequest{}, &hosts); err != nil {
+		s.writeRequestError(w, r, err, "Manager", id)
+		return
+	}
+	mgr.Links.ManagerForServers = make([]odataLink, 0, len(hosts.GetHosts()))
+	for _, host := range hosts.GetHosts() {
+		mgr.Links.ManagerForServers = append(mgr.Links.ManagerForServers, link(systemsPath+"/"+url.PathEscape(host.GetName())))
+	}
+
+	chassis, _, err := s.topology(r)
+	if err != nil {
+		s.writeRequestError(w, r, err, "Manager", id)
+		return
+	}
+	mgr.Links.ManagerForChassis = make([]odataLink, 0, len(chassis))
+	for _, c := range chassis {
+		mgr.Links.ManagerForChassis = append(mgr.Links.ManagerForChassis, link(chassisPath+"/"+url.PathEscape(c.GetName())))
+	}
+
+	s.writeResource(w, r, mgr)
+}
+
+func (s *redfishServer) handleManagerReset(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	resetType, ok := s.readResetType(w, r, actionManagerReset, managerResetTypes())
+	if !ok {
+		return
+	}
+
+	var resp schemav1alpha1.ChangeManagementControllerStateResponse
+	if err := s.requestNATS(r.Context(), ipc.SubjectBMCControl, &schemav1alpha1.ChangeManagementControllerStateRequest{
+		ControllerName: id,
+		Action:         managerResetAction(resetType),
+	}, &resp); err != nil {
+		s.writeRequestError(w, r, err, "Manager", id)
+		return
+	}
+
+	s.logger.InfoContext(r.Context(), "Redfish manager reset",
+		"manager", id,
+		"reset_type", resetType,
+		"status", resp.GetCurrentStatus().String())
+
+	w.WriteHeader(http.StatusNoContent)
+}
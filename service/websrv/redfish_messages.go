@@ -0,0 +1,339 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package websrv
+
+// Standard message registries implemented by the service.
+const (
+	resourceEventRegistry = "ResourceEvent.1.3.0"
+	dmtfOwningEntity      = "DMTF"
+)
+
+// Messages of the ResourceEvent registry.
+const (
+	msgResourceErrorThresholdExceeded   = "ResourceErrorThresholdExceeded"
+	msgResourceWarningThresholdExceeded = "ResourceWarningThresholdExceeded"
+	msgResourceStatusChangedOK          = "ResourceStatusChangedOK"
+	msgResourceStatusChangedWarning     = "ResourceStatusChangedWarning"
+	msgResourceStatusChangedCritical    = "ResourceStatusChangedCritical"
+)
+
+// baseRegistryMessages returns the messages of the Base registry the service
+// reports errors with.
+func baseRegistryMessages() map[string]registryMessage {
+	return map[string]registryMessage{
+		"Success": {
+			Description: "Indicates that all conditions of a successful operation were met.",
+			Message:     "The request completed successfully.",
+			Severity:    "OK",
+			Resolution:  "None.",
+		},
+		"GeneralError": {
+			Description: "Indicates that a general error has occurred.",
+			Message:     "A general error has occurred. See Resolution for information on how to resolve the error.",
+			Severity:    "Critical",
+			Resolution:  "None.",
+		},
+		"InternalError": {
+			Description: "Indicates that the request failed for an unknown internal error but that the service is still operational.",
+			Message:     "The request failed due to an internal service error. The service is still operational.",
+			Severity:    "Critical",
+			Resolution:  "Resubmit the request. If the problem persists, consider resetting the service.",
+		},
+		"ServiceTemporarilyUnavailable": {
+			Description:  "Indicates that the service is temporarily unavailable.",
+			Message:      "The service is temporarily unavailable. Retry in %1 seconds.",
+			Severity:     "Critical",
+			NumberOfArgs: 1,
+			ParamTypes:   []string{"string"},
+			Resolution:   "Wait for the indicated retry duration and retry the operation.",
+		},
+		"MalformedJSON": {
+			Description: "Indicates that the request body was malformed JSON.",
+			Message:     "The request body submitted was malformed JSON and could not be parsed by the receiving service.",
+			Severity:    "Critical",
+			Resolution:  "Ensure that the request body is valid JSON and resubmit the request.",
+		},
+		"OperationNotAllowed": {
+			Description: "Indicates that the HTTP method in the request is not allowed on this resource.",
+			Message:     "The HTTP method is not allowed on this resource.",
+			Severity:    "Critical",
+			Resolution:  "None.",
+		},
+		"QueryParameterValueFormatError": {
+			Description:  "Indicates that a query parameter was given the correct value type but the format is not supported.",
+			Message:      "The value '%1' for the parameter %2 is of a different format than the parameter can accept.",
+			Severity:     "Warning",
+			NumberOfArgs: 2,
+			ParamTypes:   []string{"string", "string"},
+			Resolution:   "Correct the value for the query parameter in the request and resubmit the request.",
+		},
+		"ResourceMissingAtURI": {
+			Description:  "Indicates that the operation expected an image or other resource at the provided URI but none was found.",
+			Message:      "The resource at the URI %1 was not found.",
+			Severity:     "Critical",
+			NumberOfArgs: 1,
+			ParamTypes:   []string{"string"},
+			Resolution:   "Place a valid resource at the URI or correct the URI and resubmit the request.",
+		},
+		"ResourceNotFound": {
+			Description:  "Indicates that the operation expected a resource identifier that corresponds to an existing resource but one was not found.",
+			Message:      "The requested resource of type %1 named '%2' was not found.",
+			Severity:     "Critical",
+			NumberOfArgs: 2,
+			ParamTypes:   []string{"string", "string"},
+			Resolution:   "Provide a valid resource identifier and resubmit the request.",
+		},
+		"ResourceAlreadyExists": {
+			Description:  "Indicates that a resource change or creation was attempted but that the operation cannot proceed because the resource already exists.",
+			Message:      "The requested resource of type %1 with the property %2 with the value '%3' already exists.",
+			Severity:     "Critical",
+			NumberOfArgs: 3,
+			ParamTypes:   []string{"string", "string", "string"},
+			Resolution:   "Do not repeat the create operation as the resource has already been created.",
+		},
+		"ResourceInUse": {
+			Description: "Indicates that a change was requested to a resource but the change was rejected because the resource is in use or in transition.",
+			Message:     "The change to the requested resource failed because the resource is in use or in transition.",
+			Severity:    "Warning",
+			Resolution:  "Remove the condition and resubmit the request if the operation failed.",
+		},
+		"ResourceAtURIUnauthorized": {
+			Description:  "Indicates that the attempt to access the resource, file, or image at the URI was unauthorized.",
+			Message:      "While accessing the resource at %1, the service received an authorization error %2.",
+			Severity:     "Critical",
+			NumberOfArgs: 2,
+			ParamTypes:   []string{"string", "string"},
+			Resolution:   "Ensure that the appropriate access is provided for the service in order for it to access the URI.",
+		},
+		"CreateLimitReachedForResource": {
+			Description: "Indicates that no more resources can be created on the resource as it has reached its create limit.",
+			Message:     "The create operation failed because the resource has reached the limit of possible resources.",
+			Severity:    "Critical",
+			Resolution:  "Either delete resources and resubmit the request if the operation failed or do not resubmit the request.",
+		},
+		"PropertyMissing": {
+			Description:  "Indicates that a required property was not supplied as part of the request.",
+			Message:      "The property %1 is a required property and must be included in the request.",
+			Severity:     "Warning",
+			NumberOfArgs: 1,
+			ParamTypes:   []string{"string"},
+			Resolution:   "Ensure that the property is in the request body and has a valid value and resubmit the request if the operation failed.",
+		},
+		"PropertyNotWritable": {
+			Description:  "Indicates that a property was given a value in the request body, but the property is a read-only property.",
+			Message:      "The property %1 is a read only property and cannot be assigned a value.",
+			Severity:     "Warning",
+			NumberOfArgs: 1,
+			ParamTypes:   []string{"string"},
+			Resolution:   "Remove the property from the request body and resubmit the request if the operation failed.",
+		},
+		"PropertyUnknown": {
+			Description:  "Indicates that an unknown property was included in the request body.",
+			Message:      "The property %1 is not in the list of valid properties for the resource.",
+			Severity:     "Warning",
+			NumberOfArgs: 1,
+			ParamTypes:   []string{"string"},
+			Resolution:   "Remove the unknown property from the request body and resubmit the request if the operation failed.",
+		},
+		"PropertyValueConflict": {
+			Description:  "Indicates that the requested write of a property value could not be completed, because of a conflict with another property value.",
+			Message:      "The property '%1' could not be written because its value would conflict with the value of the '%2' property.",
+			Severity:     "Warning",
+			NumberOfArgs: 2,
+			ParamTypes:   []string{"string", "string"},
+			Resolution:   "No resolution is required.",
+		},
+		"PropertyValueFormatError": {
+			Description:  "Indicates that a property was given the correct value type but the value of that property was not supported.",
+			Message:      "The value '%1' for the property %2 is of a different format than the property can accept.",
+			Severity:     "Warning",
+			NumberOfArgs: 2,
+			ParamTypes:   []string{"string", "string"},
+			Resolution:   "Correct the value for the property in the request body and resubmit the request if the operation failed.",
+		},
+		"PropertyValueIncorrect": {
+			Description:  "Indicates that the requested write of a property value could not be completed, because of an incorrect value of the property such as when it does not meet the constraints of the implementation.",
+			Message:      "The property '%1' with the requested value of '%2' could not be written because the value does not meet the constraints of the implementation.",
+			Severity:     "Warning",
+			NumberOfArgs: 2,
+			ParamTypes:   []string{"string", "string"},
+			Resolution:   "No resolution is required.",
+		},
+		"PropertyValueNotInList": {
+			Description:  "Indicates that a property was given the correct value type but the value of that property was not supported.",
+			Message:      "The value '%1' for the property %2 is not in the list of acceptable values.",
+			Severity:     "Warning",
+			NumberOfArgs: 2,
+			ParamTypes:   []string{"string", "string"},
+			Resolution:   "Choose a value from the enumeration list that the implementation can support and resubmit the request if the operation failed.",
+		},
+		"PropertyValueOutOfRange": {
+			Description:  "Indicates that a property was given the correct value type but the value of that property is not supported.",
+			Message:      "The value '%1' for the property %2 is not in the supported range of acceptable values.",
+			Severity:     "Warning",
+			NumberOfArgs: 2,
+			ParamTypes:   []string{"string", "string"},
+			Resolution:   "Correct the value for the property in the request body and resubmit the request if the operation failed.",
+		},
+		"PropertyValueTypeError": {
+			Description:  "Indicates that a property was given the wrong value type, such as when a number is supplied for a property that requires a string.",
+			Message:      "The value '%1' for the property %2 is of a different type than the property can accept.",
+			Severity:     "Warning",
+			NumberOfArgs: 2,
+			ParamTypes:   []string{"string", "string"},
+			Resolution:   "Correct the value for the property in the request body and resubmit the request if the operation failed.",
+		},
+		"ArraySizeTooLong": {
+			Description:  "Indicates that a string value passed to the given resource exceeded its length limit.",
+			Message:      "The array provided for property %1 exceeds the size limit %2.",
+			Severity:     "Warning",
+			NumberOfArgs: 2,
+			ParamTypes:   []string{"string", "number"},
+			Resolution:   "Resubmit the request with an appropriate array size.",
+		},
+		"ActionParameterMissing": {
+			Description:  "Indicates that the action requested was missing an action parameter that is required to process the action.",
+			Message:      "The action %1 requires the parameter %2 to be present in the request body.",
+			Severity:     "Critical",
+			NumberOfArgs: 2,
+			ParamTypes:   []string{"string", "string"},
+			Resolution:   "Supply the action with the required parameter in the request body when the request is resubmitted.",
+		},
+		"ActionParameterNotSupported": {
+			Description:  "Indicates that the parameter supplied for the action is not supported on the resource.",
+			Message:      "The parameter %1 for the action %2 is not supported on the target resource.",
+			Severity:     "Warning",
+			NumberOfArgs: 2,
+			ParamTypes:   []string{"string", "string"},
+			Resolution:   "Remove the parameter supplied and resubmit the request if the operation failed.",
+		},
+		"ActionParameterValueFormatError": {
+			Description:  "Indicates that a parameter was given the correct value type but the value of that parameter was not supported.",
+			Message:      "The value '%1' for the parameter %2 in the action %3 is of a different format than the parameter can accept.",
+			Severity:     "Warning",
+			NumberOfArgs: 3,
+			ParamTypes:   []string{"string", "string", "string"},
+			Resolution:   "Correct the value for the parameter in the request body and resubmit the request if the operation failed.",
+		},
+		"ActionParameterValueNotInList": {
+			Description:  "Indicates that a parameter was given the correct value type but the value of that parameter was not supported.",
+			Message:      "The value '%1' for the parameter %2 in the action %3 is not in the list of acceptable values.",
+			Severity:     "Warning",
+			NumberOfArgs: 3,
+			ParamTypes:   []string{"string", "string", "string"},
+			Resolution:   "Choose a value from the enumeration list that the implementation can support and resubmit the request if the operation failed.",
+		},
+		"NoValidSession": {
+			Description: "Indicates that the operation failed because a valid session is required in order to access any resources.",
+			Message:     "There is no valid session established with the implementation.",
+			Severity:    "Critical",
+			Resolution:  "Establish a session before attempting any operations.",
+		},
+		"InsufficientPrivilege": {
+			Description: "Indicates that the credentials associated with the established session do not have sufficient privileges for the requested operation.",
+			Message:     "There are insufficient privileges for the account or credentials associated with the current session to perform the requested operation.",
+			Severity:    "Critical",
+			Resolution:  "Either abandon the operation or change the associated access rights and resubmit the request if the operation failed.",
+		},
+		"SessionLimitExceeded": {
+			Description: "Indicates that a session establishment has been requested but the operation failed due to the number of simultaneous sessions exceeding the limit of the implementation.",
+			Message:     "The session establishment failed due to the number of simultaneous sessions exceeding the limit of the implementation.",
+			Severity:    "Critical",
+			Resolution:  "Reduce the number of other sessions before trying to establish the session or increase the limit of simultaneous sessions, if supported.",
+		},
+		"PasswordChangeRequired": {
+			Description:  "Indicates that the password for the account provided must be changed before accessing the service.",
+			Message:      "The password provided for this account must be changed before access is granted. PATCH the Password property for this account located at the target URI '%1' to complete this process.",
+			Severity:     "Critical",
+			NumberOfArgs: 1,
+			ParamTypes:   []string{"string"},
+			Resolution:   "Change the password for this account by using a PATCH to the Password property at the URI provided.",
+		},
+	}
+}
+
+// resourceEventRegistryMessages returns the messages of the ResourceEvent
+// registry that system event log entries refer to.
+func resourceEventRegistryMessages() map[string]registryMessage {
+	return map[string]registryMessage{
+		msgResourceErrorThresholdExceeded: {
+			Description:  "Indicates that a specified resource property has exceeded its error threshold.",
+			Message:      "The resource property %1 has exceeded error threshold of value %2.",
+			Severity:     "Critical",
+			NumberOfArgs: 2,
+			ParamTypes:   []string{"string", "number"},
+			Resolution:   "None.",
+		},
+		msgResourceWarningThresholdExceeded: {
+			Description:  "Indicates that a specified resource property has exceeded its warning threshold.",
+			Message:      "The resource property %1 has exceeded its warning threshold of value %2.",
+			Severity:     "Warning",
+			NumberOfArgs: 2,
+			ParamTypes:   []string{"string", "number"},
+			Resolution:   "None.",
+		},
+		msgResourceStatusChangedOK: {
+			Description:  "Indicates that the health of a resource has changed to OK.",
+			Message:      "The health of resource '%1' has changed to %2.",
+			Severity:     "OK",
+			NumberOfArgs: 2,
+			ParamTypes:   []string{"string", "string"},
+			Resolution:   "None.",
+		},
+		msgResourceStatusChangedWarning: {
+			Description:  "Indicates that the health of a resource has changed to Warning.",
+			Message:      "The health of resource '%1' has changed to %2.",
+			Severity:     "Warning",
+			NumberOfArgs: 2,
+			ParamTypes:   []string{"string", "string"},
+			Resolution:   "None.",
+		},
+		msgResourceStatusChangedCritical: {
+			Description:  "Indicates that the health of a resource has changed to Critical.",
+			Message:      "The health of resource '%1' has changed to %2.",
+			Severity:     "Critical",
+			NumberOfArgs: 2,
+			ParamTypes:   []string{"string", "string"},
+			Resolution:   "None.",
+		},
+	}
+}
+
+// taskEventRegistryMessages returns the messages of the TaskEvent registry
+// that tasks refer to.
+func taskEventRegistryMessages() map[string]registryMessage {
+	return map[string]registryMessage{
+		"TaskStarted": {
+			Description:  "A task has started.",
+			Message:      "The task with Id '%1' has started.",
+			Severity:     "OK",
+			NumberOfArgs: 1,
+			ParamTypes:   []string{"string"},
+			Resolution:   "None.",
+		},
+		"TaskProgressChanged": {
+			Description:  "A task has changed progress.",
+			Message:      "The task with Id '%1' has changed to progress %2 percent complete.",
+			Severity:     "OK",
+			NumberOfArgs: 2,
+			ParamTypes:   []string{"string", "number"},
+			Resolution:   "None.",
+		},
+		"TaskCompletedOK": {
+			Description:  "A task has completed.",
+			Message:      "The task with Id '%1' has completed.",
+			Severity:     "OK",
+			NumberOfArgs: 1,
+			ParamTypes:   []string{"string"},
+			Resolution:   "None.",
+		},
+		"TaskAborted": {
+			Description:  "A task has been aborted.",
+			Message:      "The task with Id '%1' has been aborted.",
+			Severity:     "Critical",
+			NumberOfArgs: 1,
+			ParamTypes:   []string{"string"},
+			Resolution:   "None.",
+		},
+	}
+}
@@ -0,0 +1,200 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package websrv
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// OData metadata constants.
+const (
+	metadataPath         = redfishRoot + "/$metadata"
+	odataServicePath     = redfishRoot + "/odata"
+	contentTypeXML       = "application/xml; charset=utf-8"
+	edmxNamespace        = "http://docs.oasis-open.org/odata/ns/edmx"
+	edmNamespace         = "http://docs.oasis-open.org/odata/ns/edm"
+	dmtfSchemaURI        = "http://redfish.dmtf.org/schemas/v1/"
+	redfishExtensionsNS  = "RedfishExtensions.v1_0_0"
+	serviceContainerName = "Service"
+)
+
+// metadataTypes returns the @odata.type of every resource and payload the
+// service emits. The CSDL of their schemas is referenced by $metadata.
+func metadataTypes() []string {
+	return []string{
+		odataTypeServiceRoot,
+		odataTypeComputerSystemCollection, odataTypeComputerSystem,
+		odataTypeChassisCollection, odataTypeChassis,
+		odataTypeSensorCollection, odataTypeSensor,
+		odataTypeThermalSubsystem, odataTypeThermalMetrics, odataTypeFanCollection, odataTypeFan,
+		odataTypePowerSubsystem, odataTypePowerSupplyCollection, odataTypePowerSupply,
+		odataTypeEnvironmentMetrics, odataTypeThermal, odataTypePower,
+		odataTypeManagerCollection, odataTypeManager,
+		odataTypeVirtualMediaCollection, odataTypeVirtualMedia,
+		odataTypeLogServiceCollection, odataTypeLogService, odataTypeLogEntryCollection, odataTypeLogEntry,
+		odataTypeEventService, odataTypeEventDestinationCollection, odataTypeEventDestination, odataTypeEvent,
+		odataTypeMessageRegistryFileCollection, odataTypeMessageRegistryFile, odataTypeMessageRegistry,
+		odataTypeSessionService, odataTypeSessionCollection, odataTypeSession,
+		odataTypeAccountService, odataTypeManagerAccountCollection, odataTypeManagerAccount,
+		odataTypeRoleCollection, odataTypeRole,
+		odataTypeUpdateService, odataTypeSoftwareInventoryCollection, odataTypeSoftwareInventory,
+		odataTypeTaskService, odataTypeTaskCollection, odataTypeTask,
+		odataTypeTelemetryService, odataTypeMetricDefinitionCollection, odataTypeMetricDefinition,
+		odataTypeMetricReportDefinitionCollection, odataTypeMetricReportDefinition,
+		odataTypeMetricReportCollection, odataTypeMetricReport,
+		odataTypeAggregationService, odataTypeAggregationSourceCollection, odataTypeAggregationSource,
+		redfishMessageType,
+	}
+}
+
+// edmxDocument is the OData CSDL document served as $metadata.
+type edmxDocument struct {
+	XMLName      xml.Name         `xml:"edmx:Edmx"`
+	XMLNS        string           `xml:"xmlns:edmx,attr"`
+	Version      string           `xml:"Version,attr"`
+	References   []edmxReference  `xml:"edmx:Reference"`
+	DataServices edmxDataServices `xml:"edmx:DataServices"`
+}
+
+// edmxReference references the CSDL document of a schema.
+type edmxReference struct {
+	URI      string        `xml:"Uri,attr"`
+	Includes []edmxInclude `xml:"edmx:Include"`
+}
+
+// edmxInclude includes a namespace of a referenced CSDL document.
+type edmxInclude struct {
+	Namespace string `xml:"Namespace,attr"`
+	Alias     string `xml:"Alias,attr,omitempty"`
+}
+
+// edmxDataServices holds the schema defining the service container.
+type edmxDataServices struct {
+	Schema struct {
+		XMLNS           string `xml:"xmlns,attr"`
+		Namespace       string `xml:"Namespace,attr"`
+		EntityContainer struct {
+			Name    string `xml:"Name,attr"`
+			Extends string `xml:"Extends,attr"`
+		} `xml:"EntityContainer"`
+	} `xml:"Schema"`
+}
+
+// odataNamespaces splits an @odata.type such as #Chassis.v1_25_0.Chassis into
+// the unversioned and the versioned namespace. The versioned namespace is
+// empty for types such as collections that are not versioned.
+func odataNamespaces(odataType string) (string, string) {
+	namespace := strings.TrimPrefix(odataType, "#")
+	namespace = namespace[:strings.LastIndex(namespace, ".")]
+	unversioned, version, versioned := strings.Cut(namespace, ".")
+	if !versioned {
+		return unversioned, ""
+	}
+	return unversioned, unversioned + "." + version
+}
+
+// newMetadataDocument creates the CSDL document referencing the schemas of
+// the given types, grouped by the DMTF document defining them.
+func newMetadataDocument(types []string) *edmxDocument {
+	doc := &edmxDocument{
+		XMLNS:   edmxNamespace,
+		Version: odataVersion,
+		References: []edmxReference{
+			{
+				URI:      dmtfSchemaURI + "RedfishExtensions_v1.xml",
+				Includes: []edmxInclude{{Namespace: redfishExtensionsNS, Alias: "Redfish"}},
+			},
+			{
+				URI:      dmtfSchemaURI + "Resource_v1.xml",
+				Includes: []edmxInclude{{Namespace: "Resource"}, {Namespace: "Resource.v1_0_0"}},
+			},
+		},
+	}
+
+	var serviceRoot string
+	for _, odataType := range types {
+		unversioned, versioned := odataNamespaces(odataType)
+		uri := dmtfSchemaURI + unversioned + "_v1.xml"
+		i := slices.IndexFunc(doc.References, func(ref edmxReference) bool { return ref.URI == uri })
+		if i < 0 {
+			doc.References = append(doc.References, edmxReference{
+				URI:      uri,
+				Includes: []edmxInclude{{Namespace: unversioned}},
+			})
+			i = len(doc.References) - 1
+		}
+		if versioned != "" && !slices.Contains(doc.References[i].Includes, edmxInclude{Namespace: versioned}) {
+			doc.References[i].Includes = append(doc.References[i].Includes, edmxInclude{Namespace: versioned})
+		}
+		if odataType == odataTypeServiceRoot {
+			serviceRoot = versioned
+		}
+	}
+
+	doc.DataServices.Schema.XMLNS = edmNamespace
+	doc.DataServices.Schema.Namespace = serviceContainerName
+	doc.DataServices.Schema.EntityContainer.Name = serviceContainerName
+	doc.DataServices.Schema.EntityContainer.Extends = serviceRoot + ".ServiceContainer"
+
+	return doc
+}
+
+// odataService is a top-level resource listed by the OData service document.
+type odataService struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+	URL  string `json:"url"`
+}
+
+// odataServiceDocument is the OData service document.
+type odataServiceDocument struct {
+	ODataContext string         `json:"@odata.context"`
+	Value        []odataService `json:"value"`
+}
+
+func (s *redfishServer) registerMetadata() {
+	s.handlePrivileged(http.MethodGet, metadataPath, privilegeNone, s.handleMetadata)
+	s.handlePrivileged(http.MethodGet, odataServicePath, privilegeNone, s.handleODataService)
+}
+
+func (s *redfishServer) handleMetadata(w http.ResponseWriter, r *http.Request) {
+	body, err := xml.MarshalIndent(newMetadataDocument(metadataTypes()), "", "  ")
+	if err != nil {
+		s.writeInternalError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentTypeXML)
+	_, _ = w.Write([]byte(xml.Header))
+	_, _ = w.Write(body)
+}
+
+func (s *redfishServer) handleODataService(w http.ResponseWriter, _ *http.Request) {
+	doc := &odataServiceDocument{
+		ODataContext: metadataPath,
+		Value:        []odataService{{Name: serviceContainerName, Kind: "Singleton", URL: redfishRoot + "/"}},
+	}
+	for _, service := range []struct{ name, path string }{
+		{"Systems", systemsPath},
+		{"Chassis", chassisPath},
+		{"Managers", managersPath},
+		{"EventService", eventServicePath},
+		{"Registries", registriesPath},
+		{"SessionService", sessionServicePath},
+		{"AccountService", accountServicePath},
+		{"UpdateService", updateServicePath},
+		{"Tasks", taskServicePath},
+		{"TelemetryService", telemetryServicePath},
+		{"AggregationService", aggregationServicePath},
+		{"Sessions", sessionsPath},
+	} {
+		doc.Value = append(doc.Value, odataService{Name: service.name, Kind: "Singleton", URL: service.path})
+	}
+
+	w.Header().Set("Content-Type", contentTypeJSON)
+	_ = json.NewEncoder(w).Encode(doc)
+}
@@ -0,0 +1,519 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package websrv
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// Metric report generation parameters.
+const (
+	odataTypeMetricReport         = "#MetricReport.v1_5_1.MetricReport"
+	metricReportTypePeriodic      = "Periodic"
+	metricReportTypeOnChange      = "OnChange"
+	metricReportTypeOnRequest     = "OnRequest"
+	reportActionLog               = "LogToMetricReportsCollection"
+	reportActionEvent             = "RedfishEvent"
+	reportUpdatesOverwrite        = "Overwrite"
+	reportUpdatesAppendWraps      = "AppendWrapsWhenFull"
+	reportUpdatesAppendStops      = "AppendStopsWhenFull"
+	metricMinInterval             = 5 * time.Second
+	metricDefaultAppendLimit      = 256
+	maxMetricReportDefinitions    = 32
+	maxMetricReportDefinitionName = 64
+)
+
+// metricReportTypes returns the supported MetricReportDefinitionType values.
+func metricReportTypes() []string {
+	return []string{metricReportTypePeriodic, metricReportTypeOnChange, metricReportTypeOnRequest}
+}
+
+// reportActions returns the supported ReportActions values.
+func reportActions() []string {
+	return []string{reportActionLog, reportActionEvent}
+}
+
+// reportUpdates returns the supported ReportUpdates values.
+func reportUpdates() []string {
+	return []string{reportUpdatesOverwrite, reportUpdatesAppendWraps, reportUpdatesAppendStops}
+}
+
+// metricSample is a single metric value read from a backend service.
+type metricSample struct {
+	metricID  string
+	property  string
+	value     string
+	units     string
+	timestamp string
+}
+
+// metricValue is a metric value carried by a MetricReport.
+type metricValue struct {
+	MetricID       string `json:"MetricId"`
+	MetricValue    string `json:"MetricValue"`
+	Timestamp      string `json:"Timestamp"`
+	MetricProperty string `json:"MetricProperty"`
+}
+
+// metricReport is the Redfish MetricReport resource.
+type metricReport struct {
+	odataHeader
+	ID                     string        `json:"Id"`
+	Name                   string        `json:"Name"`
+	Context                string        `json:"Context,omitempty"`
+	Timestamp              string        `json:"Timestamp"`
+	MetricReportDefinition odataLink     `json:"MetricReportDefinition"`
+	MetricValues           []metricValue `json:"MetricValues"`
+}
+
+// metricSelection selects the metrics of a report, either all metrics of a
+// MetricDefinition, optionally narrowed to some of its properties, or
+// individual metric properties.
+type metricSelection struct {
+	MetricID         string   `json:"metric_id,omitempty"`
+	MetricProperties []string `json:"metric_properties,omitempty"`
+}
+
+// matches reports whether the selection includes sample.
+func (m *metricSelection) matches(sample *metricSample) bool {
+	if m.MetricID != "" && m.MetricID != sample.metricID {
+		return false
+	}
+	return len(m.MetricProperties) == 0 || slices.Contains(m.MetricProperties, sample.property)
+}
+
+// reportDefinition is a persisted metric report definition.
+type reportDefinition struct {
+	ID            string            `json:"id"`
+	Name          string            `json:"name"`
+	Type          string            `json:"type"`
+	Interval      time.Duration     `json:"interval,omitempty"`
+	Actions       []string          `json:"actions"`
+	Updates       string            `json:"updates"`
+	AppendLimit   int               `json:"append_limit"`
+	Metrics       []metricSelection `json:"metrics"`
+	Enabled       bool              `json:"enabled"`
+	LastTimestamp string            `json:"-"`
+}
+
+// clone returns a deep copy of the definition.
+func (d *reportDefinition) clone() reportDefinition {
+	def := *d
+	def.Actions = slices.Clone(d.Actions)
+	def.Metrics = make([]metricSelection, 0, len(d.Metrics))
+	for _, m := range d.Metrics {
+		def.Metrics = append(def.Metrics, metricSelection{MetricID: m.MetricID, MetricProperties: slices.Clone(m.MetricProperties)})
+	}
+	return def
+}
+
+// selects reports whether the definition includes sample. Definitions
+// without metrics include all metrics.
+func (d *reportDefinition) selects(sample *metricSample) bool {
+	return len(d.Metrics) == 0 || slices.ContainsFunc(d.Metrics, func(m metricSelection) bool { return m.matches(sample) })
+}
+
+// reportPath returns the path of the MetricReport generated by the definition.
+func (d *reportDefinition) reportPath() string {
+	return metricReportsPath + "/" + url.PathEscape(d.ID)
+}
+
+// reportGenerator runs a metric report definition.
+type reportGenerator struct {
+	def    reportDefinition
+	cancel context.CancelFunc
+	// last holds the values of the last sample of an OnChange definition.
+	last map[string]string
+}
+
+// metricReporter generates metric reports from sensor readings according to
+// the metric report definitions, which are persisted in a JetStream key-value
+// bucket, and delivers them through the event broker.
+type metricReporter struct {
+	nc     *nats.Conn
+	logger *slog.Logger
+	bucket string
+	events *eventBroker
+	sample func(context.Context) ([]metricSample, error)
+
+	// kv persists definitions. It is nil if JetStream is unavailable, in
+	// which case definitions only live in memory.
+	kv jetstream.KeyValue
+
+	mu         sync.Mutex
+	ctx        context.Context //nolint:containedctx // bounds the generators of definitions created by requests
+	generators map[string]*reportGenerator
+	reports    map[string]*metricReport
+	nextID     int
+}
+
+// newMetricReporter creates a metric reporter sampling metrics with sample.
+func newMetricReporter(nc *nats.Conn, logger *slog.Logger, cfg *config, events *eventBroker,
+	sample func(context.Context) ([]metricSample, error),
+) *metricReporter {
+	return &metricReporter{
+		nc:         nc,
+		logger:     logger,
+		bucket:     cfg.redfishTelemetryBucket,
+		events:     events,
+		sample:     sample,
+		ctx:        context.Background(),
+		generators: make(map[string]*reportGenerator),
+		reports:    make(map[string]*metricReport),
+		nextID:     1,
+	}
+}
+
+// start restores the persisted definitions and starts generating their
+// reports until ctx is canceled.
+func (m *metricReporter) start(ctx context.Context) {
+	m.mu.Lock()
+	m.ctx = ctx
+	m.mu.Unlock()
+
+	js, err := jetstream.New(m.nc)
+	if err == nil {
+		m.kv, err = js.CreateOrUpdateKeyValue(ctx, jetstream.KeyValueConfig{
+			Bucket:      m.bucket,
+			Description: "Redfish metric report definitions",
+		})
+	}
+	if err != nil {
+		m.logger.WarnContext(ctx, "Redfish metric report definitions will not be persisted", "bucket", m.bucket, "error", err)
+		m.kv = nil
+		return
+	}
+
+	keys, err := m.kv.ListKeys(ctx)
+	if err != nil {
+		m.logger.WarnContext(ctx, "Failed to list Redfish metric report definitions", "error", err)
+		return
+	}
+	for key := range keys.Keys() {
+		entry, err := m.kv.Get(ctx, key)
+		if err != nil {
+			m.logger.WarnContext(ctx, "Failed to load Redfish metric report definition", "id", key, "error", err)
+			continue
+		}
+		var def reportDefinition
+		if err := json.Unmarshal(entry.Value(), &def); err != nil || def.ID != key {
+			m.logger.WarnContext(ctx, "Ignoring malformed Redfish metric report definition", "id", key, "error", err)
+			continue
+		}
+		m.add(def)
+	}
+}
+
+// save persists a definition.
+func (m *metricReporter) save(ctx context.Context, def *reportDefinition) error {
+	if m.kv == nil {
+		return nil
+	}
+	data, err := json.Marshal(def)
+	if err != nil {
+		return err
+	}
+	if _, err := m.kv.Put(ctx, def.ID, data); err != nil {
+		return fmt.Errorf("%w: %w", ErrDefinitionStoreFailed, err)
+	}
+	return nil
+}
+
+// add registers a definition and starts its generator.
+func (m *metricReporter) add(def reportDefinition) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if id, err := strconv.Atoi(def.ID); err == nil && id >= m.nextID {
+		m.nextID = id + 1
+	}
+
+	g := &reportGenerator{def: def}
+	m.generators[def.ID] = g
+	m.run(g)
+}
+
+// run starts the generation of scheduled reports for g. The caller must hold
+// the mutex.
+func (m *metricReporter) run(g *reportGenerator) {
+	if g.cancel != nil {
+		g.cancel()
+		g.cancel = nil
+	}
+	g.last = nil
+	if !g.def.Enabled || g.def.Type == metricReportTypeOnRequest {
+		return
+	}
+
+	interval := g.def.Interval
+	if g.def.Type == metricReportTypeOnChange || interval < metricMinInterval {
+		interval = metricMinInterval
+	}
+
+	ctx, cancel := context.WithCancel(m.ctx)
+	g.cancel = cancel
+	go m.schedule(ctx, g.def.ID, interval)
+}
+
+// schedule generates the report of a definition every interval until ctx is
+// canceled.
+func (m *metricReporter) schedule(ctx context.Context, id string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := m.generate(ctx, id, false); err != nil && ctx.Err() == nil {
+				m.logger.WarnContext(ctx, "Failed to generate Redfish metric report", "definition", id, "error", err)
+			}
+		}
+	}
+}
+
+// create assigns an ID to def unless it has one, persists it and starts
+// generating its reports.
+func (m *metricReporter) create(ctx context.Context, def reportDefinition) (reportDefinition, error) {
+	m.mu.Lock()
+	if len(m.generators) >= maxMetricReportDefinitions {
+		m.mu.Unlock()
+		return def, ErrTooManyDefinitions
+	}
+	if def.ID == "" {
+		for def.ID = strconv.Itoa(m.nextID); m.generators[def.ID] != nil; def.ID = strconv.Itoa(m.nextID) {
+			m.nextID++
+		}
+		m.nextID++
+	} else if m.generators[def.ID] != nil {
+		m.mu.Unlock()
+		return def, ErrDefinitionExists
+	}
+	if def.Name == "" {
+		def.Name = "Metric Report Definition " + def.ID
+	}
+	// Reserve the ID until the definition is stored.
+	m.generators[def.ID] = &reportGenerator{def: def}
+	m.mu.Unlock()
+
+	if err := m.save(ctx, &def); err != nil {
+		m.mu.Lock()
+		delete(m.generators, def.ID)
+		m.mu.Unlock()
+		return def, err
+	}
+	m.add(def)
+	return def, nil
+}
+
+// definition returns a copy of a definition.
+func (m *metricReporter) definition(id string) (reportDefinition, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	g, ok := m.generators[id]
+	if !ok {
+		return reportDefinition{}, false
+	}
+	return g.def.clone(), true
+}
+
+// definitionIDs returns the IDs of all definitions in ascending order.
+func (m *metricReporter) definitionIDs() []string {
+	m.mu.Lock()
+	ids := slices.Collect(maps.Keys(m.generators))
+	m.mu.Unlock()
+
+	slices.Sort(ids)
+	return ids
+}
+
+// update applies fn to a definition, persists the result and restarts its
+// generator. Reports collected under the previous definition are discarded.
+func (m *metricReporter) update(ctx context.Context, id string, fn func(*reportDefinition)) (reportDefinition, error) {
+	m.mu.Lock()
+	g, ok := m.generators[id]
+	if !ok {
+		m.mu.Unlock()
+		return reportDefinition{}, ErrNotFound
+	}
+	def := g.def.clone()
+	fn(&def)
+	g.def = def
+	delete(m.reports, id)
+	m.run(g)
+	m.mu.Unlock()
+
+	return def, m.save(ctx, &def)
+}
+
+// remove stops generating the reports of a definition and deletes it along
+// with its report.
+func (m *metricReporter) remove(ctx context.Context, id string) error {
+	m.mu.Lock()
+	g, ok := m.generators[id]
+	if ok {
+		if g.cancel != nil {
+			g.cancel()
+		}
+		delete(m.generators, id)
+		delete(m.reports, id)
+	}
+	m.mu.Unlock()
+
+	if !ok {
+		return ErrNotFound
+	}
+	if m.kv != nil {
+		if err := m.kv.Delete(ctx, id); err != nil {
+			return fmt.Errorf("%w: %w", ErrDefinitionStoreFailed, err)
+		}
+	}
+	return nil
+}
+
+// report returns a copy of the logged report of a definition.
+func (m *metricReporter) report(id string) (*metricReport, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	rep, ok := m.reports[id]
+	if !ok {
+		return nil, false
+	}
+	res := *rep
+	res.MetricValues = slices.Clone(rep.MetricValues)
+	return &res, true
+}
+
+// reportIDs returns the IDs of all logged reports and of the reports of
+// enabled OnRequest definitions in ascending order.
+func (m *metricReporter) reportIDs() []string {
+	m.mu.Lock()
+	ids := slices.Collect(maps.Keys(m.reports))
+	for id, g := range m.generators {
+		if g.def.Enabled && g.def.Type == metricReportTypeOnRequest && m.reports[id] == nil {
+			ids = append(ids, id)
+		}
+	}
+	m.mu.Unlock()
+
+	slices.Sort(ids)
+	return ids
+}
+
+// generate samples the metrics of a definition and produces its report,
+// which is logged and sent as event according to the report actions. An
+// OnChange definition only reports the values that changed since the last
+// sample and produces no report if none did, unless the report is requested.
+func (m *metricReporter) generate(ctx context.Context, id string, requested bool) (*metricReport, error) {
+	samples, err := m.sample(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	m.mu.Lock()
+	g, ok := m.generators[id]
+	if !ok {
+		m.mu.Unlock()
+		return nil, ErrNotFound
+	}
+	def := &g.def
+
+	values := make([]metricValue, 0, len(samples))
+	current := make(map[string]string, len(samples))
+	for i := range samples {
+		sample := &samples[i]
+		if !def.selects(sample) {
+			continue
+		}
+		current[sample.property] = sample.value
+		if !requested && def.Type == metricReportTypeOnChange && g.last != nil && g.last[sample.property] == sample.value {
+			continue
+		}
+		values = append(values, metricValue{
+			MetricID:       sample.metricID,
+			MetricValue:    sample.value,
+			Timestamp:      sample.timestamp,
+			MetricProperty: sample.property,
+		})
+	}
+	if def.Type == metricReportTypeOnChange {
+		first := g.last == nil
+		g.last = current
+		if !requested && (first || len(values) == 0) {
+			m.mu.Unlock()
+			return nil, nil
+		}
+	}
+
+	rep := &metricReport{
+		odataHeader:            odataHeader{ODataID: def.reportPath(), ODataType: odataTypeMetricReport},
+		ID:                     def.ID,
+		Name:                   def.Name,
+		Timestamp:              now,
+		MetricReportDefinition: link(metricReportDefinitionsPath + "/" + url.PathEscape(def.ID)),
+		MetricValues:           values,
+	}
+
+	if slices.Contains(def.Actions, reportActionLog) {
+		logged := rep
+		if prev, ok := m.reports[id]; ok && def.Updates != reportUpdatesOverwrite && def.Updates != "" {
+			logged = &metricReport{
+				odataHeader:            rep.odataHeader,
+				ID:                     rep.ID,
+				Name:                   rep.Name,
+				Timestamp:              now,
+				MetricReportDefinition: rep.MetricReportDefinition,
+				MetricValues:           appendMetricValues(prev.MetricValues, values, def.Updates, def.AppendLimit),
+			}
+		}
+		m.reports[id] = logged
+		if requested {
+			rep = logged
+		}
+	}
+	sendEvent := !requested && slices.Contains(def.Actions, reportActionEvent)
+	m.mu.Unlock()
+
+	res := *rep
+	res.MetricValues = slices.Clone(rep.MetricValues)
+	if sendEvent {
+		m.events.publish(ctx, eventRecord{EventTimestamp: now, report: &res})
+	}
+	return &res, nil
+}
+
+// appendMetricValues appends values to a logged report according to the
+// ReportUpdates of its definition, keeping at most limit values.
+func appendMetricValues(prev, values []metricValue, updates string, limit int) []metricValue {
+	if limit <= 0 {
+		limit = metricDefaultAppendLimit
+	}
+	res := make([]metricValue, 0, min(len(prev)+len(values), limit))
+	res = append(res, prev...)
+	switch updates {
+	case reportUpdatesAppendStops:
+		res = append(res, values[:min(len(values), max(limit-len(res), 0))]...)
+	default:
+		res = append(res, values...)
+		if len(res) > limit {
+			res = res[len(res)-limit:]
+		}
+	}
+	return res
+}
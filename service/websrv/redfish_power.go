@@ -0,0 +1,654 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package websrv
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	schemav1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+	"github.com/u-bmc/u-bmc/pkg/ipc"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Power resource types.
+const (
+	odataTypePowerSubsystem        = "#PowerSubsystem.v1_1_2.PowerSubsystem"
+	odataTypePowerSupplyCollection = "#PowerSupplyCollection.PowerSupplyCollection"
+	odataTypePowerSupply           = "#PowerSupply.v1_6_0.PowerSupply"
+	odataTypeEnvironmentMetrics    = "#EnvironmentMetrics.v1_3_2.EnvironmentMetrics"
+	odataTypePower                 = "#Power.v1_7_3.Power"
+	powerSupplyResourceName        = "PowerSupply"
+	propertyPowerLimitWatts        = "PowerLimitWatts"
+	propertyPowerLimitSetPoint     = "PowerLimitWatts/SetPoint"
+	propertyPowerLimitControlMode  = "PowerLimitWatts/ControlMode"
+	propertyPowerControl           = "PowerControl"
+	propertyLimitInWatts           = "PowerControl/0/PowerLimit/LimitInWatts"
+	propertyLimitException         = "PowerControl/0/PowerLimit/LimitException"
+	propertyCorrectionInMs         = "PowerControl/0/PowerLimit/CorrectionInMs"
+)
+
+// powerSubsystem is the Redfish PowerSubsystem resource of a chassis.
+type powerSubsystem struct {
+	odataHeader
+	ID            string         `json:"Id"`
+	Name          string         `json:"Name"`
+	CapacityWatts *float64       `json:"CapacityWatts,omitempty"`
+	PowerSupplies odataLink      `json:"PowerSupplies"`
+	Status        resourceStatus `json:"Status"`
+}
+
+// powerSupplyResource is the Redfish PowerSupply resource.
+type powerSupplyResource struct {
+	odataHeader
+	ID                 string         `json:"Id"`
+	Name               string         `json:"Name"`
+	Manufacturer       string         `json:"Manufacturer,omitempty"`
+	Model              string         `json:"Model,omitempty"`
+	SerialNumber       string         `json:"SerialNumber,omitempty"`
+	PartNumber         string         `json:"PartNumber,omitempty"`
+	PowerSupplyType    string         `json:"PowerSupplyType,omitempty"`
+	PowerCapacityWatts *float64       `json:"PowerCapacityWatts,omitempty"`
+	HotPluggable       *bool          `json:"HotPluggable,omitempty"`
+	Status             resourceStatus `json:"Status"`
+}
+
+// powerLimitControl is the power limit of a chassis, which applies while the
+// control mode is Automatic and is lifted while it is Disabled.
+type powerLimitControl struct {
+	SetPoint    *float64 `json:"SetPoint"`
+	ControlMode string   `json:"ControlMode"`
+}
+
+// environmentMetrics is the Redfish EnvironmentMetrics resource of a chassis.
+type environmentMetrics struct {
+	odataHeader
+	ID                 string             `json:"Id"`
+	Name               string             `json:"Name"`
+	TemperatureCelsius *sensorExcerpt     `json:"TemperatureCelsius,omitempty"`
+	PowerWatts         *sensorExcerpt     `json:"PowerWatts,omitempty"`
+	PowerLimitWatts    *powerLimitControl `json:"PowerLimitWatts,omitempty"`
+	FanSpeedsPercent   []fanSpeed         `json:"FanSpeedsPercent"`
+}
+
+// powerMetrics are the power statistics of the deprecated PowerControl.
+type powerMetrics struct {
+	IntervalInMin        int64   `json:"IntervalInMin,omitempty"`
+	MinConsumedWatts     float64 `json:"MinConsumedWatts"`
+	MaxConsumedWatts     float64 `json:"MaxConsumedWatts"`
+	AverageConsumedWatts float64 `json:"AverageConsumedWatts"`
+}
+
+// powerLimit is the power limit of the deprecated PowerControl. A null
+// LimitInWatts means that no limit applies.
+type powerLimit struct {
+	LimitInWatts   *float64 `json:"LimitInWatts"`
+	LimitException string   `json:"LimitException,omitempty"`
+	CorrectionInMs int64    `json:"CorrectionInMs,omitempty"`
+}
+
+// powerControl is the power consumption and limit of the deprecated Power
+// resource.
+type powerControl struct {
+	ODataID            string        `json:"@odata.id"`
+	MemberID           string        `json:"MemberId"`
+	Name               string        `json:"Name"`
+	PowerConsumedWatts *float64      `json:"PowerConsumedWatts"`
+	PowerCapacityWatts *float64      `json:"PowerCapacityWatts,omitempty"`
+	PowerMetrics       *powerMetrics `json:"PowerMetrics,omitempty"`
+	PowerLimit         powerLimit    `json:"PowerLimit"`
+}
+
+// legacyVoltage is a voltage of the deprecated Power resource.
+type legacyVoltage struct {
+	ODataID      string   `json:"@odata.id"`
+	MemberID     string   `json:"MemberId"`
+	Name         string   `json:"Name"`
+	ReadingVolts *float64 `json:"ReadingVolts"`
+	legacyThresholds
+	Status resourceStatus `json:"Status"`
+}
+
+// legacyPowerSupply is a power supply of the deprecated Power resource.
+type legacyPowerSupply struct {
+	ODataID              string         `json:"@odata.id"`
+	MemberID             string         `json:"MemberId"`
+	Name                 string         `json:"Name"`
+	Manufacturer         string         `json:"Manufacturer,omitempty"`
+	Model                string         `json:"Model,omitempty"`
+	SerialNumber         string         `json:"SerialNumber,omitempty"`
+	PartNumber           string         `json:"PartNumber,omitempty"`
+	PowerSupplyType      string         `json:"PowerSupplyType,omitempty"`
+	PowerCapacityWatts   *float64       `json:"PowerCapacityWatts,omitempty"`
+	LastPowerOutputWatts *float64       `json:"LastPowerOutputWatts,omitempty"`
+	LineInputVoltage     *float64       `json:"LineInputVoltage,omitempty"`
+	Status               resourceStatus `json:"Status"`
+}
+
+// powerResource is the deprecated Redfish Power resource of a chassis.
+type powerResource struct {
+	odataHeader
+	ID            string              `json:"Id"`
+	Name          string              `json:"Name"`
+	PowerControl  []powerControl      `json:"PowerControl"`
+	Voltages      []legacyVoltage     `json:"Voltages"`
+	PowerSupplies []legacyPowerSupply `json:"PowerSupplies"`
+}
+
+// powerControlPatch holds the writable properties of the deprecated
+// PowerControl. LimitInWatts is kept raw to tell null from absent.
+type powerControlPatch struct {
+	PowerLimit *struct {
+		LimitInWatts   json.RawMessage `json:"LimitInWatts"`
+		LimitException *string         `json:"LimitException"`
+		CorrectionInMs *int64          `json:"CorrectionInMs"`
+	} `json:"PowerLimit"`
+}
+
+// powerLimitExceptions returns the supported LimitException values.
+func powerLimitExceptions() []string {
+	return []string{"NoAction", "HardPowerOff", "LogEventOnly"}
+}
+
+// powerLimitException maps a power limit exception action to the Redfish
+// LimitException.
+func powerLimitException(action schemav1alpha1.PowerLimitExceptionAction) string {
+	switch action {
+	case schemav1alpha1.PowerLimitExceptionAction_POWER_LIMIT_EXCEPTION_ACTION_NONE:
+		return "NoAction"
+	case schemav1alpha1.PowerLimitExceptionAction_POWER_LIMIT_EXCEPTION_ACTION_HARD_POWER_OFF:
+		return "HardPowerOff"
+	case schemav1alpha1.PowerLimitExceptionAction_POWER_LIMIT_EXCEPTION_ACTION_LOG_EVENT:
+		return "LogEventOnly"
+	default:
+		return ""
+	}
+}
+
+// powerLimitAction maps a Redfish LimitException to a power limit exception
+// action.
+func powerLimitAction(exception string) schemav1alpha1.PowerLimitExceptionAction {
+	switch exception {
+	case "NoAction":
+		return schemav1alpha1.PowerLimitExceptionAction_POWER_LIMIT_EXCEPTION_ACTION_NONE
+	case "HardPowerOff":
+		return schemav1alpha1.PowerLimitExceptionAction_POWER_LIMIT_EXCEPTION_ACTION_HARD_POWER_OFF
+	case "LogEventOnly":
+		return schemav1alpha1.PowerLimitExceptionAction_POWER_LIMIT_EXCEPTION_ACTION_LOG_EVENT
+	default:
+		return schemav1alpha1.PowerLimitExceptionAction_POWER_LIMIT_EXCEPTION_ACTION_UNSPECIFIED
+	}
+}
+
+// powerSupplyType maps a power supply type to the Redfish power supply type.
+// Types without a Redfish equivalent are not reported.
+func powerSupplyType(t schemav1alpha1.ChassisPowerSupplyType) string {
+	switch t {
+	case schemav1alpha1.ChassisPowerSupplyType_CHASSIS_POWER_SUPPLY_TYPE_AC:
+		return "AC"
+	case schemav1alpha1.ChassisPowerSupplyType_CHASSIS_POWER_SUPPLY_TYPE_DC:
+		return "DC"
+	default:
+		return ""
+	}
+}
+
+// powerSupplyStatus maps a power supply status to the Redfish status.
+func powerSupplyStatus(status schemav1alpha1.ChassisPowerSupplyStatus) resourceStatus {
+	switch status {
+	case schemav1alpha1.ChassisPowerSupplyStatus_CHASSIS_POWER_SUPPLY_STATUS_OK:
+		return resourceStatus{State: "Enabled", Health: "OK"}
+	case schemav1alpha1.ChassisPowerSupplyStatus_CHASSIS_POWER_SUPPLY_STATUS_WARNING:
+		return resourceStatus{State: "Enabled", Health: "Warning"}
+	case schemav1alpha1.ChassisPowerSupplyStatus_CHASSIS_POWER_SUPPLY_STATUS_CRITICAL:
+		return resourceStatus{State: "Enabled", Health: "Critical"}
+	case schemav1alpha1.ChassisPowerSupplyStatus_CHASSIS_POWER_SUPPLY_STATUS_FAILED,
+		schemav1alpha1.ChassisPowerSupplyStatus_CHASSIS_POWER_SUPPLY_STATUS_INPUT_LOST:
+		return resourceStatus{State: "UnavailableOffline", Health: "Critical"}
+	case schemav1alpha1.ChassisPowerSupplyStatus_CHASSIS_POWER_SUPPLY_STATUS_NOT_PRESENT:
+		return resourceStatus{State: "Absent"}
+	default:
+		return resourceStatus{State: "Enabled"}
+	}
+}
+
+// optionalWatts converts an optional integer number of watts.
+func optionalWatts(v *uint32) *float64 {
+	if v == nil {
+		return nil
+	}
+	watts := float64(*v)
+	return &watts
+}
+
+// newPowerSupply creates the PowerSupply resource of a power supply in the
+// collection at path.
+func newPowerSupply(path string, psu *schemav1alpha1.ChassisPowerSupply) *powerSupplyResource {
+	return &powerSupplyResource{
+		odataHeader:        odataHeader{ODataID: path + "/" + url.PathEscape(psu.GetName()), ODataType: odataTypePowerSupply},
+		ID:                 psu.GetName(),
+		Name:               psu.GetName(),
+		Manufacturer:       psu.GetAsset().GetManufacturer(),
+		Model:              psu.GetAsset().GetProductName(),
+		SerialNumber:       psu.GetAsset().GetSerialNumber(),
+		PartNumber:         psu.GetAsset().GetPartNumber(),
+		PowerSupplyType:    powerSupplyType(psu.GetType()),
+		PowerCapacityWatts: optionalWatts(psu.CapacityWatts),
+		HotPluggable:       psu.HotSwappable,
+		Status:             powerSupplyStatus(psu.GetStatus()),
+	}
+}
+
+// isInletTemperature reports whether a temperature sensor measures the inlet
+// or ambient temperature, judging by its ID and name.
+func isInletTemperature(sensor *sensorResource) bool {
+	text := strings.ToLower(sensor.ID + " " + sensor.Name)
+	return strings.Contains(text, "inlet") || strings.Contains(text, "ambient")
+}
+
+func (s *redfishServer) registerPower() {
+	path := chassisPath + "/{id}"
+	s.handle(http.MethodGet, path+"/PowerSubsystem", s.handlePowerSubsystem)
+	s.handle(http.MethodGet, path+"/PowerSubsystem/PowerSupplies", s.handlePowerSupplies)
+	s.handle(http.MethodGet, path+"/PowerSubsystem/PowerSupplies/{psuId}", s.handlePowerSupply)
+	s.handle(http.MethodGet, path+"/EnvironmentMetrics", s.handleEnvironmentMetrics)
+	s.handle(http.MethodPatch, path+"/EnvironmentMetrics", s.handlePatchEnvironmentMetrics)
+	s.handle(http.MethodGet, path+"/Power", s.handlePower)
+	s.handle(http.MethodPatch, path+"/Power", s.handlePatchPower)
+}
+
+// powerSupplies returns the power supplies of a chassis sorted by name.
+func powerSupplies(c *schemav1alpha1.Chassis) []*schemav1alpha1.ChassisPowerSupply {
+	psus := slices.Clone(c.GetPowerInfo().GetPowerSupplies())
+	slices.SortFunc(psus, func(a, b *schemav1alpha1.ChassisPowerSupply) int {
+		return cmp.Compare(a.GetName(), b.GetName())
+	})
+	return psus
+}
+
+// systemPower fetches the power reading and limit enforced by the power
+// manager, which apply to the primary chassis. A missing reading is reported
+// as nil. Failures are reported as errors about the resource of the given
+// type and ID.
+func (s *redfishServer) systemPower(w http.ResponseWriter, r *http.Request, resourceType, id string) (*schemav1alpha1.PowerReading, *schemav1alpha1.PowerLimit, bool) {
+	var reading schemav1alpha1.GetPowerReadingResponse
+	err := s.requestNATS(r.Context(), ipc.SubjectPowerReading, &schemav1alpha1.GetPowerReadingRequest{}, &reading)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		s.writeRequestError(w, r, err, resourceType, id)
+		return nil, nil, false
+	}
+
+	var limit schemav1alpha1.GetPowerLimitResponse
+	if err := s.requestNATS(r.Context(), ipc.SubjectPowerLimitInfo, &schemav1alpha1.GetPowerLimitRequest{}, &limit); err != nil {
+		s.writeRequestError(w, r, err, resourceType, id)
+		return nil, nil, false
+	}
+
+	return reading.GetReading(), limit.GetLimit(), true
+}
+
+// setPowerLimit forwards a power limit change to the power manager.
+func (s *redfishServer) setPowerLimit(w http.ResponseWriter, r *http.Request, req *schemav1alpha1.SetPowerLimitRequest, resourceType, id string) bool {
+	var resp schemav1alpha1.SetPowerLimitResponse
+	if err := s.requestNATS(r.Context(), ipc.SubjectPowerLimitSet, req, &resp); err != nil {
+		s.writeRequestError(w, r, err, resourceType, id)
+		return false
+	}
+
+	limit := resp.GetLimit()
+	s.logger.InfoContext(r.Context(), "Redfish power limit changed",
+		"chassis", r.PathValue("id"),
+		"limit_watts", limit.GetLimitWatts(),
+		"exception_action", limit.GetExceptionAction().String(),
+		"active", limit.GetActive())
+	return true
+}
+
+func (s *redfishServer) handlePowerSubsystem(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	c, ok := s.chassisState(w, r, id)
+	if !ok {
+		return
+	}
+
+	path := chassisPath + "/" + url.PathEscape(id) + "/PowerSubsystem"
+	s.writeResource(w, r, &powerSubsystem{
+		odataHeader:   odataHeader{ODataID: path, ODataType: odataTypePowerSubsystem},
+		ID:            "PowerSubsystem",
+		Name:          "Power Subsystem",
+		CapacityWatts: optionalWatts(c.GetPowerInfo().PowerCapacityWatts),
+		PowerSupplies: link(path + "/PowerSupplies"),
+		Status:        resourceStatus{State: "Enabled", Health: "OK"},
+	})
+}
+
+func (s *redfishServer) handlePowerSupplies(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	c, ok := s.chassisState(w, r, id)
+	if !ok {
+		return
+	}
+
+	psus := powerSupplies(c)
+	names := make([]string, 0, len(psus))
+	for _, psu := range psus {
+		names = append(names, psu.GetName())
+	}
+
+	s.writeResource(w, r, newCollection(chassisPath+"/"+url.PathEscape(id)+"/PowerSubsystem/PowerSupplies",
+		odataTypePowerSupplyCollection, "Power Supply Collection", names))
+}
+
+func (s *redfishServer) handlePowerSupply(w http.ResponseWriter, r *http.Request) {
+	id, psuID := r.PathValue("id"), r.PathValue("psuId")
+	c, ok := s.chassisState(w, r, id)
+	if !ok {
+		return
+	}
+
+	i := slices.IndexFunc(c.GetPowerInfo().GetPowerSupplies(), func(psu *schemav1alpha1.ChassisPowerSupply) bool {
+		return psu.GetName() == psuID
+	})
+	if i < 0 {
+		s.writeRequestError(w, r, ErrNotFound, powerSupplyResourceName, psuID)
+		return
+	}
+
+	s.writeResource(w, r, newPowerSupply(chassisPath+"/"+url.PathEscape(id)+"/PowerSubsystem/PowerSupplies",
+		c.GetPowerInfo().GetPowerSupplies()[i]))
+}
+
+// handleEnvironmentMetrics serves the environment of a chassis: the inlet
+// temperature, the fan speeds and, for the primary chassis, the power
+// consumption and limit of the system.
+func (s *redfishServer) handleEnvironmentMetrics(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	scope, ok := s.chassisScope(w, r, id)
+	if !ok {
+		return
+	}
+
+	sensors, ok := s.chassisSensors(w, r, scope, nil, "EnvironmentMetrics", "EnvironmentMetrics")
+	if !ok {
+		return
+	}
+	fans, ok := s.chassisFans(w, r, scope, "EnvironmentMetrics", "EnvironmentMetrics")
+	if !ok {
+		return
+	}
+
+	path := chassisPath + "/" + url.PathEscape(id)
+	metrics := &environmentMetrics{
+		odataHeader:      odataHeader{ODataID: path + "/EnvironmentMetrics", ODataType: odataTypeEnvironmentMetrics},
+		ID:               "EnvironmentMetrics",
+		Name:             "Environment Metrics",
+		FanSpeedsPercent: make([]fanSpeed, 0, len(fans)),
+	}
+
+	temperatures := contextSensors(path+"/Sensors", sensors, schemav1alpha1.SensorContext_SENSOR_CONTEXT_TEMPERATURE)
+	if i := slices.IndexFunc(temperatures, isInletTemperature); i >= 0 {
+		metrics.TemperatureCelsius = &sensorExcerpt{DataSourceURI: temperatures[i].ODataID, Reading: temperatures[i].Reading}
+	}
+
+	byID := sensorsByID(sensors)
+	for _, fan := range fans {
+		speed := newFanSpeed(fan, byID[fanTachSensorID(fan.GetName())])
+		speed.DeviceName = fan.GetName()
+		metrics.FanSpeedsPercent = append(metrics.FanSpeedsPercent, speed)
+	}
+
+	if scope.primary {
+		reading, limit, ok := s.systemPower(w, r, "EnvironmentMetrics", "EnvironmentMetrics")
+		if !ok {
+			return
+		}
+		if reading != nil {
+			watts := reading.GetCurrentWatts()
+			metrics.PowerWatts = &sensorExcerpt{Reading: &watts}
+		}
+		metrics.PowerLimitWatts = &powerLimitControl{ControlMode: controlModeDisabled}
+		if limit.GetLimitWatts() > 0 {
+			watts := float64(limit.GetLimitWatts())
+			metrics.PowerLimitWatts.SetPoint = &watts
+		}
+		if limit.GetActive() {
+			metrics.PowerLimitWatts.ControlMode = controlModeAutomatic
+		}
+	}
+
+	s.writeResource(w, r, metrics)
+}
+
+// handlePatchEnvironmentMetrics sets the power limit of the system through
+// the PowerLimitWatts control of the primary chassis.
+func (s *redfishServer) handlePatchEnvironmentMetrics(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	scope, ok := s.chassisScope(w, r, id)
+	if !ok {
+		return
+	}
+
+	writable := []string{}
+	readOnly := []string{"Id", "Name", "TemperatureCelsius", "PowerWatts", "FanSpeedsPercent"}
+	if scope.primary {
+		writable = append(writable, propertyPowerLimitWatts)
+	} else {
+		readOnly = append(readOnly, propertyPowerLimitWatts)
+	}
+	props, ok := s.readProperties(w, r, writable, readOnly)
+	if !ok {
+		return
+	}
+
+	var control struct {
+		SetPoint    *float64 `json:"SetPoint"`
+		ControlMode *string  `json:"ControlMode"`
+	}
+	if !s.decodeProperty(w, props, propertyPowerLimitWatts, &control) {
+		return
+	}
+
+	var req schemav1alpha1.SetPowerLimitRequest
+	if control.SetPoint != nil {
+		if *control.SetPoint < 1 || *control.SetPoint > math.MaxUint32 {
+			s.writeOutOfRange(w, propertyPowerLimitSetPoint, *control.SetPoint)
+			return
+		}
+		watts := uint32(math.Round(*control.SetPoint))
+		req.LimitWatts = &watts
+	}
+	if control.ControlMode != nil {
+		if !s.checkPropertyValues(w, propertyPowerLimitControlMode, []string{*control.ControlMode},
+			[]string{controlModeAutomatic, controlModeDisabled}) {
+			return
+		}
+		active := *control.ControlMode == controlModeAutomatic
+		req.Active = &active
+	}
+
+	if (req.LimitWatts != nil || req.Active != nil) &&
+		!s.setPowerLimit(w, r, &req, "EnvironmentMetrics", "EnvironmentMetrics") {
+		return
+	}
+
+	s.handleEnvironmentMetrics(w, r)
+}
+
+// handlePower serves the deprecated Power resource. Its single PowerControl
+// reports the power consumption and limit of the system on the primary
+// chassis.
+func (s *redfishServer) handlePower(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	scope, ok := s.chassisScope(w, r, id)
+	if !ok {
+		return
+	}
+	c, ok := s.chassisState(w, r, id)
+	if !ok {
+		return
+	}
+	sensors, ok := s.chassisSensors(w, r, scope, nil, "Power", "Power")
+	if !ok {
+		return
+	}
+
+	path := chassisPath + "/" + url.PathEscape(id)
+	voltages := contextSensors(path+"/Sensors", sensors, schemav1alpha1.SensorContext_SENSOR_CONTEXT_VOLTAGE)
+	psus := powerSupplies(c)
+	res := &powerResource{
+		odataHeader:   odataHeader{ODataID: path + "/Power", ODataType: odataTypePower},
+		ID:            "Power",
+		Name:          "Power",
+		PowerControl:  []powerControl{},
+		Voltages:      make([]legacyVoltage, 0, len(voltages)),
+		PowerSupplies: make([]legacyPowerSupply, 0, len(psus)),
+	}
+
+	if scope.primary {
+		reading, limit, ok := s.systemPower(w, r, "Power", "Power")
+		if !ok {
+			return
+		}
+		control := powerControl{
+			ODataID:            path + "/Power#/PowerControl/0",
+			MemberID:           "0",
+			Name:               "System Power Control",
+			PowerCapacityWatts: optionalWatts(c.GetPowerInfo().PowerCapacityWatts),
+			PowerLimit: powerLimit{
+				LimitException: powerLimitException(limit.GetExceptionAction()),
+				CorrectionInMs: limit.GetCorrectionTime().AsDuration().Milliseconds(),
+			},
+		}
+		if reading != nil {
+			watts := reading.GetCurrentWatts()
+			control.PowerConsumedWatts = &watts
+			control.PowerMetrics = &powerMetrics{
+				IntervalInMin:        int64(reading.GetStatisticsPeriod().AsDuration().Minutes()),
+				MinConsumedWatts:     reading.GetMinimumWatts(),
+				MaxConsumedWatts:     reading.GetMaximumWatts(),
+				AverageConsumedWatts: reading.GetAverageWatts(),
+			}
+		}
+		if limit.GetActive() {
+			watts := float64(limit.GetLimitWatts())
+			control.PowerLimit.LimitInWatts = &watts
+		}
+		res.PowerControl = append(res.PowerControl, control)
+	}
+
+	for i, v := range voltages {
+		member := strconv.Itoa(i)
+		res.Voltages = append(res.Voltages, legacyVoltage{
+			ODataID:          path + "/Power#/Voltages/" + member,
+			MemberID:         member,
+			Name:             v.Name,
+			ReadingVolts:     v.Reading,
+			legacyThresholds: newLegacyThresholds(v.Thresholds),
+			Status:           v.Status,
+		})
+	}
+
+	for i, psu := range psus {
+		member := strconv.Itoa(i)
+		res.PowerSupplies = append(res.PowerSupplies, legacyPowerSupply{
+			ODataID:              path + "/Power#/PowerSupplies/" + member,
+			MemberID:             member,
+			Name:                 psu.GetName(),
+			Manufacturer:         psu.GetAsset().GetManufacturer(),
+			Model:                psu.GetAsset().GetProductName(),
+			SerialNumber:         psu.GetAsset().GetSerialNumber(),
+			PartNumber:           psu.GetAsset().GetPartNumber(),
+			PowerSupplyType:      powerSupplyType(psu.GetType()),
+			PowerCapacityWatts:   optionalWatts(psu.CapacityWatts),
+			LastPowerOutputWatts: optionalWatts(psu.OutputWatts),
+			LineInputVoltage:     psu.InputVoltage,
+			Status:               powerSupplyStatus(psu.GetStatus()),
+		})
+	}
+
+	s.writeResource(w, r, res)
+}
+
+// handlePatchPower sets the power limit of the system through the
+// PowerLimit of the PowerControl of the primary chassis. A null LimitInWatts
+// lifts the limit.
+func (s *redfishServer) handlePatchPower(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	scope, ok := s.chassisScope(w, r, id)
+	if !ok {
+		return
+	}
+
+	writable := []string{}
+	readOnly := []string{"Id", "Name", "Voltages", "PowerSupplies"}
+	if scope.primary {
+		writable = append(writable, propertyPowerControl)
+	} else {
+		readOnly = append(readOnly, propertyPowerControl)
+	}
+	props, ok := s.readProperties(w, r, writable, readOnly)
+	if !ok {
+		return
+	}
+
+	var controls []powerControlPatch
+	if !s.decodeProperty(w, props, propertyPowerControl, &controls) {
+		return
+	}
+	if len(controls) > 1 {
+		s.writeError(w, http.StatusBadRequest, "ArraySizeTooLong",
+			"The array provided for property "+propertyPowerControl+" exceeds the size limit 1.", propertyPowerControl, "1")
+		return
+	}
+
+	var req schemav1alpha1.SetPowerLimitRequest
+	if len(controls) == 1 && controls[0].PowerLimit != nil {
+		patch := controls[0].PowerLimit
+		switch {
+		case patch.LimitInWatts == nil:
+		case string(patch.LimitInWatts) == "null":
+			active := false
+			req.Active = &active
+		default:
+			var watts float64
+			if !s.decodeProperty(w, map[string]json.RawMessage{propertyLimitInWatts: patch.LimitInWatts}, propertyLimitInWatts, &watts) {
+				return
+			}
+			if watts < 1 || watts > math.MaxUint32 {
+				s.writeOutOfRange(w, propertyLimitInWatts, watts)
+				return
+			}
+			limit, active := uint32(math.Round(watts)), true
+			req.LimitWatts, req.Active = &limit, &active
+		}
+		if patch.LimitException != nil {
+			if !s.checkPropertyValues(w, propertyLimitException, []string{*patch.LimitException}, powerLimitExceptions()) {
+				return
+			}
+			action := powerLimitAction(*patch.LimitException)
+			req.ExceptionAction = &action
+		}
+		if patch.CorrectionInMs != nil {
+			if *patch.CorrectionInMs <= 0 {
+				s.writeOutOfRange(w, propertyCorrectionInMs, *patch.CorrectionInMs)
+				return
+			}
+			req.CorrectionTime = durationpb.New(time.Duration(*patch.CorrectionInMs) * time.Millisecond)
+		}
+	}
+
+	if (req.LimitWatts != nil || req.Active != nil || req.ExceptionAction != nil || req.CorrectionTime != nil) &&
+		!s.setPowerLimit(w, r, &req, "Power", "Power") {
+		return
+	}
+
+	s.handlePower(w, r)
+}
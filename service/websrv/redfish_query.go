@@ -0,0 +1,522 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package websrv
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// Redfish query parameters.
+const (
+	queryExpand = "$expand"
+	querySelect = "$select"
+	queryFilter = "$filter"
+	queryTop    = "$top"
+	querySkip   = "$skip"
+	// maxExpandLevels is the deepest expansion served, which is enough to
+	// expand the chassis collection down to the sensors of every chassis.
+	maxExpandLevels = 3
+)
+
+// $expand modes.
+const (
+	expandAll     = "*"
+	expandNoLinks = "."
+	expandLinks   = "~"
+)
+
+// queryContextKey is the context key of the query of a request.
+type queryContextKey struct{}
+
+// protocolFeatures is the ProtocolFeaturesSupported property of the service
+// root.
+type protocolFeatures struct {
+	ExpandQuery struct {
+		ExpandAll bool `json:"ExpandAll"`
+		Levels    bool `json:"Levels"`
+		Links     bool `json:"Links"`
+		NoLinks   bool `json:"NoLinks"`
+		MaxLevels int  `json:"MaxLevels"`
+	} `json:"ExpandQuery"`
+	SelectQuery     bool `json:"SelectQuery"`
+	FilterQuery     bool `json:"FilterQuery"`
+	TopSkipQuery    bool `json:"TopSkipQuery"`
+	OnlyMemberQuery bool `json:"OnlyMemberQuery"`
+	ExcerptQuery    bool `json:"ExcerptQuery"`
+}
+
+// newProtocolFeatures returns the query parameters supported by the service.
+func newProtocolFeatures() protocolFeatures {
+	var f protocolFeatures
+	f.ExpandQuery.ExpandAll = true
+	f.ExpandQuery.Levels = true
+	f.ExpandQuery.Links = true
+	f.ExpandQuery.NoLinks = true
+	f.ExpandQuery.MaxLevels = maxExpandLevels
+	f.SelectQuery = true
+	f.FilterQuery = true
+	f.TopSkipQuery = true
+	return f
+}
+
+// redfishQuery holds the query parameters of a GET request.
+type redfishQuery struct {
+	// expand is the $expand mode, or empty if nothing is expanded.
+	expand string
+	levels int
+	// selects holds the $select properties split into their path segments.
+	selects [][]string
+	filter  filterExpr
+	// top is the $top value, or -1 if it is absent.
+	top  int
+	skip int
+}
+
+// queryError is a query parameter rejected by the service.
+type queryError struct {
+	status    int
+	messageID string
+	message   string
+	args      []string
+}
+
+func (e *queryError) Error() string {
+	return e.message
+}
+
+// errQueryFormat reports a query parameter value of the wrong format.
+func errQueryFormat(param, value string) *queryError {
+	return &queryError{
+		status:    http.StatusBadRequest,
+		messageID: "QueryParameterValueFormatError",
+		message: fmt.Sprintf("The value '%s' for the parameter %s is of a different format than the parameter can accept.",
+			value, param),
+		args: []string{value, param},
+	}
+}
+
+// errQueryRange reports a query parameter value outside the supported range.
+func errQueryRange(param, value, valid string) *queryError {
+	return &queryError{
+		status:    http.StatusBadRequest,
+		messageID: "QueryParameterOutOfRange",
+		message:   fmt.Sprintf("The value '%s' for the query parameter %s is out of range %s.", value, param, valid),
+		args:      []string{value, param, valid},
+	}
+}
+
+// requestQuery returns the query of a request, or nil if the request has none.
+func requestQuery(r *http.Request) *redfishQuery {
+	q, _ := r.Context().Value(queryContextKey{}).(*redfishQuery)
+	return q
+}
+
+// parseQuery parses the Redfish query parameters of values. It returns nil if
+// values holds none. Parameters not starting with $, such as only and
+// excerpt, are ignored.
+func parseQuery(values url.Values) (*redfishQuery, *queryError) {
+	var q *redfishQuery
+	for param, vals := range values {
+		if !strings.HasPrefix(param, "$") {
+			continue
+		}
+		if q == nil {
+			q = &redfishQuery{top: -1}
+		}
+
+		value := vals[0]
+		var err *queryError
+		switch param {
+		case queryExpand:
+			q.expand, q.levels, err = parseExpand(value)
+		case querySelect:
+			q.selects, err = parseSelect(value)
+		case queryFilter:
+			q.filter, err = parseFilter(value)
+		case queryTop:
+			q.top, err = parseCount(param, value)
+		case querySkip:
+			q.skip, err = parseCount(param, value)
+		default:
+			err = &queryError{
+				status:    http.StatusNotImplemented,
+				messageID: "QueryParameterUnsupported",
+				message:   fmt.Sprintf("Query parameter %s is not supported.", param),
+				args:      []string{param},
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+// parseExpand parses an $expand value such as ".($levels=2)".
+func parseExpand(value string) (string, int, *queryError) {
+	if value == "" {
+		return "", 0, errQueryFormat(queryExpand, value)
+	}
+	mode, options := value[:1], value[1:]
+	if mode != expandAll && mode != expandNoLinks && mode != expandLinks {
+		return "", 0, errQueryFormat(queryExpand, value)
+	}
+	if options == "" {
+		return mode, 1, nil
+	}
+
+	levels, ok := strings.CutPrefix(options, "($levels=")
+	if !ok || !strings.HasSuffix(levels, ")") {
+		return "", 0, errQueryFormat(queryExpand, value)
+	}
+	n, err := strconv.Atoi(strings.TrimSuffix(levels, ")"))
+	if err != nil {
+		return "", 0, errQueryFormat(queryExpand, value)
+	}
+	if n < 1 || n > maxExpandLevels {
+		return "", 0, errQueryRange(queryExpand, value, "1-"+strconv.Itoa(maxExpandLevels))
+	}
+	return mode, n, nil
+}
+
+// parseSelect parses a comma separated $select list of properties, whose
+// nested properties are separated by slashes.
+func parseSelect(value string) ([][]string, *queryError) {
+	var selects [][]string
+	for _, prop := range strings.Split(value, ",") {
+		segments := strings.Split(strings.TrimSpace(prop), "/")
+		for _, segment := range segments {
+			if !isPropertyName(segment) {
+				return nil, errQueryFormat(querySelect, value)
+			}
+		}
+		selects = append(selects, segments)
+	}
+	return selects, nil
+}
+
+// parseCount parses a $top or $skip value.
+func parseCount(param, value string) (int, *queryError) {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, errQueryFormat(param, value)
+	}
+	if n < 0 {
+		return 0, errQueryRange(param, value, "0-"+strconv.Itoa(math.MaxInt))
+	}
+	return n, nil
+}
+
+// isPropertyName reports whether s is a non-empty Redfish property name,
+// which may carry an annotation such as Members@odata.count.
+func isPropertyName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) && c != '_' && c != '@' && c != '.' && c != '#' {
+			return false
+		}
+	}
+	return true
+}
+
+// applyQuery applies q to the JSON object of a resource. Collections are
+// filtered and paged before their members and links are expanded, and the
+// selection is applied last.
+func (s *redfishServer) applyQuery(r *http.Request, obj map[string]any, q *redfishQuery) error {
+	members, isCollection := obj["Members"].([]any)
+	if !isCollection && (q.filter != nil || q.top >= 0 || q.skip > 0) {
+		return &queryError{
+			status:    http.StatusBadRequest,
+			messageID: "QueryNotSupportedOnResource",
+			message:   "Querying is not supported on the requested resource.",
+		}
+	}
+
+	if isCollection {
+		if q.filter != nil {
+			members = s.filterMembers(r, members, q.filter)
+			obj["Members@odata.count"] = len(members)
+		}
+
+		total := len(members)
+		start, end := min(q.skip, total), total
+		if q.top >= 0 && q.top < total-start {
+			end = start + q.top
+		}
+		members = members[start:end]
+		if end < total {
+			obj["Members@odata.nextLink"] = nextLink(r, end)
+		}
+
+		// Members are only returned expanded if the client asked for it,
+		// even if the handler or the filter already fetched them.
+		if q.expand == "" || q.expand == expandLinks {
+			for i, member := range members {
+				if m, ok := member.(map[string]any); ok {
+					members[i] = map[string]any{"@odata.id": m["@odata.id"]}
+				}
+			}
+		}
+		obj["Members"] = members
+	}
+
+	if q.expand != "" {
+		s.expandObject(r, obj, q.expand, q.levels, false)
+	}
+
+	if q.selects != nil {
+		tree := newSelectTree(q.selects)
+		if !isCollection {
+			tree.apply(obj)
+			return nil
+		}
+		for _, member := range members {
+			if m, ok := member.(map[string]any); ok && !isReference(m) {
+				tree.apply(m)
+			}
+		}
+	}
+
+	return nil
+}
+
+// selectFieldMask returns the field mask of a backend list or get request
+// that covers the $select properties of q. fields maps each property to the
+// message fields it is derived from, and the fields named by always, such as
+// those identifying the resource, are part of every mask. It returns nil if
+// all fields are needed, including when a $filter needs the full members.
+func selectFieldMask(q *redfishQuery, fields map[string][]string, always ...string) *fieldmaskpb.FieldMask {
+	if q == nil || q.selects == nil || q.filter != nil {
+		return nil
+	}
+
+	paths := slices.Clone(always)
+	for _, sel := range q.selects {
+		for _, path := range fields[sel[0]] {
+			if !slices.Contains(paths, path) {
+				paths = append(paths, path)
+			}
+		}
+	}
+	return &fieldmaskpb.FieldMask{Paths: paths}
+}
+
+// nextLink returns the URI of the page of a collection starting at skip.
+func nextLink(r *http.Request, skip int) string {
+	values := r.URL.Query()
+	values.Set(querySkip, strconv.Itoa(skip))
+	return (&url.URL{Path: r.URL.Path, RawQuery: values.Encode()}).String()
+}
+
+// filterMembers returns the members of a collection that match filter,
+// fetching members that are only referenced.
+func (s *redfishServer) filterMembers(r *http.Request, members []any, filter filterExpr) []any {
+	matches := make([]any, 0, len(members))
+	for _, member := range members {
+		m, ok := member.(map[string]any)
+		if !ok {
+			continue
+		}
+		if isReference(m) {
+			id, _ := m["@odata.id"].(string)
+			if m, ok = s.fetchResource(r, id); !ok {
+				continue
+			}
+		}
+		if filter.eval(m) {
+			matches = append(matches, m)
+		}
+	}
+	return matches
+}
+
+// isReference reports whether obj only references a resource.
+func isReference(obj map[string]any) bool {
+	_, ok := obj["@odata.id"].(string)
+	return ok && len(obj) == 1
+}
+
+// expandObject replaces the references within obj by the referenced
+// resources, down to the given number of levels. Embedded resources, such as
+// collection members fetched by their handler, count as expanded. Depending
+// on mode, only references within Links, or only those outside of them, are
+// expanded.
+func (s *redfishServer) expandObject(r *http.Request, obj map[string]any, mode string, levels int, inLinks bool) {
+	for name, value := range obj {
+		if strings.Contains(name, "@") {
+			continue
+		}
+		obj[name] = s.expandValue(r, value, mode, levels, inLinks || name == "Links")
+	}
+}
+
+// expandValue returns value with its references expanded.
+func (s *redfishServer) expandValue(r *http.Request, value any, mode string, levels int, inLinks bool) any {
+	switch v := value.(type) {
+	case []any:
+		for i := range v {
+			v[i] = s.expandValue(r, v[i], mode, levels, inLinks)
+		}
+	case map[string]any:
+		id, isResource := v["@odata.id"].(string)
+		switch {
+		case !isResource:
+			s.expandObject(r, v, mode, levels, inLinks)
+		case !isReference(v):
+			if levels > 1 {
+				s.expandObject(r, v, mode, levels-1, false)
+			}
+		case mode == expandAll || (mode == expandLinks) == inLinks:
+			res, ok := s.fetchResource(r, id)
+			if !ok {
+				return v
+			}
+			if levels > 1 {
+				s.expandObject(r, res, mode, levels-1, false)
+			}
+			return res
+		}
+	}
+	return value
+}
+
+// fetchResource serves an internal GET request for the resource at path on
+// behalf of r and returns the resource as JSON object. Resources that cannot
+// be read are reported as not found.
+func (s *redfishServer) fetchResource(r *http.Request, path string) (map[string]any, bool) {
+	u, err := url.Parse(path)
+	if err != nil || u.Fragment != "" || !strings.HasPrefix(u.Path, redfishRoot+"/") {
+		return nil, false
+	}
+
+	req := r.Clone(context.WithValue(r.Context(), queryContextKey{}, (*redfishQuery)(nil)))
+	req.Method = http.MethodGet
+	req.URL = u
+	req.RequestURI = path
+	req.Header.Del("If-None-Match")
+
+	var resp responseBuffer
+	if _, _, ok := s.aggregator.resolve(u.Path); ok {
+		s.authorize(privilegeLogin, s.handleAggregated)(&resp, req)
+	} else {
+		s.mux.ServeHTTP(&resp, req)
+	}
+	if resp.status != http.StatusOK {
+		return nil, false
+	}
+
+	obj, err := decodeObject(resp.body.Bytes())
+	if err != nil {
+		return nil, false
+	}
+	return obj, true
+}
+
+// decodeObject decodes a JSON object keeping numbers in their original
+// representation.
+func decodeObject(data []byte) (map[string]any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var obj map[string]any
+	if err := dec.Decode(&obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// responseBuffer records the response of an internal request.
+type responseBuffer struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (b *responseBuffer) Header() http.Header {
+	if b.header == nil {
+		b.header = make(http.Header)
+	}
+	return b.header
+}
+
+func (b *responseBuffer) WriteHeader(status int) {
+	if b.status == 0 {
+		b.status = status
+	}
+}
+
+func (b *responseBuffer) Write(p []byte) (int, error) {
+	b.WriteHeader(http.StatusOK)
+	return b.body.Write(p)
+}
+
+// selectTree holds the selected properties of an object, mapped to the
+// selected properties below them. A nil tree selects a property as a whole.
+type selectTree map[string]selectTree
+
+// newSelectTree builds the tree of the selected property paths.
+func newSelectTree(selects [][]string) selectTree {
+	tree := make(selectTree)
+	for _, path := range selects {
+		node := tree
+		for i, segment := range path {
+			child, seen := node[segment]
+			switch {
+			case seen && child == nil:
+				// The property is already selected as a whole.
+			case i == len(path)-1:
+				node[segment] = nil
+			case !seen:
+				child = make(selectTree)
+				node[segment] = child
+			}
+			if child == nil {
+				break
+			}
+			node = child
+		}
+	}
+	return tree
+}
+
+// apply removes the properties of obj that are not selected. OData
+// annotations of obj are always kept.
+func (t selectTree) apply(obj map[string]any) {
+	for name, value := range obj {
+		if strings.HasPrefix(name, "@odata.") {
+			continue
+		}
+		child, ok := t[name]
+		switch {
+		case !ok:
+			delete(obj, name)
+		case child != nil:
+			child.applyValue(value)
+		}
+	}
+}
+
+// applyValue applies the selection to the objects within value.
+func (t selectTree) applyValue(value any) {
+	switch v := value.(type) {
+	case map[string]any:
+		t.apply(v)
+	case []any:
+		for _, item := range v {
+			t.applyValue(item)
+		}
+	}
+}
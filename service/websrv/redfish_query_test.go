@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package websrv
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestRedfishQuery(t *testing.T) {
+	_, srv := newTestRedfish(t)
+
+	memberIDs := func(obj map[string]any) []string {
+		members, _ := obj["Members"].([]any)
+		ids := make([]string, 0, len(members))
+		for _, m := range members {
+			ids = append(ids, m.(map[string]any)["@odata.id"].(string))
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name  string
+		path  string
+		check func(t *testing.T, obj map[string]any)
+	}{
+		{
+			name: "$top and $skip page a collection",
+			path: rolesPath + "?$skip=1&$top=1",
+			check: func(t *testing.T, obj map[string]any) {
+				if got := memberIDs(obj); !reflect.DeepEqual(got, []string{rolesPath + "/" + roleOperator}) {
+					t.Errorf("members = %v", got)
+				}
+				if obj["Members@odata.count"] != float64(3) {
+					t.Errorf("count = %v, want the size of the collection", obj["Members@odata.count"])
+				}
+				next, _ := url.Parse(obj["Members@odata.nextLink"].(string))
+				if next.Path != rolesPath || next.Query().Get("$skip") != "2" || next.Query().Get("$top") != "1" {
+					t.Errorf("next link = %v", obj["Members@odata.nextLink"])
+				}
+			},
+		},
+		{
+			name: "$filter selects members",
+			path: rolesPath + "?$filter=" + url.QueryEscape("RoleId eq 'ReadOnly' or RoleId eq 'Operator'"),
+			check: func(t *testing.T, obj map[string]any) {
+				want := []string{rolesPath + "/" + roleOperator, rolesPath + "/" + roleReadOnly}
+				if got := memberIDs(obj); !reflect.DeepEqual(got, want) {
+					t.Errorf("members = %v, want %v", got, want)
+				}
+				if obj["Members@odata.count"] != float64(2) {
+					t.Errorf("count = %v", obj["Members@odata.count"])
+				}
+			},
+		},
+		{
+			name: "$expand inlines members",
+			path: rolesPath + "?$expand=.",
+			check: func(t *testing.T, obj map[string]any) {
+				members := obj["Members"].([]any)
+				if len(members) != 3 {
+					t.Fatalf("%d members", len(members))
+				}
+				if id := members[0].(map[string]any)["RoleId"]; id != roleAdministrator {
+					t.Errorf("first member RoleId = %v", id)
+				}
+			},
+		},
+		{
+			name: "$select keeps the selected properties",
+			path: rolesPath + "/" + roleOperator + "?$select=RoleId,IsPredefined",
+			check: func(t *testing.T, obj map[string]any) {
+				if obj["RoleId"] != roleOperator || obj["IsPredefined"] != true {
+					t.Errorf("selected properties missing: %v", obj)
+				}
+				if _, ok := obj["AssignedPrivileges"]; ok {
+					t.Error("unselected property returned")
+				}
+				if obj["@odata.id"] != rolesPath+"/"+roleOperator || obj["@odata.etag"] == nil {
+					t.Errorf("annotations missing: %v", obj)
+				}
+			},
+		},
+		{
+			name: "$select applies to expanded members",
+			path: rolesPath + "?$expand=.&$select=RoleId",
+			check: func(t *testing.T, obj map[string]any) {
+				for _, m := range obj["Members"].([]any) {
+					member := m.(map[string]any)
+					if _, ok := member["AssignedPrivileges"]; ok || member["RoleId"] == nil {
+						t.Errorf("member = %v", member)
+					}
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, data := testRequest{method: http.MethodGet, path: tt.path, user: "reader"}.do(t, srv)
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("status = %s %s", resp.Status, data)
+			}
+			tt.check(t, decodeJSON(t, data))
+		})
+	}
+}
+
+func TestRedfishQueryErrors(t *testing.T) {
+	_, srv := newTestRedfish(t)
+
+	tests := []struct {
+		name      string
+		path      string
+		status    int
+		messageID string
+	}{
+		{"unsupported parameter", rolesPath + "?$orderby=RoleId", http.StatusNotImplemented, "QueryParameterUnsupported"},
+		{"negative $top", rolesPath + "?$top=-1", http.StatusBadRequest, "QueryParameterOutOfRange"},
+		{"malformed $skip", rolesPath + "?$skip=two", http.StatusBadRequest, "QueryParameterValueFormatError"},
+		{"$expand levels out of range", rolesPath + "?$expand=" + url.QueryEscape(".($levels=9)"), http.StatusBadRequest, "QueryParameterOutOfRange"},
+		{"malformed $select", rolesPath + "?$select=" + url.QueryEscape("Role Id"), http.StatusBadRequest, "QueryParameterValueFormatError"},
+		{"malformed $filter", rolesPath + "?$filter=" + url.QueryEscape("RoleId eq"), http.StatusBadRequest, "QueryParameterValueFormatError"},
+		{"$top on a single resource", rolesPath + "/" + roleOperator + "?$top=1", http.StatusBadRequest, "QueryNotSupportedOnResource"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, data := testRequest{method: http.MethodGet, path: tt.path, user: "reader"}.do(t, srv)
+			if resp.StatusCode != tt.status {
+				t.Fatalf("status = %s, want %d: %s", resp.Status, tt.status, data)
+			}
+			if got := errorMessageID(t, data); got != tt.messageID {
+				t.Errorf("message = %s, want %s", got, tt.messageID)
+			}
+		})
+	}
+}
@@ -0,0 +1,269 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package websrv
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Built-in message registry.
+const (
+	eventRegistryPrefix  = "UBMC"
+	eventRegistryVersion = "1.0.0"
+	eventRegistryID      = eventRegistryPrefix + "." + eventRegistryVersion
+	registriesPath       = redfishRoot + "/Registries"
+)
+
+// Message registry resource types.
+const (
+	odataTypeMessageRegistryFileCollection = "#MessageRegistryFileCollection.MessageRegistryFileCollection"
+	odataTypeMessageRegistryFile           = "#MessageRegistryFile.v1_1_4.MessageRegistryFile"
+	odataTypeMessageRegistry               = "#MessageRegistry.v1_6_2.MessageRegistry"
+)
+
+// Messages of the built-in registry.
+const (
+	msgResourceStateChanged      = "ResourceStateChanged"
+	msgResourceStateChangeFailed = "ResourceStateChangeFailed"
+	msgReadingAboveUpperWarning  = "ReadingAboveUpperWarningThreshold"
+	msgReadingAboveUpperCritical = "ReadingAboveUpperCriticalThreshold"
+	msgReadingBelowLowerWarning  = "ReadingBelowLowerWarningThreshold"
+	msgReadingBelowLowerCritical = "ReadingBelowLowerCriticalThreshold"
+	msgReadingNormal             = "ReadingReturnedToNormal"
+	msgSensorAlert               = "SensorAlert"
+	msgTestEvent                 = "TestEvent"
+)
+
+// registryMessage is a message definition of a Redfish message registry.
+type registryMessage struct {
+	Description  string   `json:"Description"`
+	Message      string   `json:"Message"`
+	Severity     string   `json:"MessageSeverity"`
+	NumberOfArgs int      `json:"NumberOfArgs"`
+	ParamTypes   []string `json:"ParamTypes,omitempty"`
+	Resolution   string   `json:"Resolution"`
+}
+
+// eventRegistryMessages returns the messages of the built-in registry that
+// events emitted by the event service refer to.
+func eventRegistryMessages() map[string]registryMessage {
+	return map[string]registryMessage{
+		msgResourceStateChanged: {
+			Description:  "Indicates that the state of a resource changed.",
+			Message:      "The state of resource '%1' changed to '%2'.",
+			Severity:     "OK",
+			NumberOfArgs: 2,
+			ParamTypes:   []string{"string", "string"},
+			Resolution:   "None.",
+		},
+		msgResourceStateChangeFailed: {
+			Description:  "Indicates that a state transition of a resource failed.",
+			Message:      "The transition '%1' of resource '%2' failed; the resource is in state '%3'.",
+			Severity:     "Warning",
+			NumberOfArgs: 3,
+			ParamTypes:   []string{"string", "string", "string"},
+			Resolution:   "Check the resource and retry the operation.",
+		},
+		msgReadingAboveUpperWarning: {
+			Description:  "Indicates that a sensor reading is above the upper warning threshold.",
+			Message:      "Sensor '%1' reading of %2 is above the upper warning threshold of %3.",
+			Severity:     "Warning",
+			NumberOfArgs: 3,
+			ParamTypes:   []string{"string", "number", "number"},
+			Resolution:   "Check the condition of the resource that reported the reading.",
+		},
+		msgReadingAboveUpperCritical: {
+			Description:  "Indicates that a sensor reading is above the upper critical threshold.",
+			Message:      "Sensor '%1' reading of %2 is above the upper critical threshold of %3.",
+			Severity:     "Critical",
+			NumberOfArgs: 3,
+			ParamTypes:   []string{"string", "number", "number"},
+			Resolution:   "Check the condition of the resource that reported the reading.",
+		},
+		msgReadingBelowLowerWarning: {
+			Description:  "Indicates that a sensor reading is below the lower warning threshold.",
+			Message:      "Sensor '%1' reading of %2 is below the lower warning threshold of %3.",
+			Severity:     "Warning",
+			NumberOfArgs: 3,
+			ParamTypes:   []string{"string", "number", "number"},
+			Resolution:   "Check the condition of the resource that reported the reading.",
+		},
+		msgReadingBelowLowerCritical: {
+			Description:  "Indicates that a sensor reading is below the lower critical threshold.",
+			Message:      "Sensor '%1' reading of %2 is below the lower critical threshold of %3.",
+			Severity:     "Critical",
+			NumberOfArgs: 3,
+			ParamTypes:   []string{"string", "number", "number"},
+			Resolution:   "Check the condition of the resource that reported the reading.",
+		},
+		msgReadingNormal: {
+			Description:  "Indicates that a sensor reading is no longer beyond a threshold.",
+			Message:      "Sensor '%1' reading is no longer beyond the %2 threshold.",
+			Severity:     "OK",
+			NumberOfArgs: 2,
+			ParamTypes:   []string{"string", "string"},
+			Resolution:   "None.",
+		},
+		msgSensorAlert: {
+			Description:  "Indicates that a sensor raised an alert.",
+			Message:      "Sensor '%1' raised an alert: %2",
+			Severity:     "Warning",
+			NumberOfArgs: 2,
+			ParamTypes:   []string{"string", "string"},
+			Resolution:   "Check the condition of the resource that raised the alert.",
+		},
+		msgTestEvent: {
+			Description:  "Indicates that a test event was submitted.",
+			Message:      "Test event: %1",
+			Severity:     "OK",
+			NumberOfArgs: 1,
+			ParamTypes:   []string{"string"},
+			Resolution:   "None.",
+		},
+	}
+}
+
+// formatMessage substitutes the arguments of a registry message.
+func formatMessage(message string, args []string) string {
+	// Substitute in reverse so %1 does not match the prefix of %10.
+	for i := len(args); i > 0; i-- {
+		message = strings.ReplaceAll(message, "%"+strconv.Itoa(i), args[i-1])
+	}
+	return message
+}
+
+// registryLocation is the location of a message registry file.
+type registryLocation struct {
+	Language string `json:"Language"`
+	URI      string `json:"Uri"`
+}
+
+// messageRegistryFile is the Redfish MessageRegistryFile resource.
+type messageRegistryFile struct {
+	odataHeader
+	ID        string             `json:"Id"`
+	Name      string             `json:"Name"`
+	Languages []string           `json:"Languages"`
+	Registry  string             `json:"Registry"`
+	Location  []registryLocation `json:"Location"`
+}
+
+// messageRegistry is the Redfish MessageRegistry resource.
+type messageRegistry struct {
+	odataHeader
+	ID              string                     `json:"Id"`
+	Name            string                     `json:"Name"`
+	Language        string                     `json:"Language"`
+	Description     string                     `json:"Description"`
+	RegistryPrefix  string                     `json:"RegistryPrefix"`
+	RegistryVersion string                     `json:"RegistryVersion"`
+	OwningEntity    string                     `json:"OwningEntity"`
+	Messages        map[string]registryMessage `json:"Messages"`
+}
+
+// builtinRegistry is a message registry served under /redfish/v1/Registries.
+type builtinRegistry struct {
+	id           string
+	name         string
+	description  string
+	owningEntity string
+	messages     func() map[string]registryMessage
+}
+
+// prefix returns the registry prefix of the registry.
+func (b *builtinRegistry) prefix() string {
+	prefix, _, _ := strings.Cut(b.id, ".")
+	return prefix
+}
+
+// version returns the registry version of the registry.
+func (b *builtinRegistry) version() string {
+	_, version, _ := strings.Cut(b.id, ".")
+	return version
+}
+
+// builtinRegistries returns the message registries the messages emitted by
+// the service refer to.
+func builtinRegistries() []builtinRegistry {
+	return []builtinRegistry{
+		{
+			id:           redfishBaseRegistry,
+			name:         "Base Message Registry",
+			description:  "This registry defines the base messages for Redfish.",
+			owningEntity: dmtfOwningEntity,
+			messages:     baseRegistryMessages,
+		},
+		{
+			id:           resourceEventRegistry,
+			name:         "Resource Event Message Registry",
+			description:  "This registry defines the messages to use for resource events.",
+			owningEntity: dmtfOwningEntity,
+			messages:     resourceEventRegistryMessages,
+		},
+		{
+			id:           taskEventRegistry,
+			name:         "Task Event Message Registry",
+			description:  "This registry defines the messages for task related events.",
+			owningEntity: dmtfOwningEntity,
+			messages:     taskEventRegistryMessages,
+		},
+		{
+			id:           eventRegistryID,
+			name:         "u-bmc Event Message Registry",
+			description:  "This registry defines the messages of events emitted by u-bmc.",
+			owningEntity: "u-bmc",
+			messages:     eventRegistryMessages,
+		},
+	}
+}
+
+func (s *redfishServer) registerRegistries() {
+	s.handle(http.MethodGet, registriesPath, s.handleRegistries)
+	for _, reg := range builtinRegistries() {
+		path := registriesPath + "/" + reg.prefix()
+		s.handle(http.MethodGet, path, func(w http.ResponseWriter, r *http.Request) {
+			s.handleRegistryFile(w, r, &reg)
+		})
+		s.handle(http.MethodGet, path+"/"+reg.id, func(w http.ResponseWriter, r *http.Request) {
+			s.handleRegistry(w, r, &reg)
+		})
+	}
+}
+
+func (s *redfishServer) handleRegistries(w http.ResponseWriter, r *http.Request) {
+	registries := builtinRegistries()
+	ids := make([]string, 0, len(registries))
+	for i := range registries {
+		ids = append(ids, registries[i].prefix())
+	}
+	s.writeResource(w, r, newCollection(registriesPath, odataTypeMessageRegistryFileCollection,
+		"Registry File Collection", ids))
+}
+
+func (s *redfishServer) handleRegistryFile(w http.ResponseWriter, r *http.Request, reg *builtinRegistry) {
+	path := registriesPath + "/" + reg.prefix()
+	s.writeResource(w, r, &messageRegistryFile{
+		odataHeader: odataHeader{ODataID: path, ODataType: odataTypeMessageRegistryFile},
+		ID:          reg.prefix(),
+		Name:        reg.name + " File",
+		Languages:   []string{"en"},
+		Registry:    reg.id,
+		Location:    []registryLocation{{Language: "en", URI: path + "/" + reg.id}},
+	})
+}
+
+func (s *redfishServer) handleRegistry(w http.ResponseWriter, r *http.Request, reg *builtinRegistry) {
+	s.writeResource(w, r, &messageRegistry{
+		odataHeader:     odataHeader{ODataID: registriesPath + "/" + reg.prefix() + "/" + reg.id, ODataType: odataTypeMessageRegistry},
+		ID:              reg.id,
+		Name:            reg.name,
+		Language:        "en",
+		Description:     reg.description,
+		RegistryPrefix:  reg.prefix(),
+		RegistryVersion: reg.version(),
+		OwningEntity:    reg.owningEntity,
+		Messages:        reg.messages(),
+	})
+}
@@ -0,0 +1,320 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package websrv
+
+import (
+	"cmp"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	schemav1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+	"github.com/u-bmc/u-bmc/pkg/ipc"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// Sensor resource types.
+const (
+	odataTypeSensorCollection = "#SensorCollection.SensorCollection"
+	odataTypeSensor           = "#Sensor.v1_9_0.Sensor"
+	sensorResourceName        = "Sensor"
+	unitCelsius               = "Cel"
+)
+
+// sensorResource is the Redfish Sensor resource.
+type sensorResource struct {
+	odataHeader
+	ID            string            `json:"Id"`
+	Name          string            `json:"Name"`
+	ReadingType   string            `json:"ReadingType,omitempty"`
+	ReadingUnits  string            `json:"ReadingUnits,omitempty"`
+	Reading       *float64          `json:"Reading"`
+	ReadingTime   string            `json:"ReadingTime,omitempty"`
+	PeakReading   *float64          `json:"PeakReading,omitempty"`
+	LowestReading *float64          `json:"LowestReading,omitempty"`
+	Thresholds    *sensorThresholds `json:"Thresholds,omitempty"`
+	Status        resourceStatus    `json:"Status"`
+}
+
+// sensorThresholds is the Thresholds property of a Sensor.
+type sensorThresholds struct {
+	UpperCaution  *sensorThreshold `json:"UpperCaution,omitempty"`
+	UpperCritical *sensorThreshold `json:"UpperCritical,omitempty"`
+	LowerCaution  *sensorThreshold `json:"LowerCaution,omitempty"`
+	LowerCritical *sensorThreshold `json:"LowerCritical,omitempty"`
+}
+
+// sensorThreshold is a single threshold of a Sensor.
+type sensorThreshold struct {
+	Reading float64 `json:"Reading"`
+}
+
+// sensorCollection is a SensorCollection whose members are embedded, so that
+// expanding it takes a single sensor list request.
+type sensorCollection struct {
+	odataHeader
+	Name         string            `json:"Name"`
+	Members      []*sensorResource `json:"Members"`
+	MembersCount int               `json:"Members@odata.count"`
+}
+
+// sensorFieldPaths maps the Sensor properties to the sensor fields they are
+// derived from. Properties holding readings also need the unit, as readings
+// are converted to the units mandated by Redfish.
+func sensorFieldPaths() map[string][]string {
+	return map[string][]string{
+		"Id":            {"id"},
+		"Name":          {"name"},
+		"ReadingType":   {"context"},
+		"ReadingUnits":  {"unit"},
+		"Reading":       {"analog_reading.value", "unit"},
+		"ReadingTime":   {"last_reading_timestamp"},
+		"PeakReading":   {"analog_reading.min_max_recorded", "unit"},
+		"LowestReading": {"analog_reading.min_max_recorded", "unit"},
+		"Thresholds":    {"analog_reading.upper_thresholds", "analog_reading.lower_thresholds", "unit"},
+		"Status":        {"status"},
+	}
+}
+
+// sensorFieldMask returns the sensor field mask for the $select properties
+// of q. The sensor ID and chassis location are always needed to link the
+// sensor.
+func sensorFieldMask(q *redfishQuery) *fieldmaskpb.FieldMask {
+	return selectFieldMask(q, sensorFieldPaths(), "id", "location.chassis_location")
+}
+
+// sensorReadingType maps a sensor context to the Redfish reading type.
+func sensorReadingType(c schemav1alpha1.SensorContext) string {
+	switch c {
+	case schemav1alpha1.SensorContext_SENSOR_CONTEXT_TEMPERATURE:
+		return "Temperature"
+	case schemav1alpha1.SensorContext_SENSOR_CONTEXT_VOLTAGE:
+		return "Voltage"
+	case schemav1alpha1.SensorContext_SENSOR_CONTEXT_CURRENT:
+		return "Current"
+	case schemav1alpha1.SensorContext_SENSOR_CONTEXT_TACH:
+		return "Rotational"
+	case schemav1alpha1.SensorContext_SENSOR_CONTEXT_POWER:
+		return "Power"
+	case schemav1alpha1.SensorContext_SENSOR_CONTEXT_ENERGY:
+		return "EnergyJoules"
+	case schemav1alpha1.SensorContext_SENSOR_CONTEXT_PRESSURE:
+		return "PressurePa"
+	case schemav1alpha1.SensorContext_SENSOR_CONTEXT_HUMIDITY:
+		return "Humidity"
+	case schemav1alpha1.SensorContext_SENSOR_CONTEXT_ALTITUDE:
+		return "Altitude"
+	case schemav1alpha1.SensorContext_SENSOR_CONTEXT_FLOW_RATE:
+		return "LiquidFlowLPM"
+	default:
+		return ""
+	}
+}
+
+// sensorReadingUnits maps a sensor unit to the UCUM unit reported by Redfish
+// and returns the conversion of readings into that unit. Temperatures are
+// always reported in degrees Celsius.
+func sensorReadingUnits(u schemav1alpha1.SensorUnit) (string, func(float64) float64) {
+	identity := func(v float64) float64 { return v }
+	switch u {
+	case schemav1alpha1.SensorUnit_SENSOR_UNIT_CELSIUS:
+		return unitCelsius, identity
+	case schemav1alpha1.SensorUnit_SENSOR_UNIT_FAHRENHEIT:
+		return unitCelsius, func(v float64) float64 { return (v - 32) * 5 / 9 }
+	case schemav1alpha1.SensorUnit_SENSOR_UNIT_KELVIN:
+		return unitCelsius, func(v float64) float64 { return v - 273.15 }
+	case schemav1alpha1.SensorUnit_SENSOR_UNIT_VOLTS:
+		return "V", identity
+	case schemav1alpha1.SensorUnit_SENSOR_UNIT_AMPS:
+		return "A", identity
+	case schemav1alpha1.SensorUnit_SENSOR_UNIT_WATTS:
+		return "W", identity
+	case schemav1alpha1.SensorUnit_SENSOR_UNIT_JOULES:
+		return "J", identity
+	case schemav1alpha1.SensorUnit_SENSOR_UNIT_PASCALS:
+		return "Pa", identity
+	case schemav1alpha1.SensorUnit_SENSOR_UNIT_PERCENT:
+		return "%", identity
+	case schemav1alpha1.SensorUnit_SENSOR_UNIT_RPM:
+		return "{rev}/min", identity
+	case schemav1alpha1.SensorUnit_SENSOR_UNIT_HERTZ:
+		return "Hz", identity
+	case schemav1alpha1.SensorUnit_SENSOR_UNIT_METERS:
+		return "m", identity
+	case schemav1alpha1.SensorUnit_SENSOR_UNIT_LITERS_PER_MINUTE:
+		return "L/min", identity
+	default:
+		return "", identity
+	}
+}
+
+// sensorStatus maps a sensor status to the Redfish status.
+func sensorStatus(status schemav1alpha1.SensorStatus) resourceStatus {
+	switch status {
+	case schemav1alpha1.SensorStatus_SENSOR_STATUS_ENABLED:
+		return resourceStatus{State: "Enabled", Health: "OK"}
+	case schemav1alpha1.SensorStatus_SENSOR_STATUS_DISABLED:
+		return resourceStatus{State: "Disabled"}
+	case schemav1alpha1.SensorStatus_SENSOR_STATUS_NOT_PRESENT:
+		return resourceStatus{State: "Absent"}
+	case schemav1alpha1.SensorStatus_SENSOR_STATUS_WARNING:
+		return resourceStatus{State: "Enabled", Health: "Warning"}
+	case schemav1alpha1.SensorStatus_SENSOR_STATUS_CRITICAL:
+		return resourceStatus{State: "Enabled", Health: "Critical"}
+	case schemav1alpha1.SensorStatus_SENSOR_STATUS_ERROR:
+		return resourceStatus{State: "UnavailableOffline", Health: "Critical"}
+	default:
+		return resourceStatus{State: "Enabled"}
+	}
+}
+
+// newSensorThreshold converts an optional threshold value.
+func newSensorThreshold(v *float64, convert func(float64) float64) *sensorThreshold {
+	if v == nil {
+		return nil
+	}
+	return &sensorThreshold{Reading: convert(*v)}
+}
+
+// newSensor creates the Sensor resource of a sensor in the collection at path.
+func newSensor(path string, sensor *schemav1alpha1.Sensor) *sensorResource {
+	res := &sensorResource{
+		odataHeader: odataHeader{ODataID: path + "/" + url.PathEscape(sensor.GetId()), ODataType: odataTypeSensor},
+		ID:          sensor.GetId(),
+		Name:        sensor.GetName(),
+		ReadingType: sensorReadingType(sensor.GetContext()),
+		Status:      sensorStatus(sensor.GetStatus()),
+	}
+	if ts := sensor.GetLastReadingTimestamp(); ts != nil {
+		res.ReadingTime = ts.AsTime().UTC().Format(time.RFC3339)
+	}
+
+	units, convert := sensorReadingUnits(sensor.GetUnit())
+	res.ReadingUnits = units
+
+	// Discrete sensors have no numeric reading and report it as null.
+	analog := sensor.GetAnalogReading()
+	if analog == nil {
+		return res
+	}
+	reading := convert(analog.GetValue())
+	res.Reading = &reading
+	if mm := analog.GetMinMaxRecorded(); mm != nil {
+		peak, lowest := convert(mm.GetMaxValue()), convert(mm.GetMinValue())
+		res.PeakReading, res.LowestReading = &peak, &lowest
+	}
+	upper, lower := analog.GetUpperThresholds(), analog.GetLowerThresholds()
+	if upper != nil || lower != nil {
+		res.Thresholds = &sensorThresholds{}
+	}
+	if upper != nil {
+		res.Thresholds.UpperCaution = newSensorThreshold(upper.Warning, convert)
+		res.Thresholds.UpperCritical = newSensorThreshold(upper.Critical, convert)
+	}
+	if lower != nil {
+		res.Thresholds.LowerCaution = newSensorThreshold(lower.Warning, convert)
+		res.Thresholds.LowerCritical = newSensorThreshold(lower.Critical, convert)
+	}
+
+	return res
+}
+
+func (s *redfishServer) registerSensors() {
+	s.handle(http.MethodGet, chassisPath+"/{id}/Sensors", s.handleSensors)
+	s.handle(http.MethodGet, chassisPath+"/{id}/Sensors/{sensorId}", s.handleSensor)
+}
+
+// chassisSensors lists the sensors of a chassis sorted by ID, trimmed to the
+// fields of mask. Failures are reported as errors about the resource of the
+// given type and ID.
+func (s *redfishServer) chassisSensors(w http.ResponseWriter, r *http.Request, scope chassisScope, mask *fieldmaskpb.FieldMask, resourceType, id string) ([]*schemav1alpha1.Sensor, bool) {
+	var resp schemav1alpha1.ListSensorsResponse
+	if err := s.requestNATS(r.Context(), ipc.SubjectSensorList, &schemav1alpha1.ListSensorsRequest{
+		FieldMask: mask,
+	}, &resp); err != nil {
+		s.writeRequestError(w, r, err, resourceType, id)
+		return nil, false
+	}
+
+	sensors := slices.DeleteFunc(resp.GetSensor(), func(sensor *schemav1alpha1.Sensor) bool {
+		return !scope.contains(sensor.GetLocation())
+	})
+	slices.SortFunc(sensors, func(a, b *schemav1alpha1.Sensor) int {
+		return cmp.Compare(a.GetId(), b.GetId())
+	})
+	return sensors, true
+}
+
+// contextSensors returns the Sensor resources of the sensors with the given
+// context, linked to the sensor collection at path.
+func contextSensors(path string, sensors []*schemav1alpha1.Sensor, c schemav1alpha1.SensorContext) []*sensorResource {
+	res := make([]*sensorResource, 0, len(sensors))
+	for _, sensor := range sensors {
+		if sensor.GetContext() == c {
+			res = append(res, newSensor(path, sensor))
+		}
+	}
+	return res
+}
+
+// handleSensors serves the sensors of a chassis. When the members are
+// needed to expand or filter the collection, they are embedded from the same
+// list request, trimmed to the fields the $select properties need.
+func (s *redfishServer) handleSensors(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	scope, ok := s.chassisScope(w, r, id)
+	if !ok {
+		return
+	}
+
+	q := requestQuery(r)
+	sensors, ok := s.chassisSensors(w, r, scope, sensorFieldMask(q), "SensorCollection", "Sensors")
+	if !ok {
+		return
+	}
+
+	path := chassisPath + "/" + url.PathEscape(id) + "/Sensors"
+	if q == nil || (q.filter == nil && (q.expand == "" || q.expand == expandLinks)) {
+		ids := make([]string, 0, len(sensors))
+		for _, sensor := range sensors {
+			ids = append(ids, sensor.GetId())
+		}
+		s.writeResource(w, r, newCollection(path, odataTypeSensorCollection, "Sensor Collection", ids))
+		return
+	}
+
+	members := make([]*sensorResource, 0, len(sensors))
+	for _, sensor := range sensors {
+		members = append(members, newSensor(path, sensor))
+	}
+	s.writeResource(w, r, &sensorCollection{
+		odataHeader:  odataHeader{ODataID: path, ODataType: odataTypeSensorCollection},
+		Name:         "Sensor Collection",
+		Members:      members,
+		MembersCount: len(members),
+	})
+}
+
+func (s *redfishServer) handleSensor(w http.ResponseWriter, r *http.Request) {
+	id, sensorID := r.PathValue("id"), r.PathValue("sensorId")
+	scope, ok := s.chassisScope(w, r, id)
+	if !ok {
+		return
+	}
+
+	var resp schemav1alpha1.GetSensorResponse
+	err := s.requestNATS(r.Context(), ipc.SubjectSensorInfo, &schemav1alpha1.GetSensorRequest{
+		Identifier: &schemav1alpha1.GetSensorRequest_Id{Id: sensorID},
+		FieldMask:  sensorFieldMask(requestQuery(r)),
+	}, &resp)
+	if err == nil && (len(resp.GetSensors()) == 0 || !scope.contains(resp.GetSensors()[0].GetLocation())) {
+		err = ErrNotFound
+	}
+	if err != nil {
+		s.writeRequestError(w, r, err, sensorResourceName, sensorID)
+		return
+	}
+
+	s.writeResource(w, r, newSensor(chassisPath+"/"+url.PathEscape(id)+"/Sensors", resp.GetSensors()[0]))
+}
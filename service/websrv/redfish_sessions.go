@@ -0,0 +1,222 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package websrv
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// SessionService resource types.
+const (
+	odataTypeSessionService    = "#SessionService.v1_1_9.SessionService"
+	odataTypeSessionCollection = "#SessionCollection.SessionCollection"
+	odataTypeSession           = "#Session.v1_7_1.Session"
+	sessionServicePath         = redfishRoot + "/SessionService"
+	sessionsPath               = sessionServicePath + "/Sessions"
+	sessionResourceName        = "Session"
+	propertyToken              = "Token"
+	propertySessionTimeout     = "SessionTimeout"
+	minSessionTimeout          = 30
+	maxSessionTimeout          = 86400
+)
+
+// sessionService is the Redfish SessionService resource.
+type sessionService struct {
+	odataHeader
+	ID             string         `json:"Id"`
+	Name           string         `json:"Name"`
+	ServiceEnabled bool           `json:"ServiceEnabled"`
+	SessionTimeout int64          `json:"SessionTimeout"`
+	Sessions       odataLink      `json:"Sessions"`
+	Status         resourceStatus `json:"Status"`
+}
+
+// sessionResource is the Redfish Session resource.
+type sessionResource struct {
+	odataHeader
+	ID                    string  `json:"Id"`
+	Name                  string  `json:"Name"`
+	UserName              string  `json:"UserName"`
+	Password              *string `json:"Password"`
+	Token                 *string `json:"Token"`
+	SessionType           string  `json:"SessionType"`
+	CreatedTime           string  `json:"CreatedTime"`
+	ClientOriginIPAddress string  `json:"ClientOriginIPAddress,omitempty"`
+}
+
+// loginRequest is the body of a session creation request. Token carries the
+// TOTP code or recovery code of accounts with a second factor.
+type loginRequest struct {
+	UserName string `json:"UserName"`
+	Password string `json:"Password"`
+	Token    string `json:"Token"`
+}
+
+func newSessionResource(sess *redfishSession) *sessionResource {
+	sessionType := sess.sessionType
+	if sessionType == "" {
+		sessionType = sessionTypeRedfish
+	}
+	return &sessionResource{
+		odataHeader:           odataHeader{ODataID: sessionsPath + "/" + sess.id, ODataType: odataTypeSession},
+		ID:                    sess.id,
+		Name:                  "User Session",
+		UserName:              sess.username,
+		SessionType:           sessionType,
+		CreatedTime:           sess.created.UTC().Format(time.RFC3339),
+		ClientOriginIPAddress: sess.clientAddr,
+	}
+}
+
+func (s *redfishServer) registerSessionService() {
+	s.handle(http.MethodGet, sessionServicePath, s.handleSessionService)
+	s.handlePrivileged(http.MethodPatch, sessionServicePath, privilegeConfigureManager, s.handlePatchSessionService)
+	s.handle(http.MethodGet, sessionsPath, s.handleSessions)
+	s.handlePrivileged(http.MethodPost, sessionsPath, privilegeNone, s.handleCreateSession)
+	s.handle(http.MethodGet, sessionsPath+"/{id}", s.handleSession)
+	s.handlePrivileged(http.MethodDelete, sessionsPath+"/{id}", privilegeLogin, s.handleDeleteSession)
+}
+
+func (s *redfishServer) handleSessionService(w http.ResponseWriter, r *http.Request) {
+	s.writeResource(w, r, &sessionService{
+		odataHeader:    odataHeader{ODataID: sessionServicePath, ODataType: odataTypeSessionService},
+		ID:             "SessionService",
+		Name:           "Session Service",
+		ServiceEnabled: true,
+		SessionTimeout: int64(s.sessions.idleTimeout() / time.Second),
+		Sessions:       link(sessionsPath),
+		Status:         resourceStatus{State: "Enabled", Health: "OK"},
+	})
+}
+
+func (s *redfishServer) handlePatchSessionService(w http.ResponseWriter, r *http.Request) {
+	props, ok := s.readProperties(w, r, []string{propertySessionTimeout},
+		[]string{"Id", "Name", "ServiceEnabled", "Sessions", "Status"})
+	if !ok {
+		return
+	}
+
+	if _, ok := props[propertySessionTimeout]; ok {
+		var timeout int64
+		if !s.decodeProperty(w, props, propertySessionTimeout, &timeout) {
+			return
+		}
+		if timeout < minSessionTimeout || timeout > maxSessionTimeout {
+			s.writeOutOfRange(w, propertySessionTimeout, timeout)
+			return
+		}
+		s.sessions.setIdleTimeout(time.Duration(timeout) * time.Second)
+	}
+
+	s.handleSessionService(w, r)
+}
+
+func (s *redfishServer) handleSessions(w http.ResponseWriter, r *http.Request) {
+	sess := requestSession(r)
+	s.writeResource(w, r, newCollection(sessionsPath, odataTypeSessionCollection, "Session Collection",
+		s.sessions.ids(sess, sess.hasPrivilege(privilegeConfigureManager))))
+}
+
+func (s *redfishServer) handleCreateSession(w http.ResponseWriter, r *http.Request) {
+	props, ok := s.readProperties(w, r, []string{propertyUserName, propertyPassword, propertyToken},
+		[]string{"Id", "Name", "SessionType", "CreatedTime", "ClientOriginIPAddress"})
+	if !ok {
+		return
+	}
+
+	var req loginRequest
+	for _, name := range []string{propertyUserName, propertyPassword} {
+		if _, ok := props[name]; !ok {
+			s.writeError(w, http.StatusBadRequest, "PropertyMissing",
+				fmt.Sprintf("The property %s is a required property and must be included in the request.", name), name)
+			return
+		}
+	}
+	if !s.decodeProperty(w, props, propertyUserName, &req.UserName) || !s.decodeProperty(w, props, propertyPassword, &req.Password) ||
+		!s.decodeProperty(w, props, propertyToken, &req.Token) {
+		return
+	}
+
+	// Unlike Basic auth, session logins can be retried with a second factor.
+	sess, err := s.login(r.Context(), r, req.UserName, req.Password, &req.Token)
+	if err == nil {
+		err = s.sessions.add(sess)
+	}
+	switch {
+	case err == nil:
+	case errors.Is(err, ErrAuthenticationFailed), errors.Is(err, ErrNotFound):
+		s.logger.WarnContext(r.Context(), "Redfish login failed", "user", req.UserName, "error", err)
+		reason := "Invalid username or password"
+		if errors.Is(err, ErrSecondFactorRequired) {
+			reason = "A second factor is required in the Token property"
+		}
+		s.writeError(w, http.StatusUnauthorized, "ResourceAtURIUnauthorized",
+			fmt.Sprintf("While accessing the resource at '%s', the service received an authorization error '%s'.",
+				sessionsPath, reason), sessionsPath, reason)
+		return
+	case errors.Is(err, ErrTooManySessions):
+		s.writeError(w, http.StatusServiceUnavailable, "SessionLimitExceeded",
+			"The session establishment failed due to the number of simultaneous sessions exceeding the limit of the implementation.")
+		return
+	default:
+		s.writeRequestError(w, r, err, accountResourceName, req.UserName)
+		return
+	}
+
+	s.logger.InfoContext(r.Context(), "Redfish session created",
+		"session", sess.id,
+		"user", sess.username,
+		"client", sess.clientAddr)
+
+	res := newSessionResource(sess)
+	w.Header().Set(authTokenHeader, sess.token)
+	w.Header().Set("Location", res.ODataID)
+	w.Header().Set("Content-Type", contentTypeJSON)
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+// sessionOf returns the session with the given ID if the session of r may
+// access it, which requires it to belong to the same account or the
+// ConfigureManager privilege. Otherwise it writes an error response.
+func (s *redfishServer) sessionOf(w http.ResponseWriter, r *http.Request, id string) (redfishSession, bool) {
+	sess, ok := s.sessions.get(id)
+	if !ok {
+		s.writeRequestError(w, r, ErrNotFound, sessionResourceName, id)
+		return redfishSession{}, false
+	}
+	if viewer := requestSession(r); sess.owner() != viewer.owner() && !viewer.hasPrivilege(privilegeConfigureManager) {
+		s.writeForbidden(w)
+		return redfishSession{}, false
+	}
+	return sess, true
+}
+
+func (s *redfishServer) handleSession(w http.ResponseWriter, r *http.Request) {
+	sess, ok := s.sessionOf(w, r, r.PathValue("id"))
+	if !ok {
+		return
+	}
+	s.writeResource(w, r, newSessionResource(&sess))
+}
+
+func (s *redfishServer) handleDeleteSession(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	sess, ok := s.sessionOf(w, r, id)
+	if !ok {
+		return
+	}
+	if err := s.sessions.remove(id); err != nil {
+		s.writeRequestError(w, r, err, sessionResourceName, id)
+		return
+	}
+
+	s.logger.InfoContext(r.Context(), "Redfish session deleted", "session", id, "user", sess.username)
+
+	w.WriteHeader(http.StatusNoContent)
+}
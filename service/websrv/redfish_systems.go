@@ -0,0 +1,212 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package websrv
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+
+	schemav1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+	"github.com/u-bmc/u-bmc/pkg/ipc"
+)
+
+// ComputerSystem resource types.
+const (
+	odataTypeComputerSystemCollection = "#ComputerSystemCollection.ComputerSystemCollection"
+	odataTypeComputerSystem           = "#ComputerSystem.v1_22_0.ComputerSystem"
+	systemsPath                       = redfishRoot + "/Systems"
+	actionComputerSystemReset         = "ComputerSystem.Reset"
+)
+
+// computerSystem is the Redfish ComputerSystem resource.
+type computerSystem struct {
+	odataHeader
+	ID           string         `json:"Id"`
+	Name         string         `json:"Name"`
+	Description  string         `json:"Description,omitempty"`
+	SystemType   string         `json:"SystemType"`
+	Manufacturer string         `json:"Manufacturer,omitempty"`
+	Model        string         `json:"Model,omitempty"`
+	SerialNumber string         `json:"SerialNumber,omitempty"`
+	PartNumber   string         `json:"PartNumber,omitempty"`
+	SKU          string         `json:"SKU,omitempty"`
+	AssetTag     string         `json:"AssetTag,omitempty"`
+	UUID         string         `json:"UUID,omitempty"`
+	BiosVersion  string         `json:"BiosVersion,omitempty"`
+	PowerState   string         `json:"PowerState,omitempty"`
+	Status       resourceStatus `json:"Status"`
+	LogServices  odataLink      `json:"LogServices"`
+	Links        struct {
+		Chassis   []odataLink `json:"Chassis"`
+		ManagedBy []odataLink `json:"ManagedBy"`
+	} `json:"Links"`
+	Actions struct {
+		Reset resetAction `json:"#ComputerSystem.Reset"`
+	} `json:"Actions"`
+}
+
+// hostResetTypes returns the ComputerSystem reset types in the order they are
+// advertised.
+func hostResetTypes() []string {
+	return []string{"On", "ForceOff", "GracefulShutdown", "GracefulRestart", "ForceRestart"}
+}
+
+// hostResetAction maps a ComputerSystem reset type to a host action.
+func hostResetAction(resetType string) schemav1alpha1.HostAction {
+	switch resetType {
+	case "On":
+		return schemav1alpha1.HostAction_HOST_ACTION_ON
+	case "ForceOff":
+		return schemav1alpha1.HostAction_HOST_ACTION_FORCE_OFF
+	case "GracefulShutdown":
+		return schemav1alpha1.HostAction_HOST_ACTION_OFF
+	case "GracefulRestart":
+		return schemav1alpha1.HostAction_HOST_ACTION_REBOOT
+	case "ForceRestart":
+		return schemav1alpha1.HostAction_HOST_ACTION_FORCE_RESTART
+	default:
+		return schemav1alpha1.HostAction_HOST_ACTION_UNSPECIFIED
+	}
+}
+
+// hostPowerState maps a host status to the Redfish power state and status.
+func hostPowerState(status schemav1alpha1.HostStatus) (string, resourceStatus) {
+	switch status {
+	case schemav1alpha1.HostStatus_HOST_STATUS_ON, schemav1alpha1.HostStatus_HOST_STATUS_DIAGNOSTIC:
+		return "On", resourceStatus{State: "Enabled", Health: "OK"}
+	case schemav1alpha1.HostStatus_HOST_STATUS_OFF:
+		return "Off", resourceStatus{State: "StandbyOffline", Health: "OK"}
+	case schemav1alpha1.HostStatus_HOST_STATUS_QUIESCED:
+		return "Paused", resourceStatus{State: "Quiesced", Health: "OK"}
+	case schemav1alpha1.HostStatus_HOST_STATUS_TRANSITIONING:
+		return "", resourceStatus{State: "Starting", Health: "OK"}
+	case schemav1alpha1.HostStatus_HOST_STATUS_ERROR:
+		return "", resourceStatus{State: "Enabled", Health: "Critical"}
+	default:
+		return "", resourceStatus{State: "Absent"}
+	}
+}
+
+func (s *redfishServer) registerSystems() {
+	s.handle(http.MethodGet, systemsPath, s.handleSystems)
+	s.handle(http.MethodGet, systemsPath+"/{id}", s.handleSystem)
+	s.handle(http.MethodPost, systemsPath+"/{id}/Actions/"+actionComputerSystemReset, s.handleSystemReset)
+}
+
+func (s *redfishServer) handleSystems(w http.ResponseWriter, r *http.Request) {
+	var resp schemav1alpha1.ListHostsResponse
+	if err := s.requestNATS(r.Context(), ipc.SubjectHostList, &schemav1alpha1.ListHostsRequest{}, &resp); err != nil {
+		s.writeRequestError(w, r, err, "ComputerSystemCollection", "Systems")
+		return
+	}
+
+	ids := make([]string, 0, len(resp.GetHosts()))
+	for _, host := range resp.GetHosts() {
+		ids = append(ids, host.GetName())
+	}
+	ids = append(ids, s.aggregator.memberIDs(systemsPath)...)
+
+	s.writeResource(w, r, newCollection(systemsPath, odataTypeComputerSystemCollection, "Computer System Collection", ids))
+}
+
+func (s *redfishServer) handleSystem(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	var resp schemav1alpha1.GetHostResponse
+	err := s.requestNATS(r.Context(), ipc.SubjectHostState, &schemav1alpha1.GetHostRequest{
+		Identifier: &schemav1alpha1.GetHostRequest_Name{Name: id},
+	}, &resp)
+	if err == nil && len(resp.GetHosts()) == 0 {
+		err = ErrNotFound
+	}
+	if err != nil {
+		s.writeRequestError(w, r, err, "ComputerSystem", id)
+		return
+	}
+	host := resp.GetHosts()[0]
+
+	path := systemsPath + "/" + url.PathEscape(id)
+	system := &computerSystem{
+		odataHeader: odataHeader{ODataID: path, ODataType: odataTypeComputerSystem},
+		ID:          id,
+		Name:        id,
+		Description: host.GetDescription(),
+		SystemType:  "Physical",
+		BiosVersion: host.GetFirmware().GetVersion(),
+		LogServices: link(path + "/LogServices"),
+	}
+	if asset := host.GetAsset(); asset != nil {
+		system.Manufacturer = asset.GetManufacturer()
+		system.Model = asset.GetProductName()
+		system.SerialNumber = asset.GetSerialNumber()
+		system.PartNumber = asset.GetPartNumber()
+		system.SKU = asset.GetSku()
+		system.AssetTag = asset.GetAssetTag()
+		system.UUID = asset.GetUuid()
+	}
+	system.PowerState, system.Status = hostPowerState(host.GetStatus())
+	system.Actions.Reset = resetAction{
+		Target:     path + "/Actions/" + actionComputerSystemReset,
+		ResetTypes: hostResetTypes(),
+	}
+
+	chassis, managers, err := s.topology(r)
+	if err != nil {
+		s.writeRequestError(w, r, err, "ComputerSystem", id)
+		return
+	}
+	system.Links.Chassis = make([]odataLink, 0, len(chassis))
+	for _, c := range chassis {
+		if len(c.GetHostNames()) == 0 || slices.Contains(c.GetHostNames(), id) {
+			system.Links.Chassis = append(system.Links.Chassis, link(chassisPath+"/"+url.PathEscape(c.GetName())))
+		}
+	}
+	system.Links.ManagedBy = make([]odataLink, 0, len(managers))
+	for _, m := range managers {
+		system.Links.ManagedBy = append(system.Links.ManagedBy, link(managersPath+"/"+url.PathEscape(m.GetName())))
+	}
+
+	s.writeResource(w, r, system)
+}
+
+func (s *redfishServer) handleSystemReset(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	resetType, ok := s.readResetType(w, r, actionComputerSystemReset, hostResetTypes())
+	if !ok {
+		return
+	}
+
+	var resp schemav1alpha1.ChangeHostStateResponse
+	if err := s.requestNATS(r.Context(), ipc.SubjectHostControl, &schemav1alpha1.ChangeHostStateRequest{
+		HostName: id,
+		Action:   hostResetAction(resetType),
+	}, &resp); err != nil {
+		s.writeRequestError(w, r, err, "ComputerSystem", id)
+		return
+	}
+
+	s.logger.InfoContext(r.Context(), "Redfish system reset",
+		"system", id,
+		"reset_type", resetType,
+		"status", resp.GetCurrentStatus().String())
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// topology returns the chassis and management controllers the Redfish
+// resources link to each other.
+func (s *redfishServer) topology(r *http.Request) ([]*schemav1alpha1.Chassis, []*schemav1alpha1.ManagementController, error) {
+	var chassis schemav1alpha1.ListChassisResponse
+	if err := s.requestNATS(r.Context(), ipc.SubjectChassisList, &schemav1alpha1.ListChassisRequest{}, &chassis); err != nil {
+		return nil, nil, err
+	}
+
+	var managers schemav1alpha1.ListManagementControllersResponse
+	if err := s.requestNATS(r.Context(), ipc.SubjectBMCList, &schemav1alpha1.ListManagementControllersRequest{}, &managers); err != nil {
+		return nil, nil, err
+	}
+
+	return chassis.GetChassis(), managers.GetControllers(), nil
+}
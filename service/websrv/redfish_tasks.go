@@ -0,0 +1,233 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package websrv
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/u-bmc/u-bmc/pkg/ipc"
+)
+
+// TaskService resource types.
+const (
+	odataTypeTaskService    = "#TaskService.v1_2_1.TaskService"
+	odataTypeTaskCollection = "#TaskCollection.TaskCollection"
+	odataTypeTask           = "#Task.v1_7_4.Task"
+	taskServicePath         = redfishRoot + "/TaskService"
+	tasksPath               = taskServicePath + "/Tasks"
+	taskMonitorsPath        = taskServicePath + "/TaskMonitors"
+	taskResourceName        = "Task"
+	taskEventRegistry       = "TaskEvent.1.0.3"
+	// taskRetryAfter is the polling interval in seconds suggested to clients
+	// of a task monitor.
+	taskRetryAfter = 5
+)
+
+// Update job states reported by the update manager.
+const (
+	updateJobIdle     = "idle"
+	updateJobComplete = "complete"
+	updateJobFailed   = "failed"
+)
+
+// updateStartRequest mirrors the update manager request starting a job.
+type updateStartRequest struct {
+	ImagePath string   `json:"image_path,omitempty"`
+	ImageURI  string   `json:"image_uri,omitempty"`
+	Username  string   `json:"username,omitempty"`
+	Password  string   `json:"password,omitempty"`
+	Targets   []string `json:"targets,omitempty"`
+	ApplyTime string   `json:"apply_time,omitempty"`
+}
+
+// updateInfoRequest mirrors the update manager request for a single job.
+type updateInfoRequest struct {
+	ID string `json:"id"`
+}
+
+// updateListResponse mirrors the update manager list of jobs.
+type updateListResponse struct {
+	Jobs []updateJob `json:"jobs"`
+}
+
+// updateJob mirrors an update manager job.
+type updateJob struct {
+	ID              string     `json:"id"`
+	State           string     `json:"state"`
+	PercentComplete int        `json:"percent_complete"`
+	Targets         []string   `json:"targets"`
+	ImageURI        string     `json:"image_uri,omitempty"`
+	ApplyTime       string     `json:"apply_time"`
+	Message         string     `json:"message,omitempty"`
+	StartTime       time.Time  `json:"start_time"`
+	EndTime         *time.Time `json:"end_time,omitempty"`
+}
+
+// finished reports whether the job has ended.
+func (j *updateJob) finished() bool {
+	return j.State == updateJobComplete || j.State == updateJobFailed
+}
+
+// taskService is the Redfish TaskService resource.
+type taskService struct {
+	odataHeader
+	ID                              string         `json:"Id"`
+	Name                            string         `json:"Name"`
+	ServiceEnabled                  bool           `json:"ServiceEnabled"`
+	CompletedTaskOverWritePolicy    string         `json:"CompletedTaskOverWritePolicy"`
+	LifeCycleEventOnTaskStateChange bool           `json:"LifeCycleEventOnTaskStateChange"`
+	Tasks                           odataLink      `json:"Tasks"`
+	Status                          resourceStatus `json:"Status"`
+}
+
+// task is the Redfish Task resource of an update job.
+type task struct {
+	odataHeader
+	ID              string           `json:"Id"`
+	Name            string           `json:"Name"`
+	TaskState       string           `json:"TaskState"`
+	TaskStatus      string           `json:"TaskStatus"`
+	PercentComplete int              `json:"PercentComplete"`
+	StartTime       string           `json:"StartTime"`
+	EndTime         string           `json:"EndTime,omitempty"`
+	TaskMonitor     string           `json:"TaskMonitor"`
+	Messages        []redfishMessage `json:"Messages"`
+}
+
+// taskMessage creates a message of the TaskEvent registry about the task id.
+func taskMessage(messageID, message, severity string, args ...string) redfishMessage {
+	return redfishMessage{
+		ODataType:   redfishMessageType,
+		MessageID:   taskEventRegistry + "." + messageID,
+		Message:     message,
+		MessageArgs: args,
+		Severity:    severity,
+	}
+}
+
+// newTask creates the Task resource tracking an update job.
+func newTask(job *updateJob) *task {
+	id := url.PathEscape(job.ID)
+	t := &task{
+		odataHeader:     odataHeader{ODataID: tasksPath + "/" + id, ODataType: odataTypeTask},
+		ID:              job.ID,
+		Name:            "Firmware Update Task",
+		TaskState:       "Running",
+		TaskStatus:      "OK",
+		PercentComplete: job.PercentComplete,
+		StartTime:       job.StartTime.UTC().Format(time.RFC3339),
+		TaskMonitor:     taskMonitorsPath + "/" + id,
+	}
+	if job.EndTime != nil {
+		t.EndTime = job.EndTime.UTC().Format(time.RFC3339)
+	}
+
+	switch job.State {
+	case updateJobIdle:
+		t.TaskState = "New"
+		return t
+	case updateJobComplete:
+		t.TaskState = "Completed"
+	case updateJobFailed:
+		t.TaskState = "Exception"
+		t.TaskStatus = "Critical"
+	}
+
+	t.Messages = append(t.Messages, taskMessage("TaskStarted",
+		"The task with Id '"+job.ID+"' has started.", "OK", job.ID))
+
+	switch job.State {
+	case updateJobComplete:
+		t.Messages = append(t.Messages, taskMessage("TaskCompletedOK",
+			"The task with Id '"+job.ID+"' has completed.", "OK", job.ID))
+	case updateJobFailed:
+		msg := taskMessage("TaskAborted",
+			"The task with Id '"+job.ID+"' has been aborted.", "Critical", job.ID)
+		msg.Resolution = job.Message
+		t.Messages = append(t.Messages, msg)
+	default:
+		percent := strconv.Itoa(job.PercentComplete)
+		t.Messages = append(t.Messages, taskMessage("TaskProgressChanged",
+			"The task with Id '"+job.ID+"' has changed to progress "+percent+" percent complete.", "OK", job.ID, percent))
+	}
+
+	return t
+}
+
+func (s *redfishServer) registerTaskService() {
+	s.handle(http.MethodGet, taskServicePath, s.handleTaskService)
+	s.handle(http.MethodGet, tasksPath, s.handleTasks)
+	s.handle(http.MethodGet, tasksPath+"/{id}", s.handleTask)
+	s.handle(http.MethodGet, taskMonitorsPath+"/{id}", s.handleTaskMonitor)
+}
+
+func (s *redfishServer) handleTaskService(w http.ResponseWriter, r *http.Request) {
+	s.writeResource(w, r, &taskService{
+		odataHeader:                  odataHeader{ODataID: taskServicePath, ODataType: odataTypeTaskService},
+		ID:                           "TaskService",
+		Name:                         "Task Service",
+		ServiceEnabled:               true,
+		CompletedTaskOverWritePolicy: "Oldest",
+		Tasks:                        link(tasksPath),
+		Status:                       resourceStatus{State: "Enabled", Health: "OK"},
+	})
+}
+
+func (s *redfishServer) handleTasks(w http.ResponseWriter, r *http.Request) {
+	var resp updateListResponse
+	if err := s.requestJSON(r.Context(), ipc.SubjectUpdateList, struct{}{}, &resp); err != nil {
+		s.writeRequestError(w, r, err, "TaskCollection", "Tasks")
+		return
+	}
+
+	ids := make([]string, 0, len(resp.Jobs))
+	for _, job := range resp.Jobs {
+		ids = append(ids, job.ID)
+	}
+
+	s.writeResource(w, r, newCollection(tasksPath, odataTypeTaskCollection, "Task Collection", ids))
+}
+
+// updateJob fetches the update job with the given ID, writing an error
+// response if that fails.
+func (s *redfishServer) updateJob(w http.ResponseWriter, r *http.Request, id string) (*updateJob, bool) {
+	var job updateJob
+	if err := s.requestJSON(r.Context(), ipc.SubjectUpdateInfo, &updateInfoRequest{ID: id}, &job); err != nil {
+		s.writeRequestError(w, r, err, taskResourceName, id)
+		return nil, false
+	}
+	return &job, true
+}
+
+func (s *redfishServer) handleTask(w http.ResponseWriter, r *http.Request) {
+	job, ok := s.updateJob(w, r, r.PathValue("id"))
+	if !ok {
+		return
+	}
+	s.writeResource(w, r, newTask(job))
+}
+
+// handleTaskMonitor answers with 202 Accepted while the task runs and with
+// the completed Task afterwards.
+func (s *redfishServer) handleTaskMonitor(w http.ResponseWriter, r *http.Request) {
+	job, ok := s.updateJob(w, r, r.PathValue("id"))
+	if !ok {
+		return
+	}
+
+	res := newTask(job)
+	if job.finished() {
+		s.writeResource(w, r, res)
+		return
+	}
+
+	w.Header().Set("Location", res.TaskMonitor)
+	w.Header().Set("Retry-After", strconv.Itoa(taskRetryAfter))
+	w.Header().Set("Content-Type", contentTypeJSON)
+	w.WriteHeader(http.StatusAccepted)
+	_ = json.NewEncoder(w).Encode(res)
+}
@@ -0,0 +1,502 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package websrv
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	schemav1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+	"github.com/u-bmc/u-bmc/pkg/ipc"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// TelemetryService resource types.
+const (
+	odataTypeTelemetryService                 = "#TelemetryService.v1_3_4.TelemetryService"
+	odataTypeMetricDefinitionCollection       = "#MetricDefinitionCollection.MetricDefinitionCollection"
+	odataTypeMetricDefinition                 = "#MetricDefinition.v1_3_4.MetricDefinition"
+	odataTypeMetricReportDefinitionCollection = "#MetricReportDefinitionCollection.MetricReportDefinitionCollection"
+	odataTypeMetricReportDefinition           = "#MetricReportDefinition.v1_4_6.MetricReportDefinition"
+	odataTypeMetricReportCollection           = "#MetricReportCollection.MetricReportCollection"
+	telemetryServicePath                      = redfishRoot + "/TelemetryService"
+	metricDefinitionsPath                     = telemetryServicePath + "/MetricDefinitions"
+	metricReportDefinitionsPath               = telemetryServicePath + "/MetricReportDefinitions"
+	metricReportsPath                         = telemetryServicePath + "/MetricReports"
+	metricDefinitionResourceName              = "MetricDefinition"
+	metricReportDefinitionResourceName        = "MetricReportDefinition"
+	metricReportResourceName                  = "MetricReport"
+	propertyMetricReportDefinitionType        = "MetricReportDefinitionType"
+	propertyMetricReportDefinitionEnabled     = "MetricReportDefinitionEnabled"
+	propertySchedule                          = "Schedule"
+	propertyReportActions                     = "ReportActions"
+	propertyReportUpdates                     = "ReportUpdates"
+	propertyAppendLimit                       = "AppendLimit"
+	propertyMetrics                           = "Metrics"
+	maxMetricAppendLimit                      = 4096
+)
+
+// telemetryService is the Redfish TelemetryService resource.
+type telemetryService struct {
+	odataHeader
+	ID                      string         `json:"Id"`
+	Name                    string         `json:"Name"`
+	ServiceEnabled          bool           `json:"ServiceEnabled"`
+	MaxReports              int            `json:"MaxReports"`
+	MinCollectionInterval   string         `json:"MinCollectionInterval"`
+	MetricDefinitions       odataLink      `json:"MetricDefinitions"`
+	MetricReportDefinitions odataLink      `json:"MetricReportDefinitions"`
+	MetricReports           odataLink      `json:"MetricReports"`
+	Status                  resourceStatus `json:"Status"`
+}
+
+// metricDefinition is the Redfish MetricDefinition resource.
+type metricDefinition struct {
+	odataHeader
+	ID               string   `json:"Id"`
+	Name             string   `json:"Name"`
+	MetricType       string   `json:"MetricType"`
+	MetricDataType   string   `json:"MetricDataType"`
+	Implementation   string   `json:"Implementation"`
+	IsLinear         bool     `json:"IsLinear"`
+	Units            string   `json:"Units,omitempty"`
+	MetricProperties []string `json:"MetricProperties"`
+}
+
+// reportSchedule is the Schedule property of a MetricReportDefinition.
+type reportSchedule struct {
+	RecurrenceInterval string `json:"RecurrenceInterval"`
+}
+
+// reportMetric is an entry of the Metrics property of a MetricReportDefinition.
+type reportMetric struct {
+	MetricID         string   `json:"MetricId,omitempty"`
+	MetricProperties []string `json:"MetricProperties,omitempty"`
+}
+
+// metricReportDefinition is the Redfish MetricReportDefinition resource.
+type metricReportDefinition struct {
+	odataHeader
+	ID                            string          `json:"Id"`
+	Name                          string          `json:"Name"`
+	MetricReportDefinitionType    string          `json:"MetricReportDefinitionType"`
+	MetricReportDefinitionEnabled bool            `json:"MetricReportDefinitionEnabled"`
+	Schedule                      *reportSchedule `json:"Schedule,omitempty"`
+	ReportActions                 []string        `json:"ReportActions"`
+	ReportUpdates                 string          `json:"ReportUpdates"`
+	AppendLimit                   int             `json:"AppendLimit"`
+	Metrics                       []reportMetric  `json:"Metrics"`
+	MetricReport                  odataLink       `json:"MetricReport"`
+	Status                        resourceStatus  `json:"Status"`
+}
+
+// metricIDs returns the IDs of all metrics sensors can provide, which are
+// the Redfish reading types.
+func metricIDs() []string {
+	ids := make([]string, 0, len(schemav1alpha1.SensorContext_name))
+	for c := range schemav1alpha1.SensorContext_name {
+		if id := sensorReadingType(schemav1alpha1.SensorContext(c)); id != "" && !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// validDefinitionID reports whether clients may assign id to a metric report
+// definition. IDs are keys of the definition store and path segments, so they
+// are restricted to letters, digits, hyphens and underscores.
+func validDefinitionID(id string) bool {
+	return id != "" && len(id) <= maxMetricReportDefinitionName && !strings.ContainsFunc(id, func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') && r != '-' && r != '_'
+	})
+}
+
+// newMetricReportDefinition returns the Redfish representation of a
+// metric report definition.
+func newMetricReportDefinition(def *reportDefinition) *metricReportDefinition {
+	res := &metricReportDefinition{
+		odataHeader:                   odataHeader{ODataID: metricReportDefinitionsPath + "/" + url.PathEscape(def.ID), ODataType: odataTypeMetricReportDefinition},
+		ID:                            def.ID,
+		Name:                          def.Name,
+		MetricReportDefinitionType:    def.Type,
+		MetricReportDefinitionEnabled: def.Enabled,
+		ReportActions:                 slices.Clone(def.Actions),
+		ReportUpdates:                 def.Updates,
+		AppendLimit:                   def.AppendLimit,
+		Metrics:                       make([]reportMetric, 0, len(def.Metrics)),
+		MetricReport:                  link(def.reportPath()),
+		Status:                        resourceStatus{State: "Enabled", Health: "OK"},
+	}
+	if def.Type == metricReportTypePeriodic {
+		res.Schedule = &reportSchedule{RecurrenceInterval: formatISODuration(def.Interval)}
+	}
+	for _, m := range def.Metrics {
+		res.Metrics = append(res.Metrics, reportMetric{MetricID: m.MetricID, MetricProperties: slices.Clone(m.MetricProperties)})
+	}
+	if !def.Enabled {
+		res.Status.State = "Disabled"
+	}
+	return res
+}
+
+func (s *redfishServer) registerTelemetryService() {
+	s.handle(http.MethodGet, telemetryServicePath, s.handleTelemetryService)
+	s.handle(http.MethodGet, metricDefinitionsPath, s.handleMetricDefinitions)
+	s.handle(http.MethodGet, metricDefinitionsPath+"/{id}", s.handleMetricDefinition)
+	s.handle(http.MethodGet, metricReportDefinitionsPath, s.handleMetricReportDefinitions)
+	s.handlePrivileged(http.MethodPost, metricReportDefinitionsPath, privilegeConfigureManager, s.handleCreateMetricReportDefinition)
+	s.handle(http.MethodGet, metricReportDefinitionsPath+"/{id}", s.handleMetricReportDefinition)
+	s.handlePrivileged(http.MethodPatch, metricReportDefinitionsPath+"/{id}", privilegeConfigureManager, s.handlePatchMetricReportDefinition)
+	s.handlePrivileged(http.MethodDelete, metricReportDefinitionsPath+"/{id}", privilegeConfigureManager, s.handleDeleteMetricReportDefinition)
+	s.handle(http.MethodGet, metricReportsPath, s.handleMetricReports)
+	s.handle(http.MethodGet, metricReportsPath+"/{id}", s.handleMetricReport)
+}
+
+// sampleMetrics reads the numeric sensors of sensormon. Each reading is a
+// metric property referring to the Reading of the Sensor resource, with the
+// reading type as metric ID. Sensors without chassis location belong to the
+// first chassis, as in the Sensors collections.
+func (s *redfishServer) sampleMetrics(ctx context.Context) ([]metricSample, error) {
+	var chassis schemav1alpha1.ListChassisResponse
+	if err := s.requestNATS(ctx, ipc.SubjectChassisList, &schemav1alpha1.ListChassisRequest{}, &chassis); err != nil {
+		return nil, err
+	}
+	var primary string
+	if len(chassis.GetChassis()) > 0 {
+		primary = chassis.GetChassis()[0].GetName()
+	}
+
+	var resp schemav1alpha1.ListSensorsResponse
+	if err := s.requestNATS(ctx, ipc.SubjectSensorList, &schemav1alpha1.ListSensorsRequest{
+		FieldMask: &fieldmaskpb.FieldMask{Paths: []string{
+			"id", "location.chassis_location", "context", "unit", "analog_reading.value", "last_reading_timestamp",
+		}},
+	}, &resp); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	samples := make([]metricSample, 0, len(resp.GetSensor()))
+	for _, sensor := range resp.GetSensor() {
+		metricID := sensorReadingType(sensor.GetContext())
+		chassisID := cmp.Or(sensor.GetLocation().GetChassisLocation().GetName(), primary)
+		if sensor.GetAnalogReading() == nil || metricID == "" || chassisID == "" {
+			continue
+		}
+
+		units, convert := sensorReadingUnits(sensor.GetUnit())
+		sample := metricSample{
+			metricID:  metricID,
+			property:  chassisPath + "/" + url.PathEscape(chassisID) + "/Sensors/" + url.PathEscape(sensor.GetId()) + "/Reading",
+			value:     strconv.FormatFloat(convert(sensor.GetAnalogReading().GetValue()), 'f', -1, 64),
+			units:     units,
+			timestamp: now,
+		}
+		if ts := sensor.GetLastReadingTimestamp(); ts != nil {
+			sample.timestamp = ts.AsTime().UTC().Format(time.RFC3339)
+		}
+		samples = append(samples, sample)
+	}
+
+	slices.SortFunc(samples, func(a, b metricSample) int {
+		return cmp.Compare(a.property, b.property)
+	})
+	return samples, nil
+}
+
+func (s *redfishServer) handleTelemetryService(w http.ResponseWriter, r *http.Request) {
+	s.writeResource(w, r, &telemetryService{
+		odataHeader:             odataHeader{ODataID: telemetryServicePath, ODataType: odataTypeTelemetryService},
+		ID:                      "TelemetryService",
+		Name:                    "Telemetry Service",
+		ServiceEnabled:          true,
+		MaxReports:              maxMetricReportDefinitions,
+		MinCollectionInterval:   formatISODuration(metricMinInterval),
+		MetricDefinitions:       link(metricDefinitionsPath),
+		MetricReportDefinitions: link(metricReportDefinitionsPath),
+		MetricReports:           link(metricReportsPath),
+		Status:                  resourceStatus{State: "Enabled", Health: "OK"},
+	})
+}
+
+// handleMetricDefinitions lists a MetricDefinition for every reading type
+// of the present sensors.
+func (s *redfishServer) handleMetricDefinitions(w http.ResponseWriter, r *http.Request) {
+	samples, err := s.sampleMetrics(r.Context())
+	if err != nil {
+		s.writeRequestError(w, r, err, "MetricDefinitionCollection", "MetricDefinitions")
+		return
+	}
+
+	ids := make([]string, 0, len(metricIDs()))
+	for _, sample := range samples {
+		if !slices.Contains(ids, sample.metricID) {
+			ids = append(ids, sample.metricID)
+		}
+	}
+	slices.Sort(ids)
+
+	s.writeResource(w, r, newCollection(metricDefinitionsPath, odataTypeMetricDefinitionCollection, "Metric Definition Collection", ids))
+}
+
+func (s *redfishServer) handleMetricDefinition(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	samples, err := s.sampleMetrics(r.Context())
+	if err != nil {
+		s.writeRequestError(w, r, err, metricDefinitionResourceName, id)
+		return
+	}
+
+	def := &metricDefinition{
+		odataHeader:      odataHeader{ODataID: metricDefinitionsPath + "/" + url.PathEscape(id), ODataType: odataTypeMetricDefinition},
+		ID:               id,
+		Name:             id + " Metric Definition",
+		MetricType:       "Numeric",
+		MetricDataType:   "Decimal",
+		Implementation:   "PhysicalSensor",
+		IsLinear:         true,
+		MetricProperties: []string{},
+	}
+	for _, sample := range samples {
+		if sample.metricID == id {
+			def.Units = sample.units
+			def.MetricProperties = append(def.MetricProperties, sample.property)
+		}
+	}
+	if len(def.MetricProperties) == 0 {
+		s.writeRequestError(w, r, ErrNotFound, metricDefinitionResourceName, id)
+		return
+	}
+
+	s.writeResource(w, r, def)
+}
+
+func (s *redfishServer) handleMetricReportDefinitions(w http.ResponseWriter, r *http.Request) {
+	s.writeResource(w, r, newCollection(metricReportDefinitionsPath, odataTypeMetricReportDefinitionCollection,
+		"Metric Report Definition Collection", s.telemetry.definitionIDs()))
+}
+
+func (s *redfishServer) handleMetricReportDefinition(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	def, ok := s.telemetry.definition(id)
+	if !ok {
+		s.writeRequestError(w, r, ErrNotFound, metricReportDefinitionResourceName, id)
+		return
+	}
+
+	s.writeResource(w, r, newMetricReportDefinition(&def))
+}
+
+// applyDefinitionProperties decodes the properties of a metric report
+// definition that can be changed after its creation and checks the result.
+func (s *redfishServer) applyDefinitionProperties(w http.ResponseWriter, props map[string]json.RawMessage, def *reportDefinition) bool {
+	var schedule *reportSchedule
+	var metrics []reportMetric
+	if !s.decodeProperty(w, props, propertyMetricReportDefinitionEnabled, &def.Enabled) ||
+		!s.decodeProperty(w, props, propertySchedule, &schedule) ||
+		!s.decodeProperty(w, props, propertyReportActions, &def.Actions) ||
+		!s.decodeProperty(w, props, propertyReportUpdates, &def.Updates) ||
+		!s.decodeProperty(w, props, propertyAppendLimit, &def.AppendLimit) ||
+		!s.decodeProperty(w, props, propertyMetrics, &metrics) {
+		return false
+	}
+
+	if schedule != nil {
+		def.Interval = parseISODuration(schedule.RecurrenceInterval)
+		if def.Interval == 0 {
+			s.writeError(w, http.StatusBadRequest, "PropertyValueFormatError",
+				fmt.Sprintf("The value '%s' for the property Schedule/RecurrenceInterval is of a different format than the property can accept.",
+					schedule.RecurrenceInterval), schedule.RecurrenceInterval, "Schedule/RecurrenceInterval")
+			return false
+		}
+		if def.Interval < metricMinInterval {
+			s.writeOutOfRange(w, "Schedule/RecurrenceInterval", schedule.RecurrenceInterval)
+			return false
+		}
+	}
+	if def.Type == metricReportTypePeriodic && def.Interval == 0 {
+		s.writeError(w, http.StatusBadRequest, "PropertyMissing",
+			"The property Schedule is a required property and must be included in the request.", propertySchedule)
+		return false
+	}
+	if !s.checkPropertyValues(w, propertyReportActions, def.Actions, reportActions()) ||
+		!s.checkPropertyValues(w, propertyReportUpdates, []string{def.Updates}, reportUpdates()) {
+		return false
+	}
+	if def.AppendLimit < 1 || def.AppendLimit > maxMetricAppendLimit {
+		s.writeOutOfRange(w, propertyAppendLimit, def.AppendLimit)
+		return false
+	}
+
+	if _, ok := props[propertyMetrics]; ok {
+		def.Metrics = make([]metricSelection, 0, len(metrics))
+		for _, m := range metrics {
+			if m.MetricID != "" && !s.checkPropertyValues(w, "Metrics/MetricId", []string{m.MetricID}, metricIDs()) {
+				return false
+			}
+			for _, p := range m.MetricProperties {
+				if !strings.HasPrefix(p, chassisPath+"/") || !strings.HasSuffix(p, "/Reading") {
+					s.writeError(w, http.StatusBadRequest, "PropertyValueFormatError",
+						fmt.Sprintf("The value '%s' for the property Metrics/MetricProperties is of a different format than the property can accept.", p),
+						p, "Metrics/MetricProperties")
+					return false
+				}
+			}
+			def.Metrics = append(def.Metrics, metricSelection(m))
+		}
+	}
+	return true
+}
+
+func (s *redfishServer) handleCreateMetricReportDefinition(w http.ResponseWriter, r *http.Request) {
+	props, ok := s.readProperties(w, r, []string{
+		"Id", "Name", propertyMetricReportDefinitionType, propertyMetricReportDefinitionEnabled, propertySchedule,
+		propertyReportActions, propertyReportUpdates, propertyAppendLimit, propertyMetrics,
+	}, []string{"MetricReport", "Status"})
+	if !ok {
+		return
+	}
+
+	if _, ok := props[propertyMetricReportDefinitionType]; !ok {
+		s.writeError(w, http.StatusBadRequest, "PropertyMissing",
+			fmt.Sprintf("The property %s is a required property and must be included in the request.", propertyMetricReportDefinitionType),
+			propertyMetricReportDefinitionType)
+		return
+	}
+
+	def := reportDefinition{
+		Actions:     []string{reportActionLog},
+		Updates:     reportUpdatesOverwrite,
+		AppendLimit: metricDefaultAppendLimit,
+		Enabled:     true,
+	}
+	if !s.decodeProperty(w, props, "Id", &def.ID) ||
+		!s.decodeProperty(w, props, "Name", &def.Name) ||
+		!s.decodeProperty(w, props, propertyMetricReportDefinitionType, &def.Type) {
+		return
+	}
+	if !s.checkPropertyValues(w, propertyMetricReportDefinitionType, []string{def.Type}, metricReportTypes()) ||
+		!s.applyDefinitionProperties(w, props, &def) {
+		return
+	}
+	if def.ID != "" && !validDefinitionID(def.ID) {
+		s.writeError(w, http.StatusBadRequest, "PropertyValueFormatError",
+			fmt.Sprintf("The value '%s' for the property Id is of a different format than the property can accept.", def.ID), def.ID, "Id")
+		return
+	}
+	if len(def.Name) > maxMetricReportDefinitionName {
+		s.writeError(w, http.StatusBadRequest, "PropertyValueFormatError",
+			fmt.Sprintf("The value '%s' for the property Name is of a different format than the property can accept.", def.Name), def.Name, "Name")
+		return
+	}
+
+	def, err := s.telemetry.create(r.Context(), def)
+	switch {
+	case errors.Is(err, ErrTooManyDefinitions):
+		s.writeError(w, http.StatusBadRequest, "CreateLimitReachedForResource",
+			"The create operation failed because the resource has reached the limit of possible resources.")
+		return
+	case errors.Is(err, ErrDefinitionExists):
+		s.writeError(w, http.StatusConflict, "ResourceAlreadyExists",
+			fmt.Sprintf("The requested resource of type %s with the property Id with the value '%s' already exists.",
+				metricReportDefinitionResourceName, def.ID), metricReportDefinitionResourceName, "Id", def.ID)
+		return
+	case err != nil:
+		s.writeInternalError(w, r, err)
+		return
+	}
+
+	s.logger.InfoContext(r.Context(), "Redfish metric report definition created",
+		"definition", def.ID,
+		"type", def.Type)
+
+	res := newMetricReportDefinition(&def)
+	w.Header().Set("Location", res.ODataID)
+	w.Header().Set("Content-Type", contentTypeJSON)
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+func (s *redfishServer) handlePatchMetricReportDefinition(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	def, ok := s.telemetry.definition(id)
+	if !ok {
+		s.writeRequestError(w, r, ErrNotFound, metricReportDefinitionResourceName, id)
+		return
+	}
+
+	props, ok := s.readProperties(w, r,
+		[]string{
+			propertyMetricReportDefinitionEnabled, propertySchedule, propertyReportActions,
+			propertyReportUpdates, propertyAppendLimit, propertyMetrics,
+		},
+		[]string{"Id", "Name", propertyMetricReportDefinitionType, "MetricReport", "Status"})
+	if !ok || !s.applyDefinitionProperties(w, props, &def) {
+		return
+	}
+
+	def, err := s.telemetry.update(r.Context(), id, func(cur *reportDefinition) {
+		*cur = def
+	})
+	if err != nil {
+		s.writeRequestError(w, r, err, metricReportDefinitionResourceName, id)
+		return
+	}
+
+	s.writeResource(w, r, newMetricReportDefinition(&def))
+}
+
+func (s *redfishServer) handleDeleteMetricReportDefinition(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	if err := s.telemetry.remove(r.Context(), id); err != nil {
+		s.writeRequestError(w, r, err, metricReportDefinitionResourceName, id)
+		return
+	}
+
+	s.logger.InfoContext(r.Context(), "Redfish metric report definition deleted", "definition", id)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *redfishServer) handleMetricReports(w http.ResponseWriter, r *http.Request) {
+	s.writeResource(w, r, newCollection(metricReportsPath, odataTypeMetricReportCollection,
+		"Metric Report Collection", s.telemetry.reportIDs()))
+}
+
+// handleMetricReport serves the logged report of a definition. The report of
+// an OnRequest definition is generated by the request.
+func (s *redfishServer) handleMetricReport(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	if def, ok := s.telemetry.definition(id); ok && def.Enabled && def.Type == metricReportTypeOnRequest {
+		rep, err := s.telemetry.generate(r.Context(), id, true)
+		if err != nil {
+			s.writeRequestError(w, r, err, metricReportResourceName, id)
+			return
+		}
+		s.writeResource(w, r, rep)
+		return
+	}
+
+	rep, ok := s.telemetry.report(id)
+	if !ok {
+		s.writeRequestError(w, r, ErrNotFound, metricReportResourceName, id)
+		return
+	}
+
+	s.writeResource(w, r, rep)
+}
@@ -0,0 +1,505 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package websrv
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/micro"
+	schemav1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+	"github.com/u-bmc/u-bmc/pkg/ipc"
+)
+
+// testAccount is an account of the fake usermgr.
+type testAccount struct {
+	password string
+	role     string
+	// previousPasswords are the recent passwords usermgr keeps the account
+	// from reusing.
+	previousPasswords []string
+
+	passwordChangeRequired         bool
+	secondFactorEnrollmentRequired bool
+}
+
+// testAccounts returns the accounts of the fake usermgr, one per role plus a
+// user without Redfish account information.
+func testAccounts() map[string]testAccount {
+	return map[string]testAccount{
+		"admin":    {password: "admin-pw", role: roleAdministrator},
+		"operator": {password: "operator-pw", role: roleOperator},
+		"reader":   {password: "reader-pw", role: roleReadOnly, previousPasswords: []string{"reader-old-pw"}},
+		"legacy":   {password: "legacy-pw"},
+		"newhire":  {password: "newhire-pw", role: roleAdministrator, passwordChangeRequired: true},
+		"enroll":   {password: "enroll-pw", role: roleAdministrator, secondFactorEnrollmentRequired: true},
+	}
+}
+
+// newTestNATS starts an in-process NATS server with JetStream.
+func newTestNATS(t *testing.T) *nats.Conn {
+	t.Helper()
+
+	ns, err := server.NewServer(&server.Options{
+		DontListen: true,
+		NoLog:      true,
+		NoSigs:     true,
+		JetStream:  true,
+		StoreDir:   t.TempDir(),
+	})
+	if err != nil {
+		t.Fatalf("create NATS server: %v", err)
+	}
+	ns.Start()
+	t.Cleanup(ns.Shutdown)
+	if !ns.ReadyForConnections(5 * time.Second) {
+		t.Fatal("NATS server not ready")
+	}
+
+	nc, err := nats.Connect("", nats.InProcessServer(ns))
+	if err != nil {
+		t.Fatalf("connect to NATS: %v", err)
+	}
+	t.Cleanup(nc.Close)
+	return nc
+}
+
+// serveTestUsers answers the usermgr requests made by the Redfish server
+// for the given accounts.
+func serveTestUsers(t *testing.T, nc *nats.Conn, accounts map[string]testAccount) {
+	t.Helper()
+
+	respond := func(msg *nats.Msg, resp vtMessage) {
+		data, err := resp.MarshalVT()
+		if err != nil {
+			t.Errorf("marshal response: %v", err)
+			return
+		}
+		if err := msg.Respond(data); err != nil {
+			t.Errorf("respond: %v", err)
+		}
+	}
+	notFound := func(msg *nats.Msg) {
+		reply := nats.NewMsg(msg.Reply)
+		reply.Header.Set(micro.ErrorHeader, "user not found")
+		reply.Header.Set(micro.ErrorCodeHeader, "404")
+		if err := msg.RespondMsg(reply); err != nil {
+			t.Errorf("respond: %v", err)
+		}
+	}
+
+	user := func(username string) *schemav1alpha1.User {
+		user := &schemav1alpha1.User{Id: "id-" + username, Username: username, Enabled: true}
+		if role := accounts[username].role; role != "" {
+			user.RedfishInfo = &schemav1alpha1.RedfishAccountInfo{RoleId: role}
+		}
+		return user
+	}
+
+	subs := map[string]nats.MsgHandler{
+		ipc.SubjectUserAuthenticate: func(msg *nats.Msg) {
+			var req schemav1alpha1.AuthenticateUserRequest
+			if err := req.UnmarshalVT(msg.Data); err != nil {
+				t.Errorf("unmarshal authenticate request: %v", err)
+				return
+			}
+			acc, ok := accounts[req.GetUsername()]
+			if !ok || acc.password != req.GetPassword() {
+				reason := "invalid credentials"
+				respond(msg, &schemav1alpha1.AuthenticateUserResponse{FailureReason: &reason})
+				return
+			}
+			respond(msg, &schemav1alpha1.AuthenticateUserResponse{
+				Success:                        true,
+				PasswordChangeRequired:         acc.passwordChangeRequired,
+				SecondFactorEnrollmentRequired: acc.secondFactorEnrollmentRequired,
+			})
+		},
+		ipc.SubjectUserInfo: func(msg *nats.Msg) {
+			var req schemav1alpha1.GetUserRequest
+			if err := req.UnmarshalVT(msg.Data); err != nil {
+				t.Errorf("unmarshal user request: %v", err)
+				return
+			}
+			if _, ok := accounts[req.GetUsername()]; !ok {
+				notFound(msg)
+				return
+			}
+			respond(msg, &schemav1alpha1.GetUserResponse{User: user(req.GetUsername())})
+		},
+		ipc.SubjectUserList: func(msg *nats.Msg) {
+			resp := &schemav1alpha1.ListUsersResponse{}
+			for _, username := range slices.Sorted(maps.Keys(accounts)) {
+				resp.Users = append(resp.Users, user(username))
+			}
+			respond(msg, resp)
+		},
+		ipc.SubjectUserResetPassword: func(msg *nats.Msg) {
+			var req schemav1alpha1.ResetPasswordRequest
+			if err := req.UnmarshalVT(msg.Data); err != nil {
+				t.Errorf("unmarshal reset password request: %v", err)
+				return
+			}
+			acc, ok := accounts[strings.TrimPrefix(req.GetId(), "id-")]
+			if !ok {
+				notFound(msg)
+				return
+			}
+			// Forced resets skip the history check, like those of usermgr.
+			if !req.GetForce() && (req.GetNewPassword() == acc.password || slices.Contains(acc.previousPasswords, req.GetNewPassword())) {
+				reason := "invalid password: must differ from the current and the last 5 passwords"
+				respond(msg, &schemav1alpha1.ResetPasswordResponse{FailureReason: &reason})
+				return
+			}
+			respond(msg, &schemav1alpha1.ResetPasswordResponse{Success: true})
+		},
+		ipc.SubjectUserUpdate: func(msg *nats.Msg) {
+			respond(msg, &schemav1alpha1.UpdateUserResponse{})
+		},
+	}
+	for subject, handler := range subs {
+		if _, err := nc.Subscribe(subject, handler); err != nil {
+			t.Fatalf("subscribe %s: %v", subject, err)
+		}
+	}
+	if err := nc.Flush(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// newTestRedfish starts a Redfish server backed by an in-process NATS server
+// and the fake usermgr, and serves it over HTTP.
+func newTestRedfish(t *testing.T, opts ...Option) (*redfishServer, *httptest.Server) {
+	t.Helper()
+
+	nc := newTestNATS(t)
+	serveTestUsers(t, nc, testAccounts())
+
+	cfg := New(append([]Option{WithRedfishTimeout(5 * time.Second)}, opts...)...).config
+	logger := slog.New(slog.DiscardHandler)
+	rs := newRedfishServer(nc, logger, cfg, newSessionStore(cfg.redfishSessionTimeout))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := rs.start(ctx); err != nil {
+		cancel()
+		t.Fatalf("start Redfish server: %v", err)
+	}
+
+	srv := httptest.NewServer(rs)
+	t.Cleanup(func() {
+		srv.Close()
+		cancel()
+	})
+	return rs, srv
+}
+
+// testRequest describes a request to the Redfish server.
+type testRequest struct {
+	method string
+	path   string
+	body   string
+	// user authenticates the request with HTTP Basic authentication using
+	// the password of the test account.
+	user string
+	// token authenticates the request with a session token.
+	token string
+}
+
+// do sends the request and returns the response with its body read.
+func (tr testRequest) do(t *testing.T, srv *httptest.Server) (*http.Response, []byte) {
+	t.Helper()
+
+	var body io.Reader
+	if tr.body != "" {
+		body = strings.NewReader(tr.body)
+	}
+	req, err := http.NewRequest(tr.method, srv.URL+tr.path, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tr.body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if tr.user != "" {
+		req.SetBasicAuth(tr.user, testAccounts()[tr.user].password)
+	}
+	if tr.token != "" {
+		req.Header.Set(authTokenHeader, tr.token)
+	}
+
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", tr.method, tr.path, err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read %s %s: %v", tr.method, tr.path, err)
+	}
+	return resp, data
+}
+
+// decodeJSON decodes a response body into a generic JSON object.
+func decodeJSON(t *testing.T, data []byte) map[string]any {
+	t.Helper()
+
+	var obj map[string]any
+	if err := json.Unmarshal(data, &obj); err != nil {
+		t.Fatalf("decode %q: %v", data, err)
+	}
+	return obj
+}
+
+// errorMessageID returns the message ID of a Redfish error response.
+func errorMessageID(t *testing.T, data []byte) string {
+	t.Helper()
+
+	var body redfishError
+	if err := json.Unmarshal(data, &body); err != nil || len(body.Error.ExtendedInfo) == 0 {
+		t.Fatalf("not a Redfish error: %q", data)
+	}
+	return strings.TrimPrefix(body.Error.ExtendedInfo[0].MessageID, redfishBaseRegistry+".")
+}
+
+// login creates a session for a test account and returns its token and URI.
+func login(t *testing.T, srv *httptest.Server, username string) (string, string) {
+	t.Helper()
+
+	resp, data := testRequest{
+		method: http.MethodPost,
+		path:   sessionsPath,
+		body:   `{"UserName": "` + username + `", "Password": "` + testAccounts()[username].password + `"}`,
+	}.do(t, srv)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("login %s: %s %s", username, resp.Status, data)
+	}
+	return resp.Header.Get(authTokenHeader), resp.Header.Get("Location")
+}
+
+func TestRedfishSessionAuth(t *testing.T) {
+	_, srv := newTestRedfish(t)
+
+	t.Run("session login", func(t *testing.T) {
+		resp, data := testRequest{
+			method: http.MethodPost,
+			path:   sessionsPath,
+			body:   `{"UserName": "reader", "Password": "reader-pw"}`,
+		}.do(t, srv)
+		if resp.StatusCode != http.StatusCreated {
+			t.Fatalf("status = %s %s", resp.Status, data)
+		}
+		token, location := resp.Header.Get(authTokenHeader), resp.Header.Get("Location")
+		if token == "" || !strings.HasPrefix(location, sessionsPath+"/") {
+			t.Fatalf("token %q, location %q", token, location)
+		}
+		session := decodeJSON(t, data)
+		if session["UserName"] != "reader" || session["Password"] != nil || session["Token"] != nil {
+			t.Errorf("session = %v", session)
+		}
+
+		resp, data = testRequest{method: http.MethodGet, path: location, token: token}.do(t, srv)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("GET own session = %s %s", resp.Status, data)
+		}
+
+		resp, _ = testRequest{method: http.MethodDelete, path: location, token: token}.do(t, srv)
+		if resp.StatusCode != http.StatusNoContent {
+			t.Fatalf("DELETE own session = %s", resp.Status)
+		}
+		resp, _ = testRequest{method: http.MethodGet, path: sessionServicePath, token: token}.do(t, srv)
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("token after logout = %s, want 401", resp.Status)
+		}
+	})
+
+	t.Run("invalid credentials", func(t *testing.T) {
+		resp, data := testRequest{
+			method: http.MethodPost,
+			path:   sessionsPath,
+			body:   `{"UserName": "reader", "Password": "wrong"}`,
+		}.do(t, srv)
+		if resp.StatusCode != http.StatusUnauthorized || errorMessageID(t, data) != "ResourceAtURIUnauthorized" {
+			t.Errorf("login with a wrong password = %s %s", resp.Status, data)
+		}
+		if resp.Header.Get(authTokenHeader) != "" {
+			t.Error("token issued for a failed login")
+		}
+
+		resp, data = testRequest{method: http.MethodPost, path: sessionsPath, body: `{"UserName": "reader"}`}.do(t, srv)
+		if resp.StatusCode != http.StatusBadRequest || errorMessageID(t, data) != "PropertyMissing" {
+			t.Errorf("login without a password = %s %s", resp.Status, data)
+		}
+	})
+
+	tests := []struct {
+		name string
+		req  testRequest
+		want int
+	}{
+		{
+			name: "service root without credentials",
+			req:  testRequest{method: http.MethodGet, path: redfishRoot},
+			want: http.StatusOK,
+		},
+		{
+			name: "resource without credentials",
+			req:  testRequest{method: http.MethodGet, path: sessionServicePath},
+			want: http.StatusUnauthorized,
+		},
+		{
+			name: "unknown token",
+			req:  testRequest{method: http.MethodGet, path: sessionServicePath, token: "0123456789abcdef"},
+			want: http.StatusUnauthorized,
+		},
+		{
+			name: "basic authentication",
+			req:  testRequest{method: http.MethodGet, path: sessionServicePath, user: "reader"},
+			want: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, data := tt.req.do(t, srv)
+			if resp.StatusCode != tt.want {
+				t.Fatalf("status = %s, want %d: %s", resp.Status, tt.want, data)
+			}
+			if tt.want == http.StatusUnauthorized && resp.Header.Get("WWW-Authenticate") == "" {
+				t.Error("401 response without WWW-Authenticate")
+			}
+		})
+	}
+
+	t.Run("wrong basic authentication password", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, srv.URL+sessionServicePath, nil)
+		req.SetBasicAuth("reader", "wrong")
+		resp, err := srv.Client().Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("status = %s, want 401", resp.Status)
+		}
+	})
+}
+
+func TestRedfishRestrictedSessions(t *testing.T) {
+	_, srv := newTestRedfish(t)
+
+	tests := []struct {
+		name      string
+		user      string
+		messageID string
+	}{
+		{name: "password change required", user: "newhire", messageID: "PasswordChangeRequired"},
+		{name: "second factor enrollment required", user: "enroll", messageID: "InsufficientPrivilege"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, location := login(t, srv, tt.user)
+
+			resp, data := testRequest{method: http.MethodGet, path: sessionServicePath, token: token}.do(t, srv)
+			if resp.StatusCode != http.StatusForbidden || errorMessageID(t, data) != tt.messageID {
+				t.Errorf("GET SessionService = %s %s", resp.Status, data)
+			}
+			resp, data = testRequest{method: http.MethodGet, path: location, token: token}.do(t, srv)
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("GET own session = %s %s", resp.Status, data)
+			}
+			resp, data = testRequest{method: http.MethodDelete, path: location, token: token}.do(t, srv)
+			if resp.StatusCode != http.StatusNoContent {
+				t.Errorf("DELETE own session = %s %s", resp.Status, data)
+			}
+		})
+	}
+}
+
+func TestRedfishPrivileges(t *testing.T) {
+	_, srv := newTestRedfish(t)
+
+	patchTimeout := func(user string) testRequest {
+		return testRequest{method: http.MethodPatch, path: sessionServicePath, body: `{"SessionTimeout": 600}`, user: user}
+	}
+	testEvent := func(user string) testRequest {
+		return testRequest{method: http.MethodPost, path: eventServicePath + "/Actions/" + actionSubmitTestEvent, body: `{}`, user: user}
+	}
+
+	tests := []struct {
+		name string
+		req  testRequest
+		want int
+	}{
+		{"ReadOnly reads", testRequest{method: http.MethodGet, path: sessionServicePath, user: "reader"}, http.StatusOK},
+		{"ReadOnly configures the manager", patchTimeout("reader"), http.StatusForbidden},
+		{"ReadOnly submits a test event", testEvent("reader"), http.StatusForbidden},
+		{"Operator reads", testRequest{method: http.MethodGet, path: eventServicePath, user: "operator"}, http.StatusOK},
+		{"Operator configures the manager", patchTimeout("operator"), http.StatusForbidden},
+		{"Operator submits a test event", testEvent("operator"), http.StatusForbidden},
+		{"Administrator configures the manager", patchTimeout("admin"), http.StatusOK},
+		{"Administrator submits a test event", testEvent("admin"), http.StatusNoContent},
+		{"account without a Redfish role reads", testRequest{method: http.MethodGet, path: rolesPath, user: "legacy"}, http.StatusOK},
+		{"account without a Redfish role configures the manager", patchTimeout("legacy"), http.StatusForbidden},
+		{"unsupported method", testRequest{method: http.MethodPut, path: sessionServicePath, user: "admin"}, http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, data := tt.req.do(t, srv)
+			if resp.StatusCode != tt.want {
+				t.Fatalf("status = %s, want %d: %s", resp.Status, tt.want, data)
+			}
+			if tt.want == http.StatusForbidden && errorMessageID(t, data) != "InsufficientPrivilege" {
+				t.Errorf("message = %s", data)
+			}
+		})
+	}
+
+	t.Run("sessions of other accounts", func(t *testing.T) {
+		readerToken, readerSession := login(t, srv, "reader")
+		adminToken, adminSession := login(t, srv, "admin")
+
+		resp, _ := testRequest{method: http.MethodGet, path: adminSession, token: readerToken}.do(t, srv)
+		if resp.StatusCode != http.StatusForbidden {
+			t.Errorf("ReadOnly GET of another session = %s, want 403", resp.Status)
+		}
+		resp, _ = testRequest{method: http.MethodDelete, path: adminSession, token: readerToken}.do(t, srv)
+		if resp.StatusCode != http.StatusForbidden {
+			t.Errorf("ReadOnly DELETE of another session = %s, want 403", resp.Status)
+		}
+		resp, _ = testRequest{method: http.MethodGet, path: readerSession, token: adminToken}.do(t, srv)
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("Administrator GET of another session = %s, want 200", resp.Status)
+		}
+
+		members := func(token string) []string {
+			_, data := testRequest{method: http.MethodGet, path: sessionsPath, token: token}.do(t, srv)
+			var coll resourceCollection
+			if err := json.Unmarshal(data, &coll); err != nil {
+				t.Fatal(err)
+			}
+			ids := make([]string, 0, len(coll.Members))
+			for _, m := range coll.Members {
+				ids = append(ids, m.ODataID)
+			}
+			return ids
+		}
+		if got := members(readerToken); len(got) != 1 || got[0] != readerSession {
+			t.Errorf("sessions visible to ReadOnly = %v, want only %s", got, readerSession)
+		}
+		if got := members(adminToken); len(got) < 2 {
+			t.Errorf("sessions visible to Administrator = %v, want all", got)
+		}
+	})
+}
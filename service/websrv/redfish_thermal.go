@@ -0,0 +1,522 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package websrv
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	schemav1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+	"github.com/u-bmc/u-bmc/pkg/ipc"
+)
+
+// Thermal resource types.
+const (
+	odataTypeThermalSubsystem = "#ThermalSubsystem.v1_3_2.ThermalSubsystem"
+	odataTypeFanCollection    = "#FanCollection.FanCollection"
+	odataTypeFan              = "#Fan.v1_5_2.Fan"
+	odataTypeThermalMetrics   = "#ThermalMetrics.v1_3_2.ThermalMetrics"
+	odataTypeThermal          = "#Thermal.v1_7_3.Thermal"
+	fanResourceName           = "Fan"
+	propertyOem               = "Oem"
+	propertyFanControlMode    = "Oem/UBMC/ControlMode"
+	propertyFanSpeedControl   = "Oem/UBMC/SpeedControlPercent"
+)
+
+// Redfish control modes.
+const (
+	controlModeAutomatic = "Automatic"
+	controlModeManual    = "Manual"
+	controlModeDisabled  = "Disabled"
+)
+
+// Cooling device control modes of the thermal manager.
+const (
+	coolingModeAutomatic = "automatic"
+	coolingModeManual    = "manual"
+)
+
+// coolingDeviceModeRequest mirrors the thermal manager request changing the
+// control mode of a cooling device.
+type coolingDeviceModeRequest struct {
+	Name         string   `json:"name"`
+	Mode         string   `json:"mode"`
+	PowerPercent *float64 `json:"power_percent,omitempty"`
+}
+
+// coolingDeviceModeResponse mirrors the thermal manager control mode of a
+// cooling device.
+type coolingDeviceModeResponse struct {
+	Name         string  `json:"name"`
+	Mode         string  `json:"mode"`
+	PowerPercent float64 `json:"power_percent"`
+}
+
+// thermalSubsystem is the Redfish ThermalSubsystem resource of a chassis.
+type thermalSubsystem struct {
+	odataHeader
+	ID             string         `json:"Id"`
+	Name           string         `json:"Name"`
+	Fans           odataLink      `json:"Fans"`
+	ThermalMetrics odataLink      `json:"ThermalMetrics"`
+	Status         resourceStatus `json:"Status"`
+}
+
+// sensorExcerpt is the excerpt of a Sensor embedded in other resources.
+// DeviceName is only reported in arrays of excerpts.
+type sensorExcerpt struct {
+	DataSourceURI string   `json:"DataSourceUri,omitempty"`
+	DeviceName    string   `json:"DeviceName,omitempty"`
+	Reading       *float64 `json:"Reading"`
+}
+
+// fanSpeed is the speed of a fan in percent, with its rotational speed if a
+// tachometer reports it.
+type fanSpeed struct {
+	DeviceName string   `json:"DeviceName,omitempty"`
+	Reading    *float64 `json:"Reading"`
+	SpeedRPM   *float64 `json:"SpeedRPM,omitempty"`
+}
+
+// fanResource is the Redfish Fan resource of a cooling device.
+type fanResource struct {
+	odataHeader
+	ID           string         `json:"Id"`
+	Name         string         `json:"Name"`
+	SpeedPercent fanSpeed       `json:"SpeedPercent"`
+	Status       resourceStatus `json:"Status"`
+	Oem          fanOem         `json:"Oem"`
+}
+
+// fanOem holds the control of a fan, which Redfish does not standardize.
+// Setting SpeedControlPercent places the fan under manual control.
+type fanOem struct {
+	UBMC *fanControl `json:"UBMC,omitempty"`
+}
+
+// fanControl is the control mode and speed of a fan.
+type fanControl struct {
+	ControlMode         string   `json:"ControlMode,omitempty"`
+	SpeedControlPercent *float64 `json:"SpeedControlPercent,omitempty"`
+}
+
+// thermalMetrics is the Redfish ThermalMetrics resource of a chassis.
+type thermalMetrics struct {
+	odataHeader
+	ID                         string          `json:"Id"`
+	Name                       string          `json:"Name"`
+	TemperatureReadingsCelsius []sensorExcerpt `json:"TemperatureReadingsCelsius"`
+}
+
+// legacyThresholds are the thresholds of a reading in the deprecated Thermal
+// and Power resources.
+type legacyThresholds struct {
+	UpperThresholdNonCritical *float64 `json:"UpperThresholdNonCritical,omitempty"`
+	UpperThresholdCritical    *float64 `json:"UpperThresholdCritical,omitempty"`
+	LowerThresholdNonCritical *float64 `json:"LowerThresholdNonCritical,omitempty"`
+	LowerThresholdCritical    *float64 `json:"LowerThresholdCritical,omitempty"`
+}
+
+// legacyTemperature is a temperature of the deprecated Thermal resource.
+type legacyTemperature struct {
+	ODataID        string   `json:"@odata.id"`
+	MemberID       string   `json:"MemberId"`
+	Name           string   `json:"Name"`
+	ReadingCelsius *float64 `json:"ReadingCelsius"`
+	legacyThresholds
+	Status resourceStatus `json:"Status"`
+}
+
+// legacyFan is a fan of the deprecated Thermal resource.
+type legacyFan struct {
+	ODataID      string         `json:"@odata.id"`
+	MemberID     string         `json:"MemberId"`
+	Name         string         `json:"Name"`
+	Reading      *int64         `json:"Reading"`
+	ReadingUnits string         `json:"ReadingUnits"`
+	Status       resourceStatus `json:"Status"`
+}
+
+// thermalResource is the deprecated Redfish Thermal resource of a chassis.
+type thermalResource struct {
+	odataHeader
+	ID           string              `json:"Id"`
+	Name         string              `json:"Name"`
+	Temperatures []legacyTemperature `json:"Temperatures"`
+	Fans         []legacyFan         `json:"Fans"`
+}
+
+// newLegacyThresholds converts the thresholds of a Sensor.
+func newLegacyThresholds(t *sensorThresholds) legacyThresholds {
+	reading := func(t *sensorThreshold) *float64 {
+		if t == nil {
+			return nil
+		}
+		return &t.Reading
+	}
+
+	if t == nil {
+		return legacyThresholds{}
+	}
+	return legacyThresholds{
+		UpperThresholdNonCritical: reading(t.UpperCaution),
+		UpperThresholdCritical:    reading(t.UpperCritical),
+		LowerThresholdNonCritical: reading(t.LowerCaution),
+		LowerThresholdCritical:    reading(t.LowerCritical),
+	}
+}
+
+// isFan reports whether a cooling device is a fan.
+func isFan(device *schemav1alpha1.CoolingDevice) bool {
+	switch device.GetType() {
+	case schemav1alpha1.CoolingDeviceType_COOLING_DEVICE_TYPE_FAN,
+		schemav1alpha1.CoolingDeviceType_COOLING_DEVICE_TYPE_BLOWER:
+		return true
+	default:
+		return false
+	}
+}
+
+// fanTachSensorID returns the ID of the sensormon tachometer sensor paired
+// with the hwmon PWM output of a fan, following the hwmon convention of
+// numbering fanN_input like pwmN. Fans not driven by a PWM output have none.
+func fanTachSensorID(name string) string {
+	i := strings.LastIndex(name, "_pwm")
+	if i < 0 || i+len("_pwm") == len(name) {
+		return ""
+	}
+	n := name[i+len("_pwm"):]
+	if strings.Trim(n, "0123456789") != "" {
+		return ""
+	}
+	return name[:i] + "_fan" + n + "_input"
+}
+
+// coolingDeviceStatus maps a cooling device status to the Redfish status.
+func coolingDeviceStatus(status schemav1alpha1.CoolingDeviceStatus) resourceStatus {
+	switch status {
+	case schemav1alpha1.CoolingDeviceStatus_COOLING_DEVICE_STATUS_ENABLED:
+		return resourceStatus{State: "Enabled", Health: "OK"}
+	case schemav1alpha1.CoolingDeviceStatus_COOLING_DEVICE_STATUS_DISABLED:
+		return resourceStatus{State: "Disabled"}
+	case schemav1alpha1.CoolingDeviceStatus_COOLING_DEVICE_STATUS_NOT_PRESENT:
+		return resourceStatus{State: "Absent"}
+	case schemav1alpha1.CoolingDeviceStatus_COOLING_DEVICE_STATUS_ERROR:
+		return resourceStatus{State: "UnavailableOffline", Health: "Critical"}
+	default:
+		return resourceStatus{State: "Enabled"}
+	}
+}
+
+// newFanSpeed returns the speed of a fan, taking its rotational speed from
+// the tachometer sensor if there is one.
+func newFanSpeed(device *schemav1alpha1.CoolingDevice, tach *schemav1alpha1.Sensor) fanSpeed {
+	percent := device.GetCoolingPowerPercent()
+	speed := fanSpeed{Reading: &percent}
+	if analog := tach.GetAnalogReading(); analog != nil {
+		rpm := analog.GetValue()
+		speed.SpeedRPM = &rpm
+	}
+	return speed
+}
+
+// newFan creates the Fan resource of a cooling device in the collection at path.
+func newFan(path string, device *schemav1alpha1.CoolingDevice, tach *schemav1alpha1.Sensor) *fanResource {
+	res := &fanResource{
+		odataHeader:  odataHeader{ODataID: path + "/" + url.PathEscape(device.GetName()), ODataType: odataTypeFan},
+		ID:           device.GetName(),
+		Name:         device.GetName(),
+		SpeedPercent: newFanSpeed(device, tach),
+		Status:       coolingDeviceStatus(device.GetStatus()),
+	}
+
+	res.Oem.UBMC = &fanControl{ControlMode: controlModeAutomatic, SpeedControlPercent: res.SpeedPercent.Reading}
+	if device.GetControlMode() == schemav1alpha1.CoolingDeviceControlMode_COOLING_DEVICE_CONTROL_MODE_MANUAL {
+		res.Oem.UBMC.ControlMode = controlModeManual
+	}
+
+	return res
+}
+
+// sensorsByID indexes sensors by their ID.
+func sensorsByID(sensors []*schemav1alpha1.Sensor) map[string]*schemav1alpha1.Sensor {
+	m := make(map[string]*schemav1alpha1.Sensor, len(sensors))
+	for _, sensor := range sensors {
+		m[sensor.GetId()] = sensor
+	}
+	return m
+}
+
+func (s *redfishServer) registerThermal() {
+	path := chassisPath + "/{id}"
+	s.handle(http.MethodGet, path+"/ThermalSubsystem", s.handleThermalSubsystem)
+	s.handle(http.MethodGet, path+"/ThermalSubsystem/Fans", s.handleFans)
+	s.handle(http.MethodGet, path+"/ThermalSubsystem/Fans/{fanId}", s.handleFan)
+	s.handle(http.MethodPatch, path+"/ThermalSubsystem/Fans/{fanId}", s.handlePatchFan)
+	s.handle(http.MethodGet, path+"/ThermalSubsystem/ThermalMetrics", s.handleThermalMetrics)
+	s.handle(http.MethodGet, path+"/Thermal", s.handleThermal)
+}
+
+// chassisFans lists the fans of a chassis sorted by name. Failures are
+// reported as errors about the resource of the given type and ID.
+func (s *redfishServer) chassisFans(w http.ResponseWriter, r *http.Request, scope chassisScope, resourceType, id string) ([]*schemav1alpha1.CoolingDevice, bool) {
+	var resp schemav1alpha1.ListCoolingDevicesResponse
+	if err := s.requestNATS(r.Context(), ipc.SubjectCoolingDeviceList, &schemav1alpha1.ListCoolingDevicesRequest{}, &resp); err != nil {
+		s.writeRequestError(w, r, err, resourceType, id)
+		return nil, false
+	}
+
+	fans := slices.DeleteFunc(resp.GetCoolingDevices(), func(device *schemav1alpha1.CoolingDevice) bool {
+		return !isFan(device) || !scope.contains(device.GetLocation())
+	})
+	slices.SortFunc(fans, func(a, b *schemav1alpha1.CoolingDevice) int {
+		return cmp.Compare(a.GetName(), b.GetName())
+	})
+	return fans, true
+}
+
+// chassisFan fetches a fan of a chassis, writing an error response if that
+// fails.
+func (s *redfishServer) chassisFan(w http.ResponseWriter, r *http.Request, scope chassisScope, fanID string) (*schemav1alpha1.CoolingDevice, bool) {
+	var resp schemav1alpha1.GetCoolingDeviceResponse
+	err := s.requestNATS(r.Context(), ipc.SubjectCoolingDeviceInfo, &schemav1alpha1.GetCoolingDeviceRequest{Name: fanID}, &resp)
+	if err == nil && (!isFan(resp.GetCoolingDevice()) || !scope.contains(resp.GetCoolingDevice().GetLocation())) {
+		err = ErrNotFound
+	}
+	if err != nil {
+		s.writeRequestError(w, r, err, fanResourceName, fanID)
+		return nil, false
+	}
+	return resp.GetCoolingDevice(), true
+}
+
+// fanTach fetches the tachometer sensor of a fan. The rotational speed is
+// optional, so fans whose sensor cannot be fetched are reported without it.
+func (s *redfishServer) fanTach(r *http.Request, device *schemav1alpha1.CoolingDevice) *schemav1alpha1.Sensor {
+	id := fanTachSensorID(device.GetName())
+	if id == "" {
+		return nil
+	}
+
+	var resp schemav1alpha1.GetSensorResponse
+	if err := s.requestNATS(r.Context(), ipc.SubjectSensorInfo, &schemav1alpha1.GetSensorRequest{
+		Identifier: &schemav1alpha1.GetSensorRequest_Id{Id: id},
+	}, &resp); err != nil {
+		if !errors.Is(err, ErrNotFound) {
+			s.logger.WarnContext(r.Context(), "Failed to fetch fan tachometer", "fan", device.GetName(), "error", err)
+		}
+		return nil
+	}
+	if len(resp.GetSensors()) == 0 {
+		return nil
+	}
+	return resp.GetSensors()[0]
+}
+
+func (s *redfishServer) handleThermalSubsystem(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if _, ok := s.chassisScope(w, r, id); !ok {
+		return
+	}
+
+	path := chassisPath + "/" + url.PathEscape(id) + "/ThermalSubsystem"
+	s.writeResource(w, r, &thermalSubsystem{
+		odataHeader:    odataHeader{ODataID: path, ODataType: odataTypeThermalSubsystem},
+		ID:             "ThermalSubsystem",
+		Name:           "Thermal Subsystem",
+		Fans:           link(path + "/Fans"),
+		ThermalMetrics: link(path + "/ThermalMetrics"),
+		Status:         resourceStatus{State: "Enabled", Health: "OK"},
+	})
+}
+
+func (s *redfishServer) handleFans(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	scope, ok := s.chassisScope(w, r, id)
+	if !ok {
+		return
+	}
+
+	fans, ok := s.chassisFans(w, r, scope, "FanCollection", "Fans")
+	if !ok {
+		return
+	}
+
+	names := make([]string, 0, len(fans))
+	for _, fan := range fans {
+		names = append(names, fan.GetName())
+	}
+
+	s.writeResource(w, r, newCollection(chassisPath+"/"+url.PathEscape(id)+"/ThermalSubsystem/Fans",
+		odataTypeFanCollection, "Fan Collection", names))
+}
+
+func (s *redfishServer) handleFan(w http.ResponseWriter, r *http.Request) {
+	id, fanID := r.PathValue("id"), r.PathValue("fanId")
+	scope, ok := s.chassisScope(w, r, id)
+	if !ok {
+		return
+	}
+
+	device, ok := s.chassisFan(w, r, scope, fanID)
+	if !ok {
+		return
+	}
+
+	s.writeResource(w, r, newFan(chassisPath+"/"+url.PathEscape(id)+"/ThermalSubsystem/Fans", device, s.fanTach(r, device)))
+}
+
+// handlePatchFan changes the control mode and speed of a fan. Fans under
+// manual control keep their speed until they are returned to automatic
+// control, except when the thermal manager applies emergency cooling.
+func (s *redfishServer) handlePatchFan(w http.ResponseWriter, r *http.Request) {
+	id, fanID := r.PathValue("id"), r.PathValue("fanId")
+	props, ok := s.readProperties(w, r, []string{propertyOem}, []string{"Id", "Name", "SpeedPercent", "Status"})
+	if !ok {
+		return
+	}
+
+	var oem fanOem
+	if !s.decodeProperty(w, props, propertyOem, &oem) {
+		return
+	}
+
+	scope, ok := s.chassisScope(w, r, id)
+	if !ok {
+		return
+	}
+	if _, ok := s.chassisFan(w, r, scope, fanID); !ok {
+		return
+	}
+
+	if oem.UBMC != nil && (oem.UBMC.ControlMode != "" || oem.UBMC.SpeedControlPercent != nil) {
+		mode, speed := oem.UBMC.ControlMode, oem.UBMC.SpeedControlPercent
+		if mode == "" {
+			mode = controlModeManual
+		}
+		if !s.checkPropertyValues(w, propertyFanControlMode, []string{mode}, []string{controlModeAutomatic, controlModeManual}) {
+			return
+		}
+		switch {
+		case speed != nil && mode == controlModeAutomatic:
+			s.writeError(w, http.StatusBadRequest, "PropertyValueConflict",
+				fmt.Sprintf("The property '%s' could not be written because its value would conflict with the value of the '%s' property.",
+					propertyFanSpeedControl, propertyFanControlMode),
+				propertyFanSpeedControl, propertyFanControlMode)
+			return
+		case speed != nil && (*speed < 0 || *speed > 100):
+			s.writeOutOfRange(w, propertyFanSpeedControl, *speed)
+			return
+		}
+
+		req := &coolingDeviceModeRequest{Name: fanID, Mode: coolingModeAutomatic, PowerPercent: speed}
+		if mode == controlModeManual {
+			req.Mode = coolingModeManual
+		}
+		var resp coolingDeviceModeResponse
+		if err := s.requestJSON(r.Context(), ipc.SubjectCoolingDeviceMode, req, &resp); err != nil {
+			s.writeRequestError(w, r, err, fanResourceName, fanID)
+			return
+		}
+
+		s.logger.InfoContext(r.Context(), "Redfish fan control changed",
+			"chassis", id,
+			"fan", fanID,
+			"mode", resp.Mode,
+			"power_percent", resp.PowerPercent)
+	}
+
+	s.handleFan(w, r)
+}
+
+func (s *redfishServer) handleThermalMetrics(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	scope, ok := s.chassisScope(w, r, id)
+	if !ok {
+		return
+	}
+
+	sensors, ok := s.chassisSensors(w, r, scope, nil, "ThermalMetrics", "ThermalMetrics")
+	if !ok {
+		return
+	}
+
+	path := chassisPath + "/" + url.PathEscape(id)
+	temperatures := contextSensors(path+"/Sensors", sensors, schemav1alpha1.SensorContext_SENSOR_CONTEXT_TEMPERATURE)
+	metrics := &thermalMetrics{
+		odataHeader:                odataHeader{ODataID: path + "/ThermalSubsystem/ThermalMetrics", ODataType: odataTypeThermalMetrics},
+		ID:                         "ThermalMetrics",
+		Name:                       "Thermal Metrics",
+		TemperatureReadingsCelsius: make([]sensorExcerpt, 0, len(temperatures)),
+	}
+	for _, t := range temperatures {
+		metrics.TemperatureReadingsCelsius = append(metrics.TemperatureReadingsCelsius, sensorExcerpt{
+			DataSourceURI: t.ODataID,
+			DeviceName:    t.Name,
+			Reading:       t.Reading,
+		})
+	}
+
+	s.writeResource(w, r, metrics)
+}
+
+// handleThermal serves the deprecated Thermal resource, whose temperatures
+// and fans are the same as those of the ThermalSubsystem.
+func (s *redfishServer) handleThermal(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	scope, ok := s.chassisScope(w, r, id)
+	if !ok {
+		return
+	}
+
+	sensors, ok := s.chassisSensors(w, r, scope, nil, "Thermal", "Thermal")
+	if !ok {
+		return
+	}
+	fans, ok := s.chassisFans(w, r, scope, "Thermal", "Thermal")
+	if !ok {
+		return
+	}
+
+	path := chassisPath + "/" + url.PathEscape(id)
+	temperatures := contextSensors(path+"/Sensors", sensors, schemav1alpha1.SensorContext_SENSOR_CONTEXT_TEMPERATURE)
+	res := &thermalResource{
+		odataHeader:  odataHeader{ODataID: path + "/Thermal", ODataType: odataTypeThermal},
+		ID:           "Thermal",
+		Name:         "Thermal",
+		Temperatures: make([]legacyTemperature, 0, len(temperatures)),
+		Fans:         make([]legacyFan, 0, len(fans)),
+	}
+	for i, t := range temperatures {
+		member := strconv.Itoa(i)
+		res.Temperatures = append(res.Temperatures, legacyTemperature{
+			ODataID:          path + "/Thermal#/Temperatures/" + member,
+			MemberID:         member,
+			Name:             t.Name,
+			ReadingCelsius:   t.Reading,
+			legacyThresholds: newLegacyThresholds(t.Thresholds),
+			Status:           t.Status,
+		})
+	}
+	for i, fan := range fans {
+		member := strconv.Itoa(i)
+		percent := int64(math.Round(fan.GetCoolingPowerPercent()))
+		res.Fans = append(res.Fans, legacyFan{
+			ODataID:      path + "/Thermal#/Fans/" + member,
+			MemberID:     member,
+			Name:         fan.GetName(),
+			Reading:      &percent,
+			ReadingUnits: "Percent",
+			Status:       coolingDeviceStatus(fan.GetStatus()),
+		})
+	}
+
+	s.writeResource(w, r, res)
+}
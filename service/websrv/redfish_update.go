@@ -0,0 +1,501 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package websrv
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	schemav1alpha1 "github.com/u-bmc/u-bmc/api/gen/schema/v1alpha1"
+	"github.com/u-bmc/u-bmc/pkg/ipc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// UpdateService resource types.
+const (
+	odataTypeUpdateService               = "#UpdateService.v1_14_0.UpdateService"
+	odataTypeSoftwareInventoryCollection = "#SoftwareInventoryCollection.SoftwareInventoryCollection"
+	odataTypeSoftwareInventory           = "#SoftwareInventory.v1_10_0.SoftwareInventory"
+	updateServicePath                    = redfishRoot + "/UpdateService"
+	firmwareInventoryPath                = updateServicePath + "/FirmwareInventory"
+	multipartUpdatePath                  = updateServicePath + "/update-multipart"
+	actionSimpleUpdate                   = "UpdateService.SimpleUpdate"
+	updateServiceResourceName            = "UpdateService"
+	softwareInventoryResourceName        = "SoftwareInventory"
+	partUpdateParameters                 = "UpdateParameters"
+	partUpdateFile                       = "UpdateFile"
+	partUpdateSignature                  = "OemUpdateSignature"
+	parameterTargets                     = "Targets"
+	parameterApplyTime                   = "@Redfish.OperationApplyTime"
+	applyTimeImmediate                   = "Immediate"
+	applyTimeOnReset                     = "OnReset"
+	// updateUploadTimeout bounds the transfer of an image pushed to the
+	// UpdateService, which takes longer than the server read timeout allows.
+	updateUploadTimeout = 10 * time.Minute
+	// updateSignatureSuffix names the detached signature of a staged image
+	// after it, matching updatemgr.SignatureSuffix.
+	updateSignatureSuffix = ".sig"
+	// updateSignatureMaxSize limits the size of a detached signature.
+	updateSignatureMaxSize = 4 << 10
+)
+
+// updateTransferProtocols returns the transfer protocols SimpleUpdate supports.
+func updateTransferProtocols() []string {
+	return []string{"HTTP", "HTTPS"}
+}
+
+// updateApplyTimes returns the supported values of @Redfish.OperationApplyTime.
+func updateApplyTimes() []string {
+	return []string{applyTimeImmediate, applyTimeOnReset}
+}
+
+// simpleUpdateAction is the SimpleUpdate action with its allowable transfer
+// protocols.
+type simpleUpdateAction struct {
+	Target            string   `json:"target"`
+	TransferProtocols []string `json:"TransferProtocol@Redfish.AllowableValues"`
+}
+
+// updateService is the Redfish UpdateService resource.
+type updateService struct {
+	odataHeader
+	ID                   string         `json:"Id"`
+	Name                 string         `json:"Name"`
+	ServiceEnabled       bool           `json:"ServiceEnabled"`
+	MultipartHTTPPushURI string         `json:"MultipartHttpPushUri"`
+	MaxImageSizeBytes    int64          `json:"MaxImageSizeBytes"`
+	FirmwareInventory    odataLink      `json:"FirmwareInventory"`
+	Status               resourceStatus `json:"Status"`
+	Actions              struct {
+		SimpleUpdate simpleUpdateAction `json:"#UpdateService.SimpleUpdate"`
+	} `json:"Actions"`
+}
+
+// softwareInventory is the Redfish SoftwareInventory resource of a firmware
+// image or one of its components.
+type softwareInventory struct {
+	odataHeader
+	ID           string         `json:"Id"`
+	Name         string         `json:"Name"`
+	Description  string         `json:"Description,omitempty"`
+	Version      string         `json:"Version,omitempty"`
+	ReleaseDate  string         `json:"ReleaseDate,omitempty"`
+	Manufacturer string         `json:"Manufacturer,omitempty"`
+	Updateable   bool           `json:"Updateable"`
+	Status       resourceStatus `json:"Status"`
+	RelatedItem  []odataLink    `json:"RelatedItem"`
+}
+
+// updateParameters is the UpdateParameters part of a multipart update.
+type updateParameters struct {
+	Targets   []string `json:"Targets"`
+	ApplyTime string   `json:"@Redfish.OperationApplyTime"`
+}
+
+// simpleUpdateRequest is the body of a SimpleUpdate action.
+type simpleUpdateRequest struct {
+	ImageURI         string   `json:"ImageURI"`
+	TransferProtocol string   `json:"TransferProtocol"`
+	Targets          []string `json:"Targets"`
+	Username         string   `json:"Username"`
+	Password         string   `json:"Password"`
+	ApplyTime        string   `json:"@Redfish.OperationApplyTime"`
+}
+
+// newSoftwareInventory creates the inventory of the firmware fw of the
+// resource id below path. The firmware itself is the updateable member named
+// id, its components are read-only members named <id>.<component>.
+func newSoftwareInventory(id, path string, fw *schemav1alpha1.Firmware) []*softwareInventory {
+	related := []odataLink{link(path + "/" + url.PathEscape(id))}
+
+	inventory := make([]*softwareInventory, 0, 1+len(fw.GetComponents()))
+	inventory = append(inventory, &softwareInventory{
+		odataHeader:  odataHeader{ODataID: firmwareInventoryPath + "/" + url.PathEscape(id), ODataType: odataTypeSoftwareInventory},
+		ID:           id,
+		Name:         id + " Firmware",
+		Description:  fw.GetFirmwareType(),
+		Version:      fw.GetVersion(),
+		ReleaseDate:  firmwareDate(fw.GetBuildDate()),
+		Manufacturer: fw.GetVendor(),
+		Updateable:   true,
+		Status:       resourceStatus{State: "Enabled", Health: "OK"},
+		RelatedItem:  related,
+	})
+
+	for _, c := range fw.GetComponents() {
+		cid := id + "." + c.GetName()
+		inventory = append(inventory, &softwareInventory{
+			odataHeader: odataHeader{ODataID: firmwareInventoryPath + "/" + url.PathEscape(cid), ODataType: odataTypeSoftwareInventory},
+			ID:          cid,
+			Name:        c.GetName(),
+			Description: c.GetType(),
+			Version:     c.GetVersion(),
+			ReleaseDate: firmwareDate(c.GetBuildDate()),
+			Status:      firmwareComponentStatus(c.GetStatus()),
+			RelatedItem: related,
+		})
+	}
+
+	return inventory
+}
+
+// firmwareDate formats a firmware build date, which may be unset.
+func firmwareDate(date *timestamppb.Timestamp) string {
+	if date == nil {
+		return ""
+	}
+	return date.AsTime().UTC().Format(time.RFC3339)
+}
+
+// firmwareComponentStatus maps the free-form status of a firmware component to
+// the Redfish status.
+func firmwareComponentStatus(status string) resourceStatus {
+	switch strings.ToLower(status) {
+	case "", "ok", "active", "enabled", "running":
+		return resourceStatus{State: "Enabled", Health: "OK"}
+	case "inactive", "disabled", "standby":
+		return resourceStatus{State: "Disabled", Health: "OK"}
+	case "error", "failed", "critical", "corrupted":
+		return resourceStatus{State: "Enabled", Health: "Critical"}
+	default:
+		return resourceStatus{State: "Enabled", Health: "Warning"}
+	}
+}
+
+func (s *redfishServer) registerUpdateService() {
+	s.handle(http.MethodGet, updateServicePath, s.handleUpdateService)
+	s.handle(http.MethodPost, multipartUpdatePath, s.handleMultipartUpdate)
+	s.handle(http.MethodPost, updateServicePath+"/Actions/"+actionSimpleUpdate, s.handleSimpleUpdate)
+	s.handle(http.MethodGet, firmwareInventoryPath, s.handleFirmwareInventory)
+	s.handle(http.MethodGet, firmwareInventoryPath+"/{id}", s.handleSoftwareInventory)
+}
+
+func (s *redfishServer) handleUpdateService(w http.ResponseWriter, r *http.Request) {
+	res := &updateService{
+		odataHeader:          odataHeader{ODataID: updateServicePath, ODataType: odataTypeUpdateService},
+		ID:                   updateServiceResourceName,
+		Name:                 "Update Service",
+		ServiceEnabled:       true,
+		MultipartHTTPPushURI: multipartUpdatePath,
+		MaxImageSizeBytes:    s.config.redfishMaxImageSize,
+		FirmwareInventory:    link(firmwareInventoryPath),
+		Status:               resourceStatus{State: "Enabled", Health: "OK"},
+	}
+	res.Actions.SimpleUpdate = simpleUpdateAction{
+		Target:            updateServicePath + "/Actions/" + actionSimpleUpdate,
+		TransferProtocols: updateTransferProtocols(),
+	}
+	s.writeResource(w, r, res)
+}
+
+// firmwareInventory collects the software inventory of all BMCs and hosts.
+func (s *redfishServer) firmwareInventory(r *http.Request) ([]*softwareInventory, error) {
+	var managers schemav1alpha1.ListManagementControllersResponse
+	if err := s.requestNATS(r.Context(), ipc.SubjectBMCList, &schemav1alpha1.ListManagementControllersRequest{}, &managers); err != nil {
+		return nil, err
+	}
+
+	var hosts schemav1alpha1.ListHostsResponse
+	if err := s.requestNATS(r.Context(), ipc.SubjectHostList, &schemav1alpha1.ListHostsRequest{}, &hosts); err != nil {
+		return nil, err
+	}
+
+	var inventory []*softwareInventory
+	for _, m := range managers.GetControllers() {
+		inventory = append(inventory, newSoftwareInventory(m.GetName(), managersPath, m.GetFirmware())...)
+	}
+	for _, h := range hosts.GetHosts() {
+		inventory = append(inventory, newSoftwareInventory(h.GetName(), systemsPath, h.GetFirmware())...)
+	}
+	return inventory, nil
+}
+
+func (s *redfishServer) handleFirmwareInventory(w http.ResponseWriter, r *http.Request) {
+	inventory, err := s.firmwareInventory(r)
+	if err != nil {
+		s.writeRequestError(w, r, err, "SoftwareInventoryCollection", "FirmwareInventory")
+		return
+	}
+
+	ids := make([]string, 0, len(inventory))
+	for _, item := range inventory {
+		ids = append(ids, item.ID)
+	}
+
+	s.writeResource(w, r, newCollection(firmwareInventoryPath, odataTypeSoftwareInventoryCollection, "Firmware Inventory Collection", ids))
+}
+
+func (s *redfishServer) handleSoftwareInventory(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	inventory, err := s.firmwareInventory(r)
+	if err != nil {
+		s.writeRequestError(w, r, err, softwareInventoryResourceName, id)
+		return
+	}
+
+	for _, item := range inventory {
+		if item.ID == id {
+			s.writeResource(w, r, item)
+			return
+		}
+	}
+	s.writeRequestError(w, r, ErrNotFound, softwareInventoryResourceName, id)
+}
+
+// updateTargets maps the URIs of an update request, which reference
+// FirmwareInventory members, Managers or Systems, to update targets. It
+// returns the first URI that does not reference an updateable target.
+func (s *redfishServer) updateTargets(r *http.Request, uris []string) ([]string, string, error) {
+	if len(uris) == 0 {
+		return nil, "", nil
+	}
+
+	inventory, err := s.firmwareInventory(r)
+	if err != nil {
+		return nil, "", err
+	}
+
+	targets := make([]string, 0, len(uris))
+	for _, uri := range uris {
+		id, ok := "", false
+		for _, prefix := range []string{firmwareInventoryPath, managersPath, systemsPath} {
+			if rest, found := strings.CutPrefix(strings.TrimRight(uri, "/"), prefix+"/"); found {
+				id, ok = rest, true
+				break
+			}
+		}
+		if ok {
+			id, err = url.PathUnescape(id)
+			ok = err == nil
+		}
+		if ok {
+			ok = slices.ContainsFunc(inventory, func(item *softwareInventory) bool {
+				return item.ID == id && item.Updateable
+			})
+		}
+		if !ok {
+			return nil, uri, nil
+		}
+		targets = append(targets, id)
+	}
+	return targets, "", nil
+}
+
+func (s *redfishServer) handleMultipartUpdate(w http.ResponseWriter, r *http.Request) {
+	rc := http.NewResponseController(w)
+	_ = rc.SetReadDeadline(time.Now().Add(updateUploadTimeout))
+	_ = rc.SetWriteDeadline(time.Now().Add(updateUploadTimeout))
+
+	r.Body = http.MaxBytesReader(w, r.Body, s.config.redfishMaxImageSize+redfishMaxBodySize)
+	reader, err := r.MultipartReader()
+	if err != nil {
+		s.logger.WarnContext(r.Context(), "Redfish update rejected", "error", err)
+		s.writeError(w, http.StatusUnsupportedMediaType, "GeneralError", messageGeneralError)
+		return
+	}
+
+	var params updateParameters
+	var image string
+	var signature []byte
+	defer func() {
+		if image != "" {
+			_ = os.Remove(image)
+			_ = os.Remove(image + updateSignatureSuffix)
+		}
+	}()
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			s.logger.WarnContext(r.Context(), "Redfish update rejected", "error", err)
+			s.writeError(w, http.StatusBadRequest, "GeneralError", messageGeneralError)
+			return
+		}
+
+		switch part.FormName() {
+		case partUpdateParameters:
+			body, err := io.ReadAll(io.LimitReader(part, redfishMaxBodySize))
+			if err == nil {
+				err = json.Unmarshal(body, &params)
+			}
+			if err != nil {
+				s.writeError(w, http.StatusBadRequest, "MalformedJSON",
+					"The request body submitted was malformed JSON and could not be parsed by the receiving service.")
+				return
+			}
+		case partUpdateFile:
+			if image != "" {
+				s.writeError(w, http.StatusBadRequest, "GeneralError", messageGeneralError)
+				return
+			}
+			if image, err = s.stageImage(part); err != nil {
+				if errors.Is(err, ErrImageTooLarge) {
+					s.writeError(w, http.StatusRequestEntityTooLarge, "GeneralError", messageGeneralError)
+					return
+				}
+				s.writeInternalError(w, r, err)
+				return
+			}
+		case partUpdateSignature:
+			body, err := io.ReadAll(io.LimitReader(part, updateSignatureMaxSize+1))
+			if err != nil || signature != nil || len(body) > updateSignatureMaxSize {
+				s.writeError(w, http.StatusBadRequest, "GeneralError", messageGeneralError)
+				return
+			}
+			signature = body
+		}
+		_ = part.Close()
+	}
+
+	if image == "" {
+		s.writeError(w, http.StatusBadRequest, "PropertyMissing",
+			fmt.Sprintf("The property %s is a required property and must be included in the request.", partUpdateFile), partUpdateFile)
+		return
+	}
+	if signature != nil {
+		if err := os.WriteFile(image+updateSignatureSuffix, signature, 0o600); err != nil {
+			s.writeInternalError(w, r, err)
+			return
+		}
+	}
+	if params.ApplyTime != "" && !s.checkPropertyValues(w, parameterApplyTime, []string{params.ApplyTime}, updateApplyTimes()) {
+		return
+	}
+
+	targets, invalid, err := s.updateTargets(r, params.Targets)
+	if err != nil {
+		s.writeRequestError(w, r, err, updateServiceResourceName, updateServiceResourceName)
+		return
+	}
+	if invalid != "" {
+		s.writeError(w, http.StatusBadRequest, "PropertyValueNotInList",
+			fmt.Sprintf("The value '%s' for the property %s is not in the list of acceptable values.", invalid, parameterTargets),
+			invalid, parameterTargets)
+		return
+	}
+
+	if s.startUpdate(w, r, &updateStartRequest{ImagePath: image, Targets: targets, ApplyTime: params.ApplyTime}) {
+		image = ""
+	}
+}
+
+// stageImage stores the image read from part in the staging directory shared
+// with the update manager.
+func (s *redfishServer) stageImage(part io.Reader) (string, error) {
+	if err := os.MkdirAll(s.config.redfishUpdateStagingDir, 0o750); err != nil {
+		return "", err
+	}
+	f, err := os.CreateTemp(s.config.redfishUpdateStagingDir, "upload-*.img")
+	if err != nil {
+		return "", err
+	}
+
+	n, err := io.Copy(f, io.LimitReader(part, s.config.redfishMaxImageSize+1))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && n > s.config.redfishMaxImageSize {
+		err = fmt.Errorf("%w: more than %d bytes", ErrImageTooLarge, s.config.redfishMaxImageSize)
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+func (s *redfishServer) handleSimpleUpdate(w http.ResponseWriter, r *http.Request) {
+	var req simpleUpdateRequest
+	if !s.readAction(w, r, &req) {
+		return
+	}
+
+	if req.ImageURI == "" {
+		s.writeError(w, http.StatusBadRequest, "ActionParameterMissing",
+			fmt.Sprintf("The action %s requires the parameter ImageURI to be present in the request body.", actionSimpleUpdate),
+			actionSimpleUpdate, "ImageURI")
+		return
+	}
+	for _, param := range []struct {
+		name, value string
+		allowed     []string
+	}{
+		{"TransferProtocol", req.TransferProtocol, updateTransferProtocols()},
+		{parameterApplyTime, req.ApplyTime, updateApplyTimes()},
+	} {
+		if param.value != "" && !slices.Contains(param.allowed, param.value) {
+			s.writeError(w, http.StatusBadRequest, "ActionParameterValueNotInList",
+				fmt.Sprintf("The value '%s' for the parameter %s in the action %s is not in the list of acceptable values.",
+					param.value, param.name, actionSimpleUpdate), param.value, param.name, actionSimpleUpdate)
+			return
+		}
+	}
+
+	// An ImageURI without scheme is fetched with the given transfer protocol.
+	imageURI := req.ImageURI
+	if !strings.Contains(imageURI, "://") && req.TransferProtocol != "" {
+		imageURI = strings.ToLower(req.TransferProtocol) + "://" + imageURI
+	}
+	if uri, err := url.Parse(imageURI); err != nil || !slices.Contains(updateTransferProtocols(), strings.ToUpper(uri.Scheme)) || uri.Host == "" {
+		s.writeError(w, http.StatusBadRequest, "ActionParameterValueFormatError",
+			fmt.Sprintf("The value '%s' for the parameter %s in the action %s is of a different format than the parameter can accept.",
+				req.ImageURI, "ImageURI", actionSimpleUpdate), req.ImageURI, "ImageURI", actionSimpleUpdate)
+		return
+	}
+
+	targets, invalid, err := s.updateTargets(r, req.Targets)
+	if err != nil {
+		s.writeRequestError(w, r, err, updateServiceResourceName, updateServiceResourceName)
+		return
+	}
+	if invalid != "" {
+		s.writeError(w, http.StatusBadRequest, "ActionParameterValueNotInList",
+			fmt.Sprintf("The value '%s' for the parameter %s in the action %s is not in the list of acceptable values.",
+				invalid, parameterTargets, actionSimpleUpdate), invalid, parameterTargets, actionSimpleUpdate)
+		return
+	}
+
+	s.startUpdate(w, r, &updateStartRequest{
+		ImageURI:  imageURI,
+		Username:  req.Username,
+		Password:  req.Password,
+		Targets:   targets,
+		ApplyTime: req.ApplyTime,
+	})
+}
+
+// startUpdate starts an update job and answers with the task tracking it. It
+// reports whether the update manager accepted the job, which then owns the
+// staged image of req.
+func (s *redfishServer) startUpdate(w http.ResponseWriter, r *http.Request, req *updateStartRequest) bool {
+	var job updateJob
+	if err := s.requestJSON(r.Context(), ipc.SubjectUpdateStart, req, &job); err != nil {
+		s.writeRequestError(w, r, err, updateServiceResourceName, updateServiceResourceName)
+		return false
+	}
+
+	s.logger.InfoContext(r.Context(), "Redfish update started",
+		"task", job.ID,
+		"targets", job.Targets,
+		"image_uri", job.ImageURI,
+		"user", requestSession(r).username)
+
+	res := newTask(&job)
+	w.Header().Set("Location", res.TaskMonitor)
+	w.Header().Set("Content-Type", contentTypeJSON)
+	w.WriteHeader(http.StatusAccepted)
+	_ = json.NewEncoder(w).Encode(res)
+	return true
+}
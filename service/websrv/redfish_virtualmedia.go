@@ -0,0 +1,360 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+package websrv
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/u-bmc/u-bmc/pkg/ipc"
+)
+
+// VirtualMedia resource types.
+const (
+	odataTypeVirtualMediaCollection = "#VirtualMediaCollection.VirtualMediaCollection"
+	odataTypeVirtualMedia           = "#VirtualMedia.v1_6_2.VirtualMedia"
+	actionInsertMedia               = "VirtualMedia.InsertMedia"
+	actionEjectMedia                = "VirtualMedia.EjectMedia"
+	virtualMediaResourceName        = "VirtualMedia"
+	transferMethodUpload            = "Upload"
+)
+
+// Virtual media states of the KVM server.
+const (
+	mediaStateEjected   = "ejected"
+	mediaStateInserting = "inserting"
+)
+
+// virtualMediaSlot is a VirtualMedia resource. All slots share the single
+// USB mass storage function, so only one of them can hold media at a time.
+type virtualMediaSlot struct {
+	id         string
+	cdrom      bool
+	mediaTypes []string
+}
+
+// virtualMediaSlots returns the VirtualMedia resources of the BMC.
+func virtualMediaSlots() []virtualMediaSlot {
+	return []virtualMediaSlot{
+		{id: "CD1", cdrom: true, mediaTypes: []string{"CD", "DVD"}},
+		{id: "USB1", cdrom: false, mediaTypes: []string{"USBStick"}},
+	}
+}
+
+// mediaTransferProtocols returns the transfer protocols InsertMedia supports
+// for remote images.
+func mediaTransferProtocols() []string {
+	return []string{"HTTP", "HTTPS"}
+}
+
+// mediaTransferMethods returns the transfer methods InsertMedia supports.
+// Remote images are downloaded to the BMC before they are presented.
+func mediaTransferMethods() []string {
+	return []string{transferMethodUpload}
+}
+
+// mediaStatus mirrors the KVM server virtual media status.
+type mediaStatus struct {
+	Available      bool   `json:"available"`
+	State          string `json:"state"`
+	Image          string `json:"image,omitempty"`
+	ImageName      string `json:"image_name,omitempty"`
+	CDROM          bool   `json:"cdrom"`
+	WriteProtected bool   `json:"write_protected"`
+	Message        string `json:"message,omitempty"`
+}
+
+// insertMediaRequest mirrors the KVM server request inserting virtual media.
+type insertMediaRequest struct {
+	Image          string `json:"image"`
+	Username       string `json:"username,omitempty"`
+	Password       string `json:"password,omitempty"`
+	CDROM          bool   `json:"cdrom"`
+	WriteProtected bool   `json:"write_protected"`
+}
+
+// ejectMediaRequest mirrors the KVM server request ejecting virtual media.
+type ejectMediaRequest struct {
+	CDROM bool `json:"cdrom"`
+}
+
+// insertMediaAction is the InsertMedia action with its allowable transfer
+// methods and protocols.
+type insertMediaAction struct {
+	Target                string   `json:"target"`
+	TransferMethods       []string `json:"TransferMethod@Redfish.AllowableValues"`
+	TransferProtocolTypes []string `json:"TransferProtocolType@Redfish.AllowableValues"`
+}
+
+// virtualMediaResource is the Redfish VirtualMedia resource.
+type virtualMediaResource struct {
+	odataHeader
+	ID                   string         `json:"Id"`
+	Name                 string         `json:"Name"`
+	MediaTypes           []string       `json:"MediaTypes"`
+	Image                *string        `json:"Image"`
+	ImageName            *string        `json:"ImageName"`
+	Inserted             bool           `json:"Inserted"`
+	WriteProtected       bool           `json:"WriteProtected"`
+	ConnectedVia         string         `json:"ConnectedVia"`
+	TransferMethod       *string        `json:"TransferMethod"`
+	TransferProtocolType *string        `json:"TransferProtocolType"`
+	Status               resourceStatus `json:"Status"`
+	Actions              struct {
+		InsertMedia insertMediaAction `json:"#VirtualMedia.InsertMedia"`
+		EjectMedia  actionTarget      `json:"#VirtualMedia.EjectMedia"`
+	} `json:"Actions"`
+}
+
+// insertMediaParameters is the body of an InsertMedia action.
+type insertMediaParameters struct {
+	Image                string `json:"Image"`
+	Inserted             *bool  `json:"Inserted"`
+	WriteProtected       *bool  `json:"WriteProtected"`
+	TransferMethod       string `json:"TransferMethod"`
+	TransferProtocolType string `json:"TransferProtocolType"`
+	UserName             string `json:"UserName"`
+	Password             string `json:"Password"`
+}
+
+// newVirtualMedia creates the VirtualMedia resource of slot below path from
+// the virtual media status.
+func newVirtualMedia(path string, slot virtualMediaSlot, status *mediaStatus) *virtualMediaResource {
+	res := &virtualMediaResource{
+		odataHeader:    odataHeader{ODataID: path, ODataType: odataTypeVirtualMedia},
+		ID:             slot.id,
+		Name:           "Virtual Media " + slot.id,
+		MediaTypes:     slot.mediaTypes,
+		WriteProtected: true,
+		ConnectedVia:   "NotConnected",
+		Status:         resourceStatus{State: "Enabled", Health: "OK"},
+	}
+	res.Actions.InsertMedia = insertMediaAction{
+		Target:                path + "/Actions/" + actionInsertMedia,
+		TransferMethods:       mediaTransferMethods(),
+		TransferProtocolTypes: mediaTransferProtocols(),
+	}
+	res.Actions.EjectMedia = actionTarget{Target: path + "/Actions/" + actionEjectMedia}
+
+	switch {
+	case !status.Available:
+		res.Status.State = "Disabled"
+		return res
+	case status.State == mediaStateEjected || status.CDROM != slot.cdrom:
+		if status.Message != "" && status.CDROM == slot.cdrom {
+			res.Status.Health = "Warning"
+		}
+		return res
+	}
+
+	image, name, method := status.Image, status.ImageName, transferMethodUpload
+	res.Image = &image
+	res.ImageName = &name
+	res.TransferMethod = &method
+	res.WriteProtected = status.WriteProtected
+	res.ConnectedVia = "URI"
+	if uri, err := url.Parse(image); err == nil && slices.Contains(mediaTransferProtocols(), strings.ToUpper(uri.Scheme)) {
+		protocol := strings.ToUpper(uri.Scheme)
+		res.TransferProtocolType = &protocol
+	}
+	if status.State == mediaStateInserting {
+		res.Status.State = "Starting"
+	} else {
+		res.Inserted = true
+	}
+
+	return res
+}
+
+// validMediaImage reports whether image is an HTTP or HTTPS URL, or an
+// absolute path on the BMC, optionally as file URL.
+func validMediaImage(image string) bool {
+	uri, err := url.Parse(image)
+	if err != nil {
+		return false
+	}
+
+	switch uri.Scheme {
+	case "", "file":
+		return uri.Host == "" && strings.HasPrefix(uri.Path, "/")
+	default:
+		return uri.Host != "" && slices.Contains(mediaTransferProtocols(), strings.ToUpper(uri.Scheme))
+	}
+}
+
+// virtualMediaSlotOf returns the slot with the given ID.
+func virtualMediaSlotOf(id string) (virtualMediaSlot, bool) {
+	for _, slot := range virtualMediaSlots() {
+		if slot.id == id {
+			return slot, true
+		}
+	}
+	return virtualMediaSlot{}, false
+}
+
+func (s *redfishServer) registerVirtualMedia() {
+	path := managersPath + "/{id}/VirtualMedia"
+	s.handle(http.MethodGet, path, s.handleVirtualMediaCollection)
+	s.handle(http.MethodGet, path+"/{mediaId}", s.handleVirtualMedia)
+	s.handlePrivileged(http.MethodPost, path+"/{mediaId}/Actions/"+actionInsertMedia, privilegeConfigureManager, s.handleInsertMedia)
+	s.handlePrivileged(http.MethodPost, path+"/{mediaId}/Actions/"+actionEjectMedia, privilegeConfigureManager, s.handleEjectMedia)
+}
+
+// virtualMediaSlot resolves the VirtualMedia resource of a request, writing
+// an error response if it does not exist. Virtual media is only provided by
+// the BMC running this service.
+func (s *redfishServer) virtualMediaSlot(w http.ResponseWriter, r *http.Request) (virtualMediaSlot, bool) {
+	id, mediaID := r.PathValue("id"), r.PathValue("mediaId")
+
+	primary, ok := s.primaryManager(w, r, id)
+	if !ok {
+		return virtualMediaSlot{}, false
+	}
+	slot, found := virtualMediaSlotOf(mediaID)
+	if !primary || !found {
+		s.writeRequestError(w, r, ErrNotFound, virtualMediaResourceName, mediaID)
+		return virtualMediaSlot{}, false
+	}
+
+	return slot, true
+}
+
+func (s *redfishServer) handleVirtualMediaCollection(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	primary, ok := s.primaryManager(w, r, id)
+	if !ok {
+		return
+	}
+	if !primary {
+		s.writeRequestError(w, r, ErrNotFound, "VirtualMediaCollection", id)
+		return
+	}
+
+	slots := virtualMediaSlots()
+	ids := make([]string, 0, len(slots))
+	for _, slot := range slots {
+		ids = append(ids, slot.id)
+	}
+
+	path := managersPath + "/" + url.PathEscape(id) + "/VirtualMedia"
+	s.writeResource(w, r, newCollection(path, odataTypeVirtualMediaCollection, "Virtual Media Collection", ids))
+}
+
+func (s *redfishServer) handleVirtualMedia(w http.ResponseWriter, r *http.Request) {
+	slot, ok := s.virtualMediaSlot(w, r)
+	if !ok {
+		return
+	}
+
+	var status mediaStatus
+	if err := s.requestJSON(r.Context(), ipc.SubjectVirtualMediaInfo, struct{}{}, &status); err != nil {
+		s.writeRequestError(w, r, err, virtualMediaResourceName, slot.id)
+		return
+	}
+
+	path := managersPath + "/" + url.PathEscape(r.PathValue("id")) + "/VirtualMedia/" + slot.id
+	s.writeResource(w, r, newVirtualMedia(path, slot, &status))
+}
+
+func (s *redfishServer) handleInsertMedia(w http.ResponseWriter, r *http.Request) {
+	slot, ok := s.virtualMediaSlot(w, r)
+	if !ok {
+		return
+	}
+
+	var params insertMediaParameters
+	if !s.readAction(w, r, &params) {
+		return
+	}
+
+	if params.Image == "" {
+		s.writeError(w, http.StatusBadRequest, "ActionParameterMissing",
+			fmt.Sprintf("The action %s requires the parameter Image to be present in the request body.", actionInsertMedia),
+			actionInsertMedia, "Image")
+		return
+	}
+	if params.Inserted != nil && !*params.Inserted {
+		s.writeError(w, http.StatusBadRequest, "ActionParameterNotSupported",
+			fmt.Sprintf("The parameter Inserted for the action %s is not supported on the target resource.", actionInsertMedia),
+			"Inserted", actionInsertMedia)
+		return
+	}
+	for _, param := range []struct {
+		name, value string
+		allowed     []string
+	}{
+		{"TransferMethod", params.TransferMethod, mediaTransferMethods()},
+		{"TransferProtocolType", params.TransferProtocolType, mediaTransferProtocols()},
+	} {
+		if param.value != "" && !slices.Contains(param.allowed, param.value) {
+			s.writeError(w, http.StatusBadRequest, "ActionParameterValueNotInList",
+				fmt.Sprintf("The value '%s' for the parameter %s in the action %s is not in the list of acceptable values.",
+					param.value, param.name, actionInsertMedia), param.value, param.name, actionInsertMedia)
+			return
+		}
+	}
+
+	// An Image without scheme is fetched with the given transfer protocol,
+	// or else names a local image on the BMC.
+	image := params.Image
+	if !strings.Contains(image, "://") && params.TransferProtocolType != "" {
+		image = strings.ToLower(params.TransferProtocolType) + "://" + image
+	}
+	if !validMediaImage(image) {
+		s.writeError(w, http.StatusBadRequest, "ActionParameterValueFormatError",
+			fmt.Sprintf("The value '%s' for the parameter %s in the action %s is of a different format than the parameter can accept.",
+				params.Image, "Image", actionInsertMedia), params.Image, "Image", actionInsertMedia)
+		return
+	}
+
+	writeProtected := params.WriteProtected == nil || *params.WriteProtected
+	if slot.cdrom && !writeProtected {
+		s.writeError(w, http.StatusBadRequest, "ActionParameterValueNotInList",
+			fmt.Sprintf("The value '%s' for the parameter %s in the action %s is not in the list of acceptable values.",
+				"false", "WriteProtected", actionInsertMedia), "false", "WriteProtected", actionInsertMedia)
+		return
+	}
+
+	var status mediaStatus
+	if err := s.requestJSON(r.Context(), ipc.SubjectVirtualMediaInsert, &insertMediaRequest{
+		Image:          image,
+		Username:       params.UserName,
+		Password:       params.Password,
+		CDROM:          slot.cdrom,
+		WriteProtected: writeProtected,
+	}, &status); err != nil {
+		s.writeRequestError(w, r, err, virtualMediaResourceName, slot.id)
+		return
+	}
+
+	s.logger.InfoContext(r.Context(), "Redfish virtual media inserted",
+		"virtual_media", slot.id,
+		"image", status.Image,
+		"state", status.State,
+		"user", requestSession(r).username)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *redfishServer) handleEjectMedia(w http.ResponseWriter, r *http.Request) {
+	slot, ok := s.virtualMediaSlot(w, r)
+	if !ok {
+		return
+	}
+
+	var status mediaStatus
+	if err := s.requestJSON(r.Context(), ipc.SubjectVirtualMediaEject, &ejectMediaRequest{CDROM: slot.cdrom}, &status); err != nil {
+		s.writeRequestError(w, r, err, virtualMediaResourceName, slot.id)
+		return
+	}
+
+	s.logger.InfoContext(r.Context(), "Redfish virtual media ejected",
+		"virtual_media", slot.id,
+		"user", requestSession(r).username)
+
+	w.WriteHeader(http.StatusNoContent)
+}
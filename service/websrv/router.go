@@ -3,6 +3,7 @@
 package websrv
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strings"
@@ -20,7 +21,7 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
-func (s *WebSrv) setupRouter(nc *nats.Conn) (http.Handler, error) {
+func (s *WebSrv) setupRouter(ctx context.Context, nc *nats.Conn) (http.Handler, error) {
 	mux := http.NewServeMux()
 
 	// Create interceptors
@@ -34,15 +35,33 @@ func (s *WebSrv) setupRouter(nc *nats.Conn) (http.Handler, error) {
 		return nil, fmt.Errorf("%w: %w", ErrCreateOpenTelemetryInterceptor, err)
 	}
 
+	// Share sessions between the API, Redfish and single sign-on
+	sessions := newSessionStore(s.config.redfishSessionTimeout)
+	go expireSessions(ctx, sessions, s.logger)
+
+	// Single sign-on adds bearer tokens to the accepted API credentials
+	var sso *oidcServer
+	if s.config.oidc != nil {
+		sso, err = newOIDCServer(s.logger, s.config.oidc, sessions)
+		if err != nil {
+			return nil, err
+		}
+	}
+	interceptors := []connect.Interceptor{
+		newAPIAuthInterceptor(s.logger, sessions, sso),
+		validatorInterceptor,
+		otelInterceptor,
+	}
+
 	// Create the main proto server
-	protoServer := NewProtoServer(nc, s.logger)
+	protoServer := NewProtoServer(nc, s.logger, sessions)
 
 	// Setup gRPC/Connect services
 	services := []*vanguard.Service{
 		vanguard.NewService(
 			schemav1alpha1connect.NewBMCServiceHandler(
 				protoServer,
-				connect.WithInterceptors(validatorInterceptor, otelInterceptor),
+				connect.WithInterceptors(interceptors...),
 			),
 		),
 	}
@@ -53,6 +72,17 @@ func (s *WebSrv) setupRouter(nc *nats.Conn) (http.Handler, error) {
 		return nil, fmt.Errorf("%w: %w", ErrCreateTranscoder, err)
 	}
 
+	// Describe the REST transcoding for client generators
+	openAPI, err := newOpenAPIDocument("u-bmc API", "v1alpha1", schemav1alpha1connect.BMCServiceName)
+	if err != nil {
+		return nil, err
+	}
+	openAPIDoc, err := openAPIHandler(openAPI)
+	if err != nil {
+		return nil, err
+	}
+	mux.Handle(openAPIPath, openAPIDoc)
+
 	// Mount routes based on webui flag
 	if s.config.webui {
 		fileServer := http.FileServer(http.Dir(s.config.webuiPath))
@@ -72,10 +102,28 @@ func (s *WebSrv) setupRouter(nc *nats.Conn) (http.Handler, error) {
 	mux.Handle(grpcreflect.NewHandlerV1Alpha(reflector))
 	mux.Handle(grpchealth.NewHandler(healthCheck))
 
+	// Mount the Redfish service
+	if s.config.redfish {
+		redfish := newRedfishServer(nc, s.logger, s.config, sessions)
+		if err := redfish.start(ctx); err != nil {
+			return nil, err
+		}
+		mux.Handle("/redfish", redfish)
+		mux.Handle("/redfish/", redfish)
+	}
+
+	// Mount the single sign-on endpoints of the Web UI
+	allowedHeaders := append(connectcors.AllowedHeaders(), authTokenHeader)
+	if sso != nil {
+		sso.register(mux)
+		sso.start(ctx)
+		allowedHeaders = append(allowedHeaders, "Authorization")
+	}
+
 	// Apply CORS middleware
 	corsMiddleware := cors.New(cors.Options{
 		AllowedMethods: connectcors.AllowedMethods(),
-		AllowedHeaders: connectcors.AllowedHeaders(),
+		AllowedHeaders: allowedHeaders,
 		ExposedHeaders: connectcors.ExposedHeaders(),
 	})
 	handler := corsMiddleware.Handler(mux)
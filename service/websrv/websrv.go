@@ -11,6 +11,7 @@ import (
 	"github.com/lorenzosaino/go-sysctl"
 	"github.com/nats-io/nats.go"
 	"github.com/u-bmc/u-bmc/pkg/cert"
+	"github.com/u-bmc/u-bmc/pkg/ipc"
 	"github.com/u-bmc/u-bmc/pkg/log"
 	"github.com/u-bmc/u-bmc/service"
 	"go.opentelemetry.io/otel"
@@ -41,6 +42,25 @@ func New(opts ...Option) *WebSrv {
 		rmemMax:      "7500000",
 		wmemMax:      "7500000",
 		certConfig:   cert.NewConfig(),
+
+		redfish:        true,
+		redfishTimeout: 10 * time.Second,
+
+		redfishEventBucket:        "redfish_event_subscriptions",
+		redfishEventRetryAttempts: 3,
+		redfishEventRetryInterval: 30 * time.Second,
+
+		redfishSessionTimeout: 30 * time.Minute,
+
+		redfishUpdateStagingDir: "/var/lib/u-bmc/update",
+		redfishMaxImageSize:     64 << 20,
+
+		redfishTelemetryBucket: "redfish_metric_report_definitions",
+
+		redfishEventLogSubjects: []string{ipc.StreamSubjectEvents, ipc.StreamSubjectSystemEvents},
+
+		redfishAggregationBucket:   "redfish_aggregation_sources",
+		redfishAggregationInterval: time.Minute,
 	}
 	for _, opt := range opts {
 		opt.apply(cfg)
@@ -93,7 +113,7 @@ func (s *WebSrv) Run(ctx context.Context, ipcConn nats.InProcessConnProvider) er
 	}
 	defer nc.Drain() //nolint:errcheck
 
-	router, err := s.setupRouter(nc)
+	router, err := s.setupRouter(ctx, nc)
 	if err != nil {
 		span.RecordError(err)
 		return fmt.Errorf("%w: %w", ErrSetupRouter, err)
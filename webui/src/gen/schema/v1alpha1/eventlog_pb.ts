@@ -0,0 +1,306 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+// @generated by protoc-gen-es v2.6.3 with parameter "target=ts"
+// @generated from file schema/v1alpha1/eventlog.proto (package schema.v1alpha1, syntax proto3)
+/* eslint-disable */
+
+import type { GenEnum, GenFile, GenMessage } from "@bufbuild/protobuf/codegenv2";
+import { enumDesc, fileDesc, messageDesc } from "@bufbuild/protobuf/codegenv2";
+import { file_buf_validate_validate } from "../../buf/validate/validate_pb";
+import type { Timestamp } from "@bufbuild/protobuf/wkt";
+import { file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
+import type { Message } from "@bufbuild/protobuf";
+
+/**
+ * Describes the file schema/v1alpha1/eventlog.proto.
+ */
+export const file_schema_v1alpha1_eventlog: GenFile = /*@__PURE__*/
+  fileDesc("Ch5zY2hlbWEvdjFhbHBoYTEvZXZlbnRsb2cucHJvdG8SD3NjaGVtYS52MWFscGhhMSKMAwoTU3lzdGVtRXZlbnRMb2dFbnRyeRIcCglyZWNvcmRfaWQYASABKA1CCbpIBioEGP//AxItCgl0aW1lc3RhbXAYAiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEjoKCHNldmVyaXR5GAMgASgOMh4uc2NoZW1hLnYxYWxwaGExLkV2ZW50U2V2ZXJpdHlCCLpIBYIBAhABEjYKBnNvdXJjZRgEIAEoDjIcLnNjaGVtYS52MWFscGhhMS5FdmVudFNvdXJjZUIIukgFggECEAESFwoGb3JpZ2luGAUgASgJQge6SARyAhABEg8KB21lc3NhZ2UYBiABKAkSHAoLaXBtaV9yZWNvcmQYByABKAxCB7pIBHoCaBASFgoJc2Vuc29yX2lkGAggASgJSACIAQESFAoHcmVhZGluZxgJIAEoAUgBiAEBEhYKCXRocmVzaG9sZBgKIAEoAUgCiAEBQgwKCl9zZW5zb3JfaWRCCgoIX3JlYWRpbmdCDAoKX3RocmVzaG9sZCIeChxHZXRTeXN0ZW1FdmVudExvZ0luZm9SZXF1ZXN0IuUBCh1HZXRTeXN0ZW1FdmVudExvZ0luZm9SZXNwb25zZRIPCgdlbnRyaWVzGAEgASgNEhMKC21heF9lbnRyaWVzGAIgASgNEjYKDWxhc3RfYWRkaXRpb24YAyABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wSACIAQESMwoKbGFzdF9lcmFzZRgEIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXBIAYgBARIQCghvdmVyZmxvdxgFIAEoCEIQCg5fbGFzdF9hZGRpdGlvbkINCgtfbGFzdF9lcmFzZSI9Ch1HZXRTeXN0ZW1FdmVudExvZ0VudHJ5UmVxdWVzdBIcCglyZWNvcmRfaWQYASABKA1CCbpIBioEGP//AyJ4Ch5HZXRTeXN0ZW1FdmVudExvZ0VudHJ5UmVzcG9uc2USMwoFZW50cnkYASABKAsyJC5zY2hlbWEudjFhbHBoYTEuU3lzdGVtRXZlbnRMb2dFbnRyeRIhCg5uZXh0X3JlY29yZF9pZBgCIAEoDUIJukgGKgQY//8DIqsBCiBMaXN0U3lzdGVtRXZlbnRMb2dFbnRyaWVzUmVxdWVzdBIhCgttYXhfZW50cmllcxgBIAEoDUIHukgEKgIgAEgAiAEBEkMKDG1pbl9zZXZlcml0eRgCIAEoDjIeLnNjaGVtYS52MWFscGhhMS5FdmVudFNldmVyaXR5Qgi6SAWCAQIQAUgBiAEBQg4KDF9tYXhfZW50cmllc0IPCg1fbWluX3NldmVyaXR5IloKIUxpc3RTeXN0ZW1FdmVudExvZ0VudHJpZXNSZXNwb25zZRI1CgdlbnRyaWVzGAEgAygLMiQuc2NoZW1hLnYxYWxwaGExLlN5c3RlbUV2ZW50TG9nRW50cnkiHAoaQ2xlYXJTeXN0ZW1FdmVudExvZ1JlcXVlc3QiNgobQ2xlYXJTeXN0ZW1FdmVudExvZ1Jlc3BvbnNlEhcKD2NsZWFyZWRfZW50cmllcxgBIAEoDSp/Cg1FdmVudFNldmVyaXR5Eh4KGkVWRU5UX1NFVkVSSVRZX1VOU1BFQ0lGSUVEEAASFQoRRVZFTlRfU0VWRVJJVFlfT0sQARIaChZFVkVOVF9TRVZFUklUWV9XQVJOSU5HEAISGwoXRVZFTlRfU0VWRVJJVFlfQ1JJVElDQUwQAyqdAQoLRXZlbnRTb3VyY2USHAoYRVZFTlRfU09VUkNFX1VOU1BFQ0lGSUVEEAASFwoTRVZFTlRfU09VUkNFX1NFTlNPUhABEhUKEUVWRU5UX1NPVVJDRV9IT1NUEAISGAoURVZFTlRfU09VUkNFX0NIQVNTSVMQAxImCiJFVkVOVF9TT1VSQ0VfTUFOQUdFTUVOVF9DT05UUk9MTEVSEARCwAEKE2NvbS5zY2hlbWEudjFhbHBoYTFCDUV2ZW50bG9nUHJvdG9QAVo9Z2l0aHViLmNvbS91LWJtYy91LWJtYy9hcGkvZ2VuL3NjaGVtYS92MWFscGhhMTtzY2hlbWF2MWFscGhhMaICA1NYWKoCD1NjaGVtYS5WMWFscGhhMcoCD1NjaGVtYVxWMWFscGhhMeICG1NjaGVtYVxWMWFscGhhMVxHUEJNZXRhZGF0YeoCEFNjaGVtYTo6VjFhbHBoYTFiBnByb3RvMw", [file_buf_validate_validate, file_google_protobuf_timestamp]);
+
+/**
+ * @generated from message schema.v1alpha1.SystemEventLogEntry
+ */
+export type SystemEventLogEntry = Message<"schema.v1alpha1.SystemEventLogEntry"> & {
+  /**
+   * @generated from field: uint32 record_id = 1;
+   */
+  recordId: number;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp timestamp = 2;
+   */
+  timestamp?: Timestamp;
+
+  /**
+   * @generated from field: schema.v1alpha1.EventSeverity severity = 3;
+   */
+  severity: EventSeverity;
+
+  /**
+   * @generated from field: schema.v1alpha1.EventSource source = 4;
+   */
+  source: EventSource;
+
+  /**
+   * @generated from field: string origin = 5;
+   */
+  origin: string;
+
+  /**
+   * @generated from field: string message = 6;
+   */
+  message: string;
+
+  /**
+   * @generated from field: bytes ipmi_record = 7;
+   */
+  ipmiRecord: Uint8Array;
+
+  /**
+   * @generated from field: optional string sensor_id = 8;
+   */
+  sensorId?: string;
+
+  /**
+   * @generated from field: optional double reading = 9;
+   */
+  reading?: number;
+
+  /**
+   * @generated from field: optional double threshold = 10;
+   */
+  threshold?: number;
+};
+
+/**
+ * Describes the message schema.v1alpha1.SystemEventLogEntry.
+ * Use `create(SystemEventLogEntrySchema)` to create a new message.
+ */
+export const SystemEventLogEntrySchema: GenMessage<SystemEventLogEntry> = /*@__PURE__*/
+  messageDesc(file_schema_v1alpha1_eventlog, 0);
+
+/**
+ * @generated from message schema.v1alpha1.GetSystemEventLogInfoRequest
+ */
+export type GetSystemEventLogInfoRequest = Message<"schema.v1alpha1.GetSystemEventLogInfoRequest"> & {
+};
+
+/**
+ * Describes the message schema.v1alpha1.GetSystemEventLogInfoRequest.
+ * Use `create(GetSystemEventLogInfoRequestSchema)` to create a new message.
+ */
+export const GetSystemEventLogInfoRequestSchema: GenMessage<GetSystemEventLogInfoRequest> = /*@__PURE__*/
+  messageDesc(file_schema_v1alpha1_eventlog, 1);
+
+/**
+ * @generated from message schema.v1alpha1.GetSystemEventLogInfoResponse
+ */
+export type GetSystemEventLogInfoResponse = Message<"schema.v1alpha1.GetSystemEventLogInfoResponse"> & {
+  /**
+   * @generated from field: uint32 entries = 1;
+   */
+  entries: number;
+
+  /**
+   * @generated from field: uint32 max_entries = 2;
+   */
+  maxEntries: number;
+
+  /**
+   * @generated from field: optional google.protobuf.Timestamp last_addition = 3;
+   */
+  lastAddition?: Timestamp;
+
+  /**
+   * @generated from field: optional google.protobuf.Timestamp last_erase = 4;
+   */
+  lastErase?: Timestamp;
+
+  /**
+   * @generated from field: bool overflow = 5;
+   */
+  overflow: boolean;
+};
+
+/**
+ * Describes the message schema.v1alpha1.GetSystemEventLogInfoResponse.
+ * Use `create(GetSystemEventLogInfoResponseSchema)` to create a new message.
+ */
+export const GetSystemEventLogInfoResponseSchema: GenMessage<GetSystemEventLogInfoResponse> = /*@__PURE__*/
+  messageDesc(file_schema_v1alpha1_eventlog, 2);
+
+/**
+ * @generated from message schema.v1alpha1.GetSystemEventLogEntryRequest
+ */
+export type GetSystemEventLogEntryRequest = Message<"schema.v1alpha1.GetSystemEventLogEntryRequest"> & {
+  /**
+   * @generated from field: uint32 record_id = 1;
+   */
+  recordId: number;
+};
+
+/**
+ * Describes the message schema.v1alpha1.GetSystemEventLogEntryRequest.
+ * Use `create(GetSystemEventLogEntryRequestSchema)` to create a new message.
+ */
+export const GetSystemEventLogEntryRequestSchema: GenMessage<GetSystemEventLogEntryRequest> = /*@__PURE__*/
+  messageDesc(file_schema_v1alpha1_eventlog, 3);
+
+/**
+ * @generated from message schema.v1alpha1.GetSystemEventLogEntryResponse
+ */
+export type GetSystemEventLogEntryResponse = Message<"schema.v1alpha1.GetSystemEventLogEntryResponse"> & {
+  /**
+   * @generated from field: schema.v1alpha1.SystemEventLogEntry entry = 1;
+   */
+  entry?: SystemEventLogEntry;
+
+  /**
+   * @generated from field: uint32 next_record_id = 2;
+   */
+  nextRecordId: number;
+};
+
+/**
+ * Describes the message schema.v1alpha1.GetSystemEventLogEntryResponse.
+ * Use `create(GetSystemEventLogEntryResponseSchema)` to create a new message.
+ */
+export const GetSystemEventLogEntryResponseSchema: GenMessage<GetSystemEventLogEntryResponse> = /*@__PURE__*/
+  messageDesc(file_schema_v1alpha1_eventlog, 4);
+
+/**
+ * @generated from message schema.v1alpha1.ListSystemEventLogEntriesRequest
+ */
+export type ListSystemEventLogEntriesRequest = Message<"schema.v1alpha1.ListSystemEventLogEntriesRequest"> & {
+  /**
+   * @generated from field: optional uint32 max_entries = 1;
+   */
+  maxEntries?: number;
+
+  /**
+   * @generated from field: optional schema.v1alpha1.EventSeverity min_severity = 2;
+   */
+  minSeverity?: EventSeverity;
+};
+
+/**
+ * Describes the message schema.v1alpha1.ListSystemEventLogEntriesRequest.
+ * Use `create(ListSystemEventLogEntriesRequestSchema)` to create a new message.
+ */
+export const ListSystemEventLogEntriesRequestSchema: GenMessage<ListSystemEventLogEntriesRequest> = /*@__PURE__*/
+  messageDesc(file_schema_v1alpha1_eventlog, 5);
+
+/**
+ * @generated from message schema.v1alpha1.ListSystemEventLogEntriesResponse
+ */
+export type ListSystemEventLogEntriesResponse = Message<"schema.v1alpha1.ListSystemEventLogEntriesResponse"> & {
+  /**
+   * @generated from field: repeated schema.v1alpha1.SystemEventLogEntry entries = 1;
+   */
+  entries: SystemEventLogEntry[];
+};
+
+/**
+ * Describes the message schema.v1alpha1.ListSystemEventLogEntriesResponse.
+ * Use `create(ListSystemEventLogEntriesResponseSchema)` to create a new message.
+ */
+export const ListSystemEventLogEntriesResponseSchema: GenMessage<ListSystemEventLogEntriesResponse> = /*@__PURE__*/
+  messageDesc(file_schema_v1alpha1_eventlog, 6);
+
+/**
+ * @generated from message schema.v1alpha1.ClearSystemEventLogRequest
+ */
+export type ClearSystemEventLogRequest = Message<"schema.v1alpha1.ClearSystemEventLogRequest"> & {
+};
+
+/**
+ * Describes the message schema.v1alpha1.ClearSystemEventLogRequest.
+ * Use `create(ClearSystemEventLogRequestSchema)` to create a new message.
+ */
+export const ClearSystemEventLogRequestSchema: GenMessage<ClearSystemEventLogRequest> = /*@__PURE__*/
+  messageDesc(file_schema_v1alpha1_eventlog, 7);
+
+/**
+ * @generated from message schema.v1alpha1.ClearSystemEventLogResponse
+ */
+export type ClearSystemEventLogResponse = Message<"schema.v1alpha1.ClearSystemEventLogResponse"> & {
+  /**
+   * @generated from field: uint32 cleared_entries = 1;
+   */
+  clearedEntries: number;
+};
+
+/**
+ * Describes the message schema.v1alpha1.ClearSystemEventLogResponse.
+ * Use `create(ClearSystemEventLogResponseSchema)` to create a new message.
+ */
+export const ClearSystemEventLogResponseSchema: GenMessage<ClearSystemEventLogResponse> = /*@__PURE__*/
+  messageDesc(file_schema_v1alpha1_eventlog, 8);
+
+/**
+ * @generated from enum schema.v1alpha1.EventSeverity
+ */
+export enum EventSeverity {
+  /**
+   * @generated from enum value: EVENT_SEVERITY_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: EVENT_SEVERITY_OK = 1;
+   */
+  OK = 1,
+
+  /**
+   * @generated from enum value: EVENT_SEVERITY_WARNING = 2;
+   */
+  WARNING = 2,
+
+  /**
+   * @generated from enum value: EVENT_SEVERITY_CRITICAL = 3;
+   */
+  CRITICAL = 3,
+}
+
+/**
+ * Describes the enum schema.v1alpha1.EventSeverity.
+ */
+export const EventSeveritySchema: GenEnum<EventSeverity> = /*@__PURE__*/
+  enumDesc(file_schema_v1alpha1_eventlog, 0);
+
+/**
+ * @generated from enum schema.v1alpha1.EventSource
+ */
+export enum EventSource {
+  /**
+   * @generated from enum value: EVENT_SOURCE_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: EVENT_SOURCE_SENSOR = 1;
+   */
+  SENSOR = 1,
+
+  /**
+   * @generated from enum value: EVENT_SOURCE_HOST = 2;
+   */
+  HOST = 2,
+
+  /**
+   * @generated from enum value: EVENT_SOURCE_CHASSIS = 3;
+   */
+  CHASSIS = 3,
+
+  /**
+   * @generated from enum value: EVENT_SOURCE_MANAGEMENT_CONTROLLER = 4;
+   */
+  MANAGEMENT_CONTROLLER = 4,
+}
+
+/**
+ * Describes the enum schema.v1alpha1.EventSource.
+ */
+export const EventSourceSchema: GenEnum<EventSource> = /*@__PURE__*/
+  enumDesc(file_schema_v1alpha1_eventlog, 1);
+
@@ -0,0 +1,285 @@
+// SPDX-License-Identifier: BSD-3-Clause
+
+// @generated by protoc-gen-es v2.6.3 with parameter "target=ts"
+// @generated from file schema/v1alpha1/power.proto (package schema.v1alpha1, syntax proto3)
+/* eslint-disable */
+
+import type { GenEnum, GenFile, GenMessage } from "@bufbuild/protobuf/codegenv2";
+import { enumDesc, fileDesc, messageDesc } from "@bufbuild/protobuf/codegenv2";
+import { file_buf_validate_validate } from "../../buf/validate/validate_pb";
+import type { Duration, Timestamp } from "@bufbuild/protobuf/wkt";
+import { file_google_protobuf_duration, file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
+import type { Message } from "@bufbuild/protobuf";
+
+/**
+ * Describes the file schema/v1alpha1/power.proto.
+ */
+export const file_schema_v1alpha1_power: GenFile = /*@__PURE__*/
+  fileDesc("ChtzY2hlbWEvdjFhbHBoYTEvcG93ZXIucHJvdG8SD3NjaGVtYS52MWFscGhhMSLpAQoKUG93ZXJMaW1pdBITCgtsaW1pdF93YXR0cxgBIAEoDRJOChBleGNlcHRpb25fYWN0aW9uGAIgASgOMiouc2NoZW1hLnYxYWxwaGExLlBvd2VyTGltaXRFeGNlcHRpb25BY3Rpb25CCLpIBYIBAhABEjIKD2NvcnJlY3Rpb25fdGltZRgDIAEoCzIZLmdvb2dsZS5wcm90b2J1Zi5EdXJhdGlvbhIyCg9zYW1wbGluZ19wZXJpb2QYBCABKAsyGS5nb29nbGUucHJvdG9idWYuRHVyYXRpb24SDgoGYWN0aXZlGAUgASgIIuMBCgxQb3dlclJlYWRpbmcSFQoNY3VycmVudF93YXR0cxgBIAEoARIVCg1taW5pbXVtX3dhdHRzGAIgASgBEhUKDW1heGltdW1fd2F0dHMYAyABKAESFQoNYXZlcmFnZV93YXR0cxgEIAEoARItCgl0aW1lc3RhbXAYBSABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEjQKEXN0YXRpc3RpY3NfcGVyaW9kGAYgASgLMhkuZ29vZ2xlLnByb3RvYnVmLkR1cmF0aW9uEhIKCnNlbnNvcl9pZHMYByADKAkiGAoWR2V0UG93ZXJSZWFkaW5nUmVxdWVzdCJfChdHZXRQb3dlclJlYWRpbmdSZXNwb25zZRIuCgdyZWFkaW5nGAEgASgLMh0uc2NoZW1hLnYxYWxwaGExLlBvd2VyUmVhZGluZxIUCgxsaW1pdF9hY3RpdmUYAiABKAgiFgoUR2V0UG93ZXJMaW1pdFJlcXVlc3QiQwoVR2V0UG93ZXJMaW1pdFJlc3BvbnNlEioKBWxpbWl0GAEgASgLMhsuc2NoZW1hLnYxYWxwaGExLlBvd2VyTGltaXQi7QIKFFNldFBvd2VyTGltaXRSZXF1ZXN0EiEKC2xpbWl0X3dhdHRzGAEgASgNQge6SAQqAiAASACIAQESUwoQZXhjZXB0aW9uX2FjdGlvbhgCIAEoDjIqLnNjaGVtYS52MWFscGhhMS5Qb3dlckxpbWl0RXhjZXB0aW9uQWN0aW9uQgi6SAWCAQIQAUgBiAEBEjcKD2NvcnJlY3Rpb25fdGltZRgDIAEoCzIZLmdvb2dsZS5wcm90b2J1Zi5EdXJhdGlvbkgCiAEBEjcKD3NhbXBsaW5nX3BlcmlvZBgEIAEoCzIZLmdvb2dsZS5wcm90b2J1Zi5EdXJhdGlvbkgDiAEBEhMKBmFjdGl2ZRgFIAEoCEgEiAEBQg4KDF9saW1pdF93YXR0c0ITChFfZXhjZXB0aW9uX2FjdGlvbkISChBfY29ycmVjdGlvbl90aW1lQhIKEF9zYW1wbGluZ19wZXJpb2RCCQoHX2FjdGl2ZSJDChVTZXRQb3dlckxpbWl0UmVzcG9uc2USKgoFbGltaXQYASABKAsyGy5zY2hlbWEudjFhbHBoYTEuUG93ZXJMaW1pdCKVAQoPUG93ZXJMaW1pdEV2ZW50EioKBWxpbWl0GAEgASgLMhsuc2NoZW1hLnYxYWxwaGExLlBvd2VyTGltaXQSFQoNcmVhZGluZ193YXR0cxgCIAEoARIQCghleGNlZWRlZBgDIAEoCBItCgl0aW1lc3RhbXAYBCABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wKs0BChlQb3dlckxpbWl0RXhjZXB0aW9uQWN0aW9uEiwKKFBPV0VSX0xJTUlUX0VYQ0VQVElPTl9BQ1RJT05fVU5TUEVDSUZJRUQQABIlCiFQT1dFUl9MSU1JVF9FWENFUFRJT05fQUNUSU9OX05PTkUQARIvCitQT1dFUl9MSU1JVF9FWENFUFRJT05fQUNUSU9OX0hBUkRfUE9XRVJfT0ZGEAISKgomUE9XRVJfTElNSVRfRVhDRVBUSU9OX0FDVElPTl9MT0dfRVZFTlQQA0K9AQoTY29tLnNjaGVtYS52MWFscGhhMUIKUG93ZXJQcm90b1ABWj1naXRodWIuY29tL3UtYm1jL3UtYm1jL2FwaS9nZW4vc2NoZW1hL3YxYWxwaGExO3NjaGVtYXYxYWxwaGExogIDU1hYqgIPU2NoZW1hLlYxYWxwaGExygIPU2NoZW1hXFYxYWxwaGEx4gIbU2NoZW1hXFYxYWxwaGExXEdQQk1ldGFkYXRh6gIQU2NoZW1hOjpWMWFscGhhMWIGcHJvdG8z", [file_buf_validate_validate, file_google_protobuf_duration, file_google_protobuf_timestamp]);
+
+/**
+ * @generated from message schema.v1alpha1.PowerLimit
+ */
+export type PowerLimit = Message<"schema.v1alpha1.PowerLimit"> & {
+  /**
+   * @generated from field: uint32 limit_watts = 1;
+   */
+  limitWatts: number;
+
+  /**
+   * @generated from field: schema.v1alpha1.PowerLimitExceptionAction exception_action = 2;
+   */
+  exceptionAction: PowerLimitExceptionAction;
+
+  /**
+   * @generated from field: google.protobuf.Duration correction_time = 3;
+   */
+  correctionTime?: Duration;
+
+  /**
+   * @generated from field: google.protobuf.Duration sampling_period = 4;
+   */
+  samplingPeriod?: Duration;
+
+  /**
+   * @generated from field: bool active = 5;
+   */
+  active: boolean;
+};
+
+/**
+ * Describes the message schema.v1alpha1.PowerLimit.
+ * Use `create(PowerLimitSchema)` to create a new message.
+ */
+export const PowerLimitSchema: GenMessage<PowerLimit> = /*@__PURE__*/
+  messageDesc(file_schema_v1alpha1_power, 0);
+
+/**
+ * @generated from message schema.v1alpha1.PowerReading
+ */
+export type PowerReading = Message<"schema.v1alpha1.PowerReading"> & {
+  /**
+   * @generated from field: double current_watts = 1;
+   */
+  currentWatts: number;
+
+  /**
+   * @generated from field: double minimum_watts = 2;
+   */
+  minimumWatts: number;
+
+  /**
+   * @generated from field: double maximum_watts = 3;
+   */
+  maximumWatts: number;
+
+  /**
+   * @generated from field: double average_watts = 4;
+   */
+  averageWatts: number;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp timestamp = 5;
+   */
+  timestamp?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Duration statistics_period = 6;
+   */
+  statisticsPeriod?: Duration;
+
+  /**
+   * @generated from field: repeated string sensor_ids = 7;
+   */
+  sensorIds: string[];
+};
+
+/**
+ * Describes the message schema.v1alpha1.PowerReading.
+ * Use `create(PowerReadingSchema)` to create a new message.
+ */
+export const PowerReadingSchema: GenMessage<PowerReading> = /*@__PURE__*/
+  messageDesc(file_schema_v1alpha1_power, 1);
+
+/**
+ * @generated from message schema.v1alpha1.GetPowerReadingRequest
+ */
+export type GetPowerReadingRequest = Message<"schema.v1alpha1.GetPowerReadingRequest"> & {
+};
+
+/**
+ * Describes the message schema.v1alpha1.GetPowerReadingRequest.
+ * Use `create(GetPowerReadingRequestSchema)` to create a new message.
+ */
+export const GetPowerReadingRequestSchema: GenMessage<GetPowerReadingRequest> = /*@__PURE__*/
+  messageDesc(file_schema_v1alpha1_power, 2);
+
+/**
+ * @generated from message schema.v1alpha1.GetPowerReadingResponse
+ */
+export type GetPowerReadingResponse = Message<"schema.v1alpha1.GetPowerReadingResponse"> & {
+  /**
+   * @generated from field: schema.v1alpha1.PowerReading reading = 1;
+   */
+  reading?: PowerReading;
+
+  /**
+   * @generated from field: bool limit_active = 2;
+   */
+  limitActive: boolean;
+};
+
+/**
+ * Describes the message schema.v1alpha1.GetPowerReadingResponse.
+ * Use `create(GetPowerReadingResponseSchema)` to create a new message.
+ */
+export const GetPowerReadingResponseSchema: GenMessage<GetPowerReadingResponse> = /*@__PURE__*/
+  messageDesc(file_schema_v1alpha1_power, 3);
+
+/**
+ * @generated from message schema.v1alpha1.GetPowerLimitRequest
+ */
+export type GetPowerLimitRequest = Message<"schema.v1alpha1.GetPowerLimitRequest"> & {
+};
+
+/**
+ * Describes the message schema.v1alpha1.GetPowerLimitRequest.
+ * Use `create(GetPowerLimitRequestSchema)` to create a new message.
+ */
+export const GetPowerLimitRequestSchema: GenMessage<GetPowerLimitRequest> = /*@__PURE__*/
+  messageDesc(file_schema_v1alpha1_power, 4);
+
+/**
+ * @generated from message schema.v1alpha1.GetPowerLimitResponse
+ */
+export type GetPowerLimitResponse = Message<"schema.v1alpha1.GetPowerLimitResponse"> & {
+  /**
+   * @generated from field: schema.v1alpha1.PowerLimit limit = 1;
+   */
+  limit?: PowerLimit;
+};
+
+/**
+ * Describes the message schema.v1alpha1.GetPowerLimitResponse.
+ * Use `create(GetPowerLimitResponseSchema)` to create a new message.
+ */
+export const GetPowerLimitResponseSchema: GenMessage<GetPowerLimitResponse> = /*@__PURE__*/
+  messageDesc(file_schema_v1alpha1_power, 5);
+
+/**
+ * @generated from message schema.v1alpha1.SetPowerLimitRequest
+ */
+export type SetPowerLimitRequest = Message<"schema.v1alpha1.SetPowerLimitRequest"> & {
+  /**
+   * @generated from field: optional uint32 limit_watts = 1;
+   */
+  limitWatts?: number;
+
+  /**
+   * @generated from field: optional schema.v1alpha1.PowerLimitExceptionAction exception_action = 2;
+   */
+  exceptionAction?: PowerLimitExceptionAction;
+
+  /**
+   * @generated from field: optional google.protobuf.Duration correction_time = 3;
+   */
+  correctionTime?: Duration;
+
+  /**
+   * @generated from field: optional google.protobuf.Duration sampling_period = 4;
+   */
+  samplingPeriod?: Duration;
+
+  /**
+   * @generated from field: optional bool active = 5;
+   */
+  active?: boolean;
+};
+
+/**
+ * Describes the message schema.v1alpha1.SetPowerLimitRequest.
+ * Use `create(SetPowerLimitRequestSchema)` to create a new message.
+ */
+export const SetPowerLimitRequestSchema: GenMessage<SetPowerLimitRequest> = /*@__PURE__*/
+  messageDesc(file_schema_v1alpha1_power, 6);
+
+/**
+ * @generated from message schema.v1alpha1.SetPowerLimitResponse
+ */
+export type SetPowerLimitResponse = Message<"schema.v1alpha1.SetPowerLimitResponse"> & {
+  /**
+   * @generated from field: schema.v1alpha1.PowerLimit limit = 1;
+   */
+  limit?: PowerLimit;
+};
+
+/**
+ * Describes the message schema.v1alpha1.SetPowerLimitResponse.
+ * Use `create(SetPowerLimitResponseSchema)` to create a new message.
+ */
+export const SetPowerLimitResponseSchema: GenMessage<SetPowerLimitResponse> = /*@__PURE__*/
+  messageDesc(file_schema_v1alpha1_power, 7);
+
+/**
+ * @generated from message schema.v1alpha1.PowerLimitEvent
+ */
+export type PowerLimitEvent = Message<"schema.v1alpha1.PowerLimitEvent"> & {
+  /**
+   * @generated from field: schema.v1alpha1.PowerLimit limit = 1;
+   */
+  limit?: PowerLimit;
+
+  /**
+   * @generated from field: double reading_watts = 2;
+   */
+  readingWatts: number;
+
+  /**
+   * @generated from field: bool exceeded = 3;
+   */
+  exceeded: boolean;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp timestamp = 4;
+   */
+  timestamp?: Timestamp;
+};
+
+/**
+ * Describes the message schema.v1alpha1.PowerLimitEvent.
+ * Use `create(PowerLimitEventSchema)` to create a new message.
+ */
+export const PowerLimitEventSchema: GenMessage<PowerLimitEvent> = /*@__PURE__*/
+  messageDesc(file_schema_v1alpha1_power, 8);
+
+/**
+ * @generated from enum schema.v1alpha1.PowerLimitExceptionAction
+ */
+export enum PowerLimitExceptionAction {
+  /**
+   * @generated from enum value: POWER_LIMIT_EXCEPTION_ACTION_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: POWER_LIMIT_EXCEPTION_ACTION_NONE = 1;
+   */
+  NONE = 1,
+
+  /**
+   * @generated from enum value: POWER_LIMIT_EXCEPTION_ACTION_HARD_POWER_OFF = 2;
+   */
+  HARD_POWER_OFF = 2,
+
+  /**
+   * @generated from enum value: POWER_LIMIT_EXCEPTION_ACTION_LOG_EVENT = 3;
+   */
+  LOG_EVENT = 3,
+}
+
+/**
+ * Describes the enum schema.v1alpha1.PowerLimitExceptionAction.
+ */
+export const PowerLimitExceptionActionSchema: GenEnum<PowerLimitExceptionAction> = /*@__PURE__*/
+  enumDesc(file_schema_v1alpha1_power, 0);
+
@@ -16,6 +16,8 @@ import type { ChangeChassisStateRequestSchema, ChangeChassisStateResponseSchema,
 import { file_schema_v1alpha1_chassis } from "./chassis_pb";
 import type { ContactInfo } from "./contact_pb";
 import { file_schema_v1alpha1_contact } from "./contact_pb";
+import type { ClearSystemEventLogRequestSchema, ClearSystemEventLogResponseSchema, ListSystemEventLogEntriesRequestSchema, ListSystemEventLogEntriesResponseSchema } from "./eventlog_pb";
+import { file_schema_v1alpha1_eventlog } from "./eventlog_pb";
 import type { ChangeHostStateRequestSchema, ChangeHostStateResponseSchema, GetHostRequestSchema, GetHostResponseSchema, ListHostsRequestSchema, ListHostsResponseSchema, UpdateHostRequestSchema, UpdateHostResponseSchema } from "./host_pb";
 import { file_schema_v1alpha1_host } from "./host_pb";
 import type { ChangeManagementControllerStateRequestSchema, ChangeManagementControllerStateResponseSchema, GetManagementControllerRequestSchema, GetManagementControllerResponseSchema, ListManagementControllersRequestSchema, ListManagementControllersResponseSchema, UpdateManagementControllerRequestSchema, UpdateManagementControllerResponseSchema } from "./managementcontroller_pb";
@@ -24,7 +26,7 @@ import type { GetSensorRequestSchema, GetSensorResponseSchema, ListSensorsReques
 import { file_schema_v1alpha1_sensor } from "./sensor_pb";
 import type { GetThermalZoneRequestSchema, GetThermalZoneResponseSchema, ListThermalZonesRequestSchema, ListThermalZonesResponseSchema, SetThermalZoneRequestSchema, SetThermalZoneResponseSchema } from "./thermal_pb";
 import { file_schema_v1alpha1_thermal } from "./thermal_pb";
-import type { AuthenticateUserRequestSchema, AuthenticateUserResponseSchema, ChangePasswordRequestSchema, ChangePasswordResponseSchema, CreateUserRequestSchema, CreateUserResponseSchema, DeleteUserRequestSchema, DeleteUserResponseSchema, GetUserRequestSchema, GetUserResponseSchema, ListUsersRequestSchema, ListUsersResponseSchema, ResetPasswordRequestSchema, ResetPasswordResponseSchema, UpdateUserRequestSchema, UpdateUserResponseSchema } from "./user_pb";
+import type { AuthenticateUserRequestSchema, AuthenticateUserResponseSchema, ChangePasswordRequestSchema, ChangePasswordResponseSchema, ConfirmTotpRequestSchema, ConfirmTotpResponseSchema, CreateUserRequestSchema, CreateUserResponseSchema, DeleteUserRequestSchema, DeleteUserResponseSchema, DisableTotpRequestSchema, DisableTotpResponseSchema, EnrollTotpRequestSchema, EnrollTotpResponseSchema, GetUserRequestSchema, GetUserResponseSchema, ListUsersRequestSchema, ListUsersResponseSchema, RegenerateRecoveryCodesRequestSchema, RegenerateRecoveryCodesResponseSchema, ResetPasswordRequestSchema, ResetPasswordResponseSchema, UpdateUserRequestSchema, UpdateUserResponseSchema } from "./user_pb";
 import { file_schema_v1alpha1_user } from "./user_pb";
 import type { Message } from "@bufbuild/protobuf";
 
@@ -32,7 +34,7 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file schema/v1alpha1/system.proto.
  */
 export const file_schema_v1alpha1_system: GenFile = /*@__PURE__*/
-  fileDesc("ChxzY2hlbWEvdjFhbHBoYTEvc3lzdGVtLnByb3RvEg9zY2hlbWEudjFhbHBoYTEirAIKBkhlYWx0aBI3CgZzdGF0dXMYASABKA4yHS5zY2hlbWEudjFhbHBoYTEuSGVhbHRoU3RhdHVzQgi6SAWCAQIQARIfChJzdGF0dXNfZGVzY3JpcHRpb24YAiABKAlIAIgBARIuCgdkZXRhaWxzGAMgAygLMh0uc2NoZW1hLnYxYWxwaGExLkhlYWx0aERldGFpbBI1CgxsYXN0X3VwZGF0ZWQYBCABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wSAGIAQESLgoGdXB0aW1lGAUgASgLMhkuZ29vZ2xlLnByb3RvYnVmLkR1cmF0aW9uSAKIAQFCFQoTX3N0YXR1c19kZXNjcmlwdGlvbkIPCg1fbGFzdF91cGRhdGVkQgkKB191cHRpbWUixwEKDEhlYWx0aERldGFpbBIaCgljb21wb25lbnQYASABKAlCB7pIBHICEAESNwoGc3RhdHVzGAIgASgOMh0uc2NoZW1hLnYxYWxwaGExLkhlYWx0aFN0YXR1c0IIukgFggECEAESFAoHbWVzc2FnZRgDIAEoCUgAiAEBEjIKCXRpbWVzdGFtcBgEIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXBIAYgBAUIKCghfbWVzc2FnZUIMCgpfdGltZXN0YW1wIowECgpTeXN0ZW1JbmZvEhUKBG5hbWUYASABKAlCB7pIBHICEAESGAoLZGVzY3JpcHRpb24YAiABKAlIAIgBARI1CgVzdGF0ZRgDIAEoDjIcLnNjaGVtYS52MWFscGhhMS5TeXN0ZW1TdGF0ZUIIukgFggECEAESLwoGaGVhbHRoGAQgASgLMhcuc2NoZW1hLnYxYWxwaGExLkhlYWx0aEIGukgDyAEBEhQKB3ZlcnNpb24YBSABKAlIAYgBARIyCglib290X3RpbWUYBiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wSAKIAQESLgoGdXB0aW1lGAcgASgLMhkuZ29vZ2xlLnByb3RvYnVmLkR1cmF0aW9uSAOIAQESNwoMY29udGFjdF9pbmZvGAggASgLMhwuc2NoZW1hLnYxYWxwaGExLkNvbnRhY3RJbmZvSASIAQESOwoIbWV0YWRhdGEYCSADKAsyKS5zY2hlbWEudjFhbHBoYTEuU3lzdGVtSW5mby5NZXRhZGF0YUVudHJ5Gi8KDU1ldGFkYXRhRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgJOgI4AUIOCgxfZGVzY3JpcHRpb25CCgoIX3ZlcnNpb25CDAoKX2Jvb3RfdGltZUIJCgdfdXB0aW1lQg8KDV9jb250YWN0X2luZm8iWgoUR2V0U3lzdGVtSW5mb1JlcXVlc3QSMwoKZmllbGRfbWFzaxgBIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5GaWVsZE1hc2tIAIgBAUINCgtfZmllbGRfbWFzayJJChVHZXRTeXN0ZW1JbmZvUmVzcG9uc2USMAoLc3lzdGVtX2luZm8YASABKAsyGy5zY2hlbWEudjFhbHBoYTEuU3lzdGVtSW5mbyJWChBHZXRIZWFsdGhSZXF1ZXN0EjMKCmZpZWxkX21hc2sYASABKAsyGi5nb29nbGUucHJvdG9idWYuRmllbGRNYXNrSACIAQFCDQoLX2ZpZWxkX21hc2siPAoRR2V0SGVhbHRoUmVzcG9uc2USJwoGaGVhbHRoGAEgASgLMhcuc2NoZW1hLnYxYWxwaGExLkhlYWx0aCqVAQoMSGVhbHRoU3RhdHVzEh0KGUhFQUxUSF9TVEFUVVNfVU5TUEVDSUZJRUQQABIUChBIRUFMVEhfU1RBVFVTX09LEAESGQoVSEVBTFRIX1NUQVRVU19XQVJOSU5HEAISGgoWSEVBTFRIX1NUQVRVU19DUklUSUNBTBADEhkKFUhFQUxUSF9TVEFUVVNfVU5LTk9XThAEKuUBCgtTeXN0ZW1TdGF0ZRIcChhTWVNURU1fU1RBVEVfVU5TUEVDSUZJRUQQABIZChVTWVNURU1fU1RBVEVfU1RBUlRJTkcQARIYChRTWVNURU1fU1RBVEVfRU5BQkxFRBACEhkKFVNZU1RFTV9TVEFURV9ESVNBQkxFRBADEhgKFFNZU1RFTV9TVEFURV9TVEFOREJZEAQSGQoVU1lTVEVNX1NUQVRFX1FVSUVTQ0VEEAUSGAoUU1lTVEVNX1NUQVRFX0lOX1RFU1QQBhIZChVTWVNURU1fU1RBVEVfVVBEQVRJTkcQBzL6HwoKQk1DU2VydmljZRKBAQoNR2V0U3lzdGVtSW5mbxIlLnNjaGVtYS52MWFscGhhMS5HZXRTeXN0ZW1JbmZvUmVxdWVzdBomLnNjaGVtYS52MWFscGhhMS5HZXRTeXN0ZW1JbmZvUmVzcG9uc2UiIYLT5JMCGxIZL2FwaS92MWFscGhhMS9zeXN0ZW0vaW5mbxJ3CglHZXRIZWFsdGgSIS5zY2hlbWEudjFhbHBoYTEuR2V0SGVhbHRoUmVxdWVzdBoiLnNjaGVtYS52MWFscGhhMS5HZXRIZWFsdGhSZXNwb25zZSIjgtPkkwIdEhsvYXBpL3YxYWxwaGExL3N5c3RlbS9oZWFsdGgSeAoMR2V0QXNzZXRJbmZvEiQuc2NoZW1hLnYxYWxwaGExLkdldEFzc2V0SW5mb1JlcXVlc3QaJS5zY2hlbWEudjFhbHBoYTEuR2V0QXNzZXRJbmZvUmVzcG9uc2UiG4LT5JMCFRITL2FwaS92MWFscGhhMS9hc3NldBJ7CgxTZXRBc3NldEluZm8SJC5zY2hlbWEudjFhbHBoYTEuU2V0QXNzZXRJbmZvUmVxdWVzdBolLnNjaGVtYS52MWFscGhhMS5TZXRBc3NldEluZm9SZXNwb25zZSIegtPkkwIYOgEqGhMvYXBpL3YxYWxwaGExL2Fzc2V0EnsKCkdldENoYXNzaXMSIi5zY2hlbWEudjFhbHBoYTEuR2V0Q2hhc3Npc1JlcXVlc3QaIy5zY2hlbWEudjFhbHBoYTEuR2V0Q2hhc3Npc1Jlc3BvbnNlIiSC0+STAh4SHC9hcGkvdjFhbHBoYTEvY2hhc3Npcy97bmFtZX0SdwoLTGlzdENoYXNzaXMSIy5zY2hlbWEudjFhbHBoYTEuTGlzdENoYXNzaXNSZXF1ZXN0GiQuc2NoZW1hLnYxYWxwaGExLkxpc3RDaGFzc2lzUmVzcG9uc2UiHYLT5JMCFxIVL2FwaS92MWFscGhhMS9jaGFzc2lzEo8BCg1VcGRhdGVDaGFzc2lzEiUuc2NoZW1hLnYxYWxwaGExLlVwZGF0ZUNoYXNzaXNSZXF1ZXN0GiYuc2NoZW1hLnYxYWxwaGExLlVwZGF0ZUNoYXNzaXNSZXNwb25zZSIvgtPkkwIpOgEqMiQvYXBpL3YxYWxwaGExL2NoYXNzaXMve2NoYXNzaXNfbmFtZX0SpAEKEkNoYW5nZUNoYXNzaXNTdGF0ZRIqLnNjaGVtYS52MWFscGhhMS5DaGFuZ2VDaGFzc2lzU3RhdGVSZXF1ZXN0Gisuc2NoZW1hLnYxYWxwaGExLkNoYW5nZUNoYXNzaXNTdGF0ZVJlc3BvbnNlIjWC0+STAi86ASoiKi9hcGkvdjFhbHBoYTEvY2hhc3Npcy97Y2hhc3Npc19uYW1lfS9zdGF0ZRJwCgdHZXRIb3N0Eh8uc2NoZW1hLnYxYWxwaGExLkdldEhvc3RSZXF1ZXN0GiAuc2NoZW1hLnYxYWxwaGExLkdldEhvc3RSZXNwb25zZSIigtPkkwIcEhovYXBpL3YxYWxwaGExL2hvc3RzL3tuYW1lfRJvCglMaXN0SG9zdHMSIS5zY2hlbWEudjFhbHBoYTEuTGlzdEhvc3RzUmVxdWVzdBoiLnNjaGVtYS52MWFscGhhMS5MaXN0SG9zdHNSZXNwb25zZSIbgtPkkwIVEhMvYXBpL3YxYWxwaGExL2hvc3RzEoEBCgpVcGRhdGVIb3N0EiIuc2NoZW1hLnYxYWxwaGExLlVwZGF0ZUhvc3RSZXF1ZXN0GiMuc2NoZW1hLnYxYWxwaGExLlVwZGF0ZUhvc3RSZXNwb25zZSIqgtPkkwIkOgEqMh8vYXBpL3YxYWxwaGExL2hvc3RzL3tob3N0X25hbWV9EpYBCg9DaGFuZ2VIb3N0U3RhdGUSJy5zY2hlbWEudjFhbHBoYTEuQ2hhbmdlSG9zdFN0YXRlUmVxdWVzdBooLnNjaGVtYS52MWFscGhhMS5DaGFuZ2VIb3N0U3RhdGVSZXNwb25zZSIwgtPkkwIqOgEqIiUvYXBpL3YxYWxwaGExL2hvc3RzL3tob3N0X25hbWV9L3N0YXRlErEBChdHZXRNYW5hZ2VtZW50Q29udHJvbGxlchIvLnNjaGVtYS52MWFscGhhMS5HZXRNYW5hZ2VtZW50Q29udHJvbGxlclJlcXVlc3QaMC5zY2hlbWEudjFhbHBoYTEuR2V0TWFuYWdlbWVudENvbnRyb2xsZXJSZXNwb25zZSIzgtPkkwItEisvYXBpL3YxYWxwaGExL21hbmFnZW1lbnQtY29udHJvbGxlcnMve25hbWV9ErABChlMaXN0TWFuYWdlbWVudENvbnRyb2xsZXJzEjEuc2NoZW1hLnYxYWxwaGExLkxpc3RNYW5hZ2VtZW50Q29udHJvbGxlcnNSZXF1ZXN0GjIuc2NoZW1hLnYxYWxwaGExLkxpc3RNYW5hZ2VtZW50Q29udHJvbGxlcnNSZXNwb25zZSIsgtPkkwImEiQvYXBpL3YxYWxwaGExL21hbmFnZW1lbnQtY29udHJvbGxlcnMSyAEKGlVwZGF0ZU1hbmFnZW1lbnRDb250cm9sbGVyEjIuc2NoZW1hLnYxYWxwaGExLlVwZGF0ZU1hbmFnZW1lbnRDb250cm9sbGVyUmVxdWVzdBozLnNjaGVtYS52MWFscGhhMS5VcGRhdGVNYW5hZ2VtZW50Q29udHJvbGxlclJlc3BvbnNlIkGC0+STAjs6ASoyNi9hcGkvdjFhbHBoYTEvbWFuYWdlbWVudC1jb250cm9sbGVycy97Y29udHJvbGxlcl9uYW1lfRLdAQofQ2hhbmdlTWFuYWdlbWVudENvbnRyb2xsZXJTdGF0ZRI3LnNjaGVtYS52MWFscGhhMS5DaGFuZ2VNYW5hZ2VtZW50Q29udHJvbGxlclN0YXRlUmVxdWVzdBo4LnNjaGVtYS52MWFscGhhMS5DaGFuZ2VNYW5hZ2VtZW50Q29udHJvbGxlclN0YXRlUmVzcG9uc2UiR4LT5JMCQToBKiI8L2FwaS92MWFscGhhMS9tYW5hZ2VtZW50LWNvbnRyb2xsZXJzL3tjb250cm9sbGVyX25hbWV9L3N0YXRlEncKC0xpc3RTZW5zb3JzEiMuc2NoZW1hLnYxYWxwaGExLkxpc3RTZW5zb3JzUmVxdWVzdBokLnNjaGVtYS52MWFscGhhMS5MaXN0U2Vuc29yc1Jlc3BvbnNlIh2C0+STAhcSFS9hcGkvdjFhbHBoYTEvc2Vuc29ycxJ2CglHZXRTZW5zb3ISIS5zY2hlbWEudjFhbHBoYTEuR2V0U2Vuc29yUmVxdWVzdBoiLnNjaGVtYS52MWFscGhhMS5HZXRTZW5zb3JSZXNwb25zZSIigtPkkwIcEhovYXBpL3YxYWxwaGExL3NlbnNvcnMve2lkfRKNAQoOR2V0VGhlcm1hbFpvbmUSJi5zY2hlbWEudjFhbHBoYTEuR2V0VGhlcm1hbFpvbmVSZXF1ZXN0Gicuc2NoZW1hLnYxYWxwaGExLkdldFRoZXJtYWxab25lUmVzcG9uc2UiKoLT5JMCJBIiL2FwaS92MWFscGhhMS90aGVybWFsLXpvbmVzL3tuYW1lfRKQAQoOU2V0VGhlcm1hbFpvbmUSJi5zY2hlbWEudjFhbHBoYTEuU2V0VGhlcm1hbFpvbmVSZXF1ZXN0Gicuc2NoZW1hLnYxYWxwaGExLlNldFRoZXJtYWxab25lUmVzcG9uc2UiLYLT5JMCJzoBKhoiL2FwaS92MWFscGhhMS90aGVybWFsLXpvbmVzL3tuYW1lfRKMAQoQTGlzdFRoZXJtYWxab25lcxIoLnNjaGVtYS52MWFscGhhMS5MaXN0VGhlcm1hbFpvbmVzUmVxdWVzdBopLnNjaGVtYS52MWFscGhhMS5MaXN0VGhlcm1hbFpvbmVzUmVzcG9uc2UiI4LT5JMCHRIbL2FwaS92MWFscGhhMS90aGVybWFsLXpvbmVzEnUKCkNyZWF0ZVVzZXISIi5zY2hlbWEudjFhbHBoYTEuQ3JlYXRlVXNlclJlcXVlc3QaIy5zY2hlbWEudjFhbHBoYTEuQ3JlYXRlVXNlclJlc3BvbnNlIh6C0+STAhg6ASoiEy9hcGkvdjFhbHBoYTEvdXNlcnMSbgoHR2V0VXNlchIfLnNjaGVtYS52MWFscGhhMS5HZXRVc2VyUmVxdWVzdBogLnNjaGVtYS52MWFscGhhMS5HZXRVc2VyUmVzcG9uc2UiIILT5JMCGhIYL2FwaS92MWFscGhhMS91c2Vycy97aWR9En8KClVwZGF0ZVVzZXISIi5zY2hlbWEudjFhbHBoYTEuVXBkYXRlVXNlclJlcXVlc3QaIy5zY2hlbWEudjFhbHBoYTEuVXBkYXRlVXNlclJlc3BvbnNlIiiC0+STAiI6ASoyHS9hcGkvdjFhbHBoYTEvdXNlcnMve3VzZXIuaWR9EncKCkRlbGV0ZVVzZXISIi5zY2hlbWEudjFhbHBoYTEuRGVsZXRlVXNlclJlcXVlc3QaIy5zY2hlbWEudjFhbHBoYTEuRGVsZXRlVXNlclJlc3BvbnNlIiCC0+STAhoqGC9hcGkvdjFhbHBoYTEvdXNlcnMve2lkfRJvCglMaXN0VXNlcnMSIS5zY2hlbWEudjFhbHBoYTEuTGlzdFVzZXJzUmVxdWVzdBoiLnNjaGVtYS52MWFscGhhMS5MaXN0VXNlcnNSZXNwb25zZSIbgtPkkwIVEhMvYXBpL3YxYWxwaGExL3VzZXJzEpYBCg5DaGFuZ2VQYXNzd29yZBImLnNjaGVtYS52MWFscGhhMS5DaGFuZ2VQYXNzd29yZFJlcXVlc3QaJy5zY2hlbWEudjFhbHBoYTEuQ2hhbmdlUGFzc3dvcmRSZXNwb25zZSIzgtPkkwItOgEqIigvYXBpL3YxYWxwaGExL3VzZXJzL3tpZH0vY2hhbmdlLXBhc3N3b3JkEpIBCg1SZXNldFBhc3N3b3JkEiUuc2NoZW1hLnYxYWxwaGExLlJlc2V0UGFzc3dvcmRSZXF1ZXN0GiYuc2NoZW1hLnYxYWxwaGExLlJlc2V0UGFzc3dvcmRSZXNwb25zZSIygtPkkwIsOgEqIicvYXBpL3YxYWxwaGExL3VzZXJzL3tpZH0vcmVzZXQtcGFzc3dvcmQSkwEKEEF1dGhlbnRpY2F0ZVVzZXISKC5zY2hlbWEudjFhbHBoYTEuQXV0aGVudGljYXRlVXNlclJlcXVlc3QaKS5zY2hlbWEudjFhbHBoYTEuQXV0aGVudGljYXRlVXNlclJlc3BvbnNlIiqC0+STAiQ6ASoiHy9hcGkvdjFhbHBoYTEvYXV0aC9hdXRoZW50aWNhdGVCvgEKE2NvbS5zY2hlbWEudjFhbHBoYTFCC1N5c3RlbVByb3RvUAFaPWdpdGh1Yi5jb20vdS1ibWMvdS1ibWMvYXBpL2dlbi9zY2hlbWEvdjFhbHBoYTE7c2NoZW1hdjFhbHBoYTGiAgNTWFiqAg9TY2hlbWEuVjFhbHBoYTHKAg9TY2hlbWFcVjFhbHBoYTHiAhtTY2hlbWFcVjFhbHBoYTFcR1BCTWV0YWRhdGHqAhBTY2hlbWE6OlYxYWxwaGExYgZwcm90bzM", [file_buf_validate_validate, file_google_api_annotations, file_google_protobuf_field_mask, file_google_protobuf_timestamp, file_google_protobuf_duration, file_schema_v1alpha1_asset, file_schema_v1alpha1_chassis, file_schema_v1alpha1_contact, file_schema_v1alpha1_host, file_schema_v1alpha1_managementcontroller, file_schema_v1alpha1_sensor, file_schema_v1alpha1_thermal, file_schema_v1alpha1_user]);
+  fileDesc("ChxzY2hlbWEvdjFhbHBoYTEvc3lzdGVtLnByb3RvEg9zY2hlbWEudjFhbHBoYTEirAIKBkhlYWx0aBI3CgZzdGF0dXMYASABKA4yHS5zY2hlbWEudjFhbHBoYTEuSGVhbHRoU3RhdHVzQgi6SAWCAQIQARIfChJzdGF0dXNfZGVzY3JpcHRpb24YAiABKAlIAIgBARIuCgdkZXRhaWxzGAMgAygLMh0uc2NoZW1hLnYxYWxwaGExLkhlYWx0aERldGFpbBI1CgxsYXN0X3VwZGF0ZWQYBCABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wSAGIAQESLgoGdXB0aW1lGAUgASgLMhkuZ29vZ2xlLnByb3RvYnVmLkR1cmF0aW9uSAKIAQFCFQoTX3N0YXR1c19kZXNjcmlwdGlvbkIPCg1fbGFzdF91cGRhdGVkQgkKB191cHRpbWUixwEKDEhlYWx0aERldGFpbBIaCgljb21wb25lbnQYASABKAlCB7pIBHICEAESNwoGc3RhdHVzGAIgASgOMh0uc2NoZW1hLnYxYWxwaGExLkhlYWx0aFN0YXR1c0IIukgFggECEAESFAoHbWVzc2FnZRgDIAEoCUgAiAEBEjIKCXRpbWVzdGFtcBgEIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXBIAYgBAUIKCghfbWVzc2FnZUIMCgpfdGltZXN0YW1wIowECgpTeXN0ZW1JbmZvEhUKBG5hbWUYASABKAlCB7pIBHICEAESGAoLZGVzY3JpcHRpb24YAiABKAlIAIgBARI1CgVzdGF0ZRgDIAEoDjIcLnNjaGVtYS52MWFscGhhMS5TeXN0ZW1TdGF0ZUIIukgFggECEAESLwoGaGVhbHRoGAQgASgLMhcuc2NoZW1hLnYxYWxwaGExLkhlYWx0aEIGukgDyAEBEhQKB3ZlcnNpb24YBSABKAlIAYgBARIyCglib290X3RpbWUYBiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wSAKIAQESLgoGdXB0aW1lGAcgASgLMhkuZ29vZ2xlLnByb3RvYnVmLkR1cmF0aW9uSAOIAQESNwoMY29udGFjdF9pbmZvGAggASgLMhwuc2NoZW1hLnYxYWxwaGExLkNvbnRhY3RJbmZvSASIAQESOwoIbWV0YWRhdGEYCSADKAsyKS5zY2hlbWEudjFhbHBoYTEuU3lzdGVtSW5mby5NZXRhZGF0YUVudHJ5Gi8KDU1ldGFkYXRhRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgJOgI4AUIOCgxfZGVzY3JpcHRpb25CCgoIX3ZlcnNpb25CDAoKX2Jvb3RfdGltZUIJCgdfdXB0aW1lQg8KDV9jb250YWN0X2luZm8iWgoUR2V0U3lzdGVtSW5mb1JlcXVlc3QSMwoKZmllbGRfbWFzaxgBIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5GaWVsZE1hc2tIAIgBAUINCgtfZmllbGRfbWFzayJJChVHZXRTeXN0ZW1JbmZvUmVzcG9uc2USMAoLc3lzdGVtX2luZm8YASABKAsyGy5zY2hlbWEudjFhbHBoYTEuU3lzdGVtSW5mbyJWChBHZXRIZWFsdGhSZXF1ZXN0EjMKCmZpZWxkX21hc2sYASABKAsyGi5nb29nbGUucHJvdG9idWYuRmllbGRNYXNrSACIAQFCDQoLX2ZpZWxkX21hc2siPAoRR2V0SGVhbHRoUmVzcG9uc2USJwoGaGVhbHRoGAEgASgLMhcuc2NoZW1hLnYxYWxwaGExLkhlYWx0aCqVAQoMSGVhbHRoU3RhdHVzEh0KGUhFQUxUSF9TVEFUVVNfVU5TUEVDSUZJRUQQABIUChBIRUFMVEhfU1RBVFVTX09LEAESGQoVSEVBTFRIX1NUQVRVU19XQVJOSU5HEAISGgoWSEVBTFRIX1NUQVRVU19DUklUSUNBTBADEhkKFUhFQUxUSF9TVEFUVVNfVU5LTk9XThAEKuUBCgtTeXN0ZW1TdGF0ZRIcChhTWVNURU1fU1RBVEVfVU5TUEVDSUZJRUQQABIZChVTWVNURU1fU1RBVEVfU1RBUlRJTkcQARIYChRTWVNURU1fU1RBVEVfRU5BQkxFRBACEhkKFVNZU1RFTV9TVEFURV9ESVNBQkxFRBADEhgKFFNZU1RFTV9TVEFURV9TVEFOREJZEAQSGQoVU1lTVEVNX1NUQVRFX1FVSUVTQ0VEEAUSGAoUU1lTVEVNX1NUQVRFX0lOX1RFU1QQBhIZChVTWVNURU1fU1RBVEVfVVBEQVRJTkcQBzKtJwoKQk1DU2VydmljZRKBAQoNR2V0U3lzdGVtSW5mbxIlLnNjaGVtYS52MWFscGhhMS5HZXRTeXN0ZW1JbmZvUmVxdWVzdBomLnNjaGVtYS52MWFscGhhMS5HZXRTeXN0ZW1JbmZvUmVzcG9uc2UiIYLT5JMCGxIZL2FwaS92MWFscGhhMS9zeXN0ZW0vaW5mbxJ3CglHZXRIZWFsdGgSIS5zY2hlbWEudjFhbHBoYTEuR2V0SGVhbHRoUmVxdWVzdBoiLnNjaGVtYS52MWFscGhhMS5HZXRIZWFsdGhSZXNwb25zZSIjgtPkkwIdEhsvYXBpL3YxYWxwaGExL3N5c3RlbS9oZWFsdGgSeAoMR2V0QXNzZXRJbmZvEiQuc2NoZW1hLnYxYWxwaGExLkdldEFzc2V0SW5mb1JlcXVlc3QaJS5zY2hlbWEudjFhbHBoYTEuR2V0QXNzZXRJbmZvUmVzcG9uc2UiG4LT5JMCFRITL2FwaS92MWFscGhhMS9hc3NldBJ7CgxTZXRBc3NldEluZm8SJC5zY2hlbWEudjFhbHBoYTEuU2V0QXNzZXRJbmZvUmVxdWVzdBolLnNjaGVtYS52MWFscGhhMS5TZXRBc3NldEluZm9SZXNwb25zZSIegtPkkwIYOgEqGhMvYXBpL3YxYWxwaGExL2Fzc2V0EnsKCkdldENoYXNzaXMSIi5zY2hlbWEudjFhbHBoYTEuR2V0Q2hhc3Npc1JlcXVlc3QaIy5zY2hlbWEudjFhbHBoYTEuR2V0Q2hhc3Npc1Jlc3BvbnNlIiSC0+STAh4SHC9hcGkvdjFhbHBoYTEvY2hhc3Npcy97bmFtZX0SdwoLTGlzdENoYXNzaXMSIy5zY2hlbWEudjFhbHBoYTEuTGlzdENoYXNzaXNSZXF1ZXN0GiQuc2NoZW1hLnYxYWxwaGExLkxpc3RDaGFzc2lzUmVzcG9uc2UiHYLT5JMCFxIVL2FwaS92MWFscGhhMS9jaGFzc2lzEo8BCg1VcGRhdGVDaGFzc2lzEiUuc2NoZW1hLnYxYWxwaGExLlVwZGF0ZUNoYXNzaXNSZXF1ZXN0GiYuc2NoZW1hLnYxYWxwaGExLlVwZGF0ZUNoYXNzaXNSZXNwb25zZSIvgtPkkwIpOgEqMiQvYXBpL3YxYWxwaGExL2NoYXNzaXMve2NoYXNzaXNfbmFtZX0SpAEKEkNoYW5nZUNoYXNzaXNTdGF0ZRIqLnNjaGVtYS52MWFscGhhMS5DaGFuZ2VDaGFzc2lzU3RhdGVSZXF1ZXN0Gisuc2NoZW1hLnYxYWxwaGExLkNoYW5nZUNoYXNzaXNTdGF0ZVJlc3BvbnNlIjWC0+STAi86ASoiKi9hcGkvdjFhbHBoYTEvY2hhc3Npcy97Y2hhc3Npc19uYW1lfS9zdGF0ZRJwCgdHZXRIb3N0Eh8uc2NoZW1hLnYxYWxwaGExLkdldEhvc3RSZXF1ZXN0GiAuc2NoZW1hLnYxYWxwaGExLkdldEhvc3RSZXNwb25zZSIigtPkkwIcEhovYXBpL3YxYWxwaGExL2hvc3RzL3tuYW1lfRJvCglMaXN0SG9zdHMSIS5zY2hlbWEudjFhbHBoYTEuTGlzdEhvc3RzUmVxdWVzdBoiLnNjaGVtYS52MWFscGhhMS5MaXN0SG9zdHNSZXNwb25zZSIbgtPkkwIVEhMvYXBpL3YxYWxwaGExL2hvc3RzEoEBCgpVcGRhdGVIb3N0EiIuc2NoZW1hLnYxYWxwaGExLlVwZGF0ZUhvc3RSZXF1ZXN0GiMuc2NoZW1hLnYxYWxwaGExLlVwZGF0ZUhvc3RSZXNwb25zZSIqgtPkkwIkOgEqMh8vYXBpL3YxYWxwaGExL2hvc3RzL3tob3N0X25hbWV9EpYBCg9DaGFuZ2VIb3N0U3RhdGUSJy5zY2hlbWEudjFhbHBoYTEuQ2hhbmdlSG9zdFN0YXRlUmVxdWVzdBooLnNjaGVtYS52MWFscGhhMS5DaGFuZ2VIb3N0U3RhdGVSZXNwb25zZSIwgtPkkwIqOgEqIiUvYXBpL3YxYWxwaGExL2hvc3RzL3tob3N0X25hbWV9L3N0YXRlErEBChdHZXRNYW5hZ2VtZW50Q29udHJvbGxlchIvLnNjaGVtYS52MWFscGhhMS5HZXRNYW5hZ2VtZW50Q29udHJvbGxlclJlcXVlc3QaMC5zY2hlbWEudjFhbHBoYTEuR2V0TWFuYWdlbWVudENvbnRyb2xsZXJSZXNwb25zZSIzgtPkkwItEisvYXBpL3YxYWxwaGExL21hbmFnZW1lbnQtY29udHJvbGxlcnMve25hbWV9ErABChlMaXN0TWFuYWdlbWVudENvbnRyb2xsZXJzEjEuc2NoZW1hLnYxYWxwaGExLkxpc3RNYW5hZ2VtZW50Q29udHJvbGxlcnNSZXF1ZXN0GjIuc2NoZW1hLnYxYWxwaGExLkxpc3RNYW5hZ2VtZW50Q29udHJvbGxlcnNSZXNwb25zZSIsgtPkkwImEiQvYXBpL3YxYWxwaGExL21hbmFnZW1lbnQtY29udHJvbGxlcnMSyAEKGlVwZGF0ZU1hbmFnZW1lbnRDb250cm9sbGVyEjIuc2NoZW1hLnYxYWxwaGExLlVwZGF0ZU1hbmFnZW1lbnRDb250cm9sbGVyUmVxdWVzdBozLnNjaGVtYS52MWFscGhhMS5VcGRhdGVNYW5hZ2VtZW50Q29udHJvbGxlclJlc3BvbnNlIkGC0+STAjs6ASoyNi9hcGkvdjFhbHBoYTEvbWFuYWdlbWVudC1jb250cm9sbGVycy97Y29udHJvbGxlcl9uYW1lfRLdAQofQ2hhbmdlTWFuYWdlbWVudENvbnRyb2xsZXJTdGF0ZRI3LnNjaGVtYS52MWFscGhhMS5DaGFuZ2VNYW5hZ2VtZW50Q29udHJvbGxlclN0YXRlUmVxdWVzdBo4LnNjaGVtYS52MWFscGhhMS5DaGFuZ2VNYW5hZ2VtZW50Q29udHJvbGxlclN0YXRlUmVzcG9uc2UiR4LT5JMCQToBKiI8L2FwaS92MWFscGhhMS9tYW5hZ2VtZW50LWNvbnRyb2xsZXJzL3tjb250cm9sbGVyX25hbWV9L3N0YXRlEncKC0xpc3RTZW5zb3JzEiMuc2NoZW1hLnYxYWxwaGExLkxpc3RTZW5zb3JzUmVxdWVzdBokLnNjaGVtYS52MWFscGhhMS5MaXN0U2Vuc29yc1Jlc3BvbnNlIh2C0+STAhcSFS9hcGkvdjFhbHBoYTEvc2Vuc29ycxJ2CglHZXRTZW5zb3ISIS5zY2hlbWEudjFhbHBoYTEuR2V0U2Vuc29yUmVxdWVzdBoiLnNjaGVtYS52MWFscGhhMS5HZXRTZW5zb3JSZXNwb25zZSIigtPkkwIcEhovYXBpL3YxYWxwaGExL3NlbnNvcnMve2lkfRKyAQoZTGlzdFN5c3RlbUV2ZW50TG9nRW50cmllcxIxLnNjaGVtYS52MWFscGhhMS5MaXN0U3lzdGVtRXZlbnRMb2dFbnRyaWVzUmVxdWVzdBoyLnNjaGVtYS52MWFscGhhMS5MaXN0U3lzdGVtRXZlbnRMb2dFbnRyaWVzUmVzcG9uc2UiLoLT5JMCKBImL2FwaS92MWFscGhhMS9zeXN0ZW0tZXZlbnQtbG9nL2VudHJpZXMSoAEKE0NsZWFyU3lzdGVtRXZlbnRMb2cSKy5zY2hlbWEudjFhbHBoYTEuQ2xlYXJTeXN0ZW1FdmVudExvZ1JlcXVlc3QaLC5zY2hlbWEudjFhbHBoYTEuQ2xlYXJTeXN0ZW1FdmVudExvZ1Jlc3BvbnNlIi6C0+STAigqJi9hcGkvdjFhbHBoYTEvc3lzdGVtLWV2ZW50LWxvZy9lbnRyaWVzEo0BCg5HZXRUaGVybWFsWm9uZRImLnNjaGVtYS52MWFscGhhMS5HZXRUaGVybWFsWm9uZVJlcXVlc3QaJy5zY2hlbWEudjFhbHBoYTEuR2V0VGhlcm1hbFpvbmVSZXNwb25zZSIqgtPkkwIkEiIvYXBpL3YxYWxwaGExL3RoZXJtYWwtem9uZXMve25hbWV9EpABCg5TZXRUaGVybWFsWm9uZRImLnNjaGVtYS52MWFscGhhMS5TZXRUaGVybWFsWm9uZVJlcXVlc3QaJy5zY2hlbWEudjFhbHBoYTEuU2V0VGhlcm1hbFpvbmVSZXNwb25zZSItgtPkkwInOgEqGiIvYXBpL3YxYWxwaGExL3RoZXJtYWwtem9uZXMve25hbWV9EowBChBMaXN0VGhlcm1hbFpvbmVzEiguc2NoZW1hLnYxYWxwaGExLkxpc3RUaGVybWFsWm9uZXNSZXF1ZXN0Gikuc2NoZW1hLnYxYWxwaGExLkxpc3RUaGVybWFsWm9uZXNSZXNwb25zZSIjgtPkkwIdEhsvYXBpL3YxYWxwaGExL3RoZXJtYWwtem9uZXMSdQoKQ3JlYXRlVXNlchIiLnNjaGVtYS52MWFscGhhMS5DcmVhdGVVc2VyUmVxdWVzdBojLnNjaGVtYS52MWFscGhhMS5DcmVhdGVVc2VyUmVzcG9uc2UiHoLT5JMCGDoBKiITL2FwaS92MWFscGhhMS91c2VycxJuCgdHZXRVc2VyEh8uc2NoZW1hLnYxYWxwaGExLkdldFVzZXJSZXF1ZXN0GiAuc2NoZW1hLnYxYWxwaGExLkdldFVzZXJSZXNwb25zZSIggtPkkwIaEhgvYXBpL3YxYWxwaGExL3VzZXJzL3tpZH0SfwoKVXBkYXRlVXNlchIiLnNjaGVtYS52MWFscGhhMS5VcGRhdGVVc2VyUmVxdWVzdBojLnNjaGVtYS52MWFscGhhMS5VcGRhdGVVc2VyUmVzcG9uc2UiKILT5JMCIjoBKjIdL2FwaS92MWFscGhhMS91c2Vycy97dXNlci5pZH0SdwoKRGVsZXRlVXNlchIiLnNjaGVtYS52MWFscGhhMS5EZWxldGVVc2VyUmVxdWVzdBojLnNjaGVtYS52MWFscGhhMS5EZWxldGVVc2VyUmVzcG9uc2UiIILT5JMCGioYL2FwaS92MWFscGhhMS91c2Vycy97aWR9Em8KCUxpc3RVc2VycxIhLnNjaGVtYS52MWFscGhhMS5MaXN0VXNlcnNSZXF1ZXN0GiIuc2NoZW1hLnYxYWxwaGExLkxpc3RVc2Vyc1Jlc3BvbnNlIhuC0+STAhUSEy9hcGkvdjFhbHBoYTEvdXNlcnMSlgEKDkNoYW5nZVBhc3N3b3JkEiYuc2NoZW1hLnYxYWxwaGExLkNoYW5nZVBhc3N3b3JkUmVxdWVzdBonLnNjaGVtYS52MWFscGhhMS5DaGFuZ2VQYXNzd29yZFJlc3BvbnNlIjOC0+STAi06ASoiKC9hcGkvdjFhbHBoYTEvdXNlcnMve2lkfS9jaGFuZ2UtcGFzc3dvcmQSkgEKDVJlc2V0UGFzc3dvcmQSJS5zY2hlbWEudjFhbHBoYTEuUmVzZXRQYXNzd29yZFJlcXVlc3QaJi5zY2hlbWEudjFhbHBoYTEuUmVzZXRQYXNzd29yZFJlc3BvbnNlIjKC0+STAiw6ASoiJy9hcGkvdjFhbHBoYTEvdXNlcnMve2lkfS9yZXNldC1wYXNzd29yZBKTAQoQQXV0aGVudGljYXRlVXNlchIoLnNjaGVtYS52MWFscGhhMS5BdXRoZW50aWNhdGVVc2VyUmVxdWVzdBopLnNjaGVtYS52MWFscGhhMS5BdXRoZW50aWNhdGVVc2VyUmVzcG9uc2UiKoLT5JMCJDoBKiIfL2FwaS92MWFscGhhMS9hdXRoL2F1dGhlbnRpY2F0ZRKGAQoKRW5yb2xsVG90cBIiLnNjaGVtYS52MWFscGhhMS5FbnJvbGxUb3RwUmVxdWVzdBojLnNjaGVtYS52MWFscGhhMS5FbnJvbGxUb3RwUmVzcG9uc2UiL4LT5JMCKToBKiIkL2FwaS92MWFscGhhMS91c2Vycy97aWR9L3RvdHAvZW5yb2xsEooBCgtDb25maXJtVG90cBIjLnNjaGVtYS52MWFscGhhMS5Db25maXJtVG90cFJlcXVlc3QaJC5zY2hlbWEudjFhbHBoYTEuQ29uZmlybVRvdHBSZXNwb25zZSIwgtPkkwIqOgEqIiUvYXBpL3YxYWxwaGExL3VzZXJzL3tpZH0vdG90cC9jb25maXJtEooBCgtEaXNhYmxlVG90cBIjLnNjaGVtYS52MWFscGhhMS5EaXNhYmxlVG90cFJlcXVlc3QaJC5zY2hlbWEudjFhbHBoYTEuRGlzYWJsZVRvdHBSZXNwb25zZSIwgtPkkwIqOgEqIiUvYXBpL3YxYWxwaGExL3VzZXJzL3tpZH0vdG90cC9kaXNhYmxlErUBChdSZWdlbmVyYXRlUmVjb3ZlcnlDb2RlcxIvLnNjaGVtYS52MWFscGhhMS5SZWdlbmVyYXRlUmVjb3ZlcnlDb2Rlc1JlcXVlc3QaMC5zY2hlbWEudjFhbHBoYTEuUmVnZW5lcmF0ZVJlY292ZXJ5Q29kZXNSZXNwb25zZSI3gtPkkwIxOgEqIiwvYXBpL3YxYWxwaGExL3VzZXJzL3tpZH0vdG90cC9yZWNvdmVyeS1jb2Rlc0K+AQoTY29tLnNjaGVtYS52MWFscGhhMUILU3lzdGVtUHJvdG9QAVo9Z2l0aHViLmNvbS91LWJtYy91LWJtYy9hcGkvZ2VuL3NjaGVtYS92MWFscGhhMTtzY2hlbWF2MWFscGhhMaICA1NYWKoCD1NjaGVtYS5WMWFscGhhMcoCD1NjaGVtYVxWMWFscGhhMeICG1NjaGVtYVxWMWFscGhhMVxHUEJNZXRhZGF0YeoCEFNjaGVtYTo6VjFhbHBoYTFiBnByb3RvMw", [file_buf_validate_validate, file_google_api_annotations, file_google_protobuf_field_mask, file_google_protobuf_timestamp, file_google_protobuf_duration, file_schema_v1alpha1_asset, file_schema_v1alpha1_chassis, file_schema_v1alpha1_contact, file_schema_v1alpha1_eventlog, file_schema_v1alpha1_host, file_schema_v1alpha1_managementcontroller, file_schema_v1alpha1_sensor, file_schema_v1alpha1_thermal, file_schema_v1alpha1_user]);
 
 /**
  * @generated from message schema.v1alpha1.Health
@@ -463,6 +465,22 @@ export const BMCService: GenService<{
     input: typeof GetSensorRequestSchema;
     output: typeof GetSensorResponseSchema;
   },
+  /**
+   * @generated from rpc schema.v1alpha1.BMCService.ListSystemEventLogEntries
+   */
+  listSystemEventLogEntries: {
+    methodKind: "unary";
+    input: typeof ListSystemEventLogEntriesRequestSchema;
+    output: typeof ListSystemEventLogEntriesResponseSchema;
+  },
+  /**
+   * @generated from rpc schema.v1alpha1.BMCService.ClearSystemEventLog
+   */
+  clearSystemEventLog: {
+    methodKind: "unary";
+    input: typeof ClearSystemEventLogRequestSchema;
+    output: typeof ClearSystemEventLogResponseSchema;
+  },
   /**
    * @generated from rpc schema.v1alpha1.BMCService.GetThermalZone
    */
@@ -551,6 +569,38 @@ export const BMCService: GenService<{
     input: typeof AuthenticateUserRequestSchema;
     output: typeof AuthenticateUserResponseSchema;
   },
+  /**
+   * @generated from rpc schema.v1alpha1.BMCService.EnrollTotp
+   */
+  enrollTotp: {
+    methodKind: "unary";
+    input: typeof EnrollTotpRequestSchema;
+    output: typeof EnrollTotpResponseSchema;
+  },
+  /**
+   * @generated from rpc schema.v1alpha1.BMCService.ConfirmTotp
+   */
+  confirmTotp: {
+    methodKind: "unary";
+    input: typeof ConfirmTotpRequestSchema;
+    output: typeof ConfirmTotpResponseSchema;
+  },
+  /**
+   * @generated from rpc schema.v1alpha1.BMCService.DisableTotp
+   */
+  disableTotp: {
+    methodKind: "unary";
+    input: typeof DisableTotpRequestSchema;
+    output: typeof DisableTotpResponseSchema;
+  },
+  /**
+   * @generated from rpc schema.v1alpha1.BMCService.RegenerateRecoveryCodes
+   */
+  regenerateRecoveryCodes: {
+    methodKind: "unary";
+    input: typeof RegenerateRecoveryCodesRequestSchema;
+    output: typeof RegenerateRecoveryCodesResponseSchema;
+  },
 }> = /*@__PURE__*/
   serviceDesc(file_schema_v1alpha1_system, 0);
 
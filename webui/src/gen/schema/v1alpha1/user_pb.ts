@@ -15,7 +15,7 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file schema/v1alpha1/user.proto.
  */
 export const file_schema_v1alpha1_user: GenFile = /*@__PURE__*/
-  fileDesc("ChpzY2hlbWEvdjFhbHBoYTEvdXNlci5wcm90bxIPc2NoZW1hLnYxYWxwaGExIogOCgRVc2VyEhMKAmlkGAEgASgJQge6SARyAhABEi4KCHVzZXJuYW1lGAIgASgJQhy6SBlyFxABGEAyEV5bYS16QS1aMC05Ll8tXSskEiIKCWZ1bGxfbmFtZRgDIAEoCUIKukgHcgUQARiAAkgAiAEBEh4KBWVtYWlsGAQgASgJQgq6SAdyBRjAAmABSAGIAQESFwoHZW5hYmxlZBgFIAEoCEIGukgDyAEBEjYKCmNyZWF0ZWRfYXQYBiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wQga6SAPIAQESNgoKdXBkYXRlZF9hdBgHIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXBCBrpIA8gBARIzCgpsYXN0X2xvZ2luGAggASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcEgCiAEBEjwKDXNvdXJjZV9zeXN0ZW0YCSABKA4yGy5zY2hlbWEudjFhbHBoYTEuVXNlclNvdXJjZUIIukgFggECEAESTAoSY3JlYXRpb25faW50ZXJmYWNlGAogASgOMiYuc2NoZW1hLnYxYWxwaGExLlVzZXJDcmVhdGlvbkludGVyZmFjZUIIukgFggECEAESOwoJYXV0aF9kYXRhGAsgASgLMiMuc2NoZW1hLnYxYWxwaGExLkF1dGhlbnRpY2F0aW9uRGF0YUgDiAEBEjUKCXVuaXhfaW5mbxgMIAEoCzIdLnNjaGVtYS52MWFscGhhMS5Vbml4VXNlckluZm9IBIgBARI1CglsZGFwX2luZm8YDSABKAsyHS5zY2hlbWEudjFhbHBoYTEuTGRhcFVzZXJJbmZvSAWIAQESPgoMcmVkZmlzaF9pbmZvGA4gASgLMiMuc2NoZW1hLnYxYWxwaGExLlJlZGZpc2hBY2NvdW50SW5mb0gGiAEBEjgKCW5hdHNfaW5mbxgPIAEoCzIgLnNjaGVtYS52MWFscGhhMS5OYXRzQWNjb3VudEluZm9IB4gBARJGChFjdXN0b21fYXR0cmlidXRlcxgQIAMoCzIrLnNjaGVtYS52MWFscGhhMS5Vc2VyLkN1c3RvbUF0dHJpYnV0ZXNFbnRyeRo3ChVDdXN0b21BdHRyaWJ1dGVzRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgJOgI4ATqWBrpIkgYazAMKI3VzZXJfc291cmNlX3N5c3RlbV9pbmZvX2NvbnNpc3RlbmN5EjN1c2VyIG11c3QgaGF2ZSBjb3JyZXNwb25kaW5nIGluZm8gZm9yIHNvdXJjZSBzeXN0ZW0a7wIodGhpcy5zb3VyY2Vfc3lzdGVtID09IDEgJiYgaGFzKHRoaXMuYXV0aF9kYXRhKSkgfHwgKHRoaXMuc291cmNlX3N5c3RlbSA9PSAyICYmIGhhcyh0aGlzLmxkYXBfaW5mbykpIHx8ICh0aGlzLnNvdXJjZV9zeXN0ZW0gPT0gMyAmJiBoYXModGhpcy5sZGFwX2luZm8pKSB8fCAodGhpcy5zb3VyY2Vfc3lzdGVtID09IDQpIHx8ICh0aGlzLnNvdXJjZV9zeXN0ZW0gPT0gNSAmJiBoYXModGhpcy5yZWRmaXNoX2luZm8pKSB8fCAodGhpcy5zb3VyY2Vfc3lzdGVtID09IDYgJiYgaGFzKHRoaXMubmF0c19pbmZvKSkgfHwgKHRoaXMuc291cmNlX3N5c3RlbSA9PSA3ICYmIGhhcyh0aGlzLnVuaXhfaW5mbykpIHx8IHRoaXMuc291cmNlX3N5c3RlbSA9PSAwGqIBChh1c2VyX3RpbWVzdGFtcHNfb3JkZXJpbmcSMGNyZWF0ZWRfYXQgbXVzdCBiZSBiZWZvcmUgb3IgZXF1YWwgdG8gdXBkYXRlZF9hdBpUIWhhcyh0aGlzLmNyZWF0ZWRfYXQpIHx8ICFoYXModGhpcy51cGRhdGVkX2F0KSB8fCB0aGlzLmNyZWF0ZWRfYXQgPD0gdGhpcy51cGRhdGVkX2F0GpsBCh51c2VyX2xhc3RfbG9naW5fYWZ0ZXJfY3JlYXRpb24SI2xhc3RfbG9naW4gbXVzdCBiZSBhZnRlciBjcmVhdGVkX2F0GlQhaGFzKHRoaXMuY3JlYXRlZF9hdCkgfHwgIWhhcyh0aGlzLmxhc3RfbG9naW4pIHx8IHRoaXMuY3JlYXRlZF9hdCA8PSB0aGlzLmxhc3RfbG9naW5CDAoKX2Z1bGxfbmFtZUIICgZfZW1haWxCDQoLX2xhc3RfbG9naW5CDAoKX2F1dGhfZGF0YUIMCgpfdW5peF9pbmZvQgwKCl9sZGFwX2luZm9CDwoNX3JlZGZpc2hfaW5mb0IMCgpfbmF0c19pbmZvIpUJChJBdXRoZW50aWNhdGlvbkRhdGESHgoNcGFzc3dvcmRfaGFzaBgBIAEoCUIHukgEcgIQARIjCg1wYXNzd29yZF9zYWx0GAIgASgJQge6SARyAhABSACIAQESSAoOaGFzaF9hbGdvcml0aG0YAyABKA4yJi5zY2hlbWEudjFhbHBoYTEuUGFzc3dvcmRIYXNoQWxnb3JpdGhtQgi6SAWCAQIQARIbCgppdGVyYXRpb25zGAQgASgFQge6SAQaAigBEj4KFXBhc3N3b3JkX2xhc3RfY2hhbmdlZBgFIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXBIAYgBARI8ChNwYXNzd29yZF9leHBpcmVzX2F0GAYgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcEgCiAEBEj4KDGxvY2tvdXRfaW5mbxgHIAEoCzIjLnNjaGVtYS52MWFscGhhMS5BY2NvdW50TG9ja291dEluZm9IA4gBATq/BbpIuwUa3gEKJmF1dGhfZGF0YV9wYXNzd29yZF9leHBpcnlfYWZ0ZXJfY2hhbmdlEjdwYXNzd29yZF9leHBpcmVzX2F0IG11c3QgYmUgYWZ0ZXIgcGFzc3dvcmRfbGFzdF9jaGFuZ2VkGnshaGFzKHRoaXMucGFzc3dvcmRfbGFzdF9jaGFuZ2VkKSB8fCAhaGFzKHRoaXMucGFzc3dvcmRfZXhwaXJlc19hdCkgfHwgdGhpcy5wYXNzd29yZF9sYXN0X2NoYW5nZWQgPCB0aGlzLnBhc3N3b3JkX2V4cGlyZXNfYXQa1wMKImF1dGhfZGF0YV9pdGVyYXRpb25zX2Zvcl9hbGdvcml0aG0SMWl0ZXJhdGlvbnMgbXVzdCBiZSBhcHByb3ByaWF0ZSBmb3IgaGFzaCBhbGdvcml0aG0a/QIodGhpcy5oYXNoX2FsZ29yaXRobSA9PSAxICYmIHRoaXMuaXRlcmF0aW9ucyA+PSAxMCAmJiB0aGlzLml0ZXJhdGlvbnMgPD0gMTUpIHx8ICh0aGlzLmhhc2hfYWxnb3JpdGhtID09IDIgJiYgdGhpcy5pdGVyYXRpb25zID49IDEgJiYgdGhpcy5pdGVyYXRpb25zIDw9IDEwKSB8fCAodGhpcy5oYXNoX2FsZ29yaXRobSA9PSAzICYmIHRoaXMuaXRlcmF0aW9ucyA+PSAxNCAmJiB0aGlzLml0ZXJhdGlvbnMgPD0gMjApIHx8ICh0aGlzLmhhc2hfYWxnb3JpdGhtID09IDQgJiYgdGhpcy5pdGVyYXRpb25zID49IDEwMDAwMCkgfHwgKHRoaXMuaGFzaF9hbGdvcml0aG0gPT0gNSAmJiB0aGlzLml0ZXJhdGlvbnMgPj0gMTAwMDAwKSB8fCB0aGlzLmhhc2hfYWxnb3JpdGhtID09IDBCEAoOX3Bhc3N3b3JkX3NhbHRCGAoWX3Bhc3N3b3JkX2xhc3RfY2hhbmdlZEIWChRfcGFzc3dvcmRfZXhwaXJlc19hdEIPCg1fbG9ja291dF9pbmZvIsEFChJBY2NvdW50TG9ja291dEluZm8SDgoGbG9ja2VkGAEgASgIEj0KBnJlYXNvbhgCIAEoDjIeLnNjaGVtYS52MWFscGhhMS5Mb2Nrb3V0UmVhc29uQgi6SAWCAQIQAUgAiAEBEjUKDGxvY2tvdXRfdGltZRgDIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXBIAYgBARIgCg9mYWlsZWRfYXR0ZW1wdHMYBCABKAVCB7pIBBoCKAASPAoTYXR0ZW1wdHNfcmVzZXRfdGltZRgFIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXBIAogBARIpChNtYXhfZmFpbGVkX2F0dGVtcHRzGAYgASgFQge6SAQaAigBSAOIAQE6zQK6SMkCGnAKGGxvY2tvdXRfaW5mb19jb25zaXN0ZW5jeRIsbG9ja291dF90aW1lIG11c3QgYmUgc2V0IHdoZW4gbG9ja2VkIGlzIHRydWUaJiF0aGlzLmxvY2tlZCB8fCBoYXModGhpcy5sb2Nrb3V0X3RpbWUpGtQBCiJsb2Nrb3V0X2ZhaWxlZF9hdHRlbXB0c19yZXNldF90aW1lEkJhdHRlbXB0c19yZXNldF90aW1lIHNob3VsZCBiZSBhZnRlciBsb2Nrb3V0X3RpbWUgd2hlbiBib3RoIGFyZSBzZXQaaiFoYXModGhpcy5sb2Nrb3V0X3RpbWUpIHx8ICFoYXModGhpcy5hdHRlbXB0c19yZXNldF90aW1lKSB8fCB0aGlzLmxvY2tvdXRfdGltZSA8PSB0aGlzLmF0dGVtcHRzX3Jlc2V0X3RpbWVCCQoHX3JlYXNvbkIPCg1fbG9ja291dF90aW1lQhYKFF9hdHRlbXB0c19yZXNldF90aW1lQhYKFF9tYXhfZmFpbGVkX2F0dGVtcHRzIugBCgxVbml4VXNlckluZm8SGAoDdWlkGAEgASgFQgu6SAgaBhj//wMoABIYCgNnaWQYAiABKAVCC7pICBoGGP//AygAEiUKDmhvbWVfZGlyZWN0b3J5GAMgASgJQg26SApyCBABMgReLy4qEh8KBXNoZWxsGAQgASgJQgu6SAhyBjIEXi8uKkgAiAEBEhwKBWdlY29zGAUgASgJQgi6SAVyAxiAAkgBiAEBEioKFHN1cHBsZW1lbnRhcnlfZ3JvdXBzGAYgAygFQgy6SAmSAQYiBBoCKABCCAoGX3NoZWxsQggKBl9nZWNvcyLpAwoMTGRhcFVzZXJJbmZvEhgKB2xkYXBfZG4YASABKAlCB7pIBHICEAESIQoLb2JqZWN0X2d1aWQYAiABKAlCB7pIBHICEAFIAIgBARIoChBzYW1fYWNjb3VudF9uYW1lGAMgASgJQgm6SAZyBBABGBRIAYgBARIpChN1c2VyX3ByaW5jaXBhbF9uYW1lGAQgASgJQge6SARyAhABSAKIAQESEQoJbWVtYmVyX29mGAUgAygJEjgKD2FjY291bnRfZXhwaXJlcxgGIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXBIA4gBARI1Cgxwd2RfbGFzdF9zZXQYByABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wSASIAQESHAoGZG9tYWluGAggASgJQge6SARyAhABSAWIAQESIAoTb3JnYW5pemF0aW9uYWxfdW5pdBgJIAEoCUgGiAEBQg4KDF9vYmplY3RfZ3VpZEITChFfc2FtX2FjY291bnRfbmFtZUIWChRfdXNlcl9wcmluY2lwYWxfbmFtZUISChBfYWNjb3VudF9leHBpcmVzQg8KDV9wd2RfbGFzdF9zZXRCCQoHX2RvbWFpbkIWChRfb3JnYW5pemF0aW9uYWxfdW5pdCKVAgoSUmVkZmlzaEFjY291bnRJbmZvEiAKCmFjY291bnRfaWQYASABKAlCB7pIBHICEAFIAIgBARIYCgdyb2xlX2lkGAIgASgJQge6SARyAhABEkIKDmxvY2tvdXRfcG9saWN5GAMgASgLMiUuc2NoZW1hLnYxYWxwaGExLlJlZGZpc2hMb2Nrb3V0UG9saWN5SAGIAQESGQoRb2VtX2FjY291bnRfdHlwZXMYBCADKAkSJQoYcGFzc3dvcmRfY2hhbmdlX3JlcXVpcmVkGAUgASgISAKIAQFCDQoLX2FjY291bnRfaWRCEQoPX2xvY2tvdXRfcG9saWN5QhsKGV9wYXNzd29yZF9jaGFuZ2VfcmVxdWlyZWQiswEKFFJlZGZpc2hMb2Nrb3V0UG9saWN5Eh0KCXRocmVzaG9sZBgBIAEoBUIKukgHGgUY5wcoABItCghkdXJhdGlvbhgCIAEoCUIWukgTchEyD15QVFswLTldK1tITVNdJEgAiAEBEjAKC3Jlc2V0X2FmdGVyGAMgASgJQha6SBNyETIPXlBUWzAtOV0rW0hNU10kSAGIAQFCCwoJX2R1cmF0aW9uQg4KDF9yZXNldF9hZnRlciKJAwoPTmF0c0FjY291bnRJbmZvEhgKB2FjY291bnQYASABKAlCB7pIBHICEAESOgoLcGVybWlzc2lvbnMYAiABKAsyIC5zY2hlbWEudjFhbHBoYTEuTmF0c1Blcm1pc3Npb25zSACIAQESMAoGbGltaXRzGAMgASgLMhsuc2NoZW1hLnYxYWxwaGExLk5hdHNMaW1pdHNIAYgBARIeCgh1c2VyX2p3dBgEIAEoCUIHukgEcgIQAUgCiAEBEh4KCHVzZXJfa2V5GAUgASgJQge6SARyAhABSAOIAQESHwoJdXNlcl9jcmVkGAYgASgJQge6SARyAhABSASIAQESNwoOand0X2V4cGlyZXNfYXQYByABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wSAWIAQFCDgoMX3Blcm1pc3Npb25zQgkKB19saW1pdHNCCwoJX3VzZXJfand0QgsKCV91c2VyX2tleUIMCgpfdXNlcl9jcmVkQhEKD19qd3RfZXhwaXJlc19hdCJcCg9OYXRzUGVybWlzc2lvbnMSDwoHcHVibGlzaBgBIAMoCRIRCglzdWJzY3JpYmUYAiADKAkSFwoPYWxsb3dfcmVzcG9uc2VzGAMgAygJEgwKBGRlbnkYBCADKAkiywEKCk5hdHNMaW1pdHMSHgoEZGF0YRgBIAEoA0IQukgNIgso////////////ARIhCgdwYXlsb2FkGAIgASgDQhC6SA0iCyj///////////8BEh4KBHN1YnMYAyABKANCELpIDSILKP///////////wESIwoEY29ubhgEIAEoA0IQukgNIgso////////////AUgAiAEBEiMKBGxlYWYYBSABKANCELpIDSILKP///////////wFIAYgBAUIHCgVfY29ubkIHCgVfbGVhZiKTBAoSVXNlckxpbmtpbmdPcHRpb25zEj4KC3VuaXhfYWN0aW9uGAEgASgOMh8uc2NoZW1hLnYxYWxwaGExLlVzZXJMaW5rQWN0aW9uQgi6SAWCAQIQARI+CgtsZGFwX2FjdGlvbhgCIAEoDjIfLnNjaGVtYS52MWFscGhhMS5Vc2VyTGlua0FjdGlvbkIIukgFggECEAESQQoOcmVkZmlzaF9hY3Rpb24YAyABKA4yHy5zY2hlbWEudjFhbHBoYTEuVXNlckxpbmtBY3Rpb25CCLpIBYIBAhABEj4KC25hdHNfYWN0aW9uGAQgASgOMh8uc2NoZW1hLnYxYWxwaGExLlVzZXJMaW5rQWN0aW9uQgi6SAWCAQIQARIjChZleGlzdGluZ191bml4X3VzZXJuYW1lGAUgASgJSACIAQESHQoQZXhpc3RpbmdfbGRhcF9kbhgGIAEoCUgBiAEBEigKG2V4aXN0aW5nX3JlZGZpc2hfYWNjb3VudF9pZBgHIAEoCUgCiAEBEiIKFWV4aXN0aW5nX25hdHNfYWNjb3VudBgIIAEoCUgDiAEBQhkKF19leGlzdGluZ191bml4X3VzZXJuYW1lQhMKEV9leGlzdGluZ19sZGFwX2RuQh4KHF9leGlzdGluZ19yZWRmaXNoX2FjY291bnRfaWRCGAoWX2V4aXN0aW5nX25hdHNfYWNjb3VudCLyAgoRQ3JlYXRlVXNlclJlcXVlc3QSKwoEdXNlchgBIAEoCzIVLnNjaGVtYS52MWFscGhhMS5Vc2VyQga6SAPIAQESHgoIcGFzc3dvcmQYAiABKAlCB7pIBHICEAhIAIgBARJBCg9saW5raW5nX29wdGlvbnMYAyABKAsyIy5zY2hlbWEudjFhbHBoYTEuVXNlckxpbmtpbmdPcHRpb25zSAGIAQESFAoHZHJ5X3J1bhgEIAEoCEgCiAEBOokBukiFARqCAQogY3JlYXRlX3VzZXJfcGFzc3dvcmRfcmVxdWlyZW1lbnQSJHBhc3N3b3JkIGlzIHJlcXVpcmVkIGZvciBsb2NhbCB1c2Vycxo4dGhpcy51c2VyLnNvdXJjZV9zeXN0ZW0gIT0gMSB8fCBzaXplKHRoaXMucGFzc3dvcmQpID49IDhCCwoJX3Bhc3N3b3JkQhIKEF9saW5raW5nX29wdGlvbnNCCgoIX2RyeV9ydW4iZQoSQ3JlYXRlVXNlclJlc3BvbnNlEiMKBHVzZXIYASABKAsyFS5zY2hlbWEudjFhbHBoYTEuVXNlchIQCgh3YXJuaW5ncxgCIAMoCRIYChBjcmVhdGVkX2FjY291bnRzGAMgAygJIpwBCg5HZXRVc2VyUmVxdWVzdBIMCgJpZBgBIAEoCUgAEhIKCHVzZXJuYW1lGAIgASgJSAASDwoFZW1haWwYAyABKAlIABIzCgpmaWVsZF9tYXNrGAQgASgLMhouZ29vZ2xlLnByb3RvYnVmLkZpZWxkTWFza0gBiAEBQhMKCmlkZW50aWZpZXISBbpIAggBQg0KC19maWVsZF9tYXNrIjYKD0dldFVzZXJSZXNwb25zZRIjCgR1c2VyGAEgASgLMhUuc2NoZW1hLnYxYWxwaGExLlVzZXIixwEKEVVwZGF0ZVVzZXJSZXF1ZXN0EisKBHVzZXIYASABKAsyFS5zY2hlbWEudjFhbHBoYTEuVXNlckIGukgDyAEBEi4KCmZpZWxkX21hc2sYAiABKAsyGi5nb29nbGUucHJvdG9idWYuRmllbGRNYXNrEkEKD2xpbmtpbmdfb3B0aW9ucxgDIAEoCzIjLnNjaGVtYS52MWFscGhhMS5Vc2VyTGlua2luZ09wdGlvbnNIAIgBAUISChBfbGlua2luZ19vcHRpb25zIksKElVwZGF0ZVVzZXJSZXNwb25zZRIjCgR1c2VyGAEgASgLMhUuc2NoZW1hLnYxYWxwaGExLlVzZXISEAoId2FybmluZ3MYAiADKAkiggEKEURlbGV0ZVVzZXJSZXF1ZXN0EhMKAmlkGAEgASgJQge6SARyAhABEhsKDmNhc2NhZGVfZGVsZXRlGAIgASgISACIAQESGAoLYmFja3VwX2RhdGEYAyABKAhIAYgBAUIRCg9fY2FzY2FkZV9kZWxldGVCDgoMX2JhY2t1cF9kYXRhInEKEkRlbGV0ZVVzZXJSZXNwb25zZRIPCgdzdWNjZXNzGAEgASgIEhgKEGRlbGV0ZWRfYWNjb3VudHMYAiADKAkSHAoPYmFja3VwX2xvY2F0aW9uGAMgASgJSACIAQFCEgoQX2JhY2t1cF9sb2NhdGlvbiLIAgoQTGlzdFVzZXJzUmVxdWVzdBI6CgZzb3VyY2UYASABKA4yGy5zY2hlbWEudjFhbHBoYTEuVXNlclNvdXJjZUIIukgFggECEAFIAIgBARIUCgdlbmFibGVkGAIgASgISAGIAQESHAoPdXNlcm5hbWVfcHJlZml4GAMgASgJSAKIAQESMwoKZmllbGRfbWFzaxgEIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5GaWVsZE1hc2tIA4gBARIfCglwYWdlX3NpemUYBSABKAVCB7pIBBoCKAFIBIgBARIXCgpwYWdlX3Rva2VuGAYgASgJSAWIAQFCCQoHX3NvdXJjZUIKCghfZW5hYmxlZEISChBfdXNlcm5hbWVfcHJlZml4Qg0KC19maWVsZF9tYXNrQgwKCl9wYWdlX3NpemVCDQoLX3BhZ2VfdG9rZW4iawoRTGlzdFVzZXJzUmVzcG9uc2USJAoFdXNlcnMYASADKAsyFS5zY2hlbWEudjFhbHBoYTEuVXNlchIcCg9uZXh0X3BhZ2VfdG9rZW4YAiABKAlIAIgBAUISChBfbmV4dF9wYWdlX3Rva2VuInEKFUNoYW5nZVBhc3N3b3JkUmVxdWVzdBITCgJpZBgBIAEoCUIHukgEcgIQARIhChBjdXJyZW50X3Bhc3N3b3JkGAIgASgJQge6SARyAhABEiAKDG5ld19wYXNzd29yZBgDIAEoCUIKukgHcgUQCBiAASJZChZDaGFuZ2VQYXNzd29yZFJlc3BvbnNlEg8KB3N1Y2Nlc3MYASABKAgSGwoOZmFpbHVyZV9yZWFzb24YAiABKAlIAIgBAUIRCg9fZmFpbHVyZV9yZWFzb24itwEKFFJlc2V0UGFzc3dvcmRSZXF1ZXN0EhMKAmlkGAEgASgJQge6SARyAhABEiUKDG5ld19wYXNzd29yZBgCIAEoCUIKukgHcgUQCBiAAUgAiAEBEhIKBWZvcmNlGAMgASgISAGIAQESHgoRZ2VuZXJhdGVfcGFzc3dvcmQYBCABKAhIAogBAUIPCg1fbmV3X3Bhc3N3b3JkQggKBl9mb3JjZUIUChJfZ2VuZXJhdGVfcGFzc3dvcmQibgoVUmVzZXRQYXNzd29yZFJlc3BvbnNlEhQKDG5ld19wYXNzd29yZBgBIAEoCRIPCgdzdWNjZXNzGAIgASgIEhsKDmZhaWx1cmVfcmVhc29uGAMgASgJSACIAQFCEQoPX2ZhaWx1cmVfcmVhc29uIp0BChdBdXRoZW50aWNhdGVVc2VyUmVxdWVzdBIZCgh1c2VybmFtZRgBIAEoCUIHukgEcgIQARIZCghwYXNzd29yZBgCIAEoCUIHukgEcgIQARIWCglzb3VyY2VfaXAYAyABKAlIAIgBARIXCgp1c2VyX2FnZW50GAQgASgJSAGIAQFCDAoKX3NvdXJjZV9pcEINCgtfdXNlcl9hZ2VudCLrAQoYQXV0aGVudGljYXRlVXNlclJlc3BvbnNlEg8KB3N1Y2Nlc3MYASABKAgSFAoHdXNlcl9pZBgCIAEoCUgAiAEBEhIKBXRva2VuGAMgASgJSAGIAQESGwoOZmFpbHVyZV9yZWFzb24YBCABKAlIAogBARI5ChB0b2tlbl9leHBpcmVzX2F0GAUgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcEgDiAEBQgoKCF91c2VyX2lkQggKBl90b2tlbkIRCg9fZmFpbHVyZV9yZWFzb25CEwoRX3Rva2VuX2V4cGlyZXNfYXQq4wEKClVzZXJTb3VyY2USGwoXVVNFUl9TT1VSQ0VfVU5TUEVDSUZJRUQQABIVChFVU0VSX1NPVVJDRV9MT0NBTBABEhQKEFVTRVJfU09VUkNFX0xEQVAQAhISCg5VU0VSX1NPVVJDRV9BRBADEhQKEFVTRVJfU09VUkNFX0lQTUkQBBIXChNVU0VSX1NPVVJDRV9SRURGSVNIEAUSFAoQVVNFUl9TT1VSQ0VfTkFUUxAGEhQKEFVTRVJfU09VUkNFX1VOSVgQBxIcChhVU0VSX1NPVVJDRV9FWFRFUk5BTF9BUEkQCCreAgoVVXNlckNyZWF0aW9uSW50ZXJmYWNlEicKI1VTRVJfQ1JFQVRJT05fSU5URVJGQUNFX1VOU1BFQ0lGSUVEEAASJgoiVVNFUl9DUkVBVElPTl9JTlRFUkZBQ0VfU0NIRU1BX0FQSRABEigKJFVTRVJfQ1JFQVRJT05fSU5URVJGQUNFX1VOSVhfVVNFUkFERBACEiYKIlVTRVJfQ1JFQVRJT05fSU5URVJGQUNFX0xEQVBfQURNSU4QAxIkCiBVU0VSX0NSRUFUSU9OX0lOVEVSRkFDRV9BRF9BRE1JThAEEicKI1VTRVJfQ1JFQVRJT05fSU5URVJGQUNFX1JFREZJU0hfQVBJEAUSJwojVVNFUl9DUkVBVElPTl9JTlRFUkZBQ0VfTkFUU19DT05GSUcQBhIqCiZVU0VSX0NSRUFUSU9OX0lOVEVSRkFDRV9JUE1JX1VTRVJfTUdNVBAHKoQCChVQYXNzd29yZEhhc2hBbGdvcml0aG0SJwojUEFTU1dPUkRfSEFTSF9BTEdPUklUSE1fVU5TUEVDSUZJRUQQABIiCh5QQVNTV09SRF9IQVNIX0FMR09SSVRITV9CQ1JZUFQQARIkCiBQQVNTV09SRF9IQVNIX0FMR09SSVRITV9BUkdPTjJJRBACEiIKHlBBU1NXT1JEX0hBU0hfQUxHT1JJVEhNX1NDUllQVBADEikKJVBBU1NXT1JEX0hBU0hfQUxHT1JJVEhNX1BCS0RGMl9TSEEyNTYQBBIpCiVQQVNTV09SRF9IQVNIX0FMR09SSVRITV9QQktERjJfU0hBNTEyEAUq6QEKDUxvY2tvdXRSZWFzb24SHgoaTE9DS09VVF9SRUFTT05fVU5TUEVDSUZJRUQQABIoCiRMT0NLT1VUX1JFQVNPTl9GQUlMRURfTE9HSU5fQVRURU1QVFMQARIhCh1MT0NLT1VUX1JFQVNPTl9BRE1JTklTVFJBVElWRRACEiMKH0xPQ0tPVVRfUkVBU09OX1BBU1NXT1JEX0VYUElSRUQQAxIiCh5MT0NLT1VUX1JFQVNPTl9BQ0NPVU5UX0VYUElSRUQQBBIiCh5MT0NLT1VUX1JFQVNPTl9TRUNVUklUWV9QT0xJQ1kQBSqXAQoOVXNlckxpbmtBY3Rpb24SIAocVVNFUl9MSU5LX0FDVElPTl9VTlNQRUNJRklFRBAAEiIKHlVTRVJfTElOS19BQ1RJT05fTElOS19FWElTVElORxABEh8KG1VTRVJfTElOS19BQ1RJT05fQ1JFQVRFX05FVxACEh4KGlVTRVJfTElOS19BQ1RJT05fTk9fQUNUSU9OEANCvAEKE2NvbS5zY2hlbWEudjFhbHBoYTFCCVVzZXJQcm90b1ABWj1naXRodWIuY29tL3UtYm1jL3UtYm1jL2FwaS9nZW4vc2NoZW1hL3YxYWxwaGExO3NjaGVtYXYxYWxwaGExogIDU1hYqgIPU2NoZW1hLlYxYWxwaGExygIPU2NoZW1hXFYxYWxwaGEx4gIbU2NoZW1hXFYxYWxwaGExXEdQQk1ldGFkYXRh6gIQU2NoZW1hOjpWMWFscGhhMWIGcHJvdG8z", [file_buf_validate_validate, file_google_protobuf_timestamp, file_google_protobuf_field_mask]);
+  fileDesc("ChpzY2hlbWEvdjFhbHBoYTEvdXNlci5wcm90bxIPc2NoZW1hLnYxYWxwaGExItwOCgRVc2VyEhMKAmlkGAEgASgJQge6SARyAhABEi4KCHVzZXJuYW1lGAIgASgJQhy6SBlyFxABGEAyEV5bYS16QS1aMC05Ll8tXSskEiIKCWZ1bGxfbmFtZRgDIAEoCUIKukgHcgUQARiAAkgAiAEBEh4KBWVtYWlsGAQgASgJQgq6SAdyBRjAAmABSAGIAQESFwoHZW5hYmxlZBgFIAEoCEIGukgDyAEBEjYKCmNyZWF0ZWRfYXQYBiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wQga6SAPIAQESNgoKdXBkYXRlZF9hdBgHIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXBCBrpIA8gBARIzCgpsYXN0X2xvZ2luGAggASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcEgCiAEBEjwKDXNvdXJjZV9zeXN0ZW0YCSABKA4yGy5zY2hlbWEudjFhbHBoYTEuVXNlclNvdXJjZUIIukgFggECEAESTAoSY3JlYXRpb25faW50ZXJmYWNlGAogASgOMiYuc2NoZW1hLnYxYWxwaGExLlVzZXJDcmVhdGlvbkludGVyZmFjZUIIukgFggECEAESOwoJYXV0aF9kYXRhGAsgASgLMiMuc2NoZW1hLnYxYWxwaGExLkF1dGhlbnRpY2F0aW9uRGF0YUgDiAEBEjUKCXVuaXhfaW5mbxgMIAEoCzIdLnNjaGVtYS52MWFscGhhMS5Vbml4VXNlckluZm9IBIgBARI1CglsZGFwX2luZm8YDSABKAsyHS5zY2hlbWEudjFhbHBoYTEuTGRhcFVzZXJJbmZvSAWIAQESPgoMcmVkZmlzaF9pbmZvGA4gASgLMiMuc2NoZW1hLnYxYWxwaGExLlJlZGZpc2hBY2NvdW50SW5mb0gGiAEBEjgKCW5hdHNfaW5mbxgPIAEoCzIgLnNjaGVtYS52MWFscGhhMS5OYXRzQWNjb3VudEluZm9IB4gBARJGChFjdXN0b21fYXR0cmlidXRlcxgQIAMoCzIrLnNjaGVtYS52MWFscGhhMS5Vc2VyLkN1c3RvbUF0dHJpYnV0ZXNFbnRyeRI7ChJhY2NvdW50X2V4cGlyZXNfYXQYESABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wSAiIAQEaNwoVQ3VzdG9tQXR0cmlidXRlc0VudHJ5EgsKA2tleRgBIAEoCRINCgV2YWx1ZRgCIAEoCToCOAE6lga6SJIGGswDCiN1c2VyX3NvdXJjZV9zeXN0ZW1faW5mb19jb25zaXN0ZW5jeRIzdXNlciBtdXN0IGhhdmUgY29ycmVzcG9uZGluZyBpbmZvIGZvciBzb3VyY2Ugc3lzdGVtGu8CKHRoaXMuc291cmNlX3N5c3RlbSA9PSAxICYmIGhhcyh0aGlzLmF1dGhfZGF0YSkpIHx8ICh0aGlzLnNvdXJjZV9zeXN0ZW0gPT0gMiAmJiBoYXModGhpcy5sZGFwX2luZm8pKSB8fCAodGhpcy5zb3VyY2Vfc3lzdGVtID09IDMgJiYgaGFzKHRoaXMubGRhcF9pbmZvKSkgfHwgKHRoaXMuc291cmNlX3N5c3RlbSA9PSA0KSB8fCAodGhpcy5zb3VyY2Vfc3lzdGVtID09IDUgJiYgaGFzKHRoaXMucmVkZmlzaF9pbmZvKSkgfHwgKHRoaXMuc291cmNlX3N5c3RlbSA9PSA2ICYmIGhhcyh0aGlzLm5hdHNfaW5mbykpIHx8ICh0aGlzLnNvdXJjZV9zeXN0ZW0gPT0gNyAmJiBoYXModGhpcy51bml4X2luZm8pKSB8fCB0aGlzLnNvdXJjZV9zeXN0ZW0gPT0gMBqiAQoYdXNlcl90aW1lc3RhbXBzX29yZGVyaW5nEjBjcmVhdGVkX2F0IG11c3QgYmUgYmVmb3JlIG9yIGVxdWFsIHRvIHVwZGF0ZWRfYXQaVCFoYXModGhpcy5jcmVhdGVkX2F0KSB8fCAhaGFzKHRoaXMudXBkYXRlZF9hdCkgfHwgdGhpcy5jcmVhdGVkX2F0IDw9IHRoaXMudXBkYXRlZF9hdBqbAQoedXNlcl9sYXN0X2xvZ2luX2FmdGVyX2NyZWF0aW9uEiNsYXN0X2xvZ2luIG11c3QgYmUgYWZ0ZXIgY3JlYXRlZF9hdBpUIWhhcyh0aGlzLmNyZWF0ZWRfYXQpIHx8ICFoYXModGhpcy5sYXN0X2xvZ2luKSB8fCB0aGlzLmNyZWF0ZWRfYXQgPD0gdGhpcy5sYXN0X2xvZ2luQgwKCl9mdWxsX25hbWVCCAoGX2VtYWlsQg0KC19sYXN0X2xvZ2luQgwKCl9hdXRoX2RhdGFCDAoKX3VuaXhfaW5mb0IMCgpfbGRhcF9pbmZvQg8KDV9yZWRmaXNoX2luZm9CDAoKX25hdHNfaW5mb0IVChNfYWNjb3VudF9leHBpcmVzX2F0IsUKChJBdXRoZW50aWNhdGlvbkRhdGESHgoNcGFzc3dvcmRfaGFzaBgBIAEoCUIHukgEcgIQARIjCg1wYXNzd29yZF9zYWx0GAIgASgJQge6SARyAhABSACIAQESSAoOaGFzaF9hbGdvcml0aG0YAyABKA4yJi5zY2hlbWEudjFhbHBoYTEuUGFzc3dvcmRIYXNoQWxnb3JpdGhtQgi6SAWCAQIQARIbCgppdGVyYXRpb25zGAQgASgFQge6SAQaAigBEj4KFXBhc3N3b3JkX2xhc3RfY2hhbmdlZBgFIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXBIAYgBARI8ChNwYXNzd29yZF9leHBpcmVzX2F0GAYgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcEgCiAEBEj4KDGxvY2tvdXRfaW5mbxgHIAEoCzIjLnNjaGVtYS52MWFscGhhMS5BY2NvdW50TG9ja291dEluZm9IA4gBARJJChBwYXNzd29yZF9oaXN0b3J5GAggAygLMiUuc2NoZW1hLnYxYWxwaGExLlBhc3N3b3JkSGlzdG9yeUVudHJ5Qgi6SAWSAQIQGBIsCgR0b3RwGAkgASgLMhkuc2NoZW1hLnYxYWxwaGExLlRvdHBEYXRhSASIAQESGgoNdG90cF9yZXF1aXJlZBgKIAEoCEgFiAEBOr8Fuki7BRreAQomYXV0aF9kYXRhX3Bhc3N3b3JkX2V4cGlyeV9hZnRlcl9jaGFuZ2USN3Bhc3N3b3JkX2V4cGlyZXNfYXQgbXVzdCBiZSBhZnRlciBwYXNzd29yZF9sYXN0X2NoYW5nZWQaeyFoYXModGhpcy5wYXNzd29yZF9sYXN0X2NoYW5nZWQpIHx8ICFoYXModGhpcy5wYXNzd29yZF9leHBpcmVzX2F0KSB8fCB0aGlzLnBhc3N3b3JkX2xhc3RfY2hhbmdlZCA8IHRoaXMucGFzc3dvcmRfZXhwaXJlc19hdBrXAwoiYXV0aF9kYXRhX2l0ZXJhdGlvbnNfZm9yX2FsZ29yaXRobRIxaXRlcmF0aW9ucyBtdXN0IGJlIGFwcHJvcHJpYXRlIGZvciBoYXNoIGFsZ29yaXRobRr9Aih0aGlzLmhhc2hfYWxnb3JpdGhtID09IDEgJiYgdGhpcy5pdGVyYXRpb25zID49IDEwICYmIHRoaXMuaXRlcmF0aW9ucyA8PSAxNSkgfHwgKHRoaXMuaGFzaF9hbGdvcml0aG0gPT0gMiAmJiB0aGlzLml0ZXJhdGlvbnMgPj0gMSAmJiB0aGlzLml0ZXJhdGlvbnMgPD0gMTApIHx8ICh0aGlzLmhhc2hfYWxnb3JpdGhtID09IDMgJiYgdGhpcy5pdGVyYXRpb25zID49IDE0ICYmIHRoaXMuaXRlcmF0aW9ucyA8PSAyMCkgfHwgKHRoaXMuaGFzaF9hbGdvcml0aG0gPT0gNCAmJiB0aGlzLml0ZXJhdGlvbnMgPj0gMTAwMDAwKSB8fCAodGhpcy5oYXNoX2FsZ29yaXRobSA9PSA1ICYmIHRoaXMuaXRlcmF0aW9ucyA+PSAxMDAwMDApIHx8IHRoaXMuaGFzaF9hbGdvcml0aG0gPT0gMEIQCg5fcGFzc3dvcmRfc2FsdEIYChZfcGFzc3dvcmRfbGFzdF9jaGFuZ2VkQhYKFF9wYXNzd29yZF9leHBpcmVzX2F0Qg8KDV9sb2Nrb3V0X2luZm9CBwoFX3RvdHBCEAoOX3RvdHBfcmVxdWlyZWQimAIKFFBhc3N3b3JkSGlzdG9yeUVudHJ5Eh4KDXBhc3N3b3JkX2hhc2gYASABKAlCB7pIBHICEAESIwoNcGFzc3dvcmRfc2FsdBgCIAEoCUIHukgEcgIQAUgAiAEBEkgKDmhhc2hfYWxnb3JpdGhtGAMgASgOMiYuc2NoZW1hLnYxYWxwaGExLlBhc3N3b3JkSGFzaEFsZ29yaXRobUIIukgFggECEAESGwoKaXRlcmF0aW9ucxgEIAEoBUIHukgEGgIoARIzCgpjaGFuZ2VkX2F0GAUgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcEgBiAEBQhAKDl9wYXNzd29yZF9zYWx0Qg0KC19jaGFuZ2VkX2F0IscBCghUb3RwRGF0YRIPCgdlbmFibGVkGAEgASgIEiEKEGVuY3J5cHRlZF9zZWNyZXQYAiABKAxCB7pIBHoCEAESNAoLZW5yb2xsZWRfYXQYAyABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wSACIAQESIAoOcmVjb3ZlcnlfY29kZXMYBCADKAlCCLpIBZIBAhAQEh8KDmxhc3RfdXNlZF9zdGVwGAUgASgDQge6SAQiAigAQg4KDF9lbnJvbGxlZF9hdCLBBQoSQWNjb3VudExvY2tvdXRJbmZvEg4KBmxvY2tlZBgBIAEoCBI9CgZyZWFzb24YAiABKA4yHi5zY2hlbWEudjFhbHBoYTEuTG9ja291dFJlYXNvbkIIukgFggECEAFIAIgBARI1Cgxsb2Nrb3V0X3RpbWUYAyABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wSAGIAQESIAoPZmFpbGVkX2F0dGVtcHRzGAQgASgFQge6SAQaAigAEjwKE2F0dGVtcHRzX3Jlc2V0X3RpbWUYBSABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wSAKIAQESKQoTbWF4X2ZhaWxlZF9hdHRlbXB0cxgGIAEoBUIHukgEGgIoAUgDiAEBOs0CukjJAhpwChhsb2Nrb3V0X2luZm9fY29uc2lzdGVuY3kSLGxvY2tvdXRfdGltZSBtdXN0IGJlIHNldCB3aGVuIGxvY2tlZCBpcyB0cnVlGiYhdGhpcy5sb2NrZWQgfHwgaGFzKHRoaXMubG9ja291dF90aW1lKRrUAQoibG9ja291dF9mYWlsZWRfYXR0ZW1wdHNfcmVzZXRfdGltZRJCYXR0ZW1wdHNfcmVzZXRfdGltZSBzaG91bGQgYmUgYWZ0ZXIgbG9ja291dF90aW1lIHdoZW4gYm90aCBhcmUgc2V0GmohaGFzKHRoaXMubG9ja291dF90aW1lKSB8fCAhaGFzKHRoaXMuYXR0ZW1wdHNfcmVzZXRfdGltZSkgfHwgdGhpcy5sb2Nrb3V0X3RpbWUgPD0gdGhpcy5hdHRlbXB0c19yZXNldF90aW1lQgkKB19yZWFzb25CDwoNX2xvY2tvdXRfdGltZUIWChRfYXR0ZW1wdHNfcmVzZXRfdGltZUIWChRfbWF4X2ZhaWxlZF9hdHRlbXB0cyLoAQoMVW5peFVzZXJJbmZvEhgKA3VpZBgBIAEoBUILukgIGgYY//8DKAASGAoDZ2lkGAIgASgFQgu6SAgaBhj//wMoABIlCg5ob21lX2RpcmVjdG9yeRgDIAEoCUINukgKcggQATIEXi8uKhIfCgVzaGVsbBgEIAEoCUILukgIcgYyBF4vLipIAIgBARIcCgVnZWNvcxgFIAEoCUIIukgFcgMYgAJIAYgBARIqChRzdXBwbGVtZW50YXJ5X2dyb3VwcxgGIAMoBUIMukgJkgEGIgQaAigAQggKBl9zaGVsbEIICgZfZ2Vjb3Mi6QMKDExkYXBVc2VySW5mbxIYCgdsZGFwX2RuGAEgASgJQge6SARyAhABEiEKC29iamVjdF9ndWlkGAIgASgJQge6SARyAhABSACIAQESKAoQc2FtX2FjY291bnRfbmFtZRgDIAEoCUIJukgGcgQQARgUSAGIAQESKQoTdXNlcl9wcmluY2lwYWxfbmFtZRgEIAEoCUIHukgEcgIQAUgCiAEBEhEKCW1lbWJlcl9vZhgFIAMoCRI4Cg9hY2NvdW50X2V4cGlyZXMYBiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wSAOIAQESNQoMcHdkX2xhc3Rfc2V0GAcgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcEgEiAEBEhwKBmRvbWFpbhgIIAEoCUIHukgEcgIQAUgFiAEBEiAKE29yZ2FuaXphdGlvbmFsX3VuaXQYCSABKAlIBogBAUIOCgxfb2JqZWN0X2d1aWRCEwoRX3NhbV9hY2NvdW50X25hbWVCFgoUX3VzZXJfcHJpbmNpcGFsX25hbWVCEgoQX2FjY291bnRfZXhwaXJlc0IPCg1fcHdkX2xhc3Rfc2V0QgkKB19kb21haW5CFgoUX29yZ2FuaXphdGlvbmFsX3VuaXQilQIKElJlZGZpc2hBY2NvdW50SW5mbxIgCgphY2NvdW50X2lkGAEgASgJQge6SARyAhABSACIAQESGAoHcm9sZV9pZBgCIAEoCUIHukgEcgIQARJCCg5sb2Nrb3V0X3BvbGljeRgDIAEoCzIlLnNjaGVtYS52MWFscGhhMS5SZWRmaXNoTG9ja291dFBvbGljeUgBiAEBEhkKEW9lbV9hY2NvdW50X3R5cGVzGAQgAygJEiUKGHBhc3N3b3JkX2NoYW5nZV9yZXF1aXJlZBgFIAEoCEgCiAEBQg0KC19hY2NvdW50X2lkQhEKD19sb2Nrb3V0X3BvbGljeUIbChlfcGFzc3dvcmRfY2hhbmdlX3JlcXVpcmVkIrMBChRSZWRmaXNoTG9ja291dFBvbGljeRIdCgl0aHJlc2hvbGQYASABKAVCCrpIBxoFGOcHKAASLQoIZHVyYXRpb24YAiABKAlCFrpIE3IRMg9eUFRbMC05XStbSE1TXSRIAIgBARIwCgtyZXNldF9hZnRlchgDIAEoCUIWukgTchEyD15QVFswLTldK1tITVNdJEgBiAEBQgsKCV9kdXJhdGlvbkIOCgxfcmVzZXRfYWZ0ZXIiiQMKD05hdHNBY2NvdW50SW5mbxIYCgdhY2NvdW50GAEgASgJQge6SARyAhABEjoKC3Blcm1pc3Npb25zGAIgASgLMiAuc2NoZW1hLnYxYWxwaGExLk5hdHNQZXJtaXNzaW9uc0gAiAEBEjAKBmxpbWl0cxgDIAEoCzIbLnNjaGVtYS52MWFscGhhMS5OYXRzTGltaXRzSAGIAQESHgoIdXNlcl9qd3QYBCABKAlCB7pIBHICEAFIAogBARIeCgh1c2VyX2tleRgFIAEoCUIHukgEcgIQAUgDiAEBEh8KCXVzZXJfY3JlZBgGIAEoCUIHukgEcgIQAUgEiAEBEjcKDmp3dF9leHBpcmVzX2F0GAcgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcEgFiAEBQg4KDF9wZXJtaXNzaW9uc0IJCgdfbGltaXRzQgsKCV91c2VyX2p3dEILCglfdXNlcl9rZXlCDAoKX3VzZXJfY3JlZEIRCg9fand0X2V4cGlyZXNfYXQiXAoPTmF0c1Blcm1pc3Npb25zEg8KB3B1Ymxpc2gYASADKAkSEQoJc3Vic2NyaWJlGAIgAygJEhcKD2FsbG93X3Jlc3BvbnNlcxgDIAMoCRIMCgRkZW55GAQgAygJIssBCgpOYXRzTGltaXRzEh4KBGRhdGEYASABKANCELpIDSILKP///////////wESIQoHcGF5bG9hZBgCIAEoA0IQukgNIgso////////////ARIeCgRzdWJzGAMgASgDQhC6SA0iCyj///////////8BEiMKBGNvbm4YBCABKANCELpIDSILKP///////////wFIAIgBARIjCgRsZWFmGAUgASgDQhC6SA0iCyj///////////8BSAGIAQFCBwoFX2Nvbm5CBwoFX2xlYWYikwQKElVzZXJMaW5raW5nT3B0aW9ucxI+Cgt1bml4X2FjdGlvbhgBIAEoDjIfLnNjaGVtYS52MWFscGhhMS5Vc2VyTGlua0FjdGlvbkIIukgFggECEAESPgoLbGRhcF9hY3Rpb24YAiABKA4yHy5zY2hlbWEudjFhbHBoYTEuVXNlckxpbmtBY3Rpb25CCLpIBYIBAhABEkEKDnJlZGZpc2hfYWN0aW9uGAMgASgOMh8uc2NoZW1hLnYxYWxwaGExLlVzZXJMaW5rQWN0aW9uQgi6SAWCAQIQARI+CgtuYXRzX2FjdGlvbhgEIAEoDjIfLnNjaGVtYS52MWFscGhhMS5Vc2VyTGlua0FjdGlvbkIIukgFggECEAESIwoWZXhpc3RpbmdfdW5peF91c2VybmFtZRgFIAEoCUgAiAEBEh0KEGV4aXN0aW5nX2xkYXBfZG4YBiABKAlIAYgBARIoChtleGlzdGluZ19yZWRmaXNoX2FjY291bnRfaWQYByABKAlIAogBARIiChVleGlzdGluZ19uYXRzX2FjY291bnQYCCABKAlIA4gBAUIZChdfZXhpc3RpbmdfdW5peF91c2VybmFtZUITChFfZXhpc3RpbmdfbGRhcF9kbkIeChxfZXhpc3RpbmdfcmVkZmlzaF9hY2NvdW50X2lkQhgKFl9leGlzdGluZ19uYXRzX2FjY291bnQi8gIKEUNyZWF0ZVVzZXJSZXF1ZXN0EisKBHVzZXIYASABKAsyFS5zY2hlbWEudjFhbHBoYTEuVXNlckIGukgDyAEBEh4KCHBhc3N3b3JkGAIgASgJQge6SARyAhAISACIAQESQQoPbGlua2luZ19vcHRpb25zGAMgASgLMiMuc2NoZW1hLnYxYWxwaGExLlVzZXJMaW5raW5nT3B0aW9uc0gBiAEBEhQKB2RyeV9ydW4YBCABKAhIAogBATqJAbpIhQEaggEKIGNyZWF0ZV91c2VyX3Bhc3N3b3JkX3JlcXVpcmVtZW50EiRwYXNzd29yZCBpcyByZXF1aXJlZCBmb3IgbG9jYWwgdXNlcnMaOHRoaXMudXNlci5zb3VyY2Vfc3lzdGVtICE9IDEgfHwgc2l6ZSh0aGlzLnBhc3N3b3JkKSA+PSA4QgsKCV9wYXNzd29yZEISChBfbGlua2luZ19vcHRpb25zQgoKCF9kcnlfcnVuImUKEkNyZWF0ZVVzZXJSZXNwb25zZRIjCgR1c2VyGAEgASgLMhUuc2NoZW1hLnYxYWxwaGExLlVzZXISEAoId2FybmluZ3MYAiADKAkSGAoQY3JlYXRlZF9hY2NvdW50cxgDIAMoCSKcAQoOR2V0VXNlclJlcXVlc3QSDAoCaWQYASABKAlIABISCgh1c2VybmFtZRgCIAEoCUgAEg8KBWVtYWlsGAMgASgJSAASMwoKZmllbGRfbWFzaxgEIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5GaWVsZE1hc2tIAYgBAUITCgppZGVudGlmaWVyEgW6SAIIAUINCgtfZmllbGRfbWFzayI2Cg9HZXRVc2VyUmVzcG9uc2USIwoEdXNlchgBIAEoCzIVLnNjaGVtYS52MWFscGhhMS5Vc2VyIscBChFVcGRhdGVVc2VyUmVxdWVzdBIrCgR1c2VyGAEgASgLMhUuc2NoZW1hLnYxYWxwaGExLlVzZXJCBrpIA8gBARIuCgpmaWVsZF9tYXNrGAIgASgLMhouZ29vZ2xlLnByb3RvYnVmLkZpZWxkTWFzaxJBCg9saW5raW5nX29wdGlvbnMYAyABKAsyIy5zY2hlbWEudjFhbHBoYTEuVXNlckxpbmtpbmdPcHRpb25zSACIAQFCEgoQX2xpbmtpbmdfb3B0aW9ucyJLChJVcGRhdGVVc2VyUmVzcG9uc2USIwoEdXNlchgBIAEoCzIVLnNjaGVtYS52MWFscGhhMS5Vc2VyEhAKCHdhcm5pbmdzGAIgAygJIoIBChFEZWxldGVVc2VyUmVxdWVzdBITCgJpZBgBIAEoCUIHukgEcgIQARIbCg5jYXNjYWRlX2RlbGV0ZRgCIAEoCEgAiAEBEhgKC2JhY2t1cF9kYXRhGAMgASgISAGIAQFCEQoPX2Nhc2NhZGVfZGVsZXRlQg4KDF9iYWNrdXBfZGF0YSJxChJEZWxldGVVc2VyUmVzcG9uc2USDwoHc3VjY2VzcxgBIAEoCBIYChBkZWxldGVkX2FjY291bnRzGAIgAygJEhwKD2JhY2t1cF9sb2NhdGlvbhgDIAEoCUgAiAEBQhIKEF9iYWNrdXBfbG9jYXRpb24iyAIKEExpc3RVc2Vyc1JlcXVlc3QSOgoGc291cmNlGAEgASgOMhsuc2NoZW1hLnYxYWxwaGExLlVzZXJTb3VyY2VCCLpIBYIBAhABSACIAQESFAoHZW5hYmxlZBgCIAEoCEgBiAEBEhwKD3VzZXJuYW1lX3ByZWZpeBgDIAEoCUgCiAEBEjMKCmZpZWxkX21hc2sYBCABKAsyGi5nb29nbGUucHJvdG9idWYuRmllbGRNYXNrSAOIAQESHwoJcGFnZV9zaXplGAUgASgFQge6SAQaAigBSASIAQESFwoKcGFnZV90b2tlbhgGIAEoCUgFiAEBQgkKB19zb3VyY2VCCgoIX2VuYWJsZWRCEgoQX3VzZXJuYW1lX3ByZWZpeEINCgtfZmllbGRfbWFza0IMCgpfcGFnZV9zaXplQg0KC19wYWdlX3Rva2VuImsKEUxpc3RVc2Vyc1Jlc3BvbnNlEiQKBXVzZXJzGAEgAygLMhUuc2NoZW1hLnYxYWxwaGExLlVzZXISHAoPbmV4dF9wYWdlX3Rva2VuGAIgASgJSACIAQFCEgoQX25leHRfcGFnZV90b2tlbiJxChVDaGFuZ2VQYXNzd29yZFJlcXVlc3QSEwoCaWQYASABKAlCB7pIBHICEAESIQoQY3VycmVudF9wYXNzd29yZBgCIAEoCUIHukgEcgIQARIgCgxuZXdfcGFzc3dvcmQYAyABKAlCCrpIB3IFEAgYgAEiWQoWQ2hhbmdlUGFzc3dvcmRSZXNwb25zZRIPCgdzdWNjZXNzGAEgASgIEhsKDmZhaWx1cmVfcmVhc29uGAIgASgJSACIAQFCEQoPX2ZhaWx1cmVfcmVhc29uIrcBChRSZXNldFBhc3N3b3JkUmVxdWVzdBITCgJpZBgBIAEoCUIHukgEcgIQARIlCgxuZXdfcGFzc3dvcmQYAiABKAlCCrpIB3IFEAgYgAFIAIgBARISCgVmb3JjZRgDIAEoCEgBiAEBEh4KEWdlbmVyYXRlX3Bhc3N3b3JkGAQgASgISAKIAQFCDwoNX25ld19wYXNzd29yZEIICgZfZm9yY2VCFAoSX2dlbmVyYXRlX3Bhc3N3b3JkIm4KFVJlc2V0UGFzc3dvcmRSZXNwb25zZRIUCgxuZXdfcGFzc3dvcmQYASABKAkSDwoHc3VjY2VzcxgCIAEoCBIbCg5mYWlsdXJlX3JlYXNvbhgDIAEoCUgAiAEBQhEKD19mYWlsdXJlX3JlYXNvbiKYAgoXQXV0aGVudGljYXRlVXNlclJlcXVlc3QSGQoIdXNlcm5hbWUYASABKAlCB7pIBHICEAESGQoIcGFzc3dvcmQYAiABKAlCB7pIBHICEAESFgoJc291cmNlX2lwGAMgASgJSACIAQESFwoKdXNlcl9hZ2VudBgEIAEoCUgBiAEBEiMKDXNlY29uZF9mYWN0b3IYBSABKAlCB7pIBHICEAFIAogBARIfChdzZWNvbmRfZmFjdG9yX3N1cHBvcnRlZBgGIAEoCBIhChlwYXNzd29yZF9jaGFuZ2Vfc3VwcG9ydGVkGAcgASgIQgwKCl9zb3VyY2VfaXBCDQoLX3VzZXJfYWdlbnRCEAoOX3NlY29uZF9mYWN0b3IijAMKGEF1dGhlbnRpY2F0ZVVzZXJSZXNwb25zZRIPCgdzdWNjZXNzGAEgASgIEhQKB3VzZXJfaWQYAiABKAlIAIgBARISCgV0b2tlbhgDIAEoCUgBiAEBEhsKDmZhaWx1cmVfcmVhc29uGAQgASgJSAKIAQESOQoQdG9rZW5fZXhwaXJlc19hdBgFIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXBIA4gBARJGCgdmYWlsdXJlGAYgASgOMiYuc2NoZW1hLnYxYWxwaGExLkF1dGhlbnRpY2F0aW9uRmFpbHVyZUIIukgFggECEAFIBIgBARIpCiFzZWNvbmRfZmFjdG9yX2Vucm9sbG1lbnRfcmVxdWlyZWQYByABKAgSIAoYcGFzc3dvcmRfY2hhbmdlX3JlcXVpcmVkGAggASgIQgoKCF91c2VyX2lkQggKBl90b2tlbkIRCg9fZmFpbHVyZV9yZWFzb25CEwoRX3Rva2VuX2V4cGlyZXNfYXRCCgoIX2ZhaWx1cmUiagoRQ2hlY2tMb2dpblJlcXVlc3QSGQoIdXNlcm5hbWUYASABKAlCB7pIBHICEAESFgoJc291cmNlX2lwGAIgASgJSACIAQESFAoMcHJvb2ZfZmFpbGVkGAMgASgIQgwKCl9zb3VyY2VfaXAiqQEKEkNoZWNrTG9naW5SZXNwb25zZRIPCgdhbGxvd2VkGAEgASgIEhsKDmZhaWx1cmVfcmVhc29uGAIgASgJSACIAQESRgoHZmFpbHVyZRgDIAEoDjImLnNjaGVtYS52MWFscGhhMS5BdXRoZW50aWNhdGlvbkZhaWx1cmVCCLpIBYIBAhABSAGIAQFCEQoPX2ZhaWx1cmVfcmVhc29uQgoKCF9mYWlsdXJlIigKEUVucm9sbFRvdHBSZXF1ZXN0EhMKAmlkGAEgASgJQge6SARyAhABIjEKEkVucm9sbFRvdHBSZXNwb25zZRIOCgZzZWNyZXQYASABKAkSCwoDdXJpGAIgASgJIkAKEkNvbmZpcm1Ub3RwUmVxdWVzdBITCgJpZBgBIAEoCUIHukgEcgIQARIVCgRjb2RlGAIgASgJQge6SARyAhABIm4KE0NvbmZpcm1Ub3RwUmVzcG9uc2USDwoHc3VjY2VzcxgBIAEoCBIbCg5mYWlsdXJlX3JlYXNvbhgCIAEoCUgAiAEBEhYKDnJlY292ZXJ5X2NvZGVzGAMgAygJQhEKD19mYWlsdXJlX3JlYXNvbiIpChJEaXNhYmxlVG90cFJlcXVlc3QSEwoCaWQYASABKAlCB7pIBHICEAEiJgoTRGlzYWJsZVRvdHBSZXNwb25zZRIPCgdzdWNjZXNzGAEgASgIIjUKHlJlZ2VuZXJhdGVSZWNvdmVyeUNvZGVzUmVxdWVzdBITCgJpZBgBIAEoCUIHukgEcgIQASI5Ch9SZWdlbmVyYXRlUmVjb3ZlcnlDb2Rlc1Jlc3BvbnNlEhYKDnJlY292ZXJ5X2NvZGVzGAEgAygJKpMCCgpVc2VyU291cmNlEhsKF1VTRVJfU09VUkNFX1VOU1BFQ0lGSUVEEAASFQoRVVNFUl9TT1VSQ0VfTE9DQUwQARIUChBVU0VSX1NPVVJDRV9MREFQEAISEgoOVVNFUl9TT1VSQ0VfQUQQAxIUChBVU0VSX1NPVVJDRV9JUE1JEAQSFwoTVVNFUl9TT1VSQ0VfUkVERklTSBAFEhQKEFVTRVJfU09VUkNFX05BVFMQBhIUChBVU0VSX1NPVVJDRV9VTklYEAcSHAoYVVNFUl9TT1VSQ0VfRVhURVJOQUxfQVBJEAgSFgoSVVNFUl9TT1VSQ0VfUkFESVVTEAkSFgoSVVNFUl9TT1VSQ0VfVEFDQUNTEAoq3gIKFVVzZXJDcmVhdGlvbkludGVyZmFjZRInCiNVU0VSX0NSRUFUSU9OX0lOVEVSRkFDRV9VTlNQRUNJRklFRBAAEiYKIlVTRVJfQ1JFQVRJT05fSU5URVJGQUNFX1NDSEVNQV9BUEkQARIoCiRVU0VSX0NSRUFUSU9OX0lOVEVSRkFDRV9VTklYX1VTRVJBREQQAhImCiJVU0VSX0NSRUFUSU9OX0lOVEVSRkFDRV9MREFQX0FETUlOEAMSJAogVVNFUl9DUkVBVElPTl9JTlRFUkZBQ0VfQURfQURNSU4QBBInCiNVU0VSX0NSRUFUSU9OX0lOVEVSRkFDRV9SRURGSVNIX0FQSRAFEicKI1VTRVJfQ1JFQVRJT05fSU5URVJGQUNFX05BVFNfQ09ORklHEAYSKgomVVNFUl9DUkVBVElPTl9JTlRFUkZBQ0VfSVBNSV9VU0VSX01HTVQQByqEAgoVUGFzc3dvcmRIYXNoQWxnb3JpdGhtEicKI1BBU1NXT1JEX0hBU0hfQUxHT1JJVEhNX1VOU1BFQ0lGSUVEEAASIgoeUEFTU1dPUkRfSEFTSF9BTEdPUklUSE1fQkNSWVBUEAESJAogUEFTU1dPUkRfSEFTSF9BTEdPUklUSE1fQVJHT04ySUQQAhIiCh5QQVNTV09SRF9IQVNIX0FMR09SSVRITV9TQ1JZUFQQAxIpCiVQQVNTV09SRF9IQVNIX0FMR09SSVRITV9QQktERjJfU0hBMjU2EAQSKQolUEFTU1dPUkRfSEFTSF9BTEdPUklUSE1fUEJLREYyX1NIQTUxMhAFKukBCg1Mb2Nrb3V0UmVhc29uEh4KGkxPQ0tPVVRfUkVBU09OX1VOU1BFQ0lGSUVEEAASKAokTE9DS09VVF9SRUFTT05fRkFJTEVEX0xPR0lOX0FUVEVNUFRTEAESIQodTE9DS09VVF9SRUFTT05fQURNSU5JU1RSQVRJVkUQAhIjCh9MT0NLT1VUX1JFQVNPTl9QQVNTV09SRF9FWFBJUkVEEAMSIgoeTE9DS09VVF9SRUFTT05fQUNDT1VOVF9FWFBJUkVEEAQSIgoeTE9DS09VVF9SRUFTT05fU0VDVVJJVFlfUE9MSUNZEAUqugMKFUF1dGhlbnRpY2F0aW9uRmFpbHVyZRImCiJBVVRIRU5USUNBVElPTl9GQUlMVVJFX1VOU1BFQ0lGSUVEEAASLgoqQVVUSEVOVElDQVRJT05fRkFJTFVSRV9JTlZBTElEX0NSRURFTlRJQUxTEAESKQolQVVUSEVOVElDQVRJT05fRkFJTFVSRV9BQ0NPVU5UX0xPQ0tFRBACEisKJ0FVVEhFTlRJQ0FUSU9OX0ZBSUxVUkVfQUNDT1VOVF9ESVNBQkxFRBADEisKJ0FVVEhFTlRJQ0FUSU9OX0ZBSUxVUkVfUEFTU1dPUkRfRVhQSVJFRBAEEioKJkFVVEhFTlRJQ0FUSU9OX0ZBSUxVUkVfQUNDT1VOVF9FWFBJUkVEEAUSMQotQVVUSEVOVElDQVRJT05fRkFJTFVSRV9TRUNPTkRfRkFDVE9SX1JFUVVJUkVEEAYSMAosQVVUSEVOVElDQVRJT05fRkFJTFVSRV9JTlZBTElEX1NFQ09ORF9GQUNUT1IQBxIzCi9BVVRIRU5USUNBVElPTl9GQUlMVVJFX1BBU1NXT1JEX0NIQU5HRV9SRVFVSVJFRBAIKpcBCg5Vc2VyTGlua0FjdGlvbhIgChxVU0VSX0xJTktfQUNUSU9OX1VOU1BFQ0lGSUVEEAASIgoeVVNFUl9MSU5LX0FDVElPTl9MSU5LX0VYSVNUSU5HEAESHwobVVNFUl9MSU5LX0FDVElPTl9DUkVBVEVfTkVXEAISHgoaVVNFUl9MSU5LX0FDVElPTl9OT19BQ1RJT04QA0K8AQoTY29tLnNjaGVtYS52MWFscGhhMUIJVXNlclByb3RvUAFaPWdpdGh1Yi5jb20vdS1ibWMvdS1ibWMvYXBpL2dlbi9zY2hlbWEvdjFhbHBoYTE7c2NoZW1hdjFhbHBoYTGiAgNTWFiqAg9TY2hlbWEuVjFhbHBoYTHKAg9TY2hlbWFcVjFhbHBoYTHiAhtTY2hlbWFcVjFhbHBoYTFcR1BCTWV0YWRhdGHqAhBTY2hlbWE6OlYxYWxwaGExYgZwcm90bzM", [file_buf_validate_validate, file_google_protobuf_timestamp, file_google_protobuf_field_mask]);
 
 /**
  * @generated from message schema.v1alpha1.User
@@ -100,6 +100,11 @@ export type User = Message<"schema.v1alpha1.User"> & {
    * @generated from field: map<string, string> custom_attributes = 16;
    */
   customAttributes: { [key: string]: string };
+
+  /**
+   * @generated from field: optional google.protobuf.Timestamp account_expires_at = 17;
+   */
+  accountExpiresAt?: Timestamp;
 };
 
 /**
@@ -147,6 +152,21 @@ export type AuthenticationData = Message<"schema.v1alpha1.AuthenticationData"> &
    * @generated from field: optional schema.v1alpha1.AccountLockoutInfo lockout_info = 7;
    */
   lockoutInfo?: AccountLockoutInfo;
+
+  /**
+   * @generated from field: repeated schema.v1alpha1.PasswordHistoryEntry password_history = 8;
+   */
+  passwordHistory: PasswordHistoryEntry[];
+
+  /**
+   * @generated from field: optional schema.v1alpha1.TotpData totp = 9;
+   */
+  totp?: TotpData;
+
+  /**
+   * @generated from field: optional bool totp_required = 10;
+   */
+  totpRequired?: boolean;
 };
 
 /**
@@ -156,6 +176,80 @@ export type AuthenticationData = Message<"schema.v1alpha1.AuthenticationData"> &
 export const AuthenticationDataSchema: GenMessage<AuthenticationData> = /*@__PURE__*/
   messageDesc(file_schema_v1alpha1_user, 1);
 
+/**
+ * @generated from message schema.v1alpha1.PasswordHistoryEntry
+ */
+export type PasswordHistoryEntry = Message<"schema.v1alpha1.PasswordHistoryEntry"> & {
+  /**
+   * @generated from field: string password_hash = 1;
+   */
+  passwordHash: string;
+
+  /**
+   * @generated from field: optional string password_salt = 2;
+   */
+  passwordSalt?: string;
+
+  /**
+   * @generated from field: schema.v1alpha1.PasswordHashAlgorithm hash_algorithm = 3;
+   */
+  hashAlgorithm: PasswordHashAlgorithm;
+
+  /**
+   * @generated from field: int32 iterations = 4;
+   */
+  iterations: number;
+
+  /**
+   * @generated from field: optional google.protobuf.Timestamp changed_at = 5;
+   */
+  changedAt?: Timestamp;
+};
+
+/**
+ * Describes the message schema.v1alpha1.PasswordHistoryEntry.
+ * Use `create(PasswordHistoryEntrySchema)` to create a new message.
+ */
+export const PasswordHistoryEntrySchema: GenMessage<PasswordHistoryEntry> = /*@__PURE__*/
+  messageDesc(file_schema_v1alpha1_user, 2);
+
+/**
+ * @generated from message schema.v1alpha1.TotpData
+ */
+export type TotpData = Message<"schema.v1alpha1.TotpData"> & {
+  /**
+   * @generated from field: bool enabled = 1;
+   */
+  enabled: boolean;
+
+  /**
+   * @generated from field: bytes encrypted_secret = 2;
+   */
+  encryptedSecret: Uint8Array;
+
+  /**
+   * @generated from field: optional google.protobuf.Timestamp enrolled_at = 3;
+   */
+  enrolledAt?: Timestamp;
+
+  /**
+   * @generated from field: repeated string recovery_codes = 4;
+   */
+  recoveryCodes: string[];
+
+  /**
+   * @generated from field: int64 last_used_step = 5;
+   */
+  lastUsedStep: bigint;
+};
+
+/**
+ * Describes the message schema.v1alpha1.TotpData.
+ * Use `create(TotpDataSchema)` to create a new message.
+ */
+export const TotpDataSchema: GenMessage<TotpData> = /*@__PURE__*/
+  messageDesc(file_schema_v1alpha1_user, 3);
+
 /**
  * @generated from message schema.v1alpha1.AccountLockoutInfo
  */
@@ -196,7 +290,7 @@ export type AccountLockoutInfo = Message<"schema.v1alpha1.AccountLockoutInfo"> &
  * Use `create(AccountLockoutInfoSchema)` to create a new message.
  */
 export const AccountLockoutInfoSchema: GenMessage<AccountLockoutInfo> = /*@__PURE__*/
-  messageDesc(file_schema_v1alpha1_user, 2);
+  messageDesc(file_schema_v1alpha1_user, 4);
 
 /**
  * @generated from message schema.v1alpha1.UnixUserInfo
@@ -238,7 +332,7 @@ export type UnixUserInfo = Message<"schema.v1alpha1.UnixUserInfo"> & {
  * Use `create(UnixUserInfoSchema)` to create a new message.
  */
 export const UnixUserInfoSchema: GenMessage<UnixUserInfo> = /*@__PURE__*/
-  messageDesc(file_schema_v1alpha1_user, 3);
+  messageDesc(file_schema_v1alpha1_user, 5);
 
 /**
  * @generated from message schema.v1alpha1.LdapUserInfo
@@ -295,7 +389,7 @@ export type LdapUserInfo = Message<"schema.v1alpha1.LdapUserInfo"> & {
  * Use `create(LdapUserInfoSchema)` to create a new message.
  */
 export const LdapUserInfoSchema: GenMessage<LdapUserInfo> = /*@__PURE__*/
-  messageDesc(file_schema_v1alpha1_user, 4);
+  messageDesc(file_schema_v1alpha1_user, 6);
 
 /**
  * @generated from message schema.v1alpha1.RedfishAccountInfo
@@ -332,7 +426,7 @@ export type RedfishAccountInfo = Message<"schema.v1alpha1.RedfishAccountInfo"> &
  * Use `create(RedfishAccountInfoSchema)` to create a new message.
  */
 export const RedfishAccountInfoSchema: GenMessage<RedfishAccountInfo> = /*@__PURE__*/
-  messageDesc(file_schema_v1alpha1_user, 5);
+  messageDesc(file_schema_v1alpha1_user, 7);
 
 /**
  * @generated from message schema.v1alpha1.RedfishLockoutPolicy
@@ -359,7 +453,7 @@ export type RedfishLockoutPolicy = Message<"schema.v1alpha1.RedfishLockoutPolicy
  * Use `create(RedfishLockoutPolicySchema)` to create a new message.
  */
 export const RedfishLockoutPolicySchema: GenMessage<RedfishLockoutPolicy> = /*@__PURE__*/
-  messageDesc(file_schema_v1alpha1_user, 6);
+  messageDesc(file_schema_v1alpha1_user, 8);
 
 /**
  * @generated from message schema.v1alpha1.NatsAccountInfo
@@ -406,7 +500,7 @@ export type NatsAccountInfo = Message<"schema.v1alpha1.NatsAccountInfo"> & {
  * Use `create(NatsAccountInfoSchema)` to create a new message.
  */
 export const NatsAccountInfoSchema: GenMessage<NatsAccountInfo> = /*@__PURE__*/
-  messageDesc(file_schema_v1alpha1_user, 7);
+  messageDesc(file_schema_v1alpha1_user, 9);
 
 /**
  * @generated from message schema.v1alpha1.NatsPermissions
@@ -438,7 +532,7 @@ export type NatsPermissions = Message<"schema.v1alpha1.NatsPermissions"> & {
  * Use `create(NatsPermissionsSchema)` to create a new message.
  */
 export const NatsPermissionsSchema: GenMessage<NatsPermissions> = /*@__PURE__*/
-  messageDesc(file_schema_v1alpha1_user, 8);
+  messageDesc(file_schema_v1alpha1_user, 10);
 
 /**
  * @generated from message schema.v1alpha1.NatsLimits
@@ -475,7 +569,7 @@ export type NatsLimits = Message<"schema.v1alpha1.NatsLimits"> & {
  * Use `create(NatsLimitsSchema)` to create a new message.
  */
 export const NatsLimitsSchema: GenMessage<NatsLimits> = /*@__PURE__*/
-  messageDesc(file_schema_v1alpha1_user, 9);
+  messageDesc(file_schema_v1alpha1_user, 11);
 
 /**
  * @generated from message schema.v1alpha1.UserLinkingOptions
@@ -527,7 +621,7 @@ export type UserLinkingOptions = Message<"schema.v1alpha1.UserLinkingOptions"> &
  * Use `create(UserLinkingOptionsSchema)` to create a new message.
  */
 export const UserLinkingOptionsSchema: GenMessage<UserLinkingOptions> = /*@__PURE__*/
-  messageDesc(file_schema_v1alpha1_user, 10);
+  messageDesc(file_schema_v1alpha1_user, 12);
 
 /**
  * @generated from message schema.v1alpha1.CreateUserRequest
@@ -559,7 +653,7 @@ export type CreateUserRequest = Message<"schema.v1alpha1.CreateUserRequest"> & {
  * Use `create(CreateUserRequestSchema)` to create a new message.
  */
 export const CreateUserRequestSchema: GenMessage<CreateUserRequest> = /*@__PURE__*/
-  messageDesc(file_schema_v1alpha1_user, 11);
+  messageDesc(file_schema_v1alpha1_user, 13);
 
 /**
  * @generated from message schema.v1alpha1.CreateUserResponse
@@ -586,7 +680,7 @@ export type CreateUserResponse = Message<"schema.v1alpha1.CreateUserResponse"> &
  * Use `create(CreateUserResponseSchema)` to create a new message.
  */
 export const CreateUserResponseSchema: GenMessage<CreateUserResponse> = /*@__PURE__*/
-  messageDesc(file_schema_v1alpha1_user, 12);
+  messageDesc(file_schema_v1alpha1_user, 14);
 
 /**
  * @generated from message schema.v1alpha1.GetUserRequest
@@ -626,7 +720,7 @@ export type GetUserRequest = Message<"schema.v1alpha1.GetUserRequest"> & {
  * Use `create(GetUserRequestSchema)` to create a new message.
  */
 export const GetUserRequestSchema: GenMessage<GetUserRequest> = /*@__PURE__*/
-  messageDesc(file_schema_v1alpha1_user, 13);
+  messageDesc(file_schema_v1alpha1_user, 15);
 
 /**
  * @generated from message schema.v1alpha1.GetUserResponse
@@ -643,7 +737,7 @@ export type GetUserResponse = Message<"schema.v1alpha1.GetUserResponse"> & {
  * Use `create(GetUserResponseSchema)` to create a new message.
  */
 export const GetUserResponseSchema: GenMessage<GetUserResponse> = /*@__PURE__*/
-  messageDesc(file_schema_v1alpha1_user, 14);
+  messageDesc(file_schema_v1alpha1_user, 16);
 
 /**
  * @generated from message schema.v1alpha1.UpdateUserRequest
@@ -670,7 +764,7 @@ export type UpdateUserRequest = Message<"schema.v1alpha1.UpdateUserRequest"> & {
  * Use `create(UpdateUserRequestSchema)` to create a new message.
  */
 export const UpdateUserRequestSchema: GenMessage<UpdateUserRequest> = /*@__PURE__*/
-  messageDesc(file_schema_v1alpha1_user, 15);
+  messageDesc(file_schema_v1alpha1_user, 17);
 
 /**
  * @generated from message schema.v1alpha1.UpdateUserResponse
@@ -692,7 +786,7 @@ export type UpdateUserResponse = Message<"schema.v1alpha1.UpdateUserResponse"> &
  * Use `create(UpdateUserResponseSchema)` to create a new message.
  */
 export const UpdateUserResponseSchema: GenMessage<UpdateUserResponse> = /*@__PURE__*/
-  messageDesc(file_schema_v1alpha1_user, 16);
+  messageDesc(file_schema_v1alpha1_user, 18);
 
 /**
  * @generated from message schema.v1alpha1.DeleteUserRequest
@@ -719,7 +813,7 @@ export type DeleteUserRequest = Message<"schema.v1alpha1.DeleteUserRequest"> & {
  * Use `create(DeleteUserRequestSchema)` to create a new message.
  */
 export const DeleteUserRequestSchema: GenMessage<DeleteUserRequest> = /*@__PURE__*/
-  messageDesc(file_schema_v1alpha1_user, 17);
+  messageDesc(file_schema_v1alpha1_user, 19);
 
 /**
  * @generated from message schema.v1alpha1.DeleteUserResponse
@@ -746,7 +840,7 @@ export type DeleteUserResponse = Message<"schema.v1alpha1.DeleteUserResponse"> &
  * Use `create(DeleteUserResponseSchema)` to create a new message.
  */
 export const DeleteUserResponseSchema: GenMessage<DeleteUserResponse> = /*@__PURE__*/
-  messageDesc(file_schema_v1alpha1_user, 18);
+  messageDesc(file_schema_v1alpha1_user, 20);
 
 /**
  * @generated from message schema.v1alpha1.ListUsersRequest
@@ -788,7 +882,7 @@ export type ListUsersRequest = Message<"schema.v1alpha1.ListUsersRequest"> & {
  * Use `create(ListUsersRequestSchema)` to create a new message.
  */
 export const ListUsersRequestSchema: GenMessage<ListUsersRequest> = /*@__PURE__*/
-  messageDesc(file_schema_v1alpha1_user, 19);
+  messageDesc(file_schema_v1alpha1_user, 21);
 
 /**
  * @generated from message schema.v1alpha1.ListUsersResponse
@@ -810,7 +904,7 @@ export type ListUsersResponse = Message<"schema.v1alpha1.ListUsersResponse"> & {
  * Use `create(ListUsersResponseSchema)` to create a new message.
  */
 export const ListUsersResponseSchema: GenMessage<ListUsersResponse> = /*@__PURE__*/
-  messageDesc(file_schema_v1alpha1_user, 20);
+  messageDesc(file_schema_v1alpha1_user, 22);
 
 /**
  * @generated from message schema.v1alpha1.ChangePasswordRequest
@@ -837,7 +931,7 @@ export type ChangePasswordRequest = Message<"schema.v1alpha1.ChangePasswordReque
  * Use `create(ChangePasswordRequestSchema)` to create a new message.
  */
 export const ChangePasswordRequestSchema: GenMessage<ChangePasswordRequest> = /*@__PURE__*/
-  messageDesc(file_schema_v1alpha1_user, 21);
+  messageDesc(file_schema_v1alpha1_user, 23);
 
 /**
  * @generated from message schema.v1alpha1.ChangePasswordResponse
@@ -859,7 +953,7 @@ export type ChangePasswordResponse = Message<"schema.v1alpha1.ChangePasswordResp
  * Use `create(ChangePasswordResponseSchema)` to create a new message.
  */
 export const ChangePasswordResponseSchema: GenMessage<ChangePasswordResponse> = /*@__PURE__*/
-  messageDesc(file_schema_v1alpha1_user, 22);
+  messageDesc(file_schema_v1alpha1_user, 24);
 
 /**
  * @generated from message schema.v1alpha1.ResetPasswordRequest
@@ -891,7 +985,7 @@ export type ResetPasswordRequest = Message<"schema.v1alpha1.ResetPasswordRequest
  * Use `create(ResetPasswordRequestSchema)` to create a new message.
  */
 export const ResetPasswordRequestSchema: GenMessage<ResetPasswordRequest> = /*@__PURE__*/
-  messageDesc(file_schema_v1alpha1_user, 23);
+  messageDesc(file_schema_v1alpha1_user, 25);
 
 /**
  * @generated from message schema.v1alpha1.ResetPasswordResponse
@@ -918,7 +1012,7 @@ export type ResetPasswordResponse = Message<"schema.v1alpha1.ResetPasswordRespon
  * Use `create(ResetPasswordResponseSchema)` to create a new message.
  */
 export const ResetPasswordResponseSchema: GenMessage<ResetPasswordResponse> = /*@__PURE__*/
-  messageDesc(file_schema_v1alpha1_user, 24);
+  messageDesc(file_schema_v1alpha1_user, 26);
 
 /**
  * @generated from message schema.v1alpha1.AuthenticateUserRequest
@@ -943,6 +1037,21 @@ export type AuthenticateUserRequest = Message<"schema.v1alpha1.AuthenticateUserR
    * @generated from field: optional string user_agent = 4;
    */
   userAgent?: string;
+
+  /**
+   * @generated from field: optional string second_factor = 5;
+   */
+  secondFactor?: string;
+
+  /**
+   * @generated from field: bool second_factor_supported = 6;
+   */
+  secondFactorSupported: boolean;
+
+  /**
+   * @generated from field: bool password_change_supported = 7;
+   */
+  passwordChangeSupported: boolean;
 };
 
 /**
@@ -950,7 +1059,7 @@ export type AuthenticateUserRequest = Message<"schema.v1alpha1.AuthenticateUserR
  * Use `create(AuthenticateUserRequestSchema)` to create a new message.
  */
 export const AuthenticateUserRequestSchema: GenMessage<AuthenticateUserRequest> = /*@__PURE__*/
-  messageDesc(file_schema_v1alpha1_user, 25);
+  messageDesc(file_schema_v1alpha1_user, 27);
 
 /**
  * @generated from message schema.v1alpha1.AuthenticateUserResponse
@@ -980,6 +1089,21 @@ export type AuthenticateUserResponse = Message<"schema.v1alpha1.AuthenticateUser
    * @generated from field: optional google.protobuf.Timestamp token_expires_at = 5;
    */
   tokenExpiresAt?: Timestamp;
+
+  /**
+   * @generated from field: optional schema.v1alpha1.AuthenticationFailure failure = 6;
+   */
+  failure?: AuthenticationFailure;
+
+  /**
+   * @generated from field: bool second_factor_enrollment_required = 7;
+   */
+  secondFactorEnrollmentRequired: boolean;
+
+  /**
+   * @generated from field: bool password_change_required = 8;
+   */
+  passwordChangeRequired: boolean;
 };
 
 /**
@@ -987,7 +1111,217 @@ export type AuthenticateUserResponse = Message<"schema.v1alpha1.AuthenticateUser
  * Use `create(AuthenticateUserResponseSchema)` to create a new message.
  */
 export const AuthenticateUserResponseSchema: GenMessage<AuthenticateUserResponse> = /*@__PURE__*/
-  messageDesc(file_schema_v1alpha1_user, 26);
+  messageDesc(file_schema_v1alpha1_user, 28);
+
+/**
+ * @generated from message schema.v1alpha1.CheckLoginRequest
+ */
+export type CheckLoginRequest = Message<"schema.v1alpha1.CheckLoginRequest"> & {
+  /**
+   * @generated from field: string username = 1;
+   */
+  username: string;
+
+  /**
+   * @generated from field: optional string source_ip = 2;
+   */
+  sourceIp?: string;
+
+  /**
+   * @generated from field: bool proof_failed = 3;
+   */
+  proofFailed: boolean;
+};
+
+/**
+ * Describes the message schema.v1alpha1.CheckLoginRequest.
+ * Use `create(CheckLoginRequestSchema)` to create a new message.
+ */
+export const CheckLoginRequestSchema: GenMessage<CheckLoginRequest> = /*@__PURE__*/
+  messageDesc(file_schema_v1alpha1_user, 29);
+
+/**
+ * @generated from message schema.v1alpha1.CheckLoginResponse
+ */
+export type CheckLoginResponse = Message<"schema.v1alpha1.CheckLoginResponse"> & {
+  /**
+   * @generated from field: bool allowed = 1;
+   */
+  allowed: boolean;
+
+  /**
+   * @generated from field: optional string failure_reason = 2;
+   */
+  failureReason?: string;
+
+  /**
+   * @generated from field: optional schema.v1alpha1.AuthenticationFailure failure = 3;
+   */
+  failure?: AuthenticationFailure;
+};
+
+/**
+ * Describes the message schema.v1alpha1.CheckLoginResponse.
+ * Use `create(CheckLoginResponseSchema)` to create a new message.
+ */
+export const CheckLoginResponseSchema: GenMessage<CheckLoginResponse> = /*@__PURE__*/
+  messageDesc(file_schema_v1alpha1_user, 30);
+
+/**
+ * @generated from message schema.v1alpha1.EnrollTotpRequest
+ */
+export type EnrollTotpRequest = Message<"schema.v1alpha1.EnrollTotpRequest"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+};
+
+/**
+ * Describes the message schema.v1alpha1.EnrollTotpRequest.
+ * Use `create(EnrollTotpRequestSchema)` to create a new message.
+ */
+export const EnrollTotpRequestSchema: GenMessage<EnrollTotpRequest> = /*@__PURE__*/
+  messageDesc(file_schema_v1alpha1_user, 31);
+
+/**
+ * @generated from message schema.v1alpha1.EnrollTotpResponse
+ */
+export type EnrollTotpResponse = Message<"schema.v1alpha1.EnrollTotpResponse"> & {
+  /**
+   * @generated from field: string secret = 1;
+   */
+  secret: string;
+
+  /**
+   * @generated from field: string uri = 2;
+   */
+  uri: string;
+};
+
+/**
+ * Describes the message schema.v1alpha1.EnrollTotpResponse.
+ * Use `create(EnrollTotpResponseSchema)` to create a new message.
+ */
+export const EnrollTotpResponseSchema: GenMessage<EnrollTotpResponse> = /*@__PURE__*/
+  messageDesc(file_schema_v1alpha1_user, 32);
+
+/**
+ * @generated from message schema.v1alpha1.ConfirmTotpRequest
+ */
+export type ConfirmTotpRequest = Message<"schema.v1alpha1.ConfirmTotpRequest"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: string code = 2;
+   */
+  code: string;
+};
+
+/**
+ * Describes the message schema.v1alpha1.ConfirmTotpRequest.
+ * Use `create(ConfirmTotpRequestSchema)` to create a new message.
+ */
+export const ConfirmTotpRequestSchema: GenMessage<ConfirmTotpRequest> = /*@__PURE__*/
+  messageDesc(file_schema_v1alpha1_user, 33);
+
+/**
+ * @generated from message schema.v1alpha1.ConfirmTotpResponse
+ */
+export type ConfirmTotpResponse = Message<"schema.v1alpha1.ConfirmTotpResponse"> & {
+  /**
+   * @generated from field: bool success = 1;
+   */
+  success: boolean;
+
+  /**
+   * @generated from field: optional string failure_reason = 2;
+   */
+  failureReason?: string;
+
+  /**
+   * @generated from field: repeated string recovery_codes = 3;
+   */
+  recoveryCodes: string[];
+};
+
+/**
+ * Describes the message schema.v1alpha1.ConfirmTotpResponse.
+ * Use `create(ConfirmTotpResponseSchema)` to create a new message.
+ */
+export const ConfirmTotpResponseSchema: GenMessage<ConfirmTotpResponse> = /*@__PURE__*/
+  messageDesc(file_schema_v1alpha1_user, 34);
+
+/**
+ * @generated from message schema.v1alpha1.DisableTotpRequest
+ */
+export type DisableTotpRequest = Message<"schema.v1alpha1.DisableTotpRequest"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+};
+
+/**
+ * Describes the message schema.v1alpha1.DisableTotpRequest.
+ * Use `create(DisableTotpRequestSchema)` to create a new message.
+ */
+export const DisableTotpRequestSchema: GenMessage<DisableTotpRequest> = /*@__PURE__*/
+  messageDesc(file_schema_v1alpha1_user, 35);
+
+/**
+ * @generated from message schema.v1alpha1.DisableTotpResponse
+ */
+export type DisableTotpResponse = Message<"schema.v1alpha1.DisableTotpResponse"> & {
+  /**
+   * @generated from field: bool success = 1;
+   */
+  success: boolean;
+};
+
+/**
+ * Describes the message schema.v1alpha1.DisableTotpResponse.
+ * Use `create(DisableTotpResponseSchema)` to create a new message.
+ */
+export const DisableTotpResponseSchema: GenMessage<DisableTotpResponse> = /*@__PURE__*/
+  messageDesc(file_schema_v1alpha1_user, 36);
+
+/**
+ * @generated from message schema.v1alpha1.RegenerateRecoveryCodesRequest
+ */
+export type RegenerateRecoveryCodesRequest = Message<"schema.v1alpha1.RegenerateRecoveryCodesRequest"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+};
+
+/**
+ * Describes the message schema.v1alpha1.RegenerateRecoveryCodesRequest.
+ * Use `create(RegenerateRecoveryCodesRequestSchema)` to create a new message.
+ */
+export const RegenerateRecoveryCodesRequestSchema: GenMessage<RegenerateRecoveryCodesRequest> = /*@__PURE__*/
+  messageDesc(file_schema_v1alpha1_user, 37);
+
+/**
+ * @generated from message schema.v1alpha1.RegenerateRecoveryCodesResponse
+ */
+export type RegenerateRecoveryCodesResponse = Message<"schema.v1alpha1.RegenerateRecoveryCodesResponse"> & {
+  /**
+   * @generated from field: repeated string recovery_codes = 1;
+   */
+  recoveryCodes: string[];
+};
+
+/**
+ * Describes the message schema.v1alpha1.RegenerateRecoveryCodesResponse.
+ * Use `create(RegenerateRecoveryCodesResponseSchema)` to create a new message.
+ */
+export const RegenerateRecoveryCodesResponseSchema: GenMessage<RegenerateRecoveryCodesResponse> = /*@__PURE__*/
+  messageDesc(file_schema_v1alpha1_user, 38);
 
 /**
  * @generated from enum schema.v1alpha1.UserSource
@@ -1037,6 +1371,16 @@ export enum UserSource {
    * @generated from enum value: USER_SOURCE_EXTERNAL_API = 8;
    */
   EXTERNAL_API = 8,
+
+  /**
+   * @generated from enum value: USER_SOURCE_RADIUS = 9;
+   */
+  RADIUS = 9,
+
+  /**
+   * @generated from enum value: USER_SOURCE_TACACS = 10;
+   */
+  TACACS = 10,
 }
 
 /**
@@ -1178,6 +1522,62 @@ export enum LockoutReason {
 export const LockoutReasonSchema: GenEnum<LockoutReason> = /*@__PURE__*/
   enumDesc(file_schema_v1alpha1_user, 3);
 
+/**
+ * @generated from enum schema.v1alpha1.AuthenticationFailure
+ */
+export enum AuthenticationFailure {
+  /**
+   * @generated from enum value: AUTHENTICATION_FAILURE_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: AUTHENTICATION_FAILURE_INVALID_CREDENTIALS = 1;
+   */
+  INVALID_CREDENTIALS = 1,
+
+  /**
+   * @generated from enum value: AUTHENTICATION_FAILURE_ACCOUNT_LOCKED = 2;
+   */
+  ACCOUNT_LOCKED = 2,
+
+  /**
+   * @generated from enum value: AUTHENTICATION_FAILURE_ACCOUNT_DISABLED = 3;
+   */
+  ACCOUNT_DISABLED = 3,
+
+  /**
+   * @generated from enum value: AUTHENTICATION_FAILURE_PASSWORD_EXPIRED = 4;
+   */
+  PASSWORD_EXPIRED = 4,
+
+  /**
+   * @generated from enum value: AUTHENTICATION_FAILURE_ACCOUNT_EXPIRED = 5;
+   */
+  ACCOUNT_EXPIRED = 5,
+
+  /**
+   * @generated from enum value: AUTHENTICATION_FAILURE_SECOND_FACTOR_REQUIRED = 6;
+   */
+  SECOND_FACTOR_REQUIRED = 6,
+
+  /**
+   * @generated from enum value: AUTHENTICATION_FAILURE_INVALID_SECOND_FACTOR = 7;
+   */
+  INVALID_SECOND_FACTOR = 7,
+
+  /**
+   * @generated from enum value: AUTHENTICATION_FAILURE_PASSWORD_CHANGE_REQUIRED = 8;
+   */
+  PASSWORD_CHANGE_REQUIRED = 8,
+}
+
+/**
+ * Describes the enum schema.v1alpha1.AuthenticationFailure.
+ */
+export const AuthenticationFailureSchema: GenEnum<AuthenticationFailure> = /*@__PURE__*/
+  enumDesc(file_schema_v1alpha1_user, 4);
+
 /**
  * @generated from enum schema.v1alpha1.UserLinkAction
  */
@@ -1207,5 +1607,5 @@ export enum UserLinkAction {
  * Describes the enum schema.v1alpha1.UserLinkAction.
  */
 export const UserLinkActionSchema: GenEnum<UserLinkAction> = /*@__PURE__*/
-  enumDesc(file_schema_v1alpha1_user, 4);
+  enumDesc(file_schema_v1alpha1_user, 5);
 
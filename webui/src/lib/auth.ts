@@ -25,3 +25,25 @@ export async function validateSession(): Promise<boolean> {
 		return false;
 	}
 }
+
+/**
+ * Start a single sign-on login with the OpenID provider of the backend.
+ * The browser returns to the given page once the login is complete.
+ */
+export function loginWithSSO(redirect: string = '/'): void {
+	window.location.assign('/api/auth/oidc/login?redirect=' + encodeURIComponent(redirect));
+}
+
+/**
+ * Close the current session and drop its HttpOnly cookie.
+ */
+export async function logout(): Promise<void> {
+	try {
+		await fetch('/api/auth/logout', {
+			method: 'POST',
+			credentials: 'include'
+		});
+	} catch (error) {
+		// The session expires on its own if the backend is unreachable
+	}
+}